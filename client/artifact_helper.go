@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -137,6 +139,7 @@ func (ah *ArtifactHelper) ExtractFileDataFromArtifact(artifact *types.Artifact)
 			if err != nil {
 				return nil, fmt.Errorf("failed to extract file from part: %w", err)
 			}
+			fileData.Checksum = partChecksum(part)
 			files = append(files, fileData)
 		}
 	}
@@ -167,6 +170,8 @@ type FileData struct {
 	MIMEType *string
 	Data     []byte
 	URI      *string
+	// Checksum is the hex-encoded SHA-256 digest recorded by the server, if any
+	Checksum *string
 }
 
 // IsDataFile returns true if this file contains data (bytes), false if it's URI-based
@@ -232,27 +237,70 @@ func (ah *ArtifactHelper) extractFileFromPart(filePart types.FilePart) (FileData
 	return FileData{}, fmt.Errorf("file part contains neither bytes nor URI")
 }
 
-// ExtractArtifactUpdateFromStreamEvent extracts an artifact update event from a streaming event
+// ExtractArtifactUpdateFromStreamEvent extracts an artifact update event from a streaming event.
+// It accepts typed events, legacy maps tagged with kind "artifact-update", maps carrying an
+// artifact and taskId, and StreamResponse-style maps wrapping the event under artifactUpdate.
 func (ah *ArtifactHelper) ExtractArtifactUpdateFromStreamEvent(eventData any) (*types.TaskArtifactUpdateEvent, bool) {
 	switch event := eventData.(type) {
 	case types.TaskArtifactUpdateEvent:
 		return &event, true
+	case *types.TaskArtifactUpdateEvent:
+		return event, event != nil
 	case map[string]any:
-		if kind, exists := event["kind"].(string); exists && kind == "artifact-update" {
-			eventBytes, err := json.Marshal(event)
-			if err != nil {
+		if wrapped, exists := event["artifactUpdate"].(map[string]any); exists {
+			return decodeArtifactUpdate(wrapped)
+		}
+		if kind, exists := event["kind"].(string); exists {
+			if kind != "artifact-update" {
 				return nil, false
 			}
+			return decodeArtifactUpdate(event)
+		}
+		_, hasArtifact := event["artifact"].(map[string]any)
+		_, hasTaskID := event["taskId"].(string)
+		if hasArtifact && hasTaskID {
+			return decodeArtifactUpdate(event)
+		}
+	}
+	return nil, false
+}
 
-			var artifactEvent types.TaskArtifactUpdateEvent
-			if err := json.Unmarshal(eventBytes, &artifactEvent); err != nil {
-				return nil, false
-			}
+// decodeArtifactUpdate converts a generic map into a TaskArtifactUpdateEvent
+func decodeArtifactUpdate(event map[string]any) (*types.TaskArtifactUpdateEvent, bool) {
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		return nil, false
+	}
+
+	var artifactEvent types.TaskArtifactUpdateEvent
+	if err := json.Unmarshal(eventBytes, &artifactEvent); err != nil {
+		return nil, false
+	}
+
+	return &artifactEvent, true
+}
+
+// ApplyArtifactUpdate merges a streamed artifact update into the task's artifacts.
+// Updates with append set extend the parts of the artifact with the same ID,
+// all others replace it or add it as a new artifact.
+func (ah *ArtifactHelper) ApplyArtifactUpdate(task *types.Task, update *types.TaskArtifactUpdateEvent) {
+	if task == nil || update == nil {
+		return
+	}
 
-			return &artifactEvent, true
+	for i := range task.Artifacts {
+		if task.Artifacts[i].ArtifactID != update.Artifact.ArtifactID {
+			continue
+		}
+		if update.Append != nil && *update.Append {
+			task.Artifacts[i].Parts = append(task.Artifacts[i].Parts, update.Artifact.Parts...)
+			return
 		}
+		task.Artifacts[i] = update.Artifact
+		return
 	}
-	return nil, false
+
+	task.Artifacts = append(task.Artifacts, update.Artifact)
 }
 
 // HasArtifacts returns true if the task contains any artifacts
@@ -322,6 +370,8 @@ type DownloadConfig struct {
 	OverwriteExisting bool
 	// OrganizeByArtifactID creates subdirectories by artifact ID to prevent collisions (default: true)
 	OrganizeByArtifactID bool
+	// SkipChecksumVerification disables SHA-256 verification of downloaded content (default: false)
+	SkipChecksumVerification bool
 }
 
 // DownloadResult represents the result of a file download
@@ -368,11 +418,16 @@ func (ah *ArtifactHelper) DownloadFileData(ctx context.Context, fileData FileDat
 
 	var data []byte
 	var err error
+	expectedChecksum := ""
+	if fileData.Checksum != nil {
+		expectedChecksum = *fileData.Checksum
+	}
 
 	if fileData.IsDataFile() {
 		data = fileData.Data
 	} else if fileData.IsURIFile() {
-		data, err = ah.downloadFromURI(ctx, *fileData.URI, config.HTTPClient)
+		var serverChecksum string
+		data, serverChecksum, err = ah.downloadFromURI(ctx, *fileData.URI, config.HTTPClient)
 		if err != nil {
 			return &DownloadResult{
 				FileName: fileName,
@@ -380,10 +435,24 @@ func (ah *ArtifactHelper) DownloadFileData(ctx context.Context, fileData FileDat
 				Error:    err,
 			}, err
 		}
+		if expectedChecksum == "" {
+			expectedChecksum = serverChecksum
+		}
 	} else {
 		return nil, fmt.Errorf("file data contains neither bytes nor URI")
 	}
 
+	if !config.SkipChecksumVerification {
+		if err := VerifyChecksum(data, expectedChecksum); err != nil {
+			err = fmt.Errorf("failed to verify %s: %w", fileName, err)
+			return &DownloadResult{
+				FileName: fileName,
+				FilePath: filePath,
+				Error:    err,
+			}, err
+		}
+	}
+
 	bytesWritten, err := ah.writeFile(filePath, data)
 	if err != nil {
 		return &DownloadResult{
@@ -410,9 +479,10 @@ func (ah *ArtifactHelper) DownloadArtifact(ctx context.Context, artifact *types.
 	artifactConfig := config
 	if config != nil && config.OrganizeByArtifactID && artifact != nil {
 		artifactConfig = &DownloadConfig{
-			OutputDir:         filepath.Join(config.OutputDir, artifact.ArtifactID),
-			HTTPClient:        config.HTTPClient,
-			OverwriteExisting: config.OverwriteExisting,
+			OutputDir:                filepath.Join(config.OutputDir, artifact.ArtifactID),
+			HTTPClient:               config.HTTPClient,
+			OverwriteExisting:        config.OverwriteExisting,
+			SkipChecksumVerification: config.SkipChecksumVerification,
 		}
 	}
 
@@ -457,16 +527,44 @@ func (ah *ArtifactHelper) DownloadAllArtifacts(ctx context.Context, task *types.
 	return results, nil
 }
 
-// downloadFromURI downloads content from a URI
-func (ah *ArtifactHelper) downloadFromURI(ctx context.Context, uri string, client *http.Client) (data []byte, err error) {
+// VerifyChecksum checks data against a hex-encoded SHA-256 digest. An empty expected
+// checksum means no digest was recorded and always passes.
+func VerifyChecksum(data []byte, expected string) error {
+	if expected == "" {
+		return nil
+	}
+
+	digest := sha256.Sum256(data)
+	actual := hex.EncodeToString(digest[:])
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// partChecksum returns the SHA-256 digest recorded in a part's metadata, if any
+func partChecksum(part types.Part) *string {
+	if part.Metadata == nil {
+		return nil
+	}
+	checksum, ok := (*part.Metadata)[types.ArtifactChecksumMetadataKey].(string)
+	if !ok || checksum == "" {
+		return nil
+	}
+	return &checksum
+}
+
+// downloadFromURI downloads content from a URI and returns it along with the
+// checksum advertised by the server, if any
+func (ah *ArtifactHelper) downloadFromURI(ctx context.Context, uri string, client *http.Client) (data []byte, checksum string, err error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to download from %s: %w", uri, err)
+		return nil, "", fmt.Errorf("failed to download from %s: %w", uri, err)
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil && err == nil {
@@ -475,15 +573,15 @@ func (ah *ArtifactHelper) downloadFromURI(ctx context.Context, uri string, clien
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("download failed with status %d", resp.StatusCode)
+		return nil, "", fmt.Errorf("download failed with status %d", resp.StatusCode)
 	}
 
 	data, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return data, nil
+	return data, resp.Header.Get(types.ArtifactChecksumHeader), nil
 }
 
 // writeFile writes data to a file and returns the number of bytes written
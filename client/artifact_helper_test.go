@@ -561,6 +561,53 @@ func TestArtifactHelper_ExtractArtifactUpdateFromStreamEvent(t *testing.T) {
 				assert.Equal(t, "stream-artifact", event.Artifact.ArtifactID)
 			},
 		},
+		{
+			name: "map without kind discriminator",
+			event: map[string]any{
+				"taskId":    "task-123",
+				"contextId": "context-456",
+				"append":    true,
+				"lastChunk": true,
+				"artifact": map[string]any{
+					"artifactId": "stream-artifact",
+					"parts": []any{
+						map[string]any{"text": "Streaming content"},
+					},
+				},
+			},
+			wantOk: true,
+			assertions: func(t *testing.T, event *types.TaskArtifactUpdateEvent) {
+				assert.Equal(t, "task-123", event.TaskID)
+				assert.Equal(t, "stream-artifact", event.Artifact.ArtifactID)
+				assert.True(t, *event.Append)
+				assert.True(t, *event.LastChunk)
+			},
+		},
+		{
+			name: "stream response wrapper",
+			event: map[string]any{
+				"artifactUpdate": map[string]any{
+					"taskId":    "task-123",
+					"contextId": "context-456",
+					"artifact": map[string]any{
+						"artifactId": "stream-artifact",
+					},
+				},
+			},
+			wantOk: true,
+			assertions: func(t *testing.T, event *types.TaskArtifactUpdateEvent) {
+				assert.Equal(t, "stream-artifact", event.Artifact.ArtifactID)
+			},
+		},
+		{
+			name: "task result is not an artifact update",
+			event: map[string]any{
+				"id":        "task-123",
+				"contextId": "context-456",
+				"artifacts": []any{},
+			},
+			wantOk: false,
+		},
 		{
 			name: "non-artifact event",
 			event: map[string]any{
@@ -588,6 +635,36 @@ func TestArtifactHelper_ExtractArtifactUpdateFromStreamEvent(t *testing.T) {
 	}
 }
 
+func TestArtifactHelper_ApplyArtifactUpdate(t *testing.T) {
+	helper := NewArtifactHelper()
+	appendChunk := true
+	task := &types.Task{ID: "task-123"}
+
+	helper.ApplyArtifactUpdate(task, &types.TaskArtifactUpdateEvent{
+		Artifact: types.Artifact{
+			ArtifactID: "report",
+			Parts:      []types.Part{types.CreateTextPart("first ")},
+		},
+	})
+	helper.ApplyArtifactUpdate(task, &types.TaskArtifactUpdateEvent{
+		Append: &appendChunk,
+		Artifact: types.Artifact{
+			ArtifactID: "report",
+			Parts:      []types.Part{types.CreateTextPart("second")},
+		},
+	})
+	helper.ApplyArtifactUpdate(task, &types.TaskArtifactUpdateEvent{
+		Artifact: types.Artifact{
+			ArtifactID: "other",
+			Parts:      []types.Part{types.CreateTextPart("other")},
+		},
+	})
+
+	require.Len(t, task.Artifacts, 2)
+	assert.Equal(t, []string{"first ", "second"}, helper.ExtractTextFromArtifact(&task.Artifacts[0]))
+	assert.Equal(t, "other", task.Artifacts[1].ArtifactID)
+}
+
 func TestArtifactHelper_HasArtifacts(t *testing.T) {
 	helper := NewArtifactHelper()
 
@@ -951,6 +1028,99 @@ func TestArtifactHelper_DownloadFileData(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "byte-based file with matching checksum",
+			setupFileData: func(t *testing.T, tempDir string) (FileData, *DownloadConfig) {
+				fileName := "verified.txt"
+				checksum := "dffd6021bb2bd5b0af676290809ec3a53191dd81c7f70a4b28688a362182986f"
+				return FileData{
+						Name:     &fileName,
+						Data:     []byte("Hello, World!"),
+						Checksum: &checksum,
+					}, &DownloadConfig{
+						OutputDir: tempDir,
+					}
+			},
+			wantErr: false,
+			validate: func(t *testing.T, result *DownloadResult, tempDir string) {
+				assert.Equal(t, int64(13), result.BytesWritten)
+			},
+		},
+		{
+			name: "byte-based file with mismatched checksum",
+			setupFileData: func(t *testing.T, tempDir string) (FileData, *DownloadConfig) {
+				fileName := "tampered.txt"
+				checksum := "dffd6021bb2bd5b0af676290809ec3a53191dd81c7f70a4b28688a362182986f"
+				return FileData{
+						Name:     &fileName,
+						Data:     []byte("Hello, World?"),
+						Checksum: &checksum,
+					}, &DownloadConfig{
+						OutputDir: tempDir,
+					}
+			},
+			wantErr: true,
+		},
+		{
+			name: "mismatched checksum with verification skipped",
+			setupFileData: func(t *testing.T, tempDir string) (FileData, *DownloadConfig) {
+				fileName := "unverified.txt"
+				checksum := "dffd6021bb2bd5b0af676290809ec3a53191dd81c7f70a4b28688a362182986f"
+				return FileData{
+						Name:     &fileName,
+						Data:     []byte("Hello, World?"),
+						Checksum: &checksum,
+					}, &DownloadConfig{
+						OutputDir:                tempDir,
+						SkipChecksumVerification: true,
+					}
+			},
+			wantErr: false,
+			validate: func(t *testing.T, result *DownloadResult, tempDir string) {
+				assert.FileExists(t, result.FilePath)
+			},
+		},
+		{
+			name: "URI download verified against server checksum header",
+			setupFileData: func(t *testing.T, tempDir string) (FileData, *DownloadConfig) {
+				fileName := "header.txt"
+				return FileData{
+						Name: &fileName,
+					}, &DownloadConfig{
+						OutputDir: tempDir,
+					}
+			},
+			setupServer: func() *httptest.Server {
+				return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set(types.ArtifactChecksumHeader, "36ee8eac8d49e0200342c270e35f9bc2fc93cd7b53c1237ba79427b498ef4a1b")
+					_, err := w.Write([]byte("server content"))
+					require.NoError(t, err)
+				}))
+			},
+			wantErr: false,
+			validate: func(t *testing.T, result *DownloadResult, tempDir string) {
+				assert.Equal(t, int64(14), result.BytesWritten)
+			},
+		},
+		{
+			name: "URI download with corrupted content",
+			setupFileData: func(t *testing.T, tempDir string) (FileData, *DownloadConfig) {
+				fileName := "corrupted.txt"
+				return FileData{
+						Name: &fileName,
+					}, &DownloadConfig{
+						OutputDir: tempDir,
+					}
+			},
+			setupServer: func() *httptest.Server {
+				return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set(types.ArtifactChecksumHeader, "dffd6021bb2bd5b0af676290809ec3a53191dd81c7f70a4b28688a362182986f")
+					_, err := w.Write([]byte("server content"))
+					require.NoError(t, err)
+				}))
+			},
+			wantErr: true,
+		},
 		{
 			name: "default filename when not provided",
 			setupFileData: func(t *testing.T, tempDir string) (FileData, *DownloadConfig) {
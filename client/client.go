@@ -1,16 +1,18 @@
 package client
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/inference-gateway/adk/types"
 	"go.uber.org/zap"
 )
@@ -21,14 +23,36 @@ type A2AClient interface {
 	GetAgentCard(ctx context.Context) (*types.AgentCard, error)
 	GetAuthenticatedExtendedCard(ctx context.Context, params types.GetAuthenticatedExtendedCardParams) (*types.JSONRPCSuccessResponse, error)
 	GetHealth(ctx context.Context) (*HealthResponse, error)
+	SupportsStreaming(ctx context.Context) (bool, error)
+	Negotiate(ctx context.Context, outputModes []string) ([]string, error)
 
 	// Task operations
 	SendTask(ctx context.Context, params types.MessageSendParams) (*types.JSONRPCSuccessResponse, error)
+	SendTaskBatch(ctx context.Context, params types.MessageSendBatchParams) (*types.JSONRPCSuccessResponse, error)
 	SendTaskStreaming(ctx context.Context, params types.MessageSendParams) (<-chan types.JSONRPCSuccessResponse, error)
+	StreamTask(ctx context.Context, params types.MessageSendParams) (<-chan TaskEvent, error)
+	SendAndWait(ctx context.Context, params types.MessageSendParams, opts *WaitOptions) (*types.Task, error)
 	GetTask(ctx context.Context, params types.TaskQueryParams) (*types.JSONRPCSuccessResponse, error)
 	ListTasks(ctx context.Context, params types.TaskListParams) (*types.JSONRPCSuccessResponse, error)
 	CancelTask(ctx context.Context, params types.TaskIdParams) (*types.JSONRPCSuccessResponse, error)
+	SubmitTaskFeedback(ctx context.Context, params types.TaskFeedbackParams) (*types.JSONRPCSuccessResponse, error)
+	ShareTask(ctx context.Context, params types.TaskShareParams) (*types.JSONRPCSuccessResponse, error)
+	GetSharedTranscript(ctx context.Context, token string) (*types.SharedTranscript, error)
+	GetContextUsage(ctx context.Context, params types.ContextUsageParams) (*types.JSONRPCSuccessResponse, error)
+	ForkTask(ctx context.Context, params types.TaskForkParams) (*types.JSONRPCSuccessResponse, error)
+	CreateContext(ctx context.Context, params types.ContextCreateParams) (*types.JSONRPCSuccessResponse, error)
+	GetContext(ctx context.Context, params types.ContextIdParams) (*types.JSONRPCSuccessResponse, error)
+	ListContexts(ctx context.Context, params types.ContextListParams) (*types.JSONRPCSuccessResponse, error)
+	DeleteContext(ctx context.Context, params types.ContextIdParams) (*types.JSONRPCSuccessResponse, error)
+	ListArtifacts(ctx context.Context, params types.ArtifactListParams) (*types.JSONRPCSuccessResponse, error)
+	CreateMemory(ctx context.Context, params types.MemoryCreateParams) (*types.JSONRPCSuccessResponse, error)
+	GetMemory(ctx context.Context, params types.MemoryIdParams) (*types.JSONRPCSuccessResponse, error)
+	ListMemories(ctx context.Context, params types.MemoryListParams) (*types.JSONRPCSuccessResponse, error)
+	UpdateMemory(ctx context.Context, params types.MemoryUpdateParams) (*types.JSONRPCSuccessResponse, error)
+	DeleteMemory(ctx context.Context, params types.MemoryIdParams) (*types.JSONRPCSuccessResponse, error)
 	ResubscribeTask(ctx context.Context, params types.TaskResubscriptionParams) (<-chan types.JSONRPCSuccessResponse, error)
+	OpenTaskStream(ctx context.Context, params types.MessageSendParams) (*TaskStream, error)
+	OpenResubscribeStream(ctx context.Context, params types.TaskResubscriptionParams) (*TaskStream, error)
 
 	// Push notification configuration
 	SetTaskPushNotificationConfig(ctx context.Context, params types.TaskPushNotificationConfig) (*types.JSONRPCSuccessResponse, error)
@@ -36,6 +60,9 @@ type A2AClient interface {
 	ListTaskPushNotificationConfig(ctx context.Context, params types.ListTaskPushNotificationConfigParams) (*types.JSONRPCSuccessResponse, error)
 	DeleteTaskPushNotificationConfig(ctx context.Context, params types.DeleteTaskPushNotificationConfigParams) (*types.JSONRPCSuccessResponse, error)
 
+	// Custom JSON-RPC methods
+	Call(ctx context.Context, method string, params any) (*types.JSONRPCSuccessResponse, error)
+
 	// Configuration
 	SetTimeout(timeout time.Duration)
 	SetHTTPClient(client *http.Client)
@@ -67,6 +94,21 @@ type Config struct {
 	MaxRetries int
 	RetryDelay time.Duration
 	Logger     *zap.Logger
+
+	// StreamBufferSize is the number of streaming events buffered for the consumer (default 100)
+	StreamBufferSize int
+	// StreamSendTimeout is how long a stream waits for the consumer to receive an event
+	// when the buffer is full before it closes the stream (0 waits indefinitely)
+	StreamSendTimeout time.Duration
+	// StreamTransport selects the streaming transport: auto (default), sse or websocket
+	StreamTransport string
+	// AgentCardTTL is how long GetAgentCard serves the agent card from cache before it
+	// revalidates the card with the server (0 disables caching)
+	AgentCardTTL time.Duration
+	// FileStagingThreshold is the file size in bytes above which inline FileParts are uploaded
+	// to the agent's artifacts server and sent by URI, when the agent card advertises
+	// artifact uploads (0 disables staging)
+	FileStagingThreshold int64
 }
 
 // DefaultConfig returns a default configuration
@@ -79,6 +121,11 @@ func DefaultConfig(baseURL string) *Config {
 		MaxRetries: 3,
 		RetryDelay: 1 * time.Second,
 		Logger:     zap.NewNop(),
+
+		StreamBufferSize:  defaultStreamBufferSize,
+		StreamSendTimeout: 1 * time.Minute,
+		StreamTransport:   StreamTransportAuto,
+		AgentCardTTL:      5 * time.Minute,
 	}
 }
 
@@ -88,6 +135,18 @@ type Client struct {
 	httpClient     *http.Client
 	logger         *zap.Logger
 	artifactHelper *ArtifactHelper
+
+	transportMu   sync.Mutex
+	webSocketPath string
+
+	cardMu        sync.Mutex
+	card          *types.AgentCard
+	cardETag      string
+	cardFetchedAt time.Time
+
+	stagingMu     sync.Mutex
+	uploadURL     string
+	cardRetrieved bool
 }
 
 // NewClient creates a new A2A client with default configuration
@@ -144,6 +203,15 @@ func (c *Client) SendTask(ctx context.Context, params types.MessageSendParams) (
 		zap.String("message_id", params.Message.MessageID),
 		zap.String("role", string(params.Message.Role)))
 
+	if params.Message.MessageID == "" {
+		params.Message.MessageID = uuid.New().String()
+	}
+
+	params, err := c.stageFileParts(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
 	req := types.JSONRPCRequest{
 		JSONRPC: "2.0",
 		Method:  "message/send",
@@ -163,8 +231,12 @@ func (c *Client) SendTask(ctx context.Context, params types.MessageSendParams) (
 	}
 	req.Params = paramsMap
 
+	// Retries carry the same idempotency key, so the server does not create a second task
+	// when an earlier attempt reached it
+	headers := map[string]string{types.IdempotencyKeyHeader: params.Message.MessageID}
+
 	var resp types.JSONRPCSuccessResponse
-	if err := c.doRequestWithContext(ctx, req, &resp); err != nil {
+	if err := c.doRequestWithHeaders(ctx, req, &resp, headers); err != nil {
 		c.logger.Error("failed to send task", zap.Error(err), zap.String("message_id", params.Message.MessageID))
 		return nil, err
 	}
@@ -173,126 +245,30 @@ func (c *Client) SendTask(ctx context.Context, params types.MessageSendParams) (
 	return &resp, nil
 }
 
-// SendTaskStreaming sends a task and returns a channel for streaming events
+// SendTaskStreaming sends a task and returns a channel for streaming events. The channel
+// is closed when the stream ends or the context is cancelled; use OpenTaskStream to close
+// the stream explicitly.
 func (c *Client) SendTaskStreaming(ctx context.Context, params types.MessageSendParams) (<-chan types.JSONRPCSuccessResponse, error) {
+	stream, err := c.OpenTaskStream(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return stream.Events(), nil
+}
+
+// OpenTaskStream sends a task via `message/stream` and returns a handle on the stream of events
+func (c *Client) OpenTaskStream(ctx context.Context, params types.MessageSendParams) (*TaskStream, error) {
 	c.logger.Debug("starting task streaming",
 		zap.String("method", "message/stream"),
 		zap.String("message_id", params.Message.MessageID),
 		zap.String("role", string(params.Message.Role)))
 
-	req := types.JSONRPCRequest{
-		JSONRPC: "2.0",
-		Method:  "message/stream",
-		Params:  make(map[string]any),
-	}
-
-	paramsBytes, err := json.Marshal(params)
-	if err != nil {
-		c.logger.Error("failed to marshal params", zap.Error(err))
-		return nil, fmt.Errorf("failed to marshal params: %w", err)
-	}
-
-	var paramsMap map[string]any
-	if err := json.Unmarshal(paramsBytes, &paramsMap); err != nil {
-		c.logger.Error("failed to unmarshal params to map", zap.Error(err))
-		return nil, fmt.Errorf("failed to unmarshal params to map: %w", err)
-	}
-	req.Params = paramsMap
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		c.logger.Error("failed to marshal request", zap.Error(err))
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.getA2AEndpointURL(), bytes.NewBuffer(body))
-	if err != nil {
-		c.logger.Error("failed to create request", zap.Error(err))
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	c.setHeaders(httpReq)
-	httpReq.Header.Set("Accept", "text/event-stream")
-
-	c.logger.Debug("sending streaming request", zap.String("url", c.getA2AEndpointURL()))
-
-	httpResp, err := c.httpClient.Do(httpReq)
+	params, err := c.stageFileParts(ctx, params)
 	if err != nil {
-		c.logger.Error("failed to send request", zap.Error(err))
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-
-	if httpResp.StatusCode != http.StatusOK {
-		if closeErr := httpResp.Body.Close(); closeErr != nil {
-			c.logger.Warn("failed to close response body", zap.Error(closeErr))
-		}
-		c.logger.Error("unexpected status code", zap.Int("status_code", httpResp.StatusCode))
-		return nil, fmt.Errorf("unexpected status code: %d", httpResp.StatusCode)
+		return nil, err
 	}
 
-	c.logger.Debug("streaming response started successfully")
-
-	eventChan := make(chan types.JSONRPCSuccessResponse, 100)
-
-	go func() {
-		defer func() {
-			if closeErr := httpResp.Body.Close(); closeErr != nil {
-				c.logger.Warn("failed to close response body", zap.Error(closeErr))
-			}
-			close(eventChan)
-		}()
-
-		scanner := bufio.NewScanner(httpResp.Body)
-		eventCount := 0
-		for {
-			select {
-			case <-ctx.Done():
-				c.logger.Debug("streaming context cancelled", zap.Int("events_received", eventCount))
-				return
-			default:
-				if !scanner.Scan() {
-					if err := scanner.Err(); err != nil {
-						c.logger.Error("failed to scan response", zap.Error(err), zap.Int("events_received", eventCount))
-						return
-					}
-					c.logger.Debug("streaming completed", zap.Int("events_received", eventCount))
-					return
-				}
-
-				line := scanner.Text()
-				c.logger.Debug("received line", zap.String("line", line))
-
-				if line == "" || !strings.HasPrefix(line, "data: ") {
-					continue
-				}
-
-				if strings.TrimSpace(line) == "data: [DONE]" {
-					c.logger.Debug("received stream termination signal", zap.Int("events_received", eventCount))
-					return
-				}
-
-				jsonData := strings.TrimPrefix(line, "data: ")
-
-				var event types.JSONRPCSuccessResponse
-				if err := json.Unmarshal([]byte(jsonData), &event); err != nil {
-					c.logger.Error("failed to decode event", zap.Error(err), zap.Int("events_received", eventCount), zap.String("json_data", jsonData))
-					return
-				}
-
-				eventCount++
-				c.logger.Debug("received streaming event", zap.Int("event_number", eventCount))
-
-				select {
-				case eventChan <- event:
-				case <-ctx.Done():
-					c.logger.Debug("streaming context cancelled while sending event", zap.Int("events_received", eventCount))
-					return
-				}
-			}
-		}
-	}()
-
-	return eventChan, nil
+	return c.openStream(ctx, "message/stream", params)
 }
 
 // GetTaskWithContext retrieves the status of a task with context support
@@ -465,6 +441,162 @@ func (c *Client) DeleteTaskPushNotificationConfig(ctx context.Context, params ty
 	return c.doJSONRPCCall(ctx, "tasks/pushNotificationConfig/delete", params)
 }
 
+// SubmitTaskFeedback records a rating with optional free text and category against a task via
+// the `tasks/feedback` JSON-RPC method. The result is the stored types.TaskFeedback.
+func (c *Client) SubmitTaskFeedback(ctx context.Context, params types.TaskFeedbackParams) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("submitting task feedback",
+		zap.String("method", "tasks/feedback"),
+		zap.String("task_id", params.ID),
+		zap.Int("rating", params.Rating))
+	return c.doJSONRPCCall(ctx, "tasks/feedback", params)
+}
+
+// ShareTask issues a read-only share link for a redacted transcript of a task via the
+// `tasks/share` JSON-RPC method. The result is a types.TaskShareLink.
+func (c *Client) ShareTask(ctx context.Context, params types.TaskShareParams) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("sharing task",
+		zap.String("method", "tasks/share"),
+		zap.String("task_id", params.ID),
+		zap.Strings("scopes", params.Scopes))
+	return c.doJSONRPCCall(ctx, "tasks/share", params)
+}
+
+// GetContextUsage sums the token usage recorded on the tasks of a context via the
+// `tasks/usage` JSON-RPC method. The result is a types.ContextUsage.
+func (c *Client) GetContextUsage(ctx context.Context, params types.ContextUsageParams) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("getting context usage",
+		zap.String("method", "tasks/usage"),
+		zap.String("context_id", params.ContextID))
+	return c.doJSONRPCCall(ctx, "tasks/usage", params)
+}
+
+// SendTaskBatch starts a task for each message of a batch in one request via the
+// `message/sendBatch` JSON-RPC method. Messages without an ID get one, and their file parts
+// are staged like those of SendTask. The result is a types.MessageSendBatchResult.
+func (c *Client) SendTaskBatch(ctx context.Context, params types.MessageSendBatchParams) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("sending task batch",
+		zap.String("method", "message/sendBatch"),
+		zap.Int("messages", len(params.Messages)))
+
+	messages := make([]types.MessageSendParams, 0, len(params.Messages))
+	for _, message := range params.Messages {
+		if message.Message.MessageID == "" {
+			message.Message.MessageID = uuid.New().String()
+		}
+		staged, err := c.stageFileParts(ctx, message)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, staged)
+	}
+	params.Messages = messages
+	return c.doJSONRPCCall(ctx, "message/sendBatch", params)
+}
+
+// ForkTask copies the history of a task up to and including a message into a new task in the
+// same context via the `tasks/fork` JSON-RPC method. The result is a types.Task.
+func (c *Client) ForkTask(ctx context.Context, params types.TaskForkParams) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("forking task",
+		zap.String("method", "tasks/fork"),
+		zap.String("task_id", params.ID),
+		zap.Int("history_index", params.HistoryIndex))
+	return c.doJSONRPCCall(ctx, "tasks/fork", params)
+}
+
+// CreateContext creates a conversation context with its metadata and shared memory via the
+// `contexts/create` JSON-RPC method. The result is a types.ConversationContext.
+func (c *Client) CreateContext(ctx context.Context, params types.ContextCreateParams) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("creating context",
+		zap.String("method", "contexts/create"),
+		zap.String("context_id", params.ContextID))
+	return c.doJSONRPCCall(ctx, "contexts/create", params)
+}
+
+// GetContext retrieves a conversation context with the IDs of its tasks via the
+// `contexts/get` JSON-RPC method. The result is a types.ConversationContext.
+func (c *Client) GetContext(ctx context.Context, params types.ContextIdParams) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("getting context",
+		zap.String("method", "contexts/get"),
+		zap.String("context_id", params.ContextID))
+	return c.doJSONRPCCall(ctx, "contexts/get", params)
+}
+
+// ListContexts lists the conversation contexts of the server via the `contexts/list`
+// JSON-RPC method. The result is a types.ContextList.
+func (c *Client) ListContexts(ctx context.Context, params types.ContextListParams) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("listing contexts",
+		zap.String("method", "contexts/list"),
+		zap.Int("limit", params.Limit),
+		zap.Int("offset", params.Offset))
+	return c.doJSONRPCCall(ctx, "contexts/list", params)
+}
+
+// DeleteContext deletes a conversation context with its tasks and stored artifacts via the
+// `contexts/delete` JSON-RPC method. The result is a types.ContextDeleteResult.
+func (c *Client) DeleteContext(ctx context.Context, params types.ContextIdParams) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("deleting context",
+		zap.String("method", "contexts/delete"),
+		zap.String("context_id", params.ContextID))
+	return c.doJSONRPCCall(ctx, "contexts/delete", params)
+}
+
+// ListArtifacts lists the artifact files of a task or context with their download links via
+// the `artifacts/list` JSON-RPC method. The result is a types.ArtifactListResult.
+func (c *Client) ListArtifacts(ctx context.Context, params types.ArtifactListParams) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("listing artifacts",
+		zap.String("method", "artifacts/list"),
+		zap.String("context_id", params.ContextID),
+		zap.String("task_id", params.TaskID))
+	return c.doJSONRPCCall(ctx, "artifacts/list", params)
+}
+
+// CreateMemory stores a memory for a user or a context via the `memories/create` JSON-RPC
+// method. The result is the created types.Memory.
+func (c *Client) CreateMemory(ctx context.Context, params types.MemoryCreateParams) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("creating memory",
+		zap.String("method", "memories/create"),
+		zap.String("context_id", params.ContextID),
+		zap.String("user_id", params.UserID))
+	return c.doJSONRPCCall(ctx, "memories/create", params)
+}
+
+// GetMemory retrieves a memory via the `memories/get` JSON-RPC method. The result is a
+// types.Memory.
+func (c *Client) GetMemory(ctx context.Context, params types.MemoryIdParams) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("getting memory",
+		zap.String("method", "memories/get"),
+		zap.String("memory_id", params.ID))
+	return c.doJSONRPCCall(ctx, "memories/get", params)
+}
+
+// ListMemories lists the memories of a user or a context via the `memories/list` JSON-RPC
+// method. The result is a types.MemoryListResult.
+func (c *Client) ListMemories(ctx context.Context, params types.MemoryListParams) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("listing memories",
+		zap.String("method", "memories/list"),
+		zap.String("context_id", params.ContextID),
+		zap.String("user_id", params.UserID))
+	return c.doJSONRPCCall(ctx, "memories/list", params)
+}
+
+// UpdateMemory replaces the content of a memory via the `memories/update` JSON-RPC method.
+// The result is the updated types.Memory.
+func (c *Client) UpdateMemory(ctx context.Context, params types.MemoryUpdateParams) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("updating memory",
+		zap.String("method", "memories/update"),
+		zap.String("memory_id", params.ID))
+	return c.doJSONRPCCall(ctx, "memories/update", params)
+}
+
+// DeleteMemory deletes a memory via the `memories/delete` JSON-RPC method. The result is the
+// deleted types.Memory.
+func (c *Client) DeleteMemory(ctx context.Context, params types.MemoryIdParams) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("deleting memory",
+		zap.String("method", "memories/delete"),
+		zap.String("memory_id", params.ID))
+	return c.doJSONRPCCall(ctx, "memories/delete", params)
+}
+
 // GetAuthenticatedExtendedCard fetches the authenticated/extended agent card via the
 // `agent/getAuthenticatedExtendedCard` JSON-RPC method. Unlike GetAgentCard (which hits
 // the public HTTP endpoint), this call goes through the JSON-RPC route and is subject to
@@ -476,18 +608,41 @@ func (c *Client) GetAuthenticatedExtendedCard(ctx context.Context, params types.
 	return c.doJSONRPCCall(ctx, "agent/getAuthenticatedExtendedCard", params)
 }
 
+// Call invokes an arbitrary JSON-RPC method on the A2A endpoint, such as a custom method
+// registered on the server with WithJSONRPCMethod. params may be any JSON-serializable
+// object (or nil); the result is returned undecoded in the success response.
+func (c *Client) Call(ctx context.Context, method string, params any) (*types.JSONRPCSuccessResponse, error) {
+	c.logger.Debug("calling json-rpc method", zap.String("method", method))
+	return c.doJSONRPCCall(ctx, method, params)
+}
+
 // ResubscribeTask re-subscribes to a streaming task via the `tasks/resubscribe` JSON-RPC
 // method, returning a channel of streaming responses. The channel is closed when the
 // stream ends (either because the server sent `[DONE]`, the response body closed, or the
 // supplied context was cancelled).
 func (c *Client) ResubscribeTask(ctx context.Context, params types.TaskResubscriptionParams) (<-chan types.JSONRPCSuccessResponse, error) {
+	stream, err := c.OpenResubscribeStream(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return stream.Events(), nil
+}
+
+// OpenResubscribeStream re-subscribes to a streaming task via `tasks/resubscribe` and
+// returns a handle on the stream of events
+func (c *Client) OpenResubscribeStream(ctx context.Context, params types.TaskResubscriptionParams) (*TaskStream, error) {
 	c.logger.Debug("resubscribing to task",
 		zap.String("method", "tasks/resubscribe"),
 		zap.String("task_name", params.Name))
 
+	return c.openStream(ctx, "tasks/resubscribe", params)
+}
+
+// openStream sends a streaming JSON-RPC request and starts reading its server-sent events
+func (c *Client) openStream(ctx context.Context, method string, params any) (*TaskStream, error) {
 	req := types.JSONRPCRequest{
 		JSONRPC: "2.0",
-		Method:  "tasks/resubscribe",
+		Method:  method,
 		Params:  make(map[string]any),
 	}
 
@@ -510,8 +665,14 @@ func (c *Client) ResubscribeTask(ctx context.Context, params types.TaskResubscri
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.getA2AEndpointURL(), bytes.NewBuffer(body))
+	if path, ok := c.resolveWebSocketPath(); ok {
+		return c.openWebSocketStream(ctx, method, path, body)
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	httpReq, err := http.NewRequestWithContext(streamCtx, "POST", c.getA2AEndpointURL(), bytes.NewBuffer(body))
 	if err != nil {
+		cancel()
 		c.logger.Error("failed to create request", zap.Error(err))
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -519,13 +680,19 @@ func (c *Client) ResubscribeTask(ctx context.Context, params types.TaskResubscri
 	c.setHeaders(httpReq)
 	httpReq.Header.Set("Accept", "text/event-stream")
 
+	c.logger.Debug("sending streaming request",
+		zap.String("method", method),
+		zap.String("url", c.getA2AEndpointURL()))
+
 	httpResp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		c.logger.Error("failed to send resubscribe request", zap.Error(err))
+		cancel()
+		c.logger.Error("failed to send request", zap.Error(err))
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 
 	if httpResp.StatusCode != http.StatusOK {
+		cancel()
 		if closeErr := httpResp.Body.Close(); closeErr != nil {
 			c.logger.Warn("failed to close response body", zap.Error(closeErr))
 		}
@@ -533,68 +700,26 @@ func (c *Client) ResubscribeTask(ctx context.Context, params types.TaskResubscri
 		return nil, fmt.Errorf("unexpected status code: %d", httpResp.StatusCode)
 	}
 
-	eventChan := make(chan types.JSONRPCSuccessResponse, 100)
-
-	go func() {
-		defer func() {
-			if closeErr := httpResp.Body.Close(); closeErr != nil {
-				c.logger.Warn("failed to close response body", zap.Error(closeErr))
-			}
-			close(eventChan)
-		}()
+	c.logger.Debug("streaming response started successfully", zap.String("method", method))
 
-		scanner := bufio.NewScanner(httpResp.Body)
-		eventCount := 0
-		for {
-			select {
-			case <-ctx.Done():
-				c.logger.Debug("resubscribe context cancelled", zap.Int("events_received", eventCount))
-				return
-			default:
-				if !scanner.Scan() {
-					if err := scanner.Err(); err != nil {
-						c.logger.Error("failed to scan response", zap.Error(err), zap.Int("events_received", eventCount))
-						return
-					}
-					c.logger.Debug("resubscribe stream completed", zap.Int("events_received", eventCount))
-					return
-				}
-
-				line := scanner.Text()
-				if line == "" || !strings.HasPrefix(line, "data: ") {
-					continue
-				}
-
-				if strings.TrimSpace(line) == "data: [DONE]" {
-					c.logger.Debug("received resubscribe termination signal", zap.Int("events_received", eventCount))
-					return
-				}
-
-				jsonData := strings.TrimPrefix(line, "data: ")
-
-				var event types.JSONRPCSuccessResponse
-				if err := json.Unmarshal([]byte(jsonData), &event); err != nil {
-					c.logger.Error("failed to decode event", zap.Error(err), zap.String("json_data", jsonData))
-					return
-				}
-
-				eventCount++
-
-				select {
-				case eventChan <- event:
-				case <-ctx.Done():
-					c.logger.Debug("resubscribe context cancelled while sending event", zap.Int("events_received", eventCount))
-					return
-				}
-			}
-		}
-	}()
+	return newTaskStream(streamCtx, cancel, httpResp.Body, method, c.streamBufferSize(), c.config.StreamSendTimeout, c.logger), nil
+}
 
-	return eventChan, nil
+// streamBufferSize returns the configured stream buffer size, or the default when unset
+func (c *Client) streamBufferSize() int {
+	if c.config.StreamBufferSize <= 0 {
+		return defaultStreamBufferSize
+	}
+	return c.config.StreamBufferSize
 }
 
 // GetAgentCard retrieves the agent card information via HTTP GET to .well-known/agent-card.json
 func (c *Client) GetAgentCard(ctx context.Context) (*types.AgentCard, error) {
+	cached, etag, fresh := c.cachedAgentCard()
+	if fresh {
+		return cached, nil
+	}
+
 	c.logger.Debug("retrieving agent card", zap.String("endpoint", "/.well-known/agent-card.json"))
 
 	agentCardURL := c.config.BaseURL + "/.well-known/agent-card.json"
@@ -607,6 +732,9 @@ func (c *Client) GetAgentCard(ctx context.Context) (*types.AgentCard, error) {
 
 	httpReq.Header.Set("Accept", "application/json")
 	httpReq.Header.Set("User-Agent", c.config.UserAgent)
+	if cached != nil && etag != "" {
+		httpReq.Header.Set("If-None-Match", etag)
+	}
 
 	httpResp, err := c.httpClient.Do(httpReq)
 	if err != nil {
@@ -619,6 +747,12 @@ func (c *Client) GetAgentCard(ctx context.Context) (*types.AgentCard, error) {
 		}
 	}()
 
+	if httpResp.StatusCode == http.StatusNotModified && cached != nil {
+		c.logger.Debug("agent card not modified", zap.String("etag", etag))
+		c.cacheAgentCard(cached, etag)
+		return cached, nil
+	}
+
 	if httpResp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(httpResp.Body)
 		c.logger.Error("unexpected status code for agent card",
@@ -636,9 +770,59 @@ func (c *Client) GetAgentCard(ctx context.Context) (*types.AgentCard, error) {
 	c.logger.Debug("agent card retrieved successfully",
 		zap.String("name", agentCard.Name),
 		zap.String("version", agentCard.Version))
+	c.negotiateStreamTransport(&agentCard)
+	c.negotiateArtifactUpload(&agentCard)
+	c.cacheAgentCard(&agentCard, httpResp.Header.Get("ETag"))
 	return &agentCard, nil
 }
 
+// GetSharedTranscript fetches the redacted transcript granted by a share token via HTTP GET
+// to /share/{token}. The token is the only credential sent with the request.
+func (c *Client) GetSharedTranscript(ctx context.Context, token string) (*types.SharedTranscript, error) {
+	c.logger.Debug("retrieving shared transcript", zap.String("endpoint", "/share"))
+
+	transcriptURL := c.config.BaseURL + "/share/" + url.PathEscape(token)
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", transcriptURL, nil)
+	if err != nil {
+		c.logger.Error("failed to create shared transcript request", zap.Error(err))
+		return nil, fmt.Errorf("failed to create shared transcript request: %w", err)
+	}
+
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("User-Agent", c.config.UserAgent)
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		c.logger.Error("shared transcript request failed", zap.Error(err))
+		return nil, fmt.Errorf("shared transcript request failed: %w", err)
+	}
+	defer func() {
+		if closeErr := httpResp.Body.Close(); closeErr != nil {
+			c.logger.Warn("failed to close shared transcript response body", zap.Error(closeErr))
+		}
+	}()
+
+	if httpResp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(httpResp.Body)
+		c.logger.Error("unexpected status code for shared transcript",
+			zap.Int("status_code", httpResp.StatusCode),
+			zap.String("response_body", string(bodyBytes)))
+		return nil, fmt.Errorf("unexpected status code for shared transcript: %d, body: %s", httpResp.StatusCode, string(bodyBytes))
+	}
+
+	var transcript types.SharedTranscript
+	if err := json.NewDecoder(httpResp.Body).Decode(&transcript); err != nil {
+		c.logger.Error("failed to decode shared transcript response", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode shared transcript response: %w", err)
+	}
+
+	c.logger.Debug("shared transcript retrieved successfully",
+		zap.String("task_id", transcript.TaskID),
+		zap.Int("messages", len(transcript.Messages)))
+	return &transcript, nil
+}
+
 // GetHealth retrieves the health status of the agent via HTTP GET to /health
 func (c *Client) GetHealth(ctx context.Context) (*HealthResponse, error) {
 	c.logger.Debug("retrieving agent health", zap.String("endpoint", "/health"))
@@ -702,6 +886,12 @@ func (c *Client) GetHealth(ctx context.Context) (*HealthResponse, error) {
 
 // doRequestWithContext performs the HTTP request with context support and handles the response
 func (c *Client) doRequestWithContext(ctx context.Context, req types.JSONRPCRequest, resp *types.JSONRPCSuccessResponse) error {
+	return c.doRequestWithHeaders(ctx, req, resp, nil)
+}
+
+// doRequestWithHeaders sends a JSON-RPC request with additional headers, retrying it when
+// the request fails to reach the server
+func (c *Client) doRequestWithHeaders(ctx context.Context, req types.JSONRPCRequest, resp *types.JSONRPCSuccessResponse, headers map[string]string) error {
 	c.logger.Debug("preparing request", zap.String("method", req.Method), zap.String("base_url", c.config.BaseURL))
 
 	body, err := json.Marshal(req)
@@ -710,14 +900,6 @@ func (c *Client) doRequestWithContext(ctx context.Context, req types.JSONRPCRequ
 		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.getA2AEndpointURL(), bytes.NewBuffer(body))
-	if err != nil {
-		c.logger.Error("failed to create request", zap.Error(err))
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	c.setHeaders(httpReq)
-
 	var httpResp *http.Response
 	var lastErr error
 
@@ -729,6 +911,17 @@ func (c *Client) doRequestWithContext(ctx context.Context, req types.JSONRPCRequ
 				zap.Int("max_retries", c.config.MaxRetries+1))
 		}
 
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", c.getA2AEndpointURL(), bytes.NewReader(body))
+		if err != nil {
+			c.logger.Error("failed to create request", zap.Error(err))
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		c.setHeaders(httpReq)
+		for key, value := range headers {
+			httpReq.Header.Set(key, value)
+		}
+
 		httpResp, err = c.httpClient.Do(httpReq)
 		if err == nil {
 			c.logger.Debug("request successful",
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -1078,6 +1079,39 @@ func TestClient_RetryMechanism(t *testing.T) {
 	}
 }
 
+func TestClient_SendTask_RetryWithIdempotencyKey(t *testing.T) {
+	var tries atomic.Int32
+	var keys, bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		keys = append(keys, r.Header.Get(types.IdempotencyKeyHeader))
+		bodies = append(bodies, string(body))
+
+		if tries.Add(1) == 1 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			require.NoError(t, conn.Close())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"id":"task-1"}}`))
+	}))
+	defer server.Close()
+
+	c := client.NewClientWithConfig(&client.Config{BaseURL: server.URL, MaxRetries: 2, RetryDelay: time.Millisecond})
+	_, err := c.SendTask(context.Background(), types.MessageSendParams{
+		Message: types.Message{Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("hello")}},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, keys, 2)
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, keys[0], keys[1])
+	assert.Equal(t, bodies[0], bodies[1])
+	assert.Contains(t, bodies[0], keys[0])
+}
+
 func TestClient_ContextCancellation(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -2290,6 +2324,270 @@ func TestClient_GetAuthenticatedExtendedCard(t *testing.T) {
 	assert.Equal(t, "2.0", resp.JSONRPC)
 }
 
+func TestClient_Call(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req types.JSONRPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "myapp/feedback/submit", req.Method)
+		assert.Equal(t, "task-1", req.Params["taskId"])
+		assert.Equal(t, float64(5), req.Params["rating"])
+
+		response := types.JSONRPCSuccessResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Result:  map[string]any{"accepted": true},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL)
+	resp, err := c.Call(context.Background(), "myapp/feedback/submit", struct {
+		TaskID string `json:"taskId"`
+		Rating int    `json:"rating"`
+	}{TaskID: "task-1", Rating: 5})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	result, err := json.Marshal(resp.Result)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"accepted":true}`, string(result))
+}
+
+func TestClient_SubmitTaskFeedback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req types.JSONRPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "tasks/feedback", req.Method)
+		assert.Equal(t, "task-1", req.Params["id"])
+		assert.Equal(t, float64(4), req.Params["rating"])
+		assert.Equal(t, "accuracy", req.Params["category"])
+
+		response := types.JSONRPCSuccessResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Result:  map[string]any{"feedbackId": "fb-1", "taskId": "task-1", "contextId": "ctx-1", "rating": 4, "createdAt": "2026-01-01T00:00:00Z"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	category := "accuracy"
+	c := client.NewClient(server.URL)
+	resp, err := c.SubmitTaskFeedback(context.Background(), types.TaskFeedbackParams{
+		ID:       "task-1",
+		Rating:   4,
+		Category: &category,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	result, err := json.Marshal(resp.Result)
+	require.NoError(t, err)
+	var feedback types.TaskFeedback
+	require.NoError(t, json.Unmarshal(result, &feedback))
+	assert.Equal(t, "fb-1", feedback.FeedbackID)
+	assert.Equal(t, 4, feedback.Rating)
+}
+
+func TestClient_GetContextUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req types.JSONRPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "tasks/usage", req.Method)
+		assert.Equal(t, "ctx-1", req.Params["contextId"])
+
+		response := types.JSONRPCSuccessResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Result: map[string]any{
+				"contextId": "ctx-1",
+				"taskCount": 1,
+				"tasks":     []map[string]any{{"taskId": "task-1", "state": "completed", "usage": map[string]any{"prompt_tokens": 10, "completion_tokens": 5, "total_tokens": 15}}},
+				"usage":     map[string]any{"prompt_tokens": 10, "completion_tokens": 5, "total_tokens": 15},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL)
+	resp, err := c.GetContextUsage(context.Background(), types.ContextUsageParams{ContextID: "ctx-1"})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	result, err := json.Marshal(resp.Result)
+	require.NoError(t, err)
+	var usage types.ContextUsage
+	require.NoError(t, json.Unmarshal(result, &usage))
+	assert.Equal(t, 1, usage.TaskCount)
+	assert.Equal(t, int64(15), usage.Usage.TotalTokens)
+}
+
+func TestClient_ListArtifacts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req types.JSONRPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "artifacts/list", req.Method)
+		assert.Equal(t, "ctx-1", req.Params["contextId"])
+
+		response := types.JSONRPCSuccessResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Result: map[string]any{
+				"artifacts": []map[string]any{{"artifactId": "artifact-1", "contextId": "ctx-1", "taskId": "task-1", "filename": "report.csv", "url": "http://localhost:8081/artifacts/ctx-1/artifact-1/report.csv"}},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL)
+	resp, err := c.ListArtifacts(context.Background(), types.ArtifactListParams{ContextID: "ctx-1"})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	result, err := json.Marshal(resp.Result)
+	require.NoError(t, err)
+	var listed types.ArtifactListResult
+	require.NoError(t, json.Unmarshal(result, &listed))
+	require.Len(t, listed.Artifacts, 1)
+	assert.Equal(t, "report.csv", listed.Artifacts[0].Filename)
+}
+
+func TestClient_Memories(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req types.JSONRPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		methods = append(methods, req.Method)
+
+		response := types.JSONRPCSuccessResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Result:  map[string]any{"id": "memory-1", "content": req.Params["content"], "userId": "alice"},
+		}
+		if req.Method == "memories/list" {
+			assert.Equal(t, "alice", req.Params["userId"])
+			response.Result = map[string]any{"memories": []map[string]any{{"id": "memory-1", "content": "Prefers metric units"}}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL)
+	ctx := context.Background()
+	resp, err := c.CreateMemory(ctx, types.MemoryCreateParams{UserID: "alice", Content: "Prefers metric units"})
+	require.NoError(t, err)
+	result, err := json.Marshal(resp.Result)
+	require.NoError(t, err)
+	var created types.Memory
+	require.NoError(t, json.Unmarshal(result, &created))
+	assert.Equal(t, "Prefers metric units", created.Content)
+
+	resp, err = c.ListMemories(ctx, types.MemoryListParams{UserID: "alice"})
+	require.NoError(t, err)
+	result, err = json.Marshal(resp.Result)
+	require.NoError(t, err)
+	var listed types.MemoryListResult
+	require.NoError(t, json.Unmarshal(result, &listed))
+	require.Len(t, listed.Memories, 1)
+
+	_, err = c.GetMemory(ctx, types.MemoryIdParams{ID: "memory-1"})
+	require.NoError(t, err)
+	_, err = c.UpdateMemory(ctx, types.MemoryUpdateParams{ID: "memory-1", Content: "Prefers imperial units"})
+	require.NoError(t, err)
+	_, err = c.DeleteMemory(ctx, types.MemoryIdParams{ID: "memory-1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"memories/create", "memories/list", "memories/get", "memories/update", "memories/delete"}, methods)
+}
+
+func TestClient_SendTaskBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req types.JSONRPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "message/sendBatch", req.Method)
+		messages, ok := req.Params["messages"].([]any)
+		require.True(t, ok)
+		require.Len(t, messages, 2)
+		for _, item := range messages {
+			message := item.(map[string]any)["message"].(map[string]any)
+			assert.NotEmpty(t, message["messageId"], "messages get an id")
+		}
+
+		response := types.JSONRPCSuccessResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Result:  map[string]any{"taskIds": []string{"task-1", "task-2"}},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL)
+	resp, err := c.SendTaskBatch(context.Background(), types.MessageSendBatchParams{
+		Messages: []types.MessageSendParams{
+			{Message: types.Message{Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("summarize document 1")}}},
+			{Message: types.Message{Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("summarize document 2")}}},
+		},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	result, err := json.Marshal(resp.Result)
+	require.NoError(t, err)
+	var batch types.MessageSendBatchResult
+	require.NoError(t, json.Unmarshal(result, &batch))
+	assert.Equal(t, []string{"task-1", "task-2"}, batch.TaskIDs)
+}
+
+func TestClient_DeleteContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req types.JSONRPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "contexts/delete", req.Method)
+		assert.Equal(t, "ctx-1", req.Params["contextId"])
+
+		response := types.JSONRPCSuccessResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Result: map[string]any{
+				"contextId":        "ctx-1",
+				"tasksCanceled":    1,
+				"tasksDeleted":     3,
+				"artifactsDeleted": 2,
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL)
+	resp, err := c.DeleteContext(context.Background(), types.ContextIdParams{ContextID: "ctx-1"})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	result, err := json.Marshal(resp.Result)
+	require.NoError(t, err)
+	var deleted types.ContextDeleteResult
+	require.NoError(t, json.Unmarshal(result, &deleted))
+	assert.Equal(t, types.ContextDeleteResult{ContextID: "ctx-1", TasksCanceled: 1, TasksDeleted: 3, ArtifactsDeleted: 2}, deleted)
+}
+
 func TestClient_ResubscribeTask(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "POST", r.Method)
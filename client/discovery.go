@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/inference-gateway/adk/types"
+)
+
+// cachedAgentCard returns a copy of the cached agent card and its ETag, and whether the
+// card is still within its TTL
+func (c *Client) cachedAgentCard() (*types.AgentCard, string, bool) {
+	c.cardMu.Lock()
+	defer c.cardMu.Unlock()
+
+	if c.card == nil {
+		return nil, "", false
+	}
+	card := *c.card
+	fresh := c.config.AgentCardTTL > 0 && time.Since(c.cardFetchedAt) < c.config.AgentCardTTL
+	return &card, c.cardETag, fresh
+}
+
+// cacheAgentCard stores a copy of the agent card retrieved from the server
+func (c *Client) cacheAgentCard(card *types.AgentCard, etag string) {
+	if c.config.AgentCardTTL <= 0 {
+		return
+	}
+
+	cached := *card
+	c.cardMu.Lock()
+	defer c.cardMu.Unlock()
+	c.card = &cached
+	c.cardETag = etag
+	c.cardFetchedAt = time.Now()
+}
+
+// SupportsStreaming reports whether the agent card advertises streaming, retrieving the
+// card when it is not cached
+func (c *Client) SupportsStreaming(ctx context.Context) (bool, error) {
+	card, err := c.GetAgentCard(ctx)
+	if err != nil {
+		return false, err
+	}
+	return card.Capabilities.Streaming != nil && *card.Capabilities.Streaming, nil
+}
+
+// Negotiate returns the output modes from outputModes, in order of preference, that the
+// agent card lists among its default output modes. An agent that lists none accepts all of
+// them. The result can be passed as AcceptedOutputModes of a MessageSendConfiguration.
+func (c *Client) Negotiate(ctx context.Context, outputModes []string) ([]string, error) {
+	card, err := c.GetAgentCard(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(card.DefaultOutputModes) == 0 {
+		return outputModes, nil
+	}
+
+	var accepted []string
+	for _, mode := range outputModes {
+		if slices.Contains(card.DefaultOutputModes, mode) {
+			accepted = append(accepted, mode)
+		}
+	}
+	if len(accepted) == 0 {
+		return nil, fmt.Errorf("agent supports none of the output modes %v, it supports %v", outputModes, card.DefaultOutputModes)
+	}
+	return accepted, nil
+}
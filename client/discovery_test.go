@@ -0,0 +1,115 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/inference-gateway/adk/client"
+	types "github.com/inference-gateway/adk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAgentCardServer serves the agent card with an ETag, answering If-None-Match requests
+// for the current ETag with 304, and counts the requests and the 304s it sent
+func newAgentCardServer(t *testing.T, card types.AgentCard) (*httptest.Server, *atomic.Int32, *atomic.Int32) {
+	t.Helper()
+	var requests, notModified atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(card)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests, &notModified
+}
+
+func TestClient_GetAgentCard_Cache(t *testing.T) {
+	server, requests, notModified := newAgentCardServer(t, types.AgentCard{Name: "agent"})
+	config := client.DefaultConfig(server.URL)
+	config.AgentCardTTL = 50 * time.Millisecond
+	a2aClient := client.NewClientWithConfig(config)
+	ctx := context.Background()
+
+	for range 3 {
+		card, err := a2aClient.GetAgentCard(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "agent", card.Name)
+	}
+	assert.Equal(t, int32(1), requests.Load())
+
+	time.Sleep(60 * time.Millisecond)
+	card, err := a2aClient.GetAgentCard(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "agent", card.Name)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, int32(1), notModified.Load())
+
+	card.Name = "changed by the caller"
+	cached, err := a2aClient.GetAgentCard(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "agent", cached.Name)
+}
+
+func TestClient_GetAgentCard_CacheDisabled(t *testing.T) {
+	server, requests, notModified := newAgentCardServer(t, types.AgentCard{Name: "agent"})
+	config := client.DefaultConfig(server.URL)
+	config.AgentCardTTL = 0
+	a2aClient := client.NewClientWithConfig(config)
+
+	for range 2 {
+		_, err := a2aClient.GetAgentCard(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Zero(t, notModified.Load())
+}
+
+func TestClient_SupportsStreaming(t *testing.T) {
+	streaming := true
+	server, requests, _ := newAgentCardServer(t, types.AgentCard{Name: "agent", Capabilities: types.AgentCapabilities{Streaming: &streaming}})
+	a2aClient := client.NewClient(server.URL)
+
+	supported, err := a2aClient.SupportsStreaming(context.Background())
+	require.NoError(t, err)
+	assert.True(t, supported)
+
+	_, err = a2aClient.GetAgentCard(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+
+	plain, _, _ := newAgentCardServer(t, types.AgentCard{Name: "agent"})
+	supported, err = client.NewClient(plain.URL).SupportsStreaming(context.Background())
+	require.NoError(t, err)
+	assert.False(t, supported)
+}
+
+func TestClient_Negotiate(t *testing.T) {
+	server, _, _ := newAgentCardServer(t, types.AgentCard{Name: "agent", DefaultOutputModes: []string{"text/plain", "application/json"}})
+	a2aClient := client.NewClient(server.URL)
+	ctx := context.Background()
+
+	modes, err := a2aClient.Negotiate(ctx, []string{"text/markdown", "application/json", "text/plain"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"application/json", "text/plain"}, modes)
+
+	_, err = a2aClient.Negotiate(ctx, []string{"image/png"})
+	assert.ErrorContains(t, err, "agent supports none of the output modes [image/png]")
+
+	open, _, _ := newAgentCardServer(t, types.AgentCard{Name: "agent"})
+	modes, err = client.NewClient(open.URL).Negotiate(ctx, []string{"image/png"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"image/png"}, modes)
+}
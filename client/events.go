@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+)
+
+// TaskEvent is a typed event of a task stream. It is one of TaskStatusUpdate,
+// MessageDelta, ArtifactUpdate or StreamError.
+type TaskEvent interface {
+	taskEvent()
+}
+
+// TaskStatusUpdate reports a change in the status of the streamed task. Final is set
+// on the last status of the interaction.
+type TaskStatusUpdate struct {
+	types.TaskStatusUpdateEvent
+}
+
+// MessageDelta carries a message streamed by the agent, either a chunk of its
+// response or a complete message. Text holds the text of its parts.
+type MessageDelta struct {
+	TaskID    string
+	ContextID string
+	State     types.TaskState
+	Message   types.Message
+	Text      string
+}
+
+// ArtifactUpdate carries an artifact, or a chunk of one, produced by the streamed task
+type ArtifactUpdate struct {
+	types.TaskArtifactUpdateEvent
+}
+
+// StreamError is the last event of a stream that ended because of an error
+type StreamError struct {
+	Err error
+}
+
+func (TaskStatusUpdate) taskEvent() {}
+func (MessageDelta) taskEvent()     {}
+func (ArtifactUpdate) taskEvent()   {}
+func (StreamError) taskEvent()      {}
+
+// Error returns the message of the error that ended the stream
+func (e StreamError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error that ended the stream
+func (e StreamError) Unwrap() error {
+	return e.Err
+}
+
+// StreamTask sends a task via `message/stream` and returns a channel of typed events.
+// Task snapshots are delivered as MessageDelta, status updates as TaskStatusUpdate and
+// artifact updates as ArtifactUpdate. When the stream ends because of an error, a
+// StreamError is delivered before the channel is closed.
+func (c *Client) StreamTask(ctx context.Context, params types.MessageSendParams) (<-chan TaskEvent, error) {
+	stream, err := c.OpenTaskStream(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan TaskEvent, c.streamBufferSize())
+	go c.decodeTaskEvents(ctx, stream, events)
+
+	return events, nil
+}
+
+// decodeTaskEvents converts the responses of a stream into typed events until it ends
+func (c *Client) decodeTaskEvents(ctx context.Context, stream *TaskStream, events chan<- TaskEvent) {
+	defer close(events)
+
+	for response := range stream.Events() {
+		event, err := DecodeTaskEvent(response.Result)
+		if err != nil {
+			c.logger.Debug("skipping stream event", zap.Error(err))
+			continue
+		}
+		if !c.sendTaskEvent(ctx, events, event) {
+			if closeErr := stream.Close(); closeErr != nil {
+				c.logger.Debug("failed to close stream", zap.Error(closeErr))
+			}
+			return
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		c.sendTaskEvent(ctx, events, StreamError{Err: err})
+	}
+}
+
+// sendTaskEvent hands an event to the consumer, giving up when the context is cancelled
+// or the consumer has not received for longer than the send timeout
+func (c *Client) sendTaskEvent(ctx context.Context, events chan<- TaskEvent, event TaskEvent) bool {
+	var timeout <-chan time.Time
+	if c.config.StreamSendTimeout > 0 {
+		timer := time.NewTimer(c.config.StreamSendTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	case <-timeout:
+		c.logger.Warn("task event consumer stopped receiving events, closing stream",
+			zap.Duration("send_timeout", c.config.StreamSendTimeout))
+		return false
+	}
+}
+
+// DecodeTaskEvent converts the result of a streaming response into a typed event. It routes
+// on the "kind" of the result and falls back to its shape for servers that omit the kind.
+func DecodeTaskEvent(result any) (TaskEvent, error) {
+	if result == nil {
+		return nil, fmt.Errorf("stream event has no result")
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal stream event: %w", err)
+	}
+
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("failed to decode stream event: %w", err)
+	}
+
+	var kind string
+	if raw, exists := probe["kind"]; exists {
+		_ = json.Unmarshal(raw, &kind)
+	}
+	if kind == "" {
+		kind = streamEventKind(probe)
+	}
+
+	switch kind {
+	case types.StreamResultKindStatusUpdate:
+		var update types.TaskStatusUpdateEvent
+		if err := json.Unmarshal(data, &update); err != nil {
+			return nil, fmt.Errorf("failed to decode status update: %w", err)
+		}
+		return TaskStatusUpdate{TaskStatusUpdateEvent: update}, nil
+	case types.StreamResultKindArtifactUpdate:
+		var update types.TaskArtifactUpdateEvent
+		if err := json.Unmarshal(data, &update); err != nil {
+			return nil, fmt.Errorf("failed to decode artifact update: %w", err)
+		}
+		return ArtifactUpdate{TaskArtifactUpdateEvent: update}, nil
+	case types.StreamResultKindTask:
+		var task types.Task
+		if err := json.Unmarshal(data, &task); err != nil {
+			return nil, fmt.Errorf("failed to decode task: %w", err)
+		}
+		delta := MessageDelta{TaskID: task.ID, ContextID: task.ContextID, State: task.Status.State}
+		if task.Status.Message != nil {
+			delta.Message = *task.Status.Message
+			delta.Text = task.Status.Message.Text()
+		}
+		return delta, nil
+	case types.StreamResultKindMessage:
+		var message types.Message
+		if err := json.Unmarshal(data, &message); err != nil {
+			return nil, fmt.Errorf("failed to decode message: %w", err)
+		}
+		delta := MessageDelta{Message: message, Text: message.Text()}
+		if message.TaskID != nil {
+			delta.TaskID = *message.TaskID
+		}
+		if message.ContextID != nil {
+			delta.ContextID = *message.ContextID
+		}
+		return delta, nil
+	}
+
+	return nil, fmt.Errorf("unknown stream event kind '%s'", kind)
+}
+
+// streamEventKind returns the kind implied by the fields of an untagged stream event
+func streamEventKind(probe map[string]json.RawMessage) string {
+	_, hasArtifact := probe["artifact"]
+	_, hasStatus := probe["status"]
+	_, hasTaskID := probe["taskId"]
+	_, hasID := probe["id"]
+	_, hasMessageID := probe["messageId"]
+
+	switch {
+	case hasArtifact:
+		return types.StreamResultKindArtifactUpdate
+	case hasStatus && hasTaskID:
+		return types.StreamResultKindStatusUpdate
+	case hasStatus && hasID:
+		return types.StreamResultKindTask
+	case hasMessageID:
+		return types.StreamResultKindMessage
+	}
+	return ""
+}
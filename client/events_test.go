@@ -0,0 +1,168 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/inference-gateway/adk/client"
+	types "github.com/inference-gateway/adk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeTaskEvent(t *testing.T) {
+	lastChunk := true
+	taskID := "task-1"
+
+	tests := []struct {
+		name     string
+		result   any
+		expected client.TaskEvent
+		errText  string
+	}{
+		{
+			name: "task snapshot",
+			result: map[string]any{
+				"kind": "task", "id": "task-1", "contextId": "ctx-1",
+				"status": map[string]any{"state": "TASK_STATE_WORKING", "message": map[string]any{
+					"kind": "message", "messageId": "msg-1", "role": "ROLE_AGENT",
+					"parts": []any{map[string]any{"text": "Hel"}, map[string]any{"text": "lo"}},
+				}},
+			},
+			expected: client.MessageDelta{
+				TaskID:    "task-1",
+				ContextID: "ctx-1",
+				State:     types.TaskStateWorking,
+				Message:   types.Message{MessageID: "msg-1", Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart("Hel"), types.CreateTextPart("lo")}},
+				Text:      "Hello",
+			},
+		},
+		{
+			name:   "message",
+			result: map[string]any{"kind": "message", "messageId": "msg-2", "role": "ROLE_AGENT", "taskId": "task-1", "parts": []any{map[string]any{"text": "hi"}}},
+			expected: client.MessageDelta{
+				TaskID:  "task-1",
+				Message: types.Message{MessageID: "msg-2", Role: types.RoleAgent, TaskID: &taskID, Parts: []types.Part{types.CreateTextPart("hi")}},
+				Text:    "hi",
+			},
+		},
+		{
+			name:   "status update",
+			result: map[string]any{"kind": "status-update", "taskId": "task-1", "contextId": "ctx-1", "final": true, "status": map[string]any{"state": "TASK_STATE_COMPLETED"}},
+			expected: client.TaskStatusUpdate{TaskStatusUpdateEvent: types.TaskStatusUpdateEvent{
+				TaskID:    "task-1",
+				ContextID: "ctx-1",
+				Final:     true,
+				Status:    types.TaskStatus{State: types.TaskStateCompleted},
+			}},
+		},
+		{
+			name: "artifact update",
+			result: map[string]any{
+				"kind": "artifact-update", "taskId": "task-1", "contextId": "ctx-1", "lastChunk": true,
+				"artifact": map[string]any{"artifactId": "artifact-1", "parts": []any{map[string]any{"text": "report"}}},
+			},
+			expected: client.ArtifactUpdate{TaskArtifactUpdateEvent: types.TaskArtifactUpdateEvent{
+				TaskID:    "task-1",
+				ContextID: "ctx-1",
+				LastChunk: &lastChunk,
+				Artifact:  types.Artifact{ArtifactID: "artifact-1", Parts: []types.Part{types.CreateTextPart("report")}},
+			}},
+		},
+		{
+			name:   "untagged status update",
+			result: map[string]any{"taskId": "task-1", "contextId": "ctx-1", "status": map[string]any{"state": "TASK_STATE_WORKING"}},
+			expected: client.TaskStatusUpdate{TaskStatusUpdateEvent: types.TaskStatusUpdateEvent{
+				TaskID:    "task-1",
+				ContextID: "ctx-1",
+				Status:    types.TaskStatus{State: types.TaskStateWorking},
+			}},
+		},
+		{
+			name:    "unknown kind",
+			result:  map[string]any{"kind": "custom"},
+			errText: "unknown stream event kind 'custom'",
+		},
+		{
+			name:    "no result",
+			errText: "stream event has no result",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := client.DecodeTaskEvent(tt.result)
+			if tt.errText != "" {
+				assert.ErrorContains(t, err, tt.errText)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, event)
+		})
+	}
+}
+
+func TestClient_StreamTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		results := []string{
+			`{"kind":"status-update","taskId":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_WORKING"},"final":false}`,
+			`{"kind":"task","id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_WORKING","message":{"messageId":"delta-1","role":"ROLE_AGENT","parts":[{"text":"Hello"}]}}}`,
+			`{"kind":"custom"}`,
+			`{"kind":"artifact-update","taskId":"task-1","contextId":"ctx-1","artifact":{"artifactId":"artifact-1","parts":[{"text":"report"}]}}`,
+			`{"kind":"status-update","taskId":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_COMPLETED"},"final":true}`,
+		}
+		for _, result := range results {
+			_, _ = fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":\"1\",\"result\":%s}\n\n", result)
+		}
+		_, _ = fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	t.Cleanup(server.Close)
+
+	events, err := client.NewClient(server.URL).StreamTask(context.Background(), newStreamParams())
+	require.NoError(t, err)
+
+	var received []client.TaskEvent
+	for event := range events {
+		received = append(received, event)
+	}
+
+	require.Len(t, received, 4)
+	require.IsType(t, client.TaskStatusUpdate{}, received[0])
+	assert.Equal(t, types.TaskStateWorking, received[0].(client.TaskStatusUpdate).Status.State)
+	require.IsType(t, client.MessageDelta{}, received[1])
+	assert.Equal(t, "Hello", received[1].(client.MessageDelta).Text)
+	require.IsType(t, client.ArtifactUpdate{}, received[2])
+	assert.Equal(t, "artifact-1", received[2].(client.ArtifactUpdate).Artifact.ArtifactID)
+	require.IsType(t, client.TaskStatusUpdate{}, received[3])
+	assert.True(t, received[3].(client.TaskStatusUpdate).Final)
+}
+
+func TestClient_StreamTask_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, "data: {\"jsonrpc\":\"2.0\",\"id\":\"1\",\"result\":{\"kind\":\"status-update\",\"taskId\":\"task-1\",\"status\":{\"state\":\"TASK_STATE_WORKING\"}}}\n\n")
+		_, _ = fmt.Fprint(w, "data: {not json}\n\n")
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := client.NewClient(server.URL).StreamTask(ctx, newStreamParams())
+	require.NoError(t, err)
+
+	var received []client.TaskEvent
+	for event := range events {
+		received = append(received, event)
+	}
+
+	require.Len(t, received, 2)
+	assert.IsType(t, client.TaskStatusUpdate{}, received[0])
+	require.IsType(t, client.StreamError{}, received[1])
+	assert.ErrorContains(t, received[1].(client.StreamError), "failed to decode event")
+}
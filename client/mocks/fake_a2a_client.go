@@ -13,6 +13,21 @@ import (
 )
 
 type FakeA2AClient struct {
+	CallStub        func(context.Context, string, any) (*types.JSONRPCSuccessResponse, error)
+	callMutex       sync.RWMutex
+	callArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 any
+	}
+	callReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	callReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
 	CancelTaskStub        func(context.Context, types.TaskIdParams) (*types.JSONRPCSuccessResponse, error)
 	cancelTaskMutex       sync.RWMutex
 	cancelTaskArgsForCall []struct {
@@ -27,6 +42,62 @@ type FakeA2AClient struct {
 		result1 *types.JSONRPCSuccessResponse
 		result2 error
 	}
+	CreateContextStub        func(context.Context, types.ContextCreateParams) (*types.JSONRPCSuccessResponse, error)
+	createContextMutex       sync.RWMutex
+	createContextArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.ContextCreateParams
+	}
+	createContextReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	createContextReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	CreateMemoryStub        func(context.Context, types.MemoryCreateParams) (*types.JSONRPCSuccessResponse, error)
+	createMemoryMutex       sync.RWMutex
+	createMemoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.MemoryCreateParams
+	}
+	createMemoryReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	createMemoryReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	DeleteContextStub        func(context.Context, types.ContextIdParams) (*types.JSONRPCSuccessResponse, error)
+	deleteContextMutex       sync.RWMutex
+	deleteContextArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.ContextIdParams
+	}
+	deleteContextReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	deleteContextReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	DeleteMemoryStub        func(context.Context, types.MemoryIdParams) (*types.JSONRPCSuccessResponse, error)
+	deleteMemoryMutex       sync.RWMutex
+	deleteMemoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.MemoryIdParams
+	}
+	deleteMemoryReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	deleteMemoryReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
 	DeleteTaskPushNotificationConfigStub        func(context.Context, types.DeleteTaskPushNotificationConfigParams) (*types.JSONRPCSuccessResponse, error)
 	deleteTaskPushNotificationConfigMutex       sync.RWMutex
 	deleteTaskPushNotificationConfigArgsForCall []struct {
@@ -41,6 +112,20 @@ type FakeA2AClient struct {
 		result1 *types.JSONRPCSuccessResponse
 		result2 error
 	}
+	ForkTaskStub        func(context.Context, types.TaskForkParams) (*types.JSONRPCSuccessResponse, error)
+	forkTaskMutex       sync.RWMutex
+	forkTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.TaskForkParams
+	}
+	forkTaskReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	forkTaskReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
 	GetAgentCardStub        func(context.Context) (*types.AgentCard, error)
 	getAgentCardMutex       sync.RWMutex
 	getAgentCardArgsForCall []struct {
@@ -88,6 +173,34 @@ type FakeA2AClient struct {
 	getBaseURLReturnsOnCall map[int]struct {
 		result1 string
 	}
+	GetContextStub        func(context.Context, types.ContextIdParams) (*types.JSONRPCSuccessResponse, error)
+	getContextMutex       sync.RWMutex
+	getContextArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.ContextIdParams
+	}
+	getContextReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	getContextReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	GetContextUsageStub        func(context.Context, types.ContextUsageParams) (*types.JSONRPCSuccessResponse, error)
+	getContextUsageMutex       sync.RWMutex
+	getContextUsageArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.ContextUsageParams
+	}
+	getContextUsageReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	getContextUsageReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
 	GetHealthStub        func(context.Context) (*client.HealthResponse, error)
 	getHealthMutex       sync.RWMutex
 	getHealthArgsForCall []struct {
@@ -111,6 +224,34 @@ type FakeA2AClient struct {
 	getLoggerReturnsOnCall map[int]struct {
 		result1 *zap.Logger
 	}
+	GetMemoryStub        func(context.Context, types.MemoryIdParams) (*types.JSONRPCSuccessResponse, error)
+	getMemoryMutex       sync.RWMutex
+	getMemoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.MemoryIdParams
+	}
+	getMemoryReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	getMemoryReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	GetSharedTranscriptStub        func(context.Context, string) (*types.SharedTranscript, error)
+	getSharedTranscriptMutex       sync.RWMutex
+	getSharedTranscriptArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getSharedTranscriptReturns struct {
+		result1 *types.SharedTranscript
+		result2 error
+	}
+	getSharedTranscriptReturnsOnCall map[int]struct {
+		result1 *types.SharedTranscript
+		result2 error
+	}
 	GetTaskStub        func(context.Context, types.TaskQueryParams) (*types.JSONRPCSuccessResponse, error)
 	getTaskMutex       sync.RWMutex
 	getTaskArgsForCall []struct {
@@ -139,6 +280,48 @@ type FakeA2AClient struct {
 		result1 *types.JSONRPCSuccessResponse
 		result2 error
 	}
+	ListArtifactsStub        func(context.Context, types.ArtifactListParams) (*types.JSONRPCSuccessResponse, error)
+	listArtifactsMutex       sync.RWMutex
+	listArtifactsArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.ArtifactListParams
+	}
+	listArtifactsReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	listArtifactsReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	ListContextsStub        func(context.Context, types.ContextListParams) (*types.JSONRPCSuccessResponse, error)
+	listContextsMutex       sync.RWMutex
+	listContextsArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.ContextListParams
+	}
+	listContextsReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	listContextsReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	ListMemoriesStub        func(context.Context, types.MemoryListParams) (*types.JSONRPCSuccessResponse, error)
+	listMemoriesMutex       sync.RWMutex
+	listMemoriesArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.MemoryListParams
+	}
+	listMemoriesReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	listMemoriesReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
 	ListTaskPushNotificationConfigStub        func(context.Context, types.ListTaskPushNotificationConfigParams) (*types.JSONRPCSuccessResponse, error)
 	listTaskPushNotificationConfigMutex       sync.RWMutex
 	listTaskPushNotificationConfigArgsForCall []struct {
@@ -167,6 +350,48 @@ type FakeA2AClient struct {
 		result1 *types.JSONRPCSuccessResponse
 		result2 error
 	}
+	NegotiateStub        func(context.Context, []string) ([]string, error)
+	negotiateMutex       sync.RWMutex
+	negotiateArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	negotiateReturns struct {
+		result1 []string
+		result2 error
+	}
+	negotiateReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	OpenResubscribeStreamStub        func(context.Context, types.TaskResubscriptionParams) (*client.TaskStream, error)
+	openResubscribeStreamMutex       sync.RWMutex
+	openResubscribeStreamArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.TaskResubscriptionParams
+	}
+	openResubscribeStreamReturns struct {
+		result1 *client.TaskStream
+		result2 error
+	}
+	openResubscribeStreamReturnsOnCall map[int]struct {
+		result1 *client.TaskStream
+		result2 error
+	}
+	OpenTaskStreamStub        func(context.Context, types.MessageSendParams) (*client.TaskStream, error)
+	openTaskStreamMutex       sync.RWMutex
+	openTaskStreamArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.MessageSendParams
+	}
+	openTaskStreamReturns struct {
+		result1 *client.TaskStream
+		result2 error
+	}
+	openTaskStreamReturnsOnCall map[int]struct {
+		result1 *client.TaskStream
+		result2 error
+	}
 	ResubscribeTaskStub        func(context.Context, types.TaskResubscriptionParams) (<-chan types.JSONRPCSuccessResponse, error)
 	resubscribeTaskMutex       sync.RWMutex
 	resubscribeTaskArgsForCall []struct {
@@ -181,6 +406,21 @@ type FakeA2AClient struct {
 		result1 <-chan types.JSONRPCSuccessResponse
 		result2 error
 	}
+	SendAndWaitStub        func(context.Context, types.MessageSendParams, *client.WaitOptions) (*types.Task, error)
+	sendAndWaitMutex       sync.RWMutex
+	sendAndWaitArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.MessageSendParams
+		arg3 *client.WaitOptions
+	}
+	sendAndWaitReturns struct {
+		result1 *types.Task
+		result2 error
+	}
+	sendAndWaitReturnsOnCall map[int]struct {
+		result1 *types.Task
+		result2 error
+	}
 	SendTaskStub        func(context.Context, types.MessageSendParams) (*types.JSONRPCSuccessResponse, error)
 	sendTaskMutex       sync.RWMutex
 	sendTaskArgsForCall []struct {
@@ -195,6 +435,20 @@ type FakeA2AClient struct {
 		result1 *types.JSONRPCSuccessResponse
 		result2 error
 	}
+	SendTaskBatchStub        func(context.Context, types.MessageSendBatchParams) (*types.JSONRPCSuccessResponse, error)
+	sendTaskBatchMutex       sync.RWMutex
+	sendTaskBatchArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.MessageSendBatchParams
+	}
+	sendTaskBatchReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	sendTaskBatchReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
 	SendTaskStreamingStub        func(context.Context, types.MessageSendParams) (<-chan types.JSONRPCSuccessResponse, error)
 	sendTaskStreamingMutex       sync.RWMutex
 	sendTaskStreamingArgsForCall []struct {
@@ -238,10 +492,145 @@ type FakeA2AClient struct {
 	setTimeoutArgsForCall []struct {
 		arg1 time.Duration
 	}
+	ShareTaskStub        func(context.Context, types.TaskShareParams) (*types.JSONRPCSuccessResponse, error)
+	shareTaskMutex       sync.RWMutex
+	shareTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.TaskShareParams
+	}
+	shareTaskReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	shareTaskReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	StreamTaskStub        func(context.Context, types.MessageSendParams) (<-chan client.TaskEvent, error)
+	streamTaskMutex       sync.RWMutex
+	streamTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.MessageSendParams
+	}
+	streamTaskReturns struct {
+		result1 <-chan client.TaskEvent
+		result2 error
+	}
+	streamTaskReturnsOnCall map[int]struct {
+		result1 <-chan client.TaskEvent
+		result2 error
+	}
+	SubmitTaskFeedbackStub        func(context.Context, types.TaskFeedbackParams) (*types.JSONRPCSuccessResponse, error)
+	submitTaskFeedbackMutex       sync.RWMutex
+	submitTaskFeedbackArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.TaskFeedbackParams
+	}
+	submitTaskFeedbackReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	submitTaskFeedbackReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	SupportsStreamingStub        func(context.Context) (bool, error)
+	supportsStreamingMutex       sync.RWMutex
+	supportsStreamingArgsForCall []struct {
+		arg1 context.Context
+	}
+	supportsStreamingReturns struct {
+		result1 bool
+		result2 error
+	}
+	supportsStreamingReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	UpdateMemoryStub        func(context.Context, types.MemoryUpdateParams) (*types.JSONRPCSuccessResponse, error)
+	updateMemoryMutex       sync.RWMutex
+	updateMemoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.MemoryUpdateParams
+	}
+	updateMemoryReturns struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
+	updateMemoryReturnsOnCall map[int]struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeA2AClient) Call(arg1 context.Context, arg2 string, arg3 any) (*types.JSONRPCSuccessResponse, error) {
+	fake.callMutex.Lock()
+	ret, specificReturn := fake.callReturnsOnCall[len(fake.callArgsForCall)]
+	fake.callArgsForCall = append(fake.callArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 any
+	}{arg1, arg2, arg3})
+	stub := fake.CallStub
+	fakeReturns := fake.callReturns
+	fake.recordInvocation("Call", []interface{}{arg1, arg2, arg3})
+	fake.callMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) CallCallCount() int {
+	fake.callMutex.RLock()
+	defer fake.callMutex.RUnlock()
+	return len(fake.callArgsForCall)
+}
+
+func (fake *FakeA2AClient) CallCalls(stub func(context.Context, string, any) (*types.JSONRPCSuccessResponse, error)) {
+	fake.callMutex.Lock()
+	defer fake.callMutex.Unlock()
+	fake.CallStub = stub
+}
+
+func (fake *FakeA2AClient) CallArgsForCall(i int) (context.Context, string, any) {
+	fake.callMutex.RLock()
+	defer fake.callMutex.RUnlock()
+	argsForCall := fake.callArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeA2AClient) CallReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.callMutex.Lock()
+	defer fake.callMutex.Unlock()
+	fake.CallStub = nil
+	fake.callReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) CallReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.callMutex.Lock()
+	defer fake.callMutex.Unlock()
+	fake.CallStub = nil
+	if fake.callReturnsOnCall == nil {
+		fake.callReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.callReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeA2AClient) CancelTask(arg1 context.Context, arg2 types.TaskIdParams) (*types.JSONRPCSuccessResponse, error) {
 	fake.cancelTaskMutex.Lock()
 	ret, specificReturn := fake.cancelTaskReturnsOnCall[len(fake.cancelTaskArgsForCall)]
@@ -307,17 +696,17 @@ func (fake *FakeA2AClient) CancelTaskReturnsOnCall(i int, result1 *types.JSONRPC
 	}{result1, result2}
 }
 
-func (fake *FakeA2AClient) DeleteTaskPushNotificationConfig(arg1 context.Context, arg2 types.DeleteTaskPushNotificationConfigParams) (*types.JSONRPCSuccessResponse, error) {
-	fake.deleteTaskPushNotificationConfigMutex.Lock()
-	ret, specificReturn := fake.deleteTaskPushNotificationConfigReturnsOnCall[len(fake.deleteTaskPushNotificationConfigArgsForCall)]
-	fake.deleteTaskPushNotificationConfigArgsForCall = append(fake.deleteTaskPushNotificationConfigArgsForCall, struct {
+func (fake *FakeA2AClient) CreateContext(arg1 context.Context, arg2 types.ContextCreateParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.createContextMutex.Lock()
+	ret, specificReturn := fake.createContextReturnsOnCall[len(fake.createContextArgsForCall)]
+	fake.createContextArgsForCall = append(fake.createContextArgsForCall, struct {
 		arg1 context.Context
-		arg2 types.DeleteTaskPushNotificationConfigParams
+		arg2 types.ContextCreateParams
 	}{arg1, arg2})
-	stub := fake.DeleteTaskPushNotificationConfigStub
-	fakeReturns := fake.deleteTaskPushNotificationConfigReturns
-	fake.recordInvocation("DeleteTaskPushNotificationConfig", []interface{}{arg1, arg2})
-	fake.deleteTaskPushNotificationConfigMutex.Unlock()
+	stub := fake.CreateContextStub
+	fakeReturns := fake.createContextReturns
+	fake.recordInvocation("CreateContext", []interface{}{arg1, arg2})
+	fake.createContextMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -327,63 +716,64 @@ func (fake *FakeA2AClient) DeleteTaskPushNotificationConfig(arg1 context.Context
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeA2AClient) DeleteTaskPushNotificationConfigCallCount() int {
-	fake.deleteTaskPushNotificationConfigMutex.RLock()
-	defer fake.deleteTaskPushNotificationConfigMutex.RUnlock()
-	return len(fake.deleteTaskPushNotificationConfigArgsForCall)
+func (fake *FakeA2AClient) CreateContextCallCount() int {
+	fake.createContextMutex.RLock()
+	defer fake.createContextMutex.RUnlock()
+	return len(fake.createContextArgsForCall)
 }
 
-func (fake *FakeA2AClient) DeleteTaskPushNotificationConfigCalls(stub func(context.Context, types.DeleteTaskPushNotificationConfigParams) (*types.JSONRPCSuccessResponse, error)) {
-	fake.deleteTaskPushNotificationConfigMutex.Lock()
-	defer fake.deleteTaskPushNotificationConfigMutex.Unlock()
-	fake.DeleteTaskPushNotificationConfigStub = stub
+func (fake *FakeA2AClient) CreateContextCalls(stub func(context.Context, types.ContextCreateParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.createContextMutex.Lock()
+	defer fake.createContextMutex.Unlock()
+	fake.CreateContextStub = stub
 }
 
-func (fake *FakeA2AClient) DeleteTaskPushNotificationConfigArgsForCall(i int) (context.Context, types.DeleteTaskPushNotificationConfigParams) {
-	fake.deleteTaskPushNotificationConfigMutex.RLock()
-	defer fake.deleteTaskPushNotificationConfigMutex.RUnlock()
-	argsForCall := fake.deleteTaskPushNotificationConfigArgsForCall[i]
+func (fake *FakeA2AClient) CreateContextArgsForCall(i int) (context.Context, types.ContextCreateParams) {
+	fake.createContextMutex.RLock()
+	defer fake.createContextMutex.RUnlock()
+	argsForCall := fake.createContextArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeA2AClient) DeleteTaskPushNotificationConfigReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
-	fake.deleteTaskPushNotificationConfigMutex.Lock()
-	defer fake.deleteTaskPushNotificationConfigMutex.Unlock()
-	fake.DeleteTaskPushNotificationConfigStub = nil
-	fake.deleteTaskPushNotificationConfigReturns = struct {
+func (fake *FakeA2AClient) CreateContextReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.createContextMutex.Lock()
+	defer fake.createContextMutex.Unlock()
+	fake.CreateContextStub = nil
+	fake.createContextReturns = struct {
 		result1 *types.JSONRPCSuccessResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeA2AClient) DeleteTaskPushNotificationConfigReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
-	fake.deleteTaskPushNotificationConfigMutex.Lock()
-	defer fake.deleteTaskPushNotificationConfigMutex.Unlock()
-	fake.DeleteTaskPushNotificationConfigStub = nil
-	if fake.deleteTaskPushNotificationConfigReturnsOnCall == nil {
-		fake.deleteTaskPushNotificationConfigReturnsOnCall = make(map[int]struct {
+func (fake *FakeA2AClient) CreateContextReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.createContextMutex.Lock()
+	defer fake.createContextMutex.Unlock()
+	fake.CreateContextStub = nil
+	if fake.createContextReturnsOnCall == nil {
+		fake.createContextReturnsOnCall = make(map[int]struct {
 			result1 *types.JSONRPCSuccessResponse
 			result2 error
 		})
 	}
-	fake.deleteTaskPushNotificationConfigReturnsOnCall[i] = struct {
+	fake.createContextReturnsOnCall[i] = struct {
 		result1 *types.JSONRPCSuccessResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeA2AClient) GetAgentCard(arg1 context.Context) (*types.AgentCard, error) {
-	fake.getAgentCardMutex.Lock()
-	ret, specificReturn := fake.getAgentCardReturnsOnCall[len(fake.getAgentCardArgsForCall)]
-	fake.getAgentCardArgsForCall = append(fake.getAgentCardArgsForCall, struct {
+func (fake *FakeA2AClient) CreateMemory(arg1 context.Context, arg2 types.MemoryCreateParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.createMemoryMutex.Lock()
+	ret, specificReturn := fake.createMemoryReturnsOnCall[len(fake.createMemoryArgsForCall)]
+	fake.createMemoryArgsForCall = append(fake.createMemoryArgsForCall, struct {
 		arg1 context.Context
-	}{arg1})
-	stub := fake.GetAgentCardStub
-	fakeReturns := fake.getAgentCardReturns
-	fake.recordInvocation("GetAgentCard", []interface{}{arg1})
-	fake.getAgentCardMutex.Unlock()
+		arg2 types.MemoryCreateParams
+	}{arg1, arg2})
+	stub := fake.CreateMemoryStub
+	fakeReturns := fake.createMemoryReturns
+	fake.recordInvocation("CreateMemory", []interface{}{arg1, arg2})
+	fake.createMemoryMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -391,16 +781,340 @@ func (fake *FakeA2AClient) GetAgentCard(arg1 context.Context) (*types.AgentCard,
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeA2AClient) GetAgentCardCallCount() int {
-	fake.getAgentCardMutex.RLock()
-	defer fake.getAgentCardMutex.RUnlock()
-	return len(fake.getAgentCardArgsForCall)
+func (fake *FakeA2AClient) CreateMemoryCallCount() int {
+	fake.createMemoryMutex.RLock()
+	defer fake.createMemoryMutex.RUnlock()
+	return len(fake.createMemoryArgsForCall)
 }
 
-func (fake *FakeA2AClient) GetAgentCardCalls(stub func(context.Context) (*types.AgentCard, error)) {
-	fake.getAgentCardMutex.Lock()
-	defer fake.getAgentCardMutex.Unlock()
-	fake.GetAgentCardStub = stub
+func (fake *FakeA2AClient) CreateMemoryCalls(stub func(context.Context, types.MemoryCreateParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.createMemoryMutex.Lock()
+	defer fake.createMemoryMutex.Unlock()
+	fake.CreateMemoryStub = stub
+}
+
+func (fake *FakeA2AClient) CreateMemoryArgsForCall(i int) (context.Context, types.MemoryCreateParams) {
+	fake.createMemoryMutex.RLock()
+	defer fake.createMemoryMutex.RUnlock()
+	argsForCall := fake.createMemoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) CreateMemoryReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.createMemoryMutex.Lock()
+	defer fake.createMemoryMutex.Unlock()
+	fake.CreateMemoryStub = nil
+	fake.createMemoryReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) CreateMemoryReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.createMemoryMutex.Lock()
+	defer fake.createMemoryMutex.Unlock()
+	fake.CreateMemoryStub = nil
+	if fake.createMemoryReturnsOnCall == nil {
+		fake.createMemoryReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.createMemoryReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) DeleteContext(arg1 context.Context, arg2 types.ContextIdParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.deleteContextMutex.Lock()
+	ret, specificReturn := fake.deleteContextReturnsOnCall[len(fake.deleteContextArgsForCall)]
+	fake.deleteContextArgsForCall = append(fake.deleteContextArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.ContextIdParams
+	}{arg1, arg2})
+	stub := fake.DeleteContextStub
+	fakeReturns := fake.deleteContextReturns
+	fake.recordInvocation("DeleteContext", []interface{}{arg1, arg2})
+	fake.deleteContextMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) DeleteContextCallCount() int {
+	fake.deleteContextMutex.RLock()
+	defer fake.deleteContextMutex.RUnlock()
+	return len(fake.deleteContextArgsForCall)
+}
+
+func (fake *FakeA2AClient) DeleteContextCalls(stub func(context.Context, types.ContextIdParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.deleteContextMutex.Lock()
+	defer fake.deleteContextMutex.Unlock()
+	fake.DeleteContextStub = stub
+}
+
+func (fake *FakeA2AClient) DeleteContextArgsForCall(i int) (context.Context, types.ContextIdParams) {
+	fake.deleteContextMutex.RLock()
+	defer fake.deleteContextMutex.RUnlock()
+	argsForCall := fake.deleteContextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) DeleteContextReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.deleteContextMutex.Lock()
+	defer fake.deleteContextMutex.Unlock()
+	fake.DeleteContextStub = nil
+	fake.deleteContextReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) DeleteContextReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.deleteContextMutex.Lock()
+	defer fake.deleteContextMutex.Unlock()
+	fake.DeleteContextStub = nil
+	if fake.deleteContextReturnsOnCall == nil {
+		fake.deleteContextReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.deleteContextReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) DeleteMemory(arg1 context.Context, arg2 types.MemoryIdParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.deleteMemoryMutex.Lock()
+	ret, specificReturn := fake.deleteMemoryReturnsOnCall[len(fake.deleteMemoryArgsForCall)]
+	fake.deleteMemoryArgsForCall = append(fake.deleteMemoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.MemoryIdParams
+	}{arg1, arg2})
+	stub := fake.DeleteMemoryStub
+	fakeReturns := fake.deleteMemoryReturns
+	fake.recordInvocation("DeleteMemory", []interface{}{arg1, arg2})
+	fake.deleteMemoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) DeleteMemoryCallCount() int {
+	fake.deleteMemoryMutex.RLock()
+	defer fake.deleteMemoryMutex.RUnlock()
+	return len(fake.deleteMemoryArgsForCall)
+}
+
+func (fake *FakeA2AClient) DeleteMemoryCalls(stub func(context.Context, types.MemoryIdParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.deleteMemoryMutex.Lock()
+	defer fake.deleteMemoryMutex.Unlock()
+	fake.DeleteMemoryStub = stub
+}
+
+func (fake *FakeA2AClient) DeleteMemoryArgsForCall(i int) (context.Context, types.MemoryIdParams) {
+	fake.deleteMemoryMutex.RLock()
+	defer fake.deleteMemoryMutex.RUnlock()
+	argsForCall := fake.deleteMemoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) DeleteMemoryReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.deleteMemoryMutex.Lock()
+	defer fake.deleteMemoryMutex.Unlock()
+	fake.DeleteMemoryStub = nil
+	fake.deleteMemoryReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) DeleteMemoryReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.deleteMemoryMutex.Lock()
+	defer fake.deleteMemoryMutex.Unlock()
+	fake.DeleteMemoryStub = nil
+	if fake.deleteMemoryReturnsOnCall == nil {
+		fake.deleteMemoryReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.deleteMemoryReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) DeleteTaskPushNotificationConfig(arg1 context.Context, arg2 types.DeleteTaskPushNotificationConfigParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.deleteTaskPushNotificationConfigMutex.Lock()
+	ret, specificReturn := fake.deleteTaskPushNotificationConfigReturnsOnCall[len(fake.deleteTaskPushNotificationConfigArgsForCall)]
+	fake.deleteTaskPushNotificationConfigArgsForCall = append(fake.deleteTaskPushNotificationConfigArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.DeleteTaskPushNotificationConfigParams
+	}{arg1, arg2})
+	stub := fake.DeleteTaskPushNotificationConfigStub
+	fakeReturns := fake.deleteTaskPushNotificationConfigReturns
+	fake.recordInvocation("DeleteTaskPushNotificationConfig", []interface{}{arg1, arg2})
+	fake.deleteTaskPushNotificationConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) DeleteTaskPushNotificationConfigCallCount() int {
+	fake.deleteTaskPushNotificationConfigMutex.RLock()
+	defer fake.deleteTaskPushNotificationConfigMutex.RUnlock()
+	return len(fake.deleteTaskPushNotificationConfigArgsForCall)
+}
+
+func (fake *FakeA2AClient) DeleteTaskPushNotificationConfigCalls(stub func(context.Context, types.DeleteTaskPushNotificationConfigParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.deleteTaskPushNotificationConfigMutex.Lock()
+	defer fake.deleteTaskPushNotificationConfigMutex.Unlock()
+	fake.DeleteTaskPushNotificationConfigStub = stub
+}
+
+func (fake *FakeA2AClient) DeleteTaskPushNotificationConfigArgsForCall(i int) (context.Context, types.DeleteTaskPushNotificationConfigParams) {
+	fake.deleteTaskPushNotificationConfigMutex.RLock()
+	defer fake.deleteTaskPushNotificationConfigMutex.RUnlock()
+	argsForCall := fake.deleteTaskPushNotificationConfigArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) DeleteTaskPushNotificationConfigReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.deleteTaskPushNotificationConfigMutex.Lock()
+	defer fake.deleteTaskPushNotificationConfigMutex.Unlock()
+	fake.DeleteTaskPushNotificationConfigStub = nil
+	fake.deleteTaskPushNotificationConfigReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) DeleteTaskPushNotificationConfigReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.deleteTaskPushNotificationConfigMutex.Lock()
+	defer fake.deleteTaskPushNotificationConfigMutex.Unlock()
+	fake.DeleteTaskPushNotificationConfigStub = nil
+	if fake.deleteTaskPushNotificationConfigReturnsOnCall == nil {
+		fake.deleteTaskPushNotificationConfigReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.deleteTaskPushNotificationConfigReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) ForkTask(arg1 context.Context, arg2 types.TaskForkParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.forkTaskMutex.Lock()
+	ret, specificReturn := fake.forkTaskReturnsOnCall[len(fake.forkTaskArgsForCall)]
+	fake.forkTaskArgsForCall = append(fake.forkTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.TaskForkParams
+	}{arg1, arg2})
+	stub := fake.ForkTaskStub
+	fakeReturns := fake.forkTaskReturns
+	fake.recordInvocation("ForkTask", []interface{}{arg1, arg2})
+	fake.forkTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) ForkTaskCallCount() int {
+	fake.forkTaskMutex.RLock()
+	defer fake.forkTaskMutex.RUnlock()
+	return len(fake.forkTaskArgsForCall)
+}
+
+func (fake *FakeA2AClient) ForkTaskCalls(stub func(context.Context, types.TaskForkParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.forkTaskMutex.Lock()
+	defer fake.forkTaskMutex.Unlock()
+	fake.ForkTaskStub = stub
+}
+
+func (fake *FakeA2AClient) ForkTaskArgsForCall(i int) (context.Context, types.TaskForkParams) {
+	fake.forkTaskMutex.RLock()
+	defer fake.forkTaskMutex.RUnlock()
+	argsForCall := fake.forkTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) ForkTaskReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.forkTaskMutex.Lock()
+	defer fake.forkTaskMutex.Unlock()
+	fake.ForkTaskStub = nil
+	fake.forkTaskReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) ForkTaskReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.forkTaskMutex.Lock()
+	defer fake.forkTaskMutex.Unlock()
+	fake.ForkTaskStub = nil
+	if fake.forkTaskReturnsOnCall == nil {
+		fake.forkTaskReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.forkTaskReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) GetAgentCard(arg1 context.Context) (*types.AgentCard, error) {
+	fake.getAgentCardMutex.Lock()
+	ret, specificReturn := fake.getAgentCardReturnsOnCall[len(fake.getAgentCardArgsForCall)]
+	fake.getAgentCardArgsForCall = append(fake.getAgentCardArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetAgentCardStub
+	fakeReturns := fake.getAgentCardReturns
+	fake.recordInvocation("GetAgentCard", []interface{}{arg1})
+	fake.getAgentCardMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) GetAgentCardCallCount() int {
+	fake.getAgentCardMutex.RLock()
+	defer fake.getAgentCardMutex.RUnlock()
+	return len(fake.getAgentCardArgsForCall)
+}
+
+func (fake *FakeA2AClient) GetAgentCardCalls(stub func(context.Context) (*types.AgentCard, error)) {
+	fake.getAgentCardMutex.Lock()
+	defer fake.getAgentCardMutex.Unlock()
+	fake.GetAgentCardStub = stub
 }
 
 func (fake *FakeA2AClient) GetAgentCardArgsForCall(i int) context.Context {
@@ -607,18 +1321,19 @@ func (fake *FakeA2AClient) GetBaseURLReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
-func (fake *FakeA2AClient) GetHealth(arg1 context.Context) (*client.HealthResponse, error) {
-	fake.getHealthMutex.Lock()
-	ret, specificReturn := fake.getHealthReturnsOnCall[len(fake.getHealthArgsForCall)]
-	fake.getHealthArgsForCall = append(fake.getHealthArgsForCall, struct {
+func (fake *FakeA2AClient) GetContext(arg1 context.Context, arg2 types.ContextIdParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.getContextMutex.Lock()
+	ret, specificReturn := fake.getContextReturnsOnCall[len(fake.getContextArgsForCall)]
+	fake.getContextArgsForCall = append(fake.getContextArgsForCall, struct {
 		arg1 context.Context
-	}{arg1})
-	stub := fake.GetHealthStub
-	fakeReturns := fake.getHealthReturns
-	fake.recordInvocation("GetHealth", []interface{}{arg1})
-	fake.getHealthMutex.Unlock()
+		arg2 types.ContextIdParams
+	}{arg1, arg2})
+	stub := fake.GetContextStub
+	fakeReturns := fake.getContextReturns
+	fake.recordInvocation("GetContext", []interface{}{arg1, arg2})
+	fake.getContextMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -626,54 +1341,183 @@ func (fake *FakeA2AClient) GetHealth(arg1 context.Context) (*client.HealthRespon
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeA2AClient) GetHealthCallCount() int {
-	fake.getHealthMutex.RLock()
-	defer fake.getHealthMutex.RUnlock()
-	return len(fake.getHealthArgsForCall)
+func (fake *FakeA2AClient) GetContextCallCount() int {
+	fake.getContextMutex.RLock()
+	defer fake.getContextMutex.RUnlock()
+	return len(fake.getContextArgsForCall)
 }
 
-func (fake *FakeA2AClient) GetHealthCalls(stub func(context.Context) (*client.HealthResponse, error)) {
-	fake.getHealthMutex.Lock()
-	defer fake.getHealthMutex.Unlock()
-	fake.GetHealthStub = stub
+func (fake *FakeA2AClient) GetContextCalls(stub func(context.Context, types.ContextIdParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.getContextMutex.Lock()
+	defer fake.getContextMutex.Unlock()
+	fake.GetContextStub = stub
 }
 
-func (fake *FakeA2AClient) GetHealthArgsForCall(i int) context.Context {
-	fake.getHealthMutex.RLock()
-	defer fake.getHealthMutex.RUnlock()
-	argsForCall := fake.getHealthArgsForCall[i]
-	return argsForCall.arg1
+func (fake *FakeA2AClient) GetContextArgsForCall(i int) (context.Context, types.ContextIdParams) {
+	fake.getContextMutex.RLock()
+	defer fake.getContextMutex.RUnlock()
+	argsForCall := fake.getContextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeA2AClient) GetHealthReturns(result1 *client.HealthResponse, result2 error) {
-	fake.getHealthMutex.Lock()
-	defer fake.getHealthMutex.Unlock()
-	fake.GetHealthStub = nil
-	fake.getHealthReturns = struct {
-		result1 *client.HealthResponse
+func (fake *FakeA2AClient) GetContextReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.getContextMutex.Lock()
+	defer fake.getContextMutex.Unlock()
+	fake.GetContextStub = nil
+	fake.getContextReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeA2AClient) GetHealthReturnsOnCall(i int, result1 *client.HealthResponse, result2 error) {
-	fake.getHealthMutex.Lock()
-	defer fake.getHealthMutex.Unlock()
-	fake.GetHealthStub = nil
-	if fake.getHealthReturnsOnCall == nil {
-		fake.getHealthReturnsOnCall = make(map[int]struct {
-			result1 *client.HealthResponse
+func (fake *FakeA2AClient) GetContextReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.getContextMutex.Lock()
+	defer fake.getContextMutex.Unlock()
+	fake.GetContextStub = nil
+	if fake.getContextReturnsOnCall == nil {
+		fake.getContextReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
 			result2 error
 		})
 	}
-	fake.getHealthReturnsOnCall[i] = struct {
-		result1 *client.HealthResponse
+	fake.getContextReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeA2AClient) GetLogger() *zap.Logger {
-	fake.getLoggerMutex.Lock()
-	ret, specificReturn := fake.getLoggerReturnsOnCall[len(fake.getLoggerArgsForCall)]
+func (fake *FakeA2AClient) GetContextUsage(arg1 context.Context, arg2 types.ContextUsageParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.getContextUsageMutex.Lock()
+	ret, specificReturn := fake.getContextUsageReturnsOnCall[len(fake.getContextUsageArgsForCall)]
+	fake.getContextUsageArgsForCall = append(fake.getContextUsageArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.ContextUsageParams
+	}{arg1, arg2})
+	stub := fake.GetContextUsageStub
+	fakeReturns := fake.getContextUsageReturns
+	fake.recordInvocation("GetContextUsage", []interface{}{arg1, arg2})
+	fake.getContextUsageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) GetContextUsageCallCount() int {
+	fake.getContextUsageMutex.RLock()
+	defer fake.getContextUsageMutex.RUnlock()
+	return len(fake.getContextUsageArgsForCall)
+}
+
+func (fake *FakeA2AClient) GetContextUsageCalls(stub func(context.Context, types.ContextUsageParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.getContextUsageMutex.Lock()
+	defer fake.getContextUsageMutex.Unlock()
+	fake.GetContextUsageStub = stub
+}
+
+func (fake *FakeA2AClient) GetContextUsageArgsForCall(i int) (context.Context, types.ContextUsageParams) {
+	fake.getContextUsageMutex.RLock()
+	defer fake.getContextUsageMutex.RUnlock()
+	argsForCall := fake.getContextUsageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) GetContextUsageReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.getContextUsageMutex.Lock()
+	defer fake.getContextUsageMutex.Unlock()
+	fake.GetContextUsageStub = nil
+	fake.getContextUsageReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) GetContextUsageReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.getContextUsageMutex.Lock()
+	defer fake.getContextUsageMutex.Unlock()
+	fake.GetContextUsageStub = nil
+	if fake.getContextUsageReturnsOnCall == nil {
+		fake.getContextUsageReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.getContextUsageReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) GetHealth(arg1 context.Context) (*client.HealthResponse, error) {
+	fake.getHealthMutex.Lock()
+	ret, specificReturn := fake.getHealthReturnsOnCall[len(fake.getHealthArgsForCall)]
+	fake.getHealthArgsForCall = append(fake.getHealthArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetHealthStub
+	fakeReturns := fake.getHealthReturns
+	fake.recordInvocation("GetHealth", []interface{}{arg1})
+	fake.getHealthMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) GetHealthCallCount() int {
+	fake.getHealthMutex.RLock()
+	defer fake.getHealthMutex.RUnlock()
+	return len(fake.getHealthArgsForCall)
+}
+
+func (fake *FakeA2AClient) GetHealthCalls(stub func(context.Context) (*client.HealthResponse, error)) {
+	fake.getHealthMutex.Lock()
+	defer fake.getHealthMutex.Unlock()
+	fake.GetHealthStub = stub
+}
+
+func (fake *FakeA2AClient) GetHealthArgsForCall(i int) context.Context {
+	fake.getHealthMutex.RLock()
+	defer fake.getHealthMutex.RUnlock()
+	argsForCall := fake.getHealthArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AClient) GetHealthReturns(result1 *client.HealthResponse, result2 error) {
+	fake.getHealthMutex.Lock()
+	defer fake.getHealthMutex.Unlock()
+	fake.GetHealthStub = nil
+	fake.getHealthReturns = struct {
+		result1 *client.HealthResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) GetHealthReturnsOnCall(i int, result1 *client.HealthResponse, result2 error) {
+	fake.getHealthMutex.Lock()
+	defer fake.getHealthMutex.Unlock()
+	fake.GetHealthStub = nil
+	if fake.getHealthReturnsOnCall == nil {
+		fake.getHealthReturnsOnCall = make(map[int]struct {
+			result1 *client.HealthResponse
+			result2 error
+		})
+	}
+	fake.getHealthReturnsOnCall[i] = struct {
+		result1 *client.HealthResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) GetLogger() *zap.Logger {
+	fake.getLoggerMutex.Lock()
+	ret, specificReturn := fake.getLoggerReturnsOnCall[len(fake.getLoggerArgsForCall)]
 	fake.getLoggerArgsForCall = append(fake.getLoggerArgsForCall, struct {
 	}{})
 	stub := fake.GetLoggerStub
@@ -724,6 +1568,136 @@ func (fake *FakeA2AClient) GetLoggerReturnsOnCall(i int, result1 *zap.Logger) {
 	}{result1}
 }
 
+func (fake *FakeA2AClient) GetMemory(arg1 context.Context, arg2 types.MemoryIdParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.getMemoryMutex.Lock()
+	ret, specificReturn := fake.getMemoryReturnsOnCall[len(fake.getMemoryArgsForCall)]
+	fake.getMemoryArgsForCall = append(fake.getMemoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.MemoryIdParams
+	}{arg1, arg2})
+	stub := fake.GetMemoryStub
+	fakeReturns := fake.getMemoryReturns
+	fake.recordInvocation("GetMemory", []interface{}{arg1, arg2})
+	fake.getMemoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) GetMemoryCallCount() int {
+	fake.getMemoryMutex.RLock()
+	defer fake.getMemoryMutex.RUnlock()
+	return len(fake.getMemoryArgsForCall)
+}
+
+func (fake *FakeA2AClient) GetMemoryCalls(stub func(context.Context, types.MemoryIdParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.getMemoryMutex.Lock()
+	defer fake.getMemoryMutex.Unlock()
+	fake.GetMemoryStub = stub
+}
+
+func (fake *FakeA2AClient) GetMemoryArgsForCall(i int) (context.Context, types.MemoryIdParams) {
+	fake.getMemoryMutex.RLock()
+	defer fake.getMemoryMutex.RUnlock()
+	argsForCall := fake.getMemoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) GetMemoryReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.getMemoryMutex.Lock()
+	defer fake.getMemoryMutex.Unlock()
+	fake.GetMemoryStub = nil
+	fake.getMemoryReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) GetMemoryReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.getMemoryMutex.Lock()
+	defer fake.getMemoryMutex.Unlock()
+	fake.GetMemoryStub = nil
+	if fake.getMemoryReturnsOnCall == nil {
+		fake.getMemoryReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.getMemoryReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) GetSharedTranscript(arg1 context.Context, arg2 string) (*types.SharedTranscript, error) {
+	fake.getSharedTranscriptMutex.Lock()
+	ret, specificReturn := fake.getSharedTranscriptReturnsOnCall[len(fake.getSharedTranscriptArgsForCall)]
+	fake.getSharedTranscriptArgsForCall = append(fake.getSharedTranscriptArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetSharedTranscriptStub
+	fakeReturns := fake.getSharedTranscriptReturns
+	fake.recordInvocation("GetSharedTranscript", []interface{}{arg1, arg2})
+	fake.getSharedTranscriptMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) GetSharedTranscriptCallCount() int {
+	fake.getSharedTranscriptMutex.RLock()
+	defer fake.getSharedTranscriptMutex.RUnlock()
+	return len(fake.getSharedTranscriptArgsForCall)
+}
+
+func (fake *FakeA2AClient) GetSharedTranscriptCalls(stub func(context.Context, string) (*types.SharedTranscript, error)) {
+	fake.getSharedTranscriptMutex.Lock()
+	defer fake.getSharedTranscriptMutex.Unlock()
+	fake.GetSharedTranscriptStub = stub
+}
+
+func (fake *FakeA2AClient) GetSharedTranscriptArgsForCall(i int) (context.Context, string) {
+	fake.getSharedTranscriptMutex.RLock()
+	defer fake.getSharedTranscriptMutex.RUnlock()
+	argsForCall := fake.getSharedTranscriptArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) GetSharedTranscriptReturns(result1 *types.SharedTranscript, result2 error) {
+	fake.getSharedTranscriptMutex.Lock()
+	defer fake.getSharedTranscriptMutex.Unlock()
+	fake.GetSharedTranscriptStub = nil
+	fake.getSharedTranscriptReturns = struct {
+		result1 *types.SharedTranscript
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) GetSharedTranscriptReturnsOnCall(i int, result1 *types.SharedTranscript, result2 error) {
+	fake.getSharedTranscriptMutex.Lock()
+	defer fake.getSharedTranscriptMutex.Unlock()
+	fake.GetSharedTranscriptStub = nil
+	if fake.getSharedTranscriptReturnsOnCall == nil {
+		fake.getSharedTranscriptReturnsOnCall = make(map[int]struct {
+			result1 *types.SharedTranscript
+			result2 error
+		})
+	}
+	fake.getSharedTranscriptReturnsOnCall[i] = struct {
+		result1 *types.SharedTranscript
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeA2AClient) GetTask(arg1 context.Context, arg2 types.TaskQueryParams) (*types.JSONRPCSuccessResponse, error) {
 	fake.getTaskMutex.Lock()
 	ret, specificReturn := fake.getTaskReturnsOnCall[len(fake.getTaskArgsForCall)]
@@ -854,6 +1828,201 @@ func (fake *FakeA2AClient) GetTaskPushNotificationConfigReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
+func (fake *FakeA2AClient) ListArtifacts(arg1 context.Context, arg2 types.ArtifactListParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.listArtifactsMutex.Lock()
+	ret, specificReturn := fake.listArtifactsReturnsOnCall[len(fake.listArtifactsArgsForCall)]
+	fake.listArtifactsArgsForCall = append(fake.listArtifactsArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.ArtifactListParams
+	}{arg1, arg2})
+	stub := fake.ListArtifactsStub
+	fakeReturns := fake.listArtifactsReturns
+	fake.recordInvocation("ListArtifacts", []interface{}{arg1, arg2})
+	fake.listArtifactsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) ListArtifactsCallCount() int {
+	fake.listArtifactsMutex.RLock()
+	defer fake.listArtifactsMutex.RUnlock()
+	return len(fake.listArtifactsArgsForCall)
+}
+
+func (fake *FakeA2AClient) ListArtifactsCalls(stub func(context.Context, types.ArtifactListParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.listArtifactsMutex.Lock()
+	defer fake.listArtifactsMutex.Unlock()
+	fake.ListArtifactsStub = stub
+}
+
+func (fake *FakeA2AClient) ListArtifactsArgsForCall(i int) (context.Context, types.ArtifactListParams) {
+	fake.listArtifactsMutex.RLock()
+	defer fake.listArtifactsMutex.RUnlock()
+	argsForCall := fake.listArtifactsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) ListArtifactsReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.listArtifactsMutex.Lock()
+	defer fake.listArtifactsMutex.Unlock()
+	fake.ListArtifactsStub = nil
+	fake.listArtifactsReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) ListArtifactsReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.listArtifactsMutex.Lock()
+	defer fake.listArtifactsMutex.Unlock()
+	fake.ListArtifactsStub = nil
+	if fake.listArtifactsReturnsOnCall == nil {
+		fake.listArtifactsReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.listArtifactsReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) ListContexts(arg1 context.Context, arg2 types.ContextListParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.listContextsMutex.Lock()
+	ret, specificReturn := fake.listContextsReturnsOnCall[len(fake.listContextsArgsForCall)]
+	fake.listContextsArgsForCall = append(fake.listContextsArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.ContextListParams
+	}{arg1, arg2})
+	stub := fake.ListContextsStub
+	fakeReturns := fake.listContextsReturns
+	fake.recordInvocation("ListContexts", []interface{}{arg1, arg2})
+	fake.listContextsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) ListContextsCallCount() int {
+	fake.listContextsMutex.RLock()
+	defer fake.listContextsMutex.RUnlock()
+	return len(fake.listContextsArgsForCall)
+}
+
+func (fake *FakeA2AClient) ListContextsCalls(stub func(context.Context, types.ContextListParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.listContextsMutex.Lock()
+	defer fake.listContextsMutex.Unlock()
+	fake.ListContextsStub = stub
+}
+
+func (fake *FakeA2AClient) ListContextsArgsForCall(i int) (context.Context, types.ContextListParams) {
+	fake.listContextsMutex.RLock()
+	defer fake.listContextsMutex.RUnlock()
+	argsForCall := fake.listContextsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) ListContextsReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.listContextsMutex.Lock()
+	defer fake.listContextsMutex.Unlock()
+	fake.ListContextsStub = nil
+	fake.listContextsReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) ListContextsReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.listContextsMutex.Lock()
+	defer fake.listContextsMutex.Unlock()
+	fake.ListContextsStub = nil
+	if fake.listContextsReturnsOnCall == nil {
+		fake.listContextsReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.listContextsReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) ListMemories(arg1 context.Context, arg2 types.MemoryListParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.listMemoriesMutex.Lock()
+	ret, specificReturn := fake.listMemoriesReturnsOnCall[len(fake.listMemoriesArgsForCall)]
+	fake.listMemoriesArgsForCall = append(fake.listMemoriesArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.MemoryListParams
+	}{arg1, arg2})
+	stub := fake.ListMemoriesStub
+	fakeReturns := fake.listMemoriesReturns
+	fake.recordInvocation("ListMemories", []interface{}{arg1, arg2})
+	fake.listMemoriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) ListMemoriesCallCount() int {
+	fake.listMemoriesMutex.RLock()
+	defer fake.listMemoriesMutex.RUnlock()
+	return len(fake.listMemoriesArgsForCall)
+}
+
+func (fake *FakeA2AClient) ListMemoriesCalls(stub func(context.Context, types.MemoryListParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.listMemoriesMutex.Lock()
+	defer fake.listMemoriesMutex.Unlock()
+	fake.ListMemoriesStub = stub
+}
+
+func (fake *FakeA2AClient) ListMemoriesArgsForCall(i int) (context.Context, types.MemoryListParams) {
+	fake.listMemoriesMutex.RLock()
+	defer fake.listMemoriesMutex.RUnlock()
+	argsForCall := fake.listMemoriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) ListMemoriesReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.listMemoriesMutex.Lock()
+	defer fake.listMemoriesMutex.Unlock()
+	fake.ListMemoriesStub = nil
+	fake.listMemoriesReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) ListMemoriesReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.listMemoriesMutex.Lock()
+	defer fake.listMemoriesMutex.Unlock()
+	fake.ListMemoriesStub = nil
+	if fake.listMemoriesReturnsOnCall == nil {
+		fake.listMemoriesReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.listMemoriesReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeA2AClient) ListTaskPushNotificationConfig(arg1 context.Context, arg2 types.ListTaskPushNotificationConfigParams) (*types.JSONRPCSuccessResponse, error) {
 	fake.listTaskPushNotificationConfigMutex.Lock()
 	ret, specificReturn := fake.listTaskPushNotificationConfigReturnsOnCall[len(fake.listTaskPushNotificationConfigArgsForCall)]
@@ -913,23 +2082,223 @@ func (fake *FakeA2AClient) ListTaskPushNotificationConfigReturnsOnCall(i int, re
 			result2 error
 		})
 	}
-	fake.listTaskPushNotificationConfigReturnsOnCall[i] = struct {
-		result1 *types.JSONRPCSuccessResponse
+	fake.listTaskPushNotificationConfigReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) ListTasks(arg1 context.Context, arg2 types.TaskListParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.listTasksMutex.Lock()
+	ret, specificReturn := fake.listTasksReturnsOnCall[len(fake.listTasksArgsForCall)]
+	fake.listTasksArgsForCall = append(fake.listTasksArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.TaskListParams
+	}{arg1, arg2})
+	stub := fake.ListTasksStub
+	fakeReturns := fake.listTasksReturns
+	fake.recordInvocation("ListTasks", []interface{}{arg1, arg2})
+	fake.listTasksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) ListTasksCallCount() int {
+	fake.listTasksMutex.RLock()
+	defer fake.listTasksMutex.RUnlock()
+	return len(fake.listTasksArgsForCall)
+}
+
+func (fake *FakeA2AClient) ListTasksCalls(stub func(context.Context, types.TaskListParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.listTasksMutex.Lock()
+	defer fake.listTasksMutex.Unlock()
+	fake.ListTasksStub = stub
+}
+
+func (fake *FakeA2AClient) ListTasksArgsForCall(i int) (context.Context, types.TaskListParams) {
+	fake.listTasksMutex.RLock()
+	defer fake.listTasksMutex.RUnlock()
+	argsForCall := fake.listTasksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) ListTasksReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.listTasksMutex.Lock()
+	defer fake.listTasksMutex.Unlock()
+	fake.ListTasksStub = nil
+	fake.listTasksReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) ListTasksReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.listTasksMutex.Lock()
+	defer fake.listTasksMutex.Unlock()
+	fake.ListTasksStub = nil
+	if fake.listTasksReturnsOnCall == nil {
+		fake.listTasksReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.listTasksReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) Negotiate(arg1 context.Context, arg2 []string) ([]string, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.negotiateMutex.Lock()
+	ret, specificReturn := fake.negotiateReturnsOnCall[len(fake.negotiateArgsForCall)]
+	fake.negotiateArgsForCall = append(fake.negotiateArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.NegotiateStub
+	fakeReturns := fake.negotiateReturns
+	fake.recordInvocation("Negotiate", []interface{}{arg1, arg2Copy})
+	fake.negotiateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) NegotiateCallCount() int {
+	fake.negotiateMutex.RLock()
+	defer fake.negotiateMutex.RUnlock()
+	return len(fake.negotiateArgsForCall)
+}
+
+func (fake *FakeA2AClient) NegotiateCalls(stub func(context.Context, []string) ([]string, error)) {
+	fake.negotiateMutex.Lock()
+	defer fake.negotiateMutex.Unlock()
+	fake.NegotiateStub = stub
+}
+
+func (fake *FakeA2AClient) NegotiateArgsForCall(i int) (context.Context, []string) {
+	fake.negotiateMutex.RLock()
+	defer fake.negotiateMutex.RUnlock()
+	argsForCall := fake.negotiateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) NegotiateReturns(result1 []string, result2 error) {
+	fake.negotiateMutex.Lock()
+	defer fake.negotiateMutex.Unlock()
+	fake.NegotiateStub = nil
+	fake.negotiateReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) NegotiateReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.negotiateMutex.Lock()
+	defer fake.negotiateMutex.Unlock()
+	fake.NegotiateStub = nil
+	if fake.negotiateReturnsOnCall == nil {
+		fake.negotiateReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.negotiateReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) OpenResubscribeStream(arg1 context.Context, arg2 types.TaskResubscriptionParams) (*client.TaskStream, error) {
+	fake.openResubscribeStreamMutex.Lock()
+	ret, specificReturn := fake.openResubscribeStreamReturnsOnCall[len(fake.openResubscribeStreamArgsForCall)]
+	fake.openResubscribeStreamArgsForCall = append(fake.openResubscribeStreamArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.TaskResubscriptionParams
+	}{arg1, arg2})
+	stub := fake.OpenResubscribeStreamStub
+	fakeReturns := fake.openResubscribeStreamReturns
+	fake.recordInvocation("OpenResubscribeStream", []interface{}{arg1, arg2})
+	fake.openResubscribeStreamMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) OpenResubscribeStreamCallCount() int {
+	fake.openResubscribeStreamMutex.RLock()
+	defer fake.openResubscribeStreamMutex.RUnlock()
+	return len(fake.openResubscribeStreamArgsForCall)
+}
+
+func (fake *FakeA2AClient) OpenResubscribeStreamCalls(stub func(context.Context, types.TaskResubscriptionParams) (*client.TaskStream, error)) {
+	fake.openResubscribeStreamMutex.Lock()
+	defer fake.openResubscribeStreamMutex.Unlock()
+	fake.OpenResubscribeStreamStub = stub
+}
+
+func (fake *FakeA2AClient) OpenResubscribeStreamArgsForCall(i int) (context.Context, types.TaskResubscriptionParams) {
+	fake.openResubscribeStreamMutex.RLock()
+	defer fake.openResubscribeStreamMutex.RUnlock()
+	argsForCall := fake.openResubscribeStreamArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) OpenResubscribeStreamReturns(result1 *client.TaskStream, result2 error) {
+	fake.openResubscribeStreamMutex.Lock()
+	defer fake.openResubscribeStreamMutex.Unlock()
+	fake.OpenResubscribeStreamStub = nil
+	fake.openResubscribeStreamReturns = struct {
+		result1 *client.TaskStream
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) OpenResubscribeStreamReturnsOnCall(i int, result1 *client.TaskStream, result2 error) {
+	fake.openResubscribeStreamMutex.Lock()
+	defer fake.openResubscribeStreamMutex.Unlock()
+	fake.OpenResubscribeStreamStub = nil
+	if fake.openResubscribeStreamReturnsOnCall == nil {
+		fake.openResubscribeStreamReturnsOnCall = make(map[int]struct {
+			result1 *client.TaskStream
+			result2 error
+		})
+	}
+	fake.openResubscribeStreamReturnsOnCall[i] = struct {
+		result1 *client.TaskStream
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeA2AClient) ListTasks(arg1 context.Context, arg2 types.TaskListParams) (*types.JSONRPCSuccessResponse, error) {
-	fake.listTasksMutex.Lock()
-	ret, specificReturn := fake.listTasksReturnsOnCall[len(fake.listTasksArgsForCall)]
-	fake.listTasksArgsForCall = append(fake.listTasksArgsForCall, struct {
+func (fake *FakeA2AClient) OpenTaskStream(arg1 context.Context, arg2 types.MessageSendParams) (*client.TaskStream, error) {
+	fake.openTaskStreamMutex.Lock()
+	ret, specificReturn := fake.openTaskStreamReturnsOnCall[len(fake.openTaskStreamArgsForCall)]
+	fake.openTaskStreamArgsForCall = append(fake.openTaskStreamArgsForCall, struct {
 		arg1 context.Context
-		arg2 types.TaskListParams
+		arg2 types.MessageSendParams
 	}{arg1, arg2})
-	stub := fake.ListTasksStub
-	fakeReturns := fake.listTasksReturns
-	fake.recordInvocation("ListTasks", []interface{}{arg1, arg2})
-	fake.listTasksMutex.Unlock()
+	stub := fake.OpenTaskStreamStub
+	fakeReturns := fake.openTaskStreamReturns
+	fake.recordInvocation("OpenTaskStream", []interface{}{arg1, arg2})
+	fake.openTaskStreamMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -939,47 +2308,47 @@ func (fake *FakeA2AClient) ListTasks(arg1 context.Context, arg2 types.TaskListPa
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeA2AClient) ListTasksCallCount() int {
-	fake.listTasksMutex.RLock()
-	defer fake.listTasksMutex.RUnlock()
-	return len(fake.listTasksArgsForCall)
+func (fake *FakeA2AClient) OpenTaskStreamCallCount() int {
+	fake.openTaskStreamMutex.RLock()
+	defer fake.openTaskStreamMutex.RUnlock()
+	return len(fake.openTaskStreamArgsForCall)
 }
 
-func (fake *FakeA2AClient) ListTasksCalls(stub func(context.Context, types.TaskListParams) (*types.JSONRPCSuccessResponse, error)) {
-	fake.listTasksMutex.Lock()
-	defer fake.listTasksMutex.Unlock()
-	fake.ListTasksStub = stub
+func (fake *FakeA2AClient) OpenTaskStreamCalls(stub func(context.Context, types.MessageSendParams) (*client.TaskStream, error)) {
+	fake.openTaskStreamMutex.Lock()
+	defer fake.openTaskStreamMutex.Unlock()
+	fake.OpenTaskStreamStub = stub
 }
 
-func (fake *FakeA2AClient) ListTasksArgsForCall(i int) (context.Context, types.TaskListParams) {
-	fake.listTasksMutex.RLock()
-	defer fake.listTasksMutex.RUnlock()
-	argsForCall := fake.listTasksArgsForCall[i]
+func (fake *FakeA2AClient) OpenTaskStreamArgsForCall(i int) (context.Context, types.MessageSendParams) {
+	fake.openTaskStreamMutex.RLock()
+	defer fake.openTaskStreamMutex.RUnlock()
+	argsForCall := fake.openTaskStreamArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeA2AClient) ListTasksReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
-	fake.listTasksMutex.Lock()
-	defer fake.listTasksMutex.Unlock()
-	fake.ListTasksStub = nil
-	fake.listTasksReturns = struct {
-		result1 *types.JSONRPCSuccessResponse
+func (fake *FakeA2AClient) OpenTaskStreamReturns(result1 *client.TaskStream, result2 error) {
+	fake.openTaskStreamMutex.Lock()
+	defer fake.openTaskStreamMutex.Unlock()
+	fake.OpenTaskStreamStub = nil
+	fake.openTaskStreamReturns = struct {
+		result1 *client.TaskStream
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeA2AClient) ListTasksReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
-	fake.listTasksMutex.Lock()
-	defer fake.listTasksMutex.Unlock()
-	fake.ListTasksStub = nil
-	if fake.listTasksReturnsOnCall == nil {
-		fake.listTasksReturnsOnCall = make(map[int]struct {
-			result1 *types.JSONRPCSuccessResponse
+func (fake *FakeA2AClient) OpenTaskStreamReturnsOnCall(i int, result1 *client.TaskStream, result2 error) {
+	fake.openTaskStreamMutex.Lock()
+	defer fake.openTaskStreamMutex.Unlock()
+	fake.OpenTaskStreamStub = nil
+	if fake.openTaskStreamReturnsOnCall == nil {
+		fake.openTaskStreamReturnsOnCall = make(map[int]struct {
+			result1 *client.TaskStream
 			result2 error
 		})
 	}
-	fake.listTasksReturnsOnCall[i] = struct {
-		result1 *types.JSONRPCSuccessResponse
+	fake.openTaskStreamReturnsOnCall[i] = struct {
+		result1 *client.TaskStream
 		result2 error
 	}{result1, result2}
 }
@@ -1049,6 +2418,72 @@ func (fake *FakeA2AClient) ResubscribeTaskReturnsOnCall(i int, result1 <-chan ty
 	}{result1, result2}
 }
 
+func (fake *FakeA2AClient) SendAndWait(arg1 context.Context, arg2 types.MessageSendParams, arg3 *client.WaitOptions) (*types.Task, error) {
+	fake.sendAndWaitMutex.Lock()
+	ret, specificReturn := fake.sendAndWaitReturnsOnCall[len(fake.sendAndWaitArgsForCall)]
+	fake.sendAndWaitArgsForCall = append(fake.sendAndWaitArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.MessageSendParams
+		arg3 *client.WaitOptions
+	}{arg1, arg2, arg3})
+	stub := fake.SendAndWaitStub
+	fakeReturns := fake.sendAndWaitReturns
+	fake.recordInvocation("SendAndWait", []interface{}{arg1, arg2, arg3})
+	fake.sendAndWaitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) SendAndWaitCallCount() int {
+	fake.sendAndWaitMutex.RLock()
+	defer fake.sendAndWaitMutex.RUnlock()
+	return len(fake.sendAndWaitArgsForCall)
+}
+
+func (fake *FakeA2AClient) SendAndWaitCalls(stub func(context.Context, types.MessageSendParams, *client.WaitOptions) (*types.Task, error)) {
+	fake.sendAndWaitMutex.Lock()
+	defer fake.sendAndWaitMutex.Unlock()
+	fake.SendAndWaitStub = stub
+}
+
+func (fake *FakeA2AClient) SendAndWaitArgsForCall(i int) (context.Context, types.MessageSendParams, *client.WaitOptions) {
+	fake.sendAndWaitMutex.RLock()
+	defer fake.sendAndWaitMutex.RUnlock()
+	argsForCall := fake.sendAndWaitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeA2AClient) SendAndWaitReturns(result1 *types.Task, result2 error) {
+	fake.sendAndWaitMutex.Lock()
+	defer fake.sendAndWaitMutex.Unlock()
+	fake.SendAndWaitStub = nil
+	fake.sendAndWaitReturns = struct {
+		result1 *types.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) SendAndWaitReturnsOnCall(i int, result1 *types.Task, result2 error) {
+	fake.sendAndWaitMutex.Lock()
+	defer fake.sendAndWaitMutex.Unlock()
+	fake.SendAndWaitStub = nil
+	if fake.sendAndWaitReturnsOnCall == nil {
+		fake.sendAndWaitReturnsOnCall = make(map[int]struct {
+			result1 *types.Task
+			result2 error
+		})
+	}
+	fake.sendAndWaitReturnsOnCall[i] = struct {
+		result1 *types.Task
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeA2AClient) SendTask(arg1 context.Context, arg2 types.MessageSendParams) (*types.JSONRPCSuccessResponse, error) {
 	fake.sendTaskMutex.Lock()
 	ret, specificReturn := fake.sendTaskReturnsOnCall[len(fake.sendTaskArgsForCall)]
@@ -1114,6 +2549,71 @@ func (fake *FakeA2AClient) SendTaskReturnsOnCall(i int, result1 *types.JSONRPCSu
 	}{result1, result2}
 }
 
+func (fake *FakeA2AClient) SendTaskBatch(arg1 context.Context, arg2 types.MessageSendBatchParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.sendTaskBatchMutex.Lock()
+	ret, specificReturn := fake.sendTaskBatchReturnsOnCall[len(fake.sendTaskBatchArgsForCall)]
+	fake.sendTaskBatchArgsForCall = append(fake.sendTaskBatchArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.MessageSendBatchParams
+	}{arg1, arg2})
+	stub := fake.SendTaskBatchStub
+	fakeReturns := fake.sendTaskBatchReturns
+	fake.recordInvocation("SendTaskBatch", []interface{}{arg1, arg2})
+	fake.sendTaskBatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) SendTaskBatchCallCount() int {
+	fake.sendTaskBatchMutex.RLock()
+	defer fake.sendTaskBatchMutex.RUnlock()
+	return len(fake.sendTaskBatchArgsForCall)
+}
+
+func (fake *FakeA2AClient) SendTaskBatchCalls(stub func(context.Context, types.MessageSendBatchParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.sendTaskBatchMutex.Lock()
+	defer fake.sendTaskBatchMutex.Unlock()
+	fake.SendTaskBatchStub = stub
+}
+
+func (fake *FakeA2AClient) SendTaskBatchArgsForCall(i int) (context.Context, types.MessageSendBatchParams) {
+	fake.sendTaskBatchMutex.RLock()
+	defer fake.sendTaskBatchMutex.RUnlock()
+	argsForCall := fake.sendTaskBatchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) SendTaskBatchReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.sendTaskBatchMutex.Lock()
+	defer fake.sendTaskBatchMutex.Unlock()
+	fake.SendTaskBatchStub = nil
+	fake.sendTaskBatchReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) SendTaskBatchReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.sendTaskBatchMutex.Lock()
+	defer fake.sendTaskBatchMutex.Unlock()
+	fake.SendTaskBatchStub = nil
+	if fake.sendTaskBatchReturnsOnCall == nil {
+		fake.sendTaskBatchReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.sendTaskBatchReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeA2AClient) SendTaskStreaming(arg1 context.Context, arg2 types.MessageSendParams) (<-chan types.JSONRPCSuccessResponse, error) {
 	fake.sendTaskStreamingMutex.Lock()
 	ret, specificReturn := fake.sendTaskStreamingReturnsOnCall[len(fake.sendTaskStreamingArgsForCall)]
@@ -1340,13 +2840,349 @@ func (fake *FakeA2AClient) SetTimeoutArgsForCall(i int) time.Duration {
 	return argsForCall.arg1
 }
 
+func (fake *FakeA2AClient) ShareTask(arg1 context.Context, arg2 types.TaskShareParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.shareTaskMutex.Lock()
+	ret, specificReturn := fake.shareTaskReturnsOnCall[len(fake.shareTaskArgsForCall)]
+	fake.shareTaskArgsForCall = append(fake.shareTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.TaskShareParams
+	}{arg1, arg2})
+	stub := fake.ShareTaskStub
+	fakeReturns := fake.shareTaskReturns
+	fake.recordInvocation("ShareTask", []interface{}{arg1, arg2})
+	fake.shareTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) ShareTaskCallCount() int {
+	fake.shareTaskMutex.RLock()
+	defer fake.shareTaskMutex.RUnlock()
+	return len(fake.shareTaskArgsForCall)
+}
+
+func (fake *FakeA2AClient) ShareTaskCalls(stub func(context.Context, types.TaskShareParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.shareTaskMutex.Lock()
+	defer fake.shareTaskMutex.Unlock()
+	fake.ShareTaskStub = stub
+}
+
+func (fake *FakeA2AClient) ShareTaskArgsForCall(i int) (context.Context, types.TaskShareParams) {
+	fake.shareTaskMutex.RLock()
+	defer fake.shareTaskMutex.RUnlock()
+	argsForCall := fake.shareTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) ShareTaskReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.shareTaskMutex.Lock()
+	defer fake.shareTaskMutex.Unlock()
+	fake.ShareTaskStub = nil
+	fake.shareTaskReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) ShareTaskReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.shareTaskMutex.Lock()
+	defer fake.shareTaskMutex.Unlock()
+	fake.ShareTaskStub = nil
+	if fake.shareTaskReturnsOnCall == nil {
+		fake.shareTaskReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.shareTaskReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) StreamTask(arg1 context.Context, arg2 types.MessageSendParams) (<-chan client.TaskEvent, error) {
+	fake.streamTaskMutex.Lock()
+	ret, specificReturn := fake.streamTaskReturnsOnCall[len(fake.streamTaskArgsForCall)]
+	fake.streamTaskArgsForCall = append(fake.streamTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.MessageSendParams
+	}{arg1, arg2})
+	stub := fake.StreamTaskStub
+	fakeReturns := fake.streamTaskReturns
+	fake.recordInvocation("StreamTask", []interface{}{arg1, arg2})
+	fake.streamTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) StreamTaskCallCount() int {
+	fake.streamTaskMutex.RLock()
+	defer fake.streamTaskMutex.RUnlock()
+	return len(fake.streamTaskArgsForCall)
+}
+
+func (fake *FakeA2AClient) StreamTaskCalls(stub func(context.Context, types.MessageSendParams) (<-chan client.TaskEvent, error)) {
+	fake.streamTaskMutex.Lock()
+	defer fake.streamTaskMutex.Unlock()
+	fake.StreamTaskStub = stub
+}
+
+func (fake *FakeA2AClient) StreamTaskArgsForCall(i int) (context.Context, types.MessageSendParams) {
+	fake.streamTaskMutex.RLock()
+	defer fake.streamTaskMutex.RUnlock()
+	argsForCall := fake.streamTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) StreamTaskReturns(result1 <-chan client.TaskEvent, result2 error) {
+	fake.streamTaskMutex.Lock()
+	defer fake.streamTaskMutex.Unlock()
+	fake.StreamTaskStub = nil
+	fake.streamTaskReturns = struct {
+		result1 <-chan client.TaskEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) StreamTaskReturnsOnCall(i int, result1 <-chan client.TaskEvent, result2 error) {
+	fake.streamTaskMutex.Lock()
+	defer fake.streamTaskMutex.Unlock()
+	fake.StreamTaskStub = nil
+	if fake.streamTaskReturnsOnCall == nil {
+		fake.streamTaskReturnsOnCall = make(map[int]struct {
+			result1 <-chan client.TaskEvent
+			result2 error
+		})
+	}
+	fake.streamTaskReturnsOnCall[i] = struct {
+		result1 <-chan client.TaskEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) SubmitTaskFeedback(arg1 context.Context, arg2 types.TaskFeedbackParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.submitTaskFeedbackMutex.Lock()
+	ret, specificReturn := fake.submitTaskFeedbackReturnsOnCall[len(fake.submitTaskFeedbackArgsForCall)]
+	fake.submitTaskFeedbackArgsForCall = append(fake.submitTaskFeedbackArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.TaskFeedbackParams
+	}{arg1, arg2})
+	stub := fake.SubmitTaskFeedbackStub
+	fakeReturns := fake.submitTaskFeedbackReturns
+	fake.recordInvocation("SubmitTaskFeedback", []interface{}{arg1, arg2})
+	fake.submitTaskFeedbackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) SubmitTaskFeedbackCallCount() int {
+	fake.submitTaskFeedbackMutex.RLock()
+	defer fake.submitTaskFeedbackMutex.RUnlock()
+	return len(fake.submitTaskFeedbackArgsForCall)
+}
+
+func (fake *FakeA2AClient) SubmitTaskFeedbackCalls(stub func(context.Context, types.TaskFeedbackParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.submitTaskFeedbackMutex.Lock()
+	defer fake.submitTaskFeedbackMutex.Unlock()
+	fake.SubmitTaskFeedbackStub = stub
+}
+
+func (fake *FakeA2AClient) SubmitTaskFeedbackArgsForCall(i int) (context.Context, types.TaskFeedbackParams) {
+	fake.submitTaskFeedbackMutex.RLock()
+	defer fake.submitTaskFeedbackMutex.RUnlock()
+	argsForCall := fake.submitTaskFeedbackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) SubmitTaskFeedbackReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.submitTaskFeedbackMutex.Lock()
+	defer fake.submitTaskFeedbackMutex.Unlock()
+	fake.SubmitTaskFeedbackStub = nil
+	fake.submitTaskFeedbackReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) SubmitTaskFeedbackReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.submitTaskFeedbackMutex.Lock()
+	defer fake.submitTaskFeedbackMutex.Unlock()
+	fake.SubmitTaskFeedbackStub = nil
+	if fake.submitTaskFeedbackReturnsOnCall == nil {
+		fake.submitTaskFeedbackReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.submitTaskFeedbackReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) SupportsStreaming(arg1 context.Context) (bool, error) {
+	fake.supportsStreamingMutex.Lock()
+	ret, specificReturn := fake.supportsStreamingReturnsOnCall[len(fake.supportsStreamingArgsForCall)]
+	fake.supportsStreamingArgsForCall = append(fake.supportsStreamingArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.SupportsStreamingStub
+	fakeReturns := fake.supportsStreamingReturns
+	fake.recordInvocation("SupportsStreaming", []interface{}{arg1})
+	fake.supportsStreamingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) SupportsStreamingCallCount() int {
+	fake.supportsStreamingMutex.RLock()
+	defer fake.supportsStreamingMutex.RUnlock()
+	return len(fake.supportsStreamingArgsForCall)
+}
+
+func (fake *FakeA2AClient) SupportsStreamingCalls(stub func(context.Context) (bool, error)) {
+	fake.supportsStreamingMutex.Lock()
+	defer fake.supportsStreamingMutex.Unlock()
+	fake.SupportsStreamingStub = stub
+}
+
+func (fake *FakeA2AClient) SupportsStreamingArgsForCall(i int) context.Context {
+	fake.supportsStreamingMutex.RLock()
+	defer fake.supportsStreamingMutex.RUnlock()
+	argsForCall := fake.supportsStreamingArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AClient) SupportsStreamingReturns(result1 bool, result2 error) {
+	fake.supportsStreamingMutex.Lock()
+	defer fake.supportsStreamingMutex.Unlock()
+	fake.SupportsStreamingStub = nil
+	fake.supportsStreamingReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) SupportsStreamingReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.supportsStreamingMutex.Lock()
+	defer fake.supportsStreamingMutex.Unlock()
+	fake.SupportsStreamingStub = nil
+	if fake.supportsStreamingReturnsOnCall == nil {
+		fake.supportsStreamingReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.supportsStreamingReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) UpdateMemory(arg1 context.Context, arg2 types.MemoryUpdateParams) (*types.JSONRPCSuccessResponse, error) {
+	fake.updateMemoryMutex.Lock()
+	ret, specificReturn := fake.updateMemoryReturnsOnCall[len(fake.updateMemoryArgsForCall)]
+	fake.updateMemoryArgsForCall = append(fake.updateMemoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.MemoryUpdateParams
+	}{arg1, arg2})
+	stub := fake.UpdateMemoryStub
+	fakeReturns := fake.updateMemoryReturns
+	fake.recordInvocation("UpdateMemory", []interface{}{arg1, arg2})
+	fake.updateMemoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AClient) UpdateMemoryCallCount() int {
+	fake.updateMemoryMutex.RLock()
+	defer fake.updateMemoryMutex.RUnlock()
+	return len(fake.updateMemoryArgsForCall)
+}
+
+func (fake *FakeA2AClient) UpdateMemoryCalls(stub func(context.Context, types.MemoryUpdateParams) (*types.JSONRPCSuccessResponse, error)) {
+	fake.updateMemoryMutex.Lock()
+	defer fake.updateMemoryMutex.Unlock()
+	fake.UpdateMemoryStub = stub
+}
+
+func (fake *FakeA2AClient) UpdateMemoryArgsForCall(i int) (context.Context, types.MemoryUpdateParams) {
+	fake.updateMemoryMutex.RLock()
+	defer fake.updateMemoryMutex.RUnlock()
+	argsForCall := fake.updateMemoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AClient) UpdateMemoryReturns(result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.updateMemoryMutex.Lock()
+	defer fake.updateMemoryMutex.Unlock()
+	fake.UpdateMemoryStub = nil
+	fake.updateMemoryReturns = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeA2AClient) UpdateMemoryReturnsOnCall(i int, result1 *types.JSONRPCSuccessResponse, result2 error) {
+	fake.updateMemoryMutex.Lock()
+	defer fake.updateMemoryMutex.Unlock()
+	fake.UpdateMemoryStub = nil
+	if fake.updateMemoryReturnsOnCall == nil {
+		fake.updateMemoryReturnsOnCall = make(map[int]struct {
+			result1 *types.JSONRPCSuccessResponse
+			result2 error
+		})
+	}
+	fake.updateMemoryReturnsOnCall[i] = struct {
+		result1 *types.JSONRPCSuccessResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeA2AClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.callMutex.RLock()
+	defer fake.callMutex.RUnlock()
 	fake.cancelTaskMutex.RLock()
 	defer fake.cancelTaskMutex.RUnlock()
+	fake.createContextMutex.RLock()
+	defer fake.createContextMutex.RUnlock()
+	fake.createMemoryMutex.RLock()
+	defer fake.createMemoryMutex.RUnlock()
+	fake.deleteContextMutex.RLock()
+	defer fake.deleteContextMutex.RUnlock()
+	fake.deleteMemoryMutex.RLock()
+	defer fake.deleteMemoryMutex.RUnlock()
 	fake.deleteTaskPushNotificationConfigMutex.RLock()
 	defer fake.deleteTaskPushNotificationConfigMutex.RUnlock()
+	fake.forkTaskMutex.RLock()
+	defer fake.forkTaskMutex.RUnlock()
 	fake.getAgentCardMutex.RLock()
 	defer fake.getAgentCardMutex.RUnlock()
 	fake.getArtifactHelperMutex.RLock()
@@ -1355,22 +3191,46 @@ func (fake *FakeA2AClient) Invocations() map[string][][]interface{} {
 	defer fake.getAuthenticatedExtendedCardMutex.RUnlock()
 	fake.getBaseURLMutex.RLock()
 	defer fake.getBaseURLMutex.RUnlock()
+	fake.getContextMutex.RLock()
+	defer fake.getContextMutex.RUnlock()
+	fake.getContextUsageMutex.RLock()
+	defer fake.getContextUsageMutex.RUnlock()
 	fake.getHealthMutex.RLock()
 	defer fake.getHealthMutex.RUnlock()
 	fake.getLoggerMutex.RLock()
 	defer fake.getLoggerMutex.RUnlock()
+	fake.getMemoryMutex.RLock()
+	defer fake.getMemoryMutex.RUnlock()
+	fake.getSharedTranscriptMutex.RLock()
+	defer fake.getSharedTranscriptMutex.RUnlock()
 	fake.getTaskMutex.RLock()
 	defer fake.getTaskMutex.RUnlock()
 	fake.getTaskPushNotificationConfigMutex.RLock()
 	defer fake.getTaskPushNotificationConfigMutex.RUnlock()
+	fake.listArtifactsMutex.RLock()
+	defer fake.listArtifactsMutex.RUnlock()
+	fake.listContextsMutex.RLock()
+	defer fake.listContextsMutex.RUnlock()
+	fake.listMemoriesMutex.RLock()
+	defer fake.listMemoriesMutex.RUnlock()
 	fake.listTaskPushNotificationConfigMutex.RLock()
 	defer fake.listTaskPushNotificationConfigMutex.RUnlock()
 	fake.listTasksMutex.RLock()
 	defer fake.listTasksMutex.RUnlock()
+	fake.negotiateMutex.RLock()
+	defer fake.negotiateMutex.RUnlock()
+	fake.openResubscribeStreamMutex.RLock()
+	defer fake.openResubscribeStreamMutex.RUnlock()
+	fake.openTaskStreamMutex.RLock()
+	defer fake.openTaskStreamMutex.RUnlock()
 	fake.resubscribeTaskMutex.RLock()
 	defer fake.resubscribeTaskMutex.RUnlock()
+	fake.sendAndWaitMutex.RLock()
+	defer fake.sendAndWaitMutex.RUnlock()
 	fake.sendTaskMutex.RLock()
 	defer fake.sendTaskMutex.RUnlock()
+	fake.sendTaskBatchMutex.RLock()
+	defer fake.sendTaskBatchMutex.RUnlock()
 	fake.sendTaskStreamingMutex.RLock()
 	defer fake.sendTaskStreamingMutex.RUnlock()
 	fake.setHTTPClientMutex.RLock()
@@ -1381,6 +3241,16 @@ func (fake *FakeA2AClient) Invocations() map[string][][]interface{} {
 	defer fake.setTaskPushNotificationConfigMutex.RUnlock()
 	fake.setTimeoutMutex.RLock()
 	defer fake.setTimeoutMutex.RUnlock()
+	fake.shareTaskMutex.RLock()
+	defer fake.shareTaskMutex.RUnlock()
+	fake.streamTaskMutex.RLock()
+	defer fake.streamTaskMutex.RUnlock()
+	fake.submitTaskFeedbackMutex.RLock()
+	defer fake.submitTaskFeedbackMutex.RUnlock()
+	fake.supportsStreamingMutex.RLock()
+	defer fake.supportsStreamingMutex.RUnlock()
+	fake.updateMemoryMutex.RLock()
+	defer fake.updateMemoryMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,330 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/inference-gateway/adk/types"
+)
+
+// Session tracks a conversation with an agent across turns. It attaches the context ID
+// of the conversation to every message it sends, and the task ID when the last task is
+// waiting for input or authorization, so a reply resumes the paused task instead of starting
+// a new one.
+// A Session is safe for concurrent use, but turns are expected to be sent one at a time.
+type Session struct {
+	client A2AClient
+
+	mu        sync.Mutex
+	contextID string
+	taskID    string
+	state     types.TaskState
+	form      *types.FormRequest
+	auth      *types.AuthRequest
+	history   []types.Message
+	recorded  map[string]bool
+}
+
+// NewSession creates a session that starts a new conversation on its first message
+func NewSession(a2aClient A2AClient) *Session {
+	return NewSessionWithContext(a2aClient, "")
+}
+
+// NewSessionWithContext creates a session that continues the conversation with the given context ID
+func NewSessionWithContext(a2aClient A2AClient, contextID string) *Session {
+	return &Session{
+		client:    a2aClient,
+		contextID: contextID,
+		recorded:  make(map[string]bool),
+	}
+}
+
+// ContextID returns the context ID of the conversation, or an empty string before the first turn
+func (s *Session) ContextID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.contextID
+}
+
+// TaskID returns the ID of the last task of the conversation
+func (s *Session) TaskID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.taskID
+}
+
+// State returns the last known state of the last task of the conversation
+func (s *Session) State() types.TaskState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// InputRequired reports whether the last task is paused waiting for input. The next
+// message sent on the session resumes it.
+func (s *Session) InputRequired() bool {
+	return s.State() == types.TaskStateInputRequired
+}
+
+// AuthRequired reports whether the last task is paused waiting for authorization. Resume it
+// with Authenticate.
+func (s *Session) AuthRequired() bool {
+	return s.State() == types.TaskStateAuthRequired
+}
+
+// AuthRequest returns the authorization the last task waits for, or nil when it does not wait
+// for authorization or the agent did not describe it
+func (s *Session) AuthRequest() *types.AuthRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.auth == nil {
+		return nil
+	}
+	request := *s.auth
+	return &request
+}
+
+// Authenticate resumes the last task, waiting for authorization, with credentials via
+// `message/send`, such as the token of a completed OAuth flow
+func (s *Session) Authenticate(ctx context.Context, scheme, credentials string) (*types.Task, error) {
+	if !s.AuthRequired() {
+		return nil, fmt.Errorf("session has no task waiting for authorization")
+	}
+	return s.Send(ctx, types.Message{Parts: []types.Part{types.NewAuthCredentialsPart(scheme, credentials)}})
+}
+
+// Form returns the form the last task waits for the user to fill in, or nil when it does not
+// wait for a form. Fill it in with SubmitForm.
+func (s *Session) Form() *types.FormRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.form == nil {
+		return nil
+	}
+	form := *s.form
+	return &form
+}
+
+// History returns the messages exchanged in the session so far: the messages sent and
+// the replies of the agent, in order
+func (s *Session) History() []types.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.history)
+}
+
+// SendText sends a text message via `message/send`
+func (s *Session) SendText(ctx context.Context, text string) (*types.Task, error) {
+	return s.Send(ctx, types.Message{Parts: []types.Part{types.CreateTextPart(text)}})
+}
+
+// Send sends a message via `message/send` and returns the task it started or resumed.
+// The task may still be running; use Refresh to follow it.
+func (s *Session) Send(ctx context.Context, message types.Message) (*types.Task, error) {
+	params := types.MessageSendParams{Message: s.prepare(message)}
+
+	response, err := s.client.SendTask(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.client.GetArtifactHelper().ExtractTaskFromResponse(response)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.record(params.Message)
+	s.recordTask(task)
+	return task, nil
+}
+
+// SubmitForm resumes the last task with the data filled in the form it waits for, via
+// `message/send`. The data is validated against the schema of the form first, so invalid data
+// is reported without a round trip; the agent validates it again before resuming the task.
+func (s *Session) SubmitForm(ctx context.Context, data map[string]any) (*types.Task, error) {
+	form := s.Form()
+	if form == nil {
+		return nil, fmt.Errorf("session has no task waiting for a form")
+	}
+	if problems := types.ValidateFormData(form.Schema, data); len(problems) > 0 {
+		return nil, fmt.Errorf("invalid form data: %s", strings.Join(problems, "; "))
+	}
+	return s.Send(ctx, types.Message{Parts: []types.Part{types.NewFormResponsePart(form.FormID, data)}})
+}
+
+// Refresh retrieves the last task via `tasks/get`, updating the state of the session and
+// recording the reply of the agent once the task has one
+func (s *Session) Refresh(ctx context.Context) (*types.Task, error) {
+	taskID := s.TaskID()
+	if taskID == "" {
+		return nil, fmt.Errorf("session has no task")
+	}
+
+	response, err := s.client.GetTask(ctx, types.TaskQueryParams{ID: taskID})
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.client.GetArtifactHelper().ExtractTaskFromResponse(response)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recordTask(task)
+	return task, nil
+}
+
+// Stream sends a message via `message/stream` and returns its typed events. The session
+// follows the events to track the task, and records the reply of the agent once the
+// stream ends. Consume the channel until it is closed or cancel the context.
+func (s *Session) Stream(ctx context.Context, message types.Message, configuration *types.MessageSendConfiguration) (<-chan TaskEvent, error) {
+	params := types.MessageSendParams{Message: s.prepare(message), Configuration: configuration}
+
+	upstream, err := s.client.StreamTask(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.record(params.Message)
+	s.mu.Unlock()
+
+	events := make(chan TaskEvent, cap(upstream))
+	go s.follow(ctx, upstream, events)
+
+	return events, nil
+}
+
+// follow forwards the events of a streamed turn, tracking the task they belong to
+func (s *Session) follow(ctx context.Context, upstream <-chan TaskEvent, events chan<- TaskEvent) {
+	defer close(events)
+
+	var (
+		text  strings.Builder
+		reply *types.Message
+	)
+	for event := range upstream {
+		s.mu.Lock()
+		switch e := event.(type) {
+		case MessageDelta:
+			s.recordIDs(e.TaskID, e.ContextID)
+			text.WriteString(e.Text)
+		case TaskStatusUpdate:
+			s.recordIDs(e.TaskID, e.ContextID)
+			s.state = e.Status.State
+			s.recordRequest(e.Status)
+			if e.Status.Message != nil && isReplyState(e.Status.State) {
+				reply = e.Status.Message
+			}
+		}
+		s.mu.Unlock()
+
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case reply != nil:
+		s.record(*reply)
+	case text.Len() > 0:
+		s.record(types.Message{
+			MessageID: uuid.New().String(),
+			Role:      types.RoleAgent,
+			TaskID:    new(s.taskID),
+			ContextID: new(s.contextID),
+			Parts:     []types.Part{types.CreateTextPart(text.String())},
+		})
+	}
+}
+
+// prepare fills in the fields a message of the session needs: an ID, the user role, the
+// context ID of the conversation and, when the last task waits for input or authorization,
+// its task ID
+func (s *Session) prepare(message types.Message) types.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if message.MessageID == "" {
+		message.MessageID = uuid.New().String()
+	}
+	if message.Role == "" {
+		message.Role = types.RoleUser
+	}
+	if message.ContextID == nil && s.contextID != "" {
+		message.ContextID = new(s.contextID)
+	}
+	if message.TaskID == nil && s.taskID != "" && (s.state == types.TaskStateInputRequired || s.state == types.TaskStateAuthRequired) {
+		message.TaskID = new(s.taskID)
+	}
+	return message
+}
+
+// recordTask tracks a task of the session and records its reply once it has one
+func (s *Session) recordTask(task *types.Task) {
+	s.recordIDs(task.ID, task.ContextID)
+	s.state = task.Status.State
+	s.recordRequest(task.Status)
+	if task.Status.Message != nil && isReplyState(task.Status.State) {
+		s.record(*task.Status.Message)
+	}
+}
+
+// recordRequest tracks the form or the authorization the task waits for, if any
+func (s *Session) recordRequest(status types.TaskStatus) {
+	s.form = nil
+	s.auth = nil
+	switch status.State {
+	case types.TaskStateInputRequired:
+		if form, found, err := types.GetFormRequest(status.Message); err == nil && found {
+			s.form = form
+		}
+	case types.TaskStateAuthRequired:
+		if request, found, err := types.GetAuthRequest(status.Message); err == nil && found {
+			s.auth = request
+		}
+	}
+}
+
+// recordIDs tracks the task and context IDs reported by the agent
+func (s *Session) recordIDs(taskID, contextID string) {
+	if taskID != "" {
+		s.taskID = taskID
+	}
+	if contextID != "" {
+		s.contextID = contextID
+	}
+}
+
+// record appends a message to the history unless it was already recorded
+func (s *Session) record(message types.Message) {
+	if message.MessageID != "" {
+		if s.recorded[message.MessageID] {
+			return
+		}
+		s.recorded[message.MessageID] = true
+	}
+	s.history = append(s.history, message)
+}
+
+// isReplyState reports whether the status message of a task in the state is a reply to the user
+func isReplyState(state types.TaskState) bool {
+	switch state {
+	case types.TaskStateCompleted, types.TaskStateInputRequired, types.TaskStateFailed,
+		types.TaskStateRejected, types.TaskStateAuthRequired:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/inference-gateway/adk/client"
+	types "github.com/inference-gateway/adk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sessionServer answers JSON-RPC requests with a scripted sequence of tasks, recording the
+// messages it receives
+type sessionServer struct {
+	mu       sync.Mutex
+	results  []string
+	messages []types.Message
+}
+
+func (s *sessionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     any    `json:"id"`
+		Method string `json:"method"`
+		Params struct {
+			Message types.Message `json:"message"`
+		} `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if req.Method == "message/send" || req.Method == "message/stream" {
+		s.messages = append(s.messages, req.Params.Message)
+	}
+	result := s.results[0]
+	s.results = s.results[1:]
+
+	if req.Method == "message/stream" {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, result)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":"1","result":%s}`, result)
+}
+
+func TestSession_Send(t *testing.T) {
+	backend := &sessionServer{results: []string{
+		`{"id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_INPUT_REQUIRED","message":{"messageId":"ask","role":"ROLE_AGENT","parts":[{"text":"Which city?"}]}}}`,
+		`{"id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_COMPLETED","message":{"messageId":"answer","role":"ROLE_AGENT","parts":[{"text":"Sunny"}]}}}`,
+		`{"id":"task-2","contextId":"ctx-1","status":{"state":"TASK_STATE_SUBMITTED"}}`,
+		`{"id":"task-2","contextId":"ctx-1","status":{"state":"TASK_STATE_COMPLETED","message":{"messageId":"welcome","role":"ROLE_AGENT","parts":[{"text":"You're welcome"}]}}}`,
+		`{"id":"task-2","contextId":"ctx-1","status":{"state":"TASK_STATE_COMPLETED","message":{"messageId":"welcome","role":"ROLE_AGENT","parts":[{"text":"You're welcome"}]}}}`,
+	}}
+	server := httptest.NewServer(backend)
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	session := client.NewSession(client.NewClient(server.URL))
+
+	_, err := session.Refresh(ctx)
+	assert.ErrorContains(t, err, "session has no task")
+
+	task, err := session.SendText(ctx, "What's the weather?")
+	require.NoError(t, err)
+	assert.Equal(t, "task-1", task.ID)
+	assert.True(t, session.InputRequired())
+	assert.Equal(t, "ctx-1", session.ContextID())
+
+	_, err = session.SendText(ctx, "Berlin")
+	require.NoError(t, err)
+	assert.Equal(t, types.TaskStateCompleted, session.State())
+
+	_, err = session.SendText(ctx, "Thanks")
+	require.NoError(t, err)
+	assert.Equal(t, "task-2", session.TaskID())
+
+	for range 2 {
+		task, err = session.Refresh(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, types.TaskStateCompleted, task.Status.State)
+	}
+
+	require.Len(t, backend.messages, 3)
+	assert.Nil(t, backend.messages[0].ContextID)
+	assert.Nil(t, backend.messages[0].TaskID)
+	assert.Equal(t, types.RoleUser, backend.messages[0].Role)
+	assert.NotEmpty(t, backend.messages[0].MessageID)
+	assert.Equal(t, "ctx-1", *backend.messages[1].ContextID)
+	assert.Equal(t, "task-1", *backend.messages[1].TaskID)
+	assert.Equal(t, "ctx-1", *backend.messages[2].ContextID)
+	assert.Nil(t, backend.messages[2].TaskID)
+
+	var transcript []string
+	for _, message := range session.History() {
+		transcript = append(transcript, fmt.Sprintf("%s: %s", message.Role, *message.Parts[0].Text))
+	}
+	assert.Equal(t, []string{
+		"ROLE_USER: What's the weather?",
+		"ROLE_AGENT: Which city?",
+		"ROLE_USER: Berlin",
+		"ROLE_AGENT: Sunny",
+		"ROLE_USER: Thanks",
+		"ROLE_AGENT: You're welcome",
+	}, transcript)
+}
+
+func TestSession_Stream(t *testing.T) {
+	backend := &sessionServer{results: []string{
+		`data: {"jsonrpc":"2.0","id":"1","result":{"kind":"task","id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_WORKING","message":{"messageId":"d1","role":"ROLE_AGENT","parts":[{"text":"Sun"}]}}}}
+
+data: {"jsonrpc":"2.0","id":"1","result":{"kind":"task","id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_WORKING","message":{"messageId":"d2","role":"ROLE_AGENT","parts":[{"text":"ny"}]}}}}
+
+data: {"jsonrpc":"2.0","id":"1","result":{"kind":"status-update","taskId":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_COMPLETED"},"final":true}}
+
+data: [DONE]
+
+`,
+	}}
+	server := httptest.NewServer(backend)
+	t.Cleanup(server.Close)
+
+	session := client.NewSessionWithContext(client.NewClient(server.URL), "ctx-1")
+	events, err := session.Stream(context.Background(), types.Message{Parts: []types.Part{types.CreateTextPart("Weather in Berlin?")}}, nil)
+	require.NoError(t, err)
+
+	count := 0
+	for range events {
+		count++
+	}
+	assert.Equal(t, 3, count)
+
+	require.Len(t, backend.messages, 1)
+	assert.Equal(t, "ctx-1", *backend.messages[0].ContextID)
+	assert.Equal(t, "task-1", session.TaskID())
+	assert.Equal(t, types.TaskStateCompleted, session.State())
+
+	history := session.History()
+	require.Len(t, history, 2)
+	assert.Equal(t, types.RoleAgent, history[1].Role)
+	assert.Equal(t, "Sunny", *history[1].Parts[0].Text)
+	assert.Equal(t, "task-1", *history[1].TaskID)
+}
+
+func TestSession_SubmitForm(t *testing.T) {
+	backend := &sessionServer{results: []string{
+		`{"id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_INPUT_REQUIRED","message":{"messageId":"form","role":"ROLE_AGENT","parts":[{"text":"Shipping address"},{"data":{"data":{"form_request":{"form_id":"shipping","title":"Shipping address","schema":{"type":"object","properties":{"city":{"type":"string","minLength":1},"zip":{"type":"string","pattern":"^[0-9]{5}$"}},"required":["city","zip"]}}}}}]}}}`,
+		`{"id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_COMPLETED","message":{"messageId":"done","role":"ROLE_AGENT","parts":[{"text":"Shipping to Berlin"}]}}}`,
+	}}
+	server := httptest.NewServer(backend)
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	session := client.NewSession(client.NewClient(server.URL))
+
+	_, err := session.SubmitForm(ctx, map[string]any{"city": "Berlin"})
+	assert.ErrorContains(t, err, "no task waiting for a form")
+
+	_, err = session.SendText(ctx, "Ship my order")
+	require.NoError(t, err)
+	form := session.Form()
+	require.NotNil(t, form)
+	assert.Equal(t, "shipping", form.FormID)
+
+	_, err = session.SubmitForm(ctx, map[string]any{"city": "Berlin", "zip": "1O115"})
+	assert.ErrorContains(t, err, "invalid form data: zip must match the pattern ^[0-9]{5}$")
+	require.Len(t, backend.messages, 1)
+
+	task, err := session.SubmitForm(ctx, map[string]any{"city": "Berlin", "zip": "10115"})
+	require.NoError(t, err)
+	assert.Equal(t, types.TaskStateCompleted, task.Status.State)
+	assert.Nil(t, session.Form())
+
+	require.Len(t, backend.messages, 2)
+	assert.Equal(t, "task-1", *backend.messages[1].TaskID)
+	response, found, err := types.GetFormResponse(&backend.messages[1])
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "shipping", response.FormID)
+	assert.Equal(t, map[string]any{"city": "Berlin", "zip": "10115"}, response.Data)
+}
+
+func TestSession_Authenticate(t *testing.T) {
+	backend := &sessionServer{results: []string{
+		`{"id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_AUTH_REQUIRED","message":{"messageId":"auth","role":"ROLE_AGENT","parts":[{"text":"Authorize at https://auth.example.com"},{"data":{"data":{"auth_request":{"scheme":"Bearer","authorization_url":"https://auth.example.com","scopes":["calendar.read"]}}}}]}}}`,
+		`{"id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_COMPLETED","message":{"messageId":"done","role":"ROLE_AGENT","parts":[{"text":"You have 2 meetings"}]}}}`,
+	}}
+	server := httptest.NewServer(backend)
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	session := client.NewSession(client.NewClient(server.URL))
+
+	_, err := session.Authenticate(ctx, "Bearer", "token")
+	assert.ErrorContains(t, err, "no task waiting for authorization")
+
+	_, err = session.SendText(ctx, "What's on today?")
+	require.NoError(t, err)
+	assert.True(t, session.AuthRequired())
+	request := session.AuthRequest()
+	require.NotNil(t, request)
+	assert.Equal(t, "https://auth.example.com", request.AuthorizationURL)
+
+	task, err := session.Authenticate(ctx, "Bearer", "token")
+	require.NoError(t, err)
+	assert.Equal(t, types.TaskStateCompleted, task.Status.State)
+	assert.Nil(t, session.AuthRequest())
+
+	require.Len(t, backend.messages, 2)
+	assert.Equal(t, "task-1", *backend.messages[1].TaskID)
+	credentials, found, err := types.GetAuthCredentials(&backend.messages[1])
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, types.AuthCredentials{Scheme: "Bearer", Credentials: "token"}, *credentials)
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+)
+
+// negotiateArtifactUpload records the artifact upload endpoint advertised by the agent card
+func (c *Client) negotiateArtifactUpload(card *types.AgentCard) {
+	uploadURL, _ := types.GetArtifactUploadURL(card)
+
+	c.stagingMu.Lock()
+	defer c.stagingMu.Unlock()
+	c.uploadURL = uploadURL
+	c.cardRetrieved = true
+}
+
+// resolveArtifactUploadURL returns the artifact upload endpoint of the agent, retrieving the
+// agent card first when it has not been retrieved yet
+func (c *Client) resolveArtifactUploadURL(ctx context.Context) (string, bool) {
+	c.stagingMu.Lock()
+	retrieved, uploadURL := c.cardRetrieved, c.uploadURL
+	c.stagingMu.Unlock()
+
+	if !retrieved {
+		if _, err := c.GetAgentCard(ctx); err != nil {
+			c.logger.Warn("failed to retrieve agent card for file staging, sending files inline", zap.Error(err))
+			return "", false
+		}
+		c.stagingMu.Lock()
+		uploadURL = c.uploadURL
+		c.stagingMu.Unlock()
+	}
+
+	return uploadURL, uploadURL != ""
+}
+
+// stageFileParts uploads inline FileParts larger than the configured threshold to the agent's
+// artifacts server and returns params whose message references the uploaded files by URI.
+// The caller's message is left untouched, and files are sent inline when the agent does not
+// advertise artifact uploads.
+func (c *Client) stageFileParts(ctx context.Context, params types.MessageSendParams) (types.MessageSendParams, error) {
+	threshold := c.config.FileStagingThreshold
+	if threshold <= 0 {
+		return params, nil
+	}
+
+	var staged []int
+	for i, part := range params.Message.Parts {
+		if part.File != nil && part.File.FileWithBytes != nil &&
+			int64(base64.StdEncoding.DecodedLen(len(*part.File.FileWithBytes))) > threshold {
+			staged = append(staged, i)
+		}
+	}
+	if len(staged) == 0 {
+		return params, nil
+	}
+
+	uploadURL, ok := c.resolveArtifactUploadURL(ctx)
+	if !ok {
+		c.logger.Debug("agent does not advertise artifact uploads, sending files inline",
+			zap.String("message_id", params.Message.MessageID))
+		return params, nil
+	}
+
+	contextID := types.ArtifactUploadContextID
+	if params.Message.ContextID != nil && *params.Message.ContextID != "" {
+		contextID = *params.Message.ContextID
+	}
+
+	parts := slices.Clone(params.Message.Parts)
+	for _, i := range staged {
+		part, err := c.uploadFilePart(ctx, uploadURL, contextID, parts[i])
+		if err != nil {
+			return params, err
+		}
+		parts[i] = part
+	}
+
+	params.Message.Parts = parts
+	return params, nil
+}
+
+// uploadFilePart uploads the content of an inline FilePart and returns a part referencing
+// the uploaded file, with the digest of its content recorded in the part metadata
+func (c *Client) uploadFilePart(ctx context.Context, uploadURL, contextID string, part types.Part) (types.Part, error) {
+	filename := part.File.Name
+	if filename == "" {
+		filename = "file"
+	}
+
+	data, err := base64.StdEncoding.DecodeString(*part.File.FileWithBytes)
+	if err != nil {
+		return part, fmt.Errorf("failed to decode file part %q: %w", filename, err)
+	}
+
+	digest := sha256.Sum256(data)
+	checksum := hex.EncodeToString(digest[:])
+	endpoint := fmt.Sprintf("%s/%s/%s/%s",
+		strings.TrimSuffix(uploadURL, "/"),
+		url.PathEscape(contextID),
+		url.PathEscape(uuid.New().String()),
+		url.PathEscape(filename))
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return part, fmt.Errorf("failed to create upload request: %w", err)
+	}
+	c.setHeaders(httpReq)
+	contentType := part.File.MediaType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set(types.ArtifactChecksumHeader, checksum)
+
+	c.logger.Debug("staging file part",
+		zap.String("filename", filename),
+		zap.Int("size", len(data)),
+		zap.String("url", endpoint))
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		c.logger.Error("file upload failed", zap.Error(err), zap.String("filename", filename))
+		return part, fmt.Errorf("failed to upload file part %q: %w", filename, err)
+	}
+	defer func() {
+		if closeErr := httpResp.Body.Close(); closeErr != nil {
+			c.logger.Warn("failed to close upload response body", zap.Error(closeErr))
+		}
+	}()
+
+	if httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(httpResp.Body)
+		return part, fmt.Errorf("failed to upload file part %q: unexpected status code %d, body: %s", filename, httpResp.StatusCode, string(bodyBytes))
+	}
+
+	var uploaded types.ArtifactUploadResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&uploaded); err != nil {
+		return part, fmt.Errorf("failed to decode upload response: %w", err)
+	}
+	if uploaded.URI == "" {
+		return part, fmt.Errorf("failed to upload file part %q: upload response has no uri", filename)
+	}
+
+	metadata := map[string]any{types.ArtifactChecksumMetadataKey: checksum}
+	if part.Metadata != nil {
+		metadata = maps.Clone(*part.Metadata)
+		metadata[types.ArtifactChecksumMetadataKey] = checksum
+	}
+
+	c.logger.Debug("file part staged", zap.String("filename", filename), zap.String("uri", uploaded.URI))
+	return types.CreateFilePart(part.File.Name, part.File.MediaType, nil, &uploaded.URI, metadata), nil
+}
@@ -0,0 +1,163 @@
+package client_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/inference-gateway/adk/client"
+	types "github.com/inference-gateway/adk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stagingServer is a fake agent serving its card, an artifact upload endpoint and message/send
+type stagingServer struct {
+	*httptest.Server
+	mu           sync.Mutex
+	cardRequests int
+	uploads      map[string]string
+	checksums    map[string]string
+	message      types.Message
+}
+
+func newStagingServer(t *testing.T, advertiseUploads bool) *stagingServer {
+	t.Helper()
+	s := &stagingServer{uploads: map[string]string{}, checksums: map[string]string{}}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/.well-known/agent-card.json":
+			s.cardRequests++
+			card := types.AgentCard{Name: "agent"}
+			if advertiseUploads {
+				params := map[string]any{"url": s.URL + "/artifacts"}
+				card.Capabilities.Extensions = []types.AgentExtension{{URI: types.ArtifactUploadExtensionURI, Params: &params}}
+			}
+			_ = json.NewEncoder(w).Encode(card)
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/artifacts/"):
+			body, _ := io.ReadAll(r.Body)
+			s.uploads[r.URL.Path] = string(body)
+			s.checksums[r.URL.Path] = r.Header.Get(types.ArtifactChecksumHeader)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(types.ArtifactUploadResponse{URI: s.URL + r.URL.Path, SHA256: r.Header.Get(types.ArtifactChecksumHeader)})
+		case r.Method == http.MethodPost && r.URL.Path == "/a2a":
+			var req struct {
+				ID     any                     `json:"id"`
+				Params types.MessageSendParams `json:"params"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			s.message = req.Params.Message
+			_ = json.NewEncoder(w).Encode(types.JSONRPCSuccessResponse{JSONRPC: "2.0", ID: req.ID, Result: map[string]any{}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func newStagingParams(large, small string) types.MessageSendParams {
+	largeBytes := base64.StdEncoding.EncodeToString([]byte(large))
+	smallBytes := base64.StdEncoding.EncodeToString([]byte(small))
+	contextID := "ctx-1"
+	return types.MessageSendParams{
+		Message: types.Message{
+			MessageID: "msg-1",
+			ContextID: &contextID,
+			Role:      types.RoleUser,
+			Parts: []types.Part{
+				types.CreateTextPart("summarize these"),
+				types.CreateFilePart("report.txt", "text/plain", &largeBytes, nil, map[string]any{"source": "upload"}),
+				types.CreateFilePart("note.txt", "text/plain", &smallBytes, nil),
+			},
+		},
+	}
+}
+
+func TestClient_StageFileParts(t *testing.T) {
+	large := strings.Repeat("a", 64)
+	small := "hi"
+	digest := sha256.Sum256([]byte(large))
+	checksum := hex.EncodeToString(digest[:])
+
+	t.Run("uploads large files and sends them by uri", func(t *testing.T) {
+		server := newStagingServer(t, true)
+		config := client.DefaultConfig(server.URL)
+		config.FileStagingThreshold = 16
+		a2aClient := client.NewClientWithConfig(config)
+
+		params := newStagingParams(large, small)
+		_, err := a2aClient.SendTask(context.Background(), params)
+		require.NoError(t, err)
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		assert.Equal(t, 1, server.cardRequests)
+		require.Len(t, server.uploads, 1)
+		for path, body := range server.uploads {
+			assert.True(t, strings.HasPrefix(path, "/artifacts/ctx-1/"))
+			assert.True(t, strings.HasSuffix(path, "/report.txt"))
+			assert.Equal(t, large, body)
+			assert.Equal(t, checksum, server.checksums[path])
+		}
+
+		require.Len(t, server.message.Parts, 3)
+		staged := server.message.Parts[1]
+		require.NotNil(t, staged.File)
+		assert.Nil(t, staged.File.FileWithBytes)
+		require.NotNil(t, staged.File.FileWithURI)
+		assert.True(t, strings.HasPrefix(*staged.File.FileWithURI, server.URL+"/artifacts/ctx-1/"))
+		assert.Equal(t, "report.txt", staged.File.Name)
+		assert.Equal(t, "text/plain", staged.File.MediaType)
+		require.NotNil(t, staged.Metadata)
+		assert.Equal(t, checksum, (*staged.Metadata)[types.ArtifactChecksumMetadataKey])
+		assert.Equal(t, "upload", (*staged.Metadata)["source"])
+
+		require.NotNil(t, server.message.Parts[2].File.FileWithBytes)
+		assert.Nil(t, params.Message.Parts[1].File.FileWithURI)
+		assert.NotContains(t, *params.Message.Parts[1].Metadata, types.ArtifactChecksumMetadataKey)
+	})
+
+	t.Run("sends files inline when uploads are not advertised", func(t *testing.T) {
+		server := newStagingServer(t, false)
+		config := client.DefaultConfig(server.URL)
+		config.FileStagingThreshold = 16
+		a2aClient := client.NewClientWithConfig(config)
+
+		_, err := a2aClient.SendTask(context.Background(), newStagingParams(large, small))
+		require.NoError(t, err)
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		assert.Empty(t, server.uploads)
+		require.Len(t, server.message.Parts, 3)
+		require.NotNil(t, server.message.Parts[1].File.FileWithBytes)
+		assert.Nil(t, server.message.Parts[1].File.FileWithURI)
+	})
+
+	t.Run("staging disabled by default", func(t *testing.T) {
+		server := newStagingServer(t, true)
+		a2aClient := client.NewClient(server.URL)
+
+		_, err := a2aClient.SendTask(context.Background(), newStagingParams(large, small))
+		require.NoError(t, err)
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		assert.Zero(t, server.cardRequests)
+		assert.Empty(t, server.uploads)
+	})
+}
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+)
+
+// defaultStreamBufferSize is the number of streaming events buffered when none is configured
+const defaultStreamBufferSize = 100
+
+// TaskStream is a handle on a streaming JSON-RPC response. Events are delivered on
+// Events() until the server ends the stream, the context is cancelled, Close is called
+// or the consumer stops receiving for longer than the configured send timeout.
+// The underlying connection is released as soon as the stream ends.
+type TaskStream struct {
+	events      chan types.JSONRPCSuccessResponse
+	cancel      context.CancelFunc
+	done        chan struct{}
+	closeOnce   sync.Once
+	logger      *zap.Logger
+	method      string
+	sendTimeout time.Duration
+
+	mu  sync.Mutex
+	err error
+}
+
+// newTaskStream starts reading server-sent events from body in the background
+func newTaskStream(ctx context.Context, cancel context.CancelFunc, body io.ReadCloser, method string, bufferSize int, sendTimeout time.Duration, logger *zap.Logger) *TaskStream {
+	stream := &TaskStream{
+		events:      make(chan types.JSONRPCSuccessResponse, bufferSize),
+		cancel:      cancel,
+		done:        make(chan struct{}),
+		logger:      logger,
+		method:      method,
+		sendTimeout: sendTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+		if closeErr := body.Close(); closeErr != nil {
+			logger.Debug("failed to close response body", zap.Error(closeErr))
+		}
+	}()
+	go stream.read(ctx, body)
+
+	return stream
+}
+
+// Events returns the channel streaming events are delivered on. It is closed when the stream ends.
+func (s *TaskStream) Events() <-chan types.JSONRPCSuccessResponse {
+	return s.events
+}
+
+// Done returns a channel that is closed once the stream has ended and its resources are released
+func (s *TaskStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the error that ended the stream, or nil when the server ended it, it was
+// closed or its context was cancelled
+func (s *TaskStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close stops reading from the server, releases the connection and waits for the
+// background reader to exit. It is safe to call Close multiple times.
+func (s *TaskStream) Close() error {
+	s.closeOnce.Do(s.cancel)
+	<-s.done
+	return nil
+}
+
+// read decodes server-sent events and delivers them until the stream ends
+func (s *TaskStream) read(ctx context.Context, body io.Reader) {
+	eventCount := 0
+	defer func() {
+		s.closeOnce.Do(s.cancel)
+		close(s.events)
+		close(s.done)
+	}()
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+
+		if strings.TrimSpace(line) == "data: [DONE]" {
+			s.logger.Debug("received stream termination signal",
+				zap.String("method", s.method),
+				zap.Int("events_received", eventCount))
+			return
+		}
+
+		jsonData := strings.TrimPrefix(line, "data: ")
+
+		var event types.JSONRPCSuccessResponse
+		if err := json.Unmarshal([]byte(jsonData), &event); err != nil {
+			s.logger.Error("failed to decode event",
+				zap.Error(err),
+				zap.String("method", s.method),
+				zap.Int("events_received", eventCount),
+				zap.String("json_data", jsonData))
+			s.setErr(fmt.Errorf("failed to decode event: %w", err))
+			return
+		}
+
+		eventCount++
+		if !s.deliver(ctx, event) {
+			return
+		}
+	}
+
+	if ctx.Err() != nil {
+		s.logger.Debug("streaming context cancelled",
+			zap.String("method", s.method),
+			zap.Int("events_received", eventCount))
+		return
+	}
+
+	if err := scanner.Err(); err != nil {
+		s.logger.Error("failed to scan response",
+			zap.Error(err),
+			zap.String("method", s.method),
+			zap.Int("events_received", eventCount))
+		s.setErr(fmt.Errorf("failed to read stream: %w", err))
+		return
+	}
+
+	s.logger.Debug("streaming completed",
+		zap.String("method", s.method),
+		zap.Int("events_received", eventCount))
+}
+
+// deliver hands an event to the consumer, giving up when the context is cancelled or
+// the consumer has not received for longer than the send timeout
+func (s *TaskStream) deliver(ctx context.Context, event types.JSONRPCSuccessResponse) bool {
+	select {
+	case s.events <- event:
+		return true
+	default:
+	}
+
+	var timeout <-chan time.Time
+	if s.sendTimeout > 0 {
+		timer := time.NewTimer(s.sendTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case s.events <- event:
+		return true
+	case <-ctx.Done():
+		s.logger.Debug("streaming context cancelled while sending event", zap.String("method", s.method))
+		return false
+	case <-timeout:
+		s.logger.Warn("stream consumer stopped receiving events, closing stream",
+			zap.String("method", s.method),
+			zap.Duration("send_timeout", s.sendTimeout))
+		s.setErr(fmt.Errorf("stream consumer did not receive events within %s", s.sendTimeout))
+		return false
+	}
+}
+
+// setErr records the error that ended the stream
+func (s *TaskStream) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
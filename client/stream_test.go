@@ -0,0 +1,168 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/inference-gateway/adk/client"
+	types "github.com/inference-gateway/adk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newEndlessStreamServer streams status events until the client disconnects, which is
+// reported on the returned channel
+func newEndlessStreamServer(t *testing.T) (*httptest.Server, <-chan struct{}) {
+	t.Helper()
+	disconnected := make(chan struct{})
+	var once sync.Once
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		for i := 0; ; i++ {
+			select {
+			case <-r.Context().Done():
+				once.Do(func() { close(disconnected) })
+				return
+			default:
+			}
+			if _, err := fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":\"1\",\"result\":{\"kind\":\"status-update\",\"n\":%d}}\n\n", i); err != nil {
+				once.Do(func() { close(disconnected) })
+				return
+			}
+			flusher.Flush()
+			time.Sleep(time.Millisecond)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, disconnected
+}
+
+func newStreamParams() types.MessageSendParams {
+	return types.MessageSendParams{
+		Message: types.Message{
+			MessageID: "msg-1",
+			Role:      types.RoleUser,
+			Parts:     []types.Part{types.CreateTextPart("hello")},
+		},
+	}
+}
+
+func TestTaskStream_Close(t *testing.T) {
+	server, disconnected := newEndlessStreamServer(t)
+	a2aClient := client.NewClient(server.URL)
+
+	stream, err := a2aClient.OpenTaskStream(context.Background(), newStreamParams())
+	require.NoError(t, err)
+
+	select {
+	case _, ok := <-stream.Events():
+		require.True(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+	}
+
+	require.NoError(t, stream.Close())
+	require.NoError(t, stream.Close())
+	assert.NoError(t, stream.Err())
+
+	for range stream.Events() {
+	}
+
+	select {
+	case <-disconnected:
+	case <-time.After(time.Second):
+		t.Fatal("server connection was not released after Close")
+	}
+}
+
+func TestTaskStream_ContextCancellation(t *testing.T) {
+	server, disconnected := newEndlessStreamServer(t)
+	a2aClient := client.NewClient(server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := a2aClient.SendTaskStreaming(ctx, newStreamParams())
+	require.NoError(t, err)
+
+	cancel()
+
+	closed := make(chan struct{})
+	go func() {
+		for range events {
+		}
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("event channel was not closed after context cancellation")
+	}
+
+	select {
+	case <-disconnected:
+	case <-time.After(time.Second):
+		t.Fatal("server connection was not released after context cancellation")
+	}
+}
+
+func TestTaskStream_AbandonedConsumer(t *testing.T) {
+	server, disconnected := newEndlessStreamServer(t)
+	config := client.DefaultConfig(server.URL)
+	config.StreamBufferSize = 2
+	config.StreamSendTimeout = 50 * time.Millisecond
+	a2aClient := client.NewClientWithConfig(config)
+
+	stream, err := a2aClient.OpenTaskStream(context.Background(), newStreamParams())
+	require.NoError(t, err)
+
+	select {
+	case <-stream.Done():
+	case <-time.After(time.Second):
+		t.Fatal("stream was not closed after the consumer stopped receiving")
+	}
+
+	require.Error(t, stream.Err())
+	assert.Contains(t, stream.Err().Error(), "did not receive events")
+
+	received := 0
+	for range stream.Events() {
+		received++
+	}
+	assert.Equal(t, 2, received)
+
+	select {
+	case <-disconnected:
+	case <-time.After(time.Second):
+		t.Fatal("server connection was not released for an abandoned stream")
+	}
+}
+
+func TestTaskStream_NoGoroutineLeak(t *testing.T) {
+	server, _ := newEndlessStreamServer(t)
+	a2aClient := client.NewClient(server.URL)
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 20; i++ {
+		stream, err := a2aClient.OpenResubscribeStream(context.Background(), types.TaskResubscriptionParams{Name: "task-1"})
+		require.NoError(t, err)
+		<-stream.Events()
+		require.NoError(t, stream.Close())
+	}
+
+	assert.Eventually(t, func() bool {
+		return runtime.NumGoroutine() <= before+5
+	}, 2*time.Second, 20*time.Millisecond)
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+)
+
+// defaultWaitPollInterval is the interval between tasks/get polls when none is configured
+const defaultWaitPollInterval = 1 * time.Second
+
+// WaitOptions configures how SendAndWait follows a task
+type WaitOptions struct {
+	// PollInterval is the interval between `tasks/get` polls when the task is not streamed (default 1s)
+	PollInterval time.Duration
+	// DisableStreaming polls the task even when the agent card advertises streaming
+	DisableStreaming bool
+}
+
+// SendAndWait sends a message and blocks until its task reaches a terminal state or pauses
+// for input, returning the task. The task is followed via `message/stream` when the agent
+// card advertises streaming, and otherwise by polling `tasks/get`. A stream that ends before
+// the task settles falls back to polling. Pass nil opts for the defaults.
+func (c *Client) SendAndWait(ctx context.Context, params types.MessageSendParams, opts *WaitOptions) (*types.Task, error) {
+	if opts == nil {
+		opts = &WaitOptions{}
+	}
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultWaitPollInterval
+	}
+
+	if !opts.DisableStreaming {
+		streaming, err := c.SupportsStreaming(ctx)
+		if err != nil {
+			c.logger.Warn("failed to retrieve agent card, polling the task", zap.Error(err))
+		}
+		if streaming {
+			return c.streamAndWait(ctx, params, interval)
+		}
+	}
+
+	response, err := c.SendTask(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	task, err := c.artifactHelper.ExtractTaskFromResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	if isSettled(task.Status.State) {
+		return task, nil
+	}
+	return c.pollTask(ctx, task.ID, interval)
+}
+
+// streamAndWait follows a task via `message/stream` until it settles, then retrieves it
+func (c *Client) streamAndWait(ctx context.Context, params types.MessageSendParams, interval time.Duration) (*types.Task, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := c.StreamTask(streamCtx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var taskID string
+	var settled bool
+	for event := range events {
+		switch e := event.(type) {
+		case MessageDelta:
+			if e.TaskID != "" {
+				taskID = e.TaskID
+			}
+		case TaskStatusUpdate:
+			if e.TaskID != "" {
+				taskID = e.TaskID
+			}
+			settled = isSettled(e.Status.State)
+		case StreamError:
+			c.logger.Warn("task stream failed, polling the task", zap.String("task_id", taskID), zap.Error(e.Err))
+		}
+		if settled {
+			break
+		}
+	}
+	cancel()
+
+	if taskID == "" {
+		return nil, fmt.Errorf("stream ended before the task was created")
+	}
+	if settled {
+		return c.getTask(ctx, taskID)
+	}
+	return c.pollTask(ctx, taskID, interval)
+}
+
+// pollTask retrieves a task via `tasks/get` at the interval until it settles
+func (c *Client) pollTask(ctx context.Context, taskID string, interval time.Duration) (*types.Task, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		task, err := c.getTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if isSettled(task.Status.State) {
+			return task, nil
+		}
+		c.logger.Debug("waiting for task",
+			zap.String("task_id", taskID),
+			zap.String("state", string(task.Status.State)))
+	}
+}
+
+// getTask retrieves a task via `tasks/get`
+func (c *Client) getTask(ctx context.Context, taskID string) (*types.Task, error) {
+	response, err := c.GetTask(ctx, types.TaskQueryParams{ID: taskID})
+	if err != nil {
+		return nil, err
+	}
+	return c.artifactHelper.ExtractTaskFromResponse(response)
+}
+
+// isSettled reports whether a task in the state has stopped running, either because it
+// ended or because it waits for the client
+func isSettled(state types.TaskState) bool {
+	return state == types.TaskStateCancelled || isReplyState(state)
+}
@@ -0,0 +1,121 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/inference-gateway/adk/client"
+	types "github.com/inference-gateway/adk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newWaitServer serves an agent card advertising the streaming capability, answers
+// message/send and message/stream with the given results and tasks/get with the polled
+// tasks in order, and counts the requests per method
+func newWaitServer(t *testing.T, streaming bool, sent string, polled []string) (*httptest.Server, map[string]int) {
+	t.Helper()
+	var mu sync.Mutex
+	calls := make(map[string]int)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(types.AgentCard{Name: "agent", Capabilities: types.AgentCapabilities{Streaming: &streaming}})
+			return
+		}
+
+		var req struct {
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		mu.Lock()
+		defer mu.Unlock()
+		calls[req.Method]++
+
+		switch req.Method {
+		case "message/stream":
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = fmt.Fprint(w, sent)
+		case "message/send":
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":"1","result":%s}`, sent)
+		case "tasks/get":
+			result := polled[0]
+			if len(polled) > 1 {
+				polled = polled[1:]
+			}
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":"1","result":%s}`, result)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, calls
+}
+
+func TestClient_SendAndWait_Polling(t *testing.T) {
+	server, calls := newWaitServer(t, false,
+		`{"id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_SUBMITTED"}}`,
+		[]string{
+			`{"id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_WORKING"}}`,
+			`{"id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_COMPLETED","message":{"messageId":"answer","role":"ROLE_AGENT","parts":[{"text":"done"}]}}}`,
+		})
+
+	task, err := client.NewClient(server.URL).SendAndWait(context.Background(), newStreamParams(), &client.WaitOptions{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, "task-1", task.ID)
+	assert.Equal(t, types.TaskStateCompleted, task.Status.State)
+	assert.Equal(t, 1, calls["message/send"])
+	assert.Equal(t, 2, calls["tasks/get"])
+	assert.Zero(t, calls["message/stream"])
+}
+
+func TestClient_SendAndWait_Streaming(t *testing.T) {
+	server, calls := newWaitServer(t, true,
+		`data: {"jsonrpc":"2.0","id":"1","result":{"kind":"status-update","taskId":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_WORKING"},"final":false}}
+
+data: {"jsonrpc":"2.0","id":"1","result":{"kind":"status-update","taskId":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_INPUT_REQUIRED"},"final":true}}
+
+data: [DONE]
+
+`,
+		[]string{`{"id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_INPUT_REQUIRED","message":{"messageId":"ask","role":"ROLE_AGENT","parts":[{"text":"Which city?"}]}}}`})
+
+	task, err := client.NewClient(server.URL).SendAndWait(context.Background(), newStreamParams(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, types.TaskStateInputRequired, task.Status.State)
+	require.NotNil(t, task.Status.Message)
+	assert.Equal(t, 1, calls["message/stream"])
+	assert.Equal(t, 1, calls["tasks/get"])
+	assert.Zero(t, calls["message/send"])
+}
+
+func TestClient_SendAndWait_StreamEndsEarly(t *testing.T) {
+	server, calls := newWaitServer(t, true,
+		`data: {"jsonrpc":"2.0","id":"1","result":{"kind":"status-update","taskId":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_WORKING"},"final":false}}
+
+`,
+		[]string{`{"id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_COMPLETED"}}`})
+
+	task, err := client.NewClient(server.URL).SendAndWait(context.Background(), newStreamParams(), &client.WaitOptions{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, types.TaskStateCompleted, task.Status.State)
+	assert.Equal(t, 1, calls["tasks/get"])
+}
+
+func TestClient_SendAndWait_ContextCancelled(t *testing.T) {
+	server, _ := newWaitServer(t, false,
+		`{"id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_SUBMITTED"}}`,
+		[]string{`{"id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_WORKING"}}`})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.NewClient(server.URL).SendAndWait(ctx, newStreamParams(), &client.WaitOptions{PollInterval: time.Millisecond})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
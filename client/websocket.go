@@ -0,0 +1,136 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+// Streaming transports for message/stream and tasks/resubscribe
+const (
+	// StreamTransportAuto streams over WebSocket when the agent card advertises it, and over SSE otherwise
+	StreamTransportAuto = "auto"
+	// StreamTransportSSE always streams over server-sent events
+	StreamTransportSSE = "sse"
+	// StreamTransportWebSocket always streams over WebSocket
+	StreamTransportWebSocket = "websocket"
+)
+
+// resolveWebSocketPath returns the WebSocket endpoint path when streams should use the
+// WebSocket transport. In auto mode the transport follows the agent card last retrieved
+// with GetAgentCard, so clients that discover the agent first negotiate without an extra
+// request; streams opened before discovery use SSE.
+func (c *Client) resolveWebSocketPath() (string, bool) {
+	switch c.config.StreamTransport {
+	case StreamTransportSSE:
+		return "", false
+	case StreamTransportWebSocket:
+		return types.WebSocketPath, true
+	}
+
+	c.transportMu.Lock()
+	defer c.transportMu.Unlock()
+	return c.webSocketPath, c.webSocketPath != ""
+}
+
+// negotiateStreamTransport records the WebSocket endpoint advertised by the agent card
+func (c *Client) negotiateStreamTransport(card *types.AgentCard) {
+	path, _ := types.GetWebSocketPath(card)
+
+	c.transportMu.Lock()
+	defer c.transportMu.Unlock()
+	c.webSocketPath = path
+}
+
+// getWebSocketURL builds the WebSocket URL for an endpoint path from the base URL
+func (c *Client) getWebSocketURL(path string) (string, error) {
+	baseURL := strings.TrimSuffix(strings.TrimSuffix(c.config.BaseURL, "/"), "/a2a")
+	endpoint, err := url.Parse(baseURL + path)
+	if err != nil {
+		return "", fmt.Errorf("invalid base url: %w", err)
+	}
+
+	switch endpoint.Scheme {
+	case "https":
+		endpoint.Scheme = "wss"
+	case "http":
+		endpoint.Scheme = "ws"
+	}
+	return endpoint.String(), nil
+}
+
+// openWebSocketStream sends a streaming JSON-RPC request over a WebSocket connection and
+// starts reading the events the server sends as text frames
+func (c *Client) openWebSocketStream(ctx context.Context, method, path string, body []byte) (*TaskStream, error) {
+	wsURL, err := c.getWebSocketURL(path)
+	if err != nil {
+		return nil, err
+	}
+
+	wsConfig, err := websocket.NewConfig(wsURL, c.config.BaseURL)
+	if err != nil {
+		c.logger.Error("failed to create websocket config", zap.Error(err))
+		return nil, fmt.Errorf("failed to create websocket config: %w", err)
+	}
+	wsConfig.Header = make(http.Header)
+	wsConfig.Header.Set("User-Agent", c.config.UserAgent)
+	for key, value := range c.config.Headers {
+		wsConfig.Header.Set(key, value)
+	}
+
+	c.logger.Debug("opening websocket stream",
+		zap.String("method", method),
+		zap.String("url", wsURL))
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	conn, err := wsConfig.DialContext(streamCtx)
+	if err != nil {
+		cancel()
+		c.logger.Error("failed to open websocket", zap.Error(err))
+		return nil, fmt.Errorf("failed to open websocket: %w", err)
+	}
+
+	if err := websocket.Message.Send(conn, string(body)); err != nil {
+		cancel()
+		if closeErr := conn.Close(); closeErr != nil {
+			c.logger.Warn("failed to close websocket", zap.Error(closeErr))
+		}
+		c.logger.Error("failed to send request", zap.Error(err))
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	return newTaskStream(streamCtx, cancel, &webSocketEventReader{conn: conn}, method, c.streamBufferSize(), c.config.StreamSendTimeout, c.logger), nil
+}
+
+// webSocketEventReader presents the text frames of a WebSocket connection as a stream of
+// server-sent events, so both transports share the same event decoding
+type webSocketEventReader struct {
+	conn   *websocket.Conn
+	buffer bytes.Buffer
+}
+
+// Read returns the next frames formatted as server-sent events
+func (r *webSocketEventReader) Read(p []byte) (int, error) {
+	for r.buffer.Len() == 0 {
+		var frame string
+		if err := websocket.Message.Receive(r.conn, &frame); err != nil {
+			return 0, err
+		}
+		r.buffer.WriteString("data: ")
+		r.buffer.WriteString(frame)
+		r.buffer.WriteString("\n\n")
+	}
+	return r.buffer.Read(p)
+}
+
+// Close closes the WebSocket connection
+func (r *webSocketEventReader) Close() error {
+	return r.conn.Close()
+}
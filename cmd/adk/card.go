@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	conformance "github.com/inference-gateway/adk/conformance"
+)
+
+// runCard prints the agent card served by the agent
+func runCard(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := newFlagSet("card", "", stderr)
+	opts := addServerFlags(flags)
+	if _, err := parseFlags(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	card, err := opts.newClient().GetAgentCard(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get agent card: %w", err)
+	}
+	if opts.json {
+		return printJSON(stdout, card)
+	}
+
+	fmt.Fprintf(stdout, "%s %s\n%s\n", card.Name, card.Version, card.Description)
+	if card.URL != nil {
+		fmt.Fprintf(stdout, "\nURL:       %s\n", *card.URL)
+	}
+	fmt.Fprintf(stdout, "Protocol:  %s\n", card.ProtocolVersion)
+	fmt.Fprintf(stdout, "Streaming: %t\n", card.Capabilities.Streaming != nil && *card.Capabilities.Streaming)
+	fmt.Fprintf(stdout, "Input:     %v\n", card.DefaultInputModes)
+	fmt.Fprintf(stdout, "Output:    %v\n", card.DefaultOutputModes)
+	if len(card.Skills) > 0 {
+		fmt.Fprintln(stdout, "\nSkills:")
+		for _, skill := range card.Skills {
+			fmt.Fprintf(stdout, "  %s: %s\n", skill.ID, skill.Description)
+		}
+	}
+	return nil
+}
+
+// runCardValidate validates an agent card file, or the agent card served by the agent when
+// no file is given, and fails when the card has problems
+func runCardValidate(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := newFlagSet("card validate", "[file]", stderr)
+	opts := addServerFlags(flags)
+	rest, err := parseFlags(flags, args, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	source := opts.url
+	if len(rest) == 1 {
+		source = rest[0]
+		data, err = os.ReadFile(source)
+		if err != nil {
+			return fmt.Errorf("failed to read agent card: %w", err)
+		}
+	} else {
+		card, err := opts.newClient().GetAgentCard(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get agent card: %w", err)
+		}
+		if data, err = json.Marshal(card); err != nil {
+			return fmt.Errorf("failed to encode agent card: %w", err)
+		}
+	}
+
+	problems := conformance.ValidateAgentCard(data)
+	if len(problems) == 0 {
+		fmt.Fprintf(stdout, "%s: valid agent card\n", source)
+		return nil
+	}
+	for _, problem := range problems {
+		fmt.Fprintf(stdout, "%s: %s\n", source, problem)
+	}
+	return fmt.Errorf("agent card has %d problem(s)", len(problems))
+}
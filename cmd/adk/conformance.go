@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	conformance "github.com/inference-gateway/adk/conformance"
+)
+
+// runConformance runs the conformance scenarios against the agent and fails when any of
+// them finds spec violations
+func runConformance(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := newFlagSet("conformance", "", stderr)
+	opts := addServerFlags(flags)
+	scenarios := flags.String("scenario", "", "comma separated scenarios to run (default: all)")
+	message := flags.String("message", conformance.DefaultMessage, "message the agent completes a task for")
+	inputRequired := flags.String("input-required-message", "", "message the agent answers by asking for input (runs input-required)")
+	artifact := flags.String("artifact-message", "", "message the agent answers with artifacts (runs artifacts)")
+	if _, err := parseFlags(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	cfg := conformance.Config{
+		URL: opts.url,
+		// scenarios are bounded by the timeout, a client timeout would cut streams short
+		HTTPClient:           &http.Client{Transport: opts.transport()},
+		Timeout:              opts.timeout,
+		Message:              *message,
+		InputRequiredMessage: *inputRequired,
+		ArtifactMessage:      *artifact,
+	}
+	if *scenarios != "" {
+		cfg.Scenarios = strings.Split(*scenarios, ",")
+	}
+
+	report, err := conformance.Run(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if opts.json {
+		if err := printJSON(stdout, report); err != nil {
+			return err
+		}
+	} else {
+		for _, result := range report.Results {
+			switch result.Status {
+			case conformance.StatusPassed:
+				fmt.Fprintf(stdout, "PASS %s (%s)\n", result.Scenario, result.Duration.Round(time.Millisecond))
+			case conformance.StatusSkipped:
+				fmt.Fprintf(stdout, "SKIP %s: %s\n", result.Scenario, result.SkipReason)
+			case conformance.StatusFailed:
+				fmt.Fprintf(stdout, "FAIL %s (%s)\n", result.Scenario, result.Duration.Round(time.Millisecond))
+				for _, violation := range result.Violations {
+					fmt.Fprintf(stdout, "    %s\n", violation)
+				}
+			}
+		}
+	}
+
+	if failed := report.Failed(); len(failed) > 0 {
+		return fmt.Errorf("%d of %d scenarios failed", len(failed), len(report.Results))
+	}
+	return nil
+}
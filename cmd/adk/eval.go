@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	zap "go.uber.org/zap"
+
+	eval "github.com/inference-gateway/adk/eval"
+	server "github.com/inference-gateway/adk/server"
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+// runEval runs the cases of an eval suite file against the agent and fails when any of them
+// does not pass
+func runEval(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := newFlagSet("eval", "<suite-file>", stderr)
+	opts := addServerFlags(flags)
+	report := flags.String("report", "", "write the report to the file, as JUnit XML when it ends in .xml and JSON otherwise")
+	judgeProvider := flags.String("judge-provider", "", "LLM provider of the judge scoring cases with judge criteria")
+	judgeModel := flags.String("judge-model", "", "LLM model of the judge")
+	judgeURL := flags.String("judge-url", "", "base URL of the judge provider API; the API key is read from ADK_JUDGE_API_KEY")
+	rest, err := parseFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	suite, err := eval.LoadSuite(rest[0])
+	if err != nil {
+		return err
+	}
+	cfg := eval.Config{
+		Target:  eval.NewClientTarget(opts.newClient()),
+		Cases:   suite.Cases,
+		Timeout: opts.timeout,
+	}
+	if *judgeProvider != "" || *judgeModel != "" {
+		judge, err := newJudge(ctx, *judgeProvider, *judgeModel, *judgeURL)
+		if err != nil {
+			return err
+		}
+		cfg.Judge = judge
+	}
+
+	result, err := eval.Run(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if *report != "" {
+		if err := result.WriteFile(*report); err != nil {
+			return err
+		}
+	}
+	if opts.json {
+		if err := result.WriteJSON(stdout); err != nil {
+			return err
+		}
+	} else {
+		for _, caseResult := range result.Results {
+			status := "PASS"
+			if !caseResult.Passed {
+				status = "FAIL"
+			}
+			fmt.Fprintf(stdout, "%s %s (%s)\n", status, caseResult.Case, caseResult.Duration.Round(time.Millisecond))
+			if caseResult.Error != "" {
+				fmt.Fprintf(stdout, "    error: %s\n", caseResult.Error)
+			}
+			for _, check := range caseResult.Checks {
+				if !check.Passed {
+					fmt.Fprintf(stdout, "    %s: %s\n", check.Kind, check.Detail)
+				}
+			}
+		}
+	}
+
+	failed := len(result.Failed())
+	fmt.Fprintf(stderr, "%d of %d cases passed\n", len(result.Results)-failed, len(result.Results))
+	if failed > 0 {
+		return fmt.Errorf("%d of %d cases failed", failed, len(result.Results))
+	}
+	return nil
+}
+
+// newJudge creates an LLM judge for the provider and model, with the default agent
+// configuration otherwise
+func newJudge(ctx context.Context, provider, model, baseURL string) (eval.Judge, error) {
+	cfg, err := config.NewWithDefaults(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load judge configuration: %w", err)
+	}
+	agentConfig := cfg.AgentConfig
+	agentConfig.Provider = provider
+	agentConfig.Model = model
+	agentConfig.BaseURL = baseURL
+	agentConfig.APIKey = os.Getenv("ADK_JUDGE_API_KEY")
+	agentConfig.Temperature = 0
+
+	llmClient, err := server.NewOpenAICompatibleLLMClient(&agentConfig, zap.NewNop())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create judge: %w", err)
+	}
+	return eval.NewLLMJudge(llmClient), nil
+}
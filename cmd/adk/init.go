@@ -0,0 +1,118 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// projectFiles maps the files of a scaffolded project to their templates
+var projectFiles = []struct {
+	name     string
+	template string
+}{
+	{name: "go.mod", template: "go.mod.tmpl"},
+	{name: "main.go", template: "main.go.tmpl"},
+	{name: "agent-card.json", template: "agent-card.json.tmpl"},
+	{name: ".env.example", template: "env.example.tmpl"},
+	{name: "README.md", template: "README.md.tmpl"},
+}
+
+// agentNamePattern is the shape of an agent name: lowercase words joined by dashes
+var agentNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// project is the data the templates of a scaffolded project are rendered with
+type project struct {
+	Module       string
+	Name         string
+	Description  string
+	SystemPrompt string
+}
+
+// runInit scaffolds a new agent project in a directory
+func runInit(args []string, stdout, stderr io.Writer) error {
+	flags := newFlagSet("init", "<dir>", stderr)
+	module := flags.String("module", "", "Go module path of the project (default: the agent name)")
+	name := flags.String("name", "", "name of the agent (default: the directory name)")
+	description := flags.String("description", "", "description of the agent")
+	rest, err := parseFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	dir := rest[0]
+	p := project{Module: *module, Name: *name, Description: *description}
+	if p.Name == "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("failed to resolve directory: %w", err)
+		}
+		p.Name = filepath.Base(abs)
+	}
+	if !agentNamePattern.MatchString(p.Name) {
+		return fmt.Errorf("agent name '%s' must be lowercase words joined by dashes, set one with --name", p.Name)
+	}
+	if p.Module == "" {
+		p.Module = p.Name
+	}
+	if p.Description == "" {
+		p.Description = "An A2A agent built with the ADK"
+	}
+	p.SystemPrompt = fmt.Sprintf("You are %s, a helpful assistant. %s", p.Name, p.Description)
+
+	if err := scaffold(dir, p); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "created agent %s in %s\n\nNext steps:\n\n", p.Name, dir)
+	fmt.Fprintf(stdout, "  cd %s\n  cp .env.example .env\n  go mod tidy\n  go run .\n", dir)
+	return nil
+}
+
+// scaffold renders the files of the project into the directory, which must not exist or be
+// empty so that no file is overwritten
+func scaffold(dir string, p project) error {
+	entries, err := os.ReadDir(dir)
+	switch {
+	case err == nil && len(entries) > 0:
+		return fmt.Errorf("directory %s is not empty", dir)
+	case err != nil && !os.IsNotExist(err):
+		return fmt.Errorf("failed to read directory: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	parsed, err := template.New("project").Funcs(template.FuncMap{
+		"json": func(value string) (string, error) {
+			data, err := json.Marshal(value)
+			return string(data), err
+		},
+	}).ParseFS(templates, "templates/*.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to parse templates: %w", err)
+	}
+
+	for _, file := range projectFiles {
+		out, err := os.Create(filepath.Join(dir, file.name))
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", file.name, err)
+		}
+		err = parsed.ExecuteTemplate(out, file.template, p)
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+	return nil
+}
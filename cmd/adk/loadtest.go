@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	zap "go.uber.org/zap"
+
+	loadtest "github.com/inference-gateway/adk/loadtest"
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+// runLoadTest streams messages from concurrent clients to the agent, or to a mock agent
+// served in process, and prints the latency percentiles and dropped events
+func runLoadTest(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := newFlagSet("loadtest", "", stderr)
+	opts := addServerFlags(flags)
+	clients := flags.Int("clients", loadtest.DefaultClients, "number of concurrent streaming clients")
+	messages := flags.Int("messages", 0, "messages each client sends (default 10 without --duration)")
+	duration := flags.Duration("duration", 0, "how long the clients keep sending messages")
+	rate := flags.Float64("rate", 0, "messages per second each client starts at most (0: back to back)")
+	messageSize := flags.Int("message-size", loadtest.DefaultMessageSize, "size of each message in bytes")
+	expectedDeltas := flags.Int("expected-deltas", 0, "text deltas each stream should deliver, to count dropped events")
+	mockChunks := flags.Int("mock-chunks", 0, "serve a mock agent streaming this many chunks, configured from the environment, and load it instead of --url")
+	mockDelay := flags.Duration("mock-delay", 20*time.Millisecond, "pause before each chunk of the mock agent")
+	if _, err := parseFlags(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	cfg := loadtest.Config{
+		URL:            opts.url,
+		Transport:      opts.transport(),
+		Clients:        *clients,
+		Messages:       *messages,
+		Duration:       *duration,
+		Rate:           *rate,
+		MessageSize:    *messageSize,
+		Timeout:        opts.timeout,
+		ExpectedDeltas: *expectedDeltas,
+	}
+	if *mockChunks > 0 {
+		serverConfig, err := config.Load(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to load server configuration: %w", err)
+		}
+		mock, err := loadtest.StartMockServer(serverConfig, loadtest.NewMockLLMClient(*mockChunks, *mockDelay), zap.NewNop())
+		if err != nil {
+			return err
+		}
+		defer func() {
+			stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := mock.Stop(stopCtx); err != nil {
+				fmt.Fprintf(stderr, "failed to stop mock agent: %v\n", err)
+			}
+		}()
+		cfg.URL = mock.URL
+		if cfg.ExpectedDeltas == 0 {
+			cfg.ExpectedDeltas = *mockChunks
+		}
+		fmt.Fprintf(stderr, "serving mock agent at %s\n", mock.URL)
+	}
+
+	report, err := loadtest.Run(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if opts.json {
+		if err := printJSON(stdout, report); err != nil {
+			return err
+		}
+		return loadTestErr(report)
+	}
+
+	fmt.Fprintf(stdout, "Clients:     %d\n", report.Clients)
+	fmt.Fprintf(stdout, "Duration:    %s\n", report.Duration.Round(time.Millisecond))
+	fmt.Fprintf(stdout, "Streams:     %d (%d completed, %d failed, %d incomplete)\n", report.Streams, report.Completed, report.Failed, report.Incomplete)
+	fmt.Fprintf(stdout, "Throughput:  %.1f streams/s\n", report.Throughput)
+	fmt.Fprintf(stdout, "Events:      %d (%d dropped)\n", report.Events, report.DroppedEvents)
+	fmt.Fprintln(stdout)
+	fmt.Fprintln(stdout, "LATENCY       MIN       P50       P90       P95       P99       MAX")
+	printPercentiles(stdout, "first event", report.FirstEvent)
+	printPercentiles(stdout, "stream", report.StreamDuration)
+
+	if len(report.Errors) > 0 {
+		fmt.Fprintln(stdout, "\nErrors:")
+		messages := make([]string, 0, len(report.Errors))
+		for message := range report.Errors {
+			messages = append(messages, message)
+		}
+		sort.Strings(messages)
+		for _, message := range messages {
+			fmt.Fprintf(stdout, "  %d x %s\n", report.Errors[message], message)
+		}
+	}
+	return loadTestErr(report)
+}
+
+// loadTestErr fails the command when streams failed or events were dropped
+func loadTestErr(report *loadtest.Report) error {
+	if unhealthy := report.Failed + report.Incomplete; unhealthy > 0 {
+		return fmt.Errorf("%d of %d streams failed or ended without a final status", unhealthy, report.Streams)
+	}
+	if report.DroppedEvents > 0 {
+		return fmt.Errorf("%d events were dropped", report.DroppedEvents)
+	}
+	return nil
+}
+
+// printPercentiles prints a row of the latency table
+func printPercentiles(w io.Writer, name string, p loadtest.Percentiles) {
+	fmt.Fprintf(w, "%-11s", name)
+	for _, latency := range []time.Duration{p.Min, p.P50, p.P90, p.P95, p.P99, p.Max} {
+		fmt.Fprintf(w, "%10s", latency.Round(time.Millisecond))
+	}
+	fmt.Fprintln(w)
+}
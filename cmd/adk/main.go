@@ -0,0 +1,214 @@
+// Command adk scaffolds A2A agent projects and talks to running A2A agents: it prints and
+// validates agent cards, sends and streams messages, lists tasks, downloads artifacts, checks
+// servers against the A2A protocol, runs eval suites and puts streaming load on servers.
+//
+// Usage:
+//
+//	go run ./cmd/adk <command> [flags] [arguments]
+//
+// The server commands read the agent URL from --url or ADK_URL (default
+// http://localhost:8080) and an optional bearer token from --token or ADK_TOKEN.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	client "github.com/inference-gateway/adk/client"
+)
+
+const usage = `adk is a command line tool for A2A agents built with the ADK.
+
+Usage:
+
+  adk <command> [flags] [arguments]
+
+Commands:
+
+  init <dir>                     scaffold a new agent project
+  card                           print the agent card of the server
+  card validate [file]           validate an agent card file, or the card of the server
+  send <text>                    send a message and print the reply
+  stream <text>                  stream a message and print the reply as it arrives
+  tasks list                     list the tasks of the server
+  tasks get <task-id>            print a task
+  artifacts download <task-id>   download the file artifacts of a task
+  conformance                    check the server against the A2A protocol
+  eval <suite-file>              run eval cases against the agent and report the results
+  loadtest                       stream messages from concurrent clients and report latencies
+
+Run 'adk <command> -h' for the flags of a command.
+`
+
+// errUsage reports invalid arguments, after the usage of the command was printed
+var errUsage = errors.New("invalid arguments")
+
+// serverOptions are the flags of the commands that call a running agent
+type serverOptions struct {
+	url     string
+	token   string
+	timeout time.Duration
+	json    bool
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run runs the command of the arguments, writing its output to stdout and its
+// diagnostics to stderr
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return errUsage
+	}
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "init":
+		return runInit(args, stdout, stderr)
+	case "card":
+		if len(args) > 0 && args[0] == "validate" {
+			return runCardValidate(ctx, args[1:], stdout, stderr)
+		}
+		return runCard(ctx, args, stdout, stderr)
+	case "send":
+		return runSend(ctx, args, stdout, stderr)
+	case "stream":
+		return runStream(ctx, args, stdout, stderr)
+	case "tasks":
+		if len(args) == 0 {
+			break
+		}
+		switch args[0] {
+		case "list":
+			return runTasksList(ctx, args[1:], stdout, stderr)
+		case "get":
+			return runTasksGet(ctx, args[1:], stdout, stderr)
+		}
+	case "artifacts":
+		if len(args) > 0 && args[0] == "download" {
+			return runArtifactsDownload(ctx, args[1:], stdout, stderr)
+		}
+	case "conformance":
+		return runConformance(ctx, args, stdout, stderr)
+	case "eval":
+		return runEval(ctx, args, stdout, stderr)
+	case "loadtest":
+		return runLoadTest(ctx, args, stdout, stderr)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	}
+
+	fmt.Fprintf(stderr, "unknown command: adk %s\n\n%s", command, usage)
+	return errUsage
+}
+
+// newFlagSet creates the flag set of a command, printing its usage to stderr
+func newFlagSet(name, arguments string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: adk %s [flags] %s\n\nFlags:\n", name, arguments)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseFlags parses the arguments of a command, allowing flags after the positional
+// arguments, and checks that there are between minArgs and maxArgs positional arguments
+func parseFlags(flags *flag.FlagSet, args []string, minArgs, maxArgs int) ([]string, error) {
+	var rest []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, errUsage
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			break
+		}
+		rest = append(rest, args[0])
+		args = args[1:]
+	}
+
+	if len(rest) < minArgs || len(rest) > maxArgs {
+		flags.Usage()
+		return nil, errUsage
+	}
+	return rest, nil
+}
+
+// addServerFlags adds the flags of the commands that call a running agent
+func addServerFlags(flags *flag.FlagSet) *serverOptions {
+	opts := &serverOptions{}
+	url := os.Getenv("ADK_URL")
+	if url == "" {
+		url = "http://localhost:8080"
+	}
+	flags.StringVar(&opts.url, "url", url, "base URL of the agent (env ADK_URL)")
+	flags.StringVar(&opts.token, "token", os.Getenv("ADK_TOKEN"), "bearer token sent to the agent (env ADK_TOKEN)")
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "timeout of each request")
+	flags.BoolVar(&opts.json, "json", false, "print the JSON returned by the agent")
+	return opts
+}
+
+// newClient creates an A2A client for the agent of the options
+func (o *serverOptions) newClient() client.A2AClient {
+	config := client.DefaultConfig(o.url)
+	config.Timeout = o.timeout
+	config.UserAgent = "adk-cli"
+	config.Transport = o.transport()
+	return client.NewClientWithConfig(config)
+}
+
+// httpClient returns an HTTP client sending the token of the options, used to download
+// artifacts from the agent
+func (o *serverOptions) httpClient() *http.Client {
+	return &http.Client{Timeout: o.timeout, Transport: o.transport()}
+}
+
+// transport returns a transport adding the bearer token to every request
+func (o *serverOptions) transport() http.RoundTripper {
+	if o.token == "" {
+		return http.DefaultTransport
+	}
+	return &bearerTransport{token: o.token, next: http.DefaultTransport}
+}
+
+// bearerTransport sets the Authorization header of the requests it sends
+type bearerTransport struct {
+	token string
+	next  http.RoundTripper
+}
+
+// RoundTrip sends a copy of the request with the bearer token
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.next.RoundTrip(req)
+}
+
+// printJSON writes the value as indented JSON
+func printJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	conformance "github.com/inference-gateway/adk/conformance"
+	adktesting "github.com/inference-gateway/adk/testing"
+)
+
+const testTask = `{"kind":"task","id":"task-1","contextId":"ctx-1",
+	"status":{"state":"TASK_STATE_COMPLETED","message":{"kind":"message","messageId":"m-2","role":"ROLE_AGENT","parts":[{"kind":"text","text":"Sunny, 22°C"}]}},
+	"history":[{"kind":"message","messageId":"m-1","role":"ROLE_USER","parts":[{"kind":"text","text":"Weather in Berlin?"}]}],
+	"artifacts":[{"artifactId":"report","name":"Report","parts":[{"kind":"file","file":{"name":"report.txt","mediaType":"text/plain","fileWithBytes":"cmVwb3J0"}}]}]}`
+
+// newTestAgent serves an agent card and answers the JSON-RPC methods of the CLI with
+// canned results, recording the methods called and the authorization sent
+func newTestAgent(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	var calls []string
+	agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		if r.URL.Path == "/.well-known/agent-card.json" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"name":"weather-agent","description":"Weather","version":"1.0.0","url":"http://localhost:8080",
+				"protocolVersion":"0.3.0","capabilities":{"streaming":true},"defaultInputModes":["text/plain"],
+				"defaultOutputModes":["text/plain"],"skills":[{"id":"weather","name":"weather","description":"Forecasts","tags":[]}]}`)
+			return
+		}
+
+		var req struct {
+			ID     any            `json:"id"`
+			Method string         `json:"method"`
+			Params map[string]any `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		calls = append(calls, req.Method)
+		id, _ := json.Marshal(req.ID)
+
+		switch req.Method {
+		case "message/send", "tasks/get":
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, id, testTask)
+		case "tasks/list":
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"tasks":[%s],"totalSize":3,"pageSize":1,"nextPageToken":""}}`, id, testTask)
+		case "message/stream":
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, result := range []string{
+				`{"kind":"task","id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_WORKING","message":{"kind":"message","messageId":"d-1","role":"ROLE_AGENT","parts":[{"kind":"text","text":"Sunny"}]}}}`,
+				`{"kind":"task","id":"task-1","contextId":"ctx-1","status":{"state":"TASK_STATE_WORKING","message":{"kind":"message","messageId":"d-2","role":"ROLE_AGENT","parts":[{"kind":"text","text":", 22°C"}]}}}`,
+				`{"kind":"status-update","taskId":"task-1","contextId":"ctx-1","final":true,"status":{"state":"TASK_STATE_COMPLETED"}}`,
+			} {
+				_, _ = fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":%s}\n\n", id, result)
+			}
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+	}))
+	t.Cleanup(agent.Close)
+	return agent, &calls
+}
+
+// runCommand runs the CLI with the arguments and returns its output
+func runCommand(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), args, &stdout, &stderr)
+	return stdout.String(), stderr.String(), err
+}
+
+func TestRun_ServerCommands(t *testing.T) {
+	agent, calls := newTestAgent(t)
+	t.Setenv("ADK_URL", agent.URL)
+	t.Setenv("ADK_TOKEN", "secret")
+
+	stdout, _, err := runCommand(t, "card")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "weather-agent 1.0.0")
+	assert.Contains(t, stdout, "weather: Forecasts")
+
+	stdout, _, err = runCommand(t, "card", "validate")
+	require.NoError(t, err)
+	assert.Equal(t, agent.URL+": valid agent card\n", stdout)
+
+	stdout, stderr, err := runCommand(t, "send", "Weather in Berlin?", "--context", "ctx-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Sunny, 22°C\n", stdout)
+	assert.Contains(t, stderr, "task task-1 (context ctx-1) [completed]")
+	assert.Contains(t, stderr, "[artifact report Report (report.txt)]")
+
+	stdout, stderr, err = runCommand(t, "stream", "Weather in Berlin?")
+	require.NoError(t, err)
+	assert.Equal(t, "Sunny, 22°C\n", stdout)
+	assert.Equal(t, "task task-1 (context ctx-1)\n[completed]\n", stderr)
+
+	stdout, stderr, err = runCommand(t, "tasks", "list", "--state", "completed")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "TASK    CONTEXT  STATE      UPDATED")
+	assert.Contains(t, stdout, "task-1  ctx-1    completed  -")
+	assert.Equal(t, "showing 1 of 3 tasks\n", stderr)
+
+	stdout, _, err = runCommand(t, "tasks", "get", "task-1")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "State:   completed")
+	assert.Contains(t, stdout, "user: Weather in Berlin?")
+
+	dir := t.TempDir()
+	stdout, _, err = runCommand(t, "artifacts", "download", "task-1", "--out", dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "report.txt")+" (6 bytes)\n", stdout)
+	data, err := os.ReadFile(filepath.Join(dir, "report.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "report", string(data))
+
+	_, _, err = runCommand(t, "artifacts", "download", "task-1", "--out", dir, "--artifact", "missing")
+	assert.EqualError(t, err, "task task-1 has no file artifact missing")
+
+	assert.Equal(t, []string{"message/send", "message/stream", "tasks/list", "tasks/get", "tasks/get", "tasks/get"}, *calls)
+}
+
+func TestRunConformance(t *testing.T) {
+	s := adktesting.NewServer(t, adktesting.NewAgent(t, adktesting.NewFakeLLMClient(adktesting.Reply("Done."))))
+
+	stdout, _, err := runCommand(t, "conformance", "--url", s.URL, "--scenario", "agent-card,message-send,input-required,error-codes")
+	require.NoError(t, err)
+	assert.Regexp(t, `^PASS agent-card \(.+\)\nPASS message-send \(.+\)\n`, stdout)
+	assert.Contains(t, stdout, "SKIP input-required: no message that makes the agent ask for input is configured\n")
+	assert.Contains(t, stdout, "PASS error-codes")
+
+	agent, _ := newTestAgent(t)
+	stdout, _, err = runCommand(t, "conformance", "--url", agent.URL, "--token", "secret", "--scenario", "agent-card,message-send")
+	assert.EqualError(t, err, "1 of 2 scenarios failed")
+	assert.Contains(t, stdout, "PASS agent-card")
+	assert.Contains(t, stdout, "FAIL message-send")
+	assert.Contains(t, stdout, "    message/send: Content-Type is 'text/plain; charset=utf-8', expected application/json\n")
+
+	_, _, err = runCommand(t, "conformance", "--url", s.URL, "--scenario", "push")
+	assert.EqualError(t, err, "unknown scenario 'push'")
+}
+
+func TestRunEval(t *testing.T) {
+	s := adktesting.NewServer(t, adktesting.NewAgent(t, adktesting.NewFakeLLMClient(adktesting.Reply("It is sunny."), adktesting.Reply("It is sunny."))))
+	dir := t.TempDir()
+	suite := filepath.Join(dir, "weather.yaml")
+	require.NoError(t, os.WriteFile(suite, []byte(`cases:
+  - name: sunny
+    prompt: Weather in Berlin?
+    expect: {regex: sunny, state: completed}
+  - name: rainy
+    prompt: Weather in London?
+    expect: {exact: It is raining.}
+`), 0o644))
+	report := filepath.Join(dir, "report.xml")
+
+	stdout, stderr, err := runCommand(t, "eval", suite, "--url", s.URL, "--report", report)
+	assert.EqualError(t, err, "1 of 2 cases failed")
+	assert.Regexp(t, `^PASS sunny \(.+\)\nFAIL rainy \(.+\)\n    exact: expected "It is raining."\n$`, stdout)
+	assert.Equal(t, "1 of 2 cases passed\n", stderr)
+	data, err := os.ReadFile(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `<testsuite name="eval" tests="2" failures="1" errors="0"`)
+
+	_, _, err = runCommand(t, "eval", filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read eval suite")
+}
+
+func TestRunLoadTest(t *testing.T) {
+	stdout, stderr, err := runCommand(t, "loadtest", "--mock-chunks", "3", "--mock-delay", "1ms", "--clients", "2", "--messages", "2")
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "serving mock agent at http://127.0.0.1:")
+	assert.Contains(t, stdout, "Streams:     4 (4 completed, 0 failed, 0 incomplete)")
+	assert.Contains(t, stdout, "Events:      ")
+	assert.Contains(t, stdout, " (0 dropped)")
+	assert.Regexp(t, `(?m)^stream\s+\S+ms`, stdout)
+
+	s := adktesting.NewServer(t, adktesting.NewAgent(t, adktesting.NewFakeLLMClient(adktesting.Reply("Hi"))))
+	_, _, err = runCommand(t, "loadtest", "--url", s.URL, "--clients", "1", "--messages", "1", "--expected-deltas", "3", "--json")
+	assert.EqualError(t, err, "2 events were dropped")
+}
+
+func TestRun_Usage(t *testing.T) {
+	_, stderr, err := runCommand(t)
+	assert.ErrorIs(t, err, errUsage)
+	assert.Contains(t, stderr, "Commands:")
+
+	_, stderr, err = runCommand(t, "deploy")
+	assert.ErrorIs(t, err, errUsage)
+	assert.Contains(t, stderr, "unknown command: adk deploy")
+
+	_, stderr, err = runCommand(t, "send")
+	assert.ErrorIs(t, err, errUsage)
+	assert.Contains(t, stderr, "Usage: adk send [flags] <text>")
+}
+
+func TestRunInit(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "weather-agent")
+
+	stdout, _, err := runCommand(t, "init", dir, "--module", "example.com/weather-agent", "--description", `Answers "weather" questions`)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "created agent weather-agent")
+
+	for _, file := range projectFiles {
+		assert.FileExists(t, filepath.Join(dir, file.name))
+	}
+	goMod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(goMod), "module example.com/weather-agent\n"))
+
+	card, err := os.ReadFile(filepath.Join(dir, "agent-card.json"))
+	require.NoError(t, err)
+	assert.Empty(t, conformance.ValidateAgentCard(card), "the scaffolded agent card is valid")
+	assert.Contains(t, string(card), `"description": "Answers \"weather\" questions"`)
+
+	_, _, err = runCommand(t, "init", dir)
+	assert.EqualError(t, err, fmt.Sprintf("directory %s is not empty", dir))
+
+	_, _, err = runCommand(t, "init", filepath.Join(t.TempDir(), "Weather Agent"))
+	assert.EqualError(t, err, "agent name 'Weather Agent' must be lowercase words joined by dashes, set one with --name")
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	uuid "github.com/google/uuid"
+
+	client "github.com/inference-gateway/adk/client"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// messageOptions are the flags of the commands that send a message
+type messageOptions struct {
+	contextID string
+	taskID    string
+}
+
+// addMessageFlags adds the flags of the commands that send a message
+func addMessageFlags(flags *flag.FlagSet) *messageOptions {
+	opts := &messageOptions{}
+	flags.StringVar(&opts.contextID, "context", "", "context ID to continue a conversation")
+	flags.StringVar(&opts.taskID, "task", "", "task ID to continue a task waiting for input")
+	return opts
+}
+
+// params returns the parameters sending the text as a user message
+func (o *messageOptions) params(text string) types.MessageSendParams {
+	message := types.Message{
+		MessageID: uuid.New().String(),
+		Role:      types.RoleUser,
+		Parts:     []types.Part{types.CreateTextPart(text)},
+	}
+	if o.contextID != "" {
+		message.ContextID = &o.contextID
+	}
+	if o.taskID != "" {
+		message.TaskID = &o.taskID
+	}
+	return types.MessageSendParams{Message: message}
+}
+
+// runSend sends a message with message/send and prints the task it created
+func runSend(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := newFlagSet("send", "<text>", stderr)
+	opts := addServerFlags(flags)
+	message := addMessageFlags(flags)
+	rest, err := parseFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	a2aClient := opts.newClient()
+	response, err := a2aClient.SendTask(ctx, message.params(rest[0]))
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	task, err := a2aClient.GetArtifactHelper().ExtractTaskFromResponse(response)
+	if err != nil {
+		return err
+	}
+	if opts.json {
+		return printJSON(stdout, task)
+	}
+	printTaskReply(stdout, stderr, task)
+	return nil
+}
+
+// runStream sends a message with message/stream and prints the reply of the agent as it
+// arrives, with the status changes and artifacts on stderr
+func runStream(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := newFlagSet("stream", "<text>", stderr)
+	opts := addServerFlags(flags)
+	message := addMessageFlags(flags)
+	rest, err := parseFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	// a stream lasts as long as the task runs, so only the context of the command ends it
+	opts.timeout = 0
+	stream, err := opts.newClient().OpenTaskStream(ctx, message.params(rest[0]))
+	if err != nil {
+		return fmt.Errorf("failed to stream message: %w", err)
+	}
+	defer func() { _ = stream.Close() }()
+	return printStream(stream, opts.json, stdout, stderr)
+}
+
+// printStream prints the events of a task stream until it ends: the text of the agent on
+// stdout and the task, its artifacts and its final state on stderr
+func printStream(stream *client.TaskStream, raw bool, stdout, stderr io.Writer) error {
+	var taskID string
+	var streamed bool
+	for response := range stream.Events() {
+		if raw {
+			if err := printJSON(stdout, response.Result); err != nil {
+				return err
+			}
+			continue
+		}
+
+		event, err := client.DecodeTaskEvent(response.Result)
+		if err != nil {
+			continue
+		}
+		switch event := event.(type) {
+		case client.MessageDelta:
+			if taskID == "" && event.TaskID != "" {
+				taskID = event.TaskID
+				fmt.Fprintf(stderr, "task %s (context %s)\n", event.TaskID, event.ContextID)
+			}
+			if event.Message.Role == types.RoleAgent && event.Text != "" {
+				fmt.Fprint(stdout, event.Text)
+				streamed = true
+			}
+		case client.ArtifactUpdate:
+			fmt.Fprintf(stderr, "[artifact %s]\n", artifactLabel(event.Artifact))
+		case client.TaskStatusUpdate:
+			if taskID == "" && event.TaskID != "" {
+				taskID = event.TaskID
+				fmt.Fprintf(stderr, "task %s (context %s)\n", event.TaskID, event.ContextID)
+			}
+			if !event.Final {
+				continue
+			}
+			if streamed {
+				fmt.Fprintln(stdout)
+			} else if text := event.Status.Message.Text(); text != "" {
+				fmt.Fprintln(stdout, text)
+			}
+			fmt.Fprintf(stderr, "[%s]\n", stateName(event.Status.State))
+			streamed = false
+		}
+	}
+	if streamed {
+		fmt.Fprintln(stdout)
+	}
+
+	if err := stream.Err(); err != nil {
+		return fmt.Errorf("stream failed: %w", err)
+	}
+	return nil
+}
+
+// runTasksList lists the tasks of the agent
+func runTasksList(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := newFlagSet("tasks list", "", stderr)
+	opts := addServerFlags(flags)
+	contextID := flags.String("context", "", "list the tasks of the context only")
+	state := flags.String("state", "", "list the tasks in the state only, such as completed or input-required")
+	limit := flags.Int("limit", 50, "maximum number of tasks")
+	if _, err := parseFlags(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	params := types.TaskListParams{Limit: *limit}
+	if *contextID != "" {
+		params.ContextID = contextID
+	}
+	if *state != "" {
+		taskState := parseState(*state)
+		params.State = &taskState
+	}
+
+	response, err := opts.newClient().ListTasks(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+	var list types.TaskList
+	if err := decodeResult(response, &list); err != nil {
+		return err
+	}
+	if opts.json {
+		return printJSON(stdout, list)
+	}
+
+	table := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "TASK\tCONTEXT\tSTATE\tUPDATED")
+	for _, task := range list.Tasks {
+		updated := "-"
+		if task.Status.Timestamp != nil {
+			updated = task.Status.Timestamp.Format(time.RFC3339)
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", task.ID, task.ContextID, stateName(task.Status.State), updated)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	if list.TotalSize > len(list.Tasks) {
+		fmt.Fprintf(stderr, "showing %d of %d tasks\n", len(list.Tasks), list.TotalSize)
+	}
+	return nil
+}
+
+// runTasksGet prints a task with its history and artifacts
+func runTasksGet(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := newFlagSet("tasks get", "<task-id>", stderr)
+	opts := addServerFlags(flags)
+	history := flags.Int("history", -1, "number of history messages to include (-1 for all)")
+	rest, err := parseFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	params := types.TaskQueryParams{ID: rest[0]}
+	if *history >= 0 {
+		params.HistoryLength = history
+	}
+	a2aClient := opts.newClient()
+	response, err := a2aClient.GetTask(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+	task, err := a2aClient.GetArtifactHelper().ExtractTaskFromResponse(response)
+	if err != nil {
+		return err
+	}
+	if opts.json {
+		return printJSON(stdout, task)
+	}
+
+	fmt.Fprintf(stdout, "Task:    %s\nContext: %s\nState:   %s\n", task.ID, task.ContextID, stateName(task.Status.State))
+	if len(task.History) > 0 {
+		fmt.Fprintln(stdout, "\nHistory:")
+		for _, message := range task.History {
+			fmt.Fprintf(stdout, "  %s: %s\n", roleName(message.Role), message.Text())
+		}
+	}
+	if len(task.Artifacts) > 0 {
+		fmt.Fprintln(stdout, "\nArtifacts:")
+		for _, artifact := range task.Artifacts {
+			fmt.Fprintf(stdout, "  %s\n", artifactLabel(artifact))
+		}
+	}
+	return nil
+}
+
+// runArtifactsDownload downloads the file parts of the artifacts of a task
+func runArtifactsDownload(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := newFlagSet("artifacts download", "<task-id>", stderr)
+	opts := addServerFlags(flags)
+	outputDir := flags.String("out", ".", "directory the files are saved to")
+	artifactID := flags.String("artifact", "", "download the artifact with the ID only")
+	overwrite := flags.Bool("overwrite", false, "overwrite existing files")
+	rest, err := parseFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	a2aClient := opts.newClient()
+	response, err := a2aClient.GetTask(ctx, types.TaskQueryParams{ID: rest[0]})
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+	helper := a2aClient.GetArtifactHelper()
+	task, err := helper.ExtractTaskFromResponse(response)
+	if err != nil {
+		return err
+	}
+
+	config := &client.DownloadConfig{
+		OutputDir:         *outputDir,
+		HTTPClient:        opts.httpClient(),
+		OverwriteExisting: *overwrite,
+	}
+	var downloaded int
+	for _, artifact := range task.Artifacts {
+		if *artifactID != "" && artifact.ArtifactID != *artifactID {
+			continue
+		}
+		files, err := helper.ExtractFileDataFromArtifact(&artifact)
+		if err != nil {
+			return fmt.Errorf("failed to read artifact %s: %w", artifact.ArtifactID, err)
+		}
+		for _, file := range files {
+			result, err := helper.DownloadFileData(ctx, file, config)
+			if err != nil {
+				return fmt.Errorf("failed to download %s of artifact %s: %w", file.GetFileName(), artifact.ArtifactID, err)
+			}
+			fmt.Fprintf(stdout, "%s (%d bytes)\n", result.FilePath, result.BytesWritten)
+			downloaded++
+		}
+	}
+
+	if downloaded == 0 {
+		if *artifactID != "" {
+			return fmt.Errorf("task %s has no file artifact %s", task.ID, *artifactID)
+		}
+		fmt.Fprintf(stderr, "task %s has no file artifacts\n", task.ID)
+	}
+	return nil
+}
+
+// printTaskReply prints the reply of the agent in the status of the task, and the state of
+// the task and its artifacts on stderr
+func printTaskReply(stdout, stderr io.Writer, task *types.Task) {
+	fmt.Fprintf(stderr, "task %s (context %s) [%s]\n", task.ID, task.ContextID, stateName(task.Status.State))
+	if text := task.Status.Message.Text(); text != "" {
+		fmt.Fprintln(stdout, text)
+	}
+	for _, artifact := range task.Artifacts {
+		fmt.Fprintf(stderr, "[artifact %s]\n", artifactLabel(artifact))
+	}
+}
+
+// decodeResult decodes the result of a JSON-RPC response
+func decodeResult(response *types.JSONRPCSuccessResponse, result any) error {
+	data, err := json.Marshal(response.Result)
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("failed to decode result: %w", err)
+	}
+	return nil
+}
+
+// artifactLabel describes an artifact by its ID, name and parts
+func artifactLabel(artifact types.Artifact) string {
+	label := artifact.ArtifactID
+	if artifact.Name != nil && *artifact.Name != "" {
+		label += " " + *artifact.Name
+	}
+	var files []string
+	for _, part := range artifact.Parts {
+		if part.File != nil {
+			name := part.File.Name
+			if name == "" {
+				name = "unnamed file"
+			}
+			files = append(files, name)
+		}
+	}
+	if len(files) > 0 {
+		label += " (" + strings.Join(files, ", ") + ")"
+	}
+	return label
+}
+
+// parseState returns the task state named in lower case, such as input-required, or the
+// name itself when it already is a task state such as TASK_STATE_COMPLETED
+func parseState(name string) types.TaskState {
+	if strings.HasPrefix(name, "TASK_STATE_") {
+		return types.TaskState(name)
+	}
+	return types.TaskState("TASK_STATE_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")))
+}
+
+// stateName returns the task state in lower case, such as input-required
+func stateName(state types.TaskState) string {
+	name := strings.TrimPrefix(string(state), "TASK_STATE_")
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+}
+
+// roleName returns the role of a message in lower case, such as user
+func roleName(role types.Role) string {
+	return strings.ToLower(strings.TrimPrefix(string(role), "ROLE_"))
+}
@@ -0,0 +1,96 @@
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// ValidateAgentCard returns the problems of the agent card JSON: unknown or missing fields,
+// invalid URLs and media types, and skills without an ID or with a duplicate one
+func ValidateAgentCard(data []byte) []string {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var card types.AgentCard
+	if err := decoder.Decode(&card); err != nil {
+		return []string{fmt.Sprintf("invalid agent card JSON: %v", err)}
+	}
+
+	var problems []string
+	required := map[string]string{
+		"name":            card.Name,
+		"description":     card.Description,
+		"version":         card.Version,
+		"protocolVersion": card.ProtocolVersion,
+	}
+	for _, field := range []string{"name", "description", "version", "protocolVersion"} {
+		if required[field] == "" {
+			problems = append(problems, fmt.Sprintf("%s is required", field))
+		}
+	}
+
+	switch {
+	case card.URL != nil:
+		if problem := validateURL("url", *card.URL); problem != "" {
+			problems = append(problems, problem)
+		}
+	case len(card.SupportedInterfaces) == 0:
+		problems = append(problems, "url or supportedInterfaces is required")
+	}
+	for i, iface := range card.SupportedInterfaces {
+		if problem := validateURL(fmt.Sprintf("supportedInterfaces[%d].url", i), iface.URL); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+
+	problems = append(problems, validateModes("defaultInputModes", card.DefaultInputModes, true)...)
+	problems = append(problems, validateModes("defaultOutputModes", card.DefaultOutputModes, true)...)
+
+	skillIDs := make(map[string]bool, len(card.Skills))
+	for i, skill := range card.Skills {
+		field := fmt.Sprintf("skills[%d]", i)
+		switch {
+		case skill.ID == "":
+			problems = append(problems, field+".id is required")
+		case skillIDs[skill.ID]:
+			problems = append(problems, fmt.Sprintf("%s.id '%s' is not unique", field, skill.ID))
+		}
+		skillIDs[skill.ID] = true
+		if skill.Name == "" {
+			problems = append(problems, field+".name is required")
+		}
+		if skill.Description == "" {
+			problems = append(problems, field+".description is required")
+		}
+		problems = append(problems, validateModes(field+".inputModes", skill.InputModes, false)...)
+		problems = append(problems, validateModes(field+".outputModes", skill.OutputModes, false)...)
+	}
+	return problems
+}
+
+// validateURL returns the problem of a URL field that is not an absolute HTTP URL
+func validateURL(field, value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Sprintf("%s '%s' must be an absolute http or https URL", field, value)
+	}
+	return ""
+}
+
+// validateModes returns the problems of a list of media types
+func validateModes(field string, modes []string, required bool) []string {
+	if required && len(modes) == 0 {
+		return []string{field + " must list at least one media type"}
+	}
+	var problems []string
+	for _, mode := range modes {
+		if _, _, err := mime.ParseMediaType(mode); err != nil {
+			problems = append(problems, fmt.Sprintf("%s has invalid media type '%s'", field, mode))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,49 @@
+package conformance
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+func TestValidateAgentCard(t *testing.T) {
+	tests := []struct {
+		name     string
+		card     string
+		expected []string
+	}{
+		{
+			name: "valid",
+			card: `{"name":"a","description":"d","version":"1","protocolVersion":"0.3.0","url":"https://agent.example.com",
+				"capabilities":{},"defaultInputModes":["text/plain"],"defaultOutputModes":["application/json"],
+				"skills":[{"id":"s","name":"s","description":"d","tags":[]}]}`,
+		},
+		{
+			name:     "unknown field",
+			card:     `{"name":"a","default_input_modes":["text/plain"]}`,
+			expected: []string{`invalid agent card JSON: json: unknown field "default_input_modes"`},
+		},
+		{
+			name: "missing and invalid fields",
+			card: `{"name":"a","url":"localhost:8080","capabilities":{},"defaultInputModes":["text plain"],"defaultOutputModes":[],
+				"skills":[{"id":"s","name":"s","description":"d","tags":[]},{"id":"s","tags":[],"inputModes":["image/*"]}]}`,
+			expected: []string{
+				"description is required",
+				"version is required",
+				"protocolVersion is required",
+				"url 'localhost:8080' must be an absolute http or https URL",
+				"defaultInputModes has invalid media type 'text plain'",
+				"defaultOutputModes must list at least one media type",
+				"skills[1].id 's' is not unique",
+				"skills[1].name is required",
+				"skills[1].description is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ValidateAgentCard([]byte(tt.card)))
+		})
+	}
+}
@@ -0,0 +1,207 @@
+// Package conformance runs scenarios against a running A2A server and reports where it strays
+// from the protocol: the agent card, message/send, message/stream, resuming a task that
+// requires input, tasks/cancel, artifacts and the JSON-RPC error codes. It helps anyone writing
+// custom task handlers check that their server still speaks A2A.
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTimeout bounds each scenario when the configuration sets no timeout
+	DefaultTimeout = 30 * time.Second
+
+	// DefaultMessage is the text sent to the agent when the configuration sets none
+	DefaultMessage = "Hello, this is an A2A conformance check."
+)
+
+// Config configures a conformance run
+type Config struct {
+	// URL is the base URL of the server, serving the agent card under /.well-known
+	URL string
+
+	// Endpoint is the URL of the JSON-RPC endpoint (default: URL + "/a2a")
+	Endpoint string
+
+	// HTTPClient sends the requests, such as with credentials (default: http.DefaultClient).
+	// It should not set a timeout, which would cut streams short; use Timeout instead.
+	HTTPClient *http.Client
+
+	// Timeout bounds each scenario (default: DefaultTimeout)
+	Timeout time.Duration
+
+	// Message is the text of the messages the agent is expected to complete a task for
+	// (default: DefaultMessage)
+	Message string
+
+	// InputRequiredMessage is a message the agent answers by asking for more input. The
+	// input-required scenario is skipped when it is empty.
+	InputRequiredMessage string
+
+	// ArtifactMessage is a message the agent answers with at least one artifact. The
+	// artifacts scenario is skipped when it is empty.
+	ArtifactMessage string
+
+	// Scenarios lists the names of the scenarios to run (default: all of them)
+	Scenarios []string
+}
+
+// Status is the outcome of a scenario
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+// Result is the outcome of a scenario, with the spec violations it found
+type Result struct {
+	Scenario   string        `json:"scenario"`
+	Status     Status        `json:"status"`
+	Violations []string      `json:"violations,omitempty"`
+	SkipReason string        `json:"skipReason,omitempty"`
+	Duration   time.Duration `json:"duration"`
+}
+
+// Report is the outcome of a conformance run
+type Report struct {
+	URL     string   `json:"url"`
+	Results []Result `json:"results"`
+}
+
+// Passed reports whether no scenario failed
+func (r *Report) Passed() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the results of the scenarios that found violations
+func (r *Report) Failed() []Result {
+	var failed []Result
+	for _, result := range r.Results {
+		if result.Status == StatusFailed {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Scenario is a conformance check of one part of the protocol
+type Scenario struct {
+	Name        string
+	Description string
+	run         func(ctx context.Context, c *check)
+}
+
+// Scenarios returns the scenarios of the suite, in the order they run
+func Scenarios() []Scenario {
+	return []Scenario{
+		{Name: "agent-card", Description: "the agent card is served and valid", run: checkAgentCard},
+		{Name: "message-send", Description: "message/send creates a task that completes", run: checkMessageSend},
+		{Name: "message-stream", Description: "message/stream streams the events of a task up to a final status", run: checkMessageStream},
+		{Name: "input-required", Description: "a task paused for input resumes when answered", run: checkInputRequired},
+		{Name: "cancel", Description: "tasks/cancel cancels a task and rejects tasks that cannot be canceled", run: checkCancel},
+		{Name: "artifacts", Description: "artifacts carry an ID and well-formed parts", run: checkArtifacts},
+		{Name: "error-codes", Description: "invalid requests are answered with the JSON-RPC error codes of the spec", run: checkErrorCodes},
+	}
+}
+
+// Run runs the scenarios of the configuration against the server and reports their outcome.
+// It returns an error only when the configuration is invalid; violations are in the report.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	base, err := url.Parse(cfg.URL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("server URL '%s' must be an absolute URL", cfg.URL)
+	}
+	cfg.URL = strings.TrimSuffix(cfg.URL, "/")
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = cfg.URL + "/a2a"
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.Message == "" {
+		cfg.Message = DefaultMessage
+	}
+
+	scenarios := Scenarios()
+	if len(cfg.Scenarios) > 0 {
+		var selected []Scenario
+		for _, name := range cfg.Scenarios {
+			index := slices.IndexFunc(scenarios, func(s Scenario) bool { return s.Name == name })
+			if index < 0 {
+				return nil, fmt.Errorf("unknown scenario '%s'", name)
+			}
+			selected = append(selected, scenarios[index])
+		}
+		scenarios = selected
+	}
+
+	session := &session{cfg: cfg}
+	report := &Report{URL: cfg.URL}
+	for _, scenario := range scenarios {
+		report.Results = append(report.Results, session.run(ctx, scenario))
+	}
+	return report, nil
+}
+
+// session holds the state shared by the scenarios of a run
+type session struct {
+	cfg      Config
+	requests int
+}
+
+// run runs a scenario within the timeout of the configuration
+func (s *session) run(ctx context.Context, scenario Scenario) Result {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	started := time.Now()
+	c := &check{session: s}
+	scenario.run(ctx, c)
+
+	result := Result{Scenario: scenario.Name, Duration: time.Since(started), Violations: c.violations}
+	switch {
+	case len(c.violations) > 0:
+		result.Status = StatusFailed
+	case c.skipReason != "":
+		result.Status = StatusSkipped
+		result.SkipReason = c.skipReason
+	default:
+		result.Status = StatusPassed
+	}
+	return result
+}
+
+// nextID returns a unique ID for a request or message of the run
+func (s *session) nextID(kind string) string {
+	s.requests++
+	return fmt.Sprintf("conformance-%s-%d", kind, s.requests)
+}
+
+// check collects the violations found by a scenario
+type check struct {
+	*session
+	violations []string
+	skipReason string
+}
+
+// violation records a way the server strays from the protocol
+func (c *check) violation(format string, args ...any) {
+	c.violations = append(c.violations, fmt.Sprintf(format, args...))
+}
+
+// skip records why the scenario does not apply to the server
+func (c *check) skip(reason string) {
+	c.skipReason = reason
+}
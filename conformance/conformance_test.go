@@ -0,0 +1,93 @@
+package conformance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	adktesting "github.com/inference-gateway/adk/testing"
+)
+
+const askForInput = "Book me a flight"
+
+// promptLLM asks for input when prompted with askForInput, and replies otherwise
+type promptLLM struct{}
+
+func (promptLLM) respond(messages []sdk.Message) *adktesting.FakeLLMClient {
+	request := adktesting.LLMRequest{Messages: messages}
+	if request.LastMessage() == askForInput {
+		return adktesting.NewFakeLLMClient(adktesting.CallTool("input_required", map[string]any{"message": "Where to?"}))
+	}
+	return adktesting.NewFakeLLMClient(adktesting.Reply("Done."))
+}
+
+func (l promptLLM) CreateChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (*sdk.CreateChatCompletionResponse, error) {
+	return l.respond(messages).CreateChatCompletion(ctx, messages, tools...)
+}
+
+func (l promptLLM) CreateStreamingChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+	return l.respond(messages).CreateStreamingChatCompletion(ctx, messages, tools...)
+}
+
+func TestRun_ReferenceServer(t *testing.T) {
+	s := adktesting.NewServer(t, adktesting.NewAgent(t, promptLLM{}))
+
+	report, err := Run(context.Background(), Config{
+		URL:                  s.URL,
+		Timeout:              10 * time.Second,
+		InputRequiredMessage: askForInput,
+	})
+	require.NoError(t, err)
+
+	statuses := make(map[string]Status)
+	for _, result := range report.Results {
+		assert.Empty(t, result.Violations, result.Scenario)
+		statuses[result.Scenario] = result.Status
+	}
+	assert.True(t, report.Passed())
+	assert.Len(t, statuses, len(Scenarios()))
+	assert.Equal(t, StatusSkipped, statuses["artifacts"])
+	assert.Equal(t, StatusPassed, statuses["input-required"])
+	assert.Equal(t, StatusPassed, statuses["message-stream"])
+}
+
+func TestRun_ReportsViolations(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/agent-card.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"broken"}`))
+	})
+	mux.HandleFunc("/a2a", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"unsupported"}`))
+	})
+	broken := httptest.NewServer(mux)
+	defer broken.Close()
+
+	report, err := Run(context.Background(), Config{
+		URL:       broken.URL,
+		Timeout:   5 * time.Second,
+		Scenarios: []string{"agent-card", "message-send", "error-codes"},
+	})
+	require.NoError(t, err)
+	assert.False(t, report.Passed())
+
+	failed := report.Failed()
+	require.Len(t, failed, 3)
+	assert.Contains(t, failed[0].Violations, "agent card: description is required")
+	assert.Contains(t, failed[1].Violations, "message/send: HTTP status is 400, JSON-RPC responses are sent with 200")
+}
+
+func TestRun_InvalidConfig(t *testing.T) {
+	_, err := Run(context.Background(), Config{URL: "localhost:8080"})
+	assert.EqualError(t, err, "server URL 'localhost:8080' must be an absolute URL")
+
+	_, err = Run(context.Background(), Config{URL: "http://localhost:8080", Scenarios: []string{"push"}})
+	assert.EqualError(t, err, "unknown scenario 'push'")
+}
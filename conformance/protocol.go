@@ -0,0 +1,365 @@
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// pollInterval is the interval between tasks/get calls while waiting for a task to settle
+const pollInterval = 100 * time.Millisecond
+
+// response is a JSON-RPC response, kept raw to check its envelope
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *responseError  `json:"error"`
+}
+
+// responseError is the error of a JSON-RPC response
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// validStates are the states a task may report, with whether the task has settled in them
+var validStates = map[types.TaskState]bool{
+	types.TaskStateSubmitted:     false,
+	types.TaskStateWorking:       false,
+	types.TaskStateInputRequired: true,
+	types.TaskStateAuthRequired:  true,
+	types.TaskStateCompleted:     true,
+	types.TaskStateCancelled:     true,
+	types.TaskStateFailed:        true,
+	types.TaskStateRejected:      true,
+}
+
+// request encodes a JSON-RPC request with a new ID, returning the ID
+func (c *check) request(method string, params any) ([]byte, string) {
+	id := c.nextID("request")
+	body, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": id, "method": method, "params": params})
+	return body, id
+}
+
+// post sends a body to the JSON-RPC endpoint
+func (c *check) post(ctx context.Context, body []byte, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", accept)
+	return c.cfg.HTTPClient.Do(req)
+}
+
+// call sends a JSON-RPC request and returns its response, recording the violations of the
+// response envelope. It returns nil when no response could be decoded.
+func (c *check) call(ctx context.Context, method string, params any) *response {
+	body, id := c.request(method, params)
+	return c.send(ctx, method, body, id)
+}
+
+// send posts a raw JSON-RPC body and decodes the response, expecting the given ID back
+func (c *check) send(ctx context.Context, method string, body []byte, id any) *response {
+	resp, err := c.post(ctx, body, "application/json")
+	if err != nil {
+		c.violation("%s: request failed: %v", method, err)
+		return nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		c.violation("%s: HTTP status is %d, JSON-RPC responses are sent with 200", method, resp.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "application/json" {
+		c.violation("%s: Content-Type is '%s', expected application/json", method, resp.Header.Get("Content-Type"))
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.violation("%s: failed to read response: %v", method, err)
+		return nil
+	}
+	return c.decodeResponse(method, data, id)
+}
+
+// decodeResponse decodes a JSON-RPC response, recording the violations of its envelope
+func (c *check) decodeResponse(method string, data []byte, id any) *response {
+	var decoded response
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		c.violation("%s: response is not a JSON-RPC response: %v", method, err)
+		return nil
+	}
+	if decoded.JSONRPC != "2.0" {
+		c.violation("%s: response jsonrpc is '%s', expected 2.0", method, decoded.JSONRPC)
+	}
+	expectedID, _ := json.Marshal(id)
+	if !bytes.Equal(compactJSON(decoded.ID), expectedID) {
+		c.violation("%s: response id %s does not match the request id %s", method, orNull(decoded.ID), expectedID)
+	}
+	hasResult := len(decoded.Result) > 0 && string(decoded.Result) != "null"
+	switch {
+	case hasResult && decoded.Error != nil:
+		c.violation("%s: response has both a result and an error", method)
+	case !hasResult && decoded.Error == nil:
+		c.violation("%s: response has neither a result nor an error", method)
+	}
+	return &decoded
+}
+
+// expectResult returns the result of a response, recording a violation when it is an error
+func (c *check) expectResult(method string, resp *response) json.RawMessage {
+	if resp == nil {
+		return nil
+	}
+	if resp.Error != nil {
+		c.violation("%s: unexpected error %d: %s", method, resp.Error.Code, resp.Error.Message)
+		return nil
+	}
+	return resp.Result
+}
+
+// expectError records a violation unless the response is an error with the code
+func (c *check) expectError(what string, resp *response, code int) {
+	switch {
+	case resp == nil:
+	case resp.Error == nil:
+		c.violation("%s: expected error %d, got a result", what, code)
+	case resp.Error.Code != code:
+		c.violation("%s: expected error %d, got %d (%s)", what, code, resp.Error.Code, resp.Error.Message)
+	}
+}
+
+// userMessage creates a user message with the text, continuing the task when taskID is set
+func (c *check) userMessage(text, taskID, contextID string) types.Message {
+	message := types.Message{
+		MessageID: c.nextID("message"),
+		Role:      types.RoleUser,
+		Parts:     []types.Part{types.CreateTextPart(text)},
+	}
+	if taskID != "" {
+		message.TaskID = &taskID
+	}
+	if contextID != "" {
+		message.ContextID = &contextID
+	}
+	return message
+}
+
+// sendMessage sends a message with message/send and returns the task it created, or nil when
+// the agent answered with a message or the call failed
+func (c *check) sendMessage(ctx context.Context, message types.Message) *types.Task {
+	result := c.expectResult("message/send", c.call(ctx, "message/send", types.MessageSendParams{Message: message}))
+	if result == nil {
+		return nil
+	}
+
+	switch resultKind(result) {
+	case types.StreamResultKindTask:
+		var task types.Task
+		if err := json.Unmarshal(result, &task); err != nil {
+			c.violation("message/send: invalid task: %v", err)
+			return nil
+		}
+		c.checkTask("message/send", &task)
+		return &task
+	case types.StreamResultKindMessage:
+		var reply types.Message
+		if err := json.Unmarshal(result, &reply); err != nil {
+			c.violation("message/send: invalid message: %v", err)
+			return nil
+		}
+		c.checkMessage("message/send", &reply)
+		return nil
+	}
+	c.violation("message/send: result is neither a task nor a message")
+	return nil
+}
+
+// getTask retrieves a task with tasks/get
+func (c *check) getTask(ctx context.Context, taskID string) *types.Task {
+	result := c.expectResult("tasks/get", c.call(ctx, "tasks/get", types.TaskQueryParams{ID: taskID}))
+	if result == nil {
+		return nil
+	}
+	var task types.Task
+	if err := json.Unmarshal(result, &task); err != nil {
+		c.violation("tasks/get: invalid task: %v", err)
+		return nil
+	}
+	c.checkTask("tasks/get", &task)
+	if task.ID != taskID {
+		c.violation("tasks/get: returned task %s for task %s", task.ID, taskID)
+	}
+	return &task
+}
+
+// waitForTask polls a task until it completes, fails or pauses, recording a violation when it
+// does not settle before the scenario times out
+func (c *check) waitForTask(ctx context.Context, task *types.Task) *types.Task {
+	for {
+		if settled, valid := validStates[task.Status.State]; settled || !valid {
+			return task
+		}
+		select {
+		case <-ctx.Done():
+			c.violation("task %s did not settle before the timeout, last state %s", task.ID, task.Status.State)
+			return nil
+		case <-time.After(pollInterval):
+		}
+		if task = c.getTask(ctx, task.ID); task == nil {
+			return nil
+		}
+	}
+}
+
+// checkTask records the violations of a task
+func (c *check) checkTask(where string, task *types.Task) {
+	if task.ID == "" {
+		c.violation("%s: task has no id", where)
+	}
+	if task.ContextID == "" {
+		c.violation("%s: task %s has no contextId", where, task.ID)
+	}
+	if _, valid := validStates[task.Status.State]; !valid {
+		c.violation("%s: task %s has invalid state '%s'", where, task.ID, task.Status.State)
+	}
+	if task.Status.Message != nil {
+		c.checkMessage(fmt.Sprintf("%s: task %s status", where, task.ID), task.Status.Message)
+	}
+	for i := range task.History {
+		c.checkMessage(fmt.Sprintf("%s: task %s history[%d]", where, task.ID, i), &task.History[i])
+	}
+	artifactIDs := make(map[string]bool, len(task.Artifacts))
+	for i := range task.Artifacts {
+		artifact := &task.Artifacts[i]
+		if artifactIDs[artifact.ArtifactID] {
+			c.violation("%s: task %s has several artifacts with id '%s'", where, task.ID, artifact.ArtifactID)
+		}
+		artifactIDs[artifact.ArtifactID] = true
+		c.checkArtifact(fmt.Sprintf("%s: task %s artifacts[%d]", where, task.ID, i), artifact)
+	}
+}
+
+// checkMessage records the violations of a message
+func (c *check) checkMessage(where string, message *types.Message) {
+	if message.MessageID == "" {
+		c.violation("%s: message has no messageId", where)
+	}
+	if message.Role != types.RoleUser && message.Role != types.RoleAgent {
+		c.violation("%s: message has invalid role '%s'", where, message.Role)
+	}
+	if len(message.Parts) == 0 {
+		c.violation("%s: message has no parts", where)
+	}
+	for i, part := range message.Parts {
+		c.checkPart(fmt.Sprintf("%s parts[%d]", where, i), part)
+	}
+}
+
+// checkArtifact records the violations of an artifact
+func (c *check) checkArtifact(where string, artifact *types.Artifact) {
+	if artifact.ArtifactID == "" {
+		c.violation("%s: artifact has no artifactId", where)
+	}
+	if len(artifact.Parts) == 0 {
+		c.violation("%s: artifact has no parts", where)
+	}
+	for i, part := range artifact.Parts {
+		c.checkPart(fmt.Sprintf("%s parts[%d]", where, i), part)
+	}
+}
+
+// checkPart records the violations of a part: it must hold exactly one of text, a file or
+// data, and a file must carry either valid base64 bytes or an absolute URI
+func (c *check) checkPart(where string, part types.Part) {
+	contents := 0
+	for _, set := range []bool{part.Text != nil, part.File != nil, part.Data != nil} {
+		if set {
+			contents++
+		}
+	}
+	if contents != 1 {
+		c.violation("%s: part must hold exactly one of text, file or data", where)
+		return
+	}
+	if part.File == nil {
+		return
+	}
+
+	file := part.File
+	switch {
+	case (file.FileWithBytes == nil) == (file.FileWithURI == nil):
+		c.violation("%s: file must hold exactly one of fileWithBytes or fileWithUri", where)
+	case file.FileWithBytes != nil:
+		if _, err := base64.StdEncoding.DecodeString(*file.FileWithBytes); err != nil {
+			c.violation("%s: fileWithBytes is not valid base64", where)
+		}
+	default:
+		if parsed, err := url.Parse(*file.FileWithURI); err != nil || !parsed.IsAbs() {
+			c.violation("%s: fileWithUri '%s' is not an absolute URI", where, *file.FileWithURI)
+		}
+	}
+	if file.MediaType != "" {
+		if _, _, err := mime.ParseMediaType(file.MediaType); err != nil {
+			c.violation("%s: file has invalid media type '%s'", where, file.MediaType)
+		}
+	}
+}
+
+// resultKind returns the kind of a result: the value of its "kind" field, or the kind its
+// fields imply for servers that omit it
+func resultKind(result json.RawMessage) string {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(result, &probe); err != nil {
+		return ""
+	}
+	if raw, exists := probe["kind"]; exists {
+		var kind string
+		_ = json.Unmarshal(raw, &kind)
+		return kind
+	}
+
+	_, hasArtifact := probe["artifact"]
+	_, hasStatus := probe["status"]
+	_, hasTaskID := probe["taskId"]
+	_, hasID := probe["id"]
+	_, hasMessageID := probe["messageId"]
+	switch {
+	case hasArtifact:
+		return types.StreamResultKindArtifactUpdate
+	case hasStatus && hasTaskID:
+		return types.StreamResultKindStatusUpdate
+	case hasStatus && hasID:
+		return types.StreamResultKindTask
+	case hasMessageID:
+		return types.StreamResultKindMessage
+	}
+	return ""
+}
+
+// compactJSON removes the insignificant whitespace of a JSON value
+func compactJSON(data json.RawMessage) []byte {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, data); err != nil {
+		return data
+	}
+	return compacted.Bytes()
+}
+
+// orNull returns the JSON value, or null when it is missing
+func orNull(data json.RawMessage) string {
+	if len(data) == 0 {
+		return "null"
+	}
+	return string(data)
+}
@@ -0,0 +1,406 @@
+package conformance
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// agentCardPath is the well-known path of the agent card
+const agentCardPath = "/.well-known/agent-card.json"
+
+// maxStreamEventSize is the size of the largest stream event read
+const maxStreamEventSize = 4 << 20
+
+// streamTerminator is the data of the event that ends a stream
+const streamTerminator = "[DONE]"
+
+// Error codes of the JSON-RPC and A2A specifications
+const (
+	codeParseError        = -32700
+	codeInvalidRequest    = -32600
+	codeMethodNotFound    = -32601
+	codeInvalidParams     = -32602
+	codeTaskNotFound      = -32001
+	codeTaskNotCancelable = -32002
+)
+
+// checkAgentCard checks that the agent card is served as JSON and is valid
+func checkAgentCard(ctx context.Context, c *check) {
+	data, err := c.fetchAgentCard(ctx)
+	if err != nil {
+		c.violation("agent card: %v", err)
+		return
+	}
+	for _, problem := range ValidateAgentCard(data) {
+		c.violation("agent card: %s", problem)
+	}
+}
+
+// fetchAgentCard retrieves the agent card served by the server
+func (c *check) fetchAgentCard(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.URL+agentCardPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s answered with HTTP status %d", agentCardPath, resp.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "application/json" {
+		return nil, fmt.Errorf("Content-Type is '%s', expected application/json", resp.Header.Get("Content-Type"))
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// checkMessageSend checks that message/send creates a task that completes
+func checkMessageSend(ctx context.Context, c *check) {
+	task := c.sendMessage(ctx, c.userMessage(c.cfg.Message, "", ""))
+	if task == nil {
+		return
+	}
+	settled := c.waitForTask(ctx, task)
+	if settled == nil {
+		return
+	}
+	if settled.ContextID != task.ContextID {
+		c.violation("tasks/get: task %s moved from context %s to %s", task.ID, task.ContextID, settled.ContextID)
+	}
+	c.expectState(settled, types.TaskStateCompleted)
+}
+
+// checkMessageStream checks that message/stream streams well-formed events of a single task,
+// ending with a final status update that tasks/get agrees with
+func checkMessageStream(ctx context.Context, c *check) {
+	data, err := c.fetchAgentCard(ctx)
+	if err != nil {
+		c.skip(fmt.Sprintf("the agent card could not be retrieved: %v", err))
+		return
+	}
+	var card types.AgentCard
+	if err := json.Unmarshal(data, &card); err != nil || card.Capabilities.Streaming == nil || !*card.Capabilities.Streaming {
+		c.skip("the agent card does not advertise streaming")
+		return
+	}
+
+	body, id := c.request("message/stream", types.MessageSendParams{Message: c.userMessage(c.cfg.Message, "", "")})
+	resp, err := c.post(ctx, body, "text/event-stream")
+	if err != nil {
+		c.violation("message/stream: request failed: %v", err)
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/event-stream" {
+		c.violation("message/stream: Content-Type is '%s', expected text/event-stream", resp.Header.Get("Content-Type"))
+		return
+	}
+
+	stream := &streamCheck{check: c}
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxStreamEventSize)
+	var event strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if data, ok := strings.CutPrefix(line, "data:"); ok {
+			if event.Len() > 0 {
+				event.WriteByte('\n')
+			}
+			event.WriteString(strings.TrimPrefix(data, " "))
+			continue
+		}
+		if line == "" && event.Len() > 0 {
+			stream.event(event.String(), id)
+			event.Reset()
+		}
+	}
+	if event.Len() > 0 {
+		stream.event(event.String(), id)
+	}
+	if err := scanner.Err(); err != nil {
+		c.violation("message/stream: stream broke off: %v", err)
+		return
+	}
+	stream.end(ctx)
+}
+
+// streamCheck follows the events of a message/stream response
+type streamCheck struct {
+	*check
+	events     int
+	taskID     string
+	message    bool
+	final      bool
+	finalState types.TaskState
+}
+
+// event checks an event of the stream. The [DONE] terminator some servers end streams with is
+// not an event.
+func (s *streamCheck) event(data string, id string) {
+	if data == streamTerminator {
+		return
+	}
+	s.events++
+	where := fmt.Sprintf("message/stream event %d", s.events)
+	resp := s.decodeResponse(where, []byte(data), id)
+	if resp == nil {
+		return
+	}
+	if resp.Error != nil {
+		s.violation("%s: error %d: %s", where, resp.Error.Code, resp.Error.Message)
+		return
+	}
+	if s.final || s.message {
+		s.violation("%s: event streamed after the final one", where)
+	}
+
+	var taskID string
+	switch kind := resultKind(resp.Result); kind {
+	case types.StreamResultKindTask:
+		var task types.Task
+		if err := json.Unmarshal(resp.Result, &task); err != nil {
+			s.violation("%s: invalid task: %v", where, err)
+			return
+		}
+		s.checkTask(where, &task)
+		taskID = task.ID
+	case types.StreamResultKindMessage:
+		var message types.Message
+		if err := json.Unmarshal(resp.Result, &message); err != nil {
+			s.violation("%s: invalid message: %v", where, err)
+			return
+		}
+		s.checkMessage(where, &message)
+		if s.events > 1 {
+			s.violation("%s: a message may only be streamed as the single event of a stream", where)
+		}
+		s.message = true
+	case types.StreamResultKindStatusUpdate:
+		var update types.TaskStatusUpdateEvent
+		if err := json.Unmarshal(resp.Result, &update); err != nil {
+			s.violation("%s: invalid status update: %v", where, err)
+			return
+		}
+		if update.ContextID == "" {
+			s.violation("%s: status update has no contextId", where)
+		}
+		settled, valid := validStates[update.Status.State]
+		switch {
+		case !valid:
+			s.violation("%s: status update has invalid state '%s'", where, update.Status.State)
+		case update.Final && !settled:
+			s.violation("%s: final status update in state %s, which is not final", where, update.Status.State)
+		case !update.Final && settled:
+			s.violation("%s: status update in state %s is not marked final", where, update.Status.State)
+		}
+		if update.Status.Message != nil {
+			s.checkMessage(where, update.Status.Message)
+		}
+		if update.Final {
+			s.final = true
+			s.finalState = update.Status.State
+		}
+		taskID = update.TaskID
+	case types.StreamResultKindArtifactUpdate:
+		var update types.TaskArtifactUpdateEvent
+		if err := json.Unmarshal(resp.Result, &update); err != nil {
+			s.violation("%s: invalid artifact update: %v", where, err)
+			return
+		}
+		s.checkArtifact(where, &update.Artifact)
+		taskID = update.TaskID
+	default:
+		s.violation("%s: result of unknown kind '%s'", where, kind)
+		return
+	}
+
+	if !s.message && taskID == "" {
+		s.violation("%s: event has no task ID", where)
+	}
+	if s.taskID == "" {
+		s.taskID = taskID
+	} else if taskID != "" && taskID != s.taskID {
+		s.violation("%s: event of task %s in the stream of task %s", where, taskID, s.taskID)
+	}
+}
+
+// end checks how the stream ended and that tasks/get agrees with its final status
+func (s *streamCheck) end(ctx context.Context) {
+	switch {
+	case s.events == 0:
+		s.violation("message/stream: stream ended without events")
+		return
+	case s.message:
+		return
+	case !s.final:
+		s.violation("message/stream: stream ended without a final status update")
+		return
+	}
+
+	if s.finalState != types.TaskStateCompleted {
+		s.violation("message/stream: task %s ended in state %s, expected %s", s.taskID, s.finalState, types.TaskStateCompleted)
+	}
+	if s.taskID == "" {
+		return
+	}
+	if task := s.getTask(ctx, s.taskID); task != nil && task.Status.State != s.finalState {
+		s.violation("tasks/get: streamed task %s is in state %s, the stream ended in %s", s.taskID, task.Status.State, s.finalState)
+	}
+}
+
+// checkInputRequired checks that a task paused for input is resumed, as the same task, by a
+// message referencing it
+func checkInputRequired(ctx context.Context, c *check) {
+	if c.cfg.InputRequiredMessage == "" {
+		c.skip("no message that makes the agent ask for input is configured")
+		return
+	}
+
+	task := c.expectTask(ctx, c.userMessage(c.cfg.InputRequiredMessage, "", ""))
+	if task == nil {
+		return
+	}
+	if !c.expectState(task, types.TaskStateInputRequired) {
+		return
+	}
+
+	resumed := c.sendMessage(ctx, c.userMessage(c.cfg.Message, task.ID, task.ContextID))
+	if resumed == nil {
+		return
+	}
+	if resumed.ID != task.ID {
+		c.violation("message/send: answering task %s created task %s instead of resuming it", task.ID, resumed.ID)
+	}
+	if resumed.ContextID != task.ContextID {
+		c.violation("message/send: resumed task %s moved from context %s to %s", task.ID, task.ContextID, resumed.ContextID)
+	}
+	if settled := c.waitForTask(ctx, resumed); settled != nil {
+		c.expectState(settled, types.TaskStateCompleted)
+	}
+}
+
+// checkCancel checks that tasks/cancel cancels a task, and answers with the A2A error codes
+// for tasks that ended or do not exist
+func checkCancel(ctx context.Context, c *check) {
+	task := c.sendMessage(ctx, c.userMessage(c.cfg.Message, "", ""))
+	if task == nil {
+		if len(c.violations) == 0 {
+			c.skip("the agent answers message/send with a message rather than a task")
+		}
+		return
+	}
+
+	resp := c.call(ctx, "tasks/cancel", types.TaskIdParams{ID: task.ID})
+	if resp == nil {
+		return
+	}
+	canceled := false
+	switch {
+	case resp.Error == nil:
+		var result types.Task
+		if err := json.Unmarshal(resp.Result, &result); err != nil {
+			c.violation("tasks/cancel: invalid task: %v", err)
+			return
+		}
+		c.checkTask("tasks/cancel", &result)
+		if result.Status.State != types.TaskStateCancelled {
+			c.violation("tasks/cancel: task %s is in state %s after it was canceled", task.ID, result.Status.State)
+		}
+		canceled = true
+	case resp.Error.Code != codeTaskNotCancelable:
+		c.violation("tasks/cancel: unexpected error %d: %s", resp.Error.Code, resp.Error.Message)
+		return
+	}
+
+	current := c.getTask(ctx, task.ID)
+	if current == nil {
+		return
+	}
+	if canceled && current.Status.State != types.TaskStateCancelled {
+		c.violation("tasks/get: canceled task %s is in state %s", task.ID, current.Status.State)
+	}
+	if current = c.waitForTask(ctx, current); current == nil {
+		return
+	}
+	if current.Status.State != types.TaskStateInputRequired && current.Status.State != types.TaskStateAuthRequired {
+		c.expectError("tasks/cancel of a task that ended", c.call(ctx, "tasks/cancel", types.TaskIdParams{ID: task.ID}), codeTaskNotCancelable)
+	}
+	c.expectError("tasks/cancel of an unknown task", c.call(ctx, "tasks/cancel", types.TaskIdParams{ID: c.nextID("unknown-task")}), codeTaskNotFound)
+}
+
+// checkArtifacts checks that a task answered with artifacts completes with well-formed ones
+func checkArtifacts(ctx context.Context, c *check) {
+	if c.cfg.ArtifactMessage == "" {
+		c.skip("no message that makes the agent create artifacts is configured")
+		return
+	}
+
+	task := c.expectTask(ctx, c.userMessage(c.cfg.ArtifactMessage, "", ""))
+	if task == nil {
+		return
+	}
+	c.expectState(task, types.TaskStateCompleted)
+	if len(task.Artifacts) == 0 {
+		c.violation("tasks/get: task %s has no artifacts", task.ID)
+	}
+}
+
+// checkErrorCodes checks the error codes of invalid requests
+func checkErrorCodes(ctx context.Context, c *check) {
+	c.expectError("invalid JSON", c.send(ctx, "invalid JSON", []byte(`{"jsonrpc": "2.0", "method": `), nil), codeParseError)
+
+	id := c.nextID("request")
+	body, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": id})
+	c.expectError("request without a method", c.send(ctx, "request without a method", body, id), codeInvalidRequest)
+
+	body, id = c.request("conformance/unknown", map[string]any{})
+	c.expectError("unknown method", c.send(ctx, "unknown method", body, id), codeMethodNotFound)
+
+	body, id = c.request("message/send", map[string]any{"message": "not a message"})
+	c.expectError("message/send with invalid params", c.send(ctx, "message/send with invalid params", body, id), codeInvalidParams)
+
+	c.expectError("tasks/get of an unknown task", c.call(ctx, "tasks/get", types.TaskQueryParams{ID: c.nextID("unknown-task")}), codeTaskNotFound)
+}
+
+// expectTask sends a message the agent is expected to answer with a task, and waits for it
+// to settle
+func (c *check) expectTask(ctx context.Context, message types.Message) *types.Task {
+	task := c.sendMessage(ctx, message)
+	if task == nil {
+		if len(c.violations) == 0 {
+			c.violation("message/send: the agent answered with a message, expected a task")
+		}
+		return nil
+	}
+	return c.waitForTask(ctx, task)
+}
+
+// expectState records a violation unless the task is in the state
+func (c *check) expectState(task *types.Task, state types.TaskState) bool {
+	if task.Status.State == state {
+		return true
+	}
+	reason := ""
+	if task.Status.Message != nil {
+		for _, part := range task.Status.Message.Parts {
+			if part.Text != nil {
+				reason = ": " + *part.Text
+				break
+			}
+		}
+	}
+	c.violation("task %s ended in state %s, expected %s%s", task.ID, task.Status.State, state, reason)
+	return false
+}
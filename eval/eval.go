@@ -0,0 +1,323 @@
+// Package eval runs a set of prompts against an agent and scores the responses against the
+// behavior each case expects: an exact answer, a regular expression, the tools called, the
+// state the task ends in, or criteria graded by an LLM judge. The report it produces can be
+// written as JSON or JUnit XML, so prompt and tool changes can be regression tested in CI.
+package eval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	yaml "gopkg.in/yaml.v3"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+const (
+	// DefaultTimeout bounds each case when the configuration sets no timeout
+	DefaultTimeout = 2 * time.Minute
+
+	// DefaultMinScore is the judge score a response needs to pass when a case sets none
+	DefaultMinScore = 0.7
+)
+
+// Suite is a set of cases, usually loaded from a YAML or JSON file
+type Suite struct {
+	// Cases are run in order
+	Cases []Case `json:"cases"`
+}
+
+// Case is a prompt sent to the agent and the behavior expected in response
+type Case struct {
+	// Name identifies the case in the report
+	Name string `json:"name"`
+	// Prompt is the user message sent to the agent
+	Prompt string `json:"prompt"`
+	// Expect is the behavior the response is checked against
+	Expect Expectation `json:"expect"`
+}
+
+// Expectation is the behavior expected of the agent. Every expectation that is set must be
+// met for the case to pass.
+type Expectation struct {
+	// Exact is the text the response must equal, ignoring surrounding whitespace
+	Exact string `json:"exact,omitempty"`
+	// Regex is a regular expression the response text must match
+	Regex string `json:"regex,omitempty"`
+	// Tools lists tools the agent must call
+	Tools []string `json:"tools,omitempty"`
+	// State is the state the task must end in, such as input-required or
+	// TASK_STATE_INPUT_REQUIRED
+	State string `json:"state,omitempty"`
+	// Judge has an LLM judge score the response against criteria
+	Judge *JudgeCriteria `json:"judge,omitempty"`
+}
+
+// JudgeCriteria describes what a judge scores a response on
+type JudgeCriteria struct {
+	// Criteria describe a good response in plain language
+	Criteria string `json:"criteria"`
+	// MinScore is the score between 0 and 1 the response needs (default: DefaultMinScore)
+	MinScore float64 `json:"minScore,omitempty"`
+}
+
+// LoadSuite reads a suite from a .yaml, .yml or .json file. Unknown fields are rejected to
+// catch typos.
+func LoadSuite(file string) (*Suite, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read eval suite: %w", err)
+	}
+
+	if ext := strings.ToLower(filepath.Ext(file)); ext == ".yaml" || ext == ".yml" {
+		var document any
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("invalid yaml: %w", err)
+		}
+		if data, err = json.Marshal(document); err != nil {
+			return nil, fmt.Errorf("invalid yaml: %w", err)
+		}
+	}
+
+	var suite Suite
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&suite); err != nil {
+		return nil, fmt.Errorf("invalid eval suite: %w", err)
+	}
+	return &suite, nil
+}
+
+// Config configures an eval run
+type Config struct {
+	// Target is the agent the cases run against
+	Target Target
+
+	// Cases are run in order
+	Cases []Case
+
+	// Judge scores the cases that set judge criteria. Required when any case does.
+	Judge Judge
+
+	// Timeout bounds each case, including judging it (default: DefaultTimeout)
+	Timeout time.Duration
+}
+
+// CheckKind names an expectation a response is checked against
+type CheckKind string
+
+const (
+	CheckExact CheckKind = "exact"
+	CheckRegex CheckKind = "regex"
+	CheckTools CheckKind = "tools"
+	CheckState CheckKind = "state"
+	CheckJudge CheckKind = "judge"
+)
+
+// Check is the outcome of checking a response against one expectation
+type Check struct {
+	Kind   CheckKind `json:"kind"`
+	Passed bool      `json:"passed"`
+	// Detail explains why the check failed, or the reasoning of the judge
+	Detail string `json:"detail,omitempty"`
+	// Score is the score of the judge
+	Score *float64 `json:"score,omitempty"`
+}
+
+// Result is the outcome of a case
+type Result struct {
+	Case     string        `json:"case"`
+	Prompt   string        `json:"prompt"`
+	Passed   bool          `json:"passed"`
+	Response *Response     `json:"response,omitempty"`
+	Checks   []Check       `json:"checks,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Report is the outcome of an eval run
+type Report struct {
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	Results  []Result      `json:"results"`
+}
+
+// Passed reports whether every case passed
+func (r *Report) Passed() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the results of the cases that did not pass
+func (r *Report) Failed() []Result {
+	var failed []Result
+	for _, result := range r.Results {
+		if !result.Passed {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// PassRate returns the share of the cases that passed, between 0 and 1
+func (r *Report) PassRate() float64 {
+	if len(r.Results) == 0 {
+		return 0
+	}
+	return float64(len(r.Results)-len(r.Failed())) / float64(len(r.Results))
+}
+
+// Run runs the cases against the target and reports their outcome. It returns an error only
+// when the configuration is invalid; failing cases are in the report.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if cfg.Target == nil {
+		return nil, fmt.Errorf("eval target is required")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	expressions := make([]*regexp.Regexp, len(cfg.Cases))
+	seen := make(map[string]bool)
+	for i, c := range cfg.Cases {
+		if c.Name == "" {
+			return nil, fmt.Errorf("case %d has no name", i+1)
+		}
+		if seen[c.Name] {
+			return nil, fmt.Errorf("case '%s' is defined twice", c.Name)
+		}
+		seen[c.Name] = true
+		if c.Prompt == "" {
+			return nil, fmt.Errorf("case '%s' has no prompt", c.Name)
+		}
+		expect := c.Expect
+		if expect.Exact == "" && expect.Regex == "" && len(expect.Tools) == 0 && expect.State == "" && expect.Judge == nil {
+			return nil, fmt.Errorf("case '%s' expects nothing", c.Name)
+		}
+		if expect.Regex != "" {
+			expression, err := regexp.Compile(expect.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("case '%s' has an invalid regex: %w", c.Name, err)
+			}
+			expressions[i] = expression
+		}
+		if expect.Judge != nil {
+			if expect.Judge.Criteria == "" {
+				return nil, fmt.Errorf("case '%s' has no judge criteria", c.Name)
+			}
+			if expect.Judge.MinScore < 0 || expect.Judge.MinScore > 1 {
+				return nil, fmt.Errorf("case '%s' has a judge min score outside 0 to 1", c.Name)
+			}
+			if cfg.Judge == nil {
+				return nil, fmt.Errorf("case '%s' is judged, but no judge is configured", c.Name)
+			}
+		}
+	}
+
+	report := &Report{Started: time.Now()}
+	for i, c := range cfg.Cases {
+		report.Results = append(report.Results, runCase(ctx, cfg, c, expressions[i]))
+	}
+	report.Duration = time.Since(report.Started)
+	return report, nil
+}
+
+// runCase sends the prompt of a case to the target and checks the response
+func runCase(ctx context.Context, cfg Config, c Case, expression *regexp.Regexp) Result {
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	started := time.Now()
+	result := Result{Case: c.Name, Prompt: c.Prompt}
+
+	response, err := cfg.Target.Respond(ctx, c.Prompt)
+	if err != nil {
+		result.Error = err.Error()
+		result.Duration = time.Since(started)
+		return result
+	}
+	result.Response = response
+
+	expect := c.Expect
+	text := strings.TrimSpace(response.Text)
+	if expect.Exact != "" {
+		check := Check{Kind: CheckExact, Passed: text == strings.TrimSpace(expect.Exact)}
+		if !check.Passed {
+			check.Detail = fmt.Sprintf("expected %q", strings.TrimSpace(expect.Exact))
+		}
+		result.Checks = append(result.Checks, check)
+	}
+	if expression != nil {
+		check := Check{Kind: CheckRegex, Passed: expression.MatchString(response.Text)}
+		if !check.Passed {
+			check.Detail = fmt.Sprintf("response does not match %s", expect.Regex)
+		}
+		result.Checks = append(result.Checks, check)
+	}
+	if len(expect.Tools) > 0 {
+		check := Check{Kind: CheckTools, Passed: true}
+		var missing []string
+		for _, tool := range expect.Tools {
+			if !slices.Contains(response.Tools, tool) {
+				missing = append(missing, tool)
+			}
+		}
+		if len(missing) > 0 {
+			check.Passed = false
+			check.Detail = fmt.Sprintf("tools not called: %s", strings.Join(missing, ", "))
+		}
+		result.Checks = append(result.Checks, check)
+	}
+	if expect.State != "" {
+		state := parseState(expect.State)
+		check := Check{Kind: CheckState, Passed: response.State == state}
+		if !check.Passed {
+			check.Detail = fmt.Sprintf("task ended in state %s, expected %s", response.State, state)
+		}
+		result.Checks = append(result.Checks, check)
+	}
+	if expect.Judge != nil {
+		result.Checks = append(result.Checks, judgeCase(ctx, cfg.Judge, c, response))
+	}
+
+	result.Passed = true
+	for _, check := range result.Checks {
+		result.Passed = result.Passed && check.Passed
+	}
+	result.Duration = time.Since(started)
+	return result
+}
+
+// judgeCase has the judge score the response to a case against its criteria
+func judgeCase(ctx context.Context, judge Judge, c Case, response *Response) Check {
+	minScore := c.Expect.Judge.MinScore
+	if minScore == 0 {
+		minScore = DefaultMinScore
+	}
+
+	verdict, err := judge.Score(ctx, c.Prompt, response.Text, c.Expect.Judge.Criteria)
+	if err != nil {
+		return Check{Kind: CheckJudge, Detail: fmt.Sprintf("judge failed: %v", err)}
+	}
+	check := Check{Kind: CheckJudge, Passed: verdict.Score >= minScore, Score: &verdict.Score, Detail: verdict.Reason}
+	if !check.Passed {
+		check.Detail = fmt.Sprintf("score %.2f is below %.2f: %s", verdict.Score, minScore, verdict.Reason)
+	}
+	return check
+}
+
+// parseState returns the task state named in lower case, such as input-required, or the name
+// itself when it already is a task state such as TASK_STATE_COMPLETED
+func parseState(name string) types.TaskState {
+	if strings.HasPrefix(name, "TASK_STATE_") {
+		return types.TaskState(name)
+	}
+	return types.TaskState("TASK_STATE_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")))
+}
@@ -0,0 +1,202 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	server "github.com/inference-gateway/adk/server"
+	adktesting "github.com/inference-gateway/adk/testing"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func weatherTool() server.Tool {
+	return server.NewBasicTool("get_weather", "Returns the weather of a city", map[string]any{"type": "object"},
+		func(ctx context.Context, args map[string]any) (string, error) {
+			return "Sunny, 22°C", nil
+		})
+}
+
+func TestRun_AgentTarget(t *testing.T) {
+	llm := adktesting.NewFakeLLMClient(
+		adktesting.CallTool("get_weather", map[string]any{"city": "Berlin"}),
+		adktesting.Reply("It is sunny in Berlin."),
+		adktesting.Reply("It is raining."),
+		adktesting.CallTool("input_required", map[string]any{"message": "Which city?"}),
+	)
+	judge := adktesting.NewFakeLLMClient(adktesting.Reply("```json\n{\"score\": 0.4, \"reason\": \"No temperature given.\"}\n```"))
+
+	report, err := Run(context.Background(), Config{
+		Target: NewAgentTarget(adktesting.NewAgent(t, llm, weatherTool())),
+		Judge:  NewLLMJudge(judge),
+		Cases: []Case{
+			{Name: "weather", Prompt: "Weather in Berlin?", Expect: Expectation{
+				Exact: "It is sunny in Berlin.",
+				Regex: "(?i)sunny",
+				Tools: []string{"get_weather"},
+				State: "completed",
+			}},
+			{Name: "judged", Prompt: "Weather in Paris?", Expect: Expectation{
+				Judge: &JudgeCriteria{Criteria: "Gives the temperature"},
+			}},
+			{Name: "ambiguous", Prompt: "Weather?", Expect: Expectation{State: "input-required", Regex: "city"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Results, 3)
+
+	weather := report.Results[0]
+	assert.True(t, weather.Passed, weather.Checks)
+	assert.Len(t, weather.Checks, 4)
+	assert.Equal(t, []string{"get_weather"}, weather.Response.Tools)
+
+	judged := report.Results[1]
+	assert.False(t, judged.Passed)
+	require.Len(t, judged.Checks, 1)
+	assert.Equal(t, CheckJudge, judged.Checks[0].Kind)
+	assert.Equal(t, 0.4, *judged.Checks[0].Score)
+	assert.Equal(t, "score 0.40 is below 0.70: No temperature given.", judged.Checks[0].Detail)
+	assert.Equal(t, "Weather in Paris?", judge.Requests()[0].LastMessage()[len("Prompt:\n"):len("Prompt:\nWeather in Paris?")])
+
+	ambiguous := report.Results[2]
+	assert.True(t, ambiguous.Passed, ambiguous.Checks)
+	assert.Equal(t, types.TaskStateInputRequired, ambiguous.Response.State)
+
+	assert.False(t, report.Passed())
+	assert.InDelta(t, 2.0/3, report.PassRate(), 0.001)
+	assert.Equal(t, "judged", report.Failed()[0].Case)
+}
+
+func TestRun_ClientTarget(t *testing.T) {
+	llm := adktesting.NewFakeLLMClient(
+		adktesting.CallTool("get_weather", map[string]any{"city": "Berlin"}),
+		adktesting.Reply("It is sunny in Berlin."),
+	)
+	s := adktesting.NewServer(t, adktesting.NewAgent(t, llm, weatherTool()))
+
+	report, err := Run(context.Background(), Config{
+		Target:  NewClientTarget(s.Client()),
+		Timeout: 10 * time.Second,
+		Cases: []Case{{Name: "weather", Prompt: "Weather in Berlin?", Expect: Expectation{
+			Exact: "It is cloudy in Berlin.",
+			Tools: []string{"get_weather", "get_forecast"},
+		}}},
+	})
+	require.NoError(t, err)
+
+	result := report.Results[0]
+	assert.False(t, result.Passed)
+	assert.Equal(t, "It is sunny in Berlin.", result.Response.Text)
+	assert.Equal(t, []Check{
+		{Kind: CheckExact, Detail: `expected "It is cloudy in Berlin."`},
+		{Kind: CheckTools, Detail: "tools not called: get_forecast"},
+	}, result.Checks)
+}
+
+func TestRun_TargetErrors(t *testing.T) {
+	target := TargetFunc(func(ctx context.Context, prompt string) (*Response, error) {
+		return nil, errors.New("agent unavailable")
+	})
+	report, err := Run(context.Background(), Config{
+		Target: target,
+		Cases:  []Case{{Name: "down", Prompt: "Hi", Expect: Expectation{Regex: "."}}},
+	})
+	require.NoError(t, err)
+	assert.False(t, report.Results[0].Passed)
+	assert.Equal(t, "agent unavailable", report.Results[0].Error)
+}
+
+func TestRun_InvalidConfig(t *testing.T) {
+	target := TargetFunc(func(ctx context.Context, prompt string) (*Response, error) { return &Response{}, nil })
+
+	tests := []struct {
+		name   string
+		cfg    Config
+		expect string
+	}{
+		{name: "no target", cfg: Config{}, expect: "eval target is required"},
+		{name: "no name", cfg: Config{Target: target, Cases: []Case{{Prompt: "Hi"}}}, expect: "case 1 has no name"},
+		{name: "no prompt", cfg: Config{Target: target, Cases: []Case{{Name: "a"}}}, expect: "case 'a' has no prompt"},
+		{name: "no expectation", cfg: Config{Target: target, Cases: []Case{{Name: "a", Prompt: "Hi"}}}, expect: "case 'a' expects nothing"},
+		{
+			name:   "duplicate",
+			cfg:    Config{Target: target, Cases: []Case{{Name: "a", Prompt: "Hi", Expect: Expectation{Exact: "Hi"}}, {Name: "a", Prompt: "Hi"}}},
+			expect: "case 'a' is defined twice",
+		},
+		{
+			name:   "invalid regex",
+			cfg:    Config{Target: target, Cases: []Case{{Name: "a", Prompt: "Hi", Expect: Expectation{Regex: "("}}}},
+			expect: "case 'a' has an invalid regex: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:   "no judge",
+			cfg:    Config{Target: target, Cases: []Case{{Name: "a", Prompt: "Hi", Expect: Expectation{Judge: &JudgeCriteria{Criteria: "Polite"}}}}},
+			expect: "case 'a' is judged, but no judge is configured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Run(context.Background(), tt.cfg)
+			assert.EqualError(t, err, tt.expect)
+		})
+	}
+}
+
+func TestLoadSuite(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "weather.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`cases:
+  - name: weather
+    prompt: Weather in Berlin?
+    expect:
+      regex: (?i)sunny
+      tools: [get_weather]
+      judge:
+        criteria: Gives the temperature
+        minScore: 0.8
+`), 0o644))
+
+	suite, err := LoadSuite(file)
+	require.NoError(t, err)
+	require.Len(t, suite.Cases, 1)
+	assert.Equal(t, Case{Name: "weather", Prompt: "Weather in Berlin?", Expect: Expectation{
+		Regex: "(?i)sunny",
+		Tools: []string{"get_weather"},
+		Judge: &JudgeCriteria{Criteria: "Gives the temperature", MinScore: 0.8},
+	}}, suite.Cases[0])
+
+	file = filepath.Join(dir, "typo.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"cases":[{"name":"a","promt":"Hi"}]}`), 0o644))
+	_, err = LoadSuite(file)
+	assert.EqualError(t, err, `invalid eval suite: json: unknown field "promt"`)
+}
+
+func TestReport_WriteJUnit(t *testing.T) {
+	started := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	report := &Report{Started: started, Duration: 1500 * time.Millisecond, Results: []Result{
+		{Case: "weather", Passed: true, Response: &Response{Text: "Sunny"}, Duration: time.Second},
+		{Case: "forecast", Checks: []Check{{Kind: CheckRegex, Detail: "response does not match rain"}}, Duration: 500 * time.Millisecond},
+		{Case: "down", Error: "agent unavailable"},
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, report.WriteJUnit(&out))
+	assert.Contains(t, out.String(), `<testsuite name="eval" tests="3" failures="1" errors="1" time="1.500" timestamp="2025-01-01T12:00:00">`)
+	assert.Contains(t, out.String(), `<system-out>Sunny</system-out>`)
+	assert.Contains(t, out.String(), `<failure message="regex: response does not match rain">regex: response does not match rain</failure>`)
+	assert.Contains(t, out.String(), `<error message="agent unavailable"></error>`)
+
+	file := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, report.WriteFile(file))
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"case": "forecast"`)
+}
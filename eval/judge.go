@@ -0,0 +1,87 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	server "github.com/inference-gateway/adk/server"
+)
+
+// judgeInstruction is the system prompt of the LLM judge
+const judgeInstruction = `You grade the response of an AI agent to a user prompt against the given criteria.
+Reply with a JSON object only, without any commentary: {"score": <number from 0 to 1>, "reason": "<one sentence>"}.
+A score of 1 means the response fully meets the criteria, 0 means it does not meet them at all.`
+
+// Verdict is the score a judge gives a response
+type Verdict struct {
+	// Score is between 0 and 1
+	Score float64 `json:"score"`
+	// Reason explains the score
+	Reason string `json:"reason"`
+}
+
+// Judge scores responses against criteria
+type Judge interface {
+	// Score scores the response to the prompt against the criteria
+	Score(ctx context.Context, prompt, response, criteria string) (*Verdict, error)
+}
+
+// LLMJudge scores responses with a chat completion from an LLM
+type LLMJudge struct {
+	client server.LLMClient
+}
+
+var _ Judge = (*LLMJudge)(nil)
+
+// NewLLMJudge creates a judge backed by an LLM client
+func NewLLMJudge(client server.LLMClient) *LLMJudge {
+	return &LLMJudge{client: client}
+}
+
+// Score asks the LLM to score the response and decodes the JSON verdict it replies with
+func (j *LLMJudge) Score(ctx context.Context, prompt, response, criteria string) (*Verdict, error) {
+	systemMessage, err := sdk.NewTextMessage(sdk.System, judgeInstruction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create judge instruction: %w", err)
+	}
+	userMessage, err := sdk.NewTextMessage(sdk.User, fmt.Sprintf("Prompt:\n%s\n\nResponse:\n%s\n\nCriteria:\n%s", prompt, response, criteria))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create judge message: %w", err)
+	}
+
+	completion, err := j.client.CreateChatCompletion(ctx, []sdk.Message{systemMessage, userMessage})
+	if err != nil {
+		return nil, fmt.Errorf("judge request failed: %w", err)
+	}
+	if completion == nil || len(completion.Choices) == 0 {
+		return nil, fmt.Errorf("judge response is empty")
+	}
+	content, err := completion.Choices[0].Message.Content.AsMessageContent0()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read judge response: %w", err)
+	}
+	return parseVerdict(content)
+}
+
+// parseVerdict decodes the JSON object of a judge reply, ignoring text such as code fences
+// around it
+func parseVerdict(content string) (*Verdict, error) {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("judge replied without a verdict: %q", content)
+	}
+
+	var verdict Verdict
+	if err := json.Unmarshal([]byte(content[start:end+1]), &verdict); err != nil {
+		return nil, fmt.Errorf("judge replied with an invalid verdict: %w", err)
+	}
+	if verdict.Score < 0 || verdict.Score > 1 {
+		return nil, fmt.Errorf("judge score %g is outside 0 to 1", verdict.Score)
+	}
+	return &verdict, nil
+}
@@ -0,0 +1,111 @@
+package eval
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WriteJSON writes the report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// junitSuite is the testsuite element of a JUnit XML report
+type junitSuite struct {
+	XMLName   xml.Name    `xml:"testsuite"`
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Errors    int         `xml:"errors,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr"`
+	Cases     []junitCase `xml:"testcase"`
+}
+
+// junitCase is the testcase element of a JUnit XML report
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// junitProblem is the failure or error element of a JUnit XML test case
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as JUnit XML, with a test case per eval case, for CI systems
+// that display test results
+func (r *Report) WriteJUnit(w io.Writer) error {
+	suite := junitSuite{
+		Name:      "eval",
+		Tests:     len(r.Results),
+		Time:      fmt.Sprintf("%.3f", r.Duration.Seconds()),
+		Timestamp: r.Started.UTC().Format("2006-01-02T15:04:05"),
+	}
+	for _, result := range r.Results {
+		testCase := junitCase{Name: result.Case, ClassName: "eval", Time: fmt.Sprintf("%.3f", result.Duration.Seconds())}
+		if result.Response != nil {
+			testCase.SystemOut = result.Response.Text
+		}
+		switch {
+		case result.Error != "":
+			suite.Errors++
+			testCase.Error = &junitProblem{Message: result.Error}
+		case !result.Passed:
+			suite.Failures++
+			var failed []string
+			for _, check := range result.Checks {
+				if !check.Passed {
+					failed = append(failed, fmt.Sprintf("%s: %s", check.Kind, check.Detail))
+				}
+			}
+			testCase.Failure = &junitProblem{Message: failed[0], Text: strings.Join(failed, "\n")}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suite); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteFile writes the report to a file, as JUnit XML when its extension is .xml and as JSON
+// otherwise
+func (r *Report) WriteFile(file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+
+	if strings.ToLower(filepath.Ext(file)) == ".xml" {
+		err = r.WriteJUnit(f)
+	} else {
+		err = r.WriteJSON(f)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
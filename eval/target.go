@@ -0,0 +1,155 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	uuid "github.com/google/uuid"
+
+	client "github.com/inference-gateway/adk/client"
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// Response is what the agent answered a prompt with
+type Response struct {
+	// Text is the text of the final agent message
+	Text string `json:"text"`
+	// State is the state the task ended in
+	State types.TaskState `json:"state"`
+	// Tools lists the tools the agent called, in order
+	Tools []string `json:"tools,omitempty"`
+}
+
+// Target is the agent the cases of an eval run against
+type Target interface {
+	// Respond sends the prompt to the agent as a new conversation and returns its response
+	Respond(ctx context.Context, prompt string) (*Response, error)
+}
+
+// TargetFunc adapts a function to a Target
+type TargetFunc func(ctx context.Context, prompt string) (*Response, error)
+
+// Respond calls the function
+func (f TargetFunc) Respond(ctx context.Context, prompt string) (*Response, error) {
+	return f(ctx, prompt)
+}
+
+// agentTarget runs the cases against an agent in process
+type agentTarget struct {
+	agent server.OpenAICompatibleAgent
+}
+
+// NewAgentTarget creates a target running the agent in process, without a server
+func NewAgentTarget(agent server.OpenAICompatibleAgent) Target {
+	return &agentTarget{agent: agent}
+}
+
+// Respond runs the agent on the prompt and follows its events to the final status
+func (t *agentTarget) Respond(ctx context.Context, prompt string) (*Response, error) {
+	events, err := t.agent.RunWithStream(ctx, []types.Message{newPrompt(prompt)})
+	if err != nil {
+		return nil, fmt.Errorf("agent run failed: %w", err)
+	}
+
+	response := &Response{}
+	for event := range events {
+		switch event.Type() {
+		case types.EventTaskStatusChanged:
+			var status types.TaskStatus
+			if err := event.DataAs(&status); err != nil {
+				return nil, fmt.Errorf("invalid status event: %w", err)
+			}
+			response.State = status.State
+			if status.Message != nil {
+				response.Text = status.Message.Text()
+			}
+		case types.EventInputRequired:
+			var message types.Message
+			if err := event.DataAs(&message); err != nil {
+				return nil, fmt.Errorf("invalid input required event: %w", err)
+			}
+			response.State = types.TaskStateInputRequired
+			response.Text = message.Text()
+		case types.EventToolResult:
+			var message types.Message
+			if err := event.DataAs(&message); err == nil {
+				response.Tools = append(response.Tools, toolNames(&message)...)
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("agent run did not finish: %w", err)
+	}
+	return response, nil
+}
+
+// clientTarget runs the cases against a running A2A server
+type clientTarget struct {
+	client client.A2AClient
+}
+
+// NewClientTarget creates a target sending the prompts to a running A2A server
+func NewClientTarget(a2aClient client.A2AClient) Target {
+	return &clientTarget{client: a2aClient}
+}
+
+// Respond sends the prompt and waits until its task settles. The tools called are read from
+// the tool calls in the task history.
+func (t *clientTarget) Respond(ctx context.Context, prompt string) (*Response, error) {
+	task, err := t.client.SendAndWait(ctx, types.MessageSendParams{Message: newPrompt(prompt)}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send prompt: %w", err)
+	}
+
+	response := &Response{State: task.Status.State}
+	if task.Status.Message != nil {
+		response.Text = task.Status.Message.Text()
+	}
+	for i := range task.History {
+		message := &task.History[i]
+		if message.Role != types.RoleAgent {
+			continue
+		}
+		response.Tools = append(response.Tools, toolNames(message)...)
+		if task.Status.Message == nil {
+			if text := message.Text(); text != "" {
+				response.Text = text
+			}
+		}
+	}
+	return response, nil
+}
+
+// newPrompt creates the user message of a prompt
+func newPrompt(prompt string) types.Message {
+	return types.Message{
+		MessageID: uuid.New().String(),
+		Role:      types.RoleUser,
+		Parts:     []types.Part{types.CreateTextPart(prompt)},
+	}
+}
+
+// toolNames returns the names of the tools the message calls or carries the results of
+func toolNames(message *types.Message) []string {
+	var names []string
+	add := func(name any) {
+		if name, ok := name.(string); ok && name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	for _, part := range message.Parts {
+		if part.Data == nil {
+			continue
+		}
+		add(part.Data.Data["tool_name"])
+		calls, _ := part.Data.Data["tool_calls"].([]any)
+		for _, call := range calls {
+			call, _ := call.(map[string]any)
+			function, _ := call["function"].(map[string]any)
+			add(function["name"])
+		}
+	}
+	return names
+}
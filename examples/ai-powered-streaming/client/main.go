@@ -20,16 +20,6 @@ type Config struct {
 	ClientTimeout time.Duration `env:"CLIENT_TIMEOUT,default=90s"`
 }
 
-// extractTextFromParts extracts text from message parts
-func extractTextFromParts(parts []types.Part) string {
-	for _, part := range parts {
-		if part.Text != nil {
-			return *part.Text
-		}
-	}
-	return ""
-}
-
 func main() {
 	// Load configuration from environment
 	var config Config
@@ -121,7 +111,7 @@ func main() {
 
 			// If status includes a message (e.g., completion with final text), display it
 			if statusUpdate.Status.Message != nil {
-				text := extractTextFromParts(statusUpdate.Status.Message.Parts)
+				text := statusUpdate.Status.Message.Text()
 				if text != "" {
 					logger.Info("received final message",
 						zap.Int("text_length", len(text)))
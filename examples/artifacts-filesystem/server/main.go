@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
 	"log"
 	"os"
@@ -58,13 +57,11 @@ func extractMessageContent(message *types.Message) (string, string, string) {
 			userText = *part.Text
 		}
 
-		// Extract file content
+		// Extract file content, as extracted by the server's file ingestion
 		if part.File != nil {
 			fileName = part.File.Name
-			if part.File.FileWithBytes != nil {
-				if decoded, err := base64.StdEncoding.DecodeString(*part.File.FileWithBytes); err == nil {
-					fileContent = string(decoded)
-				}
+			if file, ok, err := types.GetIngestedFile(part); err == nil && ok {
+				fileContent = file.Text
 			}
 		}
 	}
@@ -196,6 +193,7 @@ func (h *ArtifactsTaskHandler) GetAgent() server.OpenAICompatibleAgent {
 //   - A2A_AGENT_NAME: Agent name (default: artifacts-filesystem-agent)
 //   - A2A_SERVER_PORT: A2A server port (default: 8080)
 //   - A2A_ARTIFACTS_ENABLE: Enable artifacts support (default: true)
+//   - A2A_FILE_INGESTION_ENABLE: Extract the text of uploaded files (default: true)
 //   - A2A_ARTIFACTS_SERVER_HOST: Artifacts server host (default: localhost)
 //   - A2A_ARTIFACTS_SERVER_PORT: Artifacts server port (default: 8081)
 //   - A2A_ARTIFACTS_STORAGE_PROVIDER: Storage provider (default: filesystem)
@@ -222,6 +220,9 @@ func main() {
 			ServerConfig: serverConfig.ServerConfig{
 				Port: "8080",
 			},
+			FileIngestionConfig: serverConfig.FileIngestionConfig{
+				Enable: true,
+			},
 			ArtifactsConfig: serverConfig.ArtifactsConfig{
 				Enable: true,
 				ServerConfig: serverConfig.ArtifactsServerConfig{
@@ -521,17 +521,32 @@ note over User,Database : %s
 @enduml`, title, description)
 			}
 
-			mimeType := "text/plain"
-
-			// Create and add artifact - storage is handled automatically by ArtifactService
-			artifact, err := artifactService.CreateFileArtifact(
-				task.ContextID,
-				fmt.Sprintf("%s - %s Diagram", title, strings.Title(diagramType)),
-				fmt.Sprintf("PlantUML %s diagram: %s", diagramType, description),
-				filename,
-				[]byte(plantumlContent),
-				&mimeType,
+			name := fmt.Sprintf("%s - %s Diagram", title, strings.Title(diagramType))
+			artifactDescription := fmt.Sprintf("PlantUML %s diagram: %s", diagramType, description)
+
+			// Render the diagram into a viewable SVG image when a diagram server is configured
+			// (ARTIFACTS_RENDER_DIAGRAM_URL), and fall back to the PlantUML source otherwise
+			var (
+				artifact types.Artifact
+				err      error
 			)
+			if cfg.A2A.ArtifactsConfig.RenderConfig.DiagramURL != "" {
+				renderer := server.NewArtifactRenderer(cfg.A2A.ArtifactsConfig.RenderConfig, artifactService)
+				artifact, err = renderer.CreateDiagramArtifact(ctx, task.ContextID, name, artifactDescription,
+					server.DiagramPlantUML, plantumlContent, server.ImageFormatSVG)
+			} else {
+				mimeType := "text/plain"
+
+				// Create and add artifact - storage is handled automatically by ArtifactService
+				artifact, err = artifactService.CreateFileArtifact(
+					task.ContextID,
+					name,
+					artifactDescription,
+					filename,
+					[]byte(plantumlContent),
+					&mimeType,
+				)
+			}
 			if err != nil {
 				return "Failed to create artifact", fmt.Errorf("failed to create artifact: %w", err)
 			}
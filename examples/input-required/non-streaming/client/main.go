@@ -3,7 +3,6 @@ package main
 import (
 	"bufio"
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -84,68 +83,32 @@ func main() {
 func demonstrateInputRequiredFlow(a2aClient client.A2AClient, initialMessage string, logger *zap.Logger) error {
 	ctx := context.Background()
 
-	// Create initial message
-	message := types.Message{
-		MessageID: fmt.Sprintf("msg-%d", time.Now().UnixNano()),
-		Role:      types.RoleUser,
-		Parts: []types.Part{
-			types.CreateTextPart(initialMessage),
-		},
-	}
+	// The session attaches the context ID to follow-up messages and the task ID when
+	// the task is waiting for input, so replies resume the paused task
+	session := client.NewSession(a2aClient)
 
 	// Send initial message
 	fmt.Printf("📤 Sending: %s\n", initialMessage)
 
-	params := types.MessageSendParams{
-		Message: message,
-	}
-
-	// Send message and get task
-	response, err := a2aClient.SendTask(ctx, params)
+	task, err := session.SendText(ctx, initialMessage)
 	if err != nil {
 		return fmt.Errorf("failed to send message: %w", err)
 	}
 
-	// Extract task ID from response
-	var taskResult struct {
-		ID        string `json:"id"`
-		ContextID string `json:"contextId"`
-	}
-	resultBytes, ok := response.Result.(json.RawMessage)
-	if !ok {
-		return fmt.Errorf("failed to parse result as json.RawMessage")
-	}
-	if err := json.Unmarshal(resultBytes, &taskResult); err != nil {
-		return fmt.Errorf("failed to parse task ID: %w", err)
-	}
-
-	fmt.Printf("🆔 Task ID: %s\n", taskResult.ID)
-	fmt.Printf("🔗 Context ID: %s\n", taskResult.ContextID)
+	fmt.Printf("🆔 Task ID: %s\n", task.ID)
+	fmt.Printf("🔗 Context ID: %s\n", task.ContextID)
 
 	// Monitor task until completion or input required
-	taskID := taskResult.ID
-	contextID := taskResult.ContextID
 	for {
 		// Wait a moment for task processing
 		time.Sleep(500 * time.Millisecond)
 
 		// Poll for task updates
-		taskResponse, err := a2aClient.GetTask(ctx, types.TaskQueryParams{
-			ID: taskID,
-		})
+		currentTask, err := session.Refresh(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to poll task: %w", err)
 		}
 
-		var currentTask types.Task
-		taskResultBytes, ok := taskResponse.Result.(json.RawMessage)
-		if !ok {
-			return fmt.Errorf("failed to parse task result as json.RawMessage")
-		}
-		if err := json.Unmarshal(taskResultBytes, &currentTask); err != nil {
-			return fmt.Errorf("failed to parse task: %w", err)
-		}
-
 		fmt.Printf("📊 Task Status: %s\n", currentTask.Status.State)
 
 		switch currentTask.Status.State {
@@ -155,6 +118,7 @@ func demonstrateInputRequiredFlow(a2aClient client.A2AClient, initialMessage str
 				responseText := extractMessageText(currentTask.Status.Message)
 				fmt.Printf("✅ Response: %s\n\n", responseText)
 			}
+			logger.Debug("conversation finished", zap.Int("messages", len(session.History())))
 			return nil
 
 		case types.TaskStateInputRequired:
@@ -177,42 +141,15 @@ func demonstrateInputRequiredFlow(a2aClient client.A2AClient, initialMessage str
 				continue
 			}
 
-			// Create follow-up message with the same context ID
-			followUpMessage := types.Message{
-				MessageID: fmt.Sprintf("msg-%d", time.Now().UnixNano()),
-				ContextID: &contextID,
-				Role:      types.RoleUser,
-				Parts: []types.Part{
-					types.CreateTextPart(userResponse),
-				},
-			}
-
 			// Send follow-up message to continue the task
-			fmt.Printf("📤 Sending follow-up: %s (context: %s)\n", userResponse, contextID)
-
-			followUpParams := types.MessageSendParams{
-				Message: followUpMessage,
-			}
+			fmt.Printf("📤 Sending follow-up: %s (context: %s)\n", userResponse, session.ContextID())
 
-			continuedResponse, err := a2aClient.SendTask(ctx, followUpParams)
+			continuedTask, err := session.SendText(ctx, userResponse)
 			if err != nil {
 				return fmt.Errorf("failed to send follow-up message: %w", err)
 			}
 
-			// Extract new task ID
-			var continuedTaskResult struct {
-				ID string `json:"id"`
-			}
-			continuedResultBytes, ok := continuedResponse.Result.(json.RawMessage)
-			if !ok {
-				return fmt.Errorf("failed to parse continued result as json.RawMessage")
-			}
-			if err := json.Unmarshal(continuedResultBytes, &continuedTaskResult); err != nil {
-				return fmt.Errorf("failed to parse continued task ID: %w", err)
-			}
-
-			taskID = continuedTaskResult.ID
-			fmt.Printf("🔄 Continuing with Task ID: %s\n", taskID)
+			fmt.Printf("🔄 Continuing with Task ID: %s\n", continuedTask.ID)
 
 		case types.TaskStateFailed:
 			// Task failed
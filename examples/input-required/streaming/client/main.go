@@ -3,12 +3,10 @@ package main
 import (
 	"bufio"
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"strings"
-	"time"
 
 	envconfig "github.com/sethvargo/go-envconfig"
 	zap "go.uber.org/zap"
@@ -83,207 +81,103 @@ func main() {
 func demonstrateStreamingInputRequiredFlow(a2aClient client.A2AClient, initialMessage string, logger *zap.Logger) error {
 	ctx := context.Background()
 
-	// Create initial message
-	message := types.Message{
-		MessageID: fmt.Sprintf("msg-%d", time.Now().UnixNano()),
-		Role:      "user",
-		Parts: []types.Part{
-			types.CreateTextPart(initialMessage),
-		},
+	// The session attaches the context ID to follow-up messages and the task ID when
+	// the task is waiting for input, so replies resume the paused task
+	session := client.NewSession(a2aClient)
+	configuration := &types.MessageSendConfiguration{
+		Blocking:            new(false),
+		AcceptedOutputModes: []string{"text/plain"},
 	}
 
 	// Send initial message with streaming
 	fmt.Printf("📤 Sending: %s\n", initialMessage)
 	fmt.Print("📥 Streaming response: ")
+	text := initialMessage
 
-	params := types.MessageSendParams{
-		Message: message,
-		Configuration: &types.MessageSendConfiguration{
-			Blocking:            new(false),
-			AcceptedOutputModes: []string{"text/plain"},
-		},
-	}
-
-	// Start streaming
-	eventChan, err := a2aClient.SendTaskStreaming(ctx, params)
-	if err != nil {
-		return fmt.Errorf("failed to start streaming: %w", err)
-	}
-
-	var streamingText strings.Builder
-	var inputRequiredMessage string
-	var taskCompleted bool
-	var taskInputRequired bool
-	var currentTaskID string
-	var currentContextID string
-
-	// Process streaming events
-	for event := range eventChan {
-		logger.Debug("received streaming event")
-
-		// Parse the event result
-		if event.Result == nil {
-			continue
+	for {
+		eventChan, err := session.Stream(ctx, types.Message{Parts: []types.Part{types.CreateTextPart(text)}}, configuration)
+		if err != nil {
+			return fmt.Errorf("failed to start streaming: %w", err)
 		}
 
-		resultBytes, _ := json.Marshal(event.Result)
-
-		// Check if this is a delta event (contains message parts to stream)
-		var task types.Task
-		if err := json.Unmarshal(resultBytes, &task); err == nil && task.Status.Message != nil {
-			// Handle delta message - display text in real-time
-			if len(task.Status.Message.Parts) > 0 {
-				text := extractMessageText(task.Status.Message)
-				if text != "" {
-					fmt.Print(text)
-					streamingText.WriteString(text)
-				}
-			}
-		}
+		var streamingText strings.Builder
+		var inputRequiredMessage string
 
-		// Check for status updates
-		var statusUpdate types.TaskStatusUpdateEvent
-		if err := json.Unmarshal(resultBytes, &statusUpdate); err == nil && statusUpdate.TaskID != "" {
-			// Handle different task states
-			switch statusUpdate.Status.State {
-			case types.TaskStateWorking:
-				logger.Info("task started")
+		// Process streaming events
+		for event := range eventChan {
+			logger.Debug("received streaming event")
 
-			case types.TaskStateCompleted:
-				logger.Info("task completed")
-				taskCompleted = true
+			switch e := event.(type) {
+			case client.MessageDelta:
+				// Handle delta message - display text in real-time
+				fmt.Print(e.Text)
+				streamingText.WriteString(e.Text)
 
-			case types.TaskStateInputRequired:
-				logger.Info("input required")
-				taskInputRequired = true
-				currentTaskID = statusUpdate.TaskID
-				currentContextID = statusUpdate.ContextID
-				if statusUpdate.Status.Message != nil {
-					inputRequiredMessage = extractMessageText(statusUpdate.Status.Message)
+			case client.TaskStatusUpdate:
+				switch e.Status.State {
+				case types.TaskStateWorking:
+					logger.Info("task started")
+				case types.TaskStateCompleted:
+					logger.Info("task completed")
+				case types.TaskStateInputRequired:
+					logger.Info("input required")
+					if e.Status.Message != nil {
+						inputRequiredMessage = extractMessageText(e.Status.Message)
+					}
+				case types.TaskStateFailed:
+					logger.Error("task failed")
+				case types.TaskStateCancelled:
+					logger.Info("task canceled")
+				default:
+					logger.Debug("unknown state", zap.String("state", string(e.Status.State)))
 				}
 
-			case types.TaskStateFailed:
-				logger.Error("task failed")
-				fmt.Print("\n❌ Task failed")
-				return nil
-
-			case types.TaskStateCancelled:
-				logger.Info("task canceled")
-				fmt.Print("\n🚫 Task canceled")
-				return nil
-
-			default:
-				logger.Debug("unknown state", zap.String("state", string(statusUpdate.Status.State)))
+			case client.StreamError:
+				return fmt.Errorf("stream failed: %w", e.Err)
 			}
 		}
-	}
-
-	// Handle final state
-	if taskCompleted {
-		fmt.Printf("\n\n✅ Response complete!\n")
-		if streamingText.Len() > 0 {
-			fmt.Printf("\n📝 Full response:\n%s\n", streamingText.String())
-		}
-		return nil
-	}
-
-	if taskInputRequired && inputRequiredMessage != "" {
-		fmt.Printf("\n❓ Input Required: %s\n", inputRequiredMessage)
 
-		// Get user input for continuation
-		fmt.Print("💬 Your response: ")
-		scanner := bufio.NewScanner(os.Stdin)
-		if !scanner.Scan() {
-			return fmt.Errorf("failed to read user input")
-		}
-
-		userResponse := strings.TrimSpace(scanner.Text())
-		if userResponse == "" {
-			fmt.Println("⚠️  Empty response, ending conversation...")
+		switch session.State() {
+		case types.TaskStateCompleted:
+			fmt.Printf("\n\n✅ Response complete!\n")
+			if streamingText.Len() > 0 {
+				fmt.Printf("\n📝 Full response:\n%s\n", streamingText.String())
+			}
 			return nil
-		}
-
-		// Create follow-up message with task context
-		followUpMessage := types.Message{
-			MessageID: fmt.Sprintf("msg-%d", time.Now().UnixNano()),
-			Role:      "user",
-			TaskID:    &currentTaskID,
-			ContextID: &currentContextID,
-			Parts: []types.Part{
-				types.CreateTextPart(userResponse),
-			},
-		}
 
-		// Continue streaming with follow-up
-		fmt.Printf("📤 Sending follow-up: %s\n", userResponse)
-		fmt.Print("📥 Continued streaming: ")
+		case types.TaskStateFailed:
+			fmt.Printf("\n❌ Task failed\n\n")
+			return nil
 
-		followUpParams := types.MessageSendParams{
-			Message: followUpMessage,
-			Configuration: &types.MessageSendConfiguration{
-				Blocking:            new(false),
-				AcceptedOutputModes: []string{"text/plain"},
-			},
-		}
+		case types.TaskStateCancelled:
+			fmt.Printf("\n🚫 Task canceled\n\n")
+			return nil
 
-		// Continue streaming
-		continuedEventChan, err := a2aClient.SendTaskStreaming(ctx, followUpParams)
-		if err != nil {
-			return fmt.Errorf("failed to continue streaming: %w", err)
-		}
+		case types.TaskStateInputRequired:
+			fmt.Printf("\n❓ Input Required: %s\n", inputRequiredMessage)
 
-		// Process continued streaming events
-		var continuedText strings.Builder
-		for event := range continuedEventChan {
-			// Parse the event result
-			if event.Result == nil {
-				continue
+			// Get user input for continuation
+			fmt.Print("💬 Your response: ")
+			scanner := bufio.NewScanner(os.Stdin)
+			if !scanner.Scan() {
+				return fmt.Errorf("failed to read user input")
 			}
 
-			resultBytes, _ := json.Marshal(event.Result)
-
-			// Check if this is a delta event (contains message parts to stream)
-			var task types.Task
-			if err := json.Unmarshal(resultBytes, &task); err == nil && task.Status.Message != nil {
-				// Handle delta message - display text in real-time
-				if len(task.Status.Message.Parts) > 0 {
-					text := extractMessageText(task.Status.Message)
-					if text != "" {
-						fmt.Print(text)
-						continuedText.WriteString(text)
-					}
-				}
+			text = strings.TrimSpace(scanner.Text())
+			if text == "" {
+				fmt.Println("⚠️  Empty response, ending conversation...")
+				return nil
 			}
 
-			// Check for status updates
-			var statusUpdate types.TaskStatusUpdateEvent
-			if err := json.Unmarshal(resultBytes, &statusUpdate); err == nil && statusUpdate.TaskID != "" {
-				// Handle different task states
-				switch statusUpdate.Status.State {
-				case types.TaskStateCompleted:
-					logger.Info("continued task completed")
-					fmt.Printf("\n\n✅ Conversation complete!\n")
-					if continuedText.Len() > 0 {
-						fmt.Printf("\n📝 Final response:\n%s\n", continuedText.String())
-					}
-					return nil
-				case types.TaskStateFailed:
-					logger.Error("continued task failed")
-					fmt.Printf("\n❌ Task failed\n\n")
-					return nil
-				}
-			}
-		}
+			// Continue streaming with follow-up
+			fmt.Printf("📤 Sending follow-up: %s\n", text)
+			fmt.Print("📥 Continued streaming: ")
 
-		fmt.Printf("\n\n✅ Stream completed!\n")
-		if continuedText.Len() > 0 {
-			fmt.Printf("\n📝 Full response:\n%s\n", continuedText.String())
+		default:
+			fmt.Printf("\n⚠️  Stream ended without clear completion\n\n")
+			return nil
 		}
-	} else {
-		fmt.Printf("\n⚠️  Stream ended without clear completion\n\n")
 	}
-
-	return nil
 }
 
 // extractMessageText extracts text content from a message
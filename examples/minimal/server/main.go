@@ -33,14 +33,7 @@ func NewSimpleTaskHandler(logger *zap.Logger) *SimpleTaskHandler {
 
 // HandleTask processes tasks with simple echo responses
 func (h *SimpleTaskHandler) HandleTask(ctx context.Context, task *types.Task, message *types.Message) (*types.Task, error) {
-	userInput := ""
-	if message != nil {
-		for _, part := range message.Parts {
-			if part.Text != nil {
-				userInput = *part.Text
-			}
-		}
-	}
+	userInput := message.Text()
 
 	responseText := fmt.Sprintf("Echo: %s", userInput)
 	if userInput == "" {
@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"time"
@@ -80,7 +79,7 @@ func main() {
 	logger.Info("sending streaming request", zap.String("prompt", promptText))
 
 	// Test streaming
-	eventChan, err := a2aClient.SendTaskStreaming(ctx, params)
+	eventChan, err := a2aClient.StreamTask(ctx, params)
 	if err != nil {
 		logger.Error("failed to send streaming message", zap.Error(err))
 		return
@@ -94,38 +93,14 @@ func main() {
 	for event := range eventChan {
 		eventCount++
 
-		if event.Result == nil {
-			continue
-		}
-
-		resultBytes, _ := json.Marshal(event.Result)
-
-		var probe map[string]json.RawMessage
-		if err := json.Unmarshal(resultBytes, &probe); err != nil {
-			continue
-		}
-
-		_, isStatusUpdate := probe["taskId"]
-
-		if !isStatusUpdate {
-			var task types.Task
-			if err := json.Unmarshal(resultBytes, &task); err == nil {
-				if task.Status.Message != nil && len(task.Status.Message.Parts) > 0 {
-					for _, part := range task.Status.Message.Parts {
-						if part.Text != nil {
-							fmt.Print(*part.Text)
-							finalResponse += *part.Text
-						}
-					}
-				}
-				continue
-			}
-		}
+		switch e := event.(type) {
+		case client.MessageDelta:
+			fmt.Print(e.Text)
+			finalResponse += e.Text
 
-		var statusUpdate types.TaskStatusUpdateEvent
-		if err := json.Unmarshal(resultBytes, &statusUpdate); err == nil && isStatusUpdate {
+		case client.TaskStatusUpdate:
 			// Handle different task states
-			switch statusUpdate.Status.State {
+			switch e.Status.State {
 			case types.TaskStateWorking:
 				logger.Info("task started", zap.Int("event", eventCount))
 
@@ -138,10 +113,13 @@ func main() {
 			case types.TaskStateCancelled:
 				logger.Info("task canceled", zap.Int("event", eventCount))
 			}
-			continue
-		}
 
-		logger.Debug("unknown event type", zap.Int("event", eventCount))
+		case client.StreamError:
+			logger.Error("stream ended with an error", zap.Error(e.Err))
+
+		default:
+			logger.Debug("unhandled event type", zap.Int("event", eventCount))
+		}
 	}
 
 	logger.Info("streaming completed", zap.Int("total_events", eventCount))
@@ -210,7 +210,7 @@ func runStreamingDemo(ctx context.Context, a2aClient client.A2AClient, logger *z
 		},
 	}
 
-	eventChan, err := a2aClient.SendTaskStreaming(ctx, params)
+	eventChan, err := a2aClient.StreamTask(ctx, params)
 	if err != nil {
 		logger.Error("failed to start streaming task", zap.Error(err))
 		return
@@ -228,48 +228,20 @@ func runStreamingDemo(ctx context.Context, a2aClient client.A2AClient, logger *z
 		eventCount++
 		fmt.Print(".")
 
-		if event.Result == nil {
-			continue
-		}
-
-		resultBytes, err := json.Marshal(event.Result)
-		if err != nil {
-			continue
-		}
-
-		// Streaming events alternate between full Task snapshots and
-		// TaskStatusUpdateEvent entries. The wire payload carries a
-		// "kind" discriminator that isn't on the generated types yet, so
-		// peek at the raw JSON to route the decode.
-		var disc struct {
-			Kind string `json:"kind"`
-		}
-		_ = json.Unmarshal(resultBytes, &disc)
-
-		switch disc.Kind {
-		case "task":
-			var task types.Task
-			if err := json.Unmarshal(resultBytes, &task); err == nil {
-				if task.ID != "" {
-					taskID = task.ID
-				}
-				if task.Status.Message != nil {
-					for _, part := range task.Status.Message.Parts {
-						if part.Text != nil {
-							streamedText += *part.Text
-						}
-					}
-				}
-				finalState = task.Status.State
+		switch e := event.(type) {
+		case client.MessageDelta:
+			if e.TaskID != "" {
+				taskID = e.TaskID
 			}
-		case "status-update":
-			var statusUpdate types.TaskStatusUpdateEvent
-			if err := json.Unmarshal(resultBytes, &statusUpdate); err == nil {
-				if statusUpdate.TaskID != "" {
-					taskID = statusUpdate.TaskID
-				}
-				finalState = statusUpdate.Status.State
+			streamedText += e.Text
+			finalState = e.State
+		case client.TaskStatusUpdate:
+			if e.TaskID != "" {
+				taskID = e.TaskID
 			}
+			finalState = e.Status.State
+		case client.StreamError:
+			logger.Error("stream ended with an error", zap.Error(e.Err))
 		}
 	}
 
@@ -0,0 +1,319 @@
+// Package loadtest puts streaming load on an A2A server: a number of concurrent clients send
+// messages of a configurable size at a configurable rate via message/stream, and the report
+// gives the latency percentiles of the first event and of whole streams, the streams that
+// failed or ended without a final status, and the events dropped on the way. It helps size
+// the queue, worker and buffer configuration of a server before production traffic does.
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	uuid "github.com/google/uuid"
+	rate "golang.org/x/time/rate"
+
+	client "github.com/inference-gateway/adk/client"
+	types "github.com/inference-gateway/adk/types"
+)
+
+const (
+	// DefaultClients is the number of concurrent clients when the configuration sets none
+	DefaultClients = 10
+
+	// DefaultMessages is the number of messages each client sends when the configuration sets
+	// neither messages nor a duration
+	DefaultMessages = 10
+
+	// DefaultMessageSize is the size in bytes of the messages when the configuration sets none
+	DefaultMessageSize = 256
+
+	// DefaultTimeout bounds each stream when the configuration sets no timeout
+	DefaultTimeout = time.Minute
+
+	// maxErrorKinds bounds the distinct error messages a report counts
+	maxErrorKinds = 20
+)
+
+// Config configures a load test
+type Config struct {
+	// URL is the base URL of the server
+	URL string
+
+	// Transport sends the requests, such as with credentials (default: http.DefaultTransport)
+	Transport http.RoundTripper
+
+	// Clients is the number of concurrent streaming clients (default: DefaultClients)
+	Clients int
+
+	// Messages is the number of messages each client sends. When Duration is set as well, the
+	// clients stop at whichever comes first (default: DefaultMessages without a Duration).
+	Messages int
+
+	// Duration is how long the clients keep sending messages
+	Duration time.Duration
+
+	// Rate is the number of messages per second each client starts at most, 0 sending the
+	// next message as soon as the previous stream ended
+	Rate float64
+
+	// MessageSize is the size in bytes of the text of each message (default: DefaultMessageSize)
+	MessageSize int
+
+	// Timeout bounds each stream (default: DefaultTimeout)
+	Timeout time.Duration
+
+	// ExpectedDeltas is the number of text deltas each stream should deliver, such as the
+	// chunks of a MockLLMClient. Streams delivering fewer count the difference as dropped
+	// events. A stream ending without its final status always counts that status as dropped.
+	ExpectedDeltas int
+}
+
+// Percentiles summarizes a distribution of latencies
+type Percentiles struct {
+	Min  time.Duration `json:"min"`
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	P95  time.Duration `json:"p95"`
+	P99  time.Duration `json:"p99"`
+	Max  time.Duration `json:"max"`
+	Mean time.Duration `json:"mean"`
+}
+
+// Report is the outcome of a load test
+type Report struct {
+	URL      string        `json:"url"`
+	Clients  int           `json:"clients"`
+	Duration time.Duration `json:"duration"`
+
+	// Streams is the number of messages sent
+	Streams int `json:"streams"`
+	// Completed is the number of streams that ended with a final status
+	Completed int `json:"completed"`
+	// Failed is the number of streams that could not be opened or broke off with an error
+	Failed int `json:"failed"`
+	// Incomplete is the number of streams the server ended without a final status
+	Incomplete int `json:"incomplete"`
+
+	// Events is the number of events received, not counting final statuses
+	Events int `json:"events"`
+	// DroppedEvents is the number of events expected but not received
+	DroppedEvents int `json:"droppedEvents"`
+
+	// Throughput is the number of completed streams per second
+	Throughput float64 `json:"throughput"`
+
+	// FirstEvent is the latency from sending a message to receiving the first event of its stream
+	FirstEvent Percentiles `json:"firstEvent"`
+	// StreamDuration is the latency from sending a message to the final status of its stream
+	StreamDuration Percentiles `json:"streamDuration"`
+
+	// Errors counts the streams that failed by error message
+	Errors map[string]int `json:"errors,omitempty"`
+}
+
+// stream is the outcome of a single message/stream request
+type stream struct {
+	events     int
+	deltas     int
+	final      bool
+	firstEvent time.Duration
+	duration   time.Duration
+	err        error
+}
+
+// Run runs the load test and reports its outcome. It returns an error only when the
+// configuration is invalid; failed streams are in the report.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	base, err := url.Parse(cfg.URL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("server URL '%s' must be an absolute URL", cfg.URL)
+	}
+	if cfg.Clients < 0 || cfg.Messages < 0 || cfg.Duration < 0 || cfg.Rate < 0 || cfg.MessageSize < 0 || cfg.ExpectedDeltas < 0 {
+		return nil, fmt.Errorf("load test settings must not be negative")
+	}
+	if cfg.Clients == 0 {
+		cfg.Clients = DefaultClients
+	}
+	if cfg.Messages == 0 && cfg.Duration == 0 {
+		cfg.Messages = DefaultMessages
+	}
+	if cfg.MessageSize == 0 {
+		cfg.MessageSize = DefaultMessageSize
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	clientConfig := client.DefaultConfig(strings.TrimSuffix(cfg.URL, "/"))
+	// streams are bounded by the timeout of the configuration instead
+	clientConfig.Timeout = 0
+	clientConfig.UserAgent = "adk-loadtest"
+	if cfg.Transport != nil {
+		clientConfig.Transport = cfg.Transport
+	}
+	a2aClient := client.NewClientWithConfig(clientConfig)
+
+	runCtx := ctx
+	if cfg.Duration > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, cfg.Duration)
+		defer cancel()
+	}
+
+	text := message(cfg.MessageSize)
+	results := make(chan stream)
+	var wg sync.WaitGroup
+	started := time.Now()
+	for range cfg.Clients {
+		wg.Go(func() {
+			var limiter *rate.Limiter
+			if cfg.Rate > 0 {
+				limiter = rate.NewLimiter(rate.Limit(cfg.Rate), 1)
+			}
+			for sent := 0; cfg.Messages == 0 || sent < cfg.Messages; sent++ {
+				if limiter != nil {
+					if err := limiter.Wait(runCtx); err != nil {
+						return
+					}
+				}
+				if runCtx.Err() != nil {
+					return
+				}
+				// a stream started before the end of the run is followed to its end
+				results <- runStream(ctx, a2aClient, cfg.Timeout, text)
+			}
+		})
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	report := &Report{URL: cfg.URL, Clients: cfg.Clients}
+	var firstEvents, durations []time.Duration
+	for result := range results {
+		report.Streams++
+		report.Events += result.events
+		if result.events > 0 {
+			firstEvents = append(firstEvents, result.firstEvent)
+		}
+		switch {
+		case result.err != nil:
+			report.Failed++
+			report.addError(result.err)
+		case !result.final:
+			report.Incomplete++
+		default:
+			report.Completed++
+			durations = append(durations, result.duration)
+		}
+		if result.err == nil {
+			report.DroppedEvents += max(cfg.ExpectedDeltas-result.deltas, 0)
+			if !result.final {
+				report.DroppedEvents++
+			}
+		}
+	}
+
+	report.Duration = time.Since(started)
+	if report.Duration > 0 {
+		report.Throughput = float64(report.Completed) / report.Duration.Seconds()
+	}
+	report.FirstEvent = percentiles(firstEvents)
+	report.StreamDuration = percentiles(durations)
+	return report, nil
+}
+
+// runStream sends a message via message/stream and follows its stream to the end
+func runStream(ctx context.Context, a2aClient client.A2AClient, timeout time.Duration, text string) stream {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	started := time.Now()
+	events, err := a2aClient.StreamTask(ctx, types.MessageSendParams{Message: types.Message{
+		MessageID: uuid.New().String(),
+		Role:      types.RoleUser,
+		Parts:     []types.Part{types.CreateTextPart(text)},
+	}})
+	if err != nil {
+		return stream{err: err}
+	}
+
+	var result stream
+	for event := range events {
+		switch e := event.(type) {
+		case client.StreamError:
+			result.err = e.Err
+			continue
+		case client.TaskStatusUpdate:
+			if e.Final {
+				result.final = true
+				result.duration = time.Since(started)
+				continue
+			}
+		case client.MessageDelta:
+			if e.Text != "" {
+				result.deltas++
+			}
+		}
+		if result.events == 0 {
+			result.firstEvent = time.Since(started)
+		}
+		result.events++
+	}
+	if result.err == nil && !result.final && ctx.Err() != nil {
+		result.err = fmt.Errorf("stream did not end within %s", timeout)
+	}
+	return result
+}
+
+// addError counts a failed stream by its error message
+func (r *Report) addError(err error) {
+	if r.Errors == nil {
+		r.Errors = make(map[string]int)
+	}
+	message := err.Error()
+	if _, exists := r.Errors[message]; !exists && len(r.Errors) >= maxErrorKinds {
+		message = "other errors"
+	}
+	r.Errors[message]++
+}
+
+// message returns text of the size in bytes
+func message(size int) string {
+	const filler = "The quick brown fox jumps over the lazy dog. "
+	return strings.Repeat(filler, size/len(filler)+1)[:size]
+}
+
+// percentiles summarizes the latencies by nearest rank
+func percentiles(latencies []time.Duration) Percentiles {
+	if len(latencies) == 0 {
+		return Percentiles{}
+	}
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+
+	rank := func(p float64) time.Duration {
+		index := int(p*float64(len(sorted))+0.5) - 1
+		return sorted[min(max(index, 0), len(sorted)-1)]
+	}
+	var total time.Duration
+	for _, latency := range sorted {
+		total += latency
+	}
+	return Percentiles{
+		Min:  sorted[0],
+		P50:  rank(0.50),
+		P90:  rank(0.90),
+		P95:  rank(0.95),
+		P99:  rank(0.99),
+		Max:  sorted[len(sorted)-1],
+		Mean: total / time.Duration(len(sorted)),
+	}
+}
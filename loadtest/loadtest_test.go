@@ -0,0 +1,114 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	adktesting "github.com/inference-gateway/adk/testing"
+)
+
+func TestRun_MockAgent(t *testing.T) {
+	s := adktesting.NewServer(t, adktesting.NewAgent(t, NewMockLLMClient(5, time.Millisecond)))
+
+	report, err := Run(context.Background(), Config{
+		URL:            s.URL,
+		Clients:        4,
+		Messages:       3,
+		MessageSize:    1024,
+		ExpectedDeltas: 5,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, report.Clients)
+	assert.Equal(t, 12, report.Streams)
+	assert.Equal(t, 12, report.Completed)
+	assert.Zero(t, report.Failed)
+	assert.Zero(t, report.Incomplete)
+	assert.Zero(t, report.DroppedEvents)
+	assert.GreaterOrEqual(t, report.Events, 12*5)
+	assert.Empty(t, report.Errors)
+	assert.Positive(t, report.Throughput)
+
+	latency := report.StreamDuration
+	assert.GreaterOrEqual(t, latency.Min, 5*time.Millisecond, "each stream waits for the delay of every chunk")
+	assert.LessOrEqual(t, latency.P50, latency.P99)
+	assert.LessOrEqual(t, latency.P99, latency.Max)
+	assert.LessOrEqual(t, report.FirstEvent.Max, latency.Max)
+}
+
+func TestRun_DroppedEvents(t *testing.T) {
+	// the server streams a single delta and ends the stream without a final status
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/a2a" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, `data: {"jsonrpc":"2.0","id":"1","result":{"kind":"message","messageId":"m-1","role":"ROLE_AGENT","parts":[{"kind":"text","text":"token1 "}]}}`+"\n\n")
+	}))
+	defer broken.Close()
+
+	report, err := Run(context.Background(), Config{URL: broken.URL, Clients: 2, Messages: 2, Rate: 100, ExpectedDeltas: 5})
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Streams)
+	assert.Equal(t, 4, report.Incomplete)
+	assert.Zero(t, report.Completed)
+	assert.Equal(t, 4, report.Events)
+	assert.Equal(t, 4*(4+1), report.DroppedEvents, "4 missing deltas and the final status per stream")
+	assert.Zero(t, report.StreamDuration)
+	assert.NotZero(t, report.FirstEvent.P50)
+}
+
+func TestRun_MockServerDuration(t *testing.T) {
+	cfg, err := config.NewWithDefaults(context.Background(), nil)
+	require.NoError(t, err)
+	s, err := StartMockServer(cfg, NewMockLLMClient(2, 0), zap.NewNop())
+	require.NoError(t, err)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, s.Stop(ctx))
+	}()
+
+	report, err := Run(context.Background(), Config{URL: s.URL, Clients: 1, Duration: 200 * time.Millisecond, Rate: 20, ExpectedDeltas: 2})
+	require.NoError(t, err)
+	assert.Equal(t, report.Streams, report.Completed)
+	assert.Zero(t, report.DroppedEvents)
+	assert.InDelta(t, 5, report.Streams, 2, "a rate of 20 per second for 200ms")
+}
+
+func TestRun_InvalidConfig(t *testing.T) {
+	_, err := Run(context.Background(), Config{URL: "localhost:8080"})
+	assert.EqualError(t, err, "server URL 'localhost:8080' must be an absolute URL")
+
+	_, err = Run(context.Background(), Config{URL: "http://localhost:8080", Clients: -1})
+	assert.EqualError(t, err, "load test settings must not be negative")
+}
+
+func TestPercentiles(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	assert.Equal(t, Percentiles{
+		Min:  time.Millisecond,
+		P50:  50 * time.Millisecond,
+		P90:  90 * time.Millisecond,
+		P95:  95 * time.Millisecond,
+		P99:  99 * time.Millisecond,
+		Max:  100 * time.Millisecond,
+		Mean: 50500 * time.Microsecond,
+	}, percentiles(latencies))
+	assert.Equal(t, 100*time.Millisecond, latencies[0], "the latencies are not sorted in place")
+	assert.Equal(t, Percentiles{}, percentiles(nil))
+}
@@ -0,0 +1,179 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+	zap "go.uber.org/zap"
+
+	server "github.com/inference-gateway/adk/server"
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+var _ server.LLMClient = (*MockLLMClient)(nil)
+
+// MockLLMClient answers every request with the same number of chunks at a fixed pace, without
+// a provider. Serving an agent backed by it puts load on the queue, streaming and buffers of
+// the server alone, and makes the number of events each stream should deliver known, so
+// dropped events can be counted with Config.ExpectedDeltas.
+type MockLLMClient struct {
+	// Chunks is the number of text chunks streamed per request
+	Chunks int
+
+	// ChunkDelay is the pause before each chunk, simulating the generation speed of a model
+	ChunkDelay time.Duration
+}
+
+// NewMockLLMClient creates a mock LLM client streaming the chunks at the delay
+func NewMockLLMClient(chunks int, chunkDelay time.Duration) *MockLLMClient {
+	return &MockLLMClient{Chunks: chunks, ChunkDelay: chunkDelay}
+}
+
+// chunk returns the text of the chunk at the index
+func (c *MockLLMClient) chunk(index int) string {
+	return fmt.Sprintf("token%d ", index+1)
+}
+
+// CreateChatCompletion answers with the text of all chunks, after the delay of all of them
+func (c *MockLLMClient) CreateChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (*sdk.CreateChatCompletionResponse, error) {
+	select {
+	case <-time.After(time.Duration(c.Chunks) * c.ChunkDelay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var text strings.Builder
+	for i := range c.Chunks {
+		text.WriteString(c.chunk(i))
+	}
+	message, err := sdk.NewTextMessage(sdk.Assistant, text.String())
+	if err != nil {
+		return nil, err
+	}
+	return &sdk.CreateChatCompletionResponse{
+		Choices: []sdk.ChatCompletionChoice{{Message: message, FinishReason: sdk.Stop}},
+	}, nil
+}
+
+// CreateStreamingChatCompletion streams the chunks, followed by a chunk finishing the answer
+func (c *MockLLMClient) CreateStreamingChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+	responseChan := make(chan *sdk.CreateChatCompletionStreamResponse)
+	errorChan := make(chan error, 1)
+
+	// the error channel stays open, as a closed one would end the stream before its chunks
+	go func() {
+		defer close(responseChan)
+		send := func(chunk *sdk.CreateChatCompletionStreamResponse) bool {
+			select {
+			case responseChan <- chunk:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for i := range c.Chunks {
+			if c.ChunkDelay > 0 {
+				select {
+				case <-time.After(c.ChunkDelay):
+				case <-ctx.Done():
+					return
+				}
+			}
+			if !send(&sdk.CreateChatCompletionStreamResponse{
+				Choices: []sdk.ChatCompletionStreamChoice{{Delta: sdk.ChatCompletionStreamResponseDelta{Content: c.chunk(i)}}},
+			}) {
+				return
+			}
+		}
+		send(&sdk.CreateChatCompletionStreamResponse{
+			Choices: []sdk.ChatCompletionStreamChoice{{FinishReason: sdk.Stop}},
+		})
+	}()
+	return responseChan, errorChan
+}
+
+// MockServer is an A2A server serving an agent backed by a MockLLMClient on a local port
+type MockServer struct {
+	// URL is the base URL the server is reachable at
+	URL string
+
+	a2aServer  server.A2AServer
+	httpServer *http.Server
+	cancel     context.CancelFunc
+	processed  chan struct{}
+}
+
+// StartMockServer serves an agent backed by the mock LLM client with the default task
+// handlers and the configuration, such as its queue and worker settings, on a free local
+// port. Stop it once the load test ends.
+func StartMockServer(cfg *config.Config, llm *MockLLMClient, logger *zap.Logger) (*MockServer, error) {
+	agent, err := server.NewAgentBuilder(logger).
+		WithConfig(&cfg.AgentConfig).
+		WithLLMClient(llm).
+		WithToolBox(server.NewToolBox()).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build mock agent: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+	url := "http://" + listener.Addr().String()
+
+	a2aServer, err := server.NewA2AServerBuilder(*cfg, logger).
+		WithAgent(agent).
+		WithDefaultTaskHandlers().
+		WithAgentCard(types.AgentCard{
+			Name:               "mock-agent",
+			Description:        "Streams mock LLM chunks for load tests",
+			Version:            "1.0.0",
+			ProtocolVersion:    "0.3.0",
+			URL:                &url,
+			Capabilities:       types.AgentCapabilities{Streaming: new(true)},
+			DefaultInputModes:  []string{"text/plain"},
+			DefaultOutputModes: []string{"text/plain"},
+			Skills:             []types.AgentSkill{},
+		}).
+		Build()
+	if err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("failed to build mock server: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &MockServer{
+		URL:        url,
+		a2aServer:  a2aServer,
+		httpServer: &http.Server{Handler: a2aServer.Handler(), ReadHeaderTimeout: 10 * time.Second},
+		cancel:     cancel,
+		processed:  make(chan struct{}),
+	}
+	go func() {
+		defer close(s.processed)
+		a2aServer.StartTaskProcessor(ctx)
+	}()
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("mock server stopped", zap.Error(err))
+		}
+	}()
+	return s, nil
+}
+
+// Stop stops serving and processing tasks
+func (s *MockServer) Stop(ctx context.Context) error {
+	err := s.httpServer.Shutdown(ctx)
+	s.cancel()
+	<-s.processed
+	return errors.Join(err, s.a2aServer.Stop(ctx))
+}
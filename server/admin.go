@@ -0,0 +1,266 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// adminPathPrefix is the path under which the admin endpoints are mounted
+const adminPathPrefix = "/admin"
+
+// defaultForceFailReason is the status message of tasks failed without a reason
+const defaultForceFailReason = "Task was failed by an administrator"
+
+// errQueuePauseChanged reports that the task processor was paused or resumed while waiting for a task
+var errQueuePauseChanged = errors.New("queue processing was paused or resumed")
+
+// queuePause pauses the task processor between tasks. The zero value is running.
+type queuePause struct {
+	mu      sync.Mutex
+	paused  bool
+	changed chan struct{}
+}
+
+// state reports whether processing is paused, together with a channel that is closed
+// when processing is next paused or resumed
+func (p *queuePause) state() (bool, <-chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.changed == nil {
+		p.changed = make(chan struct{})
+	}
+	return p.paused, p.changed
+}
+
+// set pauses or resumes processing, reporting whether the state changed
+func (p *queuePause) set(paused bool) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.paused == paused {
+		return false
+	}
+	p.paused = paused
+	if p.changed != nil {
+		close(p.changed)
+	}
+	p.changed = make(chan struct{})
+	return true
+}
+
+// isPaused reports whether processing is paused
+func (p *queuePause) isPaused() bool {
+	paused, _ := p.state()
+	return paused
+}
+
+// dequeueUnlessPaused waits for the next queued task. While processing is paused it waits
+// for processing to resume, and it stops waiting for a task when processing is paused, in
+// both cases returning errQueuePauseChanged so the caller checks again.
+func (s *A2AServerImpl) dequeueUnlessPaused(ctx context.Context) (*QueuedTask, error) {
+	paused, changed := s.queuePause.state()
+	if paused {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+			return nil, errQueuePauseChanged
+		}
+	}
+
+	dequeueCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-changed:
+			cancel()
+		case <-dequeueCtx.Done():
+		}
+	}()
+
+	queuedTask, err := s.storage.DequeueTask(dequeueCtx)
+	if err != nil && ctx.Err() == nil && dequeueCtx.Err() != nil {
+		return nil, errQueuePauseChanged
+	}
+	return queuedTask, err
+}
+
+// mountAdminEndpoints serves the admin endpoints under /admin, behind the same middleware
+// as the A2A endpoint and the admin scope. They are not mounted without authentication or
+// without an admin scope.
+func (s *A2AServerImpl) mountAdminEndpoints(r *gin.Engine, handlers []gin.HandlerFunc) {
+	if !s.cfg.AuthConfig.Enable {
+		s.logger.Error("admin endpoints require authentication and are not served")
+		return
+	}
+	if s.cfg.ServerConfig.AdminScope == "" {
+		s.logger.Error("admin endpoints require an admin scope and are not served")
+		return
+	}
+
+	group := r.Group(adminPathPrefix, append(slices.Clone(handlers), s.requireAdminScope)...)
+	group.GET("/queue", s.handleAdminQueue)
+	group.POST("/queue/pause", s.handleAdminPauseQueue)
+	group.POST("/queue/resume", s.handleAdminResumeQueue)
+	group.POST("/drain", s.handleAdminDrain)
+	group.POST("/tasks/purge", s.handleAdminPurgeTasks)
+	group.POST("/tasks/:id/fail", s.handleAdminFailTask)
+	group.GET("/schedules", s.handleAdminSchedules)
+	group.POST("/schedules/:id/enable", s.handleAdminEnableSchedule)
+	group.POST("/schedules/:id/disable", s.handleAdminDisableSchedule)
+}
+
+// requireAdminScope rejects callers that were not granted the configured admin scope
+func (s *A2AServerImpl) requireAdminScope(c *gin.Context) {
+	scope := s.cfg.ServerConfig.AdminScope
+	if scope != "" && slices.Contains(middlewares.ScopesFromContext(c.Request.Context()), scope) {
+		c.Next()
+		return
+	}
+	s.logger.Warn("admin request rejected, caller lacks the admin scope",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("scope", scope))
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "the admin scope is required"})
+}
+
+// handleAdminQueue serves the queue depth and the task processor state
+func (s *A2AServerImpl) handleAdminQueue(c *gin.Context) {
+	c.JSON(http.StatusOK, s.collectInternalStats(c.Request.Context()))
+}
+
+// handleAdminPauseQueue stops the task processor from taking further tasks from the queue.
+// The task being processed finishes, and tasks keep being queued.
+func (s *A2AServerImpl) handleAdminPauseQueue(c *gin.Context) {
+	if s.queuePause.set(true) {
+		s.logger.Info("queue processing paused by admin request")
+	}
+	c.JSON(http.StatusOK, s.collectInternalStats(c.Request.Context()))
+}
+
+// handleAdminResumeQueue lets the task processor take tasks from the queue again
+func (s *A2AServerImpl) handleAdminResumeQueue(c *gin.Context) {
+	if s.queuePause.set(false) {
+		s.logger.Info("queue processing resumed by admin request")
+	}
+	c.JSON(http.StatusOK, s.collectInternalStats(c.Request.Context()))
+}
+
+// handleAdminDrain drains the server as on shutdown, optionally with the timeout query
+// parameter instead of the configured drain timeout. The server does not accept new streams
+// afterwards and reports itself unhealthy, so it can be taken out of rotation before it is stopped.
+func (s *A2AServerImpl) handleAdminDrain(c *gin.Context) {
+	timeout := s.cfg.ServerConfig.DrainTimeout
+	if value := c.Query("timeout"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "timeout must be a non-negative duration such as 30s"})
+			return
+		}
+		timeout = parsed
+	}
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+
+	s.logger.Info("draining server by admin request", zap.Duration("drain_timeout", timeout))
+	if s.httpServer != nil {
+		s.httpServer.SetKeepAlivesEnabled(false)
+	}
+	drained := s.drainWithNamedAgents(c.Request.Context(), timeout)
+	if !drained {
+		s.logger.Warn("in-flight work did not finish within the drain timeout and was checkpointed",
+			zap.Duration("drain_timeout", timeout))
+	}
+	c.JSON(http.StatusOK, gin.H{"drained": drained})
+}
+
+// handleAdminPurgeTasks removes all completed, failed and cancelled tasks from storage
+func (s *A2AServerImpl) handleAdminPurgeTasks(c *gin.Context) {
+	purged := s.storage.CleanupCompletedTasks()
+	s.logger.Info("purged finished tasks by admin request", zap.Int("count", purged))
+	c.JSON(http.StatusOK, gin.H{"purged": purged})
+}
+
+// handleAdminFailTask ends a stuck task in the failed state, stopping its execution when it
+// is running. The optional reason in the request body becomes the task status message.
+func (s *A2AServerImpl) handleAdminFailTask(c *gin.Context) {
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+	}
+	if body.Reason == "" {
+		body.Reason = defaultForceFailReason
+	}
+
+	taskID := c.Param("id")
+	task, exists := s.taskManager.GetTask(taskID)
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		return
+	}
+	if isTerminalTaskState(task.Status.State) {
+		c.JSON(http.StatusConflict, gin.H{"error": "task is already in a final state"})
+		return
+	}
+
+	if defaultTM, ok := s.taskManager.(*DefaultTaskManager); ok {
+		defaultTM.stopRunningTask(taskID)
+	}
+	err := s.taskManager.UpdateError(taskID, &types.Message{
+		MessageID: s.ids.NewID(),
+		Role:      types.RoleAgent,
+		Parts:     []types.Part{types.CreateTextPart(body.Reason)},
+	})
+	if err != nil {
+		s.logger.Error("failed to fail task by admin request", zap.String("task_id", taskID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update task"})
+		return
+	}
+	s.logger.Info("task failed by admin request", zap.String("task_id", taskID), zap.String("reason", body.Reason))
+
+	task, _ = s.taskManager.GetTask(taskID)
+	c.JSON(http.StatusOK, task)
+}
+
+// handleAdminSchedules serves the schedules of recurring agent runs with their next and last runs
+func (s *A2AServerImpl) handleAdminSchedules(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"schedules": s.ListSchedules()})
+}
+
+// handleAdminEnableSchedule resumes the runs of a schedule
+func (s *A2AServerImpl) handleAdminEnableSchedule(c *gin.Context) {
+	s.respondScheduleChange(c, true)
+}
+
+// handleAdminDisableSchedule stops a schedule from starting further runs
+func (s *A2AServerImpl) handleAdminDisableSchedule(c *gin.Context) {
+	s.respondScheduleChange(c, false)
+}
+
+// respondScheduleChange enables or disables the schedule of the request and serves its state
+func (s *A2AServerImpl) respondScheduleChange(c *gin.Context, enabled bool) {
+	scheduleID := c.Param("id")
+	status, err := s.schedules.setEnabled(scheduleID, enabled, s.clock.Now())
+	if errors.Is(err, ErrScheduleNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "schedule not found"})
+		return
+	}
+	s.logger.Info("schedule changed by admin request",
+		zap.String("schedule_id", scheduleID),
+		zap.Bool("enabled", enabled))
+	c.JSON(http.StatusOK, status)
+}
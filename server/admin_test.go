@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// adminTestScope is the admin scope of the admin test servers
+const adminTestScope = "a2a:admin"
+
+// newAdminTestConfig returns a configuration serving the admin endpoints behind authentication
+// and the admin test scope
+func newAdminTestConfig() *config.Config {
+	return &config.Config{
+		AuthConfig:   config.AuthConfig{Enable: true},
+		ServerConfig: config.ServerConfig{EnableAdminEndpoints: true, AdminScope: adminTestScope},
+	}
+}
+
+// newAdminTestRouter mounts the admin endpoints behind a stand-in for the authentication
+// middleware, which grants the scopes of the X-Test-Scopes header or else the admin scope
+func newAdminTestRouter(s *A2AServerImpl) *gin.Engine {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		scopes := []string{adminTestScope}
+		if values, ok := c.Request.Header["X-Test-Scopes"]; ok {
+			scopes = strings.Fields(values[0])
+		}
+		c.Request = c.Request.WithContext(middlewares.ContextWithScopes(c.Request.Context(), scopes))
+	})
+	s.mountAdminEndpoints(router, nil)
+	return router
+}
+
+func newAdminTestServer(t *testing.T) (*A2AServerImpl, *blockingTaskHandler, *gin.Engine) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	cfg := newAdminTestConfig()
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	handler := &blockingTaskHandler{finishAfter: time.Minute, started: make(chan string, 10)}
+	s.SetBackgroundTaskHandler(handler)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		s.StartTaskProcessor(ctx)
+		close(stopped)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+	return s, handler, newAdminTestRouter(s)
+}
+
+func adminRequest(t *testing.T, router *gin.Engine, method, path, body string, target any) int {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	if target != nil && w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), target))
+	}
+	return w.Code
+}
+
+func TestA2AServer_AdminQueueControl(t *testing.T) {
+	s, handler, router := newAdminTestServer(t)
+
+	var stats InternalStats
+	require.Equal(t, http.StatusOK, adminRequest(t, router, http.MethodPost, "/admin/queue/pause", "", &stats))
+	assert.True(t, stats.Workers.Paused)
+
+	stuck := enqueueHandoffTestTask(t, s)
+	queued := enqueueHandoffTestTask(t, s)
+	time.Sleep(50 * time.Millisecond)
+	assert.Zero(t, handler.calls.Load(), "a paused processor must not take tasks")
+	require.Equal(t, http.StatusOK, adminRequest(t, router, http.MethodGet, "/admin/queue", "", &stats))
+	assert.Equal(t, 2, stats.Queue.Depth)
+
+	require.Equal(t, http.StatusOK, adminRequest(t, router, http.MethodPost, "/admin/tasks/"+queued.ID+"/fail", "", nil))
+	require.Equal(t, http.StatusOK, adminRequest(t, router, http.MethodPost, "/admin/queue/resume", "", &stats))
+	assert.False(t, stats.Workers.Paused)
+	waitForTaskStart(t, handler)
+
+	var failed types.Task
+	path := "/admin/tasks/" + stuck.ID + "/fail"
+	require.Equal(t, http.StatusOK, adminRequest(t, router, http.MethodPost, path, `{"reason":"stuck on a slow tool"}`, &failed))
+	assert.Equal(t, types.TaskStateFailed, failed.Status.State)
+	require.NotNil(t, failed.Status.Message)
+	require.NotNil(t, failed.Status.Message.Parts[0].Text)
+	assert.Equal(t, "stuck on a slow tool", *failed.Status.Message.Parts[0].Text)
+
+	assert.Equal(t, http.StatusConflict, adminRequest(t, router, http.MethodPost, path, "", nil))
+	assert.Equal(t, http.StatusNotFound, adminRequest(t, router, http.MethodPost, "/admin/tasks/unknown/fail", "", nil))
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), handler.calls.Load(), "tasks failed while queued are skipped")
+	stored, exists := s.taskManager.GetTask(stuck.ID)
+	require.True(t, exists)
+	assert.Equal(t, "stuck on a slow tool", *stored.Status.Message.Parts[0].Text, "the cancelled run must not overwrite the reason")
+
+	var purged struct {
+		Purged int `json:"purged"`
+	}
+	require.Equal(t, http.StatusOK, adminRequest(t, router, http.MethodPost, "/admin/tasks/purge", "", &purged))
+	assert.Equal(t, 2, purged.Purged)
+	_, exists = s.taskManager.GetTask(stuck.ID)
+	assert.False(t, exists)
+}
+
+func TestA2AServer_AdminDrain(t *testing.T) {
+	s, _, router := newAdminTestServer(t)
+
+	assert.Equal(t, http.StatusBadRequest, adminRequest(t, router, http.MethodPost, "/admin/drain?timeout=soon", "", nil))
+
+	var result struct {
+		Drained bool `json:"drained"`
+	}
+	require.Equal(t, http.StatusOK, adminRequest(t, router, http.MethodPost, "/admin/drain?timeout=10ms", "", &result))
+	assert.True(t, result.Drained)
+	assert.True(t, s.drain.isDraining(), "a drained server reports itself unhealthy")
+}
+
+func TestA2AServer_AdminAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	disabled := &config.Config{}
+	router := NewA2AServer(disabled, zap.NewNop(), nil).setupRouter(disabled)
+	assert.Equal(t, http.StatusNotFound, adminRequest(t, router, http.MethodGet, "/admin/queue", "", nil))
+
+	withoutAuth := newAdminTestConfig()
+	withoutAuth.AuthConfig.Enable = false
+	router = NewA2AServer(withoutAuth, zap.NewNop(), nil).setupRouter(withoutAuth)
+	assert.Equal(t, http.StatusNotFound, adminRequest(t, router, http.MethodGet, "/admin/queue", "", nil), "admin endpoints are not served without authentication")
+
+	withoutScope := newAdminTestConfig()
+	withoutScope.ServerConfig.AdminScope = ""
+	router = newAdminTestRouter(NewA2AServer(withoutScope, zap.NewNop(), nil))
+	assert.Equal(t, http.StatusNotFound, adminRequest(t, router, http.MethodGet, "/admin/queue", "", nil), "admin endpoints are not served without an admin scope")
+
+	_, _, router = newAdminTestServer(t)
+
+	request := func(scopes string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/admin/queue", nil)
+		req.Header.Set("X-Test-Scopes", scopes)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	assert.Equal(t, http.StatusForbidden, request(""))
+	assert.Equal(t, http.StatusForbidden, request("a2a:read"))
+	assert.Equal(t, http.StatusOK, request("a2a:read "+adminTestScope))
+}
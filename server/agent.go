@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	config "github.com/inference-gateway/adk/server/config"
@@ -31,6 +32,13 @@ type OpenAICompatibleAgentImpl struct {
 	callbackExecutor CallbackExecutor
 	converter        utils.MessageConverter
 	config           *config.AgentConfig
+	budget           *Budget
+	modelRouter      ModelRouter
+	skills           map[string]SkillBinding
+
+	// System prompt set after construction, overriding the configured one
+	promptMu     sync.RWMutex
+	systemPrompt *string
 }
 
 // NewOpenAICompatibleAgent creates a new OpenAICompatibleAgentImpl
@@ -50,7 +58,7 @@ func NewOpenAICompatibleAgent(logger *zap.Logger) *OpenAICompatibleAgentImpl {
 func NewOpenAICompatibleAgentWithConfig(logger *zap.Logger, cfg *config.AgentConfig) *OpenAICompatibleAgentImpl {
 	return &OpenAICompatibleAgentImpl{
 		logger:    logger,
-		converter: utils.NewMessageConverter(logger),
+		converter: utils.NewMessageConverterWithVision(logger, cfg != nil && cfg.EnableVision),
 		config:    cfg,
 	}
 }
@@ -74,6 +82,26 @@ func NewOpenAICompatibleAgentWithLLMConfig(logger *zap.Logger, config *config.Ag
 	return agent, nil
 }
 
+// SetSystemPrompt replaces the system prompt for runs started afterwards
+func (a *OpenAICompatibleAgentImpl) SetSystemPrompt(prompt string) {
+	a.promptMu.Lock()
+	defer a.promptMu.Unlock()
+	a.systemPrompt = &prompt
+}
+
+// currentSystemPrompt returns the system prompt for a new run
+func (a *OpenAICompatibleAgentImpl) currentSystemPrompt() string {
+	a.promptMu.RLock()
+	defer a.promptMu.RUnlock()
+	if a.systemPrompt != nil {
+		return *a.systemPrompt
+	}
+	if a.config != nil {
+		return a.config.SystemPrompt
+	}
+	return ""
+}
+
 // SetLLMClient sets the LLM client for the agent
 func (a *OpenAICompatibleAgentImpl) SetLLMClient(client LLMClient) {
 	a.llmClient = client
@@ -84,6 +112,11 @@ func (a *OpenAICompatibleAgentImpl) SetToolBox(toolBox ToolBox) {
 	a.toolBox = toolBox
 }
 
+// SetModelRouter sets the router selecting the model of each run
+func (a *OpenAICompatibleAgentImpl) SetModelRouter(router ModelRouter) {
+	a.modelRouter = router
+}
+
 // SetCallbackExecutor sets the callback executor for the agent
 func (a *OpenAICompatibleAgentImpl) SetCallbackExecutor(executor CallbackExecutor) {
 	a.callbackExecutor = executor
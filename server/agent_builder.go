@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"fmt"
 
 	config "github.com/inference-gateway/adk/server/config"
 	zap "go.uber.org/zap"
@@ -40,6 +41,18 @@ type AgentBuilder interface {
 	// Callbacks allow you to hook into various points of the agent's execution lifecycle
 	// including before/after agent execution, model calls, and tool execution
 	WithCallbacks(config *CallbackConfig) AgentBuilder
+	// WithKnowledgeBase grounds the agent in the documents indexed in the store
+	// The agent gets a knowledge_search tool that embeds queries with the embedder
+	WithKnowledgeBase(store VectorStore, embedder Embedder) AgentBuilder
+	// WithBudget enforces the budget's spend limits instead of the budget in the agent configuration
+	// A budget can be shared by several agents to limit their combined spend
+	WithBudget(budget *Budget) AgentBuilder
+	// WithModelRouter selects the model of each run instead of the routing rules in the agent configuration
+	// The router sees the length and image parts of the message, the offered tools and the modelHint metadata
+	WithModelRouter(router ModelRouter) AgentBuilder
+	// WithSkill binds a skill of the agent card to tools, instructions or a sub-agent
+	// Messages naming the skill in their skillId metadata are handled with the binding
+	WithSkill(skillID string, binding SkillBinding) AgentBuilder
 	// GetConfig returns the current agent configuration (for testing purposes)
 	GetConfig() *config.AgentConfig
 	// Build creates and returns the configured agent
@@ -57,6 +70,10 @@ type AgentBuilderImpl struct {
 	toolBox        ToolBox
 	systemPrompt   *string // Use pointer to distinguish between not set and empty string
 	callbackConfig *CallbackConfig
+	knowledgeBase  *KnowledgeBase
+	budget         *Budget
+	modelRouter    ModelRouter
+	skills         map[string]SkillBinding
 }
 
 // NewAgentBuilder creates a new agent builder with required dependencies.
@@ -153,6 +170,34 @@ func (b *AgentBuilderImpl) WithCallbacks(config *CallbackConfig) AgentBuilder {
 	return b
 }
 
+// WithKnowledgeBase grounds the agent in the documents indexed in the store
+// The knowledge_search tool is added to the agent's toolbox, or to a new toolbox if none is set
+func (b *AgentBuilderImpl) WithKnowledgeBase(store VectorStore, embedder Embedder) AgentBuilder {
+	b.knowledgeBase = NewKnowledgeBase(store, embedder)
+	return b
+}
+
+// WithBudget enforces the budget's spend limits instead of the budget in the agent configuration
+func (b *AgentBuilderImpl) WithBudget(budget *Budget) AgentBuilder {
+	b.budget = budget
+	return b
+}
+
+// WithModelRouter selects the model of each run instead of the routing rules in the agent configuration
+func (b *AgentBuilderImpl) WithModelRouter(router ModelRouter) AgentBuilder {
+	b.modelRouter = router
+	return b
+}
+
+// WithSkill binds a skill of the agent card to tools, instructions or a sub-agent
+func (b *AgentBuilderImpl) WithSkill(skillID string, binding SkillBinding) AgentBuilder {
+	if b.skills == nil {
+		b.skills = make(map[string]SkillBinding)
+	}
+	b.skills[skillID] = binding
+	return b
+}
+
 // GetConfig returns the current agent configuration (for testing purposes)
 func (b *AgentBuilderImpl) GetConfig() *config.AgentConfig {
 	return b.config
@@ -180,13 +225,58 @@ func (b *AgentBuilderImpl) Build() (*OpenAICompatibleAgentImpl, error) {
 		agent.SetLLMClient(b.llmClient)
 	}
 
-	if b.toolBox != nil {
-		agent.SetToolBox(b.toolBox)
+	toolBox := b.toolBox
+	if b.knowledgeBase != nil {
+		knowledgeSearch := NewKnowledgeSearchTool(b.knowledgeBase)
+		switch tb := toolBox.(type) {
+		case nil:
+			defaultToolBox := NewToolBox()
+			defaultToolBox.AddTool(knowledgeSearch)
+			toolBox = defaultToolBox
+		case interface{ AddTool(Tool) }:
+			tb.AddTool(knowledgeSearch)
+		default:
+			return nil, fmt.Errorf("toolbox %T does not support adding the knowledge_search tool", toolBox)
+		}
+	}
+
+	if toolBox != nil {
+		agent.SetToolBox(toolBox)
+	}
+
+	for skillID, binding := range b.skills {
+		agent.BindSkill(skillID, binding)
+	}
+
+	modelRouter := b.modelRouter
+	if modelRouter == nil && b.config != nil && b.config.Routing.Enabled() {
+		modelRouter = NewRuleModelRouter(b.config.Routing)
+	}
+	if modelRouter != nil {
+		agent.SetModelRouter(modelRouter)
+	}
+
+	callbackConfig := b.callbackConfig
+	budget := b.budget
+	if budget == nil && b.config != nil && b.config.Budget.Enabled() {
+		budget = NewBudget(b.config.Budget, b.logger)
+	}
+	if budget != nil {
+		agent.budget = budget
+		// The budget runs before the other model callbacks so nothing bypasses it
+		withBudget := CallbackConfig{}
+		if callbackConfig != nil {
+			withBudget = *callbackConfig
+		}
+		budgetCallbacks := budget.Callbacks()
+		withBudget.BeforeModel = append(budgetCallbacks.BeforeModel, withBudget.BeforeModel...)
+		withBudget.AfterModel = append(budgetCallbacks.AfterModel, withBudget.AfterModel...)
+		callbackConfig = &withBudget
 	}
 
 	// Set up callback executor if callbacks are configured
-	if b.callbackConfig != nil {
-		agent.SetCallbackExecutor(NewCallbackExecutor(b.callbackConfig, b.logger))
+	if callbackConfig != nil {
+		agent.SetCallbackExecutor(NewCallbackExecutor(callbackConfig, b.logger))
 	}
 
 	return agent, nil
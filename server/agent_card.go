@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// AgentCardProvider adjusts the agent card advertised for a request. It receives a copy of
+// the card configured on the server - the public card for `.well-known/agent-card.json`, the
+// extended card for agent/getAuthenticatedExtendedCard - and returns the card to serve, for
+// instance with skills of tools that connected after startup.
+type AgentCardProvider func(ctx context.Context, card types.AgentCard) (types.AgentCard, error)
+
+// SetAgentCardProvider sets a hook that is consulted on every agent card request
+func (s *A2AServerImpl) SetAgentCardProvider(provider AgentCardProvider) {
+	s.cardMu.Lock()
+	defer s.cardMu.Unlock()
+	s.agentCardProvider = provider
+}
+
+// UpdateAgentCard changes the public agent card while the server is running. The update
+// function receives a copy of the card, which replaces the advertised card once it returns,
+// so requests in flight keep seeing a consistent card.
+func (s *A2AServerImpl) UpdateAgentCard(update func(card *types.AgentCard)) error {
+	s.cardMu.Lock()
+	defer s.cardMu.Unlock()
+
+	if s.customAgentCard == nil {
+		return fmt.Errorf("agent card must be configured before it can be updated")
+	}
+
+	agentCard := cloneAgentCard(*s.customAgentCard)
+	update(&agentCard)
+	if s.extendedAgentCard != nil {
+		agentCard.SupportsExtendedAgentCard = new(true)
+	}
+	s.customAgentCard = &agentCard
+	s.trackStateTransitions(agentCard)
+	return nil
+}
+
+// RegisterSkill adds a skill to the public agent card and, when one is set, the extended
+// card. A skill with the same ID is replaced.
+func (s *A2AServerImpl) RegisterSkill(skill types.AgentSkill) error {
+	if skill.ID == "" {
+		return fmt.Errorf("skill id is required")
+	}
+	if s.GetAgentCard() == nil {
+		return fmt.Errorf("agent card must be configured before skills can be registered")
+	}
+
+	s.updateAgentCards(func(card *types.AgentCard) bool {
+		index := slices.IndexFunc(card.Skills, func(existing types.AgentSkill) bool { return existing.ID == skill.ID })
+		if index >= 0 {
+			card.Skills[index] = skill
+		} else {
+			card.Skills = append(card.Skills, skill)
+		}
+		return true
+	})
+
+	s.logger.Info("registered agent skill", zap.String("skill_id", skill.ID))
+	return nil
+}
+
+// UnregisterSkill removes a skill from the public agent card and, when one is set, the
+// extended card
+func (s *A2AServerImpl) UnregisterSkill(id string) error {
+	found := s.updateAgentCards(func(card *types.AgentCard) bool {
+		length := len(card.Skills)
+		card.Skills = slices.DeleteFunc(card.Skills, func(skill types.AgentSkill) bool { return skill.ID == id })
+		return len(card.Skills) != length
+	})
+	if !found {
+		return fmt.Errorf("skill '%s' not found", id)
+	}
+
+	s.logger.Info("unregistered agent skill", zap.String("skill_id", id))
+	return nil
+}
+
+// updateAgentCards applies an update to copies of the public and extended cards and
+// reports whether it changed either of them
+func (s *A2AServerImpl) updateAgentCards(update func(card *types.AgentCard) bool) bool {
+	s.cardMu.Lock()
+	defer s.cardMu.Unlock()
+
+	changed := false
+	for _, target := range []**types.AgentCard{&s.customAgentCard, &s.extendedAgentCard} {
+		if *target == nil {
+			continue
+		}
+		agentCard := cloneAgentCard(**target)
+		if update(&agentCard) {
+			*target = &agentCard
+			changed = true
+		}
+	}
+	return changed
+}
+
+// setDisabledSkills hides the skills with the IDs from the served agent cards
+func (s *A2AServerImpl) setDisabledSkills(ids []string) {
+	s.cardMu.Lock()
+	defer s.cardMu.Unlock()
+	s.disabledSkills = slices.Clone(ids)
+}
+
+// resolveAgentCard returns the card to serve for a request, consulting the agent card
+// provider and leaving out disabled skills
+func (s *A2AServerImpl) resolveAgentCard(ctx context.Context, agentCard *types.AgentCard) (*types.AgentCard, error) {
+	s.cardMu.RLock()
+	provider := s.agentCardProvider
+	disabled := s.disabledSkills
+	s.cardMu.RUnlock()
+
+	if agentCard == nil || (provider == nil && len(disabled) == 0) {
+		return agentCard, nil
+	}
+
+	resolved := cloneAgentCard(*agentCard)
+	if provider != nil {
+		var err error
+		if resolved, err = provider(ctx, resolved); err != nil {
+			return nil, err
+		}
+	}
+	if len(disabled) > 0 {
+		resolved.Skills = slices.DeleteFunc(slices.Clone(resolved.Skills), func(skill types.AgentSkill) bool {
+			return slices.Contains(disabled, skill.ID)
+		})
+	}
+	return &resolved, nil
+}
+
+// handleGetAuthenticatedExtendedCard processes agent/getAuthenticatedExtendedCard requests
+// with the card resolved for the caller
+func (s *A2AServerImpl) handleGetAuthenticatedExtendedCard(c *gin.Context, req types.JSONRPCRequest) {
+	agentCard, err := s.resolveAgentCard(c, s.authenticatedAgentCard())
+	if err != nil {
+		requestLogger(c.Request.Context(), s.logger).Error("agent card provider failed", zap.Error(err))
+		s.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to resolve agent card")
+		return
+	}
+	s.protocolHandler.HandleGetAuthenticatedExtendedCard(c, req, agentCard)
+}
+
+// cloneAgentCard copies an agent card, including the skill and extension lists that
+// updates commonly change in place
+func cloneAgentCard(card types.AgentCard) types.AgentCard {
+	card.Skills = slices.Clone(card.Skills)
+	card.Capabilities.Extensions = slices.Clone(card.Capabilities.Extensions)
+	return card
+}
+
+// agentCardETag returns a strong ETag for an encoded agent card
+func agentCardETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header matches the ETag
+func etagMatches(ifNoneMatch, etag string) bool {
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestEtagMatches(t *testing.T) {
+	assert.True(t, etagMatches(`"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`"old", W/"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`*`, `"abc"`))
+	assert.False(t, etagMatches(`"old"`, `"abc"`))
+	assert.False(t, etagMatches(``, `"abc"`))
+}
+
+func TestA2AServer_AgentCardETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "agent", Description: "first"})
+	router := s.setupRouter(cfg)
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/.well-known/agent-card.json", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := get("")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Contains(t, first.Body.String(), `"name":"agent"`)
+
+	notModified := get(etag)
+	assert.Equal(t, http.StatusNotModified, notModified.Code)
+	assert.Empty(t, notModified.Body.String())
+
+	assert.Equal(t, http.StatusOK, get(`"stale"`).Code)
+
+	s.SetAgentCard(types.AgentCard{Name: "agent", Description: "second"})
+	changed := get(etag)
+	assert.Equal(t, http.StatusOK, changed.Code)
+	assert.NotEqual(t, etag, changed.Header().Get("ETag"))
+}
+
+func TestA2AServer_ExtendedAgentCard(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{
+		Name:   "agent",
+		Skills: []types.AgentSkill{{ID: "public", Name: "Public"}},
+	})
+	router := s.setupRouter(cfg)
+
+	getCard := func() types.AgentCard {
+		body := strings.NewReader(`{"jsonrpc":"2.0","id":"1","method":"agent/getAuthenticatedExtendedCard"}`)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", body))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Result types.AgentCard `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Result
+	}
+
+	assert.Len(t, getCard().Skills, 1, "the public card is served when there is no extended card")
+	assert.Nil(t, s.customAgentCard.SupportsExtendedAgentCard)
+
+	s.SetExtendedAgentCard(types.AgentCard{
+		Name: "agent",
+		Skills: []types.AgentSkill{
+			{ID: "public", Name: "Public"},
+			{ID: "admin", Name: "Admin"},
+		},
+	})
+
+	extended := getCard()
+	require.Len(t, extended.Skills, 2)
+	assert.Equal(t, "admin", extended.Skills[1].ID)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/.well-known/agent-card.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var public types.AgentCard
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &public))
+	assert.Len(t, public.Skills, 1)
+	require.NotNil(t, public.SupportsExtendedAgentCard)
+	assert.True(t, *public.SupportsExtendedAgentCard)
+}
+
+func TestA2AServer_RuntimeAgentCardUpdates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	router := s.setupRouter(cfg)
+
+	require.Error(t, s.RegisterSkill(types.AgentSkill{ID: "search"}))
+	require.Error(t, s.UpdateAgentCard(func(card *types.AgentCard) {}))
+
+	s.SetAgentCard(types.AgentCard{Name: "agent", Skills: []types.AgentSkill{{ID: "chat", Name: "Chat"}}})
+	s.SetExtendedAgentCard(types.AgentCard{Name: "agent"})
+
+	getCard := func(ifNoneMatch string) (*httptest.ResponseRecorder, types.AgentCard) {
+		req := httptest.NewRequest(http.MethodGet, "/.well-known/agent-card.json", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var card types.AgentCard
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &card))
+		}
+		return w, card
+	}
+
+	w, card := getCard("")
+	require.Len(t, card.Skills, 1)
+	etag := w.Header().Get("ETag")
+
+	before := s.GetAgentCard()
+	require.NoError(t, s.RegisterSkill(types.AgentSkill{ID: "search", Name: "Search"}))
+	require.NoError(t, s.RegisterSkill(types.AgentSkill{ID: "search", Name: "Web search"}))
+	assert.Len(t, before.Skills, 1, "cards handed out earlier are not changed")
+	assert.Len(t, s.authenticatedAgentCard().Skills, 1, "skills are registered on the extended card too")
+
+	w, card = getCard(etag)
+	require.Equal(t, http.StatusOK, w.Code, "the ETag changes with the card")
+	require.Len(t, card.Skills, 2)
+	assert.Equal(t, "Web search", card.Skills[1].Name)
+
+	require.NoError(t, s.UpdateAgentCard(func(card *types.AgentCard) { card.Description = "updated" }))
+	assert.Equal(t, "updated", s.GetAgentCard().Description)
+	assert.True(t, *s.GetAgentCard().SupportsExtendedAgentCard)
+
+	require.NoError(t, s.UnregisterSkill("search"))
+	assert.Error(t, s.UnregisterSkill("search"))
+	assert.Empty(t, s.authenticatedAgentCard().Skills)
+
+	s.SetAgentCardProvider(func(ctx context.Context, card types.AgentCard) (types.AgentCard, error) {
+		card.Skills = append(card.Skills, types.AgentSkill{ID: "mcp", Name: "MCP tool"})
+		return card, nil
+	})
+	_, card = getCard("")
+	require.Len(t, card.Skills, 2)
+	assert.Equal(t, "mcp", card.Skills[1].ID)
+	assert.Len(t, s.GetAgentCard().Skills, 1, "the provider does not change the stored card")
+
+	s.SetAgentCardProvider(func(ctx context.Context, card types.AgentCard) (types.AgentCard, error) {
+		return card, errors.New("registry unavailable")
+	})
+	w, _ = getCard("")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
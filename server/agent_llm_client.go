@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	config "github.com/inference-gateway/adk/server/config"
@@ -25,6 +26,7 @@ var _ LLMClient = (*OpenAICompatibleLLMClient)(nil)
 
 // OpenAICompatibleLLMClient implements LLMClient using an OpenAI-compatible API via the Inference Gateway SDK
 type OpenAICompatibleLLMClient struct {
+	mu       sync.RWMutex
 	client   sdk.Client
 	config   *config.AgentConfig
 	logger   *zap.Logger
@@ -46,14 +48,34 @@ func NewOpenAICompatibleLLMClient(cfg *config.AgentConfig, logger *zap.Logger) (
 		return nil, fmt.Errorf("model is required")
 	}
 
+	client := newSDKClient(cfg, cfg.APIKey)
+
+	provider, err := parseProvider(cfg.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("invalid provider %s: %w", cfg.Provider, err)
+	}
+
+	model := parseModelName(cfg.Model, cfg.Provider)
+
+	return &OpenAICompatibleLLMClient{
+		client:   client,
+		config:   cfg,
+		logger:   logger,
+		provider: provider,
+		model:    model,
+	}, nil
+}
+
+// newSDKClient creates the SDK client for the configured provider endpoint with the API key
+func newSDKClient(cfg *config.AgentConfig, apiKey string) sdk.Client {
 	clientOptions := &sdk.ClientOptions{}
 
 	if cfg.BaseURL != "" {
 		clientOptions.BaseURL = cfg.BaseURL
 	}
 
-	if cfg.APIKey != "" {
-		clientOptions.APIKey = cfg.APIKey
+	if apiKey != "" {
+		clientOptions.APIKey = apiKey
 	}
 
 	if cfg.Timeout > 0 {
@@ -64,22 +86,37 @@ func NewOpenAICompatibleLLMClient(cfg *config.AgentConfig, logger *zap.Logger) (
 		clientOptions.Headers = cfg.CustomHeaders
 	}
 
-	client := sdk.NewClient(clientOptions)
+	return sdk.NewClient(clientOptions)
+}
 
-	provider, err := parseProvider(cfg.Provider)
-	if err != nil {
-		return nil, fmt.Errorf("invalid provider %s: %w", cfg.Provider, err)
-	}
+// SetAPIKey replaces the API key sent to the provider, for the requests made from now on, so
+// a rotated key applies without a restart
+func (c *OpenAICompatibleLLMClient) SetAPIKey(apiKey string) {
+	client := newSDKClient(c.config, apiKey)
 
-	model := parseModelName(cfg.Model, cfg.Provider)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.client = client
+}
 
-	return &OpenAICompatibleLLMClient{
-		client:   client,
-		config:   cfg,
-		logger:   logger,
-		provider: provider,
-		model:    model,
-	}, nil
+// sdkClient returns the SDK client for the current API key
+func (c *OpenAICompatibleLLMClient) sdkClient() sdk.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.client
+}
+
+// target returns the provider and the model of a request: the model routed for the agent run,
+// when the context carries one, or the configured model
+func (c *OpenAICompatibleLLMClient) target(ctx context.Context) (sdk.Provider, string) {
+	routed := routedModelFromContext(ctx)
+	if routed == "" {
+		return c.provider, c.model
+	}
+	if provider, model, found := strings.Cut(routed, "/"); found && provider != "" && model != "" {
+		return sdk.Provider(strings.ToLower(provider)), model
+	}
+	return c.provider, routed
 }
 
 // CreateChatCompletion implements LLMClient.CreateChatCompletion using SDK messages
@@ -90,6 +127,8 @@ func (c *OpenAICompatibleLLMClient) CreateChatCompletion(ctx context.Context, me
 		options.MaxTokens = &c.config.MaxTokens
 	}
 
+	provider, model := c.target(ctx)
+
 	var response *sdk.CreateChatCompletionResponse
 	var lastErr error
 
@@ -109,23 +148,23 @@ func (c *OpenAICompatibleLLMClient) CreateChatCompletion(ctx context.Context, me
 		}
 
 		if len(tools) > 0 {
-			response, lastErr = c.client.WithMiddlewareOptions(&sdk.MiddlewareOptions{
+			response, lastErr = c.sdkClient().WithMiddlewareOptions(&sdk.MiddlewareOptions{
 				DirectProvider: true,
 				SkipMCP:        true,
 			}).WithOptions(options).WithTools(&tools).GenerateContent(
 				ctx,
-				c.provider,
-				c.model,
+				provider,
+				model,
 				messages,
 			)
 		} else {
-			response, lastErr = c.client.WithMiddlewareOptions(&sdk.MiddlewareOptions{
+			response, lastErr = c.sdkClient().WithMiddlewareOptions(&sdk.MiddlewareOptions{
 				DirectProvider: true,
 				SkipMCP:        true,
 			}).WithOptions(options).GenerateContent(
 				ctx,
-				c.provider,
-				c.model,
+				provider,
+				model,
 				messages,
 			)
 		}
@@ -169,27 +208,29 @@ func (c *OpenAICompatibleLLMClient) CreateStreamingChatCompletion(ctx context.Co
 			options.MaxTokens = &c.config.MaxTokens
 		}
 
+		provider, model := c.target(ctx)
+
 		var events <-chan sdk.SSEvent
 		var err error
 
 		if len(tools) > 0 {
-			events, err = c.client.WithMiddlewareOptions(&sdk.MiddlewareOptions{
+			events, err = c.sdkClient().WithMiddlewareOptions(&sdk.MiddlewareOptions{
 				DirectProvider: true,
 				SkipMCP:        true,
 			}).WithOptions(options).WithTools(&tools).GenerateContentStream(
 				ctx,
-				c.provider,
-				c.model,
+				provider,
+				model,
 				messages,
 			)
 		} else {
-			events, err = c.client.WithMiddlewareOptions(&sdk.MiddlewareOptions{
+			events, err = c.sdkClient().WithMiddlewareOptions(&sdk.MiddlewareOptions{
 				DirectProvider: true,
 				SkipMCP:        true,
 			}).WithOptions(options).GenerateContentStream(
 				ctx,
-				c.provider,
-				c.model,
+				provider,
+				model,
 				messages,
 			)
 		}
@@ -0,0 +1,247 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	zap "go.uber.org/zap"
+	yaml "gopkg.in/yaml.v3"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// agentDefinitionProtocolVersion is the protocol version of agent cards generated from definitions
+const agentDefinitionProtocolVersion = "0.3.0"
+
+// AgentDefinition declares an agent in a YAML or JSON file, so agents can be added without
+// writing Go code. Fields left empty fall back to the agent configuration the definitions
+// are loaded with.
+type AgentDefinition struct {
+	// Name identifies the agent and is the path segment it is mounted under; defaults to the file name
+	Name string `json:"name"`
+	// Description of the agent for its card
+	Description string `json:"description"`
+	// Version of the agent for its card (default 1.0.0)
+	Version string `json:"version"`
+	// Provider is the LLM provider
+	Provider string `json:"provider"`
+	// Model is the LLM model
+	Model string `json:"model"`
+	// SystemPrompt is the system prompt of the agent
+	SystemPrompt string `json:"systemPrompt"`
+	// Tools lists the built-in tools of the agent: input_required, create_artifact, http_request
+	// and, when they are enabled in the configuration, execute_code, run_command and browse_web
+	Tools []string `json:"tools"`
+	// Skills advertised on the agent card
+	Skills []types.AgentSkill `json:"skills"`
+	// Card holds agent card fields that override the generated card
+	Card map[string]any `json:"card"`
+}
+
+// LoadedAgent is an agent built from an agent definition
+type LoadedAgent struct {
+	Name      string
+	Agent     *OpenAICompatibleAgentImpl
+	AgentCard types.AgentCard
+}
+
+// LoadAgentsFromDir builds an agent from every .yaml, .yml and .json file in a directory,
+// in file name order. The agent configuration supplies the LLM connection settings and
+// the defaults for fields a definition leaves empty. The loaded agents can be hosted with
+// WithNamedAgent, or all at once with WithAgentsFromDir.
+func LoadAgentsFromDir(path string, cfg config.AgentConfig, logger *zap.Logger) ([]LoadedAgent, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agent directory: %w", err)
+	}
+
+	var agents []LoadedAgent
+	seen := make(map[string]string)
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+
+		file := filepath.Join(path, entry.Name())
+		definition, err := readAgentDefinition(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load agent from %s: %w", file, err)
+		}
+		if definition.Name == "" {
+			definition.Name = strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		}
+		if other, exists := seen[definition.Name]; exists {
+			return nil, fmt.Errorf("agent '%s' is defined in both %s and %s", definition.Name, other, file)
+		}
+		seen[definition.Name] = file
+
+		loaded, err := definition.build(cfg, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load agent from %s: %w", file, err)
+		}
+		logger.Info("loaded agent definition",
+			zap.String("agent", loaded.Name),
+			zap.String("file", file),
+			zap.Int("skills", len(loaded.AgentCard.Skills)))
+		agents = append(agents, *loaded)
+	}
+	return agents, nil
+}
+
+// readAgentDefinition decodes an agent definition file. YAML is converted to JSON first,
+// so both formats share the field names, and unknown fields are rejected to catch typos.
+func readAgentDefinition(file string) (*AgentDefinition, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if ext := strings.ToLower(filepath.Ext(file)); ext == ".yaml" || ext == ".yml" {
+		var document any
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("invalid yaml: %w", err)
+		}
+		if data, err = json.Marshal(document); err != nil {
+			return nil, fmt.Errorf("invalid yaml: %w", err)
+		}
+	}
+
+	var definition AgentDefinition
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&definition); err != nil {
+		return nil, fmt.Errorf("invalid agent definition: %w", err)
+	}
+	return &definition, nil
+}
+
+// build creates the agent and agent card of a definition
+func (d *AgentDefinition) build(cfg config.AgentConfig, logger *zap.Logger) (*LoadedAgent, error) {
+	if !namedAgentNamePattern.MatchString(d.Name) {
+		return nil, fmt.Errorf("invalid agent name '%s': use lowercase letters, digits, '-' and '_'", d.Name)
+	}
+
+	cfg.AgentName = d.Name
+	if d.Provider != "" {
+		cfg.Provider = d.Provider
+	}
+	if d.Model != "" {
+		cfg.Model = d.Model
+	}
+	if d.SystemPrompt != "" {
+		cfg.SystemPrompt = d.SystemPrompt
+	}
+	if d.Tools != nil {
+		codeExecution := cfg.ToolBoxConfig.SkillsConfig.CodeExecution.Enable
+		browser := cfg.ToolBoxConfig.SkillsConfig.Browser.Enable
+		command := cfg.ToolBoxConfig.SkillsConfig.Command.Enable
+		cfg.ToolBoxConfig.EnableCreateArtifact = false
+		cfg.ToolBoxConfig.EnableHTTPRequest = false
+		cfg.ToolBoxConfig.SkillsConfig.CodeExecution.Enable = false
+		cfg.ToolBoxConfig.SkillsConfig.Browser.Enable = false
+		cfg.ToolBoxConfig.SkillsConfig.Command.Enable = false
+		for _, tool := range d.Tools {
+			switch tool {
+			case "input_required":
+			case "create_artifact":
+				cfg.ToolBoxConfig.EnableCreateArtifact = true
+			case "http_request":
+				cfg.ToolBoxConfig.EnableHTTPRequest = true
+			case "execute_code":
+				if !codeExecution {
+					return nil, fmt.Errorf("tool 'execute_code' requires AGENT_CLIENT_TOOLS_SKILLS_CODE_EXECUTION_ENABLE=true")
+				}
+				cfg.ToolBoxConfig.SkillsConfig.CodeExecution.Enable = true
+			case "browse_web":
+				if !browser {
+					return nil, fmt.Errorf("tool 'browse_web' requires AGENT_CLIENT_TOOLS_SKILLS_BROWSER_ENABLE=true")
+				}
+				cfg.ToolBoxConfig.SkillsConfig.Browser.Enable = true
+			case "run_command":
+				if !command {
+					return nil, fmt.Errorf("tool 'run_command' requires AGENT_CLIENT_TOOLS_SKILLS_COMMAND_ENABLE=true")
+				}
+				cfg.ToolBoxConfig.SkillsConfig.Command.Enable = true
+			default:
+				return nil, fmt.Errorf("unknown tool '%s', the available tools are input_required, create_artifact, http_request, execute_code, run_command and browse_web", tool)
+			}
+		}
+	}
+
+	logger = logger.With(zap.String("agent", d.Name))
+	llmClient, err := NewOpenAICompatibleLLMClient(&cfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create llm client: %w", err)
+	}
+	agent, err := NewAgentBuilder(logger).
+		WithConfig(&cfg).
+		WithLLMClient(llmClient).
+		WithDefaultToolBox().
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build agent: %w", err)
+	}
+
+	agentCard, err := d.agentCard()
+	if err != nil {
+		return nil, err
+	}
+	return &LoadedAgent{Name: d.Name, Agent: agent, AgentCard: agentCard}, nil
+}
+
+// agentCard generates the agent card of a definition and applies its card overrides
+func (d *AgentDefinition) agentCard() (types.AgentCard, error) {
+	version := d.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+	skills := d.Skills
+	if skills == nil {
+		skills = []types.AgentSkill{}
+	}
+
+	agentCard := types.AgentCard{
+		Name:            d.Name,
+		Description:     d.Description,
+		Version:         version,
+		ProtocolVersion: agentDefinitionProtocolVersion,
+		Capabilities: types.AgentCapabilities{
+			Streaming:              new(true),
+			PushNotifications:      new(false),
+			StateTransitionHistory: new(false),
+		},
+		DefaultInputModes:  []string{"text/plain"},
+		DefaultOutputModes: []string{"text/plain"},
+		Skills:             skills,
+	}
+	if len(d.Card) == 0 {
+		return agentCard, nil
+	}
+
+	generated, err := json.Marshal(agentCard)
+	if err != nil {
+		return types.AgentCard{}, fmt.Errorf("failed to encode agent card: %w", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(generated, &fields); err != nil {
+		return types.AgentCard{}, fmt.Errorf("failed to encode agent card: %w", err)
+	}
+	for key, value := range d.Card {
+		fields[key] = value
+	}
+	merged, err := json.Marshal(fields)
+	if err != nil {
+		return types.AgentCard{}, fmt.Errorf("invalid agent card fields: %w", err)
+	}
+	var overridden types.AgentCard
+	if err := json.Unmarshal(merged, &overridden); err != nil {
+		return types.AgentCard{}, fmt.Errorf("invalid agent card fields: %w", err)
+	}
+	return overridden, nil
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func writeAgentDefinitions(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return dir
+}
+
+func TestLoadAgentsFromDir(t *testing.T) {
+	dir := writeAgentDefinitions(t, map[string]string{
+		"travel.yaml": `
+description: Plans trips
+model: gpt-4o
+systemPrompt: You are a travel agent.
+tools: [http_request]
+skills:
+  - id: book-flight
+    name: Book flight
+    description: Books flights
+    tags: [travel]
+card:
+  version: 2.0.0
+  documentationUrl: https://example.com/travel
+`,
+		"weather.json": `{"name": "forecast", "description": "Forecasts the weather"}`,
+		"README.md":    "not an agent",
+	})
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "drafts.yaml"), 0o700))
+
+	cfg := config.AgentConfig{Provider: "openai", Model: "gpt-4o-mini", SystemPrompt: "default"}
+	cfg.ToolBoxConfig.EnableCreateArtifact = true
+	agents, err := LoadAgentsFromDir(dir, cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.Len(t, agents, 2)
+
+	travel := agents[0]
+	assert.Equal(t, "travel", travel.Name)
+	assert.Equal(t, "gpt-4o", travel.Agent.config.Model)
+	assert.Equal(t, "You are a travel agent.", travel.Agent.config.SystemPrompt)
+	assert.True(t, travel.Agent.toolBox.HasTool("http_request"))
+	assert.False(t, travel.Agent.toolBox.HasTool("create_artifact"))
+	assert.Equal(t, "Plans trips", travel.AgentCard.Description)
+	assert.Equal(t, "2.0.0", travel.AgentCard.Version)
+	require.NotNil(t, travel.AgentCard.DocumentationURL)
+	assert.Equal(t, "https://example.com/travel", *travel.AgentCard.DocumentationURL)
+	require.Len(t, travel.AgentCard.Skills, 1)
+	assert.Equal(t, []string{"travel"}, travel.AgentCard.Skills[0].Tags)
+
+	forecast := agents[1]
+	assert.Equal(t, "forecast", forecast.Name)
+	assert.Equal(t, "gpt-4o-mini", forecast.Agent.config.Model)
+	assert.Equal(t, "default", forecast.Agent.config.SystemPrompt)
+	assert.True(t, forecast.Agent.toolBox.HasTool("create_artifact"))
+	assert.Equal(t, "1.0.0", forecast.AgentCard.Version)
+	assert.Equal(t, []types.AgentSkill{}, forecast.AgentCard.Skills)
+}
+
+func TestLoadAgentsFromDir_Errors(t *testing.T) {
+	cfg := config.AgentConfig{Provider: "openai", Model: "gpt-4o-mini"}
+	tests := []struct {
+		name  string
+		files map[string]string
+		err   string
+	}{
+		{name: "unknown field", files: map[string]string{"a.yaml": "sytemPrompt: typo"}, err: "unknown field"},
+		{name: "unknown tool", files: map[string]string{"a.yaml": "tools: [shell]"}, err: "unknown tool 'shell'"},
+		{name: "code execution disabled", files: map[string]string{"a.yaml": "tools: [execute_code]"}, err: "requires AGENT_CLIENT_TOOLS_SKILLS_CODE_EXECUTION_ENABLE"},
+		{name: "browser disabled", files: map[string]string{"a.yaml": "tools: [browse_web]"}, err: "requires AGENT_CLIENT_TOOLS_SKILLS_BROWSER_ENABLE"},
+		{name: "invalid name", files: map[string]string{"Travel Agent.yaml": "description: x"}, err: "invalid agent name"},
+		{name: "duplicate name", files: map[string]string{"a.yaml": "name: b", "b.json": "{}"}, err: "defined in both"},
+		{name: "invalid yaml", files: map[string]string{"a.yml": "tools: ["}, err: "invalid yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadAgentsFromDir(writeAgentDefinitions(t, tt.files), cfg, zap.NewNop())
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	_, err := LoadAgentsFromDir(filepath.Join(t.TempDir(), "missing"), cfg, zap.NewNop())
+	assert.ErrorContains(t, err, "failed to read agent directory")
+}
+
+func TestA2AServerBuilder_WithAgentsFromDir(t *testing.T) {
+	logger := zap.NewNop()
+	dir := writeAgentDefinitions(t, map[string]string{"travel.yaml": "description: Plans trips"})
+
+	cfg := config.Config{AgentConfig: config.AgentConfig{Provider: "openai", Model: "gpt-4o-mini"}}
+	built, err := NewA2AServerBuilder(cfg, logger).
+		WithAgent(NewOpenAICompatibleAgent(logger)).
+		WithDefaultTaskHandlers().
+		WithAgentCard(types.AgentCard{Name: "platform"}).
+		WithAgentsFromDir(dir).
+		Build()
+	require.NoError(t, err)
+
+	travel, exists := built.GetNamedAgent("travel")
+	require.True(t, exists)
+	assert.Equal(t, "Plans trips", travel.GetAgentCard().Description)
+}
@@ -3,7 +3,9 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -24,6 +26,17 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 		tools = a.toolBox.GetTools()
 	}
 
+	skill := a.runSkill(ctx, messages)
+	if skill != nil {
+		if skill.binding.Agent != nil {
+			requestLogger(ctx, a.logger).Debug("handing agent run to the agent of the skill", zap.String("skill", skill.id))
+			return skill.binding.Agent.RunWithStream(ctx, messages)
+		}
+		requestLogger(ctx, a.logger).Debug("restricting agent run to skill", zap.String("skill", skill.id))
+		tools = skill.tools(tools)
+		ctx = contextWithSkill(ctx, skill)
+	}
+
 	var taskID *string
 	var contextID *string
 	if task, ok := ctx.Value(TaskContextKey).(*types.Task); ok && task != nil {
@@ -31,6 +44,13 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 		contextID = &task.ContextID
 	}
 
+	if a.modelRouter != nil {
+		if model := a.modelRouter.RouteModel(ctx, newModelRouteRequest(messages, tools)); model != "" {
+			requestLogger(ctx, a.logger).Debug("routed agent run to model", zap.String("model", model))
+			ctx = contextWithRoutedModel(ctx, model)
+		}
+	}
+
 	var usageTracker *UsageTracker
 	if tracker, ok := ctx.Value(UsageTrackerContextKey).(*UsageTracker); ok && tracker != nil {
 		usageTracker = tracker
@@ -43,6 +63,10 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 	go func() {
 		defer close(outputChan)
 
+		// the final events of a run are delivered after its context ends as well, while the
+		// consumer keeps receiving, so a cancelled run still reports how it ended
+		deliveryCtx := context.WithoutCancel(ctx)
+
 		callbackCtx := a.createCallbackContext(taskID, contextID)
 		executor := a.GetCallbackExecutor()
 		if override := executor.ExecuteBeforeAgent(ctx, callbackCtx); override != nil {
@@ -73,9 +97,39 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 		currentMessages := make([]types.Message, len(messages))
 		copy(currentMessages, messages)
 
+		// a checkpointed run saves its state after every model call and tool execution, and
+		// the run of a task that was interrupted continues from its last checkpoint
+		checkpointer := checkpointerFromContext(ctx)
+		firstIteration := 1
+		if checkpoint := checkpointer.resume(messages); checkpoint != nil {
+			a.logger.Info("resuming agent run from checkpoint",
+				zap.Int("iteration", checkpoint.Iteration),
+				zap.Int("message_count", len(checkpoint.Messages)),
+				zap.Int("pending_tool_calls", len(checkpoint.PendingToolCalls)))
+			currentMessages = slices.Clone(checkpoint.Messages)
+			firstIteration = checkpoint.Iteration + 1
+			if len(checkpoint.PendingToolCalls) > 0 {
+				toolResultMessages := a.executeToolCallsWithEvents(ctx, checkpoint.PendingToolCalls, outputChan, usageTracker)
+				currentMessages = append(currentMessages, toolResultMessages...)
+				usageTracker.AddMessages(len(toolResultMessages))
+				if awaitsInput(toolResultMessages) {
+					return
+				}
+			}
+		} else {
+			currentMessages = a.resumeToolApproval(ctx, currentMessages, outputChan, usageTracker)
+			currentMessages = a.resumeForm(ctx, currentMessages, outputChan)
+			currentMessages = a.resumeAuth(ctx, currentMessages, outputChan, usageTracker)
+			if awaitsInput(currentMessages) {
+				return
+			}
+		}
+		checkpointer.save(ctx, firstIteration-1, currentMessages, nil)
+
 		var finalAssistantMessage *types.Message
+		systemPrompt := memorySystemPrompt(ctx, skill.systemPrompt(a.currentSystemPrompt()))
 
-		for iteration := 1; iteration <= a.config.MaxChatCompletionIterations; iteration++ {
+		for iteration := firstIteration; iteration <= a.config.MaxChatCompletionIterations; iteration++ {
 			usageTracker.IncrementIteration()
 
 			a.logger.Debug("starting streaming iteration",
@@ -88,8 +142,8 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 				return
 			}
 
-			if a.config != nil && a.config.SystemPrompt != "" {
-				systemMessage, err := sdk.NewTextMessage(sdk.System, a.config.SystemPrompt)
+			if systemPrompt != "" {
+				systemMessage, err := sdk.NewTextMessage(sdk.System, systemPrompt)
 				if err != nil {
 					a.logger.Error("failed to create system message", zap.Error(err))
 					return
@@ -103,11 +157,11 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 					SystemInstruction: nil,
 				},
 			}
-			if a.config != nil && a.config.SystemPrompt != "" {
+			if systemPrompt != "" {
 				sysMsg := &types.Message{
 					Role: "system",
 					Parts: []types.Part{
-						types.CreateTextPart(a.config.SystemPrompt),
+						types.CreateTextPart(systemPrompt),
 					},
 				}
 				llmRequest.Config.SystemInstruction = sysMsg
@@ -119,12 +173,26 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 				beforeModelOverride = override
 			}
 
+			if beforeModelOverride != nil && beforeModelOverride.State != "" {
+				a.endRun(ctx, outputChan, beforeModelOverride, iteration, taskID, contextID)
+				return
+			}
+
 			var streamResponseChan <-chan *sdk.CreateChatCompletionStreamResponse
 			var streamErrorChan <-chan error
 
+			// llmStarted is reset once the time of the model call is tracked
+			var llmStarted time.Time
 			if beforeModelOverride == nil {
+				llmStarted = time.Now()
 				streamResponseChan, streamErrorChan = a.llmClient.CreateStreamingChatCompletion(ctx, sdkMessages, tools...)
 			}
+			trackLLMTime := func() {
+				if !llmStarted.IsZero() {
+					usageTracker.AddLLMTime(time.Since(llmStarted))
+					llmStarted = time.Time{}
+				}
+			}
 
 			var fullContent string
 			var fullReasoningContent string
@@ -177,10 +245,7 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 
 					if assistantMessage != nil {
 						iterationEvent := types.NewIterationCompletedEvent(iteration, "streaming-task", assistantMessage)
-						select {
-						case outputChan <- iterationEvent:
-						case <-time.After(100 * time.Millisecond):
-						}
+						sendEvent(deliveryCtx, outputChan, iterationEvent, eventDeliveryTimeout, dropChannelAgentStream, a.logger)
 					}
 
 					cancelledStatusEvent := cloudevents.NewEvent()
@@ -191,10 +256,7 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 						a.logger.Error("failed to set cancelled status event data", zap.Error(err))
 						return
 					}
-					select {
-					case outputChan <- cancelledStatusEvent:
-					case <-time.After(100 * time.Millisecond):
-					}
+					sendEvent(deliveryCtx, outputChan, cancelledStatusEvent, eventDeliveryTimeout, dropChannelAgentStream, a.logger)
 
 					interruptMessage := types.NewStreamingStatusMessage(
 						fmt.Sprintf("task-interrupted-%d", iteration),
@@ -203,13 +265,11 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 					)
 					interruptMessage.TaskID = taskID
 					interruptMessage.ContextID = contextID
-					select {
-					case outputChan <- types.NewMessageEvent(types.EventTaskInterrupted, interruptMessage.MessageID, interruptMessage):
-					default:
-					}
+					sendEvent(deliveryCtx, outputChan, types.NewMessageEvent(types.EventTaskInterrupted, interruptMessage.MessageID, interruptMessage), eventDeliveryTimeout, dropChannelAgentStream, a.logger)
 					return
 
 				case streamErr := <-streamErrorChan:
+					trackLLMTime()
 					if streamErr != nil {
 						a.logger.Error("streaming failed", zap.Error(streamErr))
 
@@ -233,21 +293,16 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 							a.logger.Error("failed to set failed status event data", zap.Error(err))
 							return
 						}
-						select {
-						case outputChan <- failedStatusEvent:
-						default:
-						}
+						sendEvent(deliveryCtx, outputChan, failedStatusEvent, eventDeliveryTimeout, dropChannelAgentStream, a.logger)
 
-						select {
-						case outputChan <- types.NewMessageEvent(types.EventStreamFailed, errorMessage.MessageID, errorMessage):
-						default:
-						}
+						sendEvent(deliveryCtx, outputChan, types.NewMessageEvent(types.EventStreamFailed, errorMessage.MessageID, errorMessage), eventDeliveryTimeout, dropChannelAgentStream, a.logger)
 						return
 					}
 					streaming = false
 
 				case streamResp, ok := <-streamResponseChan:
 					if !ok {
+						trackLLMTime()
 						streaming = false
 						break
 					}
@@ -314,6 +369,7 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 					}
 
 					if choice.FinishReason != "" {
+						trackLLMTime()
 						assistantMessage = types.NewAssistantMessage(
 							fmt.Sprintf("assistant-stream-%d", iteration),
 							make([]types.Part, 0),
@@ -372,6 +428,7 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 								return
 							}
 
+							checkpointer.save(ctx, iteration, currentMessages, toolCalls)
 							toolResultMessages = a.executeToolCallsWithEvents(ctx, toolCalls, outputChan, usageTracker)
 
 							for _, toolResult := range toolResultMessages {
@@ -406,14 +463,11 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 					zap.Int("tool_result_count", len(toolResultMessages)))
 			}
 
-			if len(toolResultMessages) > 0 {
-				lastToolMessage := toolResultMessages[len(toolResultMessages)-1]
-				if strings.HasPrefix(lastToolMessage.MessageID, "input-required") {
-					a.logger.Debug("streaming completed - input required from user",
-						zap.Int("iteration", iteration),
-						zap.Int("final_message_count", len(currentMessages)))
-					return
-				}
+			if awaitsInput(toolResultMessages) {
+				a.logger.Debug("streaming completed - input required from user",
+					zap.Int("iteration", iteration),
+					zap.Int("final_message_count", len(currentMessages)))
+				return
 			}
 
 			if assistantMessage != nil && len(toolResultMessages) == 0 {
@@ -439,14 +493,12 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 					a.logger.Error("failed to set completed status event data", zap.Error(err))
 					return
 				}
-				select {
-				case outputChan <- completedStatusEvent:
-				case <-time.After(100 * time.Millisecond):
-				}
+				sendEvent(deliveryCtx, outputChan, completedStatusEvent, eventDeliveryTimeout, dropChannelAgentStream, a.logger)
 
 				return
 			}
 
+			checkpointer.save(ctx, iteration, currentMessages, nil)
 			a.logger.Debug("tool calls executed, continuing to next iteration",
 				zap.Int("iteration", iteration),
 				zap.Int("message_count", len(currentMessages)),
@@ -464,10 +516,7 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 			a.logger.Error("failed to set canceled status event data", zap.Error(err))
 			return
 		}
-		select {
-		case outputChan <- canceledStatusEvent:
-		case <-time.After(100 * time.Millisecond):
-		}
+		sendEvent(deliveryCtx, outputChan, canceledStatusEvent, eventDeliveryTimeout, dropChannelAgentStream, a.logger)
 
 		interruptMessage := types.NewStreamingStatusMessage(
 			"max-iterations-reached",
@@ -476,22 +525,33 @@ func (a *OpenAICompatibleAgentImpl) RunWithStream(ctx context.Context, messages
 		)
 		interruptMessage.TaskID = taskID
 		interruptMessage.ContextID = contextID
-		select {
-		case outputChan <- types.NewMessageEvent(types.EventTaskInterrupted, interruptMessage.MessageID, interruptMessage):
-		default:
-		}
+		sendEvent(deliveryCtx, outputChan, types.NewMessageEvent(types.EventTaskInterrupted, interruptMessage.MessageID, interruptMessage), eventDeliveryTimeout, dropChannelAgentStream, a.logger)
 	}()
 
 	return outputChan, nil
 }
 
+// awaitsInput reports whether the tool results end with a request for user input, a form,
+// approval or authorization, which pauses the run
+func awaitsInput(toolResultMessages []types.Message) bool {
+	if len(toolResultMessages) == 0 {
+		return false
+	}
+	lastToolMessage := toolResultMessages[len(toolResultMessages)-1]
+	return strings.HasPrefix(lastToolMessage.MessageID, "input-required") ||
+		strings.HasPrefix(lastToolMessage.MessageID, "form-required") ||
+		strings.HasPrefix(lastToolMessage.MessageID, "approval-required") ||
+		strings.HasPrefix(lastToolMessage.MessageID, "auth-required")
+}
+
 // executeToolCallsWithEvents executes tool calls and emits events, returning tool result messages
 func (a *OpenAICompatibleAgentImpl) executeToolCallsWithEvents(ctx context.Context, toolCalls []sdk.ChatCompletionMessageToolCall, outputChan chan<- cloudevents.Event, usageTracker *UsageTracker) []types.Message {
 	toolResultMessages := make([]types.Message, 0, len(toolCalls))
 
 	var taskID *string
 	var contextID *string
-	if task, ok := ctx.Value(TaskContextKey).(*types.Task); ok && task != nil {
+	task, _ := ctx.Value(TaskContextKey).(*types.Task)
+	if task != nil {
 		taskID = &task.ID
 		contextID = &task.ContextID
 	}
@@ -541,10 +601,41 @@ func (a *OpenAICompatibleAgentImpl) executeToolCallsWithEvents(ctx context.Conte
 			tool, _ = a.toolBox.GetTool(toolCall.Function.Name)
 		}
 
+		if form, ok := tool.(FormTool); ok && checkSkillTool(ctx, toolCall.Function.Name) == nil {
+			a.logger.Debug("form tool called, pausing task for the form", zap.String("tool", toolCall.Function.Name), zap.String("tool_call_id", toolCall.ID))
+			formMessage := formRequestMessage(form, toolCall, args)
+			formMessage.TaskID = taskID
+			formMessage.ContextID = contextID
+			select {
+			case outputChan <- types.NewMessageEvent(types.EventInputRequired, formMessage.MessageID, formMessage):
+			case <-ctx.Done():
+			}
+			return append(toolResultMessages, *formMessage)
+		}
+
 		switch toolCall.Function.Name {
 		case types.ToolInputRequired:
 			a.logger.Debug("input_required tool called in streaming mode", zap.String("tool_call_id", toolCall.ID), zap.String("message", toolCall.Function.Arguments))
-			inputRequiredMessage := types.NewInputRequiredMessage(toolCall.ID, args["message"].(string))
+			request, err := parseInputRequest(args)
+			if err != nil {
+				a.logger.Warn("invalid input_required tool arguments", zap.String("tool_call_id", toolCall.ID), zap.Error(err))
+				usageTracker.IncrementFailedTools()
+
+				toolFailedMessage := types.NewStreamingStatusMessage(fmt.Sprintf("tool-failed-%s", toolCall.ID), string(types.TaskStateFailed), nil)
+				toolFailedMessage.TaskID = taskID
+				toolFailedMessage.ContextID = contextID
+				select {
+				case outputChan <- types.NewMessageEvent(types.EventToolFailed, fmt.Sprintf("tool-failed-%s", toolCall.ID), toolFailedMessage):
+				case <-ctx.Done():
+				}
+
+				toolResultMsg := types.NewToolResultMessage(toolCall.ID, toolCall.Function.Name, fmt.Sprintf("Invalid input request: %s", err.Error()), true)
+				toolResultMsg.TaskID = taskID
+				toolResultMsg.ContextID = contextID
+				toolResultMessages = append(toolResultMessages, *toolResultMsg)
+				continue
+			}
+			inputRequiredMessage := types.NewInputRequestMessage(toolCall.ID, request)
 			inputRequiredMessage.TaskID = taskID
 			inputRequiredMessage.ContextID = contextID
 
@@ -568,7 +659,39 @@ func (a *OpenAICompatibleAgentImpl) executeToolCallsWithEvents(ctx context.Conte
 			var result string
 			var toolErr error
 
-			if override := executor.ExecuteBeforeTool(ctx, tool, args, toolCtx); override != nil {
+			artifactCount := 0
+			if task != nil {
+				artifactCount = len(task.Artifacts)
+			}
+
+			refusal := checkSkillTool(ctx, toolCall.Function.Name)
+			if refusal == nil {
+				refusal = checkToolPolicy(ctx, toolCall.Function.Name, args, task)
+			}
+			if refusal == nil {
+				refusal = checkToolPreconditions(ctx, tool, args, task, time.Now())
+			}
+			if refusal == nil && a.requiresApproval(ctx, toolCall) {
+				a.logger.Info("tool requires approval, pausing task",
+					zap.String("tool", toolCall.Function.Name),
+					zap.String("tool_call_id", toolCall.ID))
+				approvalMessage := types.NewToolApprovalRequestMessage(toolCall.ID, toolCall.Function.Name, args)
+				approvalMessage.TaskID = taskID
+				approvalMessage.ContextID = contextID
+				select {
+				case outputChan <- types.NewMessageEvent(types.EventInputRequired, approvalMessage.MessageID, approvalMessage):
+				case <-ctx.Done():
+				}
+
+				return append(toolResultMessages, *approvalMessage)
+			}
+
+			if refusal != nil {
+				a.logger.Info("tool precondition not met, skipping tool execution",
+					zap.String("tool", toolCall.Function.Name),
+					zap.String("code", refusal.Code))
+				result = refusal.Message
+			} else if override := executor.ExecuteBeforeTool(ctx, tool, args, toolCtx); override != nil {
 				a.logger.Debug("BeforeTool callback returned override, skipping tool execution",
 					zap.String("tool", toolCall.Function.Name))
 				if resultStr, ok := override["result"].(string); ok {
@@ -579,7 +702,9 @@ func (a *OpenAICompatibleAgentImpl) executeToolCallsWithEvents(ctx context.Conte
 					}
 				}
 			} else {
-				result, toolErr = a.toolBox.ExecuteTool(ctx, toolCall.Function.Name, args)
+				toolStarted := time.Now()
+				result, toolCtx.Journal, toolCtx.Replayed, toolErr = a.executeJournaledTool(ctx, toolCall, args)
+				usageTracker.AddToolTime(time.Since(toolStarted))
 			}
 
 			toolResult := map[string]interface{}{"result": result}
@@ -595,6 +720,22 @@ func (a *OpenAICompatibleAgentImpl) executeToolCallsWithEvents(ctx context.Conte
 				}
 			}
 
+			var authErr *AuthRequiredError
+			if errors.As(toolErr, &authErr) {
+				a.logger.Info("tool requires authorization, pausing task",
+					zap.String("tool", toolCall.Function.Name),
+					zap.String("tool_call_id", toolCall.ID))
+				authMessage := authRequiredMessage(authErr, toolCall, args)
+				authMessage.TaskID = taskID
+				authMessage.ContextID = contextID
+				select {
+				case outputChan <- types.NewMessageEvent(types.EventAuthRequired, authMessage.MessageID, authMessage):
+				case <-ctx.Done():
+				}
+
+				return append(toolResultMessages, *authMessage)
+			}
+
 			if toolErr != nil {
 				a.logger.Error("failed to execute tool", zap.String("tool", toolCall.Function.Name), zap.Error(toolErr))
 				usageTracker.IncrementFailedTools()
@@ -618,6 +759,16 @@ func (a *OpenAICompatibleAgentImpl) executeToolCallsWithEvents(ctx context.Conte
 				}
 			}
 
+			if task != nil {
+				for _, artifact := range task.Artifacts[min(artifactCount, len(task.Artifacts)):] {
+					select {
+					case outputChan <- types.NewArtifactUpdateEvent(artifact):
+					case <-ctx.Done():
+						return toolResultMessages
+					}
+				}
+			}
+
 			toolResultMessage := types.NewToolResultMessage(toolCall.ID, toolCall.Function.Name, result, toolErr != nil)
 			toolResultMessage.TaskID = taskID
 			toolResultMessage.ContextID = contextID
@@ -654,6 +805,51 @@ func isCompleteJSON(s string) bool {
 	return openCount == 0
 }
 
+// endRun ends the run in the state set by a BeforeModel callback, with the callback's content as
+// the status message
+func (a *OpenAICompatibleAgentImpl) endRun(ctx context.Context, outputChan chan<- cloudevents.Event, response *LLMResponse, iteration int, taskID, contextID *string) {
+	message := response.Content
+	if message == nil {
+		message = types.NewStreamingStatusMessage(fmt.Sprintf("run-ended-%d", iteration), string(response.State), nil)
+	}
+	message.TaskID = taskID
+	message.ContextID = contextID
+
+	a.logger.Info("BeforeModel callback ended the run",
+		zap.Int("iteration", iteration),
+		zap.String("state", string(response.State)))
+
+	switch response.State {
+	case types.TaskStateInputRequired:
+		select {
+		case outputChan <- types.NewMessageEvent(types.EventInputRequired, message.MessageID, message):
+		case <-ctx.Done():
+		}
+	case types.TaskStateFailed:
+		failedStatusEvent := cloudevents.NewEvent()
+		failedStatusEvent.SetType(types.EventTaskStatusChanged)
+		if err := failedStatusEvent.SetData(cloudevents.ApplicationJSON, types.TaskStatus{
+			State:   types.TaskStateFailed,
+			Message: message,
+		}); err != nil {
+			a.logger.Error("failed to set failed status event data", zap.Error(err))
+			return
+		}
+		select {
+		case outputChan <- failedStatusEvent:
+		case <-ctx.Done():
+			return
+		}
+		select {
+		case outputChan <- types.NewMessageEvent(types.EventStreamFailed, message.MessageID, message):
+		case <-ctx.Done():
+		}
+	default:
+		a.logger.Warn("BeforeModel callback set an unsupported state, ending the run",
+			zap.String("state", string(response.State)))
+	}
+}
+
 // createCallbackContext creates a CallbackContext from the current execution state
 func (a *OpenAICompatibleAgentImpl) createCallbackContext(taskID, contextID *string) *CallbackContext {
 	agentName := ""
@@ -2,12 +2,14 @@ package server_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
 	"time"
 
 	server "github.com/inference-gateway/adk/server"
+	config "github.com/inference-gateway/adk/server/config"
 	mocks "github.com/inference-gateway/adk/server/mocks"
 	types "github.com/inference-gateway/adk/types"
 	sdk "github.com/inference-gateway/sdk"
@@ -1132,6 +1134,318 @@ func TestRunWithStream_WithSystemPrompt(t *testing.T) {
 	assert.Equal(t, "You are a helpful assistant", systemContent, "System prompt should match")
 }
 
+func TestRunWithStream_Vision(t *testing.T) {
+	run := func(vision bool) sdk.Message {
+		mockLLMClient := &mocks.FakeLLMClient{}
+		var capturedMessages []sdk.Message
+		mockLLMClient.CreateStreamingChatCompletionStub = func(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+			capturedMessages = messages
+			responseChan := make(chan *sdk.CreateChatCompletionStreamResponse, 1)
+			errorChan := make(chan error, 1)
+			responseChan <- &sdk.CreateChatCompletionStreamResponse{
+				Choices: []sdk.ChatCompletionStreamChoice{
+					{Delta: sdk.ChatCompletionStreamResponseDelta{Content: "A cat"}, FinishReason: "stop"},
+				},
+			}
+			close(responseChan)
+			close(errorChan)
+			return responseChan, errorChan
+		}
+
+		agent, err := server.NewAgentBuilder(zap.NewNop()).
+			WithConfig(&config.AgentConfig{MaxChatCompletionIterations: 1, EnableVision: vision}).
+			WithLLMClient(mockLLMClient).
+			Build()
+		require.NoError(t, err)
+
+		imageURI := "https://example.com/cat.jpg"
+		eventChan, err := agent.RunWithStream(context.Background(), []types.Message{
+			{
+				Role: types.RoleUser,
+				Parts: []types.Part{
+					types.CreateTextPart("Describe this image"),
+					types.CreateFilePart("cat.jpg", "image/jpeg", nil, &imageURI),
+				},
+			},
+		})
+		require.NoError(t, err)
+		for range eventChan {
+		}
+
+		require.Len(t, capturedMessages, 1)
+		return capturedMessages[0]
+	}
+
+	parts, err := run(true).Content.AsMessageContent1()
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	image, err := parts[1].AsImageContentPart()
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/cat.jpg", image.ImageURL.URL)
+
+	text, err := run(false).Content.AsMessageContent0()
+	require.NoError(t, err)
+	assert.Equal(t, "Describe this image", text, "images are not sent to models without vision")
+}
+
+func TestRunWithStream_ToolPreconditionRefused(t *testing.T) {
+	logger := zap.NewNop()
+	mockLLMClient := &mocks.FakeLLMClient{}
+
+	var toolResults string
+	callCount := 0
+	mockLLMClient.CreateStreamingChatCompletionStub = func(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+		responseChan := make(chan *sdk.CreateChatCompletionStreamResponse, 10)
+		errorChan := make(chan error, 1)
+
+		go func() {
+			defer close(responseChan)
+			defer close(errorChan)
+
+			callCount++
+			if callCount == 1 {
+				toolCallChunks := []sdk.ChatCompletionMessageToolCallChunk{
+					{
+						Index: 0,
+						ID:    new("call_refund"),
+						Type:  new("function"),
+						Function: &sdk.ChatCompletionMessageToolCallFunction{
+							Name:      "issue_refund",
+							Arguments: `{"orderId":"o-1"}`,
+						},
+					},
+				}
+				responseChan <- &sdk.CreateChatCompletionStreamResponse{
+					Choices: []sdk.ChatCompletionStreamChoice{
+						{
+							Delta:        sdk.ChatCompletionStreamResponseDelta{ToolCalls: &toolCallChunks},
+							FinishReason: "tool_calls",
+						},
+					},
+				}
+				return
+			}
+
+			payload, _ := json.Marshal(messages)
+			toolResults = string(payload)
+			responseChan <- &sdk.CreateChatCompletionStreamResponse{
+				Choices: []sdk.ChatCompletionStreamChoice{
+					{
+						Delta:        sdk.ChatCompletionStreamResponseDelta{Content: "You are not allowed to issue refunds"},
+						FinishReason: "stop",
+					},
+				},
+			}
+		}()
+
+		return responseChan, errorChan
+	}
+
+	executed := false
+	toolBox := server.NewDefaultToolBox(nil)
+	refundTool := server.NewBasicTool(
+		"issue_refund",
+		"Issues a refund for an order",
+		map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"orderId": map[string]any{"type": "string"},
+			},
+		},
+		func(ctx context.Context, args map[string]any) (string, error) {
+			executed = true
+			return "refunded", nil
+		},
+	).WithPreconditions(server.RequireScopes("refunds:write"))
+	toolBox.AddTool(refundTool)
+
+	agent, err := server.NewAgentBuilder(logger).
+		WithLLMClient(mockLLMClient).
+		WithToolBox(toolBox).
+		Build()
+	require.NoError(t, err)
+
+	metadata := map[string]any{types.AuthScopesMetadataKey: []string{"orders:read"}}
+	task := &types.Task{ID: "task-1", ContextID: "ctx-1", Metadata: &metadata}
+	ctx := context.WithValue(context.Background(), server.TaskContextKey, task)
+
+	eventChan, err := agent.RunWithStream(ctx, []types.Message{
+		{
+			Role:  "user",
+			Parts: []types.Part{types.CreateTextPart("Refund order o-1")},
+		},
+	})
+	require.NoError(t, err)
+
+	var hasToolCompleted bool
+	for event := range eventChan {
+		if event.Type() == "adk.agent.tool.completed" {
+			hasToolCompleted = true
+		}
+	}
+
+	assert.False(t, executed, "Tool should not execute when its precondition is not met")
+	assert.True(t, hasToolCompleted, "Refusal should be reported as a completed tool call")
+	assert.Contains(t, toolResults, "It requires the following permissions: refunds:write.")
+}
+
+func TestRunWithStream_ToolApproval(t *testing.T) {
+	tests := []struct {
+		name         string
+		decision     types.Part
+		executed     bool
+		expectResult string
+	}{
+		{
+			name:         "approved tool call runs on resume",
+			decision:     types.NewToolApprovalDecisionPart("call_write", true, ""),
+			executed:     true,
+			expectResult: "wrote notes.txt",
+		},
+		{
+			name:         "denied tool call is refused on resume",
+			decision:     types.NewToolApprovalDecisionPart("call_write", false, "not now"),
+			executed:     false,
+			expectResult: "The user denied running the write_file tool. Reason: not now",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLLMClient := &mocks.FakeLLMClient{}
+
+			var resumedMessages string
+			callCount := 0
+			mockLLMClient.CreateStreamingChatCompletionStub = func(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+				responseChan := make(chan *sdk.CreateChatCompletionStreamResponse, 10)
+				errorChan := make(chan error, 1)
+
+				go func() {
+					defer close(responseChan)
+					defer close(errorChan)
+
+					callCount++
+					if callCount == 1 {
+						toolCallChunks := []sdk.ChatCompletionMessageToolCallChunk{
+							{
+								Index: 0,
+								ID:    new("call_write"),
+								Type:  new("function"),
+								Function: &sdk.ChatCompletionMessageToolCallFunction{
+									Name:      "write_file",
+									Arguments: `{"path":"notes.txt"}`,
+								},
+							},
+						}
+						responseChan <- &sdk.CreateChatCompletionStreamResponse{
+							Choices: []sdk.ChatCompletionStreamChoice{
+								{
+									Delta:        sdk.ChatCompletionStreamResponseDelta{ToolCalls: &toolCallChunks},
+									FinishReason: "tool_calls",
+								},
+							},
+						}
+						return
+					}
+
+					payload, _ := json.Marshal(messages)
+					resumedMessages = string(payload)
+					responseChan <- &sdk.CreateChatCompletionStreamResponse{
+						Choices: []sdk.ChatCompletionStreamChoice{
+							{
+								Delta:        sdk.ChatCompletionStreamResponseDelta{Content: "All done"},
+								FinishReason: "stop",
+							},
+						},
+					}
+				}()
+
+				return responseChan, errorChan
+			}
+
+			executed := false
+			toolBox := server.NewDefaultToolBox(&config.ToolBoxConfig{RequireApproval: []string{"write_file"}})
+			toolBox.AddTool(server.NewBasicTool(
+				"write_file",
+				"Writes a file",
+				map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"path": map[string]any{"type": "string"},
+					},
+				},
+				func(ctx context.Context, args map[string]any) (string, error) {
+					executed = true
+					return fmt.Sprintf("wrote %s", args["path"]), nil
+				},
+			))
+
+			agent, err := server.NewAgentBuilder(zap.NewNop()).
+				WithLLMClient(mockLLMClient).
+				WithToolBox(toolBox).
+				Build()
+			require.NoError(t, err)
+
+			task := &types.Task{ID: "task-1", ContextID: "ctx-1"}
+			ctx := context.WithValue(context.Background(), server.TaskContextKey, task)
+			history := []types.Message{
+				{
+					Role:  types.RoleUser,
+					Parts: []types.Part{types.CreateTextPart("Save my notes")},
+				},
+			}
+
+			eventChan, err := agent.RunWithStream(ctx, history)
+			require.NoError(t, err)
+
+			var approvalMessage *types.Message
+			for event := range eventChan {
+				if event.Type() == types.EventInputRequired {
+					var message types.Message
+					require.NoError(t, event.DataAs(&message))
+					approvalMessage = &message
+				}
+			}
+
+			require.NotNil(t, approvalMessage, "Task should pause for approval")
+			assert.False(t, executed, "Tool should not execute before it is approved")
+			assert.Equal(t, "approval-required-call_write", approvalMessage.MessageID)
+			var request map[string]any
+			for _, part := range approvalMessage.Parts {
+				if part.Data != nil {
+					request, _ = part.Data.Data[types.ToolApprovalRequestDataKey].(map[string]any)
+				}
+			}
+			require.NotNil(t, request)
+			assert.Equal(t, "call_write", request["tool_call_id"])
+			assert.Equal(t, "write_file", request["tool_name"])
+			assert.Equal(t, map[string]any{"path": "notes.txt"}, request["arguments"])
+
+			history = append(history, *approvalMessage, types.Message{
+				Role:  types.RoleUser,
+				Parts: []types.Part{tt.decision},
+			})
+			eventChan, err = agent.RunWithStream(ctx, history)
+			require.NoError(t, err)
+
+			var completed bool
+			for event := range eventChan {
+				if event.Type() == types.EventTaskStatusChanged {
+					var status types.TaskStatus
+					require.NoError(t, event.DataAs(&status))
+					completed = completed || status.State == types.TaskStateCompleted
+				}
+			}
+
+			assert.True(t, completed, "Task should complete after the decision")
+			assert.Equal(t, tt.executed, executed)
+			assert.Contains(t, resumedMessages, tt.expectResult)
+			assert.Contains(t, resumedMessages, `"tool_call_id":"call_write"`)
+			assert.NotContains(t, resumedMessages, "Approval required")
+		})
+	}
+}
+
 func TestRunWithStream_MultipleIterations(t *testing.T) {
 	logger := zap.NewNop()
 	mockLLMClient := &mocks.FakeLLMClient{}
@@ -1470,3 +1784,97 @@ func TestRunWithStream_StreamFailedEventEmitted(t *testing.T) {
 	}
 	assert.Contains(t, failedStatusText, "streaming connection error", "TaskStatus.Message must carry the underlying error as a TextPart")
 }
+
+func TestRunWithStream_ToolCreatesArtifact(t *testing.T) {
+	logger := zap.NewNop()
+	mockLLMClient := &mocks.FakeLLMClient{}
+
+	callCount := 0
+	mockLLMClient.CreateStreamingChatCompletionStub = func(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+		responseChan := make(chan *sdk.CreateChatCompletionStreamResponse, 10)
+		errorChan := make(chan error, 1)
+		callCount++
+		firstCall := callCount == 1
+
+		go func() {
+			defer close(responseChan)
+			defer close(errorChan)
+
+			if !firstCall {
+				responseChan <- &sdk.CreateChatCompletionStreamResponse{
+					Choices: []sdk.ChatCompletionStreamChoice{
+						{
+							Delta:        sdk.ChatCompletionStreamResponseDelta{Content: "Report ready"},
+							FinishReason: "stop",
+						},
+					},
+				}
+				return
+			}
+
+			toolCallChunks := []sdk.ChatCompletionMessageToolCallChunk{
+				{
+					Index: 0,
+					ID:    new("call_report"),
+					Type:  new("function"),
+					Function: &sdk.ChatCompletionMessageToolCallFunction{
+						Name:      "report_tool",
+						Arguments: `{}`,
+					},
+				},
+			}
+			responseChan <- &sdk.CreateChatCompletionStreamResponse{
+				Choices: []sdk.ChatCompletionStreamChoice{
+					{
+						Delta:        sdk.ChatCompletionStreamResponseDelta{ToolCalls: &toolCallChunks},
+						FinishReason: "tool_calls",
+					},
+				},
+			}
+		}()
+
+		return responseChan, errorChan
+	}
+
+	toolBox := server.NewDefaultToolBox(nil)
+	toolBox.AddTool(server.NewBasicTool(
+		"report_tool",
+		"Tool that attaches a report artifact",
+		map[string]any{"type": "object", "properties": map[string]any{}},
+		func(ctx context.Context, args map[string]any) (string, error) {
+			task := ctx.Value(server.TaskContextKey).(*types.Task)
+			task.Artifacts = append(task.Artifacts, types.Artifact{
+				ArtifactID: "report-1",
+				Parts:      []types.Part{types.CreateTextPart("report body")},
+			})
+			return "report created", nil
+		},
+	))
+
+	agent, err := server.NewAgentBuilder(logger).
+		WithLLMClient(mockLLMClient).
+		WithToolBox(toolBox).
+		Build()
+	require.NoError(t, err)
+
+	task := &types.Task{ID: "task-1", ContextID: "ctx-1"}
+	ctx := context.WithValue(context.Background(), server.TaskContextKey, task)
+
+	eventChan, err := agent.RunWithStream(ctx, []types.Message{
+		{Role: "user", Parts: []types.Part{types.CreateTextPart("Create a report")}},
+	})
+	require.NoError(t, err)
+
+	var artifacts []types.Artifact
+	for event := range eventChan {
+		if event.Type() != types.EventArtifactUpdate {
+			continue
+		}
+		var artifact types.Artifact
+		require.NoError(t, event.DataAs(&artifact))
+		artifacts = append(artifacts, artifact)
+	}
+
+	require.Len(t, artifacts, 1)
+	assert.Equal(t, "report-1", artifacts[0].ArtifactID)
+}
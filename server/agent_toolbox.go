@@ -2,8 +2,16 @@ package server
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"mime"
+	"path"
+	"slices"
+	"strings"
+
+	uuid "github.com/google/uuid"
 
 	config "github.com/inference-gateway/adk/server/config"
 	types "github.com/inference-gateway/adk/types"
@@ -46,13 +54,17 @@ type Tool interface {
 
 // DefaultToolBox is a default implementation of ToolBox
 type DefaultToolBox struct {
-	tools map[string]Tool
+	tools       map[string]Tool
+	approvals   map[string]bool
+	sideEffects map[string]bool
 }
 
 // NewToolBox creates a new empty DefaultToolBox
 func NewToolBox() *DefaultToolBox {
 	return &DefaultToolBox{
-		tools: make(map[string]Tool),
+		tools:       make(map[string]Tool),
+		approvals:   make(map[string]bool),
+		sideEffects: make(map[string]bool),
 	}
 }
 
@@ -64,23 +76,11 @@ func NewDefaultToolBox(cfg *config.ToolBoxConfig) *DefaultToolBox {
 	inputRequiredTool := NewBasicTool(
 		"input_required",
 		"REQUIRED: Use this tool when you need additional information from the user to provide a complete and accurate response. Call this instead of making assumptions or providing incomplete answers. Examples: missing location for weather, unclear requirements, ambiguous requests, or when more context would significantly improve the response quality.",
-		map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"message": map[string]any{
-					"type":        "string",
-					"description": "Clear, specific message explaining exactly what additional information you need from the user to complete their request. Be specific about what's missing and why it's needed.",
-				},
-			},
-			"required": []string{"message"},
-		},
+		inputRequiredToolParameters(),
 		func(ctx context.Context, args map[string]any) (string, error) {
-			// NOTE: This handler is never executed in practice.
-			// The agent intercepts input_required tool calls before execution
-			// (see agent.go:186-204 and agent_streamable.go:325-354) and extracts
-			// the 'message' argument directly from the tool call to create an
-			// input_required response. This handler exists only to satisfy the
-			// tool registration interface requirements.
+			// The agent intercepts input_required tool calls before execution and pauses
+			// the task with the structured question of the arguments. This handler exists
+			// only to satisfy the tool registration interface requirements.
 			return "", nil
 		},
 	)
@@ -120,6 +120,33 @@ func NewDefaultToolBox(cfg *config.ToolBoxConfig) *DefaultToolBox {
 		toolBox.AddTool(createArtifactTool)
 	}
 
+	if cfg != nil && cfg.EnableHTTPRequest {
+		toolBox.AddTool(NewHTTPRequestTool(cfg.HTTPRequest))
+	}
+
+	if cfg != nil && cfg.EnableRedactPII {
+		toolBox.AddTool(NewRedactPIITool(NewRedactor(nil)))
+	}
+
+	if cfg != nil && cfg.SkillsConfig.CodeExecution.Enable {
+		codeExecution := cfg.SkillsConfig.CodeExecution
+		toolBox.AddTool(NewExecuteCodeTool(codeExecution, NewDockerSandbox(codeExecution)))
+	}
+
+	if cfg != nil && cfg.SkillsConfig.Command.Enable {
+		toolBox.AddTool(NewRunCommandTool(cfg.SkillsConfig.Command))
+	}
+
+	if cfg != nil && cfg.SkillsConfig.Browser.Enable {
+		browser := cfg.SkillsConfig.Browser
+		toolBox.AddTool(NewBrowseWebTool(browser, NewChromeBrowser(browser)))
+	}
+
+	if cfg != nil {
+		toolBox.RequireApproval(cfg.RequireApproval...)
+		toolBox.MarkSideEffects(cfg.SideEffects...)
+	}
+
 	return toolBox
 }
 
@@ -180,6 +207,33 @@ func (tb *DefaultToolBox) GetTool(toolName string) (Tool, bool) {
 	return tool, exists
 }
 
+// RequireApproval marks tools that only run once the client approves the call. The task
+// pauses in input-required state with an approval request until it is resumed with a decision.
+func (tb *DefaultToolBox) RequireApproval(toolNames ...string) {
+	for _, name := range toolNames {
+		tb.approvals[name] = true
+	}
+}
+
+// RequiresApproval checks if a tool only runs once the client approves the call
+func (tb *DefaultToolBox) RequiresApproval(toolName string) bool {
+	return tb.approvals[toolName]
+}
+
+// MarkSideEffects marks tools with side effects, such as sending an email or charging a card.
+// In a checkpointed task their executions are journaled, so a resumed run reuses the result
+// of a completed call instead of executing it again.
+func (tb *DefaultToolBox) MarkSideEffects(toolNames ...string) {
+	for _, name := range toolNames {
+		tb.sideEffects[name] = true
+	}
+}
+
+// HasSideEffects checks if the executions of a tool are journaled
+func (tb *DefaultToolBox) HasSideEffects(toolName string) bool {
+	return tb.sideEffects[toolName]
+}
+
 // ToolNotFoundError represents an error when a requested tool is not found
 type ToolNotFoundError struct {
 	ToolName string
@@ -195,6 +249,8 @@ type BasicTool struct {
 	description string
 	parameters  map[string]any
 	executor    func(ctx context.Context, arguments map[string]any) (string, error)
+
+	preconditions []Precondition
 }
 
 // NewBasicTool creates a new BasicTool
@@ -228,6 +284,16 @@ func (t *BasicTool) Execute(ctx context.Context, arguments map[string]any) (stri
 	return t.executor(ctx, arguments)
 }
 
+// WithPreconditions declares preconditions that must be met before the tool executes
+func (t *BasicTool) WithPreconditions(preconditions ...Precondition) *BasicTool {
+	t.preconditions = append(t.preconditions, preconditions...)
+	return t
+}
+
+func (t *BasicTool) GetPreconditions() []Precondition {
+	return t.preconditions
+}
+
 // JSONTool creates a tool result that can be marshaled to JSON
 func JSONTool(result any) (string, error) {
 	data, err := json.Marshal(result)
@@ -305,3 +371,53 @@ func executeCreateArtifact(ctx context.Context, args map[string]any) (string, er
 		"filename":    filename,
 	})
 }
+
+// attachToolFiles adds files a tool produced to the task as artifacts, stored by the artifact
+// service when there is one and inline otherwise, and describes them for the agent
+func attachToolFiles(ctx context.Context, toolName string, outputs map[string][]byte) ([]map[string]any, error) {
+	task, ok := ctx.Value(TaskContextKey).(*types.Task)
+	if !ok || task == nil {
+		return nil, fmt.Errorf("task not found in context")
+	}
+	artifactService, _ := ctx.Value(ArtifactServiceContextKey).(ArtifactService)
+
+	files := make([]map[string]any, 0, len(outputs))
+	for _, name := range slices.Sorted(maps.Keys(outputs)) {
+		data := outputs[name]
+		description := fmt.Sprintf("File produced by the %s tool: %s", toolName, name)
+		file := map[string]any{"filename": name, "size": len(data)}
+
+		if artifactService != nil {
+			artifact, err := artifactService.CreateFileArtifact(task.ContextID, name, description, name, data, artifactService.GetMimeTypeFromExtension(name))
+			if err != nil {
+				return nil, fmt.Errorf("failed to create artifact for %s: %w", name, err)
+			}
+			artifactService.AddArtifactToTask(task, artifact)
+			file["artifact_id"] = artifact.ArtifactID
+			if len(artifact.Parts) > 0 && artifact.Parts[0].File != nil && artifact.Parts[0].File.FileWithURI != nil {
+				file["url"] = *artifact.Parts[0].File.FileWithURI
+			}
+		} else {
+			encoded := base64.StdEncoding.EncodeToString(data)
+			artifact := types.Artifact{
+				ArtifactID:  uuid.New().String(),
+				Name:        &name,
+				Description: &description,
+				Parts:       []types.Part{types.CreateFilePart(name, mediaTypeOfFile(name), &encoded, nil)},
+			}
+			task.Artifacts = append(task.Artifacts, artifact)
+			file["artifact_id"] = artifact.ArtifactID
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// mediaTypeOfFile returns the media type of a file by its extension
+func mediaTypeOfFile(name string) string {
+	mediaType, _, _ := strings.Cut(mime.TypeByExtension(path.Ext(name)), ";")
+	if mediaType == "" {
+		return "application/octet-stream"
+	}
+	return mediaType
+}
@@ -0,0 +1,399 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+// ErrChromeBrowserNotBuilt is returned when a session of NewChromeBrowser is started in a build
+// without the chrome build tag
+var ErrChromeBrowserNotBuilt = errors.New("the chrome browser is not built in, build with -tags chrome")
+
+// Actions of the browse_web tool
+const (
+	BrowserActionNavigate    = "navigate"
+	BrowserActionClick       = "click"
+	BrowserActionFill        = "fill"
+	BrowserActionSubmit      = "submit"
+	BrowserActionExtractText = "extract_text"
+	BrowserActionScreenshot  = "screenshot"
+)
+
+const (
+	defaultBrowserMaxSteps    = 20
+	defaultBrowserTimeout     = 60 * time.Second
+	defaultBrowserMaxTextSize = 64 << 10
+)
+
+var browserActions = []string{
+	BrowserActionNavigate,
+	BrowserActionClick,
+	BrowserActionFill,
+	BrowserActionSubmit,
+	BrowserActionExtractText,
+	BrowserActionScreenshot,
+}
+
+// Browser starts the browsing sessions of the browse_web tool
+type Browser interface {
+	// NewSession starts a session with an empty profile that lives until ctx ends or the
+	// session is closed. Every web request of its pages is checked with allow, and the
+	// requests allow refuses fail.
+	NewSession(ctx context.Context, allow func(*url.URL) error) (BrowserSession, error)
+}
+
+// BrowserSession is a page the browse_web tool drives. Selectors are CSS selectors, and an
+// action waits for the element it needs to appear.
+type BrowserSession interface {
+	// Navigate loads a URL and waits for the page to load
+	Navigate(target string) error
+
+	// Click clicks an element
+	Click(selector string) error
+
+	// Fill replaces the value of an input element by typing into it
+	Fill(selector, value string) error
+
+	// Submit submits the form of an element
+	Submit(selector string) error
+
+	// Text returns the visible text of an element
+	Text(selector string) (string, error)
+
+	// Screenshot captures an element, the whole page when fullPage is set, or the viewport
+	// otherwise, as a PNG image
+	Screenshot(selector string, fullPage bool) ([]byte, error)
+
+	// Location returns the URL and title of the current page
+	Location() (string, string, error)
+
+	// Close ends the session and its browser
+	Close() error
+}
+
+// NewBrowseWebTool creates the browse_web tool, which opens a page in a headless browser and
+// runs a list of actions on it: navigating, clicking, filling and submitting forms, extracting
+// text and taking screenshots, which are added to the task as image artifacts. Pages are only
+// loaded from the allowed domains, and a call runs at most the configured number of steps. Use
+// NewChromeBrowser for a local Chrome, in builds with the chrome build tag.
+func NewBrowseWebTool(cfg config.BrowserConfig, browser Browser) *BasicTool {
+	browsing := newWebBrowsing(cfg, browser)
+
+	return NewBasicTool(
+		"browse_web",
+		fmt.Sprintf("Open a web page in a headless browser and run actions on it in order, for pages that need JavaScript or interaction. Use extract_text to read the page and screenshot to show it to the user. A call runs at most %d steps including the first navigation, and each call starts with a fresh browser without cookies.", browsing.maxSteps),
+		map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"url": map[string]any{
+					"type":        "string",
+					"description": "URL of the page to open first",
+				},
+				"actions": map[string]any{
+					"type":        "array",
+					"description": "Actions to run after the page loaded. Without actions the text of the page is extracted.",
+					"items": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"action": map[string]any{
+								"type":        "string",
+								"description": "Action to run",
+								"enum":        browserActions,
+							},
+							"url": map[string]any{
+								"type":        "string",
+								"description": "URL to load, for navigate",
+							},
+							"selector": map[string]any{
+								"type":        "string",
+								"description": "CSS selector of the element to act on; required for click, fill and submit, and defaults to the page body for extract_text and to the viewport for screenshot",
+							},
+							"value": map[string]any{
+								"type":        "string",
+								"description": "Text to type, for fill",
+							},
+							"full_page": map[string]any{
+								"type":        "boolean",
+								"description": "Capture the whole page instead of the viewport, for screenshot",
+							},
+						},
+						"required": []string{"action"},
+					},
+				},
+			},
+			"required": []string{"url"},
+		},
+		func(ctx context.Context, args map[string]any) (string, error) {
+			return browsing.browse(ctx, args)
+		},
+	)
+}
+
+// browserStep is an action of a browse_web call
+type browserStep struct {
+	Action   string
+	URL      string
+	Selector string
+	Value    string
+	FullPage bool
+}
+
+// webBrowsing enforces the domain policy and limits of the browse_web tool
+type webBrowsing struct {
+	browser     Browser
+	policy      *httpRequestPolicy
+	maxSteps    int
+	timeout     time.Duration
+	maxTextSize int
+	// lookupIP resolves the hosts of the pages, to refuse the ones inside the network of the server
+	lookupIP func(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+func newWebBrowsing(cfg config.BrowserConfig, browser Browser) *webBrowsing {
+	browsing := &webBrowsing{
+		browser: browser,
+		policy: &httpRequestPolicy{
+			allowedDomains: normalizeDomains(cfg.AllowedDomains),
+			deniedDomains:  normalizeDomains(cfg.DeniedDomains),
+			allowPrivate:   cfg.AllowPrivateNetworks,
+		},
+		maxSteps:    cfg.MaxSteps,
+		timeout:     cfg.Timeout,
+		maxTextSize: cfg.MaxTextSize,
+		lookupIP:    net.DefaultResolver.LookupIPAddr,
+	}
+	if browsing.maxSteps <= 0 {
+		browsing.maxSteps = defaultBrowserMaxSteps
+	}
+	if browsing.timeout <= 0 {
+		browsing.timeout = defaultBrowserTimeout
+	}
+	if browsing.maxTextSize <= 0 {
+		browsing.maxTextSize = defaultBrowserMaxTextSize
+	}
+	return browsing
+}
+
+// browse runs the steps of the tool arguments and describes their outcome. Invalid calls are
+// refused before the browser starts; a step that fails ends the call, and the outcome of the
+// steps before it is still returned.
+func (b *webBrowsing) browse(ctx context.Context, args map[string]any) (string, error) {
+	steps, err := b.parseSteps(args)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, b.timeout)
+	defer cancel()
+
+	session, err := b.browser.NewSession(ctx, func(target *url.URL) error {
+		return b.checkURL(ctx, target)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to start browser: %w", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	response := map[string]any{}
+	results := make([]map[string]any, 0, len(steps))
+	screenshots := make(map[string][]byte)
+	for i, step := range steps {
+		result, err := b.runStep(session, step)
+		if err == nil && (step.Action == BrowserActionNavigate || step.Action == BrowserActionClick || step.Action == BrowserActionSubmit) {
+			err = b.checkLocation(ctx, session)
+		}
+		if err != nil {
+			response["error"] = fmt.Sprintf("step %d (%s) failed: %v", i+1, step.Action, err)
+			break
+		}
+		if screenshot, ok := result["screenshot"].([]byte); ok {
+			name := fmt.Sprintf("screenshot-%d.png", i+1)
+			screenshots[name] = screenshot
+			delete(result, "screenshot")
+			result["filename"] = name
+		}
+		results = append(results, result)
+	}
+	response["steps"] = results
+
+	if location, title, err := session.Location(); err == nil {
+		response["url"] = location
+		response["title"] = title
+	}
+
+	if len(screenshots) > 0 {
+		files, err := attachToolFiles(ctx, "browse_web", screenshots)
+		if err != nil {
+			return "", err
+		}
+		artifacts := make(map[string]any, len(files))
+		for _, file := range files {
+			artifacts[file["filename"].(string)] = file["artifact_id"]
+		}
+		for _, result := range results {
+			if name, ok := result["filename"].(string); ok {
+				result["artifact_id"] = artifacts[name]
+			}
+		}
+	}
+	return JSONTool(response)
+}
+
+// parseSteps reads the steps of the tool arguments, starting with the navigation to the url,
+// and checks them against the step limit and the domain policy
+func (b *webBrowsing) parseSteps(args map[string]any) ([]browserStep, error) {
+	target, _ := args["url"].(string)
+	steps := []browserStep{{Action: BrowserActionNavigate, URL: target}}
+
+	actions, _ := args["actions"].([]any)
+	for _, value := range actions {
+		action, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("actions must be objects")
+		}
+		step := browserStep{}
+		step.Action, _ = action["action"].(string)
+		step.URL, _ = action["url"].(string)
+		step.Selector, _ = action["selector"].(string)
+		step.Value, _ = action["value"].(string)
+		step.FullPage, _ = action["full_page"].(bool)
+		steps = append(steps, step)
+	}
+	if len(actions) == 0 {
+		steps = append(steps, browserStep{Action: BrowserActionExtractText})
+	}
+	if len(steps) > b.maxSteps {
+		return nil, fmt.Errorf("a call runs at most %d steps including the first navigation, got %d", b.maxSteps, len(steps))
+	}
+
+	for i, step := range steps {
+		switch step.Action {
+		case BrowserActionNavigate:
+			parsed, err := url.Parse(step.URL)
+			if err != nil || step.URL == "" {
+				return nil, fmt.Errorf("step %d: a valid url is required", i+1)
+			}
+			if err := b.policy.checkURL(parsed); err != nil {
+				return nil, fmt.Errorf("step %d: %w", i+1, err)
+			}
+		case BrowserActionClick, BrowserActionFill, BrowserActionSubmit:
+			if strings.TrimSpace(step.Selector) == "" {
+				return nil, fmt.Errorf("step %d: %s requires a selector", i+1, step.Action)
+			}
+		case BrowserActionExtractText, BrowserActionScreenshot:
+		default:
+			return nil, fmt.Errorf("step %d: action must be one of %s", i+1, strings.Join(browserActions, ", "))
+		}
+	}
+	return steps, nil
+}
+
+// runStep runs a step in the session and describes its outcome; a screenshot is returned as
+// the bytes of the image under the screenshot key
+func (b *webBrowsing) runStep(session BrowserSession, step browserStep) (map[string]any, error) {
+	result := map[string]any{"action": step.Action}
+	if step.Selector != "" {
+		result["selector"] = step.Selector
+	}
+
+	switch step.Action {
+	case BrowserActionNavigate:
+		result["url"] = step.URL
+		return result, session.Navigate(step.URL)
+	case BrowserActionClick:
+		return result, session.Click(step.Selector)
+	case BrowserActionFill:
+		return result, session.Fill(step.Selector, step.Value)
+	case BrowserActionSubmit:
+		return result, session.Submit(step.Selector)
+	case BrowserActionExtractText:
+		selector := step.Selector
+		if selector == "" {
+			selector = "body"
+		}
+		text, err := session.Text(selector)
+		if err != nil {
+			return nil, err
+		}
+		text, truncated := truncateOutput(text, b.maxTextSize)
+		result["text"] = text
+		if truncated {
+			result["truncated"] = true
+		}
+		return result, nil
+	case BrowserActionScreenshot:
+		screenshot, err := session.Screenshot(step.Selector, step.FullPage)
+		if err != nil {
+			return nil, err
+		}
+		result["screenshot"] = screenshot
+		return result, nil
+	}
+	return nil, fmt.Errorf("action must be one of %s", strings.Join(browserActions, ", "))
+}
+
+// checkLocation refuses pages the browser ended up on outside the allowed domains, such as
+// through a redirect the browser followed
+func (b *webBrowsing) checkLocation(ctx context.Context, session BrowserSession) error {
+	location, _, err := session.Location()
+	if err != nil {
+		return err
+	}
+	parsed, err := url.Parse(location)
+	if err != nil {
+		return fmt.Errorf("invalid page URL %q: %w", location, err)
+	}
+	if slices.Contains([]string{"about", "data", "blob"}, parsed.Scheme) {
+		return nil
+	}
+	return b.checkURL(ctx, parsed)
+}
+
+// checkURL checks a URL a page loads against the domain lists and, unless private networks
+// are allowed, resolves its host and refuses it when any address is inside the network of the
+// server. The browser resolves hosts itself, so the dial check of the http_request tool does
+// not apply; a name whose records change between the two lookups can still reach such an
+// address, which AllowedDomains rules out.
+func (b *webBrowsing) checkURL(ctx context.Context, target *url.URL) error {
+	if err := b.policy.checkURL(target); err != nil {
+		return err
+	}
+	host := target.Hostname()
+	if b.policy.allowPrivate || net.ParseIP(host) != nil {
+		return nil
+	}
+	addresses, err := b.lookupIP(ctx, host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	for _, address := range addresses {
+		if err := b.policy.checkAddress(address.IP); err != nil {
+			return fmt.Errorf("requests to %s are not allowed: %w", host, err)
+		}
+	}
+	return nil
+}
+
+// checkBrowserRequest checks a request of a page: web requests must pass allow, data and blob
+// URLs carry content the page already has, and other schemes are refused
+func checkBrowserRequest(rawURL string, allow func(*url.URL) error) error {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	switch target.Scheme {
+	case "http", "https":
+		return allow(target)
+	case "data", "blob":
+		return nil
+	}
+	return fmt.Errorf("unsupported URL scheme %q", target.Scheme)
+}
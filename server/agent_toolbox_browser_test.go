@@ -0,0 +1,266 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// fakeBrowser serves pages from a map of URL to text, following the redirects it is given, and
+// records the actions run on them
+type fakeBrowser struct {
+	pages     map[string]string
+	redirects map[string]string
+	actions   []string
+	sessions  int
+	closed    int
+	allow     func(*url.URL) error
+}
+
+func (b *fakeBrowser) NewSession(ctx context.Context, allow func(*url.URL) error) (BrowserSession, error) {
+	b.sessions++
+	b.allow = allow
+	return &fakeBrowserSession{browser: b, location: "about:blank"}, nil
+}
+
+type fakeBrowserSession struct {
+	browser  *fakeBrowser
+	location string
+}
+
+func (s *fakeBrowserSession) Navigate(target string) error {
+	s.browser.actions = append(s.browser.actions, "navigate "+target)
+	if redirect, ok := s.browser.redirects[target]; ok {
+		target = redirect
+	}
+	if _, ok := s.browser.pages[target]; !ok {
+		return errors.New("net::ERR_NAME_NOT_RESOLVED")
+	}
+	s.location = target
+	return nil
+}
+
+func (s *fakeBrowserSession) Click(selector string) error {
+	s.browser.actions = append(s.browser.actions, "click "+selector)
+	return nil
+}
+
+func (s *fakeBrowserSession) Fill(selector, value string) error {
+	s.browser.actions = append(s.browser.actions, "fill "+selector+"="+value)
+	return nil
+}
+
+func (s *fakeBrowserSession) Submit(selector string) error {
+	s.browser.actions = append(s.browser.actions, "submit "+selector)
+	return nil
+}
+
+func (s *fakeBrowserSession) Text(selector string) (string, error) {
+	s.browser.actions = append(s.browser.actions, "text "+selector)
+	return s.browser.pages[s.location], nil
+}
+
+func (s *fakeBrowserSession) Screenshot(selector string, fullPage bool) ([]byte, error) {
+	s.browser.actions = append(s.browser.actions, "screenshot "+selector)
+	return []byte("png"), nil
+}
+
+func (s *fakeBrowserSession) Location() (string, string, error) {
+	return s.location, "Title of " + s.location, nil
+}
+
+func (s *fakeBrowserSession) Close() error {
+	s.browser.closed++
+	return nil
+}
+
+// lookupIPs resolves the hosts of the map to their address, and other hosts to a public one
+func lookupIPs(addresses map[string]string) func(context.Context, string) ([]net.IPAddr, error) {
+	return func(_ context.Context, host string) ([]net.IPAddr, error) {
+		if address, ok := addresses[host]; ok {
+			return []net.IPAddr{{IP: net.ParseIP(address)}}, nil
+		}
+		return []net.IPAddr{{IP: net.ParseIP("93.184.215.14")}}, nil
+	}
+}
+
+func browseWeb(t *testing.T, ctx context.Context, browser Browser, args map[string]any) (map[string]any, error) {
+	t.Helper()
+	cfg := config.BrowserConfig{AllowedDomains: []string{"example.com"}, MaxSteps: 4, MaxTextSize: 12}
+	browsing := newWebBrowsing(cfg, browser)
+	browsing.lookupIP = lookupIPs(map[string]string{})
+	result, err := browsing.browse(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result), &decoded))
+	return decoded, nil
+}
+
+func TestBrowseWebTool_RunsActions(t *testing.T) {
+	task := &types.Task{ID: "task-1", ContextID: "ctx-1"}
+	ctx := context.WithValue(context.Background(), TaskContextKey, task)
+	browser := &fakeBrowser{pages: map[string]string{"https://shop.example.com/search": "Results: a long list of products"}}
+
+	result, err := browseWeb(t, ctx, browser, map[string]any{
+		"url": "https://shop.example.com/search",
+		"actions": []any{
+			map[string]any{"action": "fill", "selector": "#q", "value": "lamp"},
+			map[string]any{"action": "extract_text", "selector": "#results"},
+			map[string]any{"action": "screenshot"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"navigate https://shop.example.com/search", "fill #q=lamp", "text #results", "screenshot "}, browser.actions)
+	assert.Equal(t, 1, browser.closed, "the session is closed")
+	assert.Equal(t, "https://shop.example.com/search", result["url"])
+	assert.NotContains(t, result, "error")
+
+	steps := result["steps"].([]any)
+	require.Len(t, steps, 4)
+	text := steps[2].(map[string]any)
+	assert.Equal(t, "Results: a l", text["text"])
+	assert.Equal(t, true, text["truncated"])
+
+	require.Len(t, task.Artifacts, 1)
+	artifact := task.Artifacts[0]
+	require.NotNil(t, artifact.Parts[0].File)
+	assert.Equal(t, "screenshot-4.png", artifact.Parts[0].File.Name)
+	assert.Equal(t, "image/png", artifact.Parts[0].File.MediaType)
+	assert.Equal(t, artifact.ArtifactID, steps[3].(map[string]any)["artifact_id"])
+}
+
+func TestBrowseWebTool_ExtractsTextWithoutActions(t *testing.T) {
+	browser := &fakeBrowser{pages: map[string]string{"https://example.com/": "Hello"}}
+
+	result, err := browseWeb(t, context.Background(), browser, map[string]any{"url": "https://example.com/"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"navigate https://example.com/", "text body"}, browser.actions)
+	assert.Equal(t, "Hello", result["steps"].([]any)[1].(map[string]any)["text"])
+}
+
+func TestBrowseWebTool_StopsAtRedirectsOutsideTheAllowedDomains(t *testing.T) {
+	browser := &fakeBrowser{
+		pages:     map[string]string{"https://example.com/": "Home", "https://evil.test/": "Phish"},
+		redirects: map[string]string{"https://example.com/login": "https://evil.test/"},
+	}
+
+	result, err := browseWeb(t, context.Background(), browser, map[string]any{
+		"url": "https://example.com/",
+		"actions": []any{
+			map[string]any{"action": "navigate", "url": "https://example.com/login"},
+			map[string]any{"action": "extract_text"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, result["error"], "step 2 (navigate) failed: requests to evil.test are not allowed")
+	assert.Len(t, result["steps"].([]any), 1, "the steps before the failure are reported")
+	assert.NotContains(t, browser.actions, "text body")
+
+	require.NotNil(t, browser.allow)
+	assert.Error(t, browser.allow(&url.URL{Scheme: "https", Host: "cdn.other.test"}), "the session checks every request")
+}
+
+func TestBrowseWebTool_RefusesHostsResolvingToPrivateNetworks(t *testing.T) {
+	pages := map[string]string{"https://example.com/": "Home", "https://admin.example.com/": "Router admin"}
+	addresses := map[string]string{"admin.example.com": "192.168.1.1", "metadata.example.com": "169.254.169.254", "localhost": "127.0.0.1"}
+	args := map[string]any{"url": "https://example.com/", "actions": []any{map[string]any{"action": "navigate", "url": "https://admin.example.com/"}}}
+
+	browser := &fakeBrowser{pages: pages}
+	browsing := newWebBrowsing(config.BrowserConfig{}, browser)
+	browsing.lookupIP = lookupIPs(addresses)
+	result, err := browsing.browse(context.Background(), args)
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result), &decoded))
+	assert.Contains(t, decoded["error"], "step 2 (navigate) failed: requests to admin.example.com are not allowed: requests to private network address 192.168.1.1 are not allowed")
+
+	require.NotNil(t, browser.allow)
+	assert.NoError(t, browser.allow(&url.URL{Scheme: "https", Host: "cdn.example.org"}))
+	assert.Error(t, browser.allow(&url.URL{Scheme: "http", Host: "metadata.example.com"}), "requests of the page are resolved and checked")
+	assert.Error(t, browser.allow(&url.URL{Scheme: "http", Host: "localhost:8080"}))
+
+	browser = &fakeBrowser{pages: pages}
+	browsing = newWebBrowsing(config.BrowserConfig{AllowPrivateNetworks: true}, browser)
+	browsing.lookupIP = func(context.Context, string) ([]net.IPAddr, error) {
+		t.Fatal("hosts are not resolved when private networks are allowed")
+		return nil, nil
+	}
+	result, err = browsing.browse(context.Background(), args)
+	require.NoError(t, err)
+	assert.NotContains(t, result, `"error"`)
+}
+
+func TestBrowseWebTool_RefusesInvalidCalls(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantErr string
+	}{
+		{name: "domain not allowed", args: map[string]any{"url": "https://other.test/"}, wantErr: "step 1: requests to other.test are not allowed"},
+		{name: "missing url", args: map[string]any{}, wantErr: "step 1: a valid url is required"},
+		{name: "unsupported scheme", args: map[string]any{"url": "file:///etc/passwd"}, wantErr: `unsupported URL scheme "file"`},
+		{
+			name:    "later navigation not allowed",
+			args:    map[string]any{"url": "https://example.com/", "actions": []any{map[string]any{"action": "navigate", "url": "http://169.254.169.254/"}}},
+			wantErr: "step 2: requests to 169.254.169.254 are not allowed",
+		},
+		{
+			name:    "unknown action",
+			args:    map[string]any{"url": "https://example.com/", "actions": []any{map[string]any{"action": "download"}}},
+			wantErr: "step 2: action must be one of",
+		},
+		{
+			name:    "missing selector",
+			args:    map[string]any{"url": "https://example.com/", "actions": []any{map[string]any{"action": "click"}}},
+			wantErr: "step 2: click requires a selector",
+		},
+		{
+			name: "too many steps",
+			args: map[string]any{"url": "https://example.com/", "actions": []any{
+				map[string]any{"action": "screenshot"},
+				map[string]any{"action": "screenshot"},
+				map[string]any{"action": "screenshot"},
+				map[string]any{"action": "screenshot"},
+			}},
+			wantErr: "a call runs at most 4 steps including the first navigation, got 5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			browser := &fakeBrowser{}
+			_, err := browseWeb(t, context.Background(), browser, tt.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Zero(t, browser.sessions, "the browser is not started")
+		})
+	}
+}
+
+func TestCheckBrowserRequest(t *testing.T) {
+	policy := &httpRequestPolicy{allowedDomains: []string{"example.com"}}
+
+	assert.NoError(t, checkBrowserRequest("https://www.example.com/app.js", policy.checkURL))
+	assert.NoError(t, checkBrowserRequest("data:image/png;base64,AAAA", policy.checkURL))
+	assert.Error(t, checkBrowserRequest("https://tracker.test/pixel.gif", policy.checkURL))
+	assert.Error(t, checkBrowserRequest("file:///etc/passwd", policy.checkURL))
+}
+
+func TestNewDefaultToolBox_BrowseWebDisabledByDefault(t *testing.T) {
+	assert.False(t, NewDefaultToolBox(&config.ToolBoxConfig{}).HasTool("browse_web"))
+
+	cfg := &config.ToolBoxConfig{SkillsConfig: config.SkillsConfig{Browser: config.BrowserConfig{Enable: true}}}
+	assert.True(t, NewDefaultToolBox(cfg).HasTool("browse_web"))
+}
@@ -0,0 +1,257 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// Languages of the execute_code tool
+const (
+	CodeLanguagePython = "python"
+	CodeLanguageShell  = "shell"
+)
+
+const (
+	defaultCodeExecutionMaxOutputSize = 64 << 10
+	defaultCodeExecutionMaxFileSize   = 10 << 20
+)
+
+// CodeSandbox runs code in isolation from the agent, such as in an ephemeral container
+type CodeSandbox interface {
+	// Run runs the code with the input files in its working directory and returns its output
+	// with the files it wrote to the output directory
+	Run(ctx context.Context, run SandboxRun) (*SandboxResult, error)
+}
+
+// SandboxRun is code to run in a sandbox
+type SandboxRun struct {
+	// Language is the language of the code, CodeLanguagePython or CodeLanguageShell
+	Language string
+
+	// Code is the source code of the program
+	Code string
+
+	// Files are the input files placed in the working directory, by name
+	Files map[string][]byte
+}
+
+// SandboxResult is the outcome of a sandbox run
+type SandboxResult struct {
+	ExitCode int
+	Stdout   string
+	Stderr   string
+
+	// TimedOut reports whether the run was killed at the time limit
+	TimedOut bool
+
+	// Files are the files the code wrote to the output directory, by name
+	Files map[string][]byte
+}
+
+// NewExecuteCodeTool creates the execute_code tool, which runs code in the sandbox with the files
+// of the task it names as input, and adds the files the code writes to the output directory to
+// the task as artifacts. Use NewDockerSandbox for ephemeral containers.
+func NewExecuteCodeTool(cfg config.CodeExecutionConfig, sandbox CodeSandbox) *BasicTool {
+	execution := newCodeExecution(cfg, sandbox)
+
+	return NewBasicTool(
+		"execute_code",
+		"Run a program in an isolated sandbox without access to the agent and return its exit code, stdout and stderr. Use it for calculations and data analysis. The files named in files are copied to the working directory, and files the program writes to the output directory are attached to the task for the user to download. Nothing persists between calls.",
+		map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"language": map[string]any{
+					"type":        "string",
+					"description": "Language of the code",
+					"enum":        execution.languages,
+				},
+				"code": map[string]any{
+					"type":        "string",
+					"description": fmt.Sprintf("Source code of the program. It runs in %s, and writes files for the user to %s.", sandboxWorkDir, sandboxOutputDir),
+				},
+				"files": map[string]any{
+					"type":        "array",
+					"description": "Names of files of the conversation, from user uploads and earlier artifacts, to copy to the working directory",
+					"items":       map[string]any{"type": "string"},
+				},
+			},
+			"required": []string{"language", "code"},
+		},
+		func(ctx context.Context, args map[string]any) (string, error) {
+			return execution.execute(ctx, args)
+		},
+	)
+}
+
+// codeExecution enforces the languages and file limits of the execute_code tool
+type codeExecution struct {
+	sandbox       CodeSandbox
+	languages     []string
+	maxOutputSize int
+	maxFileSize   int64
+}
+
+func newCodeExecution(cfg config.CodeExecutionConfig, sandbox CodeSandbox) *codeExecution {
+	execution := &codeExecution{
+		sandbox:       sandbox,
+		maxOutputSize: int(cfg.MaxOutputSize),
+		maxFileSize:   cfg.MaxFileSize,
+	}
+	for _, language := range cfg.Languages {
+		language = strings.ToLower(strings.TrimSpace(language))
+		if _, supported := sandboxCommands[language]; supported && !slices.Contains(execution.languages, language) {
+			execution.languages = append(execution.languages, language)
+		}
+	}
+	if len(execution.languages) == 0 {
+		execution.languages = []string{CodeLanguagePython}
+	}
+	if execution.maxOutputSize <= 0 {
+		execution.maxOutputSize = defaultCodeExecutionMaxOutputSize
+	}
+	if execution.maxFileSize <= 0 {
+		execution.maxFileSize = defaultCodeExecutionMaxFileSize
+	}
+	return execution
+}
+
+// execute runs the code of the tool arguments and describes the outcome
+func (e *codeExecution) execute(ctx context.Context, args map[string]any) (string, error) {
+	language, _ := args["language"].(string)
+	if !slices.Contains(e.languages, language) {
+		return "", fmt.Errorf("language must be one of %s", strings.Join(e.languages, ", "))
+	}
+	code, _ := args["code"].(string)
+	if strings.TrimSpace(code) == "" {
+		return "", fmt.Errorf("code is required")
+	}
+
+	run := SandboxRun{Language: language, Code: code, Files: make(map[string][]byte)}
+	if names, ok := args["files"].([]any); ok && len(names) > 0 {
+		available, err := e.taskFiles(ctx)
+		if err != nil {
+			return "", err
+		}
+		for _, value := range names {
+			name, _ := value.(string)
+			file, exists := available[name]
+			if !exists {
+				return "", fmt.Errorf("file %q not found, the available files are: %s", name, strings.Join(slices.Sorted(maps.Keys(available)), ", "))
+			}
+			data, err := file(ctx)
+			if err != nil {
+				return "", fmt.Errorf("failed to read file %s: %w", name, err)
+			}
+			run.Files[path.Base(name)] = data
+		}
+	}
+
+	result, err := e.sandbox.Run(ctx, run)
+	if err != nil {
+		return "", fmt.Errorf("failed to run code: %w", err)
+	}
+
+	stdout, stdoutTruncated := truncateOutput(result.Stdout, e.maxOutputSize)
+	stderr, stderrTruncated := truncateOutput(result.Stderr, e.maxOutputSize)
+	response := map[string]any{
+		"exit_code": result.ExitCode,
+		"stdout":    stdout,
+		"stderr":    stderr,
+	}
+	if stdoutTruncated || stderrTruncated {
+		response["truncated"] = true
+	}
+	if result.TimedOut {
+		response["timed_out"] = true
+	}
+	if len(result.Files) > 0 {
+		files, err := attachToolFiles(ctx, "execute_code", result.Files)
+		if err != nil {
+			return "", err
+		}
+		response["files"] = files
+	}
+	return JSONTool(response)
+}
+
+// sandboxFile reads the content of an input file
+type sandboxFile func(ctx context.Context) ([]byte, error)
+
+// taskFiles returns the named files the code can be given: the file parts of the user messages
+// and the artifacts of the task, later files replacing earlier ones of the same name. Files
+// stored by the artifact service are read from it.
+func (e *codeExecution) taskFiles(ctx context.Context) (map[string]sandboxFile, error) {
+	task, ok := ctx.Value(TaskContextKey).(*types.Task)
+	if !ok || task == nil {
+		return nil, fmt.Errorf("task not found in context")
+	}
+	artifactService, _ := ctx.Value(ArtifactServiceContextKey).(ArtifactService)
+
+	files := make(map[string]sandboxFile)
+	add := func(artifactID string, part types.Part) {
+		if part.File == nil || part.File.Name == "" {
+			return
+		}
+		name := part.File.Name
+		switch {
+		case part.File.FileWithBytes != nil:
+			encoded := *part.File.FileWithBytes
+			files[name] = func(context.Context) ([]byte, error) {
+				return e.readLimited(base64.NewDecoder(base64.StdEncoding, strings.NewReader(encoded)))
+			}
+		case part.File.FileWithURI != nil && artifactID != "" && artifactService != nil:
+			files[name] = func(ctx context.Context) ([]byte, error) {
+				reader, err := artifactService.Retrieve(ctx, task.ContextID, artifactID, name)
+				if err != nil {
+					return nil, err
+				}
+				defer func() { _ = reader.Close() }()
+				return e.readLimited(reader)
+			}
+		}
+	}
+
+	for _, message := range task.History {
+		if message.Role != types.RoleUser {
+			continue
+		}
+		for _, part := range message.Parts {
+			add("", part)
+		}
+	}
+	for _, artifact := range task.Artifacts {
+		for _, part := range artifact.Parts {
+			add(artifact.ArtifactID, part)
+		}
+	}
+	return files, nil
+}
+
+// readLimited reads a file, failing when it exceeds the file size limit
+func (e *codeExecution) readLimited(reader io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, e.maxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > e.maxFileSize {
+		return nil, fmt.Errorf("file exceeds %d bytes", e.maxFileSize)
+	}
+	return data, nil
+}
+
+// truncateOutput cuts output to the size limit
+func truncateOutput(output string, limit int) (string, bool) {
+	if len(output) <= limit {
+		return output, false
+	}
+	return output[:limit], true
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// fakeSandbox records the runs it is given and answers them with a fixed result
+type fakeSandbox struct {
+	runs   []SandboxRun
+	result SandboxResult
+}
+
+func (s *fakeSandbox) Run(ctx context.Context, run SandboxRun) (*SandboxResult, error) {
+	s.runs = append(s.runs, run)
+	result := s.result
+	return &result, nil
+}
+
+func executeCode(t *testing.T, ctx context.Context, sandbox CodeSandbox, args map[string]any) (map[string]any, error) {
+	t.Helper()
+	cfg := config.CodeExecutionConfig{Languages: []string{"python", "shell", "cobol"}, MaxOutputSize: 8}
+	result, err := NewExecuteCodeTool(cfg, sandbox).Execute(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result), &decoded))
+	return decoded, nil
+}
+
+func TestExecuteCodeTool_RunsCodeWithTaskFiles(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString([]byte("a,b\n1,2\n"))
+	task := &types.Task{
+		ID:        "task-1",
+		ContextID: "ctx-1",
+		History: []types.Message{{
+			MessageID: "m1",
+			Role:      types.RoleUser,
+			Parts:     []types.Part{types.CreateFilePart("data.csv", "text/csv", &encoded, nil)},
+		}},
+	}
+	ctx := context.WithValue(context.Background(), TaskContextKey, task)
+	sandbox := &fakeSandbox{result: SandboxResult{
+		Stdout: "mean is 1.5, median is 1.5",
+		Files:  map[string][]byte{"chart.png": []byte("png")},
+	}}
+
+	result, err := executeCode(t, ctx, sandbox, map[string]any{
+		"language": "python",
+		"code":     "import pandas",
+		"files":    []any{"data.csv"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, sandbox.runs, 1)
+	assert.Equal(t, "python", sandbox.runs[0].Language)
+	assert.Equal(t, map[string][]byte{"data.csv": []byte("a,b\n1,2\n")}, sandbox.runs[0].Files)
+
+	assert.Equal(t, float64(0), result["exit_code"])
+	assert.Equal(t, "mean is ", result["stdout"])
+	assert.Equal(t, true, result["truncated"])
+
+	require.Len(t, task.Artifacts, 1)
+	artifact := task.Artifacts[0]
+	require.NotNil(t, artifact.Parts[0].File)
+	assert.Equal(t, "chart.png", artifact.Parts[0].File.Name)
+	assert.Equal(t, "image/png", artifact.Parts[0].File.MediaType)
+	files := result["files"].([]any)
+	require.Len(t, files, 1)
+	assert.Equal(t, artifact.ArtifactID, files[0].(map[string]any)["artifact_id"])
+}
+
+func TestExecuteCodeTool_RefusesInvalidCalls(t *testing.T) {
+	ctx := context.WithValue(context.Background(), TaskContextKey, &types.Task{ID: "task-1"})
+
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantErr string
+	}{
+		{name: "unsupported language", args: map[string]any{"language": "cobol", "code": "DISPLAY 'HI'"}, wantErr: "language must be one of python, shell"},
+		{name: "missing code", args: map[string]any{"language": "shell"}, wantErr: "code is required"},
+		{name: "unknown file", args: map[string]any{"language": "shell", "code": "cat x", "files": []any{"x"}}, wantErr: `file "x" not found`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sandbox := &fakeSandbox{}
+			_, err := executeCode(t, ctx, sandbox, tt.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Empty(t, sandbox.runs)
+		})
+	}
+}
+
+func TestNewDefaultToolBox_ExecuteCodeDisabledByDefault(t *testing.T) {
+	assert.False(t, NewDefaultToolBox(&config.ToolBoxConfig{}).HasTool("execute_code"))
+
+	cfg := &config.ToolBoxConfig{SkillsConfig: config.SkillsConfig{CodeExecution: config.CodeExecutionConfig{Enable: true}}}
+	assert.True(t, NewDefaultToolBox(cfg).HasTool("execute_code"))
+}
@@ -0,0 +1,224 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+const (
+	defaultCommandTimeout       = 30 * time.Second
+	defaultCommandMaxOutputSize = 64 << 10
+	defaultCommandMaxFileSize   = 10 << 20
+
+	// commandWaitDelay bounds how long a killed command may keep its output open, such as
+	// through a child process it started
+	commandWaitDelay = time.Second
+)
+
+// NewRunCommandTool creates the run_command tool, which runs one of the allowlisted binaries
+// with the arguments of the call, without a shell, in the working directory of the policy.
+// Commands get a minimal environment, so the secrets of the server are not passed on. Output
+// longer than the limit returned to the agent is attached to the task as an artifact.
+func NewRunCommandTool(cfg config.CommandConfig) *BasicTool {
+	policy := newCommandPolicy(cfg)
+	command := map[string]any{
+		"type":        "string",
+		"description": "Binary to run",
+	}
+	if len(policy.binaries) > 0 {
+		command["enum"] = policy.binaries
+	}
+
+	return NewBasicTool(
+		"run_command",
+		fmt.Sprintf("Run a command and return its exit code, stdout and stderr. The command is run directly, not through a shell, so pipes, redirects and variables are not available. Only these binaries can be run: %s.", strings.Join(policy.binaries, ", ")),
+		map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"command": command,
+				"args": map[string]any{
+					"type":        "array",
+					"description": "Arguments passed to the binary",
+					"items":       map[string]any{"type": "string"},
+				},
+				"dir": map[string]any{
+					"type":        "string",
+					"description": "Subdirectory of the working directory to run the command in, as a relative path",
+				},
+			},
+			"required": []string{"command"},
+		},
+		func(ctx context.Context, args map[string]any) (string, error) {
+			return policy.execute(ctx, args)
+		},
+	)
+}
+
+// commandPolicy enforces the binaries, working directory, time and output limits of the
+// run_command tool
+type commandPolicy struct {
+	binaries      []string
+	workDir       string
+	timeout       time.Duration
+	maxOutputSize int
+	maxFileSize   int
+}
+
+func newCommandPolicy(cfg config.CommandConfig) *commandPolicy {
+	policy := &commandPolicy{
+		workDir:       cfg.WorkDir,
+		timeout:       cfg.Timeout,
+		maxOutputSize: int(cfg.MaxOutputSize),
+		maxFileSize:   int(cfg.MaxFileSize),
+	}
+	for _, binary := range cfg.AllowedBinaries {
+		binary = strings.TrimSpace(binary)
+		if binary != "" && !slices.Contains(policy.binaries, binary) {
+			policy.binaries = append(policy.binaries, binary)
+		}
+	}
+	if policy.timeout <= 0 {
+		policy.timeout = defaultCommandTimeout
+	}
+	if policy.maxOutputSize <= 0 {
+		policy.maxOutputSize = defaultCommandMaxOutputSize
+	}
+	if policy.maxFileSize <= 0 {
+		policy.maxFileSize = defaultCommandMaxFileSize
+	}
+	policy.maxFileSize = max(policy.maxFileSize, policy.maxOutputSize)
+	return policy
+}
+
+// binary resolves an allowlisted binary to the path it is run from. Binaries named without a
+// path are looked up on the PATH of the server.
+func (p *commandPolicy) binary(command string) (string, error) {
+	if command == "" {
+		return "", fmt.Errorf("command is required")
+	}
+	if !slices.Contains(p.binaries, command) {
+		if len(p.binaries) == 0 {
+			return "", fmt.Errorf("command %q is not allowed, no binaries are allowed", command)
+		}
+		return "", fmt.Errorf("command %q is not allowed, the allowed binaries are: %s", command, strings.Join(p.binaries, ", "))
+	}
+	if filepath.IsAbs(command) {
+		return command, nil
+	}
+	if strings.ContainsRune(command, filepath.Separator) {
+		return "", fmt.Errorf("command %q must be a name or an absolute path", command)
+	}
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return "", fmt.Errorf("command %q not found: %w", command, err)
+	}
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("command %q resolves to the relative path %s", command, path)
+	}
+	return path, nil
+}
+
+// dir resolves the directory a command runs in, refusing directories outside the working
+// directory, including through symbolic links
+func (p *commandPolicy) dir(root, dir string) (string, error) {
+	if dir == "" {
+		return root, nil
+	}
+	if filepath.IsAbs(dir) {
+		return "", fmt.Errorf("dir %q must be relative to the working directory", dir)
+	}
+	root, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the working directory: %w", err)
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Join(root, dir))
+	if err != nil {
+		return "", fmt.Errorf("dir %q not found", dir)
+	}
+	if rel, err := filepath.Rel(root, resolved); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("dir %q is outside the working directory", dir)
+	}
+	return resolved, nil
+}
+
+// execute runs the command of the tool arguments and describes the outcome
+func (p *commandPolicy) execute(ctx context.Context, args map[string]any) (string, error) {
+	command, _ := args["command"].(string)
+	binary, err := p.binary(command)
+	if err != nil {
+		return "", err
+	}
+	var commandArgs []string
+	if values, ok := args["args"].([]any); ok {
+		for _, value := range values {
+			arg, ok := value.(string)
+			if !ok {
+				return "", fmt.Errorf("args must be strings")
+			}
+			commandArgs = append(commandArgs, arg)
+		}
+	}
+
+	root := p.workDir
+	if root == "" {
+		root, err = os.MkdirTemp("", "run-command-")
+		if err != nil {
+			return "", fmt.Errorf("failed to create working directory: %w", err)
+		}
+		defer func() { _ = os.RemoveAll(root) }()
+	}
+	dir, _ := args["dir"].(string)
+	workDir, err := p.dir(root, dir)
+	if err != nil {
+		return "", err
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	stdout := &cappedBuffer{limit: p.maxFileSize}
+	stderr := &cappedBuffer{limit: p.maxFileSize}
+	cmd := exec.CommandContext(runCtx, binary, commandArgs...)
+	cmd.Dir = workDir
+	cmd.Env = []string{"PATH=" + os.Getenv("PATH"), "HOME=" + workDir, "LANG=C.UTF-8"}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = commandWaitDelay
+
+	if err := cmd.Run(); err != nil && cmd.ProcessState == nil {
+		return "", fmt.Errorf("failed to run command: %w", err)
+	}
+
+	response := map[string]any{"exit_code": cmd.ProcessState.ExitCode()}
+	if errors.Is(runCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		response["timed_out"] = true
+	}
+	files := make(map[string][]byte)
+	for name, output := range map[string]*cappedBuffer{"stdout": stdout, "stderr": stderr} {
+		text, truncated := truncateOutput(output.String(), p.maxOutputSize)
+		response[name] = text
+		if truncated || output.cut {
+			response["truncated"] = true
+		}
+		if truncated {
+			files[name+".txt"] = output.Bytes()
+		}
+	}
+	if len(files) > 0 {
+		attached, err := attachToolFiles(ctx, "run_command", files)
+		if err != nil {
+			return "", err
+		}
+		response["files"] = attached
+	}
+	return JSONTool(response)
+}
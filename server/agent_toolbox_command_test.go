@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func runCommand(t *testing.T, ctx context.Context, cfg config.CommandConfig, args map[string]any) (map[string]any, error) {
+	t.Helper()
+	result, err := NewRunCommandTool(cfg).Execute(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result), &decoded))
+	return decoded, nil
+}
+
+func TestRunCommandTool_RunsAllowedBinaries(t *testing.T) {
+	workDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(workDir, "data"), 0o755))
+	resolved, err := filepath.EvalSymlinks(filepath.Join(workDir, "data"))
+	require.NoError(t, err)
+	t.Setenv("OPENAI_API_KEY", "sk-secret")
+	cfg := config.CommandConfig{AllowedBinaries: []string{"pwd", "env", "false"}, WorkDir: workDir}
+
+	result, err := runCommand(t, context.Background(), cfg, map[string]any{"command": "pwd", "dir": "data"})
+	require.NoError(t, err)
+	assert.Equal(t, float64(0), result["exit_code"])
+	assert.Equal(t, resolved+"\n", result["stdout"])
+
+	result, err = runCommand(t, context.Background(), cfg, map[string]any{"command": "env"})
+	require.NoError(t, err)
+	assert.NotContains(t, result["stdout"], "sk-secret", "the environment of the server is not passed on")
+
+	result, err = runCommand(t, context.Background(), cfg, map[string]any{"command": "false"})
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), result["exit_code"])
+}
+
+func TestRunCommandTool_RefusesCommandsOutsideThePolicy(t *testing.T) {
+	workDir := t.TempDir()
+	require.NoError(t, os.Symlink(os.TempDir(), filepath.Join(workDir, "escape")))
+	cfg := config.CommandConfig{AllowedBinaries: []string{"ls"}, WorkDir: workDir}
+
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantErr string
+	}{
+		{name: "missing command", args: map[string]any{}, wantErr: "command is required"},
+		{name: "binary outside the allowlist", args: map[string]any{"command": "rm", "args": []any{"-rf", "/"}}, wantErr: `command "rm" is not allowed, the allowed binaries are: ls`},
+		{name: "path of an allowed binary", args: map[string]any{"command": "/bin/ls"}, wantErr: `command "/bin/ls" is not allowed`},
+		{name: "parent directory", args: map[string]any{"command": "ls", "dir": "../"}, wantErr: `dir "../" is outside the working directory`},
+		{name: "absolute directory", args: map[string]any{"command": "ls", "dir": "/etc"}, wantErr: `dir "/etc" must be relative to the working directory`},
+		{name: "symbolic link out of the working directory", args: map[string]any{"command": "ls", "dir": "escape"}, wantErr: `dir "escape" is outside the working directory`},
+		{name: "arguments that are not strings", args: map[string]any{"command": "ls", "args": []any{1}}, wantErr: "args must be strings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runCommand(t, context.Background(), cfg, tt.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	_, err := runCommand(t, context.Background(), config.CommandConfig{}, map[string]any{"command": "ls"})
+	assert.EqualError(t, err, `command "ls" is not allowed, no binaries are allowed`)
+}
+
+func TestRunCommandTool_KillsCommandsAtTheTimeout(t *testing.T) {
+	cfg := config.CommandConfig{AllowedBinaries: []string{"sleep"}, Timeout: 100 * time.Millisecond}
+
+	start := time.Now()
+	result, err := runCommand(t, context.Background(), cfg, map[string]any{"command": "sleep", "args": []any{"10"}})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, true, result["timed_out"])
+	assert.NotEqual(t, float64(0), result["exit_code"])
+}
+
+func TestRunCommandTool_AttachesLargeOutput(t *testing.T) {
+	task := &types.Task{ID: "task-1", ContextID: "ctx-1"}
+	ctx := context.WithValue(context.Background(), TaskContextKey, task)
+	cfg := config.CommandConfig{AllowedBinaries: []string{"seq"}, MaxOutputSize: 8, MaxFileSize: 64}
+
+	result, err := runCommand(t, ctx, cfg, map[string]any{"command": "seq", "args": []any{"1", "10000"}})
+	require.NoError(t, err)
+	assert.Equal(t, "1\n2\n3\n4\n", result["stdout"])
+	assert.Equal(t, true, result["truncated"])
+
+	files := result["files"].([]any)
+	require.Len(t, files, 1)
+	assert.Equal(t, "stdout.txt", files[0].(map[string]any)["filename"])
+	require.Len(t, task.Artifacts, 1)
+	part := task.Artifacts[0].Parts[0]
+	require.NotNil(t, part.File)
+	require.NotNil(t, part.File.FileWithBytes)
+	stored, err := base64.StdEncoding.DecodeString(*part.File.FileWithBytes)
+	require.NoError(t, err)
+	assert.Equal(t, 64, len(stored), "output past the file size limit is cut")
+	assert.Equal(t, task.Artifacts[0].ArtifactID, files[0].(map[string]any)["artifact_id"])
+}
+
+func TestNewDefaultToolBox_RunCommandDisabledByDefault(t *testing.T) {
+	assert.False(t, NewDefaultToolBox(&config.ToolBoxConfig{}).HasTool("run_command"))
+
+	cfg := &config.ToolBoxConfig{SkillsConfig: config.SkillsConfig{Command: config.CommandConfig{Enable: true}}}
+	assert.True(t, NewDefaultToolBox(cfg).HasTool("run_command"))
+}
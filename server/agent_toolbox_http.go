@@ -0,0 +1,301 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+const (
+	defaultHTTPRequestMaxResponseSize = 1 << 20
+	defaultHTTPRequestTimeout         = 30 * time.Second
+)
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which some clouds serve their
+// metadata service from
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// NewHTTPRequestTool creates the http_request tool, which sends GET and POST requests to the
+// domains allowed by the policy. Secret headers configured for a host are added to the
+// requests sent to it, without the agent ever seeing them. Loopback, private, link-local and
+// cloud metadata addresses are refused unless the policy allows private networks, including
+// when a host name resolves to one of them.
+func NewHTTPRequestTool(cfg config.HTTPRequestToolConfig) *BasicTool {
+	policy := newHTTPRequestPolicy(cfg)
+	client := &http.Client{
+		Timeout:       policy.timeout,
+		Transport:     &secretHeaderTransport{base: policy.transport(), headers: policy.secretHeaders},
+		CheckRedirect: policy.checkRedirect,
+	}
+
+	return NewBasicTool(
+		"http_request",
+		"Send an HTTP GET or POST request to an external API and return the status code, content type and response body. Only some domains may be reachable; requests to other domains are refused.",
+		map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"url": map[string]any{
+					"type":        "string",
+					"description": "Absolute http or https URL to send the request to",
+				},
+				"method": map[string]any{
+					"type":        "string",
+					"description": "HTTP method, defaults to GET",
+					"enum":        []string{http.MethodGet, http.MethodPost},
+				},
+				"headers": map[string]any{
+					"type":                 "object",
+					"description":          "Request headers to send",
+					"additionalProperties": map[string]any{"type": "string"},
+				},
+				"body": map[string]any{
+					"type":        "string",
+					"description": "Request body for POST requests",
+				},
+			},
+			"required": []string{"url"},
+		},
+		func(ctx context.Context, args map[string]any) (string, error) {
+			return policy.execute(ctx, client, args)
+		},
+	)
+}
+
+// httpRequestPolicy enforces the domain, redirect and size limits of the http_request tool
+type httpRequestPolicy struct {
+	allowedDomains  []string
+	deniedDomains   []string
+	maxResponseSize int64
+	maxRedirects    int
+	timeout         time.Duration
+	secretHeaders   map[string]http.Header
+	allowPrivate    bool
+}
+
+func newHTTPRequestPolicy(cfg config.HTTPRequestToolConfig) *httpRequestPolicy {
+	policy := &httpRequestPolicy{
+		allowedDomains:  normalizeDomains(cfg.AllowedDomains),
+		deniedDomains:   normalizeDomains(cfg.DeniedDomains),
+		maxResponseSize: cfg.MaxResponseSize,
+		maxRedirects:    cfg.MaxRedirects,
+		timeout:         cfg.Timeout,
+		secretHeaders:   make(map[string]http.Header),
+		allowPrivate:    cfg.AllowPrivateNetworks,
+	}
+	if policy.maxResponseSize <= 0 {
+		policy.maxResponseSize = defaultHTTPRequestMaxResponseSize
+	}
+	if policy.timeout <= 0 {
+		policy.timeout = defaultHTTPRequestTimeout
+	}
+
+	for host, header := range cfg.SecretHeaders {
+		name, value, ok := strings.Cut(header, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			continue
+		}
+		host = strings.ToLower(strings.TrimSpace(host))
+		if policy.secretHeaders[host] == nil {
+			policy.secretHeaders[host] = make(http.Header)
+		}
+		policy.secretHeaders[host].Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return policy
+}
+
+// normalizeDomains lowercases domains and strips wildcard prefixes, since subdomains always match
+func normalizeDomains(domains []string) []string {
+	normalized := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		domain = strings.TrimPrefix(strings.TrimPrefix(domain, "*"), ".")
+		if domain != "" {
+			normalized = append(normalized, domain)
+		}
+	}
+	return normalized
+}
+
+// matchesDomain checks if a host is the domain or one of its subdomains
+func matchesDomain(host string, domains []string) bool {
+	for _, domain := range domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkURL refuses URLs that are not http or https, or whose host the policy does not allow
+func (p *httpRequestPolicy) checkURL(target *url.URL) error {
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q, only http and https are allowed", target.Scheme)
+	}
+	host := strings.ToLower(target.Hostname())
+	if host == "" {
+		return fmt.Errorf("URL %s has no host", target.Redacted())
+	}
+	if matchesDomain(host, p.deniedDomains) {
+		return fmt.Errorf("requests to %s are not allowed", host)
+	}
+	if len(p.allowedDomains) > 0 && !matchesDomain(host, p.allowedDomains) {
+		return fmt.Errorf("requests to %s are not allowed", host)
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return p.checkAddress(ip)
+	}
+	return nil
+}
+
+// checkAddress refuses addresses inside the network of the server unless the policy allows
+// private networks
+func (p *httpRequestPolicy) checkAddress(ip net.IP) error {
+	if !p.allowPrivate && isPrivateNetworkAddress(ip) {
+		return fmt.Errorf("requests to private network address %s are not allowed", ip)
+	}
+	return nil
+}
+
+// checkDial checks the address a connection is about to be made to, once the host name has
+// been resolved, so a name resolving to a private address is refused as well
+func (p *httpRequestPolicy) checkDial(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("cannot dial unresolved address %s", address)
+	}
+	return p.checkAddress(ip)
+}
+
+// transport returns the transport of the tool, which dials only the addresses the policy
+// allows. Proxies are not used, as the dial check would see the address of the proxy rather
+// than that of the target.
+func (p *httpRequestPolicy) transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   p.checkDial,
+	}).DialContext
+	return transport
+}
+
+// isPrivateNetworkAddress checks if an address is a loopback, private, link-local, shared or
+// unspecified address. Link-local addresses include the cloud metadata service 169.254.169.254.
+func isPrivateNetworkAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || sharedAddressSpace.Contains(ip)
+}
+
+// checkRedirect follows redirects up to the configured limit, as long as every target passes
+// the domain policy. Past the limit the redirect response itself is returned to the agent.
+func (p *httpRequestPolicy) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) > p.maxRedirects {
+		return http.ErrUseLastResponse
+	}
+	if err := p.checkURL(req.URL); err != nil {
+		return fmt.Errorf("redirect refused: %w", err)
+	}
+	return nil
+}
+
+// execute sends the request described by the tool arguments and describes the response
+func (p *httpRequestPolicy) execute(ctx context.Context, client *http.Client, args map[string]any) (string, error) {
+	rawURL, ok := args["url"].(string)
+	if !ok || rawURL == "" {
+		return "", fmt.Errorf("url is required")
+	}
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid url: %w", err)
+	}
+	if err := p.checkURL(target); err != nil {
+		return "", err
+	}
+
+	method := http.MethodGet
+	if m, ok := args["method"].(string); ok && m != "" {
+		method = strings.ToUpper(m)
+	}
+	if method != http.MethodGet && method != http.MethodPost {
+		return "", fmt.Errorf("unsupported method %s, only GET and POST are allowed", method)
+	}
+
+	var body io.Reader
+	if b, ok := args["body"].(string); ok && b != "" {
+		if method != http.MethodPost {
+			return "", fmt.Errorf("a request body is only allowed for POST requests")
+		}
+		body = strings.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	if headers, ok := args["headers"].(map[string]any); ok {
+		for name, value := range headers {
+			if s, ok := value.(string); ok {
+				req.Header.Set(name, s)
+			}
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, p.maxResponseSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	truncated := int64(len(data)) > p.maxResponseSize
+	if truncated {
+		data = data[:p.maxResponseSize]
+	}
+
+	result := map[string]any{
+		"status_code":  resp.StatusCode,
+		"content_type": resp.Header.Get("Content-Type"),
+		"body":         string(data),
+		"truncated":    truncated,
+	}
+	if location := resp.Header.Get("Location"); location != "" && resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		result["location"] = location
+	}
+	return JSONTool(result)
+}
+
+// secretHeaderTransport adds the secret headers configured for a host to each request sent to
+// it, including redirects, without them being copied to requests for other hosts
+type secretHeaderTransport struct {
+	base    http.RoundTripper
+	headers map[string]http.Header
+}
+
+func (t *secretHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	headers := t.headers[strings.ToLower(req.URL.Hostname())]
+	if len(headers) == 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	secured := req.Clone(req.Context())
+	for name, values := range headers {
+		secured.Header[name] = values
+	}
+	return t.base.RoundTrip(secured)
+}
@@ -0,0 +1,231 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+func newEchoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"method":        r.Method,
+			"body":          string(body),
+			"authorization": r.Header.Get("Authorization"),
+			"trace":         r.Header.Get("X-Trace"),
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func executeHTTPRequest(t *testing.T, cfg config.HTTPRequestToolConfig, args map[string]any) (map[string]any, error) {
+	t.Helper()
+	result, err := NewHTTPRequestTool(cfg).Execute(context.Background(), args)
+	if err != nil {
+		return nil, err
+	}
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result), &decoded))
+	return decoded, nil
+}
+
+func echoed(t *testing.T, result map[string]any) map[string]string {
+	t.Helper()
+	var body map[string]string
+	require.NoError(t, json.Unmarshal([]byte(result["body"].(string)), &body))
+	return body
+}
+
+func TestHTTPRequestTool_SendsRequests(t *testing.T) {
+	echo := newEchoServer(t)
+	cfg := config.HTTPRequestToolConfig{
+		AllowedDomains:       []string{"127.0.0.1"},
+		SecretHeaders:        map[string]string{"127.0.0.1": "Authorization=Bearer secret"},
+		AllowPrivateNetworks: true,
+	}
+
+	result, err := executeHTTPRequest(t, cfg, map[string]any{
+		"url":     echo.URL + "/items",
+		"method":  "post",
+		"headers": map[string]any{"X-Trace": "abc", "Authorization": "Bearer spoofed"},
+		"body":    `{"name":"widget"}`,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(http.StatusOK), result["status_code"])
+	assert.Equal(t, "application/json", result["content_type"])
+	assert.Equal(t, false, result["truncated"])
+	assert.Equal(t, map[string]string{
+		"method":        http.MethodPost,
+		"body":          `{"name":"widget"}`,
+		"authorization": "Bearer secret",
+		"trace":         "abc",
+	}, echoed(t, result))
+}
+
+func TestHTTPRequestTool_DomainPolicy(t *testing.T) {
+	echo := newEchoServer(t)
+	localhostURL := strings.Replace(echo.URL, "127.0.0.1", "localhost", 1)
+
+	tests := []struct {
+		name     string
+		cfg      config.HTTPRequestToolConfig
+		url      string
+		errorMsg string
+	}{
+		{
+			name: "no allowlist allows any domain",
+			cfg:  config.HTTPRequestToolConfig{AllowPrivateNetworks: true},
+			url:  echo.URL,
+		},
+		{
+			name:     "domain outside allowlist",
+			cfg:      config.HTTPRequestToolConfig{AllowedDomains: []string{"127.0.0.1"}},
+			url:      localhostURL,
+			errorMsg: "requests to localhost are not allowed",
+		},
+		{
+			name:     "denylist wins over allowlist",
+			cfg:      config.HTTPRequestToolConfig{AllowedDomains: []string{"localhost"}, DeniedDomains: []string{"localhost"}},
+			url:      localhostURL,
+			errorMsg: "requests to localhost are not allowed",
+		},
+		{
+			name:     "subdomains match",
+			cfg:      config.HTTPRequestToolConfig{DeniedDomains: []string{"*.example.com"}},
+			url:      "https://api.example.com/v1",
+			errorMsg: "requests to api.example.com are not allowed",
+		},
+		{
+			name:     "unsupported scheme",
+			url:      "file:///etc/passwd",
+			errorMsg: `unsupported URL scheme "file", only http and https are allowed`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := executeHTTPRequest(t, tt.cfg, map[string]any{"url": tt.url})
+			if tt.errorMsg != "" {
+				require.EqualError(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestHTTPRequestTool_TruncatesLargeResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("0123456789"))
+	}))
+	defer server.Close()
+
+	result, err := executeHTTPRequest(t, config.HTTPRequestToolConfig{MaxResponseSize: 4, AllowPrivateNetworks: true}, map[string]any{"url": server.URL})
+	require.NoError(t, err)
+
+	assert.Equal(t, "0123", result["body"])
+	assert.Equal(t, true, result["truncated"])
+}
+
+func TestHTTPRequestTool_Redirects(t *testing.T) {
+	echo := newEchoServer(t)
+	target, err := url.Parse(echo.URL)
+	require.NoError(t, err)
+	target.Host = "localhost:" + target.Port()
+
+	redirect := httptest.NewServer(http.RedirectHandler(target.String(), http.StatusFound))
+	defer redirect.Close()
+
+	secrets := map[string]string{"127.0.0.1": "Authorization=Bearer secret"}
+
+	t.Run("follows allowed redirects without leaking secret headers", func(t *testing.T) {
+		result, err := executeHTTPRequest(t, config.HTTPRequestToolConfig{MaxRedirects: 1, SecretHeaders: secrets, AllowPrivateNetworks: true}, map[string]any{"url": redirect.URL})
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(http.StatusOK), result["status_code"])
+		assert.Empty(t, echoed(t, result)["authorization"])
+	})
+
+	t.Run("refuses redirects to domains outside the allowlist", func(t *testing.T) {
+		_, err := executeHTTPRequest(t, config.HTTPRequestToolConfig{MaxRedirects: 1, AllowedDomains: []string{"127.0.0.1"}, AllowPrivateNetworks: true}, map[string]any{"url": redirect.URL})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "redirect refused: requests to localhost are not allowed")
+	})
+
+	t.Run("returns the redirect when redirects are disabled", func(t *testing.T) {
+		result, err := executeHTTPRequest(t, config.HTTPRequestToolConfig{AllowPrivateNetworks: true}, map[string]any{"url": redirect.URL})
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(http.StatusFound), result["status_code"])
+		assert.Equal(t, target.String(), result["location"])
+	})
+}
+
+func TestHTTPRequestTool_PrivateNetworks(t *testing.T) {
+	echo := newEchoServer(t)
+	target, err := url.Parse(echo.URL)
+	require.NoError(t, err)
+	localhostURL := "http://localhost:" + target.Port()
+
+	t.Run("refuses private addresses by default", func(t *testing.T) {
+		for _, rawURL := range []string{
+			echo.URL,
+			"http://169.254.169.254/latest/meta-data/",
+			"http://10.0.0.8:8080/admin",
+			"http://192.168.1.1/",
+			"http://[::1]:8080/",
+			"http://[fd00:ec2::254]/",
+			"http://100.100.100.200/latest/meta-data/",
+			"http://0.0.0.0:8080/",
+		} {
+			_, err := executeHTTPRequest(t, config.HTTPRequestToolConfig{}, map[string]any{"url": rawURL})
+			require.Error(t, err, rawURL)
+			assert.Contains(t, err.Error(), "requests to private network address", rawURL)
+		}
+	})
+
+	t.Run("refuses host names resolving to private addresses", func(t *testing.T) {
+		_, err := executeHTTPRequest(t, config.HTTPRequestToolConfig{}, map[string]any{"url": localhostURL})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requests to private network address")
+	})
+
+	t.Run("refuses redirects to private addresses", func(t *testing.T) {
+		policy := newHTTPRequestPolicy(config.HTTPRequestToolConfig{MaxRedirects: 1})
+		req := httptest.NewRequest(http.MethodGet, "http://169.254.169.254/latest/meta-data/", nil)
+		assert.ErrorContains(t, policy.checkRedirect(req, []*http.Request{{}}), "redirect refused: requests to private network address 169.254.169.254")
+	})
+
+	t.Run("allows private addresses when configured", func(t *testing.T) {
+		result, err := executeHTTPRequest(t, config.HTTPRequestToolConfig{AllowPrivateNetworks: true}, map[string]any{"url": localhostURL})
+		require.NoError(t, err)
+		assert.Equal(t, float64(http.StatusOK), result["status_code"])
+	})
+
+	assert.False(t, isPrivateNetworkAddress(net.ParseIP("93.184.216.34")))
+	assert.False(t, isPrivateNetworkAddress(net.ParseIP("2606:4700::1111")))
+	assert.True(t, isPrivateNetworkAddress(net.ParseIP("::ffff:127.0.0.1")))
+}
+
+func TestNewDefaultToolBox_WithHTTPRequest(t *testing.T) {
+	toolBox := NewDefaultToolBox(&config.ToolBoxConfig{EnableHTTPRequest: true})
+
+	assert.True(t, toolBox.HasTool("http_request"))
+	assert.False(t, NewDefaultToolBox(nil).HasTool("http_request"))
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+// NewRedactPIITool creates the redact_pii tool, which masks the personal data in a text with
+// the redactor, so the agent can pass the text on, such as to another tool or agent, without
+// it. The agent may limit the masked entities to some of those of the redactor.
+func NewRedactPIITool(redactor *Redactor) *BasicTool {
+	return NewBasicTool(
+		"redact_pii",
+		"Mask personal data in a text, such as email addresses, phone numbers and card numbers, replacing each occurrence with a placeholder like [EMAIL]. Use it before storing or forwarding text that may contain personal data. Returns the redacted text and the kinds of personal data masked.",
+		map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"text": map[string]any{
+					"type":        "string",
+					"description": "The text to redact",
+				},
+				"entities": map[string]any{
+					"type":        "array",
+					"description": "Kinds of personal data to mask, all supported kinds when omitted",
+					"items": map[string]any{
+						"type": "string",
+						"enum": config.PIIEntities,
+					},
+				},
+			},
+			"required": []string{"text"},
+		},
+		func(ctx context.Context, args map[string]any) (string, error) {
+			text, ok := args["text"].(string)
+			if !ok {
+				return "", fmt.Errorf("text is required")
+			}
+
+			selected := redactor
+			if requested, ok := args["entities"].([]any); ok && len(requested) > 0 {
+				var entities []string
+				for _, value := range requested {
+					entity, _ := value.(string)
+					if len(redactor.entities) == 0 || slices.Contains(redactor.entities, entity) {
+						entities = append(entities, entity)
+					}
+				}
+				if len(entities) == 0 {
+					return JSONTool(map[string]any{"text": text, "entities": []string{}})
+				}
+				selected = &Redactor{entities: entities, detectors: redactor.detectors}
+			}
+
+			redacted, entities, err := selected.Redact(ctx, text)
+			if err != nil {
+				return "", fmt.Errorf("failed to redact text: %w", err)
+			}
+			if entities == nil {
+				entities = []string{}
+			}
+			return JSONTool(map[string]any{"text": redacted, "entities": entities})
+		},
+	)
+}
@@ -152,6 +152,25 @@ func TestDefaultToolBox_WithCreateArtifactAdded(t *testing.T) {
 	}
 }
 
+func TestDefaultToolBox_RequireApproval(t *testing.T) {
+	toolBox := NewDefaultToolBox(&config.ToolBoxConfig{
+		RequireApproval: []string{"write_file"},
+	})
+	toolBox.RequireApproval("run_shell")
+
+	if !toolBox.RequiresApproval("write_file") {
+		t.Error("Expected write_file configured in ToolBoxConfig to require approval")
+	}
+
+	if !toolBox.RequiresApproval("run_shell") {
+		t.Error("Expected run_shell to require approval after RequireApproval")
+	}
+
+	if toolBox.RequiresApproval("input_required") {
+		t.Error("Expected input_required not to require approval")
+	}
+}
+
 func TestNewDefaultToolBox_DefaultBehavior(t *testing.T) {
 	toolBox := NewDefaultToolBox(nil)
 
@@ -0,0 +1,250 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NewTypedTool creates a tool whose arguments are decoded into TArgs, a struct describing
+// the tool parameters. The JSON schema sent to the LLM is derived from the struct fields:
+//
+//   - the `json` tag names the parameter; fields tagged `json:"-"` are skipped
+//   - fields are required unless they are pointers or tagged `omitempty`
+//   - the `description` tag describes the parameter
+//   - the `enum` tag lists the comma-separated values the parameter accepts
+//
+// Arguments are validated against the schema before the executor runs. Invalid arguments
+// are returned as an error describing every problem, so the LLM can correct the call.
+// NewTypedTool panics when TArgs is not a struct.
+func NewTypedTool[TArgs any](
+	name string,
+	description string,
+	executor func(ctx context.Context, args TArgs) (string, error),
+) *BasicTool {
+	argsType := reflect.TypeFor[TArgs]()
+	if argsType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("typed tool %s: arguments must be a struct, got %s", name, argsType))
+	}
+	parameters := schemaForType(argsType)
+
+	return NewBasicTool(name, description, parameters, func(ctx context.Context, arguments map[string]any) (string, error) {
+		if arguments == nil {
+			arguments = map[string]any{}
+		}
+		data, err := json.Marshal(arguments)
+		if err != nil {
+			return "", fmt.Errorf("invalid arguments for tool %s: %w", name, err)
+		}
+
+		var decoded any
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return "", fmt.Errorf("invalid arguments for tool %s: %w", name, err)
+		}
+		if problems := validateAgainstSchema(parameters, decoded, ""); len(problems) > 0 {
+			return "", fmt.Errorf("invalid arguments for tool %s: %s", name, strings.Join(problems, "; "))
+		}
+
+		var args TArgs
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&args); err != nil {
+			return "", fmt.Errorf("invalid arguments for tool %s: %w", name, err)
+		}
+
+		return executor(ctx, args)
+	})
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// schemaForType derives the JSON schema of a Go type
+func schemaForType(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": "array", "items": schemaForType(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaForType(t.Elem())}
+	case reflect.Struct:
+		properties := map[string]any{}
+		required := []string{}
+		addStructFields(t, properties, &required)
+		return map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	default:
+		return map[string]any{}
+	}
+}
+
+// addStructFields adds the schema of the exported fields of a struct, flattening embedded
+// structs the way encoding/json does
+func addStructFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addStructFields(embedded, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := schemaForType(field.Type)
+		if description := field.Tag.Get("description"); description != "" {
+			schema["description"] = description
+		}
+		if enum := field.Tag.Get("enum"); enum != "" {
+			values := strings.Split(enum, ",")
+			for i, value := range values {
+				values[i] = strings.TrimSpace(value)
+			}
+			schema["enum"] = values
+		}
+		properties[name] = schema
+
+		optional := field.Type.Kind() == reflect.Pointer || slices.Contains(strings.Split(options, ","), "omitempty")
+		if !optional {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// validateAgainstSchema checks a decoded JSON value against a schema produced by
+// schemaForType and describes every problem found
+func validateAgainstSchema(schema map[string]any, value any, path string) []string {
+	field := path
+	if field == "" {
+		field = "arguments"
+	}
+
+	if value == nil {
+		return nil
+	}
+
+	if enum, ok := schema["enum"].([]string); ok {
+		if s, isString := value.(string); isString && !slices.Contains(enum, s) {
+			return []string{fmt.Sprintf("%s must be one of: %s", field, strings.Join(enum, ", "))}
+		}
+	}
+
+	switch schema["type"] {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return []string{fmt.Sprintf("%s must be a string", field)}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []string{fmt.Sprintf("%s must be a boolean", field)}
+		}
+	case "integer":
+		if n, ok := value.(float64); !ok || n != float64(int64(n)) {
+			return []string{fmt.Sprintf("%s must be an integer", field)}
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return []string{fmt.Sprintf("%s must be a number", field)}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s must be an array", field)}
+		}
+		itemSchema, _ := schema["items"].(map[string]any)
+		var problems []string
+		for i, item := range items {
+			problems = append(problems, validateAgainstSchema(itemSchema, item, fmt.Sprintf("%s[%d]", field, i))...)
+		}
+		return problems
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s must be an object", field)}
+		}
+		return validateObject(schema, object, path)
+	}
+	return nil
+}
+
+// validateObject checks the required, known and nested fields of an object
+func validateObject(schema map[string]any, object map[string]any, path string) []string {
+	var problems []string
+	prefix := ""
+	if path != "" {
+		prefix = path + "."
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	if required, ok := schema["required"].([]string); ok {
+		for _, name := range required {
+			if _, present := object[name]; !present {
+				problems = append(problems, fmt.Sprintf("missing required field %s%s", prefix, name))
+			}
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	additional, _ := schema["additionalProperties"].(map[string]any)
+	for _, name := range names {
+		propertySchema, known := properties[name].(map[string]any)
+		switch {
+		case known:
+			problems = append(problems, validateAgainstSchema(propertySchema, object[name], prefix+name)...)
+		case additional != nil:
+			problems = append(problems, validateAgainstSchema(additional, object[name], prefix+name)...)
+		case schema["additionalProperties"] == false:
+			problems = append(problems, fmt.Sprintf("unknown field %s%s", prefix, name))
+		}
+	}
+	return problems
+}
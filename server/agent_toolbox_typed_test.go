@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+)
+
+type weatherLocation struct {
+	City    string `json:"city" description:"City name"`
+	Country string `json:"country,omitempty"`
+}
+
+type weatherOptions struct {
+	Days int `json:"days,omitempty" description:"Number of forecast days"`
+}
+
+type weatherArgs struct {
+	weatherOptions
+	Location weatherLocation   `json:"location"`
+	Units    string            `json:"units" enum:"metric, imperial" description:"Unit system"`
+	Alerts   *bool             `json:"alerts"`
+	Tags     []string          `json:"tags,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Since    time.Time         `json:"since,omitempty"`
+	Internal string            `json:"-"`
+	secret   string
+}
+
+func newWeatherTool(calls *[]weatherArgs) *BasicTool {
+	return NewTypedTool("get_weather", "Gets the weather forecast", func(ctx context.Context, args weatherArgs) (string, error) {
+		*calls = append(*calls, args)
+		return fmt.Sprintf("%s in %s", args.Units, args.Location.City), nil
+	})
+}
+
+func TestNewTypedTool_Schema(t *testing.T) {
+	tool := newWeatherTool(&[]weatherArgs{})
+
+	assert.Equal(t, "get_weather", tool.GetName())
+	assert.Equal(t, "Gets the weather forecast", tool.GetDescription())
+	assert.Equal(t, map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"days": map[string]any{"type": "integer", "description": "Number of forecast days"},
+			"location": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"city":    map[string]any{"type": "string", "description": "City name"},
+					"country": map[string]any{"type": "string"},
+				},
+				"required":             []string{"city"},
+				"additionalProperties": false,
+			},
+			"units":  map[string]any{"type": "string", "description": "Unit system", "enum": []string{"metric", "imperial"}},
+			"alerts": map[string]any{"type": "boolean"},
+			"tags":   map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"labels": map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+			"since":  map[string]any{"type": "string", "format": "date-time"},
+		},
+		"required":             []string{"location", "units"},
+		"additionalProperties": false,
+	}, tool.GetParameters())
+}
+
+func TestNewTypedTool_Execute(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		result    string
+		errorMsg  string
+	}{
+		{
+			name: "valid arguments",
+			arguments: map[string]any{
+				"location": map[string]any{"city": "Berlin"},
+				"units":    "metric",
+				"days":     3,
+				"alerts":   true,
+				"tags":     []string{"outdoor"},
+			},
+			result: "metric in Berlin",
+		},
+		{
+			name:      "missing required fields",
+			arguments: map[string]any{"location": map[string]any{"country": "DE"}},
+			errorMsg:  "invalid arguments for tool get_weather: missing required field units; missing required field location.city",
+		},
+		{
+			name: "wrong types and values",
+			arguments: map[string]any{
+				"location": map[string]any{"city": 42},
+				"units":    "kelvin",
+				"days":     1.5,
+				"tags":     []any{"ok", false},
+			},
+			errorMsg: "invalid arguments for tool get_weather: days must be an integer; location.city must be a string; tags[1] must be a string; units must be one of: metric, imperial",
+		},
+		{
+			name: "unknown fields",
+			arguments: map[string]any{
+				"location": map[string]any{"city": "Berlin", "zip": "10115"},
+				"units":    "metric",
+				"extra":    true,
+			},
+			errorMsg: "invalid arguments for tool get_weather: unknown field extra; unknown field location.zip",
+		},
+		{
+			name:     "no arguments",
+			errorMsg: "invalid arguments for tool get_weather: missing required field location; missing required field units",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []weatherArgs
+			tool := newWeatherTool(&calls)
+
+			result, err := tool.Execute(context.Background(), tt.arguments)
+			if tt.errorMsg != "" {
+				require.EqualError(t, err, tt.errorMsg)
+				assert.Empty(t, calls)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.result, result)
+			require.Len(t, calls, 1)
+			assert.Equal(t, 3, calls[0].Days)
+			require.NotNil(t, calls[0].Alerts)
+			assert.True(t, *calls[0].Alerts)
+			assert.Equal(t, []string{"outdoor"}, calls[0].Tags)
+		})
+	}
+}
+
+func TestNewTypedTool_RequiresStruct(t *testing.T) {
+	assert.Panics(t, func() {
+		NewTypedTool("bad", "", func(ctx context.Context, args string) (string, error) { return args, nil })
+	})
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	sdk "github.com/inference-gateway/sdk"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// ToolApprovalPolicy is implemented by toolboxes that hold back dangerous tools, such as
+// file writes or shell commands, until the client approves the call
+type ToolApprovalPolicy interface {
+	// RequiresApproval checks if a tool only runs once the client approves the call
+	RequiresApproval(toolName string) bool
+}
+
+var _ ToolApprovalPolicy = (*DefaultToolBox)(nil)
+
+// approvedToolCallContextKey carries the ID of the tool call the client approved
+const approvedToolCallContextKey ContextKey = "approvedToolCall"
+
+// requiresApproval checks if a tool call must wait for the client to approve it
+func (a *OpenAICompatibleAgentImpl) requiresApproval(ctx context.Context, toolCall sdk.ChatCompletionMessageToolCall) bool {
+	policy, ok := a.toolBox.(ToolApprovalPolicy)
+	if !ok || !policy.RequiresApproval(toolCall.Function.Name) {
+		return false
+	}
+	approved, _ := ctx.Value(approvedToolCallContextKey).(string)
+	return approved != toolCall.ID
+}
+
+// findToolApproval returns the approval request a conversation paused on and the client's
+// decision, when the last message resumes the task with a decision for that tool call
+func findToolApproval(messages []types.Message) (int, *types.ToolApprovalRequest, *types.ToolApprovalDecision) {
+	if len(messages) < 2 {
+		return -1, nil, nil
+	}
+	requestIndex := len(messages) - 2
+	requestMessage, resumeMessage := messages[requestIndex], messages[len(messages)-1]
+	if requestMessage.Role != types.RoleAgent || resumeMessage.Role != types.RoleUser {
+		return -1, nil, nil
+	}
+
+	var request types.ToolApprovalRequest
+	if !decodeDataPartValue(requestMessage.Parts, types.ToolApprovalRequestDataKey, &request) || request.ToolCallID == "" {
+		return -1, nil, nil
+	}
+
+	var decision types.ToolApprovalDecision
+	if !decodeDataPartValue(resumeMessage.Parts, types.ToolApprovalDecisionDataKey, &decision) {
+		return -1, nil, nil
+	}
+	if decision.ToolCallID != "" && decision.ToolCallID != request.ToolCallID {
+		return -1, nil, nil
+	}
+
+	return requestIndex, &request, &decision
+}
+
+// decodeDataPartValue decodes the value stored under key in the first data part holding it
+func decodeDataPartValue(parts []types.Part, key string, target any) bool {
+	for _, part := range parts {
+		if part.Data == nil {
+			continue
+		}
+		value, exists := part.Data.Data[key]
+		if !exists {
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return false
+		}
+		return json.Unmarshal(data, target) == nil
+	}
+	return false
+}
+
+// resumeToolApproval runs or refuses the tool call a task paused on, according to the
+// decision the client resumed the task with. The approval request and decision are replaced
+// by the tool call and its result, so the LLM continues as if the call had run right away.
+// Messages are returned unchanged when the task was not resumed with an approval decision.
+func (a *OpenAICompatibleAgentImpl) resumeToolApproval(ctx context.Context, messages []types.Message, outputChan chan<- cloudevents.Event, usageTracker *UsageTracker) []types.Message {
+	requestIndex, request, decision := findToolApproval(messages)
+	if request == nil {
+		return messages
+	}
+
+	var taskID *string
+	var contextID *string
+	if task, ok := ctx.Value(TaskContextKey).(*types.Task); ok && task != nil {
+		taskID = &task.ID
+		contextID = &task.ContextID
+	}
+
+	if request.Arguments == nil {
+		request.Arguments = map[string]any{}
+	}
+	arguments, err := json.Marshal(request.Arguments)
+	if err != nil {
+		a.logger.Error("failed to encode approved tool arguments", zap.String("tool", request.ToolName), zap.Error(err))
+		return messages
+	}
+	toolCall := sdk.ChatCompletionMessageToolCall{
+		ID:   request.ToolCallID,
+		Type: "function",
+		Function: sdk.ChatCompletionMessageToolCallFunction{
+			Name:      request.ToolName,
+			Arguments: string(arguments),
+		},
+	}
+
+	toolCallMessage := types.NewAssistantMessage(
+		fmt.Sprintf("approved-tool-call-%s", request.ToolCallID),
+		[]types.Part{types.CreateDataPart(map[string]any{
+			"tool_calls": []sdk.ChatCompletionMessageToolCall{toolCall},
+		})},
+	)
+	toolCallMessage.TaskID = taskID
+	toolCallMessage.ContextID = contextID
+	resumed := append(slices.Clone(messages[:requestIndex]), *toolCallMessage)
+
+	if decision.Approved {
+		a.logger.Info("tool call approved, executing tool",
+			zap.String("tool", request.ToolName),
+			zap.String("tool_call_id", request.ToolCallID))
+		approvedCtx := context.WithValue(ctx, approvedToolCallContextKey, request.ToolCallID)
+		return append(resumed, a.executeToolCallsWithEvents(approvedCtx, []sdk.ChatCompletionMessageToolCall{toolCall}, outputChan, usageTracker)...)
+	}
+
+	a.logger.Info("tool call denied, skipping tool execution",
+		zap.String("tool", request.ToolName),
+		zap.String("tool_call_id", request.ToolCallID))
+	result := fmt.Sprintf("The user denied running the %s tool.", request.ToolName)
+	if decision.Reason != "" {
+		result = fmt.Sprintf("%s Reason: %s", result, decision.Reason)
+	}
+	toolResultMessage := types.NewToolResultMessage(request.ToolCallID, request.ToolName, result, true)
+	toolResultMessage.TaskID = taskID
+	toolResultMessage.ContextID = contextID
+	select {
+	case outputChan <- types.NewMessageEvent(types.EventToolResult, toolResultMessage.MessageID, toolResultMessage):
+	case <-ctx.Done():
+	}
+	return append(resumed, *toolResultMessage)
+}
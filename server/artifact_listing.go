@@ -0,0 +1,190 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// HandleArtifactList processes artifacts/list requests
+func (h *DefaultA2AProtocolHandler) HandleArtifactList(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	var params types.ArtifactListParams
+	paramsBytes, err := json.Marshal(req.Params)
+	if err != nil {
+		logger.Error("failed to marshal params", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
+		return
+	}
+
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		logger.Error("failed to parse artifacts/list request", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
+		return
+	}
+
+	result, code, err := listArtifacts(c.Request.Context(), h.taskManager, h.storage, h.artifactService, params)
+	if err != nil {
+		if code == ErrInternalError {
+			logger.Error("failed to list artifacts", zap.Error(err))
+		}
+		h.responseSender.SendError(c, req.ID, int(code), err.Error())
+		return
+	}
+	h.responseSender.SendSuccess(c, req.ID, result)
+}
+
+// handleArtifactList serves GET /artifacts, listing the artifact files of the task or context
+// given by the taskId and contextId query parameters like artifacts/list. Callers identified as
+// a user only list the artifacts of their own tasks and contexts, and other callers those of the
+// tasks and contexts of no user.
+func (s *A2AServerImpl) handleArtifactList(c *gin.Context) {
+	var service ArtifactService
+	if ph, ok := s.protocolHandler.(*DefaultA2AProtocolHandler); ok {
+		service = ph.artifactService
+	}
+
+	params := types.ArtifactListParams{ContextID: c.Query("contextId"), TaskID: c.Query("taskId")}
+	if !s.checkArtifactAccess(c, params.TaskID, params.ContextID) {
+		return
+	}
+
+	result, code, err := listArtifacts(c.Request.Context(), s.taskManager, s.storage, service, params)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch code {
+		case ErrInvalidParams:
+			status = http.StatusBadRequest
+		case ErrTaskNotFound:
+			status = http.StatusNotFound
+		default:
+			requestLogger(c.Request.Context(), s.logger).Error("failed to list artifacts", zap.Error(err))
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// listArtifacts lists the files of the artifacts of a task, or of every task of a context,
+// with their download links. Listing a context also includes the files the artifact service
+// holds for it that no task artifact references, such as uploads. It returns the JSON-RPC
+// error code to report when the files cannot be listed.
+func listArtifacts(ctx context.Context, taskManager TaskManager, storage Storage, service ArtifactService, params types.ArtifactListParams) (*types.ArtifactListResult, JRPCErrorCode, error) {
+	var tasks []*types.Task
+	contextID := params.ContextID
+	switch {
+	case params.TaskID != "":
+		task, exists := taskManager.GetTask(params.TaskID)
+		if !exists || (contextID != "" && task.ContextID != contextID) {
+			return nil, ErrTaskNotFound, NewTaskNotFoundError(params.TaskID)
+		}
+		tasks = []*types.Task{task}
+		contextID = task.ContextID
+	case contextID != "":
+		listed, err := storage.ListTasks(TaskFilter{
+			ContextID: &contextID,
+			SortBy:    TaskSortFieldCreatedAt,
+			SortOrder: SortOrderAsc,
+		})
+		if err != nil {
+			return nil, ErrInternalError, fmt.Errorf("failed to list tasks: %w", err)
+		}
+		tasks = listed
+	default:
+		return nil, ErrInvalidParams, fmt.Errorf("task id or context id is required")
+	}
+
+	stored := make(map[string]ArtifactMetadata)
+	if service != nil {
+		files, err := service.ListStoredArtifacts(ctx)
+		if err != nil {
+			return nil, ErrInternalError, fmt.Errorf("failed to list stored artifacts: %w", err)
+		}
+		for _, file := range files {
+			if file.ContextID == contextID {
+				stored[file.ArtifactID+"/"+file.Filename] = file
+			}
+		}
+	}
+
+	result := &types.ArtifactListResult{Artifacts: []types.ArtifactFile{}}
+	listed := make(map[string]bool)
+	for _, task := range tasks {
+		for _, artifact := range task.Artifacts {
+			for _, part := range artifact.Parts {
+				if part.File == nil {
+					continue
+				}
+				file := types.ArtifactFile{
+					ArtifactID: artifact.ArtifactID,
+					ContextID:  task.ContextID,
+					Filename:   part.File.Name,
+					MimeType:   part.File.MediaType,
+					TaskID:     task.ID,
+				}
+				if artifact.Name != nil {
+					file.ArtifactName = *artifact.Name
+				}
+				if part.File.FileWithURI != nil {
+					file.URL = *part.File.FileWithURI
+				}
+				key := artifact.ArtifactID + "/" + part.File.Name
+				if metadata, exists := stored[key]; exists {
+					describeStoredArtifact(&file, metadata)
+				}
+				listed[key] = true
+				result.Artifacts = append(result.Artifacts, file)
+			}
+		}
+	}
+
+	if params.TaskID != "" || service == nil {
+		return result, 0, nil
+	}
+	var unreferenced []ArtifactMetadata
+	for key, metadata := range stored {
+		if !listed[key] {
+			unreferenced = append(unreferenced, metadata)
+		}
+	}
+	slices.SortFunc(unreferenced, func(a, b ArtifactMetadata) int {
+		if order := a.UploadedAt.Compare(b.UploadedAt); order != 0 {
+			return order
+		}
+		return strings.Compare(a.ArtifactID+"/"+a.Filename, b.ArtifactID+"/"+b.Filename)
+	})
+	for _, metadata := range unreferenced {
+		file := types.ArtifactFile{
+			ArtifactID: metadata.ArtifactID,
+			ContextID:  metadata.ContextID,
+			Filename:   metadata.Filename,
+			URL:        service.GetURL(metadata.ContextID, metadata.ArtifactID, metadata.Filename),
+		}
+		describeStoredArtifact(&file, metadata)
+		result.Artifacts = append(result.Artifacts, file)
+	}
+	return result, 0, nil
+}
+
+// describeStoredArtifact sets the size and upload time of an artifact file held by the
+// artifact service
+func describeStoredArtifact(file *types.ArtifactFile, metadata ArtifactMetadata) {
+	size := metadata.Size
+	file.Size = &size
+	if !metadata.UploadedAt.IsZero() {
+		file.UploadedAt = metadata.UploadedAt.UTC().Format(time.RFC3339Nano)
+	}
+	if file.MimeType == "" {
+		file.MimeType = metadata.ContentType
+	}
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestA2AServer_ListArtifacts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	service, err := NewArtifactService(&config.ArtifactsConfig{
+		Enable: true,
+		StorageConfig: config.ArtifactsStorageConfig{
+			Provider: "filesystem",
+			BasePath: t.TempDir(),
+			BaseURL:  "https://files.example.com",
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	cfg := &config.Config{}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "assistant"})
+	s.protocolHandler.(*DefaultA2AProtocolHandler).SetArtifactService(service)
+	tm := s.taskManager.(*DefaultTaskManager)
+	router := s.setupRouter(cfg)
+
+	mimeType := "text/csv"
+	report, err := service.CreateFileArtifact("ctx-1", "report", "the report", "report.csv", []byte("a,b\n1,2\n"), &mimeType)
+	require.NoError(t, err)
+	task := tm.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+	task.Status.State = types.TaskStateCompleted
+	task.Artifacts = []types.Artifact{report, service.CreateTextArtifact("summary", "", "two rows")}
+	require.NoError(t, tm.UpdateTask(task))
+
+	_, _, err = service.StoreFile(context.Background(), "ctx-1", "upload-1", "notes.txt", strings.NewReader("notes"))
+	require.NoError(t, err)
+	_, _, err = service.StoreFile(context.Background(), "ctx-2", "upload-2", "other.txt", strings.NewReader("other"))
+	require.NoError(t, err)
+
+	call := func(t *testing.T, params string) (*types.ArtifactListResult, *types.JSONRPCError) {
+		body := `{"jsonrpc":"2.0","id":"1","method":"artifacts/list","params":` + params + `}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+		var response struct {
+			Result *types.ArtifactListResult `json:"result"`
+			Error  *types.JSONRPCError       `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Result, response.Error
+	}
+
+	t.Run("context lists the files of its tasks and its uploads", func(t *testing.T) {
+		result, rpcErr := call(t, `{"contextId":"ctx-1"}`)
+		require.Nil(t, rpcErr)
+		require.Len(t, result.Artifacts, 2)
+
+		produced := result.Artifacts[0]
+		assert.Equal(t, task.ID, produced.TaskID)
+		assert.Equal(t, report.ArtifactID, produced.ArtifactID)
+		assert.Equal(t, "report", produced.ArtifactName)
+		assert.Equal(t, "report.csv", produced.Filename)
+		assert.Equal(t, "text/csv", produced.MimeType)
+		assert.Equal(t, "https://files.example.com/artifacts/ctx-1/"+report.ArtifactID+"/report.csv", produced.URL)
+		require.NotNil(t, produced.Size)
+		assert.Equal(t, int64(8), *produced.Size)
+		assert.NotEmpty(t, produced.UploadedAt)
+
+		uploaded := result.Artifacts[1]
+		assert.Empty(t, uploaded.TaskID)
+		assert.Equal(t, "upload-1", uploaded.ArtifactID)
+		assert.Equal(t, "https://files.example.com/artifacts/ctx-1/upload-1/notes.txt", uploaded.URL)
+	})
+
+	t.Run("task lists the files of its artifacts", func(t *testing.T) {
+		result, rpcErr := call(t, `{"taskId":"`+task.ID+`"}`)
+		require.Nil(t, rpcErr)
+		require.Len(t, result.Artifacts, 1)
+		assert.Equal(t, "report.csv", result.Artifacts[0].Filename)
+	})
+
+	t.Run("invalid params are rejected", func(t *testing.T) {
+		_, rpcErr := call(t, `{}`)
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, int(ErrInvalidParams), rpcErr.Code)
+
+		_, rpcErr = call(t, `{"taskId":"`+task.ID+`","contextId":"ctx-2"}`)
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, int(ErrTaskNotFound), rpcErr.Code)
+	})
+
+	t.Run("http endpoint serves the same list", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/artifacts?taskId="+task.ID, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var result types.ArtifactListResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		require.Len(t, result.Artifacts, 1)
+		assert.Equal(t, report.ArtifactID, result.Artifacts[0].ArtifactID)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/artifacts", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/artifacts?taskId=missing", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
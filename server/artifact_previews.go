@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"slices"
+
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// ArtifactPreviews attaches the content of small file artifacts inline to the response message
+// of a completed task, as file parts with bytes alongside the artifacts' URIs, so clients that
+// cannot reach the artifacts server can still display them
+type ArtifactPreviews struct {
+	artifactService ArtifactService
+	maxSize         int64
+	logger          *zap.Logger
+}
+
+// NewArtifactPreviews creates previews of the stored file artifacts of at most maxSize bytes
+func NewArtifactPreviews(artifactService ArtifactService, maxSize int64, logger *zap.Logger) *ArtifactPreviews {
+	return &ArtifactPreviews{artifactService: artifactService, maxSize: maxSize, logger: logger}
+}
+
+// attach returns the response message of a completed task with previews of the task's file
+// artifacts attached, as a copy so the message kept in the history is left as it is. Artifacts
+// already previewed on the message, embedded in the artifact, or over the size limit are
+// skipped; files that cannot be read are logged and skipped.
+func (p *ArtifactPreviews) attach(ctx context.Context, task *types.Task, message *types.Message) *types.Message {
+	if p == nil || task == nil || task.Status.State != types.TaskStateCompleted || message == nil || message.Role != types.RoleAgent {
+		return message
+	}
+
+	previewed := make(map[string]bool)
+	for _, part := range message.Parts {
+		if part.Metadata == nil {
+			continue
+		}
+		if artifactID, ok := (*part.Metadata)[types.ArtifactPreviewMetadataKey].(string); ok {
+			previewed[artifactID] = true
+		}
+	}
+
+	var previews []types.Part
+	for _, artifact := range task.Artifacts {
+		if previewed[artifact.ArtifactID] {
+			continue
+		}
+		for _, part := range artifact.Parts {
+			if part.File == nil || part.File.FileWithURI == nil || part.File.FileWithBytes != nil {
+				continue
+			}
+			content, ok := p.read(ctx, task, artifact.ArtifactID, part.File.Name)
+			if !ok {
+				continue
+			}
+			encoded := base64.StdEncoding.EncodeToString(content)
+			previews = append(previews, types.CreateFilePart(part.File.Name, part.File.MediaType, &encoded, nil, map[string]any{
+				types.ArtifactPreviewMetadataKey: artifact.ArtifactID,
+			}))
+		}
+	}
+	if len(previews) == 0 {
+		return message
+	}
+
+	withPreviews := *message
+	withPreviews.Parts = append(slices.Clone(message.Parts), previews...)
+	return &withPreviews
+}
+
+// read returns the content of a stored file artifact when it fits the size limit
+func (p *ArtifactPreviews) read(ctx context.Context, task *types.Task, artifactID, filename string) ([]byte, bool) {
+	reader, err := p.artifactService.Retrieve(ctx, task.ContextID, artifactID, filename)
+	if err != nil {
+		p.logger.Debug("failed to retrieve artifact for preview",
+			zap.String("task_id", task.ID),
+			zap.String("artifact_id", artifactID),
+			zap.Error(err))
+		return nil, false
+	}
+	defer func() { _ = reader.Close() }()
+
+	content, err := io.ReadAll(io.LimitReader(reader, p.maxSize+1))
+	if err != nil {
+		p.logger.Debug("failed to read artifact for preview",
+			zap.String("task_id", task.ID),
+			zap.String("artifact_id", artifactID),
+			zap.Error(err))
+		return nil, false
+	}
+	if int64(len(content)) > p.maxSize {
+		return nil, false
+	}
+	return content, true
+}
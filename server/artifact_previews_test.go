@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestArtifactPreviews_Attach(t *testing.T) {
+	service := newTestArtifactService(t, 0)
+	csv := "text/csv"
+	small, err := service.CreateFileArtifact("ctx-1", "summary", "", "summary.csv", []byte("a,b\n1,2\n"), &csv)
+	require.NoError(t, err)
+	large, err := service.CreateFileArtifact("ctx-1", "export", "", "export.csv", []byte("a,b\n1,2\n3,4\n5,6\n"), &csv)
+	require.NoError(t, err)
+	embedded := "aGk="
+
+	response := types.Message{MessageID: "m-2", Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart("done")}}
+	task := &types.Task{
+		ID:        "task-1",
+		ContextID: "ctx-1",
+		Status:    types.TaskStatus{State: types.TaskStateCompleted},
+		History:   []types.Message{response},
+		Artifacts: []types.Artifact{
+			small,
+			large,
+			service.CreateMultiPartArtifact("inline", "", []types.Part{types.CreateFilePart("hi.txt", "text/plain", &embedded, nil)}),
+			service.CreateTextArtifact("note", "", "text only"),
+		},
+	}
+	previews := NewArtifactPreviews(service, 10, zap.NewNop())
+
+	t.Run("small stored files are attached inline", func(t *testing.T) {
+		message := previews.attach(context.Background(), task, &task.History[0])
+		require.Len(t, message.Parts, 2)
+		preview := message.Parts[1]
+		require.NotNil(t, preview.File)
+		assert.Equal(t, "summary.csv", preview.File.Name)
+		assert.Equal(t, "text/csv", preview.File.MediaType)
+		require.NotNil(t, preview.File.FileWithBytes)
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("a,b\n1,2\n")), *preview.File.FileWithBytes)
+		require.NotNil(t, preview.Metadata)
+		assert.Equal(t, small.ArtifactID, (*preview.Metadata)[types.ArtifactPreviewMetadataKey])
+
+		assert.Len(t, task.History[0].Parts, 1, "the history keeps the message as it is")
+		assert.Len(t, previews.attach(context.Background(), task, message).Parts, 2, "previewed artifacts are not attached twice")
+	})
+
+	t.Run("messages of unfinished tasks are left as they are", func(t *testing.T) {
+		working := *task
+		working.Status.State = types.TaskStateWorking
+		assert.Same(t, &task.History[0], previews.attach(context.Background(), &working, &task.History[0]))
+
+		var disabled *ArtifactPreviews
+		assert.Same(t, &task.History[0], disabled.attach(context.Background(), task, &task.History[0]))
+	})
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inference-gateway/adk/server/config"
+	"github.com/inference-gateway/adk/types"
+)
+
+// ErrDiagramRenderingDisabled is returned when a diagram is rendered without a diagram server configured
+var ErrDiagramRenderingDisabled = errors.New("diagram rendering is not configured")
+
+// DiagramLanguage is the language a diagram source is written in
+type DiagramLanguage string
+
+// Diagram languages the ArtifactRenderer renders
+const (
+	DiagramMermaid  DiagramLanguage = "mermaid"
+	DiagramPlantUML DiagramLanguage = "plantuml"
+)
+
+// ImageFormat is the format diagrams and charts are rendered to
+type ImageFormat string
+
+// Image formats the ArtifactRenderer renders to
+const (
+	ImageFormatSVG ImageFormat = "svg"
+	ImageFormatPNG ImageFormat = "png"
+)
+
+// mimeType returns the media type of images in the format
+func (f ImageFormat) mimeType() string {
+	if f == ImageFormatPNG {
+		return "image/png"
+	}
+	return "image/svg+xml"
+}
+
+// ArtifactRenderer renders common agent outputs - diagrams, tables and charts - into files
+// clients can view, and wraps them in artifacts. Diagrams are rendered by a Kroki-compatible
+// server; tables and charts are rendered in process.
+//
+// With an artifact service the files are stored and referenced by URL; without one they are
+// embedded in the artifacts as base64.
+type ArtifactRenderer struct {
+	artifactService ArtifactService
+	diagramURL      string
+	httpClient      *http.Client
+}
+
+// NewArtifactRenderer creates a renderer storing its files through the artifact service, which may be nil
+func NewArtifactRenderer(cfg config.ArtifactRenderConfig, artifactService ArtifactService) *ArtifactRenderer {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &ArtifactRenderer{
+		artifactService: artifactService,
+		diagramURL:      strings.TrimSuffix(cfg.DiagramURL, "/"),
+		httpClient:      &http.Client{Timeout: timeout},
+	}
+}
+
+// RenderDiagram renders a Mermaid or PlantUML diagram into an image
+func (r *ArtifactRenderer) RenderDiagram(ctx context.Context, language DiagramLanguage, source string, format ImageFormat) ([]byte, error) {
+	if r.diagramURL == "" {
+		return nil, ErrDiagramRenderingDisabled
+	}
+	switch language {
+	case DiagramMermaid, DiagramPlantUML:
+	default:
+		return nil, fmt.Errorf("unsupported diagram language %q", language)
+	}
+	if err := checkImageFormat(format); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", r.diagramURL, language, format)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(source))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create diagram request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render diagram: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rendered diagram: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to render diagram: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// CreateDiagramArtifact renders a diagram into an image artifact named after the artifact
+func (r *ArtifactRenderer) CreateDiagramArtifact(ctx context.Context, contextID, name, description string, language DiagramLanguage, source string, format ImageFormat) (types.Artifact, error) {
+	image, err := r.RenderDiagram(ctx, language, source, format)
+	if err != nil {
+		return types.Artifact{}, err
+	}
+	return r.createArtifact(ctx, FileArtifactMeta{
+		ContextID:   contextID,
+		Name:        name,
+		Description: description,
+		Filename:    renderedFilename(name, "diagram", string(format)),
+		MimeType:    format.mimeType(),
+	}, image)
+}
+
+// CreateTableArtifact renders a table into a CSV or XLSX artifact named after the artifact
+func (r *ArtifactRenderer) CreateTableArtifact(ctx context.Context, contextID, name, description string, table Table, format TableFormat) (types.Artifact, error) {
+	content, err := r.RenderTable(table, format)
+	if err != nil {
+		return types.Artifact{}, err
+	}
+	return r.createArtifact(ctx, FileArtifactMeta{
+		ContextID:   contextID,
+		Name:        name,
+		Description: description,
+		Filename:    renderedFilename(name, "table", string(format)),
+		MimeType:    format.mimeType(),
+	}, content)
+}
+
+// CreateChartArtifact renders a chart into an image artifact named after the artifact
+func (r *ArtifactRenderer) CreateChartArtifact(ctx context.Context, contextID, name, description string, chart Chart, format ImageFormat) (types.Artifact, error) {
+	image, err := r.RenderChart(chart, format)
+	if err != nil {
+		return types.Artifact{}, err
+	}
+	return r.createArtifact(ctx, FileArtifactMeta{
+		ContextID:   contextID,
+		Name:        name,
+		Description: description,
+		Filename:    renderedFilename(name, "chart", string(format)),
+		MimeType:    format.mimeType(),
+	}, image)
+}
+
+// createArtifact stores a rendered file through the artifact service, or embeds it in the
+// artifact when there is none
+func (r *ArtifactRenderer) createArtifact(ctx context.Context, meta FileArtifactMeta, content []byte) (types.Artifact, error) {
+	if r.artifactService != nil {
+		return r.artifactService.CreateFileArtifactFromReader(ctx, meta, bytes.NewReader(content))
+	}
+	encoded := base64.StdEncoding.EncodeToString(content)
+	return types.Artifact{
+		ArtifactID:  uuid.New().String(),
+		Name:        &meta.Name,
+		Description: &meta.Description,
+		Parts:       []types.Part{types.CreateFilePart(meta.Filename, meta.MimeType, &encoded, nil)},
+	}, nil
+}
+
+// checkImageFormat rejects formats diagrams and charts cannot be rendered to
+func checkImageFormat(format ImageFormat) error {
+	switch format {
+	case ImageFormatSVG, ImageFormatPNG:
+		return nil
+	default:
+		return fmt.Errorf("unsupported image format %q", format)
+	}
+}
+
+// renderedFilename derives the filename of a rendered file from the artifact name, keeping
+// letters, digits, dashes and underscores
+func renderedFilename(name, fallback, extension string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	base := strings.Trim(b.String(), "_")
+	if base == "" {
+		base = fallback
+	}
+	return base + "." + extension
+}
@@ -0,0 +1,303 @@
+package server
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ChartKind is the kind of plot a chart is drawn as
+type ChartKind string
+
+// Chart kinds the ArtifactRenderer draws
+const (
+	ChartLine    ChartKind = "line"
+	ChartBar     ChartKind = "bar"
+	ChartScatter ChartKind = "scatter"
+)
+
+// Chart is numeric output drawn into an image
+type Chart struct {
+	Kind   ChartKind
+	Title  string
+	XLabel string
+	YLabel string
+	// Categories label the bars of a bar chart
+	Categories []string
+	Series     []ChartSeries
+	// Width and Height are the size of the image in inches, 6 by 4 when 0
+	Width  float64
+	Height float64
+}
+
+// ChartSeries is a series of values of a chart. Bar charts only use Y; line and scatter
+// charts plot Y against X, or against the index of the values when X is empty.
+type ChartSeries struct {
+	Name string
+	X    []float64
+	Y    []float64
+}
+
+const (
+	// chartDPI converts the size of a chart in inches to the pixels of the image
+	chartDPI = 96
+
+	chartMarginTop    = 40
+	chartMarginRight  = 16
+	chartMarginBottom = 48
+	chartMarginLeft   = 64
+
+	// chartTicks is the number of ticks the axes aim for
+	chartTicks = 5
+)
+
+// chartPalette colors the series of a chart, repeating after the last color
+var chartPalette = []string{"#4e79a7", "#f28e2b", "#59a14f", "#e15759", "#76b7b2", "#b07aa1", "#edc948", "#9c755f"}
+
+// RenderChart draws a chart into an SVG image. Charts are drawn in process without a plotting
+// library, so they are only rendered to SVG.
+func (r *ArtifactRenderer) RenderChart(chart Chart, format ImageFormat) ([]byte, error) {
+	if err := checkImageFormat(format); err != nil {
+		return nil, err
+	}
+	if format != ImageFormatSVG {
+		return nil, fmt.Errorf("charts can only be rendered to %s, not %s", ImageFormatSVG, format)
+	}
+	if len(chart.Series) == 0 {
+		return nil, fmt.Errorf("chart has no series")
+	}
+	switch chart.Kind {
+	case ChartLine, ChartScatter, ChartBar:
+	default:
+		return nil, fmt.Errorf("unsupported chart kind %q", chart.Kind)
+	}
+	for _, series := range chart.Series {
+		if chart.Kind != ChartBar && len(series.X) > 0 && len(series.X) != len(series.Y) {
+			return nil, fmt.Errorf("series %q has %d x values and %d y values", series.Name, len(series.X), len(series.Y))
+		}
+		for _, value := range slices.Concat(series.X, series.Y) {
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				return nil, fmt.Errorf("series %q has a value that is not a finite number", series.Name)
+			}
+		}
+	}
+
+	width, height := chart.Width, chart.Height
+	if width <= 0 {
+		width = 6
+	}
+	if height <= 0 {
+		height = 4
+	}
+	canvas := newChartCanvas(chart, width*chartDPI, height*chartDPI)
+	return canvas.draw(chart), nil
+}
+
+// chartCanvas maps the values of a chart onto the plot area of the image
+type chartCanvas struct {
+	width, height float64
+	// left, top, right and bottom bound the plot area in pixels
+	left, top, right, bottom float64
+	xMin, xMax, yMin, yMax   float64
+	xTicks, yTicks           []float64
+	// slots is the number of bar groups of a bar chart
+	slots int
+	b     strings.Builder
+}
+
+func newChartCanvas(chart Chart, width, height float64) *chartCanvas {
+	c := &chartCanvas{
+		width:  width,
+		height: height,
+		left:   chartMarginLeft,
+		top:    chartMarginTop,
+		right:  max(width-chartMarginRight, chartMarginLeft+1),
+		bottom: max(height-chartMarginBottom, chartMarginTop+1),
+	}
+
+	yMin, yMax := math.Inf(1), math.Inf(-1)
+	xMin, xMax := math.Inf(1), math.Inf(-1)
+	for _, series := range chart.Series {
+		for i, y := range series.Y {
+			yMin, yMax = min(yMin, y), max(yMax, y)
+			x := seriesX(series, i)
+			xMin, xMax = min(xMin, x), max(xMax, x)
+		}
+		c.slots = max(c.slots, len(series.Y))
+	}
+	if math.IsInf(yMin, 0) {
+		yMin, yMax, xMin, xMax = 0, 1, 0, 1
+	}
+	if chart.Kind == ChartBar {
+		yMin, yMax = min(yMin, 0), max(yMax, 0)
+		c.slots = max(c.slots, len(chart.Categories), 1)
+	}
+	c.yMin, c.yMax, c.yTicks = chartAxis(yMin, yMax)
+	c.xMin, c.xMax, c.xTicks = chartAxis(xMin, xMax)
+	return c
+}
+
+// seriesX returns the X coordinate of the i-th value of a line or scatter series
+func seriesX(series ChartSeries, i int) float64 {
+	if len(series.X) > 0 {
+		return series.X[i]
+	}
+	return float64(i)
+}
+
+// chartAxis widens a range of values to round bounds and returns the ticks between them
+func chartAxis(lo, hi float64) (float64, float64, []float64) {
+	if lo == hi {
+		lo, hi = lo-1, hi+1
+	}
+	raw := (hi - lo) / chartTicks
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	step := 10 * magnitude
+	for _, factor := range []float64{1, 2, 5} {
+		if raw <= factor*magnitude {
+			step = factor * magnitude
+			break
+		}
+	}
+	first, last := math.Floor(lo/step), math.Ceil(hi/step)
+	var ticks []float64
+	for n := first; n <= last; n++ {
+		ticks = append(ticks, n*step)
+	}
+	return first * step, last * step, ticks
+}
+
+func (c *chartCanvas) x(value float64) float64 {
+	return c.left + (value-c.xMin)/(c.xMax-c.xMin)*(c.right-c.left)
+}
+
+func (c *chartCanvas) y(value float64) float64 {
+	return c.bottom - (value-c.yMin)/(c.yMax-c.yMin)*(c.bottom-c.top)
+}
+
+// draw writes the SVG document of the chart
+func (c *chartCanvas) draw(chart Chart) []byte {
+	c.b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	fmt.Fprintf(&c.b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s" font-family="sans-serif" font-size="12">`,
+		coord(c.width), coord(c.height), coord(c.width), coord(c.height))
+	c.b.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/>`)
+
+	c.drawAxes(chart)
+	for i, series := range chart.Series {
+		color := chartPalette[i%len(chartPalette)]
+		switch chart.Kind {
+		case ChartLine:
+			points := make([]string, len(series.Y))
+			for j, y := range series.Y {
+				points[j] = coord(c.x(seriesX(series, j))) + "," + coord(c.y(y))
+			}
+			fmt.Fprintf(&c.b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`, strings.Join(points, " "), color)
+		case ChartScatter:
+			for j, y := range series.Y {
+				fmt.Fprintf(&c.b, `<circle cx="%s" cy="%s" r="3" fill="%s"/>`, coord(c.x(seriesX(series, j))), coord(c.y(y)), color)
+			}
+		case ChartBar:
+			slot := (c.right - c.left) / float64(c.slots)
+			barWidth := slot * 0.8 / float64(len(chart.Series))
+			for j, y := range series.Y {
+				x := c.left + float64(j)*slot + slot*0.1 + float64(i)*barWidth
+				top, bottom := c.y(max(y, 0)), c.y(min(y, 0))
+				fmt.Fprintf(&c.b, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`, coord(x), coord(top), coord(barWidth), coord(bottom-top), color)
+			}
+		}
+	}
+	c.drawLegend(chart)
+
+	if chart.Title != "" {
+		c.text(c.width/2, 24, "middle", `font-size="16"`, chart.Title)
+	}
+	c.b.WriteString("</svg>\n")
+	return []byte(c.b.String())
+}
+
+// drawAxes writes the grid, the ticks and their labels, and the axis labels
+func (c *chartCanvas) drawAxes(chart Chart) {
+	for _, tick := range c.yTicks {
+		y := c.y(tick)
+		fmt.Fprintf(&c.b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="#e0e0e0"/>`, coord(c.left), coord(y), coord(c.right), coord(y))
+		c.text(c.left-6, y+4, "end", "", formatTick(tick))
+	}
+	if chart.Kind == ChartBar {
+		slot := (c.right - c.left) / float64(c.slots)
+		for i := range c.slots {
+			label := strconv.Itoa(i)
+			if i < len(chart.Categories) {
+				label = chart.Categories[i]
+			}
+			c.text(c.left+(float64(i)+0.5)*slot, c.bottom+16, "middle", "", label)
+		}
+	} else {
+		for _, tick := range c.xTicks {
+			x := c.x(tick)
+			fmt.Fprintf(&c.b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="#e0e0e0"/>`, coord(x), coord(c.top), coord(x), coord(c.bottom))
+			c.text(x, c.bottom+16, "middle", "", formatTick(tick))
+		}
+	}
+	fmt.Fprintf(&c.b, `<polyline points="%s,%s %s,%s %s,%s" fill="none" stroke="#333333"/>`,
+		coord(c.left), coord(c.top), coord(c.left), coord(c.bottom), coord(c.right), coord(c.bottom))
+
+	if chart.XLabel != "" {
+		c.text((c.left+c.right)/2, c.height-10, "middle", "", chart.XLabel)
+	}
+	if chart.YLabel != "" {
+		y := (c.top + c.bottom) / 2
+		c.text(16, y, "middle", fmt.Sprintf(`transform="rotate(-90 16 %s)"`, coord(y)), chart.YLabel)
+	}
+}
+
+// drawLegend lists the named series of the chart in the top right corner of the plot area
+func (c *chartCanvas) drawLegend(chart Chart) {
+	var names []string
+	var colors []string
+	for i, series := range chart.Series {
+		if series.Name != "" {
+			names = append(names, series.Name)
+			colors = append(colors, chartPalette[i%len(chartPalette)])
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	longest := 0
+	for _, name := range names {
+		longest = max(longest, len([]rune(name)))
+	}
+	width := float64(longest)*7 + 28
+	x, y := c.right-width-4, c.top+4
+	fmt.Fprintf(&c.b, `<rect x="%s" y="%s" width="%s" height="%s" fill="#ffffff" fill-opacity="0.8" stroke="#cccccc"/>`,
+		coord(x), coord(y), coord(width), coord(float64(len(names))*16+8))
+	for i, name := range names {
+		rowY := y + 8 + float64(i)*16
+		fmt.Fprintf(&c.b, `<rect x="%s" y="%s" width="10" height="10" fill="%s"/>`, coord(x+8), coord(rowY+1), colors[i])
+		c.text(x+22, rowY+10, "start", "", name)
+	}
+}
+
+// text writes escaped text anchored at a point, with extra attributes when given
+func (c *chartCanvas) text(x, y float64, anchor, attributes, text string) {
+	fmt.Fprintf(&c.b, `<text x="%s" y="%s" text-anchor="%s"`, coord(x), coord(y), anchor)
+	if attributes != "" {
+		c.b.WriteString(" " + attributes)
+	}
+	c.b.WriteString(">")
+	_ = xml.EscapeText(&c.b, []byte(text))
+	c.b.WriteString("</text>")
+}
+
+// coord formats a pixel coordinate with two decimals at most
+func coord(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+// formatTick formats the value of a tick, hiding the rounding errors of the tick steps
+func formatTick(value float64) string {
+	return strconv.FormatFloat(value, 'g', 6, 64)
+}
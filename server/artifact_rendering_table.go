@@ -0,0 +1,184 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// TableFormat is the file format tables are rendered to
+type TableFormat string
+
+// Table formats the ArtifactRenderer renders to
+const (
+	TableFormatCSV  TableFormat = "csv"
+	TableFormatXLSX TableFormat = "xlsx"
+)
+
+// mimeType returns the media type of tables in the format
+func (f TableFormat) mimeType() string {
+	if f == TableFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv"
+}
+
+// Table is tabular output rendered into a spreadsheet. Cells hold strings, numbers or booleans;
+// nil cells are left empty.
+type Table struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// RenderTable renders a table into a CSV or XLSX file
+func (r *ArtifactRenderer) RenderTable(table Table, format TableFormat) ([]byte, error) {
+	switch format {
+	case TableFormatCSV:
+		return renderTableCSV(table)
+	case TableFormatXLSX:
+		return renderTableXLSX(table)
+	default:
+		return nil, fmt.Errorf("unsupported table format %q", format)
+	}
+}
+
+// renderTableCSV writes the columns as the header record followed by a record per row
+func renderTableCSV(table Table) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if len(table.Columns) > 0 {
+		if err := writer.Write(table.Columns); err != nil {
+			return nil, fmt.Errorf("failed to write table header: %w", err)
+		}
+	}
+	for i, row := range table.Rows {
+		record := make([]string, len(row))
+		for j, cell := range row {
+			if cell != nil {
+				record[j] = fmt.Sprint(cell)
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write table row %d: %w", i, err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write table: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// xlsxParts are the fixed parts of a workbook holding a single worksheet
+var xlsxParts = []struct{ name, content string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+// renderTableXLSX writes the table into the first worksheet of a workbook, keeping numbers
+// and booleans typed so spreadsheets can compute with them
+func renderTableXLSX(table Table) ([]byte, error) {
+	var sheet bytes.Buffer
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	rowNumber := 0
+	writeRow := func(cells []any) error {
+		rowNumber++
+		fmt.Fprintf(&sheet, `<row r="%d">`, rowNumber)
+		for i, cell := range cells {
+			if err := writeXLSXCell(&sheet, xlsxCellRef(i, rowNumber), cell); err != nil {
+				return err
+			}
+		}
+		sheet.WriteString(`</row>`)
+		return nil
+	}
+	if len(table.Columns) > 0 {
+		header := make([]any, len(table.Columns))
+		for i, column := range table.Columns {
+			header[i] = column
+		}
+		if err := writeRow(header); err != nil {
+			return nil, err
+		}
+	}
+	for _, row := range table.Rows {
+		if err := writeRow(row); err != nil {
+			return nil, err
+		}
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	parts := append(xlsxParts, struct{ name, content string }{"xl/worksheets/sheet1.xml", sheet.String()})
+	for _, part := range parts {
+		w, err := archive.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write workbook: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeXLSXCell writes a worksheet cell, as a number or boolean when the value is one and as
+// an inline string otherwise
+func writeXLSXCell(w *bytes.Buffer, ref string, value any) error {
+	var number string
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case bool:
+		flag := "0"
+		if v {
+			flag = "1"
+		}
+		fmt.Fprintf(w, `<c r="%s" t="b"><v>%s</v></c>`, ref, flag)
+		return nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		number = fmt.Sprint(v)
+	case float32:
+		number = strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		number = strconv.FormatFloat(v, 'g', -1, 64)
+	case json.Number:
+		number = v.String()
+	}
+	if number != "" {
+		fmt.Fprintf(w, `<c r="%s"><v>%s</v></c>`, ref, number)
+		return nil
+	}
+
+	fmt.Fprintf(w, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+	if err := xml.EscapeText(w, []byte(fmt.Sprint(value))); err != nil {
+		return fmt.Errorf("failed to write cell %s: %w", ref, err)
+	}
+	w.WriteString(`</t></is></c>`)
+	return nil
+}
+
+// xlsxCellRef returns the A1 reference of the cell at a zero-based column and one-based row
+func xlsxCellRef(column, row int) string {
+	var name []byte
+	for column++; column > 0; column = (column - 1) / 26 {
+		name = append([]byte{byte('A' + (column-1)%26)}, name...)
+	}
+	return string(name) + strconv.Itoa(row)
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+func TestArtifactRenderer_Diagrams(t *testing.T) {
+	var requestedPath, requestedSource string
+	kroki := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requestedPath, requestedSource = r.URL.Path, string(body)
+		if string(body) == "invalid" {
+			http.Error(w, "syntax error in diagram", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "image/svg+xml")
+		_, _ = w.Write([]byte("<svg/>"))
+	}))
+	defer kroki.Close()
+
+	t.Run("diagrams are rendered by the diagram server", func(t *testing.T) {
+		renderer := NewArtifactRenderer(config.ArtifactRenderConfig{DiagramURL: kroki.URL + "/"}, nil)
+		source := "@startuml\nAlice -> Bob: hello\n@enduml"
+		artifact, err := renderer.CreateDiagramArtifact(context.Background(), "ctx-1", "Login Flow", "", DiagramPlantUML, source, ImageFormatSVG)
+		require.NoError(t, err)
+		assert.Equal(t, "/plantuml/svg", requestedPath)
+		assert.Equal(t, source, requestedSource)
+
+		require.Len(t, artifact.Parts, 1)
+		file := artifact.Parts[0].File
+		require.NotNil(t, file)
+		assert.Equal(t, "login_flow.svg", file.Name)
+		assert.Equal(t, "image/svg+xml", file.MediaType)
+		require.NotNil(t, file.FileWithBytes)
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("<svg/>")), *file.FileWithBytes)
+	})
+
+	t.Run("rendering errors are reported", func(t *testing.T) {
+		renderer := NewArtifactRenderer(config.ArtifactRenderConfig{DiagramURL: kroki.URL}, nil)
+		_, err := renderer.RenderDiagram(context.Background(), DiagramMermaid, "invalid", ImageFormatPNG)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "syntax error in diagram")
+
+		_, err = renderer.RenderDiagram(context.Background(), "graphviz", "digraph {}", ImageFormatPNG)
+		assert.Error(t, err)
+	})
+
+	t.Run("rendering requires a diagram server", func(t *testing.T) {
+		renderer := NewArtifactRenderer(config.ArtifactRenderConfig{}, nil)
+		_, err := renderer.RenderDiagram(context.Background(), DiagramMermaid, "graph TD; A-->B", ImageFormatSVG)
+		assert.ErrorIs(t, err, ErrDiagramRenderingDisabled)
+	})
+}
+
+func TestArtifactRenderer_Tables(t *testing.T) {
+	renderer := NewArtifactRenderer(config.ArtifactRenderConfig{}, nil)
+	table := Table{
+		Columns: []string{"region", "sales", "target met"},
+		Rows: [][]any{
+			{"north, east", 1250.5, true},
+			{"south <b>", 980, nil},
+		},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		content, err := renderer.RenderTable(table, TableFormatCSV)
+		require.NoError(t, err)
+		assert.Equal(t, "region,sales,target met\n\"north, east\",1250.5,true\nsouth <b>,980,\n", string(content))
+	})
+
+	t.Run("xlsx", func(t *testing.T) {
+		content, err := renderer.RenderTable(table, TableFormatXLSX)
+		require.NoError(t, err)
+
+		archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+		require.NoError(t, err)
+		var sheet string
+		for _, file := range archive.File {
+			if file.Name == "xl/worksheets/sheet1.xml" {
+				reader, err := file.Open()
+				require.NoError(t, err)
+				data, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				sheet = string(data)
+			}
+		}
+		assert.Contains(t, sheet, `<c r="A1" t="inlineStr"><is><t xml:space="preserve">region</t></is></c>`)
+		assert.Contains(t, sheet, `<c r="B2"><v>1250.5</v></c>`)
+		assert.Contains(t, sheet, `<c r="C2" t="b"><v>1</v></c>`)
+		assert.Contains(t, sheet, `<t xml:space="preserve">south &lt;b&gt;</t>`)
+		assert.NotContains(t, sheet, `r="C3"`, "nil cells are left empty")
+	})
+
+	t.Run("stored through the artifact service", func(t *testing.T) {
+		service := newTestArtifactService(t, 0)
+		renderer := NewArtifactRenderer(config.ArtifactRenderConfig{}, service)
+		artifact, err := renderer.CreateTableArtifact(context.Background(), "ctx-1", "sales", "quarterly sales", table, TableFormatCSV)
+		require.NoError(t, err)
+		assert.Equal(t, "sales.csv", artifact.Parts[0].File.Name)
+		assert.Equal(t, "text/csv", artifact.Parts[0].File.MediaType)
+		assert.NotNil(t, artifact.Parts[0].File.FileWithURI)
+		assert.Contains(t, readArtifactFile(t, service, "ctx-1", artifact), "region,sales,target met\n")
+	})
+
+	_, err := renderer.RenderTable(table, "ods")
+	assert.Error(t, err)
+}
+
+func TestArtifactRenderer_Charts(t *testing.T) {
+	renderer := NewArtifactRenderer(config.ArtifactRenderConfig{}, nil)
+	tests := []struct {
+		name    string
+		chart   Chart
+		format  ImageFormat
+		want    []string
+		wantErr string
+	}{
+		{
+			name:   "line chart",
+			chart:  Chart{Kind: ChartLine, Title: "Latency <ms>", XLabel: "minute", YLabel: "ms", Series: []ChartSeries{{Name: "p50", Y: []float64{10, 12, 11}}, {Name: "p99", X: []float64{0, 1, 2}, Y: []float64{40, 55, 47}}}},
+			format: ImageFormatSVG,
+			want:   []string{`width="576" height="384"`, "<polyline points=", ">Latency &lt;ms&gt;</text>", ">minute</text>", ">ms</text>", ">p50</text>", ">p99</text>", ">60</text>"},
+		},
+		{
+			name:   "bar chart",
+			chart:  Chart{Kind: ChartBar, Categories: []string{"Q1", "Q2"}, Series: []ChartSeries{{Name: "2025", Y: []float64{3, -4}}, {Name: "2026", Y: []float64{5, 6}}}, Width: 3, Height: 2},
+			format: ImageFormatSVG,
+			want:   []string{`width="288" height="192"`, "<rect x=", ">Q1</text>", ">Q2</text>", ">0</text>", ">-4</text>"},
+		},
+		{
+			name:   "scatter chart",
+			chart:  Chart{Kind: ChartScatter, Series: []ChartSeries{{X: []float64{0.1, 0.2, 0.3}, Y: []float64{2, 4, 4}}}},
+			format: ImageFormatSVG,
+			want:   []string{"<circle cx=", ">0.3</text>"},
+		},
+		{name: "mismatched series", chart: Chart{Kind: ChartLine, Series: []ChartSeries{{X: []float64{1}, Y: []float64{1, 2}}}}, format: ImageFormatSVG, wantErr: "1 x values and 2 y values"},
+		{name: "value that is not finite", chart: Chart{Kind: ChartLine, Series: []ChartSeries{{Y: []float64{1, math.NaN()}}}}, format: ImageFormatSVG, wantErr: "not a finite number"},
+		{name: "no series", chart: Chart{Kind: ChartLine}, format: ImageFormatSVG, wantErr: "chart has no series"},
+		{name: "unknown kind", chart: Chart{Kind: "pie", Series: []ChartSeries{{Y: []float64{1}}}}, format: ImageFormatSVG, wantErr: `unsupported chart kind "pie"`},
+		{name: "png", chart: Chart{Kind: ChartLine, Series: []ChartSeries{{Y: []float64{1}}}}, format: ImageFormatPNG, wantErr: "charts can only be rendered to svg, not png"},
+		{name: "unknown format", chart: Chart{Kind: ChartLine, Series: []ChartSeries{{Y: []float64{1}}}}, format: "gif", wantErr: `unsupported image format "gif"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image, err := renderer.RenderChart(tt.chart, tt.format)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, xml.Unmarshal(image, new(struct{})), "the image is well-formed XML")
+			assert.True(t, bytes.HasPrefix(image, []byte("<?xml")))
+			for _, want := range tt.want {
+				assert.Contains(t, string(image), want)
+			}
+		})
+	}
+}
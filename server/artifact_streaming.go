@@ -0,0 +1,106 @@
+package server
+
+import (
+	"unicode/utf8"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// DefaultArtifactChunkSize is the maximum number of text bytes carried by a single
+// artifact-update event before an artifact is split into appended chunks
+const DefaultArtifactChunkSize = 16 * 1024
+
+// ChunkArtifactUpdates splits an artifact into one or more TaskArtifactUpdateEvents.
+// Small artifacts produce a single event; larger ones are split so that the first event
+// carries the artifact descriptors and subsequent events set append, the final one lastChunk.
+func ChunkArtifactUpdates(taskID, contextID string, artifact types.Artifact, chunkSize int) []types.TaskArtifactUpdateEvent {
+	if chunkSize <= 0 {
+		chunkSize = DefaultArtifactChunkSize
+	}
+
+	if artifactTextSize(artifact) <= chunkSize {
+		return []types.TaskArtifactUpdateEvent{
+			newArtifactUpdate(taskID, contextID, artifact, false, true),
+		}
+	}
+
+	var chunks [][]types.Part
+	for _, part := range artifact.Parts {
+		if part.Text == nil || len(*part.Text) <= chunkSize {
+			chunks = append(chunks, []types.Part{part})
+			continue
+		}
+		for _, text := range splitText(*part.Text, chunkSize) {
+			chunks = append(chunks, []types.Part{{Text: &text, Metadata: part.Metadata}})
+		}
+	}
+
+	updates := make([]types.TaskArtifactUpdateEvent, 0, len(chunks))
+	for i, parts := range chunks {
+		chunk := types.Artifact{
+			ArtifactID: artifact.ArtifactID,
+			Name:       artifact.Name,
+			Parts:      parts,
+		}
+		if i == 0 {
+			chunk.Description = artifact.Description
+			chunk.Extensions = artifact.Extensions
+			chunk.Metadata = artifact.Metadata
+		}
+		updates = append(updates, newArtifactUpdate(taskID, contextID, chunk, i > 0, i == len(chunks)-1))
+	}
+
+	return updates
+}
+
+// newArtifactUpdate builds a TaskArtifactUpdateEvent with the given chunk flags
+func newArtifactUpdate(taskID, contextID string, artifact types.Artifact, appendChunk, lastChunk bool) types.TaskArtifactUpdateEvent {
+	return types.TaskArtifactUpdateEvent{
+		TaskID:    taskID,
+		ContextID: contextID,
+		Artifact:  artifact,
+		Append:    &appendChunk,
+		LastChunk: &lastChunk,
+	}
+}
+
+// artifactTextSize returns the total number of text bytes in an artifact
+func artifactTextSize(artifact types.Artifact) int {
+	size := 0
+	for _, part := range artifact.Parts {
+		if part.Text != nil {
+			size += len(*part.Text)
+		}
+	}
+	return size
+}
+
+// splitText splits text into pieces of at most size bytes without breaking UTF-8 runes
+func splitText(text string, size int) []string {
+	var pieces []string
+	for len(text) > size {
+		cut := size
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		if cut == 0 {
+			cut = size
+		}
+		pieces = append(pieces, text[:cut])
+		text = text[cut:]
+	}
+	if len(text) > 0 {
+		pieces = append(pieces, text)
+	}
+	return pieces
+}
+
+// hasArtifact reports whether the task already holds an artifact with the given ID
+func hasArtifact(task *types.Task, artifactID string) bool {
+	for _, artifact := range task.Artifacts {
+		if artifact.ArtifactID == artifactID {
+			return true
+		}
+	}
+	return false
+}
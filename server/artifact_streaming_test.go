@@ -0,0 +1,81 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestChunkArtifactUpdates(t *testing.T) {
+	name := "report"
+
+	tests := []struct {
+		name          string
+		artifact      types.Artifact
+		chunkSize     int
+		expectedTexts []string
+	}{
+		{
+			name: "small artifact is sent as a single final chunk",
+			artifact: types.Artifact{
+				ArtifactID: "artifact-1",
+				Name:       &name,
+				Parts:      []types.Part{types.CreateTextPart("hello")},
+			},
+			chunkSize:     10,
+			expectedTexts: []string{"hello"},
+		},
+		{
+			name: "large text part is split into appended chunks",
+			artifact: types.Artifact{
+				ArtifactID: "artifact-2",
+				Name:       &name,
+				Parts:      []types.Part{types.CreateTextPart("abcdefghij")},
+			},
+			chunkSize:     4,
+			expectedTexts: []string{"abcd", "efgh", "ij"},
+		},
+		{
+			name: "multibyte runes are not split",
+			artifact: types.Artifact{
+				ArtifactID: "artifact-3",
+				Parts:      []types.Part{types.CreateTextPart(strings.Repeat("é", 3))},
+			},
+			chunkSize:     3,
+			expectedTexts: []string{"é", "é", "é"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates := ChunkArtifactUpdates("task-1", "ctx-1", tt.artifact, tt.chunkSize)
+			require.Len(t, updates, len(tt.expectedTexts))
+
+			for i, update := range updates {
+				assert.Equal(t, "task-1", update.TaskID)
+				assert.Equal(t, "ctx-1", update.ContextID)
+				assert.Equal(t, tt.artifact.ArtifactID, update.Artifact.ArtifactID)
+				require.Len(t, update.Artifact.Parts, 1)
+				assert.Equal(t, tt.expectedTexts[i], *update.Artifact.Parts[0].Text)
+				require.NotNil(t, update.Append)
+				require.NotNil(t, update.LastChunk)
+				assert.Equal(t, i > 0, *update.Append)
+				assert.Equal(t, i == len(updates)-1, *update.LastChunk)
+			}
+		})
+	}
+}
+
+func TestChunkArtifactUpdates_DefaultChunkSize(t *testing.T) {
+	artifact := types.Artifact{
+		ArtifactID: "artifact-1",
+		Parts:      []types.Part{types.CreateTextPart(strings.Repeat("a", DefaultArtifactChunkSize+1))},
+	}
+
+	updates := ChunkArtifactUpdates("task-1", "ctx-1", artifact, 0)
+	assert.Len(t, updates, 2)
+}
@@ -2,14 +2,23 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"mime"
 	"net/http"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/inference-gateway/adk/server/config"
+	"github.com/inference-gateway/adk/server/middlewares"
+	"github.com/inference-gateway/adk/types"
+	sdkotel "go.opentelemetry.io/otel"
+	attribute "go.opentelemetry.io/otel/attribute"
+	metric "go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 )
 
@@ -20,8 +29,20 @@ type ArtifactsServer interface {
 
 	// Stop stops the artifacts server
 	Stop(ctx context.Context) error
+
+	// Handler returns the HTTP handler serving the artifact endpoints, for serving them on a
+	// listener of the caller's choosing or embedding them into an existing mux
+	Handler() http.Handler
+
+	// StartBackground starts the artifact cleanup Start runs besides serving HTTP. Use it with
+	// Handler when the caller owns the listener; Stop ends it and closes the artifact service.
+	StartBackground(ctx context.Context) error
 }
 
+// ArtifactAccessCheck decides whether the caller of a request may reach the artifact files of a
+// context. When it may not, the check answers the request and returns false.
+type ArtifactAccessCheck func(c *gin.Context, contextID string) bool
+
 // ArtifactsServerImpl implements the ArtifactsServer interface
 type ArtifactsServerImpl struct {
 	config          *config.ArtifactsConfig
@@ -31,6 +52,18 @@ type ArtifactsServerImpl struct {
 	router          *gin.Engine
 	cleanupTicker   *time.Ticker
 	stopCleanup     chan struct{}
+	metrics         artifactsMetrics
+	audit           *auditLog
+	httpMiddleware  []gin.HandlerFunc
+	accessCheck     ArtifactAccessCheck
+	started         bool
+}
+
+// artifactsMetrics holds the instruments recorded by the artifact cleanup process
+type artifactsMetrics struct {
+	removedCounter metric.Int64Counter
+	storedBytes    metric.Int64Gauge
+	storedFiles    metric.Int64Gauge
 }
 
 // NewArtifactsServer creates a new artifacts server instance with the provided service
@@ -40,21 +73,68 @@ func NewArtifactsServer(cfg *config.ArtifactsConfig, logger *zap.Logger, artifac
 		logger:          logger,
 		artifactService: artifactService,
 		stopCleanup:     make(chan struct{}),
+		metrics:         newArtifactsMetrics(logger),
 	}
 }
 
+// newArtifactsMetrics creates the artifact cleanup instruments from the global meter provider
+func newArtifactsMetrics(logger *zap.Logger) artifactsMetrics {
+	meter := sdkotel.GetMeterProvider().Meter("github.com/inference-gateway/adk/server/artifacts")
+	var metrics artifactsMetrics
+	var err error
+
+	if metrics.removedCounter, err = meter.Int64Counter(
+		"artifacts.cleanup.removed",
+		metric.WithDescription("Number of artifact files removed by the cleanup process"),
+		metric.WithUnit("{file}"),
+	); err != nil {
+		logger.Warn("failed to create artifacts removed counter", zap.Error(err))
+	}
+
+	if metrics.storedBytes, err = meter.Int64Gauge(
+		"artifacts.storage.size",
+		metric.WithDescription("Total size of stored artifact files after the last cleanup run"),
+		metric.WithUnit("By"),
+	); err != nil {
+		logger.Warn("failed to create artifacts storage size gauge", zap.Error(err))
+	}
+
+	if metrics.storedFiles, err = meter.Int64Gauge(
+		"artifacts.storage.files",
+		metric.WithDescription("Number of stored artifact files after the last cleanup run"),
+		metric.WithUnit("{file}"),
+	); err != nil {
+		logger.Warn("failed to create artifacts storage files gauge", zap.Error(err))
+	}
+
+	return metrics
+}
+
+// setAuditLogger records the artifact downloads through the audit logger
+func (s *ArtifactsServerImpl) setAuditLogger(auditLogger AuditLogger) {
+	s.audit = newAuditLog(auditLogger, s.logger)
+}
+
+// setAccessCheck checks that callers may reach the artifact files they download or delete
+func (s *ArtifactsServerImpl) setAccessCheck(check ArtifactAccessCheck) {
+	s.accessCheck = check
+}
+
+// useHTTPMiddleware appends middleware run on every request to the HTTP router
+func (s *ArtifactsServerImpl) useHTTPMiddleware(mw ...gin.HandlerFunc) {
+	s.httpMiddleware = append(s.httpMiddleware, mw...)
+}
+
 // Start starts the artifacts server
 func (s *ArtifactsServerImpl) Start(ctx context.Context) error {
 	if s.artifactService == nil {
 		return fmt.Errorf("artifact service must be set before starting artifacts server")
 	}
 
-	s.setupRouter()
-
 	addr := fmt.Sprintf("0.0.0.0:%s", s.config.ServerConfig.Port)
 	s.server = &http.Server{
 		Addr:           addr,
-		Handler:        s.router,
+		Handler:        s.Handler(),
 		ReadTimeout:    s.config.ServerConfig.ReadTimeout,
 		WriteTimeout:   s.config.ServerConfig.WriteTimeout,
 		IdleTimeout:    s.config.ServerConfig.IdleTimeout,
@@ -63,15 +143,24 @@ func (s *ArtifactsServerImpl) Start(ctx context.Context) error {
 
 	s.logger.Info("starting artifacts server", zap.String("address", addr))
 
-	s.startCleanupProcess(ctx)
+	tlsConfig := s.config.ServerConfig.TLSConfig
+	if tlsConfig.Enable {
+		certificates, err := newCertificateStore(tlsConfig, httpNextProtos(true), s.logger)
+		if err != nil {
+			return err
+		}
+		s.server.TLSConfig = certificates.serverConfig()
+		go certificates.watch(ctx, tlsConfig.ReloadInterval)
+	}
+
+	if err := s.StartBackground(ctx); err != nil {
+		return err
+	}
 
 	errChan := make(chan error, 1)
 	go func() {
-		if s.config.ServerConfig.TLSConfig.Enable {
-			errChan <- s.server.ListenAndServeTLS(
-				s.config.ServerConfig.TLSConfig.CertPath,
-				s.config.ServerConfig.TLSConfig.KeyPath,
-			)
+		if tlsConfig.Enable {
+			errChan <- s.server.ListenAndServeTLS("", "")
 		} else {
 			errChan <- s.server.ListenAndServe()
 		}
@@ -89,9 +178,25 @@ func (s *ArtifactsServerImpl) Start(ctx context.Context) error {
 	}
 }
 
+// Handler returns the HTTP handler serving the artifact endpoints
+func (s *ArtifactsServerImpl) Handler() http.Handler {
+	s.setupRouter()
+	return s.router
+}
+
+// StartBackground starts the artifact cleanup without serving HTTP
+func (s *ArtifactsServerImpl) StartBackground(ctx context.Context) error {
+	if s.artifactService == nil {
+		return fmt.Errorf("artifact service must be set before starting artifacts server")
+	}
+	s.started = true
+	s.startCleanupProcess(ctx)
+	return nil
+}
+
 // Stop stops the artifacts server
 func (s *ArtifactsServerImpl) Stop(ctx context.Context) error {
-	if s.server == nil {
+	if s.server == nil && !s.started {
 		return nil
 	}
 
@@ -99,13 +204,14 @@ func (s *ArtifactsServerImpl) Stop(ctx context.Context) error {
 
 	s.stopCleanupProcess()
 
-	shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
+	if s.server != nil {
+		shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
 
-	err := s.server.Shutdown(shutdownCtx)
-	if err != nil {
-		s.logger.Error("failed to gracefully shutdown artifacts server", zap.Error(err))
-		return err
+		if err := s.server.Shutdown(shutdownCtx); err != nil {
+			s.logger.Error("failed to gracefully shutdown artifacts server", zap.Error(err))
+			return err
+		}
 	}
 
 	if s.artifactService != nil {
@@ -114,6 +220,10 @@ func (s *ArtifactsServerImpl) Stop(ctx context.Context) error {
 		}
 	}
 
+	if err := s.audit.close(); err != nil {
+		s.logger.Error("failed to close audit logger", zap.Error(err))
+	}
+
 	s.logger.Info("artifacts server stopped")
 	return nil
 }
@@ -127,10 +237,26 @@ func (s *ArtifactsServerImpl) setupRouter() {
 	s.router = gin.New()
 	s.router.Use(gin.Recovery())
 	s.router.Use(s.loggingMiddleware())
+	s.router.Use(middlewares.RequestIDMiddleware())
+	if s.config != nil && s.config.ServerConfig.CORS.Enable {
+		s.router.Use(middlewares.CORSMiddleware(s.config.ServerConfig.CORS))
+	}
+	if len(s.httpMiddleware) > 0 {
+		s.router.Use(s.httpMiddleware...)
+	}
 
 	s.router.GET("/health", s.handleHealth)
 
 	s.router.GET("/artifacts/:contextId/:artifactId/:filename", s.handleArtifactDownload)
+
+	if s.config != nil && s.config.ServerConfig.EnableUpload {
+		s.router.PUT("/artifacts/:contextId/:artifactId/:filename", s.handleArtifactUpload)
+	}
+
+	if s.config != nil && s.config.ServerConfig.EnableDelete {
+		s.router.DELETE("/artifacts/:contextId/:artifactId", s.handleArtifactDelete)
+		s.router.DELETE("/artifacts/:contextId/:artifactId/:filename", s.handleArtifactDelete)
+	}
 }
 
 // loggingMiddleware provides request logging
@@ -167,6 +293,9 @@ func (s *ArtifactsServerImpl) handleArtifactDownload(c *gin.Context) {
 		})
 		return
 	}
+	if s.accessCheck != nil && !s.accessCheck(c, contextID) {
+		return
+	}
 
 	ctx := c.Request.Context()
 	exists, err := s.artifactService.Exists(ctx, contextID, artifactID, filename)
@@ -190,6 +319,7 @@ func (s *ArtifactsServerImpl) handleArtifactDownload(c *gin.Context) {
 	}
 
 	reader, err := s.artifactService.Retrieve(ctx, contextID, artifactID, filename)
+	s.audit.artifactAccess(c, contextID, artifactID, filename, err)
 	if err != nil {
 		s.logger.Error("failed to retrieve artifact",
 			zap.String("context_id", contextID),
@@ -212,12 +342,183 @@ func (s *ArtifactsServerImpl) handleArtifactDownload(c *gin.Context) {
 		contentType = "application/octet-stream"
 	}
 
+	checksum, err := s.artifactService.Checksum(ctx, contextID, artifactID, filename)
+	if err != nil {
+		s.logger.Warn("failed to compute artifact checksum",
+			zap.String("context_id", contextID),
+			zap.String("artifact_id", artifactID),
+			zap.String("filename", filename),
+			zap.Error(err))
+	}
+	if checksum != "" {
+		c.Header(types.ArtifactChecksumHeader, checksum)
+		c.Header("ETag", fmt.Sprintf("%q", checksum))
+	}
+
 	c.Header("Content-Type", contentType)
 	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
 
 	c.DataFromReader(http.StatusOK, -1, contentType, reader, nil)
 }
 
+// handleArtifactUpload stores the request body as an artifact file, so clients can stage large
+// files and reference them by URI instead of inlining them in a message
+func (s *ArtifactsServerImpl) handleArtifactUpload(c *gin.Context) {
+	contextID := c.Param("contextId")
+	artifactID := c.Param("artifactId")
+	filename := c.Param("filename")
+
+	if contextID == "" || artifactID == "" || filename == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "context ID, artifact ID and filename are required",
+		})
+		return
+	}
+
+	maxSize := s.config.ServerConfig.MaxUploadSize
+	if maxSize > 0 && c.Request.ContentLength > maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error": fmt.Sprintf("upload exceeds the maximum size of %d bytes", maxSize),
+		})
+		return
+	}
+
+	body := io.Reader(c.Request.Body)
+	if maxSize > 0 {
+		body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize)
+	}
+
+	ctx := c.Request.Context()
+	uri, checksum, err := s.artifactService.StoreFile(ctx, contextID, artifactID, filename, body)
+	if err != nil {
+		if deleteErr := s.artifactService.Delete(ctx, contextID, artifactID, filename); deleteErr != nil {
+			s.logger.Debug("failed to remove partial upload", zap.Error(deleteErr))
+		}
+
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error": fmt.Sprintf("upload exceeds the maximum size of %d bytes", maxSize),
+			})
+			return
+		}
+
+		s.logger.Error("failed to store uploaded artifact",
+			zap.String("context_id", contextID),
+			zap.String("artifact_id", artifactID),
+			zap.String("filename", filename),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to store artifact",
+		})
+		return
+	}
+
+	if expected := c.GetHeader(types.ArtifactChecksumHeader); expected != "" && !strings.EqualFold(expected, checksum) {
+		if deleteErr := s.artifactService.Delete(ctx, contextID, artifactID, filename); deleteErr != nil {
+			s.logger.Warn("failed to remove corrupted upload", zap.Error(deleteErr))
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "checksum mismatch",
+		})
+		return
+	}
+
+	s.logger.Info("artifact uploaded",
+		zap.String("context_id", contextID),
+		zap.String("artifact_id", artifactID),
+		zap.String("filename", filename))
+
+	c.Header(types.ArtifactChecksumHeader, checksum)
+	c.JSON(http.StatusCreated, types.ArtifactUploadResponse{
+		URI:    uri,
+		SHA256: checksum,
+	})
+}
+
+// handleArtifactDelete removes a single artifact file, or every file of an artifact when no filename is given
+func (s *ArtifactsServerImpl) handleArtifactDelete(c *gin.Context) {
+	contextID := c.Param("contextId")
+	artifactID := c.Param("artifactId")
+	filename := c.Param("filename")
+
+	if contextID == "" || artifactID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "context ID and artifact ID are required",
+		})
+		return
+	}
+	if s.accessCheck != nil && !s.accessCheck(c, contextID) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	filenames := []string{filename}
+	if filename == "" {
+		stored, err := s.artifactService.ListStoredArtifacts(ctx)
+		if err != nil {
+			s.logger.Error("failed to list artifacts for deletion",
+				zap.String("context_id", contextID),
+				zap.String("artifact_id", artifactID),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to list artifacts",
+			})
+			return
+		}
+
+		filenames = filenames[:0]
+		for _, artifact := range stored {
+			if artifact.ContextID == contextID && artifact.ArtifactID == artifactID {
+				filenames = append(filenames, artifact.Filename)
+			}
+		}
+	} else {
+		exists, err := s.artifactService.Exists(ctx, contextID, artifactID, filename)
+		if err != nil {
+			s.logger.Error("failed to check artifact existence",
+				zap.String("context_id", contextID),
+				zap.String("artifact_id", artifactID),
+				zap.String("filename", filename),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to check artifact existence",
+			})
+			return
+		}
+		if !exists {
+			filenames = nil
+		}
+	}
+
+	if len(filenames) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "artifact not found",
+		})
+		return
+	}
+
+	for _, name := range filenames {
+		if err := s.artifactService.Delete(ctx, contextID, artifactID, name); err != nil {
+			s.logger.Error("failed to delete artifact",
+				zap.String("context_id", contextID),
+				zap.String("artifact_id", artifactID),
+				zap.String("filename", name),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to delete artifact",
+			})
+			return
+		}
+	}
+
+	s.logger.Info("artifact deleted",
+		zap.String("context_id", contextID),
+		zap.String("artifact_id", artifactID),
+		zap.Int("files", len(filenames)))
+	c.Status(http.StatusNoContent)
+}
+
 // startCleanupProcess starts the background artifact cleanup process
 func (s *ArtifactsServerImpl) startCleanupProcess(ctx context.Context) {
 	cleanupInterval := s.config.RetentionConfig.CleanupInterval
@@ -267,6 +568,7 @@ func (s *ArtifactsServerImpl) performCleanup(ctx context.Context) {
 			s.logger.Error("failed to cleanup expired artifacts", zap.Error(err))
 		} else {
 			totalRemoved += removed
+			s.recordRemoved(ctx, "max_age", removed)
 			s.logger.Debug("cleaned up expired artifacts",
 				zap.Int("removed_count", removed),
 				zap.Duration("max_age", retentionConfig.MaxAge))
@@ -279,13 +581,83 @@ func (s *ArtifactsServerImpl) performCleanup(ctx context.Context) {
 			s.logger.Error("failed to cleanup oldest artifacts", zap.Error(err))
 		} else {
 			totalRemoved += removed
+			s.recordRemoved(ctx, "max_artifacts", removed)
 			s.logger.Debug("cleaned up oldest artifacts",
 				zap.Int("removed_count", removed),
 				zap.Int("max_artifacts", retentionConfig.MaxArtifacts))
 		}
 	}
 
+	if retentionConfig.MaxContextSize > 0 || retentionConfig.MaxTotalSize > 0 {
+		removed, err := s.artifactService.CleanupArtifactsOverQuota(ctx, retentionConfig.MaxContextSize, retentionConfig.MaxTotalSize)
+		if err != nil {
+			s.logger.Error("failed to cleanup artifacts over quota", zap.Error(err))
+		} else {
+			totalRemoved += removed
+			s.recordRemoved(ctx, "quota", removed)
+			s.logger.Debug("cleaned up artifacts over quota",
+				zap.Int("removed_count", removed),
+				zap.Int64("max_context_size", retentionConfig.MaxContextSize),
+				zap.Int64("max_total_size", retentionConfig.MaxTotalSize))
+		}
+	}
+
+	s.recordStorageUsage(ctx)
+
 	if totalRemoved > 0 {
 		s.logger.Info("artifact cleanup completed", zap.Int("total_removed", totalRemoved))
 	}
 }
+
+// recordRemoved records the number of artifact files removed by a cleanup policy
+func (s *ArtifactsServerImpl) recordRemoved(ctx context.Context, policy string, removed int) {
+	if s.metrics.removedCounter == nil || removed == 0 {
+		return
+	}
+	s.metrics.removedCounter.Add(ctx, int64(removed), metric.WithAttributes(attribute.String("policy", policy)))
+}
+
+// recordStorageUsage records the current artifact storage footprint
+func (s *ArtifactsServerImpl) recordStorageUsage(ctx context.Context) {
+	if s.metrics.storedBytes == nil || s.metrics.storedFiles == nil {
+		return
+	}
+
+	artifacts, err := s.artifactService.ListStoredArtifacts(ctx)
+	if err != nil {
+		s.logger.Warn("failed to list artifacts for storage metrics", zap.Error(err))
+		return
+	}
+
+	var totalSize int64
+	for _, artifact := range artifacts {
+		totalSize += artifact.Size
+	}
+	s.metrics.storedBytes.Record(ctx, totalSize)
+	s.metrics.storedFiles.Record(ctx, int64(len(artifacts)))
+}
+
+// withArtifactUploadExtension returns a copy of the agent card advertising the artifacts
+// server upload endpoint, so clients can stage large files instead of inlining them
+func withArtifactUploadExtension(card types.AgentCard, uploadURL string) types.AgentCard {
+	if _, exists := types.GetArtifactUploadURL(&card); exists {
+		return card
+	}
+
+	params := map[string]any{"url": uploadURL}
+	card.Capabilities.Extensions = append(slices.Clone(card.Capabilities.Extensions), types.AgentExtension{
+		URI:         types.ArtifactUploadExtensionURI,
+		Description: "Files can be uploaded to the artifacts server and referenced by URI",
+		Params:      &params,
+	})
+	return card
+}
+
+// artifactUploadURL returns the public URL of the artifacts server upload endpoint
+func artifactUploadURL(cfg *config.ArtifactsConfig) string {
+	baseURL := artifactsServerURL(cfg)
+	if cfg.StorageConfig.Provider == "filesystem" && cfg.StorageConfig.BaseURL != "" {
+		baseURL = cfg.StorageConfig.BaseURL
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/artifacts"
+}
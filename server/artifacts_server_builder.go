@@ -3,6 +3,7 @@ package server
 import (
 	"fmt"
 
+	"github.com/gin-gonic/gin"
 	"github.com/inference-gateway/adk/server/config"
 	"go.uber.org/zap"
 )
@@ -22,6 +23,17 @@ type ArtifactsServerBuilder interface {
 	// WithArtifactService sets a pre-configured artifact service for the server.
 	WithArtifactService(service ArtifactService) ArtifactsServerBuilder
 
+	// WithAuditLogger records every artifact download with its caller through the audit logger
+	WithAuditLogger(auditLogger AuditLogger) ArtifactsServerBuilder
+
+	// WithHTTPMiddleware appends middleware run on every request to the HTTP router, after the
+	// built-in request ID and CORS middleware
+	WithHTTPMiddleware(mw ...gin.HandlerFunc) ArtifactsServerBuilder
+
+	// WithAccessCheck checks that callers may reach the artifact files they download or
+	// delete. A suite checks them against the users of the A2A server's tasks and contexts.
+	WithAccessCheck(check ArtifactAccessCheck) ArtifactsServerBuilder
+
 	// Build creates and returns the configured artifacts server
 	Build() (ArtifactsServer, error)
 }
@@ -35,6 +47,9 @@ type ArtifactsServerBuilderImpl struct {
 	config          *config.ArtifactsConfig
 	logger          *zap.Logger
 	artifactService ArtifactService
+	auditLogger     AuditLogger
+	httpMiddleware  []gin.HandlerFunc
+	accessCheck     ArtifactAccessCheck
 }
 
 // NewArtifactsServerBuilder creates a new artifacts server builder with required dependencies.
@@ -82,6 +97,24 @@ func (b *ArtifactsServerBuilderImpl) WithArtifactService(service ArtifactService
 	return b
 }
 
+// WithAuditLogger sets the audit logger recording the artifact downloads
+func (b *ArtifactsServerBuilderImpl) WithAuditLogger(auditLogger AuditLogger) ArtifactsServerBuilder {
+	b.auditLogger = auditLogger
+	return b
+}
+
+// WithHTTPMiddleware appends middleware run on every request to the HTTP router
+func (b *ArtifactsServerBuilderImpl) WithHTTPMiddleware(mw ...gin.HandlerFunc) ArtifactsServerBuilder {
+	b.httpMiddleware = append(b.httpMiddleware, mw...)
+	return b
+}
+
+// WithAccessCheck sets the check of the callers downloading or deleting artifact files
+func (b *ArtifactsServerBuilderImpl) WithAccessCheck(check ArtifactAccessCheck) ArtifactsServerBuilder {
+	b.accessCheck = check
+	return b
+}
+
 // Build creates and returns the configured artifacts server
 func (b *ArtifactsServerBuilderImpl) Build() (ArtifactsServer, error) {
 	if b.config == nil {
@@ -104,5 +137,15 @@ func (b *ArtifactsServerBuilderImpl) Build() (ArtifactsServer, error) {
 		}
 	}
 
-	return NewArtifactsServer(b.config, b.logger, artifactService), nil
+	server := NewArtifactsServer(b.config, b.logger, artifactService).(*ArtifactsServerImpl)
+	if b.auditLogger != nil {
+		server.setAuditLogger(b.auditLogger)
+	}
+	if len(b.httpMiddleware) > 0 {
+		server.useHTTPMiddleware(b.httpMiddleware...)
+	}
+	if b.accessCheck != nil {
+		server.setAccessCheck(b.accessCheck)
+	}
+	return server, nil
 }
@@ -2,9 +2,13 @@ package server_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -19,6 +23,7 @@ import (
 
 	server "github.com/inference-gateway/adk/server"
 	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
 )
 
 func init() {
@@ -138,6 +143,7 @@ func TestArtifactsServer_ArtifactDownload(t *testing.T) {
 		}
 		return nil, fmt.Errorf("artifact not found")
 	}
+	mockService.ChecksumReturns("2362660f9e876799563237b854032040dd853aee58f6d135884c5f3f63712183", nil)
 
 	srv := server.NewArtifactsServer(cfg, logger, mockService)
 
@@ -161,6 +167,7 @@ func TestArtifactsServer_ArtifactDownload(t *testing.T) {
 	assert.Equal(t, testContent, string(body))
 
 	assert.Equal(t, "text/plain; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "2362660f9e876799563237b854032040dd853aee58f6d135884c5f3f63712183", resp.Header.Get(types.ArtifactChecksumHeader))
 }
 
 func TestArtifactsServer_ArtifactNotFound(t *testing.T) {
@@ -262,3 +269,263 @@ func TestArtifactsServer_StorageError(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, string(body), "failed to check artifact existence")
 }
+
+func TestArtifactsServer_ArtifactDelete(t *testing.T) {
+	logger := zaptest.NewLogger(t, zaptest.Level(zap.WarnLevel))
+
+	tests := []struct {
+		name           string
+		enableDelete   bool
+		port           string
+		path           string
+		expectedStatus int
+		expectedFiles  []string
+	}{
+		{
+			name:           "delete disabled by default",
+			port:           "8089",
+			path:           "/artifacts/test-context/test-artifact/test.txt",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "delete single file",
+			enableDelete:   true,
+			port:           "8090",
+			path:           "/artifacts/test-context/test-artifact/test.txt",
+			expectedStatus: http.StatusNoContent,
+			expectedFiles:  []string{"test.txt"},
+		},
+		{
+			name:           "delete all files of an artifact",
+			enableDelete:   true,
+			port:           "8091",
+			path:           "/artifacts/test-context/test-artifact",
+			expectedStatus: http.StatusNoContent,
+			expectedFiles:  []string{"a.txt", "b.txt"},
+		},
+		{
+			name:           "unknown artifact",
+			enableDelete:   true,
+			port:           "8092",
+			path:           "/artifacts/test-context/missing",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.ArtifactsConfig{
+				Enable: true,
+				ServerConfig: config.ArtifactsServerConfig{
+					Port:         tt.port,
+					EnableDelete: tt.enableDelete,
+				},
+			}
+
+			mockService := &mocks.FakeArtifactService{}
+			mockService.ExistsReturns(true, nil)
+			mockService.ListStoredArtifactsReturns([]server.ArtifactMetadata{
+				{ContextID: "test-context", ArtifactID: "test-artifact", Filename: "a.txt"},
+				{ContextID: "test-context", ArtifactID: "test-artifact", Filename: "b.txt"},
+				{ContextID: "other-context", ArtifactID: "test-artifact", Filename: "c.txt"},
+			}, nil)
+
+			srv := server.NewArtifactsServer(cfg, logger, mockService)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			go func() {
+				_ = srv.Start(ctx)
+			}()
+
+			time.Sleep(100 * time.Millisecond)
+
+			req, err := http.NewRequest(http.MethodDelete, "http://localhost:"+tt.port+tt.path, nil)
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, len(tt.expectedFiles), mockService.DeleteCallCount())
+			for i, filename := range tt.expectedFiles {
+				_, contextID, artifactID, deleted := mockService.DeleteArgsForCall(i)
+				assert.Equal(t, "test-context", contextID)
+				assert.Equal(t, "test-artifact", artifactID)
+				assert.Equal(t, filename, deleted)
+			}
+		})
+	}
+}
+
+func TestArtifactsServerUpload(t *testing.T) {
+	logger := zaptest.NewLogger(t, zaptest.Level(zap.ErrorLevel))
+	content := "hello world"
+	digest := sha256.Sum256([]byte(content))
+	checksum := hex.EncodeToString(digest[:])
+
+	tests := []struct {
+		name           string
+		enableUpload   bool
+		maxUploadSize  int64
+		port           string
+		checksumHeader string
+		expectedStatus int
+		expectStored   bool
+		expectDeleted  bool
+	}{
+		{
+			name:           "upload disabled",
+			port:           "8095",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "stores uploaded file",
+			enableUpload:   true,
+			port:           "8096",
+			checksumHeader: checksum,
+			expectedStatus: http.StatusCreated,
+			expectStored:   true,
+		},
+		{
+			name:           "rejects file over the size limit",
+			enableUpload:   true,
+			maxUploadSize:  4,
+			port:           "8097",
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "rejects checksum mismatch",
+			enableUpload:   true,
+			port:           "8098",
+			checksumHeader: strings.Repeat("0", 64),
+			expectedStatus: http.StatusBadRequest,
+			expectStored:   true,
+			expectDeleted:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.ArtifactsConfig{
+				Enable: true,
+				ServerConfig: config.ArtifactsServerConfig{
+					Port:          tt.port,
+					EnableUpload:  tt.enableUpload,
+					MaxUploadSize: tt.maxUploadSize,
+				},
+			}
+
+			var stored string
+			mockService := &mocks.FakeArtifactService{}
+			mockService.StoreFileStub = func(_ context.Context, contextID, artifactID, filename string, data io.Reader) (string, string, error) {
+				body, err := io.ReadAll(data)
+				if err != nil {
+					return "", "", err
+				}
+				stored = string(body)
+				sum := sha256.Sum256(body)
+				return fmt.Sprintf("http://localhost:%s/artifacts/%s/%s/%s", tt.port, contextID, artifactID, filename), hex.EncodeToString(sum[:]), nil
+			}
+
+			srv := server.NewArtifactsServer(cfg, logger, mockService)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			go func() {
+				_ = srv.Start(ctx)
+			}()
+
+			time.Sleep(100 * time.Millisecond)
+
+			req, err := http.NewRequest(http.MethodPut, "http://localhost:"+tt.port+"/artifacts/test-context/test-artifact/hello.txt", strings.NewReader(content))
+			require.NoError(t, err)
+			if tt.checksumHeader != "" {
+				req.Header.Set(types.ArtifactChecksumHeader, tt.checksumHeader)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectStored, mockService.StoreFileCallCount() == 1)
+			assert.Equal(t, tt.expectDeleted, mockService.DeleteCallCount() == 1)
+
+			if tt.expectedStatus == http.StatusCreated {
+				assert.Equal(t, content, stored)
+				assert.Equal(t, checksum, resp.Header.Get(types.ArtifactChecksumHeader))
+
+				var uploaded types.ArtifactUploadResponse
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&uploaded))
+				assert.Equal(t, checksum, uploaded.SHA256)
+				assert.Equal(t, "http://localhost:"+tt.port+"/artifacts/test-context/test-artifact/hello.txt", uploaded.URI)
+			}
+		})
+	}
+}
+
+func TestArtifactUploadAdvertisement(t *testing.T) {
+	tests := []struct {
+		name        string
+		artifacts   config.ArtifactsConfig
+		expectedURL string
+	}{
+		{
+			name: "uploads disabled",
+			artifacts: config.ArtifactsConfig{
+				Enable: true,
+			},
+		},
+		{
+			name: "derived from the artifacts server address",
+			artifacts: config.ArtifactsConfig{
+				Enable:       true,
+				ServerConfig: config.ArtifactsServerConfig{Host: "artifacts.local", Port: "9000", EnableUpload: true},
+			},
+			expectedURL: "http://artifacts.local:9000/artifacts",
+		},
+		{
+			name: "public base url of filesystem storage",
+			artifacts: config.ArtifactsConfig{
+				Enable:        true,
+				ServerConfig:  config.ArtifactsServerConfig{EnableUpload: true},
+				StorageConfig: config.ArtifactsStorageConfig{Provider: "filesystem", BaseURL: "https://files.example.com/"},
+			},
+			expectedURL: "https://files.example.com/artifacts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{ArtifactsConfig: tt.artifacts}
+			a2aServer := server.NewA2AServer(cfg, zap.NewNop(), nil)
+			a2aServer.SetAgentCard(types.AgentCard{Name: "agent"})
+
+			uploadURL, advertised := types.GetArtifactUploadURL(a2aServer.GetAgentCard())
+			assert.Equal(t, tt.expectedURL != "", advertised)
+			assert.Equal(t, tt.expectedURL, uploadURL)
+		})
+	}
+}
+
+func TestArtifactsServer_EmbeddedHandler(t *testing.T) {
+	cfg := &config.ArtifactsConfig{Enable: true}
+	mockService := &mocks.FakeArtifactService{}
+	mockService.ExistsReturns(true, nil)
+	mockService.RetrieveReturns(io.NopCloser(strings.NewReader("report")), nil)
+	srv := server.NewArtifactsServer(cfg, zap.NewNop(), mockService)
+
+	mux := http.NewServeMux()
+	mux.Handle("/files/", http.StripPrefix("/files", srv.Handler()))
+	require.NoError(t, srv.StartBackground(context.Background()))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/files/artifacts/ctx-1/art-1/report.txt", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "report", w.Body.String())
+
+	require.NoError(t, srv.Stop(context.Background()))
+	assert.Equal(t, 1, mockService.CloseCallCount(), "stopping an embedded server closes the artifact service")
+}
@@ -3,9 +3,12 @@ package server
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,9 +25,24 @@ type ArtifactService interface {
 
 	// CreateFileArtifact creates a file artifact with URI by storing the content.
 	// contextID is the A2A context (session) ID the artifact belongs to; it is
-	// used to group stored files by conversation.
+	// used to group stored files by conversation. The SHA-256 digest of the content
+	// is recorded in the artifact and file part metadata.
 	CreateFileArtifact(contextID, name, description, filename string, data []byte, mimeType *string) (types.Artifact, error)
 
+	// CreateFileArtifactFromReader creates a file artifact by streaming its content from the
+	// reader to storage, without holding it in memory. The content may not exceed the size
+	// limit of the metadata or of the service; a file over it is removed and
+	// ErrArtifactTooLarge returned.
+	CreateFileArtifactFromReader(ctx context.Context, meta FileArtifactMeta, data io.Reader) (types.Artifact, error)
+
+	// Writer returns a writer streaming the content of a file artifact to storage. The file
+	// is complete once the writer is closed, and Artifact then returns the artifact.
+	Writer(ctx context.Context, meta FileArtifactMeta) (ArtifactWriter, error)
+
+	// StoreFile stores file content uploaded by a client under the given context, artifact ID
+	// and filename, and returns its URI with the hex-encoded SHA-256 digest of the content
+	StoreFile(ctx context.Context, contextID, artifactID, filename string, data io.Reader) (string, string, error)
+
 	// CreateFileArtifactFromURI creates a file artifact from an existing URI
 	CreateFileArtifactFromURI(name, description, filename, uri string, mimeType *string) types.Artifact
 
@@ -68,6 +86,22 @@ type ArtifactService interface {
 	// CleanupOldestArtifacts removes oldest artifacts keeping only maxArtifacts
 	CleanupOldestArtifacts(ctx context.Context, maxArtifacts int) (int, error)
 
+	// CleanupArtifactsOverQuota removes the oldest artifact files until every context is within
+	// maxContextSize bytes and all artifacts together are within maxTotalSize bytes (0 = unlimited)
+	CleanupArtifactsOverQuota(ctx context.Context, maxContextSize, maxTotalSize int64) (int, error)
+
+	// Delete removes a single artifact file
+	Delete(ctx context.Context, contextID, artifactID, filename string) error
+
+	// ListStoredArtifacts returns metadata for every stored artifact file
+	ListStoredArtifacts(ctx context.Context) ([]ArtifactMetadata, error)
+
+	// Checksum returns the hex-encoded SHA-256 digest of a stored artifact file
+	Checksum(ctx context.Context, contextID, artifactID, filename string) (string, error)
+
+	// GetURL returns the URL a stored artifact file is downloaded from
+	GetURL(contextID, artifactID, filename string) string
+
 	// Close closes the artifact service and releases resources
 	Close() error
 }
@@ -75,8 +109,9 @@ type ArtifactService interface {
 // ArtifactServiceImpl is the concrete implementation of ArtifactService.
 // It encapsulates the storage dependency and provides a clean API for artifact creation.
 type ArtifactServiceImpl struct {
-	storage ArtifactStorageProvider
-	logger  *zap.Logger
+	storage     ArtifactStorageProvider
+	logger      *zap.Logger
+	maxFileSize int64
 }
 
 // NewArtifactService creates a new artifact service from configuration.
@@ -97,19 +132,7 @@ func NewArtifactService(cfg *config.ArtifactsConfig, logger *zap.Logger) (Artifa
 	// Generate base URL if not provided
 	storageConfig := cfg.StorageConfig
 	if storageConfig.BaseURL == "" {
-		scheme := "http"
-		if cfg.ServerConfig.TLSConfig.Enable {
-			scheme = "https"
-		}
-		host := cfg.ServerConfig.Host
-		if host == "" {
-			host = "localhost"
-		}
-		port := cfg.ServerConfig.Port
-		if port == "" {
-			port = "8081"
-		}
-		storageConfig.BaseURL = fmt.Sprintf("%s://%s:%s", scheme, host, port)
+		storageConfig.BaseURL = artifactsServerURL(cfg)
 	}
 
 	switch storageConfig.Provider {
@@ -136,11 +159,30 @@ func NewArtifactService(cfg *config.ArtifactsConfig, logger *zap.Logger) (Artifa
 	}
 
 	return &ArtifactServiceImpl{
-		storage: storage,
-		logger:  logger,
+		storage:     storage,
+		logger:      logger,
+		maxFileSize: storageConfig.MaxFileSize,
 	}, nil
 }
 
+// artifactsServerURL returns the URL the artifacts server is reachable at, derived from its
+// server configuration
+func artifactsServerURL(cfg *config.ArtifactsConfig) string {
+	scheme := "http"
+	if cfg.ServerConfig.TLSConfig.Enable {
+		scheme = "https"
+	}
+	host := cfg.ServerConfig.Host
+	if host == "" {
+		host = "localhost"
+	}
+	port := cfg.ServerConfig.Port
+	if port == "" {
+		port = "8081"
+	}
+	return fmt.Sprintf("%s://%s:%s", scheme, host, port)
+}
+
 // CreateTextArtifact creates a text artifact
 func (as *ArtifactServiceImpl) CreateTextArtifact(name, description, text string) types.Artifact {
 	return types.Artifact{
@@ -164,16 +206,33 @@ func (as *ArtifactServiceImpl) CreateFileArtifact(contextID, name, description,
 		return types.Artifact{}, fmt.Errorf("failed to store artifact: %w", err)
 	}
 
+	digest := sha256.Sum256(data)
+	checksum := hex.EncodeToString(digest[:])
+	artifactMetadata := types.Struct{types.ArtifactChecksumMetadataKey: checksum}
+
 	return types.Artifact{
 		ArtifactID:  artifactID,
 		Name:        &name,
 		Description: &description,
+		Metadata:    &artifactMetadata,
 		Parts: []types.Part{
-			types.CreateFilePart(filename, *mimeType, nil, &uri),
+			types.CreateFilePart(filename, *mimeType, nil, &uri, map[string]any{
+				types.ArtifactChecksumMetadataKey: checksum,
+			}),
 		},
 	}, nil
 }
 
+// StoreFile stores uploaded file content and returns its URI and SHA-256 digest
+func (as *ArtifactServiceImpl) StoreFile(ctx context.Context, contextID, artifactID, filename string, data io.Reader) (string, string, error) {
+	hasher := sha256.New()
+	uri, err := as.storage.Store(ctx, contextID, artifactID, filename, io.TeeReader(data, hasher))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to store file: %w", err)
+	}
+	return uri, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
 // CreateFileArtifactFromURI creates a file artifact from an existing URI
 func (as *ArtifactServiceImpl) CreateFileArtifactFromURI(name, description, filename, uri string, mimeType *string) types.Artifact {
 	mediaType := "application/octet-stream"
@@ -382,6 +441,88 @@ func (as *ArtifactServiceImpl) CleanupOldestArtifacts(ctx context.Context, maxAr
 	return as.storage.CleanupOldestArtifacts(ctx, maxArtifacts)
 }
 
+// CleanupArtifactsOverQuota removes the oldest artifact files until all size quotas are satisfied
+func (as *ArtifactServiceImpl) CleanupArtifactsOverQuota(ctx context.Context, maxContextSize, maxTotalSize int64) (int, error) {
+	if maxContextSize <= 0 && maxTotalSize <= 0 {
+		return 0, nil
+	}
+
+	artifacts, err := as.storage.ListArtifacts(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].UploadedAt.Before(artifacts[j].UploadedAt)
+	})
+
+	contextSizes := make(map[string]int64)
+	var totalSize int64
+	for _, artifact := range artifacts {
+		contextSizes[artifact.ContextID] += artifact.Size
+		totalSize += artifact.Size
+	}
+
+	removed := make([]bool, len(artifacts))
+	removedCount := 0
+	remove := func(i int) {
+		artifact := artifacts[i]
+		if err := as.storage.Delete(ctx, artifact.ContextID, artifact.ArtifactID, artifact.Filename); err != nil {
+			as.logger.Warn("failed to delete artifact over quota",
+				zap.String("context_id", artifact.ContextID),
+				zap.String("artifact_id", artifact.ArtifactID),
+				zap.String("filename", artifact.Filename),
+				zap.Error(err))
+			return
+		}
+		removed[i] = true
+		removedCount++
+		contextSizes[artifact.ContextID] -= artifact.Size
+		totalSize -= artifact.Size
+	}
+
+	if maxContextSize > 0 {
+		for i, artifact := range artifacts {
+			if contextSizes[artifact.ContextID] > maxContextSize {
+				remove(i)
+			}
+		}
+	}
+
+	if maxTotalSize > 0 {
+		for i := range artifacts {
+			if totalSize <= maxTotalSize {
+				break
+			}
+			if !removed[i] {
+				remove(i)
+			}
+		}
+	}
+
+	return removedCount, nil
+}
+
+// Delete removes a single artifact file from storage
+func (as *ArtifactServiceImpl) Delete(ctx context.Context, contextID, artifactID, filename string) error {
+	return as.storage.Delete(ctx, contextID, artifactID, filename)
+}
+
+// ListStoredArtifacts returns metadata for every stored artifact file
+func (as *ArtifactServiceImpl) ListStoredArtifacts(ctx context.Context) ([]ArtifactMetadata, error) {
+	return as.storage.ListArtifacts(ctx)
+}
+
+// Checksum returns the hex-encoded SHA-256 digest of a stored artifact file
+func (as *ArtifactServiceImpl) Checksum(ctx context.Context, contextID, artifactID, filename string) (string, error) {
+	return as.storage.Checksum(ctx, contextID, artifactID, filename)
+}
+
+// GetURL returns the URL a stored artifact file is downloaded from
+func (as *ArtifactServiceImpl) GetURL(contextID, artifactID, filename string) string {
+	return as.storage.GetURL(contextID, artifactID, filename)
+}
+
 // Close closes the artifact service and releases resources
 func (as *ArtifactServiceImpl) Close() error {
 	if as.storage != nil {
@@ -2,10 +2,16 @@ package server
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"time"
 )
 
+// artifactBlobDir is the reserved directory (or object prefix) that holds deduplicated
+// artifact content, addressed by its SHA-256 digest
+const artifactBlobDir = ".blobs"
+
 // ArtifactStorageProvider defines the interface for artifact storage backends.
 //
 // Artifacts are grouped by the A2A context (session) ID so files belonging to
@@ -34,13 +40,29 @@ type ArtifactStorageProvider interface {
 
 	// CleanupOldestArtifacts removes old artifacts keeping only maxCount per artifact ID
 	CleanupOldestArtifacts(ctx context.Context, maxCount int) (int, error)
+
+	// ListArtifacts returns metadata for every stored artifact file
+	ListArtifacts(ctx context.Context) ([]ArtifactMetadata, error)
+
+	// Checksum returns the hex-encoded SHA-256 digest of a stored artifact
+	Checksum(ctx context.Context, contextID string, artifactID string, filename string) (string, error)
 }
 
 // ArtifactMetadata holds metadata about stored artifacts
 type ArtifactMetadata struct {
+	ContextID   string    `json:"context_id"`
 	ArtifactID  string    `json:"artifact_id"`
 	Filename    string    `json:"filename"`
 	Size        int64     `json:"size"`
 	ContentType string    `json:"content_type"`
 	UploadedAt  time.Time `json:"uploaded_at"`
 }
+
+// checksumReader returns the hex-encoded SHA-256 digest of everything read from r
+func checksumReader(r io.Reader) (string, error) {
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -2,8 +2,12 @@ package server
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"os"
 	"path/filepath"
 	"sort"
@@ -13,10 +17,14 @@ import (
 	"github.com/inference-gateway/adk/server/config"
 )
 
+// blobUploadPrefix marks in-flight uploads inside the blob directory
+const blobUploadPrefix = "upload-"
+
 // FilesystemArtifactStorage implements ArtifactStorageProvider using local filesystem
 type FilesystemArtifactStorage struct {
-	basePath string
-	baseURL  string
+	basePath    string
+	baseURL     string
+	deduplicate bool
 }
 
 // NewFilesystemArtifactStorage creates a new filesystem-based artifact storage provider
@@ -28,8 +36,9 @@ func NewFilesystemArtifactStorage(cfg *config.ArtifactsStorageConfig) (*Filesyst
 	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
 
 	return &FilesystemArtifactStorage{
-		basePath: cfg.BasePath,
-		baseURL:  baseURL,
+		basePath:    cfg.BasePath,
+		baseURL:     baseURL,
+		deduplicate: cfg.Deduplicate,
 	}, nil
 }
 
@@ -39,7 +48,7 @@ func (fs *FilesystemArtifactStorage) Store(ctx context.Context, contextID string
 	artifactID = sanitizePath(artifactID)
 	filename = sanitizePath(filename)
 
-	if contextID == "" || artifactID == "" || filename == "" {
+	if contextID == "" || artifactID == "" || filename == "" || contextID == artifactBlobDir {
 		return "", fmt.Errorf("invalid context ID, artifact ID or filename")
 	}
 
@@ -49,6 +58,13 @@ func (fs *FilesystemArtifactStorage) Store(ctx context.Context, contextID string
 	}
 
 	filePath := filepath.Join(artifactDir, filename)
+	if fs.deduplicate {
+		if err := fs.storeDeduplicated(filePath, data); err != nil {
+			return "", err
+		}
+		return fs.GetURL(contextID, artifactID, filename), nil
+	}
+
 	file, err := os.Create(filePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to create artifact file: %w", err)
@@ -161,6 +177,9 @@ func (fs *FilesystemArtifactStorage) CleanupExpiredArtifacts(ctx context.Context
 		}
 
 		if info.IsDir() {
+			if info.Name() == artifactBlobDir {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 
@@ -197,7 +216,7 @@ func (fs *FilesystemArtifactStorage) CleanupOldestArtifacts(ctx context.Context,
 	}
 
 	for _, contextEntry := range contexts {
-		if !contextEntry.IsDir() {
+		if !contextEntry.IsDir() || contextEntry.Name() == artifactBlobDir {
 			continue
 		}
 
@@ -224,6 +243,192 @@ func (fs *FilesystemArtifactStorage) CleanupOldestArtifacts(ctx context.Context,
 	return removedCount, nil
 }
 
+// ListArtifacts returns metadata for every artifact file under the base path
+func (fs *FilesystemArtifactStorage) ListArtifacts(ctx context.Context) ([]ArtifactMetadata, error) {
+	contexts, err := os.ReadDir(fs.basePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifacts directory: %w", err)
+	}
+
+	var artifacts []ArtifactMetadata
+	for _, contextEntry := range contexts {
+		if !contextEntry.IsDir() || contextEntry.Name() == artifactBlobDir {
+			continue
+		}
+
+		contextDir := filepath.Join(fs.basePath, contextEntry.Name())
+		artifactEntries, err := os.ReadDir(contextDir)
+		if err != nil {
+			continue
+		}
+
+		for _, artifactEntry := range artifactEntries {
+			if !artifactEntry.IsDir() {
+				continue
+			}
+
+			files, err := os.ReadDir(filepath.Join(contextDir, artifactEntry.Name()))
+			if err != nil {
+				continue
+			}
+
+			for _, file := range files {
+				if file.IsDir() {
+					continue
+				}
+
+				info, err := file.Info()
+				if err != nil {
+					continue
+				}
+
+				artifacts = append(artifacts, ArtifactMetadata{
+					ContextID:   contextEntry.Name(),
+					ArtifactID:  artifactEntry.Name(),
+					Filename:    file.Name(),
+					Size:        info.Size(),
+					ContentType: mime.TypeByExtension(filepath.Ext(file.Name())),
+					UploadedAt:  info.ModTime(),
+				})
+			}
+		}
+	}
+
+	return artifacts, nil
+}
+
+// Checksum returns the SHA-256 digest of an artifact file by hashing its content
+func (fs *FilesystemArtifactStorage) Checksum(ctx context.Context, contextID string, artifactID string, filename string) (string, error) {
+	reader, err := fs.Retrieve(ctx, contextID, artifactID, filename)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	checksum, err := checksumReader(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to checksum artifact: %w", err)
+	}
+	return checksum, nil
+}
+
+// storeDeduplicated writes data into the content-addressed blob directory and hard-links
+// filePath to the blob, so identical content is stored once regardless of how many
+// artifacts reference it
+func (fs *FilesystemArtifactStorage) storeDeduplicated(filePath string, data io.Reader) error {
+	blobDir := filepath.Join(fs.basePath, artifactBlobDir)
+	if err := os.MkdirAll(blobDir, 0755); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(blobDir, blobUploadPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("failed to create artifact file: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	hasher := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, hasher), data)
+	closeErr := tmp.Close()
+	if err != nil || closeErr != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write artifact data: %w", errors.Join(err, closeErr))
+	}
+
+	blobPath := filepath.Join(blobDir, hex.EncodeToString(hasher.Sum(nil)))
+	if _, err := os.Stat(blobPath); err == nil {
+		_ = os.Remove(tmpPath)
+		now := time.Now()
+		_ = os.Chtimes(blobPath, now, now)
+	} else if err := os.Rename(tmpPath, blobPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to store artifact blob: %w", err)
+	}
+
+	_ = os.Remove(filePath)
+	if err := os.Link(blobPath, filePath); err != nil {
+		return copyFile(blobPath, filePath)
+	}
+	return nil
+}
+
+// pruneOrphanBlobs removes deduplicated blobs that are no longer linked from any artifact file
+func (fs *FilesystemArtifactStorage) pruneOrphanBlobs() {
+	blobDir := filepath.Join(fs.basePath, artifactBlobDir)
+	blobs, err := os.ReadDir(blobDir)
+	if err != nil {
+		return
+	}
+
+	blobsBySize := make(map[int64][]os.FileInfo)
+	for _, blob := range blobs {
+		if blob.IsDir() || strings.HasPrefix(blob.Name(), blobUploadPrefix) {
+			continue
+		}
+		info, err := blob.Info()
+		if err != nil {
+			continue
+		}
+		blobsBySize[info.Size()] = append(blobsBySize[info.Size()], info)
+	}
+	if len(blobsBySize) == 0 {
+		return
+	}
+
+	referenced := make(map[string]bool)
+	_ = filepath.Walk(fs.basePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if info.Name() == artifactBlobDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		for _, blob := range blobsBySize[info.Size()] {
+			if os.SameFile(blob, info) {
+				referenced[blob.Name()] = true
+			}
+		}
+		return nil
+	})
+
+	for _, candidates := range blobsBySize {
+		for _, blob := range candidates {
+			if !referenced[blob.Name()] {
+				_ = os.Remove(filepath.Join(blobDir, blob.Name()))
+			}
+		}
+	}
+}
+
+// copyFile copies src to dst, used when hard links are not supported by the filesystem
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open artifact blob: %w", err)
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("failed to create artifact file: %w", err)
+	}
+
+	_, err = io.Copy(out, in)
+	closeErr := out.Close()
+	if err != nil || closeErr != nil {
+		_ = os.Remove(dst)
+		return fmt.Errorf("failed to write artifact data: %w", errors.Join(err, closeErr))
+	}
+	return nil
+}
+
 // cleanupArtifactDirectory removes oldest files in a directory, keeping only maxCount files
 func (fs *FilesystemArtifactStorage) cleanupArtifactDirectory(artifactDir string, maxCount int) (int, error) {
 	files, err := os.ReadDir(artifactDir)
@@ -272,15 +477,20 @@ func (fs *FilesystemArtifactStorage) cleanupArtifactDirectory(artifactDir string
 	return removedCount, nil
 }
 
-// cleanupEmptyDirectories removes empty artifact and context directories
+// cleanupEmptyDirectories removes empty artifact and context directories, and any
+// deduplicated blobs left without references
 func (fs *FilesystemArtifactStorage) cleanupEmptyDirectories() {
+	if fs.deduplicate {
+		fs.pruneOrphanBlobs()
+	}
+
 	contexts, err := os.ReadDir(fs.basePath)
 	if err != nil {
 		return
 	}
 
 	for _, contextEntry := range contexts {
-		if !contextEntry.IsDir() {
+		if !contextEntry.IsDir() || contextEntry.Name() == artifactBlobDir {
 			continue
 		}
 
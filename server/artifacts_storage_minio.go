@@ -1,11 +1,16 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/inference-gateway/adk/server/config"
@@ -13,17 +18,29 @@ import (
 	"github.com/minio/minio-go/v7/pkg/credentials"
 )
 
-// MinIOArtifactStorage implements ArtifactStorageProvider using MinIO/S3
+// User metadata keys recorded on artifact objects
+const (
+	minioChecksumMetadataKey = "Sha256"
+	minioBlobMetadataKey     = "Blob"
+	minioSizeMetadataKey     = "Blob-Size"
+)
+
+// MinIOArtifactStorage implements ArtifactStorageProvider using MinIO/S3.
+// With deduplication enabled, content is stored once under .blobs/{sha256} and each
+// artifact object is an empty reference whose metadata points at the blob.
 type MinIOArtifactStorage struct {
-	client     *minio.Client
-	bucketName string
-	baseURL    string
+	client      *minio.Client
+	credentials *minioCredentials
+	bucketName  string
+	baseURL     string
+	deduplicate bool
 }
 
 // NewMinIOArtifactStorage creates a new MinIO-based artifact storage provider
 func NewMinIOArtifactStorage(cfg *config.ArtifactsStorageConfig) (*MinIOArtifactStorage, error) {
+	creds := newMinIOCredentials(cfg.AccessKey, cfg.SecretKey)
 	client, err := minio.New(cfg.Endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Creds:  creds.credentials,
 		Secure: cfg.UseSSL,
 	})
 	if err != nil {
@@ -31,9 +48,11 @@ func NewMinIOArtifactStorage(cfg *config.ArtifactsStorageConfig) (*MinIOArtifact
 	}
 
 	storage := &MinIOArtifactStorage{
-		client:     client,
-		bucketName: cfg.BucketName,
-		baseURL:    strings.TrimSuffix(cfg.BaseURL, "/"),
+		client:      client,
+		credentials: creds,
+		bucketName:  cfg.BucketName,
+		baseURL:     strings.TrimSuffix(cfg.BaseURL, "/"),
+		deduplicate: cfg.Deduplicate,
 	}
 
 	ctx := context.Background()
@@ -52,19 +71,123 @@ func NewMinIOArtifactStorage(cfg *config.ArtifactsStorageConfig) (*MinIOArtifact
 	return storage, nil
 }
 
+// SetCredentials replaces the access and secret keys signing the requests made from now on,
+// so rotated keys apply without a restart
+func (s *MinIOArtifactStorage) SetCredentials(accessKey, secretKey string) {
+	s.credentials.set(accessKey, secretKey)
+}
+
+// minioCredentials provides the static keys of a MinIO client until they are replaced, which
+// expires the credentials the client cached so the next request uses the new keys
+type minioCredentials struct {
+	mu          sync.RWMutex
+	value       credentials.Value
+	credentials *credentials.Credentials
+}
+
+// newMinIOCredentials creates the credentials of a MinIO client from its keys
+func newMinIOCredentials(accessKey, secretKey string) *minioCredentials {
+	creds := &minioCredentials{}
+	creds.value = minioCredentialsValue(accessKey, secretKey)
+	creds.credentials = credentials.New(creds)
+	return creds
+}
+
+// minioCredentialsValue returns the signature V4 credentials of the keys, anonymous when they
+// are empty
+func minioCredentialsValue(accessKey, secretKey string) credentials.Value {
+	signerType := credentials.SignatureV4
+	if accessKey == "" || secretKey == "" {
+		signerType = credentials.SignatureAnonymous
+	}
+	return credentials.Value{
+		AccessKeyID:     accessKey,
+		SecretAccessKey: secretKey,
+		SignerType:      signerType,
+	}
+}
+
+// set replaces the keys
+func (c *minioCredentials) set(accessKey, secretKey string) {
+	c.mu.Lock()
+	c.value = minioCredentialsValue(accessKey, secretKey)
+	c.mu.Unlock()
+	c.credentials.Expire()
+}
+
+// Retrieve returns the current keys
+func (c *minioCredentials) Retrieve() (credentials.Value, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.value, nil
+}
+
+// RetrieveWithCredContext returns the current keys
+func (c *minioCredentials) RetrieveWithCredContext(*credentials.CredContext) (credentials.Value, error) {
+	return c.Retrieve()
+}
+
+// IsExpired reports false, the keys stay valid until they are replaced
+func (c *minioCredentials) IsExpired() bool {
+	return false
+}
+
 // Store stores an artifact to MinIO
 func (m *MinIOArtifactStorage) Store(ctx context.Context, contextID string, artifactID string, filename string, data io.Reader) (string, error) {
 	contextID = sanitizePath(contextID)
 	artifactID = sanitizePath(artifactID)
 	filename = sanitizePath(filename)
 
-	if contextID == "" || artifactID == "" || filename == "" {
+	if contextID == "" || artifactID == "" || filename == "" || contextID == artifactBlobDir {
 		return "", fmt.Errorf("invalid context ID, artifact ID or filename")
 	}
 
 	objectName := fmt.Sprintf("%s/%s/%s", contextID, artifactID, filename)
 
-	_, err := m.client.PutObject(ctx, m.bucketName, objectName, data, -1, minio.PutObjectOptions{})
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to read artifact data: %w", err)
+	}
+	digest := sha256.Sum256(content)
+	checksum := hex.EncodeToString(digest[:])
+	metadata := map[string]string{minioChecksumMetadataKey: checksum}
+
+	if !m.deduplicate {
+		_, err = m.client.PutObject(ctx, m.bucketName, objectName, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{
+			UserMetadata: metadata,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to store artifact in MinIO: %w", err)
+		}
+		return m.GetURL(contextID, artifactID, filename), nil
+	}
+
+	blobName := fmt.Sprintf("%s/%s", artifactBlobDir, checksum)
+	_, err = m.client.StatObject(ctx, m.bucketName, blobName, minio.StatObjectOptions{})
+	switch {
+	case err == nil:
+		_, err = m.client.CopyObject(ctx,
+			minio.CopyDestOptions{Bucket: m.bucketName, Object: blobName, UserMetadata: metadata, ReplaceMetadata: true},
+			minio.CopySrcOptions{Bucket: m.bucketName, Object: blobName})
+		if err != nil {
+			return "", fmt.Errorf("failed to refresh artifact blob in MinIO: %w", err)
+		}
+	case minio.ToErrorResponse(err).Code == "NoSuchKey":
+		_, err = m.client.PutObject(ctx, m.bucketName, blobName, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{
+			UserMetadata: metadata,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to store artifact blob in MinIO: %w", err)
+		}
+	default:
+		return "", fmt.Errorf("failed to check artifact blob in MinIO: %w", err)
+	}
+
+	metadata[minioBlobMetadataKey] = blobName
+	metadata[minioSizeMetadataKey] = strconv.Itoa(len(content))
+	_, err = m.client.PutObject(ctx, m.bucketName, objectName, bytes.NewReader(nil), 0, minio.PutObjectOptions{
+		UserMetadata: metadata,
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to store artifact in MinIO: %w", err)
 	}
@@ -85,6 +208,14 @@ func (m *MinIOArtifactStorage) Retrieve(ctx context.Context, contextID string, a
 
 	objectName := fmt.Sprintf("%s/%s/%s", contextID, artifactID, filename)
 
+	info, err := m.client.StatObject(ctx, m.bucketName, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve artifact from MinIO: %w", err)
+	}
+	if blobName := userMetadata(info, minioBlobMetadataKey); blobName != "" {
+		objectName = blobName
+	}
+
 	object, err := m.client.GetObject(ctx, m.bucketName, objectName, minio.GetObjectOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve artifact from MinIO: %w", err)
@@ -164,7 +295,7 @@ func (m *MinIOArtifactStorage) CleanupExpiredArtifacts(ctx context.Context, maxA
 
 	var objectsToDelete []minio.ObjectInfo
 	for object := range objectCh {
-		if object.Err != nil {
+		if object.Err != nil || isBlobKey(object.Key) {
 			continue
 		}
 
@@ -180,6 +311,7 @@ func (m *MinIOArtifactStorage) CleanupExpiredArtifacts(ctx context.Context, maxA
 		}
 	}
 
+	m.pruneOrphanBlobs(ctx)
 	return removedCount, nil
 }
 
@@ -195,7 +327,7 @@ func (m *MinIOArtifactStorage) CleanupOldestArtifacts(ctx context.Context, maxCo
 
 	artifactGroups := make(map[string][]minio.ObjectInfo)
 	for object := range objectCh {
-		if object.Err != nil {
+		if object.Err != nil || isBlobKey(object.Key) {
 			continue
 		}
 
@@ -224,5 +356,137 @@ func (m *MinIOArtifactStorage) CleanupOldestArtifacts(ctx context.Context, maxCo
 		}
 	}
 
+	m.pruneOrphanBlobs(ctx)
 	return removedCount, nil
 }
+
+// ListArtifacts returns metadata for every artifact object in the bucket
+func (m *MinIOArtifactStorage) ListArtifacts(ctx context.Context) ([]ArtifactMetadata, error) {
+	objectCh := m.client.ListObjects(ctx, m.bucketName, minio.ListObjectsOptions{
+		Recursive: true,
+	})
+
+	var artifacts []ArtifactMetadata
+	for object := range objectCh {
+		if object.Err != nil {
+			return artifacts, fmt.Errorf("failed to list artifacts: %w", object.Err)
+		}
+
+		parts := strings.Split(object.Key, "/")
+		if len(parts) != 3 || isBlobKey(object.Key) {
+			continue
+		}
+
+		size := object.Size
+		if size == 0 && m.deduplicate {
+			size = m.referencedSize(ctx, object.Key)
+		}
+
+		artifacts = append(artifacts, ArtifactMetadata{
+			ContextID:   parts[0],
+			ArtifactID:  parts[1],
+			Filename:    parts[2],
+			Size:        size,
+			ContentType: object.ContentType,
+			UploadedAt:  object.LastModified,
+		})
+	}
+
+	return artifacts, nil
+}
+
+// Checksum returns the SHA-256 digest recorded on the artifact object, hashing the
+// content for objects stored before checksums were recorded
+func (m *MinIOArtifactStorage) Checksum(ctx context.Context, contextID string, artifactID string, filename string) (string, error) {
+	contextID = sanitizePath(contextID)
+	artifactID = sanitizePath(artifactID)
+	filename = sanitizePath(filename)
+
+	if contextID == "" || artifactID == "" || filename == "" {
+		return "", fmt.Errorf("invalid context ID, artifact ID or filename")
+	}
+
+	objectName := fmt.Sprintf("%s/%s/%s", contextID, artifactID, filename)
+
+	info, err := m.client.StatObject(ctx, m.bucketName, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to stat artifact in MinIO: %w", err)
+	}
+	if checksum := userMetadata(info, minioChecksumMetadataKey); checksum != "" {
+		return checksum, nil
+	}
+
+	reader, err := m.Retrieve(ctx, contextID, artifactID, filename)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	checksum, err := checksumReader(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to checksum artifact: %w", err)
+	}
+	return checksum, nil
+}
+
+// referencedSize returns the content size recorded on a deduplicated reference object
+func (m *MinIOArtifactStorage) referencedSize(ctx context.Context, objectName string) int64 {
+	info, err := m.client.StatObject(ctx, m.bucketName, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		return 0
+	}
+	size, err := strconv.ParseInt(userMetadata(info, minioSizeMetadataKey), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// pruneOrphanBlobs removes deduplicated blobs that are no longer referenced by any artifact object
+func (m *MinIOArtifactStorage) pruneOrphanBlobs(ctx context.Context) {
+	if !m.deduplicate {
+		return
+	}
+
+	referenced := make(map[string]bool)
+	var blobs []string
+	for object := range m.client.ListObjects(ctx, m.bucketName, minio.ListObjectsOptions{Recursive: true}) {
+		if object.Err != nil {
+			return
+		}
+		if isBlobKey(object.Key) {
+			blobs = append(blobs, object.Key)
+			continue
+		}
+		info, err := m.client.StatObject(ctx, m.bucketName, object.Key, minio.StatObjectOptions{})
+		if err != nil {
+			return
+		}
+		if blobName := userMetadata(info, minioBlobMetadataKey); blobName != "" {
+			referenced[blobName] = true
+		}
+	}
+
+	for _, blob := range blobs {
+		if !referenced[blob] {
+			_ = m.client.RemoveObject(ctx, m.bucketName, blob, minio.RemoveObjectOptions{})
+		}
+	}
+}
+
+// isBlobKey reports whether an object key lives under the deduplicated blob prefix
+func isBlobKey(key string) bool {
+	return strings.HasPrefix(key, artifactBlobDir+"/")
+}
+
+// userMetadata looks up a user metadata value case-insensitively
+func userMetadata(info minio.ObjectInfo, key string) string {
+	for k, v := range info.UserMetadata {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
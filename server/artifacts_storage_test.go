@@ -3,12 +3,17 @@ package server
 import (
 	"context"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
 	assert "github.com/stretchr/testify/assert"
 	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
 )
 
 func TestFilesystemArtifactStorage_NewFilesystemArtifactStorage(t *testing.T) {
@@ -165,3 +170,189 @@ func TestSanitizePath(t *testing.T) {
 		})
 	}
 }
+
+func TestFilesystemArtifactStorage_ListArtifacts(t *testing.T) {
+	cfg := &config.ArtifactsStorageConfig{
+		BasePath: t.TempDir(),
+	}
+	storage, err := NewFilesystemArtifactStorage(cfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = storage.Store(ctx, "ctx-1", "artifact-1", "report.txt", strings.NewReader("hello"))
+	require.NoError(t, err)
+	_, err = storage.Store(ctx, "ctx-2", "artifact-2", "data.json", strings.NewReader("{}"))
+	require.NoError(t, err)
+
+	artifacts, err := storage.ListArtifacts(ctx)
+	require.NoError(t, err)
+	require.Len(t, artifacts, 2)
+
+	byContext := map[string]ArtifactMetadata{}
+	for _, artifact := range artifacts {
+		byContext[artifact.ContextID] = artifact
+	}
+	assert.Equal(t, "artifact-1", byContext["ctx-1"].ArtifactID)
+	assert.Equal(t, "report.txt", byContext["ctx-1"].Filename)
+	assert.Equal(t, int64(5), byContext["ctx-1"].Size)
+	assert.Equal(t, "data.json", byContext["ctx-2"].Filename)
+}
+
+func TestArtifactService_CleanupArtifactsOverQuota(t *testing.T) {
+	now := time.Now()
+	stored := []ArtifactMetadata{
+		{ContextID: "ctx-1", ArtifactID: "a", Filename: "old.txt", Size: 60, UploadedAt: now.Add(-3 * time.Hour)},
+		{ContextID: "ctx-1", ArtifactID: "b", Filename: "new.txt", Size: 60, UploadedAt: now.Add(-1 * time.Hour)},
+		{ContextID: "ctx-2", ArtifactID: "c", Filename: "mid.txt", Size: 50, UploadedAt: now.Add(-2 * time.Hour)},
+	}
+
+	tests := []struct {
+		name            string
+		maxContextSize  int64
+		maxTotalSize    int64
+		expectedDeleted []string
+	}{
+		{
+			name: "no quotas",
+		},
+		{
+			name:            "per-context quota removes oldest files of the context",
+			maxContextSize:  100,
+			expectedDeleted: []string{"old.txt"},
+		},
+		{
+			name:            "total quota removes oldest files across contexts",
+			maxTotalSize:    70,
+			expectedDeleted: []string{"old.txt", "mid.txt"},
+		},
+		{
+			name:            "combined quotas",
+			maxContextSize:  100,
+			maxTotalSize:    100,
+			expectedDeleted: []string{"old.txt", "mid.txt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeListingStorage{artifacts: stored}
+			service := &ArtifactServiceImpl{storage: provider, logger: zap.NewNop()}
+
+			removed, err := service.CleanupArtifactsOverQuota(context.Background(), tt.maxContextSize, tt.maxTotalSize)
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.expectedDeleted), removed)
+			assert.Equal(t, tt.expectedDeleted, provider.deleted)
+		})
+	}
+}
+
+// fakeListingStorage is a minimal ArtifactStorageProvider that records deletions
+type fakeListingStorage struct {
+	ArtifactStorageProvider
+	artifacts []ArtifactMetadata
+	deleted   []string
+}
+
+func (f *fakeListingStorage) ListArtifacts(ctx context.Context) ([]ArtifactMetadata, error) {
+	listed := make([]ArtifactMetadata, len(f.artifacts))
+	copy(listed, f.artifacts)
+	return listed, nil
+}
+
+func (f *fakeListingStorage) Delete(ctx context.Context, contextID, artifactID, filename string) error {
+	f.deleted = append(f.deleted, filename)
+	return nil
+}
+
+func TestFilesystemArtifactStorage_Checksum(t *testing.T) {
+	storage, err := NewFilesystemArtifactStorage(&config.ArtifactsStorageConfig{BasePath: t.TempDir()})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = storage.Store(ctx, "ctx-1", "artifact-1", "hello.txt", strings.NewReader("hello"))
+	require.NoError(t, err)
+
+	checksum, err := storage.Checksum(ctx, "ctx-1", "artifact-1", "hello.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", checksum)
+
+	_, err = storage.Checksum(ctx, "ctx-1", "artifact-1", "missing.txt")
+	assert.Error(t, err)
+}
+
+func TestFilesystemArtifactStorage_Deduplicate(t *testing.T) {
+	basePath := t.TempDir()
+	storage, err := NewFilesystemArtifactStorage(&config.ArtifactsStorageConfig{
+		BasePath:    basePath,
+		Deduplicate: true,
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = storage.Store(ctx, "ctx-1", "artifact-1", "report.txt", strings.NewReader("shared content"))
+	require.NoError(t, err)
+	_, err = storage.Store(ctx, "ctx-2", "artifact-2", "copy.txt", strings.NewReader("shared content"))
+	require.NoError(t, err)
+
+	first, err := os.Stat(filepath.Join(basePath, "ctx-1", "artifact-1", "report.txt"))
+	require.NoError(t, err)
+	second, err := os.Stat(filepath.Join(basePath, "ctx-2", "artifact-2", "copy.txt"))
+	require.NoError(t, err)
+	assert.True(t, os.SameFile(first, second))
+
+	blobs, err := os.ReadDir(filepath.Join(basePath, artifactBlobDir))
+	require.NoError(t, err)
+	assert.Len(t, blobs, 1)
+
+	artifacts, err := storage.ListArtifacts(ctx)
+	require.NoError(t, err)
+	assert.Len(t, artifacts, 2)
+
+	reader, err := storage.Retrieve(ctx, "ctx-2", "artifact-2", "copy.txt")
+	require.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	_ = reader.Close()
+	assert.Equal(t, "shared content", string(content))
+
+	_, err = storage.Store(ctx, artifactBlobDir, "artifact-3", "file.txt", strings.NewReader("x"))
+	assert.Error(t, err)
+
+	require.NoError(t, storage.Delete(ctx, "ctx-1", "artifact-1", "report.txt"))
+	storage.cleanupEmptyDirectories()
+	blobs, err = os.ReadDir(filepath.Join(basePath, artifactBlobDir))
+	require.NoError(t, err)
+	assert.Len(t, blobs, 1)
+
+	require.NoError(t, storage.Delete(ctx, "ctx-2", "artifact-2", "copy.txt"))
+	storage.cleanupEmptyDirectories()
+	blobs, err = os.ReadDir(filepath.Join(basePath, artifactBlobDir))
+	require.NoError(t, err)
+	assert.Empty(t, blobs)
+}
+
+func TestArtifactService_CreateFileArtifactRecordsChecksum(t *testing.T) {
+	service, err := NewArtifactService(&config.ArtifactsConfig{
+		Enable: true,
+		StorageConfig: config.ArtifactsStorageConfig{
+			Provider: "filesystem",
+			BasePath: t.TempDir(),
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	mimeType := "text/plain"
+	artifact, err := service.CreateFileArtifact("ctx-1", "greeting", "a greeting", "hello.txt", []byte("hello"), &mimeType)
+	require.NoError(t, err)
+
+	expected := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	require.NotNil(t, artifact.Metadata)
+	assert.Equal(t, expected, (*artifact.Metadata)[types.ArtifactChecksumMetadataKey])
+	require.Len(t, artifact.Parts, 1)
+	require.NotNil(t, artifact.Parts[0].Metadata)
+	assert.Equal(t, expected, (*artifact.Parts[0].Metadata)[types.ArtifactChecksumMetadataKey])
+
+	checksum, err := service.Checksum(context.Background(), "ctx-1", artifact.ArtifactID, "hello.txt")
+	require.NoError(t, err)
+	assert.Equal(t, expected, checksum)
+}
@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/inference-gateway/adk/types"
+	"go.uber.org/zap"
+)
+
+// ErrArtifactTooLarge is returned when the content of a file artifact exceeds its size limit
+var ErrArtifactTooLarge = errors.New("artifact exceeds the maximum size")
+
+// FileArtifactMeta describes a file artifact whose content is streamed to storage
+type FileArtifactMeta struct {
+	// ContextID is the A2A context (session) ID the file is stored under
+	ContextID string
+	// Name and Description describe the artifact
+	Name        string
+	Description string
+	// Filename is the name of the stored file
+	Filename string
+	// MimeType is the media type of the file, derived from the filename extension when empty
+	MimeType string
+	// MaxSize limits the size of the content in bytes. The limit of the service applies when
+	// it is 0 or larger.
+	MaxSize int64
+}
+
+// ArtifactWriter streams the content of a file artifact to storage. Close completes the file;
+// CloseWithError abandons it, removing what was stored.
+type ArtifactWriter interface {
+	io.WriteCloser
+
+	// CloseWithError abandons the file, making the pending storage fail with the error
+	CloseWithError(err error) error
+
+	// Artifact returns the artifact referencing the file once the writer is closed
+	Artifact() (types.Artifact, error)
+}
+
+// CreateFileArtifactFromReader creates a file artifact by streaming its content to storage
+func (as *ArtifactServiceImpl) CreateFileArtifactFromReader(ctx context.Context, meta FileArtifactMeta, data io.Reader) (types.Artifact, error) {
+	if meta.Filename == "" {
+		return types.Artifact{}, fmt.Errorf("artifact filename is required")
+	}
+	mimeType := meta.MimeType
+	if mimeType == "" {
+		mimeType = *as.GetMimeTypeFromExtension(meta.Filename)
+	}
+
+	artifactID := uuid.New().String()
+	hasher := sha256.New()
+	limited := &sizeLimitedReader{reader: io.TeeReader(data, hasher), limit: as.sizeLimit(meta.MaxSize)}
+	uri, err := as.storage.Store(ctx, meta.ContextID, artifactID, meta.Filename, limited)
+	if err == nil && limited.exceeded {
+		err = ErrArtifactTooLarge
+	}
+	if err != nil {
+		if deleteErr := as.storage.Delete(context.WithoutCancel(ctx), meta.ContextID, artifactID, meta.Filename); deleteErr != nil {
+			as.logger.Debug("failed to remove partial artifact",
+				zap.String("artifact_id", artifactID),
+				zap.Error(deleteErr))
+		}
+		if limited.exceeded {
+			return types.Artifact{}, fmt.Errorf("%w of %d bytes", ErrArtifactTooLarge, limited.limit)
+		}
+		return types.Artifact{}, fmt.Errorf("failed to store artifact: %w", err)
+	}
+
+	checksum := hex.EncodeToString(hasher.Sum(nil))
+	artifactMetadata := types.Struct{types.ArtifactChecksumMetadataKey: checksum}
+	return types.Artifact{
+		ArtifactID:  artifactID,
+		Name:        &meta.Name,
+		Description: &meta.Description,
+		Metadata:    &artifactMetadata,
+		Parts: []types.Part{
+			types.CreateFilePart(meta.Filename, mimeType, nil, &uri, map[string]any{
+				types.ArtifactChecksumMetadataKey: checksum,
+			}),
+		},
+	}, nil
+}
+
+// Writer returns a writer streaming the content of a file artifact to storage
+func (as *ArtifactServiceImpl) Writer(ctx context.Context, meta FileArtifactMeta) (ArtifactWriter, error) {
+	if meta.Filename == "" {
+		return nil, fmt.Errorf("artifact filename is required")
+	}
+
+	reader, writer := io.Pipe()
+	w := &artifactWriter{pipe: writer, done: make(chan struct{})}
+	go func() {
+		defer close(w.done)
+		w.artifact, w.err = as.CreateFileArtifactFromReader(ctx, meta, reader)
+		_ = reader.CloseWithError(w.err)
+	}()
+	return w, nil
+}
+
+// sizeLimit returns the size limit of a file artifact: the smaller of the limit requested
+// and the limit of the service, 0 meaning none
+func (as *ArtifactServiceImpl) sizeLimit(requested int64) int64 {
+	if as.maxFileSize > 0 && (requested <= 0 || requested > as.maxFileSize) {
+		return as.maxFileSize
+	}
+	return max(requested, 0)
+}
+
+// artifactWriter writes the content of a file artifact into the pipe the storage reads from
+type artifactWriter struct {
+	pipe      *io.PipeWriter
+	done      chan struct{}
+	closeOnce sync.Once
+	artifact  types.Artifact
+	err       error
+}
+
+// Write streams content to storage, failing once storage has failed or the size limit is exceeded
+func (w *artifactWriter) Write(p []byte) (int, error) {
+	return w.pipe.Write(p)
+}
+
+// Close completes the file and waits for storage to finish
+func (w *artifactWriter) Close() error {
+	return w.CloseWithError(nil)
+}
+
+// CloseWithError abandons the file when err is set, and completes it otherwise
+func (w *artifactWriter) CloseWithError(err error) error {
+	w.closeOnce.Do(func() {
+		_ = w.pipe.CloseWithError(err)
+	})
+	<-w.done
+	return w.err
+}
+
+// Artifact returns the stored artifact, or the error storing it failed with
+func (w *artifactWriter) Artifact() (types.Artifact, error) {
+	select {
+	case <-w.done:
+		return w.artifact, w.err
+	default:
+		return types.Artifact{}, fmt.Errorf("artifact writer is not closed")
+	}
+}
+
+// sizeLimitedReader reads up to limit bytes, failing once the content exceeds it
+type sizeLimitedReader struct {
+	reader   io.Reader
+	limit    int64
+	read     int64
+	exceeded bool
+}
+
+// Read reads from the underlying reader, failing with ErrArtifactTooLarge past the limit
+func (r *sizeLimitedReader) Read(p []byte) (int, error) {
+	if r.exceeded {
+		return 0, ErrArtifactTooLarge
+	}
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	if r.limit > 0 && r.read > r.limit {
+		r.exceeded = true
+		return n, ErrArtifactTooLarge
+	}
+	return n, err
+}
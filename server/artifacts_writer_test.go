@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func newTestArtifactService(t *testing.T, maxFileSize int64) ArtifactService {
+	service, err := NewArtifactService(&config.ArtifactsConfig{
+		Enable: true,
+		StorageConfig: config.ArtifactsStorageConfig{
+			Provider:    "filesystem",
+			BasePath:    t.TempDir(),
+			MaxFileSize: maxFileSize,
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	return service
+}
+
+func readArtifactFile(t *testing.T, service ArtifactService, contextID string, artifact types.Artifact) string {
+	require.Len(t, artifact.Parts, 1)
+	require.NotNil(t, artifact.Parts[0].File)
+	reader, err := service.Retrieve(context.Background(), contextID, artifact.ArtifactID, artifact.Parts[0].File.Name)
+	require.NoError(t, err)
+	defer func() { _ = reader.Close() }()
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestArtifactService_CreateFileArtifactFromReader(t *testing.T) {
+	tests := []struct {
+		name             string
+		serviceMax       int64
+		meta             FileArtifactMeta
+		content          string
+		tooLarge         bool
+		expectedMimeType string
+	}{
+		{name: "without limits", meta: FileArtifactMeta{ContextID: "ctx-1", Name: "log", Filename: "run.log"}, content: "line 1\nline 2\n", expectedMimeType: "application/octet-stream"},
+		{name: "with a media type", meta: FileArtifactMeta{ContextID: "ctx-1", Filename: "data.bin", MimeType: "application/x-custom"}, content: "data", expectedMimeType: "application/x-custom"},
+		{name: "within the limit", serviceMax: 4, meta: FileArtifactMeta{ContextID: "ctx-1", Filename: "a.csv"}, content: "a,b\n", expectedMimeType: "text/csv"},
+		{name: "over the service limit", serviceMax: 4, meta: FileArtifactMeta{ContextID: "ctx-1", Filename: "a.csv"}, content: "a,b,c\n", tooLarge: true},
+		{name: "over the requested limit", serviceMax: 100, meta: FileArtifactMeta{ContextID: "ctx-1", Filename: "a.csv", MaxSize: 2}, content: "a,b\n", tooLarge: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestArtifactService(t, tt.serviceMax)
+			artifact, err := service.CreateFileArtifactFromReader(context.Background(), tt.meta, strings.NewReader(tt.content))
+			if tt.tooLarge {
+				require.ErrorIs(t, err, ErrArtifactTooLarge)
+				stored, err := service.ListStoredArtifacts(context.Background())
+				require.NoError(t, err)
+				assert.Empty(t, stored, "the partial file is removed")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, readArtifactFile(t, service, tt.meta.ContextID, artifact))
+			assert.Equal(t, tt.expectedMimeType, artifact.Parts[0].File.MediaType)
+			require.NotNil(t, artifact.Metadata)
+			assert.NotEmpty(t, (*artifact.Metadata)[types.ArtifactChecksumMetadataKey])
+		})
+	}
+}
+
+func TestArtifactService_Writer(t *testing.T) {
+	t.Run("streams the written content to storage", func(t *testing.T) {
+		service := newTestArtifactService(t, 0)
+		writer, err := service.Writer(context.Background(), FileArtifactMeta{ContextID: "ctx-1", Name: "report", Filename: "report.txt"})
+		require.NoError(t, err)
+
+		_, err = writer.Artifact()
+		assert.Error(t, err, "the artifact is not ready before the writer is closed")
+		for _, line := range []string{"first\n", "second\n"} {
+			_, err := io.WriteString(writer, line)
+			require.NoError(t, err)
+		}
+		require.NoError(t, writer.Close())
+
+		artifact, err := writer.Artifact()
+		require.NoError(t, err)
+		assert.Equal(t, "report", *artifact.Name)
+		assert.Equal(t, "first\nsecond\n", readArtifactFile(t, service, "ctx-1", artifact))
+	})
+
+	t.Run("fails once the size limit is exceeded", func(t *testing.T) {
+		service := newTestArtifactService(t, 8)
+		writer, err := service.Writer(context.Background(), FileArtifactMeta{ContextID: "ctx-1", Filename: "big.txt"})
+		require.NoError(t, err)
+
+		_, err = io.Copy(writer, strings.NewReader(strings.Repeat("x", 64*1024)))
+		require.ErrorIs(t, err, ErrArtifactTooLarge)
+		assert.ErrorIs(t, writer.Close(), ErrArtifactTooLarge)
+	})
+
+	t.Run("abandoned files are removed", func(t *testing.T) {
+		service := newTestArtifactService(t, 0)
+		writer, err := service.Writer(context.Background(), FileArtifactMeta{ContextID: "ctx-1", Filename: "partial.txt"})
+		require.NoError(t, err)
+		_, err = io.WriteString(writer, "partial")
+		require.NoError(t, err)
+
+		abandoned := errors.New("tool failed")
+		assert.ErrorIs(t, writer.CloseWithError(abandoned), abandoned)
+		stored, err := service.ListStoredArtifacts(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, stored)
+	})
+}
@@ -0,0 +1,328 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	oidcV3 "github.com/coreos/go-oidc/v3/oidc"
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// Audited actions
+const (
+	AuditActionJSONRPCCall    = "jsonrpc.call"
+	AuditActionToolInvocation = "tool.invocation"
+	AuditActionArtifactAccess = "artifact.access"
+)
+
+// auditLogContextKey carries the audit log into the context of the tools run for a task
+const auditLogContextKey ContextKey = "auditLog"
+
+// AuditEvent is the structured record of an action taken on behalf of a caller
+type AuditEvent struct {
+	Timestamp  string `json:"timestamp"`
+	Action     string `json:"action"`
+	RequestID  string `json:"requestId,omitempty"`
+	Actor      string `json:"actor,omitempty"`
+	Method     string `json:"method,omitempty"`
+	TaskID     string `json:"taskId,omitempty"`
+	ContextID  string `json:"contextId,omitempty"`
+	Tool       string `json:"tool,omitempty"`
+	ArtifactID string `json:"artifactId,omitempty"`
+	Filename   string `json:"filename,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// AuditLogger writes audit events to a durable destination
+type AuditLogger interface {
+	// Log writes an audit event
+	Log(ctx context.Context, event AuditEvent) error
+
+	// Close releases the resources of the logger once the server stops
+	Close() error
+}
+
+// auditLog records the audit events of a server through its audit logger, completing them
+// with the time and the correlation ID of the request they belong to
+type auditLog struct {
+	auditLogger AuditLogger
+	logger      *zap.Logger
+}
+
+// newAuditLog creates an audit log writing to the audit logger
+func newAuditLog(auditLogger AuditLogger, logger *zap.Logger) *auditLog {
+	return &auditLog{
+		auditLogger: auditLogger,
+		logger:      logger,
+	}
+}
+
+// record writes an audit event. It does nothing without an audit logger.
+func (a *auditLog) record(ctx context.Context, event AuditEvent) {
+	if a == nil {
+		return
+	}
+
+	event.Timestamp = time.Now().UTC().Format(time.RFC3339Nano)
+	if event.RequestID == "" {
+		event.RequestID = middlewares.RequestIDFromContext(ctx)
+	}
+	if err := a.auditLogger.Log(ctx, event); err != nil {
+		requestLogger(ctx, a.logger).Error("failed to write audit event",
+			zap.String("action", event.Action),
+			zap.String("method", event.Method),
+			zap.String("task_id", event.TaskID),
+			zap.Error(err))
+	}
+}
+
+// call records a JSON-RPC call along with the caller and the task or context it targets
+func (a *auditLog) call(c *gin.Context, req types.JSONRPCRequest) {
+	if a == nil {
+		return
+	}
+
+	taskID, contextID := auditRequestTarget(req)
+	a.record(c.Request.Context(), AuditEvent{
+		Action:    AuditActionJSONRPCCall,
+		Actor:     auditActor(c),
+		Method:    req.Method,
+		TaskID:    taskID,
+		ContextID: contextID,
+	})
+}
+
+// toolInvocation records the invocation of a tool for the task in the context
+func (a *auditLog) toolInvocation(ctx context.Context, toolName string, toolErr error) {
+	if a == nil {
+		return
+	}
+
+	event := AuditEvent{
+		Action: AuditActionToolInvocation,
+		Tool:   toolName,
+	}
+	if task, ok := ctx.Value(TaskContextKey).(*types.Task); ok && task != nil {
+		event.TaskID = task.ID
+		event.ContextID = task.ContextID
+	}
+	if toolErr != nil {
+		event.Error = toolErr.Error()
+	}
+	a.record(ctx, event)
+}
+
+// artifactAccess records the download of an artifact file by the caller
+func (a *auditLog) artifactAccess(c *gin.Context, contextID, artifactID, filename string, accessErr error) {
+	if a == nil {
+		return
+	}
+
+	event := AuditEvent{
+		Action:     AuditActionArtifactAccess,
+		Actor:      auditActor(c),
+		ContextID:  contextID,
+		ArtifactID: artifactID,
+		Filename:   filename,
+	}
+	if accessErr != nil {
+		event.Error = accessErr.Error()
+	}
+	a.record(c.Request.Context(), event)
+}
+
+// close closes the audit logger
+func (a *auditLog) close() error {
+	if a == nil {
+		return nil
+	}
+	return a.auditLogger.Close()
+}
+
+// contextWithAuditLog returns a copy of ctx carrying the audit log, so the tools run for a task
+// are audited
+func contextWithAuditLog(ctx context.Context, audit *auditLog) context.Context {
+	if audit == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, auditLogContextKey, audit)
+}
+
+// auditLogFromContext returns the audit log carried by ctx, or nil when auditing is disabled
+func auditLogFromContext(ctx context.Context) *auditLog {
+	audit, _ := ctx.Value(auditLogContextKey).(*auditLog)
+	return audit
+}
+
+// auditActor returns the subject of the ID token the caller authenticated with, if any
+func auditActor(c *gin.Context) string {
+	value, exists := c.Get(string(middlewares.IDTokenContextKey))
+	if !exists {
+		return ""
+	}
+	if idToken, ok := value.(*oidcV3.IDToken); ok && idToken != nil {
+		return idToken.Subject
+	}
+	return ""
+}
+
+// auditRequestTarget returns the IDs of the task and the context a JSON-RPC request targets,
+// from the message it sends or from its task and context parameters, or the task a
+// resubscription or a push notification config method names
+func auditRequestTarget(req types.JSONRPCRequest) (string, string) {
+	if params := decodeMessageParams(req); params != nil {
+		var taskID, contextID string
+		if params.Message.TaskID != nil {
+			taskID = *params.Message.TaskID
+		}
+		if params.Message.ContextID != nil {
+			contextID = *params.Message.ContextID
+		}
+		return taskID, contextID
+	}
+
+	taskID, _ := req.Params["taskId"].(string)
+	contextID, _ := req.Params["contextId"].(string)
+	if id, ok := req.Params["id"].(string); ok && id != "" {
+		switch {
+		case strings.HasPrefix(req.Method, "contexts/"):
+			contextID = id
+		case !strings.HasPrefix(req.Method, "memories/"):
+			taskID = id
+		}
+	}
+	// the task these methods name wins over any other parameter, which the handlers ignore
+	switch req.Method {
+	case "tasks/resubscribe":
+		if name, ok := req.Params["name"].(string); ok {
+			taskID = name
+		}
+	case "tasks/pushNotificationConfig/set", "tasks/pushNotificationConfig/get", "tasks/pushNotificationConfig/delete":
+		if name, ok := req.Params["name"].(string); ok {
+			taskID = pushNotificationConfigTaskID(name)
+		}
+	case "tasks/pushNotificationConfig/list":
+		if parent, ok := req.Params["parent"].(string); ok {
+			taskID = pushNotificationConfigTaskID(parent)
+		}
+	}
+	return taskID, contextID
+}
+
+// pushNotificationConfigTaskID returns the task a push notification config method names, given
+// as the task ID or as a resource name such as tasks/{task_id}/pushNotificationConfigs/{config_id}
+func pushNotificationConfigTaskID(name string) string {
+	if rest, ok := strings.CutPrefix(name, "tasks/"); ok {
+		taskID, _, _ := strings.Cut(rest, "/")
+		return taskID
+	}
+	return name
+}
+
+// FileAuditLogger appends audit events to a file as JSON lines
+type FileAuditLogger struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileAuditLogger creates an audit logger appending to the file at the path, creating it
+// when missing
+func NewFileAuditLogger(path string) (*FileAuditLogger, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	return &FileAuditLogger{file: file}, nil
+}
+
+// Log appends the event as a line of JSON
+func (l *FileAuditLogger) Log(ctx context.Context, event AuditEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write audit event: %w", err)
+	}
+	return nil
+}
+
+// Close closes the audit log file
+func (l *FileAuditLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// HTTPAuditLogger posts every audit event to an HTTP endpoint as JSON
+type HTTPAuditLogger struct {
+	url        string
+	headers    map[string]string
+	httpClient HTTPDoer
+}
+
+// NewHTTPAuditLogger creates an audit logger that posts events to the URL with the given
+// headers
+func NewHTTPAuditLogger(url string, headers map[string]string) *HTTPAuditLogger {
+	return NewHTTPAuditLoggerWithClient(url, headers, &http.Client{
+		Timeout: 10 * time.Second,
+	})
+}
+
+// NewHTTPAuditLoggerWithClient creates an audit logger that posts events to the URL through the
+// given HTTP client
+func NewHTTPAuditLoggerWithClient(url string, headers map[string]string, httpClient HTTPDoer) *HTTPAuditLogger {
+	return &HTTPAuditLogger{
+		url:        url,
+		headers:    headers,
+		httpClient: httpClient,
+	}
+}
+
+// Log posts the event with the application/json content type
+func (l *HTTPAuditLogger) Log(ctx context.Context, event AuditEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(context.WithoutCancel(ctx), http.MethodPost, l.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range l.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post audit event: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Close does nothing, the logger holds no connection
+func (l *HTTPAuditLogger) Close() error {
+	return nil
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+	types "github.com/inference-gateway/adk/types"
+)
+
+type recordingAuditLogger struct {
+	mu     sync.Mutex
+	events []AuditEvent
+}
+
+func (l *recordingAuditLogger) Log(ctx context.Context, event AuditEvent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+	return nil
+}
+
+func (l *recordingAuditLogger) Close() error {
+	return nil
+}
+
+func TestA2AServer_AuditLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "assistant"})
+	auditLogger := &recordingAuditLogger{}
+	s.setAuditLogger(auditLogger)
+	router := s.setupRouter(cfg)
+
+	call := func(requestID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body))
+		if requestID != "" {
+			req.Header.Set(types.RequestIDHeader, requestID)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	call("req-42", `{"jsonrpc":"2.0","id":"1","method":"tasks/get","params":{"id":"task-1"}}`)
+	call("", `{"jsonrpc":"2.0","id":"2","method":"contexts/get","params":{"id":"ctx-1"}}`)
+	call("bad id with spaces", `{"jsonrpc":"2.0","id":"3","method":"message/send","params":{"message":{"messageId":"m-1","role":"user","taskId":"task-2","contextId":"ctx-2","parts":[{"kind":"text","text":"hi"}]}}}`)
+
+	require.Len(t, auditLogger.events, 3)
+
+	get := auditLogger.events[0]
+	assert.Equal(t, AuditActionJSONRPCCall, get.Action)
+	assert.Equal(t, "tasks/get", get.Method)
+	assert.Equal(t, "task-1", get.TaskID)
+	assert.Equal(t, "req-42", get.RequestID)
+	assert.NotEmpty(t, get.Timestamp)
+
+	contextGet := auditLogger.events[1]
+	assert.Equal(t, "ctx-1", contextGet.ContextID)
+	assert.Empty(t, contextGet.TaskID)
+	assert.NotEmpty(t, contextGet.RequestID, "a correlation ID is generated when the caller sends none")
+
+	send := auditLogger.events[2]
+	assert.Equal(t, "message/send", send.Method)
+	assert.Equal(t, "task-2", send.TaskID)
+	assert.Equal(t, "ctx-2", send.ContextID)
+	assert.NotEqual(t, "bad id with spaces", send.RequestID)
+}
+
+func TestAuditLog_ToolInvocation(t *testing.T) {
+	auditLogger := &recordingAuditLogger{}
+	audit := newAuditLog(auditLogger, zap.NewNop())
+	task := &types.Task{ID: "task-1", ContextID: "ctx-1"}
+
+	ctx := context.WithValue(middlewares.ContextWithRequestID(context.Background(), "req-1"), TaskContextKey, task)
+	ctx = contextWithAuditLog(ctx, audit)
+	auditLogFromContext(ctx).toolInvocation(ctx, "search", errors.New("timeout"))
+	auditLogFromContext(context.Background()).toolInvocation(context.Background(), "ignored", nil)
+
+	require.Len(t, auditLogger.events, 1)
+	event := auditLogger.events[0]
+	assert.Equal(t, AuditActionToolInvocation, event.Action)
+	assert.Equal(t, "search", event.Tool)
+	assert.Equal(t, "task-1", event.TaskID)
+	assert.Equal(t, "ctx-1", event.ContextID)
+	assert.Equal(t, "req-1", event.RequestID)
+	assert.Equal(t, "timeout", event.Error)
+}
+
+func TestFileAuditLogger_Log(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditLogger, err := NewFileAuditLogger(path)
+	require.NoError(t, err)
+
+	require.NoError(t, auditLogger.Log(context.Background(), AuditEvent{Action: AuditActionJSONRPCCall, Method: "tasks/get"}))
+	require.NoError(t, auditLogger.Log(context.Background(), AuditEvent{Action: AuditActionToolInvocation, Tool: "search"}))
+	require.NoError(t, auditLogger.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	var events []AuditEvent
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event AuditEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.Len(t, events, 2)
+	assert.Equal(t, "tasks/get", events[0].Method)
+	assert.Equal(t, "search", events[1].Tool)
+}
+
+func TestHTTPAuditLogger_Log(t *testing.T) {
+	var received AuditEvent
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer endpoint.Close()
+
+	auditLogger := NewHTTPAuditLogger(endpoint.URL, map[string]string{"Authorization": "Bearer secret"})
+	event := AuditEvent{Action: AuditActionArtifactAccess, ArtifactID: "artifact-1", Filename: "report.pdf"}
+	require.NoError(t, auditLogger.Log(context.Background(), event))
+	assert.Equal(t, "artifact-1", received.ArtifactID)
+	assert.Equal(t, "report.pdf", received.Filename)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	err := NewHTTPAuditLogger(failing.URL, nil).Log(context.Background(), event)
+	assert.EqualError(t, err, "audit endpoint returned status 500")
+}
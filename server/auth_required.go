@@ -0,0 +1,203 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	sdk "github.com/inference-gateway/sdk"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// AuthRequiredError is returned by a tool that needs the client to authorize the agent, such
+// as with an OAuth token for a third-party API. The agent pauses the task in auth-required
+// state, and runs the tool call again once the client resumes the task with credentials, which
+// the tool then takes from its context with AuthCredentialsFromContext.
+type AuthRequiredError struct {
+	// Scheme is the authorization scheme the credentials are expected in, such as Bearer
+	Scheme           string
+	AuthorizationURL string
+	Scopes           []string
+	// Description tells the user what the authorization is for
+	Description string
+}
+
+func (e *AuthRequiredError) Error() string {
+	if e.AuthorizationURL != "" {
+		return fmt.Sprintf("authorization required: %s", e.AuthorizationURL)
+	}
+	return "authorization required"
+}
+
+// AuthCredentialsContextKey carries the credentials the client resumed an auth-required task with
+const AuthCredentialsContextKey ContextKey = "authCredentials"
+
+// AuthCredentialsFromContext returns the credentials the client resumed an auth-required task
+// with, or nil when the tool runs without credentials
+func AuthCredentialsFromContext(ctx context.Context) *types.AuthCredentials {
+	credentials, _ := ctx.Value(AuthCredentialsContextKey).(*types.AuthCredentials)
+	return credentials
+}
+
+// authRequiredMessage returns the auth-required message of a tool call that needs authorization
+func authRequiredMessage(err *AuthRequiredError, toolCall sdk.ChatCompletionMessageToolCall, args map[string]any) *types.Message {
+	return types.NewAuthRequiredMessage(toolCall.ID, types.AuthRequest{
+		Scheme:           err.Scheme,
+		AuthorizationURL: err.AuthorizationURL,
+		Scopes:           err.Scopes,
+		Description:      err.Description,
+		ToolCallID:       toolCall.ID,
+		ToolName:         toolCall.Function.Name,
+		Arguments:        args,
+	})
+}
+
+// findAuthCredentials returns the authorization a conversation paused on and the credentials
+// the client supplied, when the last message resumes the task from an auth-required tool call
+func findAuthCredentials(messages []types.Message) (int, *types.AuthRequest, *types.AuthCredentials) {
+	if len(messages) < 2 {
+		return -1, nil, nil
+	}
+	requestIndex := len(messages) - 2
+	requestMessage, resumeMessage := messages[requestIndex], messages[len(messages)-1]
+	if requestMessage.Role != types.RoleAgent || resumeMessage.Role != types.RoleUser {
+		return -1, nil, nil
+	}
+
+	request, found, err := types.GetAuthRequest(&requestMessage)
+	if err != nil || !found || request.ToolCallID == "" {
+		return -1, nil, nil
+	}
+	credentials, found, err := types.GetAuthCredentials(&resumeMessage)
+	if err != nil || !found {
+		return -1, nil, nil
+	}
+	return requestIndex, request, credentials
+}
+
+// resumeAuth runs the tool call a task paused on for authorization again, with the credentials
+// the client resumed the task with in its context. The auth request and the credentials are
+// replaced by the tool call and its result, so the credentials never reach the LLM. The
+// credentials held for the run take precedence over those of the message, which the server
+// redacts before storing it. Messages are returned unchanged when the task was not resumed
+// with credentials.
+func (a *OpenAICompatibleAgentImpl) resumeAuth(ctx context.Context, messages []types.Message, outputChan chan<- cloudevents.Event, usageTracker *UsageTracker) []types.Message {
+	requestIndex, request, credentials := findAuthCredentials(messages)
+	if request == nil {
+		return messages
+	}
+	if supplied := AuthCredentialsFromContext(ctx); supplied != nil {
+		credentials = supplied
+	}
+
+	var taskID *string
+	var contextID *string
+	if task, ok := ctx.Value(TaskContextKey).(*types.Task); ok && task != nil {
+		taskID = &task.ID
+		contextID = &task.ContextID
+	}
+
+	if request.Arguments == nil {
+		request.Arguments = map[string]any{}
+	}
+	arguments, err := json.Marshal(request.Arguments)
+	if err != nil {
+		a.logger.Error("failed to encode authorized tool arguments", zap.String("tool", request.ToolName), zap.Error(err))
+		return messages
+	}
+	toolCall := sdk.ChatCompletionMessageToolCall{
+		ID:   request.ToolCallID,
+		Type: "function",
+		Function: sdk.ChatCompletionMessageToolCallFunction{
+			Name:      request.ToolName,
+			Arguments: string(arguments),
+		},
+	}
+
+	toolCallMessage := types.NewAssistantMessage(
+		fmt.Sprintf("authorized-tool-call-%s", request.ToolCallID),
+		[]types.Part{types.CreateDataPart(map[string]any{
+			"tool_calls": []sdk.ChatCompletionMessageToolCall{toolCall},
+		})},
+	)
+	toolCallMessage.TaskID = taskID
+	toolCallMessage.ContextID = contextID
+	resumed := append(slices.Clone(messages[:requestIndex]), *toolCallMessage)
+
+	a.logger.Info("task resumed with credentials, executing tool",
+		zap.String("tool", request.ToolName),
+		zap.String("tool_call_id", request.ToolCallID))
+	// the call paused after any approval it needed, so it is not asked for again
+	authorizedCtx := context.WithValue(ctx, approvedToolCallContextKey, request.ToolCallID)
+	if credentials.Credentials != "" {
+		authorizedCtx = context.WithValue(authorizedCtx, AuthCredentialsContextKey, credentials)
+	}
+	return append(resumed, a.executeToolCallsWithEvents(authorizedCtx, []sdk.ChatCompletionMessageToolCall{toolCall}, outputChan, usageTracker)...)
+}
+
+// taskCredentials holds the credentials clients resume auth-required tasks with until the run
+// of the task takes them, so they are kept out of the stored task history. Credentials are
+// held in memory, so a task run by another instance finds none and asks for them again.
+type taskCredentials struct {
+	mu     sync.Mutex
+	byTask map[string]*types.AuthCredentials
+}
+
+// newTaskCredentials returns an empty credential holder
+func newTaskCredentials() *taskCredentials {
+	return &taskCredentials{byTask: make(map[string]*types.AuthCredentials)}
+}
+
+// hold takes the credentials out of a message resuming an auth-required task, leaving the
+// scheme only, and holds them for the run of the task
+func (c *taskCredentials) hold(taskID string, message *types.Message) {
+	if c == nil {
+		return
+	}
+	credentials, found, err := types.GetAuthCredentials(message)
+	if err != nil || !found || credentials.Credentials == "" {
+		return
+	}
+
+	parts := slices.Clone(message.Parts)
+	for i, part := range parts {
+		if part.Data != nil && part.Data.Data[types.AuthCredentialsDataKey] != nil {
+			parts[i] = types.NewAuthCredentialsPart(credentials.Scheme, "")
+		}
+	}
+	message.Parts = parts
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byTask[taskID] = credentials
+}
+
+// context returns the context of a run of the task with the credentials held for it, which
+// are released, so each set of credentials serves one run
+func (c *taskCredentials) context(ctx context.Context, taskID string) context.Context {
+	if c == nil {
+		return ctx
+	}
+	c.mu.Lock()
+	credentials, held := c.byTask[taskID]
+	delete(c.byTask, taskID)
+	c.mu.Unlock()
+	if !held {
+		return ctx
+	}
+	return context.WithValue(ctx, AuthCredentialsContextKey, credentials)
+}
+
+// pausedTaskState returns the state a task pauses in on an agent event requesting input or
+// authorization
+func pausedTaskState(eventType string) types.TaskState {
+	if eventType == types.EventAuthRequired {
+		return types.TaskStateAuthRequired
+	}
+	return types.TaskStateInputRequired
+}
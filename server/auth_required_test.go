@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestAgentRun_PausesAndResumesWithCredentials(t *testing.T) {
+	llmClient := &scriptedLLMClient{responses: []*sdk.CreateChatCompletionStreamResponse{
+		toolCallChunk("call-1", "calendar"),
+		textChunk("You have 2 meetings"),
+	}}
+	var seen []string
+	toolBox := NewDefaultToolBox(nil)
+	toolBox.AddTool(NewBasicTool("calendar", "Lists the meetings of the user", map[string]any{"type": "object"},
+		func(ctx context.Context, args map[string]any) (string, error) {
+			credentials := AuthCredentialsFromContext(ctx)
+			if credentials == nil {
+				seen = append(seen, "")
+				return "", &AuthRequiredError{Scheme: "Bearer", AuthorizationURL: "https://auth.example.com/authorize", Scopes: []string{"calendar.read"}}
+			}
+			seen = append(seen, credentials.Credentials)
+			return "2 meetings", nil
+		}))
+	agent, err := NewAgentBuilder(zap.NewNop()).WithLLMClient(llmClient).WithToolBox(toolBox).Build()
+	require.NoError(t, err)
+
+	messages := []types.Message{{MessageID: "m1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("what's on today?")}}}
+	events, err := agent.RunWithStream(context.Background(), messages)
+	require.NoError(t, err)
+
+	var authMessage *types.Message
+	for event := range events {
+		if event.Type() == types.EventAuthRequired {
+			authMessage = &types.Message{}
+			require.NoError(t, event.DataAs(authMessage))
+		}
+	}
+	require.NotNil(t, authMessage)
+	request, found, err := types.GetAuthRequest(authMessage)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "https://auth.example.com/authorize", request.AuthorizationURL)
+	assert.Equal(t, []string{"calendar.read"}, request.Scopes)
+	assert.Equal(t, "call-1", request.ToolCallID)
+	assert.Equal(t, "Authorization is required to continue. Authorize at https://auth.example.com/authorize", *authMessage.Parts[0].Text)
+
+	resume := types.Message{MessageID: "m2", Role: types.RoleUser, Parts: []types.Part{types.NewAuthCredentialsPart("Bearer", "secret-token")}}
+	assert.Equal(t, types.TaskStateCompleted, runToCompletion(t, context.Background(), agent, append(messages, *authMessage, resume)))
+
+	assert.Equal(t, []string{"", "secret-token"}, seen)
+	require.Len(t, llmClient.calls, 2)
+	for _, message := range llmClient.calls[1] {
+		content, _ := message.Content.AsMessageContent0()
+		assert.NotContains(t, content, "secret-token", "credentials never reach the model")
+	}
+	last := llmClient.calls[1][len(llmClient.calls[1])-1]
+	assert.Equal(t, sdk.Tool, last.Role)
+}
+
+func TestProtocolHandler_HoldsAuthCredentials(t *testing.T) {
+	logger := zap.NewNop()
+	storage := NewInMemoryStorage(logger, 20)
+	taskManager := NewDefaultTaskManagerWithStorage(logger, storage)
+	h := NewDefaultA2AProtocolHandler(logger, storage, taskManager, NewDefaultResponseSender(logger))
+	h.setTaskCredentials(newTaskCredentials())
+
+	task := taskManager.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "m1", Role: types.RoleUser})
+	task.Status.State = types.TaskStateAuthRequired
+	task.Status.Message = types.NewAuthRequiredMessage("call-1", types.AuthRequest{Scheme: "Bearer", ToolCallID: "call-1", ToolName: "calendar"})
+	require.NoError(t, taskManager.UpdateTask(task))
+
+	message := types.Message{MessageID: "m2", Role: types.RoleUser, TaskID: &task.ID, Parts: []types.Part{types.NewAuthCredentialsPart("Bearer", "secret-token")}}
+	resumed, refusal, err := h.createTaskFromMessage(context.Background(), types.MessageSendParams{Message: message})
+	require.NoError(t, err)
+	require.Nil(t, refusal)
+	assert.Equal(t, types.TaskStateWorking, resumed.Status.State)
+
+	stored, exists := taskManager.GetTask(task.ID)
+	require.True(t, exists)
+	history, err := json.Marshal(stored.History)
+	require.NoError(t, err)
+	assert.NotContains(t, string(history), "secret-token", "credentials are kept out of the task history")
+	credentials, found, err := types.GetAuthCredentials(&stored.History[len(stored.History)-1])
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, types.AuthCredentials{Scheme: "Bearer"}, *credentials)
+
+	runCtx := h.credentials.context(context.Background(), task.ID)
+	require.NotNil(t, AuthCredentialsFromContext(runCtx))
+	assert.Equal(t, "secret-token", AuthCredentialsFromContext(runCtx).Credentials)
+	assert.Nil(t, AuthCredentialsFromContext(h.credentials.context(context.Background(), task.ID)), "credentials serve one run")
+}
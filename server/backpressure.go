@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	zap "go.uber.org/zap"
+)
+
+// eventDeliveryTimeout bounds how long a producer waits for the consumer of a full event
+// channel before dropping the event
+const eventDeliveryTimeout = 5 * time.Second
+
+// Channels events are dropped from, as reported by the debug stats and metrics
+const (
+	dropChannelAgentStream = "agent_stream"
+	dropChannelEventSink   = "event_sink"
+	dropChannelProgress    = "progress"
+)
+
+// droppedEvents counts the events dropped in the process by channel. Agents stream without a
+// server, so the count is shared by every server and agent of the process.
+var droppedEvents = &eventDropCounter{}
+
+// eventDropCounter counts dropped events by channel
+type eventDropCounter struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+// add counts events dropped from the channel
+func (c *eventDropCounter) add(channel string, dropped int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[string]int64)
+	}
+	c.counts[channel] += dropped
+}
+
+// snapshot returns the events dropped so far by channel
+func (c *eventDropCounter) snapshot() map[string]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts := make(map[string]int64, len(c.counts))
+	maps.Copy(counts, c.counts)
+	return counts
+}
+
+// sendEvent delivers the event on a bounded channel, blocking while the channel is full until
+// the consumer receives it, the context ends or the timeout passes. An event that could not
+// be delivered is logged and counted as dropped from the named channel.
+func sendEvent(ctx context.Context, events chan<- cloudevents.Event, event cloudevents.Event, timeout time.Duration, channel string, logger *zap.Logger) bool {
+	select {
+	case events <- event:
+		return true
+	default:
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+
+	droppedEvents.add(channel, 1)
+	logger.Warn("event consumer is not receiving, dropping event",
+		zap.String("channel", channel),
+		zap.String("event_type", event.Type()),
+		zap.Duration("timeout", timeout))
+	return false
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	assert "github.com/stretchr/testify/assert"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestSendEvent(t *testing.T) {
+	const channel = "test_channel"
+	event := types.NewAgentEvent(types.EventToolStarted, "event-1", nil)
+	// the counters are process-wide, so drops are counted from the start of the test
+	before := droppedEvents.snapshot()[channel]
+	dropped := func() int64 { return droppedEvents.snapshot()[channel] - before }
+
+	events := make(chan cloudevents.Event, 1)
+	assert.True(t, sendEvent(context.Background(), events, event, time.Second, channel, zap.NewNop()))
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		<-events
+	}()
+	assert.True(t, sendEvent(context.Background(), events, event, time.Second, channel, zap.NewNop()),
+		"a full channel blocks until the consumer receives")
+	assert.Zero(t, dropped())
+
+	assert.False(t, sendEvent(context.Background(), events, event, 10*time.Millisecond, channel, zap.NewNop()),
+		"a consumer that stops receiving gets the event dropped after the timeout")
+	assert.Equal(t, int64(1), dropped())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, sendEvent(ctx, events, event, time.Minute, channel, zap.NewNop()))
+	assert.Equal(t, int64(2), dropped())
+}
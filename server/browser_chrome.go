@@ -0,0 +1,119 @@
+//go:build chrome
+
+package server
+
+import (
+	"context"
+	"net/url"
+
+	cdp "github.com/chromedp/cdproto/cdp"
+	fetch "github.com/chromedp/cdproto/fetch"
+	network "github.com/chromedp/cdproto/network"
+	chromedp "github.com/chromedp/chromedp"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+// chromeBrowser starts a headless Chrome with a temporary profile per session
+type chromeBrowser struct {
+	execPath string
+}
+
+// NewChromeBrowser creates a Browser that drives a local headless Chrome or Chromium through
+// the DevTools protocol. The binary is looked up on the PATH unless the config names it. It is
+// only built with the chrome build tag, which keeps chromedp out of other builds.
+func NewChromeBrowser(cfg config.BrowserConfig) Browser {
+	return &chromeBrowser{execPath: cfg.ExecPath}
+}
+
+// NewSession starts a browser whose requests are intercepted and failed when allow refuses them
+func (b *chromeBrowser) NewSession(ctx context.Context, allow func(*url.URL) error) (BrowserSession, error) {
+	options := append([]chromedp.ExecAllocatorOption{}, chromedp.DefaultExecAllocatorOptions[:]...)
+	if b.execPath != "" {
+		options = append(options, chromedp.ExecPath(b.execPath))
+	}
+	allocatorCtx, cancelAllocator := chromedp.NewExecAllocator(ctx, options...)
+	browserCtx, cancelBrowser := chromedp.NewContext(allocatorCtx)
+	session := &chromeSession{ctx: browserCtx, cancel: func() {
+		cancelBrowser()
+		cancelAllocator()
+	}}
+
+	chromedp.ListenTarget(browserCtx, func(event any) {
+		paused, ok := event.(*fetch.EventRequestPaused)
+		if !ok {
+			return
+		}
+		go func() {
+			executor := cdp.WithExecutor(browserCtx, chromedp.FromContext(browserCtx).Target)
+			if err := checkBrowserRequest(paused.Request.URL, allow); err != nil {
+				_ = fetch.FailRequest(paused.RequestID, network.ErrorReasonBlockedByClient).Do(executor)
+				return
+			}
+			_ = fetch.ContinueRequest(paused.RequestID).Do(executor)
+		}()
+	})
+	if err := chromedp.Run(browserCtx, fetch.Enable()); err != nil {
+		_ = session.Close()
+		return nil, err
+	}
+	return session, nil
+}
+
+// chromeSession is the page of a chromeBrowser session
+type chromeSession struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (s *chromeSession) Navigate(target string) error {
+	return chromedp.Run(s.ctx, chromedp.Navigate(target))
+}
+
+func (s *chromeSession) Click(selector string) error {
+	return chromedp.Run(s.ctx, chromedp.Click(selector, chromedp.ByQuery, chromedp.NodeVisible))
+}
+
+func (s *chromeSession) Fill(selector, value string) error {
+	return chromedp.Run(s.ctx,
+		chromedp.Clear(selector, chromedp.ByQuery, chromedp.NodeVisible),
+		chromedp.SendKeys(selector, value, chromedp.ByQuery, chromedp.NodeVisible),
+	)
+}
+
+func (s *chromeSession) Submit(selector string) error {
+	return chromedp.Run(s.ctx, chromedp.Submit(selector, chromedp.ByQuery))
+}
+
+func (s *chromeSession) Text(selector string) (string, error) {
+	var text string
+	err := chromedp.Run(s.ctx, chromedp.Text(selector, &text, chromedp.ByQuery))
+	return text, err
+}
+
+func (s *chromeSession) Screenshot(selector string, fullPage bool) ([]byte, error) {
+	var image []byte
+	var action chromedp.Action
+	switch {
+	case selector != "":
+		action = chromedp.Screenshot(selector, &image, chromedp.ByQuery, chromedp.NodeVisible)
+	case fullPage:
+		action = chromedp.FullScreenshot(&image, 100)
+	default:
+		action = chromedp.CaptureScreenshot(&image)
+	}
+	err := chromedp.Run(s.ctx, action)
+	return image, err
+}
+
+func (s *chromeSession) Location() (string, string, error) {
+	var location, title string
+	err := chromedp.Run(s.ctx, chromedp.Location(&location), chromedp.Title(&title))
+	return location, title, err
+}
+
+func (s *chromeSession) Close() error {
+	err := chromedp.Cancel(s.ctx)
+	s.cancel()
+	return err
+}
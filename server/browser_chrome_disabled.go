@@ -0,0 +1,25 @@
+//go:build !chrome
+
+package server
+
+import (
+	"context"
+	"net/url"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+// chromeBrowser stands in for the Chrome browser in builds without the chrome build tag
+type chromeBrowser struct{}
+
+// NewChromeBrowser creates a Browser that drives a local headless Chrome or Chromium. This
+// build does not include it, so its sessions fail with ErrChromeBrowserNotBuilt; build with
+// the chrome build tag to use Chrome.
+func NewChromeBrowser(cfg config.BrowserConfig) Browser {
+	return &chromeBrowser{}
+}
+
+// NewSession fails, as the build has no Chrome support
+func (b *chromeBrowser) NewSession(ctx context.Context, allow func(*url.URL) error) (BrowserSession, error) {
+	return nil, ErrChromeBrowserNotBuilt
+}
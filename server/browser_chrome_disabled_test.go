@@ -0,0 +1,22 @@
+//go:build !chrome
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+func TestBrowseWebTool_ChromeNotBuilt(t *testing.T) {
+	cfg := config.BrowserConfig{AllowedDomains: []string{"example.com"}}
+	tool := NewBrowseWebTool(cfg, NewChromeBrowser(cfg))
+
+	_, err := tool.Execute(context.Background(), map[string]any{"url": "https://example.com"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrChromeBrowserNotBuilt)
+}
@@ -0,0 +1,281 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	uuid "github.com/google/uuid"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// Budget scopes
+const (
+	BudgetScopeTask    = "task"
+	BudgetScopeContext = "context"
+	BudgetScopeDaily   = "daily"
+	BudgetScopeUser    = "user"
+)
+
+// Budget units
+const (
+	BudgetUnitTokens = "tokens"
+	BudgetUnitCost   = "cost"
+)
+
+// BudgetUsage is the token usage counted against each budget scope
+type BudgetUsage struct {
+	// Task is the usage of the current task, across all of its runs
+	Task types.TokenUsage
+
+	// Context is the usage of the tasks of the current context
+	Context types.TokenUsage
+
+	// Daily is the usage of the agent on the current UTC day
+	Daily types.TokenUsage
+
+	// User is the usage of the tasks of the current task's user on the current UTC day
+	User types.TokenUsage
+}
+
+// BudgetExceeded describes a budget limit that was reached
+type BudgetExceeded struct {
+	Scope string  `json:"scope"`
+	Unit  string  `json:"unit"`
+	Used  float64 `json:"used"`
+	Limit float64 `json:"limit"`
+}
+
+// Error describes the exhausted budget
+func (e *BudgetExceeded) Error() string {
+	if e.Unit == BudgetUnitCost {
+		return fmt.Sprintf("%s budget of %.4f exhausted (%.4f estimated cost)", e.Scope, e.Limit, e.Used)
+	}
+	return fmt.Sprintf("%s budget of %d tokens exhausted (%d tokens used)", e.Scope, int64(e.Limit), int64(e.Used))
+}
+
+// NewBudgetExceededResponse creates the BeforeModel response that ends a run because a budget
+// was exceeded, failing the task or pausing it for input. Custom budget policies can return it
+// from their own BeforeModel callbacks.
+func NewBudgetExceededResponse(exceeded *BudgetExceeded, state types.TaskState) *LLMResponse {
+	text := fmt.Sprintf("Budget exceeded: %s.", exceeded.Error())
+	if state == types.TaskStateInputRequired {
+		text += " Reply to continue once the budget has been raised."
+	}
+
+	return &LLMResponse{
+		Content: &types.Message{
+			MessageID: fmt.Sprintf("budget-exceeded-%s", uuid.New().String()),
+			Role:      types.RoleAgent,
+			Parts: []types.Part{
+				types.NewTextPart(text),
+				types.NewDataPart(map[string]any{
+					types.BudgetExceededDataKey: map[string]any{
+						"scope": exceeded.Scope,
+						"unit":  exceeded.Unit,
+						"used":  exceeded.Used,
+						"limit": exceeded.Limit,
+					},
+				}),
+			},
+		},
+		State: state,
+	}
+}
+
+// Budget enforces the LLM spend limits of an agent. Its BeforeModel callback stops the agent
+// before an LLM call once the task, its context, the daily spend of its user or the agent's
+// daily spend reached a limit.
+//
+// Task usage is read from the task metadata and the usage tracker of the current run. Context,
+// user and daily usage are accumulated in memory from the task usage the budget observes, so
+// they count the tasks the agent ran since it started.
+type Budget struct {
+	cfg    config.BudgetConfig
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu       sync.Mutex
+	tasks    map[string]types.TokenUsage
+	contexts map[string]types.TokenUsage
+	day      string
+	daily    types.TokenUsage
+	users    map[string]types.TokenUsage
+}
+
+// NewBudget creates a budget enforcing the configured limits
+func NewBudget(cfg config.BudgetConfig, logger *zap.Logger) *Budget {
+	return &Budget{
+		cfg:      cfg,
+		logger:   logger,
+		now:      time.Now,
+		tasks:    make(map[string]types.TokenUsage),
+		contexts: make(map[string]types.TokenUsage),
+		users:    make(map[string]types.TokenUsage),
+	}
+}
+
+// SetConfig replaces the limits, prices and action of the budget. The usage counted so far is kept.
+func (b *Budget) SetConfig(cfg config.BudgetConfig) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cfg = cfg
+}
+
+// config returns the current budget configuration
+func (b *Budget) config() config.BudgetConfig {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.cfg
+}
+
+// Callbacks returns the callbacks enforcing the budget, for agents built without the agent builder
+func (b *Budget) Callbacks() *CallbackConfig {
+	return &CallbackConfig{
+		BeforeModel: []BeforeModelCallback{b.BeforeModel},
+		AfterModel:  []AfterModelCallback{b.AfterModel},
+	}
+}
+
+// BeforeModel stops the run when a budget limit is reached, in the configured state
+func (b *Budget) BeforeModel(ctx context.Context, callbackCtx *CallbackContext, llmRequest *LLMRequest) *LLMResponse {
+	exceeded := b.Check(b.Usage(ctx, callbackCtx))
+	if exceeded == nil {
+		return nil
+	}
+
+	state := types.TaskStateFailed
+	if b.config().Action == config.BudgetActionInputRequired {
+		state = types.TaskStateInputRequired
+	}
+
+	b.logger.Warn("budget exceeded, stopping the agent",
+		zap.String("task_id", callbackCtx.TaskID),
+		zap.String("context_id", callbackCtx.ContextID),
+		zap.String("scope", exceeded.Scope),
+		zap.String("unit", exceeded.Unit),
+		zap.Float64("used", exceeded.Used),
+		zap.Float64("limit", exceeded.Limit))
+	return NewBudgetExceededResponse(exceeded, state)
+}
+
+// AfterModel records the spend of the LLM call, so it counts against the context, user and
+// daily budgets even when the task makes no further calls
+func (b *Budget) AfterModel(ctx context.Context, callbackCtx *CallbackContext, llmResponse *LLMResponse) *LLMResponse {
+	b.Usage(ctx, callbackCtx)
+	return nil
+}
+
+// Usage records the spend of the current task and returns the usage counted against each scope
+func (b *Budget) Usage(ctx context.Context, callbackCtx *CallbackContext) BudgetUsage {
+	taskUsage := b.taskUsage(ctx)
+	var userID string
+	if task, ok := ctx.Value(TaskContextKey).(*types.Task); ok {
+		userID = types.GetTaskUserID(task)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	day := b.now().UTC().Format(time.DateOnly)
+	if day != b.day {
+		b.day = day
+		b.daily = types.TokenUsage{}
+		clear(b.users)
+	}
+
+	if callbackCtx.TaskID != "" {
+		last, seen := b.tasks[callbackCtx.TaskID]
+		spent := usageSince(taskUsage, last)
+		b.tasks[callbackCtx.TaskID] = taskUsage
+
+		if callbackCtx.ContextID != "" {
+			b.contexts[callbackCtx.ContextID] = b.contexts[callbackCtx.ContextID].Add(spent)
+		}
+		// Usage recorded before the budget first saw the task may be from an earlier day
+		if seen {
+			b.daily = b.daily.Add(spent)
+			if userID != "" {
+				b.users[userID] = b.users[userID].Add(spent)
+			}
+		}
+	}
+
+	usage := BudgetUsage{
+		Task:    taskUsage,
+		Context: b.contexts[callbackCtx.ContextID],
+		Daily:   b.daily,
+	}
+	if userID != "" {
+		usage.User = b.users[userID]
+	}
+	return usage
+}
+
+// Cost estimates the cost of the token usage from the configured prices per million tokens
+func (b *Budget) Cost(usage types.TokenUsage) float64 {
+	cfg := b.config()
+	return float64(usage.PromptTokens)*cfg.PromptTokenPrice/1_000_000 +
+		float64(usage.CompletionTokens)*cfg.CompletionTokenPrice/1_000_000
+}
+
+// Check returns the first budget limit the usage reached, or nil when it is within budget
+func (b *Budget) Check(usage BudgetUsage) *BudgetExceeded {
+	cfg := b.config()
+	scopes := []struct {
+		scope     string
+		usage     types.TokenUsage
+		maxTokens int64
+		maxCost   float64
+	}{
+		{BudgetScopeTask, usage.Task, cfg.MaxTaskTokens, cfg.MaxTaskCost},
+		{BudgetScopeContext, usage.Context, cfg.MaxContextTokens, cfg.MaxContextCost},
+		{BudgetScopeUser, usage.User, cfg.MaxUserTokens, cfg.MaxUserCost},
+		{BudgetScopeDaily, usage.Daily, cfg.MaxDailyTokens, cfg.MaxDailyCost},
+	}
+
+	for _, s := range scopes {
+		if s.maxTokens > 0 && s.usage.TotalTokens >= s.maxTokens {
+			return &BudgetExceeded{Scope: s.scope, Unit: BudgetUnitTokens, Used: float64(s.usage.TotalTokens), Limit: float64(s.maxTokens)}
+		}
+		if cost := b.Cost(s.usage); s.maxCost > 0 && cost >= s.maxCost {
+			return &BudgetExceeded{Scope: s.scope, Unit: BudgetUnitCost, Used: cost, Limit: s.maxCost}
+		}
+	}
+	return nil
+}
+
+// taskUsage returns the usage recorded on the task by earlier runs plus the usage of the
+// current run
+func (b *Budget) taskUsage(ctx context.Context) types.TokenUsage {
+	var usage types.TokenUsage
+	if task, ok := ctx.Value(TaskContextKey).(*types.Task); ok && task != nil {
+		recorded, _, err := types.GetTaskUsage(task)
+		if err != nil {
+			b.logger.Warn("failed to read recorded task usage", zap.String("task_id", task.ID), zap.Error(err))
+		}
+		usage = recorded
+	}
+	if tracker, ok := ctx.Value(UsageTrackerContextKey).(*UsageTracker); ok && tracker != nil {
+		run, _ := tracker.TokenUsage()
+		usage = usage.Add(run)
+	}
+	return usage
+}
+
+// usageSince returns the usage added since the previous observation, ignoring counts that went
+// down
+func usageSince(current, previous types.TokenUsage) types.TokenUsage {
+	since := func(current, previous int64) int64 {
+		return max(current-previous, 0)
+	}
+	return types.TokenUsage{
+		CompletionTokens: since(current.CompletionTokens, previous.CompletionTokens),
+		PromptTokens:     since(current.PromptTokens, previous.PromptTokens),
+		TotalTokens:      since(current.TotalTokens, previous.TotalTokens),
+	}
+}
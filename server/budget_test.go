@@ -0,0 +1,219 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	server "github.com/inference-gateway/adk/server"
+	config "github.com/inference-gateway/adk/server/config"
+	mocks "github.com/inference-gateway/adk/server/mocks"
+	types "github.com/inference-gateway/adk/types"
+	sdk "github.com/inference-gateway/sdk"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+)
+
+func budgetContext(taskID, contextID string, recorded map[string]any, run *sdk.CompletionUsage) context.Context {
+	task := &types.Task{ID: taskID, ContextID: contextID}
+	if recorded != nil {
+		task.Metadata = &map[string]any{types.UsageMetadataKey: recorded}
+	}
+	tracker := server.NewUsageTracker()
+	if run != nil {
+		tracker.AddTokenUsage(*run)
+	}
+	ctx := context.WithValue(context.Background(), server.TaskContextKey, task)
+	return context.WithValue(ctx, server.UsageTrackerContextKey, tracker)
+}
+
+func TestBudget_Check(t *testing.T) {
+	usage := server.BudgetUsage{
+		Task:    types.TokenUsage{PromptTokens: 800, CompletionTokens: 200, TotalTokens: 1000},
+		Context: types.TokenUsage{PromptTokens: 4000, CompletionTokens: 1000, TotalTokens: 5000},
+		User:    types.TokenUsage{PromptTokens: 16000, CompletionTokens: 4000, TotalTokens: 20000},
+		Daily:   types.TokenUsage{PromptTokens: 40000, CompletionTokens: 10000, TotalTokens: 50000},
+	}
+
+	tests := []struct {
+		name     string
+		cfg      config.BudgetConfig
+		expected *server.BudgetExceeded
+	}{
+		{
+			name: "within budget",
+			cfg:  config.BudgetConfig{MaxTaskTokens: 2000, MaxContextTokens: 10000, MaxDailyTokens: 100000},
+		},
+		{
+			name:     "task tokens",
+			cfg:      config.BudgetConfig{MaxTaskTokens: 1000, MaxDailyTokens: 10},
+			expected: &server.BudgetExceeded{Scope: server.BudgetScopeTask, Unit: server.BudgetUnitTokens, Used: 1000, Limit: 1000},
+		},
+		{
+			name:     "context tokens",
+			cfg:      config.BudgetConfig{MaxTaskTokens: 2000, MaxContextTokens: 4000},
+			expected: &server.BudgetExceeded{Scope: server.BudgetScopeContext, Unit: server.BudgetUnitTokens, Used: 5000, Limit: 4000},
+		},
+		{
+			name:     "user tokens",
+			cfg:      config.BudgetConfig{MaxContextTokens: 10000, MaxUserTokens: 20000, MaxDailyTokens: 40000},
+			expected: &server.BudgetExceeded{Scope: server.BudgetScopeUser, Unit: server.BudgetUnitTokens, Used: 20000, Limit: 20000},
+		},
+		{
+			name: "daily cost",
+			cfg: config.BudgetConfig{
+				MaxDailyCost:         0.3,
+				PromptTokenPrice:     5,
+				CompletionTokenPrice: 15,
+			},
+			expected: &server.BudgetExceeded{Scope: server.BudgetScopeDaily, Unit: server.BudgetUnitCost, Used: 0.35, Limit: 0.3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exceeded := server.NewBudget(tt.cfg, zap.NewNop()).Check(usage)
+			if tt.expected == nil {
+				assert.Nil(t, exceeded)
+				return
+			}
+			require.NotNil(t, exceeded)
+			assert.Equal(t, tt.expected.Scope, exceeded.Scope)
+			assert.Equal(t, tt.expected.Unit, exceeded.Unit)
+			assert.InDelta(t, tt.expected.Used, exceeded.Used, 1e-9)
+			assert.InDelta(t, tt.expected.Limit, exceeded.Limit, 1e-9)
+		})
+	}
+}
+
+func TestBudget_Usage(t *testing.T) {
+	budget := server.NewBudget(config.BudgetConfig{MaxContextTokens: 1000}, zap.NewNop())
+	callbackCtx := func(taskID string) *server.CallbackContext {
+		return &server.CallbackContext{TaskID: taskID, ContextID: "ctx-1"}
+	}
+
+	// task-1 was resumed with usage recorded by an earlier run, which predates the day's budget
+	resumed := budgetContext("task-1", "ctx-1", map[string]any{"prompt_tokens": 300, "completion_tokens": 100, "total_tokens": 400}, nil)
+	usage := budget.Usage(resumed, callbackCtx("task-1"))
+	assert.Equal(t, int64(400), usage.Task.TotalTokens)
+	assert.Equal(t, int64(400), usage.Context.TotalTokens)
+	assert.Equal(t, int64(0), usage.Daily.TotalTokens)
+
+	afterCall := budgetContext("task-1", "ctx-1", map[string]any{"prompt_tokens": 300, "completion_tokens": 100, "total_tokens": 400},
+		&sdk.CompletionUsage{PromptTokens: 150, CompletionTokens: 50, TotalTokens: 200})
+	usage = budget.Usage(afterCall, callbackCtx("task-1"))
+	assert.Equal(t, int64(600), usage.Task.TotalTokens)
+	assert.Equal(t, int64(600), usage.Context.TotalTokens)
+	assert.Equal(t, int64(200), usage.Daily.TotalTokens)
+
+	// Observing the same usage again does not count it twice
+	usage = budget.Usage(afterCall, callbackCtx("task-1"))
+	assert.Equal(t, int64(600), usage.Context.TotalTokens)
+
+	other := budgetContext("task-2", "ctx-1", nil, nil)
+	assert.Nil(t, budget.Check(budget.Usage(other, callbackCtx("task-2"))))
+
+	other = budgetContext("task-2", "ctx-1", nil, &sdk.CompletionUsage{PromptTokens: 300, CompletionTokens: 100, TotalTokens: 400})
+	usage = budget.Usage(other, callbackCtx("task-2"))
+	assert.Equal(t, int64(400), usage.Task.TotalTokens)
+	assert.Equal(t, int64(1000), usage.Context.TotalTokens)
+	assert.Equal(t, int64(600), usage.Daily.TotalTokens)
+
+	exceeded := budget.Check(usage)
+	require.NotNil(t, exceeded)
+	assert.Equal(t, "context budget of 1000 tokens exhausted (1000 tokens used)", exceeded.Error())
+}
+
+func TestBudget_UserUsage(t *testing.T) {
+	budget := server.NewBudget(config.BudgetConfig{MaxUserTokens: 500}, zap.NewNop())
+	userContext := func(taskID, contextID, userID string, run *sdk.CompletionUsage) context.Context {
+		ctx := budgetContext(taskID, contextID, nil, run)
+		task := ctx.Value(server.TaskContextKey).(*types.Task)
+		task.Metadata = &map[string]any{types.UserIDMetadataKey: userID}
+		return ctx
+	}
+
+	for _, taskID := range []string{"task-1", "task-2"} {
+		contextID := "ctx-" + taskID
+		callbackCtx := &server.CallbackContext{TaskID: taskID, ContextID: contextID}
+		budget.Usage(userContext(taskID, contextID, "alice", nil), callbackCtx)
+		usage := budget.Usage(userContext(taskID, contextID, "alice", &sdk.CompletionUsage{PromptTokens: 200, CompletionTokens: 50, TotalTokens: 250}), callbackCtx)
+		assert.Equal(t, int64(250), usage.Context.TotalTokens)
+	}
+
+	callbackCtx := &server.CallbackContext{TaskID: "task-2", ContextID: "ctx-task-2"}
+	usage := budget.Usage(userContext("task-2", "ctx-task-2", "alice", &sdk.CompletionUsage{PromptTokens: 200, CompletionTokens: 50, TotalTokens: 250}), callbackCtx)
+	assert.Equal(t, int64(500), usage.User.TotalTokens, "the spend of every task of the user is counted")
+	exceeded := budget.Check(usage)
+	require.NotNil(t, exceeded)
+	assert.Equal(t, server.BudgetScopeUser, exceeded.Scope)
+
+	bob := &server.CallbackContext{TaskID: "task-3", ContextID: "ctx-3"}
+	usage = budget.Usage(userContext("task-3", "ctx-3", "bob", nil), bob)
+	assert.Nil(t, budget.Check(usage), "other users have their own budget")
+}
+
+func TestRunWithStream_BudgetExceeded(t *testing.T) {
+	tests := []struct {
+		name          string
+		action        string
+		expectedEvent string
+		expectedText  string
+	}{
+		{
+			name:          "fails the task",
+			action:        config.BudgetActionFail,
+			expectedEvent: types.EventStreamFailed,
+			expectedText:  "Budget exceeded: task budget of 500 tokens exhausted (600 tokens used).",
+		},
+		{
+			name:          "pauses the task for input",
+			action:        config.BudgetActionInputRequired,
+			expectedEvent: types.EventInputRequired,
+			expectedText:  "Budget exceeded: task budget of 500 tokens exhausted (600 tokens used). Reply to continue once the budget has been raised.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLLMClient := &mocks.FakeLLMClient{}
+			agent, err := server.NewAgentBuilder(zap.NewNop()).
+				WithConfig(&config.AgentConfig{
+					MaxChatCompletionIterations: 5,
+					Budget:                      config.BudgetConfig{MaxTaskTokens: 500, Action: tt.action},
+				}).
+				WithLLMClient(mockLLMClient).
+				Build()
+			require.NoError(t, err)
+
+			ctx := budgetContext("task-1", "ctx-1", map[string]any{"prompt_tokens": 450, "completion_tokens": 150, "total_tokens": 600}, nil)
+			eventChan, err := agent.RunWithStream(ctx, []types.Message{
+				{Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("Keep going")}},
+			})
+			require.NoError(t, err)
+
+			var stopMessage *types.Message
+			var failedStatus bool
+			for event := range eventChan {
+				switch event.Type() {
+				case tt.expectedEvent:
+					var message types.Message
+					require.NoError(t, event.DataAs(&message))
+					stopMessage = &message
+				case types.EventTaskStatusChanged:
+					var status types.TaskStatus
+					require.NoError(t, event.DataAs(&status))
+					failedStatus = failedStatus || status.State == types.TaskStateFailed
+				}
+			}
+
+			assert.Equal(t, 0, mockLLMClient.CreateStreamingChatCompletionCallCount(), "the LLM must not be called once the budget is exhausted")
+			assert.Equal(t, tt.action == config.BudgetActionFail, failedStatus)
+			require.NotNil(t, stopMessage)
+			assert.Equal(t, tt.expectedText, *stopMessage.Parts[0].Text)
+			require.NotNil(t, stopMessage.Parts[1].Data)
+			assert.Equal(t, map[string]any{"scope": "task", "unit": "tokens", "used": float64(600), "limit": float64(500)},
+				stopMessage.Parts[1].Data.Data[types.BudgetExceededDataKey])
+		})
+	}
+}
@@ -9,7 +9,7 @@
 //
 // Before callbacks can skip default behavior by returning a non-nil value:
 //   - BeforeAgent returning a Message skips agent execution
-//   - BeforeModel returning an LLMResponse skips the LLM call
+//   - BeforeModel returning an LLMResponse skips the LLM call, and ends the run when its State is set
 //   - BeforeTool returning a map skips tool execution
 //
 // After callbacks can modify outputs by returning a non-nil value:
@@ -86,6 +86,14 @@ type ToolContext struct {
 
 	// Logger provides access to the logger for callback implementations
 	Logger *zap.Logger
+
+	// Journal is the journal entry of the tool call when the tool has side effects and the
+	// task is checkpointed, nil otherwise. Only set for AfterTool callbacks.
+	Journal *ToolJournalEntry
+
+	// Replayed reports that the tool was not executed because the journal already held the
+	// outcome of the call, recorded before the task was interrupted
+	Replayed bool
 }
 
 // LLMRequest represents a request to be sent to the LLM
@@ -113,6 +121,11 @@ type LLMConfig struct {
 type LLMResponse struct {
 	// Content is the main response content from the LLM
 	Content *types.Message
+
+	// State ends the run in the given state when set by a BeforeModel callback, with Content as
+	// the status message. Only types.TaskStateFailed and types.TaskStateInputRequired are
+	// supported, e.g. to stop an agent that exceeded its budget.
+	State types.TaskState
 }
 
 // Agent Lifecycle Callbacks
@@ -142,6 +155,7 @@ type AfterAgentCallback func(ctx context.Context, callbackContext *CallbackConte
 //
 // Return nil to allow the request to proceed, or return LLMResponse to skip the LLM call.
 // The returned LLMResponse is used directly as if it came from the model making it a powerful option for implementing guardrails or caching.
+// An LLMResponse with a State instead ends the run, failing the task or pausing it for input, which is how budgets are enforced.
 type BeforeModelCallback func(ctx context.Context, callbackContext *CallbackContext, llmRequest *LLMRequest) *LLMResponse
 
 // AfterModelCallback is called just after receiving a response from the LLM, before it's processed further by the invoking agent.
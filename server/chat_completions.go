@@ -0,0 +1,580 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// Paths of the OpenAI-compatible endpoints
+const (
+	chatCompletionsPath = "/v1/chat/completions"
+	chatModelsPath      = "/v1/models"
+)
+
+// conversationHistoryContextKey carries the earlier turns of a chat completion request to the
+// task created for it
+const conversationHistoryContextKey ContextKey = "conversationHistory"
+
+// chatCompletionRequest is the part of an OpenAI chat completion request the endpoint maps
+// onto a task. Sampling parameters are ignored, the agent's configuration applies.
+type chatCompletionRequest struct {
+	Model    string                  `json:"model"`
+	Messages []chatCompletionMessage `json:"messages"`
+	Stream   bool                    `json:"stream"`
+}
+
+// chatCompletionMessage is a message of a chat completion request, whose content is either a
+// string or a list of content parts
+type chatCompletionMessage struct {
+	Role    string          `json:"role"`
+	Content json.RawMessage `json:"content"`
+}
+
+// chatCompletionContentPart is a text or image_url content part of a chat message
+type chatCompletionContentPart struct {
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	ImageURL *struct {
+		URL string `json:"url"`
+	} `json:"image_url"`
+}
+
+// chatCompletion is a chat completion response, or a chunk of a streamed one
+type chatCompletion struct {
+	ID      string                 `json:"id"`
+	Object  string                 `json:"object"`
+	Created int64                  `json:"created"`
+	Model   string                 `json:"model"`
+	Choices []chatCompletionChoice `json:"choices"`
+}
+
+// chatCompletionChoice is the single choice of a chat completion, with the full message or,
+// in a chunk, the delta
+type chatCompletionChoice struct {
+	Index        int                  `json:"index"`
+	Message      *chatCompletionReply `json:"message,omitempty"`
+	Delta        *chatCompletionReply `json:"delta,omitempty"`
+	FinishReason *string              `json:"finish_reason"`
+}
+
+// chatCompletionReply is the assistant message of a choice
+type chatCompletionReply struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content"`
+}
+
+// chatCompletionError is the error body of the OpenAI API
+type chatCompletionError struct {
+	Error chatCompletionErrorDetail `json:"error"`
+}
+
+// chatCompletionErrorDetail describes an error of the OpenAI API
+type chatCompletionErrorDetail struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+// handleChatCompletions runs an OpenAI chat completion request as an A2A message/stream
+// request and answers in the chat completion format, streamed when the request asks for it.
+// The last message is sent to the agent and the earlier ones become the history of the task.
+func (s *A2AServerImpl) handleChatCompletions(c *gin.Context) {
+	logger := requestLogger(c.Request.Context(), s.logger)
+
+	body := c.Request.Body
+	limit := s.payloadLimitsFor("message/stream").maxBodySize
+	if limit > 0 {
+		body = http.MaxBytesReader(c.Writer, body, limit)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			logger.Warn("rejected oversized chat completion request", zap.Int64("limit", tooLarge.Limit))
+			writeChatCompletionError(c.Writer, http.StatusRequestEntityTooLarge, bodyTooLargeError(tooLarge.Limit).Message)
+			return
+		}
+		writeChatCompletionError(c.Writer, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+
+	var chatReq chatCompletionRequest
+	if err := json.Unmarshal(data, &chatReq); err != nil {
+		logger.Error("failed to parse chat completion request", zap.Error(err))
+		writeChatCompletionError(c.Writer, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	message, history, err := chatCompletionTask(chatReq.Messages, s.ids)
+	if err != nil {
+		writeChatCompletionError(c.Writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	params, err := json.Marshal(types.MessageSendParams{Message: *message})
+	if err != nil {
+		writeChatCompletionError(c.Writer, http.StatusInternalServerError, "failed to encode message")
+		return
+	}
+	id := any(s.ids.NewID())
+	req := types.JSONRPCRequest{JSONRPC: "2.0", ID: &id, Method: "message/stream"}
+	if err := json.Unmarshal(params, &req.Params); err != nil {
+		writeChatCompletionError(c.Writer, http.StatusInternalServerError, "failed to encode message")
+		return
+	}
+
+	logger.Info("received chat completion request",
+		zap.Int("message_count", len(chatReq.Messages)),
+		zap.Bool("stream", chatReq.Stream))
+
+	s.identifyUser(c, &req)
+	ctx := c.Request.Context()
+	if validationErr := s.validateA2ARequest(ctx, req, int64(len(data))); validationErr != nil {
+		logger.Warn("rejected invalid chat completion request", zap.String("reason", validationErr.Message))
+		writeChatCompletionError(c.Writer, chatCompletionErrorStatus(validationErr.Code), validationErr.Message)
+		return
+	}
+
+	model := chatReq.Model
+	if model == "" {
+		if card := s.GetAgentCard(); card != nil {
+			model = card.Name
+		}
+	}
+	writer := newChatCompletionWriter(c.Writer, chatReq.Stream, model, "chatcmpl-"+s.ids.NewID(), s.clock.Now())
+	c.Writer = writer
+
+	if s.rejectDuringDrain(c, req) {
+		writer.finish()
+		return
+	}
+
+	s.audit.call(c, req)
+	ctx = contextWithAuditLog(ctx, s.audit)
+	ctx = contextWithPolicy(ctx, s.policy)
+	c.Request = c.Request.WithContext(contextWithConversationHistory(ctx, history))
+
+	s.protocolHandler.HandleMessageStream(c, req, s.streamingTaskHandler)
+	writer.finish()
+}
+
+// handleChatModels lists the agent as the only model, so OpenAI clients that look up the
+// available models find it
+func (s *A2AServerImpl) handleChatModels(c *gin.Context) {
+	var name string
+	if card := s.GetAgentCard(); card != nil {
+		name = card.Name
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"object": "list",
+		"data": []gin.H{{
+			"id":       name,
+			"object":   "model",
+			"created":  0,
+			"owned_by": "adk",
+		}},
+	})
+}
+
+// chatCompletionTask maps the messages of a chat completion request onto the message sent to
+// the agent, the last one which must be from the user, and the history of the task. System and
+// developer messages are left out, the agent has its own system prompt.
+func chatCompletionTask(messages []chatCompletionMessage, ids IDGenerator) (*types.Message, []types.Message, error) {
+	if len(messages) == 0 {
+		return nil, nil, fmt.Errorf("messages must not be empty")
+	}
+	last := messages[len(messages)-1]
+	if last.Role != "user" {
+		return nil, nil, fmt.Errorf("the last message must be from the user")
+	}
+
+	var history []types.Message
+	for i, chatMessage := range messages {
+		var role types.Role
+		switch chatMessage.Role {
+		case "user":
+			role = types.RoleUser
+		case "assistant":
+			role = types.RoleAgent
+		case "system", "developer", "tool":
+			continue
+		default:
+			return nil, nil, fmt.Errorf("unsupported message role '%s'", chatMessage.Role)
+		}
+
+		parts, err := chatCompletionParts(chatMessage.Content)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid content of message %d: %w", i, err)
+		}
+		if len(parts) == 0 {
+			continue
+		}
+
+		message := types.Message{
+			MessageID: ids.NewID(),
+			Role:      role,
+			Parts:     parts,
+		}
+		if i == len(messages)-1 {
+			return &message, history, nil
+		}
+		history = append(history, message)
+	}
+	return nil, nil, fmt.Errorf("the last message must not be empty")
+}
+
+// chatCompletionParts maps the content of a chat message onto message parts. Images given as
+// data URLs become file parts with their bytes and other image URLs file parts with the URL.
+func chatCompletionParts(content json.RawMessage) ([]types.Part, error) {
+	if len(content) == 0 || string(content) == "null" {
+		return nil, nil
+	}
+
+	var text string
+	if err := json.Unmarshal(content, &text); err == nil {
+		if text == "" {
+			return nil, nil
+		}
+		return []types.Part{types.CreateTextPart(text)}, nil
+	}
+
+	var contentParts []chatCompletionContentPart
+	if err := json.Unmarshal(content, &contentParts); err != nil {
+		return nil, fmt.Errorf("content must be a string or a list of content parts")
+	}
+
+	parts := make([]types.Part, 0, len(contentParts))
+	for _, contentPart := range contentParts {
+		switch contentPart.Type {
+		case "text":
+			parts = append(parts, types.CreateTextPart(contentPart.Text))
+		case "image_url":
+			if contentPart.ImageURL == nil || contentPart.ImageURL.URL == "" {
+				return nil, fmt.Errorf("image_url content part without url")
+			}
+			url := contentPart.ImageURL.URL
+			if mediaType, data, ok := parseDataURL(url); ok {
+				parts = append(parts, types.CreateFilePart("", mediaType, &data, nil))
+			} else {
+				parts = append(parts, types.CreateFilePart("", "", nil, &url))
+			}
+		default:
+			return nil, fmt.Errorf("unsupported content part type '%s'", contentPart.Type)
+		}
+	}
+	return parts, nil
+}
+
+// parseDataURL returns the media type and the base64 data of a base64 data URL
+func parseDataURL(url string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(url, "data:")
+	if !ok {
+		return "", "", false
+	}
+	header, data, found := strings.Cut(rest, ",")
+	mediaType, base64, _ := strings.Cut(header, ";")
+	if !found || base64 != "base64" {
+		return "", "", false
+	}
+	return mediaType, data, true
+}
+
+// contextWithConversationHistory returns a copy of ctx carrying the earlier turns of the
+// conversation, which become the history of the task created in a new context
+func contextWithConversationHistory(ctx context.Context, history []types.Message) context.Context {
+	if len(history) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, conversationHistoryContextKey, history)
+}
+
+// conversationHistoryFromContext returns the earlier turns of the conversation, if any
+func conversationHistoryFromContext(ctx context.Context) []types.Message {
+	history, _ := ctx.Value(conversationHistoryContextKey).([]types.Message)
+	return history
+}
+
+// chatCompletionErrorStatus returns the HTTP status of a JSON-RPC error code
+func chatCompletionErrorStatus(code int) int {
+	switch JRPCErrorCode(code) {
+	case ErrParseError, ErrInvalidRequest, ErrInvalidParams, ErrContentTypeNotSupported:
+		return http.StatusBadRequest
+	case ErrServerError:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeChatCompletionError answers with an error in the format of the OpenAI API
+func writeChatCompletionError(w http.ResponseWriter, status int, message string) {
+	errorType := "server_error"
+	if status >= 400 && status < 500 {
+		errorType = "invalid_request_error"
+	}
+	body, _ := json.Marshal(chatCompletionError{Error: chatCompletionErrorDetail{Message: message, Type: errorType}})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// chatCompletionWriter is a gin.ResponseWriter that turns the JSON-RPC events written by the
+// message/stream handler into a chat completion. Streamed requests get a chunk per text
+// delta; the others get the completion once the task ends.
+type chatCompletionWriter struct {
+	gin.ResponseWriter
+	header  http.Header
+	buffer  bytes.Buffer
+	stream  bool
+	model   string
+	created int64
+	id      string
+
+	content  strings.Builder
+	status   *types.TaskStatus
+	failure  *types.JSONRPCError
+	started  bool
+	streamed bool
+	err      error
+}
+
+// chatCompletionResult is the part of a streamed task or status update the writer reads
+type chatCompletionResult struct {
+	Kind   string           `json:"kind"`
+	ID     string           `json:"id"`
+	TaskID string           `json:"taskId"`
+	Status types.TaskStatus `json:"status"`
+}
+
+// newChatCompletionWriter creates a writer answering the chat completion request on w with the
+// completion id, created at the given time
+func newChatCompletionWriter(w gin.ResponseWriter, stream bool, model, id string, created time.Time) *chatCompletionWriter {
+	return &chatCompletionWriter{
+		ResponseWriter: w,
+		header:         make(http.Header),
+		stream:         stream,
+		model:          model,
+		created:        created.Unix(),
+		id:             id,
+	}
+}
+
+// Header returns headers that are discarded, the writer sets the headers of the response
+func (w *chatCompletionWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader ignores the status code of the JSON-RPC response
+func (w *chatCompletionWriter) WriteHeader(int) {}
+
+// WriteHeaderNow ignores the status code of the JSON-RPC response
+func (w *chatCompletionWriter) WriteHeaderNow() {}
+
+// Write buffers the data and handles every complete event
+func (w *chatCompletionWriter) Write(data []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	w.buffer.Write(data)
+	w.handleEvents()
+	return len(data), w.err
+}
+
+// WriteString buffers the string and handles every complete event
+func (w *chatCompletionWriter) WriteString(data string) (int, error) {
+	return w.Write([]byte(data))
+}
+
+// Flush handles every complete event
+func (w *chatCompletionWriter) Flush() {
+	w.handleEvents()
+}
+
+// handleEvents handles the data of every complete server-sent event in the buffer
+func (w *chatCompletionWriter) handleEvents() {
+	for w.err == nil {
+		event, rest, found := bytes.Cut(w.buffer.Bytes(), []byte("\n\n"))
+		if !found {
+			return
+		}
+		for line := range strings.SplitSeq(string(event), "\n") {
+			payload := strings.TrimPrefix(line, "data: ")
+			if payload != "" && payload != "[DONE]" {
+				w.handle([]byte(payload))
+			}
+		}
+		remaining := bytes.Clone(rest)
+		w.buffer.Reset()
+		w.buffer.Write(remaining)
+	}
+}
+
+// handle reads a JSON-RPC response: text deltas are collected, and streamed when requested,
+// and the latest task status and error are kept
+func (w *chatCompletionWriter) handle(payload []byte) {
+	var response struct {
+		Result json.RawMessage     `json:"result"`
+		Error  *types.JSONRPCError `json:"error"`
+	}
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return
+	}
+	if response.Error != nil {
+		w.failure = response.Error
+		return
+	}
+
+	var result chatCompletionResult
+	if err := json.Unmarshal(response.Result, &result); err != nil {
+		return
+	}
+	switch result.Kind {
+	case types.StreamResultKindTask:
+		delta := chatMessageText(result.Status.Message)
+		if delta == "" || result.Status.State != types.TaskStateWorking || result.Status.Message.Role != types.RoleAgent {
+			return
+		}
+		w.content.WriteString(delta)
+		if w.stream {
+			w.writeChunk(w.delta(delta), nil)
+			w.streamed = true
+		}
+	case types.StreamResultKindStatusUpdate:
+		status := result.Status
+		w.status = &status
+	}
+}
+
+// finish handles what is left in the buffer, such as a plain JSON-RPC error response, and
+// completes the response once the task ended
+func (w *chatCompletionWriter) finish() {
+	w.handleEvents()
+	if remaining := bytes.TrimSpace(w.buffer.Bytes()); len(remaining) > 0 && w.err == nil {
+		w.handle(remaining)
+	}
+	w.buffer.Reset()
+
+	if w.failure != nil {
+		w.fail(chatCompletionErrorStatus(w.failure.Code), w.failure.Message)
+		return
+	}
+
+	content := w.content.String()
+	finishReason := "stop"
+	if w.status != nil {
+		text := chatMessageText(w.status.Message)
+		switch w.status.State {
+		case types.TaskStateFailed, types.TaskStateCancelled:
+			if text == "" {
+				text = fmt.Sprintf("task ended in state %s", w.status.State)
+			}
+			w.fail(http.StatusInternalServerError, text)
+			return
+		case types.TaskStateRejected:
+			finishReason = "content_filter"
+		}
+		if text != "" && !w.streamed {
+			content = text
+		}
+	}
+
+	if !w.stream {
+		body, _ := json.Marshal(chatCompletion{
+			ID:      w.id,
+			Object:  "chat.completion",
+			Created: w.created,
+			Model:   w.model,
+			Choices: []chatCompletionChoice{{
+				Message:      &chatCompletionReply{Role: "assistant", Content: content},
+				FinishReason: &finishReason,
+			}},
+		})
+		w.ResponseWriter.Header().Set("Content-Type", "application/json")
+		w.ResponseWriter.WriteHeader(http.StatusOK)
+		_, _ = w.ResponseWriter.Write(body)
+		return
+	}
+
+	if !w.streamed && content != "" {
+		w.writeChunk(w.delta(content), nil)
+	}
+	w.writeChunk(&chatCompletionReply{}, &finishReason)
+	w.writeEvent("[DONE]")
+}
+
+// fail answers with an error, sent as the last event when the stream already started
+func (w *chatCompletionWriter) fail(status int, message string) {
+	if !w.started {
+		writeChatCompletionError(w.ResponseWriter, status, message)
+		return
+	}
+	body, _ := json.Marshal(chatCompletionError{Error: chatCompletionErrorDetail{Message: message, Type: "server_error"}})
+	w.writeEvent(string(body))
+	w.writeEvent("[DONE]")
+}
+
+// delta returns the delta of a chunk, naming the role in the first one only
+func (w *chatCompletionWriter) delta(content string) *chatCompletionReply {
+	if w.streamed {
+		return &chatCompletionReply{Content: content}
+	}
+	return &chatCompletionReply{Role: "assistant", Content: content}
+}
+
+// writeChunk streams a chat completion chunk with the delta
+func (w *chatCompletionWriter) writeChunk(delta *chatCompletionReply, finishReason *string) {
+	body, err := json.Marshal(chatCompletion{
+		ID:      w.id,
+		Object:  "chat.completion.chunk",
+		Created: w.created,
+		Model:   w.model,
+		Choices: []chatCompletionChoice{{Delta: delta, FinishReason: finishReason}},
+	})
+	if err != nil {
+		return
+	}
+	w.writeEvent(string(body))
+}
+
+// writeEvent writes a server-sent event, starting the event stream on the first one
+func (w *chatCompletionWriter) writeEvent(data string) {
+	if w.err != nil {
+		return
+	}
+	if !w.started {
+		w.started = true
+		w.ResponseWriter.Header().Set("Content-Type", "text/event-stream")
+		w.ResponseWriter.Header().Set("Cache-Control", "no-cache")
+		w.ResponseWriter.WriteHeader(http.StatusOK)
+	}
+	if _, err := w.ResponseWriter.Write([]byte("data: " + data + "\n\n")); err != nil {
+		w.err = err
+		return
+	}
+	w.ResponseWriter.Flush()
+}
+
+// chatMessageText returns the text of the parts of a message
+func chatMessageText(message *types.Message) string {
+	if message == nil {
+		return ""
+	}
+	var text strings.Builder
+	for _, part := range message.Parts {
+		if part.Text != nil {
+			text.WriteString(*part.Text)
+		}
+	}
+	return text.String()
+}
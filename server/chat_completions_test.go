@@ -0,0 +1,220 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// historyRecordingHandler records the history of the streamed task and answers with two deltas
+type historyRecordingHandler struct {
+	state   types.TaskState
+	history []types.Message
+}
+
+func (h *historyRecordingHandler) HandleStreamingTask(ctx context.Context, task *types.Task, message *types.Message) (<-chan cloudevents.Event, error) {
+	h.history = task.History
+
+	final := cloudevents.NewEvent()
+	final.SetType(types.EventTaskStatusChanged)
+	answer := &types.Message{MessageID: "answer", Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart("Hello there")}}
+	if err := final.SetData(cloudevents.ApplicationJSON, types.TaskStatus{State: h.state, Message: answer}); err != nil {
+		return nil, err
+	}
+
+	events := make(chan cloudevents.Event, 3)
+	events <- types.NewDeltaEvent(&types.Message{MessageID: "delta-1", Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart("Hello")}})
+	events <- types.NewDeltaEvent(&types.Message{MessageID: "delta-2", Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart(" there")}})
+	events <- final
+	close(events)
+	return events, nil
+}
+
+func (h *historyRecordingHandler) SetAgent(OpenAICompatibleAgent) {}
+
+func (h *historyRecordingHandler) GetAgent() OpenAICompatibleAgent { return nil }
+
+func newChatCompletionsTestServer(t *testing.T, handler StreamableTaskHandler) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		CapabilitiesConfig: config.CapabilitiesConfig{Streaming: true},
+		ServerConfig:       config.ServerConfig{EnableChatCompletions: true},
+	}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "weather-agent"})
+	s.SetStreamingTaskHandler(handler)
+	return s.setupRouter(cfg)
+}
+
+func postChatCompletion(router *gin.Engine, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, chatCompletionsPath, strings.NewReader(body)))
+	return w
+}
+
+func TestA2AServer_ChatCompletions(t *testing.T) {
+	handler := &historyRecordingHandler{state: types.TaskStateCompleted}
+	router := newChatCompletionsTestServer(t, handler)
+
+	w := postChatCompletion(router, `{"model":"weather","messages":[
+		{"role":"system","content":"Be brief."},
+		{"role":"user","content":"Hi"},
+		{"role":"assistant","content":"Hello, how can I help?"},
+		{"role":"user","content":[{"type":"text","text":"Greet me"}]}
+	]}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var completion chatCompletion
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &completion))
+	assert.Equal(t, "chat.completion", completion.Object)
+	assert.Equal(t, "weather", completion.Model)
+	require.Len(t, completion.Choices, 1)
+	assert.Equal(t, "assistant", completion.Choices[0].Message.Role)
+	assert.Equal(t, "Hello there", completion.Choices[0].Message.Content)
+	require.NotNil(t, completion.Choices[0].FinishReason)
+	assert.Equal(t, "stop", *completion.Choices[0].FinishReason)
+
+	var turns []string
+	for _, message := range handler.history {
+		turns = append(turns, string(message.Role)+": "+chatMessageText(&message))
+	}
+	assert.Equal(t, []string{"ROLE_USER: Hi", "ROLE_AGENT: Hello, how can I help?", "ROLE_USER: Greet me"}, turns,
+		"the earlier turns are the history of the task")
+}
+
+func TestA2AServer_ChatCompletions_Stream(t *testing.T) {
+	router := newChatCompletionsTestServer(t, &historyRecordingHandler{state: types.TaskStateCompleted})
+
+	w := postChatCompletion(router, `{"stream":true,"messages":[{"role":"user","content":"Greet me"}]}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+
+	var chunks []chatCompletion
+	var done bool
+	for chunk := range strings.SplitSeq(w.Body.String(), "\n\n") {
+		data, ok := strings.CutPrefix(strings.TrimSpace(chunk), "data: ")
+		if !ok {
+			continue
+		}
+		if data == "[DONE]" {
+			done = true
+			continue
+		}
+		var completion chatCompletion
+		require.NoError(t, json.Unmarshal([]byte(data), &completion))
+		chunks = append(chunks, completion)
+	}
+	assert.True(t, done, "the stream ends with [DONE]")
+
+	require.Len(t, chunks, 3)
+	for _, chunk := range chunks {
+		assert.Equal(t, "chat.completion.chunk", chunk.Object)
+		assert.Equal(t, "weather-agent", chunk.Model, "the model defaults to the agent name")
+		assert.Equal(t, chunks[0].ID, chunk.ID)
+	}
+	assert.Equal(t, &chatCompletionReply{Role: "assistant", Content: "Hello"}, chunks[0].Choices[0].Delta)
+	assert.Equal(t, &chatCompletionReply{Content: " there"}, chunks[1].Choices[0].Delta)
+	assert.Nil(t, chunks[1].Choices[0].FinishReason)
+	require.NotNil(t, chunks[2].Choices[0].FinishReason)
+	assert.Equal(t, "stop", *chunks[2].Choices[0].FinishReason)
+}
+
+func TestA2AServer_ChatCompletions_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   types.TaskState
+		body    string
+		status  int
+		message string
+	}{
+		{
+			name:    "invalid body",
+			body:    `{"messages":`,
+			status:  http.StatusBadRequest,
+			message: "invalid request body",
+		},
+		{
+			name:    "last message not from the user",
+			body:    `{"messages":[{"role":"user","content":"Hi"},{"role":"assistant","content":"Hello"}]}`,
+			status:  http.StatusBadRequest,
+			message: "the last message must be from the user",
+		},
+		{
+			name:    "failed task",
+			state:   types.TaskStateFailed,
+			body:    `{"messages":[{"role":"user","content":"Hi"}]}`,
+			status:  http.StatusInternalServerError,
+			message: "Hello there",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newChatCompletionsTestServer(t, &historyRecordingHandler{state: tt.state})
+
+			w := postChatCompletion(router, tt.body)
+			assert.Equal(t, tt.status, w.Code)
+
+			var response chatCompletionError
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.message, response.Error.Message)
+		})
+	}
+}
+
+func TestA2AServer_ChatModels(t *testing.T) {
+	router := newChatCompletionsTestServer(t, &historyRecordingHandler{state: types.TaskStateCompleted})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, chatModelsPath, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"object":"list","data":[{"id":"weather-agent","object":"model","created":0,"owned_by":"adk"}]}`, w.Body.String())
+}
+
+func TestA2AServer_ChatCompletionsDisabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{CapabilitiesConfig: config.CapabilitiesConfig{Streaming: true}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "agent"})
+	s.SetStreamingTaskHandler(&historyRecordingHandler{state: types.TaskStateCompleted})
+	router := s.setupRouter(cfg)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, chatCompletionsPath, bytes.NewReader([]byte(`{}`))))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestChatCompletionParts(t *testing.T) {
+	parts, err := chatCompletionParts(json.RawMessage(`[
+		{"type":"text","text":"What is this?"},
+		{"type":"image_url","image_url":{"url":"data:image/png;base64,aGVsbG8="}},
+		{"type":"image_url","image_url":{"url":"https://example.com/cat.jpg"}}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, parts, 3)
+	assert.Equal(t, "What is this?", *parts[0].Text)
+	assert.Equal(t, "image/png", parts[1].File.MediaType)
+	assert.Equal(t, "aGVsbG8=", *parts[1].File.FileWithBytes)
+	assert.Equal(t, "https://example.com/cat.jpg", *parts[2].File.FileWithURI)
+
+	_, err = chatCompletionParts(json.RawMessage(`[{"type":"input_audio"}]`))
+	assert.EqualError(t, err, "unsupported content part type 'input_audio'")
+
+	_, err = chatCompletionParts(json.RawMessage(`42`))
+	assert.EqualError(t, err, "content must be a string or a list of content parts")
+}
@@ -0,0 +1,428 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// AgentCheckpoint is the state of the agent loop of a running task, saved to the task store
+// as the run progresses so the run can continue where it stopped after a restart
+type AgentCheckpoint struct {
+	TaskID string `json:"taskId"`
+	// InstanceID identifies the server instance that ran the task
+	InstanceID string `json:"instanceId"`
+	// HistoryLength is the number of task history messages the run started from
+	HistoryLength int `json:"historyLength"`
+	// Messages holds the conversation of the run so far, starting with the task history
+	Messages []types.Message `json:"messages"`
+	// PendingToolCalls holds the tool calls the model requested that have no result yet
+	PendingToolCalls []sdk.ChatCompletionMessageToolCall `json:"pendingToolCalls,omitempty"`
+	// Iteration is the number of model calls of the run
+	Iteration int       `json:"iteration"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// checkpointContextKey is the context key of the checkpointer of an agent run
+type checkpointContextKey struct{}
+
+// taskCheckpoints saves the agent loop state of the runs of tasks to the task store, and
+// recovers the tasks a stopped server left working
+type taskCheckpoints struct {
+	cfg        config.TaskCheckpointConfig
+	store      CheckpointStore
+	journal    ToolJournalStore
+	instanceID string
+	clock      Clock
+	// startedAt is when this instance started, before which its own leases belong to a
+	// previous process
+	startedAt time.Time
+	logger    *zap.Logger
+
+	// runs holds the runCheckpointer of the tasks running on this instance by task ID
+	runs sync.Map
+}
+
+// newTaskCheckpoints returns the checkpoints of the task runs, or nil when they are disabled
+// or the storage cannot persist them
+func newTaskCheckpoints(cfg config.TaskCheckpointConfig, storage Storage, instanceID string, logger *zap.Logger) *taskCheckpoints {
+	if !cfg.Enable {
+		return nil
+	}
+	store, ok := storage.(CheckpointStore)
+	if !ok {
+		logger.Warn("task checkpoints are enabled but the storage does not support them, checkpoints are disabled")
+		return nil
+	}
+	if _, inMemory := storage.(*InMemoryStorage); inMemory {
+		logger.Warn("task checkpoints are enabled with in-memory storage, checkpoints are lost when the process exits")
+	}
+	journal, _ := storage.(ToolJournalStore)
+	return &taskCheckpoints{cfg: cfg, store: store, journal: journal, instanceID: instanceID, clock: SystemClock{}, startedAt: time.Now(), logger: logger}
+}
+
+// setClock sets the clock of the checkpoint and lease times, from which the instance starts
+func (c *taskCheckpoints) setClock(clock Clock) {
+	if c == nil {
+		return
+	}
+	c.clock = clock
+	c.startedAt = clock.Now()
+}
+
+// leaseRenewal returns how often a run renews its lease, or 0 when leases do not expire
+func (c *taskCheckpoints) leaseRenewal() time.Duration {
+	return c.cfg.StaleAfter / 3
+}
+
+// runContext returns the context of a run of the task, through which the agent saves the
+// checkpoints of the run and continues from the last checkpoint of an interrupted run. The
+// run takes the lease of the task right away and renews it until the run is cleared or the
+// context is done.
+func (c *taskCheckpoints) runContext(ctx context.Context, taskID string) context.Context {
+	if c == nil {
+		return ctx
+	}
+	run := &runCheckpointer{checkpoints: c, taskID: taskID}
+	checkpoint, exists, err := c.store.GetCheckpoint(ctx, taskID)
+	if err != nil {
+		requestLogger(ctx, c.logger).Warn("failed to load task checkpoint, running the task from its history",
+			zap.String("task_id", taskID),
+			zap.Error(err))
+	} else if exists {
+		run.interrupted = checkpoint
+		run.last = checkpoint
+	}
+	if run.last == nil {
+		run.last = &AgentCheckpoint{TaskID: taskID}
+	}
+
+	c.runs.Store(taskID, run)
+	context.AfterFunc(ctx, func() {
+		c.runs.CompareAndDelete(taskID, run)
+		run.end()
+	})
+	run.renew(ctx)
+	if renewal := c.leaseRenewal(); renewal > 0 {
+		go run.keepLease(ctx, renewal)
+	}
+	return context.WithValue(ctx, checkpointContextKey{}, run)
+}
+
+// running reports whether a run of the task is in progress on this instance
+func (c *taskCheckpoints) running(taskID string) bool {
+	_, ok := c.runs.Load(taskID)
+	return ok
+}
+
+// clear removes the checkpoint and the tool journal of a task whose run ended
+func (c *taskCheckpoints) clear(ctx context.Context, taskID string) {
+	if c == nil {
+		return
+	}
+	if run, ok := c.runs.LoadAndDelete(taskID); ok {
+		run.(*runCheckpointer).end()
+	}
+	if err := c.store.DeleteCheckpoint(context.WithoutCancel(ctx), taskID); err != nil {
+		requestLogger(ctx, c.logger).Warn("failed to delete task checkpoint",
+			zap.String("task_id", taskID),
+			zap.Error(err))
+	}
+	if c.journal == nil {
+		return
+	}
+	if err := c.journal.DeleteToolJournal(context.WithoutCancel(ctx), taskID); err != nil {
+		requestLogger(ctx, c.logger).Warn("failed to delete tool journal",
+			zap.String("task_id", taskID),
+			zap.Error(err))
+	}
+}
+
+// abandoned reports whether nothing runs a working task anymore. A task running on this
+// instance is not abandoned. Otherwise the lease of its run expired: the checkpoint, or the
+// last update of the task when it has none, was not renewed for the stale time, or it was
+// last renewed by a previous process of this instance.
+func (c *taskCheckpoints) abandoned(task *types.Task, checkpoint *AgentCheckpoint, now time.Time) bool {
+	if c.running(task.ID) {
+		return false
+	}
+	var lastSeen time.Time
+	if checkpoint != nil {
+		if checkpoint.InstanceID == c.instanceID && checkpoint.UpdatedAt.Before(c.startedAt) {
+			return true
+		}
+		lastSeen = checkpoint.UpdatedAt
+	} else if task.Status.Timestamp != nil {
+		lastSeen = *task.Status.Timestamp
+	}
+	return now.Sub(lastSeen) > c.cfg.StaleAfter
+}
+
+// runCheckpointer saves the checkpoints of one run of a task
+type runCheckpointer struct {
+	checkpoints   *taskCheckpoints
+	taskID        string
+	historyLength int
+
+	// checkpoint of the interrupted run of the task, when there is one
+	interrupted *AgentCheckpoint
+
+	// mu guards the last saved checkpoint, which the lease renewals save again, and whether
+	// the run ended
+	mu    sync.Mutex
+	last  *AgentCheckpoint
+	ended bool
+}
+
+// checkpointerFromContext returns the checkpointer of the agent run, or nil when the run is
+// not checkpointed
+func checkpointerFromContext(ctx context.Context) *runCheckpointer {
+	run, _ := ctx.Value(checkpointContextKey{}).(*runCheckpointer)
+	return run
+}
+
+// resume starts the run from the messages. It returns the checkpoint of the interrupted run
+// to continue from, when that run started from the same messages.
+func (r *runCheckpointer) resume(messages []types.Message) *AgentCheckpoint {
+	if r == nil {
+		return nil
+	}
+	r.historyLength = len(messages)
+	interrupted := r.interrupted
+	r.interrupted = nil
+	if interrupted == nil || interrupted.HistoryLength != len(messages) || len(interrupted.Messages) < len(messages) {
+		return nil
+	}
+	return interrupted
+}
+
+// save records the state of the run. The state of a cancelled run may be incomplete, so the
+// previous checkpoint is kept instead; a failed save is logged and the run goes on.
+func (r *runCheckpointer) save(ctx context.Context, iteration int, messages []types.Message, pending []sdk.ChatCompletionMessageToolCall) {
+	if r == nil || ctx.Err() != nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ended {
+		return
+	}
+	r.last = &AgentCheckpoint{
+		TaskID:           r.taskID,
+		InstanceID:       r.checkpoints.instanceID,
+		HistoryLength:    r.historyLength,
+		Messages:         slices.Clone(messages),
+		PendingToolCalls: slices.Clone(pending),
+		Iteration:        iteration,
+		UpdatedAt:        r.checkpoints.clock.Now(),
+	}
+	if err := r.checkpoints.store.SaveCheckpoint(ctx, r.last); err != nil {
+		requestLogger(ctx, r.checkpoints.logger).Warn("failed to save task checkpoint",
+			zap.String("task_id", r.taskID),
+			zap.Int("iteration", iteration),
+			zap.Error(err))
+	}
+}
+
+// renew saves the last checkpoint of the run again as a checkpoint of this instance, which
+// renews the lease of the run. It reports whether the run still goes on.
+func (r *runCheckpointer) renew(ctx context.Context) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ended || ctx.Err() != nil {
+		return false
+	}
+	lease := *r.last
+	lease.InstanceID = r.checkpoints.instanceID
+	lease.UpdatedAt = r.checkpoints.clock.Now()
+	if err := r.checkpoints.store.SaveCheckpoint(ctx, &lease); err != nil {
+		requestLogger(ctx, r.checkpoints.logger).Warn("failed to renew the lease of the task run",
+			zap.String("task_id", r.taskID),
+			zap.Error(err))
+		return true
+	}
+	r.last = &lease
+	return true
+}
+
+// keepLease renews the lease of the run at every renewal until the run ends or the context
+// is done
+func (r *runCheckpointer) keepLease(ctx context.Context, renewal time.Duration) {
+	ticker := time.NewTicker(renewal)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.renew(ctx) {
+				return
+			}
+		}
+	}
+}
+
+// end stops the run from saving checkpoints and renewing its lease
+func (r *runCheckpointer) end() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ended = true
+}
+
+// startTaskRecovery recovers the working tasks whose lease expires after startup, for
+// instance because the replica running them stopped, every recovery interval
+func (s *A2AServerImpl) startTaskRecovery(ctx context.Context) {
+	if s.checkpoints == nil || s.checkpoints.cfg.RecoveryInterval <= 0 {
+		return
+	}
+	interval := s.checkpoints.cfg.RecoveryInterval
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	job := newPeriodicJob("task_recovery", interval, s.clock.Now())
+	s.recoveryJob.Store(job)
+	defer s.recoveryJob.Store(nil)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.recoverInterruptedTasks(ctx)
+			job.ran(s.clock.Now())
+		}
+	}
+}
+
+// recoverInterruptedTasks recovers the tasks a stopped server left working, whose lease
+// expired: the tasks of other instances that have not been checkpointed for longer than the
+// stale time, and the tasks a previous process of this instance ran. They are queued again
+// to resume from their last checkpoint, or failed with a status message when configured so
+// or when they were recovered too many times already.
+func (s *A2AServerImpl) recoverInterruptedTasks(ctx context.Context) {
+	if s.checkpoints == nil {
+		return
+	}
+	working := types.TaskStateWorking
+	tasks, err := s.storage.ListTasks(TaskFilter{State: &working})
+	if err != nil {
+		s.logger.Error("failed to list working tasks for recovery", zap.Error(err))
+		return
+	}
+
+	cfg := s.checkpoints.cfg
+	now := s.clock.Now()
+	for _, task := range tasks {
+		checkpoint, _, err := s.checkpoints.store.GetCheckpoint(ctx, task.ID)
+		if err != nil {
+			s.logger.Error("failed to load task checkpoint for recovery",
+				zap.String("task_id", task.ID),
+				zap.Error(err))
+			continue
+		}
+		if !s.checkpoints.abandoned(task, checkpoint, now) {
+			continue
+		}
+
+		recoveries := taskRecoveryCount(task)
+		if cfg.OnRestart == config.TaskCheckpointRestartFail || (cfg.MaxRecoveries > 0 && recoveries >= cfg.MaxRecoveries) {
+			s.failInterruptedTask(ctx, task, recoveries)
+			continue
+		}
+		s.resumeInterruptedTask(ctx, task, checkpoint, recoveries)
+	}
+}
+
+// resumeInterruptedTask queues a working task again, so it continues from its last
+// checkpoint or runs again from its last user message when it has none
+func (s *A2AServerImpl) resumeInterruptedTask(ctx context.Context, task *types.Task, checkpoint *AgentCheckpoint, recoveries int) {
+	task.Status.State = types.TaskStateSubmitted
+	if message := lastUserMessage(task.History); message != nil {
+		task.Status.Message = message
+	}
+
+	setTaskMetadata(task, types.RecoveryCountMetadataKey, recoveries+1)
+	recordStateTransition(s.taskManager, task, types.TaskActorServer)
+
+	if err := s.taskManager.UpdateTask(task); err != nil {
+		s.logger.Error("failed to update recovered task",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+		return
+	}
+	if err := s.storage.EnqueueTask(ctx, task, nil); err != nil {
+		s.logger.Error("failed to queue recovered task",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+		return
+	}
+
+	iteration := 0
+	if checkpoint != nil {
+		iteration = checkpoint.Iteration
+	}
+	s.logger.Info("resuming task interrupted by a server restart",
+		zap.String("task_id", task.ID),
+		zap.String("context_id", task.ContextID),
+		zap.Bool("checkpointed", checkpoint != nil),
+		zap.Int("iteration", iteration),
+		zap.Int("recovery_count", recoveries+1))
+}
+
+// failInterruptedTask fails a working task that is not resumed, telling the client why
+func (s *A2AServerImpl) failInterruptedTask(ctx context.Context, task *types.Task, recoveries int) {
+	text := "The task was interrupted by a server restart and was not resumed. Send the message again to retry."
+	if s.checkpoints.cfg.OnRestart != config.TaskCheckpointRestartFail {
+		text = "The task was interrupted by server restarts too many times and was not resumed again."
+	}
+	err := s.taskManager.UpdateError(task.ID, &types.Message{
+		MessageID: s.ids.NewID(),
+		Role:      types.RoleAgent,
+		TaskID:    &task.ID,
+		ContextID: &task.ContextID,
+		Parts:     []types.Part{types.CreateTextPart(text)},
+	})
+	if err != nil {
+		s.logger.Error("failed to fail interrupted task",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+		return
+	}
+	s.checkpoints.clear(ctx, task.ID)
+
+	s.logger.Warn("failed task interrupted by a server restart",
+		zap.String("task_id", task.ID),
+		zap.String("context_id", task.ContextID),
+		zap.Int("recovery_count", recoveries))
+}
+
+// taskRecoveryCount returns the number of times a task was recovered after a restart
+func taskRecoveryCount(task *types.Task) int {
+	if task.Metadata == nil {
+		return 0
+	}
+	switch count := (*task.Metadata)[types.RecoveryCountMetadataKey].(type) {
+	case int:
+		return count
+	case float64:
+		return int(count)
+	}
+	return 0
+}
+
+// lastUserMessage returns the last message of the history sent by the user
+func lastUserMessage(history []types.Message) *types.Message {
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Role == types.RoleUser || history[i].Role == "user" {
+			return &history[i]
+		}
+	}
+	return nil
+}
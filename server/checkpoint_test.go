@@ -0,0 +1,300 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// scriptedLLMClient streams one response chunk per call and records the messages of the calls.
+// Its error channels stay open, since a closed one ends the stream.
+type scriptedLLMClient struct {
+	responses []*sdk.CreateChatCompletionStreamResponse
+	calls     [][]sdk.Message
+	tools     [][]sdk.ChatCompletionTool
+}
+
+func (c *scriptedLLMClient) CreateChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (*sdk.CreateChatCompletionResponse, error) {
+	return nil, nil
+}
+
+func (c *scriptedLLMClient) CreateStreamingChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+	responseChan := make(chan *sdk.CreateChatCompletionStreamResponse, 1)
+	errorChan := make(chan error)
+	responseChan <- c.responses[len(c.calls)]
+	c.calls = append(c.calls, messages)
+	c.tools = append(c.tools, tools)
+	close(responseChan)
+	return responseChan, errorChan
+}
+
+func toolCallChunk(id, name string) *sdk.CreateChatCompletionStreamResponse {
+	toolCalls := []sdk.ChatCompletionMessageToolCallChunk{{
+		Index:    0,
+		ID:       new(id),
+		Type:     new("function"),
+		Function: &sdk.ChatCompletionMessageToolCallFunction{Name: name, Arguments: `{}`},
+	}}
+	return &sdk.CreateChatCompletionStreamResponse{Choices: []sdk.ChatCompletionStreamChoice{{
+		Delta:        sdk.ChatCompletionStreamResponseDelta{ToolCalls: &toolCalls},
+		FinishReason: "tool_calls",
+	}}}
+}
+
+func textChunk(text string) *sdk.CreateChatCompletionStreamResponse {
+	return &sdk.CreateChatCompletionStreamResponse{Choices: []sdk.ChatCompletionStreamChoice{{
+		Delta:        sdk.ChatCompletionStreamResponseDelta{Content: text},
+		FinishReason: "stop",
+	}}}
+}
+
+// newCheckpointedAgent returns an agent with a lookup tool that counts its calls
+func newCheckpointedAgent(t *testing.T, llmClient LLMClient, lookup func(ctx context.Context)) OpenAICompatibleAgent {
+	t.Helper()
+	toolBox := NewDefaultToolBox(nil)
+	toolBox.AddTool(NewBasicTool("lookup", "Looks something up", map[string]any{"type": "object"},
+		func(ctx context.Context, args map[string]any) (string, error) {
+			lookup(ctx)
+			return "found", nil
+		}))
+	agent, err := NewAgentBuilder(zap.NewNop()).WithLLMClient(llmClient).WithToolBox(toolBox).Build()
+	require.NoError(t, err)
+	return agent
+}
+
+// runToCompletion runs the agent and returns the state it ended with
+func runToCompletion(t *testing.T, ctx context.Context, agent OpenAICompatibleAgent, messages []types.Message) types.TaskState {
+	t.Helper()
+	events, err := agent.RunWithStream(ctx, messages)
+	require.NoError(t, err)
+	var state types.TaskState
+	for event := range events {
+		if event.Type() != types.EventTaskStatusChanged {
+			continue
+		}
+		var status types.TaskStatus
+		require.NoError(t, event.DataAs(&status))
+		state = status.State
+	}
+	return state
+}
+
+func TestAgentRun_SavesCheckpoints(t *testing.T) {
+	storage := NewInMemoryStorage(zap.NewNop(), 0)
+	checkpoints := newTaskCheckpoints(config.TaskCheckpointConfig{Enable: true}, storage, "instance-a", zap.NewNop())
+
+	llmClient := &scriptedLLMClient{responses: []*sdk.CreateChatCompletionStreamResponse{toolCallChunk("call-1", "lookup"), textChunk("done")}}
+
+	var duringTool *AgentCheckpoint
+	agent := newCheckpointedAgent(t, llmClient, func(ctx context.Context) {
+		duringTool, _, _ = storage.GetCheckpoint(ctx, "task-1")
+	})
+
+	ctx := checkpoints.runContext(context.Background(), "task-1")
+	history := []types.Message{{MessageID: "m1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("look it up")}}}
+	assert.Equal(t, types.TaskStateCompleted, runToCompletion(t, ctx, agent, history))
+
+	require.NotNil(t, duringTool, "the run is checkpointed before its tools execute")
+	assert.Equal(t, 1, duringTool.Iteration)
+	assert.Equal(t, "instance-a", duringTool.InstanceID)
+	assert.Equal(t, 1, duringTool.HistoryLength)
+	assert.Len(t, duringTool.Messages, 2, "the history and the tool call of the model")
+	require.Len(t, duringTool.PendingToolCalls, 1)
+	assert.Equal(t, "call-1", duringTool.PendingToolCalls[0].ID)
+
+	checkpoint, exists, err := storage.GetCheckpoint(context.Background(), "task-1")
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, 1, checkpoint.Iteration)
+	assert.Len(t, checkpoint.Messages, 3, "the tool result is checkpointed")
+	assert.Empty(t, checkpoint.PendingToolCalls)
+}
+
+func TestAgentRun_ResumesFromCheckpoint(t *testing.T) {
+	storage := NewInMemoryStorage(zap.NewNop(), 0)
+	checkpoints := newTaskCheckpoints(config.TaskCheckpointConfig{Enable: true}, storage, "instance-a", zap.NewNop())
+
+	history := []types.Message{{MessageID: "m1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("look it up")}}}
+	pending := sdk.ChatCompletionMessageToolCall{
+		ID:       "call-1",
+		Type:     "function",
+		Function: sdk.ChatCompletionMessageToolCallFunction{Name: "lookup", Arguments: `{}`},
+	}
+	toolCallMessage := types.NewAssistantMessage("assistant-1", []types.Part{
+		types.CreateDataPart(map[string]any{"tool_calls": []sdk.ChatCompletionMessageToolCall{pending}}),
+	})
+	require.NoError(t, storage.SaveCheckpoint(context.Background(), &AgentCheckpoint{
+		TaskID:           "task-1",
+		InstanceID:       "instance-b",
+		HistoryLength:    1,
+		Messages:         append(history, *toolCallMessage),
+		PendingToolCalls: []sdk.ChatCompletionMessageToolCall{pending},
+		Iteration:        1,
+	}))
+
+	llmClient := &scriptedLLMClient{responses: []*sdk.CreateChatCompletionStreamResponse{textChunk("done")}}
+	lookups := 0
+	agent := newCheckpointedAgent(t, llmClient, func(context.Context) { lookups++ })
+
+	ctx := checkpoints.runContext(context.Background(), "task-1")
+	assert.Equal(t, types.TaskStateCompleted, runToCompletion(t, ctx, agent, history))
+
+	assert.Equal(t, 1, lookups, "the pending tool call is executed")
+	require.Len(t, llmClient.calls, 1, "the model is not called again for the checkpointed iteration")
+	assert.Len(t, llmClient.calls[0], 4, "the system prompt, the history, the tool call and its result")
+
+	t.Run("checkpoint of a run from other messages is ignored", func(t *testing.T) {
+		require.NoError(t, storage.SaveCheckpoint(context.Background(), &AgentCheckpoint{
+			TaskID:           "task-2",
+			HistoryLength:    3,
+			Messages:         append(history, *toolCallMessage),
+			PendingToolCalls: []sdk.ChatCompletionMessageToolCall{pending},
+			Iteration:        1,
+		}))
+		lookups = 0
+		llmClient.calls = nil
+
+		ctx := checkpoints.runContext(context.Background(), "task-2")
+		assert.Equal(t, types.TaskStateCompleted, runToCompletion(t, ctx, agent, history))
+		assert.Equal(t, 0, lookups)
+	})
+}
+
+func TestA2AServer_RecoverInterruptedTasks(t *testing.T) {
+	newServer := func(onRestart string) *A2AServerImpl {
+		cfg := &config.Config{
+			ServerConfig: config.ServerConfig{InstanceID: "replica-1"},
+			TaskCheckpointConfig: config.TaskCheckpointConfig{
+				Enable:        true,
+				OnRestart:     onRestart,
+				StaleAfter:    time.Minute,
+				MaxRecoveries: 2,
+			},
+		}
+		s := NewA2AServer(cfg, zap.NewNop(), nil)
+		require.Equal(t, "replica-1", s.instanceID)
+		return s
+	}
+	workingTask := func(s *A2AServerImpl, recoveries int, checkpoint *AgentCheckpoint) string {
+		message := &types.Message{MessageID: "m1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("hi")}}
+		task := s.taskManager.CreateTask("ctx", types.TaskStateWorking, message)
+		if recoveries > 0 {
+			task.Metadata = &map[string]any{types.RecoveryCountMetadataKey: recoveries}
+			require.NoError(t, s.taskManager.UpdateTask(task))
+		}
+		if checkpoint != nil {
+			checkpoint.TaskID = task.ID
+			require.NoError(t, s.storage.(CheckpointStore).SaveCheckpoint(context.Background(), checkpoint))
+		}
+		return task.ID
+	}
+	statusText := func(s *A2AServerImpl, taskID string) string {
+		task, exists := s.taskManager.GetTask(taskID)
+		require.True(t, exists)
+		require.NotNil(t, task.Status.Message)
+		require.NotEmpty(t, task.Status.Message.Parts)
+		require.NotNil(t, task.Status.Message.Parts[0].Text)
+		return *task.Status.Message.Parts[0].Text
+	}
+
+	t.Run("abandoned tasks are resumed", func(t *testing.T) {
+		s := newServer(config.TaskCheckpointRestartResume)
+		beforeStart := s.checkpoints.startedAt.Add(-time.Second)
+		own := workingTask(s, 0, &AgentCheckpoint{InstanceID: s.instanceID, UpdatedAt: beforeStart})
+		running := workingTask(s, 0, &AgentCheckpoint{InstanceID: "other", UpdatedAt: time.Now()})
+		sharingID := workingTask(s, 0, &AgentCheckpoint{InstanceID: s.instanceID, UpdatedAt: time.Now()})
+		stale := workingTask(s, 1, &AgentCheckpoint{InstanceID: "other", UpdatedAt: time.Now().Add(-2 * time.Minute)})
+		exhausted := workingTask(s, 2, &AgentCheckpoint{InstanceID: s.instanceID, UpdatedAt: beforeStart})
+		runningHere := workingTask(s, 0, &AgentCheckpoint{InstanceID: "other", UpdatedAt: time.Now().Add(-2 * time.Minute)})
+		runCtx, stopRun := context.WithCancel(context.Background())
+		defer stopRun()
+		s.checkpoints.runContext(runCtx, runningHere)
+
+		s.recoverInterruptedTasks(context.Background())
+
+		assert.Equal(t, 2, s.storage.GetQueueLength())
+		for taskID, recoveries := range map[string]int{own: 1, stale: 2} {
+			task, exists := s.taskManager.GetTask(taskID)
+			require.True(t, exists)
+			assert.Equal(t, types.TaskStateSubmitted, task.Status.State)
+			assert.Equal(t, recoveries, taskRecoveryCount(task))
+		}
+
+		for taskID, reason := range map[string]string{
+			running:     "a task another instance still checkpoints is left alone",
+			sharingID:   "a task whose lease was renewed since this instance started is left alone",
+			runningHere: "a task running on this instance is left alone",
+		} {
+			task, exists := s.taskManager.GetTask(taskID)
+			require.True(t, exists)
+			assert.Equal(t, types.TaskStateWorking, task.Status.State, reason)
+		}
+
+		task, exists := s.taskManager.GetTask(exhausted)
+		require.True(t, exists)
+		assert.Equal(t, types.TaskStateFailed, task.Status.State)
+		assert.Contains(t, statusText(s, exhausted), "too many times")
+		_, hasCheckpoint, err := s.storage.(CheckpointStore).GetCheckpoint(context.Background(), exhausted)
+		require.NoError(t, err)
+		assert.False(t, hasCheckpoint)
+	})
+
+	t.Run("abandoned tasks are failed", func(t *testing.T) {
+		s := newServer(config.TaskCheckpointRestartFail)
+		own := workingTask(s, 0, &AgentCheckpoint{InstanceID: s.instanceID, UpdatedAt: s.checkpoints.startedAt.Add(-time.Second)})
+
+		s.recoverInterruptedTasks(context.Background())
+
+		task, exists := s.taskManager.GetTask(own)
+		require.True(t, exists)
+		assert.Equal(t, types.TaskStateFailed, task.Status.State)
+		assert.Contains(t, statusText(s, own), "interrupted by a server restart")
+		assert.Equal(t, 0, s.storage.GetQueueLength())
+	})
+}
+
+func TestTaskCheckpoints_RenewsTheLeaseOfRuns(t *testing.T) {
+	storage := NewInMemoryStorage(zap.NewNop(), 0)
+	checkpoints := newTaskCheckpoints(config.TaskCheckpointConfig{Enable: true, StaleAfter: 60 * time.Millisecond}, storage, "instance-a", zap.NewNop())
+	require.NoError(t, storage.SaveCheckpoint(context.Background(), &AgentCheckpoint{
+		TaskID:        "task-1",
+		InstanceID:    "instance-b",
+		HistoryLength: 1,
+		Iteration:     2,
+		UpdatedAt:     time.Now().Add(-time.Hour),
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	run := checkpointerFromContext(checkpoints.runContext(ctx, "task-1"))
+	require.NotNil(t, run)
+	assert.NotNil(t, run.interrupted, "the run still continues from the interrupted checkpoint")
+
+	lease, exists, err := storage.GetCheckpoint(context.Background(), "task-1")
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, "instance-a", lease.InstanceID, "the run takes the lease as it starts")
+	assert.Equal(t, 2, lease.Iteration, "taking the lease keeps the interrupted checkpoint")
+	taken := lease.UpdatedAt
+
+	require.Eventually(t, func() bool {
+		lease, _, err := storage.GetCheckpoint(context.Background(), "task-1")
+		return err == nil && lease.UpdatedAt.After(taken)
+	}, time.Second, 5*time.Millisecond, "the run renews its lease")
+	assert.True(t, checkpoints.running("task-1"))
+
+	checkpoints.clear(context.Background(), "task-1")
+	assert.False(t, checkpoints.running("task-1"))
+	time.Sleep(60 * time.Millisecond)
+	_, exists, err = storage.GetCheckpoint(context.Background(), "task-1")
+	require.NoError(t, err)
+	assert.False(t, exists, "a cleared run does not renew its lease again")
+}
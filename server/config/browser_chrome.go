@@ -0,0 +1,6 @@
+//go:build chrome
+
+package config
+
+// chromeBrowserBuilt reports whether the build includes the Chrome browser of the browse_web tool
+const chromeBrowserBuilt = true
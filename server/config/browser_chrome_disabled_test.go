@@ -0,0 +1,19 @@
+//go:build !chrome
+
+package config_test
+
+import (
+	"context"
+	"testing"
+
+	config "github.com/inference-gateway/adk/server/config"
+	envconfig "github.com/sethvargo/go-envconfig"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate_BrowserNeedsTheChromeBuild(t *testing.T) {
+	lookuper := envconfig.MapLookuper(map[string]string{"AGENT_CLIENT_TOOLS_SKILLS_BROWSER_ENABLE": "true"})
+
+	_, err := config.LoadWithLookuper(context.Background(), nil, lookuper)
+	assert.ErrorContains(t, err, "build with -tags chrome")
+}
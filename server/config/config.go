@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -11,24 +12,45 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	AgentName                     string              // Build-time metadata, not configurable via environment
-	AgentDescription              string              // Build-time metadata, not configurable via environment
-	AgentVersion                  string              // Build-time metadata, not configurable via environment
-	AgentURL                      string              `env:"AGENT_URL"`
-	AgentCardFilePath             string              `env:"AGENT_CARD_FILE_PATH" description:"Path to JSON file containing static agent card definition"`
-	Debug                         bool                `env:"DEBUG,default=false"`
-	Timezone                      string              `env:"TIMEZONE,default=UTC" description:"Timezone for timestamps (e.g., UTC, America/New_York, Europe/London)"`
-	StreamingStatusUpdateInterval time.Duration       `env:"STREAMING_STATUS_UPDATE_INTERVAL,default=1s"`
-	AgentConfig                   AgentConfig         `env:",prefix=AGENT_CLIENT_"`
-	CapabilitiesConfig            CapabilitiesConfig  `env:",prefix=CAPABILITIES_"`
-	AuthConfig                    AuthConfig          `env:",prefix=AUTH_"`
-	QueueConfig                   QueueConfig         `env:",prefix=QUEUE_"`
-	TaskRetentionConfig           TaskRetentionConfig `env:",prefix=TASK_RETENTION_"`
-	ServerConfig                  ServerConfig        `env:",prefix=SERVER_"`
-	TelemetryConfig               TelemetryConfig     `env:",prefix=TELEMETRY_"`
-	ArtifactsConfig               ArtifactsConfig     `env:",prefix=ARTIFACTS_"`
-	MCPConfig                     MCPConfig           `env:",prefix=MCP_"`
-	OTelConfig                    OTelConfig          // Standard OpenTelemetry SDK env vars (OTEL_*), read without a prefix
+	AgentName                     string               // Build-time metadata, not configurable via environment
+	AgentDescription              string               // Build-time metadata, not configurable via environment
+	AgentVersion                  string               // Build-time metadata, not configurable via environment
+	AgentURL                      string               `env:"AGENT_URL"`
+	AgentCardFilePath             string               `env:"AGENT_CARD_FILE_PATH" description:"Path to JSON file containing static agent card definition"`
+	DisabledSkills                []string             `env:"DISABLED_SKILLS" description:"Skill IDs (comma-separated) hidden from the agent card"`
+	EnforceInputModes             bool                 `env:"ENFORCE_INPUT_MODES,default=true" description:"Reject messages with parts whose media type is not among the input modes of the agent card"`
+	Debug                         bool                 `env:"DEBUG,default=false"`
+	Timezone                      string               `env:"TIMEZONE,default=UTC" description:"Timezone for timestamps (e.g., UTC, America/New_York, Europe/London)"`
+	StreamingStatusUpdateInterval time.Duration        `env:"STREAMING_STATUS_UPDATE_INTERVAL,default=1s" description:"Idle time after which a working streaming task sends a heartbeat status event, keeping the connection open (0 disables heartbeats)"`
+	AgentConfig                   AgentConfig          `env:",prefix=AGENT_CLIENT_"`
+	CapabilitiesConfig            CapabilitiesConfig   `env:",prefix=CAPABILITIES_"`
+	AuthConfig                    AuthConfig           `env:",prefix=AUTH_"`
+	QueueConfig                   QueueConfig          `env:",prefix=QUEUE_"`
+	TaskRetentionConfig           TaskRetentionConfig  `env:",prefix=TASK_RETENTION_"`
+	TaskHistoryConfig             TaskHistoryConfig    `env:",prefix=TASK_HISTORY_"`
+	InputTimeoutConfig            InputTimeoutConfig   `env:",prefix=INPUT_TIMEOUT_"`
+	TaskResumeConfig              TaskResumeConfig     `env:",prefix=TASK_RESUME_"`
+	TaskCheckpointConfig          TaskCheckpointConfig `env:",prefix=TASK_CHECKPOINT_"`
+	ScheduleConfig                ScheduleConfig       `env:",prefix=SCHEDULE_"`
+	TaskDependencyConfig          TaskDependencyConfig `env:",prefix=TASK_DEPENDENCIES_"`
+	ServerConfig                  ServerConfig         `env:",prefix=SERVER_"`
+	TelemetryConfig               TelemetryConfig      `env:",prefix=TELEMETRY_"`
+	ArtifactsConfig               ArtifactsConfig      `env:",prefix=ARTIFACTS_"`
+	MCPConfig                     MCPConfig            `env:",prefix=MCP_"`
+	SharingConfig                 SharingConfig        `env:",prefix=SHARING_"`
+	LanguageConfig                LanguageConfig       `env:",prefix=LANGUAGE_"`
+	ModerationConfig              ModerationConfig     `env:",prefix=MODERATION_"`
+	RedactionConfig               RedactionConfig      `env:",prefix=REDACTION_"`
+	FileIngestionConfig           FileIngestionConfig  `env:",prefix=FILE_INGESTION_"`
+	SpeechConfig                  SpeechConfig         `env:",prefix=SPEECH_"`
+	ReloadConfig                  ReloadConfig         `env:",prefix=RELOAD_"`
+	SecretsConfig                 SecretsConfig        `env:",prefix=SECRETS_"`
+	MemoryConfig                  MemoryConfig         `env:",prefix=MEMORY_"`
+	UserConfig                    UserConfig           `env:",prefix=USER_"`
+	EncryptionConfig              EncryptionConfig     `env:",prefix=ENCRYPTION_"`
+	OTelConfig                    OTelConfig           // Standard OpenTelemetry SDK env vars (OTEL_*), read without a prefix
+
+	secretReferences map[string]string // Secret names referenced by the secret variables, by variable
 }
 
 // MCPConfig holds Model Context Protocol client configuration. When enabled, the
@@ -48,6 +70,225 @@ type MCPConfig struct {
 	RetryMaxInterval time.Duration `env:"RETRY_MAX_INTERVAL,default=30s" description:"Maximum backoff between connection/refresh retries"`
 }
 
+// SharingConfig holds configuration for read-only task share links. Share tokens are
+// signed with Secret and grant access to a redacted transcript of a single task through
+// the /share/{token} endpoint, without exposing the authenticated JSON-RPC API.
+type SharingConfig struct {
+	Enable         bool          `env:"ENABLE,default=false" description:"Enable tasks/share and the /share/{token} transcript endpoint"`
+	Secret         string        `env:"SECRET" description:"Secret used to sign share tokens (required when sharing is enabled)"`
+	DefaultTTL     time.Duration `env:"DEFAULT_TTL,default=24h" description:"Lifetime of a share token when the request does not set one"`
+	MaxTTL         time.Duration `env:"MAX_TTL,default=168h" description:"Maximum lifetime a share token may be issued for"`
+	RedactPatterns []string      `env:"REDACT_PATTERNS" description:"Additional regular expressions (comma-separated) redacted from shared transcripts"`
+}
+
+// Memory scopes
+const (
+	MemoryScopeContext = "context"
+	MemoryScopeUser    = "user"
+)
+
+// MemoryConfig holds configuration for long-term memory. When enabled, the facts and
+// preferences stated in a completed task are extracted with the LLM and stored, and the
+// memories relevant to a new task are added to the system prompt of its agent run.
+// Memories are kept per context, or per authenticated user with the user scope.
+type MemoryConfig struct {
+	Enable      bool   `env:"ENABLE,default=false" description:"Extract memories from completed tasks and recall them in later runs"`
+	Scope       string `env:"SCOPE,default=context" description:"What memories are kept for: context, or user (the authenticated subject, falling back to the context)"`
+	RecallLimit int    `env:"RECALL_LIMIT,default=10" description:"Most memories added to the system prompt of a run"`
+	MaxPerOwner int    `env:"MAX_PER_OWNER,default=100" description:"Most memories kept per context or user, the oldest being forgotten first (0 = unlimited)"`
+	MinMessages int    `env:"MIN_MESSAGES,default=2" description:"Fewest user and agent messages a completed task needs for memories to be extracted from it"`
+}
+
+// UserConfig holds configuration for user identity. The user a request is made for is the
+// authenticated subject, unless the caller is unauthenticated or a trusted front-end, which
+// name the user they call on behalf of in the configured header or in the userId key of the
+// request metadata. Tasks belong to the user that created them, and requests only reach the
+// tasks of their user, or the tasks of no user for requests without one.
+type UserConfig struct {
+	Require         bool     `env:"REQUIRE,default=false" description:"Reject requests made without a user identity"`
+	Header          string   `env:"HEADER" description:"Request header naming the user a trusted front-end calls on behalf of, such as X-User-ID"`
+	FromMetadata    bool     `env:"FROM_METADATA,default=false" description:"Accept the user named by the userId key of the request or message metadata"`
+	TrustedSubjects []string `env:"TRUSTED_SUBJECTS" description:"Authenticated subjects (comma-separated) of front-ends allowed to name the user in the header or metadata"`
+}
+
+// EncryptionConfig holds configuration for encrypting tasks at rest. The message parts and
+// artifacts of tasks are sealed with AES-GCM before the task store persists them and opened
+// again on read. Key can reference a secret; when it rotates, the replaced key keeps opening
+// the data sealed with it, which is sealed again with the new key in the background.
+type EncryptionConfig struct {
+	Enable       bool     `env:"ENABLE,default=false" description:"Encrypt the message parts and artifacts of tasks before the task store persists them"`
+	Key          string   `env:"KEY" description:"Base64-encoded AES key of 16, 24 or 32 bytes sealing new data (required when encryption is enabled)"`
+	PreviousKeys []string `env:"PREVIOUS_KEYS" description:"Base64-encoded keys (comma-separated) replaced by Key, still opening the data sealed with them"`
+}
+
+// LanguageConfig holds per-message language detection and enforcement configuration.
+// Supported languages are ISO 639-1 codes; the first one is the agent's default language,
+// used for refusals and as the translation target.
+type LanguageConfig struct {
+	Enable      bool     `env:"ENABLE,default=false" description:"Detect the language of incoming messages and record it in task metadata"`
+	Supported   []string `env:"SUPPORTED" description:"Supported ISO 639-1 language codes (comma-separated), advertised in the agent card"`
+	Enforcement string   `env:"ENFORCEMENT,default=none" description:"Handling of unsupported languages: none, refuse or translate"`
+}
+
+// Language enforcement modes for messages in unsupported languages
+const (
+	LanguageEnforcementNone      = "none"
+	LanguageEnforcementRefuse    = "refuse"
+	LanguageEnforcementTranslate = "translate"
+)
+
+// ModerationConfig holds configuration for the content moderation stage. When enabled, user
+// messages and agent responses are sent to a moderation endpoint - the OpenAI moderation API
+// or a custom webhook - and flagged messages are recorded or blocked according to the policy.
+type ModerationConfig struct {
+	Enable   bool          `env:"ENABLE,default=false" description:"Send user messages and agent responses to a moderation endpoint"`
+	Provider string        `env:"PROVIDER,default=openai" description:"Moderation provider: openai or webhook"`
+	URL      string        `env:"URL" description:"Moderation endpoint, defaults to the OpenAI moderation API for the openai provider"`
+	APIKey   string        `env:"API_KEY" description:"API key sent as a bearer token to the moderation endpoint"`
+	Model    string        `env:"MODEL,default=omni-moderation-latest" description:"Moderation model for the openai provider"`
+	Timeout  time.Duration `env:"TIMEOUT,default=10s" description:"Timeout for each moderation request"`
+	Policy   string        `env:"POLICY,default=flag" description:"Handling of flagged messages: flag records them in metadata, fail blocks them"`
+	Input    bool          `env:"INPUT,default=true" description:"Moderate incoming user messages"`
+	Output   bool          `env:"OUTPUT,default=true" description:"Moderate the agent's responses"`
+}
+
+// PII entities masked by redaction. Names of people, locations and organizations are only
+// found by a named entity recognition model.
+const (
+	PIIEntityEmail        = "email"
+	PIIEntityPhone        = "phone"
+	PIIEntityCardNumber   = "card_number"
+	PIIEntitySSN          = "ssn"
+	PIIEntityIPAddress    = "ip_address"
+	PIIEntityPerson       = "person"
+	PIIEntityLocation     = "location"
+	PIIEntityOrganization = "organization"
+)
+
+// PIIEntities are the PII entities redaction can mask
+var PIIEntities = []string{
+	PIIEntityEmail, PIIEntityPhone, PIIEntityCardNumber, PIIEntitySSN, PIIEntityIPAddress,
+	PIIEntityPerson, PIIEntityLocation, PIIEntityOrganization,
+}
+
+// RedactionConfig holds configuration for masking personal data. When enabled, the configured
+// entities are replaced with placeholders such as [EMAIL] in user messages before the agent
+// and its LLM see them, in the agent's responses, and in the artifacts of completed tasks
+// before they are stored.
+type RedactionConfig struct {
+	Enable    bool     `env:"ENABLE,default=false" description:"Mask personal data in messages and artifacts"`
+	Entities  []string `env:"ENTITIES,default=email,phone,card_number,ssn,ip_address" description:"Entities (comma-separated) to mask: email, phone, card_number, ssn, ip_address, and person, location and organization with a NER model"`
+	Input     bool     `env:"INPUT,default=true" description:"Redact incoming user messages before the agent sees them"`
+	Output    bool     `env:"OUTPUT,default=true" description:"Redact the agent's responses"`
+	Artifacts bool     `env:"ARTIFACTS,default=true" description:"Redact the artifacts of completed tasks before they are stored"`
+}
+
+// Moderation providers
+const (
+	ModerationProviderOpenAI  = "openai"
+	ModerationProviderWebhook = "webhook"
+)
+
+// Moderation policies for flagged messages
+const (
+	ModerationPolicyFlag = "flag"
+	ModerationPolicyFail = "fail"
+)
+
+// FileIngestionConfig holds configuration for the processing of inbound file parts. When
+// enabled, the bytes of each file part are sniffed for their real media type, files of types
+// that are not allowed are rejected, and the text of documents and a thumbnail of images are
+// attached to the part for the agent.
+type FileIngestionConfig struct {
+	Enable         bool     `env:"ENABLE,default=false" description:"Sniff, validate and convert the file parts of incoming messages"`
+	AllowedTypes   []string `env:"ALLOWED_TYPES" description:"Media types (comma-separated, wildcards such as image/* allowed) accepted in file parts, all when empty"`
+	ExtractText    bool     `env:"EXTRACT_TEXT,default=true" description:"Extract plain text from text, HTML, PDF and DOCX files"`
+	MaxTextLength  int      `env:"MAX_TEXT_LENGTH,default=100000" description:"Maximum characters of extracted text kept per file (0 = unlimited)"`
+	Thumbnails     bool     `env:"THUMBNAILS,default=true" description:"Attach a base64 JPEG thumbnail of PNG, JPEG and GIF images"`
+	ThumbnailSize  int      `env:"THUMBNAIL_SIZE,default=512" description:"Maximum width and height of image thumbnails in pixels"`
+	MaxImagePixels int      `env:"MAX_IMAGE_PIXELS,default=40000000" description:"Images with more pixels than this are not decoded for thumbnails"`
+}
+
+// SpeechConfig holds configuration for the speech subsystems. Transcription turns the audio
+// file parts of incoming messages into text before they reach the agent, and synthesis speaks
+// the agent's responses into audio artifacts for requests that accept an audio output mode.
+// Both call OpenAI-compatible speech endpoints.
+type SpeechConfig struct {
+	Transcription      bool          `env:"TRANSCRIPTION,default=false" description:"Transcribe the audio file parts of incoming messages"`
+	TranscriptionURL   string        `env:"TRANSCRIPTION_URL,default=https://api.openai.com/v1/audio/transcriptions" description:"OpenAI-compatible transcription endpoint"`
+	TranscriptionModel string        `env:"TRANSCRIPTION_MODEL,default=whisper-1" description:"Speech-to-text model"`
+	Synthesis          bool          `env:"SYNTHESIS,default=false" description:"Synthesize the agent's responses to audio artifacts for requests accepting an audio output mode"`
+	SynthesisURL       string        `env:"SYNTHESIS_URL,default=https://api.openai.com/v1/audio/speech" description:"OpenAI-compatible speech synthesis endpoint"`
+	SynthesisModel     string        `env:"SYNTHESIS_MODEL,default=tts-1" description:"Text-to-speech model"`
+	Voice              string        `env:"VOICE,default=alloy" description:"Voice of synthesized speech"`
+	Format             string        `env:"FORMAT,default=mp3" description:"Audio format of synthesized speech: mp3, opus, aac, flac or wav"`
+	MaxSynthesisLength int           `env:"MAX_SYNTHESIS_LENGTH,default=4096" description:"Responses longer than this many characters are not synthesized (0 = unlimited)"`
+	APIKey             string        `env:"API_KEY" description:"API key sent as a bearer token to the speech endpoints"`
+	Timeout            time.Duration `env:"TIMEOUT,default=60s" description:"Timeout for each transcription or synthesis request"`
+}
+
+// SynthesisMediaType returns the media type of speech synthesized in the configured format,
+// or an empty string for an unsupported format
+func (c SpeechConfig) SynthesisMediaType() string {
+	switch c.Format {
+	case "mp3":
+		return "audio/mpeg"
+	case "opus":
+		return "audio/ogg"
+	case "aac":
+		return "audio/aac"
+	case "flac":
+		return "audio/flac"
+	case "wav":
+		return "audio/wav"
+	default:
+		return ""
+	}
+}
+
+// ReloadConfig holds configuration for hot reloading. When enabled, the server watches an
+// env file and the agent card file and applies changes to the system prompt, disabled
+// skills, budget limits and agent card to new tasks, without a restart.
+type ReloadConfig struct {
+	Enable   bool          `env:"ENABLE,default=false" description:"Watch the env file and agent card file and apply changed settings to new tasks"`
+	EnvFile  string        `env:"ENV_FILE" description:"Env file with KEY=value lines whose values override the environment on reload"`
+	Interval time.Duration `env:"INTERVAL,default=5s" description:"How often the watched files are checked for changes"`
+}
+
+// SecretReferencePrefix starts a configuration value that names a secret of the secrets
+// provider instead of holding the secret itself, such as secret:llm/api-key
+const SecretReferencePrefix = "secret:"
+
+// Variables that can reference a secret
+const (
+	SecretAgentAPIKey               = "AGENT_CLIENT_API_KEY"
+	SecretAuthClientSecret          = "AUTH_CLIENT_SECRET"
+	SecretSharingSecret             = "SHARING_SECRET"
+	SecretArtifactsStorageAccessKey = "ARTIFACTS_STORAGE_ACCESS_KEY"
+	SecretArtifactsStorageSecretKey = "ARTIFACTS_STORAGE_SECRET_KEY"
+	SecretEncryptionKey             = "ENCRYPTION_KEY"
+)
+
+// Secrets providers
+const (
+	SecretsProviderFile  = "file"
+	SecretsProviderVault = "vault"
+)
+
+// SecretsConfig holds configuration for resolving secrets from a secrets provider. The API key
+// of the LLM provider, the auth client secret, the sharing secret, the artifacts storage keys
+// and the encryption key can reference a secret as secret:<name>; references are resolved at startup and
+// checked again at the refresh interval so rotated secrets apply without a restart.
+type SecretsConfig struct {
+	Provider        string        `env:"PROVIDER" description:"Secrets provider resolving secret:<name> references (file or vault); empty disables secret references"`
+	Dir             string        `env:"DIR,default=/run/secrets" description:"Directory of the secret files of the file provider, a secret name being a path relative to it"`
+	VaultAddress    string        `env:"VAULT_ADDRESS" description:"Vault server URL, e.g. https://vault:8200; secret names are path#field, e.g. secret/data/llm#api_key"`
+	VaultToken      string        `env:"VAULT_TOKEN" description:"Vault token used to read secrets"`
+	VaultNamespace  string        `env:"VAULT_NAMESPACE" description:"Vault Enterprise namespace sent with every request"`
+	RefreshInterval time.Duration `env:"REFRESH_INTERVAL,default=1m" description:"How often secret references are resolved again to pick up rotated secrets (0 disables rotation)"`
+	Timeout         time.Duration `env:"TIMEOUT,default=10s" description:"Timeout for reading a secret"`
+}
+
 // AgentConfig holds agent-specific configuration
 type AgentConfig struct {
 	AgentName                   string            `env:"NAME" description:"Name of the agent for identification in callbacks and logging"`
@@ -70,12 +311,134 @@ type AgentConfig struct {
 	SystemPrompt                string            `env:"SYSTEM_PROMPT,default=You are a helpful AI assistant processing an A2A (Agent-to-Agent) task. Please provide helpful and accurate responses." description:"System prompt for LLM interactions"`
 	MaxConversationHistory      int               `env:"MAX_CONVERSATION_HISTORY,default=20" description:"Maximum number of messages to keep in conversation history per context"`
 	ToolBoxConfig               ToolBoxConfig     `env:",prefix=TOOLS_" description:"Tool configuration for agents"`
-	EnableUsageMetadata         bool              `env:"ENABLE_USAGE_METADATA,default=true" description:"Enable usage metadata (token counts and execution stats) in task responses"`
+	EnableUsageMetadata         bool              `env:"ENABLE_USAGE_METADATA,default=true" description:"Enable usage metadata (token counts, execution stats and timings) in task responses"`
+	EnableVision                bool              `env:"ENABLE_VISION,default=false" description:"Send image file parts to the LLM as image content, for vision-capable models"`
+	Embeddings                  EmbeddingsConfig  `env:",prefix=EMBEDDINGS_" description:"Embeddings client configuration"`
+	Budget                      BudgetConfig      `env:",prefix=BUDGET_" description:"LLM spend limits"`
+	Routing                     RoutingConfig     `env:",prefix=ROUTING_" description:"Model selection per agent run"`
+}
+
+// RoutingConfig selects the model of an agent run from the request instead of always using
+// the configured model. A model may be prefixed with its provider, as provider/model, to route
+// to another provider of the gateway. Empty models fall back to the configured model.
+type RoutingConfig struct {
+	Hints             map[string]string `env:"HINTS" description:"Models selected by the modelHint metadata of the message, as hint:model pairs (comma-separated), e.g. fast:openai/gpt-4o-mini,smart:openai/o3"`
+	VisionModel       string            `env:"VISION_MODEL" description:"Model for messages with image file parts"`
+	LongMessageModel  string            `env:"LONG_MESSAGE_MODEL" description:"Model for messages with at least LONG_MESSAGE_LENGTH characters of text"`
+	LongMessageLength int               `env:"LONG_MESSAGE_LENGTH,default=4000" description:"Text length from which a message is routed to LONG_MESSAGE_MODEL"`
+	ToolsModel        string            `env:"TOOLS_MODEL" description:"Model for runs that offer tools to the model"`
+}
+
+// Enabled reports whether any routing rule is set
+func (c RoutingConfig) Enabled() bool {
+	return len(c.Hints) > 0 || c.VisionModel != "" || c.LongMessageModel != "" || c.ToolsModel != ""
+}
+
+// BudgetConfig limits the LLM spend of the agent per task, per context, per user a day and per
+// UTC day, in tokens or in cost estimated from the token prices. A limit of 0 is unlimited. When a limit
+// is reached the agent stops before its next LLM call and the task ends in the configured state.
+type BudgetConfig struct {
+	MaxTaskTokens        int64   `env:"MAX_TASK_TOKENS,default=0" description:"Maximum tokens a task may use (0 = unlimited)"`
+	MaxContextTokens     int64   `env:"MAX_CONTEXT_TOKENS,default=0" description:"Maximum tokens the tasks of a context may use (0 = unlimited)"`
+	MaxDailyTokens       int64   `env:"MAX_DAILY_TOKENS,default=0" description:"Maximum tokens the agent may use per UTC day (0 = unlimited)"`
+	MaxUserTokens        int64   `env:"MAX_USER_TOKENS,default=0" description:"Maximum tokens the tasks of a user may use per UTC day (0 = unlimited)"`
+	MaxTaskCost          float64 `env:"MAX_TASK_COST,default=0" description:"Maximum estimated cost of a task (0 = unlimited)"`
+	MaxContextCost       float64 `env:"MAX_CONTEXT_COST,default=0" description:"Maximum estimated cost of the tasks of a context (0 = unlimited)"`
+	MaxDailyCost         float64 `env:"MAX_DAILY_COST,default=0" description:"Maximum estimated cost per UTC day (0 = unlimited)"`
+	MaxUserCost          float64 `env:"MAX_USER_COST,default=0" description:"Maximum estimated cost of the tasks of a user per UTC day (0 = unlimited)"`
+	PromptTokenPrice     float64 `env:"PROMPT_TOKEN_PRICE,default=0" description:"Price per million prompt tokens, used to estimate cost"`
+	CompletionTokenPrice float64 `env:"COMPLETION_TOKEN_PRICE,default=0" description:"Price per million completion tokens, used to estimate cost"`
+	Action               string  `env:"ACTION,default=fail" description:"State of a task that exceeds the budget: fail or input_required"`
+}
+
+// Enabled reports whether any budget limit is set
+func (c BudgetConfig) Enabled() bool {
+	return c.MaxTaskTokens > 0 || c.MaxContextTokens > 0 || c.MaxDailyTokens > 0 || c.MaxUserTokens > 0 ||
+		c.MaxTaskCost > 0 || c.MaxContextCost > 0 || c.MaxDailyCost > 0 || c.MaxUserCost > 0
+}
+
+// Budget actions for tasks that exceed the budget
+const (
+	BudgetActionFail          = "fail"
+	BudgetActionInputRequired = "input_required"
+)
+
+// EmbeddingsConfig defines the embedding model and how requests to the embeddings endpoint are batched and rate limited
+type EmbeddingsConfig struct {
+	Model             string  `env:"MODEL" description:"Embedding model name"`
+	Dimensions        int     `env:"DIMENSIONS,default=0" description:"Number of dimensions of the returned embeddings, for models that support shortening them (0 = model default)"`
+	BatchSize         int     `env:"BATCH_SIZE,default=100" description:"Maximum number of texts embedded in a single request"`
+	RequestsPerSecond float64 `env:"REQUESTS_PER_SECOND,default=0" description:"Maximum number of requests sent per second (0 = unlimited)"`
 }
 
 // ToolBoxConfig defines configuration options for creating a DefaultToolBox
 type ToolBoxConfig struct {
-	EnableCreateArtifact bool `env:"CREATE_ARTIFACT,default=false" description:"Enable create_artifact tool for autonomous artifact creation"`
+	EnableCreateArtifact bool                  `env:"CREATE_ARTIFACT,default=false" description:"Enable create_artifact tool for autonomous artifact creation"`
+	EnableHTTPRequest    bool                  `env:"HTTP_REQUEST,default=false" description:"Enable http_request tool for calling external APIs"`
+	EnableRedactPII      bool                  `env:"REDACT_PII,default=false" description:"Enable redact_pii tool for masking personal data in text"`
+	HTTPRequest          HTTPRequestToolConfig `env:",prefix=HTTP_REQUEST_" description:"Policy for the http_request tool"`
+	RequireApproval      []string              `env:"REQUIRE_APPROVAL" description:"Tool names (comma-separated) that only run after the client approves the call"`
+	SideEffects          []string              `env:"SIDE_EFFECTS" description:"Tool names (comma-separated) with side effects, whose executions are journaled so a resumed task does not execute them again"`
+	SkillsConfig         SkillsConfig          `env:",prefix=SKILLS_" description:"Built-in skills that run untrusted work in isolation, disabled by default"`
+}
+
+// SkillsConfig holds the safety settings of the built-in skills that run work the model
+// decides on, such as code, in isolation from the agent. Each skill is disabled by default.
+type SkillsConfig struct {
+	CodeExecution CodeExecutionConfig `env:",prefix=CODE_EXECUTION_" description:"Sandboxed code execution for the execute_code tool"`
+	Browser       BrowserConfig       `env:",prefix=BROWSER_" description:"Headless browser of the browse_web tool"`
+	Command       CommandConfig       `env:",prefix=COMMAND_" description:"Command policy of the run_command tool"`
+}
+
+// CommandConfig defines the policy of the run_command tool, which runs allowlisted binaries on
+// the host of the agent, without a shell
+type CommandConfig struct {
+	Enable          bool          `env:"ENABLE,default=false" description:"Enable the run_command tool"`
+	AllowedBinaries []string      `env:"ALLOWED_BINARIES" description:"Binaries (comma-separated) commands may run, by name looked up on the PATH or by absolute path; empty allows none"`
+	WorkDir         string        `env:"WORK_DIR" description:"Directory commands run in, or in one of its subdirectories; a fresh temporary directory per call when empty"`
+	Timeout         time.Duration `env:"TIMEOUT,default=30s" description:"Time after which a command is killed"`
+	MaxOutputSize   int64         `env:"MAX_OUTPUT_SIZE,default=65536" description:"Maximum bytes of stdout and of stderr returned to the agent; longer output is attached to the task as an artifact"`
+	MaxFileSize     int64         `env:"MAX_FILE_SIZE,default=10485760" description:"Maximum bytes of stdout and of stderr kept; longer output is cut"`
+}
+
+// CodeExecutionConfig defines the sandbox of the execute_code tool, which runs code in an
+// ephemeral Docker container per call
+type CodeExecutionConfig struct {
+	Enable        bool          `env:"ENABLE,default=false" description:"Enable the execute_code tool"`
+	DockerHost    string        `env:"DOCKER_HOST,default=unix:///var/run/docker.sock" description:"Docker Engine API endpoint, as unix:///path/to/docker.sock or tcp://host:port"`
+	Image         string        `env:"IMAGE,default=python:3.12-slim" description:"Container image the code runs in, which must provide python3 and sh"`
+	Languages     []string      `env:"LANGUAGES,default=python,shell" description:"Languages (comma-separated) the tool accepts, among python and shell"`
+	CPUs          float64       `env:"CPUS,default=1" description:"CPUs available to a run"`
+	MemoryLimit   int64         `env:"MEMORY_LIMIT,default=268435456" description:"Memory limit of a run in bytes"`
+	PidsLimit     int64         `env:"PIDS_LIMIT,default=64" description:"Maximum number of processes of a run"`
+	Timeout       time.Duration `env:"TIMEOUT,default=30s" description:"Time after which a run is killed"`
+	EnableNetwork bool          `env:"ENABLE_NETWORK,default=false" description:"Give runs network access"`
+	MaxOutputSize int64         `env:"MAX_OUTPUT_SIZE,default=65536" description:"Maximum bytes of stdout and of stderr returned to the agent; longer output is truncated"`
+	MaxFileSize   int64         `env:"MAX_FILE_SIZE,default=10485760" description:"Maximum size in bytes of an input file and of an output file"`
+}
+
+// BrowserConfig defines the headless browser of the browse_web tool, which starts a fresh
+// browser profile per call
+type BrowserConfig struct {
+	Enable               bool          `env:"ENABLE,default=false" description:"Enable the browse_web tool"`
+	ExecPath             string        `env:"EXEC_PATH" description:"Path of the Chrome or Chromium binary; looked up on the PATH when empty"`
+	AllowedDomains       []string      `env:"ALLOWED_DOMAINS" description:"Domains (comma-separated) pages may be loaded from, including their subdomains; empty allows any domain not denied"`
+	DeniedDomains        []string      `env:"DENIED_DOMAINS" description:"Domains (comma-separated) pages are never loaded from, including their subdomains"`
+	AllowPrivateNetworks bool          `env:"ALLOW_PRIVATE_NETWORKS,default=false" description:"Allow pages from hosts resolving to loopback, private, link-local and shared addresses"`
+	MaxSteps             int           `env:"MAX_STEPS,default=20" description:"Maximum actions of a call, including the first navigation"`
+	Timeout              time.Duration `env:"TIMEOUT,default=60s" description:"Time after which a call is stopped"`
+	MaxTextSize          int           `env:"MAX_TEXT_SIZE,default=65536" description:"Maximum bytes of page text returned to the agent per extraction; longer text is truncated"`
+}
+
+// HTTPRequestToolConfig defines the policy the http_request tool enforces
+type HTTPRequestToolConfig struct {
+	AllowedDomains       []string          `env:"ALLOWED_DOMAINS" description:"Domains (comma-separated) requests may be sent to, including their subdomains; empty allows any domain not denied"`
+	DeniedDomains        []string          `env:"DENIED_DOMAINS" description:"Domains (comma-separated) requests are never sent to, including their subdomains"`
+	MaxResponseSize      int64             `env:"MAX_RESPONSE_SIZE,default=1048576" description:"Maximum response body size in bytes returned to the agent; larger bodies are truncated"`
+	MaxRedirects         int               `env:"MAX_REDIRECTS,default=5" description:"Maximum redirects followed, each checked against the domain policy; 0 disables redirects"`
+	Timeout              time.Duration     `env:"TIMEOUT,default=30s" description:"Timeout for each request"`
+	SecretHeaders        map[string]string `env:"SECRET_HEADERS" description:"Headers injected per domain, as domain:Header=value pairs (comma-separated); never shown to the agent"`
+	AllowPrivateNetworks bool              `env:"ALLOW_PRIVATE_NETWORKS,default=false" description:"Allow requests to loopback, private, link-local and cloud metadata addresses"`
 }
 
 // ClientTLSConfig holds TLS configuration for LLM client
@@ -91,13 +454,50 @@ type CapabilitiesConfig struct {
 	Streaming              bool `env:"STREAMING,default=true" description:"Enable streaming support"`
 	PushNotifications      bool `env:"PUSH_NOTIFICATIONS,default=true" description:"Enable push notifications"`
 	StateTransitionHistory bool `env:"STATE_TRANSITION_HISTORY,default=false" description:"Enable state transition history"`
+	WebSocket              bool `env:"WEBSOCKET,default=false" description:"Serve message/stream and tasks/resubscribe over WebSocket at /a2a/ws"`
 }
 
 // TLSConfig holds TLS configuration
 type TLSConfig struct {
-	Enable   bool   `env:"ENABLE,default=false"`
-	CertPath string `env:"CERT_PATH" description:"TLS certificate path"`
-	KeyPath  string `env:"KEY_PATH" description:"TLS key path"`
+	Enable          bool          `env:"ENABLE,default=false"`
+	CertPath        string        `env:"CERT_PATH" description:"TLS certificate path"`
+	KeyPath         string        `env:"KEY_PATH" description:"TLS key path"`
+	SNICertificates []string      `env:"SNI_CERTIFICATES" description:"Additional certificates (comma-separated cert_path:key_path pairs) selected by the server name clients request"`
+	ClientAuth      string        `env:"CLIENT_AUTH,default=none" description:"Client certificate policy: none, request, require, verify_if_given or require_and_verify"`
+	ClientCAPath    string        `env:"CLIENT_CA_PATH" description:"PEM bundle of the CAs client certificates are verified against"`
+	ReloadInterval  time.Duration `env:"RELOAD_INTERVAL,default=0s" description:"How often the certificate, key and client CA files are checked for changes and reloaded (0 disables)"`
+}
+
+// TLS client certificate policies
+const (
+	TLSClientAuthNone             = "none"
+	TLSClientAuthRequest          = "request"
+	TLSClientAuthRequire          = "require"
+	TLSClientAuthVerifyIfGiven    = "verify_if_given"
+	TLSClientAuthRequireAndVerify = "require_and_verify"
+)
+
+// Validate checks the client certificate policy and the additional certificates of an
+// enabled TLS configuration
+func (c TLSConfig) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+	switch c.ClientAuth {
+	case "", TLSClientAuthNone, TLSClientAuthRequest, TLSClientAuthRequire:
+	case TLSClientAuthVerifyIfGiven, TLSClientAuthRequireAndVerify:
+		if c.ClientCAPath == "" {
+			return fmt.Errorf("client ca path is required for tls client auth '%s'", c.ClientAuth)
+		}
+	default:
+		return fmt.Errorf("invalid tls client auth '%s': must be none, request, require, verify_if_given or require_and_verify", c.ClientAuth)
+	}
+	for _, pair := range c.SNICertificates {
+		if certPath, keyPath, ok := strings.Cut(pair, ":"); !ok || certPath == "" || keyPath == "" {
+			return fmt.Errorf("invalid sni certificate '%s': must be cert_path:key_path", pair)
+		}
+	}
+	return nil
 }
 
 // AuthConfig holds authentication configuration
@@ -106,16 +506,18 @@ type AuthConfig struct {
 	IssuerURL    string `env:"ISSUER_URL,default=http://keycloak:8080/realms/inference-gateway-realm"`
 	ClientID     string `env:"CLIENT_ID,default=inference-gateway-client"`
 	ClientSecret string `env:"CLIENT_SECRET"`
+	PolicyFile   string `env:"POLICY_FILE" description:"YAML or JSON file with the access policy rules checked before methods and tool executions"`
 }
 
 // QueueConfig holds task queue configuration
 type QueueConfig struct {
-	Provider        string            `env:"PROVIDER,default=memory" description:"Message broker provider (memory, redis, sqs, pubsub)"`
-	URL             string            `env:"URL" description:"Connection URL for the message broker"`
-	MaxSize         int               `env:"MAX_SIZE,default=100"`
-	CleanupInterval time.Duration     `env:"CLEANUP_INTERVAL,default=120s"`
-	Credentials     map[string]string `env:"CREDENTIALS" description:"Broker-specific credentials"`
-	Options         map[string]string `env:"OPTIONS" description:"Broker-specific configuration options"`
+	Provider          string            `env:"PROVIDER,default=memory" description:"Message broker provider (memory, redis, sqs, pubsub)"`
+	URL               string            `env:"URL" description:"Connection URL for the message broker"`
+	MaxSize           int               `env:"MAX_SIZE,default=100"`
+	CleanupInterval   time.Duration     `env:"CLEANUP_INTERVAL,default=120s"`
+	HandoffOnShutdown bool              `env:"HANDOFF_ON_SHUTDOWN,default=false" description:"Queue background tasks still running at shutdown again for other instances to resume"`
+	Credentials       map[string]string `env:"CREDENTIALS" description:"Broker-specific credentials"`
+	Options           map[string]string `env:"OPTIONS" description:"Broker-specific configuration options"`
 }
 
 // TaskRetentionConfig defines how many completed and failed tasks to retain
@@ -125,14 +527,162 @@ type TaskRetentionConfig struct {
 	CleanupInterval   time.Duration `env:"CLEANUP_INTERVAL,default=5m" description:"How often to run cleanup (0 = manual cleanup only)"`
 }
 
+// TaskHistoryConfig limits the message history stored with each task. The most recent
+// messages are retained, and the agent only sees the retained history on later turns.
+type TaskHistoryConfig struct {
+	MaxMessages      int  `env:"MAX_MESSAGES,default=0" description:"Maximum number of messages kept in a task's history (0 = unlimited)"`
+	MaxBytes         int  `env:"MAX_BYTES,default=0" description:"Maximum JSON-encoded size of a task's history in bytes (0 = unlimited)"`
+	DropToolMessages bool `env:"DROP_TOOL_MESSAGES,default=false" description:"Drop tool call and tool result messages from the stored history"`
+}
+
+// Enabled reports whether any history limit is set
+func (c TaskHistoryConfig) Enabled() bool {
+	return c.MaxMessages > 0 || c.MaxBytes > 0 || c.DropToolMessages
+}
+
+// InputTimeoutConfig expires tasks left waiting for input. A task in the input-required
+// state longer than the timeout ends in the configured state, with a status message saying why.
+type InputTimeoutConfig struct {
+	Timeout       time.Duration `env:"TIMEOUT,default=0" description:"How long a task may wait for input before it expires (0 = never)"`
+	Action        string        `env:"ACTION,default=cancel" description:"State of an expired task: cancel or fail"`
+	CheckInterval time.Duration `env:"CHECK_INTERVAL,default=30s" description:"How often tasks waiting for input are checked for expiry"`
+}
+
+// Enabled reports whether tasks waiting for input expire
+func (c InputTimeoutConfig) Enabled() bool {
+	return c.Timeout > 0
+}
+
+// Input timeout actions for expired tasks
+const (
+	InputTimeoutActionCancel = "cancel"
+	InputTimeoutActionFail   = "fail"
+)
+
+// ScheduleConfig configures the recurring agent runs the server starts on cron schedules
+type ScheduleConfig struct {
+	File          string        `env:"FILE" description:"YAML or JSON file with the schedules of recurring agent runs"`
+	CheckInterval time.Duration `env:"CHECK_INTERVAL,default=10s" description:"How often schedules are checked for due runs"`
+}
+
+// TaskDependencyConfig controls how tasks held for their dependencies are continued. Held tasks
+// are stored with their dependencies, and are checked again at startup and periodically, so a
+// dependency completing after a restart or on another replica sharing the storage releases them.
+type TaskDependencyConfig struct {
+	CheckInterval time.Duration `env:"CHECK_INTERVAL,default=30s" description:"How often tasks held for their dependencies are checked against the stored state of the dependencies"`
+}
+
+// TaskResumeConfig controls messages sent to an existing task that cannot be resumed with
+// them right away: a task that has ended, or a task that is still working
+type TaskResumeConfig struct {
+	TerminalAction    string `env:"TERMINAL_ACTION,default=reject" description:"Handling of a message sent to a task that has ended: reject, or follow_up to start a new task in the same context"`
+	QueueWhileWorking bool   `env:"QUEUE_WHILE_WORKING,default=false" description:"Queue a message sent to a working task and continue the task with it once the current execution ends"`
+}
+
+// Task resume actions for messages sent to a task that has ended
+const (
+	TaskResumeActionReject   = "reject"
+	TaskResumeActionFollowUp = "follow_up"
+)
+
+// TaskCheckpointConfig controls the checkpoints of the agent loop of running tasks. The
+// messages, pending tool calls and iteration count of a run are saved to the task store as
+// it progresses, and the checkpoint doubles as the lease of the run: it is renewed while the
+// run goes on, so the working tasks whose lease expired are recovered on startup and
+// periodically.
+type TaskCheckpointConfig struct {
+	Enable           bool          `env:"ENABLE,default=false" description:"Save the agent loop state of running tasks to the task store after every model call and tool execution"`
+	OnRestart        string        `env:"ON_RESTART,default=resume" description:"Handling of abandoned working tasks: resume them from their last checkpoint, or fail them with a status message"`
+	StaleAfter       time.Duration `env:"STALE_AFTER,default=10m" description:"Time after which the lease of a run that was not renewed expires and its working task is recovered"`
+	MaxRecoveries    int           `env:"MAX_RECOVERIES,default=3" description:"Maximum number of times a task is resumed after a restart before it is failed instead (0 = unlimited)"`
+	RecoveryInterval time.Duration `env:"RECOVERY_INTERVAL,default=1m" description:"How often working tasks are checked for expired leases after startup (0 = on startup only)"`
+}
+
+// Task checkpoint actions for tasks found working on startup
+const (
+	TaskCheckpointRestartResume = "resume"
+	TaskCheckpointRestartFail   = "fail"
+)
+
 // ServerConfig holds HTTP server configuration
 type ServerConfig struct {
-	Port                  string        `env:"PORT,default=8080" description:"HTTP server port"`
-	ReadTimeout           time.Duration `env:"READ_TIMEOUT,default=120s" description:"HTTP server read timeout"`
-	WriteTimeout          time.Duration `env:"WRITE_TIMEOUT,default=120s" description:"HTTP server write timeout"`
-	IdleTimeout           time.Duration `env:"IDLE_TIMEOUT,default=120s" description:"HTTP server idle timeout"`
-	DisableHealthcheckLog bool          `env:"DISABLE_HEALTHCHECK_LOG,default=true" description:"Disable logging for health check requests"`
-	TLSConfig             TLSConfig     `env:",prefix=TLS_"`
+	Port                  string            `env:"PORT,default=8080" description:"HTTP server port"`
+	ReadTimeout           time.Duration     `env:"READ_TIMEOUT,default=120s" description:"HTTP server read timeout"`
+	WriteTimeout          time.Duration     `env:"WRITE_TIMEOUT,default=120s" description:"HTTP server write timeout"`
+	IdleTimeout           time.Duration     `env:"IDLE_TIMEOUT,default=120s" description:"HTTP server idle timeout"`
+	DisableHealthcheckLog bool              `env:"DISABLE_HEALTHCHECK_LOG,default=true" description:"Disable logging for health check requests"`
+	ShutdownTimeout       time.Duration     `env:"SHUTDOWN_TIMEOUT,default=10s" description:"Maximum time to wait for servers to stop gracefully"`
+	DrainTimeout          time.Duration     `env:"DRAIN_TIMEOUT,default=5s" description:"Time in-flight streams get to finish on shutdown before they are checkpointed"`
+	InstanceID            string            `env:"INSTANCE_ID" description:"Identifies this instance in task checkpoints and handoff metadata, unique per replica and stable across restarts (defaults to the hostname)"`
+	ReadHeaderTimeout     time.Duration     `env:"READ_HEADER_TIMEOUT,default=10s" description:"HTTP server timeout for reading request headers"`
+	DisableKeepAlives     bool              `env:"DISABLE_KEEP_ALIVES,default=false" description:"Close HTTP/1.1 connections after each request instead of reusing them"`
+	EnableDebugEndpoints  bool              `env:"ENABLE_DEBUG_ENDPOINTS,default=false" description:"Serve queue, worker and scheduler state at /debug/stats and /debug/dump"`
+	EnableAdminEndpoints  bool              `env:"ENABLE_ADMIN_ENDPOINTS,default=false" description:"Serve queue, drain and task controls under /admin"`
+	AdminScope            string            `env:"ADMIN_SCOPE" description:"Scope callers of the admin endpoints must be granted, required with ENABLE_ADMIN_ENDPOINTS"`
+	EnableChatCompletions bool              `env:"ENABLE_CHAT_COMPLETIONS,default=false" description:"Serve an OpenAI-compatible /v1/chat/completions endpoint that runs chat requests as A2A tasks"`
+	IdempotencyWindow     time.Duration     `env:"IDEMPOTENCY_WINDOW,default=5m" description:"How long message/send responses are replayed to retries with the same Idempotency-Key (0 disables deduplication)"`
+	MaxBatchSize          int               `env:"MAX_BATCH_SIZE,default=100" description:"Most messages a message/sendBatch request may carry (0 for no limit)"`
+	HTTP2Config           HTTP2Config       `env:",prefix=HTTP2_"`
+	TLSConfig             TLSConfig         `env:",prefix=TLS_"`
+	PayloadLimits         PayloadLimits     `env:",prefix=LIMITS_"`
+	Validation            RequestValidation `env:",prefix=VALIDATION_"`
+	CORS                  CORSConfig        `env:",prefix=CORS_"`
+}
+
+// CORSConfig controls the cross-origin requests browsers may send to a server. Preflight
+// requests are answered before authentication, so browsers can send the credentials.
+type CORSConfig struct {
+	Enable           bool          `env:"ENABLE,default=false" description:"Answer cross-origin requests from browsers, including preflight requests"`
+	AllowedOrigins   []string      `env:"ALLOWED_ORIGINS,default=*" description:"Origins (comma-separated) allowed to send cross-origin requests, * for any"`
+	AllowedMethods   []string      `env:"ALLOWED_METHODS,default=GET,POST,PUT,DELETE,OPTIONS" description:"Methods (comma-separated) allowed in cross-origin requests"`
+	AllowedHeaders   []string      `env:"ALLOWED_HEADERS,default=Authorization,Content-Type,Idempotency-Key,X-Request-ID,traceparent" description:"Request headers (comma-separated) allowed in cross-origin requests"`
+	ExposedHeaders   []string      `env:"EXPOSED_HEADERS,default=X-Request-ID,Idempotent-Replayed" description:"Response headers (comma-separated) exposed to browser scripts"`
+	AllowCredentials bool          `env:"ALLOW_CREDENTIALS,default=false" description:"Allow cross-origin requests to send cookies and authorization headers"`
+	MaxAge           time.Duration `env:"MAX_AGE,default=10m" description:"How long browsers may cache the answer to a preflight request"`
+}
+
+// RequestValidation checks the params of message/send and message/stream requests against the
+// A2A schema before they are processed, so malformed messages never reach the task history
+type RequestValidation struct {
+	Enable        bool   `env:"ENABLE,default=true" description:"Validate the params of message requests against the A2A schema"`
+	UnknownFields string `env:"UNKNOWN_FIELDS,default=ignore" description:"Handling of fields the A2A schema does not define: ignore or reject"`
+	MissingFields string `env:"MISSING_FIELDS,default=fill" description:"Handling of a message without a message ID: fill to generate one, or reject"`
+}
+
+// Request validation policies for unknown and missing fields
+const (
+	ValidationUnknownFieldsIgnore = "ignore"
+	ValidationUnknownFieldsReject = "reject"
+	ValidationMissingFieldsFill   = "fill"
+	ValidationMissingFieldsReject = "reject"
+)
+
+// PayloadLimits bounds the size of A2A requests. The limits apply to every method; the
+// Send and Stream overrides replace them for message/send and for the streaming methods
+// (message/stream and tasks/resubscribe). A limit of 0 is unlimited, and an override of 0
+// keeps the general limit.
+type PayloadLimits struct {
+	MaxBodySize int64                 `env:"MAX_BODY_SIZE,default=10485760" description:"Maximum size in bytes of a JSON-RPC request body (0 = unlimited)"`
+	MaxParts    int                   `env:"MAX_PARTS,default=100" description:"Maximum number of parts in a message (0 = unlimited)"`
+	MaxFileSize int64                 `env:"MAX_FILE_SIZE,default=5242880" description:"Maximum decoded size in bytes of a base64 file part (0 = unlimited)"`
+	Send        PayloadLimitsOverride `env:",prefix=SEND_"`
+	Stream      PayloadLimitsOverride `env:",prefix=STREAM_"`
+}
+
+// PayloadLimitsOverride replaces the general payload limits for one request mode
+type PayloadLimitsOverride struct {
+	MaxBodySize int64 `env:"MAX_BODY_SIZE,default=0" description:"Maximum size in bytes of a request body in this mode (0 = general limit)"`
+	MaxParts    int   `env:"MAX_PARTS,default=0" description:"Maximum number of parts in a message in this mode (0 = general limit)"`
+	MaxFileSize int64 `env:"MAX_FILE_SIZE,default=0" description:"Maximum decoded size in bytes of a base64 file part in this mode (0 = general limit)"`
+}
+
+// HTTP2Config holds HTTP/2 configuration for the A2A server
+type HTTP2Config struct {
+	Disable              bool          `env:"DISABLE,default=false" description:"Serve only HTTP/1.1, even to TLS clients that negotiate HTTP/2"`
+	Cleartext            bool          `env:"CLEARTEXT,default=false" description:"Also serve HTTP/2 without TLS (h2c), for deployments behind a TLS-terminating proxy"`
+	MaxConcurrentStreams int           `env:"MAX_CONCURRENT_STREAMS,default=250" description:"Maximum concurrent requests per HTTP/2 connection"`
+	PingInterval         time.Duration `env:"PING_INTERVAL,default=0s" description:"Ping HTTP/2 connections idle for this long to keep them alive (0 disables)"`
+	PingTimeout          time.Duration `env:"PING_TIMEOUT,default=15s" description:"Close HTTP/2 connections whose ping is not answered within this time"`
 }
 
 // MetricsConfig holds metrics server configuration
@@ -284,20 +834,27 @@ type LogConfig struct {
 
 // ArtifactsConfig holds artifacts server configuration
 type ArtifactsConfig struct {
-	Enable          bool                    `env:"ENABLE,default=false" description:"Enable artifacts server"`
-	ServerConfig    ArtifactsServerConfig   `env:",prefix=SERVER_" description:"HTTP server configuration for artifacts server"`
-	StorageConfig   ArtifactsStorageConfig  `env:",prefix=STORAGE_" description:"Storage configuration for artifacts"`
-	RetentionConfig ArtifactRetentionConfig `env:",prefix=RETENTION_" description:"Artifact retention and cleanup configuration"`
+	Enable           bool                     `env:"ENABLE,default=false" description:"Enable artifacts server"`
+	ServerConfig     ArtifactsServerConfig    `env:",prefix=SERVER_" description:"HTTP server configuration for artifacts server"`
+	StorageConfig    ArtifactsStorageConfig   `env:",prefix=STORAGE_" description:"Storage configuration for artifacts"`
+	RetentionConfig  ArtifactRetentionConfig  `env:",prefix=RETENTION_" description:"Artifact retention and cleanup configuration"`
+	RenderConfig     ArtifactRenderConfig     `env:",prefix=RENDER_" description:"Rendering of diagrams into image artifacts"`
+	PreviewConfig    ArtifactPreviewConfig    `env:",prefix=PREVIEW_" description:"Inline previews of small artifacts in responses"`
+	TranscriptConfig ArtifactTranscriptConfig `env:",prefix=TRANSCRIPT_" description:"Conversation transcript artifacts of completed tasks"`
 }
 
 // ArtifactsServerConfig holds artifacts HTTP server configuration
 type ArtifactsServerConfig struct {
-	Host         string        `env:"HOST,default=localhost" description:"Artifacts server host"`
-	Port         string        `env:"PORT,default=8081" description:"Artifacts server port"`
-	ReadTimeout  time.Duration `env:"READ_TIMEOUT,default=30s" description:"Artifacts server read timeout"`
-	WriteTimeout time.Duration `env:"WRITE_TIMEOUT,default=30s" description:"Artifacts server write timeout"`
-	IdleTimeout  time.Duration `env:"IDLE_TIMEOUT,default=60s" description:"Artifacts server idle timeout"`
-	TLSConfig    TLSConfig     `env:",prefix=TLS_" description:"TLS configuration for artifacts server"`
+	Host          string        `env:"HOST,default=localhost" description:"Artifacts server host"`
+	Port          string        `env:"PORT,default=8081" description:"Artifacts server port"`
+	ReadTimeout   time.Duration `env:"READ_TIMEOUT,default=30s" description:"Artifacts server read timeout"`
+	WriteTimeout  time.Duration `env:"WRITE_TIMEOUT,default=30s" description:"Artifacts server write timeout"`
+	IdleTimeout   time.Duration `env:"IDLE_TIMEOUT,default=60s" description:"Artifacts server idle timeout"`
+	TLSConfig     TLSConfig     `env:",prefix=TLS_" description:"TLS configuration for artifacts server"`
+	EnableDelete  bool          `env:"ENABLE_DELETE,default=false" description:"Expose DELETE endpoints for removing artifacts"`
+	EnableUpload  bool          `env:"ENABLE_UPLOAD,default=false" description:"Expose the PUT endpoint clients stage large files through and advertise it in the agent card"`
+	MaxUploadSize int64         `env:"MAX_UPLOAD_SIZE,default=104857600" description:"Maximum size in bytes of an uploaded file (0 = unlimited)"`
+	CORS          CORSConfig    `env:",prefix=CORS_"`
 }
 
 // ArtifactsStorageConfig holds storage configuration for artifacts
@@ -312,6 +869,28 @@ type ArtifactsStorageConfig struct {
 	Region      string            `env:"REGION,default=us-east-1" description:"Storage region"`
 	UseSSL      bool              `env:"USE_SSL,default=true" description:"Use SSL for storage connections"`
 	Credentials map[string]string `env:"CREDENTIALS" description:"Additional provider-specific credentials"`
+	Deduplicate bool              `env:"DEDUPLICATE,default=false" description:"Store identical artifact content once and share it across tasks"`
+	MaxFileSize int64             `env:"MAX_FILE_SIZE,default=0" description:"Maximum size in bytes of a file artifact streamed to storage (0 = unlimited)"`
+}
+
+// ArtifactRenderConfig configures the service rendering Mermaid and PlantUML diagrams into images
+type ArtifactRenderConfig struct {
+	DiagramURL string        `env:"DIAGRAM_URL" description:"URL of a Kroki-compatible server rendering Mermaid and PlantUML diagrams (empty disables diagram rendering)"`
+	Timeout    time.Duration `env:"TIMEOUT,default=30s" description:"Timeout of a diagram rendering request"`
+}
+
+// ArtifactPreviewConfig configures attaching the content of small file artifacts inline to the
+// response message, for clients that cannot reach the artifacts server
+type ArtifactPreviewConfig struct {
+	Enable  bool  `env:"ENABLE,default=false" description:"Attach small file artifacts inline to the response message"`
+	MaxSize int64 `env:"MAX_SIZE,default=65536" description:"Maximum size in bytes of a file artifact attached inline"`
+}
+
+// ArtifactTranscriptConfig configures rendering the conversation of completed tasks into a
+// transcript artifact
+type ArtifactTranscriptConfig struct {
+	Enable bool   `env:"ENABLE,default=false" description:"Add a transcript artifact of the conversation to completed tasks"`
+	Format string `env:"FORMAT,default=markdown" description:"Format of the transcript (markdown, html)"`
 }
 
 // ArtifactRetentionConfig defines artifact cleanup policies
@@ -319,6 +898,8 @@ type ArtifactRetentionConfig struct {
 	MaxArtifacts    int           `env:"MAX_ARTIFACTS,default=5" description:"Maximum artifacts to retain per task (0 = unlimited)"`
 	MaxAge          time.Duration `env:"MAX_AGE,default=168h" description:"Maximum age for artifacts (0 = no age limit)"`
 	CleanupInterval time.Duration `env:"CLEANUP_INTERVAL,default=24h" description:"How often to run cleanup (0 = manual cleanup only)"`
+	MaxContextSize  int64         `env:"MAX_CONTEXT_SIZE,default=0" description:"Maximum bytes of artifacts stored per context (0 = unlimited)"`
+	MaxTotalSize    int64         `env:"MAX_TOTAL_SIZE,default=0" description:"Maximum bytes of artifacts stored in total (0 = unlimited)"`
 }
 
 // Load loads configuration from environment variables, merging with the provided base config.
@@ -349,6 +930,53 @@ func LoadWithLookuper(ctx context.Context, baseConfig *Config, lookuper envconfi
 	return &cfg, nil
 }
 
+// secretVariables returns the configuration values that can reference a secret, by variable
+func (c *Config) secretVariables() map[string]*string {
+	return map[string]*string{
+		SecretAgentAPIKey:               &c.AgentConfig.APIKey,
+		SecretAuthClientSecret:          &c.AuthConfig.ClientSecret,
+		SecretSharingSecret:             &c.SharingConfig.Secret,
+		SecretArtifactsStorageAccessKey: &c.ArtifactsConfig.StorageConfig.AccessKey,
+		SecretArtifactsStorageSecretKey: &c.ArtifactsConfig.StorageConfig.SecretKey,
+		SecretEncryptionKey:             &c.EncryptionConfig.Key,
+	}
+}
+
+// ResolveSecrets replaces the values that reference a secret as secret:<name> with the secret
+// returned by resolve, and returns the variables whose value changed. References are
+// remembered, so later calls resolve them again and pick up rotated secrets. No value is
+// changed when a secret cannot be resolved.
+func (c *Config) ResolveSecrets(ctx context.Context, resolve func(ctx context.Context, name string) (string, error)) ([]string, error) {
+	variables := c.secretVariables()
+	if c.secretReferences == nil {
+		c.secretReferences = make(map[string]string)
+	}
+	for variable, value := range variables {
+		if name, ok := strings.CutPrefix(*value, SecretReferencePrefix); ok {
+			c.secretReferences[variable] = name
+		}
+	}
+
+	resolved := make(map[string]string, len(c.secretReferences))
+	for variable, name := range c.secretReferences {
+		secret, err := resolve(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve secret '%s' of %s: %w", name, variable, err)
+		}
+		resolved[variable] = secret
+	}
+
+	var changed []string
+	for variable, secret := range resolved {
+		if *variables[variable] != secret {
+			*variables[variable] = secret
+			changed = append(changed, variable)
+		}
+	}
+	slices.Sort(changed)
+	return changed, nil
+}
+
 // NewWithDefaults creates a new config with defaults applied from struct tags.
 func NewWithDefaults(ctx context.Context, baseConfig *Config) (*Config, error) {
 	return LoadWithLookuper(ctx, baseConfig, &emptyLookuper{})
@@ -371,6 +999,182 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid timezone '%s': %w", c.Timezone, err)
 	}
 
+	if c.ServerConfig.EnableAdminEndpoints {
+		if !c.AuthConfig.Enable {
+			return fmt.Errorf("admin endpoints require authentication to be enabled")
+		}
+		if c.ServerConfig.AdminScope == "" {
+			return fmt.Errorf("admin endpoints require an admin scope")
+		}
+	}
+
+	if c.TaskHistoryConfig.MaxMessages < 0 || c.TaskHistoryConfig.MaxBytes < 0 {
+		return fmt.Errorf("task history limits must not be negative")
+	}
+
+	if c.InputTimeoutConfig.Enabled() {
+		switch c.InputTimeoutConfig.Action {
+		case InputTimeoutActionCancel, InputTimeoutActionFail:
+		default:
+			return fmt.Errorf("invalid input timeout action '%s': must be cancel or fail", c.InputTimeoutConfig.Action)
+		}
+		if c.InputTimeoutConfig.CheckInterval <= 0 {
+			return fmt.Errorf("input timeout check interval must be positive")
+		}
+	}
+
+	if c.ServerConfig.MaxBatchSize < 0 {
+		return fmt.Errorf("max batch size must not be negative")
+	}
+
+	if c.ArtifactsConfig.PreviewConfig.Enable && c.ArtifactsConfig.PreviewConfig.MaxSize <= 0 {
+		return fmt.Errorf("artifact preview max size must be positive")
+	}
+
+	if transcript := c.ArtifactsConfig.TranscriptConfig; transcript.Enable {
+		switch transcript.Format {
+		case "markdown", "html":
+		default:
+			return fmt.Errorf("invalid transcript format '%s': must be markdown or html", transcript.Format)
+		}
+	}
+
+	if c.MemoryConfig.Enable {
+		switch c.MemoryConfig.Scope {
+		case MemoryScopeContext, MemoryScopeUser:
+		default:
+			return fmt.Errorf("invalid memory scope '%s': must be context or user", c.MemoryConfig.Scope)
+		}
+		if c.MemoryConfig.RecallLimit < 0 || c.MemoryConfig.MaxPerOwner < 0 {
+			return fmt.Errorf("memory limits must not be negative")
+		}
+	}
+
+	if c.AgentConfig.ToolBoxConfig.SkillsConfig.Browser.Enable && !chromeBrowserBuilt {
+		return fmt.Errorf("the browse_web tool drives Chrome, which this build does not include: build with -tags chrome")
+	}
+
+	if c.TaskDependencyConfig.CheckInterval < 0 {
+		return fmt.Errorf("task dependency check interval must not be negative")
+	}
+
+	if c.ScheduleConfig.CheckInterval < 0 {
+		return fmt.Errorf("schedule check interval must not be negative")
+	}
+
+	switch c.TaskResumeConfig.TerminalAction {
+	case "", TaskResumeActionReject, TaskResumeActionFollowUp:
+	default:
+		return fmt.Errorf("invalid task resume terminal action '%s': must be reject or follow_up", c.TaskResumeConfig.TerminalAction)
+	}
+
+	switch c.TaskCheckpointConfig.OnRestart {
+	case "", TaskCheckpointRestartResume, TaskCheckpointRestartFail:
+	default:
+		return fmt.Errorf("invalid task checkpoint restart action '%s': must be resume or fail", c.TaskCheckpointConfig.OnRestart)
+	}
+	if c.TaskCheckpointConfig.StaleAfter < 0 || c.TaskCheckpointConfig.RecoveryInterval < 0 {
+		return fmt.Errorf("task checkpoint durations must not be negative")
+	}
+
+	if err := c.ServerConfig.TLSConfig.Validate(); err != nil {
+		return err
+	}
+	if err := c.ArtifactsConfig.ServerConfig.TLSConfig.Validate(); err != nil {
+		return fmt.Errorf("artifacts server: %w", err)
+	}
+
+	validation := c.ServerConfig.Validation
+	switch validation.UnknownFields {
+	case "", ValidationUnknownFieldsIgnore, ValidationUnknownFieldsReject:
+	default:
+		return fmt.Errorf("invalid unknown fields policy '%s': must be ignore or reject", validation.UnknownFields)
+	}
+	switch validation.MissingFields {
+	case "", ValidationMissingFieldsFill, ValidationMissingFieldsReject:
+	default:
+		return fmt.Errorf("invalid missing fields policy '%s': must be fill or reject", validation.MissingFields)
+	}
+
+	if c.SharingConfig.Enable && c.SharingConfig.Secret == "" {
+		return fmt.Errorf("sharing secret is required when task sharing is enabled")
+	}
+
+	switch c.LanguageConfig.Enforcement {
+	case "", LanguageEnforcementNone:
+	case LanguageEnforcementRefuse, LanguageEnforcementTranslate:
+		if len(c.LanguageConfig.Supported) == 0 {
+			return fmt.Errorf("supported languages are required for language enforcement '%s'", c.LanguageConfig.Enforcement)
+		}
+	default:
+		return fmt.Errorf("invalid language enforcement '%s': must be none, refuse or translate", c.LanguageConfig.Enforcement)
+	}
+
+	if c.ModerationConfig.Enable {
+		switch c.ModerationConfig.Provider {
+		case ModerationProviderOpenAI:
+		case ModerationProviderWebhook:
+			if c.ModerationConfig.URL == "" {
+				return fmt.Errorf("moderation url is required for the webhook provider")
+			}
+		default:
+			return fmt.Errorf("invalid moderation provider '%s': must be openai or webhook", c.ModerationConfig.Provider)
+		}
+
+		switch c.ModerationConfig.Policy {
+		case ModerationPolicyFlag, ModerationPolicyFail:
+		default:
+			return fmt.Errorf("invalid moderation policy '%s': must be flag or fail", c.ModerationConfig.Policy)
+		}
+	}
+
+	if c.RedactionConfig.Enable {
+		for _, entity := range c.RedactionConfig.Entities {
+			if !slices.Contains(PIIEntities, entity) {
+				return fmt.Errorf("invalid redaction entity '%s': must be one of %s", entity, strings.Join(PIIEntities, ", "))
+			}
+		}
+	}
+
+	ingestion := c.FileIngestionConfig
+	if ingestion.Enable && ingestion.Thumbnails && ingestion.ThumbnailSize < 1 {
+		return fmt.Errorf("invalid thumbnail size %d: must be at least 1 pixel", ingestion.ThumbnailSize)
+	}
+
+	if c.SpeechConfig.Synthesis {
+		if c.SpeechConfig.SynthesisMediaType() == "" {
+			return fmt.Errorf("invalid speech format '%s': must be mp3, opus, aac, flac or wav", c.SpeechConfig.Format)
+		}
+	}
+
+	switch c.SecretsConfig.Provider {
+	case "", SecretsProviderFile:
+	case SecretsProviderVault:
+		if c.SecretsConfig.VaultAddress == "" {
+			return fmt.Errorf("vault address is required for the vault secrets provider")
+		}
+	default:
+		return fmt.Errorf("invalid secrets provider '%s': must be file or vault", c.SecretsConfig.Provider)
+	}
+
+	if c.EncryptionConfig.Enable && c.EncryptionConfig.Key == "" {
+		return fmt.Errorf("encryption key is required when encryption is enabled")
+	}
+
+	budget := c.AgentConfig.Budget
+	if budget.Enabled() {
+		switch budget.Action {
+		case BudgetActionFail, BudgetActionInputRequired:
+		default:
+			return fmt.Errorf("invalid budget action '%s': must be fail or input_required", budget.Action)
+		}
+
+		costLimited := budget.MaxTaskCost > 0 || budget.MaxContextCost > 0 || budget.MaxDailyCost > 0 || budget.MaxUserCost > 0
+		if costLimited && budget.PromptTokenPrice <= 0 && budget.CompletionTokenPrice <= 0 {
+			return fmt.Errorf("budget cost limits require a prompt or completion token price")
+		}
+	}
+
 	return nil
 }
 
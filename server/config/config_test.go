@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -228,6 +229,133 @@ func TestConfig_LoadWithLookuper_InvalidValues(t *testing.T) {
 			expectError: true,
 			errorText:   "strconv",
 		},
+		{
+			name: "sharing enabled without secret",
+			envVars: map[string]string{
+				"SHARING_ENABLE": "true",
+			},
+			expectError: true,
+			errorText:   "sharing secret is required",
+		},
+		{
+			name: "encryption enabled without key",
+			envVars: map[string]string{
+				"ENCRYPTION_ENABLE": "true",
+			},
+			expectError: true,
+			errorText:   "encryption key is required",
+		},
+		{
+			name: "invalid language enforcement",
+			envVars: map[string]string{
+				"LANGUAGE_ENFORCEMENT": "ignore",
+			},
+			expectError: true,
+			errorText:   "invalid language enforcement",
+		},
+		{
+			name: "language enforcement without supported languages",
+			envVars: map[string]string{
+				"LANGUAGE_ENFORCEMENT": "refuse",
+			},
+			expectError: true,
+			errorText:   "supported languages are required",
+		},
+		{
+			name: "moderation webhook without url",
+			envVars: map[string]string{
+				"MODERATION_ENABLE":   "true",
+				"MODERATION_PROVIDER": "webhook",
+			},
+			expectError: true,
+			errorText:   "moderation url is required",
+		},
+		{
+			name: "invalid moderation policy",
+			envVars: map[string]string{
+				"MODERATION_ENABLE": "true",
+				"MODERATION_POLICY": "warn",
+			},
+			expectError: true,
+			errorText:   "invalid moderation policy",
+		},
+		{
+			name: "invalid redaction entity",
+			envVars: map[string]string{
+				"REDACTION_ENABLE":   "true",
+				"REDACTION_ENTITIES": "email,passport",
+			},
+			expectError: true,
+			errorText:   "invalid redaction entity 'passport'",
+		},
+		{
+			name: "invalid budget action",
+			envVars: map[string]string{
+				"AGENT_CLIENT_BUDGET_MAX_TASK_TOKENS": "1000",
+				"AGENT_CLIENT_BUDGET_ACTION":          "pause",
+			},
+			expectError: true,
+			errorText:   "invalid budget action",
+		},
+		{
+			name: "budget cost limit without token prices",
+			envVars: map[string]string{
+				"AGENT_CLIENT_BUDGET_MAX_DAILY_COST": "5",
+			},
+			expectError: true,
+			errorText:   "budget cost limits require a prompt or completion token price",
+		},
+		{
+			name: "invalid task checkpoint restart action",
+			envVars: map[string]string{
+				"TASK_CHECKPOINT_ON_RESTART": "retry",
+			},
+			expectError: true,
+			errorText:   "invalid task checkpoint restart action 'retry'",
+		},
+		{
+			name: "invalid tls client auth",
+			envVars: map[string]string{
+				"SERVER_TLS_ENABLE":      "true",
+				"SERVER_TLS_CLIENT_AUTH": "always",
+			},
+			expectError: true,
+			errorText:   "invalid tls client auth 'always'",
+		},
+		{
+			name: "verified tls client certificates without client ca",
+			envVars: map[string]string{
+				"ARTIFACTS_SERVER_TLS_ENABLE":      "true",
+				"ARTIFACTS_SERVER_TLS_CLIENT_AUTH": "require_and_verify",
+			},
+			expectError: true,
+			errorText:   "artifacts server: client ca path is required",
+		},
+		{
+			name: "invalid sni certificate",
+			envVars: map[string]string{
+				"SERVER_TLS_ENABLE":           "true",
+				"SERVER_TLS_SNI_CERTIFICATES": "b.crt",
+			},
+			expectError: true,
+			errorText:   "invalid sni certificate 'b.crt'",
+		},
+		{
+			name: "invalid unknown fields policy",
+			envVars: map[string]string{
+				"SERVER_VALIDATION_UNKNOWN_FIELDS": "drop",
+			},
+			expectError: true,
+			errorText:   "invalid unknown fields policy 'drop'",
+		},
+		{
+			name: "invalid missing fields policy",
+			envVars: map[string]string{
+				"SERVER_VALIDATION_MISSING_FIELDS": "ignore",
+			},
+			expectError: true,
+			errorText:   "invalid missing fields policy 'ignore'",
+		},
 	}
 
 	for _, tt := range tests {
@@ -424,3 +552,81 @@ func TestConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_ResolveSecrets(t *testing.T) {
+	cfg, err := config.LoadWithLookuper(context.Background(), nil, envconfig.MapLookuper(map[string]string{
+		"AGENT_CLIENT_API_KEY":         "secret:llm",
+		"ARTIFACTS_STORAGE_ACCESS_KEY": "minio",
+		"ARTIFACTS_STORAGE_SECRET_KEY": "secret:minio",
+		"SECRETS_PROVIDER":             "file",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "/run/secrets", cfg.SecretsConfig.Dir)
+	assert.Equal(t, time.Minute, cfg.SecretsConfig.RefreshInterval)
+
+	secrets := map[string]string{"llm": "sk-1", "minio": "minio-secret"}
+	resolve := func(ctx context.Context, name string) (string, error) {
+		secret, ok := secrets[name]
+		if !ok {
+			return "", fmt.Errorf("secret %s not found", name)
+		}
+		return secret, nil
+	}
+
+	changed, err := cfg.ResolveSecrets(context.Background(), resolve)
+	require.NoError(t, err)
+	assert.Equal(t, []string{config.SecretAgentAPIKey, config.SecretArtifactsStorageSecretKey}, changed)
+	assert.Equal(t, "sk-1", cfg.AgentConfig.APIKey)
+	assert.Equal(t, "minio", cfg.ArtifactsConfig.StorageConfig.AccessKey)
+	assert.Equal(t, "minio-secret", cfg.ArtifactsConfig.StorageConfig.SecretKey)
+
+	changed, err = cfg.ResolveSecrets(context.Background(), resolve)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+
+	secrets["llm"] = "sk-2"
+	delete(secrets, "minio")
+	_, err = cfg.ResolveSecrets(context.Background(), resolve)
+	assert.EqualError(t, err, "failed to resolve secret 'minio' of ARTIFACTS_STORAGE_SECRET_KEY: secret minio not found")
+	assert.Equal(t, "sk-1", cfg.AgentConfig.APIKey, "no secret changes when one cannot be resolved")
+
+	secrets["minio"] = "minio-secret"
+	changed, err = cfg.ResolveSecrets(context.Background(), resolve)
+	require.NoError(t, err)
+	assert.Equal(t, []string{config.SecretAgentAPIKey}, changed)
+	assert.Equal(t, "sk-2", cfg.AgentConfig.APIKey)
+}
+
+func TestConfig_Validate_SecretsProvider(t *testing.T) {
+	_, err := config.LoadWithLookuper(context.Background(), nil, envconfig.MapLookuper(map[string]string{
+		"SECRETS_PROVIDER": "vault",
+	}))
+	assert.ErrorContains(t, err, "vault address is required for the vault secrets provider")
+
+	_, err = config.LoadWithLookuper(context.Background(), nil, envconfig.MapLookuper(map[string]string{
+		"SECRETS_PROVIDER": "aws",
+	}))
+	assert.ErrorContains(t, err, "invalid secrets provider 'aws': must be file or vault")
+}
+
+func TestConfig_Validate_AdminEndpoints(t *testing.T) {
+	_, err := config.LoadWithLookuper(context.Background(), nil, envconfig.MapLookuper(map[string]string{
+		"SERVER_ENABLE_ADMIN_ENDPOINTS": "true",
+		"SERVER_ADMIN_SCOPE":            "a2a:admin",
+	}))
+	assert.EqualError(t, err, "admin endpoints require authentication to be enabled")
+
+	_, err = config.LoadWithLookuper(context.Background(), nil, envconfig.MapLookuper(map[string]string{
+		"SERVER_ENABLE_ADMIN_ENDPOINTS": "true",
+		"AUTH_ENABLE":                   "true",
+	}))
+	assert.EqualError(t, err, "admin endpoints require an admin scope")
+
+	cfg, err := config.LoadWithLookuper(context.Background(), nil, envconfig.MapLookuper(map[string]string{
+		"SERVER_ENABLE_ADMIN_ENDPOINTS": "true",
+		"SERVER_ADMIN_SCOPE":            "a2a:admin",
+		"AUTH_ENABLE":                   "true",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "a2a:admin", cfg.ServerConfig.AdminScope)
+}
@@ -0,0 +1,337 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// SetArtifactService sets the artifact service holding the files stored for contexts, which
+// contexts/delete removes along with the context
+func (h *DefaultA2AProtocolHandler) SetArtifactService(service ArtifactService) {
+	h.artifactService = service
+}
+
+// HandleContextCreate processes contexts/create requests
+func (h *DefaultA2AProtocolHandler) HandleContextCreate(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	var params types.ContextCreateParams
+	paramsBytes, err := json.Marshal(req.Params)
+	if err != nil {
+		logger.Error("failed to marshal params", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
+		return
+	}
+
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		logger.Error("failed to parse contexts/create request", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
+		return
+	}
+
+	store, ok := h.storage.(ContextStore)
+	if !ok {
+		h.responseSender.SendError(c, req.ID, int(ErrServerError), "storage does not support contexts")
+		return
+	}
+
+	contextID := params.ContextID
+	if contextID == "" {
+		contextID = h.ids.NewID()
+	}
+	existing, err := h.conversationContext(c.Request.Context(), store, contextID)
+	if err != nil {
+		logger.Error("failed to look up context", zap.String("context_id", contextID), zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to create context")
+		return
+	}
+	if existing != nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), fmt.Sprintf("context '%s' already exists", contextID))
+		return
+	}
+
+	record := &types.ConversationContext{
+		ContextID: contextID,
+		CreatedAt: h.clock.Now().UTC().Format(time.RFC3339Nano),
+		Memory:    params.Memory,
+		Metadata:  params.Metadata,
+		TaskIDs:   []string{},
+	}
+	if userID := middlewares.UserIDFromContext(c.Request.Context()); userID != "" {
+		metadata := make(map[string]any, len(record.Metadata)+1)
+		for key, value := range record.Metadata {
+			metadata[key] = value
+		}
+		metadata[types.UserIDMetadataKey] = userID
+		record.Metadata = metadata
+	}
+	if err := store.SaveContextRecord(c.Request.Context(), record); err != nil {
+		logger.Error("failed to store context", zap.String("context_id", contextID), zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to create context")
+		return
+	}
+
+	logger.Info("context created", zap.String("context_id", contextID))
+	h.responseSender.SendSuccess(c, req.ID, record)
+}
+
+// HandleContextGet processes contexts/get requests
+func (h *DefaultA2AProtocolHandler) HandleContextGet(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	var params types.ContextIdParams
+	paramsBytes, err := json.Marshal(req.Params)
+	if err != nil {
+		logger.Error("failed to marshal params", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
+		return
+	}
+
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		logger.Error("failed to parse contexts/get request", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
+		return
+	}
+
+	if params.ContextID == "" {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "context id is required")
+		return
+	}
+
+	store, _ := h.storage.(ContextStore)
+	conversation, err := h.conversationContext(c.Request.Context(), store, params.ContextID)
+	if err != nil {
+		logger.Error("failed to get context", zap.String("context_id", params.ContextID), zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to get context")
+		return
+	}
+	if conversation == nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "context not found")
+		return
+	}
+
+	h.responseSender.SendSuccess(c, req.ID, conversation)
+}
+
+// HandleContextList processes contexts/list requests. Contexts created implicitly by
+// messages are listed along with the contexts created with contexts/create; callers identified
+// as a user only list their own contexts, and other callers the contexts of no user.
+func (h *DefaultA2AProtocolHandler) HandleContextList(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	var params types.ContextListParams
+	paramsBytes, err := json.Marshal(req.Params)
+	if err != nil {
+		logger.Error("failed to marshal params", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
+		return
+	}
+
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		logger.Error("failed to parse contexts/list request", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
+		return
+	}
+
+	if params.Limit < 0 || params.Offset < 0 {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "limit and offset must not be negative")
+		return
+	}
+
+	userID := middlewares.UserIDFromContext(c.Request.Context())
+	contextIDs := make(map[string]bool)
+	tasks, err := h.storage.ListTasks(TaskFilter{UserID: &userID})
+	if err != nil {
+		logger.Error("failed to list tasks", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to list contexts")
+		return
+	}
+	for _, task := range tasks {
+		contextIDs[task.ContextID] = true
+	}
+	if store, ok := h.storage.(ContextStore); ok {
+		records, err := store.ListContextRecords(c.Request.Context())
+		if err != nil {
+			logger.Error("failed to list contexts", zap.Error(err))
+			h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to list contexts")
+			return
+		}
+		for _, record := range records {
+			if contextRecordUserID(record) == userID {
+				contextIDs[record.ContextID] = true
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(contextIDs))
+	for contextID := range contextIDs {
+		ids = append(ids, contextID)
+	}
+	sort.Strings(ids)
+
+	total := len(ids)
+	start := min(params.Offset, total)
+	end := total
+	if params.Limit > 0 {
+		end = min(start+params.Limit, total)
+	}
+
+	store, _ := h.storage.(ContextStore)
+	contexts := make([]types.ConversationContext, 0, end-start)
+	for _, contextID := range ids[start:end] {
+		conversation, err := h.conversationContext(c.Request.Context(), store, contextID)
+		if err != nil {
+			logger.Error("failed to get context", zap.String("context_id", contextID), zap.Error(err))
+			h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to list contexts")
+			return
+		}
+		if conversation != nil {
+			contexts = append(contexts, *conversation)
+		}
+	}
+
+	logger.Info("contexts listed", zap.Int("count", len(contexts)), zap.Int("total", total))
+	h.responseSender.SendSuccess(c, req.ID, types.ContextList{Contexts: contexts, TotalSize: total})
+}
+
+// HandleContextDelete processes contexts/delete requests. Running tasks of the context are
+// canceled, then its tasks, its stored artifacts and its record are deleted.
+func (h *DefaultA2AProtocolHandler) HandleContextDelete(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	var params types.ContextIdParams
+	paramsBytes, err := json.Marshal(req.Params)
+	if err != nil {
+		logger.Error("failed to marshal params", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
+		return
+	}
+
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		logger.Error("failed to parse contexts/delete request", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
+		return
+	}
+
+	if params.ContextID == "" {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "context id is required")
+		return
+	}
+
+	ctx := c.Request.Context()
+	store, _ := h.storage.(ContextStore)
+	conversation, err := h.conversationContext(ctx, store, params.ContextID)
+	if err != nil {
+		logger.Error("failed to get context", zap.String("context_id", params.ContextID), zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to delete context")
+		return
+	}
+	if conversation == nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "context not found")
+		return
+	}
+
+	result, err := h.deleteContext(ctx, store, conversation)
+	if err != nil {
+		logger.Error("failed to delete context", zap.String("context_id", params.ContextID), zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to delete context")
+		return
+	}
+
+	logger.Info("context deleted",
+		zap.String("context_id", params.ContextID),
+		zap.Int("tasks_canceled", result.TasksCanceled),
+		zap.Int("tasks_deleted", result.TasksDeleted),
+		zap.Int("artifacts_deleted", result.ArtifactsDeleted))
+	h.responseSender.SendSuccess(c, req.ID, result)
+}
+
+// conversationContext returns a context with its record and tasks, or nil when the context
+// has neither. The store is nil when the storage does not persist context records.
+func (h *DefaultA2AProtocolHandler) conversationContext(ctx context.Context, store ContextStore, contextID string) (*types.ConversationContext, error) {
+	conversation := &types.ConversationContext{ContextID: contextID}
+	found := false
+	if store != nil {
+		record, exists, err := store.GetContextRecord(ctx, contextID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			conversation = record
+			found = true
+		}
+	}
+
+	tasks, err := h.storage.ListTasks(TaskFilter{
+		ContextID: &contextID,
+		SortBy:    TaskSortFieldCreatedAt,
+		SortOrder: SortOrderAsc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	if !found && len(tasks) == 0 {
+		return nil, nil
+	}
+
+	conversation.TaskIDs = make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		conversation.TaskIDs = append(conversation.TaskIDs, task.ID)
+	}
+	conversation.TaskCount = len(tasks)
+	return conversation, nil
+}
+
+// deleteContext cancels the running tasks of a context and deletes its tasks, its stored
+// artifacts and its record
+func (h *DefaultA2AProtocolHandler) deleteContext(ctx context.Context, store ContextStore, conversation *types.ConversationContext) (*types.ContextDeleteResult, error) {
+	result := &types.ContextDeleteResult{ContextID: conversation.ContextID}
+
+	for _, taskID := range conversation.TaskIDs {
+		task, exists := h.taskManager.GetTask(taskID)
+		if !exists || isTerminalTaskState(task.Status.State) {
+			continue
+		}
+		if err := h.taskManager.CancelTask(taskID); err != nil {
+			h.logger.Warn("failed to cancel task of deleted context",
+				zap.String("context_id", conversation.ContextID),
+				zap.String("task_id", taskID),
+				zap.Error(err))
+			continue
+		}
+		result.TasksCanceled++
+	}
+
+	if err := h.storage.DeleteContextAndTasks(conversation.ContextID); err != nil {
+		return nil, fmt.Errorf("failed to delete tasks: %w", err)
+	}
+	result.TasksDeleted = len(conversation.TaskIDs)
+
+	if h.artifactService != nil {
+		artifacts, err := h.artifactService.ListStoredArtifacts(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list artifacts: %w", err)
+		}
+		for _, artifact := range artifacts {
+			if artifact.ContextID != conversation.ContextID {
+				continue
+			}
+			if err := h.artifactService.Delete(ctx, artifact.ContextID, artifact.ArtifactID, artifact.Filename); err != nil {
+				return nil, fmt.Errorf("failed to delete artifact %s: %w", artifact.ArtifactID, err)
+			}
+			result.ArtifactsDeleted++
+		}
+	}
+
+	if store != nil {
+		if err := store.DeleteContextRecord(ctx, conversation.ContextID); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
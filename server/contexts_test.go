@@ -0,0 +1,94 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestA2AServer_ContextLifecycle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "assistant"})
+	router := s.setupRouter(cfg)
+
+	call := func(method string, params any, result any) *types.JSONRPCError {
+		encoded, err := json.Marshal(params)
+		require.NoError(t, err)
+		body := `{"jsonrpc":"2.0","id":"1","method":"` + method + `","params":` + string(encoded) + `}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+		var response struct {
+			Result json.RawMessage     `json:"result"`
+			Error  *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		if response.Error == nil && result != nil {
+			require.NoError(t, json.Unmarshal(response.Result, result))
+		}
+		return response.Error
+	}
+
+	var created types.ConversationContext
+	require.Nil(t, call("contexts/create", types.ContextCreateParams{
+		ContextID: "ctx-1",
+		Metadata:  map[string]any{"customer": "acme"},
+		Memory:    map[string]any{"plan": "pro"},
+	}, &created))
+	assert.Equal(t, "ctx-1", created.ContextID)
+	assert.NotEmpty(t, created.CreatedAt)
+	assert.Equal(t, 0, created.TaskCount)
+
+	rpcErr := call("contexts/create", types.ContextCreateParams{ContextID: "ctx-1"}, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, "context 'ctx-1' already exists", rpcErr.Message)
+
+	running := s.taskManager.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+	done := s.taskManager.CreateTask("ctx-1", types.TaskStateCompleted, &types.Message{MessageID: "m-2", Role: types.RoleUser})
+	require.NoError(t, s.storage.StoreDeadLetterTask(done))
+	implicit := s.taskManager.CreateTask("ctx-2", types.TaskStateCompleted, &types.Message{MessageID: "m-3", Role: types.RoleUser})
+	require.NoError(t, s.storage.StoreDeadLetterTask(implicit))
+
+	var fetched types.ConversationContext
+	require.Nil(t, call("contexts/get", types.ContextIdParams{ContextID: "ctx-1"}, &fetched))
+	assert.Equal(t, map[string]any{"plan": "pro"}, fetched.Memory)
+	assert.Equal(t, map[string]any{"customer": "acme"}, fetched.Metadata)
+	assert.ElementsMatch(t, []string{running.ID, done.ID}, fetched.TaskIDs)
+	assert.Equal(t, 2, fetched.TaskCount)
+
+	var list types.ContextList
+	require.Nil(t, call("contexts/list", types.ContextListParams{}, &list))
+	assert.Equal(t, 2, list.TotalSize)
+	require.Len(t, list.Contexts, 2)
+	assert.Equal(t, "ctx-1", list.Contexts[0].ContextID)
+	assert.Equal(t, "ctx-2", list.Contexts[1].ContextID, "contexts created by messages are listed")
+
+	require.Nil(t, call("contexts/list", types.ContextListParams{Limit: 1, Offset: 1}, &list))
+	require.Len(t, list.Contexts, 1)
+	assert.Equal(t, "ctx-2", list.Contexts[0].ContextID)
+
+	var deleted types.ContextDeleteResult
+	require.Nil(t, call("contexts/delete", types.ContextIdParams{ContextID: "ctx-1"}, &deleted))
+	assert.Equal(t, types.ContextDeleteResult{ContextID: "ctx-1", TasksCanceled: 1, TasksDeleted: 2}, deleted)
+
+	_, exists := s.taskManager.GetTask(running.ID)
+	assert.False(t, exists, "the tasks of a deleted context are deleted")
+	rpcErr = call("contexts/get", types.ContextIdParams{ContextID: "ctx-1"}, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, int(ErrInvalidParams), rpcErr.Code)
+	assert.Equal(t, "context not found", rpcErr.Message)
+
+	require.Nil(t, call("contexts/list", types.ContextListParams{}, &list))
+	assert.Equal(t, 1, list.TotalSize)
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"sort"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// BuildConversationThread derives a parent/child tree from the tasks of a single context.
+// A message belongs to the task with the shortest history containing it, and a task's
+// parent is the owner of the last message it inherited from another task.
+func BuildConversationThread(contextID string, tasks []*types.Task) *types.ConversationThread {
+	ordered := make([]*types.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task != nil && task.ContextID == contextID {
+			ordered = append(ordered, task)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if len(ordered[i].History) != len(ordered[j].History) {
+			return len(ordered[i].History) < len(ordered[j].History)
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	owners := make(map[string]string)
+	for _, task := range ordered {
+		for _, message := range task.History {
+			if message.MessageID == "" {
+				continue
+			}
+			if _, owned := owners[message.MessageID]; !owned {
+				owners[message.MessageID] = task.ID
+			}
+		}
+	}
+
+	thread := &types.ConversationThread{
+		ContextID:   contextID,
+		Nodes:       make([]types.ThreadNode, 0, len(ordered)),
+		RootTaskIDs: []string{},
+	}
+	nodeIndex := make(map[string]int, len(ordered))
+
+	for _, task := range ordered {
+		node := types.ThreadNode{
+			TaskID:   task.ID,
+			State:    task.Status.State,
+			Messages: []types.Message{},
+		}
+
+		for _, message := range task.History {
+			owner, known := owners[message.MessageID]
+			if known && owner != task.ID {
+				parentID := owner
+				node.ParentTaskID = &parentID
+				continue
+			}
+			node.Messages = append(node.Messages, message)
+			node.ReferenceTaskIDs = appendUnique(node.ReferenceTaskIDs, message.ReferenceTaskIds...)
+		}
+
+		nodeIndex[task.ID] = len(thread.Nodes)
+		thread.Nodes = append(thread.Nodes, node)
+
+		if node.ParentTaskID == nil {
+			thread.RootTaskIDs = append(thread.RootTaskIDs, task.ID)
+			continue
+		}
+		if parent, exists := nodeIndex[*node.ParentTaskID]; exists {
+			thread.Nodes[parent].ChildTaskIDs = append(thread.Nodes[parent].ChildTaskIDs, task.ID)
+		}
+	}
+
+	return thread
+}
+
+// appendUnique appends values that are not already present in the slice
+func appendUnique(values []string, candidates ...string) []string {
+	for _, candidate := range candidates {
+		found := false
+		for _, value := range values {
+			if value == candidate {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, candidate)
+		}
+	}
+	return values
+}
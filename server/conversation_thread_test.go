@@ -0,0 +1,128 @@
+package server_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func threadMessage(id string, role types.Role, refs ...string) types.Message {
+	return types.Message{
+		MessageID:        id,
+		Role:             role,
+		Parts:            []types.Part{types.CreateTextPart(id)},
+		ReferenceTaskIds: refs,
+	}
+}
+
+func TestBuildConversationThread(t *testing.T) {
+	m1 := threadMessage("m1", types.RoleUser)
+	a1 := threadMessage("a1", types.RoleAgent)
+	m2 := threadMessage("m2", types.RoleUser)
+	a2 := threadMessage("a2", types.RoleAgent)
+	m3 := threadMessage("m3", types.RoleUser, "task-1")
+	m4 := threadMessage("m4", types.RoleUser)
+
+	tests := []struct {
+		name       string
+		tasks      []*types.Task
+		assertions func(t *testing.T, thread *types.ConversationThread)
+	}{
+		{
+			name:  "empty context",
+			tasks: nil,
+			assertions: func(t *testing.T, thread *types.ConversationThread) {
+				assert.Empty(t, thread.Nodes)
+				assert.Empty(t, thread.RootTaskIDs)
+			},
+		},
+		{
+			name: "linear follow-up and fork",
+			tasks: []*types.Task{
+				{ID: "task-3", ContextID: "ctx", History: []types.Message{m1, a1, m3}},
+				{ID: "task-2", ContextID: "ctx", History: []types.Message{m1, a1, m2, a2}},
+				{ID: "task-1", ContextID: "ctx", History: []types.Message{m1, a1}},
+				{ID: "other", ContextID: "other-ctx", History: []types.Message{m1}},
+			},
+			assertions: func(t *testing.T, thread *types.ConversationThread) {
+				require.Len(t, thread.Nodes, 3)
+				assert.Equal(t, []string{"task-1"}, thread.RootTaskIDs)
+
+				root := thread.Nodes[0]
+				assert.Equal(t, "task-1", root.TaskID)
+				assert.Nil(t, root.ParentTaskID)
+				assert.Equal(t, []string{"task-3", "task-2"}, root.ChildTaskIDs)
+				assert.Len(t, root.Messages, 2)
+
+				for _, node := range thread.Nodes[1:] {
+					require.NotNil(t, node.ParentTaskID)
+					assert.Equal(t, "task-1", *node.ParentTaskID)
+				}
+
+				fork := thread.Nodes[1]
+				assert.Equal(t, "task-3", fork.TaskID)
+				assert.Equal(t, []string{"task-1"}, fork.ReferenceTaskIDs)
+				require.Len(t, fork.Messages, 1)
+				assert.Equal(t, "m3", fork.Messages[0].MessageID)
+			},
+		},
+		{
+			name: "independent tasks are separate roots",
+			tasks: []*types.Task{
+				{ID: "task-a", ContextID: "ctx", History: []types.Message{m1}},
+				{ID: "task-b", ContextID: "ctx", History: []types.Message{m4}},
+			},
+			assertions: func(t *testing.T, thread *types.ConversationThread) {
+				assert.Equal(t, []string{"task-a", "task-b"}, thread.RootTaskIDs)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thread := server.BuildConversationThread("ctx", tt.tasks)
+			require.NotNil(t, thread)
+			assert.Equal(t, "ctx", thread.ContextID)
+			tt.assertions(t, thread)
+		})
+	}
+}
+
+func TestProtocolHandler_HandleTaskGet_IncludeThread(t *testing.T) {
+	h, storage, taskManager, _ := makeProtocolHandlerWithMocks(t)
+
+	m1 := threadMessage("m1", types.RoleUser)
+	m2 := threadMessage("m2", types.RoleUser)
+	parent := &types.Task{ID: "task-1", ContextID: "ctx", History: []types.Message{m1}}
+	child := &types.Task{ID: "task-2", ContextID: "ctx", History: []types.Message{m1, m2}}
+	taskManager.GetTaskReturns(child, true)
+	storage.ListTasksReturns([]*types.Task{parent, child}, nil)
+
+	c, w := newRequestContext(t, "{}")
+	reqID := any("req-1")
+	h.HandleTaskGet(c, types.JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      &reqID,
+		Method:  "tasks/get",
+		Params:  map[string]any{"id": "task-2", "includeThread": true},
+	})
+
+	var response struct {
+		Result types.TaskWithThread `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "task-2", response.Result.ID)
+	require.NotNil(t, response.Result.Thread)
+	assert.Equal(t, []string{"task-1"}, response.Result.Thread.RootTaskIDs)
+	require.Len(t, response.Result.Thread.Nodes, 2)
+	assert.Equal(t, []string{"task-2"}, response.Result.Thread.Nodes[0].ChildTaskIDs)
+
+	filter := storage.ListTasksArgsForCall(0)
+	require.NotNil(t, filter.ContextID)
+	assert.Equal(t, "ctx", *filter.ContextID)
+}
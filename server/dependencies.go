@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	uuid "github.com/google/uuid"
+)
+
+// IDGenerator generates identifiers for tasks, contexts and messages
+type IDGenerator interface {
+	NewID() string
+}
+
+// UUIDGenerator generates random UUIDv4 identifiers
+type UUIDGenerator struct{}
+
+var _ IDGenerator = UUIDGenerator{}
+
+// NewID returns a new random UUID
+func (UUIDGenerator) NewID() string {
+	return uuid.New().String()
+}
+
+// Clock provides the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock reads the current time from the system clock
+type SystemClock struct{}
+
+var _ Clock = SystemClock{}
+
+// Now returns the current system time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// HTTPDoer sends HTTP requests, it is satisfied by *http.Client
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+var _ HTTPDoer = (*http.Client)(nil)
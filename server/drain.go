@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// defaultDrainTimeout bounds how long in-flight streams may finish on shutdown when no
+// drain timeout is configured
+const defaultDrainTimeout = 5 * time.Second
+
+// streamDrain tracks in-flight streams, and background tasks when task handoff is enabled,
+// so that stopping the server lets them finish instead of cutting them off. Once draining
+// starts no new work is accepted; work still running when the drain timeout elapses is
+// told to checkpoint and end.
+type streamDrain struct {
+	mu       sync.Mutex
+	active   sync.WaitGroup
+	draining bool
+
+	started   chan struct{}
+	startOnce sync.Once
+
+	expired    chan struct{}
+	expireOnce sync.Once
+}
+
+// newStreamDrain creates a stream drain accepting new streams
+func newStreamDrain() *streamDrain {
+	return &streamDrain{
+		started: make(chan struct{}),
+		expired: make(chan struct{}),
+	}
+}
+
+// acquire registers a new stream, returning false once draining has started. Every
+// successful acquire must be paired with a release.
+func (d *streamDrain) acquire() bool {
+	if d == nil {
+		return true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return false
+	}
+	d.active.Add(1)
+	return true
+}
+
+// release marks a stream registered with acquire as finished
+func (d *streamDrain) release() {
+	if d == nil {
+		return
+	}
+	d.active.Done()
+}
+
+// isDraining reports whether the server has started draining
+func (d *streamDrain) isDraining() bool {
+	if d == nil {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+// drainingChan returns a channel that is closed when draining starts
+func (d *streamDrain) drainingChan() <-chan struct{} {
+	if d == nil {
+		return nil
+	}
+	return d.started
+}
+
+// expiredChan returns a channel that is closed when in-flight streams must checkpoint and end
+func (d *streamDrain) expiredChan() <-chan struct{} {
+	if d == nil {
+		return nil
+	}
+	return d.expired
+}
+
+// drain stops accepting new streams and waits up to timeout for in-flight streams to
+// finish. Streams still running afterwards are told to checkpoint, and drain waits for
+// them to end until ctx is done. It reports whether every stream finished on its own.
+func (d *streamDrain) drain(ctx context.Context, timeout time.Duration) bool {
+	d.mu.Lock()
+	d.draining = true
+	d.mu.Unlock()
+	d.startOnce.Do(func() { close(d.started) })
+
+	finished := make(chan struct{})
+	go func() {
+		d.active.Wait()
+		close(finished)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-finished:
+		return true
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	d.expireOnce.Do(func() { close(d.expired) })
+
+	select {
+	case <-finished:
+	case <-ctx.Done():
+	}
+	return false
+}
+
+// rejectDuringDrain refuses requests that would start new work while the server drains,
+// so clients retry against another instance. It reports whether the request was rejected.
+func (s *A2AServerImpl) rejectDuringDrain(c *gin.Context, req types.JSONRPCRequest) bool {
+	if !s.drain.isDraining() {
+		return false
+	}
+
+	switch req.Method {
+	case "message/send", "message/sendBatch", "message/stream", "tasks/resubscribe":
+	default:
+		return false
+	}
+
+	requestLogger(c.Request.Context(), s.logger).Info("rejecting request while draining", zap.String("method", req.Method))
+	c.Header("Connection", "close")
+	s.responseSender.SendError(c, req.ID, int(ErrServerError), "server is shutting down")
+	return true
+}
+
+// checkpointStream ends a stream that is still running when the drain timeout elapses. The
+// task is saved and queued again for background processing, and the client receives a final
+// status event so it can follow the task with tasks/get or tasks/resubscribe.
+func (h *DefaultA2AProtocolHandler) checkpointStream(c *gin.Context, requestID any, task *types.Task, message *types.Message) {
+	task.Status.State = types.TaskStateSubmitted
+	task.Status.Message = message
+	recordStateTransition(h.taskManager, task, types.TaskActorServer)
+
+	if err := h.taskManager.UpdateTask(task); err != nil {
+		h.logger.Error("failed to checkpoint streaming task",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+	}
+	if err := h.storage.EnqueueTask(context.WithoutCancel(c.Request.Context()), task, requestID); err != nil {
+		h.logger.Error("failed to requeue checkpointed streaming task",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+	}
+
+	h.logger.Info("streaming task checkpointed for shutdown",
+		zap.String("task_id", task.ID),
+		zap.String("context_id", task.ContextID))
+
+	metadata := types.Struct{types.StreamEndReasonMetadataKey: types.StreamEndReasonShutdown}
+	statusResponse := types.JSONRPCSuccessResponse{
+		JSONRPC: "2.0",
+		ID:      requestID,
+		Result: types.TaskStatusUpdateEvent{
+			TaskID:    task.ID,
+			ContextID: task.ContextID,
+			Status:    task.Status,
+			Final:     true,
+			Metadata:  &metadata,
+		},
+	}
+	if err := h.writeStreamingResponse(c, &statusResponse); err != nil {
+		h.logger.Error("failed to write shutdown status", zap.Error(err))
+		return
+	}
+
+	if err := writeSSEDone(c.Writer); err != nil {
+		h.logger.Error("failed to write stream termination signal", zap.Error(err))
+	}
+}
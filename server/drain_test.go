@@ -0,0 +1,217 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	client "github.com/inference-gateway/adk/client"
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// scriptedStreamHandler streams one delta and then finishes after the configured delay, or
+// when its context is cancelled
+type scriptedStreamHandler struct {
+	finishAfter time.Duration
+}
+
+func (h *scriptedStreamHandler) HandleStreamingTask(ctx context.Context, task *types.Task, message *types.Message) (<-chan cloudevents.Event, error) {
+	events := make(chan cloudevents.Event, 1)
+	go func() {
+		defer close(events)
+		events <- types.NewDeltaEvent(&types.Message{
+			MessageID: "delta-1",
+			Role:      types.RoleAgent,
+			Parts:     []types.Part{types.CreateTextPart("working")},
+		})
+		select {
+		case <-time.After(h.finishAfter):
+		case <-ctx.Done():
+		}
+	}()
+	return events, nil
+}
+
+func (h *scriptedStreamHandler) SetAgent(OpenAICompatibleAgent) {}
+
+func (h *scriptedStreamHandler) GetAgent() OpenAICompatibleAgent { return nil }
+
+func newDrainTestServer(t *testing.T, drainTimeout, finishAfter time.Duration) (*A2AServerImpl, *httptest.Server) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		CapabilitiesConfig: config.CapabilitiesConfig{Streaming: true},
+		ServerConfig:       config.ServerConfig{DrainTimeout: drainTimeout},
+	}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "agent"})
+	s.SetStreamingTaskHandler(&scriptedStreamHandler{finishAfter: finishAfter})
+
+	httpServer := httptest.NewServer(s.setupRouter(cfg))
+	t.Cleanup(httpServer.Close)
+	return s, httpServer
+}
+
+func openDrainTestStream(t *testing.T, httpServer *httptest.Server) *client.TaskStream {
+	t.Helper()
+	stream, err := client.NewClient(httpServer.URL).OpenTaskStream(context.Background(), types.MessageSendParams{
+		Message: types.Message{
+			MessageID: "m1",
+			Role:      types.RoleUser,
+			Parts:     []types.Part{types.CreateTextPart("hello")},
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = stream.Close() })
+
+	select {
+	case _, ok := <-stream.Events():
+		require.True(t, ok)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+	}
+	return stream
+}
+
+func collectStatusEvents(t *testing.T, stream *client.TaskStream) []types.TaskStatusUpdateEvent {
+	t.Helper()
+	var statuses []types.TaskStatusUpdateEvent
+	for event := range stream.Events() {
+		payload, err := json.Marshal(event.Result)
+		require.NoError(t, err)
+		var status types.TaskStatusUpdateEvent
+		if json.Unmarshal(payload, &status) == nil && status.TaskID != "" && status.Status.State != "" {
+			statuses = append(statuses, status)
+		}
+	}
+	require.NoError(t, stream.Err())
+	return statuses
+}
+
+func TestA2AServer_StopWaitsForStreams(t *testing.T) {
+	s, httpServer := newDrainTestServer(t, 2*time.Second, 100*time.Millisecond)
+	stream := openDrainTestStream(t, httpServer)
+
+	started := time.Now()
+	require.NoError(t, s.Stop(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(started), 50*time.Millisecond)
+
+	select {
+	case <-stream.Done():
+	case <-time.After(time.Second):
+		t.Fatal("in-flight stream did not end")
+	}
+	require.NoError(t, stream.Err())
+	assert.Zero(t, s.storage.GetQueueLength())
+}
+
+func TestA2AServer_StopCheckpointsStreams(t *testing.T) {
+	s, httpServer := newDrainTestServer(t, 50*time.Millisecond, time.Minute)
+	stream := openDrainTestStream(t, httpServer)
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.Stop(context.Background()) }()
+
+	statuses := collectStatusEvents(t, stream)
+	require.NotEmpty(t, statuses)
+	final := statuses[len(statuses)-1]
+	assert.True(t, final.Final)
+	assert.Equal(t, types.TaskStateSubmitted, final.Status.State)
+	require.NotNil(t, final.Metadata)
+	assert.Equal(t, types.StreamEndReasonShutdown, (*final.Metadata)[types.StreamEndReasonMetadataKey])
+
+	select {
+	case err := <-stopped:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("stop did not return")
+	}
+
+	assert.Equal(t, 1, s.storage.GetQueueLength())
+	task, exists := s.taskManager.GetTask(final.TaskID)
+	require.True(t, exists)
+	assert.Equal(t, types.TaskStateSubmitted, task.Status.State)
+}
+
+func TestA2AServer_RejectsNewWorkWhileDraining(t *testing.T) {
+	s, httpServer := newDrainTestServer(t, time.Second, 300*time.Millisecond)
+	openDrainTestStream(t, httpServer)
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.Stop(context.Background()) }()
+	require.Eventually(t, s.drain.isDraining, time.Second, 5*time.Millisecond)
+
+	resp, err := http.Get(httpServer.URL + "/health")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	tests := []struct {
+		method   string
+		rejected bool
+	}{
+		{method: "message/send", rejected: true},
+		{method: "tasks/resubscribe", rejected: true},
+		{method: "tasks/list", rejected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			body := `{"jsonrpc":"2.0","id":"1","method":"` + tt.method + `","params":{"name":"task-1","message":{"messageId":"m2","role":"user","parts":[{"text":"hi"}]}}}`
+			resp, err := http.Post(httpServer.URL+"/a2a", "application/json", strings.NewReader(body))
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			var response struct {
+				Error *types.JSONRPCError `json:"error"`
+			}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+			if tt.rejected {
+				require.NotNil(t, response.Error)
+				assert.Equal(t, int(ErrServerError), response.Error.Code)
+				assert.Equal(t, "server is shutting down", response.Error.Message)
+			} else if response.Error != nil {
+				assert.NotEqual(t, "server is shutting down", response.Error.Message)
+			}
+		})
+	}
+
+	require.NoError(t, <-stopped)
+}
+
+func TestNewHTTPServer(t *testing.T) {
+	cfg := config.ServerConfig{
+		Port:        "8080",
+		IdleTimeout: time.Minute,
+		HTTP2Config: config.HTTP2Config{
+			Cleartext:            true,
+			MaxConcurrentStreams: 100,
+			PingInterval:         30 * time.Second,
+			PingTimeout:          5 * time.Second,
+		},
+	}
+
+	httpServer := newHTTPServer(cfg, http.NotFoundHandler())
+	assert.Equal(t, ":8080", httpServer.Addr)
+	assert.True(t, httpServer.Protocols.HTTP1())
+	assert.True(t, httpServer.Protocols.HTTP2())
+	assert.True(t, httpServer.Protocols.UnencryptedHTTP2())
+	assert.Equal(t, 100, httpServer.HTTP2.MaxConcurrentStreams)
+	assert.Equal(t, 30*time.Second, httpServer.HTTP2.SendPingTimeout)
+	assert.Equal(t, 5*time.Second, httpServer.HTTP2.PingTimeout)
+
+	cfg.HTTP2Config.Disable = true
+	httpServer = newHTTPServer(cfg, http.NotFoundHandler())
+	assert.False(t, httpServer.Protocols.HTTP2())
+	assert.False(t, httpServer.Protocols.UnencryptedHTTP2())
+}
@@ -0,0 +1,278 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	config "github.com/inference-gateway/adk/server/config"
+	zap "go.uber.org/zap"
+	rate "golang.org/x/time/rate"
+)
+
+const (
+	defaultEmbeddingsBaseURL   = "http://localhost:8080/v1"
+	defaultEmbeddingsBatchSize = 100
+)
+
+// EmbeddingsClient turns texts into embedding vectors using an embedding model
+type EmbeddingsClient interface {
+	// Embed returns one embedding per text in the same order
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+var (
+	_ EmbeddingsClient = (*OpenAICompatibleEmbeddingsClient)(nil)
+	_ Embedder         = (*OpenAICompatibleEmbeddingsClient)(nil)
+)
+
+// OpenAICompatibleEmbeddingsClient implements EmbeddingsClient against an OpenAI-compatible
+// /embeddings endpoint, such as the Inference Gateway's. Texts are sent in batches and
+// requests are rate limited as configured, so it can embed whole documents in one call.
+type OpenAICompatibleEmbeddingsClient struct {
+	httpClient *http.Client
+	config     *config.AgentConfig
+	logger     *zap.Logger
+	endpoint   string
+	model      string
+	batchSize  int
+	limiter    *rate.Limiter
+}
+
+// NewOpenAICompatibleEmbeddingsClient creates an embeddings client that uses the base URL, API
+// key, headers, timeout and retries of the agent config, and the model, batch size and rate
+// limit of its embeddings config
+func NewOpenAICompatibleEmbeddingsClient(cfg *config.AgentConfig, logger *zap.Logger) (*OpenAICompatibleEmbeddingsClient, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("embeddings client config is required")
+	}
+
+	if cfg.Embeddings.Model == "" {
+		return nil, fmt.Errorf("embedding model is required")
+	}
+
+	if cfg.Embeddings.RequestsPerSecond < 0 {
+		return nil, fmt.Errorf("embeddings requests per second must not be negative, got %v", cfg.Embeddings.RequestsPerSecond)
+	}
+
+	baseURL := defaultEmbeddingsBaseURL
+	if cfg.BaseURL != "" {
+		baseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	}
+
+	endpoint, err := url.Parse(baseURL + "/embeddings")
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url %s: %w", cfg.BaseURL, err)
+	}
+
+	if cfg.Provider != "" {
+		provider, err := parseProvider(cfg.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("invalid provider %s: %w", cfg.Provider, err)
+		}
+		query := endpoint.Query()
+		query.Set("provider", string(provider))
+		endpoint.RawQuery = query.Encode()
+	}
+
+	batchSize := cfg.Embeddings.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultEmbeddingsBatchSize
+	}
+
+	var limiter *rate.Limiter
+	if cfg.Embeddings.RequestsPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(cfg.Embeddings.RequestsPerSecond), 1)
+	}
+
+	return &OpenAICompatibleEmbeddingsClient{
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		config:     cfg,
+		logger:     logger,
+		endpoint:   endpoint.String(),
+		model:      parseModelName(cfg.Embeddings.Model, cfg.Provider),
+		batchSize:  batchSize,
+		limiter:    limiter,
+	}, nil
+}
+
+// embeddingsRequest is the body of a request to the /embeddings endpoint
+type embeddingsRequest struct {
+	Model          string   `json:"model"`
+	Input          []string `json:"input"`
+	EncodingFormat string   `json:"encoding_format"`
+	Dimensions     int      `json:"dimensions,omitempty"`
+}
+
+// embeddingsResponse is the body of a successful response from the /embeddings endpoint
+type embeddingsResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// embeddingsError is a failed request to the /embeddings endpoint
+type embeddingsError struct {
+	statusCode int
+	message    string
+}
+
+func (e *embeddingsError) Error() string {
+	return fmt.Sprintf("embeddings request failed with status %d: %s", e.statusCode, e.message)
+}
+
+// retryable reports whether the request may succeed when sent again
+func (e *embeddingsError) retryable() bool {
+	return e.statusCode == http.StatusTooManyRequests || e.statusCode >= http.StatusInternalServerError
+}
+
+// Embed implements EmbeddingsClient.Embed, sending the texts in batches of the configured size
+func (c *OpenAICompatibleEmbeddingsClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += c.batchSize {
+		batch := texts[start:min(start+c.batchSize, len(texts))]
+
+		batchEmbeddings, err := c.embedBatch(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		embeddings = append(embeddings, batchEmbeddings...)
+	}
+
+	c.logger.Debug("embeddings created",
+		zap.String("model", c.model),
+		zap.Int("texts", len(texts)))
+
+	return embeddings, nil
+}
+
+// embedBatch embeds a single batch of texts, retrying rate limited and failed requests
+func (c *OpenAICompatibleEmbeddingsClient) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(embeddingsRequest{
+		Model:          c.model,
+		Input:          texts,
+		EncodingFormat: "float",
+		Dimensions:     c.config.Embeddings.Dimensions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode embeddings request: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			c.logger.Debug("retrying embeddings request",
+				zap.Int("attempt", attempt),
+				zap.Int("max_retries", c.config.MaxRetries),
+				zap.Error(lastErr))
+
+			backoff := time.Duration(attempt) * time.Second
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+		}
+
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		var embeddings [][]float32
+		embeddings, lastErr = c.send(ctx, body, len(texts))
+		if lastErr == nil {
+			return embeddings, nil
+		}
+
+		if apiErr, ok := lastErr.(*embeddingsError); ok && !apiErr.retryable() {
+			return nil, lastErr
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	return nil, fmt.Errorf("embeddings request failed after %d retries: %w", c.config.MaxRetries, lastErr)
+}
+
+// send posts an encoded request to the /embeddings endpoint and decodes the embeddings, in the
+// order of the texts they were requested for
+func (c *OpenAICompatibleEmbeddingsClient) send(ctx context.Context, body []byte, count int) ([][]float32, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create embeddings request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.config.UserAgent != "" {
+		req.Header.Set("User-Agent", c.config.UserAgent)
+	}
+	for name, value := range c.config.CustomHeaders {
+		req.Header.Set(name, value)
+	}
+	if c.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send embeddings request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embeddings response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &embeddingsError{statusCode: resp.StatusCode, message: embeddingsErrorMessage(data)}
+	}
+
+	var decoded embeddingsResponse
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode embeddings response: %w", err)
+	}
+	if len(decoded.Data) != count {
+		return nil, fmt.Errorf("got %d embeddings for %d texts", len(decoded.Data), count)
+	}
+
+	sort.SliceStable(decoded.Data, func(i, j int) bool {
+		return decoded.Data[i].Index < decoded.Data[j].Index
+	})
+	embeddings := make([][]float32, count)
+	for i, item := range decoded.Data {
+		embeddings[i] = item.Embedding
+	}
+	return embeddings, nil
+}
+
+// embeddingsErrorMessage extracts the error message from an error response body, which is either
+// OpenAI's {"error": {"message": ...}} or the gateway's {"error": "..."}
+func embeddingsErrorMessage(data []byte) string {
+	var body struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(data, &body); err == nil && len(body.Error) > 0 {
+		var message string
+		if err := json.Unmarshal(body.Error, &message); err == nil {
+			return message
+		}
+		var detailed struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(body.Error, &detailed); err == nil && detailed.Message != "" {
+			return detailed.Message
+		}
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,149 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	server "github.com/inference-gateway/adk/server"
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+// embeddingsServer serves an OpenAI-compatible /embeddings endpoint that embeds every text as
+// its length, returning the data in reverse order to check the client sorts it by index
+type embeddingsServer struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	inputs   [][]string
+	status   []int
+}
+
+func (s *embeddingsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Model string   `json:"model"`
+		Input []string `json:"input"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, r)
+	s.inputs = append(s.inputs, body.Input)
+	status := http.StatusOK
+	if len(s.status) > 0 {
+		status, s.status = s.status[0], s.status[1:]
+	}
+	s.mu.Unlock()
+
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"error":{"message":"invalid input","type":"invalid_request_error"}}`))
+		return
+	}
+
+	data := make([]map[string]any, 0, len(body.Input))
+	for i := len(body.Input) - 1; i >= 0; i-- {
+		data = append(data, map[string]any{
+			"object":    "embedding",
+			"index":     i,
+			"embedding": []float32{float32(len(body.Input[i])), 1},
+		})
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"object": "list", "data": data, "model": body.Model})
+}
+
+func newEmbeddingsTestConfig(baseURL string) *config.AgentConfig {
+	return &config.AgentConfig{
+		Provider:      "openai",
+		BaseURL:       baseURL,
+		APIKey:        "secret",
+		Timeout:       5 * time.Second,
+		CustomHeaders: map[string]string{"X-Team": "support"},
+		Embeddings: config.EmbeddingsConfig{
+			Model:     "openai/text-embedding-3-small",
+			BatchSize: 2,
+		},
+	}
+}
+
+func TestOpenAICompatibleEmbeddingsClient_Embed(t *testing.T) {
+	backend := &embeddingsServer{}
+	httpServer := httptest.NewServer(backend)
+	defer httpServer.Close()
+
+	client, err := server.NewOpenAICompatibleEmbeddingsClient(newEmbeddingsTestConfig(httpServer.URL+"/v1/"), zap.NewNop())
+	require.NoError(t, err)
+
+	embeddings, err := client.Embed(context.Background(), []string{"a", "bb", "ccc", "dddd", "eeeee"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 1}, {2, 1}, {3, 1}, {4, 1}, {5, 1}}, embeddings)
+
+	assert.Equal(t, [][]string{{"a", "bb"}, {"ccc", "dddd"}, {"eeeee"}}, backend.inputs)
+	request := backend.requests[0]
+	assert.Equal(t, "/v1/embeddings", request.URL.Path)
+	assert.Equal(t, "openai", request.URL.Query().Get("provider"))
+	assert.Equal(t, "Bearer secret", request.Header.Get("Authorization"))
+	assert.Equal(t, "support", request.Header.Get("X-Team"))
+
+	embeddings, err = client.Embed(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, embeddings)
+	assert.Len(t, backend.requests, 3)
+}
+
+func TestOpenAICompatibleEmbeddingsClient_Errors(t *testing.T) {
+	backend := &embeddingsServer{status: []int{http.StatusBadRequest}}
+	httpServer := httptest.NewServer(backend)
+	defer httpServer.Close()
+
+	cfg := newEmbeddingsTestConfig(httpServer.URL)
+	cfg.MaxRetries = 3
+	client, err := server.NewOpenAICompatibleEmbeddingsClient(cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = client.Embed(context.Background(), []string{"a"})
+	assert.EqualError(t, err, "embeddings request failed with status 400: invalid input")
+	assert.Len(t, backend.requests, 1, "client errors are not retried")
+
+	_, err = server.NewOpenAICompatibleEmbeddingsClient(&config.AgentConfig{}, zap.NewNop())
+	assert.EqualError(t, err, "embedding model is required")
+}
+
+func TestOpenAICompatibleEmbeddingsClient_RateLimit(t *testing.T) {
+	backend := &embeddingsServer{}
+	httpServer := httptest.NewServer(backend)
+	defer httpServer.Close()
+
+	cfg := newEmbeddingsTestConfig(httpServer.URL)
+	cfg.Embeddings.BatchSize = 1
+	cfg.Embeddings.RequestsPerSecond = 20
+	client, err := server.NewOpenAICompatibleEmbeddingsClient(cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = client.Embed(context.Background(), []string{"a", "b", "c", "d"})
+	require.NoError(t, err)
+
+	assert.Len(t, backend.requests, 4)
+	assert.GreaterOrEqual(t, time.Since(start), 140*time.Millisecond, "four requests at 20 per second take at least 150ms")
+}
+
+func TestOpenAICompatibleEmbeddingsClient_AsKnowledgeBaseEmbedder(t *testing.T) {
+	httpServer := httptest.NewServer(&embeddingsServer{})
+	defer httpServer.Close()
+
+	client, err := server.NewOpenAICompatibleEmbeddingsClient(newEmbeddingsTestConfig(httpServer.URL), zap.NewNop())
+	require.NoError(t, err)
+
+	kb := server.NewKnowledgeBase(server.NewInMemoryVectorStore(), client)
+	count, err := kb.Index(context.Background(), "notes", "short note", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
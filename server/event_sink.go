@@ -0,0 +1,196 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	uuid "github.com/google/uuid"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// eventExportBuffer is the number of task events waiting for the event sink before further
+// events are dropped
+const eventExportBuffer = 1024
+
+// EventSink receives the CloudEvents of the tasks processed by the server, to export them to
+// an external event bus
+type EventSink interface {
+	// Publish delivers an event to the sink
+	Publish(ctx context.Context, event cloudevents.Event) error
+
+	// Close releases the resources of the sink once the server stops
+	Close() error
+}
+
+// eventExport delivers task events to an event sink in the background, in the order they were
+// emitted, so a slow sink never delays a task. Events are dropped while the buffer is full, and
+// the sink is told how many with an adk.server.events.dropped event once the buffer has room.
+type eventExport struct {
+	sink    EventSink
+	logger  *zap.Logger
+	events  chan cloudevents.Event
+	done    chan struct{}
+	dropped atomic.Int64
+	mu      sync.RWMutex
+	closed  bool
+}
+
+// newEventExport starts delivering task events to the sink
+func newEventExport(sink EventSink, logger *zap.Logger) *eventExport {
+	export := &eventExport{
+		sink:   sink,
+		logger: logger,
+		events: make(chan cloudevents.Event, eventExportBuffer),
+		done:   make(chan struct{}),
+	}
+	go export.run()
+	return export
+}
+
+// run publishes the buffered events until the export is closed
+func (e *eventExport) run() {
+	defer close(e.done)
+	for event := range e.events {
+		if err := e.sink.Publish(context.Background(), event); err != nil {
+			e.logger.Warn("failed to publish task event",
+				zap.String("event_id", event.ID()),
+				zap.String("event_type", event.Type()),
+				zap.Error(err))
+		}
+	}
+}
+
+// taskEvent queues an event of a task for the sink, with the IDs of the task and its context
+// as the taskid and contextid extensions, and the correlation ID recorded on the task as the
+// requestid extension unless the event carries one. It does nothing without an event sink.
+func (e *eventExport) taskEvent(task *types.Task, event cloudevents.Event) {
+	if e == nil {
+		return
+	}
+
+	exported := event.Clone()
+	if task != nil {
+		exported.SetExtension("taskid", task.ID)
+		exported.SetExtension("contextid", task.ContextID)
+		if _, set := exported.Extensions()[requestIDExtension]; !set {
+			if requestID := taskRequestID(task); requestID != "" {
+				exported.SetExtension(requestIDExtension, requestID)
+			}
+		}
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closed {
+		return
+	}
+	if dropped := e.dropped.Load(); dropped > 0 {
+		overflow := types.NewEventsDroppedEvent(uuid.New().String(), types.EventsDropped{
+			Channel: dropChannelEventSink,
+			Dropped: dropped,
+		})
+		select {
+		case e.events <- overflow:
+			e.dropped.Add(-dropped)
+		default:
+		}
+	}
+	select {
+	case e.events <- exported:
+	default:
+		e.dropped.Add(1)
+		droppedEvents.add(dropChannelEventSink, 1)
+		e.logger.Warn("event sink is falling behind, dropping task event",
+			zap.String("event_type", event.Type()))
+	}
+}
+
+// close stops accepting events, waits for the buffered ones to be published until the context
+// ends, and closes the sink
+func (e *eventExport) close(ctx context.Context) error {
+	if e == nil {
+		return nil
+	}
+
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return nil
+	}
+	e.closed = true
+	close(e.events)
+	e.mu.Unlock()
+
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		e.logger.Warn("event sink did not publish all task events before shutdown")
+	}
+	return e.sink.Close()
+}
+
+// HTTPEventSink posts every task event to a webhook as a structured-mode CloudEvent
+type HTTPEventSink struct {
+	url        string
+	headers    map[string]string
+	httpClient HTTPDoer
+}
+
+// NewHTTPEventSink creates an event sink that posts events to the URL with the given headers
+func NewHTTPEventSink(url string, headers map[string]string) *HTTPEventSink {
+	return NewHTTPEventSinkWithClient(url, headers, &http.Client{
+		Timeout: 10 * time.Second,
+	})
+}
+
+// NewHTTPEventSinkWithClient creates an event sink that posts events to the URL through the
+// given HTTP client
+func NewHTTPEventSinkWithClient(url string, headers map[string]string, httpClient HTTPDoer) *HTTPEventSink {
+	return &HTTPEventSink{
+		url:        url,
+		headers:    headers,
+		httpClient: httpClient,
+	}
+}
+
+// Publish posts the event with the application/cloudevents+json content type
+func (s *HTTPEventSink) Publish(ctx context.Context, event cloudevents.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+	for name, value := range s.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post event: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("event sink returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Close does nothing, the sink holds no connection
+func (s *HTTPEventSink) Close() error {
+	return nil
+}
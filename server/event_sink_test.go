@@ -0,0 +1,183 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+type recordingEventSink struct {
+	mu     sync.Mutex
+	events []cloudevents.Event
+	closed bool
+}
+
+func (s *recordingEventSink) Publish(ctx context.Context, event cloudevents.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *recordingEventSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func TestEventExport_TaskEvent(t *testing.T) {
+	sink := &recordingEventSink{}
+	export := newEventExport(sink, zap.NewNop())
+	task := &types.Task{ID: "task-1", ContextID: "ctx-1"}
+
+	for i := range 3 {
+		export.taskEvent(task, types.NewAgentEvent(types.EventToolStarted, "event-"+strconv.Itoa(i), nil))
+	}
+	require.NoError(t, export.close(context.Background()))
+	export.taskEvent(task, types.NewAgentEvent(types.EventToolStarted, "late", nil))
+
+	require.Len(t, sink.events, 3, "buffered events are published before the sink closes")
+	for i, event := range sink.events {
+		assert.Equal(t, "event-"+strconv.Itoa(i), event.ID())
+		assert.Equal(t, "task-1", event.Extensions()["taskid"])
+		assert.Equal(t, "ctx-1", event.Extensions()["contextid"])
+	}
+	assert.True(t, sink.closed)
+
+	var disabled *eventExport
+	disabled.taskEvent(task, types.NewAgentEvent(types.EventToolStarted, "ignored", nil))
+	assert.NoError(t, disabled.close(context.Background()))
+}
+
+func TestEventExport_Overflow(t *testing.T) {
+	export := &eventExport{
+		sink:   &recordingEventSink{},
+		logger: zap.NewNop(),
+		events: make(chan cloudevents.Event, 1),
+		done:   make(chan struct{}),
+	}
+	task := &types.Task{ID: "task-1", ContextID: "ctx-1"}
+	before := droppedEvents.snapshot()[dropChannelEventSink]
+
+	export.taskEvent(task, types.NewAgentEvent(types.EventToolStarted, "event-1", nil))
+	export.taskEvent(task, types.NewAgentEvent(types.EventToolStarted, "event-2", nil))
+	export.taskEvent(task, types.NewAgentEvent(types.EventToolStarted, "event-3", nil))
+	assert.Equal(t, before+2, droppedEvents.snapshot()[dropChannelEventSink])
+
+	assert.Equal(t, "event-1", (<-export.events).ID())
+	export.taskEvent(task, types.NewAgentEvent(types.EventToolStarted, "event-4", nil))
+	overflow := <-export.events
+	assert.Equal(t, types.EventEventsDropped, overflow.Type(), "the sink learns about the dropped events once the buffer has room")
+	var dropped types.EventsDropped
+	require.NoError(t, overflow.DataAs(&dropped))
+	assert.Equal(t, types.EventsDropped{Channel: dropChannelEventSink, Dropped: 2}, dropped)
+
+	assert.Empty(t, export.events, "the event following the overflow event did not fit the buffer")
+	assert.Equal(t, int64(1), export.dropped.Load())
+}
+
+func TestHTTPEventSink_Publish(t *testing.T) {
+	var received cloudevents.Event
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/cloudevents+json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer webhook.Close()
+
+	sink := NewHTTPEventSink(webhook.URL, map[string]string{"Authorization": "Bearer secret"})
+	event := types.NewAgentEvent(types.EventToolStarted, "event-1", map[string]any{"tool": "search"})
+	require.NoError(t, sink.Publish(context.Background(), event))
+	assert.Equal(t, "event-1", received.ID())
+	assert.Equal(t, types.EventToolStarted, received.Type())
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	err := NewHTTPEventSink(failing.URL, nil).Publish(context.Background(), event)
+	assert.EqualError(t, err, "event sink returned status 503")
+}
+
+func TestNATSEventSink_Publish(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+
+	type published struct {
+		connect string
+		subject string
+		payload []byte
+	}
+	received := make(chan published, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		reader := bufio.NewReader(conn)
+
+		_, _ = conn.Write([]byte("INFO {\"server_id\":\"test\"}\r\n"))
+		connect, _ := reader.ReadString('\n')
+		if ping, _ := reader.ReadString('\n'); ping != "PING\r\n" {
+			return
+		}
+		_, _ = conn.Write([]byte("PONG\r\n"))
+
+		pub, _ := reader.ReadString('\n')
+		fields := strings.Fields(pub)
+		if len(fields) != 3 {
+			return
+		}
+		size, _ := strconv.Atoi(fields[2])
+		payload := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			return
+		}
+		received <- published{connect: connect, subject: fields[1], payload: payload[:size]}
+	}()
+
+	sink, err := NewNATSEventSink(zap.NewNop(), "nats://token@"+listener.Addr().String(), "adk.events")
+	require.NoError(t, err)
+	defer func() { _ = sink.Close() }()
+
+	event := types.NewAgentEvent(types.EventToolStarted, "event-1", map[string]any{"tool": "search"})
+	require.NoError(t, sink.Publish(context.Background(), event))
+
+	message := <-received
+	assert.Contains(t, message.connect, `"auth_token":"token"`)
+	assert.Equal(t, "adk.events."+types.EventToolStarted, message.subject)
+	var decoded cloudevents.Event
+	require.NoError(t, json.Unmarshal(message.payload, &decoded))
+	assert.Equal(t, "event-1", decoded.ID())
+}
+
+func TestNewNATSEventSink_Validation(t *testing.T) {
+	_, err := NewNATSEventSink(zap.NewNop(), "localhost", "")
+	assert.EqualError(t, err, "nats subject is required")
+
+	_, err = NewNATSEventSink(zap.NewNop(), "tls://localhost:4222", "adk")
+	assert.EqualError(t, err, "unsupported nats url scheme 'tls'")
+
+	sink, err := NewNATSEventSink(zap.NewNop(), "localhost", "adk")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:4222", sink.address)
+}
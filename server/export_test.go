@@ -1,9 +1,12 @@
 package server
 
 import (
+	"time"
+
 	zap "go.uber.org/zap"
 
 	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
 )
 
 // NewRedisStorageForTest constructs a RedisStorage backed by the supplied
@@ -16,3 +19,14 @@ func NewRedisStorageForTest(client RedisClient, logger *zap.Logger, cfg config.Q
 		config: cfg,
 	}
 }
+
+// NewRedisSemanticCacheBackendForTest constructs a RedisSemanticCacheBackend backed by the
+// supplied RedisClient.
+func NewRedisSemanticCacheBackendForTest(client RedisClient, keyPrefix string, ttl time.Duration) *RedisSemanticCacheBackend {
+	return newRedisSemanticCacheBackend(client, keyPrefix, ttl)
+}
+
+// SnapshotTaskForTest returns the deep copy of a task that is queued for processing.
+func SnapshotTaskForTest(task types.Task) (types.Task, error) {
+	return snapshotTask(task)
+}
@@ -0,0 +1,655 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	html "golang.org/x/net/html"
+	atom "golang.org/x/net/html/atom"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+const (
+	// docxDocumentPath is the entry of a DOCX archive holding the document body
+	docxDocumentPath = "word/document.xml"
+
+	// maxDecompressedSize bounds how much a DOCX entry or PDF stream may inflate to
+	maxDecompressedSize = 64 << 20
+
+	thumbnailMediaType = "image/jpeg"
+	thumbnailQuality   = 80
+)
+
+// NewPlainTextConverter creates a converter attaching the content of text files, such as
+// text/plain, text/markdown, text/csv or application/json, as their text
+func NewPlainTextConverter() FileConverter {
+	mediaTypes := []string{"text/*", "application/json", "application/xml", "application/yaml", "application/x-yaml",
+		"application/javascript", "application/x-ndjson", "application/toml", "application/sql"}
+	return NewFileConverter(mediaTypes, func(ctx context.Context, data []byte, file *types.IngestedFile) error {
+		if !utf8.Valid(data) {
+			return fmt.Errorf("file is not valid UTF-8")
+		}
+		file.Text = strings.TrimPrefix(string(data), "\ufeff")
+		return nil
+	})
+}
+
+// NewHTMLTextConverter creates a converter extracting the visible text of HTML documents,
+// leaving out scripts and styles
+func NewHTMLTextConverter() FileConverter {
+	return NewFileConverter([]string{"text/html", "application/xhtml+xml"}, func(ctx context.Context, data []byte, file *types.IngestedFile) error {
+		file.Text = extractHTMLText(data)
+		return nil
+	})
+}
+
+// NewDOCXTextConverter creates a converter extracting the paragraphs of Word documents
+func NewDOCXTextConverter() FileConverter {
+	return NewFileConverter([]string{docxMediaType}, func(ctx context.Context, data []byte, file *types.IngestedFile) error {
+		text, err := extractDOCXText(data)
+		if err != nil {
+			return err
+		}
+		file.Text = text
+		return nil
+	})
+}
+
+// NewPDFTextConverter creates a converter extracting the text of PDF documents. Extraction is
+// best effort: text in uncompressed and Flate-compressed content streams is recovered, text
+// drawn with fonts using custom glyph encodings is not.
+func NewPDFTextConverter() FileConverter {
+	return NewFileConverter([]string{"application/pdf"}, func(ctx context.Context, data []byte, file *types.IngestedFile) error {
+		text, err := extractPDFText(data)
+		if err != nil {
+			return err
+		}
+		file.Text = text
+		return nil
+	})
+}
+
+// NewImageThumbnailConverter creates a converter attaching the dimensions of PNG, JPEG and GIF
+// images and a JPEG thumbnail that fits in size x size pixels. Images with more than maxPixels
+// pixels are not decoded; 0 means no limit.
+func NewImageThumbnailConverter(size, maxPixels int) FileConverter {
+	return NewFileConverter([]string{"image/png", "image/jpeg", "image/gif"}, func(ctx context.Context, data []byte, file *types.IngestedFile) error {
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("failed to read image: %w", err)
+		}
+		file.Width, file.Height = cfg.Width, cfg.Height
+		if maxPixels > 0 && cfg.Width*cfg.Height > maxPixels {
+			return fmt.Errorf("image of %dx%d pixels exceeds the limit of %d pixels", cfg.Width, cfg.Height, maxPixels)
+		}
+
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("failed to decode image: %w", err)
+		}
+		var thumbnail bytes.Buffer
+		if err := jpeg.Encode(&thumbnail, downscale(img, size), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+			return fmt.Errorf("failed to encode thumbnail: %w", err)
+		}
+		file.Thumbnail = base64.StdEncoding.EncodeToString(thumbnail.Bytes())
+		file.ThumbnailMediaType = thumbnailMediaType
+		return nil
+	})
+}
+
+// downscale shrinks an image to fit in size x size pixels by averaging the source pixels
+// each target pixel covers, over a white background. Smaller images keep their size.
+func downscale(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	scale := min(float64(size)/float64(width), float64(size)/float64(height), 1)
+	targetWidth, targetHeight := max(int(float64(width)*scale), 1), max(int(float64(height)*scale), 1)
+
+	target := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+	for y := range targetHeight {
+		y0, y1 := bounds.Min.Y+y*height/targetHeight, bounds.Min.Y+(y+1)*height/targetHeight
+		for x := range targetWidth {
+			x0, x1 := bounds.Min.X+x*width/targetWidth, bounds.Min.X+(x+1)*width/targetWidth
+			var r, g, b, a, n uint64
+			for sy := y0; sy < max(y1, y0+1); sy++ {
+				for sx := x0; sx < max(x1, x0+1); sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, b, a, n = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa), n+1
+				}
+			}
+			// colors are alpha-premultiplied, so adding the uncovered share of white composites them
+			white := 0xffff - a/n
+			target.SetRGBA(x, y, color.RGBA{
+				R: uint8((r/n + white) >> 8),
+				G: uint8((g/n + white) >> 8),
+				B: uint8((b/n + white) >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return target
+}
+
+// htmlBlockElements start a new line in the text extracted from HTML
+var htmlBlockElements = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true, atom.Br: true,
+	atom.Dd: true, atom.Div: true, atom.Dl: true, atom.Dt: true, atom.Figcaption: true,
+	atom.Footer: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true,
+	atom.H6: true, atom.Header: true, atom.Hr: true, atom.Li: true, atom.Main: true, atom.Nav: true,
+	atom.Ol: true, atom.P: true, atom.Pre: true, atom.Section: true, atom.Table: true, atom.Title: true,
+	atom.Tr: true, atom.Ul: true,
+}
+
+// htmlHiddenElements hold content that is not shown as text
+var htmlHiddenElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true, atom.Svg: true,
+}
+
+// extractHTMLText returns the visible text of an HTML document, one line per block element
+func extractHTMLText(data []byte) string {
+	tokenizer := html.NewTokenizer(bytes.NewReader(data))
+	var text strings.Builder
+	hidden := 0
+	for {
+		switch tokenType := tokenizer.Next(); tokenType {
+		case html.ErrorToken:
+			return normalizeExtractedText(text.String())
+		case html.TextToken:
+			if hidden == 0 {
+				text.Write(tokenizer.Text())
+			}
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			element := atom.Lookup(name)
+			switch {
+			case htmlHiddenElements[element]:
+				if tokenType == html.StartTagToken {
+					hidden++
+				} else if tokenType == html.EndTagToken && hidden > 0 {
+					hidden--
+				}
+			case htmlBlockElements[element]:
+				text.WriteByte('\n')
+			case element == atom.Td || element == atom.Th:
+				text.WriteByte(' ')
+			}
+		}
+	}
+}
+
+// extractDOCXText returns the paragraphs of a Word document, one per line
+func extractDOCXText(data []byte) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to open document: %w", err)
+	}
+	document, err := archive.Open(docxDocumentPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open document body: %w", err)
+	}
+	defer func() { _ = document.Close() }()
+
+	decoder := xml.NewDecoder(io.LimitReader(document, maxDecompressedSize))
+	var text strings.Builder
+	inText := false
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return normalizeExtractedText(text.String()), nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse document body: %w", err)
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				text.WriteByte('\t')
+			case "br", "cr":
+				text.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch element.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				text.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				text.Write(element)
+			}
+		}
+	}
+}
+
+// pdfSkippedStreams mark streams that hold images, fonts or other binary data rather than page content
+var pdfSkippedStreams = []string{
+	"/Subtype/Image", "/Subtype/Form", "/Length1", "/Subtype/Type1C", "/Subtype/CIDFontType0C",
+	"/Subtype/OpenType", "/Type/XRef", "/Type/ObjStm", "/Type/EmbeddedFile", "/Type/Metadata",
+}
+
+// extractPDFText returns the text shown by the content streams of a PDF document
+func extractPDFText(data []byte) (string, error) {
+	var text strings.Builder
+	found := false
+	for offset := 0; ; {
+		start, end, dictionary, ok := nextPDFStream(data, offset)
+		if !ok {
+			break
+		}
+		offset = end
+
+		compact := strings.Join(strings.Fields(dictionary), "")
+		if containsAny(compact, pdfSkippedStreams) {
+			continue
+		}
+		content := data[start:end]
+		switch filters := pdfStreamFilters(compact); {
+		case len(filters) == 0:
+		case len(filters) == 1 && filters[0] == "FlateDecode":
+			inflated, err := inflatePDFStream(content)
+			if err != nil {
+				continue
+			}
+			content = inflated
+		default:
+			continue
+		}
+		found = true
+		writePDFContentText(&text, content)
+	}
+	if !found {
+		return "", fmt.Errorf("no readable content streams found")
+	}
+	return normalizeExtractedText(text.String()), nil
+}
+
+// nextPDFStream finds the next stream at or after offset, returning the bounds of its data and
+// the dictionary that precedes it
+func nextPDFStream(data []byte, offset int) (int, int, string, bool) {
+	for {
+		index := bytes.Index(data[offset:], []byte("stream"))
+		if index < 0 {
+			return 0, 0, "", false
+		}
+		keyword := offset + index
+		offset = keyword + len("stream")
+		if keyword >= 3 && string(data[keyword-3:keyword]) == "end" {
+			continue
+		}
+
+		start := offset
+		switch {
+		case bytes.HasPrefix(data[start:], []byte("\r\n")):
+			start += 2
+		case bytes.HasPrefix(data[start:], []byte("\n")), bytes.HasPrefix(data[start:], []byte("\r")):
+			start++
+		default:
+			continue
+		}
+		length := bytes.Index(data[start:], []byte("endstream"))
+		if length < 0 {
+			return 0, 0, "", false
+		}
+
+		dictionaryStart := bytes.LastIndex(data[:keyword], []byte("obj"))
+		dictionary := string(data[max(dictionaryStart, 0):keyword])
+		return start, start + length, dictionary, true
+	}
+}
+
+// pdfStreamFilters returns the filter names of a stream dictionary with its whitespace removed
+func pdfStreamFilters(dictionary string) []string {
+	_, value, found := strings.Cut(dictionary, "/Filter")
+	if !found {
+		return nil
+	}
+	if strings.HasPrefix(value, "[") {
+		value, _, _ = strings.Cut(value[1:], "]")
+		return strings.FieldsFunc(value, func(r rune) bool { return r == '/' })
+	}
+	if !strings.HasPrefix(value, "/") {
+		return []string{value}
+	}
+	name := value[1:]
+	if end := strings.IndexAny(name, "/<>[]()"); end >= 0 {
+		name = name[:end]
+	}
+	return []string{name}
+}
+
+// inflatePDFStream decompresses a FlateDecode stream, keeping what could be read from a truncated one
+func inflatePDFStream(content []byte) ([]byte, error) {
+	reader, err := zlib.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+	inflated, err := io.ReadAll(io.LimitReader(reader, maxDecompressedSize))
+	if err != nil && len(inflated) == 0 {
+		return nil, err
+	}
+	return inflated, nil
+}
+
+// writePDFContentText writes the strings shown by the text operators of a content stream.
+// Text objects and line moves start new lines, and large gaps in TJ arrays become spaces.
+func writePDFContentText(text *strings.Builder, content []byte) {
+	var operands []any
+	inText := false
+	for scanner := (pdfScanner{data: content}); ; {
+		token, ok := scanner.next()
+		if !ok {
+			return
+		}
+		operator, isOperator := token.(pdfOperator)
+		if !isOperator {
+			operands = append(operands, token)
+			continue
+		}
+
+		switch operator {
+		case "BT":
+			inText = true
+		case "ET":
+			inText = false
+			text.WriteByte('\n')
+		case "T*":
+			text.WriteByte('\n')
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				if ty, ok := operands[len(operands)-1].(float64); ok && ty != 0 {
+					text.WriteByte('\n')
+				} else {
+					text.WriteByte(' ')
+				}
+			}
+		case "Tj", "'", "\"":
+			if operator != "Tj" {
+				text.WriteByte('\n')
+			}
+			if inText && len(operands) > 0 {
+				if s, ok := operands[len(operands)-1].(pdfString); ok {
+					text.WriteString(decodePDFString(s))
+				}
+			}
+		case "TJ":
+			if inText && len(operands) > 0 {
+				if array, ok := operands[len(operands)-1].([]any); ok {
+					for _, element := range array {
+						switch v := element.(type) {
+						case pdfString:
+							text.WriteString(decodePDFString(v))
+						case float64:
+							if v < -200 {
+								text.WriteByte(' ')
+							}
+						}
+					}
+				}
+			}
+		case "ID":
+			scanner.skipInlineImage()
+		}
+		operands = operands[:0]
+	}
+}
+
+// pdfString is a literal or hexadecimal string of a content stream
+type pdfString []byte
+
+// pdfOperator is an operator of a content stream
+type pdfOperator string
+
+// pdfScanner tokenizes a content stream into operands and operators
+type pdfScanner struct {
+	data []byte
+	pos  int
+}
+
+// next returns the next token: a float64, pdfString, name string, []any array or pdfOperator
+func (s *pdfScanner) next() (any, bool) {
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		switch {
+		case isPDFWhitespace(c):
+			s.pos++
+		case c == '%':
+			for s.pos < len(s.data) && s.data[s.pos] != '\n' && s.data[s.pos] != '\r' {
+				s.pos++
+			}
+		case c == '(':
+			return s.literalString(), true
+		case c == '<' && s.pos+1 < len(s.data) && s.data[s.pos+1] == '<':
+			s.pos += 2
+			s.skipDictionary()
+		case c == '<':
+			return s.hexString(), true
+		case c == '[':
+			s.pos++
+			var array []any
+			for {
+				s.skipWhitespace()
+				if s.pos >= len(s.data) {
+					return array, true
+				}
+				if s.data[s.pos] == ']' {
+					s.pos++
+					return array, true
+				}
+				token, ok := s.next()
+				if !ok {
+					return array, true
+				}
+				array = append(array, token)
+			}
+		case c == ']' || c == '>' || c == '{' || c == '}' || c == ')':
+			s.pos++
+		case c == '/':
+			s.pos++
+			return "/" + s.word(), true
+		default:
+			word := s.word()
+			if word == "" {
+				s.pos++
+				continue
+			}
+			if number, err := strconv.ParseFloat(word, 64); err == nil {
+				return number, true
+			}
+			return pdfOperator(word), true
+		}
+	}
+	return nil, false
+}
+
+// word reads a run of regular characters
+func (s *pdfScanner) word() string {
+	start := s.pos
+	for s.pos < len(s.data) && !isPDFWhitespace(s.data[s.pos]) && !isPDFDelimiter(s.data[s.pos]) {
+		s.pos++
+	}
+	return string(s.data[start:s.pos])
+}
+
+// literalString reads a parenthesized string with balanced parentheses and escapes
+func (s *pdfScanner) literalString() pdfString {
+	s.pos++
+	var str []byte
+	for depth := 1; s.pos < len(s.data); s.pos++ {
+		c := s.data[s.pos]
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				s.pos++
+				return str
+			}
+		case '\\':
+			s.pos++
+			if s.pos >= len(s.data) {
+				return str
+			}
+			c = s.data[s.pos]
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r', '\n':
+				continue
+			default:
+				if c >= '0' && c <= '7' {
+					value := 0
+					for digits := 0; digits < 3 && s.pos < len(s.data) && s.data[s.pos] >= '0' && s.data[s.pos] <= '7'; digits++ {
+						value = value*8 + int(s.data[s.pos]-'0')
+						s.pos++
+					}
+					s.pos--
+					c = byte(value)
+				}
+			}
+		}
+		str = append(str, c)
+	}
+	return str
+}
+
+// hexString reads a string of hexadecimal digits in angle brackets
+func (s *pdfScanner) hexString() pdfString {
+	s.pos++
+	var digits []byte
+	for ; s.pos < len(s.data) && s.data[s.pos] != '>'; s.pos++ {
+		if !isPDFWhitespace(s.data[s.pos]) {
+			digits = append(digits, s.data[s.pos])
+		}
+	}
+	s.pos++
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	decoded := make([]byte, hex.DecodedLen(len(digits)))
+	n, _ := hex.Decode(decoded, digits)
+	return decoded[:n]
+}
+
+// skipDictionary skips an inline dictionary, including nested ones
+func (s *pdfScanner) skipDictionary() {
+	for depth := 1; s.pos+1 < len(s.data) && depth > 0; s.pos++ {
+		switch string(s.data[s.pos : s.pos+2]) {
+		case "<<":
+			depth++
+			s.pos++
+		case ">>":
+			depth--
+			s.pos++
+		}
+	}
+}
+
+// skipInlineImage skips the binary data of an inline image up to its EI operator
+func (s *pdfScanner) skipInlineImage() {
+	for s.pos+2 < len(s.data) {
+		if s.data[s.pos] == 'E' && s.data[s.pos+1] == 'I' && isPDFWhitespace(s.data[s.pos-1]) &&
+			(s.pos+2 == len(s.data) || isPDFWhitespace(s.data[s.pos+2])) {
+			s.pos += 2
+			return
+		}
+		s.pos++
+	}
+	s.pos = len(s.data)
+}
+
+// skipWhitespace advances past whitespace
+func (s *pdfScanner) skipWhitespace() {
+	for s.pos < len(s.data) && isPDFWhitespace(s.data[s.pos]) {
+		s.pos++
+	}
+}
+
+func isPDFWhitespace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+// decodePDFString decodes a UTF-16 string with a byte order mark, or treats the bytes as Latin-1.
+// Control characters, which glyph IDs of custom-encoded fonts decode to, are dropped.
+func decodePDFString(s pdfString) string {
+	var runes []rune
+	if len(s) >= 2 && s[0] == 0xfe && s[1] == 0xff {
+		units := make([]uint16, 0, len(s)/2)
+		for i := 2; i+1 < len(s); i += 2 {
+			units = append(units, binary.BigEndian.Uint16(s[i:]))
+		}
+		runes = utf16.Decode(units)
+	} else {
+		runes = make([]rune, len(s))
+		for i, c := range s {
+			runes[i] = rune(c)
+		}
+	}
+
+	var text strings.Builder
+	for _, r := range runes {
+		if unicode.IsPrint(r) || r == '\n' || r == '\t' {
+			text.WriteRune(r)
+		}
+	}
+	return text.String()
+}
+
+// normalizeExtractedText collapses runs of whitespace within lines, trims the lines and drops
+// empty ones
+func normalizeExtractedText(text string) string {
+	var lines []string
+	for line := range strings.SplitSeq(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// containsAny reports whether s contains any of the substrings
+func containsAny(s string, substrings []string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
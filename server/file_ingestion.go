@@ -0,0 +1,265 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// Media types of the documents the built-in converters recognize beyond http.DetectContentType
+const (
+	docxMediaType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	zipMediaType  = "application/zip"
+	flacMediaType = "audio/flac"
+)
+
+// FileConverter extracts the content of files of the media types it handles
+type FileConverter interface {
+	// MediaTypes lists the media types the converter handles. Wildcards such as image/* are allowed.
+	MediaTypes() []string
+
+	// Convert fills in the content extracted from the file's bytes, such as its text or a thumbnail
+	Convert(ctx context.Context, data []byte, file *types.IngestedFile) error
+}
+
+// fileConverterFunc adapts a function to the FileConverter interface
+type fileConverterFunc struct {
+	mediaTypes []string
+	convert    func(ctx context.Context, data []byte, file *types.IngestedFile) error
+}
+
+func (f *fileConverterFunc) MediaTypes() []string { return f.mediaTypes }
+
+func (f *fileConverterFunc) Convert(ctx context.Context, data []byte, file *types.IngestedFile) error {
+	return f.convert(ctx, data, file)
+}
+
+// NewFileConverter creates a converter for the media types from a function
+func NewFileConverter(mediaTypes []string, convert func(ctx context.Context, data []byte, file *types.IngestedFile) error) FileConverter {
+	return &fileConverterFunc{mediaTypes: mediaTypes, convert: convert}
+}
+
+// FileIngestion normalizes the file parts of incoming messages before the agent sees them.
+// The media type of each file sent as bytes is sniffed from its content, files of types that
+// are not allowed are rejected, and the first converter handling the sniffed type attaches
+// the extracted content to the part as a types.IngestedFile. Files referenced by URI are
+// passed through unchanged.
+type FileIngestion struct {
+	cfg        config.FileIngestionConfig
+	logger     *zap.Logger
+	converters []FileConverter
+}
+
+// NewFileIngestion creates a file ingestion with the built-in converters the configuration
+// enables: text extraction from HTML, PDF, DOCX and text files, and image thumbnails
+func NewFileIngestion(cfg config.FileIngestionConfig, logger *zap.Logger) *FileIngestion {
+	ingestion := &FileIngestion{cfg: cfg, logger: logger}
+	if cfg.ExtractText {
+		ingestion.converters = append(ingestion.converters,
+			NewHTMLTextConverter(),
+			NewPDFTextConverter(),
+			NewDOCXTextConverter(),
+			NewPlainTextConverter(),
+		)
+	}
+	if cfg.Thumbnails {
+		ingestion.converters = append(ingestion.converters, NewImageThumbnailConverter(cfg.ThumbnailSize, cfg.MaxImagePixels))
+	}
+	return ingestion
+}
+
+// UseConverters registers converters that are tried before the ones already registered, so
+// they can replace a built-in converter for the media types they handle
+func (f *FileIngestion) UseConverters(converters ...FileConverter) {
+	f.converters = append(slices.Clone(converters), f.converters...)
+}
+
+// Ingest sniffs, validates and converts the file parts of a message. The message gets a new
+// parts slice, in which ingested file parts carry the sniffed media type and the
+// types.IngestedFile under types.IngestedFileMetadataKey in their metadata.
+func (f *FileIngestion) Ingest(ctx context.Context, message *types.Message) error {
+	if f == nil {
+		return nil
+	}
+
+	var parts []types.Part
+	for i, part := range message.Parts {
+		if part.File == nil || part.File.FileWithBytes == nil {
+			continue
+		}
+
+		data, mediaType, methodErr := f.inspect(part, i)
+		if methodErr != nil {
+			return methodErr
+		}
+
+		file := types.IngestedFile{MediaType: mediaType, Size: int64(len(data))}
+		if part.File.MediaType != "" && baseMediaType(part.File.MediaType) != mediaType {
+			file.DeclaredMediaType = part.File.MediaType
+		}
+		f.convert(ctx, data, &file, fileName(part, i))
+
+		if parts == nil {
+			parts = slices.Clone(message.Parts)
+		}
+		filePart := *part.File
+		filePart.MediaType = mediaType
+		metadata := make(map[string]any)
+		if part.Metadata != nil {
+			maps.Copy(metadata, *part.Metadata)
+		}
+		metadata[types.IngestedFileMetadataKey] = file
+		parts[i].File = &filePart
+		parts[i].Metadata = &metadata
+	}
+
+	if parts != nil {
+		message.Parts = parts
+	}
+	return nil
+}
+
+// checkMessage rejects messages with file parts that are not valid base64 or whose sniffed
+// media type is not allowed
+func (f *FileIngestion) checkMessage(message types.Message) *JSONRPCMethodError {
+	if f == nil {
+		return nil
+	}
+	for i, part := range message.Parts {
+		if part.File == nil || part.File.FileWithBytes == nil {
+			continue
+		}
+		if _, _, err := f.inspect(part, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inspect decodes the bytes of a file part and sniffs their media type
+func (f *FileIngestion) inspect(part types.Part, index int) ([]byte, string, *JSONRPCMethodError) {
+	data, err := decodeFileBytes(*part.File.FileWithBytes)
+	if err != nil {
+		return nil, "", &JSONRPCMethodError{
+			Code:    int(ErrInvalidParams),
+			Message: fmt.Sprintf("file '%s' is not valid base64", fileName(part, index)),
+		}
+	}
+
+	mediaType := detectMediaType(data, part.File.MediaType)
+	if len(f.cfg.AllowedTypes) > 0 && !acceptsMediaType(f.cfg.AllowedTypes, mediaType) {
+		return nil, "", &JSONRPCMethodError{
+			Code: int(ErrContentTypeNotSupported),
+			Message: fmt.Sprintf("file '%s' has content type %s, allowed file types are %s",
+				fileName(part, index), mediaType, strings.Join(f.cfg.AllowedTypes, ", ")),
+		}
+	}
+	return data, mediaType, nil
+}
+
+// convert runs the first converter handling the file's media type. A failed conversion is
+// logged and leaves the file without extracted content rather than rejecting the message.
+func (f *FileIngestion) convert(ctx context.Context, data []byte, file *types.IngestedFile, name string) {
+	for _, converter := range f.converters {
+		if !acceptsMediaType(converter.MediaTypes(), file.MediaType) {
+			continue
+		}
+		if err := converter.Convert(ctx, data, file); err != nil {
+			f.logger.Warn("failed to convert file part",
+				zap.String("file", name),
+				zap.String("media_type", file.MediaType),
+				zap.Error(err))
+			return
+		}
+		break
+	}
+
+	if f.cfg.MaxTextLength > 0 && utf8.RuneCountInString(file.Text) > f.cfg.MaxTextLength {
+		file.Text = string([]rune(file.Text)[:f.cfg.MaxTextLength])
+		file.TextTruncated = true
+	}
+}
+
+// fileName returns the name of a file part, or its position when it has none
+func fileName(part types.Part, index int) string {
+	if part.File.Name != "" {
+		return part.File.Name
+	}
+	return fmt.Sprintf("part %d", index)
+}
+
+// decodeFileBytes decodes the base64 content of a file part, padded or not
+func decodeFileBytes(data string) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return base64.RawStdEncoding.DecodeString(data)
+	}
+	return decoded, nil
+}
+
+// detectMediaType sniffs the media type of a file from its content. ZIP archives holding a
+// Word document are recognized as DOCX, and FLAC audio by its signature. Content that only
+// sniffs as plain text keeps a declared textual media type such as text/markdown or
+// application/json, and WebM, MP4 and Ogg containers keep a declared audio media type.
+// Otherwise the declared media type is ignored.
+func detectMediaType(data []byte, declared string) string {
+	if bytes.HasPrefix(data, []byte("fLaC")) {
+		return flacMediaType
+	}
+
+	sniffed := baseMediaType(http.DetectContentType(data))
+	switch sniffed {
+	case zipMediaType:
+		if isDOCX(data) {
+			return docxMediaType
+		}
+	case textPartMediaType:
+		if declared = baseMediaType(declared); isTextMediaType(declared) {
+			return declared
+		}
+	case "video/webm", "video/mp4", "application/ogg":
+		if declared = baseMediaType(declared); strings.HasPrefix(declared, "audio/") {
+			return declared
+		}
+	}
+	return sniffed
+}
+
+// isTextMediaType reports whether a media type denotes plain text content
+func isTextMediaType(mediaType string) bool {
+	if strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml") {
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/xml", "application/yaml", "application/x-yaml",
+		"application/javascript", "application/x-ndjson", "application/toml", "application/sql":
+		return true
+	}
+	return false
+}
+
+// isDOCX reports whether a ZIP archive is a Word document
+func isDOCX(data []byte) bool {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return false
+	}
+	for _, entry := range archive.File {
+		if entry.Name == docxDocumentPath {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,244 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func testPDF(t *testing.T) []byte {
+	t.Helper()
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	_, err := writer.Write([]byte("BT /F1 12 Tf 72 600 Td (Compressed page) Tj ET"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	plain := "BT /F1 12 Tf 72 712 Td (Quarterly report) Tj 0 -14 Td [(Re) 20 (venue) -400 (grew)] TJ ET"
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+	fmt.Fprintf(&pdf, "1 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(plain), plain)
+	fmt.Fprintf(&pdf, "2 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", compressed.Len())
+	pdf.Write(compressed.Bytes())
+	pdf.WriteString("\nendstream\nendobj\n")
+	pdf.WriteString("3 0 obj\n<< /Subtype /Image /Length 18 >>\nstream\nBT (pixels) Tj ET\nendstream\nendobj\n%%EOF\n")
+	return pdf.Bytes()
+}
+
+func testDOCX(t *testing.T) []byte {
+	t.Helper()
+	var docx bytes.Buffer
+	archive := zip.NewWriter(&docx)
+	entry, err := archive.Create("[Content_Types].xml")
+	require.NoError(t, err)
+	_, err = entry.Write([]byte(`<?xml version="1.0"?><Types/>`))
+	require.NoError(t, err)
+	entry, err = archive.Create("word/document.xml")
+	require.NoError(t, err)
+	_, err = entry.Write([]byte(`<?xml version="1.0"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:r><w:t>Hello</w:t></w:r><w:r><w:tab/><w:t xml:space="preserve">world </w:t></w:r></w:p>
+<w:p><w:r><w:t>Second paragraph</w:t></w:r></w:p>
+</w:body></w:document>`))
+	require.NoError(t, err)
+	require.NoError(t, archive.Close())
+	return docx.Bytes()
+}
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.RGBA{R: 200, A: 0xff})
+		}
+	}
+	var encoded bytes.Buffer
+	require.NoError(t, png.Encode(&encoded, img))
+	return encoded.Bytes()
+}
+
+func filePart(name, mediaType string, data []byte) types.Part {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	return types.CreateFilePart(name, mediaType, &encoded, nil)
+}
+
+func TestDetectMediaType(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		declared string
+		expected string
+	}{
+		{name: "pdf declared as image", data: []byte("%PDF-1.7\n"), declared: "image/png", expected: "application/pdf"},
+		{name: "markdown keeps the declared text type", data: []byte("# Title\n"), declared: "text/markdown; charset=utf-8", expected: "text/markdown"},
+		{name: "json keeps the declared type", data: []byte(`{"a":1}`), declared: "application/json", expected: "application/json"},
+		{name: "text declared as binary", data: []byte("hello"), declared: "application/octet-stream", expected: "text/plain"},
+		{name: "html", data: []byte("<!DOCTYPE html><p>hi</p>"), declared: "text/plain", expected: "text/html"},
+		{name: "docx", data: testDOCX(t), declared: "", expected: docxMediaType},
+		{name: "binary", data: []byte{0x00, 0x01, 0x02, 0xff}, declared: "text/plain", expected: "application/octet-stream"},
+		{name: "flac", data: []byte("fLaC\x00\x00\x00\x22"), declared: "", expected: "audio/flac"},
+		{name: "webm keeps the declared audio type", data: []byte("\x1a\x45\xdf\xa3\x9f\x42\x86\x81\x01"), declared: "audio/webm;codecs=opus", expected: "audio/webm"},
+		{name: "webm declared as text", data: []byte("\x1a\x45\xdf\xa3\x9f\x42\x86\x81\x01"), declared: "text/plain", expected: "video/webm"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, detectMediaType(tt.data, tt.declared))
+		})
+	}
+}
+
+func TestFileIngestion_Ingest(t *testing.T) {
+	ingestion := NewFileIngestion(config.FileIngestionConfig{
+		ExtractText:   true,
+		MaxTextLength: 60,
+		Thumbnails:    true,
+		ThumbnailSize: 100,
+	}, zap.NewNop())
+
+	html := `<html><head><title>Release notes</title><style>p { color: red }</style></head>
+<body><p>Version <b>2.0</b> is out</p><script>alert("hi")</script><ul><li>faster</li><li>smaller</li></ul></body></html>`
+	uri := "https://example.com/remote.pdf"
+	message := types.Message{
+		MessageID: "m1",
+		Role:      types.RoleUser,
+		Parts: []types.Part{
+			types.CreateTextPart("summarize these"),
+			filePart("report.pdf", "application/pdf", testPDF(t)),
+			filePart("letter.docx", "application/octet-stream", testDOCX(t)),
+			filePart("notes.html", "text/html", []byte(html)),
+			filePart("long.txt", "text/plain", []byte(strings.Repeat("a", 80))),
+			filePart("photo.png", "image/png", testPNG(t, 400, 200)),
+			types.CreateFilePart("remote.pdf", "application/pdf", nil, &uri),
+		},
+	}
+	original := message.Parts
+
+	require.NoError(t, ingestion.Ingest(context.Background(), &message))
+	assert.Nil(t, original[1].Metadata, "the original parts are left untouched")
+
+	ingested := func(index int) types.IngestedFile {
+		file, ok, err := types.GetIngestedFile(message.Parts[index])
+		require.NoError(t, err)
+		require.True(t, ok, "part %d is ingested", index)
+		return file
+	}
+
+	assert.Equal(t, "Quarterly report\nRevenue grew\nCompressed page", ingested(1).Text)
+
+	docx := ingested(2)
+	assert.Equal(t, docxMediaType, docx.MediaType)
+	assert.Equal(t, "application/octet-stream", docx.DeclaredMediaType)
+	assert.Equal(t, docxMediaType, message.Parts[2].File.MediaType)
+	assert.Equal(t, "Hello world\nSecond paragraph", docx.Text)
+
+	assert.Equal(t, "Release notes\nVersion 2.0 is out\nfaster\nsmaller", ingested(3).Text)
+
+	long := ingested(4)
+	assert.Equal(t, strings.Repeat("a", 60), long.Text)
+	assert.True(t, long.TextTruncated)
+	assert.Equal(t, int64(80), long.Size)
+
+	photo := ingested(5)
+	assert.Equal(t, 400, photo.Width)
+	assert.Equal(t, 200, photo.Height)
+	assert.Equal(t, "image/jpeg", photo.ThumbnailMediaType)
+	thumbnail, err := base64.StdEncoding.DecodeString(photo.Thumbnail)
+	require.NoError(t, err)
+	thumbnailConfig, err := jpeg.DecodeConfig(bytes.NewReader(thumbnail))
+	require.NoError(t, err)
+	assert.Equal(t, 100, thumbnailConfig.Width)
+	assert.Equal(t, 50, thumbnailConfig.Height)
+
+	_, ok, err := types.GetIngestedFile(message.Parts[6])
+	require.NoError(t, err)
+	assert.False(t, ok, "files referenced by URI are not fetched")
+}
+
+func TestFileIngestion_CustomConverter(t *testing.T) {
+	ingestion := NewFileIngestion(config.FileIngestionConfig{ExtractText: true}, zap.NewNop())
+	ingestion.UseConverters(NewFileConverter([]string{"application/pdf"}, func(ctx context.Context, data []byte, file *types.IngestedFile) error {
+		file.Text = "extracted by OCR"
+		return nil
+	}))
+
+	message := types.Message{Parts: []types.Part{filePart("scan.pdf", "application/pdf", testPDF(t))}}
+	require.NoError(t, ingestion.Ingest(context.Background(), &message))
+	file, ok, err := types.GetIngestedFile(message.Parts[0])
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "extracted by OCR", file.Text)
+}
+
+func TestA2AServer_FileIngestion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{FileIngestionConfig: config.FileIngestionConfig{
+		Enable:       true,
+		AllowedTypes: []string{"text/*", "application/pdf"},
+		ExtractText:  true,
+	}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "reader"})
+	router := s.setupRouter(cfg)
+
+	send := func(part types.Part) (*types.Task, *types.JSONRPCError) {
+		params, err := json.Marshal(types.MessageSendParams{Message: types.Message{
+			MessageID: "m1",
+			Role:      types.RoleUser,
+			Parts:     []types.Part{types.CreateTextPart("read this"), part},
+		}})
+		require.NoError(t, err)
+		body := `{"jsonrpc":"2.0","id":"1","method":"message/send","params":` + string(params) + `}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+		var response struct {
+			Result *types.Task         `json:"result"`
+			Error  *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Result, response.Error
+	}
+
+	task, rpcErr := send(filePart("report.pdf", "application/pdf", testPDF(t)))
+	require.Nil(t, rpcErr)
+	require.NotEmpty(t, task.History)
+	file, ok, err := types.GetIngestedFile(task.History[len(task.History)-1].Parts[1])
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "application/pdf", file.MediaType)
+	assert.Contains(t, file.Text, "Quarterly report")
+
+	_, rpcErr = send(filePart("cat.png", "image/png", []byte("%PDF-1.4 not really a cat")))
+	require.Nil(t, rpcErr, "the sniffed type is checked, not the declared one")
+
+	_, rpcErr = send(filePart("notes.txt", "text/plain", testPNG(t, 2, 2)))
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, int(ErrContentTypeNotSupported), rpcErr.Code)
+	assert.Equal(t, "file 'notes.txt' has content type image/png, allowed file types are text/*, application/pdf", rpcErr.Message)
+
+	encoded := "not base64!"
+	_, rpcErr = send(types.CreateFilePart("", "text/plain", &encoded, nil))
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, int(ErrInvalidParams), rpcErr.Code)
+	assert.Equal(t, "file 'part 1' is not valid base64", rpcErr.Message)
+}
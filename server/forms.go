@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	sdk "github.com/inference-gateway/sdk"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// FormTool is a tool collecting structured data from the user. When the model calls it, the
+// task pauses in input-required state with the form, and the data the user fills it in with
+// becomes the result of the tool call once the task resumes.
+type FormTool interface {
+	Tool
+
+	// GetForm returns the form to request for a call of the tool with the arguments
+	GetForm(arguments map[string]any) types.FormRequest
+}
+
+// formTool requests the same form on every call
+type formTool struct {
+	name        string
+	description string
+	form        types.FormRequest
+}
+
+var _ FormTool = (*formTool)(nil)
+
+// NewFormTool creates a tool the model calls to ask the user to fill in a form. The form ID
+// defaults to the tool name.
+func NewFormTool(name, description string, form types.FormRequest) FormTool {
+	if form.FormID == "" {
+		form.FormID = name
+	}
+	return &formTool{name: name, description: description, form: form}
+}
+
+func (t *formTool) GetName() string {
+	return t.name
+}
+
+func (t *formTool) GetDescription() string {
+	return t.description
+}
+
+func (t *formTool) GetParameters() map[string]any {
+	return map[string]any{"type": "object", "properties": map[string]any{}}
+}
+
+// Execute is not called: the agent pauses the task with the form instead
+func (t *formTool) Execute(ctx context.Context, arguments map[string]any) (string, error) {
+	return "", fmt.Errorf("the %s form tool is answered by the user", t.name)
+}
+
+func (t *formTool) GetForm(arguments map[string]any) types.FormRequest {
+	form := t.form
+	form.Schema = cloneMap(t.form.Schema)
+	return form
+}
+
+// cloneMap returns a shallow copy of a map
+func cloneMap(values map[string]any) map[string]any {
+	if values == nil {
+		return nil
+	}
+	clone := make(map[string]any, len(values))
+	for key, value := range values {
+		clone[key] = value
+	}
+	return clone
+}
+
+// formRequestMessage returns the input-required message of a form tool call
+func formRequestMessage(tool FormTool, toolCall sdk.ChatCompletionMessageToolCall, args map[string]any) *types.Message {
+	form := tool.GetForm(args)
+	if form.FormID == "" {
+		form.FormID = toolCall.Function.Name
+	}
+	form.ToolCallID = toolCall.ID
+	form.ToolName = toolCall.Function.Name
+	return types.NewFormRequestMessage(fmt.Sprintf("form-required-%s", toolCall.ID), form)
+}
+
+// findFormResponse returns the form a conversation paused on and the data the user filled it
+// in with, when the last message resumes the task with a response to a form tool call
+func findFormResponse(messages []types.Message) (int, *types.FormRequest, *types.FormResponse) {
+	if len(messages) < 2 {
+		return -1, nil, nil
+	}
+	requestIndex := len(messages) - 2
+	requestMessage, resumeMessage := messages[requestIndex], messages[len(messages)-1]
+	if requestMessage.Role != types.RoleAgent || resumeMessage.Role != types.RoleUser {
+		return -1, nil, nil
+	}
+
+	form, found, err := types.GetFormRequest(&requestMessage)
+	if err != nil || !found || form.ToolCallID == "" {
+		return -1, nil, nil
+	}
+	response, found, err := types.GetFormResponse(&resumeMessage)
+	if err != nil || !found || response.FormID != form.FormID {
+		return -1, nil, nil
+	}
+	return requestIndex, form, response
+}
+
+// resumeForm answers the form tool call a task paused on with the data the client resumed
+// the task with. The form request and response are replaced by the tool call and its result,
+// so the LLM continues as if the tool had returned the data. Data that does not match the
+// schema of the form is reported to the model as a tool error, so it can ask again.
+// Messages are returned unchanged when the task was not resumed with a form response.
+func (a *OpenAICompatibleAgentImpl) resumeForm(ctx context.Context, messages []types.Message, outputChan chan<- cloudevents.Event) []types.Message {
+	requestIndex, form, response := findFormResponse(messages)
+	if form == nil {
+		return messages
+	}
+
+	var taskID *string
+	var contextID *string
+	if task, ok := ctx.Value(TaskContextKey).(*types.Task); ok && task != nil {
+		taskID = &task.ID
+		contextID = &task.ContextID
+	}
+
+	toolCallMessage := types.NewAssistantMessage(
+		fmt.Sprintf("form-tool-call-%s", form.ToolCallID),
+		[]types.Part{types.CreateDataPart(map[string]any{
+			"tool_calls": []sdk.ChatCompletionMessageToolCall{{
+				ID:       form.ToolCallID,
+				Type:     "function",
+				Function: sdk.ChatCompletionMessageToolCallFunction{Name: form.ToolName, Arguments: "{}"},
+			}},
+		})},
+	)
+	toolCallMessage.TaskID = taskID
+	toolCallMessage.ContextID = contextID
+	resumed := append(slices.Clone(messages[:requestIndex]), *toolCallMessage)
+
+	var result string
+	problems := types.ValidateFormData(form.Schema, response.Data)
+	if len(problems) > 0 {
+		a.logger.Info("form data does not match the form schema",
+			zap.String("form_id", form.FormID),
+			zap.Strings("problems", problems))
+		result = fmt.Sprintf("The form data is invalid: %s", strings.Join(problems, "; "))
+	} else {
+		data, err := json.Marshal(response.Data)
+		if err != nil {
+			a.logger.Error("failed to encode form data", zap.String("form_id", form.FormID), zap.Error(err))
+			return messages
+		}
+		result = string(data)
+	}
+
+	toolResultMessage := types.NewToolResultMessage(form.ToolCallID, form.ToolName, result, len(problems) > 0)
+	toolResultMessage.TaskID = taskID
+	toolResultMessage.ContextID = contextID
+	select {
+	case outputChan <- types.NewMessageEvent(types.EventToolResult, toolResultMessage.MessageID, toolResultMessage):
+	case <-ctx.Done():
+	}
+	return append(resumed, *toolResultMessage)
+}
+
+// checkFormResponse rejects a message resuming a task paused on a form with data that does
+// not fill in that form, so the task keeps waiting for valid data
+func (s *A2AServerImpl) checkFormResponse(message types.Message) *JSONRPCMethodError {
+	response, found, err := types.GetFormResponse(&message)
+	if err != nil {
+		return &JSONRPCMethodError{Code: int(ErrInvalidParams), Message: fmt.Sprintf("invalid form response: %s", err.Error())}
+	}
+	if !found || message.TaskID == nil || s.taskManager == nil {
+		return nil
+	}
+
+	task, exists := s.taskManager.GetTask(*message.TaskID)
+	if !exists || task.Status.State != types.TaskStateInputRequired {
+		return nil
+	}
+	form, found, err := types.GetFormRequest(task.Status.Message)
+	if err != nil || !found {
+		return nil
+	}
+
+	if response.FormID != form.FormID {
+		return &JSONRPCMethodError{
+			Code:    int(ErrInvalidParams),
+			Message: fmt.Sprintf("form response is for form '%s', the task waits for form '%s'", response.FormID, form.FormID),
+		}
+	}
+	if problems := types.ValidateFormData(form.Schema, response.Data); len(problems) > 0 {
+		return &JSONRPCMethodError{
+			Code:    int(ErrInvalidParams),
+			Message: fmt.Sprintf("invalid form data: %s", strings.Join(problems, "; ")),
+			Data:    map[string]any{"problems": problems},
+		}
+	}
+	return nil
+}
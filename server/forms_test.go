@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	sdk "github.com/inference-gateway/sdk"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// shippingForm is a form with a required city and an optional five digit zip code
+var shippingForm = types.FormRequest{
+	Title: "Shipping address",
+	Schema: map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"city": map[string]any{"type": "string", "minLength": 1},
+			"zip":  map[string]any{"type": "string", "pattern": "^[0-9]{5}$"},
+		},
+		"required":             []string{"city"},
+		"additionalProperties": false,
+	},
+}
+
+func TestAgentRun_PausesAndResumesWithForm(t *testing.T) {
+	llmClient := &scriptedLLMClient{responses: []*sdk.CreateChatCompletionStreamResponse{
+		toolCallChunk("call-1", "shipping_address"),
+		textChunk("Shipping to Berlin"),
+	}}
+	toolBox := NewDefaultToolBox(nil)
+	toolBox.AddTool(NewFormTool("shipping_address", "Asks the user for the shipping address", shippingForm))
+	agent, err := NewAgentBuilder(zap.NewNop()).WithLLMClient(llmClient).WithToolBox(toolBox).Build()
+	require.NoError(t, err)
+
+	messages := []types.Message{{MessageID: "m1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("ship my order")}}}
+	events, err := agent.RunWithStream(context.Background(), messages)
+	require.NoError(t, err)
+
+	var formMessage *types.Message
+	for event := range events {
+		if event.Type() == types.EventInputRequired {
+			formMessage = &types.Message{}
+			require.NoError(t, event.DataAs(formMessage))
+		}
+	}
+	require.NotNil(t, formMessage)
+	form, found, err := types.GetFormRequest(formMessage)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "shipping_address", form.FormID, "the form ID defaults to the tool name")
+	assert.Equal(t, "call-1", form.ToolCallID)
+	assert.Equal(t, "Shipping address", *formMessage.Parts[0].Text)
+
+	resume := types.Message{MessageID: "m2", Role: types.RoleUser, Parts: []types.Part{
+		types.NewFormResponsePart(form.FormID, map[string]any{"city": "Berlin"}),
+	}}
+	assert.Equal(t, types.TaskStateCompleted, runToCompletion(t, context.Background(), agent, append(messages, *formMessage, resume)))
+
+	require.Len(t, llmClient.calls, 2)
+	resumed := llmClient.calls[1]
+	toolResult := resumed[len(resumed)-1]
+	assert.Equal(t, sdk.Tool, toolResult.Role)
+	require.NotNil(t, toolResult.ToolCallID)
+	assert.Equal(t, "call-1", *toolResult.ToolCallID)
+	content, err := toolResult.Content.AsMessageContent0()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"city":"Berlin"}`, content)
+}
+
+func TestA2AServer_CheckFormResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+
+	form := shippingForm
+	form.FormID = "shipping"
+	task := s.taskManager.CreateTask("ctx-1", types.TaskStateInputRequired, types.NewFormRequestMessage("form", form))
+	response := func(formID string, data map[string]any) types.Message {
+		return types.Message{MessageID: "m2", Role: types.RoleUser, TaskID: &task.ID, Parts: []types.Part{types.NewFormResponsePart(formID, data)}}
+	}
+
+	assert.Nil(t, s.checkFormResponse(response("shipping", map[string]any{"city": "Berlin", "zip": "10115"})))
+
+	rejected := s.checkFormResponse(response("billing", map[string]any{"city": "Berlin"}))
+	require.NotNil(t, rejected)
+	assert.Equal(t, "form response is for form 'billing', the task waits for form 'shipping'", rejected.Message)
+
+	rejected = s.checkFormResponse(response("shipping", map[string]any{"zip": "1O115", "street": "Main St"}))
+	require.NotNil(t, rejected)
+	assert.Equal(t, int(ErrInvalidParams), rejected.Code)
+	assert.Equal(t, []string{
+		"missing required field city",
+		"unknown field street",
+		"zip must match the pattern ^[0-9]{5}$",
+	}, rejected.Data.(map[string]any)["problems"])
+
+	body := `{"jsonrpc":"2.0","id":"1","method":"message/send","params":{"message":{"messageId":"m3","role":"user","taskId":"` + task.ID +
+		`","parts":[{"data":{"data":{"form_response":{"form_id":"shipping","data":{"zip":"10115"}}}}}]}}}`
+	w := httptest.NewRecorder()
+	s.setupRouter(cfg).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+	assert.Contains(t, w.Body.String(), "invalid form data: missing required field city")
+
+	waiting, exists := s.taskManager.GetTask(task.ID)
+	require.True(t, exists)
+	assert.Equal(t, types.TaskStateInputRequired, waiting.Status.State, "the task keeps waiting for valid data")
+}
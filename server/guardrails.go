@@ -0,0 +1,370 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	uuid "github.com/google/uuid"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// Guardrail actions a filter can take on a piece of text
+const (
+	GuardrailActionAllow   = "allow"
+	GuardrailActionRewrite = "rewrite"
+	GuardrailActionFlag    = "flag"
+	GuardrailActionBlock   = "block"
+)
+
+// GuardrailResult is the verdict of a filter on a piece of text
+type GuardrailResult struct {
+	// Action is one of GuardrailActionAllow, GuardrailActionRewrite, GuardrailActionFlag or
+	// GuardrailActionBlock. A flagged text passes unchanged but is recorded like a rewrite.
+	Action string
+
+	// Text replaces the checked text when the action is GuardrailActionRewrite
+	Text string
+
+	// Reason explains a rewrite, flag or block, and is shown to the user when a message is blocked
+	Reason string
+
+	// Details are recorded with the finding, such as the categories a moderation endpoint flagged
+	Details any
+}
+
+// GuardrailFilter checks the text of a message and allows, rewrites or blocks it
+type GuardrailFilter interface {
+	// Name identifies the filter in logs and message metadata
+	Name() string
+
+	// Check returns the filter's verdict on the text
+	Check(ctx context.Context, text string) (GuardrailResult, error)
+}
+
+// GuardrailFinding records a filter that rewrote, flagged or blocked a message, in the message
+// and task metadata
+type GuardrailFinding struct {
+	Filter    string `json:"filter"`
+	Action    string `json:"action"`
+	Reason    string `json:"reason,omitempty"`
+	MessageID string `json:"messageId,omitempty"`
+	Details   any    `json:"details,omitempty"`
+}
+
+// GuardrailDecision is the outcome of running a filter chain on a message
+type GuardrailDecision struct {
+	// Blocked is true when a filter blocked the message
+	Blocked bool
+
+	// Reason is the reason given by the filter that blocked the message
+	Reason string
+
+	// Findings are the filters that rewrote, flagged or blocked the message, in order
+	Findings []GuardrailFinding
+}
+
+// guardrailFilterFunc adapts a function to the GuardrailFilter interface
+type guardrailFilterFunc struct {
+	name  string
+	check func(ctx context.Context, text string) (GuardrailResult, error)
+}
+
+func (f *guardrailFilterFunc) Name() string { return f.name }
+
+func (f *guardrailFilterFunc) Check(ctx context.Context, text string) (GuardrailResult, error) {
+	return f.check(ctx, text)
+}
+
+// NewGuardrailFilter creates a filter from a function
+func NewGuardrailFilter(name string, check func(ctx context.Context, text string) (GuardrailResult, error)) GuardrailFilter {
+	return &guardrailFilterFunc{name: name, check: check}
+}
+
+// Guardrails runs chains of filters on the messages users send before the agent sees them,
+// and on the agent's messages before they are returned to clients. Filters run in order on
+// every text part, each seeing the text as rewritten by the filters before it, and the first
+// filter to block a message stops the chain.
+type Guardrails struct {
+	logger *zap.Logger
+	input  []GuardrailFilter
+	output []GuardrailFilter
+}
+
+// NewGuardrails creates guardrails without filters
+func NewGuardrails(logger *zap.Logger) *Guardrails {
+	return &Guardrails{logger: logger}
+}
+
+// AddInputFilters appends filters to the chain run on incoming user messages
+func (g *Guardrails) AddInputFilters(filters ...GuardrailFilter) {
+	g.input = append(g.input, filters...)
+}
+
+// AddOutputFilters appends filters to the chain run on the agent's messages
+func (g *Guardrails) AddOutputFilters(filters ...GuardrailFilter) {
+	g.output = append(g.output, filters...)
+}
+
+// CheckInput runs the input filters on a user message, rewriting its text parts in place
+func (g *Guardrails) CheckInput(ctx context.Context, message *types.Message) GuardrailDecision {
+	if g == nil {
+		return GuardrailDecision{}
+	}
+	return g.check(ctx, g.input, message)
+}
+
+// CheckOutput runs the output filters on an agent message, rewriting its text parts in place.
+// A blocked message has its parts replaced by a notice that the response was withheld.
+func (g *Guardrails) CheckOutput(ctx context.Context, message *types.Message) GuardrailDecision {
+	if g == nil {
+		return GuardrailDecision{}
+	}
+
+	decision := g.check(ctx, g.output, message)
+	if decision.Blocked {
+		message.Parts = []types.Part{
+			types.CreateTextPart(fmt.Sprintf("This response was withheld: %s.", decision.Reason)),
+		}
+	}
+	return decision
+}
+
+// hasOutputFilters reports whether agent messages are filtered
+func (g *Guardrails) hasOutputFilters() bool {
+	return g != nil && len(g.output) > 0
+}
+
+// checkTaskOutput runs the output filters on the agent message in a task's status, and on
+// the same message in its history, recording the findings in the task metadata
+func (g *Guardrails) checkTaskOutput(ctx context.Context, task *types.Task) {
+	if !g.hasOutputFilters() || task == nil || task.Status.Message == nil || task.Status.Message.Role != types.RoleAgent {
+		return
+	}
+
+	message := *task.Status.Message
+	recordTaskFindings(task, g.CheckOutput(ctx, &message).Findings)
+	task.Status.Message = &message
+
+	for i := range task.History {
+		if task.History[i].MessageID == message.MessageID {
+			task.History[i] = message
+		}
+	}
+}
+
+// recordTaskFindings appends guardrail findings to those recorded in the task metadata
+func recordTaskFindings(task *types.Task, findings []GuardrailFinding) {
+	if len(findings) == 0 {
+		return
+	}
+
+	var recorded []GuardrailFinding
+	if task.Metadata != nil {
+		recorded = taskFindings((*task.Metadata)[types.GuardrailMetadataKey])
+	}
+	setTaskMetadata(task, types.GuardrailMetadataKey, append(recorded, findings...))
+}
+
+// taskFindings reads the findings recorded in task metadata, which are decoded from JSON
+// once the task has been stored
+func taskFindings(value any) []GuardrailFinding {
+	switch findings := value.(type) {
+	case nil:
+		return nil
+	case []GuardrailFinding:
+		return slices.Clone(findings)
+	default:
+		data, err := json.Marshal(findings)
+		if err != nil {
+			return nil
+		}
+		var decoded []GuardrailFinding
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return nil
+		}
+		return decoded
+	}
+}
+
+// refusal builds the agent message sent in place of a blocked user message
+func (g *Guardrails) refusal(message *types.Message, decision GuardrailDecision) *types.Message {
+	refusal := &types.Message{
+		MessageID: uuid.New().String(),
+		Role:      types.RoleAgent,
+		ContextID: message.ContextID,
+		TaskID:    message.TaskID,
+		Parts:     []types.Part{types.CreateTextPart(fmt.Sprintf("Your message was blocked: %s.", decision.Reason))},
+	}
+	setMessageMetadata(refusal, types.GuardrailMetadataKey, decision.Findings)
+	return refusal
+}
+
+// check runs a filter chain on the text parts of a message
+func (g *Guardrails) check(ctx context.Context, filters []GuardrailFilter, message *types.Message) GuardrailDecision {
+	var decision GuardrailDecision
+	if len(filters) == 0 {
+		return decision
+	}
+
+	parts := slices.Clone(message.Parts)
+	rewritten := false
+	finding := func(filter GuardrailFilter, result GuardrailResult) GuardrailFinding {
+		return GuardrailFinding{
+			Filter:    filter.Name(),
+			Action:    result.Action,
+			Reason:    result.Reason,
+			MessageID: message.MessageID,
+			Details:   result.Details,
+		}
+	}
+	for i, part := range parts {
+		if part.Text == nil {
+			continue
+		}
+
+		text := *part.Text
+		for _, filter := range filters {
+			result, err := filter.Check(ctx, text)
+			if err != nil {
+				g.logger.Error("guardrail filter failed, blocking message",
+					zap.String("filter", filter.Name()),
+					zap.String("message_id", message.MessageID),
+					zap.Error(err))
+				result = GuardrailResult{Action: GuardrailActionBlock, Reason: "the content could not be checked"}
+			}
+
+			switch result.Action {
+			case GuardrailActionRewrite:
+				text = result.Text
+				rewritten = true
+				decision.Findings = append(decision.Findings, finding(filter, result))
+			case GuardrailActionFlag:
+				decision.Findings = append(decision.Findings, finding(filter, result))
+				g.logger.Info("message flagged by guardrail",
+					zap.String("filter", filter.Name()),
+					zap.String("message_id", message.MessageID),
+					zap.String("reason", result.Reason))
+			case GuardrailActionBlock:
+				decision.Blocked = true
+				decision.Reason = result.Reason
+				decision.Findings = append(decision.Findings, finding(filter, result))
+
+				g.logger.Info("message blocked by guardrail",
+					zap.String("filter", filter.Name()),
+					zap.String("message_id", message.MessageID),
+					zap.String("reason", result.Reason))
+				setMessageMetadata(message, types.GuardrailMetadataKey, decision.Findings)
+				return decision
+			}
+		}
+		parts[i].Text = &text
+	}
+
+	if rewritten {
+		message.Parts = parts
+		g.logger.Debug("message rewritten by guardrails",
+			zap.String("message_id", message.MessageID),
+			zap.Int("findings", len(decision.Findings)))
+	}
+	if len(decision.Findings) > 0 {
+		setMessageMetadata(message, types.GuardrailMetadataKey, decision.Findings)
+	}
+	return decision
+}
+
+// defaultProfanity is the word list used by the profanity filter when none is given
+var defaultProfanity = []string{
+	"asshole", "bastard", "bitch", "bollocks", "bullshit", "cunt", "dickhead",
+	"fuck", "fucker", "fucking", "motherfucker", "prick", "shit", "slut", "twat", "wanker", "whore",
+}
+
+// NewProfanityFilter creates a filter for profane words, matched as whole words regardless of
+// case along with their plural and common suffixed forms. It masks them with asterisks, or
+// blocks the message when block is true. Without words, a built-in English list is used.
+func NewProfanityFilter(block bool, words ...string) GuardrailFilter {
+	if len(words) == 0 {
+		words = defaultProfanity
+	}
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = regexp.QuoteMeta(strings.ToLower(word))
+	}
+	pattern := regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)(?:s|es|ed|ing)?\b`)
+
+	return NewGuardrailFilter("profanity", func(ctx context.Context, text string) (GuardrailResult, error) {
+		if !pattern.MatchString(text) {
+			return GuardrailResult{Action: GuardrailActionAllow}, nil
+		}
+		if block {
+			return GuardrailResult{Action: GuardrailActionBlock, Reason: "it contains profanity"}, nil
+		}
+		return GuardrailResult{
+			Action: GuardrailActionRewrite,
+			Text: pattern.ReplaceAllStringFunc(text, func(match string) string {
+				return strings.Repeat("*", utf8.RuneCountInString(match))
+			}),
+			Reason: "masked profanity",
+		}, nil
+	})
+}
+
+// promptInjectionPatterns are phrasings commonly used to override an agent's instructions
+var promptInjectionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(?:ignore|disregard|forget|override)\b.{0,40}\b(?:previous|prior|above|earlier|preceding|all|your|system)\b.{0,20}\b(?:instructions?|prompts?|rules|directions|guidelines)\b`),
+	regexp.MustCompile(`(?i)\b(?:reveal|show|print|repeat|output|leak)\b.{0,30}\b(?:system|initial|hidden|original)\s+(?:prompt|instructions?|message)\b`),
+	regexp.MustCompile(`(?i)\byou\s+are\s+now\b.{0,40}\b(?:DAN|unrestricted|jailbroken|developer\s+mode|no\s+(?:rules|restrictions|limits))\b`),
+	regexp.MustCompile(`(?i)\b(?:enable|enter|activate)\s+(?:developer|god|jailbreak|DAN)\s+mode\b`),
+	regexp.MustCompile(`(?i)\b(?:act|respond|behave)\s+as\s+if\s+you\s+(?:have|had)\s+no\s+(?:rules|restrictions|guidelines|filters)\b`),
+	regexp.MustCompile(`(?i)</?\s*(?:system|assistant|instructions?)\s*>|\[/?(?:system|INST)\]|<\|im_start\|>`),
+}
+
+// NewPromptInjectionFilter creates a filter that blocks messages matching common prompt
+// injection phrasings, such as asking the agent to ignore its previous instructions, reveal
+// its system prompt or switch to an unrestricted mode. It is a heuristic: it catches the
+// common cases but not paraphrased or obfuscated attacks.
+func NewPromptInjectionFilter() GuardrailFilter {
+	return NewGuardrailFilter("prompt_injection", func(ctx context.Context, text string) (GuardrailResult, error) {
+		for _, pattern := range promptInjectionPatterns {
+			if pattern.MatchString(text) {
+				return GuardrailResult{Action: GuardrailActionBlock, Reason: "it looks like an attempt to override the agent's instructions"}, nil
+			}
+		}
+		return GuardrailResult{Action: GuardrailActionAllow}, nil
+	})
+}
+
+// NewMaxLengthFilter creates a filter for text longer than maxLength characters. It blocks
+// the message, or cuts the text down to maxLength characters at a word boundary when truncate
+// is true.
+func NewMaxLengthFilter(maxLength int, truncate bool) GuardrailFilter {
+	return NewGuardrailFilter("max_length", func(ctx context.Context, text string) (GuardrailResult, error) {
+		length := utf8.RuneCountInString(text)
+		if maxLength <= 0 || length <= maxLength {
+			return GuardrailResult{Action: GuardrailActionAllow}, nil
+		}
+		if !truncate {
+			return GuardrailResult{
+				Action: GuardrailActionBlock,
+				Reason: fmt.Sprintf("it is %d characters long, the limit is %d", length, maxLength),
+			}, nil
+		}
+
+		runes := []rune(text)[:maxLength]
+		if cut := strings.LastIndexFunc(string(runes), unicode.IsSpace); cut > 0 {
+			runes = []rune(strings.TrimRightFunc(string(runes)[:cut], unicode.IsSpace))
+		}
+		return GuardrailResult{
+			Action: GuardrailActionRewrite,
+			Text:   string(runes),
+			Reason: fmt.Sprintf("truncated to %d characters", maxLength),
+		}, nil
+	})
+}
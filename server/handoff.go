@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"os"
+	"slices"
+
+	uuid "github.com/google/uuid"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// newInstanceID identifies this server instance in checkpoints and handoff metadata,
+// preferring the configured ID and then the hostname, so handed off tasks can be traced back
+// to the replica that released them
+func newInstanceID(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		return hostname
+	}
+	return uuid.New().String()
+}
+
+// handoffEnabled reports whether background tasks are handed off to peers on shutdown
+func (s *A2AServerImpl) handoffEnabled() bool {
+	return s.cfg.QueueConfig.HandoffOnShutdown
+}
+
+// dequeueContext returns the context the task processor dequeues with. With task handoff
+// enabled it is cancelled as soon as the server starts draining, so a terminating replica
+// stops taking tasks from the shared queue, including the ones it hands off itself.
+func (s *A2AServerImpl) dequeueContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	dequeueCtx, cancel := context.WithCancel(ctx)
+	if !s.handoffEnabled() {
+		return dequeueCtx, cancel
+	}
+
+	go func() {
+		select {
+		case <-s.drain.drainingChan():
+			cancel()
+		case <-dequeueCtx.Done():
+		}
+	}()
+	return dequeueCtx, cancel
+}
+
+// checkpointTask copies a task as it was dequeued, so it can be handed off without the
+// partial progress a cancelled run adds to it
+func checkpointTask(task *types.Task) *types.Task {
+	checkpoint := *task
+	checkpoint.History = slices.Clone(task.History)
+	checkpoint.Artifacts = slices.Clone(task.Artifacts)
+	return &checkpoint
+}
+
+// handOffTask checkpoints a background task that could not finish before shutdown and
+// queues it again in the shared store, where a peer or the replacement replica resumes
+// it from its last user message
+func (s *A2AServerImpl) handOffTask(ctx context.Context, task *types.Task, requestID any, message *types.Message) {
+	logger := requestLogger(ctx, s.logger)
+	task.Status.State = types.TaskStateSubmitted
+	task.Status.Message = message
+
+	handoffCount := 0
+	metadata := make(map[string]any)
+	if task.Metadata != nil {
+		for key, value := range *task.Metadata {
+			metadata[key] = value
+		}
+		if count, ok := metadata[types.HandoffCountMetadataKey].(float64); ok {
+			handoffCount = int(count)
+		} else if count, ok := metadata[types.HandoffCountMetadataKey].(int); ok {
+			handoffCount = count
+		}
+	}
+	metadata[types.HandoffFromMetadataKey] = s.instanceID
+	metadata[types.HandoffCountMetadataKey] = handoffCount + 1
+	task.Metadata = &metadata
+	recordStateTransition(s.taskManager, task, types.TaskActorServer)
+
+	if err := s.taskManager.UpdateTask(task); err != nil {
+		logger.Error("failed to checkpoint task for handoff",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+	}
+	if err := s.storage.EnqueueTask(context.WithoutCancel(ctx), task, requestID); err != nil {
+		logger.Error("failed to hand off task",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+		return
+	}
+
+	logger.Info("task handed off for another instance to resume",
+		zap.String("task_id", task.ID),
+		zap.String("context_id", task.ContextID),
+		zap.String("instance_id", s.instanceID),
+		zap.Int("handoff_count", handoffCount+1))
+}
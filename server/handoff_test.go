@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// blockingTaskHandler completes tasks after the configured delay, or fails them when its
+// context is cancelled first
+type blockingTaskHandler struct {
+	finishAfter time.Duration
+	started     chan string
+	calls       atomic.Int32
+}
+
+func (h *blockingTaskHandler) HandleTask(ctx context.Context, task *types.Task, message *types.Message) (*types.Task, error) {
+	h.calls.Add(1)
+	task.History = append(task.History, types.Message{MessageID: "partial", Role: types.RoleAgent})
+	h.started <- task.ID
+
+	select {
+	case <-time.After(h.finishAfter):
+		task.Status.State = types.TaskStateCompleted
+		return task, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (h *blockingTaskHandler) SetAgent(OpenAICompatibleAgent) {}
+
+func (h *blockingTaskHandler) GetAgent() OpenAICompatibleAgent { return nil }
+
+func newHandoffTestServer(t *testing.T, handoff bool, finishAfter time.Duration) (*A2AServerImpl, *blockingTaskHandler) {
+	t.Helper()
+	cfg := &config.Config{
+		QueueConfig:  config.QueueConfig{HandoffOnShutdown: handoff},
+		ServerConfig: config.ServerConfig{DrainTimeout: 50 * time.Millisecond},
+	}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	handler := &blockingTaskHandler{finishAfter: finishAfter, started: make(chan string, 10)}
+	s.SetBackgroundTaskHandler(handler)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		s.StartTaskProcessor(ctx)
+		close(stopped)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+	return s, handler
+}
+
+func enqueueHandoffTestTask(t *testing.T, s *A2AServerImpl) *types.Task {
+	t.Helper()
+	task := s.taskManager.CreateTask("ctx-1", types.TaskStateSubmitted, &types.Message{
+		MessageID: "m1",
+		Role:      types.RoleUser,
+		Parts:     []types.Part{types.CreateTextPart("long running work")},
+	})
+	require.NoError(t, s.storage.EnqueueTask(context.Background(), task, "req-1"))
+	return task
+}
+
+func waitForTaskStart(t *testing.T, handler *blockingTaskHandler) {
+	t.Helper()
+	select {
+	case <-handler.started:
+	case <-time.After(time.Second):
+		t.Fatal("task was not picked up")
+	}
+}
+
+func TestA2AServer_StopHandsOffRunningTasks(t *testing.T) {
+	s, handler := newHandoffTestServer(t, true, time.Minute)
+	task := enqueueHandoffTestTask(t, s)
+	waitForTaskStart(t, handler)
+
+	require.NoError(t, s.Stop(context.Background()))
+
+	assert.Equal(t, int32(1), handler.calls.Load())
+	require.Equal(t, 1, s.storage.GetQueueLength())
+	queued, err := s.storage.(QueueInspector).PeekQueue(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+	assert.Equal(t, "req-1", queued[0].RequestID)
+
+	handedOff := queued[0].Task
+	assert.Equal(t, task.ID, handedOff.ID)
+	assert.Equal(t, types.TaskStateSubmitted, handedOff.Status.State)
+	require.NotNil(t, handedOff.Status.Message)
+	assert.Equal(t, "m1", handedOff.Status.Message.MessageID)
+	for _, message := range handedOff.History {
+		assert.NotEqual(t, "partial", message.MessageID)
+	}
+	require.NotNil(t, handedOff.Metadata)
+	assert.Equal(t, s.instanceID, (*handedOff.Metadata)[types.HandoffFromMetadataKey])
+	assert.Equal(t, 1, (*handedOff.Metadata)[types.HandoffCountMetadataKey])
+
+	stored, exists := s.taskManager.GetTask(task.ID)
+	require.True(t, exists)
+	assert.Equal(t, types.TaskStateSubmitted, stored.Status.State)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), handler.calls.Load(), "a draining instance must not pick up handed off tasks")
+}
+
+func TestA2AServer_StopLetsTasksFinishBeforeHandoff(t *testing.T) {
+	s, handler := newHandoffTestServer(t, true, 10*time.Millisecond)
+	task := enqueueHandoffTestTask(t, s)
+	waitForTaskStart(t, handler)
+
+	require.NoError(t, s.Stop(context.Background()))
+
+	assert.Zero(t, s.storage.GetQueueLength())
+	stored, exists := s.taskManager.GetTask(task.ID)
+	require.True(t, exists)
+	assert.Equal(t, types.TaskStateCompleted, stored.Status.State)
+}
+
+func TestA2AServer_StopWithoutHandoff(t *testing.T) {
+	s, handler := newHandoffTestServer(t, false, time.Minute)
+	enqueueHandoffTestTask(t, s)
+	waitForTaskStart(t, handler)
+
+	start := time.Now()
+	require.NoError(t, s.Stop(context.Background()))
+
+	assert.Less(t, time.Since(start), 40*time.Millisecond, "stop must not wait for background tasks")
+	assert.Zero(t, s.storage.GetQueueLength())
+}
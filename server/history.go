@@ -0,0 +1,117 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// retainHistory applies the history retention limits to a task's history and returns the
+// retained messages, the most recent ones. A tool result left at the start of the retained
+// history without the tool call it answers is dropped as well, since the agent cannot replay
+// it to the LLM. The latest message is always retained.
+func retainHistory(history []types.Message, cfg config.TaskHistoryConfig) []types.Message {
+	if !cfg.Enabled() || len(history) == 0 {
+		return history
+	}
+
+	retained := history
+	if cfg.DropToolMessages {
+		retained = make([]types.Message, 0, len(history))
+		for _, message := range history {
+			if !isToolMessage(message) {
+				retained = append(retained, message)
+			}
+		}
+	}
+
+	start := 0
+	if cfg.MaxMessages > 0 && len(retained) > cfg.MaxMessages {
+		start = len(retained) - cfg.MaxMessages
+	}
+	if cfg.MaxBytes > 0 {
+		size := 0
+		for i := len(retained) - 1; i >= start; i-- {
+			size += messageSize(retained[i])
+			if size > cfg.MaxBytes {
+				start = min(i+1, len(retained)-1)
+				break
+			}
+		}
+	}
+	for start < len(retained)-1 && isToolResultMessage(retained[start]) {
+		start++
+	}
+
+	if start == 0 && len(retained) == len(history) {
+		return history
+	}
+	return append([]types.Message(nil), retained[start:]...)
+}
+
+// limitHistory returns a copy of the task holding only the most recent historyLength
+// messages of its history, as requested with the historyLength parameter. The task is
+// returned unchanged without the parameter.
+func limitHistory(task types.Task, historyLength *int) types.Task {
+	if historyLength == nil || len(task.History) <= *historyLength {
+		return task
+	}
+	task.History = task.History[len(task.History)-max(*historyLength, 0):]
+	return task
+}
+
+// requestedHistoryLength returns the historyLength parameter of a message request
+func requestedHistoryLength(configuration *types.MessageSendConfiguration) *int {
+	if configuration == nil {
+		return nil
+	}
+	return configuration.HistoryLength
+}
+
+// validateHistoryLength checks the historyLength parameter of a request
+func validateHistoryLength(historyLength *int) error {
+	if historyLength != nil && *historyLength < 0 {
+		return fmt.Errorf("historyLength must not be negative")
+	}
+	return nil
+}
+
+// isToolMessage reports whether a message carries tool calls or tool results
+func isToolMessage(message types.Message) bool {
+	for _, part := range message.Parts {
+		if part.Data == nil {
+			continue
+		}
+		if _, ok := part.Data.Data["tool_call"]; ok {
+			return true
+		}
+		if _, ok := part.Data.Data["tool_calls"]; ok {
+			return true
+		}
+		if _, ok := part.Data.Data["tool_call_id"]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isToolResultMessage reports whether a message carries a tool result
+func isToolResultMessage(message types.Message) bool {
+	for _, part := range message.Parts {
+		if part.Data != nil && part.Data.Data["tool_call_id"] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// messageSize returns the JSON-encoded size of a message
+func messageSize(message types.Message) int {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func textMessage(id string, role types.Role, text string) types.Message {
+	return types.Message{MessageID: id, Role: role, Parts: []types.Part{types.CreateTextPart(text)}}
+}
+
+func messageIDs(history []types.Message) []string {
+	ids := make([]string, 0, len(history))
+	for _, message := range history {
+		ids = append(ids, message.MessageID)
+	}
+	return ids
+}
+
+func TestRetainHistory(t *testing.T) {
+	toolCall := types.Message{MessageID: "call", Role: types.RoleAgent, Parts: []types.Part{types.NewToolCallPart("c1", "search", nil)}}
+	toolResult := *types.NewToolResultMessage("c1", "search", "found", false)
+	history := []types.Message{
+		textMessage("user-1", types.RoleUser, "first question"),
+		toolCall,
+		toolResult,
+		textMessage("answer-1", types.RoleAgent, "first answer"),
+		textMessage("user-2", types.RoleUser, "second question"),
+	}
+
+	tests := []struct {
+		name     string
+		cfg      config.TaskHistoryConfig
+		expected []string
+	}{
+		{
+			name:     "no limits",
+			cfg:      config.TaskHistoryConfig{},
+			expected: []string{"user-1", "call", "tool-result-c1", "answer-1", "user-2"},
+		},
+		{
+			name:     "a tool result is not retained without its call",
+			cfg:      config.TaskHistoryConfig{MaxMessages: 3},
+			expected: []string{"answer-1", "user-2"},
+		},
+		{
+			name:     "max messages",
+			cfg:      config.TaskHistoryConfig{MaxMessages: 4},
+			expected: []string{"call", "tool-result-c1", "answer-1", "user-2"},
+		},
+		{
+			name:     "drop tool messages",
+			cfg:      config.TaskHistoryConfig{DropToolMessages: true},
+			expected: []string{"user-1", "answer-1", "user-2"},
+		},
+		{
+			name:     "max bytes",
+			cfg:      config.TaskHistoryConfig{MaxBytes: messageSize(history[3]) + messageSize(history[4])},
+			expected: []string{"answer-1", "user-2"},
+		},
+		{
+			name:     "the latest message is retained",
+			cfg:      config.TaskHistoryConfig{MaxBytes: 1},
+			expected: []string{"user-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, messageIDs(retainHistory(history, tt.cfg)))
+		})
+	}
+	assert.Len(t, history, 5, "the history is not modified")
+}
+
+func TestA2AServer_HistoryLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{TaskHistoryConfig: config.TaskHistoryConfig{MaxMessages: 3}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "assistant"})
+	router := s.setupRouter(cfg)
+
+	task := s.taskManager.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "user-1", Role: types.RoleUser})
+	for _, id := range []string{"answer-1", "user-2", "answer-2"} {
+		task.History = append(task.History, textMessage(id, types.RoleAgent, id))
+	}
+	require.NoError(t, s.taskManager.UpdateTask(task))
+
+	get := func(params string) (*types.Task, *types.JSONRPCError) {
+		body := `{"jsonrpc":"2.0","id":"1","method":"tasks/get","params":` + params + `}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+		var response struct {
+			Result *types.Task         `json:"result"`
+			Error  *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Result, response.Error
+	}
+
+	stored, rpcErr := get(`{"id":"` + task.ID + `"}`)
+	require.Nil(t, rpcErr)
+	assert.Equal(t, []string{"answer-1", "user-2", "answer-2"}, messageIDs(stored.History), "the stored history is retained up to the limit")
+
+	limited, rpcErr := get(`{"id":"` + task.ID + `","historyLength":1}`)
+	require.Nil(t, rpcErr)
+	assert.Equal(t, []string{"answer-2"}, messageIDs(limited.History))
+
+	_, rpcErr = get(`{"id":"` + task.ID + `","historyLength":-1}`)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, int(ErrInvalidParams), rpcErr.Code)
+}
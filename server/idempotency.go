@@ -0,0 +1,208 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// idempotencyEntry is a message/send request seen with an idempotency key. done is closed
+// once the request has been handled; body holds its response when it succeeded.
+type idempotencyEntry struct {
+	fingerprint [sha256.Size]byte
+	done        chan struct{}
+	body        []byte
+	expires     time.Time
+}
+
+// idempotencyCache remembers message/send requests by the Idempotency-Key header they carry,
+// so that a client retrying after a network failure gets the response of the first request
+// instead of creating a second task. Keys are remembered for the configured window, and
+// only on this instance.
+type idempotencyCache struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*idempotencyEntry
+}
+
+// newIdempotencyCache creates an idempotency cache, or returns nil when the window is not positive
+func newIdempotencyCache(window time.Duration) *idempotencyCache {
+	if window <= 0 {
+		return nil
+	}
+	return &idempotencyCache{
+		window:  window,
+		now:     time.Now,
+		entries: make(map[string]*idempotencyEntry),
+	}
+}
+
+// begin looks up a request by its key. It returns the entry of an earlier request with the
+// same key and parameters, or registers the request and reports that the caller owns it and
+// must call finish. A nil entry that is not owned means the key was reused for a different
+// request, which is then handled without deduplication.
+func (c *idempotencyCache) begin(key string, fingerprint [sha256.Size]byte) (*idempotencyEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for k, entry := range c.entries {
+		if entry.expired(now) {
+			delete(c.entries, k)
+		}
+	}
+
+	if entry, exists := c.entries[key]; exists {
+		if entry.fingerprint != fingerprint {
+			return nil, false
+		}
+		return entry, false
+	}
+
+	entry := &idempotencyEntry{fingerprint: fingerprint, done: make(chan struct{})}
+	c.entries[key] = entry
+	return entry, true
+}
+
+// finish records the response of an owned request. Failed requests are forgotten so that
+// a retry is handled again.
+func (c *idempotencyCache) finish(key string, entry *idempotencyEntry, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if body == nil {
+		delete(c.entries, key)
+	} else {
+		entry.body = body
+		entry.expires = c.now().Add(c.window)
+	}
+	close(entry.done)
+}
+
+// expired reports whether a finished entry has outlived its window
+func (e *idempotencyEntry) expired(now time.Time) bool {
+	return !e.expires.IsZero() && now.After(e.expires)
+}
+
+// wait blocks until the request of the entry has been handled and returns its response,
+// or false when it failed or the context was cancelled
+func (e *idempotencyEntry) wait(ctx context.Context) ([]byte, bool) {
+	select {
+	case <-e.done:
+		return e.body, e.body != nil
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+// idempotencyFingerprint hashes the parameters of a request
+func idempotencyFingerprint(params map[string]any) ([sha256.Size]byte, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(data), nil
+}
+
+// idempotencyCacheKey scopes an idempotency key to the user the request is made for, so a
+// user cannot replay the response of a request another user made with the same key
+func idempotencyCacheKey(ctx context.Context, key string) string {
+	return middlewares.UserIDFromContext(ctx) + "\x00" + key
+}
+
+// responseRecorder is a gin.ResponseWriter that keeps a copy of the body it writes
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// successBody returns the recorded body when it is a successful JSON-RPC response
+func (w *responseRecorder) successBody() []byte {
+	if w.Status() != http.StatusOK {
+		return nil
+	}
+	var response struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(w.body.Bytes(), &response); err != nil || response.Result == nil {
+		return nil
+	}
+	return w.body.Bytes()
+}
+
+// handleMessageSend processes a message/send request, replaying the response of an earlier
+// request with the same Idempotency-Key and parameters instead of creating another task
+func (s *A2AServerImpl) handleMessageSend(c *gin.Context, req types.JSONRPCRequest) {
+	key := c.GetHeader(types.IdempotencyKeyHeader)
+	if s.idempotency == nil || key == "" {
+		s.protocolHandler.HandleMessageSend(c, req)
+		return
+	}
+
+	fingerprint, err := idempotencyFingerprint(req.Params)
+	if err != nil {
+		s.protocolHandler.HandleMessageSend(c, req)
+		return
+	}
+
+	entry, owner := s.idempotency.begin(idempotencyCacheKey(c.Request.Context(), key), fingerprint)
+	if !owner {
+		if entry == nil {
+			requestLogger(c.Request.Context(), s.logger).Warn("idempotency key reused for a different request, handling it anew",
+				zap.String("idempotency_key", key))
+		} else if body, ok := entry.wait(c.Request.Context()); ok {
+			s.replayResponse(c, req, key, body)
+			return
+		}
+		s.protocolHandler.HandleMessageSend(c, req)
+		return
+	}
+
+	recorder := &responseRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+	defer func() {
+		c.Writer = recorder.ResponseWriter
+		s.idempotency.finish(idempotencyCacheKey(c.Request.Context(), key), entry, recorder.successBody())
+	}()
+	s.protocolHandler.HandleMessageSend(c, req)
+}
+
+// replayResponse answers a retried request with the recorded response, under the ID of the retry
+func (s *A2AServerImpl) replayResponse(c *gin.Context, req types.JSONRPCRequest, key string, body []byte) {
+	requestLogger(c.Request.Context(), s.logger).Info("replaying response to retried request", zap.String("idempotency_key", key))
+
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(body, &response); err == nil {
+		if id, err := json.Marshal(req.ID); err == nil {
+			response["id"] = id
+		}
+		if replayed, err := json.Marshal(response); err == nil {
+			body = replayed
+		}
+	}
+
+	c.Header(types.IdempotentReplayedHeader, "true")
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestIdempotencyCache(t *testing.T) {
+	now := time.Now()
+	cache := newIdempotencyCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	first := sha256.Sum256([]byte("first"))
+	second := sha256.Sum256([]byte("second"))
+
+	entry, owner := cache.begin("key-1", first)
+	require.True(t, owner)
+	cache.finish("key-1", entry, []byte(`{"result":{}}`))
+
+	replayed, owner := cache.begin("key-1", first)
+	assert.False(t, owner)
+	assert.Same(t, entry, replayed)
+
+	reused, owner := cache.begin("key-1", second)
+	assert.False(t, owner)
+	assert.Nil(t, reused)
+
+	failed, owner := cache.begin("key-2", first)
+	require.True(t, owner)
+	cache.finish("key-2", failed, nil)
+	_, owner = cache.begin("key-2", first)
+	assert.True(t, owner, "a failed request is handled again")
+
+	now = now.Add(2 * time.Minute)
+	_, owner = cache.begin("key-1", first)
+	assert.True(t, owner, "keys are forgotten after the window")
+
+	assert.Nil(t, newIdempotencyCache(0))
+}
+
+func TestA2AServer_IdempotentMessageSend(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{ServerConfig: config.ServerConfig{IdempotencyWindow: time.Minute}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "agent"})
+	router := s.setupRouter(cfg)
+
+	send := func(id, key, text string) (*httptest.ResponseRecorder, types.Task) {
+		body, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      id,
+			"method":  "message/send",
+			"params": map[string]any{
+				"message": map[string]any{
+					"kind":      "message",
+					"messageId": key,
+					"role":      "user",
+					"parts":     []map[string]any{{"kind": "text", "text": text}},
+				},
+			},
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/a2a", bytes.NewReader(body))
+		if key != "" {
+			req.Header.Set(types.IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			ID     string     `json:"id"`
+			Result types.Task `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, id, response.ID)
+		return w, response.Result
+	}
+
+	w, original := send("req-1", "msg-1", "hello")
+	assert.Empty(t, w.Header().Get(types.IdempotentReplayedHeader))
+
+	w, retried := send("req-2", "msg-1", "hello")
+	assert.Equal(t, "true", w.Header().Get(types.IdempotentReplayedHeader))
+	assert.Equal(t, original.ID, retried.ID)
+
+	_, reused := send("req-3", "msg-1", "something else")
+	assert.NotEqual(t, original.ID, reused.ID)
+
+	_, other := send("req-4", "msg-2", "hello")
+	assert.NotEqual(t, original.ID, other.ID)
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"strings"
+
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// Media types of the part kinds that do not declare one
+const (
+	textPartMediaType    = "text/plain"
+	dataPartMediaType    = "application/json"
+	defaultFileMediaType = "application/octet-stream"
+)
+
+// checkInputModes rejects messages with parts whose media type the agent does not accept.
+// The skill named by the skillId message metadata accepts its own input modes when it
+// declares any, otherwise the default input modes of the agent card apply. Callers reach
+// /a2a authenticated, so the extended agent card is used when one is set. Cards without
+// input modes accept every media type.
+func (s *A2AServerImpl) checkInputModes(ctx context.Context, message types.Message) *JSONRPCMethodError {
+	agentCard := s.authenticatedAgentCard()
+	if agentCard == nil {
+		return nil
+	}
+	resolved, err := s.resolveAgentCard(ctx, agentCard)
+	if err != nil {
+		requestLogger(ctx, s.logger).Warn("failed to resolve agent card for input mode validation, using the configured card", zap.Error(err))
+		resolved = agentCard
+	}
+
+	inputModes := resolved.DefaultInputModes
+	if message.Metadata != nil {
+		if skillID, ok := (*message.Metadata)[types.SkillIDMetadataKey].(string); ok && skillID != "" {
+			skill := findSkill(resolved.Skills, skillID)
+			if skill == nil {
+				return &JSONRPCMethodError{Code: int(ErrInvalidParams), Message: fmt.Sprintf("unknown skill '%s'", skillID)}
+			}
+			if len(skill.InputModes) > 0 {
+				inputModes = skill.InputModes
+			}
+		}
+	}
+	if len(inputModes) == 0 {
+		return nil
+	}
+
+	for _, part := range message.Parts {
+		mediaType := partMediaType(part)
+		if !acceptsMediaType(inputModes, mediaType) {
+			return &JSONRPCMethodError{
+				Code:    int(ErrContentTypeNotSupported),
+				Message: fmt.Sprintf("content type %s is not supported, accepted input modes are %s", mediaType, strings.Join(inputModes, ", ")),
+			}
+		}
+	}
+	return nil
+}
+
+// findSkill returns the skill with the given ID
+func findSkill(skills []types.AgentSkill, id string) *types.AgentSkill {
+	for i := range skills {
+		if skills[i].ID == id {
+			return &skills[i]
+		}
+	}
+	return nil
+}
+
+// partMediaType returns the media type of a message part. Text parts are text/plain and
+// data parts application/json; file parts without a media type are application/octet-stream.
+func partMediaType(part types.Part) string {
+	switch {
+	case part.File != nil:
+		if part.File.MediaType != "" {
+			return part.File.MediaType
+		}
+		return defaultFileMediaType
+	case part.Data != nil:
+		return dataPartMediaType
+	default:
+		return textPartMediaType
+	}
+}
+
+// acceptsMediaType reports whether a media type matches one of the input modes. Modes may
+// use wildcards such as image/* and */*, and media type parameters are ignored.
+func acceptsMediaType(inputModes []string, mediaType string) bool {
+	mediaType = baseMediaType(mediaType)
+	majorType, _, _ := strings.Cut(mediaType, "/")
+	for _, mode := range inputModes {
+		mode = baseMediaType(mode)
+		if mode == "*/*" || mode == mediaType || mode == majorType+"/*" {
+			return true
+		}
+	}
+	return false
+}
+
+// baseMediaType lowercases a media type and strips its parameters
+func baseMediaType(mediaType string) string {
+	if parsed, _, err := mime.ParseMediaType(mediaType); err == nil {
+		return parsed
+	}
+	base, _, _ := strings.Cut(mediaType, ";")
+	return strings.ToLower(strings.TrimSpace(base))
+}
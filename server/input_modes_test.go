@@ -0,0 +1,87 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestAcceptsMediaType(t *testing.T) {
+	tests := []struct {
+		modes     []string
+		mediaType string
+		accepted  bool
+	}{
+		{modes: []string{"text/plain"}, mediaType: "text/plain", accepted: true},
+		{modes: []string{"text/plain"}, mediaType: "Text/Plain; charset=utf-8", accepted: true},
+		{modes: []string{"text/plain"}, mediaType: "text/html", accepted: false},
+		{modes: []string{"image/*"}, mediaType: "image/png", accepted: true},
+		{modes: []string{"image/*"}, mediaType: "application/pdf", accepted: false},
+		{modes: []string{"text/plain", "*/*"}, mediaType: "application/pdf", accepted: true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.accepted, acceptsMediaType(tt.modes, tt.mediaType), "%v accepts %s", tt.modes, tt.mediaType)
+	}
+}
+
+func TestA2AServer_InputModes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newRouter := func(enforce bool) *gin.Engine {
+		cfg := &config.Config{EnforceInputModes: enforce}
+		s := NewA2AServer(cfg, zap.NewNop(), nil)
+		s.SetAgentCard(types.AgentCard{
+			Name:              "vision",
+			DefaultInputModes: []string{"text/plain", "application/json"},
+			Skills: []types.AgentSkill{
+				{ID: "describe-image", InputModes: []string{"image/*"}},
+				{ID: "chat"},
+			},
+		})
+		return s.setupRouter(cfg)
+	}
+	send := func(router *gin.Engine, metadata string, parts ...string) *types.JSONRPCError {
+		body := `{"jsonrpc":"2.0","id":"1","method":"message/send","params":{"message":{"messageId":"m1","role":"user","metadata":` +
+			metadata + `,"parts":[` + strings.Join(parts, ",") + `]}}}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+		var response struct {
+			Error *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Error
+	}
+	text := `{"text":"what is in this picture?"}`
+	data := `{"data":{"data":{"width":640}}}`
+	image := `{"file":{"name":"cat.png","mediaType":"image/png","fileWithUri":"https://example.com/cat.png"}}`
+
+	router := newRouter(true)
+	assert.Nil(t, send(router, `{}`, text, data))
+	assert.Nil(t, send(router, `{"skillId":"chat"}`, text), "skills without input modes accept the defaults")
+	assert.Nil(t, send(router, `{"skillId":"describe-image"}`, image))
+
+	rejected := send(router, `{}`, text, image)
+	require.NotNil(t, rejected)
+	assert.Equal(t, int(ErrContentTypeNotSupported), rejected.Code)
+	assert.Equal(t, "content type image/png is not supported, accepted input modes are text/plain, application/json", rejected.Message)
+
+	rejected = send(router, `{"skillId":"describe-image"}`, text, image)
+	require.NotNil(t, rejected)
+	assert.Equal(t, int(ErrContentTypeNotSupported), rejected.Code)
+
+	rejected = send(router, `{"skillId":"translate"}`, text)
+	require.NotNil(t, rejected)
+	assert.Equal(t, int(ErrInvalidParams), rejected.Code)
+	assert.Equal(t, "unknown skill 'translate'", rejected.Message)
+
+	assert.Nil(t, send(newRouter(false), `{}`, image))
+}
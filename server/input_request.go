@@ -0,0 +1,275 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// inputRequestFormats are the formats the input_required tool accepts
+var inputRequestFormats = []string{
+	types.InputFormatText,
+	types.InputFormatNumber,
+	types.InputFormatBoolean,
+	types.InputFormatDate,
+	types.InputFormatEmail,
+	types.InputFormatChoice,
+}
+
+// inputRequiredToolParameters is the JSON schema of the arguments of the input_required tool
+func inputRequiredToolParameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"question": map[string]any{
+				"type":        "string",
+				"description": "Clear, specific question explaining exactly what additional information you need from the user to complete their request. Be specific about what's missing and why it's needed.",
+			},
+			"expected_format": map[string]any{
+				"type":        "string",
+				"description": "The kind of answer expected, so the user can be shown a matching input. Use choice with the choices when the answer is one of a few options.",
+				"enum":        inputRequestFormats,
+			},
+			"choices": map[string]any{
+				"type":        "array",
+				"description": "The answers the user can pick from, only for the choice format",
+				"items":       map[string]any{"type": "string"},
+			},
+			"sensitive": map[string]any{
+				"type":        "boolean",
+				"description": "Whether the answer is sensitive, such as a password or an account number, and should be masked while the user types it",
+			},
+			"fields": map[string]any{
+				"type":        "array",
+				"description": "The pieces of information to collect when you need several, introduced by the question. The user may answer them over several messages, and you continue once every field is answered.",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"name":            map[string]any{"type": "string", "description": "Short identifier of the field, such as departure_date"},
+						"question":        map[string]any{"type": "string", "description": "Question asking the user for the field"},
+						"expected_format": map[string]any{"type": "string", "enum": inputRequestFormats},
+						"choices":         map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+						"sensitive":       map[string]any{"type": "boolean"},
+					},
+					"required": []string{"name", "question"},
+				},
+			},
+		},
+		"required": []string{"question"},
+	}
+}
+
+// parseInputRequest validates the arguments of an input_required tool call. The question was
+// the message argument in earlier versions of the tool, which is still accepted.
+func parseInputRequest(args map[string]any) (types.InputRequest, error) {
+	var request types.InputRequest
+
+	question, _ := args["question"].(string)
+	if strings.TrimSpace(question) == "" {
+		question, _ = args["message"].(string)
+	}
+	request.Question = strings.TrimSpace(question)
+	if request.Question == "" {
+		return request, fmt.Errorf("question is required")
+	}
+
+	var err error
+	request.ExpectedFormat, request.Choices, request.Sensitive, err = parseInputFormat(args)
+	if err != nil {
+		return request, err
+	}
+
+	if raw, exists := args["fields"]; exists && raw != nil {
+		values, ok := raw.([]any)
+		if !ok {
+			return request, fmt.Errorf("fields must be an array of objects")
+		}
+		for i, value := range values {
+			field, err := parseInputField(value)
+			if err != nil {
+				return request, fmt.Errorf("fields[%d]: %w", i, err)
+			}
+			if slices.ContainsFunc(request.Fields, func(f types.InputField) bool { return f.Name == field.Name }) {
+				return request, fmt.Errorf("fields[%d]: duplicate name %s", i, field.Name)
+			}
+			request.Fields = append(request.Fields, field)
+		}
+	}
+
+	return request, nil
+}
+
+// parseInputField validates a field of the input_required tool arguments
+func parseInputField(value any) (types.InputField, error) {
+	var field types.InputField
+	args, ok := value.(map[string]any)
+	if !ok {
+		return field, fmt.Errorf("field must be an object")
+	}
+
+	name, _ := args["name"].(string)
+	field.Name = strings.TrimSpace(name)
+	if field.Name == "" {
+		return field, fmt.Errorf("name is required")
+	}
+	question, _ := args["question"].(string)
+	field.Question = strings.TrimSpace(question)
+	if field.Question == "" {
+		return field, fmt.Errorf("question is required")
+	}
+
+	var err error
+	field.ExpectedFormat, field.Choices, field.Sensitive, err = parseInputFormat(args)
+	return field, err
+}
+
+// parseInputFormat validates the expected format, choices and sensitive flag of a question.
+// Choices without a format imply the choice format.
+func parseInputFormat(args map[string]any) (format string, choices []string, sensitive bool, err error) {
+	if raw, exists := args["expected_format"]; exists && raw != nil {
+		value, ok := raw.(string)
+		if !ok || !slices.Contains(inputRequestFormats, value) {
+			return "", nil, false, fmt.Errorf("expected_format must be one of %s", strings.Join(inputRequestFormats, ", "))
+		}
+		format = value
+	}
+
+	if raw, exists := args["choices"]; exists && raw != nil {
+		values, ok := raw.([]any)
+		if !ok {
+			return "", nil, false, fmt.Errorf("choices must be an array of strings")
+		}
+		for _, value := range values {
+			choice, ok := value.(string)
+			if !ok || strings.TrimSpace(choice) == "" {
+				return "", nil, false, fmt.Errorf("choices must be an array of strings")
+			}
+			choices = append(choices, choice)
+		}
+	}
+	if len(choices) > 0 && format == "" {
+		format = types.InputFormatChoice
+	}
+	switch {
+	case format == types.InputFormatChoice && len(choices) == 0:
+		return "", nil, false, fmt.Errorf("choices are required with the choice format")
+	case format != types.InputFormatChoice && len(choices) > 0:
+		return "", nil, false, fmt.Errorf("choices are only allowed with the choice format")
+	}
+
+	if raw, exists := args["sensitive"]; exists && raw != nil {
+		value, ok := raw.(bool)
+		if !ok {
+			return "", nil, false, fmt.Errorf("sensitive must be a boolean")
+		}
+		sensitive = value
+	}
+
+	return format, choices, sensitive, nil
+}
+
+// collectInputAnswers records the answers of a message resuming a task that waits for the
+// fields of an input request. Answers come from an input_answers data part, by field name, or
+// else the text of the message answers the first field missing an answer. While fields remain
+// unanswered, it returns the message asking for them, which pauses the task again. Once every
+// field is answered, the answers are added to the message, so the agent resumes with all of them.
+func (h *DefaultA2AProtocolHandler) collectInputAnswers(task *types.Task, message *types.Message) *types.Message {
+	request, found, err := types.GetInputRequest(task.Status.Message)
+	if err != nil || !found || len(request.Fields) == 0 {
+		return nil
+	}
+
+	answers := make(map[string]any, len(request.Fields))
+	maps.Copy(answers, request.Answers)
+	var invalid *types.InputField
+
+	given, found, err := types.GetInputAnswers(message)
+	switch {
+	case err == nil && found:
+		for _, field := range request.Fields {
+			answer, exists := given[field.Name]
+			switch {
+			case !exists:
+			case validInputAnswer(field, answer):
+				answers[field.Name] = answer
+			case invalid == nil:
+				invalid = &field
+			}
+		}
+	case strings.TrimSpace(message.Text()) != "":
+		if missing := request.MissingFields(); len(missing) > 0 {
+			answer := strings.TrimSpace(message.Text())
+			if validInputAnswer(missing[0], answer) {
+				answers[missing[0].Name] = answer
+			} else {
+				invalid = &missing[0]
+			}
+		}
+	}
+	request.Answers = answers
+
+	if len(request.MissingFields()) == 0 {
+		message.Parts = slices.DeleteFunc(message.Parts, func(part types.Part) bool {
+			return part.Data != nil && part.Data.Data[types.InputAnswersDataKey] != nil
+		})
+		summary := inputAnswersSummary(request)
+		if message.Text() != "" {
+			summary = "\n\n" + summary
+		}
+		message.Parts = append(message.Parts, types.CreateTextPart(summary), types.NewInputAnswersPart(answers))
+		return nil
+	}
+
+	prompt := types.NewInputRequestMessage(task.ID, *request)
+	prompt.MessageID = h.ids.NewID()
+	prompt.TaskID = &task.ID
+	prompt.ContextID = &task.ContextID
+	if invalid != nil {
+		format := invalid.ExpectedFormat
+		if format == "" || format == types.InputFormatText {
+			format = "answer"
+		}
+		prompt.Parts[0] = types.CreateTextPart(fmt.Sprintf("That is not a valid %s. %s", format, invalid.Question))
+	}
+	return prompt
+}
+
+// validInputAnswer reports whether an answer fits the expected format of a field
+func validInputAnswer(field types.InputField, answer any) bool {
+	text := strings.TrimSpace(fmt.Sprint(answer))
+	if answer == nil || text == "" {
+		return false
+	}
+	switch field.ExpectedFormat {
+	case types.InputFormatNumber:
+		_, err := strconv.ParseFloat(text, 64)
+		return err == nil
+	case types.InputFormatBoolean:
+		_, err := strconv.ParseBool(text)
+		return err == nil || slices.Contains([]string{"yes", "no"}, strings.ToLower(text))
+	case types.InputFormatDate:
+		_, err := time.Parse(time.DateOnly, text)
+		return err == nil
+	case types.InputFormatEmail:
+		_, err := mail.ParseAddress(text)
+		return err == nil
+	case types.InputFormatChoice:
+		return slices.Contains(field.Choices, text)
+	}
+	return true
+}
+
+// inputAnswersSummary lists the answers to the fields of a request, one field per line
+func inputAnswersSummary(request *types.InputRequest) string {
+	lines := make([]string, 0, len(request.Fields))
+	for _, field := range request.Fields {
+		lines = append(lines, fmt.Sprintf("%s: %v", field.Name, request.Answers[field.Name]))
+	}
+	return strings.Join(lines, "\n")
+}
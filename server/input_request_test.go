@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestParseInputRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    types.InputRequest
+		wantErr string
+	}{
+		{
+			name: "question only",
+			args: map[string]any{"question": " Which city? "},
+			want: types.InputRequest{Question: "Which city?"},
+		},
+		{
+			name: "message of earlier tool versions",
+			args: map[string]any{"message": "Which city?"},
+			want: types.InputRequest{Question: "Which city?"},
+		},
+		{
+			name: "structured request",
+			args: map[string]any{"question": "Your PIN?", "expected_format": "number", "sensitive": true},
+			want: types.InputRequest{Question: "Your PIN?", ExpectedFormat: types.InputFormatNumber, Sensitive: true},
+		},
+		{
+			name: "choices imply the choice format",
+			args: map[string]any{"question": "Which size?", "choices": []any{"small", "large"}},
+			want: types.InputRequest{Question: "Which size?", ExpectedFormat: types.InputFormatChoice, Choices: []string{"small", "large"}},
+		},
+		{
+			name:    "missing question",
+			args:    map[string]any{"expected_format": "text"},
+			wantErr: "question is required",
+		},
+		{
+			name:    "unknown format",
+			args:    map[string]any{"question": "When?", "expected_format": "datetime"},
+			wantErr: "expected_format must be one of",
+		},
+		{
+			name:    "choice format without choices",
+			args:    map[string]any{"question": "Which size?", "expected_format": "choice"},
+			wantErr: "choices are required",
+		},
+		{
+			name:    "choices with another format",
+			args:    map[string]any{"question": "How many?", "expected_format": "number", "choices": []any{"1", "2"}},
+			wantErr: "choices are only allowed",
+		},
+		{
+			name:    "sensitive flag that is not a boolean",
+			args:    map[string]any{"question": "Your PIN?", "sensitive": "yes"},
+			wantErr: "sensitive must be a boolean",
+		},
+		{
+			name: "fields",
+			args: map[string]any{"question": "I need a few details to book your flight.", "fields": []any{
+				map[string]any{"name": "destination", "question": "Where to?"},
+				map[string]any{"name": "date", "question": "When?", "expected_format": "date"},
+			}},
+			want: types.InputRequest{Question: "I need a few details to book your flight.", Fields: []types.InputField{
+				{Name: "destination", Question: "Where to?"},
+				{Name: "date", Question: "When?", ExpectedFormat: types.InputFormatDate},
+			}},
+		},
+		{
+			name: "field without a name",
+			args: map[string]any{"question": "Details please", "fields": []any{
+				map[string]any{"question": "Where to?"},
+			}},
+			wantErr: "fields[0]: name is required",
+		},
+		{
+			name: "duplicate fields",
+			args: map[string]any{"question": "Details please", "fields": []any{
+				map[string]any{"name": "date", "question": "When?"},
+				map[string]any{"name": "date", "question": "Which day?"},
+			}},
+			wantErr: "fields[1]: duplicate name date",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := parseInputRequest(tt.args)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, request)
+		})
+	}
+}
+
+// inputRequiredChunk returns a model response calling the input_required tool with arguments
+func inputRequiredChunk(id, arguments string) *sdk.CreateChatCompletionStreamResponse {
+	chunk := toolCallChunk(id, types.ToolInputRequired)
+	(*chunk.Choices[0].Delta.ToolCalls)[0].Function.Arguments = arguments
+	return chunk
+}
+
+func TestAgentRun_PausesWithStructuredInputRequest(t *testing.T) {
+	llmClient := &scriptedLLMClient{responses: []*sdk.CreateChatCompletionStreamResponse{
+		inputRequiredChunk("call-1", `{"question":"Which size?","expected_format":"number","choices":["small"]}`),
+		inputRequiredChunk("call-2", `{"question":"Which size?","choices":["small","large"]}`),
+	}}
+	agent, err := NewAgentBuilder(zap.NewNop()).WithLLMClient(llmClient).WithDefaultToolBox().Build()
+	require.NoError(t, err)
+
+	messages := []types.Message{{MessageID: "m1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("order a shirt")}}}
+	events, err := agent.RunWithStream(context.Background(), messages)
+	require.NoError(t, err)
+
+	var inputMessage *types.Message
+	for event := range events {
+		if event.Type() == types.EventInputRequired {
+			inputMessage = &types.Message{}
+			require.NoError(t, event.DataAs(inputMessage))
+		}
+	}
+
+	require.Len(t, llmClient.calls, 2, "an invalid input request is returned to the model as a tool error")
+	require.NotNil(t, inputMessage)
+	require.NotEmpty(t, inputMessage.Parts)
+	require.NotNil(t, inputMessage.Parts[0].Text)
+	assert.Equal(t, "Which size?", *inputMessage.Parts[0].Text)
+
+	request, found, err := types.GetInputRequest(inputMessage)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, types.InputFormatChoice, request.ExpectedFormat)
+	assert.Equal(t, []string{"small", "large"}, request.Choices)
+}
+
+func TestProtocolHandler_CollectsInputAnswers(t *testing.T) {
+	logger := zap.NewNop()
+	storage := NewInMemoryStorage(logger, 20)
+	taskManager := NewDefaultTaskManagerWithStorage(logger, storage)
+	h := NewDefaultA2AProtocolHandler(logger, storage, taskManager, NewDefaultResponseSender(logger))
+
+	task := taskManager.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "m1", Role: types.RoleUser})
+	require.NoError(t, taskManager.PauseTaskForInput(task.ID, types.NewInputRequestMessage("call-1", types.InputRequest{
+		Question: "I need a few details to book your flight.",
+		Fields: []types.InputField{
+			{Name: "destination", Question: "Where to?"},
+			{Name: "date", Question: "When?", ExpectedFormat: types.InputFormatDate},
+			{Name: "seats", Question: "How many seats?", ExpectedFormat: types.InputFormatNumber},
+		},
+	})))
+	reply := func(messageID string, parts ...types.Part) (*types.Task, *types.Message) {
+		t.Helper()
+		message := types.Message{MessageID: messageID, Role: types.RoleUser, TaskID: &task.ID, Parts: parts}
+		resumed, prompt, err := h.createTaskFromMessage(context.Background(), types.MessageSendParams{Message: message})
+		require.NoError(t, err)
+		return resumed, prompt
+	}
+
+	resumed, prompt := reply("m2", types.CreateTextPart("Lisbon"))
+	require.NotNil(t, prompt, "the task keeps waiting while fields are unanswered")
+	assert.Equal(t, types.TaskStateInputRequired, resumed.Status.State)
+	assert.Equal(t, "I need a few details to book your flight.\n\nWhen?", *prompt.Parts[0].Text)
+
+	_, prompt = reply("m3", types.CreateTextPart("next friday"))
+	require.NotNil(t, prompt)
+	assert.Equal(t, "That is not a valid date. When?", *prompt.Parts[0].Text)
+
+	_, prompt = reply("m4", types.NewInputAnswersPart(map[string]any{"date": "2026-11-06"}))
+	require.NotNil(t, prompt)
+	request, found, err := types.GetInputRequest(prompt)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, map[string]any{"destination": "Lisbon", "date": "2026-11-06"}, request.Answers)
+	assert.Equal(t, []types.InputField{{Name: "seats", Question: "How many seats?", ExpectedFormat: types.InputFormatNumber}}, request.MissingFields())
+
+	resumed, prompt = reply("m5", types.CreateTextPart("2"))
+	assert.Nil(t, prompt, "the task resumes once every field is answered")
+	assert.Equal(t, types.TaskStateWorking, resumed.Status.State)
+
+	last := resumed.History[len(resumed.History)-1]
+	assert.Equal(t, "m5", last.MessageID)
+	assert.Equal(t, "2\n\ndestination: Lisbon\ndate: 2026-11-06\nseats: 2", last.Text())
+	answers, found, err := types.GetInputAnswers(&last)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, map[string]any{"destination": "Lisbon", "date": "2026-11-06", "seats": "2"}, answers)
+}
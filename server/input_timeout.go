@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// startInputTimeout periodically expires the tasks left waiting for input longer than the
+// input timeout
+func (s *A2AServerImpl) startInputTimeout(ctx context.Context) {
+	cfg := s.cfg.InputTimeoutConfig
+	if !cfg.Enabled() {
+		return
+	}
+
+	ticker := time.NewTicker(cfg.CheckInterval)
+	defer ticker.Stop()
+
+	job := newPeriodicJob("input_timeout", cfg.CheckInterval, s.clock.Now())
+	s.inputTimeoutJob.Store(job)
+	defer s.inputTimeoutJob.Store(nil)
+
+	s.logger.Info("input timeout enabled",
+		zap.Duration("timeout", cfg.Timeout),
+		zap.String("action", cfg.Action))
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("input timeout shutting down")
+			return
+		case <-ticker.C:
+			s.expireInputRequiredTasks(s.clock.Now())
+			job.ran(s.clock.Now())
+		}
+	}
+}
+
+// expireInputRequiredTasks ends the tasks that have waited for input longer than the input
+// timeout at now, and returns how many expired. Expired tasks end in the state of the
+// configured action, which notifies their push notification subscribers, and a resumed
+// execution still registered for them is stopped.
+func (s *A2AServerImpl) expireInputRequiredTasks(now time.Time) int {
+	cfg := s.cfg.InputTimeoutConfig
+	state := types.TaskStateInputRequired
+	tasks, err := s.storage.ListTasks(TaskFilter{State: &state})
+	if err != nil {
+		s.logger.Error("failed to list tasks waiting for input", zap.Error(err))
+		return 0
+	}
+
+	expired := 0
+	for _, task := range tasks {
+		if task.Status.Timestamp == nil || now.Sub(*task.Status.Timestamp) < cfg.Timeout {
+			continue
+		}
+
+		task.Status.State = types.TaskStateCancelled
+		if cfg.Action == config.InputTimeoutActionFail {
+			task.Status.State = types.TaskStateFailed
+		}
+		task.Status.Message = types.NewAssistantMessage(s.ids.NewID(), []types.Part{
+			types.CreateTextPart(fmt.Sprintf("Task expired after waiting %s for input", cfg.Timeout)),
+		})
+
+		if defaultTM, ok := s.taskManager.(*DefaultTaskManager); ok {
+			defaultTM.stopRunningTask(task.ID)
+			defaultTM.recordTransition(task, types.TaskActorServer)
+		}
+		if err := s.taskManager.UpdateTask(task); err != nil {
+			s.logger.Error("failed to expire task waiting for input",
+				zap.String("task_id", task.ID),
+				zap.Error(err))
+			continue
+		}
+
+		s.logger.Info("task waiting for input expired",
+			zap.String("task_id", task.ID),
+			zap.String("context_id", task.ContextID),
+			zap.String("state", string(task.Status.State)))
+		expired++
+	}
+	return expired
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestA2AServer_ExpireInputRequiredTasks(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   string
+		expected types.TaskState
+	}{
+		{name: "cancel", action: config.InputTimeoutActionCancel, expected: types.TaskStateCancelled},
+		{name: "fail", action: config.InputTimeoutActionFail, expected: types.TaskStateFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{InputTimeoutConfig: config.InputTimeoutConfig{
+				Timeout:       time.Minute,
+				Action:        tt.action,
+				CheckInterval: time.Second,
+			}}
+			s := NewA2AServer(cfg, zap.NewNop(), nil)
+
+			waiting := s.taskManager.CreateTask("ctx-1", types.TaskStateInputRequired, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+			working := s.taskManager.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "m-2", Role: types.RoleUser})
+			stopped := false
+			s.taskManager.(*DefaultTaskManager).RegisterTaskCancelFunc(waiting.ID, func() { stopped = true })
+
+			assert.Equal(t, 0, s.expireInputRequiredTasks(time.Now()), "tasks within the timeout are kept")
+			assert.Equal(t, 1, s.expireInputRequiredTasks(time.Now().Add(2*time.Minute)))
+
+			expired, exists := s.taskManager.GetTask(waiting.ID)
+			require.True(t, exists)
+			assert.Equal(t, tt.expected, expired.Status.State)
+			require.NotNil(t, expired.Status.Message)
+			assert.Equal(t, "Task expired after waiting 1m0s for input", *expired.Status.Message.Parts[0].Text)
+			assert.True(t, stopped, "the execution registered for the task is stopped")
+
+			untouched, exists := s.taskManager.GetTask(working.ID)
+			require.True(t, exists)
+			assert.Equal(t, types.TaskStateWorking, untouched.Status.State)
+		})
+	}
+}
+
+type fixedIDs string
+
+func (id fixedIDs) NewID() string {
+	return string(id)
+}
+
+type stoppedClock time.Time
+
+func (c stoppedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestA2AServer_ExpireInputRequiredTasks_UsesInjectedDependencies(t *testing.T) {
+	cfg := &config.Config{InputTimeoutConfig: config.InputTimeoutConfig{
+		Timeout: time.Minute,
+		Action:  config.InputTimeoutActionFail,
+	}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	builder := &A2AServerBuilderImpl{clock: stoppedClock(now), ids: fixedIDs("id-1")}
+	builder.configureDependencies(s)
+
+	waiting := s.taskManager.CreateTask("ctx-1", types.TaskStateInputRequired, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+	assert.Equal(t, 1, s.expireInputRequiredTasks(now.Add(2*time.Minute)))
+
+	expired, exists := s.taskManager.GetTask(waiting.ID)
+	require.True(t, exists)
+	require.NotNil(t, expired.Status.Message)
+	assert.Equal(t, "id-1", expired.Status.Message.MessageID)
+	require.NotNil(t, expired.Status.Timestamp)
+	assert.Equal(t, now, *expired.Status.Timestamp)
+}
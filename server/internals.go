@@ -0,0 +1,353 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	sdkotel "go.opentelemetry.io/otel"
+	attribute "go.opentelemetry.io/otel/attribute"
+	metric "go.opentelemetry.io/otel/metric"
+	zap "go.uber.org/zap"
+)
+
+// defaultDumpLimit bounds how many queued tasks the debug dump lists when no limit is given
+const defaultDumpLimit = 100
+
+// QueueStats describes the tasks waiting in the queue. The queue has a single priority
+// level, so the depth covers every waiting task.
+type QueueStats struct {
+	Depth             int        `json:"depth"`
+	OldestEnqueuedAt  *time.Time `json:"oldest_enqueued_at,omitempty"`
+	OldestWaitSeconds float64    `json:"oldest_wait_seconds"`
+}
+
+// WorkerStats describes the background task processor
+type WorkerStats struct {
+	Workers        int        `json:"workers"`
+	Paused         bool       `json:"paused"`
+	Busy           int        `json:"busy"`
+	CurrentTaskID  string     `json:"current_task_id,omitempty"`
+	BusySince      *time.Time `json:"busy_since,omitempty"`
+	TasksProcessed int64      `json:"tasks_processed"`
+	// Utilization is the fraction of time spent processing tasks since the processor started
+	Utilization float64 `json:"utilization"`
+}
+
+// ScheduledJobStats describes a periodic background job
+type ScheduledJobStats struct {
+	Name            string     `json:"name"`
+	IntervalSeconds float64    `json:"interval_seconds"`
+	LastRunAt       *time.Time `json:"last_run_at,omitempty"`
+	NextRunAt       time.Time  `json:"next_run_at"`
+}
+
+// InternalStats is a snapshot of the queue, worker and scheduler state of the server
+type InternalStats struct {
+	CollectedAt time.Time           `json:"collected_at"`
+	Queue       QueueStats          `json:"queue"`
+	Workers     WorkerStats         `json:"workers"`
+	Scheduler   []ScheduledJobStats `json:"scheduler"`
+	// DroppedEvents counts the events dropped by channel because their consumer was not
+	// receiving, such as agent_stream and event_sink
+	DroppedEvents map[string]int64 `json:"dropped_events"`
+}
+
+// QueuedTaskSummary describes a task waiting in the queue
+type QueuedTaskSummary struct {
+	TaskID      string     `json:"task_id"`
+	ContextID   string     `json:"context_id"`
+	State       string     `json:"state"`
+	RequestID   any        `json:"request_id,omitempty"`
+	EnqueuedAt  *time.Time `json:"enqueued_at,omitempty"`
+	WaitSeconds float64    `json:"wait_seconds"`
+}
+
+// InternalDump is the debug dump of the server internals, listing the waiting tasks
+type InternalDump struct {
+	InternalStats
+	Storage     StorageStats        `json:"storage"`
+	QueuedTasks []QueuedTaskSummary `json:"queued_tasks"`
+}
+
+// periodicJob tracks when a periodic background job last ran and runs next
+type periodicJob struct {
+	name     string
+	interval time.Duration
+
+	mu      sync.Mutex
+	lastRun time.Time
+	nextRun time.Time
+}
+
+// newPeriodicJob creates a job scheduled to run one interval after now
+func newPeriodicJob(name string, interval time.Duration, now time.Time) *periodicJob {
+	return &periodicJob{name: name, interval: interval, nextRun: now.Add(interval)}
+}
+
+// ran records a run of the job at now and schedules the next one
+func (j *periodicJob) ran(now time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.lastRun = now
+	j.nextRun = now.Add(j.interval)
+}
+
+// stats returns the schedule of the job
+func (j *periodicJob) stats() ScheduledJobStats {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	stats := ScheduledJobStats{
+		Name:            j.name,
+		IntervalSeconds: j.interval.Seconds(),
+		NextRunAt:       j.nextRun,
+	}
+	if !j.lastRun.IsZero() {
+		lastRun := j.lastRun
+		stats.LastRunAt = &lastRun
+	}
+	return stats
+}
+
+// workerTracker measures how busy the background task processor is
+type workerTracker struct {
+	mu        sync.Mutex
+	startedAt time.Time
+	busySince time.Time
+	taskID    string
+	busyTotal time.Duration
+	processed int64
+}
+
+// start records that the task processor started at now
+func (w *workerTracker) start(now time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.startedAt = now
+}
+
+// begin records that the worker picked up a task at now
+func (w *workerTracker) begin(taskID string, now time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.taskID = taskID
+	w.busySince = now
+}
+
+// end records that the worker finished its task at now
+func (w *workerTracker) end(now time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.busySince.IsZero() {
+		w.busyTotal += now.Sub(w.busySince)
+	}
+	w.taskID = ""
+	w.busySince = time.Time{}
+	w.processed++
+}
+
+// stats returns the worker state at now
+func (w *workerTracker) stats(now time.Time) WorkerStats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	stats := WorkerStats{Workers: 1, TasksProcessed: w.processed}
+	busyTotal := w.busyTotal
+	if !w.busySince.IsZero() {
+		busySince := w.busySince
+		stats.Busy = 1
+		stats.CurrentTaskID = w.taskID
+		stats.BusySince = &busySince
+		busyTotal += now.Sub(w.busySince)
+	}
+	if !w.startedAt.IsZero() {
+		if uptime := now.Sub(w.startedAt); uptime > 0 {
+			stats.Utilization = min(busyTotal.Seconds()/uptime.Seconds(), 1)
+		}
+	}
+	return stats
+}
+
+// scheduledJobs returns the periodic background jobs of the server
+func (s *A2AServerImpl) scheduledJobs() []*periodicJob {
+	var jobs []*periodicJob
+	if job := s.queueCleanupJob.Load(); job != nil {
+		jobs = append(jobs, job)
+	}
+	if job := s.inputTimeoutJob.Load(); job != nil {
+		jobs = append(jobs, job)
+	}
+	if job := s.scheduleJob.Load(); job != nil {
+		jobs = append(jobs, job)
+	}
+	if job := s.dependencyJob.Load(); job != nil {
+		jobs = append(jobs, job)
+	}
+	if job := s.recoveryJob.Load(); job != nil {
+		jobs = append(jobs, job)
+	}
+	if defaultTM, ok := s.taskManager.(*DefaultTaskManager); ok {
+		if job := defaultTM.retentionJob.Load(); job != nil {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// peekQueue lists up to limit waiting tasks when the storage backend supports it
+func (s *A2AServerImpl) peekQueue(ctx context.Context, limit int) []*QueuedTask {
+	inspector, ok := s.storage.(QueueInspector)
+	if !ok {
+		return nil
+	}
+	queued, err := inspector.PeekQueue(ctx, limit)
+	if err != nil {
+		s.logger.Warn("failed to inspect task queue", zap.Error(err))
+		return nil
+	}
+	return queued
+}
+
+// collectInternalStats takes a snapshot of the queue, worker and scheduler state
+func (s *A2AServerImpl) collectInternalStats(ctx context.Context) InternalStats {
+	now := s.clock.Now()
+	stats := InternalStats{
+		CollectedAt:   now,
+		Queue:         QueueStats{Depth: s.storage.GetQueueLength()},
+		Workers:       s.workers.stats(now),
+		Scheduler:     []ScheduledJobStats{},
+		DroppedEvents: droppedEvents.snapshot(),
+	}
+
+	stats.Workers.Paused = s.queuePause.isPaused()
+	if oldest := s.peekQueue(ctx, 1); len(oldest) > 0 && !oldest[0].EnqueuedAt.IsZero() {
+		enqueuedAt := oldest[0].EnqueuedAt
+		stats.Queue.OldestEnqueuedAt = &enqueuedAt
+		stats.Queue.OldestWaitSeconds = now.Sub(enqueuedAt).Seconds()
+	}
+
+	for _, job := range s.scheduledJobs() {
+		stats.Scheduler = append(stats.Scheduler, job.stats())
+	}
+	return stats
+}
+
+// handleDebugStats serves a snapshot of the queue, worker and scheduler state
+func (s *A2AServerImpl) handleDebugStats(c *gin.Context) {
+	c.JSON(http.StatusOK, s.collectInternalStats(c.Request.Context()))
+}
+
+// handleDebugDump serves the internal state together with the tasks waiting in the queue,
+// up to the limit query parameter
+func (s *A2AServerImpl) handleDebugDump(c *gin.Context) {
+	limit := defaultDumpLimit
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a non-negative integer"})
+			return
+		}
+		limit = parsed
+	}
+
+	dump := InternalDump{
+		InternalStats: s.collectInternalStats(c.Request.Context()),
+		Storage:       s.storage.GetStats(),
+		QueuedTasks:   []QueuedTaskSummary{},
+	}
+	for _, queued := range s.peekQueue(c.Request.Context(), limit) {
+		if queued.Task == nil {
+			continue
+		}
+		summary := QueuedTaskSummary{
+			TaskID:    queued.Task.ID,
+			ContextID: queued.Task.ContextID,
+			State:     string(queued.Task.Status.State),
+			RequestID: queued.RequestID,
+		}
+		if !queued.EnqueuedAt.IsZero() {
+			enqueuedAt := queued.EnqueuedAt
+			summary.EnqueuedAt = &enqueuedAt
+			summary.WaitSeconds = dump.CollectedAt.Sub(enqueuedAt).Seconds()
+		}
+		dump.QueuedTasks = append(dump.QueuedTasks, summary)
+	}
+
+	c.JSON(http.StatusOK, dump)
+}
+
+// registerInternalMetrics reports the queue, worker and scheduler state as gauges, and the
+// dropped events as a counter
+func (s *A2AServerImpl) registerInternalMetrics() error {
+	meter := sdkotel.GetMeterProvider().Meter("github.com/inference-gateway/adk/server")
+
+	queueDepth, err := meter.Int64ObservableGauge(
+		"a2a.queue.depth",
+		metric.WithDescription("Number of tasks waiting in the queue"),
+		metric.WithUnit("{task}"),
+	)
+	if err != nil {
+		return err
+	}
+	oldestWait, err := meter.Float64ObservableGauge(
+		"a2a.queue.oldest_wait",
+		metric.WithDescription("Time the oldest waiting task has spent in the queue"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+	utilization, err := meter.Float64ObservableGauge(
+		"a2a.worker.utilization",
+		metric.WithDescription("Fraction of time the task processor spent processing tasks"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return err
+	}
+	busyWorkers, err := meter.Int64ObservableGauge(
+		"a2a.worker.busy",
+		metric.WithDescription("Number of workers processing a task"),
+		metric.WithUnit("{worker}"),
+	)
+	if err != nil {
+		return err
+	}
+	nextRun, err := meter.Float64ObservableGauge(
+		"a2a.scheduler.next_run",
+		metric.WithDescription("Time until the next run of a periodic background job"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+	dropped, err := meter.Int64ObservableCounter(
+		"a2a.events.dropped",
+		metric.WithDescription("Number of events dropped because their consumer was not receiving"),
+		metric.WithUnit("{event}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		stats := s.collectInternalStats(ctx)
+		o.ObserveInt64(queueDepth, int64(stats.Queue.Depth))
+		o.ObserveFloat64(oldestWait, stats.Queue.OldestWaitSeconds)
+		o.ObserveFloat64(utilization, stats.Workers.Utilization)
+		o.ObserveInt64(busyWorkers, int64(stats.Workers.Busy))
+		for _, job := range stats.Scheduler {
+			o.ObserveFloat64(nextRun, max(job.NextRunAt.Sub(stats.CollectedAt).Seconds(), 0),
+				metric.WithAttributes(attribute.String("job", job.Name)))
+		}
+		for channel, count := range stats.DroppedEvents {
+			o.ObserveInt64(dropped, count, metric.WithAttributes(attribute.String("channel", channel)))
+		}
+		return nil
+	}, queueDepth, oldestWait, utilization, busyWorkers, nextRun, dropped)
+	return err
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestWorkerTracker_Stats(t *testing.T) {
+	start := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	var workers workerTracker
+
+	assert.Equal(t, WorkerStats{Workers: 1}, workers.stats(start))
+
+	workers.start(start)
+	workers.begin("task-1", start.Add(10*time.Second))
+	workers.end(start.Add(40 * time.Second))
+	workers.begin("task-2", start.Add(80*time.Second))
+
+	stats := workers.stats(start.Add(100 * time.Second))
+	assert.Equal(t, 1, stats.Busy)
+	assert.Equal(t, "task-2", stats.CurrentTaskID)
+	require.NotNil(t, stats.BusySince)
+	assert.Equal(t, start.Add(80*time.Second), *stats.BusySince)
+	assert.Equal(t, int64(1), stats.TasksProcessed)
+	assert.InDelta(t, 0.5, stats.Utilization, 0.0001)
+
+	workers.end(start.Add(100 * time.Second))
+	stats = workers.stats(start.Add(100 * time.Second))
+	assert.Zero(t, stats.Busy)
+	assert.Empty(t, stats.CurrentTaskID)
+	assert.Equal(t, int64(2), stats.TasksProcessed)
+}
+
+func TestPeriodicJob_Stats(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	job := newPeriodicJob("task_cleanup", 2*time.Minute, now)
+
+	stats := job.stats()
+	assert.Equal(t, "task_cleanup", stats.Name)
+	assert.Equal(t, 120.0, stats.IntervalSeconds)
+	assert.Nil(t, stats.LastRunAt)
+	assert.Equal(t, now.Add(2*time.Minute), stats.NextRunAt)
+
+	job.ran(now.Add(2 * time.Minute))
+	stats = job.stats()
+	require.NotNil(t, stats.LastRunAt)
+	assert.Equal(t, now.Add(2*time.Minute), *stats.LastRunAt)
+	assert.Equal(t, now.Add(4*time.Minute), stats.NextRunAt)
+}
+
+func TestInMemoryStorage_PeekQueue(t *testing.T) {
+	storage := NewInMemoryStorage(zap.NewNop(), 0)
+	for _, id := range []string{"first", "second", "third"} {
+		require.NoError(t, storage.EnqueueTask(context.Background(), &types.Task{ID: id, ContextID: "ctx-1"}, id))
+	}
+
+	queued, err := storage.PeekQueue(context.Background(), 2)
+	require.NoError(t, err)
+	require.Len(t, queued, 2)
+	assert.Equal(t, "first", queued[0].Task.ID)
+	assert.Equal(t, "second", queued[1].Task.ID)
+	assert.False(t, queued[0].EnqueuedAt.IsZero())
+
+	all, err := storage.PeekQueue(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+	assert.Equal(t, 3, storage.GetQueueLength())
+}
+
+func newDebugTestServer(t *testing.T, enabled bool) (*A2AServerImpl, *httptest.Server) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		ServerConfig: config.ServerConfig{EnableDebugEndpoints: enabled},
+		TaskRetentionConfig: config.TaskRetentionConfig{
+			MaxCompletedTasks: 10,
+			CleanupInterval:   time.Hour,
+		},
+	}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	if defaultTM, ok := s.taskManager.(*DefaultTaskManager); ok {
+		defaultTM.SetRetentionConfig(cfg.TaskRetentionConfig)
+		t.Cleanup(defaultTM.StopCleanup)
+	}
+
+	httpServer := httptest.NewServer(s.setupRouter(cfg))
+	t.Cleanup(httpServer.Close)
+	return s, httpServer
+}
+
+func getDebugJSON(t *testing.T, url string, target any) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	if target != nil && resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(target))
+	}
+	return resp.StatusCode
+}
+
+func TestA2AServer_DebugEndpoints(t *testing.T) {
+	s, httpServer := newDebugTestServer(t, true)
+	for _, id := range []string{"task-1", "task-2", "task-3"} {
+		task := &types.Task{ID: id, ContextID: "ctx-1", Status: types.TaskStatus{State: types.TaskStateSubmitted}}
+		require.NoError(t, s.storage.EnqueueTask(context.Background(), task, "req-"+id))
+	}
+	s.workers.start(time.Now().Add(-time.Minute))
+	s.workers.begin("task-0", time.Now().Add(-30*time.Second))
+
+	var stats InternalStats
+	require.Equal(t, http.StatusOK, getDebugJSON(t, httpServer.URL+"/debug/stats", &stats))
+	assert.Equal(t, 3, stats.Queue.Depth)
+	require.NotNil(t, stats.Queue.OldestEnqueuedAt)
+	assert.GreaterOrEqual(t, stats.Queue.OldestWaitSeconds, 0.0)
+	assert.Equal(t, 1, stats.Workers.Busy)
+	assert.Equal(t, "task-0", stats.Workers.CurrentTaskID)
+	assert.InDelta(t, 0.5, stats.Workers.Utilization, 0.05)
+	require.Len(t, stats.Scheduler, 1)
+	assert.Equal(t, "retention_cleanup", stats.Scheduler[0].Name)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), stats.Scheduler[0].NextRunAt, time.Minute)
+	assert.NotNil(t, stats.DroppedEvents)
+
+	var dump InternalDump
+	require.Equal(t, http.StatusOK, getDebugJSON(t, httpServer.URL+"/debug/dump?limit=2", &dump))
+	assert.Equal(t, 3, dump.Queue.Depth)
+	require.Len(t, dump.QueuedTasks, 2)
+	assert.Equal(t, "task-1", dump.QueuedTasks[0].TaskID)
+	assert.Equal(t, "ctx-1", dump.QueuedTasks[0].ContextID)
+	assert.Equal(t, string(types.TaskStateSubmitted), dump.QueuedTasks[0].State)
+	assert.Equal(t, "req-task-1", dump.QueuedTasks[0].RequestID)
+	assert.NotNil(t, dump.QueuedTasks[0].EnqueuedAt)
+
+	assert.Equal(t, http.StatusBadRequest, getDebugJSON(t, httpServer.URL+"/debug/dump?limit=-1", nil))
+}
+
+func TestA2AServer_DebugEndpointsDisabled(t *testing.T) {
+	_, httpServer := newDebugTestServer(t, false)
+
+	assert.Equal(t, http.StatusNotFound, getDebugJSON(t, httpServer.URL+"/debug/stats", nil))
+	assert.Equal(t, http.StatusNotFound, getDebugJSON(t, httpServer.URL+"/debug/dump", nil))
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// builtinJSONRPCMethods lists the A2A methods served by the protocol handler
+var builtinJSONRPCMethods = map[string]struct{}{
+	"message/send":                        {},
+	"message/stream":                      {},
+	"message/sendBatch":                   {},
+	"tasks/get":                           {},
+	"tasks/list":                          {},
+	"tasks/cancel":                        {},
+	"tasks/feedback":                      {},
+	"tasks/share":                         {},
+	"tasks/usage":                         {},
+	"tasks/fork":                          {},
+	"tasks/pushNotificationConfig/set":    {},
+	"tasks/pushNotificationConfig/get":    {},
+	"tasks/pushNotificationConfig/list":   {},
+	"tasks/pushNotificationConfig/delete": {},
+	"tasks/resubscribe":                   {},
+	"contexts/create":                     {},
+	"contexts/get":                        {},
+	"contexts/list":                       {},
+	"contexts/delete":                     {},
+	"artifacts/list":                      {},
+	"memories/create":                     {},
+	"memories/get":                        {},
+	"memories/list":                       {},
+	"memories/update":                     {},
+	"memories/delete":                     {},
+	"agent/getAuthenticatedExtendedCard":  {},
+}
+
+// reservedJSONRPCNamespaces are method prefixes reserved for the A2A protocol
+var reservedJSONRPCNamespaces = []string{"message/", "tasks/", "contexts/", "artifacts/", "memories/", "agent/"}
+
+// JSONRPCMethodHandler handles a custom JSON-RPC method served on the A2A endpoint.
+// The context is the request's gin context, so values set by middleware (such as the
+// authenticated token) are available through ctx.Value.
+type JSONRPCMethodHandler func(ctx context.Context, params map[string]any) (any, error)
+
+// JSONRPCMethodError is returned by a JSONRPCMethodHandler to control the JSON-RPC
+// error code and message sent to the caller. Any other error is reported as an internal error.
+// Data, when set, is sent as the data of the error.
+type JSONRPCMethodError struct {
+	Code    int
+	Message string
+	Data    any
+}
+
+// Error implements the error interface
+func (e *JSONRPCMethodError) Error() string {
+	return fmt.Sprintf("json-rpc error %d: %s", e.Code, e.Message)
+}
+
+// NewJSONRPCMethod adapts a function with typed params and result into a JSONRPCMethodHandler.
+// Params are decoded from the request into P; decoding failures are reported as invalid params.
+func NewJSONRPCMethod[P any, R any](fn func(ctx context.Context, params P) (R, error)) JSONRPCMethodHandler {
+	return func(ctx context.Context, raw map[string]any) (any, error) {
+		var params P
+		if raw != nil {
+			data, err := json.Marshal(raw)
+			if err != nil {
+				return nil, &JSONRPCMethodError{Code: int(ErrInvalidParams), Message: "invalid params"}
+			}
+			if err := json.Unmarshal(data, &params); err != nil {
+				return nil, &JSONRPCMethodError{Code: int(ErrInvalidParams), Message: fmt.Sprintf("invalid params: %v", err)}
+			}
+		}
+		return fn(ctx, params)
+	}
+}
+
+// JSONRPCMethodRegistry holds the custom JSON-RPC methods served alongside the A2A methods
+type JSONRPCMethodRegistry struct {
+	mu      sync.RWMutex
+	methods map[string]JSONRPCMethodHandler
+}
+
+// NewJSONRPCMethodRegistry creates an empty method registry
+func NewJSONRPCMethodRegistry() *JSONRPCMethodRegistry {
+	return &JSONRPCMethodRegistry{
+		methods: make(map[string]JSONRPCMethodHandler),
+	}
+}
+
+// Register adds a custom method. Method names must be unique and may not use the
+// message/, tasks/, contexts/, artifacts/, memories/ or agent/ namespaces reserved for the
+// A2A protocol.
+func (r *JSONRPCMethodRegistry) Register(method string, handler JSONRPCMethodHandler) error {
+	if strings.TrimSpace(method) == "" {
+		return fmt.Errorf("json-rpc method name is required")
+	}
+	if handler == nil {
+		return fmt.Errorf("handler for json-rpc method %s is required", method)
+	}
+	if _, builtin := builtinJSONRPCMethods[method]; builtin {
+		return fmt.Errorf("json-rpc method %s is a built-in A2A method", method)
+	}
+	for _, namespace := range reservedJSONRPCNamespaces {
+		if strings.HasPrefix(method, namespace) {
+			return fmt.Errorf("json-rpc method %s uses the reserved %s namespace", method, namespace)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.methods[method]; exists {
+		return fmt.Errorf("json-rpc method %s is already registered", method)
+	}
+	r.methods[method] = handler
+	return nil
+}
+
+// Lookup returns the handler registered for a method
+func (r *JSONRPCMethodRegistry) Lookup(method string) (JSONRPCMethodHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	handler, exists := r.methods[method]
+	return handler, exists
+}
+
+// Methods returns the registered method names in sorted order
+func (r *JSONRPCMethodRegistry) Methods() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	methods := make([]string, 0, len(r.methods))
+	for method := range r.methods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
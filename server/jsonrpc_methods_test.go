@@ -0,0 +1,167 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+)
+
+type feedbackParams struct {
+	TaskID string `json:"taskId"`
+	Rating int    `json:"rating"`
+}
+
+type feedbackResult struct {
+	Accepted bool   `json:"accepted"`
+	Token    string `json:"token,omitempty"`
+}
+
+func TestJSONRPCMethodRegistry_Register(t *testing.T) {
+	handler := func(ctx context.Context, params map[string]any) (any, error) { return nil, nil }
+
+	tests := []struct {
+		name        string
+		method      string
+		handler     JSONRPCMethodHandler
+		expectError string
+	}{
+		{name: "custom namespace", method: "myapp/feedback/submit", handler: handler},
+		{name: "empty name", method: " ", handler: handler, expectError: "method name is required"},
+		{name: "nil handler", method: "myapp/ping", expectError: "is required"},
+		{name: "built-in method", method: "tasks/get", handler: handler, expectError: "built-in A2A method"},
+		{name: "reserved namespace", method: "tasks/archive", handler: handler, expectError: "reserved tasks/ namespace"},
+		{name: "duplicate", method: "myapp/duplicate", handler: handler, expectError: "already registered"},
+	}
+
+	registry := NewJSONRPCMethodRegistry()
+	require.NoError(t, registry.Register("myapp/duplicate", handler))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.Register(tt.method, tt.handler)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			_, exists := registry.Lookup(tt.method)
+			assert.True(t, exists)
+		})
+	}
+
+	assert.Equal(t, []string{"myapp/duplicate", "myapp/feedback/submit"}, registry.Methods())
+}
+
+func TestA2AServer_HandleCustomJSONRPCMethod(t *testing.T) {
+	s := NewA2AServer(&config.Config{}, zap.NewNop(), nil)
+	require.NoError(t, s.RegisterJSONRPCMethod("myapp/feedback/submit", NewJSONRPCMethod(
+		func(ctx context.Context, params feedbackParams) (feedbackResult, error) {
+			if params.Rating < 1 || params.Rating > 5 {
+				return feedbackResult{}, &JSONRPCMethodError{Code: int(ErrInvalidParams), Message: "rating must be between 1 and 5"}
+			}
+			token, _ := ctx.Value(string(middlewares.AuthTokenContextKey)).(string)
+			return feedbackResult{Accepted: true, Token: token}, nil
+		},
+	)))
+	require.NoError(t, s.RegisterJSONRPCMethod("myapp/broken", func(ctx context.Context, params map[string]any) (any, error) {
+		return nil, errors.New("database unavailable")
+	}))
+
+	tests := []struct {
+		name         string
+		method       string
+		params       map[string]any
+		expectResult map[string]any
+		expectCode   int
+		expectMsg    string
+	}{
+		{
+			name:         "typed params and result",
+			method:       "myapp/feedback/submit",
+			params:       map[string]any{"taskId": "task-1", "rating": 5},
+			expectResult: map[string]any{"accepted": true, "token": "token-123"},
+		},
+		{
+			name:       "handler error code is preserved",
+			method:     "myapp/feedback/submit",
+			params:     map[string]any{"taskId": "task-1", "rating": 9},
+			expectCode: int(ErrInvalidParams),
+			expectMsg:  "rating must be between 1 and 5",
+		},
+		{
+			name:       "params that do not decode are invalid",
+			method:     "myapp/feedback/submit",
+			params:     map[string]any{"rating": "five"},
+			expectCode: int(ErrInvalidParams),
+		},
+		{
+			name:       "unexpected errors are internal",
+			method:     "myapp/broken",
+			expectCode: int(ErrInternalError),
+			expectMsg:  "internal error",
+		},
+		{
+			name:       "unknown methods are not found",
+			method:     "myapp/missing",
+			expectCode: int(ErrMethodNotFound),
+			expectMsg:  "method not found",
+		},
+		{
+			name:       "requests without a method are invalid",
+			expectCode: int(ErrInvalidRequest),
+			expectMsg:  "invalid request: method is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "req-1",
+				"method":  tt.method,
+				"params":  tt.params,
+			})
+			require.NoError(t, err)
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/a2a", bytes.NewReader(body))
+			c.Set(string(middlewares.AuthTokenContextKey), "token-123")
+
+			s.handleA2ARequest(c)
+
+			var response struct {
+				Result map[string]any `json:"result"`
+				Error  *struct {
+					Code    int    `json:"code"`
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			if tt.expectCode != 0 {
+				require.NotNil(t, response.Error)
+				assert.Equal(t, tt.expectCode, response.Error.Code)
+				if tt.expectMsg != "" {
+					assert.Equal(t, tt.expectMsg, response.Error.Message)
+				}
+				return
+			}
+			assert.Nil(t, response.Error)
+			assert.Equal(t, tt.expectResult, response.Result)
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	defaultKnowledgeChunkSize    = 1000
+	defaultKnowledgeChunkOverlap = 200
+	defaultKnowledgeSearchLimit  = 5
+	maxKnowledgeSearchLimit      = 20
+	maxKnowledgeArtifactSize     = 10 << 20
+)
+
+// Document is a chunk of indexed text with the embedding it is found by
+type Document struct {
+	ID        string         `json:"id"`
+	Content   string         `json:"content"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+	Embedding []float32      `json:"-"`
+}
+
+// SearchResult is a document matching a query, scored by cosine similarity
+type SearchResult struct {
+	Document Document `json:"document"`
+	Score    float64  `json:"score"`
+}
+
+// VectorStore stores documents by embedding and finds the ones closest to a query embedding
+type VectorStore interface {
+	// Upsert adds documents, replacing existing documents with the same ID
+	Upsert(ctx context.Context, documents []Document) error
+
+	// Search returns up to limit documents closest to the embedding, best match first
+	Search(ctx context.Context, embedding []float32, limit int) ([]SearchResult, error)
+
+	// Delete removes documents by ID
+	Delete(ctx context.Context, ids ...string) error
+}
+
+// Embedder turns texts into embeddings, returning one embedding per text in the same order
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// EmbedderFunc adapts a function to the Embedder interface
+type EmbedderFunc func(ctx context.Context, texts []string) ([][]float32, error)
+
+// Embed calls f(ctx, texts)
+func (f EmbedderFunc) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	return f(ctx, texts)
+}
+
+var _ VectorStore = (*InMemoryVectorStore)(nil)
+
+// InMemoryVectorStore is a VectorStore that keeps documents in memory and searches them
+// exhaustively. It suits tests and small knowledge bases that are indexed at startup.
+type InMemoryVectorStore struct {
+	mu        sync.RWMutex
+	documents map[string]Document
+}
+
+// NewInMemoryVectorStore creates an empty in-memory vector store
+func NewInMemoryVectorStore() *InMemoryVectorStore {
+	return &InMemoryVectorStore{
+		documents: make(map[string]Document),
+	}
+}
+
+// Upsert adds documents, replacing existing documents with the same ID
+func (s *InMemoryVectorStore) Upsert(ctx context.Context, documents []Document) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, document := range documents {
+		if document.ID == "" {
+			return fmt.Errorf("document ID is required")
+		}
+		s.documents[document.ID] = document
+	}
+	return nil
+}
+
+// Search returns up to limit documents closest to the embedding, best match first
+func (s *InMemoryVectorStore) Search(ctx context.Context, embedding []float32, limit int) ([]SearchResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results := make([]SearchResult, 0, len(s.documents))
+	for _, document := range s.documents {
+		if len(document.Embedding) != len(embedding) {
+			continue
+		}
+		results = append(results, SearchResult{
+			Document: document,
+			Score:    cosineSimilarity(embedding, document.Embedding),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Document.ID < results[j].Document.ID
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// Delete removes documents by ID
+func (s *InMemoryVectorStore) Delete(ctx context.Context, ids ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		delete(s.documents, id)
+	}
+	return nil
+}
+
+// cosineSimilarity returns the cosine of the angle between two vectors of the same length
+func cosineSimilarity(a, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// KnowledgeBase indexes text into a vector store and searches it by meaning, so agents can
+// ground their answers in indexed documents
+type KnowledgeBase struct {
+	store        VectorStore
+	embedder     Embedder
+	chunkSize    int
+	chunkOverlap int
+}
+
+// NewKnowledgeBase creates a knowledge base that embeds text with the embedder and keeps it
+// in the store. Text is split into chunks of 1000 characters overlapping by 200.
+func NewKnowledgeBase(store VectorStore, embedder Embedder) *KnowledgeBase {
+	return &KnowledgeBase{
+		store:        store,
+		embedder:     embedder,
+		chunkSize:    defaultKnowledgeChunkSize,
+		chunkOverlap: defaultKnowledgeChunkOverlap,
+	}
+}
+
+// SetChunking sets the size of the chunks text is split into and how many characters
+// consecutive chunks share, in characters
+func (kb *KnowledgeBase) SetChunking(size, overlap int) {
+	if size <= 0 {
+		size = defaultKnowledgeChunkSize
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+	kb.chunkSize = size
+	kb.chunkOverlap = overlap
+}
+
+// Index splits text into chunks, embeds them and stores them as documents with IDs derived
+// from sourceID. Every chunk carries the metadata along with its source and position.
+// Indexing the same source again replaces the chunks it shares with the previous version.
+// It returns the number of chunks indexed.
+func (kb *KnowledgeBase) Index(ctx context.Context, sourceID, text string, metadata map[string]any) (int, error) {
+	if sourceID == "" {
+		return 0, fmt.Errorf("source ID is required")
+	}
+	chunks := chunkText(text, kb.chunkSize, kb.chunkOverlap)
+	if len(chunks) == 0 {
+		return 0, nil
+	}
+
+	embeddings, err := kb.embedder.Embed(ctx, chunks)
+	if err != nil {
+		return 0, fmt.Errorf("failed to embed %s: %w", sourceID, err)
+	}
+	if len(embeddings) != len(chunks) {
+		return 0, fmt.Errorf("failed to embed %s: got %d embeddings for %d chunks", sourceID, len(embeddings), len(chunks))
+	}
+
+	documents := make([]Document, len(chunks))
+	for i, chunk := range chunks {
+		chunkMetadata := make(map[string]any, len(metadata)+2)
+		for key, value := range metadata {
+			chunkMetadata[key] = value
+		}
+		chunkMetadata["source"] = sourceID
+		chunkMetadata["chunk"] = i
+
+		documents[i] = Document{
+			ID:        fmt.Sprintf("%s#%d", sourceID, i),
+			Content:   chunk,
+			Metadata:  chunkMetadata,
+			Embedding: embeddings[i],
+		}
+	}
+
+	if err := kb.store.Upsert(ctx, documents); err != nil {
+		return 0, fmt.Errorf("failed to store %s: %w", sourceID, err)
+	}
+	return len(documents), nil
+}
+
+// Search returns up to limit chunks closest in meaning to the query, best match first
+func (kb *KnowledgeBase) Search(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+	embeddings, err := kb.embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if len(embeddings) != 1 {
+		return nil, fmt.Errorf("failed to embed query: got %d embeddings", len(embeddings))
+	}
+	return kb.store.Search(ctx, embeddings[0], limit)
+}
+
+// IngestArtifact indexes a text file stored by the artifact service, such as a file uploaded
+// through the artifact upload extension. The chunks are attributed to the artifact's context,
+// ID and filename. It returns the number of chunks indexed.
+func (kb *KnowledgeBase) IngestArtifact(ctx context.Context, artifacts ArtifactService, contextID, artifactID, filename string) (int, error) {
+	reader, err := artifacts.Retrieve(ctx, contextID, artifactID, filename)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve artifact %s: %w", artifactID, err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxKnowledgeArtifactSize+1))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read artifact %s: %w", artifactID, err)
+	}
+	if len(data) > maxKnowledgeArtifactSize {
+		return 0, fmt.Errorf("artifact %s is larger than %d bytes", artifactID, maxKnowledgeArtifactSize)
+	}
+	if !utf8.Valid(data) {
+		return 0, fmt.Errorf("artifact %s is not a text file", artifactID)
+	}
+
+	return kb.Index(ctx, fmt.Sprintf("%s/%s/%s", contextID, artifactID, filename), string(data), map[string]any{
+		"context_id":  contextID,
+		"artifact_id": artifactID,
+		"filename":    filename,
+	})
+}
+
+// chunkText splits text into chunks of at most size characters, with consecutive chunks
+// sharing up to overlap characters. Chunks start and end at word boundaries where possible.
+func chunkText(text string, size, overlap int) []string {
+	runes := []rune(strings.TrimSpace(text))
+	var chunks []string
+
+	skipSpace := func(i int) int {
+		for i < len(runes) && unicode.IsSpace(runes[i]) {
+			i++
+		}
+		return i
+	}
+
+	previousEnd := 0
+	for start := 0; start < len(runes); {
+		end := min(start+size, len(runes))
+		if end < len(runes) {
+			for cut := end; cut > start; cut-- {
+				if unicode.IsSpace(runes[cut]) {
+					end = cut
+					break
+				}
+			}
+		}
+		if end <= previousEnd {
+			// the overlap would only repeat the end of the previous chunk
+			start = skipSpace(previousEnd)
+			continue
+		}
+		previousEnd = end
+
+		if chunk := strings.TrimSpace(string(runes[start:end])); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		if end == len(runes) {
+			break
+		}
+
+		next := end
+		for i := max(end-overlap, start+1); i < end; i++ {
+			if unicode.IsSpace(runes[i-1]) && !unicode.IsSpace(runes[i]) {
+				next = i
+				break
+			}
+		}
+		start = skipSpace(next)
+	}
+	return chunks
+}
+
+// NewKnowledgeSearchTool creates the knowledge_search tool, which lets the agent look up the
+// chunks of the knowledge base most relevant to a query
+func NewKnowledgeSearchTool(kb *KnowledgeBase) *BasicTool {
+	return NewBasicTool(
+		"knowledge_search",
+		"Search the knowledge base for passages relevant to a query. Use it to ground answers in indexed documents instead of relying on memory, and cite the sources of the passages you use.",
+		map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{
+					"type":        "string",
+					"description": "What to look up, phrased as a question or a short description of the information needed",
+				},
+				"limit": map[string]any{
+					"type":        "integer",
+					"description": fmt.Sprintf("Maximum number of passages to return, defaults to %d", defaultKnowledgeSearchLimit),
+				},
+			},
+			"required": []string{"query"},
+		},
+		func(ctx context.Context, args map[string]any) (string, error) {
+			query, ok := args["query"].(string)
+			if !ok || strings.TrimSpace(query) == "" {
+				return "", fmt.Errorf("query is required")
+			}
+			limit := defaultKnowledgeSearchLimit
+			switch l := args["limit"].(type) {
+			case float64:
+				if l > 0 {
+					limit = min(int(l), maxKnowledgeSearchLimit)
+				}
+			case int:
+				if l > 0 {
+					limit = min(l, maxKnowledgeSearchLimit)
+				}
+			}
+
+			results, err := kb.Search(ctx, query, limit)
+			if err != nil {
+				return "", err
+			}
+
+			passages := make([]map[string]any, 0, len(results))
+			for _, result := range results {
+				passage := map[string]any{
+					"content": result.Document.Content,
+					"score":   result.Score,
+				}
+				if source, ok := result.Document.Metadata["source"]; ok {
+					passage["source"] = source
+				}
+				passages = append(passages, passage)
+			}
+			return JSONTool(map[string]any{"results": passages})
+		},
+	)
+}
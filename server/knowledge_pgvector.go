@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var _ VectorStore = (*PgVectorStore)(nil)
+
+var pgIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// PgVectorStore is a VectorStore backed by PostgreSQL with the pgvector extension. It works
+// with any database/sql driver for PostgreSQL, such as pgx or lib/pq, which the application
+// imports and opens the database with.
+type PgVectorStore struct {
+	db         *sql.DB
+	table      string
+	dimensions int
+}
+
+// NewPgVectorStore creates a vector store in the given table, creating the pgvector extension
+// and the table when they do not exist yet. Embeddings must have the given dimensions.
+func NewPgVectorStore(ctx context.Context, db *sql.DB, table string, dimensions int) (*PgVectorStore, error) {
+	if !pgIdentifierPattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+	if dimensions <= 0 {
+		return nil, fmt.Errorf("embedding dimensions must be positive, got %d", dimensions)
+	}
+
+	store := &PgVectorStore{
+		db:         db,
+		table:      table,
+		dimensions: dimensions,
+	}
+
+	statements := []string{
+		`CREATE EXTENSION IF NOT EXISTS vector`,
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			id TEXT PRIMARY KEY,
+			content TEXT NOT NULL,
+			metadata JSONB NOT NULL DEFAULT '{}',
+			embedding vector(%d) NOT NULL
+		)`, table, dimensions),
+	}
+	for _, statement := range statements {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return nil, fmt.Errorf("failed to prepare pgvector table %s: %w", table, err)
+		}
+	}
+
+	return store, nil
+}
+
+// Upsert adds documents, replacing existing documents with the same ID
+func (s *PgVectorStore) Upsert(ctx context.Context, documents []Document) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := fmt.Sprintf(`INSERT INTO %s (id, content, metadata, embedding)
+		VALUES ($1, $2, $3, $4::vector)
+		ON CONFLICT (id) DO UPDATE SET
+			content = EXCLUDED.content,
+			metadata = EXCLUDED.metadata,
+			embedding = EXCLUDED.embedding`, s.table)
+
+	for _, document := range documents {
+		if document.ID == "" {
+			return fmt.Errorf("document ID is required")
+		}
+		if len(document.Embedding) != s.dimensions {
+			return fmt.Errorf("document %s has %d embedding dimensions, expected %d", document.ID, len(document.Embedding), s.dimensions)
+		}
+
+		metadata := document.Metadata
+		if metadata == nil {
+			metadata = map[string]any{}
+		}
+		metadataJSON, err := json.Marshal(metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode metadata of document %s: %w", document.ID, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, query, document.ID, document.Content, string(metadataJSON), vectorLiteral(document.Embedding)); err != nil {
+			return fmt.Errorf("failed to upsert document %s: %w", document.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit documents: %w", err)
+	}
+	return nil
+}
+
+// Search returns up to limit documents closest to the embedding by cosine distance, best
+// match first
+func (s *PgVectorStore) Search(ctx context.Context, embedding []float32, limit int) ([]SearchResult, error) {
+	if len(embedding) != s.dimensions {
+		return nil, fmt.Errorf("query has %d embedding dimensions, expected %d", len(embedding), s.dimensions)
+	}
+	if limit <= 0 {
+		limit = defaultKnowledgeSearchLimit
+	}
+
+	query := fmt.Sprintf(`SELECT id, content, metadata, 1 - (embedding <=> $1::vector) AS score
+		FROM %s
+		ORDER BY embedding <=> $1::vector
+		LIMIT $2`, s.table)
+
+	rows, err := s.db.QueryContext(ctx, query, vectorLiteral(embedding), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search documents: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []SearchResult
+	for rows.Next() {
+		var result SearchResult
+		var metadataJSON []byte
+		if err := rows.Scan(&result.Document.ID, &result.Document.Content, &metadataJSON, &result.Score); err != nil {
+			return nil, fmt.Errorf("failed to read search result: %w", err)
+		}
+		if len(metadataJSON) > 0 {
+			if err := json.Unmarshal(metadataJSON, &result.Document.Metadata); err != nil {
+				return nil, fmt.Errorf("failed to decode metadata of document %s: %w", result.Document.ID, err)
+			}
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search documents: %w", err)
+	}
+	return results, nil
+}
+
+// Delete removes documents by ID
+func (s *PgVectorStore) Delete(ctx context.Context, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id IN (%s)`, s.table, strings.Join(placeholders, ", "))
+	if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete documents: %w", err)
+	}
+	return nil
+}
+
+// vectorLiteral formats an embedding in the pgvector text format, e.g. [0.1,0.2,0.3]
+func vectorLiteral(embedding []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, value := range embedding {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(value), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+)
+
+// recordingDriver is a database/sql driver that records statements and answers queries
+// with fixed rows, standing in for PostgreSQL
+type recordingDriver struct {
+	mu         sync.Mutex
+	statements []string
+	args       [][]driver.Value
+	rows       [][]driver.Value
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return &recordingConn{d}, nil }
+
+func (d *recordingDriver) record(query string, args []driver.NamedValue) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	d.statements = append(d.statements, strings.Join(strings.Fields(query), " "))
+	d.args = append(d.args, values)
+}
+
+type recordingConn struct{ driver *recordingDriver }
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepare not supported")
+}
+func (c *recordingConn) Close() error              { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *recordingConn) Commit() error             { c.driver.record("COMMIT", nil); return nil }
+func (c *recordingConn) Rollback() error           { return nil }
+
+func (c *recordingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.driver.record(query, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *recordingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.driver.record(query, args)
+	return &recordingRows{rows: c.driver.rows}, nil
+}
+
+type recordingRows struct{ rows [][]driver.Value }
+
+func (r *recordingRows) Columns() []string { return []string{"id", "content", "metadata", "score"} }
+func (r *recordingRows) Close() error      { return nil }
+func (r *recordingRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+var recordingDrivers atomic.Int64
+
+func newRecordingDB(t *testing.T) (*sql.DB, *recordingDriver) {
+	t.Helper()
+	recorder := &recordingDriver{}
+	name := fmt.Sprintf("recording-%d", recordingDrivers.Add(1))
+	sql.Register(name, recorder)
+	db, err := sql.Open(name, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db, recorder
+}
+
+func TestNewPgVectorStore(t *testing.T) {
+	db, recorder := newRecordingDB(t)
+
+	_, err := NewPgVectorStore(context.Background(), db, "documents", 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"CREATE EXTENSION IF NOT EXISTS vector",
+		"CREATE TABLE IF NOT EXISTS documents ( id TEXT PRIMARY KEY, content TEXT NOT NULL, metadata JSONB NOT NULL DEFAULT '{}', embedding vector(3) NOT NULL )",
+	}, recorder.statements)
+
+	_, err = NewPgVectorStore(context.Background(), db, "documents; DROP TABLE users", 3)
+	assert.EqualError(t, err, `invalid table name "documents; DROP TABLE users"`)
+
+	_, err = NewPgVectorStore(context.Background(), db, "documents", 0)
+	assert.EqualError(t, err, "embedding dimensions must be positive, got 0")
+}
+
+func TestPgVectorStore_UpsertSearchDelete(t *testing.T) {
+	db, recorder := newRecordingDB(t)
+	store, err := NewPgVectorStore(context.Background(), db, "documents", 2)
+	require.NoError(t, err)
+	recorder.statements, recorder.args = nil, nil
+
+	require.NoError(t, store.Upsert(context.Background(), []Document{
+		{ID: "faq#0", Content: "Refunds take five days", Metadata: map[string]any{"source": "faq"}, Embedding: []float32{0.5, -1.25}},
+	}))
+	require.Len(t, recorder.statements, 2)
+	assert.Contains(t, recorder.statements[0], "INSERT INTO documents (id, content, metadata, embedding) VALUES ($1, $2, $3, $4::vector) ON CONFLICT (id) DO UPDATE")
+	assert.Equal(t, []driver.Value{"faq#0", "Refunds take five days", `{"source":"faq"}`, "[0.5,-1.25]"}, recorder.args[0])
+	assert.Equal(t, "COMMIT", recorder.statements[1])
+
+	err = store.Upsert(context.Background(), []Document{{ID: "bad", Embedding: []float32{1}}})
+	assert.EqualError(t, err, "document bad has 1 embedding dimensions, expected 2")
+
+	recorder.rows = [][]driver.Value{{"faq#0", "Refunds take five days", []byte(`{"source":"faq"}`), 0.92}}
+	results, err := store.Search(context.Background(), []float32{1, 0}, 3)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "faq#0", results[0].Document.ID)
+	assert.Equal(t, "Refunds take five days", results[0].Document.Content)
+	assert.Equal(t, map[string]any{"source": "faq"}, results[0].Document.Metadata)
+	assert.Equal(t, 0.92, results[0].Score)
+	last := len(recorder.statements) - 1
+	assert.Equal(t, "SELECT id, content, metadata, 1 - (embedding <=> $1::vector) AS score FROM documents ORDER BY embedding <=> $1::vector LIMIT $2", recorder.statements[last])
+	assert.Equal(t, []driver.Value{"[1,0]", int64(3)}, recorder.args[last])
+
+	require.NoError(t, store.Delete(context.Background(), "faq#0", "faq#1"))
+	last = len(recorder.statements) - 1
+	assert.Equal(t, "DELETE FROM documents WHERE id IN ($1, $2)", recorder.statements[last])
+	assert.Equal(t, []driver.Value{"faq#0", "faq#1"}, recorder.args[last])
+}
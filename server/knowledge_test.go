@@ -0,0 +1,207 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	server "github.com/inference-gateway/adk/server"
+	mocks "github.com/inference-gateway/adk/server/mocks"
+	types "github.com/inference-gateway/adk/types"
+	sdk "github.com/inference-gateway/sdk"
+)
+
+// keywordEmbedder embeds texts as counts of a few keywords, so similarity follows topic
+var keywordEmbedder = server.EmbedderFunc(func(ctx context.Context, texts []string) ([][]float32, error) {
+	keywords := []string{"refund", "shipping", "password"}
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		embedding := make([]float32, len(keywords))
+		for j, keyword := range keywords {
+			embedding[j] = float32(strings.Count(strings.ToLower(text), keyword))
+		}
+		embeddings[i] = embedding
+	}
+	return embeddings, nil
+})
+
+func TestInMemoryVectorStore(t *testing.T) {
+	store := server.NewInMemoryVectorStore()
+	ctx := context.Background()
+
+	require.NoError(t, store.Upsert(ctx, []server.Document{
+		{ID: "a", Content: "refunds", Embedding: []float32{1, 0}},
+		{ID: "b", Content: "shipping", Embedding: []float32{0, 1}},
+		{ID: "c", Content: "both", Embedding: []float32{1, 1}},
+	}))
+	require.NoError(t, store.Upsert(ctx, []server.Document{{ID: "b", Content: "shipping v2", Embedding: []float32{0, 1}}}))
+
+	results, err := store.Search(ctx, []float32{0, 2}, 2)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "b", results[0].Document.ID)
+	assert.Equal(t, "shipping v2", results[0].Document.Content)
+	assert.InDelta(t, 1.0, results[0].Score, 0.0001)
+	assert.Equal(t, "c", results[1].Document.ID)
+
+	require.NoError(t, store.Delete(ctx, "b", "c"))
+	results, err = store.Search(ctx, []float32{0, 2}, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "a", results[0].Document.ID)
+
+	assert.EqualError(t, store.Upsert(ctx, []server.Document{{Content: "no id"}}), "document ID is required")
+}
+
+func TestKnowledgeBase_IndexAndSearch(t *testing.T) {
+	store := server.NewInMemoryVectorStore()
+	kb := server.NewKnowledgeBase(store, keywordEmbedder)
+	kb.SetChunking(40, 0)
+	ctx := context.Background()
+
+	count, err := kb.Index(ctx, "faq", "Refund requests are processed within five days. Shipping takes two weeks to most countries.", map[string]any{"team": "support"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	results, err := kb.Search(ctx, "how long does shipping take", 1)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Contains(t, results[0].Document.Content, "Shipping")
+	assert.Equal(t, "faq", results[0].Document.Metadata["source"])
+	assert.Equal(t, "support", results[0].Document.Metadata["team"])
+	assert.Regexp(t, `^faq#\d$`, results[0].Document.ID)
+
+	count, err = kb.Index(ctx, "empty", "   ", nil)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestKnowledgeBase_IndexChunksWithOverlap(t *testing.T) {
+	var chunks []string
+	embedder := server.EmbedderFunc(func(ctx context.Context, texts []string) ([][]float32, error) {
+		chunks = append(chunks, texts...)
+		return make([][]float32, len(texts)), nil
+	})
+	kb := server.NewKnowledgeBase(server.NewInMemoryVectorStore(), embedder)
+	kb.SetChunking(12, 5)
+
+	_, err := kb.Index(context.Background(), "doc", "alpha beta gamma delta epsilon", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"alpha beta", "beta gamma", "gamma delta", "epsilon"}, chunks)
+}
+
+func TestKnowledgeBase_IngestArtifact(t *testing.T) {
+	artifacts := &mocks.FakeArtifactService{}
+	artifacts.RetrieveReturns(io.NopCloser(strings.NewReader("Reset your password from the account settings page.")), nil)
+	store := server.NewInMemoryVectorStore()
+	kb := server.NewKnowledgeBase(store, keywordEmbedder)
+
+	count, err := kb.IngestArtifact(context.Background(), artifacts, "uploads", "artifact-1", "guide.md")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	_, contextID, artifactID, filename := artifacts.RetrieveArgsForCall(0)
+	assert.Equal(t, []string{"uploads", "artifact-1", "guide.md"}, []string{contextID, artifactID, filename})
+
+	results, err := kb.Search(context.Background(), "forgot password", 1)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "uploads/artifact-1/guide.md#0", results[0].Document.ID)
+	assert.Equal(t, "artifact-1", results[0].Document.Metadata["artifact_id"])
+	assert.Equal(t, "guide.md", results[0].Document.Metadata["filename"])
+
+	artifacts.RetrieveReturns(io.NopCloser(strings.NewReader("\xff\xfe\x00binary")), nil)
+	_, err = kb.IngestArtifact(context.Background(), artifacts, "uploads", "artifact-2", "image.png")
+	assert.EqualError(t, err, "artifact artifact-2 is not a text file")
+}
+
+func TestKnowledgeSearchTool(t *testing.T) {
+	kb := server.NewKnowledgeBase(server.NewInMemoryVectorStore(), keywordEmbedder)
+	_, err := kb.Index(context.Background(), "policy", "Refunds are issued to the original payment method.", nil)
+	require.NoError(t, err)
+
+	tool := server.NewKnowledgeSearchTool(kb)
+	assert.Equal(t, "knowledge_search", tool.GetName())
+
+	result, err := tool.Execute(context.Background(), map[string]any{"query": "refund policy", "limit": float64(3)})
+	require.NoError(t, err)
+
+	var decoded struct {
+		Results []struct {
+			Content string  `json:"content"`
+			Source  string  `json:"source"`
+			Score   float64 `json:"score"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result), &decoded))
+	require.Len(t, decoded.Results, 1)
+	assert.Equal(t, "Refunds are issued to the original payment method.", decoded.Results[0].Content)
+	assert.Equal(t, "policy", decoded.Results[0].Source)
+
+	_, err = tool.Execute(context.Background(), map[string]any{})
+	assert.EqualError(t, err, "query is required")
+}
+
+func TestAgentBuilder_WithKnowledgeBase(t *testing.T) {
+	store := server.NewInMemoryVectorStore()
+
+	t.Run("adds the tool to the configured toolbox", func(t *testing.T) {
+		toolBox := server.NewDefaultToolBox(nil)
+		_, err := server.NewAgentBuilder(zap.NewNop()).
+			WithToolBox(toolBox).
+			WithKnowledgeBase(store, keywordEmbedder).
+			Build()
+		require.NoError(t, err)
+
+		assert.True(t, toolBox.HasTool("knowledge_search"))
+		assert.True(t, toolBox.HasTool("input_required"))
+	})
+
+	t.Run("creates a toolbox when none is set", func(t *testing.T) {
+		mockLLMClient := &mocks.FakeLLMClient{}
+		var toolNames []string
+		mockLLMClient.CreateStreamingChatCompletionStub = func(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+			for _, tool := range tools {
+				toolNames = append(toolNames, tool.Function.Name)
+			}
+			responseChan := make(chan *sdk.CreateChatCompletionStreamResponse, 1)
+			responseChan <- &sdk.CreateChatCompletionStreamResponse{
+				Choices: []sdk.ChatCompletionStreamChoice{
+					{Delta: sdk.ChatCompletionStreamResponseDelta{Content: "Refunds take five days"}, FinishReason: "stop"},
+				},
+			}
+			close(responseChan)
+			return responseChan, make(chan error)
+		}
+
+		agent, err := server.NewAgentBuilder(zap.NewNop()).
+			WithLLMClient(mockLLMClient).
+			WithKnowledgeBase(store, keywordEmbedder).
+			Build()
+		require.NoError(t, err)
+
+		eventChan, err := agent.RunWithStream(context.Background(), []types.Message{
+			{Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("What is the refund policy?")}},
+		})
+		require.NoError(t, err)
+		for range eventChan {
+		}
+
+		assert.Equal(t, []string{"knowledge_search"}, toolNames)
+	})
+
+	t.Run("rejects toolboxes tools cannot be added to", func(t *testing.T) {
+		_, err := server.NewAgentBuilder(zap.NewNop()).
+			WithToolBox(&mocks.FakeToolBox{}).
+			WithKnowledgeBase(store, keywordEmbedder).
+			Build()
+		assert.EqualError(t, err, "toolbox *mocks.FakeToolBox does not support adding the knowledge_search tool")
+	})
+}
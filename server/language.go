@@ -0,0 +1,461 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	uuid "github.com/google/uuid"
+	sdk "github.com/inference-gateway/sdk"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// minLanguageConfidence is the confidence below which a detection is treated as unknown
+const minLanguageConfidence = 0.5
+
+// LanguageDetector detects the language of a piece of text
+type LanguageDetector interface {
+	// Detect returns the ISO 639-1 code of the text's language and a confidence between
+	// 0 and 1, or an empty code when the language cannot be determined
+	Detect(text string) (string, float64)
+}
+
+// Translator translates text into another language
+type Translator interface {
+	// Translate translates text into the language identified by an ISO 639-1 code
+	Translate(ctx context.Context, text, targetLanguage string) (string, error)
+}
+
+// languageNames are the English names of the languages the default detector recognises
+var languageNames = map[string]string{
+	"ar": "Arabic",
+	"de": "German",
+	"el": "Greek",
+	"en": "English",
+	"es": "Spanish",
+	"fr": "French",
+	"he": "Hebrew",
+	"hi": "Hindi",
+	"it": "Italian",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"nl": "Dutch",
+	"pt": "Portuguese",
+	"ru": "Russian",
+	"th": "Thai",
+	"uk": "Ukrainian",
+	"zh": "Chinese",
+}
+
+// languageStopwords are frequent words used to tell Latin-script languages apart
+var languageStopwords = map[string][]string{
+	"en": {"the", "and", "is", "are", "you", "what", "how", "this", "that", "with", "for", "please", "can", "have", "hello", "thanks", "my", "it", "of", "to"},
+	"es": {"el", "los", "las", "es", "que", "y", "por", "para", "con", "una", "como", "qué", "hola", "gracias", "mi", "del", "está", "puedes", "quiero", "pero"},
+	"fr": {"le", "les", "est", "et", "une", "des", "que", "pour", "avec", "vous", "je", "bonjour", "merci", "mon", "pas", "dans", "sur", "ce", "qui", "du"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ich", "sie", "mit", "ein", "eine", "hallo", "danke", "bitte", "wie", "was", "für", "auf", "mein", "zu"},
+	"it": {"il", "gli", "che", "è", "e", "per", "con", "una", "sono", "ciao", "grazie", "mio", "non", "della", "come", "cosa", "questo", "puoi", "voglio", "anche"},
+	"pt": {"o", "os", "as", "é", "que", "e", "para", "com", "uma", "não", "olá", "obrigado", "obrigada", "meu", "você", "do", "da", "como", "isso", "quero"},
+	"nl": {"de", "het", "een", "en", "is", "niet", "ik", "je", "met", "van", "hallo", "bedankt", "dank", "mijn", "wat", "hoe", "voor", "dit", "zijn", "ook"},
+}
+
+// refusalTemplates are polite refusals keyed by the language they are written in
+var refusalTemplates = map[string]string{
+	"de": "Entschuldigung, ich kann nur in diesen Sprachen helfen: %s.",
+	"en": "Sorry, I can only help in these languages: %s.",
+	"es": "Lo siento, solo puedo ayudar en estos idiomas: %s.",
+	"fr": "Désolé, je ne peux aider que dans ces langues : %s.",
+	"it": "Mi dispiace, posso aiutare solo in queste lingue: %s.",
+	"nl": "Sorry, ik kan alleen helpen in deze talen: %s.",
+	"pt": "Desculpe, só posso ajudar nestes idiomas: %s.",
+}
+
+// languageEndonyms are the names languages use for themselves, listed in refusals so
+// users recognise the languages they can switch to
+var languageEndonyms = map[string]string{
+	"ar": "العربية",
+	"de": "Deutsch",
+	"el": "Ελληνικά",
+	"en": "English",
+	"es": "Español",
+	"fr": "Français",
+	"he": "עברית",
+	"hi": "हिन्दी",
+	"it": "Italiano",
+	"ja": "日本語",
+	"ko": "한국어",
+	"nl": "Nederlands",
+	"pt": "Português",
+	"ru": "Русский",
+	"th": "ไทย",
+	"uk": "Українська",
+	"zh": "中文",
+}
+
+// DefaultLanguageDetector detects languages from the Unicode script of the text and, for
+// Latin-script text, from the frequency of common words. It needs no external service and
+// is meant for routing and enforcement rather than linguistic accuracy.
+type DefaultLanguageDetector struct{}
+
+var _ LanguageDetector = (*DefaultLanguageDetector)(nil)
+
+// NewDefaultLanguageDetector creates the built-in language detector
+func NewDefaultLanguageDetector() *DefaultLanguageDetector {
+	return &DefaultLanguageDetector{}
+}
+
+// Detect returns the language of the text and the detection confidence
+func (d *DefaultLanguageDetector) Detect(text string) (string, float64) {
+	if language, confidence := detectScript(text); language != "" {
+		return language, confidence
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+
+	scores := make(map[string]int)
+	total := 0
+	for _, word := range words {
+		for language, stopwords := range languageStopwords {
+			if slices.Contains(stopwords, word) {
+				scores[language]++
+				total++
+			}
+		}
+	}
+	if total == 0 {
+		return "", 0
+	}
+
+	best, bestScore := "", 0
+	for language, score := range scores {
+		if score > bestScore || (score == bestScore && language < best) {
+			best, bestScore = language, score
+		}
+	}
+	return best, float64(bestScore) / float64(total)
+}
+
+// detectScript identifies languages written in a script used by a single language or family
+func detectScript(text string) (string, float64) {
+	counts := make(map[string]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			counts["ja"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Han, r):
+			counts["zh"]++
+		case strings.ContainsRune("іїєґІЇЄҐ", r):
+			counts["uk"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["ru"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Hebrew, r):
+			counts["he"]++
+		case unicode.Is(unicode.Devanagari, r):
+			counts["hi"]++
+		case unicode.Is(unicode.Greek, r):
+			counts["el"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["th"]++
+		}
+	}
+	if letters == 0 {
+		return "", 0
+	}
+
+	if counts["ja"] > 0 {
+		counts["ja"] += counts["zh"]
+		delete(counts, "zh")
+	}
+	if counts["uk"] > 0 {
+		counts["uk"] += counts["ru"]
+		delete(counts, "ru")
+	}
+
+	best, bestCount := "", 0
+	for language, count := range counts {
+		if count > bestCount {
+			best, bestCount = language, count
+		}
+	}
+	if bestCount*2 < letters {
+		return "", 0
+	}
+	return best, float64(bestCount) / float64(letters)
+}
+
+// LLMTranslator translates text with a chat completion from the configured LLM
+type LLMTranslator struct {
+	client LLMClient
+}
+
+var _ Translator = (*LLMTranslator)(nil)
+
+// NewLLMTranslator creates a translator backed by an LLM client
+func NewLLMTranslator(client LLMClient) *LLMTranslator {
+	return &LLMTranslator{client: client}
+}
+
+// Translate translates text into the target language
+func (t *LLMTranslator) Translate(ctx context.Context, text, targetLanguage string) (string, error) {
+	instruction := fmt.Sprintf("Translate the user's message into %s. Reply with the translation only, without any commentary.", languageName(targetLanguage))
+	systemMessage, err := sdk.NewTextMessage(sdk.System, instruction)
+	if err != nil {
+		return "", fmt.Errorf("failed to create translation instruction: %w", err)
+	}
+	userMessage, err := sdk.NewTextMessage(sdk.User, text)
+	if err != nil {
+		return "", fmt.Errorf("failed to create translation message: %w", err)
+	}
+
+	response, err := t.client.CreateChatCompletion(ctx, []sdk.Message{systemMessage, userMessage})
+	if err != nil {
+		return "", fmt.Errorf("translation request failed: %w", err)
+	}
+	if response == nil || len(response.Choices) == 0 {
+		return "", fmt.Errorf("translation response is empty")
+	}
+
+	translated, err := response.Choices[0].Message.Content.AsMessageContent0()
+	if err != nil {
+		return "", fmt.Errorf("failed to read translation: %w", err)
+	}
+	return strings.TrimSpace(translated), nil
+}
+
+// LanguageDecision is the outcome of applying the language policy to a message
+type LanguageDecision struct {
+	Language   string
+	Supported  bool
+	Translated bool
+	Refusal    *types.Message
+}
+
+// LanguagePolicy detects the language of incoming messages and enforces the supported
+// languages by refusing or translating messages in other languages
+type LanguagePolicy struct {
+	logger      *zap.Logger
+	detector    LanguageDetector
+	translator  Translator
+	supported   []string
+	enforcement string
+}
+
+// NewLanguagePolicy creates a language policy using the default detector
+func NewLanguagePolicy(cfg config.LanguageConfig, logger *zap.Logger) *LanguagePolicy {
+	supported := make([]string, 0, len(cfg.Supported))
+	for _, language := range cfg.Supported {
+		if code := normalizeLanguage(language); code != "" {
+			supported = append(supported, code)
+		}
+	}
+
+	enforcement := cfg.Enforcement
+	if enforcement == "" {
+		enforcement = config.LanguageEnforcementNone
+	}
+
+	return &LanguagePolicy{
+		logger:      logger,
+		detector:    NewDefaultLanguageDetector(),
+		supported:   supported,
+		enforcement: enforcement,
+	}
+}
+
+// SetDetector replaces the language detector
+func (p *LanguagePolicy) SetDetector(detector LanguageDetector) {
+	p.detector = detector
+}
+
+// SetTranslator sets the translator used by the translate enforcement mode
+func (p *LanguagePolicy) SetTranslator(translator Translator) {
+	p.translator = translator
+}
+
+// Enforcement returns the configured enforcement mode
+func (p *LanguagePolicy) Enforcement() string {
+	return p.enforcement
+}
+
+// Supported returns the supported language codes, default language first
+func (p *LanguagePolicy) Supported() []string {
+	return p.supported
+}
+
+// Apply detects the message language, records it in the message metadata and enforces
+// the supported languages. Messages whose language cannot be determined are accepted.
+func (p *LanguagePolicy) Apply(ctx context.Context, message *types.Message) LanguageDecision {
+	var texts []string
+	for _, part := range message.Parts {
+		if part.Text != nil {
+			texts = append(texts, *part.Text)
+		}
+	}
+
+	language, confidence := p.detector.Detect(strings.Join(texts, "\n"))
+	language = normalizeLanguage(language)
+	if language == "" || confidence < minLanguageConfidence {
+		return LanguageDecision{Supported: true}
+	}
+
+	setMessageMetadata(message, types.LanguageMetadataKey, language)
+	decision := LanguageDecision{
+		Language:  language,
+		Supported: len(p.supported) == 0 || slices.Contains(p.supported, language),
+	}
+	if decision.Supported {
+		return decision
+	}
+
+	p.logger.Info("message in unsupported language",
+		zap.String("message_id", message.MessageID),
+		zap.String("language", language),
+		zap.String("enforcement", p.enforcement))
+
+	switch p.enforcement {
+	case config.LanguageEnforcementRefuse:
+		decision.Refusal = p.refusal(message)
+	case config.LanguageEnforcementTranslate:
+		decision.Translated = p.translate(ctx, message, language)
+	}
+	return decision
+}
+
+// translate replaces the text parts of a message with their translation into the default
+// language, keeping the original text in the part metadata
+func (p *LanguagePolicy) translate(ctx context.Context, message *types.Message, language string) bool {
+	if p.translator == nil {
+		p.logger.Warn("no translator configured, passing message through untranslated",
+			zap.String("message_id", message.MessageID))
+		return false
+	}
+
+	target := p.supported[0]
+	parts := slices.Clone(message.Parts)
+	for i, part := range parts {
+		if part.Text == nil {
+			continue
+		}
+		translated, err := p.translator.Translate(ctx, *part.Text, target)
+		if err != nil {
+			p.logger.Warn("failed to translate message, passing it through untranslated",
+				zap.String("message_id", message.MessageID),
+				zap.Error(err))
+			return false
+		}
+
+		metadata := make(map[string]any)
+		if part.Metadata != nil {
+			for key, value := range *part.Metadata {
+				metadata[key] = value
+			}
+		}
+		metadata[types.OriginalTextMetadataKey] = *part.Text
+		parts[i].Metadata = &metadata
+		parts[i].Text = &translated
+	}
+
+	message.Parts = parts
+	setMessageMetadata(message, types.TranslatedFromMetadataKey, language)
+	return true
+}
+
+// refusal builds the polite refusal sent in the agent's default language
+func (p *LanguagePolicy) refusal(message *types.Message) *types.Message {
+	defaultLanguage := p.supported[0]
+	template, exists := refusalTemplates[defaultLanguage]
+	if !exists {
+		template = refusalTemplates["en"]
+	}
+
+	names := make([]string, 0, len(p.supported))
+	for _, language := range p.supported {
+		if name, exists := languageEndonyms[language]; exists {
+			names = append(names, name)
+			continue
+		}
+		names = append(names, language)
+	}
+
+	refusal := &types.Message{
+		MessageID: uuid.New().String(),
+		Role:      types.RoleAgent,
+		ContextID: message.ContextID,
+		TaskID:    message.TaskID,
+		Parts:     []types.Part{types.CreateTextPart(fmt.Sprintf(template, strings.Join(names, ", ")))},
+	}
+	setMessageMetadata(refusal, types.LanguageMetadataKey, defaultLanguage)
+	return refusal
+}
+
+// withLanguageExtension returns a copy of the agent card advertising the supported languages
+func (p *LanguagePolicy) withLanguageExtension(card types.AgentCard) types.AgentCard {
+	if len(p.supported) == 0 {
+		return card
+	}
+	for _, extension := range card.Capabilities.Extensions {
+		if extension.URI == types.LanguageExtensionURI {
+			return card
+		}
+	}
+
+	params := map[string]any{
+		"supported":   p.supported,
+		"default":     p.supported[0],
+		"enforcement": p.enforcement,
+	}
+	card.Capabilities.Extensions = append(slices.Clone(card.Capabilities.Extensions), types.AgentExtension{
+		URI:         types.LanguageExtensionURI,
+		Description: "Languages the agent accepts messages in",
+		Params:      &params,
+		Required:    p.enforcement == config.LanguageEnforcementRefuse,
+	})
+	return card
+}
+
+// normalizeLanguage reduces a language tag such as "en-US" to its ISO 639-1 code
+func normalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if code, _, found := strings.Cut(language, "-"); found {
+		return code
+	}
+	if code, _, found := strings.Cut(language, "_"); found {
+		return code
+	}
+	return language
+}
+
+// languageName returns the English name of a language code, or the code when unknown
+func languageName(language string) string {
+	if name, exists := languageNames[language]; exists {
+		return name
+	}
+	return language
+}
+
+// setMessageMetadata sets a metadata value on a message without sharing the caller's map
+func setMessageMetadata(message *types.Message, key string, value any) {
+	metadata := make(map[string]any)
+	if message.Metadata != nil {
+		for k, v := range *message.Metadata {
+			metadata[k] = v
+		}
+	}
+	metadata[key] = value
+	message.Metadata = &metadata
+}
@@ -0,0 +1,239 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+type fakeTranslator struct {
+	err error
+}
+
+func (t *fakeTranslator) Translate(ctx context.Context, text, targetLanguage string) (string, error) {
+	if t.err != nil {
+		return "", t.err
+	}
+	return fmt.Sprintf("[%s] %s", targetLanguage, text), nil
+}
+
+func newLanguageMessage(text string) *types.Message {
+	return &types.Message{
+		MessageID: "msg-1",
+		Role:      types.RoleUser,
+		Parts:     []types.Part{types.CreateTextPart(text)},
+	}
+}
+
+func TestDefaultLanguageDetector_Detect(t *testing.T) {
+	detector := NewDefaultLanguageDetector()
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "english", text: "What is the weather like in the city today?", expected: "en"},
+		{name: "spanish", text: "Hola, ¿puedes decirme qué tiempo hace en la ciudad?", expected: "es"},
+		{name: "french", text: "Bonjour, je voudrais savoir le temps qu'il fait dans la ville.", expected: "fr"},
+		{name: "german", text: "Kannst du mir sagen, wie das Wetter in der Stadt ist?", expected: "de"},
+		{name: "japanese", text: "今日の天気はどうですか", expected: "ja"},
+		{name: "russian", text: "Какая сегодня погода в городе?", expected: "ru"},
+		{name: "no words", text: "12345 !!!", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			language, confidence := detector.Detect(tt.text)
+			assert.Equal(t, tt.expected, language)
+			if tt.expected != "" {
+				assert.GreaterOrEqual(t, confidence, minLanguageConfidence)
+			}
+		})
+	}
+}
+
+func TestLanguagePolicy_Apply(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             config.LanguageConfig
+		translator      Translator
+		text            string
+		expectLanguage  string
+		expectSupported bool
+		expectRefusal   bool
+		expectText      string
+	}{
+		{
+			name:            "detection only",
+			cfg:             config.LanguageConfig{Enable: true},
+			text:            "Hola, ¿puedes decirme qué tiempo hace en la ciudad?",
+			expectLanguage:  "es",
+			expectSupported: true,
+			expectText:      "Hola, ¿puedes decirme qué tiempo hace en la ciudad?",
+		},
+		{
+			name:            "supported language",
+			cfg:             config.LanguageConfig{Enable: true, Supported: []string{"en-US"}, Enforcement: config.LanguageEnforcementRefuse},
+			text:            "What is the weather like in the city today?",
+			expectLanguage:  "en",
+			expectSupported: true,
+			expectText:      "What is the weather like in the city today?",
+		},
+		{
+			name:           "unsupported language is refused",
+			cfg:            config.LanguageConfig{Enable: true, Supported: []string{"en", "fr"}, Enforcement: config.LanguageEnforcementRefuse},
+			text:           "Hola, ¿puedes decirme qué tiempo hace en la ciudad?",
+			expectLanguage: "es",
+			expectRefusal:  true,
+			expectText:     "Hola, ¿puedes decirme qué tiempo hace en la ciudad?",
+		},
+		{
+			name:           "unsupported language is translated",
+			cfg:            config.LanguageConfig{Enable: true, Supported: []string{"en"}, Enforcement: config.LanguageEnforcementTranslate},
+			translator:     &fakeTranslator{},
+			text:           "Hola, ¿puedes decirme qué tiempo hace en la ciudad?",
+			expectLanguage: "es",
+			expectText:     "[en] Hola, ¿puedes decirme qué tiempo hace en la ciudad?",
+		},
+		{
+			name:           "failed translation passes the message through",
+			cfg:            config.LanguageConfig{Enable: true, Supported: []string{"en"}, Enforcement: config.LanguageEnforcementTranslate},
+			translator:     &fakeTranslator{err: fmt.Errorf("llm unavailable")},
+			text:           "Hola, ¿puedes decirme qué tiempo hace en la ciudad?",
+			expectLanguage: "es",
+			expectText:     "Hola, ¿puedes decirme qué tiempo hace en la ciudad?",
+		},
+		{
+			name:            "undetected language is accepted",
+			cfg:             config.LanguageConfig{Enable: true, Supported: []string{"en"}, Enforcement: config.LanguageEnforcementRefuse},
+			text:            "42",
+			expectSupported: true,
+			expectText:      "42",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := NewLanguagePolicy(tt.cfg, zap.NewNop())
+			if tt.translator != nil {
+				policy.SetTranslator(tt.translator)
+			}
+
+			message := newLanguageMessage(tt.text)
+			decision := policy.Apply(context.Background(), message)
+
+			assert.Equal(t, tt.expectLanguage, decision.Language)
+			assert.Equal(t, tt.expectSupported, decision.Supported)
+			assert.Equal(t, tt.expectText, *message.Parts[0].Text)
+			if tt.expectLanguage != "" {
+				require.NotNil(t, message.Metadata)
+				assert.Equal(t, tt.expectLanguage, (*message.Metadata)[types.LanguageMetadataKey])
+			}
+
+			if tt.expectText != tt.text {
+				assert.True(t, decision.Translated)
+				assert.Equal(t, tt.text, (*message.Parts[0].Metadata)[types.OriginalTextMetadataKey])
+				assert.Equal(t, tt.expectLanguage, (*message.Metadata)[types.TranslatedFromMetadataKey])
+			}
+
+			if !tt.expectRefusal {
+				assert.Nil(t, decision.Refusal)
+				return
+			}
+			require.NotNil(t, decision.Refusal)
+			assert.Equal(t, types.RoleAgent, decision.Refusal.Role)
+			assert.Contains(t, *decision.Refusal.Parts[0].Text, "English, Français")
+			assert.Equal(t, "en", (*decision.Refusal.Metadata)[types.LanguageMetadataKey])
+		})
+	}
+}
+
+func TestLanguagePolicy_WithLanguageExtension(t *testing.T) {
+	policy := NewLanguagePolicy(config.LanguageConfig{
+		Enable:      true,
+		Supported:   []string{"en", "de"},
+		Enforcement: config.LanguageEnforcementRefuse,
+	}, zap.NewNop())
+
+	card := policy.withLanguageExtension(types.AgentCard{Name: "agent"})
+	require.Len(t, card.Capabilities.Extensions, 1)
+	extension := card.Capabilities.Extensions[0]
+	assert.Equal(t, types.LanguageExtensionURI, extension.URI)
+	assert.True(t, extension.Required)
+	assert.Equal(t, []string{"en", "de"}, types.GetSupportedLanguages(&card))
+
+	again := policy.withLanguageExtension(card)
+	assert.Len(t, again.Capabilities.Extensions, 1)
+
+	detectionOnly := NewLanguagePolicy(config.LanguageConfig{Enable: true}, zap.NewNop())
+	assert.Empty(t, detectionOnly.withLanguageExtension(types.AgentCard{}).Capabilities.Extensions)
+}
+
+func TestA2AServer_LanguageRefusal(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		LanguageConfig: config.LanguageConfig{
+			Enable:      true,
+			Supported:   []string{"en"},
+			Enforcement: config.LanguageEnforcementRefuse,
+		},
+	}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "agent"})
+	router := s.setupRouter(cfg)
+
+	send := func(text string) types.Task {
+		body, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      "req-1",
+			"method":  "message/send",
+			"params": map[string]any{
+				"message": map[string]any{
+					"kind":      "message",
+					"messageId": "msg-1",
+					"role":      "user",
+					"parts":     []map[string]any{{"kind": "text", "text": text}},
+				},
+			},
+		})
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", bytes.NewReader(body)))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Result types.Task `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Result
+	}
+
+	refused := send("Hola, ¿puedes decirme qué tiempo hace en la ciudad?")
+	assert.Equal(t, types.TaskStateRejected, refused.Status.State)
+	require.NotNil(t, refused.Status.Message)
+	assert.Contains(t, *refused.Status.Message.Parts[0].Text, "English")
+	require.NotNil(t, refused.Metadata)
+	assert.Equal(t, "es", (*refused.Metadata)[types.LanguageMetadataKey])
+
+	accepted := send("What is the weather like in the city today?")
+	assert.Equal(t, types.TaskStateSubmitted, accepted.Status.State)
+	require.NotNil(t, accepted.Metadata)
+	assert.Equal(t, "en", (*accepted.Metadata)[types.LanguageMetadataKey])
+
+	card := s.GetAgentCard()
+	require.NotNil(t, card)
+	assert.Equal(t, []string{"en"}, types.GetSupportedLanguages(card))
+}
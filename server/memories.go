@@ -0,0 +1,264 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// callerMemory returns a memory unless it belongs to another user than the caller, or to the
+// context of another user, in which case it is reported as missing. Callers without a user
+// only reach the memories of contexts of no user.
+func (h *DefaultA2AProtocolHandler) callerMemory(ctx context.Context, id string) (*types.Memory, error) {
+	memory, err := h.memories.Get(ctx, id)
+	if err != nil || memory == nil {
+		return memory, err
+	}
+	userID := middlewares.UserIDFromContext(ctx)
+	if memory.UserID != "" {
+		if memory.UserID != userID {
+			return nil, nil
+		}
+		return memory, nil
+	}
+	foreign, err := foreignContext(ctx, h.storage, userID, memory.ContextID)
+	if err != nil || foreign {
+		return nil, err
+	}
+	return memory, nil
+}
+
+// HandleMemoryCreate processes memories/create requests
+func (h *DefaultA2AProtocolHandler) HandleMemoryCreate(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	if h.memories == nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidRequest), "memory is not enabled")
+		return
+	}
+
+	var params types.MemoryCreateParams
+	paramsBytes, err := json.Marshal(req.Params)
+	if err != nil {
+		logger.Error("failed to marshal params", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
+		return
+	}
+
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		logger.Error("failed to parse memories/create request", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
+		return
+	}
+
+	if strings.TrimSpace(params.Content) == "" {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "content is required")
+		return
+	}
+	if userID := middlewares.UserIDFromContext(c.Request.Context()); userID != "" && (params.UserID != "" || params.ContextID == "") {
+		params.UserID = userID
+	} else if userID == "" && params.UserID != "" {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "user id requires a user identity")
+		return
+	}
+	if params.ContextID == "" && params.UserID == "" {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "context id or user id is required")
+		return
+	}
+
+	memory, err := h.memories.Create(c.Request.Context(), params)
+	if err != nil {
+		logger.Error("failed to create memory", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to create memory")
+		return
+	}
+
+	logger.Info("memory created", zap.String("memory_id", memory.ID))
+	h.responseSender.SendSuccess(c, req.ID, memory)
+}
+
+// HandleMemoryGet processes memories/get requests
+func (h *DefaultA2AProtocolHandler) HandleMemoryGet(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	if h.memories == nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidRequest), "memory is not enabled")
+		return
+	}
+
+	var params types.MemoryIdParams
+	paramsBytes, err := json.Marshal(req.Params)
+	if err != nil {
+		logger.Error("failed to marshal params", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
+		return
+	}
+
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		logger.Error("failed to parse memories/get request", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
+		return
+	}
+
+	if params.ID == "" {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "memory id is required")
+		return
+	}
+
+	memory, err := h.callerMemory(c.Request.Context(), params.ID)
+	if err != nil {
+		logger.Error("failed to get memory", zap.String("memory_id", params.ID), zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to get memory")
+		return
+	}
+	if memory == nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "memory not found")
+		return
+	}
+
+	h.responseSender.SendSuccess(c, req.ID, memory)
+}
+
+// HandleMemoryList processes memories/list requests, listing the memories of a user or of a
+// context. Callers identified as a user only list their own memories, or those of their contexts.
+func (h *DefaultA2AProtocolHandler) HandleMemoryList(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	if h.memories == nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidRequest), "memory is not enabled")
+		return
+	}
+
+	var params types.MemoryListParams
+	paramsBytes, err := json.Marshal(req.Params)
+	if err != nil {
+		logger.Error("failed to marshal params", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
+		return
+	}
+
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		logger.Error("failed to parse memories/list request", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
+		return
+	}
+
+	if userID := middlewares.UserIDFromContext(c.Request.Context()); userID != "" && (params.UserID != "" || params.ContextID == "") {
+		params.UserID = userID
+	} else if userID == "" && params.UserID != "" {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "user id requires a user identity")
+		return
+	}
+
+	if params.ContextID == "" && params.UserID == "" {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "context id or user id is required")
+		return
+	}
+
+	memories, err := h.memories.List(c.Request.Context(), params)
+	if err != nil {
+		logger.Error("failed to list memories", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to list memories")
+		return
+	}
+
+	logger.Info("memories listed", zap.Int("count", len(memories)))
+	h.responseSender.SendSuccess(c, req.ID, types.MemoryListResult{Memories: memories})
+}
+
+// HandleMemoryUpdate processes memories/update requests
+func (h *DefaultA2AProtocolHandler) HandleMemoryUpdate(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	if h.memories == nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidRequest), "memory is not enabled")
+		return
+	}
+
+	var params types.MemoryUpdateParams
+	paramsBytes, err := json.Marshal(req.Params)
+	if err != nil {
+		logger.Error("failed to marshal params", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
+		return
+	}
+
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		logger.Error("failed to parse memories/update request", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
+		return
+	}
+
+	if params.ID == "" {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "memory id is required")
+		return
+	}
+	if strings.TrimSpace(params.Content) == "" {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "content is required")
+		return
+	}
+
+	memory, err := h.callerMemory(c.Request.Context(), params.ID)
+	if err == nil && memory != nil {
+		memory, err = h.memories.Update(c.Request.Context(), params)
+	}
+	if err != nil {
+		logger.Error("failed to update memory", zap.String("memory_id", params.ID), zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to update memory")
+		return
+	}
+	if memory == nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "memory not found")
+		return
+	}
+
+	logger.Info("memory updated", zap.String("memory_id", memory.ID))
+	h.responseSender.SendSuccess(c, req.ID, memory)
+}
+
+// HandleMemoryDelete processes memories/delete requests, returning the deleted memory
+func (h *DefaultA2AProtocolHandler) HandleMemoryDelete(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	if h.memories == nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidRequest), "memory is not enabled")
+		return
+	}
+
+	var params types.MemoryIdParams
+	paramsBytes, err := json.Marshal(req.Params)
+	if err != nil {
+		logger.Error("failed to marshal params", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
+		return
+	}
+
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		logger.Error("failed to parse memories/delete request", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
+		return
+	}
+
+	if params.ID == "" {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "memory id is required")
+		return
+	}
+
+	memory, err := h.callerMemory(c.Request.Context(), params.ID)
+	if err == nil && memory != nil {
+		_, err = h.memories.Delete(c.Request.Context(), params.ID)
+	}
+	if err != nil {
+		logger.Error("failed to delete memory", zap.String("memory_id", params.ID), zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to delete memory")
+		return
+	}
+	if memory == nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "memory not found")
+		return
+	}
+
+	logger.Info("memory deleted", zap.String("memory_id", params.ID))
+	h.responseSender.SendSuccess(c, req.ID, memory)
+}
@@ -0,0 +1,513 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// memoryContextKey carries the memories recalled for an agent run to its system prompt
+const memoryContextKey ContextKey = "memories"
+
+// MemoryRecord is a stored memory with the embedding of its content, when memories are
+// ranked by relevance
+type MemoryRecord struct {
+	types.Memory
+	Embedding []float32 `json:"embedding,omitempty"`
+}
+
+// MemoryFilter selects the memories of a user, when UserID is set, or of a context
+type MemoryFilter struct {
+	ContextID string
+	UserID    string
+}
+
+// matches reports whether a memory belongs to the user or context of the filter
+func (f MemoryFilter) matches(memory types.Memory) bool {
+	if f.UserID != "" {
+		return memory.UserID == f.UserID
+	}
+	return memory.UserID == "" && memory.ContextID == f.ContextID
+}
+
+// MemoryStore persists memories
+type MemoryStore interface {
+	// Save stores a memory, replacing the memory with the same ID
+	Save(ctx context.Context, record MemoryRecord) error
+
+	// Get returns the memory with the ID, or nil when there is none
+	Get(ctx context.Context, id string) (*MemoryRecord, error)
+
+	// List returns the memories matching the filter, oldest first
+	List(ctx context.Context, filter MemoryFilter) ([]MemoryRecord, error)
+
+	// Delete removes the memory with the ID and reports whether it existed
+	Delete(ctx context.Context, id string) (bool, error)
+}
+
+var _ MemoryStore = (*InMemoryMemoryStore)(nil)
+
+// InMemoryMemoryStore is a MemoryStore that keeps memories in memory, losing them on restart
+type InMemoryMemoryStore struct {
+	mu       sync.RWMutex
+	memories map[string]MemoryRecord
+}
+
+// NewInMemoryMemoryStore creates an empty in-memory memory store
+func NewInMemoryMemoryStore() *InMemoryMemoryStore {
+	return &InMemoryMemoryStore{memories: make(map[string]MemoryRecord)}
+}
+
+// Save stores a memory
+func (s *InMemoryMemoryStore) Save(ctx context.Context, record MemoryRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.memories[record.ID] = record
+	return nil
+}
+
+// Get returns the memory with the ID
+func (s *InMemoryMemoryStore) Get(ctx context.Context, id string) (*MemoryRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	record, ok := s.memories[id]
+	if !ok {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+// List returns the memories matching the filter, oldest first
+func (s *InMemoryMemoryStore) List(ctx context.Context, filter MemoryFilter) ([]MemoryRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var records []MemoryRecord
+	for _, record := range s.memories {
+		if filter.matches(record.Memory) {
+			records = append(records, record)
+		}
+	}
+	slices.SortFunc(records, func(a, b MemoryRecord) int {
+		if c := strings.Compare(a.CreatedAt, b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return records, nil
+}
+
+// Delete removes the memory with the ID
+func (s *InMemoryMemoryStore) Delete(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.memories[id]
+	delete(s.memories, id)
+	return ok, nil
+}
+
+// MemoryExtractor extracts the facts worth remembering from a conversation
+type MemoryExtractor interface {
+	// Extract returns the new facts and preferences stated in the messages, as short
+	// self-contained statements, leaving out those already known
+	Extract(ctx context.Context, known []string, messages []types.Message) ([]string, error)
+}
+
+// memoryExtractionPrompt instructs the LLM to extract memories from a conversation
+const memoryExtractionPrompt = `You maintain the long-term memory of an assistant. Read the conversation and extract the durable facts about the user and their preferences that will help in future conversations, such as their name, role, projects, constraints and how they like to be answered. Leave out small talk, one-off requests and anything already known. Write each fact as a short self-contained statement. Reply with a JSON array of strings only, or [] when there is nothing new worth remembering.`
+
+// LLMMemoryExtractor extracts memories with a chat completion from the configured LLM
+type LLMMemoryExtractor struct {
+	client LLMClient
+}
+
+var _ MemoryExtractor = (*LLMMemoryExtractor)(nil)
+
+// NewLLMMemoryExtractor creates a memory extractor backed by an LLM client
+func NewLLMMemoryExtractor(client LLMClient) *LLMMemoryExtractor {
+	return &LLMMemoryExtractor{client: client}
+}
+
+// Extract asks the LLM for the new facts stated in the conversation
+func (e *LLMMemoryExtractor) Extract(ctx context.Context, known []string, messages []types.Message) ([]string, error) {
+	instruction := memoryExtractionPrompt
+	if len(known) > 0 {
+		instruction += "\n\nAlready known:\n- " + strings.Join(known, "\n- ")
+	}
+	systemMessage, err := sdk.NewTextMessage(sdk.System, instruction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create memory extraction instruction: %w", err)
+	}
+	conversationMessage, err := sdk.NewTextMessage(sdk.User, memoryConversation(messages))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create memory extraction message: %w", err)
+	}
+
+	response, err := e.client.CreateChatCompletion(ctx, []sdk.Message{systemMessage, conversationMessage})
+	if err != nil {
+		return nil, fmt.Errorf("memory extraction request failed: %w", err)
+	}
+	if response == nil || len(response.Choices) == 0 {
+		return nil, fmt.Errorf("memory extraction response is empty")
+	}
+	content, err := response.Choices[0].Message.Content.AsMessageContent0()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read memory extraction: %w", err)
+	}
+	return parseExtractedMemories(content)
+}
+
+// memoryConversation renders the text of the user and agent messages as a conversation
+func memoryConversation(messages []types.Message) string {
+	var b strings.Builder
+	for _, message := range messages {
+		speaker := "User"
+		if message.Role == types.RoleAgent {
+			speaker = "Assistant"
+		}
+		for _, part := range message.Parts {
+			if part.Text != nil && strings.TrimSpace(*part.Text) != "" {
+				fmt.Fprintf(&b, "%s: %s\n", speaker, strings.TrimSpace(*part.Text))
+			}
+		}
+	}
+	return b.String()
+}
+
+// parseExtractedMemories reads the JSON array of facts in a model reply, which may wrap it in
+// a code fence or surrounding prose
+func parseExtractedMemories(content string) ([]string, error) {
+	start, end := strings.Index(content, "["), strings.LastIndex(content, "]")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("memory extraction reply is not a JSON array")
+	}
+	var facts []string
+	if err := json.Unmarshal([]byte(content[start:end+1]), &facts); err != nil {
+		return nil, fmt.Errorf("failed to parse extracted memories: %w", err)
+	}
+	return facts, nil
+}
+
+// MemoryService remembers the facts and preferences stated in completed tasks and recalls
+// them in later runs. After a task completes, its conversation is handed to the extractor and
+// the new facts are stored for the task's context, or for its authenticated user with the user
+// scope; before a run, the memories of the same owner are added to the system prompt, the most
+// relevant first when an embedder is set and the most recent first otherwise.
+type MemoryService struct {
+	store     MemoryStore
+	extractor MemoryExtractor
+	embedder  Embedder
+	cfg       config.MemoryConfig
+	logger    *zap.Logger
+	clock     Clock
+	ids       IDGenerator
+
+	// mu serializes extractions so concurrent tasks of an owner do not store the same fact twice
+	mu sync.Mutex
+}
+
+// NewMemoryService creates a memory service storing the memories the extractor finds
+func NewMemoryService(store MemoryStore, extractor MemoryExtractor, cfg config.MemoryConfig, logger *zap.Logger) *MemoryService {
+	return &MemoryService{
+		store:     store,
+		extractor: extractor,
+		cfg:       cfg,
+		logger:    logger,
+		clock:     SystemClock{},
+		ids:       UUIDGenerator{},
+	}
+}
+
+// SetEmbedder ranks recalled memories by their similarity to the incoming message
+func (m *MemoryService) SetEmbedder(embedder Embedder) {
+	m.embedder = embedder
+}
+
+// Create stores a memory for the user it names, or for the context
+func (m *MemoryService) Create(ctx context.Context, params types.MemoryCreateParams) (*types.Memory, error) {
+	now := m.clock.Now().UTC().Format(time.RFC3339Nano)
+	record := MemoryRecord{Memory: types.Memory{
+		ID:        m.ids.NewID(),
+		Content:   strings.TrimSpace(params.Content),
+		ContextID: params.ContextID,
+		UserID:    params.UserID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}}
+	if err := m.save(ctx, &record); err != nil {
+		return nil, err
+	}
+	return &record.Memory, nil
+}
+
+// Get returns the memory with the ID, or nil when there is none
+func (m *MemoryService) Get(ctx context.Context, id string) (*types.Memory, error) {
+	record, err := m.store.Get(ctx, id)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	return &record.Memory, nil
+}
+
+// List returns the memories of a user, or of a context, oldest first
+func (m *MemoryService) List(ctx context.Context, params types.MemoryListParams) ([]types.Memory, error) {
+	records, err := m.store.List(ctx, MemoryFilter{ContextID: params.ContextID, UserID: params.UserID})
+	if err != nil {
+		return nil, err
+	}
+	memories := make([]types.Memory, 0, len(records))
+	for _, record := range records {
+		memories = append(memories, record.Memory)
+	}
+	return memories, nil
+}
+
+// Update replaces the content of a memory and returns it, or nil when there is none
+func (m *MemoryService) Update(ctx context.Context, params types.MemoryUpdateParams) (*types.Memory, error) {
+	record, err := m.store.Get(ctx, params.ID)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	record.Content = strings.TrimSpace(params.Content)
+	record.UpdatedAt = m.clock.Now().UTC().Format(time.RFC3339Nano)
+	if err := m.save(ctx, record); err != nil {
+		return nil, err
+	}
+	return &record.Memory, nil
+}
+
+// Delete removes a memory and reports whether it existed
+func (m *MemoryService) Delete(ctx context.Context, id string) (bool, error) {
+	return m.store.Delete(ctx, id)
+}
+
+// Remember extracts the new facts stated in a completed task and stores them for the task's
+// owner, returning the memories created. Tasks that are not completed, or hold fewer user and
+// agent messages than configured, are skipped.
+func (m *MemoryService) Remember(ctx context.Context, task *types.Task) ([]types.Memory, error) {
+	if task == nil || task.Status.State != types.TaskStateCompleted {
+		return nil, nil
+	}
+	var messages []types.Message
+	for _, message := range conversationMessages(task) {
+		if message.Role == types.RoleUser || message.Role == types.RoleAgent {
+			messages = append(messages, message)
+		}
+	}
+	if len(messages) == 0 || len(messages) < m.cfg.MinMessages {
+		return nil, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	filter := m.owner(task)
+	existing, err := m.store.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memories: %w", err)
+	}
+	known := make([]string, 0, len(existing))
+	seen := make(map[string]bool, len(existing))
+	for _, record := range existing {
+		known = append(known, record.Content)
+		seen[strings.ToLower(record.Content)] = true
+	}
+
+	facts, err := m.extractor.Extract(ctx, known, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []types.Memory
+	for _, fact := range facts {
+		fact = strings.TrimSpace(fact)
+		if fact == "" || seen[strings.ToLower(fact)] {
+			continue
+		}
+		seen[strings.ToLower(fact)] = true
+		now := m.clock.Now().UTC().Format(time.RFC3339Nano)
+		record := MemoryRecord{Memory: types.Memory{
+			ID:        m.ids.NewID(),
+			Content:   fact,
+			ContextID: task.ContextID,
+			UserID:    filter.UserID,
+			TaskID:    task.ID,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}}
+		if err := m.save(ctx, &record); err != nil {
+			return created, err
+		}
+		existing = append(existing, record)
+		created = append(created, record.Memory)
+	}
+
+	if m.cfg.MaxPerOwner > 0 && len(existing) > m.cfg.MaxPerOwner {
+		for _, record := range existing[:len(existing)-m.cfg.MaxPerOwner] {
+			if _, err := m.store.Delete(ctx, record.ID); err != nil {
+				return created, fmt.Errorf("failed to forget memory: %w", err)
+			}
+		}
+	}
+	return created, nil
+}
+
+// Recall returns the memories of the task's owner to add to the system prompt of a run on the
+// message, limited to the configured number
+func (m *MemoryService) Recall(ctx context.Context, task *types.Task, message *types.Message) ([]types.Memory, error) {
+	records, err := m.store.List(ctx, m.owner(task))
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(records)
+
+	if m.embedder != nil && message != nil {
+		if query := latestUserText([]types.Message{*message}); query != "" {
+			records, err = m.rank(ctx, records, query)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if m.cfg.RecallLimit > 0 && len(records) > m.cfg.RecallLimit {
+		records = records[:m.cfg.RecallLimit]
+	}
+	memories := make([]types.Memory, 0, len(records))
+	for _, record := range records {
+		memories = append(memories, record.Memory)
+	}
+	return memories, nil
+}
+
+// rank orders memories by the similarity of their embedding to the query, most similar first
+func (m *MemoryService) rank(ctx context.Context, records []MemoryRecord, query string) ([]MemoryRecord, error) {
+	embeddings, err := m.embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed memory query: %w", err)
+	}
+	if len(embeddings) != 1 {
+		return nil, fmt.Errorf("embedder returned %d embeddings for 1 query", len(embeddings))
+	}
+	scores := make(map[string]float64, len(records))
+	for _, record := range records {
+		if len(record.Embedding) == len(embeddings[0]) {
+			scores[record.ID] = cosineSimilarity(embeddings[0], record.Embedding)
+		}
+	}
+	slices.SortStableFunc(records, func(a, b MemoryRecord) int {
+		switch {
+		case scores[a.ID] > scores[b.ID]:
+			return -1
+		case scores[a.ID] < scores[b.ID]:
+			return 1
+		}
+		return 0
+	})
+	return records, nil
+}
+
+// save embeds the content of a memory, when an embedder is set, and stores it
+func (m *MemoryService) save(ctx context.Context, record *MemoryRecord) error {
+	if m.embedder != nil {
+		embeddings, err := m.embedder.Embed(ctx, []string{record.Content})
+		if err != nil {
+			return fmt.Errorf("failed to embed memory: %w", err)
+		}
+		if len(embeddings) != 1 {
+			return fmt.Errorf("embedder returned %d embeddings for 1 memory", len(embeddings))
+		}
+		record.Embedding = embeddings[0]
+	}
+	if err := m.store.Save(ctx, *record); err != nil {
+		return fmt.Errorf("failed to save memory: %w", err)
+	}
+	return nil
+}
+
+// owner returns the filter selecting the memories of the task's authenticated user with the
+// user scope, and of its context otherwise
+func (m *MemoryService) owner(task *types.Task) MemoryFilter {
+	if m.cfg.Scope == config.MemoryScopeUser {
+		if userID := types.GetTaskUserID(task); userID != "" {
+			return MemoryFilter{UserID: userID}
+		}
+	}
+	return MemoryFilter{ContextID: task.ContextID}
+}
+
+// rememberInBackground extracts memories from a completed task without holding up its
+// response, working on a snapshot of the task since the caller keeps updating it
+func (m *MemoryService) rememberInBackground(ctx context.Context, task *types.Task) {
+	if m == nil || task == nil || task.Status.State != types.TaskStateCompleted {
+		return
+	}
+	snapshot := *task
+	snapshot.History = slices.Clone(task.History)
+	if task.Metadata != nil {
+		metadata := maps.Clone(*task.Metadata)
+		snapshot.Metadata = &metadata
+	}
+	go func() {
+		created, err := m.Remember(context.WithoutCancel(ctx), &snapshot)
+		if err != nil {
+			m.logger.Warn("failed to extract memories",
+				zap.String("task_id", snapshot.ID),
+				zap.String("context_id", snapshot.ContextID),
+				zap.Error(err))
+			return
+		}
+		if len(created) > 0 {
+			m.logger.Debug("memories extracted",
+				zap.String("task_id", snapshot.ID),
+				zap.Int("count", len(created)))
+		}
+	}()
+}
+
+// runContext returns a copy of ctx carrying the memories recalled for a run of the task on
+// the message. Memories that cannot be recalled are logged and left out of the run.
+func (m *MemoryService) runContext(ctx context.Context, task *types.Task, message *types.Message) context.Context {
+	if m == nil || task == nil {
+		return ctx
+	}
+	memories, err := m.Recall(ctx, task, message)
+	if err != nil {
+		m.logger.Warn("failed to recall memories",
+			zap.String("task_id", task.ID),
+			zap.String("context_id", task.ContextID),
+			zap.Error(err))
+		return ctx
+	}
+	if len(memories) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, memoryContextKey, memories)
+}
+
+// memorySystemPrompt returns the system prompt with the memories recalled for the run appended
+func memorySystemPrompt(ctx context.Context, prompt string) string {
+	memories, _ := ctx.Value(memoryContextKey).([]types.Memory)
+	if len(memories) == 0 {
+		return prompt
+	}
+	var b strings.Builder
+	b.WriteString("What you remember from earlier conversations with the user:")
+	for _, memory := range memories {
+		b.WriteString("\n- " + memory.Content)
+	}
+	if prompt == "" {
+		return b.String()
+	}
+	return prompt + "\n\n" + b.String()
+}
@@ -0,0 +1,239 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// stubMemoryExtractor returns the same facts for every conversation and records what it was told
+type stubMemoryExtractor struct {
+	facts []string
+	known []string
+}
+
+func (e *stubMemoryExtractor) Extract(ctx context.Context, known []string, messages []types.Message) ([]string, error) {
+	e.known = known
+	return e.facts, nil
+}
+
+func newMemoryTestTask(contextID, userID string) *types.Task {
+	metadata := types.Struct{}
+	if userID != "" {
+		metadata[types.UserIDMetadataKey] = userID
+	}
+	return &types.Task{
+		ID:        "task-" + contextID,
+		ContextID: contextID,
+		Status:    types.TaskStatus{State: types.TaskStateCompleted},
+		Metadata:  &metadata,
+		History: []types.Message{
+			{MessageID: "m-1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("I'm Alice, please answer in metric units")}},
+			{MessageID: "m-2", Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart("Noted, Alice.")}},
+		},
+	}
+}
+
+func TestMemoryService_RememberAndRecall(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.MemoryConfig{Scope: config.MemoryScopeContext, RecallLimit: 10, MaxPerOwner: 3, MinMessages: 2}
+
+	t.Run("facts are remembered once per context", func(t *testing.T) {
+		extractor := &stubMemoryExtractor{facts: []string{"The user is Alice", " Prefers metric units ", ""}}
+		memories := NewMemoryService(NewInMemoryMemoryStore(), extractor, cfg, zap.NewNop())
+
+		created, err := memories.Remember(ctx, newMemoryTestTask("ctx-1", "alice"))
+		require.NoError(t, err)
+		require.Len(t, created, 2)
+		assert.Equal(t, "Prefers metric units", created[1].Content)
+		assert.Equal(t, "ctx-1", created[1].ContextID)
+		assert.Equal(t, "task-ctx-1", created[1].TaskID)
+		assert.Empty(t, created[1].UserID, "the context scope keeps memories for the context")
+
+		extractor.facts = []string{"prefers metric units", "Works on the billing service"}
+		created, err = memories.Remember(ctx, newMemoryTestTask("ctx-1", ""))
+		require.NoError(t, err)
+		require.Len(t, created, 1, "facts already known are not stored twice")
+		assert.Equal(t, []string{"The user is Alice", "Prefers metric units"}, extractor.known)
+
+		recalled, err := memories.Recall(ctx, newMemoryTestTask("ctx-1", ""), nil)
+		require.NoError(t, err)
+		assert.Len(t, recalled, 3)
+		other, err := memories.Recall(ctx, newMemoryTestTask("ctx-2", ""), nil)
+		require.NoError(t, err)
+		assert.Empty(t, other)
+	})
+
+	t.Run("the oldest memories are forgotten beyond the limit", func(t *testing.T) {
+		extractor := &stubMemoryExtractor{facts: []string{"fact 1", "fact 2", "fact 3"}}
+		memories := NewMemoryService(NewInMemoryMemoryStore(), extractor, cfg, zap.NewNop())
+		_, err := memories.Remember(ctx, newMemoryTestTask("ctx-1", ""))
+		require.NoError(t, err)
+		extractor.facts = []string{"fact 4"}
+		_, err = memories.Remember(ctx, newMemoryTestTask("ctx-1", ""))
+		require.NoError(t, err)
+
+		listed, err := memories.List(ctx, types.MemoryListParams{ContextID: "ctx-1"})
+		require.NoError(t, err)
+		require.Len(t, listed, 3)
+		assert.Equal(t, "fact 2", listed[0].Content)
+		assert.Equal(t, "fact 4", listed[2].Content)
+	})
+
+	t.Run("the user scope shares memories across contexts", func(t *testing.T) {
+		userCfg := cfg
+		userCfg.Scope = config.MemoryScopeUser
+		memories := NewMemoryService(NewInMemoryMemoryStore(), &stubMemoryExtractor{facts: []string{"Prefers metric units"}}, userCfg, zap.NewNop())
+		_, err := memories.Remember(ctx, newMemoryTestTask("ctx-1", "alice"))
+		require.NoError(t, err)
+
+		recalled, err := memories.Recall(ctx, newMemoryTestTask("ctx-2", "alice"), nil)
+		require.NoError(t, err)
+		require.Len(t, recalled, 1)
+		assert.Equal(t, "alice", recalled[0].UserID)
+		anonymous, err := memories.Recall(ctx, newMemoryTestTask("ctx-2", ""), nil)
+		require.NoError(t, err)
+		assert.Empty(t, anonymous, "tasks without a user fall back to their context")
+	})
+
+	t.Run("short or unfinished tasks are skipped", func(t *testing.T) {
+		extractor := &stubMemoryExtractor{facts: []string{"fact"}}
+		memories := NewMemoryService(NewInMemoryMemoryStore(), extractor, cfg, zap.NewNop())
+		short := newMemoryTestTask("ctx-1", "")
+		short.History = short.History[:1]
+		created, err := memories.Remember(ctx, short)
+		require.NoError(t, err)
+		assert.Empty(t, created)
+
+		working := newMemoryTestTask("ctx-1", "")
+		working.Status.State = types.TaskStateWorking
+		created, err = memories.Remember(ctx, working)
+		require.NoError(t, err)
+		assert.Empty(t, created)
+	})
+}
+
+func TestMemoryService_RecallRanksByRelevance(t *testing.T) {
+	ctx := context.Background()
+	embedder := EmbedderFunc(func(ctx context.Context, texts []string) ([][]float32, error) {
+		embeddings := make([][]float32, len(texts))
+		for i, text := range texts {
+			if strings.Contains(strings.ToLower(text), "units") {
+				embeddings[i] = []float32{1, 0}
+			} else {
+				embeddings[i] = []float32{0, 1}
+			}
+		}
+		return embeddings, nil
+	})
+	cfg := config.MemoryConfig{Scope: config.MemoryScopeContext, RecallLimit: 1}
+	memories := NewMemoryService(NewInMemoryMemoryStore(), &stubMemoryExtractor{}, cfg, zap.NewNop())
+	memories.SetEmbedder(embedder)
+
+	_, err := memories.Create(ctx, types.MemoryCreateParams{ContextID: "ctx-1", Content: "Prefers metric units"})
+	require.NoError(t, err)
+	_, err = memories.Create(ctx, types.MemoryCreateParams{ContextID: "ctx-1", Content: "Works on the billing service"})
+	require.NoError(t, err)
+
+	message := &types.Message{Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("Which units should I use?")}}
+	recalled, err := memories.Recall(ctx, newMemoryTestTask("ctx-1", ""), message)
+	require.NoError(t, err)
+	require.Len(t, recalled, 1)
+	assert.Equal(t, "Prefers metric units", recalled[0].Content)
+
+	runCtx := memories.runContext(ctx, newMemoryTestTask("ctx-1", ""), message)
+	assert.Equal(t, "You are helpful.\n\nWhat you remember from earlier conversations with the user:\n- Prefers metric units",
+		memorySystemPrompt(runCtx, "You are helpful."))
+	assert.Equal(t, "You are helpful.", memorySystemPrompt(ctx, "You are helpful."))
+}
+
+func TestParseExtractedMemories(t *testing.T) {
+	facts, err := parseExtractedMemories("```json\n[\"Prefers metric units\"]\n```")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Prefers metric units"}, facts)
+
+	facts, err = parseExtractedMemories("[]")
+	require.NoError(t, err)
+	assert.Empty(t, facts)
+
+	_, err = parseExtractedMemories("Nothing to remember.")
+	assert.Error(t, err)
+}
+
+func TestA2AServer_MemoryLifecycle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{UserConfig: config.UserConfig{Header: "X-User-ID"}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "assistant"})
+	router := s.setupRouter(cfg)
+
+	userID := "alice"
+	call := func(method string, params any, result any) *types.JSONRPCError {
+		encoded, err := json.Marshal(params)
+		require.NoError(t, err)
+		body := `{"jsonrpc":"2.0","id":"1","method":"` + method + `","params":` + string(encoded) + `}`
+		w := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body))
+		if userID != "" {
+			request.Header.Set("X-User-ID", userID)
+		}
+		router.ServeHTTP(w, request)
+		var response struct {
+			Result json.RawMessage     `json:"result"`
+			Error  *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		if response.Error == nil && result != nil {
+			require.NoError(t, json.Unmarshal(response.Result, result))
+		}
+		return response.Error
+	}
+
+	rpcErr := call("memories/list", types.MemoryListParams{ContextID: "ctx-1"}, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, "memory is not enabled", rpcErr.Message)
+
+	s.setMemoryService(NewMemoryService(NewInMemoryMemoryStore(), &stubMemoryExtractor{}, config.MemoryConfig{Scope: config.MemoryScopeUser}, zap.NewNop()))
+
+	var created types.Memory
+	require.Nil(t, call("memories/create", types.MemoryCreateParams{UserID: "alice", Content: "Prefers metric units"}, &created))
+	assert.NotEmpty(t, created.ID)
+	assert.NotEmpty(t, created.CreatedAt)
+
+	userID = ""
+	rpcErr = call("memories/create", types.MemoryCreateParams{Content: "Prefers metric units"}, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, "context id or user id is required", rpcErr.Message)
+	userID = "alice"
+
+	var updated types.Memory
+	require.Nil(t, call("memories/update", types.MemoryUpdateParams{ID: created.ID, Content: "Prefers imperial units"}, &updated))
+	assert.Equal(t, "Prefers imperial units", updated.Content)
+
+	var fetched types.Memory
+	require.Nil(t, call("memories/get", types.MemoryIdParams{ID: created.ID}, &fetched))
+	assert.Equal(t, "Prefers imperial units", fetched.Content)
+
+	var listed types.MemoryListResult
+	require.Nil(t, call("memories/list", types.MemoryListParams{UserID: "alice"}, &listed))
+	require.Len(t, listed.Memories, 1)
+
+	var deleted types.Memory
+	require.Nil(t, call("memories/delete", types.MemoryIdParams{ID: created.ID}, &deleted))
+	assert.Equal(t, created.ID, deleted.ID)
+
+	rpcErr = call("memories/get", types.MemoryIdParams{ID: created.ID}, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, "memory not found", rpcErr.Message)
+}
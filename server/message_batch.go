@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// HandleMessageSendBatch processes message/sendBatch requests. Every message is checked
+// before any task is created, and no task is queued until all of them are created, so a
+// rejected batch leaves no task running.
+func (h *DefaultA2AProtocolHandler) HandleMessageSendBatch(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	var params types.MessageSendBatchParams
+	paramsBytes, err := json.Marshal(req.Params)
+	if err != nil {
+		logger.Error("failed to marshal params", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
+		return
+	}
+
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		logger.Error("failed to parse message/sendBatch request", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
+		return
+	}
+
+	if len(params.Messages) == 0 {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "messages are required")
+		return
+	}
+
+	dependencies := make([][]string, len(params.Messages))
+	for i, message := range params.Messages {
+		taskDependencies, code, err := h.checkBatchMessage(message)
+		if err != nil {
+			h.responseSender.SendError(c, req.ID, int(code), fmt.Sprintf("messages[%d]: %s", i, err))
+			return
+		}
+		dependencies[i] = taskDependencies
+	}
+
+	tasks, err := h.createBatchTasks(c.Request.Context(), params.Messages)
+	if err != nil {
+		logger.Error("failed to create batch tasks", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(taskErrorCode(err)), err.Error())
+		return
+	}
+
+	for i, task := range tasks {
+		if task.Status.State != types.TaskStateSubmitted {
+			continue
+		}
+		if len(dependencies[i]) > 0 {
+			err = h.taskManager.(*DefaultTaskManager).HoldForDependencies(c.Request.Context(), task.ID, dependencies[i])
+		} else {
+			err = h.storage.EnqueueTask(c.Request.Context(), task, req.ID)
+		}
+		if err != nil {
+			logger.Error("failed to enqueue batch task",
+				zap.String("task_id", task.ID),
+				zap.Error(err))
+			h.abandonBatchTasks(c.Request.Context(), tasks[i:], "Failed to queue task for processing. Please try again later.")
+			h.responseSender.SendError(c, req.ID, int(ErrInternalError), "Failed to queue task")
+			return
+		}
+	}
+
+	result := types.MessageSendBatchResult{TaskIDs: make([]string, 0, len(tasks))}
+	for _, task := range tasks {
+		result.TaskIDs = append(result.TaskIDs, task.ID)
+	}
+	logger.Info("batch tasks created", zap.Int("tasks", len(tasks)))
+	h.responseSender.SendSuccess(c, req.ID, result)
+}
+
+// checkBatchMessage checks that a message of a batch starts a new task with valid labels and
+// dependencies, returning the tasks it depends on or the JSON-RPC error code to report
+func (h *DefaultA2AProtocolHandler) checkBatchMessage(params types.MessageSendParams) ([]string, JRPCErrorCode, error) {
+	if params.Message.TaskID != nil {
+		return nil, ErrInvalidParams, fmt.Errorf("a batch only starts new tasks, the message must not have a task id")
+	}
+	if len(params.Message.Parts) == 0 {
+		return nil, ErrInvalidParams, fmt.Errorf("empty message parts not allowed")
+	}
+	if _, err := parseTaskLabels(params.Metadata); err != nil {
+		return nil, ErrInvalidParams, err
+	}
+
+	dependencies, err := parseTaskDependencies(params.Metadata)
+	if err != nil {
+		return nil, ErrInvalidParams, err
+	}
+	if len(dependencies) > 0 {
+		if code, err := h.checkTaskDependencies(params, dependencies); err != nil {
+			return nil, code, err
+		}
+	}
+	return dependencies, 0, nil
+}
+
+// createBatchTasks creates a task for each message of a batch. When a task cannot be created,
+// the tasks created before it are failed, so none of them is processed.
+func (h *DefaultA2AProtocolHandler) createBatchTasks(ctx context.Context, messages []types.MessageSendParams) ([]*types.Task, error) {
+	tasks := make([]*types.Task, 0, len(messages))
+	for i, params := range messages {
+		task, _, err := h.createTaskFromMessage(ctx, params)
+		if err != nil {
+			h.abandonBatchTasks(ctx, tasks, "Task was not started because another message of its batch could not be processed.")
+			return nil, fmt.Errorf("messages[%d]: %w", i, err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// abandonBatchTasks fails the submitted tasks of a batch that could not be started with the reason
+func (h *DefaultA2AProtocolHandler) abandonBatchTasks(ctx context.Context, tasks []*types.Task, reason string) {
+	logger := requestLogger(ctx, h.logger)
+	for _, task := range tasks {
+		if task.Status.State != types.TaskStateSubmitted {
+			continue
+		}
+		err := h.taskManager.UpdateError(task.ID, &types.Message{
+			MessageID: h.ids.NewID(),
+			Role:      types.RoleAgent,
+			TaskID:    &task.ID,
+			ContextID: &task.ContextID,
+			Parts:     []types.Part{types.CreateTextPart(reason)},
+		})
+		if err != nil {
+			logger.Error("failed to fail task of abandoned batch",
+				zap.String("task_id", task.ID),
+				zap.Error(err))
+		}
+	}
+}
+
+// decodeBatchParams decodes the params of a message/sendBatch request. It returns nil for
+// other methods and for malformed params, which the method handler reports.
+func decodeBatchParams(req types.JSONRPCRequest) *types.MessageSendBatchParams {
+	if req.Method != "message/sendBatch" {
+		return nil
+	}
+	data, err := json.Marshal(req.Params)
+	if err != nil {
+		return nil
+	}
+	var params types.MessageSendBatchParams
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil
+	}
+	return &params
+}
+
+// checkBatch checks the size of a batch, then each of its messages like the message of a
+// message/send request, including that it only reaches the caller's own contexts and tasks,
+// returning the error of the first message rejected
+func (s *A2AServerImpl) checkBatch(ctx context.Context, req types.JSONRPCRequest, batch *types.MessageSendBatchParams) *JSONRPCMethodError {
+	if limit := s.cfg.ServerConfig.MaxBatchSize; limit > 0 && len(batch.Messages) > limit {
+		return &JSONRPCMethodError{
+			Code:    int(ErrInvalidParams),
+			Message: fmt.Sprintf("batch has %d messages, the limit is %d", len(batch.Messages), limit),
+		}
+	}
+	if err := s.checkMethodPolicy(ctx, req, nil); err != nil {
+		return err
+	}
+
+	limits := s.payloadLimitsFor(req.Method)
+	for i := range batch.Messages {
+		params := &batch.Messages[i]
+		err := s.checkMessageAccess(ctx, *params)
+		if err == nil {
+			err = limits.checkMessage(params.Message)
+		}
+		if err == nil {
+			err = s.checkMethodPolicy(ctx, req, params)
+		}
+		if err == nil {
+			err = s.fileIngestion.checkMessage(params.Message)
+		}
+		if err == nil && s.cfg.EnforceInputModes {
+			err = s.checkInputModes(ctx, params.Message)
+		}
+		if err != nil {
+			err.Message = fmt.Sprintf("messages[%d]: %s", i, err.Message)
+			return err
+		}
+	}
+	return nil
+}
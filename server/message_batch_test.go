@@ -0,0 +1,113 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestA2AServer_MessageSendBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	sendBatch := func(t *testing.T, router *gin.Engine, params string) (*types.MessageSendBatchResult, *types.JSONRPCError) {
+		body := `{"jsonrpc":"2.0","id":"1","method":"message/sendBatch","params":` + params + `}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+		var response struct {
+			Result *types.MessageSendBatchResult `json:"result"`
+			Error  *types.JSONRPCError           `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Result, response.Error
+	}
+
+	batchOf := func(t *testing.T, messages ...types.Message) string {
+		params := types.MessageSendBatchParams{}
+		for _, message := range messages {
+			params.Messages = append(params.Messages, types.MessageSendParams{Message: message})
+		}
+		encoded, err := json.Marshal(params)
+		require.NoError(t, err)
+		return string(encoded)
+	}
+
+	message := func(id, text string) types.Message {
+		return types.Message{MessageID: id, Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart(text)}}
+	}
+
+	setup := func(cfg *config.Config) (*A2AServerImpl, *gin.Engine) {
+		s := NewA2AServer(cfg, zap.NewNop(), nil)
+		s.SetAgentCard(types.AgentCard{Name: "assistant"})
+		return s, s.setupRouter(cfg)
+	}
+
+	t.Run("a task is queued for each message", func(t *testing.T) {
+		s, router := setup(&config.Config{})
+		result, rpcErr := sendBatch(t, router, batchOf(t,
+			message("m-1", "summarize document 1"),
+			message("m-2", "summarize document 2"),
+			message("m-3", "summarize document 3"),
+		))
+		require.Nil(t, rpcErr)
+		require.NotNil(t, result)
+		require.Len(t, result.TaskIDs, 3)
+		assert.Equal(t, 3, s.storage.GetQueueLength())
+
+		for i, taskID := range result.TaskIDs {
+			task, exists := s.taskManager.GetTask(taskID)
+			require.True(t, exists)
+			assert.Equal(t, types.TaskStateSubmitted, task.Status.State)
+			assert.Equal(t, "summarize document "+strconv.Itoa(i+1), *task.History[len(task.History)-1].Parts[0].Text)
+		}
+	})
+
+	t.Run("no task is created when a message is rejected", func(t *testing.T) {
+		s, router := setup(&config.Config{})
+		resumed := message("m-2", "continue")
+		taskID := "task-1"
+		resumed.TaskID = &taskID
+
+		_, rpcErr := sendBatch(t, router, batchOf(t, message("m-1", "summarize"), resumed))
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, int(ErrInvalidParams), rpcErr.Code)
+		assert.Contains(t, rpcErr.Message, "messages[1]")
+		assert.Zero(t, s.storage.GetQueueLength())
+		tasks, err := s.storage.ListTasks(TaskFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+	})
+
+	t.Run("batches over the limit are rejected", func(t *testing.T) {
+		s, router := setup(&config.Config{ServerConfig: config.ServerConfig{MaxBatchSize: 2}})
+		_, rpcErr := sendBatch(t, router, batchOf(t, message("m-1", "a"), message("m-2", "b"), message("m-3", "c")))
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, int(ErrInvalidParams), rpcErr.Code)
+		assert.Contains(t, rpcErr.Message, "the limit is 2")
+		assert.Zero(t, s.storage.GetQueueLength())
+	})
+
+	t.Run("messages are validated against the schema", func(t *testing.T) {
+		cfg := &config.Config{ServerConfig: config.ServerConfig{Validation: config.RequestValidation{Enable: true, MissingFields: config.ValidationMissingFieldsFill}}}
+		s, router := setup(cfg)
+		_, rpcErr := sendBatch(t, router, `{"messages":[{"message":{"role":"user","parts":[{"kind":"text","text":"a"}]}},{"message":{"role":"robot","parts":[]}}]}`)
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, int(ErrInvalidParams), rpcErr.Code)
+		assert.Contains(t, rpcErr.Message, "messages[1].message.role")
+		assert.Zero(t, s.storage.GetQueueLength())
+
+		_, rpcErr = sendBatch(t, router, `{}`)
+		require.NotNil(t, rpcErr)
+		assert.Contains(t, rpcErr.Message, "messages is required")
+	})
+}
@@ -17,8 +17,66 @@ type contextKey string
 const (
 	AuthTokenContextKey contextKey = "authToken"
 	IDTokenContextKey   contextKey = "idToken"
+	ScopesContextKey    contextKey = "scopes"
+	SubjectContextKey   contextKey = "subject"
+	UserIDContextKey    contextKey = "userId"
 )
 
+// ContextWithScopes returns a copy of ctx carrying the scopes granted to the authenticated caller.
+// Custom authenticators use it so scope preconditions can be evaluated for their callers.
+func ContextWithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, ScopesContextKey, scopes)
+}
+
+// ScopesFromContext returns the scopes granted to the authenticated caller, if any
+func ScopesFromContext(ctx context.Context) []string {
+	scopes, _ := ctx.Value(ScopesContextKey).([]string)
+	return scopes
+}
+
+// ContextWithSubject returns a copy of ctx carrying the subject identifying the authenticated
+// caller. Custom authenticators use it so the access policy can be evaluated for their callers.
+func ContextWithSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, SubjectContextKey, subject)
+}
+
+// SubjectFromContext returns the subject identifying the authenticated caller, if any
+func SubjectFromContext(ctx context.Context) string {
+	subject, _ := ctx.Value(SubjectContextKey).(string)
+	return subject
+}
+
+// ContextWithUserID returns a copy of ctx carrying the ID of the user a request is made for.
+// Custom authenticators use it to name the user directly, rather than through the subject.
+func ContextWithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, UserIDContextKey, userID)
+}
+
+// UserIDFromContext returns the ID of the user a request is made for, if any
+func UserIDFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(UserIDContextKey).(string)
+	return userID
+}
+
+// scopesFromClaims collects the scopes of a token from the space-separated "scope" claim
+// and the "scp" claim, which identity providers encode as a string or a list
+func scopesFromClaims(claims map[string]any) []string {
+	var scopes []string
+	for _, key := range []string{"scope", "scp"} {
+		switch value := claims[key].(type) {
+		case string:
+			scopes = append(scopes, strings.Fields(value)...)
+		case []any:
+			for _, item := range value {
+				if scope, ok := item.(string); ok && scope != "" {
+					scopes = append(scopes, scope)
+				}
+			}
+		}
+	}
+	return scopes
+}
+
 // OIDCAuthenticator interface for authentication middleware
 type OIDCAuthenticator interface {
 	Middleware() gin.HandlerFunc
@@ -94,8 +152,16 @@ func (auth *OIDCAuthenticatorImpl) Middleware() gin.HandlerFunc {
 			return
 		}
 
+		var claims map[string]any
+		if err := idToken.Claims(&claims); err != nil {
+			auth.logger.Warn("failed to decode token claims", zap.Error(err))
+		}
+		scopes := scopesFromClaims(claims)
+
 		c.Set(string(AuthTokenContextKey), token)
 		c.Set(string(IDTokenContextKey), idToken)
+		c.Set(string(ScopesContextKey), scopes)
+		c.Request = c.Request.WithContext(ContextWithSubject(ContextWithScopes(c.Request.Context(), scopes), idToken.Subject))
 		c.Next()
 	}
 }
@@ -0,0 +1,75 @@
+package middlewares
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/inference-gateway/adk/server/config"
+)
+
+// CORSMiddleware returns a gin middleware answering the cross-origin requests of browsers from
+// the allowed origins. Preflight requests are answered without reaching the handlers, so it
+// must run before authentication; preflight requests from other origins are rejected.
+func CORSMiddleware(cfg config.CORSConfig) gin.HandlerFunc {
+	anyOrigin := slices.Contains(cfg.AllowedOrigins, "*")
+	allowedMethods := strings.Join(cfg.AllowedMethods, ", ")
+	allowedHeaders := strings.Join(cfg.AllowedHeaders, ", ")
+	exposedHeaders := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if !anyOrigin && !slices.ContainsFunc(cfg.AllowedOrigins, func(allowed string) bool {
+			return strings.EqualFold(allowed, origin)
+		}) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		if anyOrigin && !cfg.AllowCredentials {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			// credentials are never sent to a wildcard origin, so the origin is echoed
+			header.Set("Access-Control-Allow-Origin", origin)
+		}
+		if cfg.AllowCredentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if exposedHeaders != "" {
+				header.Set("Access-Control-Expose-Headers", exposedHeaders)
+			}
+			c.Next()
+			return
+		}
+
+		header.Add("Vary", "Access-Control-Request-Method")
+		header.Add("Vary", "Access-Control-Request-Headers")
+		if allowedMethods != "" {
+			header.Set("Access-Control-Allow-Methods", allowedMethods)
+		}
+		if allowedHeaders != "" {
+			header.Set("Access-Control-Allow-Headers", allowedHeaders)
+		}
+		if cfg.MaxAge > 0 {
+			header.Set("Access-Control-Max-Age", maxAge)
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,138 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+
+	gin "github.com/gin-gonic/gin"
+
+	config "github.com/inference-gateway/adk/server/config"
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := config.CORSConfig{
+		Enable:         true,
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+		ExposedHeaders: []string{"X-Request-ID"},
+		MaxAge:         10 * time.Minute,
+	}
+
+	tests := []struct {
+		name     string
+		cfg      func(cfg config.CORSConfig) config.CORSConfig
+		method   string
+		headers  map[string]string
+		status   int
+		expected map[string]string
+	}{
+		{
+			name:     "same-origin request",
+			method:   http.MethodPost,
+			status:   http.StatusOK,
+			expected: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:    "request from an allowed origin",
+			method:  http.MethodPost,
+			headers: map[string]string{"Origin": "https://app.example.com"},
+			status:  http.StatusOK,
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":   "https://app.example.com",
+				"Access-Control-Expose-Headers": "X-Request-ID",
+				"Access-Control-Allow-Methods":  "",
+			},
+		},
+		{
+			name:     "request from another origin",
+			method:   http.MethodPost,
+			headers:  map[string]string{"Origin": "https://evil.example.com"},
+			status:   http.StatusOK,
+			expected: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:   "preflight request from an allowed origin",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://app.example.com",
+				"Access-Control-Request-Method": "POST",
+			},
+			status: http.StatusNoContent,
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":  "https://app.example.com",
+				"Access-Control-Allow-Methods": "GET, POST",
+				"Access-Control-Allow-Headers": "Authorization, Content-Type",
+				"Access-Control-Max-Age":       "600",
+			},
+		},
+		{
+			name:   "preflight request from another origin",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://evil.example.com",
+				"Access-Control-Request-Method": "POST",
+			},
+			status:   http.StatusForbidden,
+			expected: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name: "any origin",
+			cfg: func(cfg config.CORSConfig) config.CORSConfig {
+				cfg.AllowedOrigins = []string{"*"}
+				return cfg
+			},
+			method:   http.MethodPost,
+			headers:  map[string]string{"Origin": "https://evil.example.com"},
+			status:   http.StatusOK,
+			expected: map[string]string{"Access-Control-Allow-Origin": "*"},
+		},
+		{
+			name: "any origin with credentials",
+			cfg: func(cfg config.CORSConfig) config.CORSConfig {
+				cfg.AllowedOrigins = []string{"*"}
+				cfg.AllowCredentials = true
+				return cfg
+			},
+			method:  http.MethodPost,
+			headers: map[string]string{"Origin": "https://evil.example.com"},
+			status:  http.StatusOK,
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":      "https://evil.example.com",
+				"Access-Control-Allow-Credentials": "true",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			corsConfig := cfg
+			if tt.cfg != nil {
+				corsConfig = tt.cfg(cfg)
+			}
+			router := gin.New()
+			router.Use(middlewares.CORSMiddleware(corsConfig))
+			router.POST("/a2a", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/a2a", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			for header, value := range tt.expected {
+				assert.Equal(t, value, w.Header().Get(header), header)
+			}
+		})
+	}
+}
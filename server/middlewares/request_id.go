@@ -0,0 +1,83 @@
+package middlewares
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/inference-gateway/adk/types"
+)
+
+// RequestIDContextKey is the key of the correlation ID of a request in its context
+const RequestIDContextKey contextKey = "requestID"
+
+// maxRequestIDLength is the longest correlation ID accepted from a caller, longer ones are
+// replaced by a generated ID
+const maxRequestIDLength = 128
+
+// ContextWithRequestID returns a copy of ctx carrying the correlation ID of the request
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDContextKey, requestID)
+}
+
+// RequestIDFromContext returns the correlation ID of the request, if any
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDContextKey).(string)
+	return requestID
+}
+
+// RequestIDMiddleware returns a gin middleware that correlates the logs of a request through
+// the ID in its X-Request-ID header or, without one, the trace ID of its W3C traceparent
+// header, generating one when the caller sends neither. The ID is returned in the
+// X-Request-ID header of the response.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(types.RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = traceIDFromTraceparent(c.GetHeader("traceparent"))
+		}
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+
+		c.Set(string(RequestIDContextKey), requestID)
+		c.Header(types.RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(ContextWithRequestID(c.Request.Context(), requestID))
+		c.Next()
+	}
+}
+
+// validRequestID reports whether a correlation ID sent by a caller is short and made of
+// printable ASCII characters, so it can safely be logged
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < 0x21 || requestID[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// traceIDFromTraceparent returns the trace ID of a W3C traceparent header, such as
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01, or an empty string when the
+// header is malformed or carries the invalid all-zero trace ID
+func traceIDFromTraceparent(traceparent string) string {
+	fields := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(fields) < 4 || len(fields[0]) != 2 || len(fields[1]) != 32 {
+		return ""
+	}
+	traceID := strings.ToLower(fields[1])
+	if strings.Trim(traceID, "0") == "" {
+		return ""
+	}
+	for _, r := range traceID {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return ""
+		}
+	}
+	return traceID
+}
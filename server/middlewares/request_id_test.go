@@ -0,0 +1,76 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+
+	gin "github.com/gin-gonic/gin"
+
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.RequestIDMiddleware())
+	router.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, middlewares.RequestIDFromContext(c.Request.Context()))
+	})
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expected string
+	}{
+		{
+			name:     "request id header is kept",
+			headers:  map[string]string{types.RequestIDHeader: "req-42"},
+			expected: "req-42",
+		},
+		{
+			name:     "trace id of traceparent is used without a request id",
+			headers:  map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			expected: "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{
+			name: "request id header takes precedence over traceparent",
+			headers: map[string]string{
+				types.RequestIDHeader: "req-42",
+				"traceparent":         "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			},
+			expected: "req-42",
+		},
+		{
+			name:    "invalid request id is replaced",
+			headers: map[string]string{types.RequestIDHeader: strings.Repeat("x", 200)},
+		},
+		{
+			name:    "all-zero trace id is ignored",
+			headers: map[string]string{"traceparent": "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			requestID := w.Header().Get(types.RequestIDHeader)
+			assert.Equal(t, requestID, w.Body.String(), "the response returns the request id")
+			if tt.expected != "" {
+				assert.Equal(t, tt.expected, requestID)
+			} else {
+				assert.Len(t, requestID, 36, "a uuid is generated")
+			}
+		})
+	}
+}
@@ -10,6 +10,36 @@ import (
 )
 
 type FakeA2AProtocolHandler struct {
+	HandleArtifactListStub        func(*gin.Context, types.JSONRPCRequest)
+	handleArtifactListMutex       sync.RWMutex
+	handleArtifactListArgsForCall []struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}
+	HandleContextCreateStub        func(*gin.Context, types.JSONRPCRequest)
+	handleContextCreateMutex       sync.RWMutex
+	handleContextCreateArgsForCall []struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}
+	HandleContextDeleteStub        func(*gin.Context, types.JSONRPCRequest)
+	handleContextDeleteMutex       sync.RWMutex
+	handleContextDeleteArgsForCall []struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}
+	HandleContextGetStub        func(*gin.Context, types.JSONRPCRequest)
+	handleContextGetMutex       sync.RWMutex
+	handleContextGetArgsForCall []struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}
+	HandleContextListStub        func(*gin.Context, types.JSONRPCRequest)
+	handleContextListMutex       sync.RWMutex
+	handleContextListArgsForCall []struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}
 	HandleGetAuthenticatedExtendedCardStub        func(*gin.Context, types.JSONRPCRequest, *types.AgentCard)
 	handleGetAuthenticatedExtendedCardMutex       sync.RWMutex
 	handleGetAuthenticatedExtendedCardArgsForCall []struct {
@@ -17,12 +47,48 @@ type FakeA2AProtocolHandler struct {
 		arg2 types.JSONRPCRequest
 		arg3 *types.AgentCard
 	}
+	HandleMemoryCreateStub        func(*gin.Context, types.JSONRPCRequest)
+	handleMemoryCreateMutex       sync.RWMutex
+	handleMemoryCreateArgsForCall []struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}
+	HandleMemoryDeleteStub        func(*gin.Context, types.JSONRPCRequest)
+	handleMemoryDeleteMutex       sync.RWMutex
+	handleMemoryDeleteArgsForCall []struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}
+	HandleMemoryGetStub        func(*gin.Context, types.JSONRPCRequest)
+	handleMemoryGetMutex       sync.RWMutex
+	handleMemoryGetArgsForCall []struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}
+	HandleMemoryListStub        func(*gin.Context, types.JSONRPCRequest)
+	handleMemoryListMutex       sync.RWMutex
+	handleMemoryListArgsForCall []struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}
+	HandleMemoryUpdateStub        func(*gin.Context, types.JSONRPCRequest)
+	handleMemoryUpdateMutex       sync.RWMutex
+	handleMemoryUpdateArgsForCall []struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}
 	HandleMessageSendStub        func(*gin.Context, types.JSONRPCRequest)
 	handleMessageSendMutex       sync.RWMutex
 	handleMessageSendArgsForCall []struct {
 		arg1 *gin.Context
 		arg2 types.JSONRPCRequest
 	}
+	HandleMessageSendBatchStub        func(*gin.Context, types.JSONRPCRequest)
+	handleMessageSendBatchMutex       sync.RWMutex
+	handleMessageSendBatchArgsForCall []struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}
 	HandleMessageStreamStub        func(*gin.Context, types.JSONRPCRequest, server.StreamableTaskHandler)
 	handleMessageStreamMutex       sync.RWMutex
 	handleMessageStreamArgsForCall []struct {
@@ -36,6 +102,18 @@ type FakeA2AProtocolHandler struct {
 		arg1 *gin.Context
 		arg2 types.JSONRPCRequest
 	}
+	HandleTaskFeedbackStub        func(*gin.Context, types.JSONRPCRequest)
+	handleTaskFeedbackMutex       sync.RWMutex
+	handleTaskFeedbackArgsForCall []struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}
+	HandleTaskForkStub        func(*gin.Context, types.JSONRPCRequest)
+	handleTaskForkMutex       sync.RWMutex
+	handleTaskForkArgsForCall []struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}
 	HandleTaskGetStub        func(*gin.Context, types.JSONRPCRequest)
 	handleTaskGetMutex       sync.RWMutex
 	handleTaskGetArgsForCall []struct {
@@ -79,10 +157,187 @@ type FakeA2AProtocolHandler struct {
 		arg2 types.JSONRPCRequest
 		arg3 server.StreamableTaskHandler
 	}
+	HandleTaskShareStub        func(*gin.Context, types.JSONRPCRequest)
+	handleTaskShareMutex       sync.RWMutex
+	handleTaskShareArgsForCall []struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}
+	HandleTaskUsageStub        func(*gin.Context, types.JSONRPCRequest)
+	handleTaskUsageMutex       sync.RWMutex
+	handleTaskUsageArgsForCall []struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeA2AProtocolHandler) HandleArtifactList(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
+	fake.handleArtifactListMutex.Lock()
+	fake.handleArtifactListArgsForCall = append(fake.handleArtifactListArgsForCall, struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}{arg1, arg2})
+	stub := fake.HandleArtifactListStub
+	fake.recordInvocation("HandleArtifactList", []interface{}{arg1, arg2})
+	fake.handleArtifactListMutex.Unlock()
+	if stub != nil {
+		fake.HandleArtifactListStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeA2AProtocolHandler) HandleArtifactListCallCount() int {
+	fake.handleArtifactListMutex.RLock()
+	defer fake.handleArtifactListMutex.RUnlock()
+	return len(fake.handleArtifactListArgsForCall)
+}
+
+func (fake *FakeA2AProtocolHandler) HandleArtifactListCalls(stub func(*gin.Context, types.JSONRPCRequest)) {
+	fake.handleArtifactListMutex.Lock()
+	defer fake.handleArtifactListMutex.Unlock()
+	fake.HandleArtifactListStub = stub
+}
+
+func (fake *FakeA2AProtocolHandler) HandleArtifactListArgsForCall(i int) (*gin.Context, types.JSONRPCRequest) {
+	fake.handleArtifactListMutex.RLock()
+	defer fake.handleArtifactListMutex.RUnlock()
+	argsForCall := fake.handleArtifactListArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextCreate(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
+	fake.handleContextCreateMutex.Lock()
+	fake.handleContextCreateArgsForCall = append(fake.handleContextCreateArgsForCall, struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}{arg1, arg2})
+	stub := fake.HandleContextCreateStub
+	fake.recordInvocation("HandleContextCreate", []interface{}{arg1, arg2})
+	fake.handleContextCreateMutex.Unlock()
+	if stub != nil {
+		fake.HandleContextCreateStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextCreateCallCount() int {
+	fake.handleContextCreateMutex.RLock()
+	defer fake.handleContextCreateMutex.RUnlock()
+	return len(fake.handleContextCreateArgsForCall)
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextCreateCalls(stub func(*gin.Context, types.JSONRPCRequest)) {
+	fake.handleContextCreateMutex.Lock()
+	defer fake.handleContextCreateMutex.Unlock()
+	fake.HandleContextCreateStub = stub
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextCreateArgsForCall(i int) (*gin.Context, types.JSONRPCRequest) {
+	fake.handleContextCreateMutex.RLock()
+	defer fake.handleContextCreateMutex.RUnlock()
+	argsForCall := fake.handleContextCreateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextDelete(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
+	fake.handleContextDeleteMutex.Lock()
+	fake.handleContextDeleteArgsForCall = append(fake.handleContextDeleteArgsForCall, struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}{arg1, arg2})
+	stub := fake.HandleContextDeleteStub
+	fake.recordInvocation("HandleContextDelete", []interface{}{arg1, arg2})
+	fake.handleContextDeleteMutex.Unlock()
+	if stub != nil {
+		fake.HandleContextDeleteStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextDeleteCallCount() int {
+	fake.handleContextDeleteMutex.RLock()
+	defer fake.handleContextDeleteMutex.RUnlock()
+	return len(fake.handleContextDeleteArgsForCall)
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextDeleteCalls(stub func(*gin.Context, types.JSONRPCRequest)) {
+	fake.handleContextDeleteMutex.Lock()
+	defer fake.handleContextDeleteMutex.Unlock()
+	fake.HandleContextDeleteStub = stub
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextDeleteArgsForCall(i int) (*gin.Context, types.JSONRPCRequest) {
+	fake.handleContextDeleteMutex.RLock()
+	defer fake.handleContextDeleteMutex.RUnlock()
+	argsForCall := fake.handleContextDeleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextGet(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
+	fake.handleContextGetMutex.Lock()
+	fake.handleContextGetArgsForCall = append(fake.handleContextGetArgsForCall, struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}{arg1, arg2})
+	stub := fake.HandleContextGetStub
+	fake.recordInvocation("HandleContextGet", []interface{}{arg1, arg2})
+	fake.handleContextGetMutex.Unlock()
+	if stub != nil {
+		fake.HandleContextGetStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextGetCallCount() int {
+	fake.handleContextGetMutex.RLock()
+	defer fake.handleContextGetMutex.RUnlock()
+	return len(fake.handleContextGetArgsForCall)
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextGetCalls(stub func(*gin.Context, types.JSONRPCRequest)) {
+	fake.handleContextGetMutex.Lock()
+	defer fake.handleContextGetMutex.Unlock()
+	fake.HandleContextGetStub = stub
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextGetArgsForCall(i int) (*gin.Context, types.JSONRPCRequest) {
+	fake.handleContextGetMutex.RLock()
+	defer fake.handleContextGetMutex.RUnlock()
+	argsForCall := fake.handleContextGetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextList(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
+	fake.handleContextListMutex.Lock()
+	fake.handleContextListArgsForCall = append(fake.handleContextListArgsForCall, struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}{arg1, arg2})
+	stub := fake.HandleContextListStub
+	fake.recordInvocation("HandleContextList", []interface{}{arg1, arg2})
+	fake.handleContextListMutex.Unlock()
+	if stub != nil {
+		fake.HandleContextListStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextListCallCount() int {
+	fake.handleContextListMutex.RLock()
+	defer fake.handleContextListMutex.RUnlock()
+	return len(fake.handleContextListArgsForCall)
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextListCalls(stub func(*gin.Context, types.JSONRPCRequest)) {
+	fake.handleContextListMutex.Lock()
+	defer fake.handleContextListMutex.Unlock()
+	fake.HandleContextListStub = stub
+}
+
+func (fake *FakeA2AProtocolHandler) HandleContextListArgsForCall(i int) (*gin.Context, types.JSONRPCRequest) {
+	fake.handleContextListMutex.RLock()
+	defer fake.handleContextListMutex.RUnlock()
+	argsForCall := fake.handleContextListArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeA2AProtocolHandler) HandleGetAuthenticatedExtendedCard(arg1 *gin.Context, arg2 types.JSONRPCRequest, arg3 *types.AgentCard) {
 	fake.handleGetAuthenticatedExtendedCardMutex.Lock()
 	fake.handleGetAuthenticatedExtendedCardArgsForCall = append(fake.handleGetAuthenticatedExtendedCardArgsForCall, struct {
@@ -117,6 +372,171 @@ func (fake *FakeA2AProtocolHandler) HandleGetAuthenticatedExtendedCardArgsForCal
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *FakeA2AProtocolHandler) HandleMemoryCreate(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
+	fake.handleMemoryCreateMutex.Lock()
+	fake.handleMemoryCreateArgsForCall = append(fake.handleMemoryCreateArgsForCall, struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}{arg1, arg2})
+	stub := fake.HandleMemoryCreateStub
+	fake.recordInvocation("HandleMemoryCreate", []interface{}{arg1, arg2})
+	fake.handleMemoryCreateMutex.Unlock()
+	if stub != nil {
+		fake.HandleMemoryCreateStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryCreateCallCount() int {
+	fake.handleMemoryCreateMutex.RLock()
+	defer fake.handleMemoryCreateMutex.RUnlock()
+	return len(fake.handleMemoryCreateArgsForCall)
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryCreateCalls(stub func(*gin.Context, types.JSONRPCRequest)) {
+	fake.handleMemoryCreateMutex.Lock()
+	defer fake.handleMemoryCreateMutex.Unlock()
+	fake.HandleMemoryCreateStub = stub
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryCreateArgsForCall(i int) (*gin.Context, types.JSONRPCRequest) {
+	fake.handleMemoryCreateMutex.RLock()
+	defer fake.handleMemoryCreateMutex.RUnlock()
+	argsForCall := fake.handleMemoryCreateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryDelete(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
+	fake.handleMemoryDeleteMutex.Lock()
+	fake.handleMemoryDeleteArgsForCall = append(fake.handleMemoryDeleteArgsForCall, struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}{arg1, arg2})
+	stub := fake.HandleMemoryDeleteStub
+	fake.recordInvocation("HandleMemoryDelete", []interface{}{arg1, arg2})
+	fake.handleMemoryDeleteMutex.Unlock()
+	if stub != nil {
+		fake.HandleMemoryDeleteStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryDeleteCallCount() int {
+	fake.handleMemoryDeleteMutex.RLock()
+	defer fake.handleMemoryDeleteMutex.RUnlock()
+	return len(fake.handleMemoryDeleteArgsForCall)
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryDeleteCalls(stub func(*gin.Context, types.JSONRPCRequest)) {
+	fake.handleMemoryDeleteMutex.Lock()
+	defer fake.handleMemoryDeleteMutex.Unlock()
+	fake.HandleMemoryDeleteStub = stub
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryDeleteArgsForCall(i int) (*gin.Context, types.JSONRPCRequest) {
+	fake.handleMemoryDeleteMutex.RLock()
+	defer fake.handleMemoryDeleteMutex.RUnlock()
+	argsForCall := fake.handleMemoryDeleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryGet(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
+	fake.handleMemoryGetMutex.Lock()
+	fake.handleMemoryGetArgsForCall = append(fake.handleMemoryGetArgsForCall, struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}{arg1, arg2})
+	stub := fake.HandleMemoryGetStub
+	fake.recordInvocation("HandleMemoryGet", []interface{}{arg1, arg2})
+	fake.handleMemoryGetMutex.Unlock()
+	if stub != nil {
+		fake.HandleMemoryGetStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryGetCallCount() int {
+	fake.handleMemoryGetMutex.RLock()
+	defer fake.handleMemoryGetMutex.RUnlock()
+	return len(fake.handleMemoryGetArgsForCall)
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryGetCalls(stub func(*gin.Context, types.JSONRPCRequest)) {
+	fake.handleMemoryGetMutex.Lock()
+	defer fake.handleMemoryGetMutex.Unlock()
+	fake.HandleMemoryGetStub = stub
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryGetArgsForCall(i int) (*gin.Context, types.JSONRPCRequest) {
+	fake.handleMemoryGetMutex.RLock()
+	defer fake.handleMemoryGetMutex.RUnlock()
+	argsForCall := fake.handleMemoryGetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryList(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
+	fake.handleMemoryListMutex.Lock()
+	fake.handleMemoryListArgsForCall = append(fake.handleMemoryListArgsForCall, struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}{arg1, arg2})
+	stub := fake.HandleMemoryListStub
+	fake.recordInvocation("HandleMemoryList", []interface{}{arg1, arg2})
+	fake.handleMemoryListMutex.Unlock()
+	if stub != nil {
+		fake.HandleMemoryListStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryListCallCount() int {
+	fake.handleMemoryListMutex.RLock()
+	defer fake.handleMemoryListMutex.RUnlock()
+	return len(fake.handleMemoryListArgsForCall)
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryListCalls(stub func(*gin.Context, types.JSONRPCRequest)) {
+	fake.handleMemoryListMutex.Lock()
+	defer fake.handleMemoryListMutex.Unlock()
+	fake.HandleMemoryListStub = stub
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryListArgsForCall(i int) (*gin.Context, types.JSONRPCRequest) {
+	fake.handleMemoryListMutex.RLock()
+	defer fake.handleMemoryListMutex.RUnlock()
+	argsForCall := fake.handleMemoryListArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryUpdate(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
+	fake.handleMemoryUpdateMutex.Lock()
+	fake.handleMemoryUpdateArgsForCall = append(fake.handleMemoryUpdateArgsForCall, struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}{arg1, arg2})
+	stub := fake.HandleMemoryUpdateStub
+	fake.recordInvocation("HandleMemoryUpdate", []interface{}{arg1, arg2})
+	fake.handleMemoryUpdateMutex.Unlock()
+	if stub != nil {
+		fake.HandleMemoryUpdateStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryUpdateCallCount() int {
+	fake.handleMemoryUpdateMutex.RLock()
+	defer fake.handleMemoryUpdateMutex.RUnlock()
+	return len(fake.handleMemoryUpdateArgsForCall)
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryUpdateCalls(stub func(*gin.Context, types.JSONRPCRequest)) {
+	fake.handleMemoryUpdateMutex.Lock()
+	defer fake.handleMemoryUpdateMutex.Unlock()
+	fake.HandleMemoryUpdateStub = stub
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMemoryUpdateArgsForCall(i int) (*gin.Context, types.JSONRPCRequest) {
+	fake.handleMemoryUpdateMutex.RLock()
+	defer fake.handleMemoryUpdateMutex.RUnlock()
+	argsForCall := fake.handleMemoryUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeA2AProtocolHandler) HandleMessageSend(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
 	fake.handleMessageSendMutex.Lock()
 	fake.handleMessageSendArgsForCall = append(fake.handleMessageSendArgsForCall, struct {
@@ -150,6 +570,39 @@ func (fake *FakeA2AProtocolHandler) HandleMessageSendArgsForCall(i int) (*gin.Co
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeA2AProtocolHandler) HandleMessageSendBatch(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
+	fake.handleMessageSendBatchMutex.Lock()
+	fake.handleMessageSendBatchArgsForCall = append(fake.handleMessageSendBatchArgsForCall, struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}{arg1, arg2})
+	stub := fake.HandleMessageSendBatchStub
+	fake.recordInvocation("HandleMessageSendBatch", []interface{}{arg1, arg2})
+	fake.handleMessageSendBatchMutex.Unlock()
+	if stub != nil {
+		fake.HandleMessageSendBatchStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMessageSendBatchCallCount() int {
+	fake.handleMessageSendBatchMutex.RLock()
+	defer fake.handleMessageSendBatchMutex.RUnlock()
+	return len(fake.handleMessageSendBatchArgsForCall)
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMessageSendBatchCalls(stub func(*gin.Context, types.JSONRPCRequest)) {
+	fake.handleMessageSendBatchMutex.Lock()
+	defer fake.handleMessageSendBatchMutex.Unlock()
+	fake.HandleMessageSendBatchStub = stub
+}
+
+func (fake *FakeA2AProtocolHandler) HandleMessageSendBatchArgsForCall(i int) (*gin.Context, types.JSONRPCRequest) {
+	fake.handleMessageSendBatchMutex.RLock()
+	defer fake.handleMessageSendBatchMutex.RUnlock()
+	argsForCall := fake.handleMessageSendBatchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeA2AProtocolHandler) HandleMessageStream(arg1 *gin.Context, arg2 types.JSONRPCRequest, arg3 server.StreamableTaskHandler) {
 	fake.handleMessageStreamMutex.Lock()
 	fake.handleMessageStreamArgsForCall = append(fake.handleMessageStreamArgsForCall, struct {
@@ -217,6 +670,72 @@ func (fake *FakeA2AProtocolHandler) HandleTaskCancelArgsForCall(i int) (*gin.Con
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeA2AProtocolHandler) HandleTaskFeedback(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
+	fake.handleTaskFeedbackMutex.Lock()
+	fake.handleTaskFeedbackArgsForCall = append(fake.handleTaskFeedbackArgsForCall, struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}{arg1, arg2})
+	stub := fake.HandleTaskFeedbackStub
+	fake.recordInvocation("HandleTaskFeedback", []interface{}{arg1, arg2})
+	fake.handleTaskFeedbackMutex.Unlock()
+	if stub != nil {
+		fake.HandleTaskFeedbackStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeA2AProtocolHandler) HandleTaskFeedbackCallCount() int {
+	fake.handleTaskFeedbackMutex.RLock()
+	defer fake.handleTaskFeedbackMutex.RUnlock()
+	return len(fake.handleTaskFeedbackArgsForCall)
+}
+
+func (fake *FakeA2AProtocolHandler) HandleTaskFeedbackCalls(stub func(*gin.Context, types.JSONRPCRequest)) {
+	fake.handleTaskFeedbackMutex.Lock()
+	defer fake.handleTaskFeedbackMutex.Unlock()
+	fake.HandleTaskFeedbackStub = stub
+}
+
+func (fake *FakeA2AProtocolHandler) HandleTaskFeedbackArgsForCall(i int) (*gin.Context, types.JSONRPCRequest) {
+	fake.handleTaskFeedbackMutex.RLock()
+	defer fake.handleTaskFeedbackMutex.RUnlock()
+	argsForCall := fake.handleTaskFeedbackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AProtocolHandler) HandleTaskFork(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
+	fake.handleTaskForkMutex.Lock()
+	fake.handleTaskForkArgsForCall = append(fake.handleTaskForkArgsForCall, struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}{arg1, arg2})
+	stub := fake.HandleTaskForkStub
+	fake.recordInvocation("HandleTaskFork", []interface{}{arg1, arg2})
+	fake.handleTaskForkMutex.Unlock()
+	if stub != nil {
+		fake.HandleTaskForkStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeA2AProtocolHandler) HandleTaskForkCallCount() int {
+	fake.handleTaskForkMutex.RLock()
+	defer fake.handleTaskForkMutex.RUnlock()
+	return len(fake.handleTaskForkArgsForCall)
+}
+
+func (fake *FakeA2AProtocolHandler) HandleTaskForkCalls(stub func(*gin.Context, types.JSONRPCRequest)) {
+	fake.handleTaskForkMutex.Lock()
+	defer fake.handleTaskForkMutex.Unlock()
+	fake.HandleTaskForkStub = stub
+}
+
+func (fake *FakeA2AProtocolHandler) HandleTaskForkArgsForCall(i int) (*gin.Context, types.JSONRPCRequest) {
+	fake.handleTaskForkMutex.RLock()
+	defer fake.handleTaskForkMutex.RUnlock()
+	argsForCall := fake.handleTaskForkArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeA2AProtocolHandler) HandleTaskGet(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
 	fake.handleTaskGetMutex.Lock()
 	fake.handleTaskGetArgsForCall = append(fake.handleTaskGetArgsForCall, struct {
@@ -449,17 +968,109 @@ func (fake *FakeA2AProtocolHandler) HandleTaskResubscribeArgsForCall(i int) (*gi
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *FakeA2AProtocolHandler) HandleTaskShare(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
+	fake.handleTaskShareMutex.Lock()
+	fake.handleTaskShareArgsForCall = append(fake.handleTaskShareArgsForCall, struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}{arg1, arg2})
+	stub := fake.HandleTaskShareStub
+	fake.recordInvocation("HandleTaskShare", []interface{}{arg1, arg2})
+	fake.handleTaskShareMutex.Unlock()
+	if stub != nil {
+		fake.HandleTaskShareStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeA2AProtocolHandler) HandleTaskShareCallCount() int {
+	fake.handleTaskShareMutex.RLock()
+	defer fake.handleTaskShareMutex.RUnlock()
+	return len(fake.handleTaskShareArgsForCall)
+}
+
+func (fake *FakeA2AProtocolHandler) HandleTaskShareCalls(stub func(*gin.Context, types.JSONRPCRequest)) {
+	fake.handleTaskShareMutex.Lock()
+	defer fake.handleTaskShareMutex.Unlock()
+	fake.HandleTaskShareStub = stub
+}
+
+func (fake *FakeA2AProtocolHandler) HandleTaskShareArgsForCall(i int) (*gin.Context, types.JSONRPCRequest) {
+	fake.handleTaskShareMutex.RLock()
+	defer fake.handleTaskShareMutex.RUnlock()
+	argsForCall := fake.handleTaskShareArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AProtocolHandler) HandleTaskUsage(arg1 *gin.Context, arg2 types.JSONRPCRequest) {
+	fake.handleTaskUsageMutex.Lock()
+	fake.handleTaskUsageArgsForCall = append(fake.handleTaskUsageArgsForCall, struct {
+		arg1 *gin.Context
+		arg2 types.JSONRPCRequest
+	}{arg1, arg2})
+	stub := fake.HandleTaskUsageStub
+	fake.recordInvocation("HandleTaskUsage", []interface{}{arg1, arg2})
+	fake.handleTaskUsageMutex.Unlock()
+	if stub != nil {
+		fake.HandleTaskUsageStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeA2AProtocolHandler) HandleTaskUsageCallCount() int {
+	fake.handleTaskUsageMutex.RLock()
+	defer fake.handleTaskUsageMutex.RUnlock()
+	return len(fake.handleTaskUsageArgsForCall)
+}
+
+func (fake *FakeA2AProtocolHandler) HandleTaskUsageCalls(stub func(*gin.Context, types.JSONRPCRequest)) {
+	fake.handleTaskUsageMutex.Lock()
+	defer fake.handleTaskUsageMutex.Unlock()
+	fake.HandleTaskUsageStub = stub
+}
+
+func (fake *FakeA2AProtocolHandler) HandleTaskUsageArgsForCall(i int) (*gin.Context, types.JSONRPCRequest) {
+	fake.handleTaskUsageMutex.RLock()
+	defer fake.handleTaskUsageMutex.RUnlock()
+	argsForCall := fake.handleTaskUsageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeA2AProtocolHandler) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.handleArtifactListMutex.RLock()
+	defer fake.handleArtifactListMutex.RUnlock()
+	fake.handleContextCreateMutex.RLock()
+	defer fake.handleContextCreateMutex.RUnlock()
+	fake.handleContextDeleteMutex.RLock()
+	defer fake.handleContextDeleteMutex.RUnlock()
+	fake.handleContextGetMutex.RLock()
+	defer fake.handleContextGetMutex.RUnlock()
+	fake.handleContextListMutex.RLock()
+	defer fake.handleContextListMutex.RUnlock()
 	fake.handleGetAuthenticatedExtendedCardMutex.RLock()
 	defer fake.handleGetAuthenticatedExtendedCardMutex.RUnlock()
+	fake.handleMemoryCreateMutex.RLock()
+	defer fake.handleMemoryCreateMutex.RUnlock()
+	fake.handleMemoryDeleteMutex.RLock()
+	defer fake.handleMemoryDeleteMutex.RUnlock()
+	fake.handleMemoryGetMutex.RLock()
+	defer fake.handleMemoryGetMutex.RUnlock()
+	fake.handleMemoryListMutex.RLock()
+	defer fake.handleMemoryListMutex.RUnlock()
+	fake.handleMemoryUpdateMutex.RLock()
+	defer fake.handleMemoryUpdateMutex.RUnlock()
 	fake.handleMessageSendMutex.RLock()
 	defer fake.handleMessageSendMutex.RUnlock()
+	fake.handleMessageSendBatchMutex.RLock()
+	defer fake.handleMessageSendBatchMutex.RUnlock()
 	fake.handleMessageStreamMutex.RLock()
 	defer fake.handleMessageStreamMutex.RUnlock()
 	fake.handleTaskCancelMutex.RLock()
 	defer fake.handleTaskCancelMutex.RUnlock()
+	fake.handleTaskFeedbackMutex.RLock()
+	defer fake.handleTaskFeedbackMutex.RUnlock()
+	fake.handleTaskForkMutex.RLock()
+	defer fake.handleTaskForkMutex.RUnlock()
 	fake.handleTaskGetMutex.RLock()
 	defer fake.handleTaskGetMutex.RUnlock()
 	fake.handleTaskListMutex.RLock()
@@ -474,6 +1085,10 @@ func (fake *FakeA2AProtocolHandler) Invocations() map[string][][]interface{} {
 	defer fake.handleTaskPushNotificationConfigSetMutex.RUnlock()
 	fake.handleTaskResubscribeMutex.RLock()
 	defer fake.handleTaskResubscribeMutex.RUnlock()
+	fake.handleTaskShareMutex.RLock()
+	defer fake.handleTaskShareMutex.RUnlock()
+	fake.handleTaskUsageMutex.RLock()
+	defer fake.handleTaskUsageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
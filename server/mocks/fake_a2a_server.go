@@ -3,6 +3,7 @@ package mocks
 
 import (
 	"context"
+	"net/http"
 	"sync"
 
 	"github.com/inference-gateway/adk/server"
@@ -40,6 +41,19 @@ type FakeA2AServer struct {
 	getBackgroundTaskHandlerReturnsOnCall map[int]struct {
 		result1 server.TaskHandler
 	}
+	GetNamedAgentStub        func(string) (server.A2AServer, bool)
+	getNamedAgentMutex       sync.RWMutex
+	getNamedAgentArgsForCall []struct {
+		arg1 string
+	}
+	getNamedAgentReturns struct {
+		result1 server.A2AServer
+		result2 bool
+	}
+	getNamedAgentReturnsOnCall map[int]struct {
+		result1 server.A2AServer
+		result2 bool
+	}
 	GetStreamingTaskHandlerStub        func() server.StreamableTaskHandler
 	getStreamingTaskHandlerMutex       sync.RWMutex
 	getStreamingTaskHandlerArgsForCall []struct {
@@ -50,6 +64,16 @@ type FakeA2AServer struct {
 	getStreamingTaskHandlerReturnsOnCall map[int]struct {
 		result1 server.StreamableTaskHandler
 	}
+	HandlerStub        func() http.Handler
+	handlerMutex       sync.RWMutex
+	handlerArgsForCall []struct {
+	}
+	handlerReturns struct {
+		result1 http.Handler
+	}
+	handlerReturnsOnCall map[int]struct {
+		result1 http.Handler
+	}
 	LoadAgentCardFromFileStub        func(string, map[string]any) error
 	loadAgentCardFromFileMutex       sync.RWMutex
 	loadAgentCardFromFileArgsForCall []struct {
@@ -62,6 +86,29 @@ type FakeA2AServer struct {
 	loadAgentCardFromFileReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RegisterJSONRPCMethodStub        func(string, server.JSONRPCMethodHandler) error
+	registerJSONRPCMethodMutex       sync.RWMutex
+	registerJSONRPCMethodArgsForCall []struct {
+		arg1 string
+		arg2 server.JSONRPCMethodHandler
+	}
+	registerJSONRPCMethodReturns struct {
+		result1 error
+	}
+	registerJSONRPCMethodReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RegisterSkillStub        func(types.AgentSkill) error
+	registerSkillMutex       sync.RWMutex
+	registerSkillArgsForCall []struct {
+		arg1 types.AgentSkill
+	}
+	registerSkillReturns struct {
+		result1 error
+	}
+	registerSkillReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetAgentStub        func(server.OpenAICompatibleAgent)
 	setAgentMutex       sync.RWMutex
 	setAgentArgsForCall []struct {
@@ -72,6 +119,11 @@ type FakeA2AServer struct {
 	setAgentCardArgsForCall []struct {
 		arg1 types.AgentCard
 	}
+	SetAgentCardProviderStub        func(server.AgentCardProvider)
+	setAgentCardProviderMutex       sync.RWMutex
+	setAgentCardProviderArgsForCall []struct {
+		arg1 server.AgentCardProvider
+	}
 	SetAgentDescriptionStub        func(string)
 	setAgentDescriptionMutex       sync.RWMutex
 	setAgentDescriptionArgsForCall []struct {
@@ -97,6 +149,11 @@ type FakeA2AServer struct {
 	setBackgroundTaskHandlerArgsForCall []struct {
 		arg1 server.TaskHandler
 	}
+	SetExtendedAgentCardStub        func(types.AgentCard)
+	setExtendedAgentCardMutex       sync.RWMutex
+	setExtendedAgentCardArgsForCall []struct {
+		arg1 types.AgentCard
+	}
 	SetStreamingTaskHandlerStub        func(server.StreamableTaskHandler)
 	setStreamingTaskHandlerMutex       sync.RWMutex
 	setStreamingTaskHandlerArgsForCall []struct {
@@ -113,6 +170,17 @@ type FakeA2AServer struct {
 	startReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StartBackgroundStub        func(context.Context) error
+	startBackgroundMutex       sync.RWMutex
+	startBackgroundArgsForCall []struct {
+		arg1 context.Context
+	}
+	startBackgroundReturns struct {
+		result1 error
+	}
+	startBackgroundReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StartTaskProcessorStub        func(context.Context)
 	startTaskProcessorMutex       sync.RWMutex
 	startTaskProcessorArgsForCall []struct {
@@ -129,6 +197,28 @@ type FakeA2AServer struct {
 	stopReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UnregisterSkillStub        func(string) error
+	unregisterSkillMutex       sync.RWMutex
+	unregisterSkillArgsForCall []struct {
+		arg1 string
+	}
+	unregisterSkillReturns struct {
+		result1 error
+	}
+	unregisterSkillReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateAgentCardStub        func(func(card *types.AgentCard)) error
+	updateAgentCardMutex       sync.RWMutex
+	updateAgentCardArgsForCall []struct {
+		arg1 func(card *types.AgentCard)
+	}
+	updateAgentCardReturns struct {
+		result1 error
+	}
+	updateAgentCardReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -292,6 +382,70 @@ func (fake *FakeA2AServer) GetBackgroundTaskHandlerReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeA2AServer) GetNamedAgent(arg1 string) (server.A2AServer, bool) {
+	fake.getNamedAgentMutex.Lock()
+	ret, specificReturn := fake.getNamedAgentReturnsOnCall[len(fake.getNamedAgentArgsForCall)]
+	fake.getNamedAgentArgsForCall = append(fake.getNamedAgentArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetNamedAgentStub
+	fakeReturns := fake.getNamedAgentReturns
+	fake.recordInvocation("GetNamedAgent", []interface{}{arg1})
+	fake.getNamedAgentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeA2AServer) GetNamedAgentCallCount() int {
+	fake.getNamedAgentMutex.RLock()
+	defer fake.getNamedAgentMutex.RUnlock()
+	return len(fake.getNamedAgentArgsForCall)
+}
+
+func (fake *FakeA2AServer) GetNamedAgentCalls(stub func(string) (server.A2AServer, bool)) {
+	fake.getNamedAgentMutex.Lock()
+	defer fake.getNamedAgentMutex.Unlock()
+	fake.GetNamedAgentStub = stub
+}
+
+func (fake *FakeA2AServer) GetNamedAgentArgsForCall(i int) string {
+	fake.getNamedAgentMutex.RLock()
+	defer fake.getNamedAgentMutex.RUnlock()
+	argsForCall := fake.getNamedAgentArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServer) GetNamedAgentReturns(result1 server.A2AServer, result2 bool) {
+	fake.getNamedAgentMutex.Lock()
+	defer fake.getNamedAgentMutex.Unlock()
+	fake.GetNamedAgentStub = nil
+	fake.getNamedAgentReturns = struct {
+		result1 server.A2AServer
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeA2AServer) GetNamedAgentReturnsOnCall(i int, result1 server.A2AServer, result2 bool) {
+	fake.getNamedAgentMutex.Lock()
+	defer fake.getNamedAgentMutex.Unlock()
+	fake.GetNamedAgentStub = nil
+	if fake.getNamedAgentReturnsOnCall == nil {
+		fake.getNamedAgentReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServer
+			result2 bool
+		})
+	}
+	fake.getNamedAgentReturnsOnCall[i] = struct {
+		result1 server.A2AServer
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeA2AServer) GetStreamingTaskHandler() server.StreamableTaskHandler {
 	fake.getStreamingTaskHandlerMutex.Lock()
 	ret, specificReturn := fake.getStreamingTaskHandlerReturnsOnCall[len(fake.getStreamingTaskHandlerArgsForCall)]
@@ -345,6 +499,59 @@ func (fake *FakeA2AServer) GetStreamingTaskHandlerReturnsOnCall(i int, result1 s
 	}{result1}
 }
 
+func (fake *FakeA2AServer) Handler() http.Handler {
+	fake.handlerMutex.Lock()
+	ret, specificReturn := fake.handlerReturnsOnCall[len(fake.handlerArgsForCall)]
+	fake.handlerArgsForCall = append(fake.handlerArgsForCall, struct {
+	}{})
+	stub := fake.HandlerStub
+	fakeReturns := fake.handlerReturns
+	fake.recordInvocation("Handler", []interface{}{})
+	fake.handlerMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServer) HandlerCallCount() int {
+	fake.handlerMutex.RLock()
+	defer fake.handlerMutex.RUnlock()
+	return len(fake.handlerArgsForCall)
+}
+
+func (fake *FakeA2AServer) HandlerCalls(stub func() http.Handler) {
+	fake.handlerMutex.Lock()
+	defer fake.handlerMutex.Unlock()
+	fake.HandlerStub = stub
+}
+
+func (fake *FakeA2AServer) HandlerReturns(result1 http.Handler) {
+	fake.handlerMutex.Lock()
+	defer fake.handlerMutex.Unlock()
+	fake.HandlerStub = nil
+	fake.handlerReturns = struct {
+		result1 http.Handler
+	}{result1}
+}
+
+func (fake *FakeA2AServer) HandlerReturnsOnCall(i int, result1 http.Handler) {
+	fake.handlerMutex.Lock()
+	defer fake.handlerMutex.Unlock()
+	fake.HandlerStub = nil
+	if fake.handlerReturnsOnCall == nil {
+		fake.handlerReturnsOnCall = make(map[int]struct {
+			result1 http.Handler
+		})
+	}
+	fake.handlerReturnsOnCall[i] = struct {
+		result1 http.Handler
+	}{result1}
+}
+
 func (fake *FakeA2AServer) LoadAgentCardFromFile(arg1 string, arg2 map[string]any) error {
 	fake.loadAgentCardFromFileMutex.Lock()
 	ret, specificReturn := fake.loadAgentCardFromFileReturnsOnCall[len(fake.loadAgentCardFromFileArgsForCall)]
@@ -407,6 +614,129 @@ func (fake *FakeA2AServer) LoadAgentCardFromFileReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
+func (fake *FakeA2AServer) RegisterJSONRPCMethod(arg1 string, arg2 server.JSONRPCMethodHandler) error {
+	fake.registerJSONRPCMethodMutex.Lock()
+	ret, specificReturn := fake.registerJSONRPCMethodReturnsOnCall[len(fake.registerJSONRPCMethodArgsForCall)]
+	fake.registerJSONRPCMethodArgsForCall = append(fake.registerJSONRPCMethodArgsForCall, struct {
+		arg1 string
+		arg2 server.JSONRPCMethodHandler
+	}{arg1, arg2})
+	stub := fake.RegisterJSONRPCMethodStub
+	fakeReturns := fake.registerJSONRPCMethodReturns
+	fake.recordInvocation("RegisterJSONRPCMethod", []interface{}{arg1, arg2})
+	fake.registerJSONRPCMethodMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServer) RegisterJSONRPCMethodCallCount() int {
+	fake.registerJSONRPCMethodMutex.RLock()
+	defer fake.registerJSONRPCMethodMutex.RUnlock()
+	return len(fake.registerJSONRPCMethodArgsForCall)
+}
+
+func (fake *FakeA2AServer) RegisterJSONRPCMethodCalls(stub func(string, server.JSONRPCMethodHandler) error) {
+	fake.registerJSONRPCMethodMutex.Lock()
+	defer fake.registerJSONRPCMethodMutex.Unlock()
+	fake.RegisterJSONRPCMethodStub = stub
+}
+
+func (fake *FakeA2AServer) RegisterJSONRPCMethodArgsForCall(i int) (string, server.JSONRPCMethodHandler) {
+	fake.registerJSONRPCMethodMutex.RLock()
+	defer fake.registerJSONRPCMethodMutex.RUnlock()
+	argsForCall := fake.registerJSONRPCMethodArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AServer) RegisterJSONRPCMethodReturns(result1 error) {
+	fake.registerJSONRPCMethodMutex.Lock()
+	defer fake.registerJSONRPCMethodMutex.Unlock()
+	fake.RegisterJSONRPCMethodStub = nil
+	fake.registerJSONRPCMethodReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeA2AServer) RegisterJSONRPCMethodReturnsOnCall(i int, result1 error) {
+	fake.registerJSONRPCMethodMutex.Lock()
+	defer fake.registerJSONRPCMethodMutex.Unlock()
+	fake.RegisterJSONRPCMethodStub = nil
+	if fake.registerJSONRPCMethodReturnsOnCall == nil {
+		fake.registerJSONRPCMethodReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.registerJSONRPCMethodReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeA2AServer) RegisterSkill(arg1 types.AgentSkill) error {
+	fake.registerSkillMutex.Lock()
+	ret, specificReturn := fake.registerSkillReturnsOnCall[len(fake.registerSkillArgsForCall)]
+	fake.registerSkillArgsForCall = append(fake.registerSkillArgsForCall, struct {
+		arg1 types.AgentSkill
+	}{arg1})
+	stub := fake.RegisterSkillStub
+	fakeReturns := fake.registerSkillReturns
+	fake.recordInvocation("RegisterSkill", []interface{}{arg1})
+	fake.registerSkillMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServer) RegisterSkillCallCount() int {
+	fake.registerSkillMutex.RLock()
+	defer fake.registerSkillMutex.RUnlock()
+	return len(fake.registerSkillArgsForCall)
+}
+
+func (fake *FakeA2AServer) RegisterSkillCalls(stub func(types.AgentSkill) error) {
+	fake.registerSkillMutex.Lock()
+	defer fake.registerSkillMutex.Unlock()
+	fake.RegisterSkillStub = stub
+}
+
+func (fake *FakeA2AServer) RegisterSkillArgsForCall(i int) types.AgentSkill {
+	fake.registerSkillMutex.RLock()
+	defer fake.registerSkillMutex.RUnlock()
+	argsForCall := fake.registerSkillArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServer) RegisterSkillReturns(result1 error) {
+	fake.registerSkillMutex.Lock()
+	defer fake.registerSkillMutex.Unlock()
+	fake.RegisterSkillStub = nil
+	fake.registerSkillReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeA2AServer) RegisterSkillReturnsOnCall(i int, result1 error) {
+	fake.registerSkillMutex.Lock()
+	defer fake.registerSkillMutex.Unlock()
+	fake.RegisterSkillStub = nil
+	if fake.registerSkillReturnsOnCall == nil {
+		fake.registerSkillReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.registerSkillReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeA2AServer) SetAgent(arg1 server.OpenAICompatibleAgent) {
 	fake.setAgentMutex.Lock()
 	fake.setAgentArgsForCall = append(fake.setAgentArgsForCall, struct {
@@ -471,6 +801,38 @@ func (fake *FakeA2AServer) SetAgentCardArgsForCall(i int) types.AgentCard {
 	return argsForCall.arg1
 }
 
+func (fake *FakeA2AServer) SetAgentCardProvider(arg1 server.AgentCardProvider) {
+	fake.setAgentCardProviderMutex.Lock()
+	fake.setAgentCardProviderArgsForCall = append(fake.setAgentCardProviderArgsForCall, struct {
+		arg1 server.AgentCardProvider
+	}{arg1})
+	stub := fake.SetAgentCardProviderStub
+	fake.recordInvocation("SetAgentCardProvider", []interface{}{arg1})
+	fake.setAgentCardProviderMutex.Unlock()
+	if stub != nil {
+		fake.SetAgentCardProviderStub(arg1)
+	}
+}
+
+func (fake *FakeA2AServer) SetAgentCardProviderCallCount() int {
+	fake.setAgentCardProviderMutex.RLock()
+	defer fake.setAgentCardProviderMutex.RUnlock()
+	return len(fake.setAgentCardProviderArgsForCall)
+}
+
+func (fake *FakeA2AServer) SetAgentCardProviderCalls(stub func(server.AgentCardProvider)) {
+	fake.setAgentCardProviderMutex.Lock()
+	defer fake.setAgentCardProviderMutex.Unlock()
+	fake.SetAgentCardProviderStub = stub
+}
+
+func (fake *FakeA2AServer) SetAgentCardProviderArgsForCall(i int) server.AgentCardProvider {
+	fake.setAgentCardProviderMutex.RLock()
+	defer fake.setAgentCardProviderMutex.RUnlock()
+	argsForCall := fake.setAgentCardProviderArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeA2AServer) SetAgentDescription(arg1 string) {
 	fake.setAgentDescriptionMutex.Lock()
 	fake.setAgentDescriptionArgsForCall = append(fake.setAgentDescriptionArgsForCall, struct {
@@ -631,6 +993,38 @@ func (fake *FakeA2AServer) SetBackgroundTaskHandlerArgsForCall(i int) server.Tas
 	return argsForCall.arg1
 }
 
+func (fake *FakeA2AServer) SetExtendedAgentCard(arg1 types.AgentCard) {
+	fake.setExtendedAgentCardMutex.Lock()
+	fake.setExtendedAgentCardArgsForCall = append(fake.setExtendedAgentCardArgsForCall, struct {
+		arg1 types.AgentCard
+	}{arg1})
+	stub := fake.SetExtendedAgentCardStub
+	fake.recordInvocation("SetExtendedAgentCard", []interface{}{arg1})
+	fake.setExtendedAgentCardMutex.Unlock()
+	if stub != nil {
+		fake.SetExtendedAgentCardStub(arg1)
+	}
+}
+
+func (fake *FakeA2AServer) SetExtendedAgentCardCallCount() int {
+	fake.setExtendedAgentCardMutex.RLock()
+	defer fake.setExtendedAgentCardMutex.RUnlock()
+	return len(fake.setExtendedAgentCardArgsForCall)
+}
+
+func (fake *FakeA2AServer) SetExtendedAgentCardCalls(stub func(types.AgentCard)) {
+	fake.setExtendedAgentCardMutex.Lock()
+	defer fake.setExtendedAgentCardMutex.Unlock()
+	fake.SetExtendedAgentCardStub = stub
+}
+
+func (fake *FakeA2AServer) SetExtendedAgentCardArgsForCall(i int) types.AgentCard {
+	fake.setExtendedAgentCardMutex.RLock()
+	defer fake.setExtendedAgentCardMutex.RUnlock()
+	argsForCall := fake.setExtendedAgentCardArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeA2AServer) SetStreamingTaskHandler(arg1 server.StreamableTaskHandler) {
 	fake.setStreamingTaskHandlerMutex.Lock()
 	fake.setStreamingTaskHandlerArgsForCall = append(fake.setStreamingTaskHandlerArgsForCall, struct {
@@ -724,6 +1118,67 @@ func (fake *FakeA2AServer) StartReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeA2AServer) StartBackground(arg1 context.Context) error {
+	fake.startBackgroundMutex.Lock()
+	ret, specificReturn := fake.startBackgroundReturnsOnCall[len(fake.startBackgroundArgsForCall)]
+	fake.startBackgroundArgsForCall = append(fake.startBackgroundArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.StartBackgroundStub
+	fakeReturns := fake.startBackgroundReturns
+	fake.recordInvocation("StartBackground", []interface{}{arg1})
+	fake.startBackgroundMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServer) StartBackgroundCallCount() int {
+	fake.startBackgroundMutex.RLock()
+	defer fake.startBackgroundMutex.RUnlock()
+	return len(fake.startBackgroundArgsForCall)
+}
+
+func (fake *FakeA2AServer) StartBackgroundCalls(stub func(context.Context) error) {
+	fake.startBackgroundMutex.Lock()
+	defer fake.startBackgroundMutex.Unlock()
+	fake.StartBackgroundStub = stub
+}
+
+func (fake *FakeA2AServer) StartBackgroundArgsForCall(i int) context.Context {
+	fake.startBackgroundMutex.RLock()
+	defer fake.startBackgroundMutex.RUnlock()
+	argsForCall := fake.startBackgroundArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServer) StartBackgroundReturns(result1 error) {
+	fake.startBackgroundMutex.Lock()
+	defer fake.startBackgroundMutex.Unlock()
+	fake.StartBackgroundStub = nil
+	fake.startBackgroundReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeA2AServer) StartBackgroundReturnsOnCall(i int, result1 error) {
+	fake.startBackgroundMutex.Lock()
+	defer fake.startBackgroundMutex.Unlock()
+	fake.StartBackgroundStub = nil
+	if fake.startBackgroundReturnsOnCall == nil {
+		fake.startBackgroundReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.startBackgroundReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeA2AServer) StartTaskProcessor(arg1 context.Context) {
 	fake.startTaskProcessorMutex.Lock()
 	fake.startTaskProcessorArgsForCall = append(fake.startTaskProcessorArgsForCall, struct {
@@ -817,6 +1272,128 @@ func (fake *FakeA2AServer) StopReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeA2AServer) UnregisterSkill(arg1 string) error {
+	fake.unregisterSkillMutex.Lock()
+	ret, specificReturn := fake.unregisterSkillReturnsOnCall[len(fake.unregisterSkillArgsForCall)]
+	fake.unregisterSkillArgsForCall = append(fake.unregisterSkillArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.UnregisterSkillStub
+	fakeReturns := fake.unregisterSkillReturns
+	fake.recordInvocation("UnregisterSkill", []interface{}{arg1})
+	fake.unregisterSkillMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServer) UnregisterSkillCallCount() int {
+	fake.unregisterSkillMutex.RLock()
+	defer fake.unregisterSkillMutex.RUnlock()
+	return len(fake.unregisterSkillArgsForCall)
+}
+
+func (fake *FakeA2AServer) UnregisterSkillCalls(stub func(string) error) {
+	fake.unregisterSkillMutex.Lock()
+	defer fake.unregisterSkillMutex.Unlock()
+	fake.UnregisterSkillStub = stub
+}
+
+func (fake *FakeA2AServer) UnregisterSkillArgsForCall(i int) string {
+	fake.unregisterSkillMutex.RLock()
+	defer fake.unregisterSkillMutex.RUnlock()
+	argsForCall := fake.unregisterSkillArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServer) UnregisterSkillReturns(result1 error) {
+	fake.unregisterSkillMutex.Lock()
+	defer fake.unregisterSkillMutex.Unlock()
+	fake.UnregisterSkillStub = nil
+	fake.unregisterSkillReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeA2AServer) UnregisterSkillReturnsOnCall(i int, result1 error) {
+	fake.unregisterSkillMutex.Lock()
+	defer fake.unregisterSkillMutex.Unlock()
+	fake.UnregisterSkillStub = nil
+	if fake.unregisterSkillReturnsOnCall == nil {
+		fake.unregisterSkillReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unregisterSkillReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeA2AServer) UpdateAgentCard(arg1 func(card *types.AgentCard)) error {
+	fake.updateAgentCardMutex.Lock()
+	ret, specificReturn := fake.updateAgentCardReturnsOnCall[len(fake.updateAgentCardArgsForCall)]
+	fake.updateAgentCardArgsForCall = append(fake.updateAgentCardArgsForCall, struct {
+		arg1 func(card *types.AgentCard)
+	}{arg1})
+	stub := fake.UpdateAgentCardStub
+	fakeReturns := fake.updateAgentCardReturns
+	fake.recordInvocation("UpdateAgentCard", []interface{}{arg1})
+	fake.updateAgentCardMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServer) UpdateAgentCardCallCount() int {
+	fake.updateAgentCardMutex.RLock()
+	defer fake.updateAgentCardMutex.RUnlock()
+	return len(fake.updateAgentCardArgsForCall)
+}
+
+func (fake *FakeA2AServer) UpdateAgentCardCalls(stub func(func(card *types.AgentCard)) error) {
+	fake.updateAgentCardMutex.Lock()
+	defer fake.updateAgentCardMutex.Unlock()
+	fake.UpdateAgentCardStub = stub
+}
+
+func (fake *FakeA2AServer) UpdateAgentCardArgsForCall(i int) func(card *types.AgentCard) {
+	fake.updateAgentCardMutex.RLock()
+	defer fake.updateAgentCardMutex.RUnlock()
+	argsForCall := fake.updateAgentCardArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServer) UpdateAgentCardReturns(result1 error) {
+	fake.updateAgentCardMutex.Lock()
+	defer fake.updateAgentCardMutex.Unlock()
+	fake.UpdateAgentCardStub = nil
+	fake.updateAgentCardReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeA2AServer) UpdateAgentCardReturnsOnCall(i int, result1 error) {
+	fake.updateAgentCardMutex.Lock()
+	defer fake.updateAgentCardMutex.Unlock()
+	fake.UpdateAgentCardStub = nil
+	if fake.updateAgentCardReturnsOnCall == nil {
+		fake.updateAgentCardReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateAgentCardReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeA2AServer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -826,14 +1403,24 @@ func (fake *FakeA2AServer) Invocations() map[string][][]interface{} {
 	defer fake.getAgentCardMutex.RUnlock()
 	fake.getBackgroundTaskHandlerMutex.RLock()
 	defer fake.getBackgroundTaskHandlerMutex.RUnlock()
+	fake.getNamedAgentMutex.RLock()
+	defer fake.getNamedAgentMutex.RUnlock()
 	fake.getStreamingTaskHandlerMutex.RLock()
 	defer fake.getStreamingTaskHandlerMutex.RUnlock()
+	fake.handlerMutex.RLock()
+	defer fake.handlerMutex.RUnlock()
 	fake.loadAgentCardFromFileMutex.RLock()
 	defer fake.loadAgentCardFromFileMutex.RUnlock()
+	fake.registerJSONRPCMethodMutex.RLock()
+	defer fake.registerJSONRPCMethodMutex.RUnlock()
+	fake.registerSkillMutex.RLock()
+	defer fake.registerSkillMutex.RUnlock()
 	fake.setAgentMutex.RLock()
 	defer fake.setAgentMutex.RUnlock()
 	fake.setAgentCardMutex.RLock()
 	defer fake.setAgentCardMutex.RUnlock()
+	fake.setAgentCardProviderMutex.RLock()
+	defer fake.setAgentCardProviderMutex.RUnlock()
 	fake.setAgentDescriptionMutex.RLock()
 	defer fake.setAgentDescriptionMutex.RUnlock()
 	fake.setAgentNameMutex.RLock()
@@ -844,14 +1431,22 @@ func (fake *FakeA2AServer) Invocations() map[string][][]interface{} {
 	defer fake.setAgentVersionMutex.RUnlock()
 	fake.setBackgroundTaskHandlerMutex.RLock()
 	defer fake.setBackgroundTaskHandlerMutex.RUnlock()
+	fake.setExtendedAgentCardMutex.RLock()
+	defer fake.setExtendedAgentCardMutex.RUnlock()
 	fake.setStreamingTaskHandlerMutex.RLock()
 	defer fake.setStreamingTaskHandlerMutex.RUnlock()
 	fake.startMutex.RLock()
 	defer fake.startMutex.RUnlock()
+	fake.startBackgroundMutex.RLock()
+	defer fake.startBackgroundMutex.RUnlock()
 	fake.startTaskProcessorMutex.RLock()
 	defer fake.startTaskProcessorMutex.RUnlock()
 	fake.stopMutex.RLock()
 	defer fake.stopMutex.RUnlock()
+	fake.unregisterSkillMutex.RLock()
+	defer fake.unregisterSkillMutex.RUnlock()
+	fake.updateAgentCardMutex.RLock()
+	defer fake.updateAgentCardMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -4,6 +4,7 @@ package mocks
 import (
 	"sync"
 
+	"github.com/gin-gonic/gin"
 	"github.com/inference-gateway/adk/server"
 	"github.com/inference-gateway/adk/server/otel"
 	"github.com/inference-gateway/adk/types"
@@ -57,6 +58,28 @@ type FakeA2AServerBuilder struct {
 	withAgentCardFromFileReturnsOnCall map[int]struct {
 		result1 server.A2AServerBuilder
 	}
+	WithAgentCardProviderStub        func(server.AgentCardProvider) server.A2AServerBuilder
+	withAgentCardProviderMutex       sync.RWMutex
+	withAgentCardProviderArgsForCall []struct {
+		arg1 server.AgentCardProvider
+	}
+	withAgentCardProviderReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withAgentCardProviderReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithAgentsFromDirStub        func(string) server.A2AServerBuilder
+	withAgentsFromDirMutex       sync.RWMutex
+	withAgentsFromDirArgsForCall []struct {
+		arg1 string
+	}
+	withAgentsFromDirReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withAgentsFromDirReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
 	WithArtifactServiceStub        func(server.ArtifactService) server.A2AServerBuilder
 	withArtifactServiceMutex       sync.RWMutex
 	withArtifactServiceArgsForCall []struct {
@@ -68,6 +91,17 @@ type FakeA2AServerBuilder struct {
 	withArtifactServiceReturnsOnCall map[int]struct {
 		result1 server.A2AServerBuilder
 	}
+	WithAuditLoggerStub        func(server.AuditLogger) server.A2AServerBuilder
+	withAuditLoggerMutex       sync.RWMutex
+	withAuditLoggerArgsForCall []struct {
+		arg1 server.AuditLogger
+	}
+	withAuditLoggerReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withAuditLoggerReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
 	WithBackgroundTaskHandlerStub        func(server.TaskHandler) server.A2AServerBuilder
 	withBackgroundTaskHandlerMutex       sync.RWMutex
 	withBackgroundTaskHandlerArgsForCall []struct {
@@ -79,6 +113,28 @@ type FakeA2AServerBuilder struct {
 	withBackgroundTaskHandlerReturnsOnCall map[int]struct {
 		result1 server.A2AServerBuilder
 	}
+	WithClockStub        func(server.Clock) server.A2AServerBuilder
+	withClockMutex       sync.RWMutex
+	withClockArgsForCall []struct {
+		arg1 server.Clock
+	}
+	withClockReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withClockReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithConfigReloadHandlerStub        func(server.ConfigReloadHandler) server.A2AServerBuilder
+	withConfigReloadHandlerMutex       sync.RWMutex
+	withConfigReloadHandlerArgsForCall []struct {
+		arg1 server.ConfigReloadHandler
+	}
+	withConfigReloadHandlerReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withConfigReloadHandlerReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
 	WithDefaultBackgroundTaskHandlerStub        func() server.A2AServerBuilder
 	withDefaultBackgroundTaskHandlerMutex       sync.RWMutex
 	withDefaultBackgroundTaskHandlerArgsForCall []struct {
@@ -109,6 +165,105 @@ type FakeA2AServerBuilder struct {
 	withDefaultTaskHandlersReturnsOnCall map[int]struct {
 		result1 server.A2AServerBuilder
 	}
+	WithEventSinkStub        func(server.EventSink) server.A2AServerBuilder
+	withEventSinkMutex       sync.RWMutex
+	withEventSinkArgsForCall []struct {
+		arg1 server.EventSink
+	}
+	withEventSinkReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withEventSinkReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithExtendedAgentCardStub        func(types.AgentCard) server.A2AServerBuilder
+	withExtendedAgentCardMutex       sync.RWMutex
+	withExtendedAgentCardArgsForCall []struct {
+		arg1 types.AgentCard
+	}
+	withExtendedAgentCardReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withExtendedAgentCardReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithFileConvertersStub        func(...server.FileConverter) server.A2AServerBuilder
+	withFileConvertersMutex       sync.RWMutex
+	withFileConvertersArgsForCall []struct {
+		arg1 []server.FileConverter
+	}
+	withFileConvertersReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withFileConvertersReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithHTTPMiddlewareStub        func(...gin.HandlerFunc) server.A2AServerBuilder
+	withHTTPMiddlewareMutex       sync.RWMutex
+	withHTTPMiddlewareArgsForCall []struct {
+		arg1 []gin.HandlerFunc
+	}
+	withHTTPMiddlewareReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withHTTPMiddlewareReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithIDGeneratorStub        func(server.IDGenerator) server.A2AServerBuilder
+	withIDGeneratorMutex       sync.RWMutex
+	withIDGeneratorArgsForCall []struct {
+		arg1 server.IDGenerator
+	}
+	withIDGeneratorReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withIDGeneratorReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithInferredCapabilitiesStub        func() server.A2AServerBuilder
+	withInferredCapabilitiesMutex       sync.RWMutex
+	withInferredCapabilitiesArgsForCall []struct {
+	}
+	withInferredCapabilitiesReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withInferredCapabilitiesReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithInputGuardrailsStub        func(...server.GuardrailFilter) server.A2AServerBuilder
+	withInputGuardrailsMutex       sync.RWMutex
+	withInputGuardrailsArgsForCall []struct {
+		arg1 []server.GuardrailFilter
+	}
+	withInputGuardrailsReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withInputGuardrailsReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithJSONRPCMethodStub        func(string, server.JSONRPCMethodHandler) server.A2AServerBuilder
+	withJSONRPCMethodMutex       sync.RWMutex
+	withJSONRPCMethodArgsForCall []struct {
+		arg1 string
+		arg2 server.JSONRPCMethodHandler
+	}
+	withJSONRPCMethodReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withJSONRPCMethodReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithLanguageDetectorStub        func(server.LanguageDetector) server.A2AServerBuilder
+	withLanguageDetectorMutex       sync.RWMutex
+	withLanguageDetectorArgsForCall []struct {
+		arg1 server.LanguageDetector
+	}
+	withLanguageDetectorReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withLanguageDetectorReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
 	WithLoggerStub        func(*zap.Logger) server.A2AServerBuilder
 	withLoggerMutex       sync.RWMutex
 	withLoggerArgsForCall []struct {
@@ -120,6 +275,118 @@ type FakeA2AServerBuilder struct {
 	withLoggerReturnsOnCall map[int]struct {
 		result1 server.A2AServerBuilder
 	}
+	WithMemoryEmbedderStub        func(server.Embedder) server.A2AServerBuilder
+	withMemoryEmbedderMutex       sync.RWMutex
+	withMemoryEmbedderArgsForCall []struct {
+		arg1 server.Embedder
+	}
+	withMemoryEmbedderReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withMemoryEmbedderReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithMemoryExtractorStub        func(server.MemoryExtractor) server.A2AServerBuilder
+	withMemoryExtractorMutex       sync.RWMutex
+	withMemoryExtractorArgsForCall []struct {
+		arg1 server.MemoryExtractor
+	}
+	withMemoryExtractorReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withMemoryExtractorReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithMemoryStoreStub        func(server.MemoryStore) server.A2AServerBuilder
+	withMemoryStoreMutex       sync.RWMutex
+	withMemoryStoreArgsForCall []struct {
+		arg1 server.MemoryStore
+	}
+	withMemoryStoreReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withMemoryStoreReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithNamedAgentStub        func(string, server.OpenAICompatibleAgent, types.AgentCard) server.A2AServerBuilder
+	withNamedAgentMutex       sync.RWMutex
+	withNamedAgentArgsForCall []struct {
+		arg1 string
+		arg2 server.OpenAICompatibleAgent
+		arg3 types.AgentCard
+	}
+	withNamedAgentReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withNamedAgentReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithOutputGuardrailsStub        func(...server.GuardrailFilter) server.A2AServerBuilder
+	withOutputGuardrailsMutex       sync.RWMutex
+	withOutputGuardrailsArgsForCall []struct {
+		arg1 []server.GuardrailFilter
+	}
+	withOutputGuardrailsReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withOutputGuardrailsReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithPIIDetectorsStub        func(...server.PIIDetector) server.A2AServerBuilder
+	withPIIDetectorsMutex       sync.RWMutex
+	withPIIDetectorsArgsForCall []struct {
+		arg1 []server.PIIDetector
+	}
+	withPIIDetectorsReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withPIIDetectorsReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithPolicyStub        func(*server.Policy) server.A2AServerBuilder
+	withPolicyMutex       sync.RWMutex
+	withPolicyArgsForCall []struct {
+		arg1 *server.Policy
+	}
+	withPolicyReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withPolicyReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithSchedulesStub        func(...server.Schedule) server.A2AServerBuilder
+	withSchedulesMutex       sync.RWMutex
+	withSchedulesArgsForCall []struct {
+		arg1 []server.Schedule
+	}
+	withSchedulesReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withSchedulesReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithSecretsProviderStub        func(server.SecretsProvider) server.A2AServerBuilder
+	withSecretsProviderMutex       sync.RWMutex
+	withSecretsProviderArgsForCall []struct {
+		arg1 server.SecretsProvider
+	}
+	withSecretsProviderReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withSecretsProviderReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithSpeechSynthesizerStub        func(server.SpeechSynthesizer) server.A2AServerBuilder
+	withSpeechSynthesizerMutex       sync.RWMutex
+	withSpeechSynthesizerArgsForCall []struct {
+		arg1 server.SpeechSynthesizer
+	}
+	withSpeechSynthesizerReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withSpeechSynthesizerReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
 	WithStreamingTaskHandlerStub        func(server.StreamableTaskHandler) server.A2AServerBuilder
 	withStreamingTaskHandlerMutex       sync.RWMutex
 	withStreamingTaskHandlerArgsForCall []struct {
@@ -131,6 +398,17 @@ type FakeA2AServerBuilder struct {
 	withStreamingTaskHandlerReturnsOnCall map[int]struct {
 		result1 server.A2AServerBuilder
 	}
+	WithTaskFeedbackHandlerStub        func(server.TaskFeedbackHandler) server.A2AServerBuilder
+	withTaskFeedbackHandlerMutex       sync.RWMutex
+	withTaskFeedbackHandlerArgsForCall []struct {
+		arg1 server.TaskFeedbackHandler
+	}
+	withTaskFeedbackHandlerReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withTaskFeedbackHandlerReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
 	WithTaskResultProcessorStub        func(server.TaskResultProcessor) server.A2AServerBuilder
 	withTaskResultProcessorMutex       sync.RWMutex
 	withTaskResultProcessorArgsForCall []struct {
@@ -153,6 +431,28 @@ type FakeA2AServerBuilder struct {
 	withTelemetryReturnsOnCall map[int]struct {
 		result1 server.A2AServerBuilder
 	}
+	WithTranscriberStub        func(server.Transcriber) server.A2AServerBuilder
+	withTranscriberMutex       sync.RWMutex
+	withTranscriberArgsForCall []struct {
+		arg1 server.Transcriber
+	}
+	withTranscriberReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withTranscriberReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
+	WithTranslatorStub        func(server.Translator) server.A2AServerBuilder
+	withTranslatorMutex       sync.RWMutex
+	withTranslatorArgsForCall []struct {
+		arg1 server.Translator
+	}
+	withTranslatorReturns struct {
+		result1 server.A2AServerBuilder
+	}
+	withTranslatorReturnsOnCall map[int]struct {
+		result1 server.A2AServerBuilder
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -397,6 +697,128 @@ func (fake *FakeA2AServerBuilder) WithAgentCardFromFileReturnsOnCall(i int, resu
 	}{result1}
 }
 
+func (fake *FakeA2AServerBuilder) WithAgentCardProvider(arg1 server.AgentCardProvider) server.A2AServerBuilder {
+	fake.withAgentCardProviderMutex.Lock()
+	ret, specificReturn := fake.withAgentCardProviderReturnsOnCall[len(fake.withAgentCardProviderArgsForCall)]
+	fake.withAgentCardProviderArgsForCall = append(fake.withAgentCardProviderArgsForCall, struct {
+		arg1 server.AgentCardProvider
+	}{arg1})
+	stub := fake.WithAgentCardProviderStub
+	fakeReturns := fake.withAgentCardProviderReturns
+	fake.recordInvocation("WithAgentCardProvider", []interface{}{arg1})
+	fake.withAgentCardProviderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithAgentCardProviderCallCount() int {
+	fake.withAgentCardProviderMutex.RLock()
+	defer fake.withAgentCardProviderMutex.RUnlock()
+	return len(fake.withAgentCardProviderArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithAgentCardProviderCalls(stub func(server.AgentCardProvider) server.A2AServerBuilder) {
+	fake.withAgentCardProviderMutex.Lock()
+	defer fake.withAgentCardProviderMutex.Unlock()
+	fake.WithAgentCardProviderStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithAgentCardProviderArgsForCall(i int) server.AgentCardProvider {
+	fake.withAgentCardProviderMutex.RLock()
+	defer fake.withAgentCardProviderMutex.RUnlock()
+	argsForCall := fake.withAgentCardProviderArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithAgentCardProviderReturns(result1 server.A2AServerBuilder) {
+	fake.withAgentCardProviderMutex.Lock()
+	defer fake.withAgentCardProviderMutex.Unlock()
+	fake.WithAgentCardProviderStub = nil
+	fake.withAgentCardProviderReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithAgentCardProviderReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withAgentCardProviderMutex.Lock()
+	defer fake.withAgentCardProviderMutex.Unlock()
+	fake.WithAgentCardProviderStub = nil
+	if fake.withAgentCardProviderReturnsOnCall == nil {
+		fake.withAgentCardProviderReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withAgentCardProviderReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithAgentsFromDir(arg1 string) server.A2AServerBuilder {
+	fake.withAgentsFromDirMutex.Lock()
+	ret, specificReturn := fake.withAgentsFromDirReturnsOnCall[len(fake.withAgentsFromDirArgsForCall)]
+	fake.withAgentsFromDirArgsForCall = append(fake.withAgentsFromDirArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithAgentsFromDirStub
+	fakeReturns := fake.withAgentsFromDirReturns
+	fake.recordInvocation("WithAgentsFromDir", []interface{}{arg1})
+	fake.withAgentsFromDirMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithAgentsFromDirCallCount() int {
+	fake.withAgentsFromDirMutex.RLock()
+	defer fake.withAgentsFromDirMutex.RUnlock()
+	return len(fake.withAgentsFromDirArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithAgentsFromDirCalls(stub func(string) server.A2AServerBuilder) {
+	fake.withAgentsFromDirMutex.Lock()
+	defer fake.withAgentsFromDirMutex.Unlock()
+	fake.WithAgentsFromDirStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithAgentsFromDirArgsForCall(i int) string {
+	fake.withAgentsFromDirMutex.RLock()
+	defer fake.withAgentsFromDirMutex.RUnlock()
+	argsForCall := fake.withAgentsFromDirArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithAgentsFromDirReturns(result1 server.A2AServerBuilder) {
+	fake.withAgentsFromDirMutex.Lock()
+	defer fake.withAgentsFromDirMutex.Unlock()
+	fake.WithAgentsFromDirStub = nil
+	fake.withAgentsFromDirReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithAgentsFromDirReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withAgentsFromDirMutex.Lock()
+	defer fake.withAgentsFromDirMutex.Unlock()
+	fake.WithAgentsFromDirStub = nil
+	if fake.withAgentsFromDirReturnsOnCall == nil {
+		fake.withAgentsFromDirReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withAgentsFromDirReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
 func (fake *FakeA2AServerBuilder) WithArtifactService(arg1 server.ArtifactService) server.A2AServerBuilder {
 	fake.withArtifactServiceMutex.Lock()
 	ret, specificReturn := fake.withArtifactServiceReturnsOnCall[len(fake.withArtifactServiceArgsForCall)]
@@ -458,6 +880,67 @@ func (fake *FakeA2AServerBuilder) WithArtifactServiceReturnsOnCall(i int, result
 	}{result1}
 }
 
+func (fake *FakeA2AServerBuilder) WithAuditLogger(arg1 server.AuditLogger) server.A2AServerBuilder {
+	fake.withAuditLoggerMutex.Lock()
+	ret, specificReturn := fake.withAuditLoggerReturnsOnCall[len(fake.withAuditLoggerArgsForCall)]
+	fake.withAuditLoggerArgsForCall = append(fake.withAuditLoggerArgsForCall, struct {
+		arg1 server.AuditLogger
+	}{arg1})
+	stub := fake.WithAuditLoggerStub
+	fakeReturns := fake.withAuditLoggerReturns
+	fake.recordInvocation("WithAuditLogger", []interface{}{arg1})
+	fake.withAuditLoggerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithAuditLoggerCallCount() int {
+	fake.withAuditLoggerMutex.RLock()
+	defer fake.withAuditLoggerMutex.RUnlock()
+	return len(fake.withAuditLoggerArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithAuditLoggerCalls(stub func(server.AuditLogger) server.A2AServerBuilder) {
+	fake.withAuditLoggerMutex.Lock()
+	defer fake.withAuditLoggerMutex.Unlock()
+	fake.WithAuditLoggerStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithAuditLoggerArgsForCall(i int) server.AuditLogger {
+	fake.withAuditLoggerMutex.RLock()
+	defer fake.withAuditLoggerMutex.RUnlock()
+	argsForCall := fake.withAuditLoggerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithAuditLoggerReturns(result1 server.A2AServerBuilder) {
+	fake.withAuditLoggerMutex.Lock()
+	defer fake.withAuditLoggerMutex.Unlock()
+	fake.WithAuditLoggerStub = nil
+	fake.withAuditLoggerReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithAuditLoggerReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withAuditLoggerMutex.Lock()
+	defer fake.withAuditLoggerMutex.Unlock()
+	fake.WithAuditLoggerStub = nil
+	if fake.withAuditLoggerReturnsOnCall == nil {
+		fake.withAuditLoggerReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withAuditLoggerReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
 func (fake *FakeA2AServerBuilder) WithBackgroundTaskHandler(arg1 server.TaskHandler) server.A2AServerBuilder {
 	fake.withBackgroundTaskHandlerMutex.Lock()
 	ret, specificReturn := fake.withBackgroundTaskHandlerReturnsOnCall[len(fake.withBackgroundTaskHandlerArgsForCall)]
@@ -519,17 +1002,18 @@ func (fake *FakeA2AServerBuilder) WithBackgroundTaskHandlerReturnsOnCall(i int,
 	}{result1}
 }
 
-func (fake *FakeA2AServerBuilder) WithDefaultBackgroundTaskHandler() server.A2AServerBuilder {
-	fake.withDefaultBackgroundTaskHandlerMutex.Lock()
-	ret, specificReturn := fake.withDefaultBackgroundTaskHandlerReturnsOnCall[len(fake.withDefaultBackgroundTaskHandlerArgsForCall)]
-	fake.withDefaultBackgroundTaskHandlerArgsForCall = append(fake.withDefaultBackgroundTaskHandlerArgsForCall, struct {
-	}{})
-	stub := fake.WithDefaultBackgroundTaskHandlerStub
-	fakeReturns := fake.withDefaultBackgroundTaskHandlerReturns
-	fake.recordInvocation("WithDefaultBackgroundTaskHandler", []interface{}{})
-	fake.withDefaultBackgroundTaskHandlerMutex.Unlock()
+func (fake *FakeA2AServerBuilder) WithClock(arg1 server.Clock) server.A2AServerBuilder {
+	fake.withClockMutex.Lock()
+	ret, specificReturn := fake.withClockReturnsOnCall[len(fake.withClockArgsForCall)]
+	fake.withClockArgsForCall = append(fake.withClockArgsForCall, struct {
+		arg1 server.Clock
+	}{arg1})
+	stub := fake.WithClockStub
+	fakeReturns := fake.withClockReturns
+	fake.recordInvocation("WithClock", []interface{}{arg1})
+	fake.withClockMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -537,52 +1021,1190 @@ func (fake *FakeA2AServerBuilder) WithDefaultBackgroundTaskHandler() server.A2AS
 	return fakeReturns.result1
 }
 
-func (fake *FakeA2AServerBuilder) WithDefaultBackgroundTaskHandlerCallCount() int {
-	fake.withDefaultBackgroundTaskHandlerMutex.RLock()
-	defer fake.withDefaultBackgroundTaskHandlerMutex.RUnlock()
-	return len(fake.withDefaultBackgroundTaskHandlerArgsForCall)
+func (fake *FakeA2AServerBuilder) WithClockCallCount() int {
+	fake.withClockMutex.RLock()
+	defer fake.withClockMutex.RUnlock()
+	return len(fake.withClockArgsForCall)
 }
 
-func (fake *FakeA2AServerBuilder) WithDefaultBackgroundTaskHandlerCalls(stub func() server.A2AServerBuilder) {
-	fake.withDefaultBackgroundTaskHandlerMutex.Lock()
-	defer fake.withDefaultBackgroundTaskHandlerMutex.Unlock()
-	fake.WithDefaultBackgroundTaskHandlerStub = stub
+func (fake *FakeA2AServerBuilder) WithClockCalls(stub func(server.Clock) server.A2AServerBuilder) {
+	fake.withClockMutex.Lock()
+	defer fake.withClockMutex.Unlock()
+	fake.WithClockStub = stub
 }
 
-func (fake *FakeA2AServerBuilder) WithDefaultBackgroundTaskHandlerReturns(result1 server.A2AServerBuilder) {
-	fake.withDefaultBackgroundTaskHandlerMutex.Lock()
-	defer fake.withDefaultBackgroundTaskHandlerMutex.Unlock()
-	fake.WithDefaultBackgroundTaskHandlerStub = nil
-	fake.withDefaultBackgroundTaskHandlerReturns = struct {
+func (fake *FakeA2AServerBuilder) WithClockArgsForCall(i int) server.Clock {
+	fake.withClockMutex.RLock()
+	defer fake.withClockMutex.RUnlock()
+	argsForCall := fake.withClockArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithClockReturns(result1 server.A2AServerBuilder) {
+	fake.withClockMutex.Lock()
+	defer fake.withClockMutex.Unlock()
+	fake.WithClockStub = nil
+	fake.withClockReturns = struct {
 		result1 server.A2AServerBuilder
 	}{result1}
 }
 
-func (fake *FakeA2AServerBuilder) WithDefaultBackgroundTaskHandlerReturnsOnCall(i int, result1 server.A2AServerBuilder) {
-	fake.withDefaultBackgroundTaskHandlerMutex.Lock()
-	defer fake.withDefaultBackgroundTaskHandlerMutex.Unlock()
-	fake.WithDefaultBackgroundTaskHandlerStub = nil
+func (fake *FakeA2AServerBuilder) WithClockReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withClockMutex.Lock()
+	defer fake.withClockMutex.Unlock()
+	fake.WithClockStub = nil
+	if fake.withClockReturnsOnCall == nil {
+		fake.withClockReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withClockReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithConfigReloadHandler(arg1 server.ConfigReloadHandler) server.A2AServerBuilder {
+	fake.withConfigReloadHandlerMutex.Lock()
+	ret, specificReturn := fake.withConfigReloadHandlerReturnsOnCall[len(fake.withConfigReloadHandlerArgsForCall)]
+	fake.withConfigReloadHandlerArgsForCall = append(fake.withConfigReloadHandlerArgsForCall, struct {
+		arg1 server.ConfigReloadHandler
+	}{arg1})
+	stub := fake.WithConfigReloadHandlerStub
+	fakeReturns := fake.withConfigReloadHandlerReturns
+	fake.recordInvocation("WithConfigReloadHandler", []interface{}{arg1})
+	fake.withConfigReloadHandlerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithConfigReloadHandlerCallCount() int {
+	fake.withConfigReloadHandlerMutex.RLock()
+	defer fake.withConfigReloadHandlerMutex.RUnlock()
+	return len(fake.withConfigReloadHandlerArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithConfigReloadHandlerCalls(stub func(server.ConfigReloadHandler) server.A2AServerBuilder) {
+	fake.withConfigReloadHandlerMutex.Lock()
+	defer fake.withConfigReloadHandlerMutex.Unlock()
+	fake.WithConfigReloadHandlerStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithConfigReloadHandlerArgsForCall(i int) server.ConfigReloadHandler {
+	fake.withConfigReloadHandlerMutex.RLock()
+	defer fake.withConfigReloadHandlerMutex.RUnlock()
+	argsForCall := fake.withConfigReloadHandlerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithConfigReloadHandlerReturns(result1 server.A2AServerBuilder) {
+	fake.withConfigReloadHandlerMutex.Lock()
+	defer fake.withConfigReloadHandlerMutex.Unlock()
+	fake.WithConfigReloadHandlerStub = nil
+	fake.withConfigReloadHandlerReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithConfigReloadHandlerReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withConfigReloadHandlerMutex.Lock()
+	defer fake.withConfigReloadHandlerMutex.Unlock()
+	fake.WithConfigReloadHandlerStub = nil
+	if fake.withConfigReloadHandlerReturnsOnCall == nil {
+		fake.withConfigReloadHandlerReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withConfigReloadHandlerReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithDefaultBackgroundTaskHandler() server.A2AServerBuilder {
+	fake.withDefaultBackgroundTaskHandlerMutex.Lock()
+	ret, specificReturn := fake.withDefaultBackgroundTaskHandlerReturnsOnCall[len(fake.withDefaultBackgroundTaskHandlerArgsForCall)]
+	fake.withDefaultBackgroundTaskHandlerArgsForCall = append(fake.withDefaultBackgroundTaskHandlerArgsForCall, struct {
+	}{})
+	stub := fake.WithDefaultBackgroundTaskHandlerStub
+	fakeReturns := fake.withDefaultBackgroundTaskHandlerReturns
+	fake.recordInvocation("WithDefaultBackgroundTaskHandler", []interface{}{})
+	fake.withDefaultBackgroundTaskHandlerMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithDefaultBackgroundTaskHandlerCallCount() int {
+	fake.withDefaultBackgroundTaskHandlerMutex.RLock()
+	defer fake.withDefaultBackgroundTaskHandlerMutex.RUnlock()
+	return len(fake.withDefaultBackgroundTaskHandlerArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithDefaultBackgroundTaskHandlerCalls(stub func() server.A2AServerBuilder) {
+	fake.withDefaultBackgroundTaskHandlerMutex.Lock()
+	defer fake.withDefaultBackgroundTaskHandlerMutex.Unlock()
+	fake.WithDefaultBackgroundTaskHandlerStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithDefaultBackgroundTaskHandlerReturns(result1 server.A2AServerBuilder) {
+	fake.withDefaultBackgroundTaskHandlerMutex.Lock()
+	defer fake.withDefaultBackgroundTaskHandlerMutex.Unlock()
+	fake.WithDefaultBackgroundTaskHandlerStub = nil
+	fake.withDefaultBackgroundTaskHandlerReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithDefaultBackgroundTaskHandlerReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withDefaultBackgroundTaskHandlerMutex.Lock()
+	defer fake.withDefaultBackgroundTaskHandlerMutex.Unlock()
+	fake.WithDefaultBackgroundTaskHandlerStub = nil
 	if fake.withDefaultBackgroundTaskHandlerReturnsOnCall == nil {
 		fake.withDefaultBackgroundTaskHandlerReturnsOnCall = make(map[int]struct {
 			result1 server.A2AServerBuilder
 		})
 	}
-	fake.withDefaultBackgroundTaskHandlerReturnsOnCall[i] = struct {
+	fake.withDefaultBackgroundTaskHandlerReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithDefaultStreamingTaskHandler() server.A2AServerBuilder {
+	fake.withDefaultStreamingTaskHandlerMutex.Lock()
+	ret, specificReturn := fake.withDefaultStreamingTaskHandlerReturnsOnCall[len(fake.withDefaultStreamingTaskHandlerArgsForCall)]
+	fake.withDefaultStreamingTaskHandlerArgsForCall = append(fake.withDefaultStreamingTaskHandlerArgsForCall, struct {
+	}{})
+	stub := fake.WithDefaultStreamingTaskHandlerStub
+	fakeReturns := fake.withDefaultStreamingTaskHandlerReturns
+	fake.recordInvocation("WithDefaultStreamingTaskHandler", []interface{}{})
+	fake.withDefaultStreamingTaskHandlerMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithDefaultStreamingTaskHandlerCallCount() int {
+	fake.withDefaultStreamingTaskHandlerMutex.RLock()
+	defer fake.withDefaultStreamingTaskHandlerMutex.RUnlock()
+	return len(fake.withDefaultStreamingTaskHandlerArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithDefaultStreamingTaskHandlerCalls(stub func() server.A2AServerBuilder) {
+	fake.withDefaultStreamingTaskHandlerMutex.Lock()
+	defer fake.withDefaultStreamingTaskHandlerMutex.Unlock()
+	fake.WithDefaultStreamingTaskHandlerStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithDefaultStreamingTaskHandlerReturns(result1 server.A2AServerBuilder) {
+	fake.withDefaultStreamingTaskHandlerMutex.Lock()
+	defer fake.withDefaultStreamingTaskHandlerMutex.Unlock()
+	fake.WithDefaultStreamingTaskHandlerStub = nil
+	fake.withDefaultStreamingTaskHandlerReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithDefaultStreamingTaskHandlerReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withDefaultStreamingTaskHandlerMutex.Lock()
+	defer fake.withDefaultStreamingTaskHandlerMutex.Unlock()
+	fake.WithDefaultStreamingTaskHandlerStub = nil
+	if fake.withDefaultStreamingTaskHandlerReturnsOnCall == nil {
+		fake.withDefaultStreamingTaskHandlerReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withDefaultStreamingTaskHandlerReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithDefaultTaskHandlers() server.A2AServerBuilder {
+	fake.withDefaultTaskHandlersMutex.Lock()
+	ret, specificReturn := fake.withDefaultTaskHandlersReturnsOnCall[len(fake.withDefaultTaskHandlersArgsForCall)]
+	fake.withDefaultTaskHandlersArgsForCall = append(fake.withDefaultTaskHandlersArgsForCall, struct {
+	}{})
+	stub := fake.WithDefaultTaskHandlersStub
+	fakeReturns := fake.withDefaultTaskHandlersReturns
+	fake.recordInvocation("WithDefaultTaskHandlers", []interface{}{})
+	fake.withDefaultTaskHandlersMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithDefaultTaskHandlersCallCount() int {
+	fake.withDefaultTaskHandlersMutex.RLock()
+	defer fake.withDefaultTaskHandlersMutex.RUnlock()
+	return len(fake.withDefaultTaskHandlersArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithDefaultTaskHandlersCalls(stub func() server.A2AServerBuilder) {
+	fake.withDefaultTaskHandlersMutex.Lock()
+	defer fake.withDefaultTaskHandlersMutex.Unlock()
+	fake.WithDefaultTaskHandlersStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithDefaultTaskHandlersReturns(result1 server.A2AServerBuilder) {
+	fake.withDefaultTaskHandlersMutex.Lock()
+	defer fake.withDefaultTaskHandlersMutex.Unlock()
+	fake.WithDefaultTaskHandlersStub = nil
+	fake.withDefaultTaskHandlersReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithDefaultTaskHandlersReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withDefaultTaskHandlersMutex.Lock()
+	defer fake.withDefaultTaskHandlersMutex.Unlock()
+	fake.WithDefaultTaskHandlersStub = nil
+	if fake.withDefaultTaskHandlersReturnsOnCall == nil {
+		fake.withDefaultTaskHandlersReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withDefaultTaskHandlersReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithEventSink(arg1 server.EventSink) server.A2AServerBuilder {
+	fake.withEventSinkMutex.Lock()
+	ret, specificReturn := fake.withEventSinkReturnsOnCall[len(fake.withEventSinkArgsForCall)]
+	fake.withEventSinkArgsForCall = append(fake.withEventSinkArgsForCall, struct {
+		arg1 server.EventSink
+	}{arg1})
+	stub := fake.WithEventSinkStub
+	fakeReturns := fake.withEventSinkReturns
+	fake.recordInvocation("WithEventSink", []interface{}{arg1})
+	fake.withEventSinkMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithEventSinkCallCount() int {
+	fake.withEventSinkMutex.RLock()
+	defer fake.withEventSinkMutex.RUnlock()
+	return len(fake.withEventSinkArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithEventSinkCalls(stub func(server.EventSink) server.A2AServerBuilder) {
+	fake.withEventSinkMutex.Lock()
+	defer fake.withEventSinkMutex.Unlock()
+	fake.WithEventSinkStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithEventSinkArgsForCall(i int) server.EventSink {
+	fake.withEventSinkMutex.RLock()
+	defer fake.withEventSinkMutex.RUnlock()
+	argsForCall := fake.withEventSinkArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithEventSinkReturns(result1 server.A2AServerBuilder) {
+	fake.withEventSinkMutex.Lock()
+	defer fake.withEventSinkMutex.Unlock()
+	fake.WithEventSinkStub = nil
+	fake.withEventSinkReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithEventSinkReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withEventSinkMutex.Lock()
+	defer fake.withEventSinkMutex.Unlock()
+	fake.WithEventSinkStub = nil
+	if fake.withEventSinkReturnsOnCall == nil {
+		fake.withEventSinkReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withEventSinkReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithExtendedAgentCard(arg1 types.AgentCard) server.A2AServerBuilder {
+	fake.withExtendedAgentCardMutex.Lock()
+	ret, specificReturn := fake.withExtendedAgentCardReturnsOnCall[len(fake.withExtendedAgentCardArgsForCall)]
+	fake.withExtendedAgentCardArgsForCall = append(fake.withExtendedAgentCardArgsForCall, struct {
+		arg1 types.AgentCard
+	}{arg1})
+	stub := fake.WithExtendedAgentCardStub
+	fakeReturns := fake.withExtendedAgentCardReturns
+	fake.recordInvocation("WithExtendedAgentCard", []interface{}{arg1})
+	fake.withExtendedAgentCardMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithExtendedAgentCardCallCount() int {
+	fake.withExtendedAgentCardMutex.RLock()
+	defer fake.withExtendedAgentCardMutex.RUnlock()
+	return len(fake.withExtendedAgentCardArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithExtendedAgentCardCalls(stub func(types.AgentCard) server.A2AServerBuilder) {
+	fake.withExtendedAgentCardMutex.Lock()
+	defer fake.withExtendedAgentCardMutex.Unlock()
+	fake.WithExtendedAgentCardStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithExtendedAgentCardArgsForCall(i int) types.AgentCard {
+	fake.withExtendedAgentCardMutex.RLock()
+	defer fake.withExtendedAgentCardMutex.RUnlock()
+	argsForCall := fake.withExtendedAgentCardArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithExtendedAgentCardReturns(result1 server.A2AServerBuilder) {
+	fake.withExtendedAgentCardMutex.Lock()
+	defer fake.withExtendedAgentCardMutex.Unlock()
+	fake.WithExtendedAgentCardStub = nil
+	fake.withExtendedAgentCardReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithExtendedAgentCardReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withExtendedAgentCardMutex.Lock()
+	defer fake.withExtendedAgentCardMutex.Unlock()
+	fake.WithExtendedAgentCardStub = nil
+	if fake.withExtendedAgentCardReturnsOnCall == nil {
+		fake.withExtendedAgentCardReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withExtendedAgentCardReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithFileConverters(arg1 ...server.FileConverter) server.A2AServerBuilder {
+	fake.withFileConvertersMutex.Lock()
+	ret, specificReturn := fake.withFileConvertersReturnsOnCall[len(fake.withFileConvertersArgsForCall)]
+	fake.withFileConvertersArgsForCall = append(fake.withFileConvertersArgsForCall, struct {
+		arg1 []server.FileConverter
+	}{arg1})
+	stub := fake.WithFileConvertersStub
+	fakeReturns := fake.withFileConvertersReturns
+	fake.recordInvocation("WithFileConverters", []interface{}{arg1})
+	fake.withFileConvertersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithFileConvertersCallCount() int {
+	fake.withFileConvertersMutex.RLock()
+	defer fake.withFileConvertersMutex.RUnlock()
+	return len(fake.withFileConvertersArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithFileConvertersCalls(stub func(...server.FileConverter) server.A2AServerBuilder) {
+	fake.withFileConvertersMutex.Lock()
+	defer fake.withFileConvertersMutex.Unlock()
+	fake.WithFileConvertersStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithFileConvertersArgsForCall(i int) []server.FileConverter {
+	fake.withFileConvertersMutex.RLock()
+	defer fake.withFileConvertersMutex.RUnlock()
+	argsForCall := fake.withFileConvertersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithFileConvertersReturns(result1 server.A2AServerBuilder) {
+	fake.withFileConvertersMutex.Lock()
+	defer fake.withFileConvertersMutex.Unlock()
+	fake.WithFileConvertersStub = nil
+	fake.withFileConvertersReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithFileConvertersReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withFileConvertersMutex.Lock()
+	defer fake.withFileConvertersMutex.Unlock()
+	fake.WithFileConvertersStub = nil
+	if fake.withFileConvertersReturnsOnCall == nil {
+		fake.withFileConvertersReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withFileConvertersReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithHTTPMiddleware(arg1 ...gin.HandlerFunc) server.A2AServerBuilder {
+	fake.withHTTPMiddlewareMutex.Lock()
+	ret, specificReturn := fake.withHTTPMiddlewareReturnsOnCall[len(fake.withHTTPMiddlewareArgsForCall)]
+	fake.withHTTPMiddlewareArgsForCall = append(fake.withHTTPMiddlewareArgsForCall, struct {
+		arg1 []gin.HandlerFunc
+	}{arg1})
+	stub := fake.WithHTTPMiddlewareStub
+	fakeReturns := fake.withHTTPMiddlewareReturns
+	fake.recordInvocation("WithHTTPMiddleware", []interface{}{arg1})
+	fake.withHTTPMiddlewareMutex.Unlock()
+	if stub != nil {
+		return stub(arg1...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithHTTPMiddlewareCallCount() int {
+	fake.withHTTPMiddlewareMutex.RLock()
+	defer fake.withHTTPMiddlewareMutex.RUnlock()
+	return len(fake.withHTTPMiddlewareArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithHTTPMiddlewareCalls(stub func(...gin.HandlerFunc) server.A2AServerBuilder) {
+	fake.withHTTPMiddlewareMutex.Lock()
+	defer fake.withHTTPMiddlewareMutex.Unlock()
+	fake.WithHTTPMiddlewareStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithHTTPMiddlewareArgsForCall(i int) []gin.HandlerFunc {
+	fake.withHTTPMiddlewareMutex.RLock()
+	defer fake.withHTTPMiddlewareMutex.RUnlock()
+	argsForCall := fake.withHTTPMiddlewareArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithHTTPMiddlewareReturns(result1 server.A2AServerBuilder) {
+	fake.withHTTPMiddlewareMutex.Lock()
+	defer fake.withHTTPMiddlewareMutex.Unlock()
+	fake.WithHTTPMiddlewareStub = nil
+	fake.withHTTPMiddlewareReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithHTTPMiddlewareReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withHTTPMiddlewareMutex.Lock()
+	defer fake.withHTTPMiddlewareMutex.Unlock()
+	fake.WithHTTPMiddlewareStub = nil
+	if fake.withHTTPMiddlewareReturnsOnCall == nil {
+		fake.withHTTPMiddlewareReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withHTTPMiddlewareReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithIDGenerator(arg1 server.IDGenerator) server.A2AServerBuilder {
+	fake.withIDGeneratorMutex.Lock()
+	ret, specificReturn := fake.withIDGeneratorReturnsOnCall[len(fake.withIDGeneratorArgsForCall)]
+	fake.withIDGeneratorArgsForCall = append(fake.withIDGeneratorArgsForCall, struct {
+		arg1 server.IDGenerator
+	}{arg1})
+	stub := fake.WithIDGeneratorStub
+	fakeReturns := fake.withIDGeneratorReturns
+	fake.recordInvocation("WithIDGenerator", []interface{}{arg1})
+	fake.withIDGeneratorMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithIDGeneratorCallCount() int {
+	fake.withIDGeneratorMutex.RLock()
+	defer fake.withIDGeneratorMutex.RUnlock()
+	return len(fake.withIDGeneratorArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithIDGeneratorCalls(stub func(server.IDGenerator) server.A2AServerBuilder) {
+	fake.withIDGeneratorMutex.Lock()
+	defer fake.withIDGeneratorMutex.Unlock()
+	fake.WithIDGeneratorStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithIDGeneratorArgsForCall(i int) server.IDGenerator {
+	fake.withIDGeneratorMutex.RLock()
+	defer fake.withIDGeneratorMutex.RUnlock()
+	argsForCall := fake.withIDGeneratorArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithIDGeneratorReturns(result1 server.A2AServerBuilder) {
+	fake.withIDGeneratorMutex.Lock()
+	defer fake.withIDGeneratorMutex.Unlock()
+	fake.WithIDGeneratorStub = nil
+	fake.withIDGeneratorReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithIDGeneratorReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withIDGeneratorMutex.Lock()
+	defer fake.withIDGeneratorMutex.Unlock()
+	fake.WithIDGeneratorStub = nil
+	if fake.withIDGeneratorReturnsOnCall == nil {
+		fake.withIDGeneratorReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withIDGeneratorReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithInferredCapabilities() server.A2AServerBuilder {
+	fake.withInferredCapabilitiesMutex.Lock()
+	ret, specificReturn := fake.withInferredCapabilitiesReturnsOnCall[len(fake.withInferredCapabilitiesArgsForCall)]
+	fake.withInferredCapabilitiesArgsForCall = append(fake.withInferredCapabilitiesArgsForCall, struct {
+	}{})
+	stub := fake.WithInferredCapabilitiesStub
+	fakeReturns := fake.withInferredCapabilitiesReturns
+	fake.recordInvocation("WithInferredCapabilities", []interface{}{})
+	fake.withInferredCapabilitiesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithInferredCapabilitiesCallCount() int {
+	fake.withInferredCapabilitiesMutex.RLock()
+	defer fake.withInferredCapabilitiesMutex.RUnlock()
+	return len(fake.withInferredCapabilitiesArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithInferredCapabilitiesCalls(stub func() server.A2AServerBuilder) {
+	fake.withInferredCapabilitiesMutex.Lock()
+	defer fake.withInferredCapabilitiesMutex.Unlock()
+	fake.WithInferredCapabilitiesStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithInferredCapabilitiesReturns(result1 server.A2AServerBuilder) {
+	fake.withInferredCapabilitiesMutex.Lock()
+	defer fake.withInferredCapabilitiesMutex.Unlock()
+	fake.WithInferredCapabilitiesStub = nil
+	fake.withInferredCapabilitiesReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithInferredCapabilitiesReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withInferredCapabilitiesMutex.Lock()
+	defer fake.withInferredCapabilitiesMutex.Unlock()
+	fake.WithInferredCapabilitiesStub = nil
+	if fake.withInferredCapabilitiesReturnsOnCall == nil {
+		fake.withInferredCapabilitiesReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withInferredCapabilitiesReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithInputGuardrails(arg1 ...server.GuardrailFilter) server.A2AServerBuilder {
+	fake.withInputGuardrailsMutex.Lock()
+	ret, specificReturn := fake.withInputGuardrailsReturnsOnCall[len(fake.withInputGuardrailsArgsForCall)]
+	fake.withInputGuardrailsArgsForCall = append(fake.withInputGuardrailsArgsForCall, struct {
+		arg1 []server.GuardrailFilter
+	}{arg1})
+	stub := fake.WithInputGuardrailsStub
+	fakeReturns := fake.withInputGuardrailsReturns
+	fake.recordInvocation("WithInputGuardrails", []interface{}{arg1})
+	fake.withInputGuardrailsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithInputGuardrailsCallCount() int {
+	fake.withInputGuardrailsMutex.RLock()
+	defer fake.withInputGuardrailsMutex.RUnlock()
+	return len(fake.withInputGuardrailsArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithInputGuardrailsCalls(stub func(...server.GuardrailFilter) server.A2AServerBuilder) {
+	fake.withInputGuardrailsMutex.Lock()
+	defer fake.withInputGuardrailsMutex.Unlock()
+	fake.WithInputGuardrailsStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithInputGuardrailsArgsForCall(i int) []server.GuardrailFilter {
+	fake.withInputGuardrailsMutex.RLock()
+	defer fake.withInputGuardrailsMutex.RUnlock()
+	argsForCall := fake.withInputGuardrailsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithInputGuardrailsReturns(result1 server.A2AServerBuilder) {
+	fake.withInputGuardrailsMutex.Lock()
+	defer fake.withInputGuardrailsMutex.Unlock()
+	fake.WithInputGuardrailsStub = nil
+	fake.withInputGuardrailsReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithInputGuardrailsReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withInputGuardrailsMutex.Lock()
+	defer fake.withInputGuardrailsMutex.Unlock()
+	fake.WithInputGuardrailsStub = nil
+	if fake.withInputGuardrailsReturnsOnCall == nil {
+		fake.withInputGuardrailsReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withInputGuardrailsReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithJSONRPCMethod(arg1 string, arg2 server.JSONRPCMethodHandler) server.A2AServerBuilder {
+	fake.withJSONRPCMethodMutex.Lock()
+	ret, specificReturn := fake.withJSONRPCMethodReturnsOnCall[len(fake.withJSONRPCMethodArgsForCall)]
+	fake.withJSONRPCMethodArgsForCall = append(fake.withJSONRPCMethodArgsForCall, struct {
+		arg1 string
+		arg2 server.JSONRPCMethodHandler
+	}{arg1, arg2})
+	stub := fake.WithJSONRPCMethodStub
+	fakeReturns := fake.withJSONRPCMethodReturns
+	fake.recordInvocation("WithJSONRPCMethod", []interface{}{arg1, arg2})
+	fake.withJSONRPCMethodMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithJSONRPCMethodCallCount() int {
+	fake.withJSONRPCMethodMutex.RLock()
+	defer fake.withJSONRPCMethodMutex.RUnlock()
+	return len(fake.withJSONRPCMethodArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithJSONRPCMethodCalls(stub func(string, server.JSONRPCMethodHandler) server.A2AServerBuilder) {
+	fake.withJSONRPCMethodMutex.Lock()
+	defer fake.withJSONRPCMethodMutex.Unlock()
+	fake.WithJSONRPCMethodStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithJSONRPCMethodArgsForCall(i int) (string, server.JSONRPCMethodHandler) {
+	fake.withJSONRPCMethodMutex.RLock()
+	defer fake.withJSONRPCMethodMutex.RUnlock()
+	argsForCall := fake.withJSONRPCMethodArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeA2AServerBuilder) WithJSONRPCMethodReturns(result1 server.A2AServerBuilder) {
+	fake.withJSONRPCMethodMutex.Lock()
+	defer fake.withJSONRPCMethodMutex.Unlock()
+	fake.WithJSONRPCMethodStub = nil
+	fake.withJSONRPCMethodReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithJSONRPCMethodReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withJSONRPCMethodMutex.Lock()
+	defer fake.withJSONRPCMethodMutex.Unlock()
+	fake.WithJSONRPCMethodStub = nil
+	if fake.withJSONRPCMethodReturnsOnCall == nil {
+		fake.withJSONRPCMethodReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withJSONRPCMethodReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithLanguageDetector(arg1 server.LanguageDetector) server.A2AServerBuilder {
+	fake.withLanguageDetectorMutex.Lock()
+	ret, specificReturn := fake.withLanguageDetectorReturnsOnCall[len(fake.withLanguageDetectorArgsForCall)]
+	fake.withLanguageDetectorArgsForCall = append(fake.withLanguageDetectorArgsForCall, struct {
+		arg1 server.LanguageDetector
+	}{arg1})
+	stub := fake.WithLanguageDetectorStub
+	fakeReturns := fake.withLanguageDetectorReturns
+	fake.recordInvocation("WithLanguageDetector", []interface{}{arg1})
+	fake.withLanguageDetectorMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithLanguageDetectorCallCount() int {
+	fake.withLanguageDetectorMutex.RLock()
+	defer fake.withLanguageDetectorMutex.RUnlock()
+	return len(fake.withLanguageDetectorArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithLanguageDetectorCalls(stub func(server.LanguageDetector) server.A2AServerBuilder) {
+	fake.withLanguageDetectorMutex.Lock()
+	defer fake.withLanguageDetectorMutex.Unlock()
+	fake.WithLanguageDetectorStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithLanguageDetectorArgsForCall(i int) server.LanguageDetector {
+	fake.withLanguageDetectorMutex.RLock()
+	defer fake.withLanguageDetectorMutex.RUnlock()
+	argsForCall := fake.withLanguageDetectorArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithLanguageDetectorReturns(result1 server.A2AServerBuilder) {
+	fake.withLanguageDetectorMutex.Lock()
+	defer fake.withLanguageDetectorMutex.Unlock()
+	fake.WithLanguageDetectorStub = nil
+	fake.withLanguageDetectorReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithLanguageDetectorReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withLanguageDetectorMutex.Lock()
+	defer fake.withLanguageDetectorMutex.Unlock()
+	fake.WithLanguageDetectorStub = nil
+	if fake.withLanguageDetectorReturnsOnCall == nil {
+		fake.withLanguageDetectorReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withLanguageDetectorReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithLogger(arg1 *zap.Logger) server.A2AServerBuilder {
+	fake.withLoggerMutex.Lock()
+	ret, specificReturn := fake.withLoggerReturnsOnCall[len(fake.withLoggerArgsForCall)]
+	fake.withLoggerArgsForCall = append(fake.withLoggerArgsForCall, struct {
+		arg1 *zap.Logger
+	}{arg1})
+	stub := fake.WithLoggerStub
+	fakeReturns := fake.withLoggerReturns
+	fake.recordInvocation("WithLogger", []interface{}{arg1})
+	fake.withLoggerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithLoggerCallCount() int {
+	fake.withLoggerMutex.RLock()
+	defer fake.withLoggerMutex.RUnlock()
+	return len(fake.withLoggerArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithLoggerCalls(stub func(*zap.Logger) server.A2AServerBuilder) {
+	fake.withLoggerMutex.Lock()
+	defer fake.withLoggerMutex.Unlock()
+	fake.WithLoggerStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithLoggerArgsForCall(i int) *zap.Logger {
+	fake.withLoggerMutex.RLock()
+	defer fake.withLoggerMutex.RUnlock()
+	argsForCall := fake.withLoggerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithLoggerReturns(result1 server.A2AServerBuilder) {
+	fake.withLoggerMutex.Lock()
+	defer fake.withLoggerMutex.Unlock()
+	fake.WithLoggerStub = nil
+	fake.withLoggerReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithLoggerReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withLoggerMutex.Lock()
+	defer fake.withLoggerMutex.Unlock()
+	fake.WithLoggerStub = nil
+	if fake.withLoggerReturnsOnCall == nil {
+		fake.withLoggerReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withLoggerReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryEmbedder(arg1 server.Embedder) server.A2AServerBuilder {
+	fake.withMemoryEmbedderMutex.Lock()
+	ret, specificReturn := fake.withMemoryEmbedderReturnsOnCall[len(fake.withMemoryEmbedderArgsForCall)]
+	fake.withMemoryEmbedderArgsForCall = append(fake.withMemoryEmbedderArgsForCall, struct {
+		arg1 server.Embedder
+	}{arg1})
+	stub := fake.WithMemoryEmbedderStub
+	fakeReturns := fake.withMemoryEmbedderReturns
+	fake.recordInvocation("WithMemoryEmbedder", []interface{}{arg1})
+	fake.withMemoryEmbedderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryEmbedderCallCount() int {
+	fake.withMemoryEmbedderMutex.RLock()
+	defer fake.withMemoryEmbedderMutex.RUnlock()
+	return len(fake.withMemoryEmbedderArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryEmbedderCalls(stub func(server.Embedder) server.A2AServerBuilder) {
+	fake.withMemoryEmbedderMutex.Lock()
+	defer fake.withMemoryEmbedderMutex.Unlock()
+	fake.WithMemoryEmbedderStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryEmbedderArgsForCall(i int) server.Embedder {
+	fake.withMemoryEmbedderMutex.RLock()
+	defer fake.withMemoryEmbedderMutex.RUnlock()
+	argsForCall := fake.withMemoryEmbedderArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryEmbedderReturns(result1 server.A2AServerBuilder) {
+	fake.withMemoryEmbedderMutex.Lock()
+	defer fake.withMemoryEmbedderMutex.Unlock()
+	fake.WithMemoryEmbedderStub = nil
+	fake.withMemoryEmbedderReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryEmbedderReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withMemoryEmbedderMutex.Lock()
+	defer fake.withMemoryEmbedderMutex.Unlock()
+	fake.WithMemoryEmbedderStub = nil
+	if fake.withMemoryEmbedderReturnsOnCall == nil {
+		fake.withMemoryEmbedderReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withMemoryEmbedderReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryExtractor(arg1 server.MemoryExtractor) server.A2AServerBuilder {
+	fake.withMemoryExtractorMutex.Lock()
+	ret, specificReturn := fake.withMemoryExtractorReturnsOnCall[len(fake.withMemoryExtractorArgsForCall)]
+	fake.withMemoryExtractorArgsForCall = append(fake.withMemoryExtractorArgsForCall, struct {
+		arg1 server.MemoryExtractor
+	}{arg1})
+	stub := fake.WithMemoryExtractorStub
+	fakeReturns := fake.withMemoryExtractorReturns
+	fake.recordInvocation("WithMemoryExtractor", []interface{}{arg1})
+	fake.withMemoryExtractorMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryExtractorCallCount() int {
+	fake.withMemoryExtractorMutex.RLock()
+	defer fake.withMemoryExtractorMutex.RUnlock()
+	return len(fake.withMemoryExtractorArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryExtractorCalls(stub func(server.MemoryExtractor) server.A2AServerBuilder) {
+	fake.withMemoryExtractorMutex.Lock()
+	defer fake.withMemoryExtractorMutex.Unlock()
+	fake.WithMemoryExtractorStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryExtractorArgsForCall(i int) server.MemoryExtractor {
+	fake.withMemoryExtractorMutex.RLock()
+	defer fake.withMemoryExtractorMutex.RUnlock()
+	argsForCall := fake.withMemoryExtractorArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryExtractorReturns(result1 server.A2AServerBuilder) {
+	fake.withMemoryExtractorMutex.Lock()
+	defer fake.withMemoryExtractorMutex.Unlock()
+	fake.WithMemoryExtractorStub = nil
+	fake.withMemoryExtractorReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryExtractorReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withMemoryExtractorMutex.Lock()
+	defer fake.withMemoryExtractorMutex.Unlock()
+	fake.WithMemoryExtractorStub = nil
+	if fake.withMemoryExtractorReturnsOnCall == nil {
+		fake.withMemoryExtractorReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withMemoryExtractorReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryStore(arg1 server.MemoryStore) server.A2AServerBuilder {
+	fake.withMemoryStoreMutex.Lock()
+	ret, specificReturn := fake.withMemoryStoreReturnsOnCall[len(fake.withMemoryStoreArgsForCall)]
+	fake.withMemoryStoreArgsForCall = append(fake.withMemoryStoreArgsForCall, struct {
+		arg1 server.MemoryStore
+	}{arg1})
+	stub := fake.WithMemoryStoreStub
+	fakeReturns := fake.withMemoryStoreReturns
+	fake.recordInvocation("WithMemoryStore", []interface{}{arg1})
+	fake.withMemoryStoreMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryStoreCallCount() int {
+	fake.withMemoryStoreMutex.RLock()
+	defer fake.withMemoryStoreMutex.RUnlock()
+	return len(fake.withMemoryStoreArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryStoreCalls(stub func(server.MemoryStore) server.A2AServerBuilder) {
+	fake.withMemoryStoreMutex.Lock()
+	defer fake.withMemoryStoreMutex.Unlock()
+	fake.WithMemoryStoreStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryStoreArgsForCall(i int) server.MemoryStore {
+	fake.withMemoryStoreMutex.RLock()
+	defer fake.withMemoryStoreMutex.RUnlock()
+	argsForCall := fake.withMemoryStoreArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryStoreReturns(result1 server.A2AServerBuilder) {
+	fake.withMemoryStoreMutex.Lock()
+	defer fake.withMemoryStoreMutex.Unlock()
+	fake.WithMemoryStoreStub = nil
+	fake.withMemoryStoreReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithMemoryStoreReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withMemoryStoreMutex.Lock()
+	defer fake.withMemoryStoreMutex.Unlock()
+	fake.WithMemoryStoreStub = nil
+	if fake.withMemoryStoreReturnsOnCall == nil {
+		fake.withMemoryStoreReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withMemoryStoreReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithNamedAgent(arg1 string, arg2 server.OpenAICompatibleAgent, arg3 types.AgentCard) server.A2AServerBuilder {
+	fake.withNamedAgentMutex.Lock()
+	ret, specificReturn := fake.withNamedAgentReturnsOnCall[len(fake.withNamedAgentArgsForCall)]
+	fake.withNamedAgentArgsForCall = append(fake.withNamedAgentArgsForCall, struct {
+		arg1 string
+		arg2 server.OpenAICompatibleAgent
+		arg3 types.AgentCard
+	}{arg1, arg2, arg3})
+	stub := fake.WithNamedAgentStub
+	fakeReturns := fake.withNamedAgentReturns
+	fake.recordInvocation("WithNamedAgent", []interface{}{arg1, arg2, arg3})
+	fake.withNamedAgentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithNamedAgentCallCount() int {
+	fake.withNamedAgentMutex.RLock()
+	defer fake.withNamedAgentMutex.RUnlock()
+	return len(fake.withNamedAgentArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithNamedAgentCalls(stub func(string, server.OpenAICompatibleAgent, types.AgentCard) server.A2AServerBuilder) {
+	fake.withNamedAgentMutex.Lock()
+	defer fake.withNamedAgentMutex.Unlock()
+	fake.WithNamedAgentStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithNamedAgentArgsForCall(i int) (string, server.OpenAICompatibleAgent, types.AgentCard) {
+	fake.withNamedAgentMutex.RLock()
+	defer fake.withNamedAgentMutex.RUnlock()
+	argsForCall := fake.withNamedAgentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeA2AServerBuilder) WithNamedAgentReturns(result1 server.A2AServerBuilder) {
+	fake.withNamedAgentMutex.Lock()
+	defer fake.withNamedAgentMutex.Unlock()
+	fake.WithNamedAgentStub = nil
+	fake.withNamedAgentReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithNamedAgentReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withNamedAgentMutex.Lock()
+	defer fake.withNamedAgentMutex.Unlock()
+	fake.WithNamedAgentStub = nil
+	if fake.withNamedAgentReturnsOnCall == nil {
+		fake.withNamedAgentReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withNamedAgentReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithOutputGuardrails(arg1 ...server.GuardrailFilter) server.A2AServerBuilder {
+	fake.withOutputGuardrailsMutex.Lock()
+	ret, specificReturn := fake.withOutputGuardrailsReturnsOnCall[len(fake.withOutputGuardrailsArgsForCall)]
+	fake.withOutputGuardrailsArgsForCall = append(fake.withOutputGuardrailsArgsForCall, struct {
+		arg1 []server.GuardrailFilter
+	}{arg1})
+	stub := fake.WithOutputGuardrailsStub
+	fakeReturns := fake.withOutputGuardrailsReturns
+	fake.recordInvocation("WithOutputGuardrails", []interface{}{arg1})
+	fake.withOutputGuardrailsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithOutputGuardrailsCallCount() int {
+	fake.withOutputGuardrailsMutex.RLock()
+	defer fake.withOutputGuardrailsMutex.RUnlock()
+	return len(fake.withOutputGuardrailsArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithOutputGuardrailsCalls(stub func(...server.GuardrailFilter) server.A2AServerBuilder) {
+	fake.withOutputGuardrailsMutex.Lock()
+	defer fake.withOutputGuardrailsMutex.Unlock()
+	fake.WithOutputGuardrailsStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithOutputGuardrailsArgsForCall(i int) []server.GuardrailFilter {
+	fake.withOutputGuardrailsMutex.RLock()
+	defer fake.withOutputGuardrailsMutex.RUnlock()
+	argsForCall := fake.withOutputGuardrailsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithOutputGuardrailsReturns(result1 server.A2AServerBuilder) {
+	fake.withOutputGuardrailsMutex.Lock()
+	defer fake.withOutputGuardrailsMutex.Unlock()
+	fake.WithOutputGuardrailsStub = nil
+	fake.withOutputGuardrailsReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithOutputGuardrailsReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withOutputGuardrailsMutex.Lock()
+	defer fake.withOutputGuardrailsMutex.Unlock()
+	fake.WithOutputGuardrailsStub = nil
+	if fake.withOutputGuardrailsReturnsOnCall == nil {
+		fake.withOutputGuardrailsReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withOutputGuardrailsReturnsOnCall[i] = struct {
 		result1 server.A2AServerBuilder
 	}{result1}
 }
 
-func (fake *FakeA2AServerBuilder) WithDefaultStreamingTaskHandler() server.A2AServerBuilder {
-	fake.withDefaultStreamingTaskHandlerMutex.Lock()
-	ret, specificReturn := fake.withDefaultStreamingTaskHandlerReturnsOnCall[len(fake.withDefaultStreamingTaskHandlerArgsForCall)]
-	fake.withDefaultStreamingTaskHandlerArgsForCall = append(fake.withDefaultStreamingTaskHandlerArgsForCall, struct {
-	}{})
-	stub := fake.WithDefaultStreamingTaskHandlerStub
-	fakeReturns := fake.withDefaultStreamingTaskHandlerReturns
-	fake.recordInvocation("WithDefaultStreamingTaskHandler", []interface{}{})
-	fake.withDefaultStreamingTaskHandlerMutex.Unlock()
+func (fake *FakeA2AServerBuilder) WithPIIDetectors(arg1 ...server.PIIDetector) server.A2AServerBuilder {
+	fake.withPIIDetectorsMutex.Lock()
+	ret, specificReturn := fake.withPIIDetectorsReturnsOnCall[len(fake.withPIIDetectorsArgsForCall)]
+	fake.withPIIDetectorsArgsForCall = append(fake.withPIIDetectorsArgsForCall, struct {
+		arg1 []server.PIIDetector
+	}{arg1})
+	stub := fake.WithPIIDetectorsStub
+	fakeReturns := fake.withPIIDetectorsReturns
+	fake.recordInvocation("WithPIIDetectors", []interface{}{arg1})
+	fake.withPIIDetectorsMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1...)
 	}
 	if specificReturn {
 		return ret.result1
@@ -590,52 +2212,60 @@ func (fake *FakeA2AServerBuilder) WithDefaultStreamingTaskHandler() server.A2ASe
 	return fakeReturns.result1
 }
 
-func (fake *FakeA2AServerBuilder) WithDefaultStreamingTaskHandlerCallCount() int {
-	fake.withDefaultStreamingTaskHandlerMutex.RLock()
-	defer fake.withDefaultStreamingTaskHandlerMutex.RUnlock()
-	return len(fake.withDefaultStreamingTaskHandlerArgsForCall)
+func (fake *FakeA2AServerBuilder) WithPIIDetectorsCallCount() int {
+	fake.withPIIDetectorsMutex.RLock()
+	defer fake.withPIIDetectorsMutex.RUnlock()
+	return len(fake.withPIIDetectorsArgsForCall)
 }
 
-func (fake *FakeA2AServerBuilder) WithDefaultStreamingTaskHandlerCalls(stub func() server.A2AServerBuilder) {
-	fake.withDefaultStreamingTaskHandlerMutex.Lock()
-	defer fake.withDefaultStreamingTaskHandlerMutex.Unlock()
-	fake.WithDefaultStreamingTaskHandlerStub = stub
+func (fake *FakeA2AServerBuilder) WithPIIDetectorsCalls(stub func(...server.PIIDetector) server.A2AServerBuilder) {
+	fake.withPIIDetectorsMutex.Lock()
+	defer fake.withPIIDetectorsMutex.Unlock()
+	fake.WithPIIDetectorsStub = stub
 }
 
-func (fake *FakeA2AServerBuilder) WithDefaultStreamingTaskHandlerReturns(result1 server.A2AServerBuilder) {
-	fake.withDefaultStreamingTaskHandlerMutex.Lock()
-	defer fake.withDefaultStreamingTaskHandlerMutex.Unlock()
-	fake.WithDefaultStreamingTaskHandlerStub = nil
-	fake.withDefaultStreamingTaskHandlerReturns = struct {
+func (fake *FakeA2AServerBuilder) WithPIIDetectorsArgsForCall(i int) []server.PIIDetector {
+	fake.withPIIDetectorsMutex.RLock()
+	defer fake.withPIIDetectorsMutex.RUnlock()
+	argsForCall := fake.withPIIDetectorsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithPIIDetectorsReturns(result1 server.A2AServerBuilder) {
+	fake.withPIIDetectorsMutex.Lock()
+	defer fake.withPIIDetectorsMutex.Unlock()
+	fake.WithPIIDetectorsStub = nil
+	fake.withPIIDetectorsReturns = struct {
 		result1 server.A2AServerBuilder
 	}{result1}
 }
 
-func (fake *FakeA2AServerBuilder) WithDefaultStreamingTaskHandlerReturnsOnCall(i int, result1 server.A2AServerBuilder) {
-	fake.withDefaultStreamingTaskHandlerMutex.Lock()
-	defer fake.withDefaultStreamingTaskHandlerMutex.Unlock()
-	fake.WithDefaultStreamingTaskHandlerStub = nil
-	if fake.withDefaultStreamingTaskHandlerReturnsOnCall == nil {
-		fake.withDefaultStreamingTaskHandlerReturnsOnCall = make(map[int]struct {
+func (fake *FakeA2AServerBuilder) WithPIIDetectorsReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withPIIDetectorsMutex.Lock()
+	defer fake.withPIIDetectorsMutex.Unlock()
+	fake.WithPIIDetectorsStub = nil
+	if fake.withPIIDetectorsReturnsOnCall == nil {
+		fake.withPIIDetectorsReturnsOnCall = make(map[int]struct {
 			result1 server.A2AServerBuilder
 		})
 	}
-	fake.withDefaultStreamingTaskHandlerReturnsOnCall[i] = struct {
+	fake.withPIIDetectorsReturnsOnCall[i] = struct {
 		result1 server.A2AServerBuilder
 	}{result1}
 }
 
-func (fake *FakeA2AServerBuilder) WithDefaultTaskHandlers() server.A2AServerBuilder {
-	fake.withDefaultTaskHandlersMutex.Lock()
-	ret, specificReturn := fake.withDefaultTaskHandlersReturnsOnCall[len(fake.withDefaultTaskHandlersArgsForCall)]
-	fake.withDefaultTaskHandlersArgsForCall = append(fake.withDefaultTaskHandlersArgsForCall, struct {
-	}{})
-	stub := fake.WithDefaultTaskHandlersStub
-	fakeReturns := fake.withDefaultTaskHandlersReturns
-	fake.recordInvocation("WithDefaultTaskHandlers", []interface{}{})
-	fake.withDefaultTaskHandlersMutex.Unlock()
+func (fake *FakeA2AServerBuilder) WithPolicy(arg1 *server.Policy) server.A2AServerBuilder {
+	fake.withPolicyMutex.Lock()
+	ret, specificReturn := fake.withPolicyReturnsOnCall[len(fake.withPolicyArgsForCall)]
+	fake.withPolicyArgsForCall = append(fake.withPolicyArgsForCall, struct {
+		arg1 *server.Policy
+	}{arg1})
+	stub := fake.WithPolicyStub
+	fakeReturns := fake.withPolicyReturns
+	fake.recordInvocation("WithPolicy", []interface{}{arg1})
+	fake.withPolicyMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -643,51 +2273,119 @@ func (fake *FakeA2AServerBuilder) WithDefaultTaskHandlers() server.A2AServerBuil
 	return fakeReturns.result1
 }
 
-func (fake *FakeA2AServerBuilder) WithDefaultTaskHandlersCallCount() int {
-	fake.withDefaultTaskHandlersMutex.RLock()
-	defer fake.withDefaultTaskHandlersMutex.RUnlock()
-	return len(fake.withDefaultTaskHandlersArgsForCall)
+func (fake *FakeA2AServerBuilder) WithPolicyCallCount() int {
+	fake.withPolicyMutex.RLock()
+	defer fake.withPolicyMutex.RUnlock()
+	return len(fake.withPolicyArgsForCall)
 }
 
-func (fake *FakeA2AServerBuilder) WithDefaultTaskHandlersCalls(stub func() server.A2AServerBuilder) {
-	fake.withDefaultTaskHandlersMutex.Lock()
-	defer fake.withDefaultTaskHandlersMutex.Unlock()
-	fake.WithDefaultTaskHandlersStub = stub
+func (fake *FakeA2AServerBuilder) WithPolicyCalls(stub func(*server.Policy) server.A2AServerBuilder) {
+	fake.withPolicyMutex.Lock()
+	defer fake.withPolicyMutex.Unlock()
+	fake.WithPolicyStub = stub
 }
 
-func (fake *FakeA2AServerBuilder) WithDefaultTaskHandlersReturns(result1 server.A2AServerBuilder) {
-	fake.withDefaultTaskHandlersMutex.Lock()
-	defer fake.withDefaultTaskHandlersMutex.Unlock()
-	fake.WithDefaultTaskHandlersStub = nil
-	fake.withDefaultTaskHandlersReturns = struct {
+func (fake *FakeA2AServerBuilder) WithPolicyArgsForCall(i int) *server.Policy {
+	fake.withPolicyMutex.RLock()
+	defer fake.withPolicyMutex.RUnlock()
+	argsForCall := fake.withPolicyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithPolicyReturns(result1 server.A2AServerBuilder) {
+	fake.withPolicyMutex.Lock()
+	defer fake.withPolicyMutex.Unlock()
+	fake.WithPolicyStub = nil
+	fake.withPolicyReturns = struct {
 		result1 server.A2AServerBuilder
 	}{result1}
 }
 
-func (fake *FakeA2AServerBuilder) WithDefaultTaskHandlersReturnsOnCall(i int, result1 server.A2AServerBuilder) {
-	fake.withDefaultTaskHandlersMutex.Lock()
-	defer fake.withDefaultTaskHandlersMutex.Unlock()
-	fake.WithDefaultTaskHandlersStub = nil
-	if fake.withDefaultTaskHandlersReturnsOnCall == nil {
-		fake.withDefaultTaskHandlersReturnsOnCall = make(map[int]struct {
+func (fake *FakeA2AServerBuilder) WithPolicyReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withPolicyMutex.Lock()
+	defer fake.withPolicyMutex.Unlock()
+	fake.WithPolicyStub = nil
+	if fake.withPolicyReturnsOnCall == nil {
+		fake.withPolicyReturnsOnCall = make(map[int]struct {
 			result1 server.A2AServerBuilder
 		})
 	}
-	fake.withDefaultTaskHandlersReturnsOnCall[i] = struct {
+	fake.withPolicyReturnsOnCall[i] = struct {
 		result1 server.A2AServerBuilder
 	}{result1}
 }
 
-func (fake *FakeA2AServerBuilder) WithLogger(arg1 *zap.Logger) server.A2AServerBuilder {
-	fake.withLoggerMutex.Lock()
-	ret, specificReturn := fake.withLoggerReturnsOnCall[len(fake.withLoggerArgsForCall)]
-	fake.withLoggerArgsForCall = append(fake.withLoggerArgsForCall, struct {
-		arg1 *zap.Logger
+func (fake *FakeA2AServerBuilder) WithSchedules(arg1 ...server.Schedule) server.A2AServerBuilder {
+	fake.withSchedulesMutex.Lock()
+	ret, specificReturn := fake.withSchedulesReturnsOnCall[len(fake.withSchedulesArgsForCall)]
+	fake.withSchedulesArgsForCall = append(fake.withSchedulesArgsForCall, struct {
+		arg1 []server.Schedule
 	}{arg1})
-	stub := fake.WithLoggerStub
-	fakeReturns := fake.withLoggerReturns
-	fake.recordInvocation("WithLogger", []interface{}{arg1})
-	fake.withLoggerMutex.Unlock()
+	stub := fake.WithSchedulesStub
+	fakeReturns := fake.withSchedulesReturns
+	fake.recordInvocation("WithSchedules", []interface{}{arg1})
+	fake.withSchedulesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithSchedulesCallCount() int {
+	fake.withSchedulesMutex.RLock()
+	defer fake.withSchedulesMutex.RUnlock()
+	return len(fake.withSchedulesArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithSchedulesCalls(stub func(...server.Schedule) server.A2AServerBuilder) {
+	fake.withSchedulesMutex.Lock()
+	defer fake.withSchedulesMutex.Unlock()
+	fake.WithSchedulesStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithSchedulesArgsForCall(i int) []server.Schedule {
+	fake.withSchedulesMutex.RLock()
+	defer fake.withSchedulesMutex.RUnlock()
+	argsForCall := fake.withSchedulesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithSchedulesReturns(result1 server.A2AServerBuilder) {
+	fake.withSchedulesMutex.Lock()
+	defer fake.withSchedulesMutex.Unlock()
+	fake.WithSchedulesStub = nil
+	fake.withSchedulesReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithSchedulesReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withSchedulesMutex.Lock()
+	defer fake.withSchedulesMutex.Unlock()
+	fake.WithSchedulesStub = nil
+	if fake.withSchedulesReturnsOnCall == nil {
+		fake.withSchedulesReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withSchedulesReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithSecretsProvider(arg1 server.SecretsProvider) server.A2AServerBuilder {
+	fake.withSecretsProviderMutex.Lock()
+	ret, specificReturn := fake.withSecretsProviderReturnsOnCall[len(fake.withSecretsProviderArgsForCall)]
+	fake.withSecretsProviderArgsForCall = append(fake.withSecretsProviderArgsForCall, struct {
+		arg1 server.SecretsProvider
+	}{arg1})
+	stub := fake.WithSecretsProviderStub
+	fakeReturns := fake.withSecretsProviderReturns
+	fake.recordInvocation("WithSecretsProvider", []interface{}{arg1})
+	fake.withSecretsProviderMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
@@ -697,44 +2395,105 @@ func (fake *FakeA2AServerBuilder) WithLogger(arg1 *zap.Logger) server.A2AServerB
 	return fakeReturns.result1
 }
 
-func (fake *FakeA2AServerBuilder) WithLoggerCallCount() int {
-	fake.withLoggerMutex.RLock()
-	defer fake.withLoggerMutex.RUnlock()
-	return len(fake.withLoggerArgsForCall)
+func (fake *FakeA2AServerBuilder) WithSecretsProviderCallCount() int {
+	fake.withSecretsProviderMutex.RLock()
+	defer fake.withSecretsProviderMutex.RUnlock()
+	return len(fake.withSecretsProviderArgsForCall)
 }
 
-func (fake *FakeA2AServerBuilder) WithLoggerCalls(stub func(*zap.Logger) server.A2AServerBuilder) {
-	fake.withLoggerMutex.Lock()
-	defer fake.withLoggerMutex.Unlock()
-	fake.WithLoggerStub = stub
+func (fake *FakeA2AServerBuilder) WithSecretsProviderCalls(stub func(server.SecretsProvider) server.A2AServerBuilder) {
+	fake.withSecretsProviderMutex.Lock()
+	defer fake.withSecretsProviderMutex.Unlock()
+	fake.WithSecretsProviderStub = stub
 }
 
-func (fake *FakeA2AServerBuilder) WithLoggerArgsForCall(i int) *zap.Logger {
-	fake.withLoggerMutex.RLock()
-	defer fake.withLoggerMutex.RUnlock()
-	argsForCall := fake.withLoggerArgsForCall[i]
+func (fake *FakeA2AServerBuilder) WithSecretsProviderArgsForCall(i int) server.SecretsProvider {
+	fake.withSecretsProviderMutex.RLock()
+	defer fake.withSecretsProviderMutex.RUnlock()
+	argsForCall := fake.withSecretsProviderArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *FakeA2AServerBuilder) WithLoggerReturns(result1 server.A2AServerBuilder) {
-	fake.withLoggerMutex.Lock()
-	defer fake.withLoggerMutex.Unlock()
-	fake.WithLoggerStub = nil
-	fake.withLoggerReturns = struct {
+func (fake *FakeA2AServerBuilder) WithSecretsProviderReturns(result1 server.A2AServerBuilder) {
+	fake.withSecretsProviderMutex.Lock()
+	defer fake.withSecretsProviderMutex.Unlock()
+	fake.WithSecretsProviderStub = nil
+	fake.withSecretsProviderReturns = struct {
 		result1 server.A2AServerBuilder
 	}{result1}
 }
 
-func (fake *FakeA2AServerBuilder) WithLoggerReturnsOnCall(i int, result1 server.A2AServerBuilder) {
-	fake.withLoggerMutex.Lock()
-	defer fake.withLoggerMutex.Unlock()
-	fake.WithLoggerStub = nil
-	if fake.withLoggerReturnsOnCall == nil {
-		fake.withLoggerReturnsOnCall = make(map[int]struct {
+func (fake *FakeA2AServerBuilder) WithSecretsProviderReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withSecretsProviderMutex.Lock()
+	defer fake.withSecretsProviderMutex.Unlock()
+	fake.WithSecretsProviderStub = nil
+	if fake.withSecretsProviderReturnsOnCall == nil {
+		fake.withSecretsProviderReturnsOnCall = make(map[int]struct {
 			result1 server.A2AServerBuilder
 		})
 	}
-	fake.withLoggerReturnsOnCall[i] = struct {
+	fake.withSecretsProviderReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithSpeechSynthesizer(arg1 server.SpeechSynthesizer) server.A2AServerBuilder {
+	fake.withSpeechSynthesizerMutex.Lock()
+	ret, specificReturn := fake.withSpeechSynthesizerReturnsOnCall[len(fake.withSpeechSynthesizerArgsForCall)]
+	fake.withSpeechSynthesizerArgsForCall = append(fake.withSpeechSynthesizerArgsForCall, struct {
+		arg1 server.SpeechSynthesizer
+	}{arg1})
+	stub := fake.WithSpeechSynthesizerStub
+	fakeReturns := fake.withSpeechSynthesizerReturns
+	fake.recordInvocation("WithSpeechSynthesizer", []interface{}{arg1})
+	fake.withSpeechSynthesizerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithSpeechSynthesizerCallCount() int {
+	fake.withSpeechSynthesizerMutex.RLock()
+	defer fake.withSpeechSynthesizerMutex.RUnlock()
+	return len(fake.withSpeechSynthesizerArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithSpeechSynthesizerCalls(stub func(server.SpeechSynthesizer) server.A2AServerBuilder) {
+	fake.withSpeechSynthesizerMutex.Lock()
+	defer fake.withSpeechSynthesizerMutex.Unlock()
+	fake.WithSpeechSynthesizerStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithSpeechSynthesizerArgsForCall(i int) server.SpeechSynthesizer {
+	fake.withSpeechSynthesizerMutex.RLock()
+	defer fake.withSpeechSynthesizerMutex.RUnlock()
+	argsForCall := fake.withSpeechSynthesizerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithSpeechSynthesizerReturns(result1 server.A2AServerBuilder) {
+	fake.withSpeechSynthesizerMutex.Lock()
+	defer fake.withSpeechSynthesizerMutex.Unlock()
+	fake.WithSpeechSynthesizerStub = nil
+	fake.withSpeechSynthesizerReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithSpeechSynthesizerReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withSpeechSynthesizerMutex.Lock()
+	defer fake.withSpeechSynthesizerMutex.Unlock()
+	fake.WithSpeechSynthesizerStub = nil
+	if fake.withSpeechSynthesizerReturnsOnCall == nil {
+		fake.withSpeechSynthesizerReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withSpeechSynthesizerReturnsOnCall[i] = struct {
 		result1 server.A2AServerBuilder
 	}{result1}
 }
@@ -800,6 +2559,67 @@ func (fake *FakeA2AServerBuilder) WithStreamingTaskHandlerReturnsOnCall(i int, r
 	}{result1}
 }
 
+func (fake *FakeA2AServerBuilder) WithTaskFeedbackHandler(arg1 server.TaskFeedbackHandler) server.A2AServerBuilder {
+	fake.withTaskFeedbackHandlerMutex.Lock()
+	ret, specificReturn := fake.withTaskFeedbackHandlerReturnsOnCall[len(fake.withTaskFeedbackHandlerArgsForCall)]
+	fake.withTaskFeedbackHandlerArgsForCall = append(fake.withTaskFeedbackHandlerArgsForCall, struct {
+		arg1 server.TaskFeedbackHandler
+	}{arg1})
+	stub := fake.WithTaskFeedbackHandlerStub
+	fakeReturns := fake.withTaskFeedbackHandlerReturns
+	fake.recordInvocation("WithTaskFeedbackHandler", []interface{}{arg1})
+	fake.withTaskFeedbackHandlerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithTaskFeedbackHandlerCallCount() int {
+	fake.withTaskFeedbackHandlerMutex.RLock()
+	defer fake.withTaskFeedbackHandlerMutex.RUnlock()
+	return len(fake.withTaskFeedbackHandlerArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithTaskFeedbackHandlerCalls(stub func(server.TaskFeedbackHandler) server.A2AServerBuilder) {
+	fake.withTaskFeedbackHandlerMutex.Lock()
+	defer fake.withTaskFeedbackHandlerMutex.Unlock()
+	fake.WithTaskFeedbackHandlerStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithTaskFeedbackHandlerArgsForCall(i int) server.TaskFeedbackHandler {
+	fake.withTaskFeedbackHandlerMutex.RLock()
+	defer fake.withTaskFeedbackHandlerMutex.RUnlock()
+	argsForCall := fake.withTaskFeedbackHandlerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithTaskFeedbackHandlerReturns(result1 server.A2AServerBuilder) {
+	fake.withTaskFeedbackHandlerMutex.Lock()
+	defer fake.withTaskFeedbackHandlerMutex.Unlock()
+	fake.WithTaskFeedbackHandlerStub = nil
+	fake.withTaskFeedbackHandlerReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithTaskFeedbackHandlerReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withTaskFeedbackHandlerMutex.Lock()
+	defer fake.withTaskFeedbackHandlerMutex.Unlock()
+	fake.WithTaskFeedbackHandlerStub = nil
+	if fake.withTaskFeedbackHandlerReturnsOnCall == nil {
+		fake.withTaskFeedbackHandlerReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withTaskFeedbackHandlerReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
 func (fake *FakeA2AServerBuilder) WithTaskResultProcessor(arg1 server.TaskResultProcessor) server.A2AServerBuilder {
 	fake.withTaskResultProcessorMutex.Lock()
 	ret, specificReturn := fake.withTaskResultProcessorReturnsOnCall[len(fake.withTaskResultProcessorArgsForCall)]
@@ -922,6 +2742,128 @@ func (fake *FakeA2AServerBuilder) WithTelemetryReturnsOnCall(i int, result1 serv
 	}{result1}
 }
 
+func (fake *FakeA2AServerBuilder) WithTranscriber(arg1 server.Transcriber) server.A2AServerBuilder {
+	fake.withTranscriberMutex.Lock()
+	ret, specificReturn := fake.withTranscriberReturnsOnCall[len(fake.withTranscriberArgsForCall)]
+	fake.withTranscriberArgsForCall = append(fake.withTranscriberArgsForCall, struct {
+		arg1 server.Transcriber
+	}{arg1})
+	stub := fake.WithTranscriberStub
+	fakeReturns := fake.withTranscriberReturns
+	fake.recordInvocation("WithTranscriber", []interface{}{arg1})
+	fake.withTranscriberMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithTranscriberCallCount() int {
+	fake.withTranscriberMutex.RLock()
+	defer fake.withTranscriberMutex.RUnlock()
+	return len(fake.withTranscriberArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithTranscriberCalls(stub func(server.Transcriber) server.A2AServerBuilder) {
+	fake.withTranscriberMutex.Lock()
+	defer fake.withTranscriberMutex.Unlock()
+	fake.WithTranscriberStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithTranscriberArgsForCall(i int) server.Transcriber {
+	fake.withTranscriberMutex.RLock()
+	defer fake.withTranscriberMutex.RUnlock()
+	argsForCall := fake.withTranscriberArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithTranscriberReturns(result1 server.A2AServerBuilder) {
+	fake.withTranscriberMutex.Lock()
+	defer fake.withTranscriberMutex.Unlock()
+	fake.WithTranscriberStub = nil
+	fake.withTranscriberReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithTranscriberReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withTranscriberMutex.Lock()
+	defer fake.withTranscriberMutex.Unlock()
+	fake.WithTranscriberStub = nil
+	if fake.withTranscriberReturnsOnCall == nil {
+		fake.withTranscriberReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withTranscriberReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithTranslator(arg1 server.Translator) server.A2AServerBuilder {
+	fake.withTranslatorMutex.Lock()
+	ret, specificReturn := fake.withTranslatorReturnsOnCall[len(fake.withTranslatorArgsForCall)]
+	fake.withTranslatorArgsForCall = append(fake.withTranslatorArgsForCall, struct {
+		arg1 server.Translator
+	}{arg1})
+	stub := fake.WithTranslatorStub
+	fakeReturns := fake.withTranslatorReturns
+	fake.recordInvocation("WithTranslator", []interface{}{arg1})
+	fake.withTranslatorMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeA2AServerBuilder) WithTranslatorCallCount() int {
+	fake.withTranslatorMutex.RLock()
+	defer fake.withTranslatorMutex.RUnlock()
+	return len(fake.withTranslatorArgsForCall)
+}
+
+func (fake *FakeA2AServerBuilder) WithTranslatorCalls(stub func(server.Translator) server.A2AServerBuilder) {
+	fake.withTranslatorMutex.Lock()
+	defer fake.withTranslatorMutex.Unlock()
+	fake.WithTranslatorStub = stub
+}
+
+func (fake *FakeA2AServerBuilder) WithTranslatorArgsForCall(i int) server.Translator {
+	fake.withTranslatorMutex.RLock()
+	defer fake.withTranslatorMutex.RUnlock()
+	argsForCall := fake.withTranslatorArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeA2AServerBuilder) WithTranslatorReturns(result1 server.A2AServerBuilder) {
+	fake.withTranslatorMutex.Lock()
+	defer fake.withTranslatorMutex.Unlock()
+	fake.WithTranslatorStub = nil
+	fake.withTranslatorReturns = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
+func (fake *FakeA2AServerBuilder) WithTranslatorReturnsOnCall(i int, result1 server.A2AServerBuilder) {
+	fake.withTranslatorMutex.Lock()
+	defer fake.withTranslatorMutex.Unlock()
+	fake.WithTranslatorStub = nil
+	if fake.withTranslatorReturnsOnCall == nil {
+		fake.withTranslatorReturnsOnCall = make(map[int]struct {
+			result1 server.A2AServerBuilder
+		})
+	}
+	fake.withTranslatorReturnsOnCall[i] = struct {
+		result1 server.A2AServerBuilder
+	}{result1}
+}
+
 func (fake *FakeA2AServerBuilder) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -933,24 +2875,78 @@ func (fake *FakeA2AServerBuilder) Invocations() map[string][][]interface{} {
 	defer fake.withAgentCardMutex.RUnlock()
 	fake.withAgentCardFromFileMutex.RLock()
 	defer fake.withAgentCardFromFileMutex.RUnlock()
+	fake.withAgentCardProviderMutex.RLock()
+	defer fake.withAgentCardProviderMutex.RUnlock()
+	fake.withAgentsFromDirMutex.RLock()
+	defer fake.withAgentsFromDirMutex.RUnlock()
 	fake.withArtifactServiceMutex.RLock()
 	defer fake.withArtifactServiceMutex.RUnlock()
+	fake.withAuditLoggerMutex.RLock()
+	defer fake.withAuditLoggerMutex.RUnlock()
 	fake.withBackgroundTaskHandlerMutex.RLock()
 	defer fake.withBackgroundTaskHandlerMutex.RUnlock()
+	fake.withClockMutex.RLock()
+	defer fake.withClockMutex.RUnlock()
+	fake.withConfigReloadHandlerMutex.RLock()
+	defer fake.withConfigReloadHandlerMutex.RUnlock()
 	fake.withDefaultBackgroundTaskHandlerMutex.RLock()
 	defer fake.withDefaultBackgroundTaskHandlerMutex.RUnlock()
 	fake.withDefaultStreamingTaskHandlerMutex.RLock()
 	defer fake.withDefaultStreamingTaskHandlerMutex.RUnlock()
 	fake.withDefaultTaskHandlersMutex.RLock()
 	defer fake.withDefaultTaskHandlersMutex.RUnlock()
+	fake.withEventSinkMutex.RLock()
+	defer fake.withEventSinkMutex.RUnlock()
+	fake.withExtendedAgentCardMutex.RLock()
+	defer fake.withExtendedAgentCardMutex.RUnlock()
+	fake.withFileConvertersMutex.RLock()
+	defer fake.withFileConvertersMutex.RUnlock()
+	fake.withHTTPMiddlewareMutex.RLock()
+	defer fake.withHTTPMiddlewareMutex.RUnlock()
+	fake.withIDGeneratorMutex.RLock()
+	defer fake.withIDGeneratorMutex.RUnlock()
+	fake.withInferredCapabilitiesMutex.RLock()
+	defer fake.withInferredCapabilitiesMutex.RUnlock()
+	fake.withInputGuardrailsMutex.RLock()
+	defer fake.withInputGuardrailsMutex.RUnlock()
+	fake.withJSONRPCMethodMutex.RLock()
+	defer fake.withJSONRPCMethodMutex.RUnlock()
+	fake.withLanguageDetectorMutex.RLock()
+	defer fake.withLanguageDetectorMutex.RUnlock()
 	fake.withLoggerMutex.RLock()
 	defer fake.withLoggerMutex.RUnlock()
+	fake.withMemoryEmbedderMutex.RLock()
+	defer fake.withMemoryEmbedderMutex.RUnlock()
+	fake.withMemoryExtractorMutex.RLock()
+	defer fake.withMemoryExtractorMutex.RUnlock()
+	fake.withMemoryStoreMutex.RLock()
+	defer fake.withMemoryStoreMutex.RUnlock()
+	fake.withNamedAgentMutex.RLock()
+	defer fake.withNamedAgentMutex.RUnlock()
+	fake.withOutputGuardrailsMutex.RLock()
+	defer fake.withOutputGuardrailsMutex.RUnlock()
+	fake.withPIIDetectorsMutex.RLock()
+	defer fake.withPIIDetectorsMutex.RUnlock()
+	fake.withPolicyMutex.RLock()
+	defer fake.withPolicyMutex.RUnlock()
+	fake.withSchedulesMutex.RLock()
+	defer fake.withSchedulesMutex.RUnlock()
+	fake.withSecretsProviderMutex.RLock()
+	defer fake.withSecretsProviderMutex.RUnlock()
+	fake.withSpeechSynthesizerMutex.RLock()
+	defer fake.withSpeechSynthesizerMutex.RUnlock()
 	fake.withStreamingTaskHandlerMutex.RLock()
 	defer fake.withStreamingTaskHandlerMutex.RUnlock()
+	fake.withTaskFeedbackHandlerMutex.RLock()
+	defer fake.withTaskFeedbackHandlerMutex.RUnlock()
 	fake.withTaskResultProcessorMutex.RLock()
 	defer fake.withTaskResultProcessorMutex.RUnlock()
 	fake.withTelemetryMutex.RLock()
 	defer fake.withTelemetryMutex.RUnlock()
+	fake.withTranscriberMutex.RLock()
+	defer fake.withTranscriberMutex.RUnlock()
+	fake.withTranslatorMutex.RLock()
+	defer fake.withTranslatorMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
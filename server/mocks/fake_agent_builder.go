@@ -31,6 +31,17 @@ type FakeAgentBuilder struct {
 	getConfigReturnsOnCall map[int]struct {
 		result1 *config.AgentConfig
 	}
+	WithBudgetStub        func(*server.Budget) server.AgentBuilder
+	withBudgetMutex       sync.RWMutex
+	withBudgetArgsForCall []struct {
+		arg1 *server.Budget
+	}
+	withBudgetReturns struct {
+		result1 server.AgentBuilder
+	}
+	withBudgetReturnsOnCall map[int]struct {
+		result1 server.AgentBuilder
+	}
 	WithCallbacksStub        func(*server.CallbackConfig) server.AgentBuilder
 	withCallbacksMutex       sync.RWMutex
 	withCallbacksArgsForCall []struct {
@@ -63,6 +74,18 @@ type FakeAgentBuilder struct {
 	withDefaultToolBoxReturnsOnCall map[int]struct {
 		result1 server.AgentBuilder
 	}
+	WithKnowledgeBaseStub        func(server.VectorStore, server.Embedder) server.AgentBuilder
+	withKnowledgeBaseMutex       sync.RWMutex
+	withKnowledgeBaseArgsForCall []struct {
+		arg1 server.VectorStore
+		arg2 server.Embedder
+	}
+	withKnowledgeBaseReturns struct {
+		result1 server.AgentBuilder
+	}
+	withKnowledgeBaseReturnsOnCall map[int]struct {
+		result1 server.AgentBuilder
+	}
 	WithLLMClientStub        func(server.LLMClient) server.AgentBuilder
 	withLLMClientMutex       sync.RWMutex
 	withLLMClientArgsForCall []struct {
@@ -96,6 +119,29 @@ type FakeAgentBuilder struct {
 	withMaxConversationHistoryReturnsOnCall map[int]struct {
 		result1 server.AgentBuilder
 	}
+	WithModelRouterStub        func(server.ModelRouter) server.AgentBuilder
+	withModelRouterMutex       sync.RWMutex
+	withModelRouterArgsForCall []struct {
+		arg1 server.ModelRouter
+	}
+	withModelRouterReturns struct {
+		result1 server.AgentBuilder
+	}
+	withModelRouterReturnsOnCall map[int]struct {
+		result1 server.AgentBuilder
+	}
+	WithSkillStub        func(string, server.SkillBinding) server.AgentBuilder
+	withSkillMutex       sync.RWMutex
+	withSkillArgsForCall []struct {
+		arg1 string
+		arg2 server.SkillBinding
+	}
+	withSkillReturns struct {
+		result1 server.AgentBuilder
+	}
+	withSkillReturnsOnCall map[int]struct {
+		result1 server.AgentBuilder
+	}
 	WithSystemPromptStub        func(string) server.AgentBuilder
 	withSystemPromptMutex       sync.RWMutex
 	withSystemPromptArgsForCall []struct {
@@ -231,6 +277,67 @@ func (fake *FakeAgentBuilder) GetConfigReturnsOnCall(i int, result1 *config.Agen
 	}{result1}
 }
 
+func (fake *FakeAgentBuilder) WithBudget(arg1 *server.Budget) server.AgentBuilder {
+	fake.withBudgetMutex.Lock()
+	ret, specificReturn := fake.withBudgetReturnsOnCall[len(fake.withBudgetArgsForCall)]
+	fake.withBudgetArgsForCall = append(fake.withBudgetArgsForCall, struct {
+		arg1 *server.Budget
+	}{arg1})
+	stub := fake.WithBudgetStub
+	fakeReturns := fake.withBudgetReturns
+	fake.recordInvocation("WithBudget", []interface{}{arg1})
+	fake.withBudgetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAgentBuilder) WithBudgetCallCount() int {
+	fake.withBudgetMutex.RLock()
+	defer fake.withBudgetMutex.RUnlock()
+	return len(fake.withBudgetArgsForCall)
+}
+
+func (fake *FakeAgentBuilder) WithBudgetCalls(stub func(*server.Budget) server.AgentBuilder) {
+	fake.withBudgetMutex.Lock()
+	defer fake.withBudgetMutex.Unlock()
+	fake.WithBudgetStub = stub
+}
+
+func (fake *FakeAgentBuilder) WithBudgetArgsForCall(i int) *server.Budget {
+	fake.withBudgetMutex.RLock()
+	defer fake.withBudgetMutex.RUnlock()
+	argsForCall := fake.withBudgetArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAgentBuilder) WithBudgetReturns(result1 server.AgentBuilder) {
+	fake.withBudgetMutex.Lock()
+	defer fake.withBudgetMutex.Unlock()
+	fake.WithBudgetStub = nil
+	fake.withBudgetReturns = struct {
+		result1 server.AgentBuilder
+	}{result1}
+}
+
+func (fake *FakeAgentBuilder) WithBudgetReturnsOnCall(i int, result1 server.AgentBuilder) {
+	fake.withBudgetMutex.Lock()
+	defer fake.withBudgetMutex.Unlock()
+	fake.WithBudgetStub = nil
+	if fake.withBudgetReturnsOnCall == nil {
+		fake.withBudgetReturnsOnCall = make(map[int]struct {
+			result1 server.AgentBuilder
+		})
+	}
+	fake.withBudgetReturnsOnCall[i] = struct {
+		result1 server.AgentBuilder
+	}{result1}
+}
+
 func (fake *FakeAgentBuilder) WithCallbacks(arg1 *server.CallbackConfig) server.AgentBuilder {
 	fake.withCallbacksMutex.Lock()
 	ret, specificReturn := fake.withCallbacksReturnsOnCall[len(fake.withCallbacksArgsForCall)]
@@ -406,6 +513,68 @@ func (fake *FakeAgentBuilder) WithDefaultToolBoxReturnsOnCall(i int, result1 ser
 	}{result1}
 }
 
+func (fake *FakeAgentBuilder) WithKnowledgeBase(arg1 server.VectorStore, arg2 server.Embedder) server.AgentBuilder {
+	fake.withKnowledgeBaseMutex.Lock()
+	ret, specificReturn := fake.withKnowledgeBaseReturnsOnCall[len(fake.withKnowledgeBaseArgsForCall)]
+	fake.withKnowledgeBaseArgsForCall = append(fake.withKnowledgeBaseArgsForCall, struct {
+		arg1 server.VectorStore
+		arg2 server.Embedder
+	}{arg1, arg2})
+	stub := fake.WithKnowledgeBaseStub
+	fakeReturns := fake.withKnowledgeBaseReturns
+	fake.recordInvocation("WithKnowledgeBase", []interface{}{arg1, arg2})
+	fake.withKnowledgeBaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAgentBuilder) WithKnowledgeBaseCallCount() int {
+	fake.withKnowledgeBaseMutex.RLock()
+	defer fake.withKnowledgeBaseMutex.RUnlock()
+	return len(fake.withKnowledgeBaseArgsForCall)
+}
+
+func (fake *FakeAgentBuilder) WithKnowledgeBaseCalls(stub func(server.VectorStore, server.Embedder) server.AgentBuilder) {
+	fake.withKnowledgeBaseMutex.Lock()
+	defer fake.withKnowledgeBaseMutex.Unlock()
+	fake.WithKnowledgeBaseStub = stub
+}
+
+func (fake *FakeAgentBuilder) WithKnowledgeBaseArgsForCall(i int) (server.VectorStore, server.Embedder) {
+	fake.withKnowledgeBaseMutex.RLock()
+	defer fake.withKnowledgeBaseMutex.RUnlock()
+	argsForCall := fake.withKnowledgeBaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAgentBuilder) WithKnowledgeBaseReturns(result1 server.AgentBuilder) {
+	fake.withKnowledgeBaseMutex.Lock()
+	defer fake.withKnowledgeBaseMutex.Unlock()
+	fake.WithKnowledgeBaseStub = nil
+	fake.withKnowledgeBaseReturns = struct {
+		result1 server.AgentBuilder
+	}{result1}
+}
+
+func (fake *FakeAgentBuilder) WithKnowledgeBaseReturnsOnCall(i int, result1 server.AgentBuilder) {
+	fake.withKnowledgeBaseMutex.Lock()
+	defer fake.withKnowledgeBaseMutex.Unlock()
+	fake.WithKnowledgeBaseStub = nil
+	if fake.withKnowledgeBaseReturnsOnCall == nil {
+		fake.withKnowledgeBaseReturnsOnCall = make(map[int]struct {
+			result1 server.AgentBuilder
+		})
+	}
+	fake.withKnowledgeBaseReturnsOnCall[i] = struct {
+		result1 server.AgentBuilder
+	}{result1}
+}
+
 func (fake *FakeAgentBuilder) WithLLMClient(arg1 server.LLMClient) server.AgentBuilder {
 	fake.withLLMClientMutex.Lock()
 	ret, specificReturn := fake.withLLMClientReturnsOnCall[len(fake.withLLMClientArgsForCall)]
@@ -589,6 +758,129 @@ func (fake *FakeAgentBuilder) WithMaxConversationHistoryReturnsOnCall(i int, res
 	}{result1}
 }
 
+func (fake *FakeAgentBuilder) WithModelRouter(arg1 server.ModelRouter) server.AgentBuilder {
+	fake.withModelRouterMutex.Lock()
+	ret, specificReturn := fake.withModelRouterReturnsOnCall[len(fake.withModelRouterArgsForCall)]
+	fake.withModelRouterArgsForCall = append(fake.withModelRouterArgsForCall, struct {
+		arg1 server.ModelRouter
+	}{arg1})
+	stub := fake.WithModelRouterStub
+	fakeReturns := fake.withModelRouterReturns
+	fake.recordInvocation("WithModelRouter", []interface{}{arg1})
+	fake.withModelRouterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAgentBuilder) WithModelRouterCallCount() int {
+	fake.withModelRouterMutex.RLock()
+	defer fake.withModelRouterMutex.RUnlock()
+	return len(fake.withModelRouterArgsForCall)
+}
+
+func (fake *FakeAgentBuilder) WithModelRouterCalls(stub func(server.ModelRouter) server.AgentBuilder) {
+	fake.withModelRouterMutex.Lock()
+	defer fake.withModelRouterMutex.Unlock()
+	fake.WithModelRouterStub = stub
+}
+
+func (fake *FakeAgentBuilder) WithModelRouterArgsForCall(i int) server.ModelRouter {
+	fake.withModelRouterMutex.RLock()
+	defer fake.withModelRouterMutex.RUnlock()
+	argsForCall := fake.withModelRouterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAgentBuilder) WithModelRouterReturns(result1 server.AgentBuilder) {
+	fake.withModelRouterMutex.Lock()
+	defer fake.withModelRouterMutex.Unlock()
+	fake.WithModelRouterStub = nil
+	fake.withModelRouterReturns = struct {
+		result1 server.AgentBuilder
+	}{result1}
+}
+
+func (fake *FakeAgentBuilder) WithModelRouterReturnsOnCall(i int, result1 server.AgentBuilder) {
+	fake.withModelRouterMutex.Lock()
+	defer fake.withModelRouterMutex.Unlock()
+	fake.WithModelRouterStub = nil
+	if fake.withModelRouterReturnsOnCall == nil {
+		fake.withModelRouterReturnsOnCall = make(map[int]struct {
+			result1 server.AgentBuilder
+		})
+	}
+	fake.withModelRouterReturnsOnCall[i] = struct {
+		result1 server.AgentBuilder
+	}{result1}
+}
+
+func (fake *FakeAgentBuilder) WithSkill(arg1 string, arg2 server.SkillBinding) server.AgentBuilder {
+	fake.withSkillMutex.Lock()
+	ret, specificReturn := fake.withSkillReturnsOnCall[len(fake.withSkillArgsForCall)]
+	fake.withSkillArgsForCall = append(fake.withSkillArgsForCall, struct {
+		arg1 string
+		arg2 server.SkillBinding
+	}{arg1, arg2})
+	stub := fake.WithSkillStub
+	fakeReturns := fake.withSkillReturns
+	fake.recordInvocation("WithSkill", []interface{}{arg1, arg2})
+	fake.withSkillMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAgentBuilder) WithSkillCallCount() int {
+	fake.withSkillMutex.RLock()
+	defer fake.withSkillMutex.RUnlock()
+	return len(fake.withSkillArgsForCall)
+}
+
+func (fake *FakeAgentBuilder) WithSkillCalls(stub func(string, server.SkillBinding) server.AgentBuilder) {
+	fake.withSkillMutex.Lock()
+	defer fake.withSkillMutex.Unlock()
+	fake.WithSkillStub = stub
+}
+
+func (fake *FakeAgentBuilder) WithSkillArgsForCall(i int) (string, server.SkillBinding) {
+	fake.withSkillMutex.RLock()
+	defer fake.withSkillMutex.RUnlock()
+	argsForCall := fake.withSkillArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAgentBuilder) WithSkillReturns(result1 server.AgentBuilder) {
+	fake.withSkillMutex.Lock()
+	defer fake.withSkillMutex.Unlock()
+	fake.WithSkillStub = nil
+	fake.withSkillReturns = struct {
+		result1 server.AgentBuilder
+	}{result1}
+}
+
+func (fake *FakeAgentBuilder) WithSkillReturnsOnCall(i int, result1 server.AgentBuilder) {
+	fake.withSkillMutex.Lock()
+	defer fake.withSkillMutex.Unlock()
+	fake.WithSkillStub = nil
+	if fake.withSkillReturnsOnCall == nil {
+		fake.withSkillReturnsOnCall = make(map[int]struct {
+			result1 server.AgentBuilder
+		})
+	}
+	fake.withSkillReturnsOnCall[i] = struct {
+		result1 server.AgentBuilder
+	}{result1}
+}
+
 func (fake *FakeAgentBuilder) WithSystemPrompt(arg1 string) server.AgentBuilder {
 	fake.withSystemPromptMutex.Lock()
 	ret, specificReturn := fake.withSystemPromptReturnsOnCall[len(fake.withSystemPromptArgsForCall)]
@@ -718,18 +1010,26 @@ func (fake *FakeAgentBuilder) Invocations() map[string][][]interface{} {
 	defer fake.buildMutex.RUnlock()
 	fake.getConfigMutex.RLock()
 	defer fake.getConfigMutex.RUnlock()
+	fake.withBudgetMutex.RLock()
+	defer fake.withBudgetMutex.RUnlock()
 	fake.withCallbacksMutex.RLock()
 	defer fake.withCallbacksMutex.RUnlock()
 	fake.withConfigMutex.RLock()
 	defer fake.withConfigMutex.RUnlock()
 	fake.withDefaultToolBoxMutex.RLock()
 	defer fake.withDefaultToolBoxMutex.RUnlock()
+	fake.withKnowledgeBaseMutex.RLock()
+	defer fake.withKnowledgeBaseMutex.RUnlock()
 	fake.withLLMClientMutex.RLock()
 	defer fake.withLLMClientMutex.RUnlock()
 	fake.withMaxChatCompletionMutex.RLock()
 	defer fake.withMaxChatCompletionMutex.RUnlock()
 	fake.withMaxConversationHistoryMutex.RLock()
 	defer fake.withMaxConversationHistoryMutex.RUnlock()
+	fake.withModelRouterMutex.RLock()
+	defer fake.withModelRouterMutex.RUnlock()
+	fake.withSkillMutex.RLock()
+	defer fake.withSkillMutex.RUnlock()
 	fake.withSystemPromptMutex.RLock()
 	defer fake.withSystemPromptMutex.RUnlock()
 	fake.withToolBoxMutex.RLock()
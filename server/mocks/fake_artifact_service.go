@@ -24,6 +24,37 @@ type FakeArtifactService struct {
 		arg1 *types.Task
 		arg2 []types.Artifact
 	}
+	ChecksumStub        func(context.Context, string, string, string) (string, error)
+	checksumMutex       sync.RWMutex
+	checksumArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	checksumReturns struct {
+		result1 string
+		result2 error
+	}
+	checksumReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	CleanupArtifactsOverQuotaStub        func(context.Context, int64, int64) (int, error)
+	cleanupArtifactsOverQuotaMutex       sync.RWMutex
+	cleanupArtifactsOverQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 int64
+	}
+	cleanupArtifactsOverQuotaReturns struct {
+		result1 int
+		result2 error
+	}
+	cleanupArtifactsOverQuotaReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	CleanupExpiredArtifactsStub        func(context.Context, time.Duration) (int, error)
 	cleanupExpiredArtifactsMutex       sync.RWMutex
 	cleanupExpiredArtifactsArgsForCall []struct {
@@ -93,6 +124,21 @@ type FakeArtifactService struct {
 		result1 types.Artifact
 		result2 error
 	}
+	CreateFileArtifactFromReaderStub        func(context.Context, server.FileArtifactMeta, io.Reader) (types.Artifact, error)
+	createFileArtifactFromReaderMutex       sync.RWMutex
+	createFileArtifactFromReaderArgsForCall []struct {
+		arg1 context.Context
+		arg2 server.FileArtifactMeta
+		arg3 io.Reader
+	}
+	createFileArtifactFromReaderReturns struct {
+		result1 types.Artifact
+		result2 error
+	}
+	createFileArtifactFromReaderReturnsOnCall map[int]struct {
+		result1 types.Artifact
+		result2 error
+	}
 	CreateFileArtifactFromURIStub        func(string, string, string, string, *string) types.Artifact
 	createFileArtifactFromURIMutex       sync.RWMutex
 	createFileArtifactFromURIArgsForCall []struct {
@@ -149,6 +195,20 @@ type FakeArtifactService struct {
 	createTextArtifactReturnsOnCall map[int]struct {
 		result1 types.Artifact
 	}
+	DeleteStub        func(context.Context, string, string, string) error
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	deleteReturns struct {
+		result1 error
+	}
+	deleteReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ExistsStub        func(context.Context, string, string, string) (bool, error)
 	existsMutex       sync.RWMutex
 	existsArgsForCall []struct {
@@ -202,6 +262,32 @@ type FakeArtifactService struct {
 	getMimeTypeFromExtensionReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	GetURLStub        func(string, string, string) string
+	getURLMutex       sync.RWMutex
+	getURLArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getURLReturns struct {
+		result1 string
+	}
+	getURLReturnsOnCall map[int]struct {
+		result1 string
+	}
+	ListStoredArtifactsStub        func(context.Context) ([]server.ArtifactMetadata, error)
+	listStoredArtifactsMutex       sync.RWMutex
+	listStoredArtifactsArgsForCall []struct {
+		arg1 context.Context
+	}
+	listStoredArtifactsReturns struct {
+		result1 []server.ArtifactMetadata
+		result2 error
+	}
+	listStoredArtifactsReturnsOnCall map[int]struct {
+		result1 []server.ArtifactMetadata
+		result2 error
+	}
 	RetrieveStub        func(context.Context, string, string, string) (io.ReadCloser, error)
 	retrieveMutex       sync.RWMutex
 	retrieveArgsForCall []struct {
@@ -218,6 +304,25 @@ type FakeArtifactService struct {
 		result1 io.ReadCloser
 		result2 error
 	}
+	StoreFileStub        func(context.Context, string, string, string, io.Reader) (string, string, error)
+	storeFileMutex       sync.RWMutex
+	storeFileArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 io.Reader
+	}
+	storeFileReturns struct {
+		result1 string
+		result2 string
+		result3 error
+	}
+	storeFileReturnsOnCall map[int]struct {
+		result1 string
+		result2 string
+		result3 error
+	}
 	ValidateArtifactStub        func(types.Artifact) error
 	validateArtifactMutex       sync.RWMutex
 	validateArtifactArgsForCall []struct {
@@ -229,6 +334,20 @@ type FakeArtifactService struct {
 	validateArtifactReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WriterStub        func(context.Context, server.FileArtifactMeta) (server.ArtifactWriter, error)
+	writerMutex       sync.RWMutex
+	writerArgsForCall []struct {
+		arg1 context.Context
+		arg2 server.FileArtifactMeta
+	}
+	writerReturns struct {
+		result1 server.ArtifactWriter
+		result2 error
+	}
+	writerReturnsOnCall map[int]struct {
+		result1 server.ArtifactWriter
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -304,6 +423,139 @@ func (fake *FakeArtifactService) AddArtifactsToTaskArgsForCall(i int) (*types.Ta
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeArtifactService) Checksum(arg1 context.Context, arg2 string, arg3 string, arg4 string) (string, error) {
+	fake.checksumMutex.Lock()
+	ret, specificReturn := fake.checksumReturnsOnCall[len(fake.checksumArgsForCall)]
+	fake.checksumArgsForCall = append(fake.checksumArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ChecksumStub
+	fakeReturns := fake.checksumReturns
+	fake.recordInvocation("Checksum", []interface{}{arg1, arg2, arg3, arg4})
+	fake.checksumMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeArtifactService) ChecksumCallCount() int {
+	fake.checksumMutex.RLock()
+	defer fake.checksumMutex.RUnlock()
+	return len(fake.checksumArgsForCall)
+}
+
+func (fake *FakeArtifactService) ChecksumCalls(stub func(context.Context, string, string, string) (string, error)) {
+	fake.checksumMutex.Lock()
+	defer fake.checksumMutex.Unlock()
+	fake.ChecksumStub = stub
+}
+
+func (fake *FakeArtifactService) ChecksumArgsForCall(i int) (context.Context, string, string, string) {
+	fake.checksumMutex.RLock()
+	defer fake.checksumMutex.RUnlock()
+	argsForCall := fake.checksumArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeArtifactService) ChecksumReturns(result1 string, result2 error) {
+	fake.checksumMutex.Lock()
+	defer fake.checksumMutex.Unlock()
+	fake.ChecksumStub = nil
+	fake.checksumReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArtifactService) ChecksumReturnsOnCall(i int, result1 string, result2 error) {
+	fake.checksumMutex.Lock()
+	defer fake.checksumMutex.Unlock()
+	fake.ChecksumStub = nil
+	if fake.checksumReturnsOnCall == nil {
+		fake.checksumReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.checksumReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArtifactService) CleanupArtifactsOverQuota(arg1 context.Context, arg2 int64, arg3 int64) (int, error) {
+	fake.cleanupArtifactsOverQuotaMutex.Lock()
+	ret, specificReturn := fake.cleanupArtifactsOverQuotaReturnsOnCall[len(fake.cleanupArtifactsOverQuotaArgsForCall)]
+	fake.cleanupArtifactsOverQuotaArgsForCall = append(fake.cleanupArtifactsOverQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 int64
+	}{arg1, arg2, arg3})
+	stub := fake.CleanupArtifactsOverQuotaStub
+	fakeReturns := fake.cleanupArtifactsOverQuotaReturns
+	fake.recordInvocation("CleanupArtifactsOverQuota", []interface{}{arg1, arg2, arg3})
+	fake.cleanupArtifactsOverQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeArtifactService) CleanupArtifactsOverQuotaCallCount() int {
+	fake.cleanupArtifactsOverQuotaMutex.RLock()
+	defer fake.cleanupArtifactsOverQuotaMutex.RUnlock()
+	return len(fake.cleanupArtifactsOverQuotaArgsForCall)
+}
+
+func (fake *FakeArtifactService) CleanupArtifactsOverQuotaCalls(stub func(context.Context, int64, int64) (int, error)) {
+	fake.cleanupArtifactsOverQuotaMutex.Lock()
+	defer fake.cleanupArtifactsOverQuotaMutex.Unlock()
+	fake.CleanupArtifactsOverQuotaStub = stub
+}
+
+func (fake *FakeArtifactService) CleanupArtifactsOverQuotaArgsForCall(i int) (context.Context, int64, int64) {
+	fake.cleanupArtifactsOverQuotaMutex.RLock()
+	defer fake.cleanupArtifactsOverQuotaMutex.RUnlock()
+	argsForCall := fake.cleanupArtifactsOverQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeArtifactService) CleanupArtifactsOverQuotaReturns(result1 int, result2 error) {
+	fake.cleanupArtifactsOverQuotaMutex.Lock()
+	defer fake.cleanupArtifactsOverQuotaMutex.Unlock()
+	fake.CleanupArtifactsOverQuotaStub = nil
+	fake.cleanupArtifactsOverQuotaReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArtifactService) CleanupArtifactsOverQuotaReturnsOnCall(i int, result1 int, result2 error) {
+	fake.cleanupArtifactsOverQuotaMutex.Lock()
+	defer fake.cleanupArtifactsOverQuotaMutex.Unlock()
+	fake.CleanupArtifactsOverQuotaStub = nil
+	if fake.cleanupArtifactsOverQuotaReturnsOnCall == nil {
+		fake.cleanupArtifactsOverQuotaReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.cleanupArtifactsOverQuotaReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeArtifactService) CleanupExpiredArtifacts(arg1 context.Context, arg2 time.Duration) (int, error) {
 	fake.cleanupExpiredArtifactsMutex.Lock()
 	ret, specificReturn := fake.cleanupExpiredArtifactsReturnsOnCall[len(fake.cleanupExpiredArtifactsArgsForCall)]
@@ -624,6 +876,72 @@ func (fake *FakeArtifactService) CreateFileArtifactReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeArtifactService) CreateFileArtifactFromReader(arg1 context.Context, arg2 server.FileArtifactMeta, arg3 io.Reader) (types.Artifact, error) {
+	fake.createFileArtifactFromReaderMutex.Lock()
+	ret, specificReturn := fake.createFileArtifactFromReaderReturnsOnCall[len(fake.createFileArtifactFromReaderArgsForCall)]
+	fake.createFileArtifactFromReaderArgsForCall = append(fake.createFileArtifactFromReaderArgsForCall, struct {
+		arg1 context.Context
+		arg2 server.FileArtifactMeta
+		arg3 io.Reader
+	}{arg1, arg2, arg3})
+	stub := fake.CreateFileArtifactFromReaderStub
+	fakeReturns := fake.createFileArtifactFromReaderReturns
+	fake.recordInvocation("CreateFileArtifactFromReader", []interface{}{arg1, arg2, arg3})
+	fake.createFileArtifactFromReaderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeArtifactService) CreateFileArtifactFromReaderCallCount() int {
+	fake.createFileArtifactFromReaderMutex.RLock()
+	defer fake.createFileArtifactFromReaderMutex.RUnlock()
+	return len(fake.createFileArtifactFromReaderArgsForCall)
+}
+
+func (fake *FakeArtifactService) CreateFileArtifactFromReaderCalls(stub func(context.Context, server.FileArtifactMeta, io.Reader) (types.Artifact, error)) {
+	fake.createFileArtifactFromReaderMutex.Lock()
+	defer fake.createFileArtifactFromReaderMutex.Unlock()
+	fake.CreateFileArtifactFromReaderStub = stub
+}
+
+func (fake *FakeArtifactService) CreateFileArtifactFromReaderArgsForCall(i int) (context.Context, server.FileArtifactMeta, io.Reader) {
+	fake.createFileArtifactFromReaderMutex.RLock()
+	defer fake.createFileArtifactFromReaderMutex.RUnlock()
+	argsForCall := fake.createFileArtifactFromReaderArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeArtifactService) CreateFileArtifactFromReaderReturns(result1 types.Artifact, result2 error) {
+	fake.createFileArtifactFromReaderMutex.Lock()
+	defer fake.createFileArtifactFromReaderMutex.Unlock()
+	fake.CreateFileArtifactFromReaderStub = nil
+	fake.createFileArtifactFromReaderReturns = struct {
+		result1 types.Artifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArtifactService) CreateFileArtifactFromReaderReturnsOnCall(i int, result1 types.Artifact, result2 error) {
+	fake.createFileArtifactFromReaderMutex.Lock()
+	defer fake.createFileArtifactFromReaderMutex.Unlock()
+	fake.CreateFileArtifactFromReaderStub = nil
+	if fake.createFileArtifactFromReaderReturnsOnCall == nil {
+		fake.createFileArtifactFromReaderReturnsOnCall = make(map[int]struct {
+			result1 types.Artifact
+			result2 error
+		})
+	}
+	fake.createFileArtifactFromReaderReturnsOnCall[i] = struct {
+		result1 types.Artifact
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeArtifactService) CreateFileArtifactFromURI(arg1 string, arg2 string, arg3 string, arg4 string, arg5 *string) types.Artifact {
 	fake.createFileArtifactFromURIMutex.Lock()
 	ret, specificReturn := fake.createFileArtifactFromURIReturnsOnCall[len(fake.createFileArtifactFromURIArgsForCall)]
@@ -885,6 +1203,70 @@ func (fake *FakeArtifactService) CreateTextArtifactReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeArtifactService) Delete(arg1 context.Context, arg2 string, arg3 string, arg4 string) error {
+	fake.deleteMutex.Lock()
+	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DeleteStub
+	fakeReturns := fake.deleteReturns
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2, arg3, arg4})
+	fake.deleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeArtifactService) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *FakeArtifactService) DeleteCalls(stub func(context.Context, string, string, string) error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
+func (fake *FakeArtifactService) DeleteArgsForCall(i int) (context.Context, string, string, string) {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeArtifactService) DeleteReturns(result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	fake.deleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeArtifactService) DeleteReturnsOnCall(i int, result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	if fake.deleteReturnsOnCall == nil {
+		fake.deleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeArtifactService) Exists(arg1 context.Context, arg2 string, arg3 string, arg4 string) (bool, error) {
 	fake.existsMutex.Lock()
 	ret, specificReturn := fake.existsReturnsOnCall[len(fake.existsArgsForCall)]
@@ -1140,6 +1522,133 @@ func (fake *FakeArtifactService) GetMimeTypeFromExtensionReturnsOnCall(i int, re
 	}{result1}
 }
 
+func (fake *FakeArtifactService) GetURL(arg1 string, arg2 string, arg3 string) string {
+	fake.getURLMutex.Lock()
+	ret, specificReturn := fake.getURLReturnsOnCall[len(fake.getURLArgsForCall)]
+	fake.getURLArgsForCall = append(fake.getURLArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetURLStub
+	fakeReturns := fake.getURLReturns
+	fake.recordInvocation("GetURL", []interface{}{arg1, arg2, arg3})
+	fake.getURLMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeArtifactService) GetURLCallCount() int {
+	fake.getURLMutex.RLock()
+	defer fake.getURLMutex.RUnlock()
+	return len(fake.getURLArgsForCall)
+}
+
+func (fake *FakeArtifactService) GetURLCalls(stub func(string, string, string) string) {
+	fake.getURLMutex.Lock()
+	defer fake.getURLMutex.Unlock()
+	fake.GetURLStub = stub
+}
+
+func (fake *FakeArtifactService) GetURLArgsForCall(i int) (string, string, string) {
+	fake.getURLMutex.RLock()
+	defer fake.getURLMutex.RUnlock()
+	argsForCall := fake.getURLArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeArtifactService) GetURLReturns(result1 string) {
+	fake.getURLMutex.Lock()
+	defer fake.getURLMutex.Unlock()
+	fake.GetURLStub = nil
+	fake.getURLReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeArtifactService) GetURLReturnsOnCall(i int, result1 string) {
+	fake.getURLMutex.Lock()
+	defer fake.getURLMutex.Unlock()
+	fake.GetURLStub = nil
+	if fake.getURLReturnsOnCall == nil {
+		fake.getURLReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.getURLReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeArtifactService) ListStoredArtifacts(arg1 context.Context) ([]server.ArtifactMetadata, error) {
+	fake.listStoredArtifactsMutex.Lock()
+	ret, specificReturn := fake.listStoredArtifactsReturnsOnCall[len(fake.listStoredArtifactsArgsForCall)]
+	fake.listStoredArtifactsArgsForCall = append(fake.listStoredArtifactsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ListStoredArtifactsStub
+	fakeReturns := fake.listStoredArtifactsReturns
+	fake.recordInvocation("ListStoredArtifacts", []interface{}{arg1})
+	fake.listStoredArtifactsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeArtifactService) ListStoredArtifactsCallCount() int {
+	fake.listStoredArtifactsMutex.RLock()
+	defer fake.listStoredArtifactsMutex.RUnlock()
+	return len(fake.listStoredArtifactsArgsForCall)
+}
+
+func (fake *FakeArtifactService) ListStoredArtifactsCalls(stub func(context.Context) ([]server.ArtifactMetadata, error)) {
+	fake.listStoredArtifactsMutex.Lock()
+	defer fake.listStoredArtifactsMutex.Unlock()
+	fake.ListStoredArtifactsStub = stub
+}
+
+func (fake *FakeArtifactService) ListStoredArtifactsArgsForCall(i int) context.Context {
+	fake.listStoredArtifactsMutex.RLock()
+	defer fake.listStoredArtifactsMutex.RUnlock()
+	argsForCall := fake.listStoredArtifactsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeArtifactService) ListStoredArtifactsReturns(result1 []server.ArtifactMetadata, result2 error) {
+	fake.listStoredArtifactsMutex.Lock()
+	defer fake.listStoredArtifactsMutex.Unlock()
+	fake.ListStoredArtifactsStub = nil
+	fake.listStoredArtifactsReturns = struct {
+		result1 []server.ArtifactMetadata
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArtifactService) ListStoredArtifactsReturnsOnCall(i int, result1 []server.ArtifactMetadata, result2 error) {
+	fake.listStoredArtifactsMutex.Lock()
+	defer fake.listStoredArtifactsMutex.Unlock()
+	fake.ListStoredArtifactsStub = nil
+	if fake.listStoredArtifactsReturnsOnCall == nil {
+		fake.listStoredArtifactsReturnsOnCall = make(map[int]struct {
+			result1 []server.ArtifactMetadata
+			result2 error
+		})
+	}
+	fake.listStoredArtifactsReturnsOnCall[i] = struct {
+		result1 []server.ArtifactMetadata
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeArtifactService) Retrieve(arg1 context.Context, arg2 string, arg3 string, arg4 string) (io.ReadCloser, error) {
 	fake.retrieveMutex.Lock()
 	ret, specificReturn := fake.retrieveReturnsOnCall[len(fake.retrieveArgsForCall)]
@@ -1207,6 +1716,77 @@ func (fake *FakeArtifactService) RetrieveReturnsOnCall(i int, result1 io.ReadClo
 	}{result1, result2}
 }
 
+func (fake *FakeArtifactService) StoreFile(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 io.Reader) (string, string, error) {
+	fake.storeFileMutex.Lock()
+	ret, specificReturn := fake.storeFileReturnsOnCall[len(fake.storeFileArgsForCall)]
+	fake.storeFileArgsForCall = append(fake.storeFileArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 io.Reader
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.StoreFileStub
+	fakeReturns := fake.storeFileReturns
+	fake.recordInvocation("StoreFile", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.storeFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeArtifactService) StoreFileCallCount() int {
+	fake.storeFileMutex.RLock()
+	defer fake.storeFileMutex.RUnlock()
+	return len(fake.storeFileArgsForCall)
+}
+
+func (fake *FakeArtifactService) StoreFileCalls(stub func(context.Context, string, string, string, io.Reader) (string, string, error)) {
+	fake.storeFileMutex.Lock()
+	defer fake.storeFileMutex.Unlock()
+	fake.StoreFileStub = stub
+}
+
+func (fake *FakeArtifactService) StoreFileArgsForCall(i int) (context.Context, string, string, string, io.Reader) {
+	fake.storeFileMutex.RLock()
+	defer fake.storeFileMutex.RUnlock()
+	argsForCall := fake.storeFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeArtifactService) StoreFileReturns(result1 string, result2 string, result3 error) {
+	fake.storeFileMutex.Lock()
+	defer fake.storeFileMutex.Unlock()
+	fake.StoreFileStub = nil
+	fake.storeFileReturns = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeArtifactService) StoreFileReturnsOnCall(i int, result1 string, result2 string, result3 error) {
+	fake.storeFileMutex.Lock()
+	defer fake.storeFileMutex.Unlock()
+	fake.StoreFileStub = nil
+	if fake.storeFileReturnsOnCall == nil {
+		fake.storeFileReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 string
+			result3 error
+		})
+	}
+	fake.storeFileReturnsOnCall[i] = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeArtifactService) ValidateArtifact(arg1 types.Artifact) error {
 	fake.validateArtifactMutex.Lock()
 	ret, specificReturn := fake.validateArtifactReturnsOnCall[len(fake.validateArtifactArgsForCall)]
@@ -1268,6 +1848,71 @@ func (fake *FakeArtifactService) ValidateArtifactReturnsOnCall(i int, result1 er
 	}{result1}
 }
 
+func (fake *FakeArtifactService) Writer(arg1 context.Context, arg2 server.FileArtifactMeta) (server.ArtifactWriter, error) {
+	fake.writerMutex.Lock()
+	ret, specificReturn := fake.writerReturnsOnCall[len(fake.writerArgsForCall)]
+	fake.writerArgsForCall = append(fake.writerArgsForCall, struct {
+		arg1 context.Context
+		arg2 server.FileArtifactMeta
+	}{arg1, arg2})
+	stub := fake.WriterStub
+	fakeReturns := fake.writerReturns
+	fake.recordInvocation("Writer", []interface{}{arg1, arg2})
+	fake.writerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeArtifactService) WriterCallCount() int {
+	fake.writerMutex.RLock()
+	defer fake.writerMutex.RUnlock()
+	return len(fake.writerArgsForCall)
+}
+
+func (fake *FakeArtifactService) WriterCalls(stub func(context.Context, server.FileArtifactMeta) (server.ArtifactWriter, error)) {
+	fake.writerMutex.Lock()
+	defer fake.writerMutex.Unlock()
+	fake.WriterStub = stub
+}
+
+func (fake *FakeArtifactService) WriterArgsForCall(i int) (context.Context, server.FileArtifactMeta) {
+	fake.writerMutex.RLock()
+	defer fake.writerMutex.RUnlock()
+	argsForCall := fake.writerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeArtifactService) WriterReturns(result1 server.ArtifactWriter, result2 error) {
+	fake.writerMutex.Lock()
+	defer fake.writerMutex.Unlock()
+	fake.WriterStub = nil
+	fake.writerReturns = struct {
+		result1 server.ArtifactWriter
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArtifactService) WriterReturnsOnCall(i int, result1 server.ArtifactWriter, result2 error) {
+	fake.writerMutex.Lock()
+	defer fake.writerMutex.Unlock()
+	fake.WriterStub = nil
+	if fake.writerReturnsOnCall == nil {
+		fake.writerReturnsOnCall = make(map[int]struct {
+			result1 server.ArtifactWriter
+			result2 error
+		})
+	}
+	fake.writerReturnsOnCall[i] = struct {
+		result1 server.ArtifactWriter
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeArtifactService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1275,6 +1920,10 @@ func (fake *FakeArtifactService) Invocations() map[string][][]interface{} {
 	defer fake.addArtifactToTaskMutex.RUnlock()
 	fake.addArtifactsToTaskMutex.RLock()
 	defer fake.addArtifactsToTaskMutex.RUnlock()
+	fake.checksumMutex.RLock()
+	defer fake.checksumMutex.RUnlock()
+	fake.cleanupArtifactsOverQuotaMutex.RLock()
+	defer fake.cleanupArtifactsOverQuotaMutex.RUnlock()
 	fake.cleanupExpiredArtifactsMutex.RLock()
 	defer fake.cleanupExpiredArtifactsMutex.RUnlock()
 	fake.cleanupOldestArtifactsMutex.RLock()
@@ -1285,6 +1934,8 @@ func (fake *FakeArtifactService) Invocations() map[string][][]interface{} {
 	defer fake.createDataArtifactMutex.RUnlock()
 	fake.createFileArtifactMutex.RLock()
 	defer fake.createFileArtifactMutex.RUnlock()
+	fake.createFileArtifactFromReaderMutex.RLock()
+	defer fake.createFileArtifactFromReaderMutex.RUnlock()
 	fake.createFileArtifactFromURIMutex.RLock()
 	defer fake.createFileArtifactFromURIMutex.RUnlock()
 	fake.createMultiPartArtifactMutex.RLock()
@@ -1293,6 +1944,8 @@ func (fake *FakeArtifactService) Invocations() map[string][][]interface{} {
 	defer fake.createTaskArtifactUpdateEventMutex.RUnlock()
 	fake.createTextArtifactMutex.RLock()
 	defer fake.createTextArtifactMutex.RUnlock()
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
 	fake.existsMutex.RLock()
 	defer fake.existsMutex.RUnlock()
 	fake.getArtifactByIDMutex.RLock()
@@ -1301,10 +1954,18 @@ func (fake *FakeArtifactService) Invocations() map[string][][]interface{} {
 	defer fake.getArtifactsByTypeMutex.RUnlock()
 	fake.getMimeTypeFromExtensionMutex.RLock()
 	defer fake.getMimeTypeFromExtensionMutex.RUnlock()
+	fake.getURLMutex.RLock()
+	defer fake.getURLMutex.RUnlock()
+	fake.listStoredArtifactsMutex.RLock()
+	defer fake.listStoredArtifactsMutex.RUnlock()
 	fake.retrieveMutex.RLock()
 	defer fake.retrieveMutex.RUnlock()
+	fake.storeFileMutex.RLock()
+	defer fake.storeFileMutex.RUnlock()
 	fake.validateArtifactMutex.RLock()
 	defer fake.validateArtifactMutex.RUnlock()
+	fake.writerMutex.RLock()
+	defer fake.writerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
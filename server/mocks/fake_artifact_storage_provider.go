@@ -11,6 +11,22 @@ import (
 )
 
 type FakeArtifactStorageProvider struct {
+	ChecksumStub        func(context.Context, string, string, string) (string, error)
+	checksumMutex       sync.RWMutex
+	checksumArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	checksumReturns struct {
+		result1 string
+		result2 error
+	}
+	checksumReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	CleanupExpiredArtifactsStub        func(context.Context, time.Duration) (int, error)
 	cleanupExpiredArtifactsMutex       sync.RWMutex
 	cleanupExpiredArtifactsArgsForCall []struct {
@@ -92,6 +108,19 @@ type FakeArtifactStorageProvider struct {
 	getURLReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ListArtifactsStub        func(context.Context) ([]server.ArtifactMetadata, error)
+	listArtifactsMutex       sync.RWMutex
+	listArtifactsArgsForCall []struct {
+		arg1 context.Context
+	}
+	listArtifactsReturns struct {
+		result1 []server.ArtifactMetadata
+		result2 error
+	}
+	listArtifactsReturnsOnCall map[int]struct {
+		result1 []server.ArtifactMetadata
+		result2 error
+	}
 	RetrieveStub        func(context.Context, string, string, string) (io.ReadCloser, error)
 	retrieveMutex       sync.RWMutex
 	retrieveArgsForCall []struct {
@@ -129,6 +158,73 @@ type FakeArtifactStorageProvider struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeArtifactStorageProvider) Checksum(arg1 context.Context, arg2 string, arg3 string, arg4 string) (string, error) {
+	fake.checksumMutex.Lock()
+	ret, specificReturn := fake.checksumReturnsOnCall[len(fake.checksumArgsForCall)]
+	fake.checksumArgsForCall = append(fake.checksumArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ChecksumStub
+	fakeReturns := fake.checksumReturns
+	fake.recordInvocation("Checksum", []interface{}{arg1, arg2, arg3, arg4})
+	fake.checksumMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeArtifactStorageProvider) ChecksumCallCount() int {
+	fake.checksumMutex.RLock()
+	defer fake.checksumMutex.RUnlock()
+	return len(fake.checksumArgsForCall)
+}
+
+func (fake *FakeArtifactStorageProvider) ChecksumCalls(stub func(context.Context, string, string, string) (string, error)) {
+	fake.checksumMutex.Lock()
+	defer fake.checksumMutex.Unlock()
+	fake.ChecksumStub = stub
+}
+
+func (fake *FakeArtifactStorageProvider) ChecksumArgsForCall(i int) (context.Context, string, string, string) {
+	fake.checksumMutex.RLock()
+	defer fake.checksumMutex.RUnlock()
+	argsForCall := fake.checksumArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeArtifactStorageProvider) ChecksumReturns(result1 string, result2 error) {
+	fake.checksumMutex.Lock()
+	defer fake.checksumMutex.Unlock()
+	fake.ChecksumStub = nil
+	fake.checksumReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArtifactStorageProvider) ChecksumReturnsOnCall(i int, result1 string, result2 error) {
+	fake.checksumMutex.Lock()
+	defer fake.checksumMutex.Unlock()
+	fake.ChecksumStub = nil
+	if fake.checksumReturnsOnCall == nil {
+		fake.checksumReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.checksumReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeArtifactStorageProvider) CleanupExpiredArtifacts(arg1 context.Context, arg2 time.Duration) (int, error) {
 	fake.cleanupExpiredArtifactsMutex.Lock()
 	ret, specificReturn := fake.cleanupExpiredArtifactsReturnsOnCall[len(fake.cleanupExpiredArtifactsArgsForCall)]
@@ -506,6 +602,70 @@ func (fake *FakeArtifactStorageProvider) GetURLReturnsOnCall(i int, result1 stri
 	}{result1}
 }
 
+func (fake *FakeArtifactStorageProvider) ListArtifacts(arg1 context.Context) ([]server.ArtifactMetadata, error) {
+	fake.listArtifactsMutex.Lock()
+	ret, specificReturn := fake.listArtifactsReturnsOnCall[len(fake.listArtifactsArgsForCall)]
+	fake.listArtifactsArgsForCall = append(fake.listArtifactsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ListArtifactsStub
+	fakeReturns := fake.listArtifactsReturns
+	fake.recordInvocation("ListArtifacts", []interface{}{arg1})
+	fake.listArtifactsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeArtifactStorageProvider) ListArtifactsCallCount() int {
+	fake.listArtifactsMutex.RLock()
+	defer fake.listArtifactsMutex.RUnlock()
+	return len(fake.listArtifactsArgsForCall)
+}
+
+func (fake *FakeArtifactStorageProvider) ListArtifactsCalls(stub func(context.Context) ([]server.ArtifactMetadata, error)) {
+	fake.listArtifactsMutex.Lock()
+	defer fake.listArtifactsMutex.Unlock()
+	fake.ListArtifactsStub = stub
+}
+
+func (fake *FakeArtifactStorageProvider) ListArtifactsArgsForCall(i int) context.Context {
+	fake.listArtifactsMutex.RLock()
+	defer fake.listArtifactsMutex.RUnlock()
+	argsForCall := fake.listArtifactsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeArtifactStorageProvider) ListArtifactsReturns(result1 []server.ArtifactMetadata, result2 error) {
+	fake.listArtifactsMutex.Lock()
+	defer fake.listArtifactsMutex.Unlock()
+	fake.ListArtifactsStub = nil
+	fake.listArtifactsReturns = struct {
+		result1 []server.ArtifactMetadata
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArtifactStorageProvider) ListArtifactsReturnsOnCall(i int, result1 []server.ArtifactMetadata, result2 error) {
+	fake.listArtifactsMutex.Lock()
+	defer fake.listArtifactsMutex.Unlock()
+	fake.ListArtifactsStub = nil
+	if fake.listArtifactsReturnsOnCall == nil {
+		fake.listArtifactsReturnsOnCall = make(map[int]struct {
+			result1 []server.ArtifactMetadata
+			result2 error
+		})
+	}
+	fake.listArtifactsReturnsOnCall[i] = struct {
+		result1 []server.ArtifactMetadata
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeArtifactStorageProvider) Retrieve(arg1 context.Context, arg2 string, arg3 string, arg4 string) (io.ReadCloser, error) {
 	fake.retrieveMutex.Lock()
 	ret, specificReturn := fake.retrieveReturnsOnCall[len(fake.retrieveArgsForCall)]
@@ -644,6 +804,8 @@ func (fake *FakeArtifactStorageProvider) StoreReturnsOnCall(i int, result1 strin
 func (fake *FakeArtifactStorageProvider) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.checksumMutex.RLock()
+	defer fake.checksumMutex.RUnlock()
 	fake.cleanupExpiredArtifactsMutex.RLock()
 	defer fake.cleanupExpiredArtifactsMutex.RUnlock()
 	fake.cleanupOldestArtifactsMutex.RLock()
@@ -656,6 +818,8 @@ func (fake *FakeArtifactStorageProvider) Invocations() map[string][][]interface{
 	defer fake.existsMutex.RUnlock()
 	fake.getURLMutex.RLock()
 	defer fake.getURLMutex.RUnlock()
+	fake.listArtifactsMutex.RLock()
+	defer fake.listArtifactsMutex.RUnlock()
 	fake.retrieveMutex.RLock()
 	defer fake.retrieveMutex.RUnlock()
 	fake.storeMutex.RLock()
@@ -0,0 +1,124 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/inference-gateway/adk/server"
+)
+
+type FakeEmbeddingsClient struct {
+	EmbedStub        func(context.Context, []string) ([][]float32, error)
+	embedMutex       sync.RWMutex
+	embedArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	embedReturns struct {
+		result1 [][]float32
+		result2 error
+	}
+	embedReturnsOnCall map[int]struct {
+		result1 [][]float32
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeEmbeddingsClient) Embed(arg1 context.Context, arg2 []string) ([][]float32, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.embedMutex.Lock()
+	ret, specificReturn := fake.embedReturnsOnCall[len(fake.embedArgsForCall)]
+	fake.embedArgsForCall = append(fake.embedArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.EmbedStub
+	fakeReturns := fake.embedReturns
+	fake.recordInvocation("Embed", []interface{}{arg1, arg2Copy})
+	fake.embedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeEmbeddingsClient) EmbedCallCount() int {
+	fake.embedMutex.RLock()
+	defer fake.embedMutex.RUnlock()
+	return len(fake.embedArgsForCall)
+}
+
+func (fake *FakeEmbeddingsClient) EmbedCalls(stub func(context.Context, []string) ([][]float32, error)) {
+	fake.embedMutex.Lock()
+	defer fake.embedMutex.Unlock()
+	fake.EmbedStub = stub
+}
+
+func (fake *FakeEmbeddingsClient) EmbedArgsForCall(i int) (context.Context, []string) {
+	fake.embedMutex.RLock()
+	defer fake.embedMutex.RUnlock()
+	argsForCall := fake.embedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeEmbeddingsClient) EmbedReturns(result1 [][]float32, result2 error) {
+	fake.embedMutex.Lock()
+	defer fake.embedMutex.Unlock()
+	fake.EmbedStub = nil
+	fake.embedReturns = struct {
+		result1 [][]float32
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEmbeddingsClient) EmbedReturnsOnCall(i int, result1 [][]float32, result2 error) {
+	fake.embedMutex.Lock()
+	defer fake.embedMutex.Unlock()
+	fake.EmbedStub = nil
+	if fake.embedReturnsOnCall == nil {
+		fake.embedReturnsOnCall = make(map[int]struct {
+			result1 [][]float32
+			result2 error
+		})
+	}
+	fake.embedReturnsOnCall[i] = struct {
+		result1 [][]float32
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEmbeddingsClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.embedMutex.RLock()
+	defer fake.embedMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeEmbeddingsClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ server.EmbeddingsClient = new(FakeEmbeddingsClient)
@@ -94,6 +94,32 @@ type FakeRedisClient struct {
 	lLenReturnsOnCall map[int]struct {
 		result1 *redis.IntCmd
 	}
+	LRangeStub        func(context.Context, string, int64, int64) *redis.StringSliceCmd
+	lRangeMutex       sync.RWMutex
+	lRangeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int64
+		arg4 int64
+	}
+	lRangeReturns struct {
+		result1 *redis.StringSliceCmd
+	}
+	lRangeReturnsOnCall map[int]struct {
+		result1 *redis.StringSliceCmd
+	}
+	MGetStub        func(context.Context, ...string) *redis.SliceCmd
+	mGetMutex       sync.RWMutex
+	mGetArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	mGetReturns struct {
+		result1 *redis.SliceCmd
+	}
+	mGetReturnsOnCall map[int]struct {
+		result1 *redis.SliceCmd
+	}
 	PingStub        func(context.Context) *redis.StatusCmd
 	pingMutex       sync.RWMutex
 	pingArgsForCall []struct {
@@ -584,6 +610,132 @@ func (fake *FakeRedisClient) LLenReturnsOnCall(i int, result1 *redis.IntCmd) {
 	}{result1}
 }
 
+func (fake *FakeRedisClient) LRange(arg1 context.Context, arg2 string, arg3 int64, arg4 int64) *redis.StringSliceCmd {
+	fake.lRangeMutex.Lock()
+	ret, specificReturn := fake.lRangeReturnsOnCall[len(fake.lRangeArgsForCall)]
+	fake.lRangeArgsForCall = append(fake.lRangeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int64
+		arg4 int64
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.LRangeStub
+	fakeReturns := fake.lRangeReturns
+	fake.recordInvocation("LRange", []interface{}{arg1, arg2, arg3, arg4})
+	fake.lRangeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRedisClient) LRangeCallCount() int {
+	fake.lRangeMutex.RLock()
+	defer fake.lRangeMutex.RUnlock()
+	return len(fake.lRangeArgsForCall)
+}
+
+func (fake *FakeRedisClient) LRangeCalls(stub func(context.Context, string, int64, int64) *redis.StringSliceCmd) {
+	fake.lRangeMutex.Lock()
+	defer fake.lRangeMutex.Unlock()
+	fake.LRangeStub = stub
+}
+
+func (fake *FakeRedisClient) LRangeArgsForCall(i int) (context.Context, string, int64, int64) {
+	fake.lRangeMutex.RLock()
+	defer fake.lRangeMutex.RUnlock()
+	argsForCall := fake.lRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeRedisClient) LRangeReturns(result1 *redis.StringSliceCmd) {
+	fake.lRangeMutex.Lock()
+	defer fake.lRangeMutex.Unlock()
+	fake.LRangeStub = nil
+	fake.lRangeReturns = struct {
+		result1 *redis.StringSliceCmd
+	}{result1}
+}
+
+func (fake *FakeRedisClient) LRangeReturnsOnCall(i int, result1 *redis.StringSliceCmd) {
+	fake.lRangeMutex.Lock()
+	defer fake.lRangeMutex.Unlock()
+	fake.LRangeStub = nil
+	if fake.lRangeReturnsOnCall == nil {
+		fake.lRangeReturnsOnCall = make(map[int]struct {
+			result1 *redis.StringSliceCmd
+		})
+	}
+	fake.lRangeReturnsOnCall[i] = struct {
+		result1 *redis.StringSliceCmd
+	}{result1}
+}
+
+func (fake *FakeRedisClient) MGet(arg1 context.Context, arg2 ...string) *redis.SliceCmd {
+	fake.mGetMutex.Lock()
+	ret, specificReturn := fake.mGetReturnsOnCall[len(fake.mGetArgsForCall)]
+	fake.mGetArgsForCall = append(fake.mGetArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2})
+	stub := fake.MGetStub
+	fakeReturns := fake.mGetReturns
+	fake.recordInvocation("MGet", []interface{}{arg1, arg2})
+	fake.mGetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRedisClient) MGetCallCount() int {
+	fake.mGetMutex.RLock()
+	defer fake.mGetMutex.RUnlock()
+	return len(fake.mGetArgsForCall)
+}
+
+func (fake *FakeRedisClient) MGetCalls(stub func(context.Context, ...string) *redis.SliceCmd) {
+	fake.mGetMutex.Lock()
+	defer fake.mGetMutex.Unlock()
+	fake.MGetStub = stub
+}
+
+func (fake *FakeRedisClient) MGetArgsForCall(i int) (context.Context, []string) {
+	fake.mGetMutex.RLock()
+	defer fake.mGetMutex.RUnlock()
+	argsForCall := fake.mGetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRedisClient) MGetReturns(result1 *redis.SliceCmd) {
+	fake.mGetMutex.Lock()
+	defer fake.mGetMutex.Unlock()
+	fake.MGetStub = nil
+	fake.mGetReturns = struct {
+		result1 *redis.SliceCmd
+	}{result1}
+}
+
+func (fake *FakeRedisClient) MGetReturnsOnCall(i int, result1 *redis.SliceCmd) {
+	fake.mGetMutex.Lock()
+	defer fake.mGetMutex.Unlock()
+	fake.MGetStub = nil
+	if fake.mGetReturnsOnCall == nil {
+		fake.mGetReturnsOnCall = make(map[int]struct {
+			result1 *redis.SliceCmd
+		})
+	}
+	fake.mGetReturnsOnCall[i] = struct {
+		result1 *redis.SliceCmd
+	}{result1}
+}
+
 func (fake *FakeRedisClient) Ping(arg1 context.Context) *redis.StatusCmd {
 	fake.pingMutex.Lock()
 	ret, specificReturn := fake.pingReturnsOnCall[len(fake.pingArgsForCall)]
@@ -904,6 +1056,10 @@ func (fake *FakeRedisClient) Invocations() map[string][][]interface{} {
 	defer fake.keysMutex.RUnlock()
 	fake.lLenMutex.RLock()
 	defer fake.lLenMutex.RUnlock()
+	fake.lRangeMutex.RLock()
+	defer fake.lRangeMutex.RUnlock()
+	fake.mGetMutex.RLock()
+	defer fake.mGetMutex.RUnlock()
 	fake.pingMutex.RLock()
 	defer fake.pingMutex.RUnlock()
 	fake.pipelineMutex.RLock()
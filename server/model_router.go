@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// routedModelContextKey carries the model selected for an agent run to its LLM calls
+const routedModelContextKey ContextKey = "routedModel"
+
+// ModelRouteRequest describes an agent run to the model router
+type ModelRouteRequest struct {
+	// Messages are the messages of the run, the last one being the message to respond to
+	Messages []types.Message
+	// MessageLength is the number of characters of text of the last message
+	MessageLength int
+	// HasImages reports whether the last message has image file parts
+	HasImages bool
+	// Tools are the names of the tools offered to the model
+	Tools []string
+	// Hint is the modelHint metadata of the last message, such as fast or smart
+	Hint string
+}
+
+// ModelRouter selects the model of an agent run from the request, so one agent can use a
+// cheaper or faster model when the request allows it
+type ModelRouter interface {
+	// RouteModel returns the model for the run, optionally prefixed with its provider as
+	// provider/model, or an empty string to use the configured model
+	RouteModel(ctx context.Context, request ModelRouteRequest) string
+}
+
+// ModelRouterFunc adapts a function to the ModelRouter interface
+type ModelRouterFunc func(ctx context.Context, request ModelRouteRequest) string
+
+// RouteModel calls f(ctx, request)
+func (f ModelRouterFunc) RouteModel(ctx context.Context, request ModelRouteRequest) string {
+	return f(ctx, request)
+}
+
+var _ ModelRouter = (*RuleModelRouter)(nil)
+
+// RuleModelRouter routes runs with the rules of the routing configuration. A hint names the
+// model explicitly and wins over the other rules, which are then checked in order: image
+// parts, long messages and offered tools.
+type RuleModelRouter struct {
+	config config.RoutingConfig
+}
+
+// NewRuleModelRouter creates a model router applying the routing configuration
+func NewRuleModelRouter(cfg config.RoutingConfig) *RuleModelRouter {
+	return &RuleModelRouter{config: cfg}
+}
+
+// RouteModel returns the model of the first matching rule, or an empty string when none matches
+func (r *RuleModelRouter) RouteModel(ctx context.Context, request ModelRouteRequest) string {
+	if model, ok := r.config.Hints[request.Hint]; ok && request.Hint != "" {
+		return model
+	}
+	if request.HasImages && r.config.VisionModel != "" {
+		return r.config.VisionModel
+	}
+	if r.config.LongMessageModel != "" && r.config.LongMessageLength > 0 && request.MessageLength >= r.config.LongMessageLength {
+		return r.config.LongMessageModel
+	}
+	if len(request.Tools) > 0 && r.config.ToolsModel != "" {
+		return r.config.ToolsModel
+	}
+	return ""
+}
+
+// newModelRouteRequest describes the run of the messages with the tools to a model router
+func newModelRouteRequest(messages []types.Message, tools []sdk.ChatCompletionTool) ModelRouteRequest {
+	request := ModelRouteRequest{Messages: messages}
+	for _, tool := range tools {
+		request.Tools = append(request.Tools, tool.Function.Name)
+	}
+	if len(messages) == 0 {
+		return request
+	}
+
+	last := messages[len(messages)-1]
+	for _, part := range last.Parts {
+		switch {
+		case part.Text != nil:
+			request.MessageLength += utf8.RuneCountInString(*part.Text)
+		case part.File != nil:
+			if acceptsMediaType([]string{"image/*"}, partMediaType(part)) {
+				request.HasImages = true
+			}
+		}
+	}
+	if last.Metadata != nil {
+		if hint, ok := (*last.Metadata)[types.ModelHintMetadataKey].(string); ok {
+			request.Hint = strings.TrimSpace(hint)
+		}
+	}
+	return request
+}
+
+// contextWithRoutedModel returns a copy of ctx carrying the model selected for the run
+func contextWithRoutedModel(ctx context.Context, model string) context.Context {
+	return context.WithValue(ctx, routedModelContextKey, model)
+}
+
+// routedModelFromContext returns the model selected for the run, if any
+func routedModelFromContext(ctx context.Context) string {
+	model, _ := ctx.Value(routedModelContextKey).(string)
+	return model
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// routeRecordingLLMClient records the model routed for each streaming call and answers it
+type routeRecordingLLMClient struct {
+	models []string
+}
+
+func (c *routeRecordingLLMClient) CreateChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (*sdk.CreateChatCompletionResponse, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *routeRecordingLLMClient) CreateStreamingChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+	c.models = append(c.models, routedModelFromContext(ctx))
+	responseChan := make(chan *sdk.CreateChatCompletionStreamResponse, 1)
+	responseChan <- &sdk.CreateChatCompletionStreamResponse{
+		Choices: []sdk.ChatCompletionStreamChoice{{Delta: sdk.ChatCompletionStreamResponseDelta{Content: "done"}, FinishReason: "stop"}},
+	}
+	close(responseChan)
+	return responseChan, make(chan error)
+}
+
+func TestRuleModelRouter_RouteModel(t *testing.T) {
+	router := NewRuleModelRouter(config.RoutingConfig{
+		Hints:             map[string]string{"fast": "openai/gpt-4o-mini", "smart": "openai/o3"},
+		VisionModel:       "openai/gpt-4o",
+		LongMessageModel:  "google/gemini-2.5-pro",
+		LongMessageLength: 100,
+		ToolsModel:        "anthropic/claude-sonnet-4",
+	})
+
+	tests := []struct {
+		name     string
+		request  ModelRouteRequest
+		expected string
+	}{
+		{name: "no rule matches", request: ModelRouteRequest{MessageLength: 10}, expected: ""},
+		{name: "hint", request: ModelRouteRequest{Hint: "fast", HasImages: true}, expected: "openai/gpt-4o-mini"},
+		{name: "unknown hint", request: ModelRouteRequest{Hint: "cheap"}, expected: ""},
+		{name: "images", request: ModelRouteRequest{HasImages: true, MessageLength: 500}, expected: "openai/gpt-4o"},
+		{name: "long message", request: ModelRouteRequest{MessageLength: 100, Tools: []string{"search"}}, expected: "google/gemini-2.5-pro"},
+		{name: "tools", request: ModelRouteRequest{Tools: []string{"search"}}, expected: "anthropic/claude-sonnet-4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, router.RouteModel(context.Background(), tt.request))
+		})
+	}
+}
+
+func TestNewModelRouteRequest(t *testing.T) {
+	pixels := "aGVsbG8="
+	messages := []types.Message{
+		{Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("an earlier, much longer message")}},
+		{
+			Role: types.RoleUser,
+			Parts: []types.Part{
+				types.CreateTextPart("héllo"),
+				types.CreateFilePart("cat.png", "image/png", &pixels, nil),
+			},
+			Metadata: &map[string]any{types.ModelHintMetadataKey: " smart "},
+		},
+	}
+	tools := []sdk.ChatCompletionTool{{Function: sdk.FunctionObject{Name: "search"}}}
+
+	request := newModelRouteRequest(messages, tools)
+	assert.Equal(t, 5, request.MessageLength)
+	assert.True(t, request.HasImages)
+	assert.Equal(t, []string{"search"}, request.Tools)
+	assert.Equal(t, "smart", request.Hint)
+	assert.Len(t, request.Messages, 2)
+}
+
+func TestOpenAICompatibleAgent_ModelRouter(t *testing.T) {
+	client := &routeRecordingLLMClient{}
+	agent, err := NewAgentBuilder(zap.NewNop()).
+		WithConfig(&config.AgentConfig{
+			MaxChatCompletionIterations: 1,
+			Routing:                     config.RoutingConfig{Hints: map[string]string{"fast": "openai/gpt-4o-mini"}},
+		}).
+		WithLLMClient(client).
+		Build()
+	require.NoError(t, err)
+
+	run := func(metadata map[string]any) {
+		message := types.Message{Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("hi")}}
+		if metadata != nil {
+			message.Metadata = &metadata
+		}
+		events, err := agent.RunWithStream(context.Background(), []types.Message{message})
+		require.NoError(t, err)
+		for range events {
+		}
+	}
+
+	run(map[string]any{types.ModelHintMetadataKey: "fast"})
+	run(nil)
+
+	routed, err := NewAgentBuilder(zap.NewNop()).
+		WithConfig(&config.AgentConfig{MaxChatCompletionIterations: 1}).
+		WithLLMClient(client).
+		WithModelRouter(ModelRouterFunc(func(ctx context.Context, request ModelRouteRequest) string {
+			return "custom-model"
+		})).
+		Build()
+	require.NoError(t, err)
+	events, err := routed.RunWithStream(context.Background(), []types.Message{{Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("hi")}}})
+	require.NoError(t, err)
+	for range events {
+	}
+
+	assert.Equal(t, []string{"openai/gpt-4o-mini", "", "custom-model"}, client.models)
+}
+
+func TestOpenAICompatibleLLMClient_RoutedModel(t *testing.T) {
+	type call struct {
+		provider string
+		model    string
+	}
+	var calls []call
+	llm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request sdk.CreateChatCompletionRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		calls = append(calls, call{provider: r.URL.Query().Get("provider"), model: request.Model})
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","object":"chat.completion","created":0,"model":"m","choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":"done"}}]}`))
+	}))
+	defer llm.Close()
+
+	client, err := NewOpenAICompatibleLLMClient(&config.AgentConfig{Provider: "openai", Model: "openai/gpt-4o", BaseURL: llm.URL}, zap.NewNop())
+	require.NoError(t, err)
+
+	for _, ctx := range []context.Context{
+		context.Background(),
+		contextWithRoutedModel(context.Background(), "gpt-4o-mini"),
+		contextWithRoutedModel(context.Background(), "Groq/llama-3.3-70b"),
+	} {
+		_, err := client.CreateChatCompletion(ctx, nil)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []call{
+		{provider: "openai", model: "gpt-4o"},
+		{provider: "openai", model: "gpt-4o-mini"},
+		{provider: "groq", model: "llama-3.3-70b"},
+	}, calls)
+}
@@ -0,0 +1,226 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+const defaultOpenAIModerationURL = "https://api.openai.com/v1/moderations"
+
+// ModerationResult is the verdict of a moderation endpoint on a piece of text
+type ModerationResult struct {
+	// Flagged is true when the text violates the endpoint's content policy
+	Flagged bool `json:"flagged"`
+
+	// Categories are the policy categories the text was flagged for, sorted
+	Categories []string `json:"categories,omitempty"`
+
+	// Scores are the endpoint's confidence per category, when it reports them
+	Scores map[string]float64 `json:"scores,omitempty"`
+}
+
+// Moderator checks text against a content policy
+type Moderator interface {
+	// Moderate returns the moderation verdict on the text
+	Moderate(ctx context.Context, text string) (*ModerationResult, error)
+}
+
+// NewModerator creates the moderator for the configured provider
+func NewModerator(cfg config.ModerationConfig, logger *zap.Logger) (Moderator, error) {
+	switch cfg.Provider {
+	case "", config.ModerationProviderOpenAI:
+		return NewOpenAIModerator(cfg, logger), nil
+	case config.ModerationProviderWebhook:
+		return NewWebhookModerator(cfg, logger)
+	default:
+		return nil, fmt.Errorf("unsupported moderation provider: %s", cfg.Provider)
+	}
+}
+
+var _ Moderator = (*OpenAIModerator)(nil)
+
+// OpenAIModerator implements Moderator with the OpenAI moderation API, or any endpoint
+// compatible with it
+type OpenAIModerator struct {
+	httpClient *http.Client
+	logger     *zap.Logger
+	url        string
+	apiKey     string
+	model      string
+}
+
+// NewOpenAIModerator creates a moderator calling the OpenAI moderation API with the configured
+// model and API key. The URL defaults to https://api.openai.com/v1/moderations.
+func NewOpenAIModerator(cfg config.ModerationConfig, logger *zap.Logger) *OpenAIModerator {
+	url := cfg.URL
+	if url == "" {
+		url = defaultOpenAIModerationURL
+	}
+	return &OpenAIModerator{
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		logger:     logger,
+		url:        url,
+		apiKey:     cfg.APIKey,
+		model:      cfg.Model,
+	}
+}
+
+// openAIModerationResponse is the body returned by the OpenAI moderation API
+type openAIModerationResponse struct {
+	Results []struct {
+		Flagged        bool               `json:"flagged"`
+		Categories     map[string]bool    `json:"categories"`
+		CategoryScores map[string]float64 `json:"category_scores"`
+	} `json:"results"`
+}
+
+// Moderate sends the text to the moderation API
+func (m *OpenAIModerator) Moderate(ctx context.Context, text string) (*ModerationResult, error) {
+	request := map[string]any{"input": text}
+	if m.model != "" {
+		request["model"] = m.model
+	}
+
+	var response openAIModerationResponse
+	if err := postModeration(ctx, m.httpClient, m.url, m.apiKey, request, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Results) == 0 {
+		return nil, fmt.Errorf("moderation response has no results")
+	}
+
+	result := response.Results[0]
+	moderation := &ModerationResult{Flagged: result.Flagged, Scores: result.CategoryScores}
+	for category, flagged := range result.Categories {
+		if flagged {
+			moderation.Categories = append(moderation.Categories, category)
+		}
+	}
+	sort.Strings(moderation.Categories)
+
+	m.logger.Debug("moderated text",
+		zap.Bool("flagged", moderation.Flagged),
+		zap.Strings("categories", moderation.Categories))
+	return moderation, nil
+}
+
+var _ Moderator = (*WebhookModerator)(nil)
+
+// WebhookModerator implements Moderator with a custom webhook. The webhook receives
+// {"text": "..."} and responds with a ModerationResult:
+//
+//	{"flagged": true, "categories": ["harassment"], "scores": {"harassment": 0.91}}
+type WebhookModerator struct {
+	httpClient *http.Client
+	logger     *zap.Logger
+	url        string
+	apiKey     string
+}
+
+// NewWebhookModerator creates a moderator posting text to the configured webhook URL, with
+// the API key as a bearer token when set
+func NewWebhookModerator(cfg config.ModerationConfig, logger *zap.Logger) (*WebhookModerator, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("moderation webhook url is required")
+	}
+	return &WebhookModerator{
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		logger:     logger,
+		url:        cfg.URL,
+		apiKey:     cfg.APIKey,
+	}, nil
+}
+
+// Moderate sends the text to the webhook
+func (m *WebhookModerator) Moderate(ctx context.Context, text string) (*ModerationResult, error) {
+	var result ModerationResult
+	if err := postModeration(ctx, m.httpClient, m.url, m.apiKey, map[string]any{"text": text}, &result); err != nil {
+		return nil, err
+	}
+	sort.Strings(result.Categories)
+
+	m.logger.Debug("moderated text",
+		zap.Bool("flagged", result.Flagged),
+		zap.Strings("categories", result.Categories))
+	return &result, nil
+}
+
+// postModeration posts a JSON request to a moderation endpoint and decodes its response
+func postModeration(ctx context.Context, client *http.Client, url, apiKey string, request, response any) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to encode moderation request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create moderation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send moderation request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read moderation response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("moderation request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	if err := json.Unmarshal(data, response); err != nil {
+		return fmt.Errorf("failed to decode moderation response: %w", err)
+	}
+	return nil
+}
+
+// NewModerationFilter creates a guardrail filter that sends text to the moderator. With the
+// flag policy, flagged text passes and the moderation result is recorded in the message and
+// task metadata, as is a failed moderation request. With the fail policy, flagged text is
+// blocked, and so is text the moderator could not check.
+func NewModerationFilter(moderator Moderator, policy string) GuardrailFilter {
+	action := GuardrailActionFlag
+	if policy == config.ModerationPolicyFail {
+		action = GuardrailActionBlock
+	}
+
+	return NewGuardrailFilter("moderation", func(ctx context.Context, text string) (GuardrailResult, error) {
+		if strings.TrimSpace(text) == "" {
+			return GuardrailResult{Action: GuardrailActionAllow}, nil
+		}
+
+		result, err := moderator.Moderate(ctx, text)
+		if err != nil {
+			if action == GuardrailActionBlock {
+				return GuardrailResult{}, err
+			}
+			return GuardrailResult{Action: GuardrailActionFlag, Reason: "it could not be moderated", Details: err.Error()}, nil
+		}
+		if !result.Flagged {
+			return GuardrailResult{Action: GuardrailActionAllow}, nil
+		}
+
+		reason := "it was flagged by content moderation"
+		if len(result.Categories) > 0 {
+			reason = fmt.Sprintf("it was flagged by content moderation for %s", strings.Join(result.Categories, ", "))
+		}
+		return GuardrailResult{Action: action, Reason: reason, Details: result}, nil
+	})
+}
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+type moderatorFunc func(ctx context.Context, text string) (*ModerationResult, error)
+
+func (f moderatorFunc) Moderate(ctx context.Context, text string) (*ModerationResult, error) {
+	return f(ctx, text)
+}
+
+func TestOpenAIModerator_Moderate(t *testing.T) {
+	var request map[string]any
+	var authorization string
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		_, _ = w.Write([]byte(`{"id":"modr-1","model":"omni-moderation-latest","results":[{
+			"flagged": true,
+			"categories": {"violence": true, "harassment": true, "sexual": false},
+			"category_scores": {"violence": 0.92, "harassment": 0.81, "sexual": 0.01}
+		}]}`))
+	}))
+	defer httpServer.Close()
+
+	moderator := NewOpenAIModerator(config.ModerationConfig{
+		URL:     httpServer.URL,
+		APIKey:  "secret",
+		Model:   "omni-moderation-latest",
+		Timeout: time.Second,
+	}, zap.NewNop())
+
+	result, err := moderator.Moderate(context.Background(), "some text")
+	require.NoError(t, err)
+	assert.True(t, result.Flagged)
+	assert.Equal(t, []string{"harassment", "violence"}, result.Categories)
+	assert.Equal(t, 0.92, result.Scores["violence"])
+
+	assert.Equal(t, "Bearer secret", authorization)
+	assert.Equal(t, map[string]any{"input": "some text", "model": "omni-moderation-latest"}, request)
+}
+
+func TestWebhookModerator_Moderate(t *testing.T) {
+	status := http.StatusOK
+	var request map[string]any
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"flagged": true, "categories": ["spam"]}`))
+	}))
+	defer httpServer.Close()
+
+	moderator, err := NewWebhookModerator(config.ModerationConfig{URL: httpServer.URL, Timeout: time.Second}, zap.NewNop())
+	require.NoError(t, err)
+
+	result, err := moderator.Moderate(context.Background(), "buy now")
+	require.NoError(t, err)
+	assert.Equal(t, &ModerationResult{Flagged: true, Categories: []string{"spam"}}, result)
+	assert.Equal(t, map[string]any{"text": "buy now"}, request)
+
+	status = http.StatusBadGateway
+	_, err = moderator.Moderate(context.Background(), "buy now")
+	assert.ErrorContains(t, err, "moderation request failed with status 502")
+
+	_, err = NewWebhookModerator(config.ModerationConfig{}, zap.NewNop())
+	assert.EqualError(t, err, "moderation webhook url is required")
+}
+
+func TestModerationFilter(t *testing.T) {
+	ctx := context.Background()
+	flagged := moderatorFunc(func(ctx context.Context, text string) (*ModerationResult, error) {
+		if strings.Contains(text, "threat") {
+			return &ModerationResult{Flagged: true, Categories: []string{"violence"}}, nil
+		}
+		return &ModerationResult{}, nil
+	})
+	failing := moderatorFunc(func(ctx context.Context, text string) (*ModerationResult, error) {
+		return nil, errors.New("moderation service unavailable")
+	})
+
+	tests := []struct {
+		name      string
+		moderator Moderator
+		policy    string
+		text      string
+		expected  GuardrailResult
+		expectErr bool
+	}{
+		{
+			name:      "allows unflagged text",
+			moderator: flagged,
+			policy:    config.ModerationPolicyFail,
+			text:      "hello",
+			expected:  GuardrailResult{Action: GuardrailActionAllow},
+		},
+		{
+			name:      "flags flagged text with the flag policy",
+			moderator: flagged,
+			policy:    config.ModerationPolicyFlag,
+			text:      "a threat",
+			expected: GuardrailResult{
+				Action:  GuardrailActionFlag,
+				Reason:  "it was flagged by content moderation for violence",
+				Details: &ModerationResult{Flagged: true, Categories: []string{"violence"}},
+			},
+		},
+		{
+			name:      "blocks flagged text with the fail policy",
+			moderator: flagged,
+			policy:    config.ModerationPolicyFail,
+			text:      "a threat",
+			expected: GuardrailResult{
+				Action:  GuardrailActionBlock,
+				Reason:  "it was flagged by content moderation for violence",
+				Details: &ModerationResult{Flagged: true, Categories: []string{"violence"}},
+			},
+		},
+		{
+			name:      "flags text that could not be moderated with the flag policy",
+			moderator: failing,
+			policy:    config.ModerationPolicyFlag,
+			text:      "hello",
+			expected:  GuardrailResult{Action: GuardrailActionFlag, Reason: "it could not be moderated", Details: "moderation service unavailable"},
+		},
+		{
+			name:      "fails on text that could not be moderated with the fail policy",
+			moderator: failing,
+			policy:    config.ModerationPolicyFail,
+			text:      "hello",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewModerationFilter(tt.moderator, tt.policy).Check(ctx, tt.text)
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestA2AServer_Moderation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Text string `json:"text"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		var result ModerationResult
+		if strings.Contains(request.Text, "idiot") {
+			result = ModerationResult{Flagged: true, Categories: []string{"harassment"}}
+		}
+		_ = json.NewEncoder(w).Encode(result)
+	}))
+	defer httpServer.Close()
+
+	newRouter := func(policy string) *gin.Engine {
+		cfg := &config.Config{
+			ModerationConfig: config.ModerationConfig{
+				Enable:   true,
+				Provider: config.ModerationProviderWebhook,
+				URL:      httpServer.URL,
+				Timeout:  time.Second,
+				Policy:   policy,
+				Input:    true,
+			},
+		}
+		s := NewA2AServer(cfg, zap.NewNop(), nil)
+		s.SetAgentCard(types.AgentCard{Name: "agent"})
+		return s.setupRouter(cfg)
+	}
+
+	send := func(router *gin.Engine, text string) types.Task {
+		body, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      "req-1",
+			"method":  "message/send",
+			"params": map[string]any{
+				"message": map[string]any{
+					"kind":      "message",
+					"messageId": "msg-1",
+					"role":      "user",
+					"parts":     []map[string]any{{"kind": "text", "text": text}},
+				},
+			},
+		})
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", bytes.NewReader(body)))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Result types.Task `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Result
+	}
+
+	findings := func(task types.Task) []GuardrailFinding {
+		require.NotNil(t, task.Metadata)
+		return taskFindings((*task.Metadata)[types.GuardrailMetadataKey])
+	}
+
+	t.Run("flag policy records flagged messages in task metadata", func(t *testing.T) {
+		router := newRouter(config.ModerationPolicyFlag)
+
+		task := send(router, "You are an idiot, where is my order?")
+		assert.Equal(t, types.TaskStateSubmitted, task.Status.State)
+		recorded := findings(task)
+		require.Len(t, recorded, 1)
+		assert.Equal(t, "moderation", recorded[0].Filter)
+		assert.Equal(t, GuardrailActionFlag, recorded[0].Action)
+		assert.Equal(t, "msg-1", recorded[0].MessageID)
+		assert.Equal(t, "it was flagged by content moderation for harassment", recorded[0].Reason)
+
+		clean := send(router, "Where is my order?")
+		assert.Equal(t, types.TaskStateSubmitted, clean.Status.State)
+		if clean.Metadata != nil {
+			assert.NotContains(t, *clean.Metadata, types.GuardrailMetadataKey)
+		}
+	})
+
+	t.Run("fail policy rejects flagged messages", func(t *testing.T) {
+		router := newRouter(config.ModerationPolicyFail)
+
+		task := send(router, "You are an idiot")
+		assert.Equal(t, types.TaskStateRejected, task.Status.State)
+		require.NotNil(t, task.Status.Message)
+		assert.Equal(t, "Your message was blocked: it was flagged by content moderation for harassment.", *task.Status.Message.Parts[0].Text)
+		recorded := findings(task)
+		require.Len(t, recorded, 1)
+		assert.Equal(t, GuardrailActionBlock, recorded[0].Action)
+	})
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	otel "github.com/inference-gateway/adk/server/otel"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// namedAgentPathPrefix is the path under which named agents are mounted
+const namedAgentPathPrefix = "/agents/"
+
+// namedAgentNamePattern restricts agent names to a single URL path segment
+var namedAgentNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// namedAgent is an agent hosted next to the main agent of a server
+type namedAgent struct {
+	name      string
+	agent     OpenAICompatibleAgent
+	agentCard types.AgentCard
+}
+
+// NamedAgentPath returns the path of the A2A endpoint of a named agent, relative to the server
+func NamedAgentPath(name string) string {
+	return namedAgentPathPrefix + name + "/a2a"
+}
+
+// buildNamedAgent creates the server of a named agent. It shares the configuration, logger
+// and telemetry of the main server, and has its own task manager, default task handlers and
+// agent card. Its tasks are kept in memory.
+func (b *A2AServerBuilderImpl) buildNamedAgent(named namedAgent, telemetry otel.OpenTelemetry) (*A2AServerImpl, error) {
+	if !namedAgentNamePattern.MatchString(named.name) {
+		return nil, fmt.Errorf("invalid agent name '%s': use lowercase letters, digits, '-' and '_'", named.name)
+	}
+	if named.agent == nil {
+		return nil, fmt.Errorf("agent '%s' has no agent configured", named.name)
+	}
+
+	cfg := b.cfg
+	cfg.AgentName = named.agentCard.Name
+	cfg.AgentDescription = named.agentCard.Description
+	cfg.AgentVersion = named.agentCard.Version
+	if cfg.QueueConfig.Provider != "" && cfg.QueueConfig.Provider != "memory" {
+		b.logger.Warn("named agents keep their tasks in memory",
+			zap.String("agent", named.name),
+			zap.String("provider", cfg.QueueConfig.Provider))
+	}
+	cfg.QueueConfig.Provider = "memory"
+	cfg.ReloadConfig.Enable = false
+
+	logger := b.logger.With(zap.String("agent", named.name))
+	server := NewA2AServer(&cfg, logger, telemetry)
+	server.SetAgent(named.agent)
+
+	bgHandler := NewDefaultBackgroundTaskHandler(logger, named.agent)
+	bgHandler.artifactService = b.artifactService
+	bgHandler.SetEnableUsageMetadata(cfg.AgentConfig.EnableUsageMetadata)
+	server.SetBackgroundTaskHandler(bgHandler)
+
+	streamHandler := NewDefaultStreamingTaskHandler(logger, named.agent)
+	streamHandler.artifactService = b.artifactService
+	streamHandler.SetEnableUsageMetadata(cfg.AgentConfig.EnableUsageMetadata)
+	server.SetStreamingTaskHandler(streamHandler)
+
+	server.SetAgentCard(named.agentCard)
+	return server, nil
+}
+
+// addNamedAgent mounts the server of a named agent
+func (s *A2AServerImpl) addNamedAgent(name string, agent *A2AServerImpl) error {
+	if _, exists := s.namedAgents[name]; exists {
+		return fmt.Errorf("agent '%s' is already registered", name)
+	}
+	if s.namedAgents == nil {
+		s.namedAgents = make(map[string]*A2AServerImpl)
+	}
+	s.namedAgents[name] = agent
+	s.logger.Info("registered named agent", zap.String("agent", name), zap.String("path", NamedAgentPath(name)))
+	return nil
+}
+
+// GetNamedAgent returns the server of an agent registered with WithNamedAgent, for instance
+// to update its agent card at runtime
+func (s *A2AServerImpl) GetNamedAgent(name string) (A2AServer, bool) {
+	agent, exists := s.namedAgents[name]
+	if !exists {
+		return nil, false
+	}
+	return agent, true
+}
+
+// mountNamedAgents serves the agent card and A2A endpoints of each named agent under
+// /agents/{name}, behind the same middleware as the main A2A endpoint
+func (s *A2AServerImpl) mountNamedAgents(r *gin.Engine, handlers []gin.HandlerFunc) {
+	for _, name := range slices.Sorted(maps.Keys(s.namedAgents)) {
+		agent := s.namedAgents[name]
+		group := r.Group(namedAgentPathPrefix + name)
+		group.GET("/.well-known/agent-card.json", agent.handleAgentInfo)
+		group.POST("/a2a", append(slices.Clone(handlers), agent.handleA2ARequest)...)
+		if s.cfg.CapabilitiesConfig.WebSocket {
+			group.GET(types.WebSocketPath, append(slices.Clone(handlers), agent.handleA2AWebSocket)...)
+		}
+	}
+}
+
+// startNamedAgents starts the task processors of the named agents
+func (s *A2AServerImpl) startNamedAgents(ctx context.Context) {
+	for _, agent := range s.namedAgents {
+		go agent.StartTaskProcessor(ctx)
+	}
+}
+
+// drainWithNamedAgents drains the in-flight work of the server and its named agents in
+// parallel, reporting whether all of it finished within the timeout
+func (s *A2AServerImpl) drainWithNamedAgents(ctx context.Context, timeout time.Duration) bool {
+	var wg sync.WaitGroup
+	var incomplete atomic.Bool
+	for _, server := range append([]*A2AServerImpl{s}, slices.Collect(maps.Values(s.namedAgents))...) {
+		wg.Go(func() {
+			if !server.drain.drain(ctx, timeout) {
+				incomplete.Store(true)
+			}
+		})
+	}
+	wg.Wait()
+	return !incomplete.Load()
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestA2AServer_NamedAgents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	cfg := config.Config{}
+
+	built, err := NewA2AServerBuilder(cfg, logger).
+		WithAgent(NewOpenAICompatibleAgent(logger)).
+		WithDefaultTaskHandlers().
+		WithAgentCard(types.AgentCard{Name: "platform"}).
+		WithNamedAgent("travel", NewOpenAICompatibleAgent(logger), types.AgentCard{Name: "travel"}).
+		WithNamedAgent("weather", NewOpenAICompatibleAgent(logger), types.AgentCard{Name: "weather"}).
+		Build()
+	require.NoError(t, err)
+	s := built.(*A2AServerImpl)
+	router := s.setupRouter(s.cfg)
+
+	travel, exists := s.GetNamedAgent("travel")
+	require.True(t, exists)
+	assert.Equal(t, "travel", travel.GetAgentCard().Name)
+	_, exists = s.GetNamedAgent("unknown")
+	assert.False(t, exists)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/agents/weather/.well-known/agent-card.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var card types.AgentCard
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &card))
+	assert.Equal(t, "weather", card.Name)
+
+	call := func(path, body string) map[string]json.RawMessage {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		require.Equal(t, http.StatusOK, w.Code)
+		var response map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	response := call(NamedAgentPath("travel"), `{"jsonrpc":"2.0","id":"1","method":"message/send","params":{"message":{"kind":"message","messageId":"m1","role":"user","parts":[{"kind":"text","text":"Book a flight"}]}}}`)
+	require.Contains(t, response, "result")
+	var task types.Task
+	require.NoError(t, json.Unmarshal(response["result"], &task))
+
+	getTask := `{"jsonrpc":"2.0","id":"2","method":"tasks/get","params":{"id":"` + task.ID + `"}}`
+	assert.Contains(t, call(NamedAgentPath("travel"), getTask), "result")
+	assert.Contains(t, call(NamedAgentPath("weather"), getTask), "error", "tasks are kept per agent")
+	assert.Contains(t, call("/a2a", getTask), "error")
+}
+
+func TestA2AServerBuilder_NamedAgentValidation(t *testing.T) {
+	logger := zap.NewNop()
+	build := func(names ...string) error {
+		builder := NewA2AServerBuilder(config.Config{}, logger).
+			WithAgent(NewOpenAICompatibleAgent(logger)).
+			WithDefaultTaskHandlers().
+			WithAgentCard(types.AgentCard{Name: "platform"})
+		for _, name := range names {
+			builder = builder.WithNamedAgent(name, NewOpenAICompatibleAgent(logger), types.AgentCard{Name: name})
+		}
+		_, err := builder.Build()
+		return err
+	}
+
+	assert.NoError(t, build("travel", "weather_v2"))
+	assert.ErrorContains(t, build("travel", "travel"), "already registered")
+	assert.ErrorContains(t, build("Travel"), "invalid agent name")
+	assert.ErrorContains(t, build("a/b"), "invalid agent name")
+	assert.ErrorContains(t, build(""), "invalid agent name")
+}
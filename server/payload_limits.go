@@ -0,0 +1,145 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	gin "github.com/gin-gonic/gin"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// payloadLimits are the effective limits of a request mode, 0 meaning unlimited
+type payloadLimits struct {
+	maxBodySize int64
+	maxParts    int
+	maxFileSize int64
+}
+
+// resolvePayloadLimits applies the override of a request mode to the general limits
+func resolvePayloadLimits(cfg config.PayloadLimits, override config.PayloadLimitsOverride) payloadLimits {
+	limits := payloadLimits{maxBodySize: cfg.MaxBodySize, maxParts: cfg.MaxParts, maxFileSize: cfg.MaxFileSize}
+	if override.MaxBodySize > 0 {
+		limits.maxBodySize = override.MaxBodySize
+	}
+	if override.MaxParts > 0 {
+		limits.maxParts = override.MaxParts
+	}
+	if override.MaxFileSize > 0 {
+		limits.maxFileSize = override.MaxFileSize
+	}
+	return limits
+}
+
+// payloadLimitsFor returns the limits of the request mode a method belongs to
+func (s *A2AServerImpl) payloadLimitsFor(method string) payloadLimits {
+	cfg := s.cfg.ServerConfig.PayloadLimits
+	switch method {
+	case "message/stream", "tasks/resubscribe":
+		return resolvePayloadLimits(cfg, cfg.Stream)
+	default:
+		return resolvePayloadLimits(cfg, cfg.Send)
+	}
+}
+
+// maxRequestBodySize returns the largest body any request mode accepts, or 0 when a mode
+// is unlimited. It bounds how much of a body is read before its method is known.
+func (s *A2AServerImpl) maxRequestBodySize() int64 {
+	cfg := s.cfg.ServerConfig.PayloadLimits
+	var largest int64
+	for _, override := range []config.PayloadLimitsOverride{cfg.Send, cfg.Stream} {
+		limit := resolvePayloadLimits(cfg, override).maxBodySize
+		if limit <= 0 {
+			return 0
+		}
+		largest = max(largest, limit)
+	}
+	return largest
+}
+
+// decodeA2ARequest reads and decodes a JSON-RPC request body, returning its size. Bodies
+// larger than any mode accepts fail with an *http.MaxBytesError without being read in full.
+func (s *A2AServerImpl) decodeA2ARequest(c *gin.Context, req *types.JSONRPCRequest) (int64, error) {
+	body := c.Request.Body
+	if limit := s.maxRequestBodySize(); limit > 0 {
+		body = http.MaxBytesReader(c.Writer, body, limit)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(data)), json.Unmarshal(data, req)
+}
+
+// bodyTooLargeError is the error of a request body exceeding the limit
+func bodyTooLargeError(limit int64) *JSONRPCMethodError {
+	return &JSONRPCMethodError{
+		Code:    int(ErrInvalidRequest),
+		Message: fmt.Sprintf("request body exceeds the limit of %d bytes", limit),
+	}
+}
+
+// decodeMessageParams decodes the params of a message/send or message/stream request. It
+// returns nil for other methods and for malformed params, which the method handler reports.
+func decodeMessageParams(req types.JSONRPCRequest) *types.MessageSendParams {
+	if req.Method != "message/send" && req.Method != "message/stream" {
+		return nil
+	}
+	data, err := json.Marshal(req.Params)
+	if err != nil {
+		return nil
+	}
+	var params types.MessageSendParams
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil
+	}
+	return &params
+}
+
+// checkPayloadLimits validates the size of a request, and of the message it sends when
+// params is set, against the limits of its mode
+func (s *A2AServerImpl) checkPayloadLimits(req types.JSONRPCRequest, bodySize int64, params *types.MessageSendParams) *JSONRPCMethodError {
+	limits := s.payloadLimitsFor(req.Method)
+	if limits.maxBodySize > 0 && bodySize > limits.maxBodySize {
+		return bodyTooLargeError(limits.maxBodySize)
+	}
+	if params == nil {
+		return nil
+	}
+	return limits.checkMessage(params.Message)
+}
+
+// checkMessage validates the number of parts of a message and the size of its file parts
+func (l payloadLimits) checkMessage(message types.Message) *JSONRPCMethodError {
+	if l.maxParts > 0 && len(message.Parts) > l.maxParts {
+		return &JSONRPCMethodError{
+			Code:    int(ErrInvalidParams),
+			Message: fmt.Sprintf("message has %d parts, the limit is %d", len(message.Parts), l.maxParts),
+		}
+	}
+
+	if l.maxFileSize <= 0 {
+		return nil
+	}
+	for i, part := range message.Parts {
+		if part.File == nil || part.File.FileWithBytes == nil {
+			continue
+		}
+		if size := base64DecodedSize(*part.File.FileWithBytes); size > l.maxFileSize {
+			return &JSONRPCMethodError{
+				Code:    int(ErrInvalidParams),
+				Message: fmt.Sprintf("file '%s' is %d bytes, the limit is %d bytes", fileName(part, i), size, l.maxFileSize),
+			}
+		}
+	}
+	return nil
+}
+
+// base64DecodedSize returns the decoded size of base64 data, padded or not, without decoding it
+func base64DecodedSize(data string) int64 {
+	return int64(len(strings.TrimRight(data, "="))) * 3 / 4
+}
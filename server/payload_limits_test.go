@@ -0,0 +1,96 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestResolvePayloadLimits(t *testing.T) {
+	cfg := config.PayloadLimits{
+		MaxBodySize: 1000,
+		MaxParts:    10,
+		MaxFileSize: 500,
+		Stream:      config.PayloadLimitsOverride{MaxBodySize: 4000, MaxParts: 2},
+	}
+	s := &A2AServerImpl{cfg: &config.Config{ServerConfig: config.ServerConfig{PayloadLimits: cfg}}}
+
+	assert.Equal(t, payloadLimits{maxBodySize: 1000, maxParts: 10, maxFileSize: 500}, s.payloadLimitsFor("message/send"))
+	assert.Equal(t, payloadLimits{maxBodySize: 1000, maxParts: 10, maxFileSize: 500}, s.payloadLimitsFor("tasks/get"))
+	assert.Equal(t, payloadLimits{maxBodySize: 4000, maxParts: 2, maxFileSize: 500}, s.payloadLimitsFor("message/stream"))
+	assert.Equal(t, payloadLimits{maxBodySize: 4000, maxParts: 2, maxFileSize: 500}, s.payloadLimitsFor("tasks/resubscribe"))
+	assert.Equal(t, int64(4000), s.maxRequestBodySize())
+
+	s.cfg.ServerConfig.PayloadLimits.MaxBodySize = 0
+	assert.Zero(t, s.maxRequestBodySize(), "an unlimited mode lifts the read limit")
+}
+
+func TestBase64DecodedSize(t *testing.T) {
+	for _, data := range []string{"", "a", "ab", "abc", "abcd", strings.Repeat("x", 1000)} {
+		assert.Equal(t, int64(len(data)), base64DecodedSize(base64.StdEncoding.EncodeToString([]byte(data))))
+		assert.Equal(t, int64(len(data)), base64DecodedSize(base64.RawStdEncoding.EncodeToString([]byte(data))))
+	}
+}
+
+func TestA2AServer_PayloadLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{ServerConfig: config.ServerConfig{PayloadLimits: config.PayloadLimits{
+		MaxBodySize: 2048,
+		MaxParts:    2,
+		MaxFileSize: 64,
+		Send:        config.PayloadLimitsOverride{MaxBodySize: 1024},
+	}}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "limited"})
+	router := s.setupRouter(cfg)
+
+	send := func(parts ...string) *types.JSONRPCError {
+		body := `{"jsonrpc":"2.0","id":"1","method":"message/send","params":{"message":{"kind":"message","messageId":"m1","role":"user","parts":[` +
+			strings.Join(parts, ",") + `]}}}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+		var response struct {
+			Error *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Error
+	}
+	text := `{"kind":"text","text":"hello"}`
+	file := func(size int) string {
+		return `{"kind":"file","file":{"name":"notes.txt","mediaType":"text/plain","fileWithBytes":"` +
+			base64.StdEncoding.EncodeToString(make([]byte, size)) + `"}}`
+	}
+
+	assert.Nil(t, send(text, file(64)))
+
+	limitErr := send(text, text, text)
+	require.NotNil(t, limitErr)
+	assert.Equal(t, int(ErrInvalidParams), limitErr.Code)
+	assert.Equal(t, "message has 3 parts, the limit is 2", limitErr.Message)
+
+	limitErr = send(file(65))
+	require.NotNil(t, limitErr)
+	assert.Equal(t, int(ErrInvalidParams), limitErr.Code)
+	assert.Equal(t, "file 'notes.txt' is 65 bytes, the limit is 64 bytes", limitErr.Message)
+
+	limitErr = send(text, `{"kind":"text","text":"`+strings.Repeat("x", 1200)+`"}`)
+	require.NotNil(t, limitErr)
+	assert.Equal(t, int(ErrInvalidRequest), limitErr.Code)
+	assert.Equal(t, "request body exceeds the limit of 1024 bytes", limitErr.Message)
+
+	limitErr = send(`{"kind":"text","text":"` + strings.Repeat("x", 4096) + `"}`)
+	require.NotNil(t, limitErr)
+	assert.Equal(t, int(ErrInvalidRequest), limitErr.Code)
+	assert.Equal(t, "request body exceeds the limit of 2048 bytes", limitErr.Message, "bodies larger than every mode accepts are not read")
+}
@@ -0,0 +1,362 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+
+	zap "go.uber.org/zap"
+	yaml "gopkg.in/yaml.v3"
+
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// Policy effects
+const (
+	PolicyEffectAllow = "allow"
+	PolicyEffectDeny  = "deny"
+)
+
+// PreconditionPolicyDenied is the refusal code of a tool call the access policy denies
+const PreconditionPolicyDenied = "policy_denied"
+
+// policyContextKey carries the access policy to the tool executions of a task
+const policyContextKey ContextKey = "policy"
+
+// PolicyRule allows or denies the requests it matches. A request matches when it matches every
+// condition the rule sets; a rule without conditions matches every request. Callers, methods,
+// skills and tools accept path.Match patterns, such as delete_*.
+type PolicyRule struct {
+	// Effect is allow or deny
+	Effect string `json:"effect"`
+
+	// Callers are the subjects of the ID tokens of the callers the rule applies to; * matches
+	// every authenticated caller
+	Callers []string `json:"callers,omitempty"`
+
+	// Scopes restrict the rule to callers granted any of the scopes
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Methods restrict the rule to JSON-RPC methods, such as tasks/cancel. A rule with
+	// methods does not apply to tool calls.
+	Methods []string `json:"methods,omitempty"`
+
+	// Skills restrict the rule to the messages and tool calls of the skills
+	Skills []string `json:"skills,omitempty"`
+
+	// Tools restrict the rule to calls of the tools. A rule with tools does not apply to
+	// methods.
+	Tools []string `json:"tools,omitempty"`
+
+	// Arguments restrict the rule to tool calls whose arguments match regular expressions,
+	// by argument name. A rule with arguments does not apply to methods.
+	Arguments map[string]string `json:"arguments,omitempty"`
+
+	// Reason explains a denial to the caller
+	Reason string `json:"reason,omitempty"`
+
+	arguments map[string]*regexp.Regexp
+}
+
+// Policy decides which callers may call the methods, skills and tools of the agent, so
+// deployments shared by several clients can restrict dangerous capabilities to some of them.
+// The first rule matching a request decides; requests no rule matches get the default
+// effect, which is allow when empty.
+type Policy struct {
+	Default string       `json:"default,omitempty"`
+	Rules   []PolicyRule `json:"rules"`
+}
+
+// PolicyRequest is a JSON-RPC call or a tool call evaluated against a policy. Method is set
+// for calls and Tool for tool calls.
+type PolicyRequest struct {
+	Caller    string
+	Scopes    []string
+	Method    string
+	Skill     string
+	Tool      string
+	Arguments map[string]any
+}
+
+// PolicyDecision is the outcome of evaluating a request against a policy
+type PolicyDecision struct {
+	Allowed bool
+
+	// Rule is the index of the rule that decided, or -1 for the default effect
+	Rule int
+
+	// Reason explains a denial
+	Reason string
+}
+
+// NewPolicy creates a policy from rules, checking their effects and patterns
+func NewPolicy(defaultEffect string, rules ...PolicyRule) (*Policy, error) {
+	policy := &Policy{Default: defaultEffect, Rules: rules}
+	if err := policy.compile(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// ParsePolicy parses a policy from its YAML or JSON definition
+func ParsePolicy(data []byte) (*Policy, error) {
+	var document any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+	normalized, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+
+	var policy Policy
+	decoder := json.NewDecoder(bytes.NewReader(normalized))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+	if err := policy.compile(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// LoadPolicy reads a policy from a YAML or JSON file
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	policy, err := ParsePolicy(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// compile checks the effects and patterns of the policy and compiles the argument patterns
+func (p *Policy) compile() error {
+	if p.Default != "" && p.Default != PolicyEffectAllow && p.Default != PolicyEffectDeny {
+		return fmt.Errorf("invalid policy default %q: must be allow or deny", p.Default)
+	}
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Effect != PolicyEffectAllow && rule.Effect != PolicyEffectDeny {
+			return fmt.Errorf("rules[%d]: invalid effect %q: must be allow or deny", i, rule.Effect)
+		}
+		for _, pattern := range slices.Concat(rule.Callers, rule.Methods, rule.Skills, rule.Tools) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("rules[%d]: invalid pattern %q: %w", i, pattern, err)
+			}
+		}
+		rule.arguments = make(map[string]*regexp.Regexp, len(rule.Arguments))
+		for name, pattern := range rule.Arguments {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("rules[%d]: invalid pattern of argument %s: %w", i, name, err)
+			}
+			rule.arguments[name] = re
+		}
+	}
+	return nil
+}
+
+// Evaluate decides a request. A nil policy allows every request.
+func (p *Policy) Evaluate(request PolicyRequest) PolicyDecision {
+	if p == nil {
+		return PolicyDecision{Allowed: true, Rule: -1}
+	}
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if !rule.matches(request) {
+			continue
+		}
+		decision := PolicyDecision{Allowed: rule.Effect == PolicyEffectAllow, Rule: i}
+		if !decision.Allowed {
+			decision.Reason = rule.Reason
+		}
+		return decision
+	}
+	return PolicyDecision{Allowed: p.Default != PolicyEffectDeny, Rule: -1}
+}
+
+// matches reports whether a request meets every condition of the rule
+func (r *PolicyRule) matches(request PolicyRequest) bool {
+	if request.Tool == "" && (len(r.Tools) > 0 || len(r.Arguments) > 0) {
+		return false
+	}
+	if request.Tool != "" && len(r.Methods) > 0 {
+		return false
+	}
+	if len(r.Callers) > 0 && !matchesAny(r.Callers, request.Caller) {
+		return false
+	}
+	if len(r.Scopes) > 0 && !slices.ContainsFunc(r.Scopes, func(scope string) bool { return slices.Contains(request.Scopes, scope) }) {
+		return false
+	}
+	if len(r.Methods) > 0 && !matchesAny(r.Methods, request.Method) {
+		return false
+	}
+	if len(r.Skills) > 0 && !matchesAny(r.Skills, request.Skill) {
+		return false
+	}
+	if len(r.Tools) > 0 && !matchesAny(r.Tools, request.Tool) {
+		return false
+	}
+	for name, re := range r.arguments {
+		value, exists := request.Arguments[name]
+		if !exists || !re.MatchString(policyArgumentText(value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAny reports whether a value matches any of the patterns. An empty value matches none,
+// so a rule naming callers, even *, does not apply to anonymous requests, and a rule naming
+// skills does not apply to messages without one.
+func matchesAny(patterns []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		matched, _ := path.Match(pattern, value)
+		return matched
+	})
+}
+
+// policyArgumentText returns the text argument patterns are matched against: strings as they
+// are and other values as JSON
+func policyArgumentText(value any) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// denial returns the message explaining a denial
+func (d PolicyDecision) denial(subject string) string {
+	if d.Reason != "" {
+		return d.Reason
+	}
+	return fmt.Sprintf("You are not allowed to use %s.", subject)
+}
+
+// contextWithPolicy returns a copy of ctx carrying the access policy, so the tools run for a
+// task are checked against it
+func contextWithPolicy(ctx context.Context, policy *Policy) context.Context {
+	if policy == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, policyContextKey, policy)
+}
+
+// checkToolPolicy refuses the execution of a tool the access policy denies to the caller of
+// the task
+func checkToolPolicy(ctx context.Context, toolName string, arguments map[string]any, task *types.Task) *PreconditionRefusal {
+	policy, _ := ctx.Value(policyContextKey).(*Policy)
+	if policy == nil {
+		return nil
+	}
+
+	request := PolicyRequest{
+		Caller:    middlewares.SubjectFromContext(ctx),
+		Scopes:    middlewares.ScopesFromContext(ctx),
+		Tool:      toolName,
+		Arguments: arguments,
+	}
+	if skill, _ := ctx.Value(skillContextKey).(*boundSkill); skill != nil {
+		request.Skill = skill.id
+	}
+	if task != nil {
+		if request.Skill == "" {
+			request.Skill = types.GetTaskSkillID(task)
+		}
+		if task.Metadata != nil {
+			if subject, ok := (*task.Metadata)[types.AuthSubjectMetadataKey].(string); ok {
+				request.Caller = subject
+			}
+			if scopes, recorded := (*task.Metadata)[types.AuthScopesMetadataKey]; recorded {
+				request.Scopes = scopesFromMetadata(scopes)
+			}
+		}
+	}
+
+	decision := policy.Evaluate(request)
+	if decision.Allowed {
+		return nil
+	}
+	return &PreconditionRefusal{
+		Code:    PreconditionPolicyDenied,
+		Message: decision.denial(fmt.Sprintf("the tool %s", toolName)),
+	}
+}
+
+// checkMethodPolicy answers a JSON-RPC call the access policy denies to its caller with an
+// error. The skill of a message is the skill it names, or else the skill of its task.
+func (s *A2AServerImpl) checkMethodPolicy(ctx context.Context, req types.JSONRPCRequest, params *types.MessageSendParams) *JSONRPCMethodError {
+	if s.policy == nil {
+		return nil
+	}
+
+	request := PolicyRequest{
+		Caller: middlewares.SubjectFromContext(ctx),
+		Scopes: middlewares.ScopesFromContext(ctx),
+		Method: req.Method,
+	}
+	if params != nil {
+		request.Skill = messageSkillID(params.Message)
+		if request.Skill == "" && params.Message.TaskID != nil {
+			if task, exists := s.taskManager.GetTask(*params.Message.TaskID); exists {
+				request.Skill = types.GetTaskSkillID(task)
+			}
+		}
+	}
+
+	decision := s.policy.Evaluate(request)
+	if decision.Allowed {
+		return nil
+	}
+	subject := req.Method
+	if request.Skill != "" {
+		subject = fmt.Sprintf("the skill %s", request.Skill)
+	}
+	return &JSONRPCMethodError{
+		Code:    int(ErrInvalidRequest),
+		Message: fmt.Sprintf("request denied by policy: %s", decision.denial(subject)),
+		Data:    map[string]any{"code": PreconditionPolicyDenied},
+	}
+}
+
+// setupPolicy loads the access policy file when one is configured. A policy that cannot be
+// loaded denies every request, rather than leaving the agent open.
+func (s *A2AServerImpl) setupPolicy() {
+	if s.cfg.AuthConfig.PolicyFile == "" {
+		return
+	}
+
+	policy, err := LoadPolicy(s.cfg.AuthConfig.PolicyFile)
+	if err != nil {
+		s.logger.Error("failed to load access policy, denying every request", zap.Error(err))
+		policy = &Policy{Default: PolicyEffectDeny}
+	}
+	s.setPolicy(policy)
+}
+
+// setPolicy sets the access policy checked before methods and tool executions
+func (s *A2AServerImpl) setPolicy(policy *Policy) {
+	s.policy = policy
+	for _, named := range s.namedAgents {
+		named.setPolicy(policy)
+	}
+}
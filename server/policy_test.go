@@ -0,0 +1,275 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	sdk "github.com/inference-gateway/sdk"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+	types "github.com/inference-gateway/adk/types"
+)
+
+const testPolicy = `
+default: allow
+rules:
+  - effect: allow
+    scopes: [admin]
+  - effect: deny
+    tools: [delete_*]
+    reason: Deleting records requires the admin scope.
+  - effect: deny
+    tools: [run_query]
+    arguments:
+      query: "(?i)\\b(drop|truncate)\\b"
+  - effect: deny
+    methods: [tasks/cancel]
+    callers: [guest-*]
+  - effect: deny
+    skills: [billing]
+    callers: ["*"]
+`
+
+func TestPolicy_Evaluate(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		request     PolicyRequest
+		wantAllowed bool
+		wantRule    int
+	}{
+		{
+			name:        "tool matching a pattern",
+			request:     PolicyRequest{Caller: "alice", Tool: "delete_order"},
+			wantAllowed: false,
+			wantRule:    1,
+		},
+		{
+			name:        "earlier rule granting a scope",
+			request:     PolicyRequest{Caller: "alice", Scopes: []string{"admin"}, Tool: "delete_order"},
+			wantAllowed: true,
+			wantRule:    0,
+		},
+		{
+			name:        "arguments matching",
+			request:     PolicyRequest{Tool: "run_query", Arguments: map[string]any{"query": "DROP TABLE orders"}},
+			wantAllowed: false,
+			wantRule:    2,
+		},
+		{
+			name:        "arguments not matching",
+			request:     PolicyRequest{Tool: "run_query", Arguments: map[string]any{"query": "SELECT * FROM orders"}},
+			wantAllowed: true,
+			wantRule:    -1,
+		},
+		{
+			name:        "method of a matching caller",
+			request:     PolicyRequest{Caller: "guest-42", Method: "tasks/cancel"},
+			wantAllowed: false,
+			wantRule:    3,
+		},
+		{
+			name:        "method of another caller",
+			request:     PolicyRequest{Caller: "alice", Method: "tasks/cancel"},
+			wantAllowed: true,
+			wantRule:    -1,
+		},
+		{
+			name:        "tool rule does not apply to methods",
+			request:     PolicyRequest{Method: "message/send"},
+			wantAllowed: true,
+			wantRule:    -1,
+		},
+		{
+			name:        "anonymous caller does not match a caller pattern",
+			request:     PolicyRequest{Method: "message/send", Skill: "billing"},
+			wantAllowed: true,
+			wantRule:    -1,
+		},
+		{
+			name:        "authenticated caller of a skill",
+			request:     PolicyRequest{Caller: "alice", Method: "message/send", Skill: "billing"},
+			wantAllowed: false,
+			wantRule:    4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := policy.Evaluate(tt.request)
+			assert.Equal(t, tt.wantAllowed, decision.Allowed)
+			assert.Equal(t, tt.wantRule, decision.Rule)
+		})
+	}
+
+	t.Run("default deny", func(t *testing.T) {
+		policy, err := NewPolicy(PolicyEffectDeny, PolicyRule{Effect: PolicyEffectAllow, Methods: []string{"tasks/get"}})
+		require.NoError(t, err)
+		assert.True(t, policy.Evaluate(PolicyRequest{Method: "tasks/get"}).Allowed)
+		assert.False(t, policy.Evaluate(PolicyRequest{Method: "tasks/cancel"}).Allowed)
+		assert.False(t, policy.Evaluate(PolicyRequest{Tool: "lookup"}).Allowed)
+	})
+
+	t.Run("nil policy", func(t *testing.T) {
+		var policy *Policy
+		assert.True(t, policy.Evaluate(PolicyRequest{Tool: "delete_order"}).Allowed)
+	})
+}
+
+func TestParsePolicy_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr string
+	}{
+		{name: "invalid effect", policy: "rules:\n  - effect: maybe", wantErr: `invalid effect "maybe"`},
+		{name: "invalid default", policy: "default: block\nrules: []", wantErr: `invalid policy default "block"`},
+		{name: "unknown field", policy: "rules:\n  - effect: deny\n    tool: [delete]", wantErr: "unknown field"},
+		{name: "invalid pattern", policy: "rules:\n  - effect: deny\n    tools: ['[']", wantErr: "invalid pattern"},
+		{name: "invalid argument pattern", policy: "rules:\n  - effect: deny\n    arguments: {query: '('}", wantErr: "invalid pattern of argument query"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePolicy([]byte(tt.policy))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestA2AServer_PolicyDeniesMethods(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testPolicy), 0o600))
+
+	cfg := &config.Config{AuthConfig: config.AuthConfig{PolicyFile: path}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	require.NotNil(t, s.policy)
+	caller := ""
+	s.useHTTPMiddleware(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(middlewares.ContextWithSubject(c.Request.Context(), caller))
+	})
+	router := s.setupRouter(cfg)
+
+	call := func(body string) *types.JSONRPCError {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+		var response struct {
+			Error *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Error
+	}
+	cancel := `{"jsonrpc":"2.0","id":"1","method":"tasks/cancel","params":{"id":"missing"}}`
+	send := `{"jsonrpc":"2.0","id":"1","method":"message/send","params":{"message":{"messageId":"m1","role":"user","metadata":{"skillId":"billing"},"parts":[{"text":"refund my order"}]}}}`
+
+	caller = "guest-7"
+	denied := call(cancel)
+	require.NotNil(t, denied)
+	assert.Equal(t, int(ErrInvalidRequest), denied.Code)
+	assert.Contains(t, denied.Message, "request denied by policy")
+
+	caller = "alice"
+	allowed := call(cancel)
+	require.NotNil(t, allowed)
+	assert.Equal(t, int(ErrTaskNotFound), allowed.Code, "the call reaches its handler")
+
+	denied = call(send)
+	require.NotNil(t, denied)
+	assert.Contains(t, denied.Message, "the skill billing")
+}
+
+func TestA2AServer_UnloadablePolicyDeniesEverything(t *testing.T) {
+	cfg := &config.Config{AuthConfig: config.AuthConfig{PolicyFile: filepath.Join(t.TempDir(), "missing.yaml")}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	require.NotNil(t, s.policy)
+	assert.False(t, s.policy.Evaluate(PolicyRequest{Method: "tasks/get"}).Allowed)
+}
+
+func TestAgentRun_PolicyDeniesTools(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		metadata  map[string]any
+		wantSends int
+	}{
+		{name: "caller without the admin scope", metadata: map[string]any{types.AuthSubjectMetadataKey: "alice"}},
+		{name: "caller with the admin scope", metadata: map[string]any{types.AuthScopesMetadataKey: []any{"admin"}}, wantSends: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			llmClient := &scriptedLLMClient{responses: []*sdk.CreateChatCompletionStreamResponse{toolCallChunk("call-1", "delete_order"), textChunk("done")}}
+			deletes := 0
+			toolBox := NewDefaultToolBox(&config.ToolBoxConfig{})
+			toolBox.AddTool(NewBasicTool("delete_order", "Deletes an order", map[string]any{"type": "object"},
+				func(ctx context.Context, args map[string]any) (string, error) {
+					deletes++
+					return "deleted", nil
+				}))
+			agent, err := NewAgentBuilder(zap.NewNop()).WithLLMClient(llmClient).WithToolBox(toolBox).Build()
+			require.NoError(t, err)
+
+			task := &types.Task{ID: "task-1", Metadata: &tt.metadata}
+			ctx := contextWithPolicy(context.WithValue(context.Background(), TaskContextKey, task), policy)
+			message := types.Message{MessageID: "m1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("delete order 42")}}
+			assert.Equal(t, types.TaskStateCompleted, runToCompletion(t, ctx, agent, []types.Message{message}))
+			assert.Equal(t, tt.wantSends, deletes)
+
+			if tt.wantSends == 0 {
+				require.Len(t, llmClient.calls, 2)
+				result := llmClient.calls[1][len(llmClient.calls[1])-1]
+				text, err := result.Content.AsMessageContent0()
+				require.NoError(t, err)
+				assert.Equal(t, "Deleting records requires the admin scope.", text)
+			}
+		})
+	}
+}
+
+func TestProtocolHandler_RecordsCallerSubject(t *testing.T) {
+	logger := zap.NewNop()
+	storage := NewInMemoryStorage(logger, 20)
+	taskManager := NewDefaultTaskManagerWithStorage(logger, storage)
+	h := NewDefaultA2AProtocolHandler(logger, storage, taskManager, NewDefaultResponseSender(logger))
+
+	spoofed := map[string]any{types.AuthSubjectMetadataKey: "admin"}
+	params := types.MessageSendParams{Message: types.Message{
+		MessageID: "m1",
+		Role:      types.RoleUser,
+		Parts:     []types.Part{types.CreateTextPart("hello")},
+		Metadata:  &spoofed,
+	}}
+
+	task, refusal, err := h.createTaskFromMessage(middlewares.ContextWithSubject(context.Background(), "alice"), params)
+	require.NoError(t, err)
+	require.Nil(t, refusal)
+	require.NotNil(t, task.Metadata)
+	assert.Equal(t, "alice", (*task.Metadata)[types.AuthSubjectMetadataKey])
+
+	taskID := task.ID
+	require.NoError(t, taskManager.PauseTaskForInput(taskID, &types.Message{MessageID: "a1", Role: types.RoleAgent}))
+	params.Message.MessageID = "m2"
+	params.Message.TaskID = &taskID
+	params.Message.Metadata = nil
+	task, _, err = h.createTaskFromMessage(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, task.Metadata)
+	assert.NotContains(t, *task.Metadata, types.AuthSubjectMetadataKey)
+}
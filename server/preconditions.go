@@ -0,0 +1,248 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// Precondition refusal codes
+const (
+	PreconditionMissingMetadata      = "missing_metadata"
+	PreconditionMissingScopes        = "missing_scopes"
+	PreconditionOutsideBusinessHours = "outside_business_hours"
+)
+
+// Precondition is a policy check a tool declares, evaluated before the tool executes.
+// A tool whose precondition is not met is not executed; the refusal message is returned
+// to the model as the tool result instead, so it can explain the refusal to the user.
+type Precondition interface {
+	// Check returns a refusal when the precondition is not met, or nil to allow execution
+	Check(ctx context.Context, input PreconditionInput) *PreconditionRefusal
+}
+
+// PreconditionFunc adapts a function to the Precondition interface
+type PreconditionFunc func(ctx context.Context, input PreconditionInput) *PreconditionRefusal
+
+// Check calls the function
+func (f PreconditionFunc) Check(ctx context.Context, input PreconditionInput) *PreconditionRefusal {
+	return f(ctx, input)
+}
+
+// PreconditionInput is what preconditions are evaluated against
+type PreconditionInput struct {
+	// Tool is the tool about to be executed
+	Tool Tool
+
+	// Arguments are the arguments the tool is called with
+	Arguments map[string]any
+
+	// Task is the task being processed, nil outside of task processing
+	Task *types.Task
+
+	// Metadata is the task metadata merged with the metadata of the latest user message
+	Metadata map[string]any
+
+	// Scopes are the scopes granted to the authenticated caller of the task
+	Scopes []string
+
+	// Now is the time the precondition is evaluated at
+	Now time.Time
+}
+
+// PreconditionRefusal describes why a precondition is not met
+type PreconditionRefusal struct {
+	// Code identifies the kind of refusal, such as PreconditionMissingScopes
+	Code string
+
+	// Message is a user-facing explanation of the refusal
+	Message string
+}
+
+func (r *PreconditionRefusal) Error() string {
+	return r.Message
+}
+
+// GuardedTool is a tool declaring preconditions that must be met before it executes
+type GuardedTool interface {
+	Tool
+
+	// GetPreconditions returns the preconditions of the tool
+	GetPreconditions() []Precondition
+}
+
+// guardedTool adds preconditions to an existing tool
+type guardedTool struct {
+	Tool
+	preconditions []Precondition
+}
+
+// NewGuardedTool wraps a tool, such as one provided by an MCP server, with preconditions
+func NewGuardedTool(tool Tool, preconditions ...Precondition) GuardedTool {
+	return &guardedTool{Tool: tool, preconditions: preconditions}
+}
+
+func (t *guardedTool) GetPreconditions() []Precondition {
+	return t.preconditions
+}
+
+// RequireMetadata refuses execution unless every field is set in the task or latest user
+// message metadata
+func RequireMetadata(fields ...string) Precondition {
+	return PreconditionFunc(func(ctx context.Context, input PreconditionInput) *PreconditionRefusal {
+		var missing []string
+		for _, field := range fields {
+			if value, ok := input.Metadata[field]; !ok || value == nil || value == "" {
+				missing = append(missing, field)
+			}
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+		return &PreconditionRefusal{
+			Code:    PreconditionMissingMetadata,
+			Message: fmt.Sprintf("This request can't be completed because it is missing required information: %s.", strings.Join(missing, ", ")),
+		}
+	})
+}
+
+// RequireScopes refuses execution unless the authenticated caller was granted every scope
+func RequireScopes(scopes ...string) Precondition {
+	return PreconditionFunc(func(ctx context.Context, input PreconditionInput) *PreconditionRefusal {
+		var missing []string
+		for _, scope := range scopes {
+			if !slices.Contains(input.Scopes, scope) {
+				missing = append(missing, scope)
+			}
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+		return &PreconditionRefusal{
+			Code:    PreconditionMissingScopes,
+			Message: fmt.Sprintf("You are not authorized to perform this action. It requires the following permissions: %s.", strings.Join(missing, ", ")),
+		}
+	})
+}
+
+// BusinessHours is a daily window during which a tool is available
+type BusinessHours struct {
+	// Location is the time zone of the window, UTC when nil
+	Location *time.Location
+
+	// Days are the weekdays the window applies to, every day when empty
+	Days []time.Weekday
+
+	// Start and End are offsets from midnight bounding the window, End being exclusive
+	Start time.Duration
+	End   time.Duration
+}
+
+// WithinBusinessHours refuses execution outside of the business hours window
+func WithinBusinessHours(hours BusinessHours) Precondition {
+	location := hours.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	return PreconditionFunc(func(ctx context.Context, input PreconditionInput) *PreconditionRefusal {
+		now := input.Now.In(location)
+		midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+		offset := now.Sub(midnight)
+
+		if (len(hours.Days) == 0 || slices.Contains(hours.Days, now.Weekday())) &&
+			offset >= hours.Start && offset < hours.End {
+			return nil
+		}
+
+		days := ""
+		if len(hours.Days) > 0 {
+			names := make([]string, len(hours.Days))
+			for i, day := range hours.Days {
+				names[i] = day.String()
+			}
+			days = " on " + strings.Join(names, ", ")
+		}
+		return &PreconditionRefusal{
+			Code: PreconditionOutsideBusinessHours,
+			Message: fmt.Sprintf("This action is only available%s between %s and %s (%s). Please try again during those hours.",
+				days, formatClock(hours.Start), formatClock(hours.End), location),
+		}
+	})
+}
+
+// formatClock formats an offset from midnight as HH:MM
+func formatClock(offset time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(offset.Hours()), int(offset.Minutes())%60)
+}
+
+// checkToolPreconditions evaluates the preconditions declared by a tool against the task,
+// returning the first refusal or nil when the tool may execute
+func checkToolPreconditions(ctx context.Context, tool Tool, arguments map[string]any, task *types.Task, now time.Time) *PreconditionRefusal {
+	guarded, ok := tool.(GuardedTool)
+	if !ok {
+		return nil
+	}
+	preconditions := guarded.GetPreconditions()
+	if len(preconditions) == 0 {
+		return nil
+	}
+
+	input := PreconditionInput{
+		Tool:      tool,
+		Arguments: arguments,
+		Task:      task,
+		Metadata:  make(map[string]any),
+		Now:       now,
+	}
+	if task != nil {
+		if task.Metadata != nil {
+			for key, value := range *task.Metadata {
+				input.Metadata[key] = value
+			}
+		}
+		for i := len(task.History) - 1; i >= 0; i-- {
+			if task.History[i].Role != types.RoleUser {
+				continue
+			}
+			if task.History[i].Metadata != nil {
+				for key, value := range *task.History[i].Metadata {
+					input.Metadata[key] = value
+				}
+			}
+			break
+		}
+		if task.Metadata != nil {
+			input.Scopes = scopesFromMetadata((*task.Metadata)[types.AuthScopesMetadataKey])
+		}
+	}
+
+	for _, precondition := range preconditions {
+		if refusal := precondition.Check(ctx, input); refusal != nil {
+			return refusal
+		}
+	}
+	return nil
+}
+
+// scopesFromMetadata reads the recorded caller scopes, which are a list of any once the
+// task has been through a storage round trip
+func scopesFromMetadata(value any) []string {
+	switch scopes := value.(type) {
+	case []string:
+		return scopes
+	case []any:
+		result := make([]string, 0, len(scopes))
+		for _, scope := range scopes {
+			if s, ok := scope.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
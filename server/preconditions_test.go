@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestPreconditions_BuiltIns(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	hours := WithinBusinessHours(BusinessHours{
+		Location: berlin,
+		Days:     []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Start:    9 * time.Hour,
+		End:      17*time.Hour + 30*time.Minute,
+	})
+
+	tests := []struct {
+		name         string
+		precondition Precondition
+		input        PreconditionInput
+		code         string
+		message      string
+	}{
+		{
+			name:         "metadata present",
+			precondition: RequireMetadata("customerId"),
+			input:        PreconditionInput{Metadata: map[string]any{"customerId": "c-1"}},
+		},
+		{
+			name:         "metadata missing",
+			precondition: RequireMetadata("customerId", "region"),
+			input:        PreconditionInput{Metadata: map[string]any{"region": ""}},
+			code:         PreconditionMissingMetadata,
+			message:      "This request can't be completed because it is missing required information: customerId, region.",
+		},
+		{
+			name:         "scopes granted",
+			precondition: RequireScopes("orders:write"),
+			input:        PreconditionInput{Scopes: []string{"orders:read", "orders:write"}},
+		},
+		{
+			name:         "scopes missing",
+			precondition: RequireScopes("orders:read", "orders:write"),
+			input:        PreconditionInput{Scopes: []string{"orders:read"}},
+			code:         PreconditionMissingScopes,
+			message:      "You are not authorized to perform this action. It requires the following permissions: orders:write.",
+		},
+		{
+			name:         "within business hours",
+			precondition: hours,
+			input:        PreconditionInput{Now: time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:         "after business hours",
+			precondition: hours,
+			input:        PreconditionInput{Now: time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)},
+			code:         PreconditionOutsideBusinessHours,
+			message:      "This action is only available on Monday, Tuesday, Wednesday, Thursday, Friday between 09:00 and 17:30 (Europe/Berlin). Please try again during those hours.",
+		},
+		{
+			name:         "weekend",
+			precondition: hours,
+			input:        PreconditionInput{Now: time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)},
+			code:         PreconditionOutsideBusinessHours,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refusal := tt.precondition.Check(context.Background(), tt.input)
+			if tt.code == "" {
+				assert.Nil(t, refusal)
+				return
+			}
+			require.NotNil(t, refusal)
+			assert.Equal(t, tt.code, refusal.Code)
+			if tt.message != "" {
+				assert.Equal(t, tt.message, refusal.Message)
+			}
+		})
+	}
+}
+
+func TestCheckToolPreconditions(t *testing.T) {
+	execute := func(ctx context.Context, args map[string]any) (string, error) { return "ok", nil }
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+
+	newTask := func(taskMetadata, messageMetadata map[string]any) *types.Task {
+		task := &types.Task{ID: "task-1", ContextID: "ctx-1", Metadata: &taskMetadata}
+		task.History = []types.Message{
+			{MessageID: "m1", Role: types.RoleUser, Metadata: &map[string]any{"customerId": "stale"}},
+			{MessageID: "m2", Role: types.RoleUser, Metadata: &messageMetadata},
+			{MessageID: "m3", Role: types.RoleAgent, Metadata: &map[string]any{"region": "eu"}},
+		}
+		return task
+	}
+
+	t.Run("tools without preconditions execute", func(t *testing.T) {
+		tool := NewBasicTool("lookup", "", nil, execute)
+		assert.Nil(t, checkToolPreconditions(context.Background(), tool, nil, nil, now))
+		assert.Nil(t, checkToolPreconditions(context.Background(), nil, nil, nil, now))
+	})
+
+	t.Run("metadata from task and latest user message", func(t *testing.T) {
+		tool := NewBasicTool("lookup", "", nil, execute).WithPreconditions(RequireMetadata("tenant", "customerId"))
+		task := newTask(map[string]any{"tenant": "acme"}, map[string]any{"customerId": "c-1"})
+		assert.Nil(t, checkToolPreconditions(context.Background(), tool, nil, task, now))
+
+		tool = NewBasicTool("lookup", "", nil, execute).WithPreconditions(RequireMetadata("region"))
+		refusal := checkToolPreconditions(context.Background(), tool, nil, task, now)
+		require.NotNil(t, refusal)
+		assert.Equal(t, PreconditionMissingMetadata, refusal.Code)
+	})
+
+	t.Run("scopes recorded on the task", func(t *testing.T) {
+		tool := NewGuardedTool(NewBasicTool("refund", "", nil, execute), RequireScopes("refunds:write"))
+
+		task := newTask(map[string]any{types.AuthScopesMetadataKey: []any{"refunds:write"}}, nil)
+		assert.Nil(t, checkToolPreconditions(context.Background(), tool, nil, task, now))
+
+		task = newTask(map[string]any{}, map[string]any{types.AuthScopesMetadataKey: []string{"refunds:write"}})
+		refusal := checkToolPreconditions(context.Background(), tool, nil, task, now)
+		require.NotNil(t, refusal)
+		assert.Equal(t, PreconditionMissingScopes, refusal.Code)
+	})
+
+	t.Run("first refusal wins", func(t *testing.T) {
+		var evaluated []string
+		record := func(name string, refuse bool) Precondition {
+			return PreconditionFunc(func(ctx context.Context, input PreconditionInput) *PreconditionRefusal {
+				evaluated = append(evaluated, name)
+				assert.Equal(t, "lookup", input.Tool.GetName())
+				assert.Equal(t, "value", input.Arguments["param"])
+				assert.Equal(t, now, input.Now)
+				if refuse {
+					return &PreconditionRefusal{Code: "custom", Message: name}
+				}
+				return nil
+			})
+		}
+
+		tool := NewBasicTool("lookup", "", nil, execute).WithPreconditions(record("first", false), record("second", true), record("third", true))
+		refusal := checkToolPreconditions(context.Background(), tool, map[string]any{"param": "value"}, nil, now)
+		require.NotNil(t, refusal)
+		assert.Equal(t, "second", refusal.Message)
+		assert.Equal(t, []string{"first", "second"}, evaluated)
+	})
+}
+
+func TestProtocolHandler_RecordsCallerScopes(t *testing.T) {
+	logger := zap.NewNop()
+	storage := NewInMemoryStorage(logger, 20)
+	taskManager := NewDefaultTaskManagerWithStorage(logger, storage)
+	h := NewDefaultA2AProtocolHandler(logger, storage, taskManager, NewDefaultResponseSender(logger))
+
+	spoofed := map[string]any{types.AuthScopesMetadataKey: []string{"admin"}}
+	params := types.MessageSendParams{Message: types.Message{
+		MessageID: "m1",
+		Role:      types.RoleUser,
+		Parts:     []types.Part{types.CreateTextPart("hello")},
+		Metadata:  &spoofed,
+	}}
+
+	ctx := middlewares.ContextWithScopes(context.Background(), []string{"orders:read"})
+	task, refusal, err := h.createTaskFromMessage(ctx, params)
+	require.NoError(t, err)
+	require.Nil(t, refusal)
+	require.NotNil(t, task.Metadata)
+	assert.Equal(t, []string{"orders:read"}, (*task.Metadata)[types.AuthScopesMetadataKey])
+
+	tool := NewBasicTool("admin", "", nil, nil).WithPreconditions(RequireScopes("admin"))
+	assert.NotNil(t, checkToolPreconditions(context.Background(), tool, nil, task, time.Now()))
+
+	taskID := task.ID
+	require.NoError(t, taskManager.PauseTaskForInput(taskID, &types.Message{MessageID: "a1", Role: types.RoleAgent}))
+	params.Message.MessageID = "m2"
+	params.Message.TaskID = &taskID
+	params.Message.Metadata = nil
+	task, _, err = h.createTaskFromMessage(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, task.Metadata)
+	assert.NotContains(t, *task.Metadata, types.AuthScopesMetadataKey)
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// TaskProgressReporter reports the progress of a working task. Task handlers and tools take it
+// from their context with ProgressReporterFromContext.
+type TaskProgressReporter interface {
+	// Update reports the share of the work done, from 0 to 100, with a note on the current step
+	Update(percent int, note string)
+}
+
+// ProgressReporterContextKey carries the TaskProgressReporter of the task being processed
+const ProgressReporterContextKey ContextKey = "progressReporter"
+
+// ProgressReporterFromContext returns the progress reporter of the task being processed. Outside
+// of a task it returns a reporter that discards the updates, so callers need no check.
+func ProgressReporterFromContext(ctx context.Context) TaskProgressReporter {
+	if reporter, ok := ctx.Value(ProgressReporterContextKey).(TaskProgressReporter); ok && reporter != nil {
+		return reporter
+	}
+	return discardProgress{}
+}
+
+// discardProgress is the progress reporter of a context without a task
+type discardProgress struct{}
+
+func (discardProgress) Update(int, string) {}
+
+// progressUpdateBuffer bounds the progress updates waiting to be streamed; an update is dropped
+// and counted when the stream falls behind, the task keeping the latest one
+const progressUpdateBuffer = 16
+
+// taskProgress reports the progress of one task, recording the latest progress in the task
+// metadata for polling clients and handing each update to the stream of the task, if any
+type taskProgress struct {
+	taskManager TaskManager
+	clock       Clock
+	taskID      string
+	logger      *zap.Logger
+	updates     chan types.TaskProgress
+
+	mu     sync.Mutex
+	latest *types.TaskProgress
+}
+
+var _ TaskProgressReporter = (*taskProgress)(nil)
+
+// newTaskProgress returns the progress reporter of a task. The updates of a streamed task are
+// also delivered on C.
+func newTaskProgress(taskManager TaskManager, clock Clock, taskID string, logger *zap.Logger, streamed bool) *taskProgress {
+	progress := &taskProgress{taskManager: taskManager, clock: clock, taskID: taskID, logger: logger}
+	if streamed {
+		progress.updates = make(chan types.TaskProgress, progressUpdateBuffer)
+	}
+	return progress
+}
+
+// context returns the context with the progress reporter, for the task handler and its tools
+func (p *taskProgress) context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ProgressReporterContextKey, TaskProgressReporter(p))
+}
+
+// Update records the progress of the task. The percentage is clamped to 0-100.
+func (p *taskProgress) Update(percent int, note string) {
+	progress := types.TaskProgress{
+		Percent:   min(max(percent, 0), 100),
+		Note:      note,
+		UpdatedAt: p.clock.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.latest = &progress
+	p.store(progress)
+
+	if p.updates != nil {
+		select {
+		case p.updates <- progress:
+		default:
+			droppedEvents.add(dropChannelProgress, 1)
+			p.logger.Warn("stream is not receiving, dropping progress update",
+				zap.String("task_id", p.taskID),
+				zap.String("channel", dropChannelProgress))
+		}
+	}
+}
+
+// store records the progress on the stored task while it is working
+func (p *taskProgress) store(progress types.TaskProgress) {
+	task, exists := p.taskManager.GetTask(p.taskID)
+	if !exists || task.Status.State != types.TaskStateWorking {
+		return
+	}
+	setTaskProgress(task, progress)
+	if err := p.taskManager.UpdateTask(task); err != nil {
+		p.logger.Warn("failed to store task progress",
+			zap.String("task_id", p.taskID),
+			zap.Error(err))
+	}
+}
+
+// C returns the channel the updates of a streamed task are delivered on
+func (p *taskProgress) C() <-chan types.TaskProgress {
+	if p == nil {
+		return nil
+	}
+	return p.updates
+}
+
+// apply records the latest progress on a task about to be saved by its handler, which would
+// otherwise replace the progress stored meanwhile
+func (p *taskProgress) apply(task *types.Task) {
+	if p == nil || task == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.latest != nil {
+		setTaskProgress(task, *p.latest)
+	}
+}
+
+// setTaskProgress records the progress in the task metadata
+func setTaskProgress(task *types.Task, progress types.TaskProgress) {
+	setTaskMetadata(task, types.ProgressMetadataKey, progress)
+}
+
+// writeProgress streams a working status update carrying the progress in its metadata
+func (h *DefaultA2AProtocolHandler) writeProgress(c *gin.Context, id any, task *types.Task, progress types.TaskProgress) error {
+	now := h.clock.Now()
+	metadata := types.Struct{types.ProgressMetadataKey: progress}
+	response := types.JSONRPCSuccessResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Result: types.TaskStatusUpdateEvent{
+			TaskID:    task.ID,
+			ContextID: task.ContextID,
+			Status: types.TaskStatus{
+				State:     types.TaskStateWorking,
+				Timestamp: &now,
+			},
+			Metadata: &metadata,
+		},
+	}
+	return h.writeStreamingResponse(c, &response)
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// progressStreamHandler streams one delta, reports progress through the reporter of its
+// context and finishes
+type progressStreamHandler struct{}
+
+func (h *progressStreamHandler) HandleStreamingTask(ctx context.Context, task *types.Task, message *types.Message) (<-chan cloudevents.Event, error) {
+	events := make(chan cloudevents.Event)
+	go func() {
+		defer close(events)
+		events <- types.NewDeltaEvent(&types.Message{
+			MessageID: "delta-1",
+			Role:      types.RoleAgent,
+			Parts:     []types.Part{types.CreateTextPart("working")},
+		})
+		reporter := ProgressReporterFromContext(ctx)
+		reporter.Update(25, "fetching")
+		reporter.Update(75, "summarizing")
+		// leaves the stream time to deliver the updates before the task completes
+		time.Sleep(50 * time.Millisecond)
+	}()
+	return events, nil
+}
+
+func (h *progressStreamHandler) SetAgent(OpenAICompatibleAgent) {}
+
+func (h *progressStreamHandler) GetAgent() OpenAICompatibleAgent { return nil }
+
+func TestTaskProgress(t *testing.T) {
+	taskManager := NewDefaultTaskManager(zap.NewNop())
+	task := taskManager.CreateTask("ctx", types.TaskStateWorking, &types.Message{MessageID: "m1", Role: types.RoleUser})
+	progress := newTaskProgress(taskManager, SystemClock{}, task.ID, zap.NewNop(), false)
+
+	reporter := ProgressReporterFromContext(progress.context(context.Background()))
+	reporter.Update(150, "almost there")
+
+	stored, exists := taskManager.GetTask(task.ID)
+	require.True(t, exists)
+	recorded, err := types.GetTaskProgress(stored)
+	require.NoError(t, err)
+	require.NotNil(t, recorded, "the progress is stored for polling clients")
+	assert.Equal(t, 100, recorded.Percent, "the percentage is clamped")
+	assert.Equal(t, "almost there", recorded.Note)
+
+	progress.apply(task)
+	applied, err := types.GetTaskProgress(task)
+	require.NoError(t, err)
+	assert.Equal(t, recorded, applied, "the task saved by the handler keeps the progress")
+
+	t.Run("updates a stream does not receive are counted as dropped", func(t *testing.T) {
+		before := droppedEvents.snapshot()[dropChannelProgress]
+		streamed := newTaskProgress(taskManager, SystemClock{}, task.ID, zap.NewNop(), true)
+		for i := range progressUpdateBuffer + 2 {
+			streamed.Update(i, "")
+		}
+		assert.Len(t, streamed.C(), progressUpdateBuffer)
+		assert.Equal(t, int64(2), droppedEvents.snapshot()[dropChannelProgress]-before)
+	})
+
+	t.Run("context without a task discards updates", func(t *testing.T) {
+		assert.NotPanics(t, func() { ProgressReporterFromContext(context.Background()).Update(10, "ignored") })
+	})
+}
+
+func TestA2AServer_StreamsProgress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{CapabilitiesConfig: config.CapabilitiesConfig{Streaming: true}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "agent"})
+	s.SetStreamingTaskHandler(&progressStreamHandler{})
+	httpServer := httptest.NewServer(s.setupRouter(cfg))
+	t.Cleanup(httpServer.Close)
+
+	stream := openDrainTestStream(t, httpServer)
+	var notes []string
+	var taskID string
+	for _, status := range collectStatusEvents(t, stream) {
+		taskID = status.TaskID
+		if status.Metadata == nil {
+			continue
+		}
+		if _, ok := (*status.Metadata)[types.ProgressMetadataKey]; !ok {
+			continue
+		}
+		assert.Equal(t, types.TaskStateWorking, status.Status.State)
+		assert.False(t, status.Final)
+		task := types.Task{Metadata: status.Metadata}
+		progress, err := types.GetTaskProgress(&task)
+		require.NoError(t, err)
+		notes = append(notes, progress.Note)
+	}
+	assert.Equal(t, []string{"fetching", "summarizing"}, notes)
+
+	task, exists := s.taskManager.GetTask(taskID)
+	require.True(t, exists)
+	progress, err := types.GetTaskProgress(task)
+	require.NoError(t, err)
+	require.NotNil(t, progress)
+	assert.Equal(t, 75, progress.Percent, "the final task keeps the latest progress")
+}
@@ -169,6 +169,59 @@ func TestProtocolHandler_HandleTaskResubscribe_WorkingTaskInvokesStreamingHandle
 	assert.GreaterOrEqual(t, strings.Count(body, "data: "), 2)
 }
 
+func TestProtocolHandler_HandleTaskResubscribe_StreamsArtifactUpdates(t *testing.T) {
+	h, _, taskManager, _ := makeProtocolHandlerWithMocks(t)
+	workingTask := &types.Task{
+		ID:        "task-working",
+		ContextID: "ctx-1",
+		Status: types.TaskStatus{
+			State: types.TaskStateWorking,
+		},
+	}
+	taskManager.GetTaskReturns(workingTask, true)
+
+	streamingHandler := &mocks.FakeStreamableTaskHandler{}
+	events := make(chan cloudevents.Event, 1)
+	events <- types.NewArtifactUpdateEvent(types.Artifact{
+		ArtifactID: "artifact-1",
+		Parts:      []types.Part{types.CreateTextPart(strings.Repeat("a", server.DefaultArtifactChunkSize+1))},
+	})
+	close(events)
+	streamingHandler.HandleStreamingTaskReturns(events, nil)
+
+	c, w := newRequestContext(t, "{}")
+	reqID := any("req-1")
+	req := types.JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      &reqID,
+		Method:  "tasks/resubscribe",
+		Params:  map[string]any{"name": "task-working"},
+	}
+
+	h.HandleTaskResubscribe(c, req, streamingHandler)
+
+	var updates []types.TaskArtifactUpdateEvent
+	for _, line := range strings.Split(w.Body.String(), "\n") {
+		if !strings.HasPrefix(line, "data: {") {
+			continue
+		}
+		var response struct {
+			Result types.TaskArtifactUpdateEvent `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &response))
+		if response.Result.Artifact.ArtifactID != "" {
+			updates = append(updates, response.Result)
+		}
+	}
+
+	require.Len(t, updates, 2)
+	assert.False(t, *updates[0].Append)
+	assert.True(t, *updates[1].Append)
+	assert.True(t, *updates[1].LastChunk)
+	require.Len(t, workingTask.Artifacts, 1)
+	assert.Equal(t, "artifact-1", workingTask.Artifacts[0].ArtifactID)
+}
+
 func TestProtocolHandler_HandleGetAuthenticatedExtendedCard_ReturnsCard(t *testing.T) {
 	h, _, _, _ := makeProtocolHandlerWithMocks(t)
 
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	otel "github.com/inference-gateway/adk/server/otel"
+)
+
+// Providers lists the constructors an A2A server is assembled from, in a shape that
+// dependency injection frameworks accept directly, e.g. fx.Provide(server.Providers...).
+// The graph requires a *config.Config and a *zap.Logger to be supplied by the application.
+// Replace any provider to swap the corresponding component.
+var Providers = []any{
+	ProvideIDGenerator,
+	ProvideClock,
+	ProvideHTTPClient,
+	ProvideTelemetry,
+	ProvideStorage,
+	ProvideTaskManager,
+	ProvideResponseSender,
+	ProvideProtocolHandler,
+	ProvideA2AServer,
+}
+
+// ProvideIDGenerator provides the default UUID generator
+func ProvideIDGenerator() IDGenerator {
+	return UUIDGenerator{}
+}
+
+// ProvideClock provides the system clock
+func ProvideClock() Clock {
+	return SystemClock{}
+}
+
+// ProvideHTTPClient provides the HTTP client used for outgoing webhooks
+func ProvideHTTPClient() HTTPDoer {
+	return &http.Client{Timeout: 30 * time.Second}
+}
+
+// ProvideTelemetry provides the OpenTelemetry instance, or nil when telemetry is disabled
+func ProvideTelemetry(cfg *config.Config, logger *zap.Logger) (otel.OpenTelemetry, error) {
+	if !cfg.TelemetryConfig.Enable {
+		return nil, nil
+	}
+	return otel.NewOpenTelemetry(cfg, logger)
+}
+
+// ProvideStorage provides the storage configured by the queue configuration, or in-memory
+// storage when no provider is configured
+func ProvideStorage(cfg *config.Config, logger *zap.Logger) (Storage, error) {
+	if cfg.QueueConfig.Provider == "" {
+		return NewInMemoryStorage(logger, cfg.AgentConfig.MaxConversationHistory), nil
+	}
+	return CreateStorage(context.Background(), cfg.QueueConfig, logger)
+}
+
+// ProvideTaskManager provides the default task manager with push notifications sent
+// through the injected HTTP client
+func ProvideTaskManager(logger *zap.Logger, storage Storage, ids IDGenerator, clock Clock, httpClient HTTPDoer) TaskManager {
+	taskManager := NewDefaultTaskManagerWithStorage(logger, storage)
+	taskManager.SetIDGenerator(ids)
+	taskManager.SetClock(clock)
+	taskManager.SetNotificationSender(NewHTTPPushNotificationSenderWithClient(logger, httpClient))
+	return taskManager
+}
+
+// ProvideResponseSender provides the default JSON-RPC response sender
+func ProvideResponseSender(logger *zap.Logger) ResponseSender {
+	return NewDefaultResponseSender(logger)
+}
+
+// ProvideProtocolHandler provides the default A2A protocol handler
+func ProvideProtocolHandler(logger *zap.Logger, storage Storage, taskManager TaskManager, responseSender ResponseSender, ids IDGenerator, clock Clock) A2AProtocolHandler {
+	protocolHandler := NewDefaultA2AProtocolHandler(logger, storage, taskManager, responseSender)
+	protocolHandler.SetIDGenerator(ids)
+	protocolHandler.SetClock(clock)
+	return protocolHandler
+}
+
+// ProvideA2AServer provides the A2A server assembled from the injected components
+func ProvideA2AServer(
+	cfg *config.Config,
+	logger *zap.Logger,
+	telemetry otel.OpenTelemetry,
+	storage Storage,
+	taskManager TaskManager,
+	responseSender ResponseSender,
+	protocolHandler A2AProtocolHandler,
+) A2AServer {
+	return NewA2AServerWithDependencies(cfg, logger, telemetry, storage, taskManager, responseSender, protocolHandler)
+}
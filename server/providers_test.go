@@ -0,0 +1,101 @@
+package server_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	server "github.com/inference-gateway/adk/server"
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+type sequentialIDs struct {
+	next int
+}
+
+func (g *sequentialIDs) NewID() string {
+	g.next++
+	return fmt.Sprintf("id-%d", g.next)
+}
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+type recordingHTTPClient struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func (c *recordingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, req.URL.String())
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+func TestProviders_AssembleServer(t *testing.T) {
+	cfg := &config.Config{}
+	logger := zap.NewNop()
+	ids := &sequentialIDs{}
+	clock := fixedClock{now: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	httpClient := &recordingHTTPClient{}
+
+	telemetry, err := server.ProvideTelemetry(cfg, logger)
+	require.NoError(t, err)
+	assert.Nil(t, telemetry)
+
+	storage, err := server.ProvideStorage(cfg, logger)
+	require.NoError(t, err)
+	taskManager := server.ProvideTaskManager(logger, storage, ids, clock, httpClient)
+	responseSender := server.ProvideResponseSender(logger)
+	protocolHandler := server.ProvideProtocolHandler(logger, storage, taskManager, responseSender, ids, clock)
+	a2aServer := server.ProvideA2AServer(cfg, logger, telemetry, storage, taskManager, responseSender, protocolHandler)
+	require.NotNil(t, a2aServer)
+
+	task := taskManager.CreateTask("ctx-1", types.TaskStateSubmitted, nil)
+	assert.Equal(t, "id-1", task.ID)
+	require.NotNil(t, task.Status.Timestamp)
+	assert.Equal(t, clock.now, *task.Status.Timestamp)
+
+	pushConfig, err := taskManager.SetTaskPushNotificationConfig(types.TaskPushNotificationConfig{
+		Name:                   task.ID,
+		PushNotificationConfig: types.PushNotificationConfig{URL: "https://hooks.example.com/task"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "id-2", *pushConfig.PushNotificationConfig.ID)
+
+	require.NoError(t, taskManager.UpdateState(task.ID, types.TaskStateWorking))
+	assert.Eventually(t, func() bool {
+		httpClient.mu.Lock()
+		defer httpClient.mu.Unlock()
+		return len(httpClient.requests) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestProviders(t *testing.T) {
+	assert.Len(t, server.Providers, 9)
+	assert.Equal(t, server.UUIDGenerator{}, server.ProvideIDGenerator())
+	assert.Equal(t, server.SystemClock{}, server.ProvideClock())
+	assert.IsType(t, &http.Client{}, server.ProvideHTTPClient())
+
+	_, err := server.ProvideStorage(&config.Config{QueueConfig: config.QueueConfig{Provider: "unknown"}}, zap.NewNop())
+	assert.Error(t, err)
+
+	ids := server.UUIDGenerator{}
+	assert.NotEqual(t, ids.NewID(), ids.NewID())
+	assert.WithinDuration(t, time.Now(), server.SystemClock{}.Now(), time.Second)
+}
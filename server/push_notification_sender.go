@@ -19,7 +19,7 @@ type PushNotificationSender interface {
 
 // HTTPPushNotificationSender implements push notifications via HTTP webhooks
 type HTTPPushNotificationSender struct {
-	httpClient *http.Client
+	httpClient HTTPDoer
 	logger     *zap.Logger
 }
 
@@ -33,6 +33,15 @@ func NewHTTPPushNotificationSender(logger *zap.Logger) *HTTPPushNotificationSend
 	}
 }
 
+// NewHTTPPushNotificationSenderWithClient creates a new HTTP-based push notification sender
+// that sends webhooks through the given HTTP client
+func NewHTTPPushNotificationSenderWithClient(logger *zap.Logger, httpClient HTTPDoer) *HTTPPushNotificationSender {
+	return &HTTPPushNotificationSender{
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
 // TaskUpdateNotification represents the payload sent to webhook URLs
 type TaskUpdateNotification struct {
 	Type      string      `json:"type"`
@@ -0,0 +1,478 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// PIIMatch is an occurrence of personal data in a text, by byte offsets
+type PIIMatch struct {
+	// Entity is the kind of personal data, such as config.PIIEntityEmail
+	Entity string
+
+	// Start and End are the byte offsets of the occurrence in the text
+	Start int
+	End   int
+
+	// Score is the confidence of the detector, 1 for regular expression matches
+	Score float64
+}
+
+// PIIDetector finds personal data in text
+type PIIDetector interface {
+	// Detect returns the occurrences of personal data in the text, in any order
+	Detect(ctx context.Context, text string) ([]PIIMatch, error)
+}
+
+// piiEntity is the name of a PII entity in findings and the placeholder that masks it. The
+// order of piiEntities decides which entity wins where matches overlap.
+type piiEntity struct {
+	entity      string
+	name        string
+	placeholder string
+}
+
+var piiEntities = []piiEntity{
+	{entity: config.PIIEntityEmail, name: "email", placeholder: "[EMAIL]"},
+	{entity: config.PIIEntityCardNumber, name: "card number", placeholder: "[CARD_NUMBER]"},
+	{entity: config.PIIEntitySSN, name: "ssn", placeholder: "[SSN]"},
+	{entity: config.PIIEntityPhone, name: "phone number", placeholder: "[PHONE]"},
+	{entity: config.PIIEntityIPAddress, name: "ip address", placeholder: "[IP_ADDRESS]"},
+	{entity: config.PIIEntityPerson, name: "person", placeholder: "[PERSON]"},
+	{entity: config.PIIEntityLocation, name: "location", placeholder: "[LOCATION]"},
+	{entity: config.PIIEntityOrganization, name: "organization", placeholder: "[ORGANIZATION]"},
+}
+
+// lookupPIIEntity returns the rank, name and placeholder of an entity. Entities of custom
+// detectors rank last and are masked with their name in upper case.
+func lookupPIIEntity(entity string) (int, piiEntity) {
+	for i, known := range piiEntities {
+		if known.entity == entity {
+			return i, known
+		}
+	}
+	return len(piiEntities), piiEntity{
+		entity:      entity,
+		name:        strings.ReplaceAll(entity, "_", " "),
+		placeholder: "[" + strings.ToUpper(entity) + "]",
+	}
+}
+
+// piiPattern is a regular expression matching a PII entity, with an optional check of the match
+type piiPattern struct {
+	entity  string
+	pattern *regexp.Regexp
+	valid   func(match string) bool
+}
+
+var piiPatterns = []piiPattern{
+	{entity: config.PIIEntityEmail, pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)},
+	{entity: config.PIIEntityCardNumber, pattern: regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`), valid: luhnValid},
+	{entity: config.PIIEntitySSN, pattern: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`)},
+	{entity: config.PIIEntityPhone, pattern: regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?(?:\(\d{2,4}\)|\b\d{2,4})[ .-]?\d{3,4}[ .-]?\d{3,4}\b`)},
+	{entity: config.PIIEntityIPAddress, pattern: regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`)},
+}
+
+// luhnValid reports whether the digits in s pass the Luhn checksum used by card numbers
+func luhnValid(s string) bool {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] < '0' || s[i] > '9' {
+			continue
+		}
+		digit := int(s[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// regexPIIDetector finds PII entities with regular expressions
+type regexPIIDetector struct {
+	patterns []piiPattern
+}
+
+// NewRegexPIIDetector creates a detector finding email addresses, phone numbers, card numbers
+// passing the Luhn checksum, US social security numbers and IP addresses with regular
+// expressions. Without entities, all of them are found.
+func NewRegexPIIDetector(entities ...string) PIIDetector {
+	detector := &regexPIIDetector{}
+	for _, pii := range piiPatterns {
+		if len(entities) == 0 || slices.Contains(entities, pii.entity) {
+			detector.patterns = append(detector.patterns, pii)
+		}
+	}
+	return detector
+}
+
+func (d *regexPIIDetector) Detect(ctx context.Context, text string) ([]PIIMatch, error) {
+	var matches []PIIMatch
+	for _, pii := range d.patterns {
+		for _, loc := range pii.pattern.FindAllStringIndex(text, -1) {
+			if pii.valid != nil && !pii.valid(text[loc[0]:loc[1]]) {
+				continue
+			}
+			matches = append(matches, PIIMatch{Entity: pii.entity, Start: loc[0], End: loc[1], Score: 1})
+		}
+	}
+	return matches, nil
+}
+
+// NEREntity is an entity recognized by a named entity recognition model, by byte offsets in
+// the text. Labels follow the CoNLL scheme, such as PER, LOC and ORG, optionally with the B-
+// and I- prefixes of token classification models.
+type NEREntity struct {
+	Label string
+	Start int
+	End   int
+	Score float64
+}
+
+// NERModel recognizes named entities in text. Implement it with an ONNX runtime binding
+// running a token classification model exported to ONNX, such as a BERT NER model, mapping
+// the labeled tokens back to their offsets in the text.
+type NERModel interface {
+	Recognize(ctx context.Context, text string) ([]NEREntity, error)
+}
+
+// nerLabels maps the labels of NER models to the PII entities they name
+var nerLabels = map[string]string{
+	"PER":          config.PIIEntityPerson,
+	"PERSON":       config.PIIEntityPerson,
+	"LOC":          config.PIIEntityLocation,
+	"LOCATION":     config.PIIEntityLocation,
+	"GPE":          config.PIIEntityLocation,
+	"ORG":          config.PIIEntityOrganization,
+	"ORGANIZATION": config.PIIEntityOrganization,
+}
+
+// nerPIIDetector finds the names of people, locations and organizations with a NER model
+type nerPIIDetector struct {
+	model    NERModel
+	minScore float64
+}
+
+// NewNERPIIDetector creates a detector finding the names of people, locations and
+// organizations with a named entity recognition model. Entities scored below minScore are
+// ignored, and the tokens continuing an entity are merged into it.
+func NewNERPIIDetector(model NERModel, minScore float64) PIIDetector {
+	return &nerPIIDetector{model: model, minScore: minScore}
+}
+
+func (d *nerPIIDetector) Detect(ctx context.Context, text string) ([]PIIMatch, error) {
+	recognized, err := d.model.Recognize(ctx, text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recognize entities: %w", err)
+	}
+
+	var matches []PIIMatch
+	for _, entity := range recognized {
+		label := strings.ToUpper(entity.Label)
+		inside := strings.HasPrefix(label, "I-")
+		label = strings.TrimPrefix(strings.TrimPrefix(label, "B-"), "I-")
+		kind, ok := nerLabels[label]
+		if !ok || entity.Score < d.minScore || entity.Start < 0 || entity.End > len(text) || entity.Start >= entity.End {
+			continue
+		}
+
+		if n := len(matches); inside && n > 0 {
+			last := &matches[n-1]
+			if last.Entity == kind && last.End <= entity.Start && strings.TrimSpace(text[last.End:entity.Start]) == "" {
+				last.End = entity.End
+				last.Score = min(last.Score, entity.Score)
+				continue
+			}
+		}
+		matches = append(matches, PIIMatch{Entity: kind, Start: entity.Start, End: entity.End, Score: entity.Score})
+	}
+	return matches, nil
+}
+
+// Redactor masks personal data in text, messages and artifacts, replacing each occurrence
+// with a placeholder naming its entity, such as [EMAIL]. Where the matches of its detectors
+// overlap, emails win over card numbers, card numbers over social security numbers, then
+// phone numbers, IP addresses and the entities of a NER model.
+type Redactor struct {
+	entities  []string
+	detectors []PIIDetector
+}
+
+// NewRedactor creates a redactor masking the entities found by the regular expression
+// detector and the given detectors, such as a NER model. Without entities, every entity the
+// detectors find is masked.
+func NewRedactor(entities []string, detectors ...PIIDetector) *Redactor {
+	return &Redactor{
+		entities:  entities,
+		detectors: append([]PIIDetector{NewRegexPIIDetector(entities...)}, detectors...),
+	}
+}
+
+// AddDetectors adds detectors to the redactor, before it is used
+func (r *Redactor) AddDetectors(detectors ...PIIDetector) {
+	r.detectors = append(r.detectors, detectors...)
+}
+
+// Entities returns the entities the redactor masks, or nil when it masks every entity found
+func (r *Redactor) Entities() []string {
+	return slices.Clone(r.entities)
+}
+
+// Redact returns the text with its personal data masked, and the entities masked
+func (r *Redactor) Redact(ctx context.Context, text string) (string, []string, error) {
+	redacted, masked, err := r.redact(ctx, text)
+	if err != nil {
+		return "", nil, err
+	}
+	var entities []string
+	for _, match := range masked {
+		entities = addPIIEntities(entities, match.Entity)
+	}
+	return redacted, entities, nil
+}
+
+// redact returns the text with its personal data masked, and the occurrences masked
+func (r *Redactor) redact(ctx context.Context, text string) (string, []PIIMatch, error) {
+	var matches []PIIMatch
+	for _, detector := range r.detectors {
+		found, err := detector.Detect(ctx, text)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, match := range found {
+			if match.Start < 0 || match.End > len(text) || match.Start >= match.End {
+				continue
+			}
+			if len(r.entities) > 0 && !slices.Contains(r.entities, match.Entity) {
+				continue
+			}
+			matches = append(matches, match)
+		}
+	}
+	if len(matches) == 0 {
+		return text, nil, nil
+	}
+
+	rank := func(match PIIMatch) int {
+		i, _ := lookupPIIEntity(match.Entity)
+		return i
+	}
+	// higher ranked entities claim their text first
+	slices.SortStableFunc(matches, func(a, b PIIMatch) int {
+		return cmp.Or(cmp.Compare(rank(a), rank(b)), cmp.Compare(a.Start, b.Start))
+	})
+	var masked []PIIMatch
+	for _, match := range matches {
+		overlaps := slices.ContainsFunc(masked, func(other PIIMatch) bool {
+			return match.Start < other.End && other.Start < match.End
+		})
+		if !overlaps {
+			masked = append(masked, match)
+		}
+	}
+	slices.SortFunc(masked, func(a, b PIIMatch) int { return cmp.Compare(a.Start, b.Start) })
+
+	var b strings.Builder
+	last := 0
+	for _, match := range masked {
+		_, entity := lookupPIIEntity(match.Entity)
+		b.WriteString(text[last:match.Start])
+		b.WriteString(entity.placeholder)
+		last = match.End
+	}
+	b.WriteString(text[last:])
+	return b.String(), masked, nil
+}
+
+// RedactMessage masks the personal data in the text parts of a message and in the strings of
+// its data parts in place, returning the entities masked
+func (r *Redactor) RedactMessage(ctx context.Context, message *types.Message) ([]string, error) {
+	parts, entities, err := r.redactParts(ctx, message.Parts)
+	if err != nil {
+		return nil, err
+	}
+	message.Parts = parts
+	return entities, nil
+}
+
+// RedactArtifact masks the personal data in the text parts of an artifact and in the strings
+// of its data parts in place, returning the entities masked
+func (r *Redactor) RedactArtifact(ctx context.Context, artifact *types.Artifact) ([]string, error) {
+	parts, entities, err := r.redactParts(ctx, artifact.Parts)
+	if err != nil {
+		return nil, err
+	}
+	artifact.Parts = parts
+	return entities, nil
+}
+
+// Filter returns a guardrail filter rewriting text with its personal data masked. A detector
+// that fails blocks the message.
+func (r *Redactor) Filter() GuardrailFilter {
+	return NewGuardrailFilter("pii_redaction", func(ctx context.Context, text string) (GuardrailResult, error) {
+		redacted, entities, err := r.Redact(ctx, text)
+		if err != nil {
+			return GuardrailResult{}, err
+		}
+		if len(entities) == 0 {
+			return GuardrailResult{Action: GuardrailActionAllow}, nil
+		}
+		return GuardrailResult{
+			Action: GuardrailActionRewrite,
+			Text:   redacted,
+			Reason: piiReason(entities),
+		}, nil
+	})
+}
+
+// NewPIIRedactionFilter creates a filter that replaces email addresses, card numbers, US social
+// security numbers, phone numbers and IP addresses with placeholders such as [EMAIL]. Without
+// entities, all of them are replaced.
+func NewPIIRedactionFilter(entities ...string) GuardrailFilter {
+	return NewRedactor(entities).Filter()
+}
+
+// redactTaskArtifacts masks the personal data in the artifacts of a task before it is stored,
+// recording the artifacts redacted in the task metadata. An artifact that cannot be checked is
+// replaced by a notice that it was withheld.
+func (r *Redactor) redactTaskArtifacts(ctx context.Context, task *types.Task, logger *zap.Logger) {
+	if r == nil || task == nil {
+		return
+	}
+
+	var findings []GuardrailFinding
+	for i := range task.Artifacts {
+		artifact := &task.Artifacts[i]
+		entities, err := r.RedactArtifact(ctx, artifact)
+		if err != nil {
+			logger.Error("failed to redact artifact, withholding it",
+				zap.String("task_id", task.ID),
+				zap.String("artifact_id", artifact.ArtifactID),
+				zap.Error(err))
+			artifact.Parts = []types.Part{types.CreateTextPart("This artifact was withheld: the content could not be checked.")}
+			findings = append(findings, GuardrailFinding{
+				Filter:  "pii_redaction",
+				Action:  GuardrailActionBlock,
+				Reason:  "the content could not be checked",
+				Details: map[string]string{"artifactId": artifact.ArtifactID},
+			})
+			continue
+		}
+		if len(entities) > 0 {
+			findings = append(findings, GuardrailFinding{
+				Filter:  "pii_redaction",
+				Action:  GuardrailActionRewrite,
+				Reason:  piiReason(entities),
+				Details: map[string]string{"artifactId": artifact.ArtifactID},
+			})
+		}
+	}
+	recordTaskFindings(task, findings)
+}
+
+// redactParts returns copies of the parts with the personal data of their text and of the
+// strings of their data masked, and the entities masked
+func (r *Redactor) redactParts(ctx context.Context, parts []types.Part) ([]types.Part, []string, error) {
+	var entities []string
+	redacted := slices.Clone(parts)
+	for i, part := range redacted {
+		if part.Text != nil {
+			text, found, err := r.Redact(ctx, *part.Text)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(found) > 0 {
+				redacted[i].Text = &text
+				entities = addPIIEntities(entities, found...)
+			}
+		}
+		if part.Data != nil {
+			data, found, err := r.redactValue(ctx, part.Data.Data)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(found) > 0 {
+				redacted[i].Data = &types.DataPart{Data: data.(types.Struct)}
+				entities = addPIIEntities(entities, found...)
+			}
+		}
+	}
+	if len(entities) == 0 {
+		return parts, nil, nil
+	}
+	return redacted, entities, nil
+}
+
+// redactValue returns a copy of a JSON value with the personal data of its strings masked,
+// and the entities masked
+func (r *Redactor) redactValue(ctx context.Context, value any) (any, []string, error) {
+	var entities []string
+	switch v := value.(type) {
+	case string:
+		return r.Redact(ctx, v)
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, item := range v {
+			masked, found, err := r.redactValue(ctx, item)
+			if err != nil {
+				return nil, nil, err
+			}
+			redacted[key] = masked
+			entities = addPIIEntities(entities, found...)
+		}
+		return redacted, entities, nil
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			masked, found, err := r.redactValue(ctx, item)
+			if err != nil {
+				return nil, nil, err
+			}
+			redacted[i] = masked
+			entities = addPIIEntities(entities, found...)
+		}
+		return redacted, entities, nil
+	default:
+		return value, nil, nil
+	}
+}
+
+// addPIIEntities adds the entities missing from a list of masked entities, keeping the list
+// in the order of piiEntities
+func addPIIEntities(entities []string, found ...string) []string {
+	for _, entity := range found {
+		if !slices.Contains(entities, entity) {
+			entities = append(entities, entity)
+		}
+	}
+	slices.SortStableFunc(entities, func(a, b string) int {
+		rankA, _ := lookupPIIEntity(a)
+		rankB, _ := lookupPIIEntity(b)
+		return cmp.Compare(rankA, rankB)
+	})
+	return entities
+}
+
+// piiReason describes the entities masked in a finding
+func piiReason(entities []string) string {
+	names := make([]string, len(entities))
+	for i, entity := range entities {
+		_, known := lookupPIIEntity(entity)
+		names[i] = known.name
+	}
+	return "redacted " + strings.Join(names, ", ")
+}
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// stubNERModel returns the same entities for any text
+type stubNERModel struct {
+	entities []NEREntity
+	err      error
+}
+
+func (m *stubNERModel) Recognize(ctx context.Context, text string) ([]NEREntity, error) {
+	return m.entities, m.err
+}
+
+func TestRedactor_Redact(t *testing.T) {
+	ctx := context.Background()
+	text := "Ask Jane Doe at jane@example.com or +1 555 123 4567 in Berlin"
+	model := &stubNERModel{entities: []NEREntity{
+		{Label: "B-PER", Start: 4, End: 8, Score: 0.99},
+		{Label: "I-PER", Start: 9, End: 12, Score: 0.97},
+		{Label: "B-ORG", Start: 16, End: 20, Score: 0.42},
+		{Label: "B-MISC", Start: 36, End: 39, Score: 0.9},
+		{Label: "B-LOC", Start: 55, End: 61, Score: 0.95},
+	}}
+
+	tests := []struct {
+		name     string
+		redactor *Redactor
+		expected string
+		entities []string
+	}{
+		{
+			name:     "masks every entity the regular expressions find",
+			redactor: NewRedactor(nil),
+			expected: "Ask Jane Doe at [EMAIL] or [PHONE] in Berlin",
+			entities: []string{config.PIIEntityEmail, config.PIIEntityPhone},
+		},
+		{
+			name:     "masks only the configured entities",
+			redactor: NewRedactor([]string{config.PIIEntityPhone}),
+			expected: "Ask Jane Doe at jane@example.com or [PHONE] in Berlin",
+			entities: []string{config.PIIEntityPhone},
+		},
+		{
+			name:     "masks the names a NER model recognizes",
+			redactor: NewRedactor([]string{config.PIIEntityEmail, config.PIIEntityPerson, config.PIIEntityLocation}, NewNERPIIDetector(model, 0.5)),
+			expected: "Ask [PERSON] at [EMAIL] or +1 555 123 4567 in [LOCATION]",
+			entities: []string{config.PIIEntityEmail, config.PIIEntityPerson, config.PIIEntityLocation},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redacted, entities, err := tt.redactor.Redact(ctx, text)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, redacted)
+			assert.Equal(t, tt.entities, entities)
+		})
+	}
+
+	t.Run("higher ranked entities win overlapping matches", func(t *testing.T) {
+		overlapping := &stubNERModel{entities: []NEREntity{{Label: "ORG", Start: 8, End: 19, Score: 1}}}
+		redactor := NewRedactor(nil, NewNERPIIDetector(overlapping, 0))
+		redacted, entities, err := redactor.Redact(ctx, "Contact ops@acme.com today")
+		require.NoError(t, err)
+		assert.Equal(t, "Contact [EMAIL] today", redacted)
+		assert.Equal(t, []string{config.PIIEntityEmail}, entities)
+	})
+
+	t.Run("a failing detector fails the redaction", func(t *testing.T) {
+		redactor := NewRedactor(nil, NewNERPIIDetector(&stubNERModel{err: errors.New("model unavailable")}, 0))
+		_, _, err := redactor.Redact(ctx, text)
+		assert.ErrorContains(t, err, "model unavailable")
+
+		result, err := redactor.Filter().Check(ctx, text)
+		assert.Error(t, err, "guardrails block the text that could not be checked")
+		assert.Empty(t, result.Text)
+	})
+}
+
+func TestRedactor_RedactTaskArtifacts(t *testing.T) {
+	ctx := context.Background()
+	task := &types.Task{
+		ID: "task-1",
+		Artifacts: []types.Artifact{
+			{
+				ArtifactID: "report",
+				Parts: []types.Part{
+					types.CreateTextPart("Refund card 4111 1111 1111 1111"),
+					{Data: &types.DataPart{Data: types.Struct{
+						"customer": map[string]any{"email": "jane@example.com", "orders": []any{"A-1", "from 10.0.0.7"}},
+						"total":    42.5,
+					}}},
+				},
+			},
+			{ArtifactID: "notes", Parts: []types.Part{types.CreateTextPart("Nothing personal")}},
+		},
+	}
+
+	NewRedactor(nil).redactTaskArtifacts(ctx, task, zap.NewNop())
+
+	assert.Equal(t, "Refund card [CARD_NUMBER]", *task.Artifacts[0].Parts[0].Text)
+	data := task.Artifacts[0].Parts[1].Data.Data
+	assert.Equal(t, map[string]any{"email": "[EMAIL]", "orders": []any{"A-1", "from [IP_ADDRESS]"}}, data["customer"])
+	assert.Equal(t, 42.5, data["total"])
+	assert.Equal(t, "Nothing personal", *task.Artifacts[1].Parts[0].Text)
+	require.NotNil(t, task.Metadata)
+	assert.Equal(t, []GuardrailFinding{{
+		Filter:  "pii_redaction",
+		Action:  GuardrailActionRewrite,
+		Reason:  "redacted email, card number, ip address",
+		Details: map[string]string{"artifactId": "report"},
+	}}, (*task.Metadata)[types.GuardrailMetadataKey])
+
+	failing := NewRedactor(nil, NewNERPIIDetector(&stubNERModel{err: errors.New("model unavailable")}, 0))
+	failing.redactTaskArtifacts(ctx, task, zap.NewNop())
+	assert.Equal(t, "This artifact was withheld: the content could not be checked.", *task.Artifacts[1].Parts[0].Text)
+
+	var nilRedactor *Redactor
+	nilRedactor.redactTaskArtifacts(ctx, task, zap.NewNop())
+}
+
+func TestRedactPIITool(t *testing.T) {
+	tool := NewRedactPIITool(NewRedactor([]string{config.PIIEntityEmail, config.PIIEntityPhone}))
+	assert.Equal(t, "redact_pii", tool.GetName())
+
+	call := func(args map[string]any) map[string]any {
+		output, err := tool.Execute(context.Background(), args)
+		require.NoError(t, err)
+		var result map[string]any
+		require.NoError(t, json.Unmarshal([]byte(output), &result))
+		return result
+	}
+
+	result := call(map[string]any{"text": "Mail jane@example.com or call 555 123 4567 from 10.0.0.7"})
+	assert.Equal(t, "Mail [EMAIL] or call [PHONE] from 10.0.0.7", result["text"], "entities the redactor does not mask are kept")
+	assert.Equal(t, []any{"email", "phone"}, result["entities"])
+
+	result = call(map[string]any{"text": "Mail jane@example.com or call 555 123 4567", "entities": []any{"phone", "ip_address"}})
+	assert.Equal(t, "Mail jane@example.com or call [PHONE]", result["text"])
+
+	result = call(map[string]any{"text": "Mail jane@example.com", "entities": []any{"ssn"}})
+	assert.Equal(t, "Mail jane@example.com", result["text"])
+	assert.Equal(t, []any{}, result["entities"])
+
+	_, err := tool.Execute(context.Background(), map[string]any{})
+	assert.Error(t, err)
+}
+
+func TestA2AServer_SetupRedaction(t *testing.T) {
+	cfg := &config.Config{RedactionConfig: config.RedactionConfig{
+		Enable:    true,
+		Entities:  []string{config.PIIEntityEmail},
+		Input:     true,
+		Artifacts: true,
+	}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+
+	require.NotNil(t, s.guardrails)
+	require.Len(t, s.guardrails.input, 1)
+	assert.Equal(t, "pii_redaction", s.guardrails.input[0].Name())
+	assert.Empty(t, s.guardrails.output)
+	assert.Same(t, s.redactor, s.artifactRedactor)
+
+	message := &types.Message{MessageID: "msg-1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("I am jane@example.com, call 555 123 4567")}}
+	s.guardrails.CheckInput(context.Background(), message)
+	assert.Equal(t, "I am [EMAIL], call 555 123 4567", *message.Parts[0].Text)
+}
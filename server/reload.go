@@ -0,0 +1,280 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	uuid "github.com/google/uuid"
+	envconfig "github.com/sethvargo/go-envconfig"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// ConfigReloadHandler receives an adk.server.config.reloaded CloudEvent after the server
+// applied reloaded settings, so reloads can be audited or forwarded
+type ConfigReloadHandler func(ctx context.Context, event cloudevents.Event)
+
+// Settings that can be reloaded, as listed in the adk.server.config.reloaded event
+const (
+	ReloadedSystemPrompt   = "system_prompt"
+	ReloadedDisabledSkills = "disabled_skills"
+	ReloadedBudget         = "budget"
+	ReloadedAgentCard      = "agent_card"
+)
+
+// watchedFile detects changes of a file by its content
+type watchedFile struct {
+	path string
+	sum  [sha256.Size]byte
+}
+
+// changed reports whether the content of the file differs from the previous check
+func (f *watchedFile) changed() (bool, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return false, err
+	}
+	sum := sha256.Sum256(data)
+	if sum == f.sum {
+		return false, nil
+	}
+	f.sum = sum
+	return true, nil
+}
+
+// configReloader watches the env file and the agent card file, and applies the settings
+// that can change at runtime when they do. Settings are compared with the values the
+// files had when watching started, so settings made in code are only replaced once the
+// corresponding variable changes.
+type configReloader struct {
+	server   *A2AServerImpl
+	logger   *zap.Logger
+	interval time.Duration
+	lookuper envconfig.Lookuper
+	handler  ConfigReloadHandler
+
+	envFile  *watchedFile
+	cardFile *watchedFile
+	applied  *config.Config
+}
+
+// newConfigReloader creates the reloader of a server, or returns nil when reloading is disabled
+func newConfigReloader(server *A2AServerImpl) *configReloader {
+	cfg := server.cfg.ReloadConfig
+	if !cfg.Enable {
+		return nil
+	}
+
+	reloader := &configReloader{
+		server:   server,
+		logger:   server.logger,
+		interval: cfg.Interval,
+		lookuper: envconfig.OsLookuper(),
+	}
+	if reloader.interval <= 0 {
+		reloader.interval = 5 * time.Second
+	}
+	if cfg.EnvFile != "" {
+		reloader.envFile = &watchedFile{path: cfg.EnvFile}
+	}
+	if server.cfg.AgentCardFilePath != "" {
+		reloader.cardFile = &watchedFile{path: server.cfg.AgentCardFilePath}
+	}
+	if reloader.envFile == nil && reloader.cardFile == nil {
+		server.logger.Warn("configuration reload is enabled but neither RELOAD_ENV_FILE nor AGENT_CARD_FILE_PATH is set, nothing is watched")
+	}
+	return reloader
+}
+
+// run watches the files at the configured interval until the context is cancelled
+func (r *configReloader) run(ctx context.Context) {
+	if err := r.start(ctx); err != nil {
+		r.logger.Error("failed to start configuration reload", zap.Error(err))
+		return
+	}
+	r.logger.Info("watching configuration for changes", zap.Duration("interval", r.interval))
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.check(ctx)
+		}
+	}
+}
+
+// start records the current content of the watched files and the settings they define
+func (r *configReloader) start(ctx context.Context) error {
+	for _, file := range []*watchedFile{r.envFile, r.cardFile} {
+		if file == nil {
+			continue
+		}
+		if _, err := file.changed(); err != nil {
+			r.logger.Warn("watched file cannot be read yet", zap.String("file", file.path), zap.Error(err))
+		}
+	}
+
+	applied, err := r.load(ctx)
+	if err != nil {
+		return err
+	}
+	r.applied = applied
+	return nil
+}
+
+// check reloads the settings when a watched file changed
+func (r *configReloader) check(ctx context.Context) {
+	envChanged := r.fileChanged(r.envFile)
+	cardChanged := r.fileChanged(r.cardFile)
+	if !envChanged && !cardChanged {
+		return
+	}
+
+	changed, err := r.reload(ctx, envChanged, cardChanged)
+	if err != nil {
+		r.logger.Error("failed to reload configuration, keeping the current settings", zap.Error(err))
+		return
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	r.logger.Info("configuration reloaded", zap.Strings("changed", changed))
+	if r.handler != nil {
+		r.handler(ctx, types.NewConfigReloadedEvent(types.ConfigReload{
+			Changed:    changed,
+			ReloadID:   uuid.NewString(),
+			ReloadedAt: r.server.clock.Now().UTC().Format(time.RFC3339Nano),
+		}))
+	}
+}
+
+// fileChanged reports whether a watched file changed, logging files that cannot be read
+func (r *configReloader) fileChanged(file *watchedFile) bool {
+	if file == nil {
+		return false
+	}
+	changed, err := file.changed()
+	if err != nil {
+		r.logger.Warn("failed to read watched file", zap.String("file", file.path), zap.Error(err))
+		return false
+	}
+	return changed
+}
+
+// reload applies the settings that changed and returns their names
+func (r *configReloader) reload(ctx context.Context, envChanged, cardChanged bool) ([]string, error) {
+	var changed []string
+
+	if envChanged {
+		next, err := r.load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		changed = r.apply(next)
+		r.applied = next
+	}
+
+	if cardChanged {
+		if err := r.server.LoadAgentCardFromFile(r.cardFile.path, nil); err != nil {
+			return changed, fmt.Errorf("failed to reload agent card: %w", err)
+		}
+		changed = append(changed, ReloadedAgentCard)
+	}
+	return changed, nil
+}
+
+// load reads the configuration from the environment, with the values of the env file taking precedence
+func (r *configReloader) load(ctx context.Context) (*config.Config, error) {
+	lookuper := r.lookuper
+	if r.envFile != nil {
+		values, err := readEnvFile(r.envFile.path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		lookuper = envconfig.MultiLookuper(envconfig.MapLookuper(values), r.lookuper)
+	}
+	return config.LoadWithLookuper(ctx, nil, lookuper)
+}
+
+// apply applies the reloadable settings that differ from the applied configuration
+func (r *configReloader) apply(next *config.Config) []string {
+	var changed []string
+
+	if prompt := next.AgentConfig.SystemPrompt; prompt != r.applied.AgentConfig.SystemPrompt {
+		if agent, ok := r.server.GetAgent().(interface{ SetSystemPrompt(string) }); ok {
+			agent.SetSystemPrompt(prompt)
+			if ph, ok := r.server.protocolHandler.(*DefaultA2AProtocolHandler); ok {
+				ph.SetVersionInfo(r.server.cfg.AgentVersion, PromptVersion(prompt))
+			}
+			changed = append(changed, ReloadedSystemPrompt)
+		} else {
+			r.logger.Warn("system prompt changed but the agent does not support replacing it")
+		}
+	}
+
+	if !slices.Equal(next.DisabledSkills, r.applied.DisabledSkills) {
+		r.server.setDisabledSkills(next.DisabledSkills)
+		changed = append(changed, ReloadedDisabledSkills)
+	}
+
+	if next.AgentConfig.Budget != r.applied.AgentConfig.Budget {
+		if agent, ok := r.server.GetAgent().(*OpenAICompatibleAgentImpl); ok && agent.budget != nil {
+			agent.budget.SetConfig(next.AgentConfig.Budget)
+			changed = append(changed, ReloadedBudget)
+		} else {
+			r.logger.Warn("budget limits changed but the agent was built without a budget")
+		}
+	}
+
+	return changed
+}
+
+// readEnvFile parses an env file of KEY=value lines. Empty lines and lines starting with #
+// are skipped, an export prefix is allowed and values may be quoted.
+func readEnvFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid line %d in env file %s: expected KEY=value", number, path)
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				value = unquoted
+			}
+		} else if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	return values, scanner.Err()
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	envconfig "github.com/sethvargo/go-envconfig"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestA2AServer_ConfigReload(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop()
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, "agent.env")
+	cardFile := filepath.Join(dir, "agent-card.json")
+	writeFile := func(path, content string) {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	writeFile(envFile, "AGENT_CLIENT_SYSTEM_PROMPT=You are helpful.\nAGENT_CLIENT_BUDGET_MAX_TASK_TOKENS=1000\n")
+	writeFile(cardFile, `{"name":"weather","version":"1.0.0","skills":[{"id":"forecast"},{"id":"alerts"}]}`)
+
+	cfg := config.Config{
+		AgentCardFilePath: cardFile,
+		ReloadConfig:      config.ReloadConfig{Enable: true, EnvFile: envFile},
+	}
+	agent, err := NewAgentBuilder(logger).
+		WithConfig(&config.AgentConfig{Budget: config.BudgetConfig{MaxTaskTokens: 1000, Action: config.BudgetActionFail}}).
+		Build()
+	require.NoError(t, err)
+
+	var events []cloudevents.Event
+	built, err := NewA2AServerBuilder(cfg, logger).
+		WithAgent(agent).
+		WithDefaultTaskHandlers().
+		WithAgentCardFromFile(cardFile, nil).
+		WithConfigReloadHandler(func(ctx context.Context, event cloudevents.Event) {
+			events = append(events, event)
+		}).
+		Build()
+	require.NoError(t, err)
+	s := built.(*A2AServerImpl)
+	require.NotNil(t, s.reloader)
+	s.reloader.lookuper = envconfig.MapLookuper(map[string]string{})
+	require.NoError(t, s.reloader.start(ctx))
+
+	s.reloader.check(ctx)
+	assert.Empty(t, events, "unchanged files are not reloaded")
+
+	writeFile(envFile, `# reloaded
+export AGENT_CLIENT_SYSTEM_PROMPT="You are concise."
+AGENT_CLIENT_BUDGET_MAX_TASK_TOKENS=5000
+DISABLED_SKILLS=alerts
+`)
+	writeFile(cardFile, `{"name":"weather","version":"1.1.0","skills":[{"id":"forecast"},{"id":"alerts"}]}`)
+	s.reloader.check(ctx)
+
+	assert.Equal(t, "You are concise.", agent.currentSystemPrompt())
+	assert.Equal(t, int64(5000), agent.budget.config().MaxTaskTokens)
+
+	card, err := s.resolveAgentCard(ctx, s.GetAgentCard())
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", card.Version)
+	require.Len(t, card.Skills, 1)
+	assert.Equal(t, "forecast", card.Skills[0].ID)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, types.EventConfigReloaded, events[0].Type())
+	var reload types.ConfigReload
+	require.NoError(t, json.Unmarshal(events[0].Data(), &reload))
+	assert.Equal(t, []string{ReloadedSystemPrompt, ReloadedDisabledSkills, ReloadedBudget, ReloadedAgentCard}, reload.Changed)
+
+	writeFile(envFile, "AGENT_CLIENT_BUDGET_MAX_TASK_TOKENS=invalid\n")
+	s.reloader.check(ctx)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "You are concise.", agent.currentSystemPrompt(), "invalid files keep the current settings")
+}
+
+func TestReadEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(path, []byte(`
+# comment
+A=1
+export B = two
+C="line\nbreak"
+D='single # quoted'
+E=
+`), 0o600))
+
+	values, err := readEnvFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"A": "1", "B": "two", "C": "line\nbreak", "D": "single # quoted", "E": ""}, values)
+
+	require.NoError(t, os.WriteFile(path, []byte("A=1\nINVALID\n"), 0o600))
+	_, err = readEnvFile(path)
+	assert.ErrorContains(t, err, "invalid line 2")
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	zap "go.uber.org/zap"
+
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// requestIDExtension is the CloudEvents extension carrying the correlation ID of the call an
+// event belongs to
+const requestIDExtension = "requestid"
+
+// requestLogger returns the logger with the correlation ID of the request in ctx, if any
+func requestLogger(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	if requestID := middlewares.RequestIDFromContext(ctx); requestID != "" {
+		return logger.With(zap.String("request_id", requestID))
+	}
+	return logger
+}
+
+// taskRequestID returns the correlation ID of the latest call that sent a message to the task
+func taskRequestID(task *types.Task) string {
+	if task == nil || task.Metadata == nil {
+		return ""
+	}
+	requestID, _ := (*task.Metadata)[types.RequestIDMetadataKey].(string)
+	return requestID
+}
+
+// contextWithTaskRequestID returns a copy of ctx carrying the correlation ID recorded on the
+// task, so the logs of a task processed in the background correlate with the call that
+// queued it
+func contextWithTaskRequestID(ctx context.Context, task *types.Task) context.Context {
+	if middlewares.RequestIDFromContext(ctx) != "" {
+		return ctx
+	}
+	if requestID := taskRequestID(task); requestID != "" {
+		return middlewares.ContextWithRequestID(ctx, requestID)
+	}
+	return ctx
+}
+
+// setEventRequestID sets the correlation ID of the request in ctx on the event, if any
+func setEventRequestID(ctx context.Context, event *cloudevents.Event) {
+	if requestID := middlewares.RequestIDFromContext(ctx); requestID != "" {
+		event.SetExtension(requestIDExtension, requestID)
+	}
+}
+
+// recordTaskRequestID records the correlation ID of the call that sent a message to the task
+// in the task metadata
+func (h *DefaultA2AProtocolHandler) recordTaskRequestID(ctx context.Context, task *types.Task) {
+	requestID := middlewares.RequestIDFromContext(ctx)
+	if requestID == "" {
+		return
+	}
+
+	setTaskMetadata(task, types.RequestIDMetadataKey, requestID)
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestA2AServer_RequestIDPropagation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "assistant"})
+	sink := &recordingEventSink{}
+	s.setEventSink(sink)
+	router := s.setupRouter(cfg)
+
+	call := func(requestID, method string, params any) (*httptest.ResponseRecorder, *types.Task) {
+		encoded, err := json.Marshal(params)
+		require.NoError(t, err)
+		body := `{"jsonrpc":"2.0","id":"1","method":"` + method + `","params":` + string(encoded) + `}`
+		req := httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body))
+		req.Header.Set(types.RequestIDHeader, requestID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response struct {
+			Result *types.Task         `json:"result"`
+			Error  *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Nil(t, response.Error)
+		return w, response.Result
+	}
+
+	w, created := call("req-send", "message/send", types.MessageSendParams{
+		Message: types.Message{
+			MessageID: "m-1",
+			Role:      types.RoleUser,
+			Parts:     []types.Part{types.CreateTextPart("hello")},
+		},
+		Metadata: map[string]any{types.RequestIDMetadataKey: "spoofed"},
+	})
+	assert.Equal(t, "req-send", w.Header().Get(types.RequestIDHeader))
+	require.NotNil(t, created)
+
+	w, task := call("req-get", "tasks/get", types.TaskQueryParams{ID: created.ID})
+	assert.Equal(t, "req-get", w.Header().Get(types.RequestIDHeader))
+	require.NotNil(t, task)
+	require.NotNil(t, task.Metadata)
+	assert.Equal(t, "req-send", (*task.Metadata)[types.RequestIDMetadataKey], "the task keeps the id of the call that sent its message")
+
+	s.events.taskEvent(task, types.NewAgentEvent(types.EventToolStarted, "event-1", nil))
+	require.NoError(t, s.events.close(context.Background()))
+	require.Len(t, sink.events, 1)
+	assert.Equal(t, "req-send", sink.events[0].Extensions()[requestIDExtension])
+}
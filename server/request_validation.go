@@ -0,0 +1,331 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	uuid "github.com/google/uuid"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// Fields the A2A schema defines for the objects of message params. Messages and parts accept
+// the fields of the canonical representation and of the kind-tagged one of A2A 0.3 clients.
+var (
+	messageParamsFields = []string{"message", "configuration", "metadata"}
+	batchParamsFields   = []string{"messages"}
+	messageFields       = []string{"kind", "messageId", "contextId", "taskId", "role", "parts", "metadata", "extensions", "referenceTaskIds"}
+	partFields          = []string{"kind", "text", "file", "data", "metadata"}
+	fileFields          = []string{"name", "mimeType", "mediaType", "bytes", "fileWithBytes", "uri", "fileWithUri"}
+	configurationFields = []string{"acceptedOutputModes", "blocking", "historyLength", "metadata", "pushNotificationConfig"}
+)
+
+// messageRoles are the roles a message may be sent with
+var messageRoles = []string{"user", "agent", string(types.RoleUser), string(types.RoleAgent)}
+
+// ParamViolation is a field of the params of a request that does not match the A2A schema. The
+// violations of a rejected request are sent as the violations of its error data.
+type ParamViolation struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// paramValidator collects the violations of the params of a request
+type paramValidator struct {
+	cfg        config.RequestValidation
+	violations []ParamViolation
+}
+
+// validateParams checks the params of message requests against the A2A schema when request
+// validation is enabled. A missing message ID is generated into the params under the fill
+// policy, so the handlers see the message with it.
+func (s *A2AServerImpl) validateParams(req types.JSONRPCRequest) *JSONRPCMethodError {
+	cfg := s.cfg.ServerConfig.Validation
+	if !cfg.Enable {
+		return nil
+	}
+	switch req.Method {
+	case "message/send", "message/stream":
+		return validateMessageParams(cfg, req.Params)
+	case "message/sendBatch":
+		return validateBatchParams(cfg, req.Params)
+	default:
+		return nil
+	}
+}
+
+// validateMessageParams checks the params of a message/send or message/stream request
+func validateMessageParams(cfg config.RequestValidation, params map[string]any) *JSONRPCMethodError {
+	v := &paramValidator{cfg: cfg}
+	v.messageParams("", params)
+	return v.err()
+}
+
+// validateBatchParams checks the params of a message/sendBatch request, each of its messages
+// like the params of a message/send request
+func validateBatchParams(cfg config.RequestValidation, params map[string]any) *JSONRPCMethodError {
+	v := &paramValidator{cfg: cfg}
+	v.unknownFields("", params, batchParamsFields)
+	if params["messages"] == nil {
+		v.violate("messages", "is required")
+		return v.err()
+	}
+	messages, ok := params["messages"].([]any)
+	if !ok {
+		v.violate("messages", "must be an array of message params")
+		return v.err()
+	}
+	for i, item := range messages {
+		path := fmt.Sprintf("messages[%d]", i)
+		if object, ok := v.object(path, item, true); ok {
+			v.messageParams(path, object)
+		}
+	}
+	return v.err()
+}
+
+// messageParams checks the params of a message request at the path
+func (v *paramValidator) messageParams(path string, params map[string]any) {
+	v.unknownFields(path, params, messageParamsFields)
+	if message, ok := v.object(fieldPath(path, "message"), params["message"], true); ok {
+		v.message(fieldPath(path, "message"), message)
+	}
+	if configuration, ok := v.object(fieldPath(path, "configuration"), params["configuration"], false); ok {
+		v.configuration(fieldPath(path, "configuration"), configuration)
+	}
+	v.object(fieldPath(path, "metadata"), params["metadata"], false)
+}
+
+// violate records a violation of the field
+func (v *paramValidator) violate(field, format string, args ...any) {
+	v.violations = append(v.violations, ParamViolation{Field: field, Reason: fmt.Sprintf(format, args...)})
+}
+
+// err returns the invalid params error listing the violations, or nil when there are none
+func (v *paramValidator) err() *JSONRPCMethodError {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	reasons := make([]string, 0, len(v.violations))
+	for _, violation := range v.violations {
+		reasons = append(reasons, violation.Field+" "+violation.Reason)
+	}
+	return &JSONRPCMethodError{
+		Code:    int(ErrInvalidParams),
+		Message: "invalid params: " + strings.Join(reasons, "; "),
+		Data:    map[string]any{"violations": v.violations},
+	}
+}
+
+// unknownFields records the fields of the object the schema does not define, under the reject
+// policy
+func (v *paramValidator) unknownFields(path string, object map[string]any, known []string) {
+	if v.cfg.UnknownFields != config.ValidationUnknownFieldsReject {
+		return
+	}
+	var unknown []string
+	for field := range object {
+		if !slices.Contains(known, field) {
+			unknown = append(unknown, field)
+		}
+	}
+	slices.Sort(unknown)
+	for _, field := range unknown {
+		v.violate(fieldPath(path, field), "is not defined by the A2A schema")
+	}
+}
+
+// object checks that a value is an object, returning it. A missing value is a violation when
+// the field is required.
+func (v *paramValidator) object(path string, value any, required bool) (map[string]any, bool) {
+	if value == nil {
+		if required {
+			v.violate(path, "is required")
+		}
+		return nil, false
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		v.violate(path, "must be an object")
+	}
+	return object, ok
+}
+
+// optionalString checks that a field of an object is a string when it is set
+func (v *paramValidator) optionalString(path string, object map[string]any, field string) {
+	if value, set := object[field]; set && value != nil {
+		if _, ok := value.(string); !ok {
+			v.violate(fieldPath(path, field), "must be a string")
+		}
+	}
+}
+
+// optionalStrings checks that a field of an object is an array of strings when it is set
+func (v *paramValidator) optionalStrings(path string, object map[string]any, field string) {
+	value, set := object[field]
+	if !set || value == nil {
+		return
+	}
+	values, ok := value.([]any)
+	if !ok {
+		v.violate(fieldPath(path, field), "must be an array of strings")
+		return
+	}
+	for i, item := range values {
+		if _, ok := item.(string); !ok {
+			v.violate(fmt.Sprintf("%s[%d]", fieldPath(path, field), i), "must be a string")
+		}
+	}
+}
+
+// message checks a message, generating its message ID when it is missing under the fill policy
+func (v *paramValidator) message(path string, message map[string]any) {
+	v.unknownFields(path, message, messageFields)
+
+	if kind, set := message["kind"]; set && kind != "message" {
+		v.violate(fieldPath(path, "kind"), "must be 'message'")
+	}
+
+	switch id := message["messageId"].(type) {
+	case string:
+		if id == "" {
+			v.missingMessageID(path, message)
+		}
+	case nil:
+		v.missingMessageID(path, message)
+	default:
+		v.violate(fieldPath(path, "messageId"), "must be a string")
+	}
+
+	switch role := message["role"].(type) {
+	case string:
+		if role == "" {
+			v.violate(fieldPath(path, "role"), "is required")
+		} else if !slices.Contains(messageRoles, role) {
+			v.violate(fieldPath(path, "role"), "must be one of %s", strings.Join(messageRoles, ", "))
+		}
+	case nil:
+		v.violate(fieldPath(path, "role"), "is required")
+	default:
+		v.violate(fieldPath(path, "role"), "must be a string")
+	}
+
+	v.optionalString(path, message, "contextId")
+	v.optionalString(path, message, "taskId")
+	v.optionalStrings(path, message, "extensions")
+	v.optionalStrings(path, message, "referenceTaskIds")
+	v.object(fieldPath(path, "metadata"), message["metadata"], false)
+
+	partsPath := fieldPath(path, "parts")
+	switch parts := message["parts"].(type) {
+	case []any:
+		if len(parts) == 0 {
+			v.violate(partsPath, "must not be empty")
+		}
+		for i, part := range parts {
+			v.part(fmt.Sprintf("%s[%d]", partsPath, i), part)
+		}
+	case nil:
+		v.violate(partsPath, "is required")
+	default:
+		v.violate(partsPath, "must be an array")
+	}
+}
+
+// missingMessageID generates the ID of a message without one under the fill policy, and
+// records the violation otherwise
+func (v *paramValidator) missingMessageID(path string, message map[string]any) {
+	if v.cfg.MissingFields == config.ValidationMissingFieldsReject {
+		v.violate(fieldPath(path, "messageId"), "is required")
+		return
+	}
+	message["messageId"] = uuid.New().String()
+}
+
+// part checks that a part holds exactly one of a text, file or data, matching its kind when it
+// is tagged with one
+func (v *paramValidator) part(path string, value any) {
+	part, ok := v.object(path, value, true)
+	if !ok {
+		return
+	}
+	v.unknownFields(path, part, partFields)
+
+	var held []string
+	for _, kind := range []string{types.PartKindText, types.PartKindFile, types.PartKindData} {
+		if content, set := part[kind]; set && content != nil {
+			held = append(held, kind)
+		}
+	}
+
+	kind, tagged := part["kind"]
+	switch {
+	case tagged && !slices.Contains([]any{types.PartKindText, types.PartKindFile, types.PartKindData}, kind):
+		v.violate(fieldPath(path, "kind"), "must be one of text, file, data")
+		return
+	case tagged && (len(held) != 1 || held[0] != kind):
+		v.violate(path, "must hold the %s of its kind only", kind)
+		return
+	case len(held) == 0:
+		v.violate(path, "must hold a text, file or data")
+		return
+	case len(held) > 1:
+		v.violate(path, "must hold only one of text, file or data, not %s", strings.Join(held, " and "))
+		return
+	}
+
+	switch held[0] {
+	case types.PartKindText:
+		v.optionalString(path, part, "text")
+	case types.PartKindFile:
+		if file, ok := v.object(fieldPath(path, "file"), part["file"], true); ok {
+			v.file(fieldPath(path, "file"), file)
+		}
+	case types.PartKindData:
+		v.object(fieldPath(path, "data"), part["data"], true)
+	}
+	v.object(fieldPath(path, "metadata"), part["metadata"], false)
+}
+
+// file checks that a file holds either its bytes or a URI
+func (v *paramValidator) file(path string, file map[string]any) {
+	v.unknownFields(path, file, fileFields)
+	for _, field := range fileFields {
+		v.optionalString(path, file, field)
+	}
+
+	hasBytes := file["bytes"] != nil || file["fileWithBytes"] != nil
+	hasURI := file["uri"] != nil || file["fileWithUri"] != nil
+	if hasBytes == hasURI {
+		v.violate(path, "must hold either bytes or a uri")
+	}
+}
+
+// configuration checks the configuration of a message request
+func (v *paramValidator) configuration(path string, configuration map[string]any) {
+	v.unknownFields(path, configuration, configurationFields)
+	v.optionalStrings(path, configuration, "acceptedOutputModes")
+	if blocking, set := configuration["blocking"]; set && blocking != nil {
+		if _, ok := blocking.(bool); !ok {
+			v.violate(fieldPath(path, "blocking"), "must be a boolean")
+		}
+	}
+	if length, set := configuration["historyLength"]; set && length != nil {
+		if number, ok := length.(float64); !ok || number < 0 || number != math.Trunc(number) {
+			v.violate(fieldPath(path, "historyLength"), "must be a non-negative integer")
+		}
+	}
+	v.object(fieldPath(path, "metadata"), configuration["metadata"], false)
+	v.object(fieldPath(path, "pushNotificationConfig"), configuration["pushNotificationConfig"], false)
+}
+
+// fieldPath joins the path of an object and the name of one of its fields
+func fieldPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestValidateMessageParams(t *testing.T) {
+	lenient := config.RequestValidation{Enable: true, UnknownFields: config.ValidationUnknownFieldsIgnore, MissingFields: config.ValidationMissingFieldsFill}
+	strict := config.RequestValidation{Enable: true, UnknownFields: config.ValidationUnknownFieldsReject, MissingFields: config.ValidationMissingFieldsReject}
+
+	tests := []struct {
+		name       string
+		cfg        config.RequestValidation
+		params     string
+		violations []ParamViolation
+	}{
+		{
+			name:   "canonical message",
+			cfg:    strict,
+			params: `{"message":{"messageId":"m1","role":"ROLE_USER","parts":[{"text":"hi"},{"file":{"name":"a.png","mediaType":"image/png","fileWithUri":"https://example.com/a.png"}},{"data":{"data":{"n":1}}}]},"configuration":{"blocking":true,"historyLength":5,"acceptedOutputModes":["text/plain"]}}`,
+		},
+		{
+			name:   "kind-tagged message",
+			cfg:    strict,
+			params: `{"message":{"kind":"message","messageId":"m1","role":"user","parts":[{"kind":"text","text":"hi"},{"kind":"file","file":{"uri":"https://example.com/a.png","mimeType":"image/png"}},{"kind":"data","data":{"n":1}}]}}`,
+		},
+		{
+			name:       "missing message",
+			cfg:        lenient,
+			params:     `{}`,
+			violations: []ParamViolation{{Field: "message", Reason: "is required"}},
+		},
+		{
+			name:   "missing message id under the reject policy",
+			cfg:    strict,
+			params: `{"message":{"role":"user","parts":[{"kind":"text","text":"hi"}]}}`,
+			violations: []ParamViolation{
+				{Field: "message.messageId", Reason: "is required"},
+			},
+		},
+		{
+			name:   "malformed message",
+			cfg:    lenient,
+			params: `{"message":{"kind":"task","messageId":7,"role":"robot","contextId":1,"parts":[]},"configuration":{"historyLength":-1,"blocking":"yes"}}`,
+			violations: []ParamViolation{
+				{Field: "message.kind", Reason: "must be 'message'"},
+				{Field: "message.messageId", Reason: "must be a string"},
+				{Field: "message.role", Reason: "must be one of user, agent, ROLE_USER, ROLE_AGENT"},
+				{Field: "message.contextId", Reason: "must be a string"},
+				{Field: "message.parts", Reason: "must not be empty"},
+				{Field: "configuration.blocking", Reason: "must be a boolean"},
+				{Field: "configuration.historyLength", Reason: "must be a non-negative integer"},
+			},
+		},
+		{
+			name:   "malformed parts",
+			cfg:    lenient,
+			params: `{"message":{"messageId":"m1","role":"user","parts":["hi",{},{"text":"a","data":{}},{"kind":"image","text":"a"},{"kind":"text","data":{}},{"file":{"name":"a.txt"}},{"text":3}]}}`,
+			violations: []ParamViolation{
+				{Field: "message.parts[0]", Reason: "must be an object"},
+				{Field: "message.parts[1]", Reason: "must hold a text, file or data"},
+				{Field: "message.parts[2]", Reason: "must hold only one of text, file or data, not text and data"},
+				{Field: "message.parts[3].kind", Reason: "must be one of text, file, data"},
+				{Field: "message.parts[4]", Reason: "must hold the text of its kind only"},
+				{Field: "message.parts[5].file", Reason: "must hold either bytes or a uri"},
+				{Field: "message.parts[6].text", Reason: "must be a string"},
+			},
+		},
+		{
+			name:   "unknown fields under the reject policy",
+			cfg:    strict,
+			params: `{"tenant":"t","message":{"messageId":"m1","role":"user","priority":1,"parts":[{"text":"hi","lang":"en"}]}}`,
+			violations: []ParamViolation{
+				{Field: "tenant", Reason: "is not defined by the A2A schema"},
+				{Field: "message.priority", Reason: "is not defined by the A2A schema"},
+				{Field: "message.parts[0].lang", Reason: "is not defined by the A2A schema"},
+			},
+		},
+		{
+			name:   "unknown fields under the ignore policy",
+			cfg:    lenient,
+			params: `{"tenant":"t","message":{"messageId":"m1","role":"user","priority":1,"parts":[{"text":"hi","lang":"en"}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params map[string]any
+			require.NoError(t, json.Unmarshal([]byte(tt.params), &params))
+
+			err := validateMessageParams(tt.cfg, params)
+			if tt.violations == nil {
+				assert.Nil(t, err)
+				return
+			}
+			require.NotNil(t, err)
+			assert.Equal(t, int(ErrInvalidParams), err.Code)
+			assert.Equal(t, map[string]any{"violations": tt.violations}, err.Data)
+			assert.True(t, strings.HasPrefix(err.Message, "invalid params: "+tt.violations[0].Field+" "+tt.violations[0].Reason))
+		})
+	}
+}
+
+func TestValidateMessageParams_FillsMessageID(t *testing.T) {
+	cfg := config.RequestValidation{Enable: true, MissingFields: config.ValidationMissingFieldsFill}
+	message := map[string]any{"role": "user", "parts": []any{map[string]any{"text": "hi"}}}
+
+	assert.Nil(t, validateMessageParams(cfg, map[string]any{"message": message}))
+	assert.NotEmpty(t, message["messageId"], "the message ID is generated into the params")
+}
+
+func TestA2AServer_RequestValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{ServerConfig: config.ServerConfig{Validation: config.RequestValidation{
+		Enable:        true,
+		UnknownFields: config.ValidationUnknownFieldsReject,
+		MissingFields: config.ValidationMissingFieldsReject,
+	}}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "validated"})
+	router := s.setupRouter(cfg)
+
+	body := `{"jsonrpc":"2.0","id":"1","method":"message/send","params":{"message":{"role":"user","parts":[{"kind":"text","text":"hi"}]}}}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+
+	var response struct {
+		Error struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+			Data    struct {
+				Violations []ParamViolation `json:"violations"`
+			} `json:"data"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int(ErrInvalidParams), response.Error.Code)
+	assert.Equal(t, "invalid params: message.messageId is required", response.Error.Message)
+	assert.Equal(t, []ParamViolation{{Field: "message.messageId", Reason: "is required"}}, response.Error.Data.Violations)
+}
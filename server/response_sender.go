@@ -15,6 +15,13 @@ type ResponseSender interface {
 	SendError(c *gin.Context, id any, code int, message string)
 }
 
+// ErrorDataSender is implemented by response senders that can attach data to a JSON-RPC error
+// response, such as the violations of a request failing validation
+type ErrorDataSender interface {
+	// SendErrorWithData sends a JSON-RPC error response carrying data
+	SendErrorWithData(c *gin.Context, id any, code int, message string, data any)
+}
+
 // DefaultResponseSender implements the ResponseSender interface
 type DefaultResponseSender struct {
 	logger *zap.Logger
@@ -40,13 +47,22 @@ func (rs *DefaultResponseSender) SendSuccess(c *gin.Context, id any, result any)
 
 // SendError sends a JSON-RPC error response
 func (rs *DefaultResponseSender) SendError(c *gin.Context, id any, code int, message string) {
+	rs.SendErrorWithData(c, id, code, message, nil)
+}
+
+// SendErrorWithData sends a JSON-RPC error response carrying data, omitted when it is nil
+func (rs *DefaultResponseSender) SendErrorWithData(c *gin.Context, id any, code int, message string, data any) {
+	jsonrpcErr := &adk.JSONRPCError{
+		Code:    code,
+		Message: message,
+	}
+	if data != nil {
+		jsonrpcErr.Data = &data
+	}
 	resp := adk.JSONRPCErrorResponse{
 		JSONRPC: "2.0",
 		ID:      id,
-		Error: &adk.JSONRPCError{
-			Code:    code,
-			Message: message,
-		},
+		Error:   jsonrpcErr,
 	}
 	c.JSON(200, resp) // JSON-RPC always returns 200 OK, errors are in the response body
 	rs.logger.Error("sending error response", zap.Int("code", code), zap.String("message", message))
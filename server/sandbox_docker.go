@@ -0,0 +1,412 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+const (
+	// sandboxWorkDir is the working directory of a sandbox run, holding its input files
+	sandboxWorkDir = "/workspace"
+	// sandboxOutputDir is where a sandbox run writes the files attached to the task
+	sandboxOutputDir = "/workspace/output"
+	// sandboxProgramDir holds the program, apart from the input files
+	sandboxProgramDir = "/sandbox"
+	// sandboxUser runs the code as nobody
+	sandboxUser = "65534:65534"
+
+	dockerAPIVersion         = "v1.41"
+	defaultSandboxTimeout    = 30 * time.Second
+	sandboxCleanupTimeout    = 10 * time.Second
+	defaultSandboxDockerHost = "unix:///var/run/docker.sock"
+	defaultSandboxImage      = "python:3.12-slim"
+)
+
+// sandboxCommand is how a language runs: the file the code is written to and the command
+// running it
+type sandboxCommand struct {
+	file    string
+	command []string
+}
+
+// sandboxCommands are the languages sandboxes run, by name
+var sandboxCommands = map[string]sandboxCommand{
+	CodeLanguagePython: {file: "main.py", command: []string{"python3", sandboxProgramDir + "/main.py"}},
+	CodeLanguageShell:  {file: "main.sh", command: []string{"sh", sandboxProgramDir + "/main.sh"}},
+}
+
+// dockerSandbox runs code in an ephemeral container per run through the Docker Engine API,
+// with CPU, memory, process and time limits, no capabilities and, unless enabled, no network
+type dockerSandbox struct {
+	cfg     config.CodeExecutionConfig
+	client  *http.Client
+	baseURL string
+	hostErr error
+}
+
+var _ CodeSandbox = (*dockerSandbox)(nil)
+
+// NewDockerSandbox returns a sandbox running code in ephemeral containers of the configured
+// image, through the Docker Engine API at the configured host. The image is pulled on first use.
+func NewDockerSandbox(cfg config.CodeExecutionConfig) CodeSandbox {
+	sandbox := &dockerSandbox{cfg: cfg, client: &http.Client{}}
+	if sandbox.cfg.Image == "" {
+		sandbox.cfg.Image = defaultSandboxImage
+	}
+	if sandbox.cfg.Timeout <= 0 {
+		sandbox.cfg.Timeout = defaultSandboxTimeout
+	}
+	if sandbox.cfg.MaxOutputSize <= 0 {
+		sandbox.cfg.MaxOutputSize = defaultCodeExecutionMaxOutputSize
+	}
+	if sandbox.cfg.MaxFileSize <= 0 {
+		sandbox.cfg.MaxFileSize = defaultCodeExecutionMaxFileSize
+	}
+
+	host := cfg.DockerHost
+	if host == "" {
+		host = defaultSandboxDockerHost
+	}
+	endpoint, err := url.Parse(host)
+	switch {
+	case err != nil:
+		sandbox.hostErr = fmt.Errorf("invalid docker host %q: %w", host, err)
+	case endpoint.Scheme == "unix":
+		socket := endpoint.Path
+		sandbox.baseURL = "http://docker"
+		sandbox.client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+			},
+		}
+	case endpoint.Scheme == "tcp":
+		sandbox.baseURL = "http://" + endpoint.Host
+	case endpoint.Scheme == "http" || endpoint.Scheme == "https":
+		sandbox.baseURL = strings.TrimSuffix(endpoint.String(), "/")
+	default:
+		sandbox.hostErr = fmt.Errorf("invalid docker host %q: the scheme must be unix, tcp, http or https", host)
+	}
+	return sandbox
+}
+
+// dockerError is an error answered by the Docker Engine API
+type dockerError struct {
+	status  int
+	message string
+}
+
+func (e *dockerError) Error() string {
+	return fmt.Sprintf("docker api returned %d: %s", e.status, e.message)
+}
+
+// Run runs the code in a new container, removed once the run is over
+func (d *dockerSandbox) Run(ctx context.Context, run SandboxRun) (*SandboxResult, error) {
+	if d.hostErr != nil {
+		return nil, d.hostErr
+	}
+	command, supported := sandboxCommands[run.Language]
+	if !supported {
+		return nil, fmt.Errorf("unsupported language %q", run.Language)
+	}
+
+	id, err := d.createContainer(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sandboxCleanupTimeout)
+		defer cancel()
+		_ = d.call(cleanupCtx, http.MethodDelete, "/containers/"+id+"?force=true", nil, "", nil)
+	}()
+
+	archive, err := sandboxArchive(run, command)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.call(ctx, http.MethodPut, "/containers/"+id+"/archive?path=/", archive, "application/x-tar", nil); err != nil {
+		return nil, fmt.Errorf("failed to copy files to the sandbox: %w", err)
+	}
+	if err := d.call(ctx, http.MethodPost, "/containers/"+id+"/start", nil, "", nil); err != nil {
+		return nil, fmt.Errorf("failed to start the sandbox: %w", err)
+	}
+
+	result := &SandboxResult{}
+	runCtx, cancel := context.WithTimeout(ctx, d.cfg.Timeout)
+	defer cancel()
+	var wait struct {
+		StatusCode int `json:"StatusCode"`
+	}
+	if err := d.call(runCtx, http.MethodPost, "/containers/"+id+"/wait", nil, "", &wait); err != nil {
+		if ctx.Err() != nil || runCtx.Err() == nil {
+			return nil, fmt.Errorf("failed to wait for the sandbox: %w", err)
+		}
+		result.TimedOut = true
+		wait.StatusCode = -1
+		_ = d.call(ctx, http.MethodPost, "/containers/"+id+"/kill", nil, "", nil)
+	}
+	result.ExitCode = wait.StatusCode
+
+	if result.Stdout, result.Stderr, err = d.logs(ctx, id); err != nil {
+		return nil, err
+	}
+	files, skipped, err := d.outputFiles(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	result.Files = files
+	for _, name := range skipped {
+		result.Stderr += fmt.Sprintf("\noutput file %s was not attached: it exceeds %d bytes", name, d.cfg.MaxFileSize)
+	}
+	return result, nil
+}
+
+// createContainer creates the container of a run, pulling the image when it is missing
+func (d *dockerSandbox) createContainer(ctx context.Context, command sandboxCommand) (string, error) {
+	hostConfig := map[string]any{
+		"CapDrop":     []string{"ALL"},
+		"SecurityOpt": []string{"no-new-privileges"},
+		"Tmpfs":       map[string]string{"/tmp": "rw,noexec,size=64m"},
+	}
+	if d.cfg.MemoryLimit > 0 {
+		hostConfig["Memory"] = d.cfg.MemoryLimit
+		hostConfig["MemorySwap"] = d.cfg.MemoryLimit
+	}
+	if d.cfg.CPUs > 0 {
+		hostConfig["NanoCpus"] = int64(d.cfg.CPUs * 1e9)
+	}
+	if d.cfg.PidsLimit > 0 {
+		hostConfig["PidsLimit"] = d.cfg.PidsLimit
+	}
+	if !d.cfg.EnableNetwork {
+		hostConfig["NetworkMode"] = "none"
+	}
+	body, err := json.Marshal(map[string]any{
+		"Image":           d.cfg.Image,
+		"Cmd":             command.command,
+		"WorkingDir":      sandboxWorkDir,
+		"User":            sandboxUser,
+		"Env":             []string{"HOME=/tmp"},
+		"NetworkDisabled": !d.cfg.EnableNetwork,
+		"HostConfig":      hostConfig,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create the sandbox: %w", err)
+	}
+
+	var created struct {
+		ID string `json:"Id"`
+	}
+	err = d.call(ctx, http.MethodPost, "/containers/create", bytes.NewReader(body), "application/json", &created)
+	var apiErr *dockerError
+	if errors.As(err, &apiErr) && apiErr.status == http.StatusNotFound {
+		if err := d.call(ctx, http.MethodPost, "/images/create?fromImage="+url.QueryEscape(d.cfg.Image), nil, "", nil); err != nil {
+			return "", fmt.Errorf("failed to pull the sandbox image %s: %w", d.cfg.Image, err)
+		}
+		err = d.call(ctx, http.MethodPost, "/containers/create", bytes.NewReader(body), "application/json", &created)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create the sandbox: %w", err)
+	}
+	return created.ID, nil
+}
+
+// logs reads the stdout and stderr of a container, each up to the output size limit
+func (d *dockerSandbox) logs(ctx context.Context, id string) (string, string, error) {
+	resp, err := d.request(ctx, http.MethodGet, "/containers/"+id+"/logs?stdout=true&stderr=true", nil, "")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read the sandbox output: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	stdout := &cappedBuffer{limit: int(d.cfg.MaxOutputSize) + 1}
+	stderr := &cappedBuffer{limit: int(d.cfg.MaxOutputSize) + 1}
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(resp.Body, header); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				break
+			}
+			return "", "", fmt.Errorf("failed to read the sandbox output: %w", err)
+		}
+		target := stdout
+		if header[0] == 2 {
+			target = stderr
+		}
+		if _, err := io.CopyN(target, resp.Body, int64(binary.BigEndian.Uint32(header[4:]))); err != nil {
+			return "", "", fmt.Errorf("failed to read the sandbox output: %w", err)
+		}
+	}
+	return stdout.String(), stderr.String(), nil
+}
+
+// outputFiles reads the files the code wrote to the output directory, skipping the files
+// over the size limit
+func (d *dockerSandbox) outputFiles(ctx context.Context, id string) (map[string][]byte, []string, error) {
+	resp, err := d.request(ctx, http.MethodGet, "/containers/"+id+"/archive?path="+url.QueryEscape(sandboxOutputDir), nil, "")
+	var apiErr *dockerError
+	if errors.As(err, &apiErr) && apiErr.status == http.StatusNotFound {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the sandbox files: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	files := make(map[string][]byte)
+	var skipped []string
+	reader := tar.NewReader(resp.Body)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read the sandbox files: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := strings.TrimPrefix(path.Clean(header.Name), path.Base(sandboxOutputDir)+"/")
+		if header.Size > d.cfg.MaxFileSize {
+			skipped = append(skipped, name)
+			continue
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read the sandbox files: %w", err)
+		}
+		files[name] = data
+	}
+	return files, skipped, nil
+}
+
+// call sends a request to the Docker Engine API and decodes the JSON response into result,
+// when given
+func (d *dockerSandbox) call(ctx context.Context, method, endpoint string, body io.Reader, contentType string, result any) error {
+	resp, err := d.request(ctx, method, endpoint, body, contentType)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if result == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// request sends a request to the Docker Engine API, turning error statuses into a dockerError
+func (d *dockerSandbox) request(ctx context.Context, method, endpoint string, body io.Reader, contentType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, d.baseURL+"/"+dockerAPIVersion+endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+	var message struct {
+		Message string `json:"message"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if json.Unmarshal(data, &message) != nil || message.Message == "" {
+		message.Message = strings.TrimSpace(string(data))
+	}
+	return nil, &dockerError{status: resp.StatusCode, message: message.Message}
+}
+
+// sandboxArchive packs the program and the input files of a run, owned by the sandbox user,
+// with an empty output directory
+func sandboxArchive(run SandboxRun, command sandboxCommand) (io.Reader, error) {
+	var buf bytes.Buffer
+	writer := tar.NewWriter(&buf)
+	now := time.Now()
+	write := func(name string, mode int64, data []byte) error {
+		header := &tar.Header{Name: name, Mode: mode, Uid: 65534, Gid: 65534, ModTime: now, Size: int64(len(data)), Typeflag: tar.TypeReg}
+		if strings.HasSuffix(name, "/") {
+			header.Typeflag = tar.TypeDir
+		}
+		if err := writer.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := writer.Write(data)
+		return err
+	}
+
+	workDir := strings.TrimPrefix(sandboxWorkDir, "/") + "/"
+	programDir := strings.TrimPrefix(sandboxProgramDir, "/") + "/"
+	if err := errors.Join(
+		write(programDir, 0o755, nil),
+		write(programDir+command.file, 0o644, []byte(run.Code)),
+		write(workDir, 0o755, nil),
+		write(strings.TrimPrefix(sandboxOutputDir, "/")+"/", 0o755, nil),
+	); err != nil {
+		return nil, fmt.Errorf("failed to pack the sandbox files: %w", err)
+	}
+	for _, name := range slices.Sorted(maps.Keys(run.Files)) {
+		base := path.Base(path.Clean("/" + name))
+		if base == "/" || base == path.Base(sandboxOutputDir) {
+			return nil, fmt.Errorf("invalid input file name %q", name)
+		}
+		if err := write(workDir+base, 0o644, run.Files[name]); err != nil {
+			return nil, fmt.Errorf("failed to pack the sandbox files: %w", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to pack the sandbox files: %w", err)
+	}
+	return &buf, nil
+}
+
+// cappedBuffer keeps the first bytes written to it, up to its limit, and discards the rest,
+// reporting whether it did. The buffer is not embedded, so io.Copy cannot bypass the limit
+// through its ReadFrom method.
+type cappedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+	cut   bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	room := b.limit - b.buf.Len()
+	if room > 0 {
+		b.buf.Write(p[:min(len(p), room)])
+	}
+	if len(p) > room {
+		b.cut = true
+	}
+	return len(p), nil
+}
+
+// Bytes returns the bytes kept
+func (b *cappedBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+// String returns the bytes kept as a string
+func (b *cappedBuffer) String() string {
+	return b.buf.String()
+}
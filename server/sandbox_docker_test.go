@@ -0,0 +1,189 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+// fakeDockerAPI serves the Docker Engine API endpoints a sandbox run uses, recording the
+// container it creates and the files copied into it
+type fakeDockerAPI struct {
+	mu       sync.Mutex
+	imageOK  bool
+	pulled   []string
+	created  map[string]any
+	copied   map[string]string
+	removed  bool
+	killed   bool
+	block    bool
+	exitCode int
+	stdout   string
+	stderr   string
+	outputs  map[string]string
+}
+
+func (f *fakeDockerAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	endpoint := strings.TrimPrefix(r.URL.Path, "/"+dockerAPIVersion)
+
+	switch {
+	case r.Method == http.MethodPost && endpoint == "/containers/create":
+		if !f.imageOK {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"No such image"}`))
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&f.created)
+		_, _ = w.Write([]byte(`{"Id":"c1"}`))
+	case r.Method == http.MethodPost && endpoint == "/images/create":
+		f.pulled = append(f.pulled, r.URL.Query().Get("fromImage"))
+		f.imageOK = true
+	case r.Method == http.MethodPut && endpoint == "/containers/c1/archive":
+		f.copied = make(map[string]string)
+		reader := tar.NewReader(r.Body)
+		for {
+			header, err := reader.Next()
+			if err != nil {
+				break
+			}
+			data, _ := io.ReadAll(reader)
+			f.copied[header.Name] = string(data)
+		}
+	case r.Method == http.MethodPost && endpoint == "/containers/c1/start":
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost && endpoint == "/containers/c1/wait":
+		if f.block {
+			f.mu.Unlock()
+			<-r.Context().Done()
+			f.mu.Lock()
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]int{"StatusCode": f.exitCode})
+	case r.Method == http.MethodPost && endpoint == "/containers/c1/kill":
+		f.killed = true
+	case r.Method == http.MethodGet && endpoint == "/containers/c1/logs":
+		writeDockerFrame(w, 1, f.stdout)
+		writeDockerFrame(w, 2, f.stderr)
+	case r.Method == http.MethodGet && endpoint == "/containers/c1/archive":
+		writer := tar.NewWriter(w)
+		_ = writer.WriteHeader(&tar.Header{Name: "output/", Typeflag: tar.TypeDir, Mode: 0o755})
+		for name, content := range f.outputs {
+			_ = writer.WriteHeader(&tar.Header{Name: "output/" + name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))})
+			_, _ = writer.Write([]byte(content))
+		}
+		_ = writer.Close()
+	case r.Method == http.MethodDelete && endpoint == "/containers/c1":
+		f.removed = true
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// writeDockerFrame writes a frame of a multiplexed container log stream
+func writeDockerFrame(w io.Writer, stream byte, data string) {
+	header := make([]byte, 8)
+	header[0] = stream
+	binary.BigEndian.PutUint32(header[4:], uint32(len(data)))
+	_, _ = w.Write(append(header, data...))
+}
+
+func newFakeDocker(t *testing.T, api *fakeDockerAPI) string {
+	t.Helper()
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestDockerSandbox_Run(t *testing.T) {
+	api := &fakeDockerAPI{
+		exitCode: 3,
+		stdout:   "hello\n",
+		stderr:   "warning\n",
+		outputs:  map[string]string{"result.txt": "42", "huge.bin": strings.Repeat("x", 32)},
+	}
+	sandbox := NewDockerSandbox(config.CodeExecutionConfig{
+		DockerHost:  newFakeDocker(t, api),
+		Image:       "python:3.12-slim",
+		CPUs:        0.5,
+		MemoryLimit: 64 << 20,
+		PidsLimit:   16,
+		Timeout:     time.Second,
+		MaxFileSize: 16,
+	})
+
+	result, err := sandbox.Run(context.Background(), SandboxRun{
+		Language: CodeLanguagePython,
+		Code:     "print('hello')",
+		Files:    map[string][]byte{"../data.csv": []byte("a,b")},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"python:3.12-slim"}, api.pulled, "the missing image is pulled")
+	assert.Equal(t, "python:3.12-slim", api.created["Image"])
+	assert.Equal(t, sandboxUser, api.created["User"])
+	assert.Equal(t, true, api.created["NetworkDisabled"])
+	hostConfig := api.created["HostConfig"].(map[string]any)
+	assert.Equal(t, float64(64<<20), hostConfig["Memory"])
+	assert.Equal(t, float64(5e8), hostConfig["NanoCpus"])
+	assert.Equal(t, float64(16), hostConfig["PidsLimit"])
+	assert.Equal(t, "none", hostConfig["NetworkMode"])
+
+	assert.Equal(t, "print('hello')", api.copied["sandbox/main.py"])
+	assert.Equal(t, "a,b", api.copied["workspace/data.csv"], "input files stay in the working directory")
+	assert.Contains(t, api.copied, "workspace/output/")
+
+	assert.Equal(t, 3, result.ExitCode)
+	assert.Equal(t, "hello\n", result.Stdout)
+	assert.Contains(t, result.Stderr, "warning\n")
+	assert.Contains(t, result.Stderr, "output file huge.bin was not attached")
+	assert.Equal(t, map[string][]byte{"result.txt": []byte("42")}, result.Files)
+	assert.False(t, result.TimedOut)
+	assert.True(t, api.removed, "the container is removed")
+}
+
+func TestDockerSandbox_KillsRunsAtTheTimeLimit(t *testing.T) {
+	api := &fakeDockerAPI{imageOK: true, block: true, stdout: "partial"}
+	sandbox := NewDockerSandbox(config.CodeExecutionConfig{DockerHost: newFakeDocker(t, api), Timeout: 50 * time.Millisecond})
+
+	result, err := sandbox.Run(context.Background(), SandboxRun{Language: CodeLanguageShell, Code: "sleep 60"})
+	require.NoError(t, err)
+	assert.True(t, result.TimedOut)
+	assert.Equal(t, -1, result.ExitCode)
+	assert.Equal(t, "partial", result.Stdout)
+	assert.True(t, api.killed)
+	assert.True(t, api.removed)
+}
+
+func TestDockerSandbox_InvalidHost(t *testing.T) {
+	sandbox := NewDockerSandbox(config.CodeExecutionConfig{DockerHost: "ftp://docker"})
+	_, err := sandbox.Run(context.Background(), SandboxRun{Language: CodeLanguagePython, Code: "print(1)"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid docker host")
+}
+
+func TestSandboxArchive_RefusesOutputDirectoryName(t *testing.T) {
+	_, err := sandboxArchive(SandboxRun{Code: "x", Files: map[string][]byte{"output": nil}}, sandboxCommands[CodeLanguagePython])
+	require.Error(t, err)
+
+	archive, err := sandboxArchive(SandboxRun{Code: "x"}, sandboxCommands[CodeLanguageShell])
+	require.NoError(t, err)
+	data, err := io.ReadAll(archive)
+	require.NoError(t, err)
+	assert.True(t, bytes.Contains(data, []byte("sandbox/main.sh")))
+}
@@ -0,0 +1,463 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	cron "github.com/robfig/cron/v3"
+	zap "go.uber.org/zap"
+	yaml "gopkg.in/yaml.v3"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// defaultScheduleCheckInterval is how often schedules are checked for due runs when the
+// configuration does not set it
+const defaultScheduleCheckInterval = 10 * time.Second
+
+// ErrScheduleNotFound reports that no schedule has the requested ID
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// Schedule is a recurring agent run. Whenever its cron spec comes due, the server starts a
+// new task with the message rendered from its template and queues it for processing.
+type Schedule struct {
+	// ID identifies the schedule in the admin API and in the metadata of the tasks it starts
+	ID string `json:"id"`
+
+	// Cron is a five-field cron spec, such as 0 9 * * 1-5, or a descriptor such as @hourly or
+	// @every 30m. It is evaluated in the configured timezone unless it starts with
+	// CRON_TZ=<zone>.
+	Cron string `json:"cron"`
+
+	// Message is the text of the message of every run, a text/template executed with the
+	// ScheduleRun of the run
+	Message string `json:"message"`
+
+	// ContextID continues the same conversation context on every run, so the agent sees the
+	// earlier runs. Each run starts a new context when it is empty.
+	ContextID string `json:"contextId,omitempty"`
+
+	// Metadata is recorded on the task of every run like the metadata of a message request
+	Metadata map[string]any `json:"metadata,omitempty"`
+
+	// Webhook receives the push notifications of the tasks of the runs, including their
+	// results once they complete
+	Webhook *types.PushNotificationConfig `json:"webhook,omitempty"`
+
+	// Disabled schedules start no runs until they are enabled
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// ScheduleRun is the data the message template of a schedule is executed with
+type ScheduleRun struct {
+	ScheduleID string
+	// Run counts the runs of the schedule since the server started, starting at 1
+	Run int
+	// Time is when the run came due
+	Time time.Time
+}
+
+// ScheduleStatus is a schedule together with its runs, as listed by the admin API
+type ScheduleStatus struct {
+	Schedule
+	Runs       int        `json:"runs"`
+	NextRunAt  *time.Time `json:"nextRunAt,omitempty"`
+	LastRunAt  *time.Time `json:"lastRunAt,omitempty"`
+	LastTaskID string     `json:"lastTaskId,omitempty"`
+	LastError  string     `json:"lastError,omitempty"`
+}
+
+// ParseSchedules parses a list of schedules from its YAML or JSON definition
+func ParseSchedules(data []byte) ([]Schedule, error) {
+	var document any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse schedules: %w", err)
+	}
+	normalized, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schedules: %w", err)
+	}
+
+	var schedules []Schedule
+	decoder := json.NewDecoder(bytes.NewReader(normalized))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&schedules); err != nil {
+		return nil, fmt.Errorf("failed to parse schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// LoadSchedules reads a list of schedules from a YAML or JSON file
+func LoadSchedules(path string) ([]Schedule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schedules file: %w", err)
+	}
+	schedules, err := ParseSchedules(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return schedules, nil
+}
+
+// scheduleEntry is a registered schedule with its parsed spec and template and its runs
+type scheduleEntry struct {
+	schedule   Schedule
+	spec       cron.Schedule
+	message    *template.Template
+	runs       int
+	nextRun    time.Time
+	lastRun    time.Time
+	lastTaskID string
+	lastError  string
+}
+
+// status returns the schedule together with its runs
+func (e *scheduleEntry) status() ScheduleStatus {
+	status := ScheduleStatus{
+		Schedule:   e.schedule,
+		Runs:       e.runs,
+		LastTaskID: e.lastTaskID,
+		LastError:  e.lastError,
+	}
+	if !e.nextRun.IsZero() {
+		nextRun := e.nextRun
+		status.NextRunAt = &nextRun
+	}
+	if !e.lastRun.IsZero() {
+		lastRun := e.lastRun
+		status.LastRunAt = &lastRun
+	}
+	return status
+}
+
+// agentSchedules holds the schedules of the server in the order they were added
+type agentSchedules struct {
+	mu       sync.Mutex
+	location *time.Location
+	entries  map[string]*scheduleEntry
+	order    []string
+}
+
+// newAgentSchedules creates an empty set of schedules evaluated in the timezone
+func newAgentSchedules(timezone string) *agentSchedules {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	return &agentSchedules{location: location, entries: make(map[string]*scheduleEntry)}
+}
+
+// add registers a schedule, with its first run at the next time its spec comes due after now
+func (a *agentSchedules) add(schedule Schedule, now time.Time) error {
+	if schedule.ID == "" {
+		return fmt.Errorf("schedule id is required")
+	}
+	if strings.TrimSpace(schedule.Message) == "" {
+		return fmt.Errorf("schedule '%s': message is required", schedule.ID)
+	}
+	if schedule.Webhook != nil && schedule.Webhook.URL == "" {
+		return fmt.Errorf("schedule '%s': webhook url is required", schedule.ID)
+	}
+	spec, err := cron.ParseStandard(schedule.Cron)
+	if err != nil {
+		return fmt.Errorf("schedule '%s': invalid cron spec '%s': %w", schedule.ID, schedule.Cron, err)
+	}
+	message, err := template.New(schedule.ID).Option("missingkey=error").Parse(schedule.Message)
+	if err != nil {
+		return fmt.Errorf("schedule '%s': invalid message template: %w", schedule.ID, err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, exists := a.entries[schedule.ID]; exists {
+		return fmt.Errorf("schedule '%s' is already defined", schedule.ID)
+	}
+	entry := &scheduleEntry{schedule: schedule, spec: spec, message: message}
+	if !schedule.Disabled {
+		entry.nextRun = spec.Next(now.In(a.location))
+	}
+	a.entries[schedule.ID] = entry
+	a.order = append(a.order, schedule.ID)
+	return nil
+}
+
+// len returns the number of schedules
+func (a *agentSchedules) len() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.order)
+}
+
+// list returns the schedules with their runs
+func (a *agentSchedules) list() []ScheduleStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	statuses := make([]ScheduleStatus, 0, len(a.order))
+	for _, id := range a.order {
+		statuses = append(statuses, a.entries[id].status())
+	}
+	return statuses
+}
+
+// setEnabled enables or disables a schedule. An enabled schedule runs next when its spec
+// comes due after now, so the runs missed while it was disabled are not caught up.
+func (a *agentSchedules) setEnabled(id string, enabled bool, now time.Time) (ScheduleStatus, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	entry, exists := a.entries[id]
+	if !exists {
+		return ScheduleStatus{}, ErrScheduleNotFound
+	}
+	if entry.schedule.Disabled == !enabled {
+		return entry.status(), nil
+	}
+
+	entry.schedule.Disabled = !enabled
+	entry.nextRun = time.Time{}
+	if enabled {
+		entry.nextRun = entry.spec.Next(now.In(a.location))
+	}
+	return entry.status(), nil
+}
+
+// takeDue returns the runs of the enabled schedules that came due by now and schedules their
+// next runs after now. A schedule that came due several times since the last check runs once.
+func (a *agentSchedules) takeDue(now time.Time) ([]Schedule, []ScheduleRun) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var schedules []Schedule
+	var runs []ScheduleRun
+	for _, id := range a.order {
+		entry := a.entries[id]
+		if entry.schedule.Disabled || entry.nextRun.IsZero() || now.Before(entry.nextRun) {
+			continue
+		}
+		entry.runs++
+		schedules = append(schedules, entry.schedule)
+		runs = append(runs, ScheduleRun{ScheduleID: id, Run: entry.runs, Time: entry.nextRun})
+		entry.nextRun = entry.spec.Next(now.In(a.location))
+	}
+	return schedules, runs
+}
+
+// render executes the message template of a schedule with a run
+func (a *agentSchedules) render(run ScheduleRun) (string, error) {
+	a.mu.Lock()
+	entry, exists := a.entries[run.ScheduleID]
+	a.mu.Unlock()
+	if !exists {
+		return "", ErrScheduleNotFound
+	}
+
+	var message strings.Builder
+	if err := entry.message.Execute(&message, run); err != nil {
+		return "", fmt.Errorf("failed to render message template: %w", err)
+	}
+	return message.String(), nil
+}
+
+// recordRun records the task a run started at now, or the reason it failed to start
+func (a *agentSchedules) recordRun(id string, now time.Time, taskID string, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	entry, exists := a.entries[id]
+	if !exists {
+		return
+	}
+	entry.lastRun = now
+	entry.lastTaskID = taskID
+	entry.lastError = ""
+	if err != nil {
+		entry.lastError = err.Error()
+	}
+}
+
+// setupSchedules loads the schedules file when one is configured
+func (s *A2AServerImpl) setupSchedules() {
+	if s.cfg.ScheduleConfig.File == "" {
+		return
+	}
+
+	schedules, err := LoadSchedules(s.cfg.ScheduleConfig.File)
+	if err != nil {
+		s.logger.Error("failed to load schedules", zap.Error(err))
+		return
+	}
+	for _, schedule := range schedules {
+		if err := s.addSchedule(schedule); err != nil {
+			s.logger.Error("failed to add schedule", zap.Error(err))
+		}
+	}
+}
+
+// addSchedule registers a schedule of recurring agent runs. The push notifications of the
+// runs of a schedule with a webhook are sent even when the agent card does not advertise
+// push notifications.
+func (s *A2AServerImpl) addSchedule(schedule Schedule) error {
+	if err := s.schedules.add(schedule, s.clock.Now()); err != nil {
+		return err
+	}
+	if schedule.Webhook != nil {
+		if defaultTM, ok := s.taskManager.(*DefaultTaskManager); ok && defaultTM.notificationSender == nil {
+			defaultTM.SetNotificationSender(NewHTTPPushNotificationSender(s.logger))
+		}
+	}
+	s.logger.Info("schedule added",
+		zap.String("schedule_id", schedule.ID),
+		zap.String("cron", schedule.Cron),
+		zap.Bool("disabled", schedule.Disabled))
+	return nil
+}
+
+// ListSchedules returns the schedules of recurring agent runs with their next and last runs
+func (s *A2AServerImpl) ListSchedules() []ScheduleStatus {
+	return s.schedules.list()
+}
+
+// EnableSchedule resumes the runs of a schedule, starting with the next time it comes due
+func (s *A2AServerImpl) EnableSchedule(id string) (ScheduleStatus, error) {
+	return s.schedules.setEnabled(id, true, s.clock.Now())
+}
+
+// DisableSchedule stops a schedule from starting further runs. Runs already started continue.
+func (s *A2AServerImpl) DisableSchedule(id string) (ScheduleStatus, error) {
+	return s.schedules.setEnabled(id, false, s.clock.Now())
+}
+
+// startSchedules periodically starts the due runs of the schedules
+func (s *A2AServerImpl) startSchedules(ctx context.Context) {
+	if s.schedules.len() == 0 {
+		return
+	}
+
+	interval := s.cfg.ScheduleConfig.CheckInterval
+	if interval <= 0 {
+		interval = defaultScheduleCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	job := newPeriodicJob("schedules", interval, s.clock.Now())
+	s.scheduleJob.Store(job)
+	defer s.scheduleJob.Store(nil)
+
+	s.logger.Info("schedules enabled", zap.Int("schedules", s.schedules.len()))
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("schedules shutting down")
+			return
+		case <-ticker.C:
+			s.runDueSchedules(ctx, s.clock.Now())
+			job.ran(s.clock.Now())
+		}
+	}
+}
+
+// runDueSchedules starts the runs of the schedules that came due by now and returns how many
+// started
+func (s *A2AServerImpl) runDueSchedules(ctx context.Context, now time.Time) int {
+	schedules, runs := s.schedules.takeDue(now)
+
+	started := 0
+	for i, schedule := range schedules {
+		task, err := s.startScheduledRun(ctx, schedule, runs[i])
+		taskID := ""
+		if task != nil {
+			taskID = task.ID
+		}
+		s.schedules.recordRun(schedule.ID, now, taskID, err)
+		if err != nil {
+			s.logger.Error("failed to start scheduled run",
+				zap.String("schedule_id", schedule.ID),
+				zap.Int("run", runs[i].Run),
+				zap.Error(err))
+			continue
+		}
+
+		s.logger.Info("scheduled run started",
+			zap.String("schedule_id", schedule.ID),
+			zap.Int("run", runs[i].Run),
+			zap.String("task_id", task.ID),
+			zap.String("context_id", task.ContextID))
+		started++
+	}
+	return started
+}
+
+// startScheduledRun creates the task of a run of a schedule as if its message was sent with
+// message/send, so the same guardrails and policies apply, and queues it for processing
+func (s *A2AServerImpl) startScheduledRun(ctx context.Context, schedule Schedule, run ScheduleRun) (*types.Task, error) {
+	protocolHandler, ok := s.protocolHandler.(*DefaultA2AProtocolHandler)
+	if !ok {
+		return nil, fmt.Errorf("scheduled runs require the default protocol handler")
+	}
+
+	text, err := s.schedules.render(run)
+	if err != nil {
+		return nil, err
+	}
+	message := types.Message{
+		Role:  types.RoleUser,
+		Parts: []types.Part{types.CreateTextPart(text)},
+	}
+	if schedule.ContextID != "" {
+		contextID := schedule.ContextID
+		message.ContextID = &contextID
+	}
+
+	task, refusal, err := protocolHandler.createTaskFromMessage(ctx, types.MessageSendParams{
+		Message:  message,
+		Metadata: maps.Clone(schedule.Metadata),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if refusal != nil {
+		return task, fmt.Errorf("the message of the run was rejected")
+	}
+
+	recordScheduleRun(task, schedule.ID)
+	if schedule.Webhook != nil {
+		_, err := s.taskManager.SetTaskPushNotificationConfig(types.TaskPushNotificationConfig{
+			Name:                   task.ID,
+			PushNotificationConfig: *schedule.Webhook,
+		})
+		if err != nil {
+			s.logger.Warn("failed to set the webhook of a scheduled run",
+				zap.String("schedule_id", schedule.ID),
+				zap.String("task_id", task.ID),
+				zap.Error(err))
+		}
+	}
+
+	if err := s.storage.EnqueueTask(ctx, task, nil); err != nil {
+		updateErr := s.taskManager.UpdateError(task.ID, types.NewAssistantMessage(protocolHandler.ids.NewID(), []types.Part{
+			types.CreateTextPart("Failed to queue the scheduled run for processing."),
+		}))
+		if updateErr != nil {
+			s.logger.Error("failed to fail scheduled run that could not be queued",
+				zap.String("task_id", task.ID),
+				zap.Error(updateErr))
+		}
+		return task, fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	return task, nil
+}
+
+// recordScheduleRun records on the task of a run the ID of its schedule
+func recordScheduleRun(task *types.Task, scheduleID string) {
+	setTaskMetadata(task, types.ScheduleIDMetadataKey, scheduleID)
+}
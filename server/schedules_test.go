@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestParseSchedules(t *testing.T) {
+	schedules, err := ParseSchedules([]byte(`
+- id: daily-report
+  cron: "0 9 * * 1-5"
+  message: "Summarize the open tickets"
+  contextId: reports
+  webhook:
+    url: https://hooks.example.com/reports
+- id: cleanup
+  cron: "@every 1h"
+  message: "Clean up"
+  disabled: true
+`))
+	require.NoError(t, err)
+	require.Len(t, schedules, 2)
+	assert.Equal(t, "daily-report", schedules[0].ID)
+	assert.Equal(t, "reports", schedules[0].ContextID)
+	require.NotNil(t, schedules[0].Webhook)
+	assert.Equal(t, "https://hooks.example.com/reports", schedules[0].Webhook.URL)
+	assert.True(t, schedules[1].Disabled)
+
+	_, err = ParseSchedules([]byte(`[{"id": "a", "cron": "@daily", "message": "hi", "every": "day"}]`))
+	assert.Error(t, err, "unknown fields are rejected")
+}
+
+func TestAgentSchedules_Add(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule Schedule
+		errorMsg string
+	}{
+		{name: "missing id", schedule: Schedule{Cron: "@daily", Message: "hi"}, errorMsg: "schedule id is required"},
+		{name: "missing message", schedule: Schedule{ID: "a", Cron: "@daily"}, errorMsg: "message is required"},
+		{name: "invalid cron", schedule: Schedule{ID: "a", Cron: "every day", Message: "hi"}, errorMsg: "invalid cron spec"},
+		{name: "invalid template", schedule: Schedule{ID: "a", Cron: "@daily", Message: "{{.Run"}, errorMsg: "invalid message template"},
+		{name: "webhook without url", schedule: Schedule{ID: "a", Cron: "@daily", Message: "hi", Webhook: &types.PushNotificationConfig{}}, errorMsg: "webhook url is required"},
+		{name: "duplicate id", schedule: Schedule{ID: "existing", Cron: "@daily", Message: "hi"}, errorMsg: "already defined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedules := newAgentSchedules("UTC")
+			require.NoError(t, schedules.add(Schedule{ID: "existing", Cron: "@hourly", Message: "hi"}, time.Now()))
+
+			err := schedules.add(tt.schedule, time.Now())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestA2AServer_RunDueSchedules(t *testing.T) {
+	s := NewA2AServer(&config.Config{Timezone: "UTC"}, zap.NewNop(), nil)
+	require.NoError(t, s.addSchedule(Schedule{
+		ID:        "report",
+		Cron:      "0 9 * * *",
+		Message:   "Run {{.Run}} of {{.ScheduleID}}",
+		ContextID: "reports",
+		Metadata:  map[string]any{"team": "support"},
+		Webhook:   &types.PushNotificationConfig{URL: "https://hooks.example.com/reports"},
+	}))
+
+	statuses := s.ListSchedules()
+	require.Len(t, statuses, 1)
+	require.NotNil(t, statuses[0].NextRunAt)
+	due := *statuses[0].NextRunAt
+	ctx := context.Background()
+
+	assert.Equal(t, 0, s.runDueSchedules(ctx, due.Add(-time.Minute)), "schedules do not run before they are due")
+	assert.Equal(t, 1, s.runDueSchedules(ctx, due.Add(time.Minute)))
+	assert.Equal(t, 0, s.runDueSchedules(ctx, due.Add(2*time.Minute)), "a run is started once")
+	assert.Equal(t, 1, s.storage.GetQueueLength())
+
+	status := s.ListSchedules()[0]
+	assert.Equal(t, 1, status.Runs)
+	assert.Empty(t, status.LastError)
+	assert.Equal(t, due.Add(24*time.Hour), *status.NextRunAt)
+
+	task, exists := s.taskManager.GetTask(status.LastTaskID)
+	require.True(t, exists)
+	assert.Equal(t, "reports", task.ContextID)
+	assert.Equal(t, types.TaskStateSubmitted, task.Status.State)
+	require.NotNil(t, task.Metadata)
+	assert.Equal(t, "report", (*task.Metadata)[types.ScheduleIDMetadataKey])
+	assert.Equal(t, "support", (*task.Metadata)["team"])
+	require.NotEmpty(t, task.History)
+	assert.Equal(t, "Run 1 of report", *task.History[len(task.History)-1].Parts[0].Text)
+
+	configs, err := s.taskManager.ListTaskPushNotificationConfigs(types.ListTaskPushNotificationConfigParams{Parent: task.ID})
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "https://hooks.example.com/reports", configs[0].PushNotificationConfig.URL)
+
+	disabled, err := s.DisableSchedule("report")
+	require.NoError(t, err)
+	assert.True(t, disabled.Disabled)
+	assert.Nil(t, disabled.NextRunAt)
+	assert.Equal(t, 0, s.runDueSchedules(ctx, due.Add(48*time.Hour)), "disabled schedules do not run")
+
+	enabled, err := s.EnableSchedule("report")
+	require.NoError(t, err)
+	assert.False(t, enabled.Disabled)
+	assert.NotNil(t, enabled.NextRunAt)
+
+	_, err = s.EnableSchedule("unknown")
+	assert.ErrorIs(t, err, ErrScheduleNotFound)
+}
+
+func TestA2AServer_AdminSchedules(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s := NewA2AServer(newAdminTestConfig(), zap.NewNop(), nil)
+	require.NoError(t, s.addSchedule(Schedule{ID: "cleanup", Cron: "@hourly", Message: "Clean up"}))
+	router := newAdminTestRouter(s)
+
+	var listed struct {
+		Schedules []ScheduleStatus `json:"schedules"`
+	}
+	require.Equal(t, http.StatusOK, adminRequest(t, router, http.MethodGet, "/admin/schedules", "", &listed))
+	require.Len(t, listed.Schedules, 1)
+	assert.Equal(t, "cleanup", listed.Schedules[0].ID)
+	assert.NotNil(t, listed.Schedules[0].NextRunAt)
+
+	var disabled, enabled ScheduleStatus
+	require.Equal(t, http.StatusOK, adminRequest(t, router, http.MethodPost, "/admin/schedules/cleanup/disable", "", &disabled))
+	assert.True(t, disabled.Disabled)
+	assert.Nil(t, disabled.NextRunAt)
+	require.Equal(t, http.StatusOK, adminRequest(t, router, http.MethodPost, "/admin/schedules/cleanup/enable", "", &enabled))
+	assert.False(t, enabled.Disabled)
+	assert.NotNil(t, enabled.NextRunAt)
+
+	assert.Equal(t, http.StatusNotFound, adminRequest(t, router, http.MethodPost, "/admin/schedules/unknown/enable", "", nil))
+}
@@ -0,0 +1,235 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+// SecretsProvider resolves the secrets that configuration values reference as secret:<name>
+type SecretsProvider interface {
+	// GetSecret returns the current value of the named secret
+	GetSecret(ctx context.Context, name string) (string, error)
+}
+
+// NewSecretsProvider creates the secrets provider for the configured provider, or returns nil
+// when no provider is configured
+func NewSecretsProvider(cfg config.SecretsConfig) (SecretsProvider, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case config.SecretsProviderFile:
+		return NewFileSecretsProvider(cfg.Dir), nil
+	case config.SecretsProviderVault:
+		return NewVaultSecretsProvider(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported secrets provider: %s", cfg.Provider)
+	}
+}
+
+var _ SecretsProvider = (*FileSecretsProvider)(nil)
+
+// FileSecretsProvider reads every secret from a file of a directory, such as the files Docker
+// and Kubernetes mount for their secrets. The name of a secret is the path of its file
+// relative to the directory, and a trailing newline is not part of the secret.
+type FileSecretsProvider struct {
+	dir string
+}
+
+// NewFileSecretsProvider creates a secrets provider reading the secret files of the directory
+func NewFileSecretsProvider(dir string) *FileSecretsProvider {
+	return &FileSecretsProvider{dir: dir}
+}
+
+// GetSecret reads the file of the secret
+func (p *FileSecretsProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("secret name '%s' must be a path within the secrets directory", name)
+	}
+	data, err := os.ReadFile(filepath.Join(p.dir, name))
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+var _ SecretsProvider = (*VaultSecretsProvider)(nil)
+
+// VaultSecretsProvider reads secrets from HashiCorp Vault through its HTTP API. The name of a
+// secret is the API path of the secret followed by the field holding the value, as
+// path#field: secret/data/llm#api_key reads the api_key field of a KV version 2 secret and
+// kv/llm#api_key the field of a KV version 1 secret.
+type VaultSecretsProvider struct {
+	httpClient *http.Client
+	address    string
+	token      string
+	namespace  string
+}
+
+// NewVaultSecretsProvider creates a secrets provider reading from the configured Vault server
+// with the configured token
+func NewVaultSecretsProvider(cfg config.SecretsConfig) (*VaultSecretsProvider, error) {
+	if cfg.VaultAddress == "" {
+		return nil, fmt.Errorf("vault address is required")
+	}
+	return &VaultSecretsProvider{
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		address:    strings.TrimRight(cfg.VaultAddress, "/"),
+		token:      cfg.VaultToken,
+		namespace:  cfg.VaultNamespace,
+	}, nil
+}
+
+// vaultSecretResponse is the body returned by Vault when reading a secret. The fields of a KV
+// version 2 secret are nested under data.data.
+type vaultSecretResponse struct {
+	Data map[string]any `json:"data"`
+}
+
+// GetSecret reads the field of the secret at the path
+func (p *VaultSecretsProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	path, field, found := strings.Cut(name, "#")
+	path = strings.Trim(path, "/")
+	if !found || path == "" || field == "" {
+		return "", fmt.Errorf("vault secret name '%s' must be path#field", name)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.address+"/v1/"+path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	if p.token != "" {
+		req.Header.Set("X-Vault-Token", p.token)
+	}
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read vault secret: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned status %d for secret '%s'", resp.StatusCode, path)
+	}
+
+	var response vaultSecretResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	fields := response.Data
+	if nested, ok := fields["data"].(map[string]any); ok {
+		fields = nested
+	}
+	value, ok := fields[field].(string)
+	if !ok {
+		return "", fmt.Errorf("vault secret '%s' has no string field '%s'", path, field)
+	}
+	return value, nil
+}
+
+// secretRotation resolves the secret references of the server configuration again at the
+// refresh interval, and applies the rotated secrets to the components using them
+type secretRotation struct {
+	server          *A2AServerImpl
+	provider        SecretsProvider
+	artifactService ArtifactService
+	interval        time.Duration
+	logger          *zap.Logger
+}
+
+// run resolves the secret references at the refresh interval until the context is cancelled
+func (r *secretRotation) run(ctx context.Context) {
+	if r.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.rotate(ctx)
+		}
+	}
+}
+
+// rotate resolves the secret references and applies the secrets that changed, keeping the
+// current secrets when one cannot be resolved
+func (r *secretRotation) rotate(ctx context.Context) {
+	changed, err := r.server.cfg.ResolveSecrets(ctx, r.provider.GetSecret)
+	if err != nil {
+		r.logger.Error("failed to resolve secrets, keeping the current secrets", zap.Error(err))
+		return
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	r.apply(changed)
+	r.logger.Info("secrets rotated", zap.Strings("variables", changed))
+}
+
+// apply hands the secrets of the changed variables to the components using them. The auth
+// client secret is read when the server starts.
+func (r *secretRotation) apply(changed []string) {
+	cfg := r.server.cfg
+
+	if slices.Contains(changed, config.SecretAgentAPIKey) {
+		if agent, ok := r.server.GetAgent().(*OpenAICompatibleAgentImpl); ok {
+			if client, ok := agent.llmClient.(interface{ SetAPIKey(string) }); ok {
+				client.SetAPIKey(cfg.AgentConfig.APIKey)
+			}
+		}
+	}
+
+	if slices.Contains(changed, config.SecretSharingSecret) && r.server.taskShares != nil {
+		r.server.taskShares.SetSecret(cfg.SharingConfig.Secret)
+	}
+
+	if slices.Contains(changed, config.SecretArtifactsStorageAccessKey) || slices.Contains(changed, config.SecretArtifactsStorageSecretKey) {
+		if service, ok := r.artifactService.(*ArtifactServiceImpl); ok {
+			if storage, ok := service.storage.(*MinIOArtifactStorage); ok {
+				storage.SetCredentials(cfg.ArtifactsConfig.StorageConfig.AccessKey, cfg.ArtifactsConfig.StorageConfig.SecretKey)
+			}
+		}
+	}
+
+	if slices.Contains(changed, config.SecretEncryptionKey) && r.server.taskCipher != nil {
+		r.rotateEncryptionKey(cfg.EncryptionConfig.Key)
+	}
+}
+
+// rotateEncryptionKey seals new data with the rotated encryption key, and the stored tasks
+// sealed with the replaced key again in the background
+func (r *secretRotation) rotateEncryptionKey(encoded string) {
+	cipher := r.server.taskCipher
+	previous := cipher.currentKeyID()
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err == nil {
+		err = cipher.Rotate(key)
+	}
+	if err != nil {
+		r.logger.Error("failed to rotate encryption key, keeping the current key", zap.Error(err))
+		return
+	}
+	if cipher.currentKeyID() != previous {
+		go r.server.reencryptTasks(context.Background())
+	}
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+type staticSecretsProvider map[string]string
+
+func (p staticSecretsProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	return p[name], nil
+}
+
+func TestFileSecretsProvider_GetSecret(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "llm_api_key"), []byte("sk-123\n"), 0o600))
+
+	provider := NewFileSecretsProvider(dir)
+	secret, err := provider.GetSecret(context.Background(), "llm_api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-123", secret)
+
+	_, err = provider.GetSecret(context.Background(), "missing")
+	assert.Error(t, err)
+
+	_, err = provider.GetSecret(context.Background(), "../llm_api_key")
+	assert.EqualError(t, err, "secret name '../llm_api_key' must be a path within the secrets directory")
+}
+
+func TestVaultSecretsProvider_GetSecret(t *testing.T) {
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "root-token", r.Header.Get("X-Vault-Token"))
+		assert.Equal(t, "team-a", r.Header.Get("X-Vault-Namespace"))
+		switch r.URL.Path {
+		case "/v1/secret/data/llm":
+			_, _ = w.Write([]byte(`{"data":{"data":{"api_key":"sk-v2"},"metadata":{"version":3}}}`))
+		case "/v1/kv/llm":
+			_, _ = w.Write([]byte(`{"data":{"api_key":"sk-v1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer vault.Close()
+
+	provider, err := NewVaultSecretsProvider(config.SecretsConfig{
+		VaultAddress:   vault.URL + "/",
+		VaultToken:     "root-token",
+		VaultNamespace: "team-a",
+	})
+	require.NoError(t, err)
+
+	secret, err := provider.GetSecret(context.Background(), "secret/data/llm#api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-v2", secret)
+
+	secret, err = provider.GetSecret(context.Background(), "kv/llm#api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-v1", secret)
+
+	_, err = provider.GetSecret(context.Background(), "kv/llm#token")
+	assert.EqualError(t, err, "vault secret 'kv/llm' has no string field 'token'")
+
+	_, err = provider.GetSecret(context.Background(), "kv/missing#api_key")
+	assert.EqualError(t, err, "vault returned status 404 for secret 'kv/missing'")
+
+	_, err = provider.GetSecret(context.Background(), "kv/llm")
+	assert.EqualError(t, err, "vault secret name 'kv/llm' must be path#field")
+}
+
+func TestNewSecretsProvider(t *testing.T) {
+	provider, err := NewSecretsProvider(config.SecretsConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+
+	provider, err = NewSecretsProvider(config.SecretsConfig{Provider: config.SecretsProviderFile, Dir: "/run/secrets"})
+	require.NoError(t, err)
+	assert.IsType(t, &FileSecretsProvider{}, provider)
+
+	_, err = NewSecretsProvider(config.SecretsConfig{Provider: config.SecretsProviderVault})
+	assert.EqualError(t, err, "vault address is required")
+}
+
+func TestSecretRotation_Rotate(t *testing.T) {
+	var authorization string
+	llm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer llm.Close()
+
+	cfg := &config.Config{}
+	cfg.AgentConfig.Provider = "openai"
+	cfg.AgentConfig.Model = "gpt-4o"
+	cfg.AgentConfig.BaseURL = llm.URL
+	cfg.AgentConfig.APIKey = "secret:llm"
+	cfg.SharingConfig.Secret = "secret:sharing"
+	provider := staticSecretsProvider{"llm": "sk-1", "sharing": "first"}
+
+	_, err := cfg.ResolveSecrets(context.Background(), provider.GetSecret)
+	require.NoError(t, err)
+
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	client, err := NewOpenAICompatibleLLMClient(&cfg.AgentConfig, zap.NewNop())
+	require.NoError(t, err)
+	agent := NewOpenAICompatibleAgentWithConfig(zap.NewNop(), &cfg.AgentConfig)
+	agent.SetLLMClient(client)
+	s.SetAgent(agent)
+	shares, err := NewTaskShareService(cfg.SharingConfig, "")
+	require.NoError(t, err)
+	s.taskShares = shares
+
+	rotation := &secretRotation{server: s, provider: provider, logger: zap.NewNop()}
+	signature := shares.sign("claims")
+
+	provider["llm"] = "sk-2"
+	provider["sharing"] = "second"
+	rotation.rotate(context.Background())
+
+	assert.Equal(t, "sk-2", cfg.AgentConfig.APIKey)
+	assert.Equal(t, "second", cfg.SharingConfig.Secret)
+	assert.NotEqual(t, signature, shares.sign("claims"), "shares are signed with the rotated secret")
+
+	_, _ = client.CreateChatCompletion(context.Background(), nil)
+	assert.Equal(t, "Bearer sk-2", authorization, "the agent calls the provider with the rotated key")
+}
@@ -0,0 +1,270 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	uuid "github.com/google/uuid"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+const (
+	defaultSemanticCacheThreshold = 0.95
+	semanticCacheStateKey         = "semantic_cache"
+)
+
+// SemanticCacheEntry is a cached model response with the embedding of the prompt it answered
+type SemanticCacheEntry struct {
+	Prompt    string         `json:"prompt"`
+	Embedding []float32      `json:"embedding"`
+	Response  *types.Message `json:"response"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// SemanticCacheBackend stores cached responses and finds the one whose prompt is closest in
+// meaning to a new prompt
+type SemanticCacheBackend interface {
+	// Nearest returns the entry whose embedding is most similar to the given one, with its
+	// cosine similarity, or nil when the cache has no entry of the same dimensions
+	Nearest(ctx context.Context, embedding []float32) (*SemanticCacheEntry, float64, error)
+
+	// Put adds an entry, replacing an existing entry for the same prompt
+	Put(ctx context.Context, entry SemanticCacheEntry) error
+}
+
+var _ SemanticCacheBackend = (*InMemorySemanticCacheBackend)(nil)
+
+// InMemorySemanticCacheBackend is a SemanticCacheBackend that keeps entries in memory and
+// searches them exhaustively. When full, the oldest entry is evicted.
+type InMemorySemanticCacheBackend struct {
+	mu         sync.RWMutex
+	entries    []SemanticCacheEntry
+	maxEntries int
+	ttl        time.Duration
+}
+
+// NewInMemorySemanticCacheBackend creates an in-memory backend holding up to maxEntries
+// entries, each expiring after ttl. Zero means no limit and no expiry respectively.
+func NewInMemorySemanticCacheBackend(maxEntries int, ttl time.Duration) *InMemorySemanticCacheBackend {
+	return &InMemorySemanticCacheBackend{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+	}
+}
+
+// Nearest returns the unexpired entry most similar to the embedding
+func (b *InMemorySemanticCacheBackend) Nearest(ctx context.Context, embedding []float32) (*SemanticCacheEntry, float64, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var nearest *SemanticCacheEntry
+	bestScore := 0.0
+	for i := range b.entries {
+		entry := &b.entries[i]
+		if b.expired(entry) || len(entry.Embedding) != len(embedding) {
+			continue
+		}
+		if score := cosineSimilarity(embedding, entry.Embedding); nearest == nil || score > bestScore {
+			nearest, bestScore = entry, score
+		}
+	}
+	if nearest == nil {
+		return nil, 0, nil
+	}
+
+	entry := *nearest
+	return &entry, bestScore, nil
+}
+
+// Put adds an entry, dropping expired entries and evicting the oldest one when full
+func (b *InMemorySemanticCacheBackend) Put(ctx context.Context, entry SemanticCacheEntry) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entries := b.entries[:0]
+	for _, existing := range b.entries {
+		if existing.Prompt != entry.Prompt && !b.expired(&existing) {
+			entries = append(entries, existing)
+		}
+	}
+	entries = append(entries, entry)
+	if b.maxEntries > 0 && len(entries) > b.maxEntries {
+		entries = entries[len(entries)-b.maxEntries:]
+	}
+	b.entries = entries
+	return nil
+}
+
+// expired reports whether the entry has outlived the backend's TTL
+func (b *InMemorySemanticCacheBackend) expired(entry *SemanticCacheEntry) bool {
+	return b.ttl > 0 && time.Since(entry.CreatedAt) > b.ttl
+}
+
+// SemanticCache answers prompts from previous model responses to prompts with the same
+// meaning, skipping the LLM call. It plugs into an agent through its BeforeModel, AfterModel
+// and AfterAgent callbacks:
+//
+//	cache := server.NewSemanticCache(embedder, server.NewInMemorySemanticCacheBackend(1000, time.Hour), 0.95)
+//	agent, err := server.NewAgentBuilder(logger).
+//	    WithCallbacks(cache.Callbacks()).
+//	    Build()
+//
+// The cache is keyed on the text of the latest user message. Only answers the model gives
+// in a single call are cached; responses that lead to tool calls depend on the tool results
+// and are never cached.
+type SemanticCache struct {
+	embedder  Embedder
+	backend   SemanticCacheBackend
+	threshold float64
+}
+
+// semanticCacheRun tracks the cache across the model calls of one agent run, in the callback
+// context state
+type semanticCacheRun struct {
+	calls     int
+	hit       bool
+	prompt    string
+	embedding []float32
+	response  *types.Message
+}
+
+// NewSemanticCache creates a semantic cache that embeds prompts with the embedder and keeps
+// responses in the backend. A cached response is used when the cosine similarity of its
+// prompt to the new prompt is at least threshold, which defaults to 0.95.
+func NewSemanticCache(embedder Embedder, backend SemanticCacheBackend, threshold float64) *SemanticCache {
+	if threshold <= 0 || threshold > 1 {
+		threshold = defaultSemanticCacheThreshold
+	}
+	return &SemanticCache{
+		embedder:  embedder,
+		backend:   backend,
+		threshold: threshold,
+	}
+}
+
+// Callbacks returns a callback configuration with the cache's callbacks. To combine the cache
+// with other callbacks, add BeforeModel, AfterModel and AfterAgent to your own configuration.
+func (c *SemanticCache) Callbacks() *CallbackConfig {
+	return &CallbackConfig{
+		BeforeModel: []BeforeModelCallback{c.BeforeModel},
+		AfterModel:  []AfterModelCallback{c.AfterModel},
+		AfterAgent:  []AfterAgentCallback{c.AfterAgent},
+	}
+}
+
+// BeforeModel looks up the latest user message and returns the cached response of the most
+// similar prompt above the threshold, skipping the LLM call
+func (c *SemanticCache) BeforeModel(ctx context.Context, callbackContext *CallbackContext, llmRequest *LLMRequest) *LLMResponse {
+	run := c.run(callbackContext)
+	run.calls++
+	if run.calls > 1 {
+		// the model called tools, so its answer depends on their results
+		run.response = nil
+		return nil
+	}
+
+	prompt := latestUserText(llmRequest.Contents)
+	if prompt == "" {
+		return nil
+	}
+
+	embeddings, err := c.embedder.Embed(ctx, []string{prompt})
+	if err != nil || len(embeddings) != 1 {
+		c.logger(callbackContext).Warn("semantic cache failed to embed prompt", zap.Error(err))
+		return nil
+	}
+	run.prompt = prompt
+	run.embedding = embeddings[0]
+
+	entry, score, err := c.backend.Nearest(ctx, run.embedding)
+	if err != nil {
+		c.logger(callbackContext).Warn("semantic cache lookup failed", zap.Error(err))
+		return nil
+	}
+	if entry == nil || entry.Response == nil || score < c.threshold {
+		return nil
+	}
+
+	c.logger(callbackContext).Debug("semantic cache hit",
+		zap.Float64("similarity", score),
+		zap.String("cached_prompt", entry.Prompt))
+
+	run.hit = true
+	response := *entry.Response
+	response.MessageID = fmt.Sprintf("semantic-cache-%s", uuid.New().String())
+	response.TaskID = nil
+	response.ContextID = nil
+	return &LLMResponse{Content: &response}
+}
+
+// AfterModel remembers the model's response to the prompt, to be cached if the agent run
+// completes without calling tools
+func (c *SemanticCache) AfterModel(ctx context.Context, callbackContext *CallbackContext, llmResponse *LLMResponse) *LLMResponse {
+	run := c.run(callbackContext)
+	if run.hit || run.calls != 1 || run.embedding == nil || llmResponse == nil {
+		return nil
+	}
+	run.response = llmResponse.Content
+	return nil
+}
+
+// AfterAgent caches the response remembered by AfterModel once the run completed with it
+func (c *SemanticCache) AfterAgent(ctx context.Context, callbackContext *CallbackContext, agentOutput *types.Message) *types.Message {
+	run := c.run(callbackContext)
+	if run.hit || run.calls != 1 || run.response == nil {
+		return nil
+	}
+
+	response := *run.response
+	response.TaskID = nil
+	response.ContextID = nil
+	if err := c.backend.Put(ctx, SemanticCacheEntry{
+		Prompt:    run.prompt,
+		Embedding: run.embedding,
+		Response:  &response,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		c.logger(callbackContext).Warn("semantic cache failed to store response", zap.Error(err))
+	}
+	return nil
+}
+
+// run returns the cache's state for the current agent run
+func (c *SemanticCache) run(callbackContext *CallbackContext) *semanticCacheRun {
+	if callbackContext.State == nil {
+		callbackContext.State = make(map[string]any)
+	}
+	run, ok := callbackContext.State[semanticCacheStateKey].(*semanticCacheRun)
+	if !ok {
+		run = &semanticCacheRun{}
+		callbackContext.State[semanticCacheStateKey] = run
+	}
+	return run
+}
+
+func (c *SemanticCache) logger(callbackContext *CallbackContext) *zap.Logger {
+	if callbackContext.Logger != nil {
+		return callbackContext.Logger
+	}
+	return zap.NewNop()
+}
+
+// latestUserText returns the text of the last message when it is from the user
+func latestUserText(messages []types.Message) string {
+	if len(messages) == 0 || messages[len(messages)-1].Role != types.RoleUser {
+		return ""
+	}
+
+	var texts []string
+	for _, part := range messages[len(messages)-1].Parts {
+		if part.Text != nil {
+			texts = append(texts, *part.Text)
+		}
+	}
+	return strings.TrimSpace(strings.Join(texts, "\n"))
+}
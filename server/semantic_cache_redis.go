@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	redis "github.com/redis/go-redis/v9"
+)
+
+const defaultSemanticCacheKeyPrefix = "a2a:semantic-cache:"
+
+var _ SemanticCacheBackend = (*RedisSemanticCacheBackend)(nil)
+
+// RedisSemanticCacheBackend is a SemanticCacheBackend that keeps entries in Redis, so replicas
+// share the cache. Entries are stored as JSON under a key prefix and expire after the TTL.
+// Lookups load every entry and compare them in the agent, which suits caches of up to a few
+// thousand entries.
+type RedisSemanticCacheBackend struct {
+	client    RedisClient
+	keyPrefix string
+	ttl       time.Duration
+}
+
+// NewRedisSemanticCacheBackend creates a backend storing entries in Redis under keyPrefix,
+// each expiring after ttl. The prefix defaults to "a2a:semantic-cache:" and zero TTL means
+// entries never expire.
+func NewRedisSemanticCacheBackend(client *redis.Client, keyPrefix string, ttl time.Duration) *RedisSemanticCacheBackend {
+	return newRedisSemanticCacheBackend(&realRedisClient{client}, keyPrefix, ttl)
+}
+
+func newRedisSemanticCacheBackend(client RedisClient, keyPrefix string, ttl time.Duration) *RedisSemanticCacheBackend {
+	if keyPrefix == "" {
+		keyPrefix = defaultSemanticCacheKeyPrefix
+	}
+	return &RedisSemanticCacheBackend{
+		client:    client,
+		keyPrefix: keyPrefix,
+		ttl:       ttl,
+	}
+}
+
+// Nearest returns the stored entry most similar to the embedding
+func (b *RedisSemanticCacheBackend) Nearest(ctx context.Context, embedding []float32) (*SemanticCacheEntry, float64, error) {
+	keys, err := b.client.Keys(ctx, b.keyPrefix+"*").Result()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list semantic cache entries: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil, 0, nil
+	}
+
+	values, err := b.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load semantic cache entries: %w", err)
+	}
+
+	var nearest *SemanticCacheEntry
+	bestScore := 0.0
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			// expired since it was listed
+			continue
+		}
+		var entry SemanticCacheEntry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode semantic cache entry %s: %w", keys[i], err)
+		}
+		if len(entry.Embedding) != len(embedding) {
+			continue
+		}
+		if score := cosineSimilarity(embedding, entry.Embedding); nearest == nil || score > bestScore {
+			nearest, bestScore = &entry, score
+		}
+	}
+	return nearest, bestScore, nil
+}
+
+// Put stores an entry under a key derived from its prompt
+func (b *RedisSemanticCacheBackend) Put(ctx context.Context, entry SemanticCacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode semantic cache entry: %w", err)
+	}
+
+	hash := sha256.Sum256([]byte(entry.Prompt))
+	key := b.keyPrefix + hex.EncodeToString(hash[:16])
+	if err := b.client.Set(ctx, key, data, b.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to store semantic cache entry: %w", err)
+	}
+	return nil
+}
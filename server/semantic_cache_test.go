@@ -0,0 +1,184 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	redis "github.com/redis/go-redis/v9"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	server "github.com/inference-gateway/adk/server"
+	mocks "github.com/inference-gateway/adk/server/mocks"
+	types "github.com/inference-gateway/adk/types"
+	sdk "github.com/inference-gateway/sdk"
+)
+
+// streamLLMResponses makes the fake LLM client stream one response per call, in order
+func streamLLMResponses(client *mocks.FakeLLMClient, responses ...sdk.ChatCompletionStreamResponseDelta) {
+	calls := 0
+	client.CreateStreamingChatCompletionStub = func(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+		delta := responses[min(calls, len(responses)-1)]
+		calls++
+
+		finishReason := sdk.FinishReason("stop")
+		if delta.ToolCalls != nil {
+			finishReason = "tool_calls"
+		}
+		responseChan := make(chan *sdk.CreateChatCompletionStreamResponse, 1)
+		responseChan <- &sdk.CreateChatCompletionStreamResponse{
+			Choices: []sdk.ChatCompletionStreamChoice{{Delta: delta, FinishReason: finishReason}},
+		}
+		close(responseChan)
+		return responseChan, make(chan error)
+	}
+}
+
+// runAgentForText runs the agent on a user prompt and returns the text of the completed task
+func runAgentForText(t *testing.T, agent server.OpenAICompatibleAgent, prompt string) string {
+	t.Helper()
+	eventChan, err := agent.RunWithStream(context.Background(), []types.Message{
+		{Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart(prompt)}},
+	})
+	require.NoError(t, err)
+
+	var text string
+	for event := range eventChan {
+		if event.Type() != types.EventTaskStatusChanged {
+			continue
+		}
+		var status types.TaskStatus
+		require.NoError(t, event.DataAs(&status))
+		if status.State == types.TaskStateCompleted && status.Message != nil {
+			for _, part := range status.Message.Parts {
+				if part.Text != nil {
+					text = *part.Text
+				}
+			}
+		}
+	}
+	return text
+}
+
+func TestSemanticCache(t *testing.T) {
+	t.Run("answers similar prompts from the cache", func(t *testing.T) {
+		mockLLMClient := &mocks.FakeLLMClient{}
+		streamLLMResponses(mockLLMClient, sdk.ChatCompletionStreamResponseDelta{Content: "Refunds take five days"})
+
+		cache := server.NewSemanticCache(keywordEmbedder, server.NewInMemorySemanticCacheBackend(10, time.Hour), 0.9)
+		agent, err := server.NewAgentBuilder(zap.NewNop()).
+			WithLLMClient(mockLLMClient).
+			WithCallbacks(cache.Callbacks()).
+			Build()
+		require.NoError(t, err)
+
+		assert.Equal(t, "Refunds take five days", runAgentForText(t, agent, "How long does a refund take?"))
+		assert.Equal(t, 1, mockLLMClient.CreateStreamingChatCompletionCallCount())
+
+		assert.Equal(t, "Refunds take five days", runAgentForText(t, agent, "When will I get my refund?"))
+		assert.Equal(t, 1, mockLLMClient.CreateStreamingChatCompletionCallCount(), "cached answer skips the LLM call")
+
+		runAgentForText(t, agent, "How do I change my shipping address?")
+		assert.Equal(t, 2, mockLLMClient.CreateStreamingChatCompletionCallCount(), "dissimilar prompt calls the LLM")
+	})
+
+	t.Run("does not cache answers that needed tools", func(t *testing.T) {
+		toolCalls := []sdk.ChatCompletionMessageToolCallChunk{{
+			Index:    0,
+			ID:       new("call_lookup"),
+			Type:     new("function"),
+			Function: &sdk.ChatCompletionMessageToolCallFunction{Name: "lookup_order", Arguments: `{}`},
+		}}
+		mockLLMClient := &mocks.FakeLLMClient{}
+		streamLLMResponses(mockLLMClient,
+			sdk.ChatCompletionStreamResponseDelta{ToolCalls: &toolCalls},
+			sdk.ChatCompletionStreamResponseDelta{Content: "Your refund was issued yesterday"},
+		)
+
+		toolBox := server.NewToolBox()
+		toolBox.AddTool(server.NewBasicTool("lookup_order", "Looks up the order", map[string]any{"type": "object"},
+			func(ctx context.Context, args map[string]any) (string, error) { return "refund issued", nil }))
+
+		backend := server.NewInMemorySemanticCacheBackend(10, 0)
+		agent, err := server.NewAgentBuilder(zap.NewNop()).
+			WithLLMClient(mockLLMClient).
+			WithToolBox(toolBox).
+			WithCallbacks(server.NewSemanticCache(keywordEmbedder, backend, 0.9).Callbacks()).
+			Build()
+		require.NoError(t, err)
+
+		assert.Equal(t, "Your refund was issued yesterday", runAgentForText(t, agent, "Where is my refund?"))
+
+		entry, _, err := backend.Nearest(context.Background(), []float32{1, 0, 0})
+		require.NoError(t, err)
+		assert.Nil(t, entry)
+	})
+}
+
+func TestInMemorySemanticCacheBackend(t *testing.T) {
+	ctx := context.Background()
+	backend := server.NewInMemorySemanticCacheBackend(2, time.Hour)
+	answer := func(text string) *types.Message {
+		return types.NewAssistantMessage("msg", []types.Part{types.CreateTextPart(text)})
+	}
+
+	require.NoError(t, backend.Put(ctx, server.SemanticCacheEntry{Prompt: "refunds", Embedding: []float32{1, 0}, Response: answer("five days"), CreatedAt: time.Now()}))
+	require.NoError(t, backend.Put(ctx, server.SemanticCacheEntry{Prompt: "refunds", Embedding: []float32{1, 0}, Response: answer("three days"), CreatedAt: time.Now()}))
+	require.NoError(t, backend.Put(ctx, server.SemanticCacheEntry{Prompt: "shipping", Embedding: []float32{0, 1}, Response: answer("two weeks"), CreatedAt: time.Now()}))
+
+	entry, score, err := backend.Nearest(ctx, []float32{2, 0.1})
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "three days", *entry.Response.Parts[0].Text, "a prompt is cached once, with its latest response")
+	assert.Greater(t, score, 0.99)
+
+	require.NoError(t, backend.Put(ctx, server.SemanticCacheEntry{Prompt: "passwords", Embedding: []float32{-1, 0}, Response: answer("settings page"), CreatedAt: time.Now()}))
+	entry, _, err = backend.Nearest(ctx, []float32{1, 0})
+	require.NoError(t, err)
+	assert.NotEqual(t, "refunds", entry.Prompt, "the oldest entry is evicted when full")
+
+	expiring := server.NewInMemorySemanticCacheBackend(0, time.Minute)
+	require.NoError(t, expiring.Put(ctx, server.SemanticCacheEntry{Prompt: "old", Embedding: []float32{1, 0}, Response: answer("stale"), CreatedAt: time.Now().Add(-time.Hour)}))
+	entry, _, err = expiring.Nearest(ctx, []float32{1, 0})
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+}
+
+func TestRedisSemanticCacheBackend(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := &mocks.FakeRedisClient{}
+	backend := server.NewRedisSemanticCacheBackendForTest(fakeClient, "", time.Hour)
+
+	fakeClient.SetReturns(redis.NewStatusResult("OK", nil))
+	entry := server.SemanticCacheEntry{
+		Prompt:    "refunds",
+		Embedding: []float32{1, 0},
+		Response:  types.NewAssistantMessage("msg", []types.Part{types.CreateTextPart("five days")}),
+		CreatedAt: time.Now(),
+	}
+	require.NoError(t, backend.Put(ctx, entry))
+
+	require.Equal(t, 1, fakeClient.SetCallCount())
+	_, key, value, ttl := fakeClient.SetArgsForCall(0)
+	assert.Regexp(t, `^a2a:semantic-cache:[0-9a-f]{32}$`, key)
+	assert.Equal(t, time.Hour, ttl)
+	stored := string(value.([]byte))
+
+	other, err := json.Marshal(server.SemanticCacheEntry{Prompt: "shipping", Embedding: []float32{0, 1}})
+	require.NoError(t, err)
+	fakeClient.KeysReturns(redis.NewStringSliceResult([]string{key, "a2a:semantic-cache:other", "a2a:semantic-cache:expired"}, nil))
+	fakeClient.MGetReturns(redis.NewSliceResult([]any{stored, string(other), nil}, nil))
+
+	nearest, score, err := backend.Nearest(ctx, []float32{3, 0.2})
+	require.NoError(t, err)
+	require.NotNil(t, nearest)
+	assert.Equal(t, "refunds", nearest.Prompt)
+	assert.Equal(t, "five days", *nearest.Response.Parts[0].Text)
+	assert.Greater(t, score, 0.99)
+
+	_, pattern := fakeClient.KeysArgsForCall(0)
+	assert.Equal(t, "a2a:semantic-cache:*", pattern)
+}
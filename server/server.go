@@ -3,14 +3,17 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	gin "github.com/gin-gonic/gin"
-	uuid "github.com/google/uuid"
 	config "github.com/inference-gateway/adk/server/config"
 	middlewares "github.com/inference-gateway/adk/server/middlewares"
 	otel "github.com/inference-gateway/adk/server/otel"
@@ -38,6 +41,17 @@ type A2AServer interface {
 	// StartTaskProcessor starts the background task processor
 	StartTaskProcessor(ctx context.Context)
 
+	// Handler returns the HTTP handler serving the A2A endpoints, for serving the server on
+	// a listener of the caller's choosing, embedding it into an existing mux, or in tests.
+	// Unlike Start, it starts no background processing; call StartBackground to process
+	// queued tasks.
+	Handler() http.Handler
+
+	// StartBackground starts the background processing Start runs besides serving HTTP: the
+	// task processors of the server and its named agents, the periodic jobs, secret rotation
+	// and hot reload. Use it with Handler when the caller owns the listener; Stop ends it.
+	StartBackground(ctx context.Context) error
+
 	// SetPollingTaskHandler sets the task handler for polling/queue-based scenarios
 	SetBackgroundTaskHandler(handler TaskHandler)
 
@@ -74,6 +88,30 @@ type A2AServer interface {
 	// LoadAgentCardFromFile loads and sets an agent card from a JSON file
 	// The optional overrides map allows dynamic replacement of JSON attribute values
 	LoadAgentCardFromFile(filePath string, overrides map[string]any) error
+
+	// SetExtendedAgentCard sets the card returned by agent/getAuthenticatedExtendedCard, which
+	// may list skills and endpoints that the public card does not expose
+	SetExtendedAgentCard(agentCard types.AgentCard)
+
+	// SetAgentCardProvider sets a hook that is consulted on every agent card request and
+	// may adjust the card that is advertised
+	SetAgentCardProvider(provider AgentCardProvider)
+
+	// UpdateAgentCard changes the advertised agent card while the server is running
+	UpdateAgentCard(update func(card *types.AgentCard)) error
+
+	// RegisterSkill adds a skill to the agent card, replacing a skill with the same ID
+	RegisterSkill(skill types.AgentSkill) error
+
+	// UnregisterSkill removes a skill from the agent card
+	UnregisterSkill(id string) error
+
+	// GetNamedAgent returns the server of an agent hosted under /agents/{name}
+	GetNamedAgent(name string) (A2AServer, bool)
+
+	// RegisterJSONRPCMethod serves an additional JSON-RPC method on the A2A endpoint,
+	// behind the same authentication and middleware as the built-in methods
+	RegisterJSONRPCMethod(method string, handler JSONRPCMethodHandler) error
 }
 
 // TaskResultProcessor defines how to process tool call results for task completion
@@ -93,6 +131,11 @@ const (
 	ErrInvalidParams  JRPCErrorCode = -32602
 	ErrInternalError  JRPCErrorCode = -32603
 	ErrServerError    JRPCErrorCode = -32000
+
+	ErrTaskNotFound            JRPCErrorCode = -32001
+	ErrTaskNotCancelable       JRPCErrorCode = -32002
+	ErrUnsupportedOperation    JRPCErrorCode = -32004
+	ErrContentTypeNotSupported JRPCErrorCode = -32005
 )
 
 type A2AServerImpl struct {
@@ -112,14 +155,110 @@ type A2AServerImpl struct {
 	agent               OpenAICompatibleAgent
 
 	// Custom agent card
+	cardMu          sync.RWMutex
 	customAgentCard *types.AgentCard
 
+	// Card served to authenticated callers via agent/getAuthenticatedExtendedCard
+	extendedAgentCard *types.AgentCard
+
+	// Optional hook that adjusts the agent card on every request
+	agentCardProvider AgentCardProvider
+
+	// Skill IDs left out of the served agent cards
+	disabledSkills []string
+
+	// Applies changed settings from the watched configuration files
+	reloader *configReloader
+
+	// Rotation of the secrets referenced by the configuration
+	secrets *secretRotation
+
+	// Agents hosted under /agents/{name}, each with its own server
+	namedAgents map[string]*A2AServerImpl
+
 	// Separate task handlers for different scenarios
 	backgroundTaskHandler TaskHandler
 	streamingTaskHandler  StreamableTaskHandler
 
 	// Protocol handler
 	protocolHandler A2AProtocolHandler
+
+	// Custom JSON-RPC methods
+	jsonrpcMethods *JSONRPCMethodRegistry
+
+	// Read-only task share links
+	taskShares *TaskShareService
+
+	// Language detection and enforcement
+	languagePolicy *LanguagePolicy
+
+	// Input and output message filters
+	guardrails *Guardrails
+
+	// Masking of personal data in messages, and in artifacts before they are stored
+	redactor         *Redactor
+	artifactRedactor *Redactor
+
+	// Sniffing, validation and conversion of inbound file parts
+	fileIngestion *FileIngestion
+
+	// Transcription of inbound audio and synthesis of spoken responses
+	transcription bool
+	speechOutput  *SpeechOutput
+	previews      *ArtifactPreviews
+	transcripts   *TaskTranscripts
+
+	// Long-term memory extracted from completed tasks and recalled in agent runs
+	memories *MemoryService
+
+	// Encryption of the tasks the storage persists
+	taskCipher *TaskCipher
+
+	// Export of task events to an external event bus
+	events *eventExport
+
+	// Audit log of the calls, tool invocations of tasks and their callers
+	audit *auditLog
+
+	// Access policy checked before methods and tool executions
+	policy *Policy
+
+	// In-flight streams drained on shutdown
+	drain *streamDrain
+
+	// message/send responses replayed to retries with the same idempotency key
+	idempotency *idempotencyCache
+
+	// Task processor and periodic job state for observability
+	workers         workerTracker
+	queueCleanupJob atomic.Pointer[periodicJob]
+	inputTimeoutJob atomic.Pointer[periodicJob]
+	scheduleJob     atomic.Pointer[periodicJob]
+	dependencyJob   atomic.Pointer[periodicJob]
+	recoveryJob     atomic.Pointer[periodicJob]
+
+	// Recurring agent runs started on cron schedules
+	schedules *agentSchedules
+
+	// Pauses the task processor on admin request
+	queuePause queuePause
+
+	// Identifies this instance in handoff metadata and task checkpoints
+	instanceID string
+
+	// Clock and ID generator of the times and IDs the server sets itself, injected like
+	// those of the task manager and protocol handler
+	clock Clock
+	ids   IDGenerator
+
+	// Agent loop checkpoints of running tasks, recovered on startup
+	checkpoints *taskCheckpoints
+
+	// Credentials auth-required tasks were resumed with, until their run takes them
+	credentials *taskCredentials
+
+	// Middleware of the HTTP router added by the builder
+	httpMiddleware []gin.HandlerFunc
 }
 
 var _ A2AServer = (*A2AServerImpl)(nil)
@@ -151,27 +290,83 @@ func NewA2AServer(cfg *config.Config, logger *zap.Logger, otel otel.OpenTelemetr
 		storage = NewInMemoryStorage(logger, maxConversationHistory)
 	}
 
+	taskManager := NewDefaultTaskManagerWithStorage(logger, storage)
+	responseSender := NewDefaultResponseSender(logger)
+	protocolHandler := NewDefaultA2AProtocolHandler(logger, storage, taskManager, responseSender)
+
+	return NewA2AServerWithDependencies(cfg, logger, otel, storage, taskManager, responseSender, protocolHandler)
+}
+
+// NewA2AServerWithDependencies creates a new A2A server from injected components, so
+// applications can replace the storage, task manager, response sender or protocol handler.
+// The default task handlers are configured without an agent.
+func NewA2AServerWithDependencies(
+	cfg *config.Config,
+	logger *zap.Logger,
+	otel otel.OpenTelemetry,
+	storage Storage,
+	taskManager TaskManager,
+	responseSender ResponseSender,
+	protocolHandler A2AProtocolHandler,
+) *A2AServerImpl {
 	server := &A2AServerImpl{
-		cfg:     cfg,
-		logger:  logger,
-		storage: storage,
-		otel:    otel,
+		cfg:             cfg,
+		logger:          logger,
+		storage:         storage,
+		otel:            otel,
+		taskManager:     taskManager,
+		responseSender:  responseSender,
+		protocolHandler: protocolHandler,
+		jsonrpcMethods:  NewJSONRPCMethodRegistry(),
+		drain:           newStreamDrain(),
+		disabledSkills:  slices.Clone(cfg.DisabledSkills),
+		idempotency:     newIdempotencyCache(cfg.ServerConfig.IdempotencyWindow),
+		instanceID:      newInstanceID(cfg.ServerConfig.InstanceID),
+		clock:           SystemClock{},
+		ids:             UUIDGenerator{},
+	}
+
+	server.reloader = newConfigReloader(server)
+	server.checkpoints = newTaskCheckpoints(cfg.TaskCheckpointConfig, storage, server.instanceID, logger)
+	server.credentials = newTaskCredentials()
+	server.schedules = newAgentSchedules(cfg.Timezone)
+
+	if defaultTM, ok := taskManager.(*DefaultTaskManager); ok {
+		defaultTM.SetHistoryConfig(cfg.TaskHistoryConfig)
+		defaultTM.SetTaskTimings(cfg.AgentConfig.EnableUsageMetadata)
+		server.clock, server.ids = defaultTM.clock, defaultTM.ids
+		server.checkpoints.setClock(defaultTM.clock)
+	}
+
+	if cfg.QueueConfig.HandoffOnShutdown {
+		if _, inMemory := storage.(*InMemoryStorage); inMemory {
+			logger.Warn("task handoff is enabled with in-memory storage, handed off tasks are lost when the process exits")
+		}
 	}
 
-	server.taskManager = NewDefaultTaskManagerWithStorage(logger, storage)
-	server.responseSender = NewDefaultResponseSender(logger)
 	bgHandler := NewDefaultBackgroundTaskHandler(logger, server.agent)
 	bgHandler.SetEnableUsageMetadata(cfg.AgentConfig.EnableUsageMetadata)
 	server.backgroundTaskHandler = bgHandler
 	streamHandler := NewDefaultStreamingTaskHandler(logger, server.agent)
 	streamHandler.SetEnableUsageMetadata(cfg.AgentConfig.EnableUsageMetadata)
 	server.streamingTaskHandler = streamHandler
-	server.protocolHandler = NewDefaultA2AProtocolHandler(
-		logger,
-		server.storage,
-		server.taskManager,
-		server.responseSender,
-	)
+
+	if ph, ok := protocolHandler.(*DefaultA2AProtocolHandler); ok {
+		ph.SetVersionInfo(cfg.AgentVersion, PromptVersion(cfg.AgentConfig.SystemPrompt))
+		ph.SetResumeConfig(cfg.TaskResumeConfig)
+		ph.SetHeartbeatInterval(cfg.StreamingStatusUpdateInterval)
+		server.setupTaskSharing(ph)
+		server.setupLanguagePolicy(ph)
+		server.setupRedaction()
+		server.setupModeration()
+		server.setupFileIngestion()
+		server.setupSpeech()
+		server.setupPolicy()
+		ph.setStreamDrain(server.drain)
+		ph.setTaskCheckpoints(server.checkpoints)
+		ph.setTaskCredentials(server.credentials)
+	}
+	server.setupSchedules()
 
 	return server
 }
@@ -237,32 +432,236 @@ func NewA2AServerEnvironmentAware(cfg *config.Config, logger *zap.Logger, otel o
 		log.Fatalf("failed to load configuration: %v", err)
 	}
 
-	server := &A2AServerImpl{
-		cfg:    cfg,
-		logger: logger,
-		otel:   otel,
-	}
-
 	maxConversationHistory := cfg.AgentConfig.MaxConversationHistory
 	storage := NewInMemoryStorage(logger, maxConversationHistory)
-	server.storage = storage
+	taskManager := NewDefaultTaskManagerWithStorage(logger, storage)
+	responseSender := NewDefaultResponseSender(logger)
+	protocolHandler := NewDefaultA2AProtocolHandler(logger, storage, taskManager, responseSender)
 
-	server.taskManager = NewDefaultTaskManagerWithStorage(logger, storage)
-	server.responseSender = NewDefaultResponseSender(logger)
-	bgHandler := NewDefaultBackgroundTaskHandler(logger, server.agent)
-	bgHandler.SetEnableUsageMetadata(cfg.AgentConfig.EnableUsageMetadata)
-	server.backgroundTaskHandler = bgHandler
-	streamHandler := NewDefaultStreamingTaskHandler(logger, server.agent)
-	streamHandler.SetEnableUsageMetadata(cfg.AgentConfig.EnableUsageMetadata)
-	server.streamingTaskHandler = streamHandler
-	server.protocolHandler = NewDefaultA2AProtocolHandler(
-		logger,
-		server.storage,
-		server.taskManager,
-		server.responseSender,
-	)
+	return NewA2AServerWithDependencies(cfg, logger, otel, storage, taskManager, responseSender, protocolHandler)
+}
 
-	return server
+// setupTaskSharing enables tasks/share and the share endpoint when sharing is configured
+func (s *A2AServerImpl) setupTaskSharing(protocolHandler *DefaultA2AProtocolHandler) {
+	if !s.cfg.SharingConfig.Enable {
+		return
+	}
+
+	taskShares, err := NewTaskShareService(s.cfg.SharingConfig, s.cfg.AgentURL)
+	if err != nil {
+		s.logger.Error("failed to create task share service, task sharing is disabled", zap.Error(err))
+		return
+	}
+
+	s.taskShares = taskShares
+	protocolHandler.SetTaskShareService(taskShares)
+}
+
+// setupLanguagePolicy enables per-message language detection when language support is configured
+func (s *A2AServerImpl) setupLanguagePolicy(protocolHandler *DefaultA2AProtocolHandler) {
+	if !s.cfg.LanguageConfig.Enable {
+		return
+	}
+
+	s.languagePolicy = NewLanguagePolicy(s.cfg.LanguageConfig, s.logger)
+	protocolHandler.SetLanguagePolicy(s.languagePolicy)
+}
+
+// setupRedaction masks personal data in user messages, the agent's responses and the artifacts
+// of completed tasks when redaction is enabled
+func (s *A2AServerImpl) setupRedaction() {
+	cfg := s.cfg.RedactionConfig
+	if !cfg.Enable {
+		return
+	}
+
+	s.redactor = NewRedactor(cfg.Entities)
+	if cfg.Input || cfg.Output {
+		guardrails := s.guardrails
+		if guardrails == nil {
+			guardrails = NewGuardrails(s.logger)
+		}
+		filter := s.redactor.Filter()
+		if cfg.Input {
+			guardrails.AddInputFilters(filter)
+		}
+		if cfg.Output {
+			guardrails.AddOutputFilters(filter)
+		}
+		s.setGuardrails(guardrails)
+	}
+	if cfg.Artifacts {
+		s.setArtifactRedactor(s.redactor)
+	}
+
+	s.logger.Info("pii redaction enabled",
+		zap.Strings("entities", cfg.Entities),
+		zap.Bool("input", cfg.Input),
+		zap.Bool("output", cfg.Output),
+		zap.Bool("artifacts", cfg.Artifacts))
+}
+
+// setArtifactRedactor sets the masking of personal data in the artifacts of completed tasks
+func (s *A2AServerImpl) setArtifactRedactor(redactor *Redactor) {
+	s.artifactRedactor = redactor
+	if ph, ok := s.protocolHandler.(*DefaultA2AProtocolHandler); ok {
+		ph.SetArtifactRedactor(redactor)
+	}
+}
+
+// setupModeration sends user messages and agent responses to the moderation endpoint when
+// moderation is enabled. Moderation runs after redaction, so it sees redacted text.
+func (s *A2AServerImpl) setupModeration() {
+	if !s.cfg.ModerationConfig.Enable {
+		return
+	}
+
+	moderator, err := NewModerator(s.cfg.ModerationConfig, s.logger)
+	if err != nil {
+		s.logger.Error("failed to set up content moderation", zap.Error(err))
+		return
+	}
+
+	filter := NewModerationFilter(moderator, s.cfg.ModerationConfig.Policy)
+	guardrails := s.guardrails
+	if guardrails == nil {
+		guardrails = NewGuardrails(s.logger)
+	}
+	if s.cfg.ModerationConfig.Input {
+		guardrails.AddInputFilters(filter)
+	}
+	if s.cfg.ModerationConfig.Output {
+		guardrails.AddOutputFilters(filter)
+	}
+	s.setGuardrails(guardrails)
+
+	s.logger.Info("content moderation enabled",
+		zap.String("provider", s.cfg.ModerationConfig.Provider),
+		zap.String("policy", s.cfg.ModerationConfig.Policy),
+		zap.Bool("input", s.cfg.ModerationConfig.Input),
+		zap.Bool("output", s.cfg.ModerationConfig.Output))
+}
+
+// setGuardrails sets the guardrails run on incoming messages and on the agent's responses
+func (s *A2AServerImpl) setGuardrails(guardrails *Guardrails) {
+	s.guardrails = guardrails
+	if ph, ok := s.protocolHandler.(*DefaultA2AProtocolHandler); ok {
+		ph.SetGuardrails(guardrails)
+	}
+}
+
+// setupFileIngestion sniffs, validates and converts the file parts of incoming messages when
+// file ingestion is enabled
+func (s *A2AServerImpl) setupFileIngestion() {
+	if !s.cfg.FileIngestionConfig.Enable {
+		return
+	}
+
+	s.setFileIngestion(NewFileIngestion(s.cfg.FileIngestionConfig, s.logger))
+	s.logger.Info("file ingestion enabled",
+		zap.Strings("allowed_types", s.cfg.FileIngestionConfig.AllowedTypes),
+		zap.Bool("extract_text", s.cfg.FileIngestionConfig.ExtractText),
+		zap.Bool("thumbnails", s.cfg.FileIngestionConfig.Thumbnails))
+}
+
+// setFileIngestion sets the processing applied to the file parts of incoming messages
+func (s *A2AServerImpl) setFileIngestion(ingestion *FileIngestion) {
+	s.fileIngestion = ingestion
+	if ph, ok := s.protocolHandler.(*DefaultA2AProtocolHandler); ok {
+		ph.SetFileIngestion(ingestion)
+	}
+}
+
+// setupSpeech transcribes inbound audio and synthesizes spoken responses when the speech
+// subsystems are enabled
+func (s *A2AServerImpl) setupSpeech() {
+	cfg := s.cfg.SpeechConfig
+	if cfg.Transcription {
+		s.setTranscriber(NewOpenAITranscriber(cfg, s.logger))
+		s.logger.Info("speech transcription enabled", zap.String("model", cfg.TranscriptionModel))
+	}
+
+	if cfg.Synthesis {
+		synthesizer, err := NewOpenAISpeechSynthesizer(cfg, s.logger)
+		if err != nil {
+			s.logger.Error("failed to set up speech synthesis", zap.Error(err))
+			return
+		}
+		s.setSpeechOutput(NewSpeechOutput(synthesizer, cfg.MaxSynthesisLength, s.logger))
+		s.logger.Info("speech synthesis enabled",
+			zap.String("model", cfg.SynthesisModel),
+			zap.String("voice", cfg.Voice),
+			zap.String("format", cfg.Format))
+	}
+}
+
+// setTranscriber transcribes the audio file parts of incoming messages with the transcriber,
+// enabling file ingestion when it is not already
+func (s *A2AServerImpl) setTranscriber(transcriber Transcriber) {
+	ingestion := s.fileIngestion
+	if ingestion == nil {
+		ingestion = NewFileIngestion(s.cfg.FileIngestionConfig, s.logger)
+	}
+	ingestion.UseConverters(NewTranscriptionConverter(transcriber))
+	s.setFileIngestion(ingestion)
+	s.transcription = true
+}
+
+// setSpeechOutput sets the synthesis of the agent's responses to audio artifacts
+func (s *A2AServerImpl) setSpeechOutput(output *SpeechOutput) {
+	s.speechOutput = output
+	if ph, ok := s.protocolHandler.(*DefaultA2AProtocolHandler); ok {
+		ph.SetSpeechOutput(output)
+	}
+}
+
+// setArtifactPreviews sets the inline previews of small file artifacts attached to the response
+// message of completed tasks
+func (s *A2AServerImpl) setArtifactPreviews(previews *ArtifactPreviews) {
+	s.previews = previews
+	if ph, ok := s.protocolHandler.(*DefaultA2AProtocolHandler); ok {
+		ph.SetArtifactPreviews(previews)
+	}
+}
+
+// setTaskTranscripts sets the rendering of the conversation of completed tasks into a
+// transcript artifact
+func (s *A2AServerImpl) setTaskTranscripts(transcripts *TaskTranscripts) {
+	s.transcripts = transcripts
+	if ph, ok := s.protocolHandler.(*DefaultA2AProtocolHandler); ok {
+		ph.SetTaskTranscripts(transcripts)
+	}
+}
+
+// setMemoryService sets the long-term memory extracted from completed tasks and recalled in
+// agent runs
+func (s *A2AServerImpl) setMemoryService(memories *MemoryService) {
+	s.memories = memories
+	if ph, ok := s.protocolHandler.(*DefaultA2AProtocolHandler); ok {
+		ph.SetMemoryService(memories)
+	}
+}
+
+// useHTTPMiddleware appends middleware run on every request to the HTTP router
+func (s *A2AServerImpl) useHTTPMiddleware(mw ...gin.HandlerFunc) {
+	s.httpMiddleware = append(s.httpMiddleware, mw...)
+}
+
+// setEventSink exports the events of the tasks processed by the default handlers to the sink
+func (s *A2AServerImpl) setEventSink(sink EventSink) {
+	s.events = newEventExport(sink, s.logger)
+	if ph, ok := s.protocolHandler.(*DefaultA2AProtocolHandler); ok {
+		ph.setEventExport(s.events)
+	}
+	if bth, ok := s.backgroundTaskHandler.(*DefaultBackgroundTaskHandler); ok {
+		bth.setEventExport(s.events)
+	}
+}
+
+// setAuditLogger records the JSON-RPC calls and the tool invocations of the processed tasks
+// through the audit logger
+func (s *A2AServerImpl) setAuditLogger(auditLogger AuditLogger) {
+	s.audit = newAuditLog(auditLogger, s.logger)
 }
 
 // SetBackgroundTaskHandler sets the task handler for polling/queue-based scenarios
@@ -328,18 +727,89 @@ func (s *A2AServerImpl) SetAgentVersion(version string) {
 
 // SetAgentCard sets a custom agent card that overrides the default card generation
 func (s *A2AServerImpl) SetAgentCard(agentCard types.AgentCard) {
+	agentCard = s.withServerExtensions(agentCard)
+
+	s.cardMu.Lock()
+	defer s.cardMu.Unlock()
+	if s.extendedAgentCard != nil {
+		agentCard.SupportsExtendedAgentCard = new(true)
+	}
 	s.customAgentCard = &agentCard
+	s.trackStateTransitions(agentCard)
+}
+
+// trackStateTransitions makes the task manager record the state transitions of the tasks when
+// the agent card advertises the stateTransitionHistory capability
+func (s *A2AServerImpl) trackStateTransitions(agentCard types.AgentCard) {
+	if defaultTM, ok := s.taskManager.(*DefaultTaskManager); ok {
+		enabled := agentCard.Capabilities.StateTransitionHistory
+		defaultTM.SetStateTransitionHistory(enabled != nil && *enabled)
+	}
+}
+
+// SetExtendedAgentCard sets the card returned by agent/getAuthenticatedExtendedCard. The
+// public card advertises that an extended card is available.
+func (s *A2AServerImpl) SetExtendedAgentCard(agentCard types.AgentCard) {
+	if !s.cfg.AuthConfig.Enable {
+		s.logger.Warn("authentication is disabled, the extended agent card is served to every caller")
+	}
+
+	agentCard = s.withServerExtensions(agentCard)
+	agentCard.SupportsExtendedAgentCard = new(true)
+
+	s.cardMu.Lock()
+	defer s.cardMu.Unlock()
+	s.extendedAgentCard = &agentCard
+
+	if s.customAgentCard != nil {
+		public := *s.customAgentCard
+		public.SupportsExtendedAgentCard = new(true)
+		s.customAgentCard = &public
+	}
+}
+
+// withServerExtensions adds the extensions of the enabled server features to an agent card
+func (s *A2AServerImpl) withServerExtensions(agentCard types.AgentCard) types.AgentCard {
+	if s.languagePolicy != nil {
+		agentCard = s.languagePolicy.withLanguageExtension(agentCard)
+	}
+	if s.cfg.CapabilitiesConfig.WebSocket {
+		agentCard = withWebSocketExtension(agentCard)
+	}
+	if s.transcription {
+		agentCard = withAudioInputMode(agentCard)
+	}
+	if s.speechOutput != nil {
+		agentCard = s.speechOutput.withOutputMode(agentCard)
+	}
+	if s.cfg.ArtifactsConfig.Enable && s.cfg.ArtifactsConfig.ServerConfig.EnableUpload {
+		agentCard = withArtifactUploadExtension(agentCard, artifactUploadURL(&s.cfg.ArtifactsConfig))
+	}
+	return agentCard
+}
+
+// authenticatedAgentCard returns the card for agent/getAuthenticatedExtendedCard: the
+// extended card when one is set, and the public card otherwise
+func (s *A2AServerImpl) authenticatedAgentCard() *types.AgentCard {
+	s.cardMu.RLock()
+	defer s.cardMu.RUnlock()
+
+	if s.extendedAgentCard != nil {
+		return s.extendedAgentCard
+	}
+	return s.customAgentCard
 }
 
 // validateStreamingConfiguration checks if streaming is enabled but no streaming handler is configured
 func (s *A2AServerImpl) validateStreamingConfiguration() {
-	if s.customAgentCard == nil {
+	agentCard := s.GetAgentCard()
+	if agentCard == nil {
 		return
 	}
 
 	streamingEnabled := false
-	if s.customAgentCard.Capabilities.Streaming != nil {
-		streamingEnabled = *s.customAgentCard.Capabilities.Streaming
+	if agentCard.Capabilities.Streaming != nil {
+		streamingEnabled = *agentCard.Capabilities.Streaming
 	}
 
 	if streamingEnabled {
@@ -395,7 +865,16 @@ func (s *A2AServerImpl) LoadAgentCardFromFile(filePath string, overrides map[str
 		zap.String("name", agentCard.Name),
 		zap.String("version", agentCard.Version),
 		zap.Int("overrides_count", len(overrides)))
-	s.customAgentCard = &agentCard
+	s.SetAgentCard(agentCard)
+	return nil
+}
+
+// RegisterJSONRPCMethod serves an additional JSON-RPC method on the A2A endpoint
+func (s *A2AServerImpl) RegisterJSONRPCMethod(method string, handler JSONRPCMethodHandler) error {
+	if err := s.jsonrpcMethods.Register(method, handler); err != nil {
+		return err
+	}
+	s.logger.Info("registered custom json-rpc method", zap.String("method", method))
 	return nil
 }
 
@@ -410,13 +889,28 @@ func (s *A2AServerImpl) setupRouter(cfg *config.Config) *gin.Engine {
 
 	r.Use(gin.Recovery())
 	r.Use(middlewares.LoggingMiddleware(cfg.ServerConfig.DisableHealthcheckLog))
+	r.Use(middlewares.RequestIDMiddleware())
+	if cfg.ServerConfig.CORS.Enable {
+		r.Use(middlewares.CORSMiddleware(cfg.ServerConfig.CORS))
+	}
+	if len(s.httpMiddleware) > 0 {
+		r.Use(s.httpMiddleware...)
+	}
 
 	r.GET("/health", func(c *gin.Context) {
+		if s.drain.isDraining() {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": types.HealthStatusUnhealthy})
+			return
+		}
 		c.JSON(http.StatusOK, gin.H{"status": types.HealthStatusHealthy})
 	})
 
 	r.GET("/.well-known/agent-card.json", s.handleAgentInfo)
 
+	if s.taskShares != nil {
+		r.GET("/share/:token", s.handleSharedTranscript)
+	}
+
 	var telemetryMiddleware gin.HandlerFunc
 	if s.otel != nil {
 		telemetryMw, err := middlewares.NewTelemetryMiddleware(*s.cfg, s.otel, s.logger)
@@ -427,46 +921,88 @@ func (s *A2AServerImpl) setupRouter(cfg *config.Config) *gin.Engine {
 		}
 	}
 
-	if !cfg.AuthConfig.Enable {
-		if telemetryMiddleware != nil {
-			r.POST("/a2a", telemetryMiddleware, s.handleA2ARequest)
-		} else {
-			r.POST("/a2a", s.handleA2ARequest)
+	var handlers []gin.HandlerFunc
+	if telemetryMiddleware != nil {
+		handlers = append(handlers, telemetryMiddleware)
+	}
+
+	if cfg.AuthConfig.Enable {
+		oidcAuthenticator, err := middlewares.NewOIDCAuthenticatorMiddleware(s.logger, *s.cfg)
+		if err != nil {
+			s.logger.Error("failed to create OIDC authenticator", zap.Error(err))
+			return r
 		}
+		s.logger.Info("oidcAuthenticator is valid, setting up authentication")
+		handlers = append(handlers, oidcAuthenticator.Middleware())
+	} else {
 		s.logger.Warn("authentication is disabled, oidcAuthenticator will be nil")
-		return r
-	}
-	oidcAuthenticator, err := middlewares.NewOIDCAuthenticatorMiddleware(s.logger, *s.cfg)
-	if err != nil {
-		s.logger.Error("failed to create OIDC authenticator", zap.Error(err))
-		return r
 	}
 
-	s.logger.Info("oidcAuthenticator is valid, setting up authentication")
-	if telemetryMiddleware != nil {
-		r.POST("/a2a", telemetryMiddleware, oidcAuthenticator.Middleware(), s.handleA2ARequest)
-	} else {
-		r.POST("/a2a", oidcAuthenticator.Middleware(), s.handleA2ARequest)
+	r.POST("/a2a", append(slices.Clone(handlers), s.handleA2ARequest)...)
+	r.GET("/artifacts", append(slices.Clone(handlers), s.handleArtifactList)...)
+	s.mountNamedAgents(r, handlers)
+	if cfg.ServerConfig.EnableDebugEndpoints {
+		r.GET("/debug/stats", append(slices.Clone(handlers), s.handleDebugStats)...)
+		r.GET("/debug/dump", append(slices.Clone(handlers), s.handleDebugDump)...)
+	}
+	if cfg.ServerConfig.EnableAdminEndpoints {
+		s.mountAdminEndpoints(r, handlers)
+	}
+	if cfg.CapabilitiesConfig.WebSocket {
+		r.GET(types.WebSocketPath, append(slices.Clone(handlers), s.handleA2AWebSocket)...)
+	}
+	if cfg.ServerConfig.EnableChatCompletions {
+		if s.streamingTaskHandler != nil {
+			r.POST(chatCompletionsPath, append(slices.Clone(handlers), s.handleChatCompletions)...)
+			r.GET(chatModelsPath, append(slices.Clone(handlers), s.handleChatModels)...)
+		} else {
+			s.logger.Warn("chat completions endpoint requires a streaming task handler and is disabled")
+		}
 	}
 
 	return r
 }
 
+// newHTTPServer creates the HTTP server for the A2A endpoints with the configured
+// keep-alive and HTTP/2 settings
+func newHTTPServer(cfg config.ServerConfig, handler http.Handler) *http.Server {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(!cfg.HTTP2Config.Disable)
+	protocols.SetUnencryptedHTTP2(!cfg.HTTP2Config.Disable && cfg.HTTP2Config.Cleartext)
+
+	httpServer := &http.Server{
+		Addr:              fmt.Sprintf(":%s", cfg.Port),
+		Handler:           handler,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		Protocols:         protocols,
+		HTTP2: &http.HTTP2Config{
+			MaxConcurrentStreams: cfg.HTTP2Config.MaxConcurrentStreams,
+			SendPingTimeout:      cfg.HTTP2Config.PingInterval,
+			PingTimeout:          cfg.HTTP2Config.PingTimeout,
+		},
+	}
+	httpServer.SetKeepAlivesEnabled(!cfg.DisableKeepAlives)
+	return httpServer
+}
+
+// Handler returns the HTTP handler serving the A2A endpoints
+func (s *A2AServerImpl) Handler() http.Handler {
+	return s.setupRouter(s.cfg)
+}
+
 // Start starts the A2A server
 func (s *A2AServerImpl) Start(ctx context.Context) error {
-	if s.customAgentCard == nil {
+	if s.GetAgentCard() == nil {
 		return fmt.Errorf("agent card must be configured before starting the server - use SetAgentCard() or LoadAgentCardFromFile()")
 	}
 
 	router := s.setupRouter(s.cfg)
 
-	s.httpServer = &http.Server{
-		Addr:         fmt.Sprintf(":%s", s.cfg.ServerConfig.Port),
-		Handler:      router,
-		ReadTimeout:  s.cfg.ServerConfig.ReadTimeout,
-		WriteTimeout: s.cfg.ServerConfig.WriteTimeout,
-		IdleTimeout:  s.cfg.ServerConfig.IdleTimeout,
-	}
+	s.httpServer = newHTTPServer(s.cfg.ServerConfig, router)
 
 	s.logger.Info("starting A2A server",
 		zap.String("port", s.cfg.ServerConfig.Port),
@@ -474,8 +1010,6 @@ func (s *A2AServerImpl) Start(ctx context.Context) error {
 		zap.String("agent_description", s.cfg.AgentDescription),
 		zap.String("agent_version", s.cfg.AgentVersion))
 
-	s.validateStreamingConfiguration()
-
 	resolvedTelemetry := s.cfg.ResolveTelemetry()
 	if s.otel != nil && resolvedTelemetry.MetricsExporter == config.MetricsExporterPrometheus {
 		go func() {
@@ -498,21 +1032,77 @@ func (s *A2AServerImpl) Start(ctx context.Context) error {
 		}()
 	}
 
-	go s.StartTaskProcessor(ctx)
+	tlsConfig := s.cfg.ServerConfig.TLSConfig
+	if tlsConfig.Enable {
+		certificates, err := newCertificateStore(tlsConfig, httpNextProtos(!s.cfg.ServerConfig.HTTP2Config.Disable), s.logger)
+		if err != nil {
+			return err
+		}
+		s.httpServer.TLSConfig = certificates.serverConfig()
+		go certificates.watch(ctx, tlsConfig.ReloadInterval)
+	}
 
-	if s.cfg.ServerConfig.TLSConfig.Enable {
-		return s.httpServer.ListenAndServeTLS(s.cfg.ServerConfig.TLSConfig.CertPath, s.cfg.ServerConfig.TLSConfig.KeyPath)
+	if err := s.StartBackground(ctx); err != nil {
+		return err
+	}
+
+	if tlsConfig.Enable {
+		return s.httpServer.ListenAndServeTLS("", "")
 	}
 
 	return s.httpServer.ListenAndServe()
 }
 
+// StartBackground starts the task processors, periodic jobs, secret rotation and hot reload
+// of the server without serving HTTP
+func (s *A2AServerImpl) StartBackground(ctx context.Context) error {
+	if s.GetAgentCard() == nil {
+		return fmt.Errorf("agent card must be configured before starting the server - use SetAgentCard() or LoadAgentCardFromFile()")
+	}
+
+	s.validateStreamingConfiguration()
+
+	if s.otel != nil {
+		if err := s.registerInternalMetrics(); err != nil {
+			s.logger.Error("failed to register queue and scheduler metrics", zap.Error(err))
+		}
+	}
+
+	s.recoverInterruptedTasks(ctx)
+	s.checkHeldTasks(ctx)
+	go s.StartTaskProcessor(ctx)
+	s.startNamedAgents(ctx)
+	if s.taskCipher != nil {
+		go s.reencryptTasks(ctx)
+	}
+	if s.secrets != nil {
+		go s.secrets.run(ctx)
+	}
+	if s.reloader != nil {
+		go s.reloader.run(ctx)
+	}
+	return nil
+}
+
 // Stop gracefully stops the A2A server
 func (s *A2AServerImpl) Stop(ctx context.Context) error {
 	s.logger.Info("stopping A2A server")
 
 	var err error
 
+	if s.httpServer != nil {
+		s.httpServer.SetKeepAlivesEnabled(false)
+	}
+
+	drainTimeout := s.cfg.ServerConfig.DrainTimeout
+	if drainTimeout <= 0 {
+		drainTimeout = defaultDrainTimeout
+	}
+	if !s.drainWithNamedAgents(ctx, drainTimeout) {
+		s.logger.Warn("in-flight work did not finish within the drain timeout and was checkpointed",
+			zap.Duration("drain_timeout", drainTimeout))
+	}
+
 	if s.httpServer != nil {
 		if shutdownErr := s.httpServer.Shutdown(ctx); shutdownErr != nil {
 			s.logger.Error("error stopping HTTP server", zap.Error(shutdownErr))
@@ -529,6 +1119,20 @@ func (s *A2AServerImpl) Stop(ctx context.Context) error {
 		}
 	}
 
+	if closeErr := s.events.close(ctx); closeErr != nil {
+		s.logger.Error("error closing event sink", zap.Error(closeErr))
+		if err == nil {
+			err = closeErr
+		}
+	}
+
+	if closeErr := s.audit.close(); closeErr != nil {
+		s.logger.Error("error closing audit logger", zap.Error(closeErr))
+		if err == nil {
+			err = closeErr
+		}
+	}
+
 	if s.otel != nil {
 		if shutdownErr := s.otel.ShutDown(ctx); shutdownErr != nil {
 			s.logger.Error("error shutting down telemetry", zap.Error(shutdownErr))
@@ -550,23 +1154,36 @@ func (s *A2AServerImpl) Stop(ctx context.Context) error {
 // GetAgentCard returns the agent's capabilities and metadata
 // Returns nil if no agent card has been explicitly set
 func (s *A2AServerImpl) GetAgentCard() *types.AgentCard {
+	s.cardMu.RLock()
+	defer s.cardMu.RUnlock()
 	return s.customAgentCard
 }
 
 // StartTaskProcessor starts the background task processing goroutine
 func (s *A2AServerImpl) StartTaskProcessor(ctx context.Context) {
 	s.logger.Info("starting task processor")
+	s.workers.start(s.clock.Now())
 
 	go s.startTaskCleanup(ctx)
+	go s.startInputTimeout(ctx)
+	go s.startSchedules(ctx)
+	go s.startDependencyChecks(ctx)
+	go s.startTaskRecovery(ctx)
+
+	dequeueCtx, stopDequeue := s.dequeueContext(ctx)
+	defer stopDequeue()
 
 	for {
 		select {
-		case <-ctx.Done():
+		case <-dequeueCtx.Done():
 			s.logger.Info("task processor shutting down")
 			return
 		default:
-			queuedTask, err := s.storage.DequeueTask(ctx)
+			queuedTask, err := s.dequeueUnlessPaused(dequeueCtx)
 			if err != nil {
+				if err == errQueuePauseChanged {
+					continue
+				}
 				if err == context.Canceled || err == context.DeadlineExceeded {
 					s.logger.Info("task processor shutting down due to context cancellation")
 					return
@@ -576,7 +1193,9 @@ func (s *A2AServerImpl) StartTaskProcessor(ctx context.Context) {
 			}
 
 			if queuedTask != nil {
+				s.workers.begin(queuedTask.Task.ID, s.clock.Now())
 				s.processQueuedTask(ctx, queuedTask)
+				s.workers.end(s.clock.Now())
 			}
 		}
 	}
@@ -587,6 +1206,10 @@ func (s *A2AServerImpl) processQueuedTask(ctx context.Context, queuedTask *Queue
 	task := queuedTask.Task
 
 	ctx = extractTraceContext(ctx, queuedTask.TraceContext)
+	ctx = contextWithTaskRequestID(ctx, task)
+	ctx = contextWithAuditLog(ctx, s.audit)
+	ctx = contextWithPolicy(ctx, s.policy)
+	logger := requestLogger(ctx, s.logger)
 	ctx, span := sdkotel.Tracer("github.com/inference-gateway/adk/server").Start(ctx, "task.process",
 		trace.WithAttributes(attribute.String("a2a.task.id", task.ID)))
 	defer span.End()
@@ -596,21 +1219,49 @@ func (s *A2AServerImpl) processQueuedTask(ctx context.Context, queuedTask *Queue
 		message = task.Status.Message
 	} else {
 		message = &types.Message{
-			MessageID: uuid.New().String(),
+			MessageID: s.ids.NewID(),
 			Role:      "user",
 			Parts:     []types.Part{},
 		}
 	}
 
-	s.logger.Info("processing task",
+	var checkpoint *types.Task
+	if s.handoffEnabled() {
+		if !s.drain.acquire() {
+			s.handOffTask(ctx, task, queuedTask.RequestID, message)
+			return
+		}
+		defer s.drain.release()
+		checkpoint = checkpointTask(task)
+	}
+
+	if task.Metadata != nil {
+		if from, ok := (*task.Metadata)[types.HandoffFromMetadataKey].(string); ok && from != s.instanceID {
+			logger.Info("resuming task handed off by another instance",
+				zap.String("task_id", task.ID),
+				zap.String("handoff_from", from))
+		}
+	}
+
+	if stored, exists := s.taskManager.GetTask(task.ID); exists && isTerminalTaskState(stored.Status.State) {
+		logger.Info("skipping task that ended while queued",
+			zap.String("task_id", task.ID),
+			zap.String("context_id", task.ContextID))
+		return
+	}
+
+	logger.Info("processing task",
 		zap.String("task_id", task.ID),
 		zap.String("context_id", task.ContextID))
 
 	err := s.taskManager.UpdateState(task.ID, types.TaskStateWorking)
 	if err != nil {
-		s.logger.Error("failed to update task state", zap.Error(err))
+		logger.Error("failed to update task state", zap.Error(err))
 		return
 	}
+	if defaultTM, ok := s.taskManager.(*DefaultTaskManager); ok {
+		defer defaultTM.dispatchQueuedInput(ctx, task.ID)
+	}
 
 	taskCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -619,21 +1270,46 @@ func (s *A2AServerImpl) processQueuedTask(ctx context.Context, queuedTask *Queue
 		defer defaultTM.UnregisterTaskCancelFunc(task.ID)
 	}
 
-	updatedTask, err := s.backgroundTaskHandler.HandleTask(taskCtx, task, message)
+	var handedOff atomic.Bool
+	if s.handoffEnabled() {
+		go func() {
+			select {
+			case <-s.drain.expiredChan():
+				handedOff.Store(true)
+				cancel()
+			case <-taskCtx.Done():
+			}
+		}()
+	}
+
+	progress := newTaskProgress(s.taskManager, s.clock, task.ID, logger, false)
+	runCtx := s.memories.runContext(s.credentials.context(s.checkpoints.runContext(taskCtx, task.ID), task.ID), task, message)
+	updatedTask, err := s.backgroundTaskHandler.HandleTask(progress.context(runCtx), task, message)
+	if err != nil && handedOff.Load() {
+		s.handOffTask(ctx, checkpoint, queuedTask.RequestID, message)
+		return
+	}
+	s.checkpoints.clear(ctx, task.ID)
+	if stored, exists := s.taskManager.GetTask(task.ID); taskCtx.Err() != nil && exists && isTerminalTaskState(stored.Status.State) {
+		logger.Info("task was ended while processing",
+			zap.String("task_id", task.ID),
+			zap.String("context_id", task.ContextID))
+		return
+	}
 	if err != nil {
-		s.logger.Error("failed to process task",
+		logger.Error("failed to process task",
 			zap.Error(err),
 			zap.String("task_id", task.ID),
 			zap.String("context_id", task.ContextID))
 		updateErr := s.taskManager.UpdateError(task.ID, &types.Message{
-			MessageID: uuid.New().String(),
+			MessageID: s.ids.NewID(),
 			Role:      types.RoleAgent,
 			Parts: []types.Part{
 				types.CreateTextPart(err.Error()),
 			},
 		})
 		if updateErr != nil {
-			s.logger.Error("failed to update task to failed state",
+			logger.Error("failed to update task to failed state",
 				zap.Error(updateErr),
 				zap.String("task_id", task.ID),
 				zap.String("context_id", task.ContextID))
@@ -641,14 +1317,22 @@ func (s *A2AServerImpl) processQueuedTask(ctx context.Context, queuedTask *Queue
 		return
 	}
 
+	progress.apply(updatedTask)
+	s.guardrails.checkTaskOutput(ctx, updatedTask)
+	s.artifactRedactor.redactTaskArtifacts(ctx, updatedTask, s.logger)
+	s.speechOutput.synthesizeTask(ctx, updatedTask)
+	s.transcripts.attach(updatedTask)
+	updatedTask.Status.Message = s.previews.attach(ctx, updatedTask, updatedTask.Status.Message)
+
 	if err := s.taskManager.UpdateTask(updatedTask); err != nil {
-		s.logger.Error("failed to update task",
+		logger.Error("failed to update task",
 			zap.Error(err),
 			zap.String("task_id", updatedTask.ID),
 			zap.String("context_id", updatedTask.ContextID))
 		return
 	}
-	s.logger.Info("task processed successfully",
+	s.memories.rememberInBackground(ctx, updatedTask)
+	logger.Info("task processed successfully",
 		zap.String("task_id", task.ID),
 		zap.String("context_id", task.ContextID))
 }
@@ -666,6 +1350,10 @@ func (s *A2AServerImpl) startTaskCleanup(ctx context.Context) {
 	ticker := time.NewTicker(cleanupInterval)
 	defer ticker.Stop()
 
+	job := newPeriodicJob("task_cleanup", cleanupInterval, s.clock.Now())
+	s.queueCleanupJob.Store(job)
+	defer s.queueCleanupJob.Store(nil)
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -673,6 +1361,7 @@ func (s *A2AServerImpl) startTaskCleanup(ctx context.Context) {
 			return
 		case <-ticker.C:
 			s.taskManager.CleanupCompletedTasks()
+			job.ran(s.clock.Now())
 		}
 	}
 }
@@ -689,14 +1378,44 @@ func (s *A2AServerImpl) handleAgentInfo(c *gin.Context) {
 		})
 		return
 	}
-	c.JSON(http.StatusOK, *agentCard)
+
+	agentCard, err := s.resolveAgentCard(c.Request.Context(), agentCard)
+	if err != nil {
+		s.logger.Error("agent card provider failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve agent card"})
+		return
+	}
+
+	body, err := json.Marshal(*agentCard)
+	if err != nil {
+		s.logger.Error("failed to encode agent card", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to encode agent card"})
+		return
+	}
+
+	etag := agentCardETag(body)
+	c.Header("ETag", etag)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
 }
 
 // handleA2ARequest processes A2A protocol requests
 func (s *A2AServerImpl) handleA2ARequest(c *gin.Context) {
+	logger := requestLogger(c.Request.Context(), s.logger)
 	var req types.JSONRPCRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		s.logger.Error("failed to parse json request", zap.Error(err))
+	bodySize, err := s.decodeA2ARequest(c, &req)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			logger.Warn("rejected oversized a2a request", zap.Int64("limit", tooLarge.Limit))
+			limitErr := bodyTooLargeError(tooLarge.Limit)
+			s.responseSender.SendError(c, nil, limitErr.Code, limitErr.Message)
+			return
+		}
+		logger.Error("failed to parse json request", zap.Error(err))
 		s.responseSender.SendError(c, req.ID, int(ErrParseError), "parse error")
 		return
 	}
@@ -705,17 +1424,35 @@ func (s *A2AServerImpl) handleA2ARequest(c *gin.Context) {
 		req.JSONRPC = "2.0"
 	}
 	if req.ID == nil {
-		id := any(uuid.New().String())
+		id := any(s.ids.NewID())
 		req.ID = &id
 	}
 
-	s.logger.Info("received a2a request",
+	logger.Info("received a2a request",
 		zap.String("method", req.Method),
 		zap.Any("id", req.ID))
 
+	s.identifyUser(c, &req)
+	if validationErr := s.validateA2ARequest(c.Request.Context(), req, bodySize); validationErr != nil {
+		logger.Warn("rejected invalid a2a request",
+			zap.String("method", req.Method),
+			zap.String("reason", validationErr.Message))
+		s.sendMethodError(c, req.ID, validationErr)
+		return
+	}
+
+	if s.rejectDuringDrain(c, req) {
+		return
+	}
+
+	s.audit.call(c, req)
+	c.Request = c.Request.WithContext(contextWithPolicy(contextWithAuditLog(c.Request.Context(), s.audit), s.policy))
+
 	switch req.Method {
 	case "message/send":
-		s.protocolHandler.HandleMessageSend(c, req)
+		s.handleMessageSend(c, req)
+	case "message/sendBatch":
+		s.protocolHandler.HandleMessageSendBatch(c, req)
 	case "message/stream":
 		s.protocolHandler.HandleMessageStream(c, req, s.streamingTaskHandler)
 	case "tasks/get":
@@ -724,6 +1461,34 @@ func (s *A2AServerImpl) handleA2ARequest(c *gin.Context) {
 		s.protocolHandler.HandleTaskList(c, req)
 	case "tasks/cancel":
 		s.protocolHandler.HandleTaskCancel(c, req)
+	case "tasks/feedback":
+		s.protocolHandler.HandleTaskFeedback(c, req)
+	case "tasks/share":
+		s.protocolHandler.HandleTaskShare(c, req)
+	case "tasks/usage":
+		s.protocolHandler.HandleTaskUsage(c, req)
+	case "tasks/fork":
+		s.protocolHandler.HandleTaskFork(c, req)
+	case "contexts/create":
+		s.protocolHandler.HandleContextCreate(c, req)
+	case "contexts/get":
+		s.protocolHandler.HandleContextGet(c, req)
+	case "contexts/list":
+		s.protocolHandler.HandleContextList(c, req)
+	case "contexts/delete":
+		s.protocolHandler.HandleContextDelete(c, req)
+	case "artifacts/list":
+		s.protocolHandler.HandleArtifactList(c, req)
+	case "memories/create":
+		s.protocolHandler.HandleMemoryCreate(c, req)
+	case "memories/get":
+		s.protocolHandler.HandleMemoryGet(c, req)
+	case "memories/list":
+		s.protocolHandler.HandleMemoryList(c, req)
+	case "memories/update":
+		s.protocolHandler.HandleMemoryUpdate(c, req)
+	case "memories/delete":
+		s.protocolHandler.HandleMemoryDelete(c, req)
 	case "tasks/pushNotificationConfig/set":
 		s.protocolHandler.HandleTaskPushNotificationConfigSet(c, req)
 	case "tasks/pushNotificationConfig/get":
@@ -735,9 +1500,84 @@ func (s *A2AServerImpl) handleA2ARequest(c *gin.Context) {
 	case "tasks/resubscribe":
 		s.protocolHandler.HandleTaskResubscribe(c, req, s.streamingTaskHandler)
 	case "agent/getAuthenticatedExtendedCard":
-		s.protocolHandler.HandleGetAuthenticatedExtendedCard(c, req, s.customAgentCard)
+		s.handleGetAuthenticatedExtendedCard(c, req)
 	default:
-		s.logger.Warn("unknown method requested", zap.String("method", req.Method))
+		s.handleCustomMethod(c, req)
+	}
+}
+
+// validateA2ARequest checks that a request names a method and, for messages, that its params
+// match the A2A schema, then checks it against the user it is made for, the payload limits, the
+// access policy and, for messages, the form the task waits for and the input modes of the agent
+// card, returning the error to answer the request with
+func (s *A2AServerImpl) validateA2ARequest(ctx context.Context, req types.JSONRPCRequest, bodySize int64) *JSONRPCMethodError {
+	if req.Method == "" {
+		return &JSONRPCMethodError{Code: int(ErrInvalidRequest), Message: "invalid request: method is required"}
+	}
+	if err := s.validateParams(req); err != nil {
+		return err
+	}
+	if err := s.checkUserAccess(ctx, req); err != nil {
+		return err
+	}
+	params := decodeMessageParams(req)
+	if err := s.checkPayloadLimits(req, bodySize, params); err != nil {
+		return err
+	}
+	if batch := decodeBatchParams(req); batch != nil {
+		return s.checkBatch(ctx, req, batch)
+	}
+	if err := s.checkMethodPolicy(ctx, req, params); err != nil {
+		return err
+	}
+	if params == nil {
+		return nil
+	}
+	if err := s.fileIngestion.checkMessage(params.Message); err != nil {
+		return err
+	}
+	if err := s.checkFormResponse(params.Message); err != nil {
+		return err
+	}
+	if s.cfg.EnforceInputModes {
+		return s.checkInputModes(ctx, params.Message)
+	}
+	return nil
+}
+
+// sendMethodError answers a request with the error, attaching its data when the response
+// sender supports it
+func (s *A2AServerImpl) sendMethodError(c *gin.Context, id any, err *JSONRPCMethodError) {
+	if sender, ok := s.responseSender.(ErrorDataSender); ok && err.Data != nil {
+		sender.SendErrorWithData(c, id, err.Code, err.Message, err.Data)
+		return
+	}
+	s.responseSender.SendError(c, id, err.Code, err.Message)
+}
+
+// handleCustomMethod dispatches a request to a registered custom JSON-RPC method
+func (s *A2AServerImpl) handleCustomMethod(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), s.logger)
+	handler, exists := s.jsonrpcMethods.Lookup(req.Method)
+	if !exists {
+		logger.Warn("unknown method requested", zap.String("method", req.Method))
 		s.responseSender.SendError(c, req.ID, int(ErrMethodNotFound), "method not found")
+		return
 	}
+
+	result, err := handler(c, req.Params)
+	if err != nil {
+		var methodErr *JSONRPCMethodError
+		if errors.As(err, &methodErr) {
+			s.sendMethodError(c, req.ID, methodErr)
+			return
+		}
+		logger.Error("custom json-rpc method failed",
+			zap.String("method", req.Method),
+			zap.Error(err))
+		s.responseSender.SendError(c, req.ID, int(ErrInternalError), "internal error")
+		return
+	}
+
+	s.responseSender.SendSuccess(c, req.ID, result)
 }
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 
+	gin "github.com/gin-gonic/gin"
 	zap "go.uber.org/zap"
 
 	config "github.com/inference-gateway/adk/server/config"
@@ -59,6 +61,36 @@ type A2AServerBuilder interface {
 	// The optional overrides map allows dynamic replacement of JSON attribute values.
 	WithAgentCardFromFile(filePath string, overrides map[string]any) A2AServerBuilder
 
+	// WithInferredCapabilities derives the capabilities of the agent card from what is wired
+	// when the server is built, instead of trusting the card: streaming is advertised when a
+	// streaming task handler is installed and streaming is enabled in the configuration, and
+	// push notifications and state transition history follow the configuration.
+	WithInferredCapabilities() A2AServerBuilder
+
+	// WithExtendedAgentCard sets the card returned to authenticated callers by
+	// agent/getAuthenticatedExtendedCard, separately from the public card. It can list
+	// skills and endpoints that only authenticated callers should see.
+	WithExtendedAgentCard(agentCard types.AgentCard) A2AServerBuilder
+
+	// WithAgentCardProvider sets a hook that is consulted on every agent card request, so the
+	// advertised card can reflect skills that are added or removed while the server runs.
+	WithAgentCardProvider(provider AgentCardProvider) A2AServerBuilder
+
+	// WithNamedAgent hosts an additional agent on the same server under /agents/{name}, with
+	// its own agent card at /agents/{name}/.well-known/agent-card.json and A2A endpoint at
+	// /agents/{name}/a2a. The agent brings its own toolbox and is served by the default task
+	// handlers. Names use lowercase letters, digits, '-' and '_'.
+	WithNamedAgent(name string, agent OpenAICompatibleAgent, agentCard types.AgentCard) A2AServerBuilder
+
+	// WithAgentsFromDir hosts an agent for every definition file in a directory as a named
+	// agent. The files are loaded with LoadAgentsFromDir when the server is built, using the
+	// agent configuration of the server for the LLM connection and defaults.
+	WithAgentsFromDir(path string) A2AServerBuilder
+
+	// WithConfigReloadHandler sets a handler that receives an adk.server.config.reloaded
+	// CloudEvent whenever settings are hot reloaded (see RELOAD_ENABLE).
+	WithConfigReloadHandler(handler ConfigReloadHandler) A2AServerBuilder
+
 	// WithLogger sets a custom logger for the builder and resulting server.
 	// This allows using a logger configured with appropriate level based on the Debug config.
 	WithLogger(logger *zap.Logger) A2AServerBuilder
@@ -73,6 +105,103 @@ type A2AServerBuilder interface {
 	// ignored in favor of this instance.
 	WithTelemetry(telemetry otel.OpenTelemetry) A2AServerBuilder
 
+	// WithJSONRPCMethod registers an additional JSON-RPC method served on the A2A endpoint
+	// behind the same authentication and middleware as the built-in methods.
+	// Use NewJSONRPCMethod to build a handler with typed params and result.
+	WithJSONRPCMethod(method string, handler JSONRPCMethodHandler) A2AServerBuilder
+
+	// WithTaskFeedbackHandler sets a handler that receives an adk.task.feedback CloudEvent
+	// for every feedback entry submitted through tasks/feedback.
+	WithTaskFeedbackHandler(handler TaskFeedbackHandler) A2AServerBuilder
+
+	// WithEventSink sets a sink that receives every CloudEvent of the tasks processed by the
+	// default handlers, tagged with the task and context IDs, to export them to an external
+	// event bus. Use NewHTTPEventSink or NewNATSEventSink for the built-in sinks.
+	WithEventSink(sink EventSink) A2AServerBuilder
+
+	// WithAuditLogger records every JSON-RPC call with its caller and target task, and every
+	// tool invocation of the processed tasks, through the audit logger. Use NewFileAuditLogger
+	// or NewHTTPAuditLogger for the built-in backends.
+	WithAuditLogger(auditLogger AuditLogger) A2AServerBuilder
+
+	// WithPolicy checks every JSON-RPC call and tool execution against an access policy of
+	// allow and deny rules by caller, scope, method, skill, tool and arguments, replacing
+	// the policy file configured by AUTH_POLICY_FILE. Use NewPolicy or LoadPolicy to build it.
+	WithPolicy(policy *Policy) A2AServerBuilder
+
+	// WithSchedules adds schedules of recurring agent runs, in addition to those in the file
+	// configured by SCHEDULE_FILE. Each run starts a new task with the message of its schedule
+	// and can push its results to the webhook of the schedule.
+	WithSchedules(schedules ...Schedule) A2AServerBuilder
+
+	// WithSecretsProvider resolves the configuration values referencing a secret as
+	// secret:<name> through the provider when building the server, and again at the
+	// SECRETS_REFRESH_INTERVAL while it runs. When not set, the provider configured by
+	// SECRETS_PROVIDER is used.
+	WithSecretsProvider(provider SecretsProvider) A2AServerBuilder
+
+	// WithLanguageDetector replaces the default stopword-based language detector
+	// used when language detection is enabled.
+	WithLanguageDetector(detector LanguageDetector) A2AServerBuilder
+
+	// WithTranslator sets the translator used by the translate language enforcement.
+	// When not set, the configured agent's LLM client is used.
+	WithTranslator(translator Translator) A2AServerBuilder
+
+	// WithMemoryStore sets the store of the memories extracted when memory is enabled.
+	// When not set, memories are kept in memory and lost on restart.
+	WithMemoryStore(store MemoryStore) A2AServerBuilder
+
+	// WithMemoryExtractor sets the extractor of the memories of completed tasks.
+	// When not set, the configured agent's LLM client is used.
+	WithMemoryExtractor(extractor MemoryExtractor) A2AServerBuilder
+
+	// WithMemoryEmbedder ranks the memories recalled in a run by their similarity to the
+	// incoming message. When not set, the most recent memories are recalled.
+	WithMemoryEmbedder(embedder Embedder) A2AServerBuilder
+
+	// WithInputGuardrails appends filters run on incoming user messages before the agent sees them.
+	// Filters can rewrite a message, such as redacting personal data, or block it, which rejects the task.
+	WithInputGuardrails(filters ...GuardrailFilter) A2AServerBuilder
+
+	// WithOutputGuardrails appends filters run on the agent's messages before they are returned to clients.
+	// A blocked response is replaced by a notice that it was withheld.
+	WithOutputGuardrails(filters ...GuardrailFilter) A2AServerBuilder
+
+	// WithPIIDetectors adds detectors, such as a NER model run with ONNX, to the redaction of
+	// personal data enabled with REDACTION_ENABLE. Their matches are masked when their entity is
+	// among REDACTION_ENTITIES.
+	WithPIIDetectors(detectors ...PIIDetector) A2AServerBuilder
+
+	// WithFileConverters registers converters for the file parts of incoming messages, tried
+	// before the built-in ones. File parts are sniffed and converted even when file ingestion
+	// is not enabled in the configuration.
+	WithFileConverters(converters ...FileConverter) A2AServerBuilder
+
+	// WithTranscriber sets the speech-to-text provider that transcribes the audio file parts of
+	// incoming messages, replacing the configured endpoint. Audio is accepted among the default
+	// input modes of the agent card.
+	WithTranscriber(transcriber Transcriber) A2AServerBuilder
+
+	// WithSpeechSynthesizer sets the text-to-speech provider that speaks the agent's responses
+	// into audio artifacts for requests accepting audio, replacing the configured endpoint. The
+	// audio media type is advertised among the default output modes of the agent card.
+	WithSpeechSynthesizer(synthesizer SpeechSynthesizer) A2AServerBuilder
+
+	// WithClock replaces the system clock used by the server, the default task manager and
+	// protocol handler for task timestamps and scheduled jobs, such as a fake clock making
+	// tests deterministic.
+	WithClock(clock Clock) A2AServerBuilder
+
+	// WithIDGenerator replaces the UUID generator used by the server, the default task manager
+	// and protocol handler for task, context, message and request IDs.
+	WithIDGenerator(ids IDGenerator) A2AServerBuilder
+
+	// WithHTTPMiddleware appends middleware run on every request to the HTTP router, such as
+	// compression, logging or custom authentication. It runs after the built-in request ID
+	// and CORS middleware and before the authentication of the A2A endpoints.
+	WithHTTPMiddleware(mw ...gin.HandlerFunc) A2AServerBuilder
+
 	// Build creates and returns the configured A2A server.
 	// This method applies configuration defaults and initializes all components.
 	Build() (A2AServer, error)
@@ -91,8 +220,41 @@ type A2AServerBuilderImpl struct {
 	taskResultProcessor  TaskResultProcessor   // Optional custom task result processor
 	agent                OpenAICompatibleAgent // Optional pre-configured agent
 	agentCard            *types.AgentCard      // Optional custom agent card
+	inferCapabilities    bool                  // Derive the card capabilities from the wiring
+	extendedAgentCard    *types.AgentCard      // Optional card for authenticated callers
+	agentCardProvider    AgentCardProvider     // Optional hook adjusting the card per request
+	namedAgents          []namedAgent          // Optional agents hosted under /agents/{name}
+	agentDirs            []string              // Optional directories of agent definitions
+	configReloadHandler  ConfigReloadHandler   // Optional receiver for config reload events
 	artifactService      ArtifactService       // Optional artifact service for storage operations
 	telemetry            otel.OpenTelemetry    // Optional pre-configured telemetry instance
+	jsonrpcMethods       []customJSONRPCMethod // Optional custom JSON-RPC methods
+	feedbackHandler      TaskFeedbackHandler   // Optional receiver for task feedback events
+	eventSink            EventSink             // Optional exporter of task events
+	auditLogger          AuditLogger           // Optional audit logger of calls and tool invocations
+	policy               *Policy               // Optional access policy of calls and tool executions
+	schedules            []Schedule            // Optional schedules of recurring agent runs
+	secretsProvider      SecretsProvider       // Optional provider of the referenced secrets
+	languageDetector     LanguageDetector      // Optional custom language detector
+	translator           Translator            // Optional translator for unsupported languages
+	memoryStore          MemoryStore           // Optional store of long-term memories
+	memoryExtractor      MemoryExtractor       // Optional extractor of memories from completed tasks
+	memoryEmbedder       Embedder              // Optional embedder ranking recalled memories
+	inputGuardrails      []GuardrailFilter     // Optional filters for incoming messages
+	outputGuardrails     []GuardrailFilter     // Optional filters for agent responses
+	piiDetectors         []PIIDetector         // Optional detectors of personal data for redaction
+	fileConverters       []FileConverter       // Optional converters for inbound file parts
+	transcriber          Transcriber           // Optional speech-to-text provider for inbound audio
+	speechSynthesizer    SpeechSynthesizer     // Optional text-to-speech provider for responses
+	clock                Clock                 // Optional clock for task timestamps
+	ids                  IDGenerator           // Optional generator of task and message IDs
+	httpMiddleware       []gin.HandlerFunc     // Optional middleware of the HTTP router
+}
+
+// customJSONRPCMethod pairs a custom method name with its handler until the server is built
+type customJSONRPCMethod struct {
+	method  string
+	handler JSONRPCMethodHandler
 }
 
 // NewA2AServerBuilder creates a new server builder with required dependencies.
@@ -224,6 +386,42 @@ func (b *A2AServerBuilderImpl) WithAgentCard(agentCard types.AgentCard) A2AServe
 	return b
 }
 
+// WithInferredCapabilities derives the capabilities of the agent card from what is wired
+func (b *A2AServerBuilderImpl) WithInferredCapabilities() A2AServerBuilder {
+	b.inferCapabilities = true
+	return b
+}
+
+// WithExtendedAgentCard sets the card returned to authenticated callers by agent/getAuthenticatedExtendedCard
+func (b *A2AServerBuilderImpl) WithExtendedAgentCard(agentCard types.AgentCard) A2AServerBuilder {
+	b.extendedAgentCard = &agentCard
+	return b
+}
+
+// WithAgentCardProvider sets a hook that is consulted on every agent card request
+func (b *A2AServerBuilderImpl) WithAgentCardProvider(provider AgentCardProvider) A2AServerBuilder {
+	b.agentCardProvider = provider
+	return b
+}
+
+// WithNamedAgent hosts an additional agent on the same server under /agents/{name}
+func (b *A2AServerBuilderImpl) WithNamedAgent(name string, agent OpenAICompatibleAgent, agentCard types.AgentCard) A2AServerBuilder {
+	b.namedAgents = append(b.namedAgents, namedAgent{name: name, agent: agent, agentCard: agentCard})
+	return b
+}
+
+// WithAgentsFromDir hosts an agent for every definition file in a directory as a named agent
+func (b *A2AServerBuilderImpl) WithAgentsFromDir(path string) A2AServerBuilder {
+	b.agentDirs = append(b.agentDirs, path)
+	return b
+}
+
+// WithConfigReloadHandler sets a handler that receives config reload events
+func (b *A2AServerBuilderImpl) WithConfigReloadHandler(handler ConfigReloadHandler) A2AServerBuilder {
+	b.configReloadHandler = handler
+	return b
+}
+
 // WithAgentCardFromFile loads and sets an agent card from a JSON file
 // The optional overrides map allows dynamic replacement of JSON attribute values
 func (b *A2AServerBuilderImpl) WithAgentCardFromFile(filePath string, overrides map[string]any) A2AServerBuilder {
@@ -291,16 +489,152 @@ func (b *A2AServerBuilderImpl) WithTelemetry(telemetry otel.OpenTelemetry) A2ASe
 	return b
 }
 
+// WithJSONRPCMethod registers an additional JSON-RPC method served on the A2A endpoint
+func (b *A2AServerBuilderImpl) WithJSONRPCMethod(method string, handler JSONRPCMethodHandler) A2AServerBuilder {
+	b.jsonrpcMethods = append(b.jsonrpcMethods, customJSONRPCMethod{method: method, handler: handler})
+	return b
+}
+
+// WithTaskFeedbackHandler sets a handler that receives task feedback events
+func (b *A2AServerBuilderImpl) WithTaskFeedbackHandler(handler TaskFeedbackHandler) A2AServerBuilder {
+	b.feedbackHandler = handler
+	return b
+}
+
+// WithEventSink sets a sink that receives the events of the processed tasks
+func (b *A2AServerBuilderImpl) WithEventSink(sink EventSink) A2AServerBuilder {
+	b.eventSink = sink
+	return b
+}
+
+// WithAuditLogger sets the audit logger recording calls and tool invocations
+func (b *A2AServerBuilderImpl) WithAuditLogger(auditLogger AuditLogger) A2AServerBuilder {
+	b.auditLogger = auditLogger
+	return b
+}
+
+// WithPolicy sets the access policy checked before calls and tool executions
+func (b *A2AServerBuilderImpl) WithPolicy(policy *Policy) A2AServerBuilder {
+	b.policy = policy
+	return b
+}
+
+// WithSchedules adds schedules of recurring agent runs
+func (b *A2AServerBuilderImpl) WithSchedules(schedules ...Schedule) A2AServerBuilder {
+	b.schedules = append(b.schedules, schedules...)
+	return b
+}
+
+// WithSecretsProvider sets the provider resolving the secrets referenced by the configuration
+func (b *A2AServerBuilderImpl) WithSecretsProvider(provider SecretsProvider) A2AServerBuilder {
+	b.secretsProvider = provider
+	return b
+}
+
+// WithLanguageDetector sets a custom language detector
+func (b *A2AServerBuilderImpl) WithLanguageDetector(detector LanguageDetector) A2AServerBuilder {
+	b.languageDetector = detector
+	return b
+}
+
+// WithTranslator sets the translator used for messages in unsupported languages
+func (b *A2AServerBuilderImpl) WithTranslator(translator Translator) A2AServerBuilder {
+	b.translator = translator
+	return b
+}
+
+// WithMemoryStore sets the store of long-term memories
+func (b *A2AServerBuilderImpl) WithMemoryStore(store MemoryStore) A2AServerBuilder {
+	b.memoryStore = store
+	return b
+}
+
+// WithMemoryExtractor sets the extractor of memories from completed tasks
+func (b *A2AServerBuilderImpl) WithMemoryExtractor(extractor MemoryExtractor) A2AServerBuilder {
+	b.memoryExtractor = extractor
+	return b
+}
+
+// WithMemoryEmbedder sets the embedder ranking recalled memories by relevance
+func (b *A2AServerBuilderImpl) WithMemoryEmbedder(embedder Embedder) A2AServerBuilder {
+	b.memoryEmbedder = embedder
+	return b
+}
+
+// WithInputGuardrails appends filters run on incoming user messages
+func (b *A2AServerBuilderImpl) WithInputGuardrails(filters ...GuardrailFilter) A2AServerBuilder {
+	b.inputGuardrails = append(b.inputGuardrails, filters...)
+	return b
+}
+
+// WithOutputGuardrails appends filters run on the agent's messages
+func (b *A2AServerBuilderImpl) WithOutputGuardrails(filters ...GuardrailFilter) A2AServerBuilder {
+	b.outputGuardrails = append(b.outputGuardrails, filters...)
+	return b
+}
+
+// WithPIIDetectors adds detectors of personal data to the configured redaction
+func (b *A2AServerBuilderImpl) WithPIIDetectors(detectors ...PIIDetector) A2AServerBuilder {
+	b.piiDetectors = append(b.piiDetectors, detectors...)
+	return b
+}
+
+// WithFileConverters registers converters for the file parts of incoming messages
+func (b *A2AServerBuilderImpl) WithFileConverters(converters ...FileConverter) A2AServerBuilder {
+	b.fileConverters = append(b.fileConverters, converters...)
+	return b
+}
+
+// WithTranscriber sets the speech-to-text provider for the audio file parts of incoming messages
+func (b *A2AServerBuilderImpl) WithTranscriber(transcriber Transcriber) A2AServerBuilder {
+	b.transcriber = transcriber
+	return b
+}
+
+// WithSpeechSynthesizer sets the text-to-speech provider for the agent's responses
+func (b *A2AServerBuilderImpl) WithSpeechSynthesizer(synthesizer SpeechSynthesizer) A2AServerBuilder {
+	b.speechSynthesizer = synthesizer
+	return b
+}
+
+// WithClock sets the clock used for task timestamps
+func (b *A2AServerBuilderImpl) WithClock(clock Clock) A2AServerBuilder {
+	b.clock = clock
+	return b
+}
+
+// WithIDGenerator sets the generator used for task, context and message IDs
+func (b *A2AServerBuilderImpl) WithIDGenerator(ids IDGenerator) A2AServerBuilder {
+	b.ids = ids
+	return b
+}
+
+// WithHTTPMiddleware appends middleware run on every request to the HTTP router
+func (b *A2AServerBuilderImpl) WithHTTPMiddleware(mw ...gin.HandlerFunc) A2AServerBuilder {
+	b.httpMiddleware = append(b.httpMiddleware, mw...)
+	return b
+}
+
 // Build creates and returns the configured A2A server.
 func (b *A2AServerBuilderImpl) Build() (A2AServer, error) {
 	if b.agentCard == nil {
 		return nil, fmt.Errorf("agent card must be configured before building the server - use WithAgentCard() or WithAgentCardFromFile()")
 	}
 
+	if b.inferCapabilities {
+		b.inferAgentCapabilities()
+	}
+
 	if err := b.validateTaskHandlerConfiguration(); err != nil {
 		return nil, err
 	}
 
+	if err := b.validateCapabilities(); err != nil {
+		return nil, err
+	}
+
+	b.wireHandlerAgents()
+
 	if b.agentCard != nil {
 		b.cfg.AgentName = b.agentCard.Name
 		b.cfg.AgentDescription = b.agentCard.Description
@@ -321,6 +655,23 @@ func (b *A2AServerBuilderImpl) Build() (A2AServer, error) {
 		b.logger.Info("telemetry enabled - metrics will be available", zap.String("metrics_url", metricsAddr+"/metrics"))
 	}
 
+	secretsProvider := b.secretsProvider
+	if secretsProvider == nil {
+		var err error
+		secretsProvider, err = NewSecretsProvider(b.cfg.SecretsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create secrets provider: %w", err)
+		}
+	}
+	var changedSecrets []string
+	if secretsProvider != nil {
+		var err error
+		changedSecrets, err = b.cfg.ResolveSecrets(context.Background(), secretsProvider.GetSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve secrets: %w", err)
+		}
+	}
+
 	server := NewA2AServer(&b.cfg, b.logger, telemetryInstance)
 
 	pushEnabled := b.agentCard != nil &&
@@ -349,13 +700,272 @@ func (b *A2AServerBuilderImpl) Build() (A2AServer, error) {
 		server.SetTaskResultProcessor(b.taskResultProcessor)
 	}
 
+	if b.clock != nil || b.ids != nil {
+		b.configureDependencies(server)
+	}
+
+	if b.eventSink != nil {
+		server.setEventSink(b.eventSink)
+	}
+
+	if len(b.httpMiddleware) > 0 {
+		server.useHTTPMiddleware(b.httpMiddleware...)
+	}
+
+	if b.auditLogger != nil {
+		server.setAuditLogger(b.auditLogger)
+	}
+
+	if err := b.configureLanguagePolicy(server); err != nil {
+		return nil, err
+	}
+
+	if err := b.configureMemory(server); err != nil {
+		return nil, err
+	}
+
+	if err := b.configureEncryption(server); err != nil {
+		return nil, err
+	}
+
+	if len(b.piiDetectors) > 0 {
+		if server.redactor == nil {
+			b.logger.Warn("pii detectors are ignored because redaction is disabled")
+		} else {
+			server.redactor.AddDetectors(b.piiDetectors...)
+		}
+	}
+
+	if len(b.inputGuardrails) > 0 || len(b.outputGuardrails) > 0 {
+		guardrails := NewGuardrails(b.logger)
+		guardrails.AddInputFilters(b.inputGuardrails...)
+		guardrails.AddOutputFilters(b.outputGuardrails...)
+		if configured := server.guardrails; configured != nil {
+			// redaction and moderation run after the builder's filters
+			guardrails.AddInputFilters(configured.input...)
+			guardrails.AddOutputFilters(configured.output...)
+		}
+		server.setGuardrails(guardrails)
+	}
+
+	if b.transcriber != nil {
+		server.setTranscriber(b.transcriber)
+	}
+
+	if b.speechSynthesizer != nil {
+		server.setSpeechOutput(NewSpeechOutput(b.speechSynthesizer, b.cfg.SpeechConfig.MaxSynthesisLength, b.logger))
+	}
+
+	if len(b.fileConverters) > 0 {
+		ingestion := server.fileIngestion
+		if ingestion == nil {
+			ingestion = NewFileIngestion(b.cfg.FileIngestionConfig, b.logger)
+		}
+		ingestion.UseConverters(b.fileConverters...)
+		server.setFileIngestion(ingestion)
+	}
+
 	if b.agentCard != nil {
 		server.SetAgentCard(*b.agentCard)
 	}
 
+	if b.extendedAgentCard != nil {
+		server.SetExtendedAgentCard(*b.extendedAgentCard)
+	}
+
+	if b.agentCardProvider != nil {
+		server.SetAgentCardProvider(b.agentCardProvider)
+	}
+
+	if b.configReloadHandler != nil && server.reloader != nil {
+		server.reloader.handler = b.configReloadHandler
+	}
+
+	if b.feedbackHandler != nil {
+		if ph, ok := server.protocolHandler.(*DefaultA2AProtocolHandler); ok {
+			ph.SetTaskFeedbackHandler(b.feedbackHandler)
+		}
+	}
+
+	if b.artifactService != nil {
+		if ph, ok := server.protocolHandler.(*DefaultA2AProtocolHandler); ok {
+			ph.SetArtifactService(b.artifactService)
+		}
+		if previews := b.cfg.ArtifactsConfig.PreviewConfig; previews.Enable {
+			server.setArtifactPreviews(NewArtifactPreviews(b.artifactService, previews.MaxSize, b.logger))
+		}
+		if transcripts := b.cfg.ArtifactsConfig.TranscriptConfig; transcripts.Enable {
+			server.setTaskTranscripts(NewTaskTranscripts(b.artifactService, TranscriptFormat(transcripts.Format), b.logger))
+		}
+	}
+
+	for _, custom := range b.jsonrpcMethods {
+		if err := server.RegisterJSONRPCMethod(custom.method, custom.handler); err != nil {
+			return nil, fmt.Errorf("failed to register json-rpc method: %w", err)
+		}
+	}
+
+	namedAgents := slices.Clone(b.namedAgents)
+	for _, dir := range b.agentDirs {
+		loaded, err := LoadAgentsFromDir(dir, b.cfg.AgentConfig, b.logger)
+		if err != nil {
+			return nil, err
+		}
+		for _, agent := range loaded {
+			namedAgents = append(namedAgents, namedAgent{name: agent.Name, agent: agent.Agent, agentCard: agent.AgentCard})
+		}
+	}
+
+	for _, named := range namedAgents {
+		agent, err := b.buildNamedAgent(named, telemetryInstance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build named agent: %w", err)
+		}
+		if err := server.addNamedAgent(named.name, agent); err != nil {
+			return nil, fmt.Errorf("failed to build named agent: %w", err)
+		}
+	}
+
+	if b.policy != nil {
+		server.setPolicy(b.policy)
+	}
+
+	for _, schedule := range b.schedules {
+		if err := server.addSchedule(schedule); err != nil {
+			return nil, fmt.Errorf("failed to add schedule: %w", err)
+		}
+	}
+
+	if secretsProvider != nil {
+		server.secrets = &secretRotation{
+			server:          server,
+			provider:        secretsProvider,
+			artifactService: b.artifactService,
+			interval:        b.cfg.SecretsConfig.RefreshInterval,
+			logger:          b.logger,
+		}
+		server.secrets.apply(changedSecrets)
+	}
+
 	return server, nil
 }
 
+// configureEncryption seals the tasks the storage persists with the configured keys. The
+// in-memory storage persists nothing, so there is nothing to encrypt.
+func (b *A2AServerBuilderImpl) configureEncryption(server *A2AServerImpl) error {
+	if !b.cfg.EncryptionConfig.Enable {
+		return nil
+	}
+
+	cipher, err := NewTaskCipherFromConfig(b.cfg.EncryptionConfig)
+	if err != nil {
+		return fmt.Errorf("failed to configure encryption: %w", err)
+	}
+	switch store := server.storage.(type) {
+	case EncryptedStore:
+		store.SetTaskCipher(cipher)
+		server.taskCipher = cipher
+	case *InMemoryStorage:
+		b.logger.Info("in-memory storage does not persist tasks, encryption at rest is not needed")
+	default:
+		return fmt.Errorf("encryption requires a storage that implements EncryptedStore")
+	}
+	return nil
+}
+
+// configureDependencies applies the custom clock and ID generator to the server, the default
+// task manager and protocol handler
+func (b *A2AServerBuilderImpl) configureDependencies(server *A2AServerImpl) {
+	if b.clock != nil {
+		server.clock = b.clock
+		server.checkpoints.setClock(b.clock)
+	}
+	if b.ids != nil {
+		server.ids = b.ids
+	}
+
+	if tm, ok := server.taskManager.(*DefaultTaskManager); ok {
+		if b.clock != nil {
+			tm.SetClock(b.clock)
+		}
+		if b.ids != nil {
+			tm.SetIDGenerator(b.ids)
+		}
+	}
+
+	if ph, ok := server.protocolHandler.(*DefaultA2AProtocolHandler); ok {
+		if b.clock != nil {
+			ph.SetClock(b.clock)
+		}
+		if b.ids != nil {
+			ph.SetIDGenerator(b.ids)
+		}
+	}
+}
+
+// configureLanguagePolicy applies the custom detector and translator to the server's language policy
+func (b *A2AServerBuilderImpl) configureLanguagePolicy(server *A2AServerImpl) error {
+	if server.languagePolicy == nil {
+		return nil
+	}
+
+	if b.languageDetector != nil {
+		server.languagePolicy.SetDetector(b.languageDetector)
+	}
+
+	if server.languagePolicy.Enforcement() != config.LanguageEnforcementTranslate {
+		return nil
+	}
+
+	translator := b.translator
+	if translator == nil {
+		if agent, ok := b.agent.(*OpenAICompatibleAgentImpl); ok && agent.llmClient != nil {
+			translator = NewLLMTranslator(agent.llmClient)
+		}
+	}
+	if translator == nil {
+		return fmt.Errorf("translate language enforcement requires a translator - use WithTranslator() or configure an agent with an LLM client")
+	}
+
+	server.languagePolicy.SetTranslator(translator)
+	return nil
+}
+
+// configureMemory sets up long-term memory when it is enabled, extracting memories with the
+// custom extractor or the agent's LLM client
+func (b *A2AServerBuilderImpl) configureMemory(server *A2AServerImpl) error {
+	if !b.cfg.MemoryConfig.Enable {
+		return nil
+	}
+
+	extractor := b.memoryExtractor
+	if extractor == nil {
+		if agent, ok := b.agent.(*OpenAICompatibleAgentImpl); ok && agent.llmClient != nil {
+			extractor = NewLLMMemoryExtractor(agent.llmClient)
+		}
+	}
+	if extractor == nil {
+		return fmt.Errorf("memory requires a memory extractor - use WithMemoryExtractor() or configure an agent with an LLM client")
+	}
+
+	store := b.memoryStore
+	if store == nil {
+		store = NewInMemoryMemoryStore()
+	}
+	memories := NewMemoryService(store, extractor, b.cfg.MemoryConfig, b.logger)
+	if b.memoryEmbedder != nil {
+		memories.SetEmbedder(b.memoryEmbedder)
+	}
+	if b.clock != nil {
+		memories.clock = b.clock
+	}
+	if b.ids != nil {
+		memories.ids = b.ids
+	}
+	server.setMemoryService(memories)
+	return nil
+}
+
 // validateTaskHandlerConfiguration ensures task handlers are configured based on agent card capabilities
 func (b *A2AServerBuilderImpl) validateTaskHandlerConfiguration() error {
 	streamingEnabled := false
@@ -378,6 +988,74 @@ func (b *A2AServerBuilderImpl) validateTaskHandlerConfiguration() error {
 	return nil
 }
 
+// inferAgentCapabilities sets the capabilities of the agent card from the installed task
+// handlers and the capabilities configuration
+func (b *A2AServerBuilderImpl) inferAgentCapabilities() {
+	agentCard := *b.agentCard
+	agentCard.Capabilities.Streaming = new(b.streamingTaskHandler != nil && b.cfg.CapabilitiesConfig.Streaming)
+	agentCard.Capabilities.PushNotifications = new(b.cfg.CapabilitiesConfig.PushNotifications)
+	agentCard.Capabilities.StateTransitionHistory = new(b.cfg.CapabilitiesConfig.StateTransitionHistory)
+	b.agentCard = &agentCard
+
+	b.logger.Info("inferred agent capabilities from the server wiring",
+		zap.Bool("streaming", *agentCard.Capabilities.Streaming),
+		zap.Bool("push_notifications", *agentCard.Capabilities.PushNotifications),
+		zap.Bool("state_transition_history", *agentCard.Capabilities.StateTransitionHistory))
+}
+
+// validateCapabilities cross-checks the capabilities advertised by the agent card against the
+// capabilities configuration and the installed agent. Advertising streaming while the
+// configuration disables it fails the build; the other mismatches are logged, the agent card
+// deciding what the server does.
+func (b *A2AServerBuilderImpl) validateCapabilities() error {
+	capabilities := b.agentCard.Capabilities
+	advertised := func(capability *bool) bool { return capability != nil && *capability }
+
+	if advertised(capabilities.Streaming) && !b.cfg.CapabilitiesConfig.Streaming {
+		return fmt.Errorf("agent card advertises streaming but streaming is disabled in the capabilities configuration - set CAPABILITIES_STREAMING=true, remove the streaming capability from the agent card or use WithInferredCapabilities()")
+	}
+
+	if !advertised(capabilities.Streaming) && b.streamingTaskHandler != nil {
+		b.logger.Warn("a streaming task handler is configured but the agent card does not advertise streaming",
+			zap.String("suggestion", "set the streaming capability in the agent card or use WithInferredCapabilities()"))
+	}
+
+	if advertised(capabilities.PushNotifications) != b.cfg.CapabilitiesConfig.PushNotifications {
+		b.logger.Warn("push notifications capability of the agent card differs from the capabilities configuration",
+			zap.Bool("agent_card", advertised(capabilities.PushNotifications)),
+			zap.Bool("config", b.cfg.CapabilitiesConfig.PushNotifications))
+	}
+
+	if advertised(capabilities.StateTransitionHistory) != b.cfg.CapabilitiesConfig.StateTransitionHistory {
+		b.logger.Warn("state transition history capability of the agent card differs from the capabilities configuration",
+			zap.Bool("agent_card", advertised(capabilities.StateTransitionHistory)),
+			zap.Bool("config", b.cfg.CapabilitiesConfig.StateTransitionHistory))
+	}
+
+	if advertised(capabilities.Streaming) && b.agent == nil {
+		if handler, ok := b.streamingTaskHandler.(*DefaultStreamingTaskHandler); ok && handler.GetAgent() == nil {
+			b.logger.Warn("the default streaming task handler has no agent and will fail streaming requests",
+				zap.String("suggestion", "use WithAgent() or set an agent on the server before serving streaming requests"))
+		}
+	}
+
+	return nil
+}
+
+// wireHandlerAgents gives the configured agent to the task handlers created without one, so
+// the default handlers work whatever the order WithAgent() and the handler options are called in
+func (b *A2AServerBuilderImpl) wireHandlerAgents() {
+	if b.agent == nil {
+		return
+	}
+	if b.pollingTaskHandler != nil && b.pollingTaskHandler.GetAgent() == nil {
+		b.pollingTaskHandler.SetAgent(b.agent)
+	}
+	if b.streamingTaskHandler != nil && b.streamingTaskHandler.GetAgent() == nil {
+		b.streamingTaskHandler.SetAgent(b.agent)
+	}
+}
+
 // SimpleA2AServerWithAgent creates a basic A2A server with an OpenAI-compatible agent
 // This is a convenience function for agent-based use cases
 func SimpleA2AServerWithAgent(cfg config.Config, logger *zap.Logger, agent OpenAICompatibleAgent, agentCard types.AgentCard) (A2AServer, error) {
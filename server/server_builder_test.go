@@ -1,12 +1,17 @@
 package server_test
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	assert "github.com/stretchr/testify/assert"
 	require "github.com/stretchr/testify/require"
 
+	gin "github.com/gin-gonic/gin"
 	zap "go.uber.org/zap"
 
 	mocks "github.com/inference-gateway/adk/server/mocks"
@@ -553,6 +558,109 @@ func TestA2AServerBuilder_Build_RequiresTaskHandlers(t *testing.T) {
 	})
 }
 
+func TestA2AServerBuilder_Build_ValidatesCapabilities(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("fails when the agent card advertises streaming disabled in the configuration", func(t *testing.T) {
+		cfg := config.Config{
+			AgentName:          "test-agent",
+			ServerConfig:       config.ServerConfig{Port: "8080"},
+			CapabilitiesConfig: config.CapabilitiesConfig{PushNotifications: true},
+		}
+
+		_, err := server.NewA2AServerBuilder(cfg, logger).
+			WithAgentCard(createTestAgentCard()).
+			WithBackgroundTaskHandler(&mocks.FakeTaskHandler{}).
+			WithStreamingTaskHandler(&mocks.FakeStreamableTaskHandler{}).
+			Build()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "streaming is disabled in the capabilities configuration")
+	})
+
+	t.Run("gives the agent to default handlers configured before it", func(t *testing.T) {
+		cfg := config.Config{
+			AgentName:          "test-agent",
+			ServerConfig:       config.ServerConfig{Port: "8080"},
+			CapabilitiesConfig: config.CapabilitiesConfig{Streaming: true},
+		}
+		agent, err := server.NewAgentBuilder(logger).Build()
+		require.NoError(t, err)
+
+		a2aServer, err := server.NewA2AServerBuilder(cfg, logger).
+			WithDefaultTaskHandlers().
+			WithAgent(agent).
+			WithAgentCard(createTestAgentCard()).
+			Build()
+
+		require.NoError(t, err)
+		assert.Equal(t, agent, a2aServer.GetBackgroundTaskHandler().GetAgent())
+		assert.Equal(t, agent, a2aServer.GetStreamingTaskHandler().GetAgent())
+	})
+}
+
+func TestA2AServerBuilder_WithInferredCapabilities(t *testing.T) {
+	tests := []struct {
+		name                  string
+		capabilities          config.CapabilitiesConfig
+		streamingHandler      bool
+		wantStreaming         bool
+		wantPush              bool
+		wantTransitionHistory bool
+	}{
+		{
+			name:             "background handler only does not advertise streaming",
+			capabilities:     config.CapabilitiesConfig{Streaming: true, PushNotifications: true},
+			streamingHandler: false,
+			wantStreaming:    false,
+			wantPush:         true,
+		},
+		{
+			name:                  "streaming handler advertises streaming",
+			capabilities:          config.CapabilitiesConfig{Streaming: true, StateTransitionHistory: true},
+			streamingHandler:      true,
+			wantStreaming:         true,
+			wantTransitionHistory: true,
+		},
+		{
+			name:             "streaming disabled in the configuration is not advertised",
+			capabilities:     config.CapabilitiesConfig{PushNotifications: true},
+			streamingHandler: true,
+			wantStreaming:    false,
+			wantPush:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Config{
+				AgentName:          "test-agent",
+				ServerConfig:       config.ServerConfig{Port: "8080"},
+				CapabilitiesConfig: tt.capabilities,
+			}
+
+			builder := server.NewA2AServerBuilder(cfg, zap.NewNop()).
+				WithAgentCard(createTestAgentCard()).
+				WithInferredCapabilities().
+				WithBackgroundTaskHandler(&mocks.FakeTaskHandler{})
+			if tt.streamingHandler {
+				builder = builder.WithStreamingTaskHandler(&mocks.FakeStreamableTaskHandler{})
+			}
+
+			a2aServer, err := builder.Build()
+			require.NoError(t, err)
+
+			capabilities := a2aServer.GetAgentCard().Capabilities
+			require.NotNil(t, capabilities.Streaming)
+			require.NotNil(t, capabilities.PushNotifications)
+			require.NotNil(t, capabilities.StateTransitionHistory)
+			assert.Equal(t, tt.wantStreaming, *capabilities.Streaming)
+			assert.Equal(t, tt.wantPush, *capabilities.PushNotifications)
+			assert.Equal(t, tt.wantTransitionHistory, *capabilities.StateTransitionHistory)
+		})
+	}
+}
+
 func TestA2AServerBuilder_WithTelemetry(t *testing.T) {
 	cfg := config.Config{
 		AgentName:    "test-agent",
@@ -573,3 +681,77 @@ func TestA2AServerBuilder_WithTelemetry(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, a2aServer)
 }
+
+func TestA2AServerBuilder_WithJSONRPCMethod(t *testing.T) {
+	handler := func(ctx context.Context, params map[string]any) (any, error) {
+		return map[string]any{"ok": true}, nil
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		expectError bool
+	}{
+		{name: "custom method", method: "myapp/ping", expectError: false},
+		{name: "reserved namespace", method: "tasks/ping", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a2aServer, err := server.NewA2AServerBuilder(config.Config{}, zap.NewNop()).
+				WithAgentCard(createTestAgentCard()).
+				WithDefaultTaskHandlers().
+				WithJSONRPCMethod(tt.method, handler).
+				Build()
+
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "failed to register json-rpc method")
+				return
+			}
+			require.NoError(t, err)
+			assert.Error(t, a2aServer.RegisterJSONRPCMethod(tt.method, handler))
+		})
+	}
+}
+
+func TestA2AServerBuilder_WithHTTPMiddleware(t *testing.T) {
+	cfg := config.Config{ServerConfig: config.ServerConfig{CORS: config.CORSConfig{
+		Enable:         true,
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"POST"},
+	}}}
+	var seen []string
+	a2aServer, err := server.NewA2AServerBuilder(cfg, zap.NewNop()).
+		WithAgentCard(createTestAgentCard()).
+		WithDefaultTaskHandlers().
+		WithHTTPMiddleware(func(c *gin.Context) {
+			seen = append(seen, c.Request.Method+" "+c.Request.URL.Path)
+			c.Header("X-Served-By", "custom")
+			c.Next()
+		}).
+		Build()
+	require.NoError(t, err)
+	handler := a2aServer.Handler()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/.well-known/agent-card.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "custom", w.Header().Get("X-Served-By"))
+
+	preflight := httptest.NewRequest(http.MethodOptions, "/a2a", nil)
+	preflight.Header.Set("Origin", "https://app.example.com")
+	preflight.Header.Set("Access-Control-Request-Method", "POST")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, preflight)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, []string{"GET /.well-known/agent-card.json"}, seen, "preflight requests are answered before the custom middleware")
+
+	request := httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(`{"jsonrpc":"2.0","id":"1","method":"tasks/get","params":{"id":"missing"}}`))
+	request.Header.Set("Origin", "https://app.example.com")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, request)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "custom", w.Header().Get("X-Served-By"))
+}
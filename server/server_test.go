@@ -2,8 +2,11 @@ package server_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -852,3 +855,55 @@ func TestAgentStreaming_WithToolCalls(t *testing.T) {
 	assert.Greater(t, eventCount, 0)
 	assert.GreaterOrEqual(t, callCount, 2, "Should make at least 2 LLM calls (tool + final)")
 }
+
+func TestA2AServer_EmbeddedHandler(t *testing.T) {
+	taskHandler := &mocks.FakeTaskHandler{}
+	taskHandler.HandleTaskStub = func(ctx context.Context, task *types.Task, message *types.Message) (*types.Task, error) {
+		task.Status.State = types.TaskStateCompleted
+		return task, nil
+	}
+	a2aServer, err := server.NewA2AServerBuilder(config.Config{}, zap.NewNop()).
+		WithAgentCard(createTestAgentCard()).
+		WithBackgroundTaskHandler(taskHandler).
+		WithStreamingTaskHandler(&mocks.FakeStreamableTaskHandler{}).
+		Build()
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle("/agent/", http.StripPrefix("/agent", a2aServer.Handler()))
+	existing := httptest.NewServer(mux)
+	defer existing.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, a2aServer.StartBackground(ctx))
+
+	call := func(method string, params string) map[string]any {
+		body := `{"jsonrpc":"2.0","id":"1","method":"` + method + `","params":` + params + `}`
+		resp, err := http.Post(existing.URL+"/agent/a2a", "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		var response struct {
+			Result map[string]any `json:"result"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		return response.Result
+	}
+
+	task := call("message/send", `{"message":{"messageId":"m1","role":"user","parts":[{"kind":"text","text":"hi"}]}}`)
+	require.NotEmpty(t, task["id"])
+	assert.Eventually(t, func() bool {
+		task := call("tasks/get", `{"id":"`+task["id"].(string)+`"}`)
+		status, _ := task["status"].(map[string]any)
+		return status["state"] == string(types.TaskStateCompleted)
+	}, 5*time.Second, 20*time.Millisecond, "the background task processor runs without the server owning the listener")
+
+	stopCtx, stop := context.WithTimeout(context.Background(), 5*time.Second)
+	defer stop()
+	require.NoError(t, a2aServer.Stop(stopCtx))
+}
+
+func TestA2AServer_StartBackground_RequiresAgentCard(t *testing.T) {
+	a2aServer := server.NewA2AServer(&config.Config{}, zap.NewNop(), nil)
+	assert.Error(t, a2aServer.StartBackground(context.Background()))
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// PreconditionToolNotInSkill is the refusal code of a tool called outside the tools bound to
+// the skill of the run
+const PreconditionToolNotInSkill = "tool_not_in_skill"
+
+// skillContextKey carries the skill of an agent run to its tool executions
+const skillContextKey ContextKey = "skill"
+
+// SkillBinding binds a skill of the agent card to what the agent uses for the messages that
+// name it in their skillId metadata. Messages naming a skill without a binding, or no skill,
+// are handled with the whole toolbox and the agent's system prompt.
+type SkillBinding struct {
+	// Tools are the names of the tools offered to the model for the skill. Empty offers
+	// every tool of the toolbox. The input_required tool is always offered.
+	Tools []string
+
+	// Instructions are appended to the system prompt for the skill
+	Instructions string
+
+	// Agent handles the messages of the skill instead, such as an agent with its own model,
+	// toolbox and prompt
+	Agent OpenAICompatibleAgent
+}
+
+// boundSkill is the skill an agent run is restricted to
+type boundSkill struct {
+	id      string
+	binding SkillBinding
+}
+
+// BindSkill binds a skill of the agent card to a set of tools, instructions or a sub-agent.
+// Skills are bound before the agent handles messages.
+func (a *OpenAICompatibleAgentImpl) BindSkill(skillID string, binding SkillBinding) {
+	if a.skills == nil {
+		a.skills = make(map[string]SkillBinding)
+	}
+	a.skills[skillID] = binding
+}
+
+// runSkill returns the bound skill of a run: the skill named by the last message, or else the
+// skill recorded on the task by an earlier message. It returns nil when the skill is not bound.
+func (a *OpenAICompatibleAgentImpl) runSkill(ctx context.Context, messages []types.Message) *boundSkill {
+	if len(a.skills) == 0 {
+		return nil
+	}
+
+	skillID := ""
+	if len(messages) > 0 {
+		skillID = messageSkillID(messages[len(messages)-1])
+	}
+	if skillID == "" {
+		if task, ok := ctx.Value(TaskContextKey).(*types.Task); ok && task != nil {
+			skillID = types.GetTaskSkillID(task)
+		}
+	}
+
+	binding, bound := a.skills[skillID]
+	if skillID == "" || !bound {
+		return nil
+	}
+	return &boundSkill{id: skillID, binding: binding}
+}
+
+// tools returns the tools the skill offers among the tools of the toolbox
+func (s *boundSkill) tools(tools []sdk.ChatCompletionTool) []sdk.ChatCompletionTool {
+	if s == nil || len(s.binding.Tools) == 0 {
+		return tools
+	}
+	offered := make([]sdk.ChatCompletionTool, 0, len(s.binding.Tools))
+	for _, tool := range tools {
+		if s.allows(tool.Function.Name) {
+			offered = append(offered, tool)
+		}
+	}
+	return offered
+}
+
+// allows reports whether the skill offers a tool
+func (s *boundSkill) allows(toolName string) bool {
+	return s == nil || len(s.binding.Tools) == 0 || toolName == types.ToolInputRequired || slices.Contains(s.binding.Tools, toolName)
+}
+
+// systemPrompt returns the system prompt of the agent with the instructions of the skill
+func (s *boundSkill) systemPrompt(prompt string) string {
+	if s == nil || strings.TrimSpace(s.binding.Instructions) == "" {
+		return prompt
+	}
+	if prompt == "" {
+		return s.binding.Instructions
+	}
+	return prompt + "\n\n" + s.binding.Instructions
+}
+
+// contextWithSkill returns a copy of ctx carrying the skill of the run
+func contextWithSkill(ctx context.Context, skill *boundSkill) context.Context {
+	return context.WithValue(ctx, skillContextKey, skill)
+}
+
+// checkSkillTool refuses the execution of a tool the skill of the run does not offer, which
+// the model may still call by name
+func checkSkillTool(ctx context.Context, toolName string) *PreconditionRefusal {
+	skill, _ := ctx.Value(skillContextKey).(*boundSkill)
+	if skill.allows(toolName) {
+		return nil
+	}
+	return &PreconditionRefusal{
+		Code:    PreconditionToolNotInSkill,
+		Message: fmt.Sprintf("The tool %s is not available for the skill %s.", toolName, skill.id),
+	}
+}
+
+// messageSkillID returns the skill named by the skillId metadata of a message
+func messageSkillID(message types.Message) string {
+	if message.Metadata == nil {
+		return ""
+	}
+	skillID, _ := (*message.Metadata)[types.SkillIDMetadataKey].(string)
+	return strings.TrimSpace(skillID)
+}
+
+// recordTaskSkill records the skill named by a message on its task, so the messages that
+// follow, such as the input of an input-required task, stay on the skill
+func (h *DefaultA2AProtocolHandler) recordTaskSkill(ctx context.Context, task *types.Task, message types.Message) {
+	skillID := messageSkillID(message)
+	if skillID == "" || types.GetTaskSkillID(task) == skillID {
+		return
+	}
+
+	setTaskMetadata(task, types.SkillIDMetadataKey, skillID)
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// newSkilledAgent returns an agent with lookup and send tools, counting the sends, and the
+// support skill bound to the lookup tool
+func newSkilledAgent(t *testing.T, llmClient LLMClient, sends *int) *OpenAICompatibleAgentImpl {
+	t.Helper()
+	toolBox := NewDefaultToolBox(&config.ToolBoxConfig{})
+	toolBox.AddTool(NewBasicTool("lookup", "Looks up an order", map[string]any{"type": "object"},
+		func(ctx context.Context, args map[string]any) (string, error) { return "shipped", nil }))
+	toolBox.AddTool(NewBasicTool("send", "Sends an email", map[string]any{"type": "object"},
+		func(ctx context.Context, args map[string]any) (string, error) {
+			*sends++
+			return "sent", nil
+		}))
+	agent, err := NewAgentBuilder(zap.NewNop()).
+		WithLLMClient(llmClient).
+		WithToolBox(toolBox).
+		WithSystemPrompt("You are a helpful assistant.").
+		WithSkill("support", SkillBinding{Tools: []string{"lookup"}, Instructions: "Only answer questions about orders."}).
+		Build()
+	require.NoError(t, err)
+	return agent
+}
+
+// skillMessage returns a user message naming a skill in its metadata
+func skillMessage(skillID string) types.Message {
+	message := types.Message{MessageID: "m1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("where is my order?")}}
+	if skillID != "" {
+		metadata := map[string]any{types.SkillIDMetadataKey: skillID}
+		message.Metadata = &metadata
+	}
+	return message
+}
+
+// toolNames returns the names of the tools offered to the model
+func toolNames(tools []sdk.ChatCompletionTool) []string {
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, tool.Function.Name)
+	}
+	return names
+}
+
+func TestAgentRun_RestrictsToolsAndPromptToSkill(t *testing.T) {
+	tests := []struct {
+		name        string
+		message     types.Message
+		task        *types.Task
+		wantTools   []string
+		wantPrompt  string
+		wantRefusal bool
+	}{
+		{
+			name:        "message naming the skill",
+			message:     skillMessage("support"),
+			wantTools:   []string{"lookup", types.ToolInputRequired},
+			wantPrompt:  "You are a helpful assistant.\n\nOnly answer questions about orders.",
+			wantRefusal: true,
+		},
+		{
+			name:        "skill recorded on the task",
+			message:     skillMessage(""),
+			task:        &types.Task{ID: "task-1", Metadata: &map[string]any{types.SkillIDMetadataKey: "support"}},
+			wantTools:   []string{"lookup", types.ToolInputRequired},
+			wantPrompt:  "You are a helpful assistant.\n\nOnly answer questions about orders.",
+			wantRefusal: true,
+		},
+		{
+			name:       "skill without a binding",
+			message:    skillMessage("billing"),
+			wantTools:  []string{"lookup", "send", types.ToolInputRequired},
+			wantPrompt: "You are a helpful assistant.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			llmClient := &scriptedLLMClient{responses: []*sdk.CreateChatCompletionStreamResponse{toolCallChunk("call-1", "send"), textChunk("done")}}
+			sends := 0
+			agent := newSkilledAgent(t, llmClient, &sends)
+
+			ctx := context.Background()
+			if tt.task != nil {
+				ctx = context.WithValue(ctx, TaskContextKey, tt.task)
+			}
+			assert.Equal(t, types.TaskStateCompleted, runToCompletion(t, ctx, agent, []types.Message{tt.message}))
+
+			require.Len(t, llmClient.tools, 2)
+			assert.ElementsMatch(t, tt.wantTools, toolNames(llmClient.tools[0]))
+			system, err := llmClient.calls[0][0].Content.AsMessageContent0()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPrompt, system)
+
+			if tt.wantRefusal {
+				assert.Equal(t, 0, sends, "a tool outside the skill is not executed")
+			} else {
+				assert.Equal(t, 1, sends)
+			}
+		})
+	}
+}
+
+func TestAgentRun_HandsSkillToItsAgent(t *testing.T) {
+	skillClient := &scriptedLLMClient{responses: []*sdk.CreateChatCompletionStreamResponse{textChunk("from the skill agent")}}
+	skillAgent, err := NewAgentBuilder(zap.NewNop()).WithLLMClient(skillClient).Build()
+	require.NoError(t, err)
+
+	llmClient := &scriptedLLMClient{}
+	agent, err := NewAgentBuilder(zap.NewNop()).
+		WithLLMClient(llmClient).
+		WithSkill("research", SkillBinding{Agent: skillAgent}).
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, types.TaskStateCompleted, runToCompletion(t, context.Background(), agent, []types.Message{skillMessage("research")}))
+	assert.Len(t, skillClient.calls, 1)
+	assert.Empty(t, llmClient.calls)
+}
+
+func TestProtocolHandler_RecordsTaskSkill(t *testing.T) {
+	logger := zap.NewNop()
+	storage := NewInMemoryStorage(logger, 20)
+	taskManager := NewDefaultTaskManagerWithStorage(logger, storage)
+	h := NewDefaultA2AProtocolHandler(logger, storage, taskManager, NewDefaultResponseSender(logger))
+
+	params := types.MessageSendParams{Message: skillMessage("support")}
+	task, refusal, err := h.createTaskFromMessage(context.Background(), params)
+	require.NoError(t, err)
+	require.Nil(t, refusal)
+	assert.Equal(t, "support", types.GetTaskSkillID(task))
+
+	taskID := task.ID
+	require.NoError(t, taskManager.PauseTaskForInput(taskID, &types.Message{MessageID: "a1", Role: types.RoleAgent}))
+	params.Message = skillMessage("")
+	params.Message.MessageID = "m2"
+	params.Message.TaskID = &taskID
+	task, _, err = h.createTaskFromMessage(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, "support", types.GetTaskSkillID(task), "the input of the task stays on its skill")
+}
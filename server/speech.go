@@ -0,0 +1,346 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	uuid "github.com/google/uuid"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// audioInputMode is the input mode advertised for the audio transcribed from file parts
+const audioInputMode = "audio/*"
+
+// Transcriber converts speech to text
+type Transcriber interface {
+	// Transcribe returns the text spoken in the audio, encoded as the media type
+	Transcribe(ctx context.Context, audio []byte, mediaType string) (string, error)
+}
+
+// SpeechSynthesizer converts text to speech
+type SpeechSynthesizer interface {
+	// Synthesize returns the audio of the text spoken, encoded as MediaType()
+	Synthesize(ctx context.Context, text string) ([]byte, error)
+
+	// MediaType is the media type of the synthesized audio, such as audio/mpeg
+	MediaType() string
+}
+
+var _ Transcriber = (*OpenAITranscriber)(nil)
+
+// OpenAITranscriber implements Transcriber with the OpenAI transcription API, or any endpoint
+// compatible with it
+type OpenAITranscriber struct {
+	httpClient *http.Client
+	logger     *zap.Logger
+	url        string
+	apiKey     string
+	model      string
+}
+
+// NewOpenAITranscriber creates a transcriber calling the configured transcription endpoint
+// and model
+func NewOpenAITranscriber(cfg config.SpeechConfig, logger *zap.Logger) *OpenAITranscriber {
+	return &OpenAITranscriber{
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		logger:     logger,
+		url:        cfg.TranscriptionURL,
+		apiKey:     cfg.APIKey,
+		model:      cfg.TranscriptionModel,
+	}
+}
+
+// Transcribe uploads the audio to the transcription endpoint as multipart form data
+func (t *OpenAITranscriber) Transcribe(ctx context.Context, audio []byte, mediaType string) (string, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if t.model != "" {
+		if err := form.WriteField("model", t.model); err != nil {
+			return "", fmt.Errorf("failed to encode transcription request: %w", err)
+		}
+	}
+	file, err := form.CreateFormFile("file", audioFileName(mediaType))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode transcription request: %w", err)
+	}
+	if _, err := file.Write(audio); err != nil {
+		return "", fmt.Errorf("failed to encode transcription request: %w", err)
+	}
+	if err := form.Close(); err != nil {
+		return "", fmt.Errorf("failed to encode transcription request: %w", err)
+	}
+
+	data, err := postSpeech(ctx, t.httpClient, t.url, t.apiKey, form.FormDataContentType(), &body)
+	if err != nil {
+		return "", fmt.Errorf("transcription failed: %w", err)
+	}
+
+	var response struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return "", fmt.Errorf("failed to decode transcription response: %w", err)
+	}
+
+	t.logger.Debug("transcribed audio",
+		zap.String("media_type", mediaType),
+		zap.Int("audio_size", len(audio)),
+		zap.Int("text_length", len(response.Text)))
+	return strings.TrimSpace(response.Text), nil
+}
+
+var _ SpeechSynthesizer = (*OpenAISpeechSynthesizer)(nil)
+
+// OpenAISpeechSynthesizer implements SpeechSynthesizer with the OpenAI speech API, or any
+// endpoint compatible with it
+type OpenAISpeechSynthesizer struct {
+	httpClient *http.Client
+	logger     *zap.Logger
+	url        string
+	apiKey     string
+	model      string
+	voice      string
+	format     string
+	mediaType  string
+}
+
+// NewOpenAISpeechSynthesizer creates a synthesizer calling the configured speech endpoint with
+// the model, voice and audio format
+func NewOpenAISpeechSynthesizer(cfg config.SpeechConfig, logger *zap.Logger) (*OpenAISpeechSynthesizer, error) {
+	mediaType := cfg.SynthesisMediaType()
+	if mediaType == "" {
+		return nil, fmt.Errorf("unsupported speech format: %s", cfg.Format)
+	}
+	return &OpenAISpeechSynthesizer{
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		logger:     logger,
+		url:        cfg.SynthesisURL,
+		apiKey:     cfg.APIKey,
+		model:      cfg.SynthesisModel,
+		voice:      cfg.Voice,
+		format:     cfg.Format,
+		mediaType:  mediaType,
+	}, nil
+}
+
+// MediaType returns the media type of the configured audio format
+func (s *OpenAISpeechSynthesizer) MediaType() string {
+	return s.mediaType
+}
+
+// Synthesize sends the text to the speech endpoint and returns the audio it responds with
+func (s *OpenAISpeechSynthesizer) Synthesize(ctx context.Context, text string) ([]byte, error) {
+	body, err := json.Marshal(map[string]any{
+		"model":           s.model,
+		"input":           text,
+		"voice":           s.voice,
+		"response_format": s.format,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode speech request: %w", err)
+	}
+
+	audio, err := postSpeech(ctx, s.httpClient, s.url, s.apiKey, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("speech synthesis failed: %w", err)
+	}
+
+	s.logger.Debug("synthesized speech",
+		zap.Int("text_length", len(text)),
+		zap.Int("audio_size", len(audio)))
+	return audio, nil
+}
+
+// postSpeech posts a request to a speech endpoint and returns its response body
+func postSpeech(ctx context.Context, client *http.Client, url, apiKey, contentType string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
+
+// audioFileName names an upload after the media type of the audio, since transcription
+// endpoints tell the audio format from the file extension
+func audioFileName(mediaType string) string {
+	switch baseMediaType(mediaType) {
+	case "audio/mpeg", "audio/mp3":
+		return "audio.mp3"
+	case "audio/wav", "audio/wave", "audio/x-wav", "audio/vnd.wave":
+		return "audio.wav"
+	case "audio/ogg", "audio/opus":
+		return "audio.ogg"
+	case "audio/webm":
+		return "audio.webm"
+	case "audio/mp4", "audio/m4a", "audio/x-m4a":
+		return "audio.m4a"
+	case "audio/flac", "audio/x-flac":
+		return "audio.flac"
+	default:
+		return "audio"
+	}
+}
+
+// NewTranscriptionConverter creates a file converter attaching the transcript of audio files
+// as their text
+func NewTranscriptionConverter(transcriber Transcriber) FileConverter {
+	return NewFileConverter([]string{audioInputMode}, func(ctx context.Context, data []byte, file *types.IngestedFile) error {
+		text, err := transcriber.Transcribe(ctx, data, file.MediaType)
+		if err != nil {
+			return err
+		}
+		file.Text = text
+		return nil
+	})
+}
+
+// withAudioInputMode returns a copy of the agent card accepting audio among its default input
+// modes. Cards without default input modes already accept every media type.
+func withAudioInputMode(card types.AgentCard) types.AgentCard {
+	if len(card.DefaultInputModes) == 0 || acceptsMediaType(card.DefaultInputModes, audioInputMode) {
+		return card
+	}
+	card.DefaultInputModes = append(slices.Clone(card.DefaultInputModes), audioInputMode)
+	return card
+}
+
+// SpeechOutput synthesizes the agent's responses to audio artifacts for the tasks whose
+// requests accept the audio among their output modes
+type SpeechOutput struct {
+	synthesizer   SpeechSynthesizer
+	maxTextLength int
+	logger        *zap.Logger
+}
+
+// NewSpeechOutput creates the speech output of a synthesizer. Responses longer than
+// maxTextLength characters are not synthesized, unless it is 0.
+func NewSpeechOutput(synthesizer SpeechSynthesizer, maxTextLength int, logger *zap.Logger) *SpeechOutput {
+	return &SpeechOutput{synthesizer: synthesizer, maxTextLength: maxTextLength, logger: logger}
+}
+
+// accepts reports whether a request asks for speech: one of its accepted output modes must
+// be an audio mode matching the synthesized media type, so clients accepting */* are not
+// sent audio they did not ask for
+func (o *SpeechOutput) accepts(configuration *types.MessageSendConfiguration) bool {
+	if o == nil || configuration == nil {
+		return false
+	}
+	mediaType := o.synthesizer.MediaType()
+	for _, mode := range configuration.AcceptedOutputModes {
+		if strings.HasPrefix(baseMediaType(mode), "audio/") && acceptsMediaType([]string{mode}, mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+// withOutputMode returns a copy of the agent card advertising the synthesized audio among
+// its default output modes
+func (o *SpeechOutput) withOutputMode(card types.AgentCard) types.AgentCard {
+	mediaType := o.synthesizer.MediaType()
+	if slices.Contains(card.DefaultOutputModes, mediaType) {
+		return card
+	}
+	card.DefaultOutputModes = append(slices.Clone(card.DefaultOutputModes), mediaType)
+	return card
+}
+
+// synthesizeTask speaks the response of a completed task whose request asked for speech and
+// attaches the audio to the task as an artifact, which it returns. A response that cannot be
+// synthesized is logged and leaves the task without audio.
+func (o *SpeechOutput) synthesizeTask(ctx context.Context, task *types.Task) *types.Artifact {
+	if o == nil || task == nil || task.Status.State != types.TaskStateCompleted || task.Metadata == nil {
+		return nil
+	}
+	if _, requested := (*task.Metadata)[types.SpeechOutputMetadataKey]; !requested {
+		return nil
+	}
+
+	message := task.Status.Message
+	if message == nil || message.Role != types.RoleAgent {
+		return nil
+	}
+	var texts []string
+	for _, part := range message.Parts {
+		if part.Text != nil {
+			texts = append(texts, *part.Text)
+		}
+	}
+	text := strings.TrimSpace(strings.Join(texts, "\n"))
+	if text == "" {
+		return nil
+	}
+	if o.maxTextLength > 0 && utf8.RuneCountInString(text) > o.maxTextLength {
+		o.logger.Warn("response is too long to synthesize",
+			zap.String("task_id", task.ID),
+			zap.Int("max_length", o.maxTextLength))
+		return nil
+	}
+
+	audio, err := o.synthesizer.Synthesize(ctx, text)
+	if err != nil {
+		o.logger.Warn("failed to synthesize response",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+		return nil
+	}
+
+	mediaType := o.synthesizer.MediaType()
+	encoded := base64.StdEncoding.EncodeToString(audio)
+	artifact := types.Artifact{
+		ArtifactID:  uuid.New().String(),
+		Name:        new("speech"),
+		Description: new("The agent's response spoken"),
+		Parts:       []types.Part{types.CreateFilePart("response"+speechFileExtension(mediaType), mediaType, &encoded, nil)},
+	}
+	task.Artifacts = append(task.Artifacts, artifact)
+	return &artifact
+}
+
+// speechFileExtension returns the file extension of synthesized audio
+func speechFileExtension(mediaType string) string {
+	switch mediaType {
+	case "audio/mpeg":
+		return ".mp3"
+	case "audio/ogg":
+		return ".opus"
+	case "audio/aac":
+		return ".aac"
+	case "audio/flac":
+		return ".flac"
+	case "audio/wav":
+		return ".wav"
+	default:
+		return ""
+	}
+}
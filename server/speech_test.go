@@ -0,0 +1,280 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+var testWAV = []byte("RIFF\x24\x00\x00\x00WAVEfmt \x10\x00\x00\x00")
+
+type speechSynthesizerFunc func(ctx context.Context, text string) ([]byte, error)
+
+func (f speechSynthesizerFunc) Synthesize(ctx context.Context, text string) ([]byte, error) {
+	return f(ctx, text)
+}
+
+func (f speechSynthesizerFunc) MediaType() string { return "audio/mpeg" }
+
+// newSpeechServer serves a transcription endpoint answering with the transcript and a speech
+// endpoint answering with the input text as audio
+func newSpeechServer(t *testing.T, transcript string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/audio/transcriptions":
+			_ = json.NewEncoder(w).Encode(map[string]string{"text": transcript})
+		case "/audio/speech":
+			var request struct {
+				Input string `json:"input"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			_, _ = w.Write([]byte("ID3" + request.Input))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestOpenAITranscriber_Transcribe(t *testing.T) {
+	var model, fileName, authorization string
+	var audio []byte
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		model = r.FormValue("model")
+		file, header, err := r.FormFile("file")
+		if err == nil {
+			fileName = header.Filename
+			audio, _ = io.ReadAll(file)
+		}
+		_, _ = w.Write([]byte(`{"text":" What is the weather like? "}`))
+	}))
+	defer httpServer.Close()
+
+	transcriber := NewOpenAITranscriber(config.SpeechConfig{
+		TranscriptionURL:   httpServer.URL,
+		TranscriptionModel: "whisper-1",
+		APIKey:             "secret",
+		Timeout:            time.Second,
+	}, zap.NewNop())
+
+	text, err := transcriber.Transcribe(context.Background(), testWAV, "audio/wave")
+	require.NoError(t, err)
+	assert.Equal(t, "What is the weather like?", text)
+	assert.Equal(t, "whisper-1", model)
+	assert.Equal(t, "audio.wav", fileName)
+	assert.Equal(t, testWAV, audio)
+	assert.Equal(t, "Bearer secret", authorization)
+}
+
+func TestOpenAISpeechSynthesizer_Synthesize(t *testing.T) {
+	var request map[string]any
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		_, _ = w.Write([]byte("OggS audio"))
+	}))
+	defer httpServer.Close()
+
+	cfg := config.SpeechConfig{
+		SynthesisURL:   httpServer.URL,
+		SynthesisModel: "tts-1",
+		Voice:          "nova",
+		Format:         "opus",
+		Timeout:        time.Second,
+	}
+	synthesizer, err := NewOpenAISpeechSynthesizer(cfg, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, "audio/ogg", synthesizer.MediaType())
+
+	audio, err := synthesizer.Synthesize(context.Background(), "Sunny and warm")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("OggS audio"), audio)
+	assert.Equal(t, map[string]any{"model": "tts-1", "input": "Sunny and warm", "voice": "nova", "response_format": "opus"}, request)
+
+	cfg.Format = "midi"
+	_, err = NewOpenAISpeechSynthesizer(cfg, zap.NewNop())
+	assert.EqualError(t, err, "unsupported speech format: midi")
+}
+
+func TestSpeechOutput(t *testing.T) {
+	var synthesized []string
+	output := NewSpeechOutput(speechSynthesizerFunc(func(ctx context.Context, text string) ([]byte, error) {
+		if text == "fail" {
+			return nil, errors.New("speech endpoint unavailable")
+		}
+		synthesized = append(synthesized, text)
+		return []byte("ID3" + text), nil
+	}), 20, zap.NewNop())
+
+	accepts := func(modes ...string) bool {
+		return output.accepts(&types.MessageSendConfiguration{AcceptedOutputModes: modes})
+	}
+	assert.True(t, accepts("text/plain", "audio/mpeg"))
+	assert.True(t, accepts("audio/*"))
+	assert.False(t, accepts("*/*"), "a catch-all mode does not ask for speech")
+	assert.False(t, accepts("audio/wav"))
+	assert.False(t, output.accepts(nil))
+
+	card := output.withOutputMode(types.AgentCard{DefaultOutputModes: []string{"text/plain"}})
+	assert.Equal(t, []string{"text/plain", "audio/mpeg"}, card.DefaultOutputModes)
+	assert.Equal(t, card, output.withOutputMode(card))
+
+	newTask := func(text string, requested bool) *types.Task {
+		task := &types.Task{
+			ID: "task-1",
+			Status: types.TaskStatus{
+				State:   types.TaskStateCompleted,
+				Message: &types.Message{Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart(text)}},
+			},
+			Metadata: &map[string]any{},
+		}
+		if requested {
+			(*task.Metadata)[types.SpeechOutputMetadataKey] = "audio/mpeg"
+		}
+		return task
+	}
+
+	task := newTask("It is sunny", true)
+	artifact := output.synthesizeTask(context.Background(), task)
+	require.NotNil(t, artifact)
+	require.Len(t, task.Artifacts, 1)
+	part := task.Artifacts[0].Parts[0]
+	require.NotNil(t, part.File)
+	assert.Equal(t, "response.mp3", part.File.Name)
+	assert.Equal(t, "audio/mpeg", part.File.MediaType)
+	audio, err := base64.StdEncoding.DecodeString(*part.File.FileWithBytes)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ID3It is sunny"), audio)
+
+	assert.Nil(t, output.synthesizeTask(context.Background(), newTask("Not requested", false)))
+	assert.Nil(t, output.synthesizeTask(context.Background(), newTask("This response is far too long to speak", true)))
+	assert.Nil(t, output.synthesizeTask(context.Background(), newTask("fail", true)))
+	working := newTask("Still working", true)
+	working.Status.State = types.TaskStateWorking
+	assert.Nil(t, output.synthesizeTask(context.Background(), working))
+	assert.Equal(t, []string{"It is sunny"}, synthesized)
+}
+
+func TestA2AServer_Speech(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	speechServer := newSpeechServer(t, "What is the weather like?")
+	defer speechServer.Close()
+
+	cfg := &config.Config{CapabilitiesConfig: config.CapabilitiesConfig{Streaming: true}, SpeechConfig: config.SpeechConfig{
+		Transcription:    true,
+		TranscriptionURL: speechServer.URL + "/audio/transcriptions",
+		Synthesis:        true,
+		SynthesisURL:     speechServer.URL + "/audio/speech",
+		Format:           "mp3",
+		Timeout:          time.Second,
+	}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{
+		Name:               "assistant",
+		DefaultInputModes:  []string{"text/plain"},
+		DefaultOutputModes: []string{"text/plain"},
+	})
+	router := s.setupRouter(cfg)
+
+	card := s.GetAgentCard()
+	require.NotNil(t, card)
+	assert.Equal(t, []string{"text/plain", "audio/*"}, card.DefaultInputModes)
+	assert.Equal(t, []string{"text/plain", "audio/mpeg"}, card.DefaultOutputModes)
+
+	send := func(outputModes ...string) types.Task {
+		params, err := json.Marshal(types.MessageSendParams{
+			Message: types.Message{
+				MessageID: "m1",
+				Role:      types.RoleUser,
+				Parts:     []types.Part{filePart("question.wav", "audio/wav", testWAV)},
+			},
+			Configuration: &types.MessageSendConfiguration{AcceptedOutputModes: outputModes},
+		})
+		require.NoError(t, err)
+		body := `{"jsonrpc":"2.0","id":"1","method":"message/send","params":` + string(params) + `}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+		var response struct {
+			Result *types.Task         `json:"result"`
+			Error  *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Nil(t, response.Error)
+		return *response.Result
+	}
+
+	task := send("text/plain", "audio/mpeg")
+	file, ok, err := types.GetIngestedFile(task.History[len(task.History)-1].Parts[0])
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "What is the weather like?", file.Text)
+	require.NotNil(t, task.Metadata)
+	assert.Equal(t, "audio/mpeg", (*task.Metadata)[types.SpeechOutputMetadataKey])
+
+	task = send("text/plain")
+	if task.Metadata != nil {
+		assert.NotContains(t, *task.Metadata, types.SpeechOutputMetadataKey)
+	}
+
+	answer := &types.Message{MessageID: "answer", Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart("Sunny")}}
+	completed := cloudevents.NewEvent()
+	completed.SetType(types.EventTaskStatusChanged)
+	require.NoError(t, completed.SetData(cloudevents.ApplicationJSON, types.TaskStatus{State: types.TaskStateCompleted, Message: answer}))
+	s.SetStreamingTaskHandler(&eventSequenceHandler{events: []cloudevents.Event{
+		types.NewIterationCompletedEvent(1, "streaming-task", answer),
+		completed,
+	}})
+
+	params, err := json.Marshal(types.MessageSendParams{
+		Message:       types.Message{MessageID: "m2", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("And tomorrow?")}},
+		Configuration: &types.MessageSendConfiguration{AcceptedOutputModes: []string{"audio/*"}},
+	})
+	require.NoError(t, err)
+	body := `{"jsonrpc":"2.0","id":"2","method":"message/stream","params":` + string(params) + `}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+
+	var kinds []string
+	for _, chunk := range strings.Split(w.Body.String(), "data: ") {
+		chunk = strings.TrimSpace(chunk)
+		if chunk == "" || chunk == "[DONE]" {
+			continue
+		}
+		var response struct {
+			Result struct {
+				Artifact *types.Artifact `json:"artifact"`
+				Final    bool            `json:"final"`
+			} `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(chunk), &response))
+		switch {
+		case response.Result.Artifact != nil:
+			kinds = append(kinds, "artifact")
+			file := response.Result.Artifact.Parts[0].File
+			require.NotNil(t, file)
+			assert.Equal(t, "audio/mpeg", file.MediaType)
+			audio, err := base64.StdEncoding.DecodeString(*file.FileWithBytes)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("ID3Sunny"), audio)
+		case response.Result.Final:
+			kinds = append(kinds, "final")
+		}
+	}
+	assert.Equal(t, []string{"artifact", "final"}, kinds, "the speech is streamed before the final status")
+}
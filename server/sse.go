@@ -0,0 +1,111 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// maxPooledFrameSize bounds the buffers kept for reuse, so a single large frame, such as a task
+// snapshot with a long history, does not stay in memory once its stream ended
+const maxPooledFrameSize = 64 << 10
+
+// sseDoneFrame terminates a stream
+var sseDoneFrame = []byte("data: [DONE]\n\n")
+
+// sseFramePool holds the buffers frames are serialized into
+var sseFramePool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// writeSSEFrame serializes the value as a data frame into a pooled buffer and writes it with a
+// single write, flushing it to the client
+func writeSSEFrame(w http.ResponseWriter, value any) error {
+	buf := sseFramePool.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledFrameSize {
+			buf.Reset()
+			sseFramePool.Put(buf)
+		}
+	}()
+
+	buf.Reset()
+	buf.WriteString("data: ")
+	// Encode ends the value with a newline, the frame with a blank line
+	if err := json.NewEncoder(buf).Encode(value); err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+	buf.WriteByte('\n')
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write response: %w", err)
+	}
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// writeSSEDone writes the frame terminating a stream, flushing it to the client
+func writeSSEDone(w http.ResponseWriter) error {
+	if _, err := w.Write(sseDoneFrame); err != nil {
+		return err
+	}
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// streamEncodedTask is a task snapshot delivered on a stream whose history messages were
+// encoded beforehand
+type streamEncodedTask struct {
+	Kind string `json:"kind"`
+	types.Task
+	History []json.RawMessage `json:"history,omitempty"`
+	Status  streamStatus      `json:"status"`
+}
+
+// streamHistoryCache keeps the JSON of the history messages a stream already delivered. A
+// stream sends a task snapshot with every delta while its history only grows between
+// iterations, so each snapshot encodes its status message alone.
+type streamHistoryCache struct {
+	encoded map[string]json.RawMessage
+}
+
+// newStreamHistoryCache creates an empty history cache for a stream
+func newStreamHistoryCache() *streamHistoryCache {
+	return &streamHistoryCache{encoded: make(map[string]json.RawMessage)}
+}
+
+// snapshot tags a task snapshot like translateStreamResult, taking the history messages from
+// the cache and encoding the ones it has not seen. Messages without an ID are not cached.
+func (c *streamHistoryCache) snapshot(task types.Task) (any, error) {
+	translated := streamEncodedTask{
+		Kind:   types.StreamResultKindTask,
+		Task:   task,
+		Status: newStreamStatus(task.Status, task.ID, task.ContextID),
+	}
+	if len(task.History) > 0 {
+		translated.History = make([]json.RawMessage, 0, len(task.History))
+		for _, message := range task.History {
+			encoded, cached := c.encoded[message.MessageID]
+			if !cached {
+				var err error
+				encoded, err = json.Marshal(newStreamMessage(message, task.ID, task.ContextID))
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal history message: %w", err)
+				}
+				if message.MessageID != "" {
+					c.encoded[message.MessageID] = encoded
+				}
+			}
+			translated.History = append(translated.History, encoded)
+		}
+	}
+	return translated, nil
+}
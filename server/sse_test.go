@@ -0,0 +1,114 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// streamingTask returns a working task with a history of the given number of messages and a
+// delta as its status message
+func streamingTask(historySize int) types.Task {
+	task := types.Task{
+		ID:        "task-1",
+		ContextID: "ctx-1",
+		Status: types.TaskStatus{
+			State:   types.TaskStateWorking,
+			Message: &types.Message{MessageID: "delta-1", Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart("token ")}},
+		},
+	}
+	for i := range historySize {
+		role := types.RoleUser
+		if i%2 == 1 {
+			role = types.RoleAgent
+		}
+		task.History = append(task.History, types.Message{
+			MessageID: fmt.Sprintf("message-%d", i),
+			Role:      role,
+			Parts:     []types.Part{types.CreateTextPart(strings.Repeat("The quick brown fox jumps over the lazy dog. ", 10))},
+		})
+	}
+	return task
+}
+
+func TestWriteSSEFrame(t *testing.T) {
+	response := types.JSONRPCSuccessResponse{JSONRPC: "2.0", ID: "1", Result: map[string]any{"text": "<b>&</b>"}}
+	expected, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	require.NoError(t, writeSSEFrame(recorder, response))
+	require.NoError(t, writeSSEFrame(recorder, response), "pooled buffers are reset between frames")
+	require.NoError(t, writeSSEDone(recorder))
+
+	frame := "data: " + string(expected) + "\n\n"
+	assert.Equal(t, frame+frame+"data: [DONE]\n\n", recorder.Body.String())
+	assert.True(t, recorder.Flushed)
+
+	assert.ErrorContains(t, writeSSEFrame(recorder, func() {}), "failed to marshal response")
+}
+
+func TestStreamHistoryCache_Snapshot(t *testing.T) {
+	cache := newStreamHistoryCache()
+	task := streamingTask(3)
+	task.History = append(task.History, types.Message{Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart("no ID")}})
+
+	for range 2 {
+		snapshot, err := cache.snapshot(task)
+		require.NoError(t, err)
+		actual, err := json.Marshal(snapshot)
+		require.NoError(t, err)
+		expected, err := json.Marshal(translateStreamResult(task))
+		require.NoError(t, err)
+		assert.JSONEq(t, string(expected), string(actual))
+
+		task.History = append(task.History, types.Message{MessageID: "message-new", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("next")}})
+	}
+	assert.Len(t, cache.encoded, 4, "messages without an ID are encoded with every snapshot")
+}
+
+// BenchmarkStreamingDelta measures writing the task snapshot of a streamed delta, as written
+// before frames were pooled and history messages cached, and as written now
+func BenchmarkStreamingDelta(b *testing.B) {
+	for _, historySize := range []int{2, 20, 100} {
+		task := streamingTask(historySize)
+
+		b.Run(fmt.Sprintf("history=%d/marshal", historySize), func(b *testing.B) {
+			recorder := httptest.NewRecorder()
+			b.ReportAllocs()
+			for b.Loop() {
+				recorder.Body.Reset()
+				data, err := json.Marshal(types.JSONRPCSuccessResponse{JSONRPC: "2.0", ID: "1", Result: translateStreamResult(task)})
+				if err != nil {
+					b.Fatal(err)
+				}
+				_, _ = recorder.Write([]byte("data: "))
+				_, _ = recorder.Write(data)
+				_, _ = recorder.Write([]byte("\n\n"))
+			}
+		})
+
+		b.Run(fmt.Sprintf("history=%d/pooled", historySize), func(b *testing.B) {
+			recorder := httptest.NewRecorder()
+			cache := newStreamHistoryCache()
+			b.ReportAllocs()
+			for b.Loop() {
+				recorder.Body.Reset()
+				snapshot, err := cache.snapshot(task)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err := writeSSEFrame(recorder, types.JSONRPCSuccessResponse{JSONRPC: "2.0", ID: "1", Result: snapshot}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
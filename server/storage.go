@@ -3,7 +3,9 @@ package server
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
+	"time"
 
 	types "github.com/inference-gateway/adk/types"
 	otel "go.opentelemetry.io/otel"
@@ -39,6 +41,59 @@ type QueuedTask struct {
 	// TraceContext carries the W3C trace context and baggage of the request
 	// that enqueued the task, so background processing joins the caller's trace.
 	TraceContext map[string]string `json:"trace_context,omitempty"`
+	// EnqueuedAt is when the task entered the queue
+	EnqueuedAt time.Time `json:"enqueued_at,omitzero"`
+}
+
+// QueueInspector is implemented by storage backends that can list the tasks waiting in
+// the queue, for queue observability
+type QueueInspector interface {
+	// PeekQueue returns up to limit waiting tasks, oldest first, without removing them.
+	// A limit of zero or less returns every waiting task.
+	PeekQueue(ctx context.Context, limit int) ([]*QueuedTask, error)
+}
+
+// ContextStore is implemented by storage backends that persist the contexts created with
+// the contexts/* methods, with their metadata and shared memory
+type ContextStore interface {
+	// SaveContextRecord creates or replaces the record of a context
+	SaveContextRecord(ctx context.Context, record *types.ConversationContext) error
+
+	// GetContextRecord returns the record of a context, reporting false when there is none
+	GetContextRecord(ctx context.Context, contextID string) (*types.ConversationContext, bool, error)
+
+	// ListContextRecords returns the records of every context
+	ListContextRecords(ctx context.Context) ([]*types.ConversationContext, error)
+
+	// DeleteContextRecord removes the record of a context
+	DeleteContextRecord(ctx context.Context, contextID string) error
+}
+
+// CheckpointStore is implemented by storage backends that persist the agent loop checkpoints
+// of running tasks, so the tasks continue where they stopped after a restart
+type CheckpointStore interface {
+	// SaveCheckpoint creates or replaces the checkpoint of a task
+	SaveCheckpoint(ctx context.Context, checkpoint *AgentCheckpoint) error
+
+	// GetCheckpoint returns the checkpoint of a task, reporting false when there is none
+	GetCheckpoint(ctx context.Context, taskID string) (*AgentCheckpoint, bool, error)
+
+	// DeleteCheckpoint removes the checkpoint of a task
+	DeleteCheckpoint(ctx context.Context, taskID string) error
+}
+
+// ToolJournalStore is implemented by storage backends that persist the journal of the
+// side-effecting tool calls of running tasks, so a resumed task does not execute them again
+type ToolJournalStore interface {
+	// RecordToolExecution creates or replaces the journal entry of a tool call
+	RecordToolExecution(ctx context.Context, entry *ToolJournalEntry) error
+
+	// GetToolExecution returns the journal entry of a tool call of a task, reporting false
+	// when there is none
+	GetToolExecution(ctx context.Context, taskID, toolCallID string) (*ToolJournalEntry, bool, error)
+
+	// DeleteToolJournal removes the journal entries of a task
+	DeleteToolJournal(ctx context.Context, taskID string) error
 }
 
 // Storage defines the interface for queue-centric task management
@@ -81,6 +136,8 @@ type Storage interface {
 type TaskFilter struct {
 	State     *types.TaskState
 	ContextID *string
+	Labels    map[string]string
+	UserID    *string
 	Limit     int
 	Offset    int
 	SortBy    TaskSortField
@@ -133,6 +190,18 @@ type InMemoryStorage struct {
 	taskQueue   []*QueuedTask
 	queueMu     sync.RWMutex
 	queueNotify chan struct{}
+
+	// Records of the contexts created with the contexts/* methods
+	contextRecords   map[string]*types.ConversationContext
+	contextRecordsMu sync.RWMutex
+
+	// Agent loop checkpoints of running tasks
+	checkpoints   map[string]*AgentCheckpoint
+	checkpointsMu sync.RWMutex
+
+	// Journal of the side-effecting tool calls of running tasks, by task and tool call ID
+	toolJournal   map[string]map[string]*ToolJournalEntry
+	toolJournalMu sync.RWMutex
 }
 
 // NewInMemoryStorage creates a new in-memory storage instance
@@ -148,6 +217,9 @@ func NewInMemoryStorage(logger *zap.Logger, maxConversationHistory int) *InMemor
 		tasksByContext:      make(map[string][]string),
 		taskQueue:           make([]*QueuedTask, 0),
 		queueNotify:         make(chan struct{}, 1000), // Buffered channel for queue notifications
+		contextRecords:      make(map[string]*types.ConversationContext),
+		checkpoints:         make(map[string]*AgentCheckpoint),
+		toolJournal:         make(map[string]map[string]*ToolJournalEntry),
 	}
 }
 
@@ -330,6 +402,10 @@ func (s *InMemoryStorage) ListTasks(filter TaskFilter) ([]*types.Task, error) {
 			continue
 		}
 
+		if !hasTaskLabels(task, filter.Labels) || !hasTaskUser(task, filter.UserID) {
+			continue
+		}
+
 		taskCopy := *task
 		filteredTasks = append(filteredTasks, &taskCopy)
 	}
@@ -349,6 +425,10 @@ func (s *InMemoryStorage) ListTasks(filter TaskFilter) ([]*types.Task, error) {
 			continue
 		}
 
+		if !hasTaskLabels(task, filter.Labels) || !hasTaskUser(task, filter.UserID) {
+			continue
+		}
+
 		taskCopy := *task
 		filteredTasks = append(filteredTasks, &taskCopy)
 	}
@@ -365,6 +445,10 @@ func (s *InMemoryStorage) ListTasks(filter TaskFilter) ([]*types.Task, error) {
 			continue
 		}
 
+		if !hasTaskLabels(task, filter.Labels) || !hasTaskUser(task, filter.UserID) {
+			continue
+		}
+
 		if !queueTaskIDs[task.ID] {
 			taskCopy := *task
 			filteredTasks = append(filteredTasks, &taskCopy)
@@ -413,6 +497,10 @@ func (s *InMemoryStorage) ListTasksByContext(contextID string, filter TaskFilter
 			continue
 		}
 
+		if !hasTaskLabels(task, filter.Labels) || !hasTaskUser(task, filter.UserID) {
+			continue
+		}
+
 		taskCopy := *task
 		filteredTasks = append(filteredTasks, &taskCopy)
 	}
@@ -758,6 +846,7 @@ func (s *InMemoryStorage) EnqueueTask(ctx context.Context, task *types.Task, req
 		Task:         task,
 		RequestID:    requestID,
 		TraceContext: injectTraceContext(ctx),
+		EnqueuedAt:   time.Now(),
 	}
 
 	s.queueMu.Lock()
@@ -820,6 +909,20 @@ func (s *InMemoryStorage) GetQueueLength() int {
 	return len(s.taskQueue)
 }
 
+// PeekQueue returns up to limit waiting tasks, oldest first, without removing them
+func (s *InMemoryStorage) PeekQueue(ctx context.Context, limit int) ([]*QueuedTask, error) {
+	s.queueMu.RLock()
+	defer s.queueMu.RUnlock()
+
+	count := len(s.taskQueue)
+	if limit > 0 && limit < count {
+		count = limit
+	}
+	queued := make([]*QueuedTask, count)
+	copy(queued, s.taskQueue)
+	return queued, nil
+}
+
 // ClearQueue removes all tasks from the queue
 func (s *InMemoryStorage) ClearQueue() error {
 	s.queueMu.Lock()
@@ -831,3 +934,134 @@ func (s *InMemoryStorage) ClearQueue() error {
 	s.logger.Info("task queue cleared", zap.Int("removed_tasks", queueLength))
 	return nil
 }
+
+// SaveContextRecord creates or replaces the record of a context
+func (s *InMemoryStorage) SaveContextRecord(ctx context.Context, record *types.ConversationContext) error {
+	if record == nil {
+		return fmt.Errorf("context record cannot be nil")
+	}
+
+	s.contextRecordsMu.Lock()
+	defer s.contextRecordsMu.Unlock()
+
+	recordCopy := *record
+	s.contextRecords[record.ContextID] = &recordCopy
+	return nil
+}
+
+// GetContextRecord returns the record of a context, reporting false when there is none
+func (s *InMemoryStorage) GetContextRecord(ctx context.Context, contextID string) (*types.ConversationContext, bool, error) {
+	s.contextRecordsMu.RLock()
+	defer s.contextRecordsMu.RUnlock()
+
+	record, exists := s.contextRecords[contextID]
+	if !exists {
+		return nil, false, nil
+	}
+	recordCopy := *record
+	return &recordCopy, true, nil
+}
+
+// ListContextRecords returns the records of every context
+func (s *InMemoryStorage) ListContextRecords(ctx context.Context) ([]*types.ConversationContext, error) {
+	s.contextRecordsMu.RLock()
+	defer s.contextRecordsMu.RUnlock()
+
+	records := make([]*types.ConversationContext, 0, len(s.contextRecords))
+	for _, record := range s.contextRecords {
+		recordCopy := *record
+		records = append(records, &recordCopy)
+	}
+	return records, nil
+}
+
+// DeleteContextRecord removes the record of a context
+func (s *InMemoryStorage) DeleteContextRecord(ctx context.Context, contextID string) error {
+	s.contextRecordsMu.Lock()
+	defer s.contextRecordsMu.Unlock()
+
+	delete(s.contextRecords, contextID)
+	return nil
+}
+
+// SaveCheckpoint creates or replaces the checkpoint of a task
+func (s *InMemoryStorage) SaveCheckpoint(ctx context.Context, checkpoint *AgentCheckpoint) error {
+	if checkpoint == nil {
+		return fmt.Errorf("checkpoint cannot be nil")
+	}
+
+	s.checkpointsMu.Lock()
+	defer s.checkpointsMu.Unlock()
+
+	checkpointCopy := *checkpoint
+	checkpointCopy.Messages = slices.Clone(checkpoint.Messages)
+	checkpointCopy.PendingToolCalls = slices.Clone(checkpoint.PendingToolCalls)
+	s.checkpoints[checkpoint.TaskID] = &checkpointCopy
+	return nil
+}
+
+// GetCheckpoint returns the checkpoint of a task, reporting false when there is none
+func (s *InMemoryStorage) GetCheckpoint(ctx context.Context, taskID string) (*AgentCheckpoint, bool, error) {
+	s.checkpointsMu.RLock()
+	defer s.checkpointsMu.RUnlock()
+
+	checkpoint, exists := s.checkpoints[taskID]
+	if !exists {
+		return nil, false, nil
+	}
+	checkpointCopy := *checkpoint
+	checkpointCopy.Messages = slices.Clone(checkpoint.Messages)
+	checkpointCopy.PendingToolCalls = slices.Clone(checkpoint.PendingToolCalls)
+	return &checkpointCopy, true, nil
+}
+
+// DeleteCheckpoint removes the checkpoint of a task
+func (s *InMemoryStorage) DeleteCheckpoint(ctx context.Context, taskID string) error {
+	s.checkpointsMu.Lock()
+	defer s.checkpointsMu.Unlock()
+
+	delete(s.checkpoints, taskID)
+	return nil
+}
+
+// RecordToolExecution creates or replaces the journal entry of a tool call
+func (s *InMemoryStorage) RecordToolExecution(ctx context.Context, entry *ToolJournalEntry) error {
+	if entry == nil {
+		return fmt.Errorf("tool journal entry cannot be nil")
+	}
+
+	s.toolJournalMu.Lock()
+	defer s.toolJournalMu.Unlock()
+
+	entries, exists := s.toolJournal[entry.TaskID]
+	if !exists {
+		entries = make(map[string]*ToolJournalEntry)
+		s.toolJournal[entry.TaskID] = entries
+	}
+	entryCopy := *entry
+	entries[entry.ToolCallID] = &entryCopy
+	return nil
+}
+
+// GetToolExecution returns the journal entry of a tool call of a task, reporting false when
+// there is none
+func (s *InMemoryStorage) GetToolExecution(ctx context.Context, taskID, toolCallID string) (*ToolJournalEntry, bool, error) {
+	s.toolJournalMu.RLock()
+	defer s.toolJournalMu.RUnlock()
+
+	entry, exists := s.toolJournal[taskID][toolCallID]
+	if !exists {
+		return nil, false, nil
+	}
+	entryCopy := *entry
+	return &entryCopy, true, nil
+}
+
+// DeleteToolJournal removes the journal entries of a task
+func (s *InMemoryStorage) DeleteToolJournal(ctx context.Context, taskID string) error {
+	s.toolJournalMu.Lock()
+	defer s.toolJournalMu.Unlock()
+
+	delete(s.toolJournal, taskID)
+	return nil
+}
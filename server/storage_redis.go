@@ -15,7 +15,8 @@ import (
 	"go.uber.org/zap"
 )
 
-// RedisClient is the subset of *redis.Client methods used by RedisStorage.
+// RedisClient is the subset of *redis.Client methods used by RedisStorage and
+// RedisSemanticCacheBackend.
 // Defined as an interface so tests can drive RedisStorage against a counterfeiter fake
 // instead of requiring a live Redis server.
 type RedisClient interface {
@@ -23,8 +24,10 @@ type RedisClient interface {
 	Publish(ctx context.Context, channel string, message any) *redis.IntCmd
 	BRPop(ctx context.Context, timeout time.Duration, keys ...string) *redis.StringSliceCmd
 	LLen(ctx context.Context, key string) *redis.IntCmd
+	LRange(ctx context.Context, key string, start, stop int64) *redis.StringSliceCmd
 	Del(ctx context.Context, keys ...string) *redis.IntCmd
 	Get(ctx context.Context, key string) *redis.StringCmd
+	MGet(ctx context.Context, keys ...string) *redis.SliceCmd
 	Exists(ctx context.Context, keys ...string) *redis.IntCmd
 	Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd
 	Keys(ctx context.Context, pattern string) *redis.StringSliceCmd
@@ -123,18 +126,135 @@ type RedisStorage struct {
 	client RedisClient
 	logger *zap.Logger
 	config config.QueueConfig
+	cipher *TaskCipher
 }
 
-var _ Storage = (*RedisStorage)(nil)
+var (
+	_ Storage          = (*RedisStorage)(nil)
+	_ QueueInspector   = (*RedisStorage)(nil)
+	_ ContextStore     = (*RedisStorage)(nil)
+	_ CheckpointStore  = (*RedisStorage)(nil)
+	_ ToolJournalStore = (*RedisStorage)(nil)
+	_ EncryptedStore   = (*RedisStorage)(nil)
+)
 
 const (
 	taskQueueKey        = "a2a:queue"
 	activeTaskKeyPrefix = "a2a:active:"
 	deadLetterKeyPrefix = "a2a:deadletter:"
 	contextTasksPrefix  = "a2a:context:"
+	contextRecordPrefix = "a2a:contextrecord:"
+	checkpointKeyPrefix = "a2a:checkpoint:"
+	toolJournalPrefix   = "a2a:tooljournal:"
 	queueNotifyChannel  = "a2a:queue:notify"
 )
 
+// SetTaskCipher seals the message parts and artifacts of the tasks and checkpoints stored
+// from now on with the cipher, which also opens them on read
+func (s *RedisStorage) SetTaskCipher(cipher *TaskCipher) {
+	s.cipher = cipher
+}
+
+// encodeTask serializes a task, sealing it when a cipher is set
+func (s *RedisStorage) encodeTask(task *types.Task) ([]byte, error) {
+	if s.cipher != nil {
+		sealed, err := s.cipher.EncryptTask(task)
+		if err != nil {
+			return nil, err
+		}
+		task = sealed
+	}
+	return json.Marshal(task)
+}
+
+// decodeTask deserializes a stored task, opening it when a cipher is set
+func (s *RedisStorage) decodeTask(data []byte) (*types.Task, error) {
+	var task types.Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		return nil, err
+	}
+	if s.cipher != nil {
+		if err := s.cipher.DecryptTask(&task); err != nil {
+			return nil, err
+		}
+	}
+	return &task, nil
+}
+
+// encodeQueuedTask serializes a queued task, sealing its task when a cipher is set
+func (s *RedisStorage) encodeQueuedTask(queuedTask *QueuedTask) ([]byte, error) {
+	if s.cipher != nil {
+		sealed, err := s.cipher.EncryptTask(queuedTask.Task)
+		if err != nil {
+			return nil, err
+		}
+		copied := *queuedTask
+		copied.Task = sealed
+		queuedTask = &copied
+	}
+	return json.Marshal(queuedTask)
+}
+
+// decodeQueuedTask deserializes a queued task, opening its task when a cipher is set
+func (s *RedisStorage) decodeQueuedTask(data []byte) (*QueuedTask, error) {
+	var queuedTask QueuedTask
+	if err := json.Unmarshal(data, &queuedTask); err != nil {
+		return nil, err
+	}
+	if s.cipher != nil && queuedTask.Task != nil {
+		if err := s.cipher.DecryptTask(queuedTask.Task); err != nil {
+			return nil, err
+		}
+	}
+	return &queuedTask, nil
+}
+
+// ReencryptTasks seals the finished tasks stored in plain text or with a replaced key again
+// with the current key of the cipher, returning their number
+func (s *RedisStorage) ReencryptTasks(ctx context.Context) (int, error) {
+	if s.cipher == nil {
+		return 0, nil
+	}
+
+	keys, err := s.client.Keys(ctx, deadLetterKeyPrefix+"*").Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get dead letter tasks: %w", err)
+	}
+
+	reencrypted := 0
+	for _, key := range keys {
+		data, err := s.client.Get(ctx, key).Result()
+		if err != nil {
+			if err == redis.Nil {
+				continue
+			}
+			return reencrypted, fmt.Errorf("failed to get dead letter task: %w", err)
+		}
+		var stored types.Task
+		if err := json.Unmarshal([]byte(data), &stored); err != nil {
+			s.logger.Warn("skipping unreadable dead letter task", zap.String("key", key), zap.Error(err))
+			continue
+		}
+		if s.cipher.Current(&stored) {
+			continue
+		}
+
+		task, err := s.decodeTask([]byte(data))
+		if err != nil {
+			return reencrypted, fmt.Errorf("failed to decrypt task %s: %w", stored.ID, err)
+		}
+		taskData, err := s.encodeTask(task)
+		if err != nil {
+			return reencrypted, fmt.Errorf("failed to encrypt task %s: %w", task.ID, err)
+		}
+		if err := s.client.Set(ctx, key, taskData, 0).Err(); err != nil {
+			return reencrypted, fmt.Errorf("failed to store task %s: %w", task.ID, err)
+		}
+		reencrypted++
+	}
+	return reencrypted, nil
+}
+
 // EnqueueTask adds a task to the processing queue
 func (s *RedisStorage) EnqueueTask(ctx context.Context, task *types.Task, requestID any) error {
 	if task == nil {
@@ -145,9 +265,10 @@ func (s *RedisStorage) EnqueueTask(ctx context.Context, task *types.Task, reques
 		Task:         task,
 		RequestID:    requestID,
 		TraceContext: injectTraceContext(ctx),
+		EnqueuedAt:   time.Now(),
 	}
 
-	data, err := json.Marshal(queuedTask)
+	data, err := s.encodeQueuedTask(queuedTask)
 	if err != nil {
 		return fmt.Errorf("failed to serialize task: %w", err)
 	}
@@ -157,7 +278,7 @@ func (s *RedisStorage) EnqueueTask(ctx context.Context, task *types.Task, reques
 	pipe.LPush(ctx, taskQueueKey, data)
 
 	activeKey := activeTaskKeyPrefix + task.ID
-	taskData, err := json.Marshal(task)
+	taskData, err := s.encodeTask(task)
 	if err != nil {
 		return fmt.Errorf("failed to serialize active task: %w", err)
 	}
@@ -192,8 +313,8 @@ func (s *RedisStorage) DequeueTask(ctx context.Context) (*QueuedTask, error) {
 		return nil, fmt.Errorf("unexpected BRPOP result format")
 	}
 
-	var queuedTask QueuedTask
-	if err := json.Unmarshal([]byte(result[1]), &queuedTask); err != nil {
+	queuedTask, err := s.decodeQueuedTask([]byte(result[1]))
+	if err != nil {
 		return nil, fmt.Errorf("failed to deserialize queued task: %w", err)
 	}
 
@@ -203,7 +324,7 @@ func (s *RedisStorage) DequeueTask(ctx context.Context) (*QueuedTask, error) {
 		zap.String("context_id", queuedTask.Task.ContextID),
 		zap.Int("remaining_queue_length", remainingQueueLength))
 
-	return &queuedTask, nil
+	return queuedTask, nil
 }
 
 // GetQueueLength returns the current number of tasks in the queue
@@ -217,6 +338,31 @@ func (s *RedisStorage) GetQueueLength() int {
 	return int(length)
 }
 
+// PeekQueue returns up to limit waiting tasks, oldest first, without removing them. Tasks
+// are pushed to the head of the list and popped from its tail, so the oldest come last.
+func (s *RedisStorage) PeekQueue(ctx context.Context, limit int) ([]*QueuedTask, error) {
+	start := int64(0)
+	if limit > 0 {
+		start = -int64(limit)
+	}
+
+	entries, err := s.client.LRange(ctx, taskQueueKey, start, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task queue: %w", err)
+	}
+
+	queued := make([]*QueuedTask, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		queuedTask, err := s.decodeQueuedTask([]byte(entries[i]))
+		if err != nil {
+			s.logger.Warn("skipping unreadable queued task", zap.Error(err))
+			continue
+		}
+		queued = append(queued, queuedTask)
+	}
+	return queued, nil
+}
+
 // ClearQueue removes all tasks from the queue
 func (s *RedisStorage) ClearQueue() error {
 	ctx := context.Background()
@@ -230,6 +376,246 @@ func (s *RedisStorage) ClearQueue() error {
 	return nil
 }
 
+// SaveContextRecord creates or replaces the record of a context
+func (s *RedisStorage) SaveContextRecord(ctx context.Context, record *types.ConversationContext) error {
+	if record == nil {
+		return fmt.Errorf("context record cannot be nil")
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to serialize context record: %w", err)
+	}
+
+	if err := s.client.Set(ctx, contextRecordPrefix+record.ContextID, data, 0).Err(); err != nil {
+		return fmt.Errorf("failed to store context record: %w", err)
+	}
+	return nil
+}
+
+// GetContextRecord returns the record of a context, reporting false when there is none
+func (s *RedisStorage) GetContextRecord(ctx context.Context, contextID string) (*types.ConversationContext, bool, error) {
+	data, err := s.client.Get(ctx, contextRecordPrefix+contextID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get context record: %w", err)
+	}
+
+	var record types.ConversationContext
+	if err := json.Unmarshal([]byte(data), &record); err != nil {
+		return nil, false, fmt.Errorf("failed to deserialize context record: %w", err)
+	}
+	return &record, true, nil
+}
+
+// ListContextRecords returns the records of every context
+func (s *RedisStorage) ListContextRecords(ctx context.Context) ([]*types.ConversationContext, error) {
+	keys, err := s.client.Keys(ctx, contextRecordPrefix+"*").Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list context records: %w", err)
+	}
+	if len(keys) == 0 {
+		return []*types.ConversationContext{}, nil
+	}
+
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get context records: %w", err)
+	}
+
+	records := make([]*types.ConversationContext, 0, len(values))
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var record types.ConversationContext
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			s.logger.Warn("skipping unreadable context record", zap.Error(err))
+			continue
+		}
+		records = append(records, &record)
+	}
+	return records, nil
+}
+
+// DeleteContextRecord removes the record of a context
+func (s *RedisStorage) DeleteContextRecord(ctx context.Context, contextID string) error {
+	if err := s.client.Del(ctx, contextRecordPrefix+contextID).Err(); err != nil {
+		return fmt.Errorf("failed to delete context record: %w", err)
+	}
+	return nil
+}
+
+// SaveCheckpoint creates or replaces the checkpoint of a task
+func (s *RedisStorage) SaveCheckpoint(ctx context.Context, checkpoint *AgentCheckpoint) error {
+	if checkpoint == nil {
+		return fmt.Errorf("checkpoint cannot be nil")
+	}
+
+	if s.cipher != nil {
+		messages, err := s.cipher.EncryptMessages(checkpoint.TaskID, checkpoint.Messages)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt checkpoint: %w", err)
+		}
+		sealed := *checkpoint
+		sealed.Messages = messages
+		checkpoint = &sealed
+	}
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to serialize checkpoint: %w", err)
+	}
+
+	if err := s.client.Set(ctx, checkpointKeyPrefix+checkpoint.TaskID, data, 0).Err(); err != nil {
+		return fmt.Errorf("failed to store checkpoint: %w", err)
+	}
+	return nil
+}
+
+// GetCheckpoint returns the checkpoint of a task, reporting false when there is none
+func (s *RedisStorage) GetCheckpoint(ctx context.Context, taskID string) (*AgentCheckpoint, bool, error) {
+	data, err := s.client.Get(ctx, checkpointKeyPrefix+taskID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get checkpoint: %w", err)
+	}
+
+	var checkpoint AgentCheckpoint
+	if err := json.Unmarshal([]byte(data), &checkpoint); err != nil {
+		return nil, false, fmt.Errorf("failed to deserialize checkpoint: %w", err)
+	}
+	if s.cipher != nil {
+		if err := s.cipher.DecryptMessages(checkpoint.TaskID, checkpoint.Messages); err != nil {
+			return nil, false, fmt.Errorf("failed to decrypt checkpoint: %w", err)
+		}
+	}
+	return &checkpoint, true, nil
+}
+
+// DeleteCheckpoint removes the checkpoint of a task
+func (s *RedisStorage) DeleteCheckpoint(ctx context.Context, taskID string) error {
+	if err := s.client.Del(ctx, checkpointKeyPrefix+taskID).Err(); err != nil {
+		return fmt.Errorf("failed to delete checkpoint: %w", err)
+	}
+	return nil
+}
+
+// RecordToolExecution creates or replaces the journal entry of a tool call
+func (s *RedisStorage) RecordToolExecution(ctx context.Context, entry *ToolJournalEntry) error {
+	if entry == nil {
+		return fmt.Errorf("tool journal entry cannot be nil")
+	}
+
+	data, err := s.encodeToolExecution(entry)
+	if err != nil {
+		return fmt.Errorf("failed to serialize tool journal entry: %w", err)
+	}
+
+	if err := s.client.Set(ctx, toolJournalKey(entry.TaskID, entry.ToolCallID), data, 0).Err(); err != nil {
+		return fmt.Errorf("failed to store tool journal entry: %w", err)
+	}
+	return nil
+}
+
+// GetToolExecution returns the journal entry of a tool call of a task, reporting false when
+// there is none
+func (s *RedisStorage) GetToolExecution(ctx context.Context, taskID, toolCallID string) (*ToolJournalEntry, bool, error) {
+	data, err := s.client.Get(ctx, toolJournalKey(taskID, toolCallID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get tool journal entry: %w", err)
+	}
+
+	entry, err := s.decodeToolExecution(taskID, toolCallID, []byte(data))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to deserialize tool journal entry: %w", err)
+	}
+	return entry, true, nil
+}
+
+// storedToolJournalEntry is a tool journal entry as stored, whose result and error are sealed
+// into a part when a cipher is set
+type storedToolJournalEntry struct {
+	ToolJournalEntry
+	Sealed *types.Part `json:"sealed,omitempty"`
+}
+
+// sealedToolOutcome is the result and error of a tool call sealed into a single part
+type sealedToolOutcome struct {
+	Result string `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// encodeToolExecution serializes a tool journal entry, sealing its result and error, bound to
+// the tool call, when a cipher is set
+func (s *RedisStorage) encodeToolExecution(entry *ToolJournalEntry) ([]byte, error) {
+	if s.cipher == nil {
+		return json.Marshal(entry)
+	}
+	stored := storedToolJournalEntry{ToolJournalEntry: *entry}
+	stored.Result, stored.Error = "", ""
+	part, err := s.cipher.seal(toolJournalKey(entry.TaskID, entry.ToolCallID), sealedToolOutcome{Result: entry.Result, Error: entry.Error})
+	if err != nil {
+		return nil, err
+	}
+	stored.Sealed = &part
+	return json.Marshal(stored)
+}
+
+// decodeToolExecution deserializes the stored journal entry of a tool call, opening its result
+// and error when they are sealed. Entries stored before encryption was enabled are read as
+// they are.
+func (s *RedisStorage) decodeToolExecution(taskID, toolCallID string, data []byte) (*ToolJournalEntry, error) {
+	var stored storedToolJournalEntry
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+	entry := stored.ToolJournalEntry
+	if stored.Sealed == nil {
+		return &entry, nil
+	}
+	if s.cipher == nil {
+		return nil, fmt.Errorf("tool journal entry %s is encrypted and no encryption key is set", toolCallID)
+	}
+	var outcome sealedToolOutcome
+	opened, err := s.cipher.open(toolJournalKey(taskID, toolCallID), []types.Part{*stored.Sealed}, &outcome)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt tool journal entry %s: %w", toolCallID, err)
+	}
+	if opened {
+		entry.Result, entry.Error = outcome.Result, outcome.Error
+	}
+	return &entry, nil
+}
+
+// DeleteToolJournal removes the journal entries of a task
+func (s *RedisStorage) DeleteToolJournal(ctx context.Context, taskID string) error {
+	keys, err := s.client.Keys(ctx, toolJournalPrefix+taskID+":*").Result()
+	if err != nil {
+		return fmt.Errorf("failed to list tool journal entries: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	if err := s.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to delete tool journal entries: %w", err)
+	}
+	return nil
+}
+
+// toolJournalKey returns the key of the journal entry of a tool call of a task
+func toolJournalKey(taskID, toolCallID string) string {
+	return toolJournalPrefix + taskID + ":" + toolCallID
+}
+
 // GetActiveTask retrieves an active task by ID
 func (s *RedisStorage) GetActiveTask(taskID string) (*types.Task, error) {
 	ctx := context.Background()
@@ -243,12 +629,12 @@ func (s *RedisStorage) GetActiveTask(taskID string) (*types.Task, error) {
 		return nil, fmt.Errorf("failed to get active task: %w", err)
 	}
 
-	var task types.Task
-	if err := json.Unmarshal([]byte(data), &task); err != nil {
+	task, err := s.decodeTask([]byte(data))
+	if err != nil {
 		return nil, fmt.Errorf("failed to deserialize active task: %w", err)
 	}
 
-	return &task, nil
+	return task, nil
 }
 
 // CreateActiveTask creates a new active task
@@ -268,7 +654,7 @@ func (s *RedisStorage) CreateActiveTask(task *types.Task) error {
 		return fmt.Errorf("active task already exists: %s", task.ID)
 	}
 
-	taskData, err := json.Marshal(task)
+	taskData, err := s.encodeTask(task)
 	if err != nil {
 		return fmt.Errorf("failed to serialize task: %w", err)
 	}
@@ -302,7 +688,7 @@ func (s *RedisStorage) UpdateActiveTask(task *types.Task) error {
 		return fmt.Errorf("active task not found: %s", task.ID)
 	}
 
-	taskData, err := json.Marshal(task)
+	taskData, err := s.encodeTask(task)
 	if err != nil {
 		return fmt.Errorf("failed to serialize task: %w", err)
 	}
@@ -330,7 +716,7 @@ func (s *RedisStorage) StoreDeadLetterTask(task *types.Task) error {
 	contextKey := contextTasksPrefix + task.ContextID
 	activeKey := activeTaskKeyPrefix + task.ID
 
-	taskData, err := json.Marshal(task)
+	taskData, err := s.encodeTask(task)
 	if err != nil {
 		return fmt.Errorf("failed to serialize task: %w", err)
 	}
@@ -369,13 +755,13 @@ func (s *RedisStorage) GetTask(taskID string) (*types.Task, bool) {
 		return nil, false
 	}
 
-	var task types.Task
-	if err := json.Unmarshal([]byte(data), &task); err != nil {
+	task, err := s.decodeTask([]byte(data))
+	if err != nil {
 		s.logger.Error("failed to deserialize dead letter task", zap.String("task_id", taskID), zap.Error(err))
 		return nil, false
 	}
 
-	return &task, true
+	return task, true
 }
 
 // GetTaskByContextAndID retrieves a task by context ID and task ID
@@ -521,7 +907,7 @@ func (s *RedisStorage) matchesFilter(task *types.Task, filter TaskFilter) bool {
 		return false
 	}
 
-	return true
+	return hasTaskLabels(task, filter.Labels) && hasTaskUser(task, filter.UserID)
 }
 
 // sortTasks sorts tasks based on the specified field and order
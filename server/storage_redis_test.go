@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	redis "github.com/redis/go-redis/v9"
 	assert "github.com/stretchr/testify/assert"
@@ -28,6 +29,7 @@ const (
 	testDeadLetterKeyPrefix = "a2a:deadletter:"
 	testContextTasksPrefix  = "a2a:context:"
 	testQueueNotifyChannel  = "a2a:queue:notify"
+	testToolJournalPrefix   = "a2a:tooljournal:"
 )
 
 func newTestRedisStorage(t *testing.T) (*server.RedisStorage, *mocks.FakeRedisClient, *mocks.FakeRedisPipeliner) {
@@ -173,6 +175,30 @@ func TestRedisStorageGetQueueLength(t *testing.T) {
 	assert.Equal(t, testTaskQueueKey, key)
 }
 
+func TestRedisStoragePeekQueue(t *testing.T) {
+	storage, fakeClient, _ := newTestRedisStorage(t)
+
+	var entries []string
+	for _, id := range []string{"newest", "middle", "oldest"} {
+		data, err := json.Marshal(server.QueuedTask{Task: &types.Task{ID: id, ContextID: "test-context"}})
+		require.NoError(t, err)
+		entries = append(entries, string(data))
+	}
+	fakeClient.LRangeReturns(redis.NewStringSliceResult(entries, nil))
+
+	queued, err := storage.PeekQueue(context.Background(), 3)
+	require.NoError(t, err)
+	require.Len(t, queued, 3)
+	assert.Equal(t, "oldest", queued[0].Task.ID)
+	assert.Equal(t, "newest", queued[2].Task.ID)
+
+	require.Equal(t, 1, fakeClient.LRangeCallCount())
+	_, key, start, stop := fakeClient.LRangeArgsForCall(0)
+	assert.Equal(t, testTaskQueueKey, key)
+	assert.Equal(t, int64(-3), start)
+	assert.Equal(t, int64(-1), stop)
+}
+
 func TestRedisStorageClearQueue(t *testing.T) {
 	storage, fakeClient, _ := newTestRedisStorage(t)
 	fakeClient.LLenReturns(redis.NewIntResult(3, nil))
@@ -566,3 +592,83 @@ func TestRedisStorageGetStats(t *testing.T) {
 	assert.Equal(t, 2, stats.TotalMessages)
 	assert.Equal(t, float64(1), stats.AverageMessagesPerContext)
 }
+
+func TestRedisStorageEncryption(t *testing.T) {
+	storage, fakeClient, _ := newTestRedisStorage(t)
+	cipher, err := server.NewTaskCipher([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	storage.SetTaskCipher(cipher)
+
+	stored := map[string]string{}
+	fakeClient.ExistsReturns(redis.NewIntResult(0, nil))
+	fakeClient.SetStub = func(_ context.Context, key string, value any, _ time.Duration) *redis.StatusCmd {
+		stored[key] = string(value.([]byte))
+		return redis.NewStatusResult("OK", nil)
+	}
+	fakeClient.GetStub = func(_ context.Context, key string) *redis.StringCmd {
+		if data, ok := stored[key]; ok {
+			return redis.NewStringResult(data, nil)
+		}
+		return redis.NewStringResult("", redis.Nil)
+	}
+
+	task := &types.Task{
+		ID:        "t1",
+		ContextID: "c1",
+		Status:    types.TaskStatus{State: types.TaskStateWorking},
+		History:   []types.Message{{Role: types.RoleUser, MessageID: "m1", Parts: []types.Part{types.CreateTextPart("my password is hunter2")}}},
+	}
+	require.NoError(t, storage.CreateActiveTask(task))
+	assert.NotContains(t, stored[testActiveTaskKeyPrefix+"t1"], "hunter2")
+	assert.Equal(t, "my password is hunter2", *task.History[0].Parts[0].Text, "the stored task is left unchanged")
+
+	got, err := storage.GetActiveTask("t1")
+	require.NoError(t, err)
+	assert.Equal(t, "my password is hunter2", *got.History[0].Parts[0].Text)
+
+	plain, err := json.Marshal(&types.Task{
+		ID:        "t2",
+		ContextID: "c1",
+		Status:    types.TaskStatus{State: types.TaskStateCompleted},
+		History:   []types.Message{{Role: types.RoleUser, MessageID: "m2", Parts: []types.Part{types.CreateTextPart("stored before encryption")}}},
+	})
+	require.NoError(t, err)
+	stored[testDeadLetterKeyPrefix+"t2"] = string(plain)
+	fakeClient.KeysReturns(redis.NewStringSliceResult([]string{testDeadLetterKeyPrefix + "t2"}, nil))
+
+	count, err := storage.ReencryptTasks(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.NotContains(t, stored[testDeadLetterKeyPrefix+"t2"], "stored before encryption")
+	finished, ok := storage.GetTask("t2")
+	require.True(t, ok)
+	assert.Equal(t, "stored before encryption", *finished.History[0].Parts[0].Text)
+
+	count, err = storage.ReencryptTasks(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, count, "tasks sealed with the current key are left alone")
+
+	entry := &server.ToolJournalEntry{
+		TaskID:     "t1",
+		ToolCallID: "call-1",
+		ToolName:   "charge_card",
+		Status:     server.ToolJournalStatusFailed,
+		Result:     "charged card 4242 4242 4242 4242",
+		Error:      "receipt for alice@example.com bounced",
+	}
+	require.NoError(t, storage.RecordToolExecution(context.Background(), entry))
+	journalKey := testToolJournalPrefix + "t1:call-1"
+	require.Contains(t, stored, journalKey)
+	assert.NotContains(t, stored[journalKey], "4242")
+	assert.NotContains(t, stored[journalKey], "alice@example.com")
+	assert.Contains(t, stored[journalKey], "charge_card", "the tool name stays readable")
+
+	recorded, found, err := storage.GetToolExecution(context.Background(), "t1", "call-1")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, entry, recorded)
+
+	stored[testToolJournalPrefix+"t1:call-2"] = stored[journalKey]
+	_, _, err = storage.GetToolExecution(context.Background(), "t1", "call-2")
+	assert.Error(t, err, "sealed entries are bound to their tool call")
+}
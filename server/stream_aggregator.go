@@ -0,0 +1,173 @@
+package server
+
+import (
+	"strings"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	uuid "github.com/google/uuid"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// StreamAggregator assembles the assistant messages of an agent event stream. Delta events
+// are accumulated until the iteration they belong to completes, so each iteration yields one
+// consolidated message rather than one message per delta. When a stream ends without an
+// iteration-completed event, as custom agents may stream, the pending deltas are consolidated
+// into a message of their own, so a stream that produced output always has a final message.
+//
+// Add returns a consolidated message exactly once; handlers append the messages it returns to
+// the task history. A StreamAggregator is not safe for concurrent use.
+type StreamAggregator struct {
+	pending   *types.Message
+	text      strings.Builder
+	toolCalls []types.Part
+	final     *types.Message
+	seen      map[string]bool
+}
+
+// NewStreamAggregator creates an aggregator for one agent stream
+func NewStreamAggregator() *StreamAggregator {
+	return &StreamAggregator{seen: make(map[string]bool)}
+}
+
+// Add consumes an event of the agent stream. Deltas are accumulated, and the message of an
+// iteration-completed event or of a completed task status is returned when it has not been
+// returned before. Other events are ignored and return nil.
+func (a *StreamAggregator) Add(event cloudevents.Event) *types.Message {
+	switch event.Type() {
+	case types.EventDelta:
+		var delta types.Message
+		if err := event.DataAs(&delta); err == nil {
+			a.AddDelta(delta)
+		}
+	case types.EventIterationCompleted:
+		var message types.Message
+		if err := event.DataAs(&message); err == nil {
+			return a.CompleteIteration(message)
+		}
+	case types.EventTaskStatusChanged:
+		var status types.TaskStatus
+		if err := event.DataAs(&status); err == nil && status.State == types.TaskStateCompleted {
+			return a.Complete(status.Message)
+		}
+	}
+	return nil
+}
+
+// AddDelta accumulates the text and tool call parts of a streamed delta. A tool call part
+// replaces an earlier part for the same tool call ID, so agents may stream tool calls as they
+// are built up.
+func (a *StreamAggregator) AddDelta(delta types.Message) {
+	if a.pending == nil {
+		a.pending = &types.Message{
+			MessageID: delta.MessageID,
+			Role:      types.RoleAgent,
+			TaskID:    delta.TaskID,
+			ContextID: delta.ContextID,
+		}
+	}
+
+	for _, part := range delta.Parts {
+		switch {
+		case part.Text != nil:
+			a.text.WriteString(*part.Text)
+		case part.Data != nil && (part.Data.Data["tool_call"] != nil || part.Data.Data["tool_calls"] != nil):
+			a.addToolCall(part)
+		}
+	}
+}
+
+// addToolCall adds a tool call part, replacing the part of the same tool call
+func (a *StreamAggregator) addToolCall(part types.Part) {
+	if id := toolCallPartID(part); id != "" {
+		for i := range a.toolCalls {
+			if toolCallPartID(a.toolCalls[i]) == id {
+				a.toolCalls[i] = part
+				return
+			}
+		}
+	}
+	a.toolCalls = append(a.toolCalls, part)
+}
+
+// toolCallPartID returns the ID of the tool call in a part created by types.NewToolCallPart
+func toolCallPartID(part types.Part) string {
+	toolCall, _ := part.Data.Data["tool_call"].(map[string]any)
+	id, _ := toolCall["id"].(string)
+	return id
+}
+
+// CompleteIteration consolidates an iteration into the message the agent completed it with,
+// which supersedes the deltas streamed during the iteration. It returns the message, or nil
+// when a message with the same ID was returned before.
+func (a *StreamAggregator) CompleteIteration(message types.Message) *types.Message {
+	a.reset()
+	return a.consolidate(message)
+}
+
+// Complete consolidates the end of the stream with the message of the completed task status.
+// Without a status message the pending deltas are consolidated instead. It returns the
+// message, or nil when there is none or it was returned before.
+func (a *StreamAggregator) Complete(message *types.Message) *types.Message {
+	if message == nil {
+		return a.Flush()
+	}
+	a.reset()
+	return a.consolidate(*message)
+}
+
+// Flush consolidates the deltas streamed since the last completed iteration into a message,
+// which it returns, or nil when no deltas are pending
+func (a *StreamAggregator) Flush() *types.Message {
+	if a.pending == nil {
+		return nil
+	}
+
+	message := *a.pending
+	if message.MessageID == "" {
+		message.MessageID = uuid.New().String()
+	}
+	if a.text.Len() > 0 {
+		message.Parts = append(message.Parts, types.CreateTextPart(a.text.String()))
+	}
+	message.Parts = append(message.Parts, a.toolCalls...)
+	a.reset()
+
+	if len(message.Parts) == 0 {
+		return nil
+	}
+	return a.consolidate(message)
+}
+
+// Text returns the text streamed since the last completed iteration
+func (a *StreamAggregator) Text() string {
+	return a.text.String()
+}
+
+// Final consolidates any pending deltas and returns the final assistant message of the stream,
+// or nil when the agent produced none
+func (a *StreamAggregator) Final() *types.Message {
+	a.Flush()
+	return a.final
+}
+
+// consolidate records a message as the latest of the stream and returns it unless a message
+// with the same ID was returned before
+func (a *StreamAggregator) consolidate(message types.Message) *types.Message {
+	a.final = &message
+	if message.MessageID == "" {
+		return &message
+	}
+	if a.seen[message.MessageID] {
+		return nil
+	}
+	a.seen[message.MessageID] = true
+	return &message
+}
+
+// reset drops the pending deltas
+func (a *StreamAggregator) reset() {
+	a.pending = nil
+	a.text.Reset()
+	a.toolCalls = nil
+}
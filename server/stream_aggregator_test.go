@@ -0,0 +1,129 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func deltaEvent(messageID string, parts ...types.Part) cloudevents.Event {
+	return types.NewDeltaEvent(&types.Message{MessageID: messageID, Role: types.RoleAgent, Parts: parts})
+}
+
+func completedStatusEvent(t *testing.T, message *types.Message) cloudevents.Event {
+	t.Helper()
+	event := cloudevents.NewEvent()
+	event.SetType(types.EventTaskStatusChanged)
+	require.NoError(t, event.SetData(cloudevents.ApplicationJSON, types.TaskStatus{State: types.TaskStateCompleted, Message: message}))
+	return event
+}
+
+func TestStreamAggregator(t *testing.T) {
+	t.Run("consolidates deltas with tool calls", func(t *testing.T) {
+		aggregator := NewStreamAggregator()
+		assert.Nil(t, aggregator.Add(deltaEvent("d1", types.CreateTextPart("Let me "))))
+		assert.Nil(t, aggregator.Add(deltaEvent("d1", types.CreateTextPart("check."))))
+		assert.Nil(t, aggregator.Add(deltaEvent("d1", types.NewToolCallPart("call-1", "get_weather", nil))))
+		assert.Nil(t, aggregator.Add(deltaEvent("d1", types.NewToolCallPart("call-1", "get_weather", map[string]any{"city": "Paris"}))))
+		assert.Equal(t, "Let me check.", aggregator.Text())
+
+		message := aggregator.Flush()
+		require.NotNil(t, message)
+		assert.Equal(t, "d1", message.MessageID)
+		assert.Equal(t, types.RoleAgent, message.Role)
+		require.Len(t, message.Parts, 2)
+		assert.Equal(t, "Let me check.", *message.Parts[0].Text)
+		assert.Equal(t, map[string]any{"city": "Paris"}, message.Parts[1].Data.Data["tool_call"].(map[string]any)["arguments"])
+		assert.Empty(t, aggregator.Text())
+		assert.Nil(t, aggregator.Flush(), "no deltas are pending")
+	})
+
+	t.Run("an iteration supersedes its deltas", func(t *testing.T) {
+		aggregator := NewStreamAggregator()
+		answer := &types.Message{MessageID: "answer", Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart("Sunny")}}
+		aggregator.Add(deltaEvent("answer", types.CreateTextPart("Sun")))
+
+		message := aggregator.Add(types.NewIterationCompletedEvent(1, "task-1", answer))
+		require.NotNil(t, message)
+		assert.Equal(t, *answer, *message)
+		assert.Nil(t, aggregator.Add(completedStatusEvent(t, answer)), "the completed message was already returned")
+		assert.Nil(t, aggregator.Flush())
+		assert.Equal(t, answer, aggregator.Final())
+	})
+
+	t.Run("completes without a status message", func(t *testing.T) {
+		aggregator := NewStreamAggregator()
+		aggregator.Add(deltaEvent("", types.CreateTextPart("Done")))
+
+		message := aggregator.Add(completedStatusEvent(t, nil))
+		require.NotNil(t, message)
+		assert.NotEmpty(t, message.MessageID)
+		assert.Equal(t, "Done", *message.Parts[0].Text)
+		assert.Equal(t, message, aggregator.Final())
+	})
+
+	t.Run("nothing streamed", func(t *testing.T) {
+		aggregator := NewStreamAggregator()
+		assert.Nil(t, aggregator.Add(completedStatusEvent(t, nil)))
+		assert.Nil(t, aggregator.Final())
+	})
+}
+
+func TestA2AServer_StreamAggregation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{CapabilitiesConfig: config.CapabilitiesConfig{Streaming: true}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "assistant"})
+	s.SetStreamingTaskHandler(&eventSequenceHandler{events: []cloudevents.Event{
+		deltaEvent("answer", types.CreateTextPart("It is ")),
+		deltaEvent("answer", types.CreateTextPart("sunny")),
+		completedStatusEvent(t, nil),
+	}})
+	router := s.setupRouter(cfg)
+
+	body := `{"jsonrpc":"2.0","id":"1","method":"message/stream","params":{"message":{"messageId":"m1","role":"user","parts":[{"kind":"text","text":"Weather?"}]}}}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+
+	var taskID string
+	for _, chunk := range strings.Split(w.Body.String(), "data: ") {
+		chunk = strings.TrimSpace(chunk)
+		if chunk == "" || chunk == "[DONE]" {
+			continue
+		}
+		var response struct {
+			Result struct {
+				TaskID string `json:"taskId"`
+			} `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(chunk), &response))
+		if response.Result.TaskID != "" {
+			taskID = response.Result.TaskID
+		}
+	}
+	require.NotEmpty(t, taskID)
+
+	task, ok := s.taskManager.GetTask(taskID)
+	require.True(t, ok)
+	var agentMessages []types.Message
+	for _, message := range task.History {
+		if message.Role == types.RoleAgent {
+			agentMessages = append(agentMessages, message)
+		}
+	}
+	require.Len(t, agentMessages, 1, "the deltas are written to history as one message")
+	assert.Equal(t, "It is sunny", *agentMessages[0].Parts[0].Text)
+	require.NotNil(t, task.Status.Message)
+	assert.Equal(t, "answer", task.Status.Message.MessageID)
+}
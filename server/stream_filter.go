@@ -0,0 +1,65 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// streamEventFilter holds the event kinds a message/stream client subscribed to
+type streamEventFilter map[string]bool
+
+// defaultStreamEvents are the event kinds streamed when a client does not choose any
+var defaultStreamEvents = streamEventFilter{
+	types.StreamEventDelta:    true,
+	types.StreamEventStatus:   true,
+	types.StreamEventArtifact: true,
+}
+
+// parseStreamEventFilter reads the event kinds listed under types.StreamEventsMetadataKey in
+// the configuration metadata, as a list or a comma-separated string. Without a list the
+// default event kinds are streamed.
+func parseStreamEventFilter(configuration *types.MessageSendConfiguration) (streamEventFilter, error) {
+	if configuration == nil || configuration.Metadata == nil {
+		return defaultStreamEvents, nil
+	}
+	raw, exists := configuration.Metadata[types.StreamEventsMetadataKey]
+	if !exists || raw == nil {
+		return defaultStreamEvents, nil
+	}
+
+	var kinds []string
+	switch value := raw.(type) {
+	case string:
+		kinds = strings.Split(value, ",")
+	case []any:
+		for _, item := range value {
+			kind, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must list event kinds as strings", types.StreamEventsMetadataKey)
+			}
+			kinds = append(kinds, kind)
+		}
+	default:
+		return nil, fmt.Errorf("%s must be a list of event kinds", types.StreamEventsMetadataKey)
+	}
+
+	filter := make(streamEventFilter, len(kinds))
+	for _, kind := range kinds {
+		kind = strings.TrimSpace(kind)
+		switch kind {
+		case types.StreamEventDelta, types.StreamEventStatus, types.StreamEventArtifact, types.StreamEventTool:
+			filter[kind] = true
+		case "":
+		default:
+			return nil, fmt.Errorf("unknown stream event kind '%s': must be delta, status, artifact or tool", kind)
+		}
+	}
+	return filter, nil
+}
+
+// allows reports whether events of the kind are streamed to the client
+func (f streamEventFilter) allows(kind string) bool {
+	return f[kind]
+}
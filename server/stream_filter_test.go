@@ -0,0 +1,176 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// eventSequenceHandler streams a fixed sequence of agent events
+type eventSequenceHandler struct {
+	events []cloudevents.Event
+}
+
+func (h *eventSequenceHandler) HandleStreamingTask(ctx context.Context, task *types.Task, message *types.Message) (<-chan cloudevents.Event, error) {
+	events := make(chan cloudevents.Event, len(h.events))
+	for _, event := range h.events {
+		events <- event
+	}
+	close(events)
+	return events, nil
+}
+
+func (h *eventSequenceHandler) SetAgent(OpenAICompatibleAgent) {}
+
+func (h *eventSequenceHandler) GetAgent() OpenAICompatibleAgent { return nil }
+
+func TestParseStreamEventFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]any
+		expected streamEventFilter
+		errText  string
+	}{
+		{
+			name:     "defaults without metadata",
+			expected: defaultStreamEvents,
+		},
+		{
+			name:     "list of kinds",
+			metadata: map[string]any{types.StreamEventsMetadataKey: []any{"status", "tool"}},
+			expected: streamEventFilter{types.StreamEventStatus: true, types.StreamEventTool: true},
+		},
+		{
+			name:     "comma-separated kinds",
+			metadata: map[string]any{types.StreamEventsMetadataKey: "delta, artifact"},
+			expected: streamEventFilter{types.StreamEventDelta: true, types.StreamEventArtifact: true},
+		},
+		{
+			name:     "empty list streams only the final status",
+			metadata: map[string]any{types.StreamEventsMetadataKey: []any{}},
+			expected: streamEventFilter{},
+		},
+		{
+			name:     "unknown kind",
+			metadata: map[string]any{types.StreamEventsMetadataKey: []any{"status", "reasoning"}},
+			errText:  "unknown stream event kind 'reasoning'",
+		},
+		{
+			name:     "wrong type",
+			metadata: map[string]any{types.StreamEventsMetadataKey: 3},
+			errText:  "streamEvents must be a list of event kinds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseStreamEventFilter(&types.MessageSendConfiguration{Metadata: tt.metadata})
+			if tt.errText != "" {
+				assert.ErrorContains(t, err, tt.errText)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, filter)
+		})
+	}
+}
+
+func TestA2AServer_StreamEventFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{CapabilitiesConfig: config.CapabilitiesConfig{Streaming: true}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "agent"})
+
+	answer := &types.Message{MessageID: "answer", Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart("done")}}
+	working := cloudevents.NewEvent()
+	working.SetType(types.EventTaskStatusChanged)
+	require.NoError(t, working.SetData(cloudevents.ApplicationJSON, types.TaskStatus{State: types.TaskStateWorking}))
+	completed := cloudevents.NewEvent()
+	completed.SetType(types.EventTaskStatusChanged)
+	require.NoError(t, completed.SetData(cloudevents.ApplicationJSON, types.TaskStatus{State: types.TaskStateCompleted, Message: answer}))
+
+	s.SetStreamingTaskHandler(&eventSequenceHandler{events: []cloudevents.Event{
+		working,
+		types.NewDeltaEvent(&types.Message{MessageID: "delta-1", Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart("do")}}),
+		types.NewMessageEvent(types.EventToolStarted, "tool-start-1", types.NewStreamingStatusMessage("tool-start-1", "started", map[string]any{"tool_name": "lookup"})),
+		types.NewMessageEvent(types.EventToolCompleted, "tool-completed-1", types.NewStreamingStatusMessage("tool-completed-1", "completed", nil)),
+		types.NewArtifactUpdateEvent(types.Artifact{ArtifactID: "artifact-1", Parts: []types.Part{types.CreateTextPart("report")}}),
+		types.NewIterationCompletedEvent(1, "streaming-task", answer),
+		completed,
+	}})
+	router := s.setupRouter(cfg)
+
+	// stream returns the kinds of the events the client received, classified by their shape
+	stream := func(configuration map[string]any) []string {
+		params := map[string]any{
+			"message": map[string]any{
+				"kind":      "message",
+				"messageId": "msg-1",
+				"role":      "user",
+				"parts":     []map[string]any{{"kind": "text", "text": "hello"}},
+			},
+		}
+		if configuration != nil {
+			params["configuration"] = map[string]any{"metadata": configuration}
+		}
+		body, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": "req-1", "method": "message/stream", "params": params})
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", bytes.NewReader(body)))
+
+		var kinds []string
+		for _, chunk := range strings.Split(w.Body.String(), "data: ") {
+			chunk = strings.TrimSpace(chunk)
+			if chunk == "" || chunk == "[DONE]" {
+				continue
+			}
+			var response struct {
+				Result map[string]any `json:"result"`
+				Error  map[string]any `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(chunk), &response))
+			result := response.Result
+			switch {
+			case response.Error != nil:
+				kinds = append(kinds, "error")
+			case result["artifact"] != nil:
+				kinds = append(kinds, types.StreamEventArtifact)
+			case result["metadata"] != nil && result["metadata"].(map[string]any)[types.StreamEventTypeMetadataKey] != nil:
+				kinds = append(kinds, types.StreamEventTool)
+			case result["final"] == true:
+				kinds = append(kinds, "final")
+			case result["final"] == false:
+				kinds = append(kinds, types.StreamEventStatus)
+			default:
+				kinds = append(kinds, types.StreamEventDelta)
+			}
+		}
+		return kinds
+	}
+
+	assert.Equal(t, []string{"status", "delta", "artifact", "final"}, stream(nil))
+	assert.Equal(t, []string{"tool", "tool", "final"}, stream(map[string]any{types.StreamEventsMetadataKey: []string{"tool"}}))
+	assert.Equal(t, []string{"final"}, stream(map[string]any{types.StreamEventsMetadataKey: []string{}}))
+
+	body, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": "req-2", "method": "message/stream", "params": map[string]any{
+		"message":       map[string]any{"kind": "message", "messageId": "msg-2", "role": "user", "parts": []map[string]any{{"kind": "text", "text": "hi"}}},
+		"configuration": map[string]any{"metadata": map[string]any{types.StreamEventsMetadataKey: []string{"everything"}}},
+	}})
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", bytes.NewReader(body)))
+	assert.Contains(t, w.Body.String(), "unknown stream event kind 'everything'")
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// streamHeartbeat fires when a stream has sent nothing for the interval, so a heartbeat keeps
+// proxies and load balancers from closing the idle connection during long tool executions
+type streamHeartbeat struct {
+	interval time.Duration
+	timer    *time.Timer
+}
+
+// newStreamHeartbeat returns the heartbeat of a stream, or nil when the interval disables it
+func newStreamHeartbeat(interval time.Duration) *streamHeartbeat {
+	if interval <= 0 {
+		return nil
+	}
+	return &streamHeartbeat{interval: interval, timer: time.NewTimer(interval)}
+}
+
+// C returns the channel the heartbeat fires on, which never fires when it is disabled
+func (h *streamHeartbeat) C() <-chan time.Time {
+	if h == nil {
+		return nil
+	}
+	return h.timer.C
+}
+
+// reset restarts the idle time after the stream sent an event
+func (h *streamHeartbeat) reset() {
+	if h == nil {
+		return
+	}
+	h.timer.Reset(h.interval)
+}
+
+// stop releases the timer once the stream ended
+func (h *streamHeartbeat) stop() {
+	if h == nil {
+		return
+	}
+	h.timer.Stop()
+}
+
+// writeHeartbeat streams a non-final status event with the current state of the task, marked
+// as a heartbeat. Heartbeats are sent whatever the event filter of the stream, since they keep
+// the connection open.
+func (h *DefaultA2AProtocolHandler) writeHeartbeat(c *gin.Context, id any, task *types.Task) error {
+	now := h.clock.Now()
+	metadata := types.Struct{types.StreamHeartbeatMetadataKey: true}
+	response := types.JSONRPCSuccessResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Result: types.TaskStatusUpdateEvent{
+			TaskID:    task.ID,
+			ContextID: task.ContextID,
+			Status: types.TaskStatus{
+				State:     task.Status.State,
+				Timestamp: &now,
+			},
+			Metadata: &metadata,
+		},
+	}
+	return h.writeStreamingResponse(c, &response)
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestA2AServer_StreamHeartbeats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		CapabilitiesConfig:            config.CapabilitiesConfig{Streaming: true},
+		StreamingStatusUpdateInterval: 20 * time.Millisecond,
+	}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "agent"})
+	s.SetStreamingTaskHandler(&scriptedStreamHandler{finishAfter: 150 * time.Millisecond})
+	httpServer := httptest.NewServer(s.setupRouter(cfg))
+	t.Cleanup(httpServer.Close)
+
+	stream := openDrainTestStream(t, httpServer)
+	var heartbeats []types.TaskStatusUpdateEvent
+	for _, status := range collectStatusEvents(t, stream) {
+		if status.Metadata != nil && (*status.Metadata)[types.StreamHeartbeatMetadataKey] == true {
+			heartbeats = append(heartbeats, status)
+		}
+	}
+
+	require.GreaterOrEqual(t, len(heartbeats), 2, "heartbeats are sent while the task produces no events")
+	for _, heartbeat := range heartbeats {
+		assert.False(t, heartbeat.Final)
+		assert.Equal(t, types.TaskStateWorking, heartbeat.Status.State)
+		assert.Nil(t, heartbeat.Status.Message)
+		assert.NotNil(t, heartbeat.Status.Timestamp)
+	}
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"maps"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// streamMessage is a message delivered on a stream, tagged with its kind
+type streamMessage struct {
+	Kind string `json:"kind"`
+	types.Message
+}
+
+// streamStatus is a task status delivered on a stream, with its message tagged
+type streamStatus struct {
+	types.TaskStatus
+	Message *streamMessage `json:"message,omitempty"`
+}
+
+// streamTask is a task snapshot delivered on a stream, tagged with its kind
+type streamTask struct {
+	Kind string `json:"kind"`
+	types.Task
+	History []streamMessage `json:"history,omitempty"`
+	Status  streamStatus    `json:"status"`
+}
+
+// streamStatusUpdate is a status update delivered on a stream, tagged with its kind
+type streamStatusUpdate struct {
+	Kind string `json:"kind"`
+	types.TaskStatusUpdateEvent
+	Status streamStatus `json:"status"`
+}
+
+// streamArtifactUpdate is an artifact update delivered on a stream, tagged with its kind
+type streamArtifactUpdate struct {
+	Kind string `json:"kind"`
+	types.TaskArtifactUpdateEvent
+}
+
+// translateStreamResult converts a result written to a stream into the spec-compliant object
+// of its kind: a task, message, status-update or artifact-update carrying a "kind" field.
+// Messages of a task or status update get the task and context IDs when they lack them.
+// Maps are tagged with the kind their fields imply, other results are written unchanged.
+func translateStreamResult(result any) any {
+	switch r := result.(type) {
+	case types.Task:
+		return newStreamTask(&r)
+	case *types.Task:
+		if r != nil {
+			return newStreamTask(r)
+		}
+	case types.Message:
+		return newStreamMessage(r, "", "")
+	case *types.Message:
+		if r != nil {
+			return newStreamMessage(*r, "", "")
+		}
+	case types.TaskStatusUpdateEvent:
+		return newStreamStatusUpdate(&r)
+	case *types.TaskStatusUpdateEvent:
+		if r != nil {
+			return newStreamStatusUpdate(r)
+		}
+	case types.TaskArtifactUpdateEvent:
+		return streamArtifactUpdate{Kind: types.StreamResultKindArtifactUpdate, TaskArtifactUpdateEvent: r}
+	case *types.TaskArtifactUpdateEvent:
+		if r != nil {
+			return streamArtifactUpdate{Kind: types.StreamResultKindArtifactUpdate, TaskArtifactUpdateEvent: *r}
+		}
+	case map[string]any:
+		return tagStreamMap(r)
+	}
+	return result
+}
+
+// newStreamMessage tags a message, setting the task and context IDs it lacks
+func newStreamMessage(message types.Message, taskID, contextID string) streamMessage {
+	if message.TaskID == nil && taskID != "" {
+		message.TaskID = &taskID
+	}
+	if message.ContextID == nil && contextID != "" {
+		message.ContextID = &contextID
+	}
+	return streamMessage{Kind: types.StreamResultKindMessage, Message: message}
+}
+
+// newStreamStatus tags the message of a task status
+func newStreamStatus(status types.TaskStatus, taskID, contextID string) streamStatus {
+	translated := streamStatus{TaskStatus: status}
+	if status.Message != nil {
+		message := newStreamMessage(*status.Message, taskID, contextID)
+		translated.Message = &message
+	}
+	return translated
+}
+
+// newStreamTask tags a task snapshot and the messages it holds
+func newStreamTask(task *types.Task) streamTask {
+	translated := streamTask{
+		Kind:   types.StreamResultKindTask,
+		Task:   *task,
+		Status: newStreamStatus(task.Status, task.ID, task.ContextID),
+	}
+	if len(task.History) > 0 {
+		translated.History = make([]streamMessage, 0, len(task.History))
+		for _, message := range task.History {
+			translated.History = append(translated.History, newStreamMessage(message, task.ID, task.ContextID))
+		}
+	}
+	return translated
+}
+
+// newStreamStatusUpdate tags a status update and its message
+func newStreamStatusUpdate(update *types.TaskStatusUpdateEvent) streamStatusUpdate {
+	return streamStatusUpdate{
+		Kind:                  types.StreamResultKindStatusUpdate,
+		TaskStatusUpdateEvent: *update,
+		Status:                newStreamStatus(update.Status, update.TaskID, update.ContextID),
+	}
+}
+
+// tagStreamMap adds the kind implied by its fields to a map without one
+func tagStreamMap(result map[string]any) map[string]any {
+	if _, tagged := result["kind"]; tagged {
+		return result
+	}
+
+	var kind string
+	switch {
+	case result["artifact"] != nil:
+		kind = types.StreamResultKindArtifactUpdate
+	case result["status"] != nil && result["taskId"] != nil:
+		kind = types.StreamResultKindStatusUpdate
+	case result["status"] != nil && result["id"] != nil:
+		kind = types.StreamResultKindTask
+	case result["messageId"] != nil:
+		kind = types.StreamResultKindMessage
+	default:
+		return result
+	}
+
+	tagged := maps.Clone(result)
+	tagged["kind"] = kind
+	return tagged
+}
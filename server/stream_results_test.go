@@ -0,0 +1,91 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestTranslateStreamResult(t *testing.T) {
+	agentMessage := types.Message{MessageID: "msg-2", Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart("hi")}}
+	lastChunk := true
+
+	tests := []struct {
+		name     string
+		result   any
+		expected string
+	}{
+		{
+			name: "task snapshot",
+			result: types.Task{
+				ID:        "task-1",
+				ContextID: "ctx-1",
+				History:   []types.Message{{MessageID: "msg-1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("hello")}}},
+				Status:    types.TaskStatus{State: types.TaskStateWorking, Message: &agentMessage},
+			},
+			expected: `{
+				"kind": "task", "id": "task-1", "contextId": "ctx-1",
+				"history": [{"kind": "message", "messageId": "msg-1", "role": "ROLE_USER", "taskId": "task-1", "contextId": "ctx-1", "parts": [{"text": "hello"}]}],
+				"status": {"state": "TASK_STATE_WORKING", "message": {"kind": "message", "messageId": "msg-2", "role": "ROLE_AGENT", "taskId": "task-1", "contextId": "ctx-1", "parts": [{"text": "hi"}]}}
+			}`,
+		},
+		{
+			name: "status update",
+			result: types.TaskStatusUpdateEvent{
+				TaskID:    "task-1",
+				ContextID: "ctx-1",
+				Status:    types.TaskStatus{State: types.TaskStateCompleted, Message: &agentMessage},
+				Final:     true,
+			},
+			expected: `{
+				"kind": "status-update", "taskId": "task-1", "contextId": "ctx-1", "final": true,
+				"status": {"state": "TASK_STATE_COMPLETED", "message": {"kind": "message", "messageId": "msg-2", "role": "ROLE_AGENT", "taskId": "task-1", "contextId": "ctx-1", "parts": [{"text": "hi"}]}}
+			}`,
+		},
+		{
+			name: "artifact update",
+			result: &types.TaskArtifactUpdateEvent{
+				TaskID:    "task-1",
+				ContextID: "ctx-1",
+				Artifact:  types.Artifact{ArtifactID: "artifact-1", Parts: []types.Part{types.CreateTextPart("report")}},
+				LastChunk: &lastChunk,
+			},
+			expected: `{
+				"kind": "artifact-update", "taskId": "task-1", "contextId": "ctx-1", "lastChunk": true,
+				"artifact": {"artifactId": "artifact-1", "parts": [{"text": "report"}]}
+			}`,
+		},
+		{
+			name:     "message",
+			result:   agentMessage,
+			expected: `{"kind": "message", "messageId": "msg-2", "role": "ROLE_AGENT", "parts": [{"text": "hi"}]}`,
+		},
+		{
+			name:     "map shaped like a status update",
+			result:   map[string]any{"taskId": "task-1", "contextId": "ctx-1", "status": map[string]any{"state": "TASK_STATE_WORKING"}},
+			expected: `{"kind": "status-update", "taskId": "task-1", "contextId": "ctx-1", "status": {"state": "TASK_STATE_WORKING"}}`,
+		},
+		{
+			name:     "map that already has a kind",
+			result:   map[string]any{"kind": "custom", "taskId": "task-1", "status": "working"},
+			expected: `{"kind": "custom", "taskId": "task-1", "status": "working"}`,
+		},
+		{
+			name:     "map of unknown shape",
+			result:   map[string]any{"progress": 0.5},
+			expected: `{"progress": 0.5}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(translateStreamResult(tt.result))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(data))
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+	errgroup "golang.org/x/sync/errgroup"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+// defaultShutdownTimeout bounds graceful shutdown when no timeout is configured
+const defaultShutdownTimeout = 10 * time.Second
+
+// Suite runs the A2A server together with its companion servers under one lifecycle.
+// The A2A server also serves the health endpoint and, when Prometheus export is enabled,
+// the metrics endpoint, so starting and stopping it covers those as well.
+//
+// Example:
+//
+//	suite, err := server.NewSuite(cfg, logger)
+//	a2aServer, err := suite.A2AServerBuilder().WithAgentCard(card).WithDefaultTaskHandlers().Build()
+//	err = suite.WithA2AServer(a2aServer).Run(ctx)
+type Suite struct {
+	cfg             *config.Config
+	logger          *zap.Logger
+	a2aServer       A2AServer
+	artifactsServer ArtifactsServer
+	artifactService ArtifactService
+	secretsProvider SecretsProvider
+	stopOnce        sync.Once
+	stopErr         error
+}
+
+// NewSuite creates a suite for the given configuration. When artifacts are enabled the
+// artifact service and artifacts server are constructed immediately so they can be shared
+// with the A2A server through A2AServerBuilder. When a secrets provider is configured the
+// secret references of the configuration are resolved first.
+func NewSuite(cfg *config.Config, logger *zap.Logger) (*Suite, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	suite := &Suite{
+		cfg:    cfg,
+		logger: logger,
+	}
+
+	secretsProvider, err := NewSecretsProvider(cfg.SecretsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secrets provider: %w", err)
+	}
+	if secretsProvider != nil {
+		if _, err := cfg.ResolveSecrets(context.Background(), secretsProvider.GetSecret); err != nil {
+			return nil, fmt.Errorf("failed to resolve secrets: %w", err)
+		}
+		suite.secretsProvider = secretsProvider
+	}
+
+	if !cfg.ArtifactsConfig.Enable {
+		return suite, nil
+	}
+
+	artifactService, err := NewArtifactService(&cfg.ArtifactsConfig, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create artifact service: %w", err)
+	}
+
+	artifactsServer, err := NewArtifactsServerBuilder(&cfg.ArtifactsConfig, logger).
+		WithArtifactService(artifactService).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create artifacts server: %w", err)
+	}
+
+	suite.artifactService = artifactService
+	suite.artifactsServer = artifactsServer
+	return suite, nil
+}
+
+// A2AServerBuilder returns an A2A server builder that shares the suite's logger, artifact service
+// and secrets provider
+func (s *Suite) A2AServerBuilder() A2AServerBuilder {
+	builder := NewA2AServerBuilder(*s.cfg, s.logger)
+	if s.artifactService != nil {
+		builder = builder.WithArtifactService(s.artifactService)
+	}
+	if s.secretsProvider != nil {
+		builder = builder.WithSecretsProvider(s.secretsProvider)
+	}
+	return builder
+}
+
+// WithA2AServer sets the A2A server managed by the suite. Artifact files are then only
+// downloaded by the callers that may reach their context on the A2A server.
+func (s *Suite) WithA2AServer(a2aServer A2AServer) *Suite {
+	s.a2aServer = a2aServer
+	server, ok := a2aServer.(*A2AServerImpl)
+	artifacts, served := s.artifactsServer.(*ArtifactsServerImpl)
+	if ok && served && artifacts.accessCheck == nil {
+		artifacts.setAccessCheck(func(c *gin.Context, contextID string) bool {
+			return server.checkArtifactAccess(c, "", contextID)
+		})
+	}
+	return s
+}
+
+// A2AServer returns the A2A server managed by the suite
+func (s *Suite) A2AServer() A2AServer {
+	return s.a2aServer
+}
+
+// ArtifactsServer returns the artifacts server, or nil when artifacts are disabled
+func (s *Suite) ArtifactsServer() ArtifactsServer {
+	return s.artifactsServer
+}
+
+// ArtifactService returns the shared artifact service, or nil when artifacts are disabled
+func (s *Suite) ArtifactService() ArtifactService {
+	return s.artifactService
+}
+
+// Run starts every server and blocks until ctx is cancelled or any server exits.
+// All servers are then stopped within the configured shutdown timeout and the first
+// error encountered is returned.
+func (s *Suite) Run(ctx context.Context) error {
+	if s.a2aServer == nil {
+		return fmt.Errorf("a2a server must be set before running the suite - use WithA2AServer()")
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	group, groupCtx := errgroup.WithContext(runCtx)
+
+	group.Go(func() error {
+		defer cancel()
+		if err := s.a2aServer.Start(groupCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("a2a server failed: %w", err)
+		}
+		return nil
+	})
+
+	if s.artifactsServer != nil {
+		group.Go(func() error {
+			defer cancel()
+			if err := s.artifactsServer.Start(groupCtx); err != nil {
+				return fmt.Errorf("artifacts server failed: %w", err)
+			}
+			return nil
+		})
+	}
+
+	group.Go(func() error {
+		<-groupCtx.Done()
+		shutdownTimeout := s.cfg.ServerConfig.ShutdownTimeout
+		if shutdownTimeout <= 0 {
+			shutdownTimeout = defaultShutdownTimeout
+		}
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer shutdownCancel()
+		return s.Stop(shutdownCtx)
+	})
+
+	s.logger.Info("server suite running",
+		zap.String("a2a_port", s.cfg.ServerConfig.Port),
+		zap.Bool("artifacts_enabled", s.artifactsServer != nil))
+
+	return group.Wait()
+}
+
+// Stop gracefully stops every server in the suite. It is safe to call more than once.
+func (s *Suite) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		s.logger.Info("stopping server suite")
+
+		var errs []error
+		if s.a2aServer != nil {
+			if err := s.a2aServer.Stop(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("failed to stop a2a server: %w", err))
+			}
+		}
+		if s.artifactsServer != nil {
+			if err := s.artifactsServer.Stop(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("failed to stop artifacts server: %w", err))
+			}
+		}
+		s.stopErr = errors.Join(errs...)
+	})
+	return s.stopErr
+}
@@ -0,0 +1,206 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	zap "go.uber.org/zap"
+
+	mocks "github.com/inference-gateway/adk/server/mocks"
+
+	server "github.com/inference-gateway/adk/server"
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+func blockingStart(ctx context.Context) error {
+	<-ctx.Done()
+	return http.ErrServerClosed
+}
+
+func TestNewSuite(t *testing.T) {
+	tests := []struct {
+		name              string
+		cfg               *config.Config
+		expectError       bool
+		expectArtifacts   bool
+		expectedErrorText string
+	}{
+		{
+			name:              "nil config",
+			cfg:               nil,
+			expectError:       true,
+			expectedErrorText: "config is required",
+		},
+		{
+			name: "artifacts disabled",
+			cfg: &config.Config{
+				ServerConfig: config.ServerConfig{Port: "8080"},
+			},
+		},
+		{
+			name: "artifacts enabled",
+			cfg: &config.Config{
+				ServerConfig: config.ServerConfig{Port: "8080"},
+				ArtifactsConfig: config.ArtifactsConfig{
+					Enable: true,
+					ServerConfig: config.ArtifactsServerConfig{
+						Port: "8093",
+					},
+					StorageConfig: config.ArtifactsStorageConfig{
+						Provider: "filesystem",
+						BasePath: t.TempDir(),
+					},
+				},
+			},
+			expectArtifacts: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite, err := server.NewSuite(tt.cfg, zap.NewNop())
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErrorText)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, suite)
+			assert.NotNil(t, suite.A2AServerBuilder())
+			assert.Equal(t, tt.expectArtifacts, suite.ArtifactsServer() != nil)
+			assert.Equal(t, tt.expectArtifacts, suite.ArtifactService() != nil)
+		})
+	}
+}
+
+func TestSuite_RunWithoutA2AServer(t *testing.T) {
+	suite, err := server.NewSuite(&config.Config{}, zap.NewNop())
+	require.NoError(t, err)
+
+	err = suite.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "a2a server must be set")
+}
+
+func TestSuite_RunStopsOnContextCancel(t *testing.T) {
+	fakeServer := &mocks.FakeA2AServer{}
+	fakeServer.StartStub = blockingStart
+
+	suite, err := server.NewSuite(&config.Config{}, zap.NewNop())
+	require.NoError(t, err)
+	suite.WithA2AServer(fakeServer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- suite.Run(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		return fakeServer.StartCallCount() == 1
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("suite did not stop after context cancellation")
+	}
+
+	assert.Equal(t, 1, fakeServer.StopCallCount())
+	assert.NoError(t, suite.Stop(context.Background()))
+	assert.Equal(t, 1, fakeServer.StopCallCount())
+}
+
+func TestSuite_RunPropagatesServerErrors(t *testing.T) {
+	tests := []struct {
+		name              string
+		startErr          error
+		stopErr           error
+		expectedErrorText string
+	}{
+		{
+			name:              "start failure stops the suite",
+			startErr:          errors.New("address already in use"),
+			expectedErrorText: "a2a server failed: address already in use",
+		},
+		{
+			name:              "stop failure is reported",
+			stopErr:           errors.New("shutdown timed out"),
+			expectedErrorText: "failed to stop a2a server: shutdown timed out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeServer := &mocks.FakeA2AServer{}
+			fakeServer.StartReturns(tt.startErr)
+			fakeServer.StopReturns(tt.stopErr)
+
+			suite, err := server.NewSuite(&config.Config{}, zap.NewNop())
+			require.NoError(t, err)
+
+			err = suite.WithA2AServer(fakeServer).Run(context.Background())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErrorText)
+			assert.Equal(t, 1, fakeServer.StopCallCount())
+		})
+	}
+}
+
+func TestSuite_RunWithArtifactsServer(t *testing.T) {
+	cfg := &config.Config{
+		ArtifactsConfig: config.ArtifactsConfig{
+			Enable: true,
+			ServerConfig: config.ArtifactsServerConfig{
+				Port: "8094",
+			},
+			StorageConfig: config.ArtifactsStorageConfig{
+				Provider: "filesystem",
+				BasePath: t.TempDir(),
+			},
+		},
+	}
+
+	suite, err := server.NewSuite(cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	fakeServer := &mocks.FakeA2AServer{}
+	fakeServer.StartStub = blockingStart
+	suite.WithA2AServer(fakeServer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- suite.Run(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get("http://localhost:8094/health")
+		if err != nil {
+			return false
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 2*time.Second, 50*time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("suite did not stop after context cancellation")
+	}
+
+	assert.Equal(t, 1, fakeServer.StopCallCount())
+	_, err = http.Get("http://localhost:8094/health")
+	assert.Error(t, err)
+}
@@ -0,0 +1,429 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// defaultDependencyCheckInterval is how often held tasks are checked when no interval is set
+const defaultDependencyCheckInterval = 30 * time.Second
+
+// taskDependencies are the upstream tasks a submitted task waits for
+type taskDependencies struct {
+	upstream []string
+	pending  map[string]bool
+}
+
+// parseTaskDependencies returns the IDs of the tasks a message request depends on, listed in
+// its metadata, without duplicates
+func parseTaskDependencies(metadata map[string]any) ([]string, error) {
+	raw, exists := metadata[types.DependsOnMetadataKey]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+
+	values, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("metadata %s must be a list of task ids", types.DependsOnMetadataKey)
+	}
+	var dependencies []string
+	for _, value := range values {
+		taskID, ok := value.(string)
+		if !ok || taskID == "" {
+			return nil, fmt.Errorf("metadata %s must be a list of task ids", types.DependsOnMetadataKey)
+		}
+		if !slices.Contains(dependencies, taskID) {
+			dependencies = append(dependencies, taskID)
+		}
+	}
+	return dependencies, nil
+}
+
+// checkTaskDependencies checks that a message starting a new task depends on tasks that exist,
+// returning the JSON-RPC error code to report when it does not
+func (h *DefaultA2AProtocolHandler) checkTaskDependencies(params types.MessageSendParams, dependencies []string) (JRPCErrorCode, error) {
+	if _, ok := h.taskManager.(*DefaultTaskManager); !ok {
+		return ErrUnsupportedOperation, fmt.Errorf("task dependencies require the default task manager")
+	}
+	if params.Message.TaskID != nil {
+		return ErrInvalidParams, fmt.Errorf("metadata %s only applies to messages starting a new task", types.DependsOnMetadataKey)
+	}
+	for _, taskID := range dependencies {
+		if _, exists := h.taskManager.GetTask(taskID); !exists {
+			return ErrTaskNotFound, NewTaskNotFoundError(taskID)
+		}
+	}
+	return 0, nil
+}
+
+// checkHeldTasks continues the stored tasks held for dependencies that ended while this
+// instance was down or on another replica
+func (s *A2AServerImpl) checkHeldTasks(ctx context.Context) {
+	if defaultTM, ok := s.taskManager.(*DefaultTaskManager); ok {
+		if continued := defaultTM.CheckHeldTasks(ctx); continued > 0 {
+			s.logger.Info("continued tasks held for their dependencies", zap.Int("tasks", continued))
+		}
+	}
+}
+
+// startDependencyChecks periodically checks the tasks held for their dependencies against the
+// stored state of the dependencies
+func (s *A2AServerImpl) startDependencyChecks(ctx context.Context) {
+	if _, ok := s.taskManager.(*DefaultTaskManager); !ok {
+		return
+	}
+	interval := s.cfg.TaskDependencyConfig.CheckInterval
+	if interval <= 0 {
+		interval = defaultDependencyCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	job := newPeriodicJob("task_dependencies", interval, s.clock.Now())
+	s.dependencyJob.Store(job)
+	defer s.dependencyJob.Store(nil)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.checkHeldTasks(ctx)
+			job.ran(s.clock.Now())
+		}
+	}
+}
+
+// holdForDependencies answers a message/send request starting a task with dependencies with
+// the submitted task, which is queued once its dependencies have completed
+func (h *DefaultA2AProtocolHandler) holdForDependencies(c *gin.Context, requestID any, task *types.Task, dependencies []string, historyLength *int) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	defaultTM := h.taskManager.(*DefaultTaskManager)
+	if err := defaultTM.HoldForDependencies(c.Request.Context(), task.ID, dependencies); err != nil {
+		logger.Error("failed to hold task for its dependencies",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+		updateErr := h.taskManager.UpdateError(task.ID, types.NewAssistantMessage(h.ids.NewID(), []types.Part{
+			types.CreateTextPart(fmt.Sprintf("Failed to wait for the dependencies of the task: %s", err)),
+		}))
+		if updateErr != nil {
+			logger.Error("failed to fail task that could not wait for its dependencies",
+				zap.String("task_id", task.ID),
+				zap.Error(updateErr))
+		}
+		h.responseSender.SendError(c, requestID, int(taskErrorCode(err)), err.Error())
+		return
+	}
+
+	if held, exists := h.taskManager.GetTask(task.ID); exists {
+		task = held
+	}
+	h.responseSender.SendSuccess(c, requestID, limitHistory(*task, historyLength))
+}
+
+// HoldForDependencies keeps a submitted task out of the queue until the tasks it depends on
+// have completed. The task is then queued with a message carrying the final messages and
+// artifacts of its upstream tasks ahead of its own, and it fails as soon as one of them ends
+// in any other state. A task whose dependencies have all completed already is queued right away.
+//
+// A held task is stored with its dependencies under AwaitingDependenciesMetadataKey, so
+// CheckHeldTasks continues it after a restart or when a dependency completes on another
+// replica sharing the storage.
+func (tm *DefaultTaskManager) HoldForDependencies(ctx context.Context, taskID string, dependencies []string) error {
+	task, exists := tm.GetTask(taskID)
+	if !exists {
+		return NewTaskNotFoundError(taskID)
+	}
+
+	pending, failed, err := tm.pendingDependencies(dependencies)
+	if err != nil {
+		return err
+	}
+	switch {
+	case failed != nil:
+		tm.failDependentTask(taskID, failed)
+	case len(pending) == 0:
+		tm.startDependentTask(ctx, taskID, dependencies, false)
+	default:
+		setTaskMetadata(task, types.AwaitingDependenciesMetadataKey, slices.Clone(dependencies))
+		if err := tm.UpdateTask(task); err != nil {
+			return fmt.Errorf("failed to store the dependencies of the task: %w", err)
+		}
+		tm.trackDependencies(taskID, dependencies, pending)
+		tm.logger.Info("task waiting for its dependencies",
+			zap.String("task_id", taskID),
+			zap.Strings("depends_on", dependencies),
+			zap.Int("pending", len(pending)))
+	}
+	return nil
+}
+
+// CheckHeldTasks checks the stored tasks held for their dependencies against the stored state
+// of the dependencies, queueing the tasks whose dependencies have all completed and failing
+// the ones with a dependency that did not, and returns how many it continued. The others are
+// tracked, so the completion of a dependency on this instance releases them right away.
+func (tm *DefaultTaskManager) CheckHeldTasks(ctx context.Context) int {
+	submitted := types.TaskStateSubmitted
+	tasks, err := tm.storage.ListTasks(TaskFilter{State: &submitted})
+	if err != nil {
+		tm.logger.Error("failed to list tasks held for their dependencies", zap.Error(err))
+		return 0
+	}
+
+	continued := 0
+	for _, task := range tasks {
+		dependencies := awaitedDependencies(task)
+		if len(dependencies) == 0 {
+			continue
+		}
+		pending, failed, err := tm.pendingDependencies(dependencies)
+		var notFound *TaskNotFoundError
+		switch {
+		case errors.As(err, &notFound):
+			tm.logger.Warn("task depends on a task that no longer exists",
+				zap.String("task_id", task.ID),
+				zap.String("dependency", notFound.TaskID))
+			tm.failDependentTask(task.ID, &types.Task{ID: notFound.TaskID})
+			continued++
+		case failed != nil:
+			tm.failDependentTask(task.ID, failed)
+			continued++
+		case len(pending) == 0:
+			tm.startDependentTask(ctx, task.ID, dependencies, true)
+			continued++
+		default:
+			tm.trackDependencies(task.ID, dependencies, pending)
+		}
+	}
+	return continued
+}
+
+// pendingDependencies returns the dependencies that have not ended yet, or the first one that
+// ended without completing
+func (tm *DefaultTaskManager) pendingDependencies(dependencies []string) (map[string]bool, *types.Task, error) {
+	pending := make(map[string]bool)
+	for _, upstreamID := range dependencies {
+		upstream, exists := tm.GetTask(upstreamID)
+		if !exists {
+			return nil, nil, NewTaskNotFoundError(upstreamID)
+		}
+		if upstream.Status.State == types.TaskStateCompleted {
+			continue
+		}
+		if tm.isTaskFinalState(upstream.Status.State) {
+			return nil, upstream, nil
+		}
+		pending[upstreamID] = true
+	}
+	return pending, nil, nil
+}
+
+// trackDependencies registers a held task with the dependencies it still waits for, unless it
+// is tracked already
+func (tm *DefaultTaskManager) trackDependencies(taskID string, dependencies []string, pending map[string]bool) {
+	tm.dependenciesMu.Lock()
+	defer tm.dependenciesMu.Unlock()
+	if _, exists := tm.waitingTasks[taskID]; exists {
+		return
+	}
+	tm.waitingTasks[taskID] = &taskDependencies{upstream: dependencies, pending: pending}
+	for upstreamID := range pending {
+		tm.dependents[upstreamID] = append(tm.dependents[upstreamID], taskID)
+	}
+}
+
+// untrackDependencies forgets a held task that was continued
+func (tm *DefaultTaskManager) untrackDependencies(taskID string) {
+	tm.dependenciesMu.Lock()
+	defer tm.dependenciesMu.Unlock()
+	delete(tm.waitingTasks, taskID)
+}
+
+// awaitedDependencies returns the dependencies a stored task is held for
+func awaitedDependencies(task *types.Task) []string {
+	if task.Metadata == nil {
+		return nil
+	}
+	var dependencies []string
+	switch values := (*task.Metadata)[types.AwaitingDependenciesMetadataKey].(type) {
+	case []string:
+		dependencies = values
+	case []any:
+		for _, value := range values {
+			if taskID, ok := value.(string); ok && taskID != "" {
+				dependencies = append(dependencies, taskID)
+			}
+		}
+	}
+	return dependencies
+}
+
+// releaseDependents continues the tasks waiting for a task that reached a final state. A
+// waiting task whose dependencies have all completed is queued, and a task depending on a task
+// that did not complete fails.
+func (tm *DefaultTaskManager) releaseDependents(upstream *types.Task) {
+	tm.dependenciesMu.Lock()
+	delete(tm.waitingTasks, upstream.ID)
+	waiting := tm.dependents[upstream.ID]
+	delete(tm.dependents, upstream.ID)
+
+	var ready []*taskDependencies
+	var readyIDs, failedIDs []string
+	for _, taskID := range waiting {
+		dependencies, exists := tm.waitingTasks[taskID]
+		if !exists {
+			continue
+		}
+		if upstream.Status.State != types.TaskStateCompleted {
+			delete(tm.waitingTasks, taskID)
+			failedIDs = append(failedIDs, taskID)
+			continue
+		}
+		delete(dependencies.pending, upstream.ID)
+		if len(dependencies.pending) == 0 {
+			delete(tm.waitingTasks, taskID)
+			ready = append(ready, dependencies)
+			readyIDs = append(readyIDs, taskID)
+		}
+	}
+	tm.dependenciesMu.Unlock()
+
+	for _, taskID := range failedIDs {
+		tm.failDependentTask(taskID, upstream)
+	}
+	for i, taskID := range readyIDs {
+		tm.startDependentTask(context.Background(), taskID, ready[i].upstream, true)
+	}
+}
+
+// startDependentTask queues a task whose dependencies have all completed, with their results
+// ahead of its latest message. A task that was canceled while it waited is left alone, and so
+// is a held task another instance queued already, which no longer awaits its dependencies.
+func (tm *DefaultTaskManager) startDependentTask(ctx context.Context, taskID string, dependencies []string, held bool) {
+	// the check and the update of the task are one step, so a task released by a completion
+	// and by CheckHeldTasks at once is queued once
+	tm.dependenciesMu.Lock()
+	delete(tm.waitingTasks, taskID)
+	task, exists := tm.GetTask(taskID)
+	if !exists || task.Status.State != types.TaskStateSubmitted || (held && len(awaitedDependencies(task)) == 0) {
+		tm.dependenciesMu.Unlock()
+		return
+	}
+	setTaskMetadata(task, types.AwaitingDependenciesMetadataKey, nil)
+
+	results := tm.dependencyResults(dependencies)
+	if len(task.History) > 0 {
+		task.History = slices.Insert(task.History, len(task.History)-1, results)
+	} else {
+		task.History = append(task.History, results)
+	}
+
+	err := tm.UpdateTask(task)
+	tm.dependenciesMu.Unlock()
+	if err != nil {
+		tm.logger.Error("failed to add the results of the dependencies of a task",
+			zap.String("task_id", taskID),
+			zap.Error(err))
+		return
+	}
+	if err := tm.storage.EnqueueTask(ctx, task, nil); err != nil {
+		tm.logger.Error("failed to enqueue task whose dependencies completed",
+			zap.String("task_id", taskID),
+			zap.Error(err))
+		// held again, so the next check queues it
+		setTaskMetadata(task, types.AwaitingDependenciesMetadataKey, slices.Clone(dependencies))
+		task.History = slices.DeleteFunc(task.History, func(message types.Message) bool {
+			return message.MessageID == results.MessageID
+		})
+		if err := tm.UpdateTask(task); err != nil {
+			tm.logger.Error("failed to hold task that could not be queued",
+				zap.String("task_id", taskID),
+				zap.Error(err))
+		}
+		return
+	}
+
+	tm.logger.Info("task dependencies completed, task queued",
+		zap.String("task_id", taskID),
+		zap.Strings("depends_on", dependencies))
+}
+
+// failDependentTask fails a task waiting for a task that ended without completing
+func (tm *DefaultTaskManager) failDependentTask(taskID string, upstream *types.Task) {
+	tm.untrackDependencies(taskID)
+	task, exists := tm.GetTask(taskID)
+	if !exists || task.Status.State != types.TaskStateSubmitted {
+		return
+	}
+	if len(awaitedDependencies(task)) > 0 {
+		setTaskMetadata(task, types.AwaitingDependenciesMetadataKey, nil)
+		if err := tm.UpdateTask(task); err != nil {
+			tm.logger.Error("failed to clear the dependencies of a task",
+				zap.String("task_id", taskID),
+				zap.Error(err))
+		}
+	}
+
+	reason := fmt.Sprintf("Task %s this task depends on did not complete", upstream.ID)
+	message := types.NewAssistantMessage(tm.ids.NewID(), []types.Part{types.CreateTextPart(reason)})
+	if err := tm.UpdateError(taskID, message); err != nil {
+		tm.logger.Error("failed to fail task whose dependency did not complete",
+			zap.String("task_id", taskID),
+			zap.Error(err))
+		return
+	}
+
+	tm.logger.Info("task dependency did not complete, task failed",
+		zap.String("task_id", taskID),
+		zap.String("dependency", upstream.ID),
+		zap.String("state", string(upstream.Status.State)))
+}
+
+// dependencyResults builds the message carrying the final message and artifacts of each
+// upstream task, marked with the IDs of the upstream tasks under DependencyResultsMetadataKey
+func (tm *DefaultTaskManager) dependencyResults(dependencies []string) types.Message {
+	var parts []types.Part
+	for _, upstreamID := range dependencies {
+		upstream, exists := tm.GetTask(upstreamID)
+		if !exists {
+			continue
+		}
+		parts = append(parts, types.CreateTextPart(fmt.Sprintf("Result of task %s:", upstreamID)))
+		if final := finalAgentMessage(upstream); final != nil {
+			parts = append(parts, final.Parts...)
+		}
+		for _, artifact := range upstream.Artifacts {
+			parts = append(parts, artifact.Parts...)
+		}
+	}
+
+	metadata := map[string]any{types.DependencyResultsMetadataKey: slices.Clone(dependencies)}
+	return types.Message{
+		MessageID: tm.ids.NewID(),
+		Role:      types.RoleUser,
+		Parts:     parts,
+		Metadata:  &metadata,
+	}
+}
+
+// finalAgentMessage returns the status message of a task when the agent sent it, or else the
+// latest agent message of its history
+func finalAgentMessage(task *types.Task) *types.Message {
+	if task.Status.Message != nil && task.Status.Message.Role == types.RoleAgent {
+		return task.Status.Message
+	}
+	for i := len(task.History) - 1; i >= 0; i-- {
+		if task.History[i].Role == types.RoleAgent {
+			return &task.History[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestParseTaskDependencies(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]any
+		expected []string
+		errorMsg string
+	}{
+		{name: "no dependencies", metadata: map[string]any{"other": "value"}},
+		{name: "dependencies without duplicates", metadata: map[string]any{types.DependsOnMetadataKey: []any{"a", "b", "a"}}, expected: []string{"a", "b"}},
+		{name: "not a list", metadata: map[string]any{types.DependsOnMetadataKey: "a"}, errorMsg: "must be a list of task ids"},
+		{name: "empty id", metadata: map[string]any{types.DependsOnMetadataKey: []any{"a", ""}}, errorMsg: "must be a list of task ids"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dependencies, err := parseTaskDependencies(tt.metadata)
+			if tt.errorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, dependencies)
+		})
+	}
+}
+
+func TestA2AServer_TaskDependencies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	send := func(t *testing.T, router *gin.Engine, method string, dependencies ...string) (*types.Task, *types.JSONRPCError) {
+		params := types.MessageSendParams{
+			Message: types.Message{
+				MessageID: "downstream",
+				Role:      types.RoleUser,
+				Parts:     []types.Part{types.CreateTextPart("combine the reports")},
+			},
+			Metadata: map[string]any{types.DependsOnMetadataKey: dependencies},
+		}
+		encoded, err := json.Marshal(params)
+		require.NoError(t, err)
+		body := `{"jsonrpc":"2.0","id":"1","method":"` + method + `","params":` + string(encoded) + `}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+		var response struct {
+			Result *types.Task         `json:"result"`
+			Error  *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Result, response.Error
+	}
+
+	setup := func() (*A2AServerImpl, *DefaultTaskManager, *gin.Engine) {
+		cfg := &config.Config{}
+		s := NewA2AServer(cfg, zap.NewNop(), nil)
+		s.SetAgentCard(types.AgentCard{Name: "assistant"})
+		return s, s.taskManager.(*DefaultTaskManager), s.setupRouter(cfg)
+	}
+
+	complete := func(t *testing.T, tm *DefaultTaskManager, task *types.Task, result string) {
+		task.Status.State = types.TaskStateCompleted
+		task.Status.Message = types.NewAssistantMessage("result-"+task.ID, []types.Part{types.CreateTextPart(result)})
+		task.Artifacts = []types.Artifact{{ArtifactID: "artifact-" + task.ID, Parts: []types.Part{types.CreateTextPart(result + " artifact")}}}
+		require.NoError(t, tm.UpdateTask(task))
+	}
+
+	t.Run("task is queued with the results once its dependencies complete", func(t *testing.T) {
+		s, tm, router := setup()
+		first := tm.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+		second := tm.CreateTask("ctx-2", types.TaskStateWorking, &types.Message{MessageID: "m-2", Role: types.RoleUser})
+
+		task, rpcErr := send(t, router, "message/send", first.ID, second.ID)
+		require.Nil(t, rpcErr)
+		require.NotNil(t, task)
+		assert.Equal(t, types.TaskStateSubmitted, task.Status.State)
+		assert.Zero(t, s.storage.GetQueueLength(), "the task waits for its dependencies")
+
+		complete(t, tm, first, "first report")
+		assert.Zero(t, s.storage.GetQueueLength(), "the task waits for all of its dependencies")
+		complete(t, tm, second, "second report")
+		assert.Equal(t, 1, s.storage.GetQueueLength())
+
+		queued, exists := tm.GetTask(task.ID)
+		require.True(t, exists)
+		require.Len(t, queued.History, 2)
+		results := queued.History[0]
+		require.NotNil(t, results.Metadata)
+		assert.Equal(t, []string{first.ID, second.ID}, (*results.Metadata)[types.DependencyResultsMetadataKey])
+		var texts []string
+		for _, part := range results.Parts {
+			texts = append(texts, *part.Text)
+		}
+		assert.Equal(t, []string{
+			"Result of task " + first.ID + ":", "first report", "first report artifact",
+			"Result of task " + second.ID + ":", "second report", "second report artifact",
+		}, texts)
+		assert.Equal(t, "downstream", queued.History[1].MessageID, "the message of the task stays last")
+	})
+
+	t.Run("task is queued right away when its dependencies completed", func(t *testing.T) {
+		s, tm, router := setup()
+		done := tm.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+		complete(t, tm, done, "report")
+
+		task, rpcErr := send(t, router, "message/send", done.ID)
+		require.Nil(t, rpcErr)
+		require.NotNil(t, task)
+		assert.Equal(t, 1, s.storage.GetQueueLength())
+	})
+
+	t.Run("task fails when a dependency does not complete", func(t *testing.T) {
+		s, tm, router := setup()
+		upstream := tm.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+
+		task, rpcErr := send(t, router, "message/send", upstream.ID)
+		require.Nil(t, rpcErr)
+		require.NoError(t, tm.CancelTask(upstream.ID))
+
+		failed, exists := tm.GetTask(task.ID)
+		require.True(t, exists)
+		assert.Equal(t, types.TaskStateFailed, failed.Status.State)
+		require.NotNil(t, failed.Status.Message)
+		assert.Equal(t, "Task "+upstream.ID+" this task depends on did not complete", *failed.Status.Message.Parts[0].Text)
+		assert.Zero(t, s.storage.GetQueueLength())
+	})
+
+	t.Run("held task stores the dependencies it awaits", func(t *testing.T) {
+		_, tm, router := setup()
+		upstream := tm.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+
+		task, rpcErr := send(t, router, "message/send", upstream.ID)
+		require.Nil(t, rpcErr)
+
+		held, exists := tm.GetTask(task.ID)
+		require.True(t, exists)
+		assert.Equal(t, []string{upstream.ID}, awaitedDependencies(held))
+
+		complete(t, tm, upstream, "report")
+		queued, exists := tm.GetTask(task.ID)
+		require.True(t, exists)
+		assert.Empty(t, awaitedDependencies(queued), "the marker is cleared once the task is queued")
+	})
+
+	t.Run("held task is queued by a check after its dependency completed elsewhere", func(t *testing.T) {
+		s, tm, router := setup()
+		upstream := tm.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+		task, rpcErr := send(t, router, "message/send", upstream.ID)
+		require.Nil(t, rpcErr)
+
+		// another instance sharing the storage completes the dependency
+		other := NewDefaultTaskManagerWithStorage(zap.NewNop(), s.storage)
+		complete(t, other, upstream, "report")
+		assert.Zero(t, s.storage.GetQueueLength())
+
+		assert.Equal(t, 1, other.CheckHeldTasks(context.Background()))
+		assert.Equal(t, 1, s.storage.GetQueueLength())
+		assert.Zero(t, tm.CheckHeldTasks(context.Background()), "a queued task is not queued again")
+		assert.Equal(t, 1, s.storage.GetQueueLength())
+
+		queued, exists := tm.GetTask(task.ID)
+		require.True(t, exists)
+		require.Len(t, queued.History, 2)
+		assert.Equal(t, []string{upstream.ID}, (*queued.History[0].Metadata)[types.DependencyResultsMetadataKey])
+	})
+
+	t.Run("held task fails on a check after its dependency failed elsewhere", func(t *testing.T) {
+		s, tm, router := setup()
+		upstream := tm.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+		task, rpcErr := send(t, router, "message/send", upstream.ID)
+		require.Nil(t, rpcErr)
+
+		restarted := NewDefaultTaskManagerWithStorage(zap.NewNop(), s.storage)
+		upstream.Status.State = types.TaskStateFailed
+		require.NoError(t, restarted.UpdateTask(upstream))
+
+		assert.Equal(t, 1, restarted.CheckHeldTasks(context.Background()))
+		failed, exists := tm.GetTask(task.ID)
+		require.True(t, exists)
+		assert.Equal(t, types.TaskStateFailed, failed.Status.State)
+		assert.Empty(t, awaitedDependencies(failed))
+		assert.Zero(t, s.storage.GetQueueLength())
+	})
+
+	t.Run("invalid dependencies are rejected", func(t *testing.T) {
+		_, tm, router := setup()
+		_, rpcErr := send(t, router, "message/send", "missing")
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, int(ErrTaskNotFound), rpcErr.Code)
+
+		upstream := tm.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+		_, rpcErr = send(t, router, "message/stream", upstream.ID)
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, int(ErrInvalidParams), rpcErr.Code)
+	})
+}
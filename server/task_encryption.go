@@ -0,0 +1,340 @@
+package server
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// encryptedPartMetadataKey marks the part holding the sealed content of a message or artifact,
+// naming the key that sealed it
+const encryptedPartMetadataKey = "adk.encrypted"
+
+// EncryptedStore is implemented by storage backends that encrypt the tasks they persist
+type EncryptedStore interface {
+	// SetTaskCipher seals the tasks persisted from now on with the cipher, which also opens
+	// the tasks read back
+	SetTaskCipher(cipher *TaskCipher)
+
+	// ReencryptTasks seals the finished tasks stored in plain text or with a replaced key
+	// again with the current key, returning their number. Active tasks are sealed again
+	// when they are next updated.
+	ReencryptTasks(ctx context.Context) (int, error)
+}
+
+// TaskCipher seals the message parts and artifacts of tasks with AES-GCM before they are
+// persisted, and opens them on read. New data is sealed with the current key, while the keys
+// it replaced keep opening the data sealed with them. Task IDs, states and metadata stay in
+// plain text so stores can still filter tasks.
+type TaskCipher struct {
+	mu      sync.RWMutex
+	current string
+	keys    map[string]cipher.AEAD
+}
+
+// sealedArtifact is the content of an artifact sealed into a single part
+type sealedArtifact struct {
+	Name        *string       `json:"name,omitempty"`
+	Description *string       `json:"description,omitempty"`
+	Metadata    *types.Struct `json:"metadata,omitempty"`
+	Parts       []types.Part  `json:"parts"`
+}
+
+// NewTaskCipher creates a cipher sealing new data with the key and opening the data sealed
+// with the key or one of the previous keys. Keys are AES keys of 16, 24 or 32 bytes.
+func NewTaskCipher(key []byte, previous ...[]byte) (*TaskCipher, error) {
+	c := &TaskCipher{keys: make(map[string]cipher.AEAD)}
+	for _, old := range previous {
+		if _, err := c.addKey(old); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.Rotate(key); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// NewTaskCipherFromConfig creates a cipher from the base64-encoded keys of the configuration
+func NewTaskCipherFromConfig(cfg config.EncryptionConfig) (*TaskCipher, error) {
+	key, err := base64.StdEncoding.DecodeString(cfg.Key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	previous := make([][]byte, 0, len(cfg.PreviousKeys))
+	for i, encoded := range cfg.PreviousKeys {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid previous encryption key %d: %w", i+1, err)
+		}
+		previous = append(previous, decoded)
+	}
+	return NewTaskCipher(key, previous...)
+}
+
+// Rotate seals new data with the key, keeping the replaced key to open the data sealed with it
+func (c *TaskCipher) Rotate(key []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id, err := c.addKey(key)
+	if err != nil {
+		return err
+	}
+	c.current = id
+	return nil
+}
+
+// currentKeyID returns the ID of the key sealing new data
+func (c *TaskCipher) currentKeyID() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.current
+}
+
+// addKey adds a key opening data and returns its ID, the start of the hash of the key
+func (c *TaskCipher) addKey(key []byte) (string, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", fmt.Errorf("invalid encryption key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", fmt.Errorf("invalid encryption key: %w", err)
+	}
+	sum := sha256.Sum256(key)
+	id := hex.EncodeToString(sum[:4])
+	c.keys[id] = aead
+	return id, nil
+}
+
+// EncryptTask returns a copy of the task whose message parts and artifacts are sealed, bound
+// to the task's ID. The task itself is left unchanged.
+func (c *TaskCipher) EncryptTask(task *types.Task) (*types.Task, error) {
+	sealed := *task
+	history, err := c.EncryptMessages(task.ID, task.History)
+	if err != nil {
+		return nil, err
+	}
+	sealed.History = history
+	if task.Status.Message != nil {
+		message, err := c.encryptMessage(task.ID, *task.Status.Message)
+		if err != nil {
+			return nil, err
+		}
+		sealed.Status.Message = &message
+	}
+	if task.Artifacts != nil {
+		sealed.Artifacts = make([]types.Artifact, len(task.Artifacts))
+		for i, artifact := range task.Artifacts {
+			part, err := c.seal(task.ID, sealedArtifact{
+				Name:        artifact.Name,
+				Description: artifact.Description,
+				Metadata:    artifact.Metadata,
+				Parts:       artifact.Parts,
+			})
+			if err != nil {
+				return nil, err
+			}
+			sealed.Artifacts[i] = types.Artifact{ArtifactID: artifact.ArtifactID, Extensions: artifact.Extensions, Parts: []types.Part{part}}
+		}
+	}
+	return &sealed, nil
+}
+
+// DecryptTask opens the sealed message parts and artifacts of a task read from a store in
+// place. Parts stored in plain text, before encryption was enabled, are left as they are.
+func (c *TaskCipher) DecryptTask(task *types.Task) error {
+	if err := c.DecryptMessages(task.ID, task.History); err != nil {
+		return err
+	}
+	if task.Status.Message != nil {
+		if err := c.decryptMessage(task.ID, task.Status.Message); err != nil {
+			return err
+		}
+	}
+	for i := range task.Artifacts {
+		artifact := &task.Artifacts[i]
+		var content sealedArtifact
+		opened, err := c.open(task.ID, artifact.Parts, &content)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt artifact %s: %w", artifact.ArtifactID, err)
+		}
+		if opened {
+			artifact.Name = content.Name
+			artifact.Description = content.Description
+			artifact.Metadata = content.Metadata
+			artifact.Parts = content.Parts
+		}
+	}
+	return nil
+}
+
+// EncryptMessages returns copies of the messages whose parts are sealed, bound to the ID
+func (c *TaskCipher) EncryptMessages(id string, messages []types.Message) ([]types.Message, error) {
+	if messages == nil {
+		return nil, nil
+	}
+	sealed := make([]types.Message, len(messages))
+	for i, message := range messages {
+		encrypted, err := c.encryptMessage(id, message)
+		if err != nil {
+			return nil, err
+		}
+		sealed[i] = encrypted
+	}
+	return sealed, nil
+}
+
+// DecryptMessages opens the sealed parts of the messages in place
+func (c *TaskCipher) DecryptMessages(id string, messages []types.Message) error {
+	for i := range messages {
+		if err := c.decryptMessage(id, &messages[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Current reports whether the message parts and artifacts of a stored task are all sealed
+// with the current key
+func (c *TaskCipher) Current(task *types.Task) bool {
+	current := c.currentKeyID()
+	sealedWith := func(parts []types.Part) bool {
+		if len(parts) == 0 {
+			return true
+		}
+		return len(parts) == 1 && sealedKeyID(parts[0]) == current
+	}
+	for _, message := range task.History {
+		if !sealedWith(message.Parts) {
+			return false
+		}
+	}
+	if task.Status.Message != nil && !sealedWith(task.Status.Message.Parts) {
+		return false
+	}
+	for _, artifact := range task.Artifacts {
+		if !sealedWith(artifact.Parts) {
+			return false
+		}
+	}
+	return true
+}
+
+// reencryptTasks seals the stored tasks again with the current encryption key
+func (s *A2AServerImpl) reencryptTasks(ctx context.Context) {
+	store, ok := s.storage.(EncryptedStore)
+	if !ok {
+		return
+	}
+	count, err := store.ReencryptTasks(ctx)
+	if err != nil {
+		s.logger.Error("failed to re-encrypt stored tasks", zap.Error(err))
+		return
+	}
+	if count > 0 {
+		s.logger.Info("stored tasks re-encrypted", zap.Int("count", count))
+	}
+}
+
+// encryptMessage returns a copy of the message whose parts are sealed into a single part
+func (c *TaskCipher) encryptMessage(id string, message types.Message) (types.Message, error) {
+	if len(message.Parts) == 0 {
+		return message, nil
+	}
+	part, err := c.seal(id, message.Parts)
+	if err != nil {
+		return types.Message{}, fmt.Errorf("failed to encrypt message %s: %w", message.MessageID, err)
+	}
+	message.Parts = []types.Part{part}
+	return message, nil
+}
+
+// decryptMessage opens the sealed parts of a message in place
+func (c *TaskCipher) decryptMessage(id string, message *types.Message) error {
+	var parts []types.Part
+	opened, err := c.open(id, message.Parts, &parts)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt message %s: %w", message.MessageID, err)
+	}
+	if opened {
+		message.Parts = parts
+	}
+	return nil
+}
+
+// seal encrypts the JSON of a value with the current key into a text part naming the key
+func (c *TaskCipher) seal(id string, value any) (types.Part, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return types.Part{}, err
+	}
+
+	c.mu.RLock()
+	keyID := c.current
+	aead := c.keys[keyID]
+	c.mu.RUnlock()
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return types.Part{}, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	ciphertext := aead.Seal(nonce, nonce, plaintext, []byte(id))
+	text := base64.StdEncoding.EncodeToString(ciphertext)
+	return types.Part{Text: &text, Metadata: &types.Struct{encryptedPartMetadataKey: keyID}}, nil
+}
+
+// open decrypts the parts sealed by seal into the value, reporting false for parts that are
+// not sealed
+func (c *TaskCipher) open(id string, parts []types.Part, value any) (bool, error) {
+	if len(parts) != 1 {
+		return false, nil
+	}
+	keyID := sealedKeyID(parts[0])
+	if keyID == "" || parts[0].Text == nil {
+		return false, nil
+	}
+
+	c.mu.RLock()
+	aead, ok := c.keys[keyID]
+	c.mu.RUnlock()
+	if !ok {
+		return false, fmt.Errorf("unknown encryption key %s", keyID)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(*parts[0].Text)
+	if err != nil {
+		return false, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return false, fmt.Errorf("sealed content is too short")
+	}
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(id))
+	if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(plaintext, value)
+}
+
+// sealedKeyID returns the ID of the key that sealed a part, or an empty string for a part
+// that is not sealed
+func sealedKeyID(part types.Part) string {
+	if part.Metadata == nil {
+		return ""
+	}
+	keyID, _ := (*part.Metadata)[encryptedPartMetadataKey].(string)
+	return keyID
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func newEncryptionTestTask() *types.Task {
+	name := "Report"
+	metadata := types.Struct{"labels": map[string]any{"team": "billing"}}
+	return &types.Task{
+		ID:        "task-1",
+		ContextID: "ctx-1",
+		Metadata:  &metadata,
+		Status: types.TaskStatus{
+			State:   types.TaskStateCompleted,
+			Message: &types.Message{MessageID: "m-3", Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart("Your card ends in 4242.")}},
+		},
+		History: []types.Message{
+			{MessageID: "m-1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("My card number is 4242 4242 4242 4242")}},
+			{MessageID: "m-2", Role: types.RoleAgent, Parts: []types.Part{}},
+		},
+		Artifacts: []types.Artifact{{
+			ArtifactID: "a-1",
+			Name:       &name,
+			Metadata:   &types.Struct{"customer": "Alice"},
+			Parts:      []types.Part{types.CreateTextPart("Alice owes 42 EUR")},
+		}},
+	}
+}
+
+func TestTaskCipher_EncryptAndDecrypt(t *testing.T) {
+	cipher, err := NewTaskCipher(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	task := newEncryptionTestTask()
+	original, err := json.Marshal(task)
+	require.NoError(t, err)
+
+	sealed, err := cipher.EncryptTask(task)
+	require.NoError(t, err)
+	stored, err := json.Marshal(sealed)
+	require.NoError(t, err)
+	for _, secret := range []string{"4242", "Alice", "Report"} {
+		assert.NotContains(t, string(stored), secret)
+	}
+	assert.Contains(t, string(stored), "billing", "task metadata stays readable")
+	assert.Empty(t, sealed.History[1].Parts, "messages without parts are kept as they are")
+	assert.True(t, cipher.Current(sealed))
+	unchanged, err := json.Marshal(task)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(original), string(unchanged), "the task itself is not changed")
+
+	var read types.Task
+	require.NoError(t, json.Unmarshal(stored, &read))
+	require.NoError(t, cipher.DecryptTask(&read))
+	opened, err := json.Marshal(read)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(original), string(opened))
+
+	moved := *sealed
+	moved.ID = "task-2"
+	assert.Error(t, cipher.DecryptTask(&moved), "sealed content is bound to its task")
+
+	plain := newEncryptionTestTask()
+	require.NoError(t, cipher.DecryptTask(plain), "tasks stored before encryption are read as they are")
+	assert.False(t, cipher.Current(plain))
+}
+
+func TestTaskCipher_Rotate(t *testing.T) {
+	oldKey := bytes.Repeat([]byte{1}, 32)
+	newKey := bytes.Repeat([]byte{2}, 32)
+	cipher, err := NewTaskCipher(oldKey)
+	require.NoError(t, err)
+	sealed, err := cipher.EncryptTask(newEncryptionTestTask())
+	require.NoError(t, err)
+
+	require.NoError(t, cipher.Rotate(newKey))
+	assert.False(t, cipher.Current(sealed), "data sealed with the replaced key needs sealing again")
+	read := *sealed
+	read.History = append([]types.Message(nil), sealed.History...)
+	require.NoError(t, cipher.DecryptTask(&read), "the replaced key keeps opening its data")
+	assert.Equal(t, "My card number is 4242 4242 4242 4242", *read.History[0].Parts[0].Text)
+
+	restarted, err := NewTaskCipherFromConfig(config.EncryptionConfig{
+		Key:          base64.StdEncoding.EncodeToString(newKey),
+		PreviousKeys: []string{base64.StdEncoding.EncodeToString(oldKey)},
+	})
+	require.NoError(t, err)
+	require.NoError(t, restarted.DecryptTask(sealed))
+
+	resealed, err := cipher.EncryptTask(newEncryptionTestTask())
+	require.NoError(t, err)
+	stale, err := NewTaskCipher(oldKey)
+	require.NoError(t, err)
+	assert.ErrorContains(t, stale.DecryptTask(resealed), "unknown encryption key", "new data is sealed with the new key")
+
+	_, err = NewTaskCipher([]byte("short"))
+	assert.Error(t, err)
+	_, err = NewTaskCipherFromConfig(config.EncryptionConfig{Key: "not base64!"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,220 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	gin "github.com/gin-gonic/gin"
+	sdkotel "go.opentelemetry.io/otel"
+	attribute "go.opentelemetry.io/otel/attribute"
+	metric "go.opentelemetry.io/otel/metric"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// TaskFeedbackHandler receives an adk.task.feedback CloudEvent for every feedback entry
+// accepted through tasks/feedback, so it can be forwarded to downstream quality pipelines
+type TaskFeedbackHandler func(ctx context.Context, event cloudevents.Event)
+
+// taskFeedbackMetrics holds the instruments recorded for submitted task feedback
+type taskFeedbackMetrics struct {
+	submitted metric.Int64Counter
+	rating    metric.Int64Histogram
+}
+
+// newTaskFeedbackMetrics creates the task feedback instruments from the global meter provider
+func newTaskFeedbackMetrics(logger *zap.Logger) taskFeedbackMetrics {
+	meter := sdkotel.GetMeterProvider().Meter("github.com/inference-gateway/adk/server/feedback")
+	var metrics taskFeedbackMetrics
+	var err error
+
+	if metrics.submitted, err = meter.Int64Counter(
+		"a2a.task.feedback",
+		metric.WithDescription("Number of feedback entries submitted for tasks"),
+		metric.WithUnit("{feedback}"),
+	); err != nil {
+		logger.Warn("failed to create task feedback counter", zap.Error(err))
+	}
+
+	if metrics.rating, err = meter.Int64Histogram(
+		"a2a.task.feedback.rating",
+		metric.WithDescription("Distribution of ratings submitted for tasks"),
+		metric.WithUnit("{rating}"),
+		metric.WithExplicitBucketBoundaries(1, 2, 3, 4, 5),
+	); err != nil {
+		logger.Warn("failed to create task feedback rating histogram", zap.Error(err))
+	}
+
+	return metrics
+}
+
+// PromptVersion derives a stable identifier for a system prompt, so feedback can be
+// joined with the prompt that produced the rated task
+func PromptVersion(systemPrompt string) string {
+	if systemPrompt == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(systemPrompt))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// SetTaskFeedbackHandler sets the handler notified of every accepted feedback entry
+func (h *DefaultA2AProtocolHandler) SetTaskFeedbackHandler(handler TaskFeedbackHandler) {
+	h.feedbackHandler = handler
+}
+
+// SetVersionInfo sets the agent and prompt versions recorded with submitted feedback
+func (h *DefaultA2AProtocolHandler) SetVersionInfo(agentVersion, promptVersion string) {
+	h.versionMu.Lock()
+	defer h.versionMu.Unlock()
+	h.agentVersion = agentVersion
+	h.promptVersion = promptVersion
+}
+
+// HandleTaskFeedback processes tasks/feedback requests
+func (h *DefaultA2AProtocolHandler) HandleTaskFeedback(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	var params types.TaskFeedbackParams
+	paramsBytes, err := json.Marshal(req.Params)
+	if err != nil {
+		logger.Error("failed to marshal params", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
+		return
+	}
+
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		logger.Error("failed to parse tasks/feedback request", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
+		return
+	}
+
+	if params.ID == "" {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "task id is required")
+		return
+	}
+
+	if params.Rating < types.TaskFeedbackMinRating || params.Rating > types.TaskFeedbackMaxRating {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams),
+			fmt.Sprintf("rating must be between %d and %d", types.TaskFeedbackMinRating, types.TaskFeedbackMaxRating))
+		return
+	}
+
+	task, exists := h.taskManager.GetTask(params.ID)
+	if !exists {
+		logger.Error("task not found", zap.String("task_id", params.ID))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "task not found")
+		return
+	}
+
+	if params.MessageID != nil && !taskHasMessage(task, *params.MessageID) {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "message not found in task history")
+		return
+	}
+
+	h.versionMu.RLock()
+	agentVersion, promptVersion := h.agentVersion, h.promptVersion
+	h.versionMu.RUnlock()
+
+	feedback := types.TaskFeedback{
+		AgentVersion:  agentVersion,
+		Category:      params.Category,
+		ContextID:     task.ContextID,
+		CreatedAt:     h.clock.Now().UTC().Format(time.RFC3339Nano),
+		FeedbackID:    h.ids.NewID(),
+		MessageID:     params.MessageID,
+		Metadata:      params.Metadata,
+		PromptVersion: promptVersion,
+		Rating:        params.Rating,
+		TaskID:        task.ID,
+		Text:          params.Text,
+	}
+
+	if err := h.storeTaskFeedback(task, feedback); err != nil {
+		logger.Error("failed to store task feedback",
+			zap.Error(err),
+			zap.String("task_id", task.ID))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to store feedback")
+		return
+	}
+
+	logger.Info("task feedback recorded",
+		zap.String("task_id", task.ID),
+		zap.String("feedback_id", feedback.FeedbackID),
+		zap.Int("rating", feedback.Rating))
+
+	h.recordTaskFeedback(c, feedback)
+	feedbackEvent := types.NewTaskFeedbackEvent(feedback)
+	setEventRequestID(c.Request.Context(), &feedbackEvent)
+	if h.feedbackHandler != nil {
+		h.feedbackHandler(c, feedbackEvent)
+	}
+	h.events.taskEvent(task, feedbackEvent)
+
+	h.responseSender.SendSuccess(c, req.ID, feedback)
+}
+
+// storeTaskFeedback appends the feedback to the task metadata and persists the task
+// without touching its status, so the task keeps its place in listings
+func (h *DefaultA2AProtocolHandler) storeTaskFeedback(task *types.Task, feedback types.TaskFeedback) error {
+	existing, err := types.GetTaskFeedback(task)
+	if err != nil {
+		return err
+	}
+
+	metadata := make(map[string]any)
+	if task.Metadata != nil {
+		for key, value := range *task.Metadata {
+			metadata[key] = value
+		}
+	}
+	metadata[types.TaskFeedbackMetadataKey] = append(existing, feedback)
+
+	updated := *task
+	updated.Metadata = &metadata
+
+	switch updated.Status.State {
+	case types.TaskStateCompleted, types.TaskStateFailed, types.TaskStateCancelled, types.TaskStateRejected:
+		return h.storage.StoreDeadLetterTask(&updated)
+	default:
+		return h.storage.UpdateActiveTask(&updated)
+	}
+}
+
+// recordTaskFeedback records the feedback metrics
+func (h *DefaultA2AProtocolHandler) recordTaskFeedback(ctx context.Context, feedback types.TaskFeedback) {
+	category := ""
+	if feedback.Category != nil {
+		category = *feedback.Category
+	}
+	attrs := metric.WithAttributes(
+		attribute.String("category", category),
+		attribute.String("agent_version", feedback.AgentVersion),
+		attribute.String("prompt_version", feedback.PromptVersion),
+	)
+
+	if h.feedbackMetrics.submitted != nil {
+		h.feedbackMetrics.submitted.Add(ctx, 1, attrs, metric.WithAttributes(attribute.Int("rating", feedback.Rating)))
+	}
+	if h.feedbackMetrics.rating != nil {
+		h.feedbackMetrics.rating.Record(ctx, int64(feedback.Rating), attrs)
+	}
+}
+
+// taskHasMessage reports whether the task history or status contains the message
+func taskHasMessage(task *types.Task, messageID string) bool {
+	if task.Status.Message != nil && task.Status.Message.MessageID == messageID {
+		return true
+	}
+	for _, message := range task.History {
+		if message.MessageID == messageID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	mocks "github.com/inference-gateway/adk/server/mocks"
+
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestProtocolHandler_HandleTaskFeedback(t *testing.T) {
+	agentMessage := types.Message{MessageID: "msg-agent", Role: types.RoleAgent}
+
+	tests := []struct {
+		name           string
+		task           *types.Task
+		params         map[string]any
+		expectError    string
+		expectDeadTask bool
+	}{
+		{
+			name:           "completed task is updated in the dead letter queue",
+			task:           &types.Task{ID: "task-1", ContextID: "ctx-1", Status: types.TaskStatus{State: types.TaskStateCompleted}, History: []types.Message{agentMessage}},
+			params:         map[string]any{"id": "task-1", "rating": 5, "text": "spot on", "category": "accuracy", "messageId": "msg-agent"},
+			expectDeadTask: true,
+		},
+		{
+			name:   "active task is updated in place",
+			task:   &types.Task{ID: "task-1", ContextID: "ctx-1", Status: types.TaskStatus{State: types.TaskStateInputRequired}},
+			params: map[string]any{"id": "task-1", "rating": 2},
+		},
+		{
+			name:        "rating out of range",
+			params:      map[string]any{"id": "task-1", "rating": 6},
+			expectError: "rating must be between 1 and 5",
+		},
+		{
+			name:        "missing task id",
+			params:      map[string]any{"rating": 3},
+			expectError: "task id is required",
+		},
+		{
+			name:        "unknown task",
+			params:      map[string]any{"id": "missing", "rating": 3},
+			expectError: "task not found",
+		},
+		{
+			name:        "message not in task",
+			task:        &types.Task{ID: "task-1", ContextID: "ctx-1", Status: types.TaskStatus{State: types.TaskStateCompleted}},
+			params:      map[string]any{"id": "task-1", "rating": 3, "messageId": "other"},
+			expectError: "message not found in task history",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			storage := &mocks.FakeStorage{}
+			taskManager := &mocks.FakeTaskManager{}
+			taskManager.GetTaskReturns(tt.task, tt.task != nil)
+			h := server.NewDefaultA2AProtocolHandler(logger, storage, taskManager, server.NewDefaultResponseSender(logger))
+			h.SetVersionInfo("1.2.0", server.PromptVersion("You are helpful."))
+
+			var events []cloudevents.Event
+			h.SetTaskFeedbackHandler(func(ctx context.Context, event cloudevents.Event) {
+				events = append(events, event)
+			})
+
+			c, w := newRequestContext(t, "{}")
+			reqID := any("req-1")
+			h.HandleTaskFeedback(c, types.JSONRPCRequest{
+				JSONRPC: "2.0",
+				ID:      &reqID,
+				Method:  "tasks/feedback",
+				Params:  tt.params,
+			})
+
+			var response struct {
+				Result types.TaskFeedback `json:"result"`
+				Error  *struct {
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			if tt.expectError != "" {
+				require.NotNil(t, response.Error)
+				assert.Contains(t, response.Error.Message, tt.expectError)
+				assert.Empty(t, events)
+				assert.Equal(t, 0, storage.StoreDeadLetterTaskCallCount()+storage.UpdateActiveTaskCallCount())
+				return
+			}
+
+			require.Nil(t, response.Error)
+			feedback := response.Result
+			assert.NotEmpty(t, feedback.FeedbackID)
+			assert.Equal(t, "task-1", feedback.TaskID)
+			assert.Equal(t, "ctx-1", feedback.ContextID)
+			assert.Equal(t, "1.2.0", feedback.AgentVersion)
+			assert.Equal(t, server.PromptVersion("You are helpful."), feedback.PromptVersion)
+
+			var stored *types.Task
+			if tt.expectDeadTask {
+				require.Equal(t, 1, storage.StoreDeadLetterTaskCallCount())
+				assert.Equal(t, 0, storage.UpdateActiveTaskCallCount())
+				stored = storage.StoreDeadLetterTaskArgsForCall(0)
+			} else {
+				require.Equal(t, 1, storage.UpdateActiveTaskCallCount())
+				assert.Equal(t, 0, storage.StoreDeadLetterTaskCallCount())
+				stored = storage.UpdateActiveTaskArgsForCall(0)
+			}
+			assert.Equal(t, tt.task.Status, stored.Status)
+			assert.Nil(t, tt.task.Metadata)
+
+			recorded, err := types.GetTaskFeedback(stored)
+			require.NoError(t, err)
+			require.Len(t, recorded, 1)
+			assert.Equal(t, feedback.FeedbackID, recorded[0].FeedbackID)
+
+			require.Len(t, events, 1)
+			assert.Equal(t, types.EventTaskFeedback, events[0].Type())
+			assert.Equal(t, feedback.FeedbackID, events[0].ID())
+		})
+	}
+}
+
+func TestProtocolHandler_HandleTaskFeedbackAppends(t *testing.T) {
+	existing := map[string]any{
+		types.TaskFeedbackMetadataKey: []any{
+			map[string]any{"feedbackId": "fb-1", "taskId": "task-1", "contextId": "ctx-1", "rating": 1, "createdAt": "2026-01-01T00:00:00Z"},
+		},
+		"usage": "kept",
+	}
+	task := &types.Task{ID: "task-1", ContextID: "ctx-1", Status: types.TaskStatus{State: types.TaskStateCompleted}, Metadata: &existing}
+
+	logger := zap.NewNop()
+	storage := &mocks.FakeStorage{}
+	taskManager := &mocks.FakeTaskManager{}
+	taskManager.GetTaskReturns(task, true)
+	h := server.NewDefaultA2AProtocolHandler(logger, storage, taskManager, server.NewDefaultResponseSender(logger))
+
+	c, _ := newRequestContext(t, "{}")
+	reqID := any("req-1")
+	h.HandleTaskFeedback(c, types.JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      &reqID,
+		Method:  "tasks/feedback",
+		Params:  map[string]any{"id": "task-1", "rating": 4},
+	})
+
+	require.Equal(t, 1, storage.StoreDeadLetterTaskCallCount())
+	stored := storage.StoreDeadLetterTaskArgsForCall(0)
+	assert.Equal(t, "kept", (*stored.Metadata)["usage"])
+
+	recorded, err := types.GetTaskFeedback(stored)
+	require.NoError(t, err)
+	require.Len(t, recorded, 2)
+	assert.Equal(t, "fb-1", recorded[0].FeedbackID)
+	assert.Equal(t, 4, recorded[1].Rating)
+}
+
+func TestPromptVersion(t *testing.T) {
+	assert.Empty(t, server.PromptVersion(""))
+	assert.Len(t, server.PromptVersion("prompt"), 12)
+	assert.Equal(t, server.PromptVersion("prompt"), server.PromptVersion("prompt"))
+	assert.NotEqual(t, server.PromptVersion("prompt"), server.PromptVersion("prompt v2"))
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// SetResumeConfig sets the handling of messages sent to a task that has ended or is still working
+func (h *DefaultA2AProtocolHandler) SetResumeConfig(resumeConfig config.TaskResumeConfig) {
+	h.resumeConfig = resumeConfig
+}
+
+// recordTaskFollowUp records on a task started for a message sent to an ended task the ID of
+// the ended task
+func (h *DefaultA2AProtocolHandler) recordTaskFollowUp(task *types.Task, followUpOf string) {
+	if followUpOf == "" {
+		return
+	}
+
+	setTaskMetadata(task, types.FollowUpOfMetadataKey, followUpOf)
+}
+
+// QueueTaskInput queues a message sent to a task that is still working. The task continues
+// with the queued messages once its current execution ends.
+func (tm *DefaultTaskManager) QueueTaskInput(taskID string, message *types.Message) error {
+	tm.queuedInputsMu.Lock()
+	defer tm.queuedInputsMu.Unlock()
+
+	task, exists := tm.GetTask(taskID)
+	if !exists {
+		return NewTaskNotFoundError(taskID)
+	}
+	if task.Status.State != types.TaskStateSubmitted && task.Status.State != types.TaskStateWorking {
+		return NewInvalidTaskTransitionError(taskID, task.Status.State, types.TaskStateWorking)
+	}
+
+	tm.queuedInputs[taskID] = append(tm.queuedInputs[taskID], *message)
+	tm.logger.Info("input queued for working task",
+		zap.String("task_id", taskID),
+		zap.String("context_id", task.ContextID),
+		zap.Int("queued", len(tm.queuedInputs[taskID])))
+	return nil
+}
+
+// dispatchQueuedInput continues a task with the messages queued while it was working, once its
+// execution has ended. A task waiting for input or authorization is resumed with them, and a
+// task that completed or failed is followed up by a new task in the same context. The continued
+// task is queued for background processing and returned. Messages queued for a task that was
+// canceled or rejected are dropped, and messages for a task that is still working stay queued.
+func (tm *DefaultTaskManager) dispatchQueuedInput(ctx context.Context, taskID string) *types.Task {
+	tm.queuedInputsMu.Lock()
+	defer tm.queuedInputsMu.Unlock()
+
+	queued := tm.queuedInputs[taskID]
+	if len(queued) == 0 {
+		return nil
+	}
+
+	task, exists := tm.GetTask(taskID)
+	if !exists {
+		delete(tm.queuedInputs, taskID)
+		return nil
+	}
+
+	latest := queued[len(queued)-1]
+	var next *types.Task
+	switch task.Status.State {
+	case types.TaskStateSubmitted, types.TaskStateWorking:
+		return nil
+	case types.TaskStateInputRequired, types.TaskStateAuthRequired:
+		task.History = append(task.History, queued...)
+		task.Status.State = types.TaskStateWorking
+		task.Status.Message = &latest
+		tm.recordTransition(task, types.TaskActorClient)
+		next = task
+	case types.TaskStateCompleted, types.TaskStateFailed:
+		latest.TaskID = nil
+		history := append(tm.GetConversationHistory(task.ContextID), queued[:len(queued)-1]...)
+		next = tm.CreateTaskWithHistory(task.ContextID, types.TaskStateSubmitted, &latest, history)
+		setTaskMetadata(next, types.FollowUpOfMetadataKey, task.ID)
+		if userID := types.GetTaskUserID(task); userID != "" {
+			setTaskMetadata(next, types.UserIDMetadataKey, userID)
+		}
+	default:
+		delete(tm.queuedInputs, taskID)
+		tm.logger.Info("dropping input queued for ended task",
+			zap.String("task_id", taskID),
+			zap.String("state", string(task.Status.State)),
+			zap.Int("dropped", len(queued)))
+		return nil
+	}
+	delete(tm.queuedInputs, taskID)
+
+	if err := tm.UpdateTask(next); err != nil {
+		tm.logger.Error("failed to continue task with queued input",
+			zap.String("task_id", next.ID),
+			zap.Error(err))
+		return nil
+	}
+	if err := tm.storage.EnqueueTask(ctx, next, nil); err != nil {
+		tm.logger.Error("failed to enqueue task continued with queued input",
+			zap.String("task_id", next.ID),
+			zap.Error(err))
+		return nil
+	}
+
+	tm.logger.Info("task continued with queued input",
+		zap.String("task_id", next.ID),
+		zap.String("queued_for", taskID),
+		zap.Int("messages", len(queued)))
+	return next
+}
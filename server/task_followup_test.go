@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestCanTransitionTask(t *testing.T) {
+	assert.True(t, canTransitionTask(types.TaskStateInputRequired, types.TaskStateWorking))
+	assert.True(t, canTransitionTask(types.TaskStateSubmitted, types.TaskStateWorking))
+	assert.True(t, canTransitionTask(types.TaskStateWorking, types.TaskStateCompleted))
+	assert.False(t, canTransitionTask(types.TaskStateInputRequired, types.TaskStateCompleted))
+	for _, state := range []types.TaskState{
+		types.TaskStateCompleted, types.TaskStateFailed, types.TaskStateCancelled, types.TaskStateRejected,
+	} {
+		assert.True(t, isTerminalTaskState(state))
+		assert.False(t, canTransitionTask(state, types.TaskStateWorking), "%s is terminal", state)
+	}
+}
+
+func TestDefaultTaskManager_EndedTaskCannotMove(t *testing.T) {
+	for _, state := range []types.TaskState{
+		types.TaskStateCompleted, types.TaskStateFailed, types.TaskStateCancelled, types.TaskStateRejected,
+	} {
+		t.Run(string(state), func(t *testing.T) {
+			tm := NewDefaultTaskManager(zap.NewNop())
+			ended := tm.CreateTask("ctx-1", state, nil)
+			var transitionErr *InvalidTaskTransitionError
+
+			assert.ErrorAs(t, tm.UpdateState(ended.ID, types.TaskStateWorking), &transitionErr)
+
+			update, exists := tm.GetTask(ended.ID)
+			require.True(t, exists)
+			update.Status.State = types.TaskStateInputRequired
+			assert.ErrorAs(t, tm.UpdateTask(update), &transitionErr)
+
+			if state != types.TaskStateFailed {
+				assert.ErrorAs(t, tm.UpdateError(ended.ID, nil), &transitionErr)
+			}
+			assert.ErrorAs(t, tm.ResumeTaskWithInput(ended.ID, nil), &transitionErr)
+
+			stored, exists := tm.GetTask(ended.ID)
+			require.True(t, exists)
+			assert.Equal(t, state, stored.Status.State)
+		})
+	}
+}
+
+func TestA2AServer_MessageToExistingTask(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	send := func(t *testing.T, router *gin.Engine, taskID string) (*types.Task, *types.JSONRPCError) {
+		params := types.MessageSendParams{Message: types.Message{
+			MessageID: "follow-up",
+			Role:      types.RoleUser,
+			TaskID:    &taskID,
+			Parts:     []types.Part{types.CreateTextPart("one more thing")},
+		}}
+		encoded, err := json.Marshal(params)
+		require.NoError(t, err)
+		body := `{"jsonrpc":"2.0","id":"1","method":"message/send","params":` + string(encoded) + `}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+		var response struct {
+			Result *types.Task         `json:"result"`
+			Error  *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Result, response.Error
+	}
+
+	setup := func(resume config.TaskResumeConfig) (*A2AServerImpl, *gin.Engine) {
+		cfg := &config.Config{TaskResumeConfig: resume}
+		s := NewA2AServer(cfg, zap.NewNop(), nil)
+		s.SetAgentCard(types.AgentCard{Name: "assistant"})
+		return s, s.setupRouter(cfg)
+	}
+
+	t.Run("unknown task", func(t *testing.T) {
+		_, router := setup(config.TaskResumeConfig{})
+		_, rpcErr := send(t, router, "missing")
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, int(ErrTaskNotFound), rpcErr.Code)
+	})
+
+	t.Run("ended task is rejected", func(t *testing.T) {
+		s, router := setup(config.TaskResumeConfig{TerminalAction: config.TaskResumeActionReject})
+		done := s.taskManager.CreateTask("ctx-1", types.TaskStateCompleted, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+
+		_, rpcErr := send(t, router, done.ID)
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, int(ErrUnsupportedOperation), rpcErr.Code)
+		assert.Contains(t, rpcErr.Message, "has ended in state TASK_STATE_COMPLETED")
+	})
+
+	t.Run("ended task is followed up", func(t *testing.T) {
+		s, router := setup(config.TaskResumeConfig{TerminalAction: config.TaskResumeActionFollowUp})
+		done := s.taskManager.CreateTask("ctx-1", types.TaskStateCompleted, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+
+		task, rpcErr := send(t, router, done.ID)
+		require.Nil(t, rpcErr)
+		require.NotNil(t, task)
+		assert.NotEqual(t, done.ID, task.ID)
+		assert.Equal(t, "ctx-1", task.ContextID)
+
+		stored, exists := s.taskManager.GetTask(task.ID)
+		require.True(t, exists)
+		require.NotNil(t, stored.Metadata)
+		assert.Equal(t, done.ID, (*stored.Metadata)[types.FollowUpOfMetadataKey])
+	})
+
+	t.Run("input for working task is rejected", func(t *testing.T) {
+		s, router := setup(config.TaskResumeConfig{})
+		working := s.taskManager.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+
+		_, rpcErr := send(t, router, working.ID)
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, int(ErrUnsupportedOperation), rpcErr.Code)
+		assert.Contains(t, rpcErr.Message, "is already in state TASK_STATE_WORKING")
+
+		stored, exists := s.taskManager.GetTask(working.ID)
+		require.True(t, exists)
+		assert.Equal(t, "m-1", stored.Status.Message.MessageID)
+	})
+
+	t.Run("input for working task is queued", func(t *testing.T) {
+		s, router := setup(config.TaskResumeConfig{QueueWhileWorking: true})
+		tm := s.taskManager.(*DefaultTaskManager)
+		working := tm.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+
+		task, rpcErr := send(t, router, working.ID)
+		require.Nil(t, rpcErr)
+		require.NotNil(t, task)
+		assert.Equal(t, working.ID, task.ID)
+		assert.Equal(t, types.TaskStateWorking, task.Status.State)
+		assert.Nil(t, tm.dispatchQueuedInput(context.Background(), working.ID), "input stays queued while the task works")
+
+		require.NoError(t, tm.UpdateState(working.ID, types.TaskStateInputRequired))
+		resumed := tm.dispatchQueuedInput(context.Background(), working.ID)
+		require.NotNil(t, resumed)
+		assert.Equal(t, working.ID, resumed.ID)
+		assert.Equal(t, types.TaskStateWorking, resumed.Status.State)
+		assert.Equal(t, "follow-up", resumed.Status.Message.MessageID)
+		assert.Nil(t, tm.dispatchQueuedInput(context.Background(), working.ID), "queued input is dispatched once")
+	})
+
+	t.Run("input queued for completed task starts a follow-up", func(t *testing.T) {
+		s, router := setup(config.TaskResumeConfig{QueueWhileWorking: true})
+		tm := s.taskManager.(*DefaultTaskManager)
+		working := tm.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{MessageID: "m-1", Role: types.RoleUser})
+
+		_, rpcErr := send(t, router, working.ID)
+		require.Nil(t, rpcErr)
+
+		require.NoError(t, tm.UpdateState(working.ID, types.TaskStateCompleted))
+		next := tm.dispatchQueuedInput(context.Background(), working.ID)
+		require.NotNil(t, next)
+		assert.NotEqual(t, working.ID, next.ID)
+		assert.Equal(t, "ctx-1", next.ContextID)
+		assert.Equal(t, types.TaskStateSubmitted, next.Status.State)
+		require.NotNil(t, next.Metadata)
+		assert.Equal(t, working.ID, (*next.Metadata)[types.FollowUpOfMetadataKey])
+	})
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// HandleTaskFork processes tasks/fork requests
+func (h *DefaultA2AProtocolHandler) HandleTaskFork(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	var params types.TaskForkParams
+	paramsBytes, err := json.Marshal(req.Params)
+	if err != nil {
+		logger.Error("failed to marshal params", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
+		return
+	}
+
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		logger.Error("failed to parse tasks/fork request", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
+		return
+	}
+
+	if params.ID == "" {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "task id is required")
+		return
+	}
+
+	if _, err := parseTaskLabels(params.Metadata); err != nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), err.Error())
+		return
+	}
+
+	source, exists := h.taskManager.GetTask(params.ID)
+	if !exists {
+		h.responseSender.SendError(c, req.ID, int(ErrTaskNotFound), "task not found")
+		return
+	}
+
+	if params.HistoryIndex < 0 || params.HistoryIndex >= len(source.History) {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams),
+			fmt.Sprintf("history index %d is out of range for a task with %d messages", params.HistoryIndex, len(source.History)))
+		return
+	}
+
+	task, err := h.forkTask(c.Request.Context(), source, params)
+	if err != nil {
+		logger.Error("failed to fork task",
+			zap.String("task_id", source.ID),
+			zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to fork task")
+		return
+	}
+
+	logger.Info("task forked",
+		zap.String("task_id", task.ID),
+		zap.String("forked_from", source.ID),
+		zap.String("context_id", task.ContextID),
+		zap.Int("history_index", params.HistoryIndex))
+	h.responseSender.SendSuccess(c, req.ID, task)
+}
+
+// forkTask copies the history of a task up to and including the message at the history index
+// into a new task in the same context. A fork ending with a user message is queued for
+// processing, so the agent answers that message again; any other fork waits for input.
+func (h *DefaultA2AProtocolHandler) forkTask(ctx context.Context, source *types.Task, params types.TaskForkParams) (*types.Task, error) {
+	history := source.History[:params.HistoryIndex+1]
+	last := history[len(history)-1]
+
+	state := types.TaskStateInputRequired
+	if last.Role == types.RoleUser {
+		state = types.TaskStateSubmitted
+	}
+
+	task := h.taskManager.CreateTaskWithHistory(source.ContextID, state, &last, history[:len(history)-1])
+	for i := range task.History {
+		if task.History[i].TaskID != nil {
+			task.History[i].TaskID = &task.ID
+		}
+	}
+	if len(task.History) > 0 {
+		task.Status.Message = &task.History[len(task.History)-1]
+	}
+
+	metadata := map[string]any{
+		types.ForkedFromMetadataKey: source.ID,
+		types.ForkedAtMetadataKey:   params.HistoryIndex,
+	}
+	if userID := types.GetTaskUserID(source); userID != "" {
+		metadata[types.UserIDMetadataKey] = userID
+	}
+	task.Metadata = &metadata
+	h.recordTaskMetadata(task, params.Metadata)
+
+	if err := h.storage.UpdateActiveTask(task); err != nil {
+		return nil, fmt.Errorf("failed to store forked task: %w", err)
+	}
+
+	if state == types.TaskStateSubmitted {
+		if err := h.storage.EnqueueTask(ctx, task, nil); err != nil {
+			return nil, fmt.Errorf("failed to queue forked task: %w", err)
+		}
+	}
+	return task, nil
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestA2AServer_TaskFork(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "assistant"})
+	router := s.setupRouter(cfg)
+
+	fork := func(params types.TaskForkParams) (*types.Task, *types.JSONRPCError) {
+		encoded, err := json.Marshal(params)
+		require.NoError(t, err)
+		body := `{"jsonrpc":"2.0","id":"1","method":"tasks/fork","params":` + string(encoded) + `}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+		var response struct {
+			Result *types.Task         `json:"result"`
+			Error  *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Result, response.Error
+	}
+
+	message := func(id string, role types.Role) types.Message {
+		return types.Message{MessageID: id, Role: role, Parts: []types.Part{types.CreateTextPart(id)}}
+	}
+	source := s.taskManager.CreateTaskWithHistory("ctx-1", types.TaskStateCompleted, nil, []types.Message{
+		message("question", types.RoleUser),
+		message("answer", types.RoleAgent),
+		message("follow-up", types.RoleUser),
+		message("second answer", types.RoleAgent),
+	})
+	for i := range source.History {
+		source.History[i].TaskID = &source.ID
+	}
+
+	t.Run("fork at a user message is queued for a new answer", func(t *testing.T) {
+		task, rpcErr := fork(types.TaskForkParams{ID: source.ID, HistoryIndex: 2, Metadata: map[string]any{"variant": "b"}})
+		require.Nil(t, rpcErr)
+		require.NotNil(t, task)
+		assert.NotEqual(t, source.ID, task.ID)
+		assert.Equal(t, "ctx-1", task.ContextID)
+		assert.Equal(t, types.TaskStateSubmitted, task.Status.State)
+		require.Len(t, task.History, 3)
+		assert.Equal(t, "follow-up", task.Status.Message.MessageID)
+		assert.Equal(t, task.ID, *task.History[0].TaskID, "copied messages belong to the fork")
+		require.NotNil(t, task.Metadata)
+		assert.Equal(t, source.ID, (*task.Metadata)[types.ForkedFromMetadataKey])
+		assert.EqualValues(t, 2, (*task.Metadata)[types.ForkedAtMetadataKey])
+		assert.Equal(t, "b", (*task.Metadata)["variant"])
+
+		unchanged, exists := s.taskManager.GetTask(source.ID)
+		require.True(t, exists)
+		assert.Len(t, unchanged.History, 4)
+		assert.Equal(t, source.ID, *unchanged.History[0].TaskID)
+	})
+
+	t.Run("fork at an agent message waits for input", func(t *testing.T) {
+		task, rpcErr := fork(types.TaskForkParams{ID: source.ID, HistoryIndex: 1})
+		require.Nil(t, rpcErr)
+		require.NotNil(t, task)
+		assert.Equal(t, types.TaskStateInputRequired, task.Status.State)
+		require.Len(t, task.History, 2)
+		assert.Equal(t, "answer", task.Status.Message.MessageID)
+	})
+
+	t.Run("history index out of range", func(t *testing.T) {
+		_, rpcErr := fork(types.TaskForkParams{ID: source.ID, HistoryIndex: 4})
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, int(ErrInvalidParams), rpcErr.Code)
+		assert.Equal(t, "history index 4 is out of range for a task with 4 messages", rpcErr.Message)
+	})
+
+	t.Run("unknown task", func(t *testing.T) {
+		_, rpcErr := fork(types.TaskForkParams{ID: "missing"})
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, int(ErrTaskNotFound), rpcErr.Code)
+	})
+}
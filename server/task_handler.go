@@ -4,10 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	gin "github.com/gin-gonic/gin"
-	uuid "github.com/google/uuid"
+	config "github.com/inference-gateway/adk/server/config"
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
 	types "github.com/inference-gateway/adk/types"
 	zap "go.uber.org/zap"
 )
@@ -26,6 +32,10 @@ type A2AProtocolHandler interface {
 	// HandleMessageSend processes message/send requests
 	HandleMessageSend(c *gin.Context, req types.JSONRPCRequest)
 
+	// HandleMessageSendBatch processes message/sendBatch requests, starting a task for each
+	// message of the batch
+	HandleMessageSendBatch(c *gin.Context, req types.JSONRPCRequest)
+
 	// HandleMessageStream processes message/stream requests
 	HandleMessageStream(c *gin.Context, req types.JSONRPCRequest, streamingHandler StreamableTaskHandler)
 
@@ -38,6 +48,56 @@ type A2AProtocolHandler interface {
 	// HandleTaskCancel processes tasks/cancel requests
 	HandleTaskCancel(c *gin.Context, req types.JSONRPCRequest)
 
+	// HandleTaskFeedback processes tasks/feedback requests, recording a rating and optional
+	// free text and category against a task
+	HandleTaskFeedback(c *gin.Context, req types.JSONRPCRequest)
+
+	// HandleTaskShare processes tasks/share requests, issuing a read-only share link
+	// for a redacted transcript of a task
+	HandleTaskShare(c *gin.Context, req types.JSONRPCRequest)
+
+	// HandleTaskUsage processes tasks/usage requests, summing the token usage recorded on
+	// the tasks of a context for cost attribution
+	HandleTaskUsage(c *gin.Context, req types.JSONRPCRequest)
+
+	// HandleTaskFork processes tasks/fork requests, copying the history of a task up to a
+	// message into a new task in the same context
+	HandleTaskFork(c *gin.Context, req types.JSONRPCRequest)
+
+	// HandleContextCreate processes contexts/create requests, creating a context with its
+	// metadata and shared memory
+	HandleContextCreate(c *gin.Context, req types.JSONRPCRequest)
+
+	// HandleContextGet processes contexts/get requests
+	HandleContextGet(c *gin.Context, req types.JSONRPCRequest)
+
+	// HandleContextList processes contexts/list requests
+	HandleContextList(c *gin.Context, req types.JSONRPCRequest)
+
+	// HandleContextDelete processes contexts/delete requests, deleting a context with its
+	// tasks and stored artifacts
+	HandleContextDelete(c *gin.Context, req types.JSONRPCRequest)
+
+	// HandleArtifactList processes artifacts/list requests, listing the artifact files of a
+	// task or context with their download links
+	HandleArtifactList(c *gin.Context, req types.JSONRPCRequest)
+
+	// HandleMemoryCreate processes memories/create requests, storing a memory for a user or
+	// a context
+	HandleMemoryCreate(c *gin.Context, req types.JSONRPCRequest)
+
+	// HandleMemoryGet processes memories/get requests
+	HandleMemoryGet(c *gin.Context, req types.JSONRPCRequest)
+
+	// HandleMemoryList processes memories/list requests
+	HandleMemoryList(c *gin.Context, req types.JSONRPCRequest)
+
+	// HandleMemoryUpdate processes memories/update requests, replacing the content of a memory
+	HandleMemoryUpdate(c *gin.Context, req types.JSONRPCRequest)
+
+	// HandleMemoryDelete processes memories/delete requests
+	HandleMemoryDelete(c *gin.Context, req types.JSONRPCRequest)
+
 	// HandleTaskPushNotificationConfigSet processes tasks/pushNotificationConfig/set requests
 	HandleTaskPushNotificationConfigSet(c *gin.Context, req types.JSONRPCRequest)
 
@@ -55,8 +115,8 @@ type A2AProtocolHandler interface {
 	HandleTaskResubscribe(c *gin.Context, req types.JSONRPCRequest, streamingHandler StreamableTaskHandler)
 
 	// HandleGetAuthenticatedExtendedCard processes agent/getAuthenticatedExtendedCard requests,
-	// returning the authenticated/extended agent card (the same card object the unauthenticated
-	// `.well-known/agent-card.json` endpoint exposes, but served over JSON-RPC).
+	// returning the authenticated/extended agent card. The server passes the extended card when
+	// one is set, and otherwise the card the `.well-known/agent-card.json` endpoint exposes.
 	HandleGetAuthenticatedExtendedCard(c *gin.Context, req types.JSONRPCRequest, agentCard *types.AgentCard)
 }
 
@@ -96,6 +156,7 @@ type DefaultBackgroundTaskHandler struct {
 	agent               OpenAICompatibleAgent
 	artifactService     ArtifactService
 	enableUsageMetadata bool
+	events              *eventExport
 }
 
 // NewDefaultBackgroundTaskHandler creates a new default background task handler
@@ -126,6 +187,11 @@ func (bth *DefaultBackgroundTaskHandler) GetAgent() OpenAICompatibleAgent {
 	return bth.agent
 }
 
+// setEventExport sets the export of the events of the processed tasks to an event sink
+func (bth *DefaultBackgroundTaskHandler) setEventExport(events *eventExport) {
+	bth.events = events
+}
+
 // SetEnableUsageMetadata toggles whether usage metadata (token counts and
 // execution statistics) is attached to the task's metadata when the task
 // reaches a terminal state.
@@ -149,7 +215,8 @@ func (bth *DefaultBackgroundTaskHandler) HandleTask(ctx context.Context, task *t
 
 // processWithAgentBackground processes a task using agent capabilities with automatic input-required handling
 func (bth *DefaultBackgroundTaskHandler) processWithAgentBackground(ctx context.Context, task *types.Task, message *types.Message) (*types.Task, error) {
-	bth.logger.Info("processing background task with agent capabilities",
+	logger := requestLogger(ctx, bth.logger)
+	logger.Info("processing background task with agent capabilities",
 		zap.String("task_id", task.ID))
 
 	messages := make([]types.Message, len(task.History))
@@ -165,7 +232,7 @@ func (bth *DefaultBackgroundTaskHandler) processWithAgentBackground(ctx context.
 
 	eventChan, err := bth.agent.RunWithStream(toolCtx, messages)
 	if err != nil {
-		bth.logger.Error("agent streaming failed to start", zap.Error(err))
+		logger.Error("agent streaming failed to start", zap.Error(err))
 
 		task.Status.State = types.TaskStateFailed
 		task.Status.Message = &types.Message{
@@ -180,13 +247,14 @@ func (bth *DefaultBackgroundTaskHandler) processWithAgentBackground(ctx context.
 		return task, nil
 	}
 
-	var finalMessage *types.Message
+	aggregator := NewStreamAggregator()
 
 	for event := range eventChan {
 		eventType := event.Type()
-		bth.logger.Debug("background handler received event",
+		logger.Debug("background handler received event",
 			zap.String("task_id", task.ID),
 			zap.String("event_type", eventType))
+		bth.events.taskEvent(task, event)
 
 		switch eventType {
 		case types.EventTaskStatusChanged:
@@ -195,9 +263,11 @@ func (bth *DefaultBackgroundTaskHandler) processWithAgentBackground(ctx context.
 				task.Status.State = statusData.State
 				if statusData.Message != nil {
 					task.Status.Message = statusData.Message
+				} else if statusData.State == types.TaskStateCompleted {
+					task.Status.Message = aggregator.Final()
 				}
 
-				bth.logger.Info("background task status changed",
+				logger.Info("background task status changed",
 					zap.String("task_id", task.ID),
 					zap.String("state", string(statusData.State)))
 
@@ -212,13 +282,13 @@ func (bth *DefaultBackgroundTaskHandler) processWithAgentBackground(ctx context.
 		case types.EventIterationCompleted:
 			var iterationMessage types.Message
 			if err := event.DataAs(&iterationMessage); err == nil {
-				finalMessage = &iterationMessage
-				bth.logger.Debug("captured iteration message",
+				aggregator.CompleteIteration(iterationMessage)
+				logger.Debug("captured iteration message",
 					zap.String("task_id", task.ID),
 					zap.String("message_id", iterationMessage.MessageID))
 			}
 
-		case types.EventInputRequired:
+		case types.EventInputRequired, types.EventAuthRequired:
 			var inputMessage types.Message
 			if err := event.DataAs(&inputMessage); err == nil {
 				if task.History == nil {
@@ -226,38 +296,42 @@ func (bth *DefaultBackgroundTaskHandler) processWithAgentBackground(ctx context.
 				}
 				task.History = append(task.History, inputMessage)
 
-				task.Status.State = types.TaskStateInputRequired
+				task.Status.State = pausedTaskState(eventType)
 				task.Status.Message = &inputMessage
 
-				bth.logger.Info("background task paused for user input",
+				logger.Info("background task paused for user input",
 					zap.String("task_id", task.ID),
 					zap.String("state", string(task.Status.State)))
 
+				bth.populateTaskMetadata(task, usageTracker)
 				return task, nil
 			}
 
 		case types.EventDelta:
-			continue
+			var deltaMessage types.Message
+			if err := event.DataAs(&deltaMessage); err == nil {
+				aggregator.AddDelta(deltaMessage)
+			}
 
 		case types.EventToolStarted, types.EventToolCompleted, types.EventToolFailed, types.EventToolResult:
-			bth.logger.Debug("tool event in background task",
+			logger.Debug("tool event in background task",
 				zap.String("task_id", task.ID),
 				zap.String("event_type", eventType))
 		}
 	}
 
-	if finalMessage != nil {
+	if finalMessage := aggregator.Final(); finalMessage != nil {
 		task.Status.State = types.TaskStateCompleted
 		task.Status.Message = finalMessage
 
-		bth.logger.Info("background task completed successfully",
+		logger.Info("background task completed successfully",
 			zap.String("task_id", task.ID))
 
 		bth.populateTaskMetadata(task, usageTracker)
 		return task, nil
 	}
 
-	bth.logger.Warn("background task completed but no final message received",
+	logger.Warn("background task completed but no final message received",
 		zap.String("task_id", task.ID))
 
 	task.Status.State = types.TaskStateCompleted
@@ -277,7 +351,7 @@ func (bth *DefaultBackgroundTaskHandler) processWithAgentBackground(ctx context.
 
 // processWithoutAgentBackground processes a task without agent capabilities for background
 func (bth *DefaultBackgroundTaskHandler) processWithoutAgentBackground(ctx context.Context, task *types.Task, message *types.Message) (*types.Task, error) {
-	bth.logger.Info("processing background task without agent",
+	requestLogger(ctx, bth.logger).Info("processing background task without agent",
 		zap.String("task_id", task.ID))
 
 	response := &types.Message{
@@ -347,9 +421,11 @@ func (sth *DefaultStreamingTaskHandler) IsUsageMetadataEnabled() bool {
 }
 
 // HandleStreamingTask processes a task and returns a channel of CloudEvents
-// It forwards events from the agent directly without conversion
+// It forwards events from the agent, streaming the artifacts added to the task and pausing
+// the task for input-required status changes like the background handler does
 func (sth *DefaultStreamingTaskHandler) HandleStreamingTask(ctx context.Context, task *types.Task, message *types.Message) (<-chan cloudevents.Event, error) {
-	sth.logger.Info("processing streaming task",
+	logger := requestLogger(ctx, sth.logger)
+	logger.Info("processing streaming task",
 		zap.String("task_id", task.ID),
 		zap.String("context_id", task.ContextID),
 		zap.Bool("has_agent", sth.agent != nil))
@@ -369,6 +445,12 @@ func (sth *DefaultStreamingTaskHandler) HandleStreamingTask(ctx context.Context,
 		toolCtx = context.WithValue(toolCtx, ArtifactServiceContextKey, sth.artifactService)
 	}
 
+	// the artifacts of earlier runs were streamed with them
+	announced := make(map[string]bool, len(task.Artifacts))
+	for _, artifact := range task.Artifacts {
+		announced[artifact.ArtifactID] = true
+	}
+
 	eventChan, err := sth.agent.RunWithStream(toolCtx, messages)
 	if err != nil {
 		return nil, err
@@ -378,29 +460,114 @@ func (sth *DefaultStreamingTaskHandler) HandleStreamingTask(ctx context.Context,
 	go func() {
 		defer close(wrappedChan)
 		for event := range eventChan {
-			if event.Type() == types.EventTaskStatusChanged {
+			switch event.Type() {
+			case types.EventArtifactUpdate:
+				var artifact types.Artifact
+				if err := event.DataAs(&artifact); err == nil {
+					announced[artifact.ArtifactID] = true
+				}
+			case types.EventTaskStatusChanged:
 				var statusData types.TaskStatus
 				if err := event.DataAs(&statusData); err == nil {
+					if statusData.State == types.TaskStateInputRequired || statusData.State == types.TaskStateAuthRequired {
+						event = sth.inputRequiredEvent(task, statusData.State, statusData.Message)
+						break
+					}
 					if statusData.State == types.TaskStateCompleted ||
 						statusData.State == types.TaskStateFailed ||
 						statusData.State == types.TaskStateCancelled {
+						sth.extractArtifacts(task, announced, wrappedChan)
 						sth.populateTaskMetadata(task, usageTracker)
 					}
 				}
 			}
+
+			if event.Type() == types.EventInputRequired || event.Type() == types.EventAuthRequired {
+				sth.extractArtifacts(task, announced, wrappedChan)
+				sth.populateTaskMetadata(task, usageTracker)
+				wrappedChan <- event
+
+				logger.Info("streaming task paused for user input",
+					zap.String("task_id", task.ID))
+				// like the background handler, the task pauses here; later events of the
+				// agent are drained so it is not blocked on a full channel
+				for range eventChan {
+				}
+				return
+			}
 			wrappedChan <- event
 		}
+		sth.extractArtifacts(task, announced, wrappedChan)
 	}()
 
 	return wrappedChan, nil
 }
 
+// extractArtifacts streams the artifacts added to the task during the run that the agent did
+// not announce with an artifact update event, such as the artifacts created by tools of custom
+// agents, so they reach streaming clients as they reach the result of a background task
+func (sth *DefaultStreamingTaskHandler) extractArtifacts(task *types.Task, announced map[string]bool, events chan<- cloudevents.Event) {
+	for _, artifact := range task.Artifacts {
+		if announced[artifact.ArtifactID] {
+			continue
+		}
+		announced[artifact.ArtifactID] = true
+		sth.logger.Debug("streaming artifact added to the task",
+			zap.String("task_id", task.ID),
+			zap.String("artifact_id", artifact.ArtifactID))
+		events <- types.NewArtifactUpdateEvent(artifact)
+	}
+}
+
+// inputRequiredEvent converts a status change to input-required or auth-required into an
+// input-required or auth-required event, so the task pauses for the user instead of completing
+// when the stream ends
+func (sth *DefaultStreamingTaskHandler) inputRequiredEvent(task *types.Task, state types.TaskState, message *types.Message) cloudevents.Event {
+	if state == types.TaskStateAuthRequired {
+		if message == nil {
+			message = types.NewAuthRequiredMessage(task.ID, types.AuthRequest{})
+			message.TaskID = &task.ID
+			message.ContextID = &task.ContextID
+		}
+		return types.NewMessageEvent(types.EventAuthRequired, message.MessageID, message)
+	}
+	if message == nil {
+		message = types.NewInputRequiredMessage(task.ID, "Additional input is required to continue")
+		message.TaskID = &task.ID
+		message.ContextID = &task.ContextID
+	}
+	return types.NewMessageEvent(types.EventInputRequired, message.MessageID, message)
+}
+
 // DefaultA2AProtocolHandler implements the A2AProtocolHandler interface
 type DefaultA2AProtocolHandler struct {
-	logger         *zap.Logger
-	storage        Storage
-	taskManager    TaskManager
-	responseSender ResponseSender
+	logger          *zap.Logger
+	storage         Storage
+	taskManager     TaskManager
+	responseSender  ResponseSender
+	feedbackHandler TaskFeedbackHandler
+	feedbackMetrics taskFeedbackMetrics
+	taskShares      *TaskShareService
+	artifactService ArtifactService
+	languagePolicy  *LanguagePolicy
+	guardrails      *Guardrails
+	redactor        *Redactor
+	fileIngestion   *FileIngestion
+	speechOutput    *SpeechOutput
+	previews        *ArtifactPreviews
+	transcripts     *TaskTranscripts
+	memories        *MemoryService
+	events          *eventExport
+	resumeConfig    config.TaskResumeConfig
+	drain           *streamDrain
+	checkpoints     *taskCheckpoints
+	credentials     *taskCredentials
+	heartbeat       time.Duration
+	ids             IDGenerator
+	clock           Clock
+	versionMu       sync.RWMutex
+	agentVersion    string
+	promptVersion   string
 }
 
 // NewDefaultA2AProtocolHandler creates a new default A2A protocol handler
@@ -411,54 +578,185 @@ func NewDefaultA2AProtocolHandler(
 	responseSender ResponseSender,
 ) *DefaultA2AProtocolHandler {
 	return &DefaultA2AProtocolHandler{
-		logger:         logger,
-		storage:        storage,
-		taskManager:    taskManager,
-		responseSender: responseSender,
+		logger:          logger,
+		storage:         storage,
+		taskManager:     taskManager,
+		responseSender:  responseSender,
+		feedbackMetrics: newTaskFeedbackMetrics(logger),
+		ids:             UUIDGenerator{},
+		clock:           SystemClock{},
 	}
 }
 
+// SetIDGenerator sets the generator used for message, context and feedback IDs
+func (h *DefaultA2AProtocolHandler) SetIDGenerator(ids IDGenerator) {
+	h.ids = ids
+}
+
+// SetClock sets the clock used for feedback timestamps
+func (h *DefaultA2AProtocolHandler) SetClock(clock Clock) {
+	h.clock = clock
+}
+
+// SetHeartbeatInterval sets the idle time after which a working streaming task sends a
+// heartbeat status event. Zero disables heartbeats.
+func (h *DefaultA2AProtocolHandler) SetHeartbeatInterval(interval time.Duration) {
+	h.heartbeat = interval
+}
+
+// setStreamDrain sets the drain that tracks in-flight streams during shutdown
+func (h *DefaultA2AProtocolHandler) setStreamDrain(drain *streamDrain) {
+	h.drain = drain
+}
+
+// setTaskCheckpoints sets the checkpoints of the agent loop of streaming tasks
+func (h *DefaultA2AProtocolHandler) setTaskCheckpoints(checkpoints *taskCheckpoints) {
+	h.checkpoints = checkpoints
+}
+
+// setTaskCredentials sets the holder of the credentials auth-required tasks are resumed with
+func (h *DefaultA2AProtocolHandler) setTaskCredentials(credentials *taskCredentials) {
+	h.credentials = credentials
+}
+
+// SetLanguagePolicy sets the policy applied to the language of incoming messages
+func (h *DefaultA2AProtocolHandler) SetLanguagePolicy(policy *LanguagePolicy) {
+	h.languagePolicy = policy
+}
+
+// SetGuardrails sets the guardrails run on incoming messages and streamed agent messages
+func (h *DefaultA2AProtocolHandler) SetGuardrails(guardrails *Guardrails) {
+	h.guardrails = guardrails
+}
+
+// SetFileIngestion sets the processing applied to the file parts of incoming messages
+func (h *DefaultA2AProtocolHandler) SetFileIngestion(ingestion *FileIngestion) {
+	h.fileIngestion = ingestion
+}
+
+// SetSpeechOutput sets the synthesis of the agent's responses for requests that accept audio
+func (h *DefaultA2AProtocolHandler) SetSpeechOutput(output *SpeechOutput) {
+	h.speechOutput = output
+}
+
+// SetArtifactPreviews sets the inline previews of small file artifacts attached to the response
+// message of completed tasks
+func (h *DefaultA2AProtocolHandler) SetArtifactPreviews(previews *ArtifactPreviews) {
+	h.previews = previews
+}
+
+// SetArtifactRedactor sets the masking of personal data in the artifacts of completed tasks
+// before they are stored
+func (h *DefaultA2AProtocolHandler) SetArtifactRedactor(redactor *Redactor) {
+	h.redactor = redactor
+}
+
+// SetTaskTranscripts sets the rendering of the conversation of completed tasks into a
+// transcript artifact
+func (h *DefaultA2AProtocolHandler) SetTaskTranscripts(transcripts *TaskTranscripts) {
+	h.transcripts = transcripts
+}
+
+// SetMemoryService sets the long-term memory recalled in agent runs, extracted from completed
+// tasks and managed through the memories/* methods
+func (h *DefaultA2AProtocolHandler) SetMemoryService(memories *MemoryService) {
+	h.memories = memories
+}
+
+// setEventExport sets the export of the events of streamed tasks and of feedback to an event
+// sink
+func (h *DefaultA2AProtocolHandler) setEventExport(events *eventExport) {
+	h.events = events
+}
+
 // CreateTaskFromMessage creates a task directly from message parameters
 func (h *DefaultA2AProtocolHandler) CreateTaskFromMessage(ctx context.Context, params types.MessageSendParams) (*types.Task, error) {
+	task, _, err := h.createTaskFromMessage(ctx, params)
+	return task, err
+}
+
+// createTaskFromMessage creates or resumes a task from message parameters. When the language
+// policy refuses the message or the input guardrails block it, the refused task is returned
+// together with the refusal and must not be processed further. A message sent to a task that
+// has ended either fails with an InvalidTaskTransitionError or starts a follow-up task in the
+// same context, and a message sent to a working task can be queued, in which case the working
+// task is returned together with the queued message and must not be processed further either.
+// So is a task waiting for the fields of an input request that the message leaves unanswered,
+// returned together with the message asking for the remaining fields.
+func (h *DefaultA2AProtocolHandler) createTaskFromMessage(ctx context.Context, params types.MessageSendParams) (*types.Task, *types.Message, error) {
+	logger := requestLogger(ctx, h.logger)
 	if len(params.Message.Parts) == 0 {
-		return nil, fmt.Errorf("empty message parts not allowed")
+		return nil, nil, fmt.Errorf("empty message parts not allowed")
 	}
 
 	enrichedMessage := params.Message
 	if enrichedMessage.MessageID == "" {
-		enrichedMessage.MessageID = uuid.New().String()
+		enrichedMessage.MessageID = h.ids.NewID()
 	}
 
-	if params.Message.TaskID != nil {
-		taskID := *params.Message.TaskID
+	if err := h.fileIngestion.Ingest(ctx, &enrichedMessage); err != nil {
+		return nil, nil, fmt.Errorf("failed to ingest file parts: %w", err)
+	}
 
-		err := h.taskManager.ResumeTaskWithInput(taskID, &enrichedMessage)
-		if err != nil {
-			h.logger.Error("failed to resume task with input",
-				zap.String("task_id", taskID),
-				zap.Error(err))
-			return nil, fmt.Errorf("failed to resume task: %w", err)
+	var decision LanguageDecision
+	if h.languagePolicy != nil {
+		decision = h.languagePolicy.Apply(ctx, &enrichedMessage)
+	}
+
+	refusal := decision.Refusal
+	rejection := "unsupported language"
+	var guard GuardrailDecision
+	if refusal == nil {
+		if guard = h.guardrails.CheckInput(ctx, &enrichedMessage); guard.Blocked {
+			refusal = h.guardrails.refusal(&enrichedMessage, guard)
+			rejection = "blocked by input guardrails"
 		}
+	}
 
-		task, exists := h.taskManager.GetTask(taskID)
+	var followUpOf string
+	if params.Message.TaskID != nil {
+		taskID := *params.Message.TaskID
+		current, exists := h.taskManager.GetTask(taskID)
 		if !exists {
-			h.logger.Error("failed to get resumed task",
-				zap.String("task_id", taskID))
-			return nil, fmt.Errorf("resumed task not found: %s", taskID)
+			return nil, nil, NewTaskNotFoundError(taskID)
 		}
 
-		h.logger.Info("task resumed with user input",
-			zap.String("task_id", taskID),
-			zap.String("context_id", task.ContextID))
-
-		return task, nil
+		switch {
+		case isTerminalTaskState(current.Status.State) && h.resumeConfig.TerminalAction == config.TaskResumeActionFollowUp:
+			followUpOf = current.ID
+			params.Message.ContextID = &current.ContextID
+			enrichedMessage.TaskID = nil
+			enrichedMessage.ContextID = &current.ContextID
+		case h.resumeConfig.QueueWhileWorking && (current.Status.State == types.TaskStateSubmitted || current.Status.State == types.TaskStateWorking):
+			if refusal != nil {
+				return current, refusal, nil
+			}
+			defaultTM, ok := h.taskManager.(*DefaultTaskManager)
+			if !ok {
+				return nil, nil, NewInvalidTaskTransitionError(taskID, current.Status.State, types.TaskStateWorking)
+			}
+			if err := defaultTM.QueueTaskInput(taskID, &enrichedMessage); err != nil {
+				return nil, nil, err
+			}
+			return current, &enrichedMessage, nil
+		case !isPausedTaskState(current.Status.State):
+			return nil, nil, NewInvalidTaskTransitionError(taskID, current.Status.State, types.TaskStateWorking)
+		default:
+			if refusal == nil && current.Status.State == types.TaskStateInputRequired {
+				refusal = h.collectInputAnswers(current, &enrichedMessage)
+			}
+			if refusal == nil && current.Status.State == types.TaskStateAuthRequired {
+				h.credentials.hold(taskID, &enrichedMessage)
+			}
+			return h.resumeTask(ctx, taskID, &enrichedMessage, params, decision, guard, refusal)
+		}
 	}
 
 	originalContextID := params.Message.ContextID
 
 	contextID := params.Message.ContextID
 	if contextID == nil {
-		newContextID := uuid.New().String()
+		newContextID := h.ids.NewID()
 		contextID = &newContextID
 	}
 
@@ -467,60 +765,246 @@ func (h *DefaultA2AProtocolHandler) CreateTaskFromMessage(ctx context.Context, p
 		conversationHistory := h.taskManager.GetConversationHistory(*contextID)
 
 		if len(conversationHistory) > 0 {
-			h.logger.Info("creating task with existing conversation history",
+			logger.Info("creating task with existing conversation history",
 				zap.String("context_id", *contextID),
 				zap.Int("history_count", len(conversationHistory)))
 			task = h.taskManager.CreateTaskWithHistory(*contextID, types.TaskStateSubmitted, &enrichedMessage, conversationHistory)
 		} else {
-			h.logger.Info("creating new task without history for existing context",
+			logger.Info("creating new task without history for existing context",
 				zap.String("context_id", *contextID))
 			task = h.taskManager.CreateTask(*contextID, types.TaskStateSubmitted, &enrichedMessage)
 		}
+	} else if history := conversationHistoryFromContext(ctx); len(history) > 0 {
+		logger.Info("creating new task with the conversation history of the request",
+			zap.String("context_id", *contextID),
+			zap.Int("history_count", len(history)))
+		task = h.taskManager.CreateTaskWithHistory(*contextID, types.TaskStateSubmitted, &enrichedMessage, history)
 	} else {
-		h.logger.Info("creating new task without history for new context",
+		logger.Info("creating new task without history for new context",
 			zap.String("context_id", *contextID))
 		task = h.taskManager.CreateTask(*contextID, types.TaskStateSubmitted, &enrichedMessage)
 	}
 
-	if task != nil {
-		h.logger.Info("task created for processing",
+	if task == nil {
+		logger.Error("failed to create task - task manager returned nil")
+		return nil, nil, fmt.Errorf("failed to create task")
+	}
+
+	if refusal != nil {
+		task.History = append(task.History, *refusal)
+		task.Status.State = types.TaskStateRejected
+		task.Status.Message = refusal
+		h.recordTaskFollowUp(task, followUpOf)
+		h.recordTaskMetadata(task, params.Metadata)
+		h.recordTaskRequestID(ctx, task)
+		h.recordTaskLanguage(task, decision.Language)
+		h.recordTaskGuardrails(task, guard.Findings)
+		recordStateTransition(h.taskManager, task, types.TaskActorServer)
+		if err := h.taskManager.UpdateTask(task); err != nil {
+			return nil, nil, fmt.Errorf("failed to refuse message: %w", err)
+		}
+
+		logger.Info("task rejected",
 			zap.String("task_id", task.ID),
-			zap.String("context_id", task.ContextID))
+			zap.String("reason", rejection),
+			zap.String("language", decision.Language))
+		return task, refusal, nil
+	}
+
+	h.recordTaskFollowUp(task, followUpOf)
+	h.recordTaskMetadata(task, params.Metadata)
+	h.recordTaskRequestID(ctx, task)
+	h.recordTaskScopes(ctx, task)
+	h.recordTaskSubject(ctx, task)
+	h.recordTaskUser(ctx, task)
+	h.recordTaskSkill(ctx, task, params.Message)
+	h.recordTaskLanguage(task, decision.Language)
+	h.recordTaskGuardrails(task, guard.Findings)
+	h.recordTaskSpeechOutput(task, params.Configuration)
+	h.storeTaskMetadata(ctx, task)
+	logger.Info("task created for processing",
+		zap.String("task_id", task.ID),
+		zap.String("context_id", task.ContextID))
+	return task, nil, nil
+}
+
+// resumeTask resumes a task with the input of a message sent to it, pausing it again with the
+// refusal when the message was refused or left fields of an input request unanswered
+func (h *DefaultA2AProtocolHandler) resumeTask(ctx context.Context, taskID string, message *types.Message, params types.MessageSendParams, decision LanguageDecision, guard GuardrailDecision, refusal *types.Message) (*types.Task, *types.Message, error) {
+	logger := requestLogger(ctx, h.logger)
+	err := h.taskManager.ResumeTaskWithInput(taskID, message)
+	if err != nil {
+		logger.Error("failed to resume task with input",
+			zap.String("task_id", taskID),
+			zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to resume task: %w", err)
+	}
+
+	if refusal != nil {
+		if err := h.taskManager.PauseTaskForInput(taskID, refusal); err != nil {
+			return nil, nil, fmt.Errorf("failed to refuse message: %w", err)
+		}
+	}
+
+	task, exists := h.taskManager.GetTask(taskID)
+	if !exists {
+		logger.Error("failed to get resumed task",
+			zap.String("task_id", taskID))
+		return nil, nil, fmt.Errorf("resumed task not found: %s", taskID)
+	}
+
+	logger.Info("task resumed with user input",
+		zap.String("task_id", taskID),
+		zap.String("context_id", task.ContextID))
+
+	h.recordTaskMetadata(task, params.Metadata)
+	h.recordTaskRequestID(ctx, task)
+	h.recordTaskScopes(ctx, task)
+	h.recordTaskSubject(ctx, task)
+	h.recordTaskSkill(ctx, task, params.Message)
+	h.recordTaskLanguage(task, decision.Language)
+	h.recordTaskGuardrails(task, guard.Findings)
+	h.recordTaskSpeechOutput(task, params.Configuration)
+	h.storeTaskMetadata(ctx, task)
+	return task, refusal, nil
+}
+
+// recordTaskLanguage records the language of the latest user message in the task metadata
+func (h *DefaultA2AProtocolHandler) recordTaskLanguage(task *types.Task, language string) {
+	if language == "" {
+		return
+	}
+
+	setTaskMetadata(task, types.LanguageMetadataKey, language)
+}
+
+// recordTaskGuardrails records the findings of the input guardrails in the task metadata
+func (h *DefaultA2AProtocolHandler) recordTaskGuardrails(task *types.Task, findings []GuardrailFinding) {
+	if len(findings) == 0 {
+		return
+	}
+
+	recordTaskFindings(task, findings)
+}
+
+// recordTaskSpeechOutput records in the task metadata whether the latest request asked for
+// the agent's response as speech, replacing what an earlier request asked for
+func (h *DefaultA2AProtocolHandler) recordTaskSpeechOutput(task *types.Task, configuration *types.MessageSendConfiguration) {
+	if h.speechOutput == nil {
+		return
+	}
+
+	recorded := false
+	if task.Metadata != nil {
+		_, recorded = (*task.Metadata)[types.SpeechOutputMetadataKey]
+	}
+	accepted := h.speechOutput.accepts(configuration)
+	if recorded == accepted {
+		return
+	}
+	if accepted {
+		setTaskMetadata(task, types.SpeechOutputMetadataKey, h.speechOutput.synthesizer.MediaType())
 	} else {
-		h.logger.Error("failed to create task - task manager returned nil")
-		return nil, fmt.Errorf("failed to create task")
+		setTaskMetadata(task, types.SpeechOutputMetadataKey, nil)
+	}
+}
+
+// recordTaskScopes records the scopes granted to the authenticated caller in the task metadata,
+// replacing any scopes recorded for an earlier caller, so tool preconditions can check them
+// while the task is processed in the background
+func (h *DefaultA2AProtocolHandler) recordTaskScopes(ctx context.Context, task *types.Task) {
+	scopes := middlewares.ScopesFromContext(ctx)
+
+	if scopes == nil {
+		setTaskMetadata(task, types.AuthScopesMetadataKey, nil)
+	} else {
+		setTaskMetadata(task, types.AuthScopesMetadataKey, scopes)
+	}
+}
+
+// recordTaskSubject records the subject of the authenticated caller in the task metadata,
+// replacing the subject of an earlier caller, so the access policy can check tool calls while
+// the task is processed in the background
+func (h *DefaultA2AProtocolHandler) recordTaskSubject(ctx context.Context, task *types.Task) {
+	subject := middlewares.SubjectFromContext(ctx)
+
+	if subject == "" {
+		setTaskMetadata(task, types.AuthSubjectMetadataKey, nil)
+	} else {
+		setTaskMetadata(task, types.AuthSubjectMetadataKey, subject)
 	}
-	return task, nil
 }
 
 // HandleMessageSend processes message/send requests
 func (h *DefaultA2AProtocolHandler) HandleMessageSend(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
 	var params types.MessageSendParams
 	paramsBytes, err := json.Marshal(req.Params)
 	if err != nil {
-		h.logger.Error("failed to marshal params", zap.Error(err))
+		logger.Error("failed to marshal params", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
 		return
 	}
 
 	if err := json.Unmarshal(paramsBytes, &params); err != nil {
-		h.logger.Error("failed to parse message/send request", zap.Error(err))
+		logger.Error("failed to parse message/send request", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
 		return
 	}
 
-	task, err := h.CreateTaskFromMessage(c.Request.Context(), params)
+	historyLength := requestedHistoryLength(params.Configuration)
+	if err := validateHistoryLength(historyLength); err != nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), err.Error())
+		return
+	}
+
+	if _, err := parseTaskLabels(params.Metadata); err != nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), err.Error())
+		return
+	}
+
+	dependencies, err := parseTaskDependencies(params.Metadata)
 	if err != nil {
-		h.logger.Error("failed to create task", zap.Error(err))
-		h.responseSender.SendError(c, req.ID, int(ErrInternalError), err.Error())
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), err.Error())
+		return
+	}
+	if len(dependencies) > 0 {
+		if code, err := h.checkTaskDependencies(params, dependencies); err != nil {
+			h.responseSender.SendError(c, req.ID, int(code), err.Error())
+			return
+		}
+	}
+
+	task, refusal, err := h.createTaskFromMessage(c.Request.Context(), params)
+	if err != nil {
+		logger.Error("failed to create task", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(taskErrorCode(err)), err.Error())
+		return
+	}
+
+	if refusal != nil {
+		h.responseSender.SendSuccess(c, req.ID, limitHistory(*task, historyLength))
+		return
+	}
+
+	if len(dependencies) > 0 {
+		h.holdForDependencies(c, req.ID, task, dependencies, historyLength)
+		return
+	}
+
+	// the worker writes the task as soon as it is queued, so the response is taken before
+	response, err := snapshotTask(limitHistory(*task, historyLength))
+	if err != nil {
+		logger.Error("failed to snapshot task", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to encode task")
 		return
 	}
 
 	err = h.storage.EnqueueTask(c.Request.Context(), task, req.ID)
 	if err != nil {
-		h.logger.Error("failed to enqueue task", zap.Error(err))
+		logger.Error("failed to enqueue task", zap.Error(err))
 		err := h.taskManager.UpdateError(task.ID, &types.Message{
-			MessageID: uuid.New().String(),
+			MessageID: h.ids.NewID(),
 			Role:      types.RoleAgent,
 			TaskID:    &task.ID,
 			ContextID: &task.ContextID,
@@ -529,7 +1013,7 @@ func (h *DefaultA2AProtocolHandler) HandleMessageSend(c *gin.Context, req types.
 			},
 		})
 		if err != nil {
-			h.logger.Error("failed to update task to failed state due to enqueue failure",
+			logger.Error("failed to update task to failed state due to enqueue failure",
 				zap.Error(err),
 				zap.String("task_id", task.ID),
 				zap.String("context_id", task.ContextID))
@@ -538,71 +1022,142 @@ func (h *DefaultA2AProtocolHandler) HandleMessageSend(c *gin.Context, req types.
 		return
 	}
 
-	h.responseSender.SendSuccess(c, req.ID, *task)
+	h.responseSender.SendSuccess(c, req.ID, response)
 }
 
-// writeStreamingResponse writes a JSON-RPC response to the streaming connection in SSE format
-func (h *DefaultA2AProtocolHandler) writeStreamingResponse(c *gin.Context, response *types.JSONRPCSuccessResponse) error {
-	responseBytes, err := json.Marshal(response)
+// snapshotTask returns a deep copy of a task that shares nothing with it, to answer with a
+// task another goroutine goes on writing
+func snapshotTask(task types.Task) (types.Task, error) {
+	data, err := json.Marshal(task)
 	if err != nil {
-		return fmt.Errorf("failed to marshal response: %w", err)
+		return types.Task{}, err
 	}
-
-	if _, err := c.Writer.Write([]byte("data: ")); err != nil {
-		return fmt.Errorf("failed to write data prefix: %w", err)
+	var snapshot types.Task
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return types.Task{}, err
 	}
+	return snapshot, nil
+}
 
-	if _, err := c.Writer.Write(responseBytes); err != nil {
-		return fmt.Errorf("failed to write response: %w", err)
-	}
+// streamHandlerTask returns the copy of a streaming task handed to its handler. The handler
+// and the tools it runs write their copy while the stream writes the task, so the two share
+// no metadata, history or artifacts. The metadata the handler sets is merged back with
+// mergeHandlerMetadata.
+func streamHandlerTask(task *types.Task) *types.Task {
+	copied := *task
+	copied.History = slices.Clone(task.History)
+	copied.Artifacts = slices.Clone(task.Artifacts)
+	if task.Metadata != nil {
+		metadata := maps.Clone(*task.Metadata)
+		copied.Metadata = &metadata
+	}
+	return &copied
+}
 
-	if _, err := c.Writer.Write([]byte("\n\n")); err != nil {
-		return fmt.Errorf("failed to write SSE terminator: %w", err)
+// mergeHandlerMetadata copies the metadata the handler of a streaming task set on its copy since
+// it was made with the initial metadata, such as usage statistics, into the task. It is only
+// called once the handler stopped writing its copy: after it sent its last event or the one
+// pausing the task.
+func mergeHandlerMetadata(task, handlerTask *types.Task, initial map[string]any) {
+	if handlerTask.Metadata == nil {
+		return
 	}
+	for key, value := range *handlerTask.Metadata {
+		if previous, exists := initial[key]; exists && reflect.DeepEqual(previous, value) {
+			continue
+		}
+		if task.Metadata == nil {
+			task.Metadata = &map[string]any{}
+		}
+		(*task.Metadata)[key] = value
+	}
+}
 
-	c.Writer.Flush()
-	return nil
+// writeStreamingResponse writes a JSON-RPC response to the streaming connection in SSE format,
+// with its result translated into the spec-compliant object of its kind
+func (h *DefaultA2AProtocolHandler) writeStreamingResponse(c *gin.Context, response *types.JSONRPCSuccessResponse) error {
+	translated := *response
+	translated.Result = translateStreamResult(response.Result)
+	return writeSSEFrame(c.Writer, translated)
 }
 
 // writeStreamingErrorResponse writes a JSON-RPC error response to the streaming connection in SSE format
 func (h *DefaultA2AProtocolHandler) writeStreamingErrorResponse(c *gin.Context, response *types.JSONRPCErrorResponse) error {
-	responseBytes, err := json.Marshal(response)
-	if err != nil {
-		return fmt.Errorf("failed to marshal error response: %w", err)
-	}
+	return writeSSEFrame(c.Writer, response)
+}
 
-	if _, err := c.Writer.Write([]byte("data: ")); err != nil {
-		return fmt.Errorf("failed to write data prefix: %w", err)
+// writeArtifactUpdates attaches the artifact to the task when missing and streams it
+// to the client as one or more artifact-update events
+func (h *DefaultA2AProtocolHandler) writeArtifactUpdates(c *gin.Context, id any, task *types.Task, artifact types.Artifact) error {
+	if !hasArtifact(task, artifact.ArtifactID) {
+		task.Artifacts = append(task.Artifacts, artifact)
 	}
 
-	if _, err := c.Writer.Write(responseBytes); err != nil {
-		return fmt.Errorf("failed to write error response: %w", err)
-	}
+	updates := ChunkArtifactUpdates(task.ID, task.ContextID, artifact, DefaultArtifactChunkSize)
+	requestLogger(c.Request.Context(), h.logger).Debug("streaming artifact update",
+		zap.String("task_id", task.ID),
+		zap.String("artifact_id", artifact.ArtifactID),
+		zap.Int("chunks", len(updates)))
 
-	if _, err := c.Writer.Write([]byte("\n\n")); err != nil {
-		return fmt.Errorf("failed to write SSE terminator: %w", err)
+	for _, update := range updates {
+		response := types.JSONRPCSuccessResponse{
+			JSONRPC: "2.0",
+			ID:      id,
+			Result:  update,
+		}
+		if err := h.writeStreamingResponse(c, &response); err != nil {
+			return err
+		}
 	}
-
-	c.Writer.Flush()
 	return nil
 }
 
 // HandleMessageStream processes message/stream requests
 func (h *DefaultA2AProtocolHandler) HandleMessageStream(c *gin.Context, req types.JSONRPCRequest, streamingHandler StreamableTaskHandler) {
+	logger := requestLogger(c.Request.Context(), h.logger)
 	var params types.MessageSendParams
 	paramsBytes, err := json.Marshal(req.Params)
 	if err != nil {
-		h.logger.Error("failed to marshal params", zap.Error(err))
+		logger.Error("failed to marshal params", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
 		return
 	}
 
 	if err := json.Unmarshal(paramsBytes, &params); err != nil {
-		h.logger.Error("failed to parse message/stream request", zap.Error(err))
+		logger.Error("failed to parse message/stream request", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
 		return
 	}
 
+	filter, err := parseStreamEventFilter(params.Configuration)
+	if err != nil {
+		logger.Error("invalid stream event filter", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), err.Error())
+		return
+	}
+
+	historyLength := requestedHistoryLength(params.Configuration)
+	if err := validateHistoryLength(historyLength); err != nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), err.Error())
+		return
+	}
+
+	if _, err := parseTaskLabels(params.Metadata); err != nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), err.Error())
+		return
+	}
+
+	if dependencies, err := parseTaskDependencies(params.Metadata); err != nil || len(dependencies) > 0 {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), fmt.Sprintf("metadata %s is only supported by message/send", types.DependsOnMetadataKey))
+		return
+	}
+
+	if !h.drain.acquire() {
+		h.responseSender.SendError(c, req.ID, int(ErrServerError), "server is shutting down")
+		return
+	}
+	defer h.drain.release()
+
 	c.Header("Content-Type", "text/event-stream")
 	c.Header("Cache-Control", "no-cache")
 	c.Header("Connection", "keep-alive")
@@ -611,39 +1166,47 @@ func (h *DefaultA2AProtocolHandler) HandleMessageStream(c *gin.Context, req type
 
 	ctx := c.Request.Context()
 
-	task, err := h.CreateTaskFromMessage(ctx, params)
+	task, refusal, err := h.createTaskFromMessage(ctx, params)
 	if err != nil {
-		h.logger.Error("failed to create streaming task", zap.Error(err))
+		logger.Error("failed to create streaming task", zap.Error(err))
 		errorResponse := types.JSONRPCErrorResponse{
 			JSONRPC: "2.0",
 			ID:      req.ID,
 			Error: &types.JSONRPCError{
-				Code:    int(ErrInternalError),
+				Code:    int(taskErrorCode(err)),
 				Message: err.Error(),
 			},
 		}
 		if writeErr := h.writeStreamingErrorResponse(c, &errorResponse); writeErr != nil {
-			h.logger.Error("failed to write streaming error response", zap.Error(writeErr))
+			logger.Error("failed to write streaming error response", zap.Error(writeErr))
 		}
 		return
 	}
 
-	h.logger.Info("processing streaming task",
+	if refusal != nil {
+		h.writeRefusal(c, req.ID, task)
+		return
+	}
+
+	logger.Info("processing streaming task",
 		zap.String("task_id", task.ID),
 		zap.String("context_id", task.ContextID))
 
 	err = h.taskManager.UpdateState(task.ID, types.TaskStateWorking)
 	if err != nil {
-		h.logger.Error("failed to update streaming task state", zap.Error(err))
+		logger.Error("failed to update streaming task state", zap.Error(err))
 		return
 	}
+	if defaultTM, ok := h.taskManager.(*DefaultTaskManager); ok {
+		defer defaultTM.dispatchQueuedInput(context.Background(), task.ID)
+	}
 
 	var message *types.Message
 	if task.Status.Message != nil {
 		message = task.Status.Message
 	} else {
 		message = &types.Message{
-			MessageID: uuid.New().String(),
+			MessageID: h.ids.NewID(),
 			Role:      "user",
 			Parts:     []types.Part{},
 		}
@@ -655,9 +1218,23 @@ func (h *DefaultA2AProtocolHandler) HandleMessageStream(c *gin.Context, req type
 		defaultTM.RegisterTaskCancelFunc(task.ID, cancel)
 	}
 
-	eventsChan, err := streamingHandler.HandleStreamingTask(taskCtx, task, message)
+	handedOff := false
+	defer func() {
+		if !handedOff {
+			h.checkpoints.clear(ctx, task.ID)
+		}
+	}()
+
+	progress := newTaskProgress(h.taskManager, h.clock, task.ID, logger, true)
+	handlerTask := streamHandlerTask(task)
+	var initialMetadata map[string]any
+	if task.Metadata != nil {
+		initialMetadata = maps.Clone(*task.Metadata)
+	}
+	runCtx := h.memories.runContext(h.credentials.context(h.checkpoints.runContext(taskCtx, task.ID), task.ID), handlerTask, message)
+	eventsChan, err := streamingHandler.HandleStreamingTask(progress.context(runCtx), handlerTask, message)
 	if err != nil {
-		h.logger.Error("failed to start streaming task",
+		logger.Error("failed to start streaming task",
 			zap.Error(err),
 			zap.String("task_id", task.ID),
 			zap.String("context_id", task.ContextID))
@@ -671,38 +1248,84 @@ func (h *DefaultA2AProtocolHandler) HandleMessageStream(c *gin.Context, req type
 			},
 		}
 		if writeErr := h.writeStreamingErrorResponse(c, &errorResponse); writeErr != nil {
-			h.logger.Error("failed to write streaming error response", zap.Error(writeErr))
+			logger.Error("failed to write streaming error response", zap.Error(writeErr))
 		}
 		return
 	}
 
-	var accumulatedText string
+	aggregator := NewStreamAggregator()
+	history := newStreamHistoryCache()
+	heartbeat := newStreamHeartbeat(h.heartbeat)
+	defer heartbeat.stop()
+
+	for {
+		var event cloudevents.Event
+		var ok bool
+		select {
+		case event, ok = <-eventsChan:
+		case <-heartbeat.C():
+			if err := h.writeHeartbeat(c, req.ID, task); err != nil {
+				logger.Error("failed to write heartbeat", zap.Error(err))
+				return
+			}
+			heartbeat.reset()
+			continue
+		case update := <-progress.C():
+			progress.apply(task)
+			heartbeat.reset()
+			if !filter.allows(types.StreamEventStatus) {
+				continue
+			}
+			if err := h.writeProgress(c, req.ID, task, update); err != nil {
+				logger.Error("failed to write progress", zap.Error(err))
+				return
+			}
+			continue
+		case <-h.drain.expiredChan():
+			cancel()
+			handedOff = true
+			h.checkpointStream(c, req.ID, task, message)
+			return
+		}
+		if !ok {
+			break
+		}
+		heartbeat.reset()
+		h.events.taskEvent(task, event)
 
-	for event := range eventsChan {
 		switch event.Type() {
 		case types.EventDelta:
 			var deltaMessage types.Message
 			if err := event.DataAs(&deltaMessage); err == nil {
-				for _, part := range deltaMessage.Parts {
-					if part.Text != nil {
-						accumulatedText += *part.Text
-					}
-				}
-				h.logger.Debug("accumulated delta text",
+				aggregator.AddDelta(deltaMessage)
+				logger.Debug("accumulated delta text",
 					zap.String("task_id", task.ID),
-					zap.Int("total_length", len(accumulatedText)))
+					zap.Int("total_length", len(aggregator.Text())))
+
+				if h.guardrails.hasOutputFilters() {
+					// a filter can only judge a complete message, so deltas are withheld
+					continue
+				}
 
 				task.Status.Message = &deltaMessage
 				task.Status.State = types.TaskStateWorking
+				if !filter.allows(types.StreamEventDelta) {
+					continue
+				}
 
+				snapshot, err := history.snapshot(limitHistory(*task, historyLength))
+				if err != nil {
+					logger.Error("failed to encode delta", zap.Error(err))
+					return
+				}
 				deltaResponse := types.JSONRPCSuccessResponse{
 					JSONRPC: "2.0",
 					ID:      req.ID,
-					Result:  *task,
+					Result:  snapshot,
 				}
 
 				if err := h.writeStreamingResponse(c, &deltaResponse); err != nil {
-					h.logger.Error("failed to write delta", zap.Error(err))
+					logger.Error("failed to write delta", zap.Error(err))
 					return
 				}
 			}
@@ -710,21 +1333,47 @@ func (h *DefaultA2AProtocolHandler) HandleMessageStream(c *gin.Context, req type
 		case types.EventIterationCompleted:
 			var iterationMessage types.Message
 			if err := event.DataAs(&iterationMessage); err == nil {
-				task.History = append(task.History, iterationMessage)
-				h.logger.Debug("stored iteration completed message to history",
-					zap.String("task_id", task.ID),
-					zap.String("message_id", iterationMessage.MessageID),
-					zap.Int("history_size", len(task.History)))
+				if consolidated := aggregator.CompleteIteration(iterationMessage); consolidated != nil {
+					recordTaskFindings(task, h.guardrails.CheckOutput(ctx, consolidated).Findings)
+					task.History = append(task.History, *consolidated)
+					logger.Debug("stored iteration completed message to history",
+						zap.String("task_id", task.ID),
+						zap.String("message_id", consolidated.MessageID),
+						zap.Int("history_size", len(task.History)))
+				}
 			}
 
 		case types.EventTaskStatusChanged:
 			var statusData types.TaskStatus
 			if err := event.DataAs(&statusData); err == nil {
-				h.logger.Info("task state changed",
+				logger.Info("task state changed",
 					zap.String("task_id", task.ID),
 					zap.String("new_state", string(statusData.State)))
 
 				task.Status.State = statusData.State
+				if statusData.Message != nil && statusData.Message.Role == types.RoleAgent {
+					h.guardrails.CheckOutput(ctx, statusData.Message)
+				}
+				if statusData.State == types.TaskStateCompleted {
+					if consolidated := aggregator.Complete(statusData.Message); consolidated != nil {
+						if statusData.Message == nil {
+							recordTaskFindings(task, h.guardrails.CheckOutput(ctx, consolidated).Findings)
+						}
+						task.History = append(task.History, *consolidated)
+					}
+					h.redactor.redactTaskArtifacts(ctx, task, logger)
+				}
+				if err := h.writeSpeechArtifact(c, req.ID, task, statusData, filter.allows(types.StreamEventArtifact)); err != nil {
+					logger.Error("failed to write speech artifact", zap.Error(err))
+					return
+				}
+				if transcript := h.transcripts.attach(task); transcript != nil && filter.allows(types.StreamEventArtifact) {
+					if err := h.writeArtifactUpdates(c, req.ID, task, *transcript); err != nil {
+						logger.Error("failed to write transcript artifact", zap.Error(err))
+						return
+					}
+				}
+				statusData.Message = h.previews.attach(ctx, task, statusData.Message)
 
 				statusUpdate := types.TaskStatusUpdateEvent{
 					TaskID:    task.ID,
@@ -732,6 +1381,10 @@ func (h *DefaultA2AProtocolHandler) HandleMessageStream(c *gin.Context, req type
 					Status:    statusData,
 					Final:     statusData.State == types.TaskStateCompleted || statusData.State == types.TaskStateFailed || statusData.State == types.TaskStateCancelled,
 				}
+				// the final status is always streamed, so the client learns how the task ended
+				if !statusUpdate.Final && !filter.allows(types.StreamEventStatus) {
+					continue
+				}
 
 				statusResponse := types.JSONRPCSuccessResponse{
 					JSONRPC: "2.0",
@@ -740,30 +1393,61 @@ func (h *DefaultA2AProtocolHandler) HandleMessageStream(c *gin.Context, req type
 				}
 
 				if err := h.writeStreamingResponse(c, &statusResponse); err != nil {
-					h.logger.Error("failed to write status change", zap.Error(err))
+					logger.Error("failed to write status change", zap.Error(err))
+					return
+				}
+			}
+
+		case types.EventArtifactUpdate:
+			var artifact types.Artifact
+			if err := event.DataAs(&artifact); err == nil {
+				if !filter.allows(types.StreamEventArtifact) {
+					if !hasArtifact(task, artifact.ArtifactID) {
+						task.Artifacts = append(task.Artifacts, artifact)
+					}
+					continue
+				}
+				if err := h.writeArtifactUpdates(c, req.ID, task, artifact); err != nil {
+					logger.Error("failed to write artifact update", zap.Error(err))
+					return
+				}
+			}
+
+		case types.EventToolStarted, types.EventToolCompleted, types.EventToolFailed, types.EventToolResult:
+			if !filter.allows(types.StreamEventTool) {
+				continue
+			}
+
+			var toolMessage types.Message
+			if err := event.DataAs(&toolMessage); err == nil {
+				if err := h.writeToolEvent(c, req.ID, task, event.Type(), &toolMessage); err != nil {
+					logger.Error("failed to write tool event", zap.Error(err))
 					return
 				}
 			}
 
-		case types.EventInputRequired:
+		case types.EventInputRequired, types.EventAuthRequired:
 			var inputMessage types.Message
 			if err := event.DataAs(&inputMessage); err == nil {
+				recordTaskFindings(task, h.guardrails.CheckOutput(ctx, &inputMessage).Findings)
 				task.History = append(task.History, inputMessage)
-				task.Status.State = types.TaskStateInputRequired
+				task.Status.State = pausedTaskState(event.Type())
 				task.Status.Message = &inputMessage
 
-				h.logger.Info("streaming task paused for user input",
+				logger.Info("streaming task paused for user input",
 					zap.String("task_id", task.ID),
-					zap.String("context_id", task.ContextID))
+					zap.String("context_id", task.ContextID),
+					zap.String("state", string(task.Status.State)))
 
 				statusUpdate := types.TaskStatusUpdateEvent{
 					TaskID:    task.ID,
 					ContextID: task.ContextID,
 					Status: types.TaskStatus{
-						State:   types.TaskStateInputRequired,
+						State:   task.Status.State,
 						Message: &inputMessage,
 					},
-					Final: false,
+					// the stream ends while the task waits for input
+					Final: true,
 				}
 
 				statusResponse := types.JSONRPCSuccessResponse{
@@ -773,12 +1457,13 @@ func (h *DefaultA2AProtocolHandler) HandleMessageStream(c *gin.Context, req type
 				}
 
 				if err := h.writeStreamingResponse(c, &statusResponse); err != nil {
-					h.logger.Error("failed to write input-required status", zap.Error(err))
+					logger.Error("failed to write input-required status", zap.Error(err))
 					return
 				}
 
+				mergeHandlerMetadata(task, handlerTask, initialMetadata)
 				if err := h.taskManager.UpdateTask(task); err != nil {
-					h.logger.Error("failed to save input-required task",
+					logger.Error("failed to save input-required task",
 						zap.String("task_id", task.ID),
 						zap.Error(err))
 				}
@@ -791,12 +1476,12 @@ func (h *DefaultA2AProtocolHandler) HandleMessageStream(c *gin.Context, req type
 				task.History = append(task.History, interruptMessage)
 				task.Status.State = types.TaskStateCancelled
 
-				h.logger.Info("streaming task was interrupted",
+				logger.Info("streaming task was interrupted",
 					zap.String("task_id", task.ID),
 					zap.String("context_id", task.ContextID))
 
 				if err := h.taskManager.UpdateTask(task); err != nil {
-					h.logger.Error("failed to save interrupted task",
+					logger.Error("failed to save interrupted task",
 						zap.String("task_id", task.ID),
 						zap.Error(err))
 				}
@@ -810,11 +1495,11 @@ func (h *DefaultA2AProtocolHandler) HandleMessageStream(c *gin.Context, req type
 				task.Status.State = types.TaskStateFailed
 				task.Status.Message = &errorMessage
 
-				h.logger.Error("streaming task failed",
+				logger.Error("streaming task failed",
 					zap.String("task_id", task.ID))
 
 				if err := h.taskManager.UpdateTask(task); err != nil {
-					h.logger.Error("failed to save failed task",
+					logger.Error("failed to save failed task",
 						zap.String("task_id", task.ID),
 						zap.Error(err))
 				}
@@ -828,92 +1513,187 @@ func (h *DefaultA2AProtocolHandler) HandleMessageStream(c *gin.Context, req type
 					},
 				}
 				if writeErr := h.writeStreamingErrorResponse(c, &errorResponse); writeErr != nil {
-					h.logger.Error("failed to write error response", zap.Error(writeErr))
+					logger.Error("failed to write error response", zap.Error(writeErr))
 				}
 				return
 			}
 		}
 	}
 
+	if consolidated := aggregator.Flush(); consolidated != nil {
+		recordTaskFindings(task, h.guardrails.CheckOutput(ctx, consolidated).Findings)
+		task.History = append(task.History, *consolidated)
+	}
+	mergeHandlerMetadata(task, handlerTask, initialMetadata)
+
 	if len(task.History) > 0 {
 		task.Status.State = types.TaskStateCompleted
-		task.Status.Message = &task.History[len(task.History)-1]
+		h.redactor.redactTaskArtifacts(ctx, task, logger)
+		task.Status.Message = h.previews.attach(ctx, task, &task.History[len(task.History)-1])
 
 		if err := h.taskManager.UpdateTask(task); err != nil {
-			h.logger.Error("failed to update completed task",
+			logger.Error("failed to update completed task",
 				zap.Error(err),
 				zap.String("task_id", task.ID))
 		}
+		h.memories.rememberInBackground(ctx, task)
 	}
 
-	if _, err := c.Writer.Write([]byte("data: [DONE]\n\n")); err != nil {
-		h.logger.Error("failed to write stream termination signal", zap.Error(err))
+	if err := writeSSEDone(c.Writer); err != nil {
+		logger.Error("failed to write stream termination signal", zap.Error(err))
 	} else {
-		c.Writer.Flush()
-		h.logger.Debug("sent stream termination signal [DONE]")
+		logger.Debug("sent stream termination signal [DONE]")
 	}
 
-	h.logger.Info("streaming task processed successfully",
+	logger.Info("streaming task processed successfully",
 		zap.String("task_id", task.ID),
 		zap.String("context_id", task.ContextID))
 }
 
+// writeSpeechArtifact synthesizes the response of a streaming task that completed with the
+// status when its request asked for speech, and streams the audio artifact ahead of the final
+// status unless the client filtered artifact events out
+func (h *DefaultA2AProtocolHandler) writeSpeechArtifact(c *gin.Context, id any, task *types.Task, status types.TaskStatus, stream bool) error {
+	if h.speechOutput == nil || status.State != types.TaskStateCompleted || status.Message == nil {
+		return nil
+	}
+
+	task.Status.Message = status.Message
+	artifact := h.speechOutput.synthesizeTask(c.Request.Context(), task)
+	if artifact == nil || !stream {
+		return nil
+	}
+	return h.writeArtifactUpdates(c, id, task, *artifact)
+}
+
+// writeToolEvent streams a tool event to a client subscribed to tool events, as a working
+// status update with the tool message and the event type in the metadata
+func (h *DefaultA2AProtocolHandler) writeToolEvent(c *gin.Context, id any, task *types.Task, eventType string, toolMessage *types.Message) error {
+	h.guardrails.CheckOutput(c.Request.Context(), toolMessage)
+
+	statusUpdate := types.TaskStatusUpdateEvent{
+		TaskID:    task.ID,
+		ContextID: task.ContextID,
+		Status: types.TaskStatus{
+			State:   types.TaskStateWorking,
+			Message: toolMessage,
+		},
+		Metadata: &types.Struct{types.StreamEventTypeMetadataKey: eventType},
+	}
+
+	return h.writeStreamingResponse(c, &types.JSONRPCSuccessResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Result:  statusUpdate,
+	})
+}
+
+// writeRefusal streams the status of a task refused by the language policy or the input
+// guardrails and ends the stream
+func (h *DefaultA2AProtocolHandler) writeRefusal(c *gin.Context, requestID any, task *types.Task) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	statusResponse := types.JSONRPCSuccessResponse{
+		JSONRPC: "2.0",
+		ID:      requestID,
+		Result: types.TaskStatusUpdateEvent{
+			TaskID:    task.ID,
+			ContextID: task.ContextID,
+			Status:    task.Status,
+			Final:     task.Status.State == types.TaskStateRejected,
+		},
+	}
+	if err := h.writeStreamingResponse(c, &statusResponse); err != nil {
+		logger.Error("failed to write refusal", zap.Error(err))
+		return
+	}
+
+	if err := writeSSEDone(c.Writer); err != nil {
+		logger.Error("failed to write stream termination signal", zap.Error(err))
+	}
+}
+
 // HandleTaskGet processes tasks/get requests
 func (h *DefaultA2AProtocolHandler) HandleTaskGet(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
 	var params types.TaskQueryParams
 	paramsBytes, err := json.Marshal(req.Params)
 	if err != nil {
-		h.logger.Error("failed to marshal params", zap.Error(err))
+		logger.Error("failed to marshal params", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
 		return
 	}
 
 	if err := json.Unmarshal(paramsBytes, &params); err != nil {
-		h.logger.Error("failed to parse tasks/get request", zap.Error(err))
+		logger.Error("failed to parse tasks/get request", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
 		return
 	}
 
-	h.logger.Info("retrieving task", zap.String("task_id", params.ID))
+	if err := validateHistoryLength(params.HistoryLength); err != nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), err.Error())
+		return
+	}
+
+	logger.Info("retrieving task", zap.String("task_id", params.ID))
 
 	task, exists := h.taskManager.GetTask(params.ID)
 	if !exists {
-		h.logger.Error("task not found", zap.String("task_id", params.ID))
-		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "task not found")
+		logger.Error("task not found", zap.String("task_id", params.ID))
+		h.responseSender.SendError(c, req.ID, int(ErrTaskNotFound), "task not found")
 		return
 	}
 
-	h.logger.Info("task retrieved successfully",
+	logger.Info("task retrieved successfully",
 		zap.String("task_id", params.ID),
 		zap.String("context_id", task.ContextID),
 		zap.String("status", string(task.Status.State)))
-	h.responseSender.SendSuccess(c, req.ID, *task)
+
+	if !params.IncludeThread {
+		h.responseSender.SendSuccess(c, req.ID, limitHistory(*task, params.HistoryLength))
+		return
+	}
+
+	contextTasks, err := h.storage.ListTasks(TaskFilter{ContextID: &task.ContextID})
+	if err != nil {
+		logger.Error("failed to list context tasks for thread view",
+			zap.String("task_id", params.ID),
+			zap.String("context_id", task.ContextID),
+			zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to build conversation thread")
+		return
+	}
+
+	h.responseSender.SendSuccess(c, req.ID, types.TaskWithThread{
+		Task:   limitHistory(*task, params.HistoryLength),
+		Thread: BuildConversationThread(task.ContextID, contextTasks),
+	})
 }
 
 // HandleTaskCancel processes tasks/cancel requests
 func (h *DefaultA2AProtocolHandler) HandleTaskCancel(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
 	var params types.TaskIdParams
 	paramsBytes, err := json.Marshal(req.Params)
 	if err != nil {
-		h.logger.Error("failed to marshal params", zap.Error(err))
+		logger.Error("failed to marshal params", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
 		return
 	}
 
 	if err := json.Unmarshal(paramsBytes, &params); err != nil {
-		h.logger.Error("failed to parse tasks/cancel request", zap.Error(err))
+		logger.Error("failed to parse tasks/cancel request", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
 		return
 	}
 
-	h.logger.Info("canceling task", zap.String("task_id", params.ID))
+	logger.Info("canceling task", zap.String("task_id", params.ID))
 
 	err = h.taskManager.CancelTask(params.ID)
 	if err != nil {
-		h.logger.Error("failed to cancel task",
+		logger.Error("failed to cancel task",
 			zap.Error(err),
 			zap.String("task_id", params.ID))
-		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), err.Error())
+		h.responseSender.SendError(c, req.ID, int(taskErrorCode(err)), err.Error())
 		return
 	}
 
@@ -923,119 +1703,126 @@ func (h *DefaultA2AProtocolHandler) HandleTaskCancel(c *gin.Context, req types.J
 
 // HandleTaskList processes tasks/list requests
 func (h *DefaultA2AProtocolHandler) HandleTaskList(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
 	var params types.TaskListParams
 	paramsBytes, err := json.Marshal(req.Params)
 	if err != nil {
-		h.logger.Error("failed to marshal params", zap.Error(err))
+		logger.Error("failed to marshal params", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
 		return
 	}
 
 	if err := json.Unmarshal(paramsBytes, &params); err != nil {
-		h.logger.Error("failed to parse tasks/list request", zap.Error(err))
+		logger.Error("failed to parse tasks/list request", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
 		return
 	}
 
-	h.logger.Info("listing tasks")
+	params.UserID = middlewares.UserIDFromContext(c.Request.Context())
+	params.Unowned = params.UserID == ""
+
+	logger.Info("listing tasks")
 
 	taskList, err := h.taskManager.ListTasks(params)
 	if err != nil {
-		h.logger.Error("failed to list tasks", zap.Error(err))
+		logger.Error("failed to list tasks", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInternalError), err.Error())
 		return
 	}
 
-	h.logger.Info("tasks listed successfully", zap.Int("count", len(taskList.Tasks)), zap.Int("total", taskList.TotalSize))
+	logger.Info("tasks listed successfully", zap.Int("count", len(taskList.Tasks)), zap.Int("total", taskList.TotalSize))
 	h.responseSender.SendSuccess(c, req.ID, taskList)
 }
 
 // HandleTaskPushNotificationConfigSet processes tasks/pushNotificationConfig/set requests
 func (h *DefaultA2AProtocolHandler) HandleTaskPushNotificationConfigSet(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
 	var params types.TaskPushNotificationConfig
 	paramsBytes, err := json.Marshal(req.Params)
 	if err != nil {
-		h.logger.Error("failed to marshal params", zap.Error(err))
+		logger.Error("failed to marshal params", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
 		return
 	}
 
 	if err := json.Unmarshal(paramsBytes, &params); err != nil {
-		h.logger.Error("failed to parse tasks/pushNotificationConfig/set request", zap.Error(err))
+		logger.Error("failed to parse tasks/pushNotificationConfig/set request", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
 		return
 	}
 
-	h.logger.Info("setting push notification config for task",
+	logger.Info("setting push notification config for task",
 		zap.String("task_name", params.Name),
 		zap.String("url", params.PushNotificationConfig.URL))
 
 	config, err := h.taskManager.SetTaskPushNotificationConfig(params)
 	if err != nil {
-		h.logger.Error("failed to set push notification config", zap.Error(err))
+		logger.Error("failed to set push notification config", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInternalError), err.Error())
 		return
 	}
 
-	h.logger.Info("push notification config set successfully", zap.String("task_name", params.Name))
+	logger.Info("push notification config set successfully", zap.String("task_name", params.Name))
 	h.responseSender.SendSuccess(c, req.ID, config)
 }
 
 // HandleTaskPushNotificationConfigGet processes tasks/pushNotificationConfig/get requests
 func (h *DefaultA2AProtocolHandler) HandleTaskPushNotificationConfigGet(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
 	var params types.GetTaskPushNotificationConfigParams
 	paramsBytes, err := json.Marshal(req.Params)
 	if err != nil {
-		h.logger.Error("failed to marshal params", zap.Error(err))
+		logger.Error("failed to marshal params", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
 		return
 	}
 
 	if err := json.Unmarshal(paramsBytes, &params); err != nil {
-		h.logger.Error("failed to parse tasks/pushNotificationConfig/get request", zap.Error(err))
+		logger.Error("failed to parse tasks/pushNotificationConfig/get request", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
 		return
 	}
 
-	h.logger.Info("getting push notification config for task", zap.String("task_name", params.Name))
+	logger.Info("getting push notification config for task", zap.String("task_name", params.Name))
 
 	config, err := h.taskManager.GetTaskPushNotificationConfig(params)
 	if err != nil {
-		h.logger.Error("failed to get push notification config", zap.Error(err))
+		logger.Error("failed to get push notification config", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInternalError), err.Error())
 		return
 	}
 
-	h.logger.Info("push notification config retrieved successfully", zap.String("task_name", params.Name))
+	logger.Info("push notification config retrieved successfully", zap.String("task_name", params.Name))
 	h.responseSender.SendSuccess(c, req.ID, config)
 }
 
 // HandleTaskPushNotificationConfigList processes tasks/pushNotificationConfig/list requests
 func (h *DefaultA2AProtocolHandler) HandleTaskPushNotificationConfigList(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
 	var params types.ListTaskPushNotificationConfigParams
 	paramsBytes, err := json.Marshal(req.Params)
 	if err != nil {
-		h.logger.Error("failed to marshal params", zap.Error(err))
+		logger.Error("failed to marshal params", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
 		return
 	}
 
 	if err := json.Unmarshal(paramsBytes, &params); err != nil {
-		h.logger.Error("failed to parse tasks/pushNotificationConfig/list request", zap.Error(err))
+		logger.Error("failed to parse tasks/pushNotificationConfig/list request", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
 		return
 	}
 
-	h.logger.Info("listing push notification configs for task", zap.String("parent", params.Parent))
+	logger.Info("listing push notification configs for task", zap.String("parent", params.Parent))
 
 	configs, err := h.taskManager.ListTaskPushNotificationConfigs(params)
 	if err != nil {
-		h.logger.Error("failed to list push notification configs", zap.Error(err))
+		logger.Error("failed to list push notification configs", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInternalError), err.Error())
 		return
 	}
 
-	h.logger.Info("push notification configs listed successfully",
+	logger.Info("push notification configs listed successfully",
 		zap.String("parent", params.Parent),
 		zap.Int("count", len(configs)))
 	h.responseSender.SendSuccess(c, req.ID, configs)
@@ -1043,31 +1830,32 @@ func (h *DefaultA2AProtocolHandler) HandleTaskPushNotificationConfigList(c *gin.
 
 // HandleTaskPushNotificationConfigDelete processes tasks/pushNotificationConfig/delete requests
 func (h *DefaultA2AProtocolHandler) HandleTaskPushNotificationConfigDelete(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
 	var params types.DeleteTaskPushNotificationConfigParams
 	paramsBytes, err := json.Marshal(req.Params)
 	if err != nil {
-		h.logger.Error("failed to marshal params", zap.Error(err))
+		logger.Error("failed to marshal params", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
 		return
 	}
 
 	if err := json.Unmarshal(paramsBytes, &params); err != nil {
-		h.logger.Error("failed to parse tasks/pushNotificationConfig/delete request", zap.Error(err))
+		logger.Error("failed to parse tasks/pushNotificationConfig/delete request", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
 		return
 	}
 
-	h.logger.Info("deleting push notification config",
+	logger.Info("deleting push notification config",
 		zap.String("task_name", params.Name))
 
 	err = h.taskManager.DeleteTaskPushNotificationConfig(params)
 	if err != nil {
-		h.logger.Error("failed to delete push notification config", zap.Error(err))
+		logger.Error("failed to delete push notification config", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInternalError), err.Error())
 		return
 	}
 
-	h.logger.Info("push notification config deleted successfully",
+	logger.Info("push notification config deleted successfully",
 		zap.String("task_name", params.Name))
 	h.responseSender.SendSuccess(c, req.ID, nil)
 }
@@ -1080,26 +1868,33 @@ func (h *DefaultA2AProtocolHandler) HandleTaskPushNotificationConfigDelete(c *gi
 // `[DONE]` terminator. When the task is still in a working state, the streaming
 // handler is invoked to continue delivering live events for the task.
 func (h *DefaultA2AProtocolHandler) HandleTaskResubscribe(c *gin.Context, req types.JSONRPCRequest, streamingHandler StreamableTaskHandler) {
+	logger := requestLogger(c.Request.Context(), h.logger)
 	var params types.TaskResubscriptionParams
 	paramsBytes, err := json.Marshal(req.Params)
 	if err != nil {
-		h.logger.Error("failed to marshal params", zap.Error(err))
+		logger.Error("failed to marshal params", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
 		return
 	}
 
 	if err := json.Unmarshal(paramsBytes, &params); err != nil {
-		h.logger.Error("failed to parse tasks/resubscribe request", zap.Error(err))
+		logger.Error("failed to parse tasks/resubscribe request", zap.Error(err))
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
 		return
 	}
 
 	if params.Name == "" {
-		h.logger.Error("tasks/resubscribe missing task name")
+		logger.Error("tasks/resubscribe missing task name")
 		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "task name is required")
 		return
 	}
 
+	if !h.drain.acquire() {
+		h.responseSender.SendError(c, req.ID, int(ErrServerError), "server is shutting down")
+		return
+	}
+	defer h.drain.release()
+
 	c.Header("Content-Type", "text/event-stream")
 	c.Header("Cache-Control", "no-cache")
 	c.Header("Connection", "keep-alive")
@@ -1108,7 +1903,7 @@ func (h *DefaultA2AProtocolHandler) HandleTaskResubscribe(c *gin.Context, req ty
 
 	task, exists := h.taskManager.GetTask(params.Name)
 	if !exists {
-		h.logger.Error("task not found for resubscribe", zap.String("task_id", params.Name))
+		logger.Error("task not found for resubscribe", zap.String("task_id", params.Name))
 		errorResponse := types.JSONRPCErrorResponse{
 			JSONRPC: "2.0",
 			ID:      req.ID,
@@ -1118,12 +1913,12 @@ func (h *DefaultA2AProtocolHandler) HandleTaskResubscribe(c *gin.Context, req ty
 			},
 		}
 		if writeErr := h.writeStreamingErrorResponse(c, &errorResponse); writeErr != nil {
-			h.logger.Error("failed to write streaming error response", zap.Error(writeErr))
+			logger.Error("failed to write streaming error response", zap.Error(writeErr))
 		}
 		return
 	}
 
-	h.logger.Info("resubscribing to task",
+	logger.Info("resubscribing to task",
 		zap.String("task_id", task.ID),
 		zap.String("context_id", task.ContextID),
 		zap.String("state", string(task.Status.State)))
@@ -1142,26 +1937,22 @@ func (h *DefaultA2AProtocolHandler) HandleTaskResubscribe(c *gin.Context, req ty
 	}
 
 	if err := h.writeStreamingResponse(c, &initialResponse); err != nil {
-		h.logger.Error("failed to write initial resubscribe status", zap.Error(err))
+		logger.Error("failed to write initial resubscribe status", zap.Error(err))
 		return
 	}
 
 	if task.Status.State != types.TaskStateWorking && task.Status.State != types.TaskStateSubmitted {
-		if _, err := c.Writer.Write([]byte("data: [DONE]\n\n")); err != nil {
-			h.logger.Error("failed to write stream termination signal", zap.Error(err))
-		} else {
-			c.Writer.Flush()
+		if err := writeSSEDone(c.Writer); err != nil {
+			logger.Error("failed to write stream termination signal", zap.Error(err))
 		}
 		return
 	}
 
 	if streamingHandler == nil {
-		h.logger.Warn("no streaming handler configured; resubscribe will end after sending current state",
+		logger.Warn("no streaming handler configured; resubscribe will end after sending current state",
 			zap.String("task_id", task.ID))
-		if _, err := c.Writer.Write([]byte("data: [DONE]\n\n")); err != nil {
-			h.logger.Error("failed to write stream termination signal", zap.Error(err))
-		} else {
-			c.Writer.Flush()
+		if err := writeSSEDone(c.Writer); err != nil {
+			logger.Error("failed to write stream termination signal", zap.Error(err))
 		}
 		return
 	}
@@ -1171,7 +1962,7 @@ func (h *DefaultA2AProtocolHandler) HandleTaskResubscribe(c *gin.Context, req ty
 		message = task.Status.Message
 	} else {
 		message = &types.Message{
-			MessageID: uuid.New().String(),
+			MessageID: h.ids.NewID(),
 			Role:      "user",
 			Parts:     []types.Part{},
 		}
@@ -1184,9 +1975,10 @@ func (h *DefaultA2AProtocolHandler) HandleTaskResubscribe(c *gin.Context, req ty
 		defaultTM.RegisterTaskCancelFunc(task.ID, cancel)
 	}
 
-	eventsChan, err := streamingHandler.HandleStreamingTask(taskCtx, task, message)
+	progress := newTaskProgress(h.taskManager, h.clock, task.ID, logger, true)
+	eventsChan, err := streamingHandler.HandleStreamingTask(progress.context(taskCtx), task, message)
 	if err != nil {
-		h.logger.Error("failed to resume streaming task",
+		logger.Error("failed to resume streaming task",
 			zap.Error(err),
 			zap.String("task_id", task.ID))
 		errorResponse := types.JSONRPCErrorResponse{
@@ -1198,25 +1990,68 @@ func (h *DefaultA2AProtocolHandler) HandleTaskResubscribe(c *gin.Context, req ty
 			},
 		}
 		if writeErr := h.writeStreamingErrorResponse(c, &errorResponse); writeErr != nil {
-			h.logger.Error("failed to write streaming error response", zap.Error(writeErr))
+			logger.Error("failed to write streaming error response", zap.Error(writeErr))
 		}
 		return
 	}
 
-	for event := range eventsChan {
+	history := newStreamHistoryCache()
+	heartbeat := newStreamHeartbeat(h.heartbeat)
+	defer heartbeat.stop()
+	for {
+		var event cloudevents.Event
+		var ok bool
+		select {
+		case event, ok = <-eventsChan:
+		case <-heartbeat.C():
+			if err := h.writeHeartbeat(c, req.ID, task); err != nil {
+				logger.Error("failed to write heartbeat", zap.Error(err))
+				return
+			}
+			heartbeat.reset()
+			continue
+		case update := <-progress.C():
+			progress.apply(task)
+			heartbeat.reset()
+			if err := h.writeProgress(c, req.ID, task, update); err != nil {
+				logger.Error("failed to write progress", zap.Error(err))
+				return
+			}
+			continue
+		case <-h.drain.expiredChan():
+			cancel()
+			h.checkpointStream(c, req.ID, task, message)
+			return
+		}
+		if !ok {
+			break
+		}
+		heartbeat.reset()
+		h.events.taskEvent(task, event)
+
 		switch event.Type() {
 		case types.EventDelta:
+			if h.guardrails.hasOutputFilters() {
+				// a filter can only judge a complete message, so deltas are withheld
+				continue
+			}
+
 			var deltaMessage types.Message
 			if err := event.DataAs(&deltaMessage); err == nil {
 				task.Status.Message = &deltaMessage
 				task.Status.State = types.TaskStateWorking
+				snapshot, err := history.snapshot(*task)
+				if err != nil {
+					logger.Error("failed to encode delta", zap.Error(err))
+					return
+				}
 				deltaResponse := types.JSONRPCSuccessResponse{
 					JSONRPC: "2.0",
 					ID:      req.ID,
-					Result:  *task,
+					Result:  snapshot,
 				}
 				if err := h.writeStreamingResponse(c, &deltaResponse); err != nil {
-					h.logger.Error("failed to write delta", zap.Error(err))
+					logger.Error("failed to write delta", zap.Error(err))
 					return
 				}
 			}
@@ -1225,6 +2060,14 @@ func (h *DefaultA2AProtocolHandler) HandleTaskResubscribe(c *gin.Context, req ty
 			var statusData types.TaskStatus
 			if err := event.DataAs(&statusData); err == nil {
 				task.Status.State = statusData.State
+				if statusData.Message != nil && statusData.Message.Role == types.RoleAgent {
+					h.guardrails.CheckOutput(ctx, statusData.Message)
+				}
+				if err := h.writeSpeechArtifact(c, req.ID, task, statusData, true); err != nil {
+					logger.Error("failed to write speech artifact", zap.Error(err))
+					return
+				}
+				statusData.Message = h.previews.attach(ctx, task, statusData.Message)
 				statusEvent := types.TaskStatusUpdateEvent{
 					TaskID:    task.ID,
 					ContextID: task.ContextID,
@@ -1237,20 +2080,27 @@ func (h *DefaultA2AProtocolHandler) HandleTaskResubscribe(c *gin.Context, req ty
 					Result:  statusEvent,
 				}
 				if err := h.writeStreamingResponse(c, &statusResponse); err != nil {
-					h.logger.Error("failed to write status change", zap.Error(err))
+					logger.Error("failed to write status change", zap.Error(err))
+					return
+				}
+			}
+
+		case types.EventArtifactUpdate:
+			var artifact types.Artifact
+			if err := event.DataAs(&artifact); err == nil {
+				if err := h.writeArtifactUpdates(c, req.ID, task, artifact); err != nil {
+					logger.Error("failed to write artifact update", zap.Error(err))
 					return
 				}
 			}
 		}
 	}
 
-	if _, err := c.Writer.Write([]byte("data: [DONE]\n\n")); err != nil {
-		h.logger.Error("failed to write stream termination signal", zap.Error(err))
-	} else {
-		c.Writer.Flush()
+	if err := writeSSEDone(c.Writer); err != nil {
+		logger.Error("failed to write stream termination signal", zap.Error(err))
 	}
 
-	h.logger.Info("task resubscribe completed",
+	logger.Info("task resubscribe completed",
 		zap.String("task_id", task.ID),
 		zap.String("context_id", task.ContextID))
 }
@@ -1262,8 +2112,9 @@ func (h *DefaultA2AProtocolHandler) HandleTaskResubscribe(c *gin.Context, req ty
 // authentication middleware when enabled), reaching this method implies the caller has
 // successfully authenticated.
 func (h *DefaultA2AProtocolHandler) HandleGetAuthenticatedExtendedCard(c *gin.Context, req types.JSONRPCRequest, agentCard *types.AgentCard) {
+	logger := requestLogger(c.Request.Context(), h.logger)
 	if agentCard == nil {
-		h.logger.Error("no agent card configured for agent/getAuthenticatedExtendedCard")
+		logger.Error("no agent card configured for agent/getAuthenticatedExtendedCard")
 		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "agent card not configured")
 		return
 	}
@@ -1272,18 +2123,18 @@ func (h *DefaultA2AProtocolHandler) HandleGetAuthenticatedExtendedCard(c *gin.Co
 		var params types.GetAuthenticatedExtendedCardParams
 		paramsBytes, err := json.Marshal(req.Params)
 		if err != nil {
-			h.logger.Error("failed to marshal params", zap.Error(err))
+			logger.Error("failed to marshal params", zap.Error(err))
 			h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
 			return
 		}
 		if err := json.Unmarshal(paramsBytes, &params); err != nil {
-			h.logger.Error("failed to parse agent/getAuthenticatedExtendedCard request", zap.Error(err))
+			logger.Error("failed to parse agent/getAuthenticatedExtendedCard request", zap.Error(err))
 			h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
 			return
 		}
-		h.logger.Info("returning authenticated extended agent card", zap.String("tenant", params.Tenant))
+		logger.Info("returning authenticated extended agent card", zap.String("tenant", params.Tenant))
 	} else {
-		h.logger.Info("returning authenticated extended agent card")
+		logger.Info("returning authenticated extended agent card")
 	}
 
 	h.responseSender.SendSuccess(c, req.ID, *agentCard)
@@ -1299,14 +2150,12 @@ func (bth *DefaultBackgroundTaskHandler) populateTaskMetadata(task *types.Task,
 		return
 	}
 
-	if task.Metadata == nil {
-		m := make(map[string]any)
-		task.Metadata = &m
-	}
-
-	metadata := usageTracker.GetMetadata()
-	for key, value := range metadata {
-		(*task.Metadata)[key] = value
+	metadata, err := applyUsageMetadata(task, usageTracker)
+	if err != nil {
+		bth.logger.Warn("failed to read recorded task usage",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+		return
 	}
 
 	bth.logger.Debug("populated task metadata with usage statistics",
@@ -1324,14 +2173,12 @@ func (sth *DefaultStreamingTaskHandler) populateTaskMetadata(task *types.Task, u
 		return
 	}
 
-	if task.Metadata == nil {
-		m := make(map[string]any)
-		task.Metadata = &m
-	}
-
-	metadata := usageTracker.GetMetadata()
-	for key, value := range metadata {
-		(*task.Metadata)[key] = value
+	metadata, err := applyUsageMetadata(task, usageTracker)
+	if err != nil {
+		sth.logger.Warn("failed to read recorded task usage",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+		return
 	}
 
 	sth.logger.Debug("populated task metadata with usage statistics",
@@ -9,6 +9,7 @@ import (
 	mocks "github.com/inference-gateway/adk/server/mocks"
 	types "github.com/inference-gateway/adk/types"
 	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
 	zap "go.uber.org/zap"
 )
 
@@ -221,6 +222,78 @@ func TestDefaultStreamingTaskHandler_HandleStreamingTask(t *testing.T) {
 	}
 }
 
+func TestDefaultStreamingTaskHandler_BackgroundParity(t *testing.T) {
+	statusEvent := func(state types.TaskState) cloudevents.Event {
+		event := cloudevents.NewEvent()
+		event.SetType(types.EventTaskStatusChanged)
+		_ = event.SetData(cloudevents.ApplicationJSON, types.TaskStatus{State: state})
+		return event
+	}
+
+	tests := []struct {
+		name           string
+		events         []cloudevents.Event
+		expectedEvents []string
+	}{
+		{
+			name:           "artifacts added by the agent are streamed before the final status",
+			events:         []cloudevents.Event{statusEvent(types.TaskStateCompleted)},
+			expectedEvents: []string{types.EventArtifactUpdate, types.EventTaskStatusChanged},
+		},
+		{
+			name: "input-required status pauses the task",
+			events: []cloudevents.Event{
+				statusEvent(types.TaskStateInputRequired),
+				types.NewDeltaEvent(&types.Message{MessageID: "late", Role: types.RoleAgent}),
+			},
+			expectedEvents: []string{types.EventArtifactUpdate, types.EventInputRequired},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &types.Task{
+				ID:        "task-1",
+				ContextID: "context-1",
+				Artifacts: []types.Artifact{{ArtifactID: "earlier"}},
+			}
+			agent := &mocks.FakeOpenAICompatibleAgent{}
+			agent.RunWithStreamStub = func(ctx context.Context, messages []types.Message) (<-chan cloudevents.Event, error) {
+				runTask := ctx.Value(server.TaskContextKey).(*types.Task)
+				runTask.Artifacts = append(runTask.Artifacts, types.Artifact{ArtifactID: "report"})
+
+				events := make(chan cloudevents.Event, len(tt.events))
+				for _, event := range tt.events {
+					events <- event
+				}
+				close(events)
+				return events, nil
+			}
+
+			handler := server.NewDefaultStreamingTaskHandler(zap.NewNop(), agent)
+			eventsChan, err := handler.HandleStreamingTask(context.Background(), task, &types.Message{Role: types.RoleUser})
+			assert.NoError(t, err)
+
+			var received []string
+			for event := range eventsChan {
+				received = append(received, event.Type())
+				switch event.Type() {
+				case types.EventArtifactUpdate:
+					var artifact types.Artifact
+					assert.NoError(t, event.DataAs(&artifact))
+					assert.Equal(t, "report", artifact.ArtifactID)
+				case types.EventInputRequired:
+					var message types.Message
+					assert.NoError(t, event.DataAs(&message))
+					assert.Equal(t, types.RoleAgent, message.Role)
+					assert.NotEmpty(t, message.Parts)
+				}
+			}
+			assert.Equal(t, tt.expectedEvents, received)
+		})
+	}
+}
+
 // createMockAgentWithInputRequired creates a mock agent that returns an input_required response
 func createMockAgentWithInputRequired() server.OpenAICompatibleAgent {
 	mockAgent := &mocks.FakeOpenAICompatibleAgent{}
@@ -484,3 +557,24 @@ func TestDefaultA2AProtocolHandler_MessageEnrichment(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotTask_SharesNothingWithTheTask(t *testing.T) {
+	task := types.Task{
+		ID:       "task-1",
+		Status:   types.TaskStatus{State: types.TaskStateSubmitted},
+		History:  []types.Message{{MessageID: "m1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("hello")}}},
+		Metadata: &map[string]any{"labels": map[string]any{"team": "a"}},
+	}
+
+	snapshot, err := server.SnapshotTaskForTest(task)
+	require.NoError(t, err)
+
+	// the worker goes on writing the queued task
+	task.History[0].Parts[0] = types.CreateTextPart("changed")
+	task.History = append(task.History, types.Message{MessageID: "m2", Role: types.RoleAgent})
+	(*task.Metadata)["labels"].(map[string]any)["team"] = "b"
+
+	require.Len(t, snapshot.History, 1)
+	assert.Equal(t, "hello", *snapshot.History[0].Parts[0].Text)
+	assert.Equal(t, "a", (*snapshot.Metadata)["labels"].(map[string]any)["team"])
+}
@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	uuid "github.com/google/uuid"
 	"github.com/inference-gateway/adk/server/config"
 	types "github.com/inference-gateway/adk/types"
 	zap "go.uber.org/zap"
@@ -86,10 +86,21 @@ type DefaultTaskManager struct {
 	notificationSender        PushNotificationSender
 	pushNotificationConfigsMu sync.RWMutex
 	retentionConfig           config.TaskRetentionConfig
+	historyConfig             config.TaskHistoryConfig
 	cleanupTicker             *time.Ticker
 	stopCleanup               chan struct{}
 	runningTasks              map[string]context.CancelFunc
 	runningTasksMu            sync.RWMutex
+	ids                       IDGenerator
+	clock                     Clock
+	retentionJob              atomic.Pointer[periodicJob]
+	queuedInputs              map[string][]types.Message
+	queuedInputsMu            sync.Mutex
+	waitingTasks              map[string]*taskDependencies
+	dependents                map[string][]string
+	dependenciesMu            sync.Mutex
+	stateTransitionHistory    atomic.Bool
+	taskTimings               atomic.Bool
 }
 
 // NewDefaultTaskManager creates a new default task manager
@@ -100,6 +111,11 @@ func NewDefaultTaskManager(logger *zap.Logger) *DefaultTaskManager {
 		pushNotificationConfigs: make(map[string]map[string]*types.TaskPushNotificationConfig),
 		notificationSender:      nil,
 		runningTasks:            make(map[string]context.CancelFunc),
+		queuedInputs:            make(map[string][]types.Message),
+		waitingTasks:            make(map[string]*taskDependencies),
+		dependents:              make(map[string][]string),
+		ids:                     UUIDGenerator{},
+		clock:                   SystemClock{},
 	}
 }
 
@@ -111,6 +127,11 @@ func NewDefaultTaskManagerWithStorage(logger *zap.Logger, storage Storage) *Defa
 		pushNotificationConfigs: make(map[string]map[string]*types.TaskPushNotificationConfig),
 		notificationSender:      nil,
 		runningTasks:            make(map[string]context.CancelFunc),
+		queuedInputs:            make(map[string][]types.Message),
+		waitingTasks:            make(map[string]*taskDependencies),
+		dependents:              make(map[string][]string),
+		ids:                     UUIDGenerator{},
+		clock:                   SystemClock{},
 	}
 }
 
@@ -122,6 +143,11 @@ func NewDefaultTaskManagerWithNotifications(logger *zap.Logger, notificationSend
 		pushNotificationConfigs: make(map[string]map[string]*types.TaskPushNotificationConfig),
 		notificationSender:      notificationSender,
 		runningTasks:            make(map[string]context.CancelFunc),
+		queuedInputs:            make(map[string][]types.Message),
+		waitingTasks:            make(map[string]*taskDependencies),
+		dependents:              make(map[string][]string),
+		ids:                     UUIDGenerator{},
+		clock:                   SystemClock{},
 	}
 }
 
@@ -130,6 +156,32 @@ func (tm *DefaultTaskManager) SetNotificationSender(sender PushNotificationSende
 	tm.notificationSender = sender
 }
 
+// SetIDGenerator sets the generator used for task and push notification config IDs
+func (tm *DefaultTaskManager) SetIDGenerator(ids IDGenerator) {
+	tm.ids = ids
+}
+
+// SetClock sets the clock used for task status timestamps
+func (tm *DefaultTaskManager) SetClock(clock Clock) {
+	tm.clock = clock
+}
+
+// SetHistoryConfig sets the limits applied to the history of the tasks when they are stored
+func (tm *DefaultTaskManager) SetHistoryConfig(historyConfig config.TaskHistoryConfig) {
+	tm.historyConfig = historyConfig
+}
+
+// SetStateTransitionHistory sets whether the state transitions of the tasks are recorded in
+// their metadata
+func (tm *DefaultTaskManager) SetStateTransitionHistory(enabled bool) {
+	tm.stateTransitionHistory.Store(enabled)
+}
+
+// SetTaskTimings sets whether the timing metrics of the tasks are recorded in their metadata
+func (tm *DefaultTaskManager) SetTaskTimings(enabled bool) {
+	tm.taskTimings.Store(enabled)
+}
+
 // GetStorage returns the storage interface used by this task manager
 func (tm *DefaultTaskManager) GetStorage() Storage {
 	return tm.storage
@@ -151,6 +203,20 @@ func (tm *DefaultTaskManager) UnregisterTaskCancelFunc(taskID string) {
 	tm.logger.Debug("unregistered cancel function for task", zap.String("task_id", taskID))
 }
 
+// stopRunningTask cancels the execution of a running task, reporting whether it was running
+func (tm *DefaultTaskManager) stopRunningTask(taskID string) bool {
+	tm.runningTasksMu.Lock()
+	cancelFunc, isRunning := tm.runningTasks[taskID]
+	delete(tm.runningTasks, taskID)
+	tm.runningTasksMu.Unlock()
+
+	if isRunning {
+		tm.logger.Info("stopping running task execution", zap.String("task_id", taskID))
+		cancelFunc()
+	}
+	return isRunning
+}
+
 // CreateTask creates a new task with message history managed within the task
 func (tm *DefaultTaskManager) CreateTask(contextID string, state types.TaskState, message *types.Message) *types.Task {
 	var history []types.Message
@@ -159,9 +225,9 @@ func (tm *DefaultTaskManager) CreateTask(contextID string, state types.TaskState
 		history = append(history, *message)
 	}
 
-	now := time.Now()
+	now := tm.clock.Now()
 	task := &types.Task{
-		ID: uuid.New().String(),
+		ID: tm.ids.NewID(),
 		Status: types.TaskStatus{
 			State:     types.TaskState(state),
 			Message:   message,
@@ -170,6 +236,7 @@ func (tm *DefaultTaskManager) CreateTask(contextID string, state types.TaskState
 		ContextID: contextID,
 		History:   history,
 	}
+	tm.recordTransition(task, types.TaskActorClient)
 
 	switch state {
 	case types.TaskStateCompleted, types.TaskStateFailed, types.TaskStateCancelled, types.TaskStateRejected:
@@ -201,10 +268,11 @@ func (tm *DefaultTaskManager) CreateTaskWithHistory(contextID string, state type
 	if message != nil {
 		taskHistory = append(taskHistory, *message)
 	}
+	taskHistory = retainHistory(taskHistory, tm.historyConfig)
 
-	now := time.Now()
+	now := tm.clock.Now()
 	task := &types.Task{
-		ID: uuid.New().String(),
+		ID: tm.ids.NewID(),
 		Status: types.TaskStatus{
 			State:     types.TaskState(state),
 			Message:   message,
@@ -213,6 +281,7 @@ func (tm *DefaultTaskManager) CreateTaskWithHistory(contextID string, state type
 		ContextID: contextID,
 		History:   taskHistory,
 	}
+	tm.recordTransition(task, types.TaskActorClient)
 
 	switch state {
 	case types.TaskStateCompleted, types.TaskStateFailed, types.TaskStateCancelled, types.TaskStateRejected:
@@ -263,9 +332,14 @@ func (tm *DefaultTaskManager) UpdateState(taskID string, state types.TaskState)
 		}
 	}
 
+	if err := checkTaskTransition(taskID, task.Status.State, state); err != nil {
+		return err
+	}
+
 	task.Status.State = types.TaskState(state)
-	now := time.Now()
+	now := tm.clock.Now()
 	task.Status.Timestamp = &now
+	tm.recordTransition(task, types.TaskActorAgent)
 
 	if tm.isTaskFinalState(state) {
 		tm.UnregisterTaskCancelFunc(taskID)
@@ -275,6 +349,7 @@ func (tm *DefaultTaskManager) UpdateState(taskID string, state types.TaskState)
 			tm.logger.Error("failed to store task in dead letter queue", zap.Error(err))
 			return err
 		}
+		defer tm.releaseDependents(task)
 	} else {
 		err := tm.storage.UpdateActiveTask(task)
 		if err != nil {
@@ -301,8 +376,16 @@ func (tm *DefaultTaskManager) UpdateTask(task *types.Task) error {
 		return fmt.Errorf("task cannot be nil")
 	}
 
-	now := time.Now()
+	if stored, exists := tm.GetTask(task.ID); exists {
+		if err := checkTaskTransition(task.ID, stored.Status.State, task.Status.State); err != nil {
+			return err
+		}
+	}
+
+	now := tm.clock.Now()
 	task.Status.Timestamp = &now
+	task.History = retainHistory(task.History, tm.historyConfig)
+	tm.recordTransition(task, types.TaskActorAgent)
 
 	if tm.isTaskFinalState(types.TaskState(task.Status.State)) {
 		tm.UnregisterTaskCancelFunc(task.ID)
@@ -312,6 +395,7 @@ func (tm *DefaultTaskManager) UpdateTask(task *types.Task) error {
 			tm.logger.Error("failed to store task in dead letter queue", zap.Error(err))
 			return err
 		}
+		defer tm.releaseDependents(task)
 	} else {
 		err := tm.storage.UpdateActiveTask(task)
 		if err != nil {
@@ -340,10 +424,15 @@ func (tm *DefaultTaskManager) UpdateError(taskID string, message *types.Message)
 		return NewTaskNotFoundError(taskID)
 	}
 
+	if err := checkTaskTransition(taskID, task.Status.State, types.TaskStateFailed); err != nil {
+		return err
+	}
+
 	task.Status.State = types.TaskStateFailed
 	task.Status.Message = message
-	now := time.Now()
+	now := tm.clock.Now()
 	task.Status.Timestamp = &now
+	tm.recordTransition(task, types.TaskActorAgent)
 
 	tm.UnregisterTaskCancelFunc(taskID)
 
@@ -352,6 +441,7 @@ func (tm *DefaultTaskManager) UpdateError(taskID string, message *types.Message)
 		tm.logger.Error("failed to store failed task in dead letter queue", zap.Error(err))
 		return err
 	}
+	defer tm.releaseDependents(task)
 	tm.logger.Debug("task error updated",
 		zap.String("task_id", taskID),
 		zap.String("context_id", task.ContextID),
@@ -419,9 +509,15 @@ func (tm *DefaultTaskManager) ListTasks(params types.TaskListParams) (*types.Tas
 	filter := TaskFilter{
 		State:     params.State,
 		ContextID: params.ContextID,
+		Labels:    params.Labels,
 		Limit:     params.Limit,
 		Offset:    params.Offset,
 	}
+	if params.Unowned {
+		filter.UserID = new(string)
+	} else if params.UserID != "" {
+		filter.UserID = &params.UserID
+	}
 
 	if filter.Limit <= 0 {
 		filter.Limit = 50
@@ -486,14 +582,16 @@ func (tm *DefaultTaskManager) CancelTask(taskID string) error {
 	}
 
 	task.Status.State = types.TaskStateCancelled
-	now := time.Now()
+	now := tm.clock.Now()
 	task.Status.Timestamp = &now
+	tm.recordTransition(task, types.TaskActorClient)
 
 	err := tm.storage.StoreDeadLetterTask(task)
 	if err != nil {
 		tm.logger.Error("failed to store canceled task in dead letter queue", zap.Error(err))
 		return err
 	}
+	defer tm.releaseDependents(task)
 
 	tm.logger.Info("task canceled", zap.String("task_id", taskID))
 
@@ -607,9 +705,9 @@ func (tm *DefaultTaskManager) UpdateConversationHistory(contextID string, messag
 	historyCopy := make([]types.Message, len(messages))
 	copy(historyCopy, messages)
 
-	now := time.Now()
+	now := tm.clock.Now()
 	task := &types.Task{
-		ID: uuid.New().String(),
+		ID: tm.ids.NewID(),
 		Status: types.TaskStatus{
 			State:     types.TaskStateCompleted,
 			Message:   nil,
@@ -637,7 +735,7 @@ func (tm *DefaultTaskManager) SetTaskPushNotificationConfig(config types.TaskPus
 
 	configID := config.PushNotificationConfig.ID
 	if configID == nil || *configID == "" {
-		id := uuid.New().String()
+		id := tm.ids.NewID()
 		config.PushNotificationConfig.ID = &id
 		configID = &id
 	}
@@ -740,8 +838,9 @@ func (tm *DefaultTaskManager) PauseTaskForInput(taskID string, message *types.Me
 
 	task.Status.State = types.TaskStateInputRequired
 	task.Status.Message = message
-	now := time.Now()
+	now := tm.clock.Now()
 	task.Status.Timestamp = &now
+	tm.recordTransition(task, types.TaskActorAgent)
 
 	if message != nil {
 		task.History = append(task.History, *message)
@@ -772,14 +871,15 @@ func (tm *DefaultTaskManager) ResumeTaskWithInput(taskID string, message *types.
 		return NewTaskNotFoundError(taskID)
 	}
 
-	if task.Status.State == types.TaskStateCompleted {
-		return fmt.Errorf("task %s is already completed and cannot be resumed, current state: %s", taskID, task.Status.State)
+	if !isPausedTaskState(task.Status.State) {
+		return NewInvalidTaskTransitionError(taskID, task.Status.State, types.TaskStateWorking)
 	}
 
 	task.Status.State = types.TaskStateWorking
 	task.Status.Message = message
-	now := time.Now()
+	now := tm.clock.Now()
 	task.Status.Timestamp = &now
+	tm.recordTransition(task, types.TaskActorClient)
 
 	if message != nil {
 		task.History = append(task.History, *message)
@@ -824,13 +924,17 @@ func (tm *DefaultTaskManager) SetRetentionConfig(retentionConfig config.TaskRete
 	if retentionConfig.CleanupInterval > 0 {
 		tm.stopCleanup = make(chan struct{})
 		tm.cleanupTicker = time.NewTicker(retentionConfig.CleanupInterval)
+		job := newPeriodicJob("retention_cleanup", retentionConfig.CleanupInterval, tm.clock.Now())
+		tm.retentionJob.Store(job)
 
+		ticker, stop := tm.cleanupTicker, tm.stopCleanup
 		go func() {
 			for {
 				select {
-				case <-tm.cleanupTicker.C:
+				case <-ticker.C:
 					tm.cleanupWithRetention()
-				case <-tm.stopCleanup:
+					job.ran(tm.clock.Now())
+				case <-stop:
 					return
 				}
 			}
@@ -845,6 +949,8 @@ func (tm *DefaultTaskManager) SetRetentionConfig(retentionConfig config.TaskRete
 
 // StopCleanup stops the automatic cleanup process
 func (tm *DefaultTaskManager) StopCleanup() {
+	tm.retentionJob.Store(nil)
+
 	if tm.cleanupTicker != nil {
 		tm.cleanupTicker.Stop()
 		tm.cleanupTicker = nil
@@ -788,18 +788,16 @@ func TestDefaultTaskManager_ResumeTaskWithInput(t *testing.T) {
 		assert.Equal(t, resumeMessage.MessageID, retrievedTask.History[0].MessageID)
 	})
 
-	t.Run("resume task in non-completed state should succeed", func(t *testing.T) {
+	t.Run("resume task that is not paused should fail", func(t *testing.T) {
 		task := taskManager.CreateTask("test-context-2", types.TaskStateWorking, nil)
 
 		err := taskManager.GetStorage().EnqueueTask(context.Background(), task, "test-request-id-2")
 		assert.NoError(t, err)
 
 		err = taskManager.ResumeTaskWithInput(task.ID, nil)
-		assert.NoError(t, err)
-
-		retrievedTask, exists := taskManager.GetTask(task.ID)
-		assert.True(t, exists)
-		assert.Equal(t, types.TaskStateWorking, retrievedTask.Status.State)
+		var transitionErr *server.InvalidTaskTransitionError
+		assert.ErrorAs(t, err, &transitionErr)
+		assert.Contains(t, err.Error(), "is already in state TASK_STATE_WORKING")
 	})
 
 	t.Run("resume completed task should fail", func(t *testing.T) {
@@ -810,8 +808,9 @@ func TestDefaultTaskManager_ResumeTaskWithInput(t *testing.T) {
 
 		err = taskManager.ResumeTaskWithInput(task.ID, nil)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "already completed and cannot be resumed")
-		assert.Contains(t, err.Error(), "current state: TASK_STATE_COMPLETED")
+		var transitionErr *server.InvalidTaskTransitionError
+		assert.ErrorAs(t, err, &transitionErr)
+		assert.Contains(t, err.Error(), "has ended in state TASK_STATE_COMPLETED and cannot move to TASK_STATE_WORKING")
 	})
 
 	t.Run("resume non-existent task", func(t *testing.T) {
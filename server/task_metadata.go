@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// serverTaskMetadataKeys are the task metadata keys the server records itself, which the
+// metadata of a request cannot set
+var serverTaskMetadataKeys = map[string]bool{
+	types.TaskFeedbackMetadataKey:         true,
+	types.LanguageMetadataKey:             true,
+	types.HandoffFromMetadataKey:          true,
+	types.HandoffCountMetadataKey:         true,
+	types.RecoveryCountMetadataKey:        true,
+	types.AuthScopesMetadataKey:           true,
+	types.AuthSubjectMetadataKey:          true,
+	types.UserIDMetadataKey:               true,
+	types.SpeechOutputMetadataKey:         true,
+	types.GuardrailMetadataKey:            true,
+	types.UsageMetadataKey:                true,
+	types.StreamEndReasonMetadataKey:      true,
+	types.FollowUpOfMetadataKey:           true,
+	types.ScheduleIDMetadataKey:           true,
+	types.ForkedFromMetadataKey:           true,
+	types.ForkedAtMetadataKey:             true,
+	types.StateTransitionsMetadataKey:     true,
+	types.RequestIDMetadataKey:            true,
+	types.ProgressMetadataKey:             true,
+	types.TimingsMetadataKey:              true,
+	types.SkillIDMetadataKey:              true,
+	types.AwaitingDependenciesMetadataKey: true,
+}
+
+// setTaskMetadata sets a metadata value on a task without sharing the map of copies of the
+// task. A nil value removes the key.
+func setTaskMetadata(task *types.Task, key string, value any) {
+	if value == nil && (task.Metadata == nil || (*task.Metadata)[key] == nil) {
+		return
+	}
+
+	metadata := make(map[string]any)
+	if task.Metadata != nil {
+		for k, v := range *task.Metadata {
+			metadata[k] = v
+		}
+	}
+	if value == nil {
+		delete(metadata, key)
+	} else {
+		metadata[key] = value
+	}
+	task.Metadata = &metadata
+}
+
+// parseTaskLabels returns the labels in the metadata of a message request, which must map
+// label names to strings
+func parseTaskLabels(metadata map[string]any) (map[string]string, error) {
+	raw, exists := metadata[types.TaskLabelsMetadataKey]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+
+	values, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("metadata labels must be an object")
+	}
+	labels := make(map[string]string, len(values))
+	for name, value := range values {
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("metadata label '%s' must be a string", name)
+		}
+		labels[name] = text
+	}
+	return labels, nil
+}
+
+// storeTaskMetadata stores the metadata recorded on a task for a request, in a single update
+// of the active task
+func (h *DefaultA2AProtocolHandler) storeTaskMetadata(ctx context.Context, task *types.Task) {
+	if err := h.storage.UpdateActiveTask(task); err != nil {
+		requestLogger(ctx, h.logger).Warn("failed to record task metadata",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+	}
+}
+
+// recordTaskMetadata records the metadata of a message request on the task. Labels are
+// merged with the labels of earlier requests, other keys replace earlier values, and keys
+// the server records itself are ignored.
+func (h *DefaultA2AProtocolHandler) recordTaskMetadata(task *types.Task, requestMetadata map[string]any) {
+	if len(requestMetadata) == 0 {
+		return
+	}
+
+	metadata := make(map[string]any)
+	if task.Metadata != nil {
+		for key, value := range *task.Metadata {
+			metadata[key] = value
+		}
+	}
+	for key, value := range requestMetadata {
+		if serverTaskMetadataKeys[key] || key == types.TaskLabelsMetadataKey {
+			continue
+		}
+		metadata[key] = value
+	}
+
+	labels, err := parseTaskLabels(requestMetadata)
+	if err != nil {
+		h.logger.Warn("ignoring invalid task labels",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+	}
+	if len(labels) > 0 {
+		merged, _ := types.GetTaskLabels(task)
+		if merged == nil {
+			merged = make(map[string]string, len(labels))
+		}
+		for name, value := range labels {
+			merged[name] = value
+		}
+		taskLabels := make(map[string]any, len(merged))
+		for name, value := range merged {
+			taskLabels[name] = value
+		}
+		metadata[types.TaskLabelsMetadataKey] = taskLabels
+	}
+	task.Metadata = &metadata
+}
+
+// hasTaskUser reports whether the task belongs to the user, when one is given
+func hasTaskUser(task *types.Task, userID *string) bool {
+	return userID == nil || types.GetTaskUserID(task) == *userID
+}
+
+// hasTaskLabels reports whether the task carries every one of the labels
+func hasTaskLabels(task *types.Task, labels map[string]string) bool {
+	if len(labels) == 0 {
+		return true
+	}
+	taskLabels, err := types.GetTaskLabels(task)
+	if err != nil {
+		return false
+	}
+	for name, value := range labels {
+		if taskValue, ok := taskLabels[name]; !ok || taskValue != value {
+			return false
+		}
+	}
+	return true
+}
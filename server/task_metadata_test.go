@@ -0,0 +1,142 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestA2AServer_TaskLabels(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "assistant"})
+	router := s.setupRouter(cfg)
+
+	call := func(method string, params any) (json.RawMessage, *types.JSONRPCError) {
+		encoded, err := json.Marshal(params)
+		require.NoError(t, err)
+		body := `{"jsonrpc":"2.0","id":"1","method":"` + method + `","params":` + string(encoded) + `}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+		var response struct {
+			Result json.RawMessage     `json:"result"`
+			Error  *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Result, response.Error
+	}
+	send := func(metadata map[string]any) (types.Task, *types.JSONRPCError) {
+		result, rpcErr := call("message/send", types.MessageSendParams{
+			Message:  types.Message{Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("hello")}},
+			Metadata: metadata,
+		})
+		var task types.Task
+		if rpcErr == nil {
+			require.NoError(t, json.Unmarshal(result, &task))
+		}
+		return task, rpcErr
+	}
+
+	billing, rpcErr := send(map[string]any{
+		"labels":                       map[string]any{"team": "billing", "env": "prod"},
+		"ticket":                       "OPS-42",
+		types.UsageMetadataKey:         "forged",
+		types.RecoveryCountMetadataKey: 99,
+	})
+	require.Nil(t, rpcErr)
+	require.NotNil(t, billing.Metadata)
+	assert.Equal(t, "OPS-42", (*billing.Metadata)["ticket"])
+	assert.NotContains(t, *billing.Metadata, types.UsageMetadataKey, "keys the server records cannot be set")
+	assert.NotContains(t, *billing.Metadata, types.RecoveryCountMetadataKey, "clients cannot forge the recovery count")
+	labels, err := types.GetTaskLabels(&billing)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "billing", "env": "prod"}, labels)
+
+	_, rpcErr = send(map[string]any{"labels": map[string]any{"team": "search", "env": "prod"}})
+	require.Nil(t, rpcErr)
+
+	list := func(labels map[string]string) []string {
+		result, rpcErr := call("tasks/list", types.TaskListParams{Labels: labels})
+		require.Nil(t, rpcErr)
+		var taskList types.TaskList
+		require.NoError(t, json.Unmarshal(result, &taskList))
+		var ids []string
+		for _, task := range taskList.Tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{billing.ID}, list(map[string]string{"team": "billing"}))
+	assert.Len(t, list(map[string]string{"env": "prod"}), 2)
+	assert.Empty(t, list(map[string]string{"team": "billing", "env": "dev"}))
+
+	_, rpcErr = send(map[string]any{"labels": map[string]any{"priority": 1}})
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, int(ErrInvalidParams), rpcErr.Code)
+	assert.Equal(t, "metadata label 'priority' must be a string", rpcErr.Message)
+}
+
+func TestSetTaskMetadata(t *testing.T) {
+	task := &types.Task{ID: "t1"}
+	setTaskMetadata(task, types.LanguageMetadataKey, nil)
+	assert.Nil(t, task.Metadata, "removing a key of a task without metadata leaves it without")
+
+	setTaskMetadata(task, types.LanguageMetadataKey, "de")
+	copied := *task
+	setTaskMetadata(task, types.RequestIDMetadataKey, "req-1")
+	assert.Equal(t, types.Struct{types.LanguageMetadataKey: "de"}, *copied.Metadata, "copies of the task are left unchanged")
+	assert.Equal(t, "req-1", (*task.Metadata)[types.RequestIDMetadataKey])
+
+	setTaskMetadata(task, types.LanguageMetadataKey, nil)
+	assert.NotContains(t, *task.Metadata, types.LanguageMetadataKey)
+}
+
+// updateCountingStorage counts the updates of active tasks
+type updateCountingStorage struct {
+	*InMemoryStorage
+	updates int
+}
+
+func (s *updateCountingStorage) UpdateActiveTask(task *types.Task) error {
+	s.updates++
+	return s.InMemoryStorage.UpdateActiveTask(task)
+}
+
+func TestProtocolHandler_StoresTaskMetadataOnce(t *testing.T) {
+	logger := zap.NewNop()
+	storage := &updateCountingStorage{InMemoryStorage: NewInMemoryStorage(logger, 20)}
+	taskManager := NewDefaultTaskManagerWithStorage(logger, storage)
+	h := NewDefaultA2AProtocolHandler(logger, storage, taskManager, NewDefaultResponseSender(logger))
+
+	ctx := middlewares.ContextWithRequestID(context.Background(), "req-1")
+	ctx = middlewares.ContextWithSubject(ctx, "alice")
+	ctx = middlewares.ContextWithScopes(ctx, []string{"tasks:write"})
+	ctx = middlewares.ContextWithUserID(ctx, "alice")
+	task, _, err := h.createTaskFromMessage(ctx, types.MessageSendParams{
+		Message:  types.Message{MessageID: "m1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("hello")}},
+		Metadata: map[string]any{"ticket": "OPS-42"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, storage.updates, "the metadata of the request is stored in one update")
+
+	stored, err := storage.GetActiveTask(task.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.Metadata)
+	assert.Equal(t, "OPS-42", (*stored.Metadata)["ticket"])
+	assert.Equal(t, "req-1", (*stored.Metadata)[types.RequestIDMetadataKey])
+	assert.Equal(t, "alice", (*stored.Metadata)[types.AuthSubjectMetadataKey])
+	assert.Equal(t, "alice", types.GetTaskUserID(stored))
+}
@@ -0,0 +1,382 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	uuid "github.com/google/uuid"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// withheldPlaceholder replaces a value of a shared transcript that could not be checked
+const withheldPlaceholder = "[WITHHELD]"
+
+// Entities masked in shared transcripts besides personal data. The Redactor masks them with
+// their name in upper case, so the matches of the configured patterns become [REDACTED].
+const (
+	shareCredentialEntity = "credential"
+	shareRedactedEntity   = "redacted"
+)
+
+// shareCredentialPatterns mask the credentials that should never leave the server in a
+// shared transcript
+var shareCredentialPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9\-._~+/]+=*`),
+	regexp.MustCompile(`\b(?:sk|pk|rk)-[A-Za-z0-9_-]{16,}\b`),
+	regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`),
+}
+
+// ShareClaims are the claims carried by a signed share token
+type ShareClaims struct {
+	TaskID    string   `json:"tid"`
+	Scopes    []string `json:"scp"`
+	ExpiresAt int64    `json:"exp"`
+	Nonce     string   `json:"jti"`
+}
+
+// HasScope reports whether the token grants the scope
+func (c *ShareClaims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+// TaskShareService issues and verifies read-only share tokens for tasks and renders the
+// redacted transcripts they grant access to. Tokens are stateless HMAC-signed claims, so
+// they stay valid until they expire or the signing secret is rotated.
+type TaskShareService struct {
+	mu         sync.RWMutex
+	secret     []byte
+	defaultTTL time.Duration
+	maxTTL     time.Duration
+	baseURL    string
+	redactor   *Redactor
+}
+
+// NewTaskShareService creates a share service from the sharing configuration. baseURL is
+// the public URL of the A2A server used to build share links; when empty links are relative.
+func NewTaskShareService(cfg config.SharingConfig, baseURL string) (*TaskShareService, error) {
+	if cfg.Secret == "" {
+		return nil, fmt.Errorf("sharing secret is required")
+	}
+
+	patterns := &regexPIIDetector{}
+	for _, pattern := range shareCredentialPatterns {
+		patterns.patterns = append(patterns.patterns, piiPattern{entity: shareCredentialEntity, pattern: pattern})
+	}
+	for _, expr := range cfg.RedactPatterns {
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", expr, err)
+		}
+		patterns.patterns = append(patterns.patterns, piiPattern{entity: shareRedactedEntity, pattern: pattern})
+	}
+
+	defaultTTL := cfg.DefaultTTL
+	if defaultTTL <= 0 {
+		defaultTTL = 24 * time.Hour
+	}
+	maxTTL := cfg.MaxTTL
+	if maxTTL < defaultTTL {
+		maxTTL = defaultTTL
+	}
+
+	return &TaskShareService{
+		secret:     []byte(cfg.Secret),
+		defaultTTL: defaultTTL,
+		maxTTL:     maxTTL,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		redactor:   NewRedactor(nil, patterns),
+	}, nil
+}
+
+// Issue creates a share link for a task. Scopes default to the transcript only and a
+// zero ttl uses the configured default lifetime.
+func (s *TaskShareService) Issue(taskID string, scopes []string, ttl time.Duration) (*types.TaskShareLink, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("task id is required")
+	}
+	if ttl == 0 {
+		ttl = s.defaultTTL
+	}
+	if ttl < 0 || ttl > s.maxTTL {
+		return nil, fmt.Errorf("share lifetime must be between 1s and %s", s.maxTTL)
+	}
+	if len(scopes) == 0 {
+		scopes = []string{types.TaskShareScopeTranscript}
+	}
+	for _, scope := range scopes {
+		if scope != types.TaskShareScopeTranscript && scope != types.TaskShareScopeArtifacts {
+			return nil, fmt.Errorf("unsupported share scope: %s", scope)
+		}
+	}
+	if !slices.Contains(scopes, types.TaskShareScopeTranscript) {
+		scopes = append([]string{types.TaskShareScopeTranscript}, scopes...)
+	}
+
+	expiresAt := time.Now().Add(ttl).UTC()
+	claims := ShareClaims{
+		TaskID:    taskID,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt.Unix(),
+		Nonce:     uuid.New().String(),
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal share claims: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	token := encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded))
+
+	return &types.TaskShareLink{
+		ExpiresAt: expiresAt.Format(time.RFC3339),
+		Scopes:    scopes,
+		TaskID:    taskID,
+		Token:     token,
+		URL:       s.baseURL + "/share/" + token,
+	}, nil
+}
+
+// Verify checks a share token's signature and expiry and returns its claims
+func (s *TaskShareService) Verify(token string) (*ShareClaims, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found {
+		return nil, fmt.Errorf("malformed share token")
+	}
+
+	got, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(got, s.sign(encoded)) {
+		return nil, fmt.Errorf("invalid share token signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("malformed share token")
+	}
+	var claims ShareClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("malformed share token")
+	}
+
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, fmt.Errorf("share token expired")
+	}
+	return &claims, nil
+}
+
+// Transcript renders the redacted view of a task permitted by the claims
+func (s *TaskShareService) Transcript(ctx context.Context, task *types.Task, claims *ShareClaims) types.SharedTranscript {
+	transcript := types.SharedTranscript{
+		ContextID: task.ContextID,
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0).UTC().Format(time.RFC3339),
+		Messages:  []types.Message{},
+		State:     task.Status.State,
+		TaskID:    task.ID,
+	}
+
+	for _, message := range conversationMessages(task) {
+		if message.Role != types.RoleUser && message.Role != types.RoleAgent {
+			continue
+		}
+		transcript.Messages = append(transcript.Messages, types.Message{
+			ContextID:        message.ContextID,
+			MessageID:        message.MessageID,
+			Parts:            s.redactParts(ctx, message.Parts, &transcript.Redactions),
+			ReferenceTaskIds: message.ReferenceTaskIds,
+			Role:             message.Role,
+			TaskID:           message.TaskID,
+		})
+	}
+
+	if claims.HasScope(types.TaskShareScopeArtifacts) {
+		for _, artifact := range task.Artifacts {
+			transcript.Artifacts = append(transcript.Artifacts, types.Artifact{
+				ArtifactID:  artifact.ArtifactID,
+				Description: s.redactStringPtr(ctx, artifact.Description, &transcript.Redactions),
+				Name:        artifact.Name,
+				Parts:       s.redactParts(ctx, artifact.Parts, &transcript.Redactions),
+			})
+		}
+	}
+
+	return transcript
+}
+
+// redactParts copies parts without metadata, withholding file contents and masking text
+func (s *TaskShareService) redactParts(ctx context.Context, parts []types.Part, redactions *int) []types.Part {
+	redacted := make([]types.Part, 0, len(parts))
+	for _, part := range parts {
+		var out types.Part
+		if part.Text != nil {
+			out.Text = s.redactStringPtr(ctx, part.Text, redactions)
+		}
+		if part.Data != nil {
+			data, _ := s.redactValue(ctx, map[string]any(part.Data.Data), redactions).(map[string]any)
+			out.Data = &types.DataPart{Data: data}
+		}
+		if part.File != nil {
+			if part.File.FileWithBytes != nil || part.File.FileWithURI != nil {
+				*redactions++
+			}
+			out.File = &types.FilePart{MediaType: part.File.MediaType, Name: part.File.Name}
+		}
+		redacted = append(redacted, out)
+	}
+	return redacted
+}
+
+// redactValue masks every string inside a decoded JSON value
+func (s *TaskShareService) redactValue(ctx context.Context, value any, redactions *int) any {
+	switch v := value.(type) {
+	case string:
+		return s.redactString(ctx, v, redactions)
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			out[key] = s.redactValue(ctx, item, redactions)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = s.redactValue(ctx, item, redactions)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// redactStringPtr masks an optional string
+func (s *TaskShareService) redactStringPtr(ctx context.Context, value *string, redactions *int) *string {
+	if value == nil {
+		return nil
+	}
+	redacted := s.redactString(ctx, *value, redactions)
+	return &redacted
+}
+
+// redactString masks the personal data and credentials in a string, and every match of the
+// configured patterns. A string that cannot be checked is withheld.
+func (s *TaskShareService) redactString(ctx context.Context, value string, redactions *int) string {
+	redacted, masked, err := s.redactor.redact(ctx, value)
+	if err != nil {
+		*redactions++
+		return withheldPlaceholder
+	}
+	*redactions += len(masked)
+	return redacted
+}
+
+// SetSecret replaces the secret signing share tokens. Tokens signed with the previous secret
+// are no longer valid.
+func (s *TaskShareService) SetSecret(secret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.secret = []byte(secret)
+}
+
+// sign computes the token signature for the encoded claims
+func (s *TaskShareService) sign(encoded string) []byte {
+	s.mu.RLock()
+	secret := s.secret
+	s.mu.RUnlock()
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// SetTaskShareService sets the service used to issue share links for tasks/share
+func (h *DefaultA2AProtocolHandler) SetTaskShareService(service *TaskShareService) {
+	h.taskShares = service
+}
+
+// HandleTaskShare processes tasks/share requests
+func (h *DefaultA2AProtocolHandler) HandleTaskShare(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	if h.taskShares == nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidRequest), "task sharing is not enabled")
+		return
+	}
+
+	var params types.TaskShareParams
+	paramsBytes, err := json.Marshal(req.Params)
+	if err != nil {
+		logger.Error("failed to marshal params", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
+		return
+	}
+
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		logger.Error("failed to parse tasks/share request", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
+		return
+	}
+
+	if _, exists := h.taskManager.GetTask(params.ID); !exists {
+		logger.Error("task not found", zap.String("task_id", params.ID))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "task not found")
+		return
+	}
+
+	var ttl time.Duration
+	if params.TTLSeconds != nil {
+		if *params.TTLSeconds <= 0 {
+			h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "ttlSeconds must be positive")
+			return
+		}
+		ttl = time.Duration(*params.TTLSeconds) * time.Second
+	}
+
+	link, err := h.taskShares.Issue(params.ID, params.Scopes, ttl)
+	if err != nil {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), err.Error())
+		return
+	}
+
+	logger.Info("task share link issued",
+		zap.String("task_id", params.ID),
+		zap.Strings("scopes", link.Scopes),
+		zap.String("expires_at", link.ExpiresAt))
+
+	h.responseSender.SendSuccess(c, req.ID, *link)
+}
+
+// handleSharedTranscript serves the redacted transcript granted by a share token
+func (s *A2AServerImpl) handleSharedTranscript(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+
+	claims, err := s.taskShares.Verify(c.Param("token"))
+	if err != nil {
+		s.logger.Debug("rejected share token", zap.Error(err))
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	task, exists := s.taskManager.GetTask(claims.TaskID)
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "task not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, s.taskShares.Transcript(c.Request.Context(), task, claims))
+}
@@ -0,0 +1,237 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func newTestTaskShareService(t *testing.T, secret string) *TaskShareService {
+	t.Helper()
+	service, err := NewTaskShareService(config.SharingConfig{
+		Secret:         secret,
+		DefaultTTL:     time.Hour,
+		MaxTTL:         24 * time.Hour,
+		RedactPatterns: []string{`ACME-\d+`},
+	}, "https://agent.example.com/")
+	require.NoError(t, err)
+	return service
+}
+
+func TestNewTaskShareService(t *testing.T) {
+	_, err := NewTaskShareService(config.SharingConfig{}, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sharing secret is required")
+
+	_, err = NewTaskShareService(config.SharingConfig{Secret: "s", RedactPatterns: []string{"("}}, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid redact pattern")
+}
+
+func TestTaskShareService_IssueAndVerify(t *testing.T) {
+	service := newTestTaskShareService(t, "secret")
+	other := newTestTaskShareService(t, "other-secret")
+
+	tests := []struct {
+		name         string
+		scopes       []string
+		ttl          time.Duration
+		expectScopes []string
+		expectError  string
+	}{
+		{name: "defaults to transcript", expectScopes: []string{types.TaskShareScopeTranscript}},
+		{name: "artifacts include transcript", scopes: []string{types.TaskShareScopeArtifacts}, ttl: time.Minute, expectScopes: []string{types.TaskShareScopeTranscript, types.TaskShareScopeArtifacts}},
+		{name: "unsupported scope", scopes: []string{"write"}, expectError: "unsupported share scope"},
+		{name: "lifetime above maximum", ttl: 48 * time.Hour, expectError: "share lifetime must be between"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link, err := service.Issue("task-1", tt.scopes, tt.ttl)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "https://agent.example.com/share/"+link.Token, link.URL)
+			assert.Equal(t, tt.expectScopes, link.Scopes)
+
+			claims, err := service.Verify(link.Token)
+			require.NoError(t, err)
+			assert.Equal(t, "task-1", claims.TaskID)
+			assert.Equal(t, tt.expectScopes, claims.Scopes)
+
+			_, err = other.Verify(link.Token)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid share token signature")
+		})
+	}
+}
+
+func TestTaskShareService_VerifyRejects(t *testing.T) {
+	service := newTestTaskShareService(t, "secret")
+	link, err := service.Issue("task-1", nil, 0)
+	require.NoError(t, err)
+
+	expiredPayload, err := json.Marshal(ShareClaims{TaskID: "task-1", Scopes: []string{"transcript"}, ExpiresAt: time.Now().Add(-time.Minute).Unix()})
+	require.NoError(t, err)
+	expiredEncoded := base64.RawURLEncoding.EncodeToString(expiredPayload)
+	expired := expiredEncoded + "." + base64.RawURLEncoding.EncodeToString(service.sign(expiredEncoded))
+
+	forgedPayload, err := json.Marshal(ShareClaims{TaskID: "task-2", Scopes: []string{"transcript"}, ExpiresAt: time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	_, signature, _ := bytes.Cut([]byte(link.Token), []byte("."))
+	forged := base64.RawURLEncoding.EncodeToString(forgedPayload) + "." + string(signature)
+
+	tests := []struct {
+		name        string
+		token       string
+		expectError string
+	}{
+		{name: "missing signature", token: "abc", expectError: "malformed share token"},
+		{name: "forged claims", token: forged, expectError: "invalid share token signature"},
+		{name: "expired", token: expired, expectError: "share token expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Verify(tt.token)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectError)
+		})
+	}
+}
+
+func TestTaskShareService_Transcript(t *testing.T) {
+	service := newTestTaskShareService(t, "secret")
+
+	text := func(s string) *string { return &s }
+	uri := "https://files.example.com/report.pdf"
+	fileName := "report.pdf"
+	metadata := map[string]any{"internal": "value"}
+	description := "summary for jane@example.com"
+	task := &types.Task{
+		ID:        "task-1",
+		ContextID: "ctx-1",
+		History: []types.Message{
+			{
+				MessageID: "m1",
+				Role:      types.RoleUser,
+				Metadata:  &metadata,
+				Parts: []types.Part{
+					{Text: text("I am jane@example.com, SSN 123-45-6789, ticket ACME-42, key sk-abcdefghijklmnopqrstuv")},
+					{File: &types.FilePart{FileWithURI: &uri, MediaType: "application/pdf", Name: fileName}},
+				},
+			},
+			{
+				MessageID: "m2",
+				Role:      types.RoleAgent,
+				Parts: []types.Part{
+					{Data: &types.DataPart{Data: map[string]any{"auth": "Bearer abc.def", "count": float64(2)}}},
+				},
+			},
+		},
+		Status: types.TaskStatus{
+			State:   types.TaskStateCompleted,
+			Message: &types.Message{MessageID: "m3", Role: types.RoleAgent, Parts: []types.Part{{Text: text("done")}}},
+		},
+		Artifacts: []types.Artifact{
+			{ArtifactID: "a1", Description: &description, Metadata: &metadata, Parts: []types.Part{{Text: text("card 4111 1111 1111 1111")}}},
+		},
+	}
+
+	link, err := service.Issue("task-1", nil, 0)
+	require.NoError(t, err)
+	claims, err := service.Verify(link.Token)
+	require.NoError(t, err)
+
+	transcript := service.Transcript(context.Background(), task, claims)
+	assert.Equal(t, "task-1", transcript.TaskID)
+	assert.Equal(t, types.TaskStateCompleted, transcript.State)
+	require.Len(t, transcript.Messages, 3)
+	assert.Nil(t, transcript.Messages[0].Metadata)
+	assert.Equal(t, "I am [EMAIL], SSN [SSN], ticket [REDACTED], key [CREDENTIAL]", *transcript.Messages[0].Parts[0].Text)
+	assert.Nil(t, transcript.Messages[0].Parts[1].File.FileWithURI)
+	assert.Equal(t, fileName, transcript.Messages[0].Parts[1].File.Name)
+	assert.Equal(t, map[string]any{"auth": "[CREDENTIAL]", "count": float64(2)}, map[string]any(transcript.Messages[1].Parts[0].Data.Data))
+	assert.Equal(t, "m3", transcript.Messages[2].MessageID)
+	assert.Empty(t, transcript.Artifacts)
+	assert.Equal(t, 6, transcript.Redactions)
+
+	link, err = service.Issue("task-1", []string{types.TaskShareScopeArtifacts}, 0)
+	require.NoError(t, err)
+	claims, err = service.Verify(link.Token)
+	require.NoError(t, err)
+
+	transcript = service.Transcript(context.Background(), task, claims)
+	require.Len(t, transcript.Artifacts, 1)
+	assert.Nil(t, transcript.Artifacts[0].Metadata)
+	assert.Equal(t, "summary for [EMAIL]", *transcript.Artifacts[0].Description)
+	assert.Equal(t, "card [CARD_NUMBER]", *transcript.Artifacts[0].Parts[0].Text)
+}
+
+func TestA2AServer_SharedTranscriptEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		SharingConfig: config.SharingConfig{Enable: true, Secret: "secret", DefaultTTL: time.Hour, MaxTTL: time.Hour},
+	}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	task := s.taskManager.CreateTask("ctx-1", types.TaskStateWorking, &types.Message{
+		MessageID: "m1",
+		Role:      types.RoleUser,
+		Parts:     []types.Part{types.CreateTextPart("contact me at jane@example.com")},
+	})
+	router := s.setupRouter(cfg)
+
+	body, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      "req-1",
+		"method":  "tasks/share",
+		"params":  map[string]any{"id": task.ID, "ttlSeconds": 60},
+	})
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var shareResponse struct {
+		Result types.TaskShareLink `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &shareResponse))
+	require.NotEmpty(t, shareResponse.Result.Token)
+	assert.Equal(t, "/share/"+shareResponse.Result.Token, shareResponse.Result.URL)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, shareResponse.Result.URL, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+	var transcript types.SharedTranscript
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &transcript))
+	assert.Equal(t, task.ID, transcript.TaskID)
+	require.NotEmpty(t, transcript.Messages)
+	assert.Equal(t, "contact me at [EMAIL]", *transcript.Messages[0].Parts[0].Text)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/share/not-a-token", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	disabled := NewA2AServer(&config.Config{}, zap.NewNop(), nil)
+	w = httptest.NewRecorder()
+	disabled.setupRouter(disabled.cfg).ServeHTTP(w, httptest.NewRequest(http.MethodGet, shareResponse.Result.URL, nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
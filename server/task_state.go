@@ -0,0 +1,150 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// taskTransitions lists the states a task may move to from each of its non-terminal states.
+// A task that has completed, failed, been canceled or been rejected never changes state again.
+// A working task may move to working again when it receives further input, and back to
+// submitted when the server queues it again on shutdown or after a restart.
+var taskTransitions = map[types.TaskState][]types.TaskState{
+	types.TaskStateUnspecified: {
+		types.TaskStateSubmitted, types.TaskStateWorking, types.TaskStateRejected,
+	},
+	types.TaskStateSubmitted: {
+		types.TaskStateWorking, types.TaskStateInputRequired, types.TaskStateAuthRequired,
+		types.TaskStateCompleted, types.TaskStateFailed, types.TaskStateCancelled, types.TaskStateRejected,
+	},
+	types.TaskStateWorking: {
+		types.TaskStateSubmitted, types.TaskStateWorking, types.TaskStateInputRequired, types.TaskStateAuthRequired,
+		types.TaskStateCompleted, types.TaskStateFailed, types.TaskStateCancelled,
+	},
+	types.TaskStateInputRequired: {
+		types.TaskStateWorking, types.TaskStateFailed, types.TaskStateCancelled,
+	},
+	types.TaskStateAuthRequired: {
+		types.TaskStateWorking, types.TaskStateFailed, types.TaskStateCancelled,
+	},
+}
+
+// canTransitionTask reports whether a task may move from one state to another
+func canTransitionTask(from, to types.TaskState) bool {
+	return slices.Contains(taskTransitions[from], to)
+}
+
+// checkTaskTransition returns an InvalidTaskTransitionError when a task may not move from one
+// state to another. Writing a task in the state it is already in is not a transition.
+func checkTaskTransition(taskID string, from, to types.TaskState) error {
+	if from == to || canTransitionTask(from, to) {
+		return nil
+	}
+	return NewInvalidTaskTransitionError(taskID, from, to)
+}
+
+// isPausedTaskState reports whether a task in the state is waiting for the client to resume it
+func isPausedTaskState(state types.TaskState) bool {
+	return state == types.TaskStateInputRequired || state == types.TaskStateAuthRequired
+}
+
+// isTerminalTaskState reports whether a task in the state has ended
+func isTerminalTaskState(state types.TaskState) bool {
+	switch state {
+	case types.TaskStateCompleted, types.TaskStateFailed, types.TaskStateCancelled, types.TaskStateRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// recordTransition appends the current state of a task to the state transition history in its
+// metadata, as triggered by the actor, when the task manager records state transitions. A
+// state already recorded as the latest transition is not recorded again, so the transition
+// recorded first, by the actor that triggered it, is kept when the task is stored again. The
+// stored transitions are used when they are ahead of a stale copy of the task. The timings of
+// the task are recorded along.
+func (tm *DefaultTaskManager) recordTransition(task *types.Task, actor string) {
+	tm.recordTimings(task)
+	if !tm.stateTransitionHistory.Load() {
+		return
+	}
+
+	transitions, err := types.GetTaskStateTransitions(task)
+	if err != nil {
+		tm.logger.Warn("replacing unreadable task state transitions",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+		transitions = nil
+	}
+	if stored, exists := tm.GetTask(task.ID); exists {
+		if storedTransitions, err := types.GetTaskStateTransitions(stored); err == nil && len(storedTransitions) > len(transitions) {
+			transitions = storedTransitions
+		}
+	}
+	if n := len(transitions); n > 0 && transitions[n-1].State == task.Status.State {
+		return
+	}
+	transitions = append(transitions, types.TaskStateTransition{
+		Actor:     actor,
+		State:     task.Status.State,
+		Timestamp: tm.clock.Now().UTC().Format(time.RFC3339Nano),
+	})
+
+	setTaskMetadata(task, types.StateTransitionsMetadataKey, transitions)
+}
+
+// recordStateTransition records the current state of a task as triggered by the actor, ahead
+// of storing the task, when the task manager records state transitions
+func recordStateTransition(taskManager TaskManager, task *types.Task, actor string) {
+	if defaultTM, ok := taskManager.(*DefaultTaskManager); ok {
+		defaultTM.recordTransition(task, actor)
+	}
+}
+
+// InvalidTaskTransitionError represents an error when a task cannot move to a state from its
+// current state, such as resuming a task that has already completed
+type InvalidTaskTransitionError struct {
+	TaskID string
+	From   types.TaskState
+	To     types.TaskState
+}
+
+func (e *InvalidTaskTransitionError) Error() string {
+	if isTerminalTaskState(e.From) {
+		return fmt.Sprintf("task %s has ended in state %s and cannot move to %s", e.TaskID, e.From, e.To)
+	}
+	if e.From == e.To {
+		return fmt.Sprintf("task %s is already in state %s", e.TaskID, e.From)
+	}
+	return fmt.Sprintf("task %s cannot move from %s to %s", e.TaskID, e.From, e.To)
+}
+
+// NewInvalidTaskTransitionError creates a new InvalidTaskTransitionError
+func NewInvalidTaskTransitionError(taskID string, from, to types.TaskState) error {
+	return &InvalidTaskTransitionError{TaskID: taskID, From: from, To: to}
+}
+
+// taskErrorCode returns the JSON-RPC error code reported when a task cannot be found, canceled
+// or have a message applied to it
+func taskErrorCode(err error) JRPCErrorCode {
+	var notFound *TaskNotFoundError
+	var notCancelable *TaskNotCancelableError
+	var transition *InvalidTaskTransitionError
+	switch {
+	case errors.As(err, &notFound):
+		return ErrTaskNotFound
+	case errors.As(err, &notCancelable):
+		return ErrTaskNotCancelable
+	case errors.As(err, &transition):
+		return ErrUnsupportedOperation
+	default:
+		return ErrInternalError
+	}
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestA2AServer_StateTransitionHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := func(enabled bool) (*A2AServerImpl, *gin.Engine) {
+		cfg := &config.Config{}
+		s := NewA2AServer(cfg, zap.NewNop(), nil)
+		s.SetAgentCard(types.AgentCard{
+			Name:         "assistant",
+			Capabilities: types.AgentCapabilities{StateTransitionHistory: new(enabled)},
+		})
+		return s, s.setupRouter(cfg)
+	}
+
+	call := func(t *testing.T, router *gin.Engine, method string, params any) *types.Task {
+		encoded, err := json.Marshal(params)
+		require.NoError(t, err)
+		body := `{"jsonrpc":"2.0","id":"1","method":"` + method + `","params":` + string(encoded) + `}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body)))
+		var response struct {
+			Result *types.Task         `json:"result"`
+			Error  *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Nil(t, response.Error)
+		return response.Result
+	}
+
+	send := types.MessageSendParams{Message: types.Message{
+		MessageID: "m-1",
+		Role:      types.RoleUser,
+		Parts:     []types.Part{types.CreateTextPart("hello")},
+	}}
+
+	t.Run("transitions are recorded and returned by tasks/get", func(t *testing.T) {
+		s, router := setup(true)
+		created := call(t, router, "message/send", send)
+		require.NotNil(t, created)
+
+		stale, exists := s.taskManager.GetTask(created.ID)
+		require.True(t, exists)
+		staleCopy := *stale
+
+		require.NoError(t, s.taskManager.UpdateState(created.ID, types.TaskStateWorking))
+		staleCopy.Status.State = types.TaskStateCompleted
+		require.NoError(t, s.taskManager.UpdateTask(&staleCopy))
+
+		task := call(t, router, "tasks/get", types.TaskQueryParams{ID: created.ID})
+		require.NotNil(t, task)
+		transitions, err := types.GetTaskStateTransitions(task)
+		require.NoError(t, err)
+		require.Len(t, transitions, 3, "transitions stored since a copy of the task was taken are kept")
+		assert.Equal(t, types.TaskStateSubmitted, transitions[0].State)
+		assert.Equal(t, types.TaskActorClient, transitions[0].Actor)
+		assert.Equal(t, types.TaskStateWorking, transitions[1].State)
+		assert.Equal(t, types.TaskActorAgent, transitions[1].Actor)
+		assert.Equal(t, types.TaskStateCompleted, transitions[2].State)
+		assert.Equal(t, types.TaskActorAgent, transitions[2].Actor)
+		assert.NotEmpty(t, transitions[2].Timestamp)
+	})
+
+	t.Run("transitions are not recorded without the capability", func(t *testing.T) {
+		s, router := setup(false)
+		created := call(t, router, "message/send", send)
+		require.NotNil(t, created)
+		require.NoError(t, s.taskManager.UpdateState(created.ID, types.TaskStateWorking))
+
+		task := call(t, router, "tasks/get", types.TaskQueryParams{ID: created.ID})
+		require.NotNil(t, task)
+		transitions, err := types.GetTaskStateTransitions(task)
+		require.NoError(t, err)
+		assert.Empty(t, transitions)
+	})
+}
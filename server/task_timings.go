@@ -0,0 +1,84 @@
+package server
+
+import (
+	"time"
+
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// recordTimings records in the task metadata when the task was first submitted, first started
+// working and ended, when the task manager records timings. The timings of the stored task are
+// merged in, since a handler saves a copy of the task taken before it started working.
+func (tm *DefaultTaskManager) recordTimings(task *types.Task) {
+	if !tm.taskTimings.Load() {
+		return
+	}
+
+	timings, err := types.GetTaskTimings(task)
+	if err != nil {
+		tm.logger.Warn("replacing unreadable task timings",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+		timings = nil
+	}
+	if timings == nil {
+		timings = &types.TaskTimings{}
+	}
+	if stored, exists := tm.GetTask(task.ID); exists {
+		if storedTimings, err := types.GetTaskTimings(stored); err == nil && storedTimings != nil {
+			mergeTaskTimings(timings, storedTimings)
+		}
+	}
+
+	now := tm.clock.Now().UTC().Format(time.RFC3339Nano)
+	switch {
+	case task.Status.State == types.TaskStateSubmitted && timings.QueuedAt == "":
+		timings.QueuedAt = now
+	case task.Status.State == types.TaskStateWorking && timings.StartedAt == "":
+		timings.StartedAt = now
+	case tm.isTaskFinalState(task.Status.State) && timings.CompletedAt == "":
+		timings.CompletedAt = now
+	}
+	setTaskTimings(task, *timings)
+}
+
+// mergeTaskTimings completes the timings with the ones of another copy of the task: the times
+// missing are taken from it, and the totals, which only grow, are kept at the larger one
+func mergeTaskTimings(timings *types.TaskTimings, other *types.TaskTimings) {
+	if timings.QueuedAt == "" {
+		timings.QueuedAt = other.QueuedAt
+	}
+	if timings.StartedAt == "" {
+		timings.StartedAt = other.StartedAt
+	}
+	if timings.CompletedAt == "" {
+		timings.CompletedAt = other.CompletedAt
+	}
+	timings.Iterations = max(timings.Iterations, other.Iterations)
+	timings.LLMTimeMs = max(timings.LLMTimeMs, other.LLMTimeMs)
+	timings.ToolTimeMs = max(timings.ToolTimeMs, other.ToolTimeMs)
+}
+
+// addRunTimings adds the LLM time, tool time and iterations of a run to the timings of the task
+func addRunTimings(task *types.Task, usageTracker *UsageTracker) error {
+	timings, err := types.GetTaskTimings(task)
+	if err != nil {
+		return err
+	}
+	if timings == nil {
+		timings = &types.TaskTimings{}
+	}
+	llmTime, toolTime, iterations := usageTracker.Timings()
+	timings.LLMTimeMs += llmTime.Milliseconds()
+	timings.ToolTimeMs += toolTime.Milliseconds()
+	timings.Iterations += iterations
+	setTaskTimings(task, *timings)
+	return nil
+}
+
+// setTaskTimings records the timings in the task metadata
+func setTaskTimings(task *types.Task, timings types.TaskTimings) {
+	setTaskMetadata(task, types.TimingsMetadataKey, timings)
+}
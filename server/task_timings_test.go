@@ -0,0 +1,115 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestDefaultTaskManager_RecordsTaskTimings(t *testing.T) {
+	timingsOf := func(t *testing.T, tm *DefaultTaskManager, taskID string) *types.TaskTimings {
+		t.Helper()
+		task, exists := tm.GetTask(taskID)
+		require.True(t, exists)
+		timings, err := types.GetTaskTimings(task)
+		require.NoError(t, err)
+		return timings
+	}
+
+	t.Run("times and run totals are recorded", func(t *testing.T) {
+		tm := NewDefaultTaskManager(zap.NewNop())
+		tm.SetTaskTimings(true)
+
+		task := tm.CreateTask("ctx", types.TaskStateSubmitted, &types.Message{MessageID: "m1", Role: types.RoleUser})
+		queued := timingsOf(t, tm, task.ID)
+		require.NotNil(t, queued)
+		assert.NotEmpty(t, queued.QueuedAt)
+		assert.Empty(t, queued.StartedAt)
+
+		// the handler works on the copy of the task taken before it started working
+		running, exists := tm.GetTask(task.ID)
+		require.True(t, exists)
+		require.NoError(t, tm.UpdateState(task.ID, types.TaskStateWorking))
+		started := timingsOf(t, tm, task.ID)
+		assert.Equal(t, queued.QueuedAt, started.QueuedAt)
+		assert.NotEmpty(t, started.StartedAt)
+
+		tracker := NewUsageTracker()
+		tracker.IncrementIteration()
+		tracker.IncrementIteration()
+		tracker.AddLLMTime(1500 * time.Millisecond)
+		tracker.AddToolTime(300 * time.Millisecond)
+		require.NoError(t, addRunTimings(running, tracker))
+		running.Status.State = types.TaskStateCompleted
+		require.NoError(t, tm.UpdateTask(running))
+
+		completed := timingsOf(t, tm, task.ID)
+		assert.Equal(t, started.QueuedAt, completed.QueuedAt)
+		assert.Equal(t, started.StartedAt, completed.StartedAt, "the start time of the stored task is kept")
+		assert.NotEmpty(t, completed.CompletedAt)
+		assert.Equal(t, int64(1500), completed.LLMTimeMs)
+		assert.Equal(t, int64(300), completed.ToolTimeMs)
+		assert.Equal(t, 2, completed.Iterations)
+	})
+
+	t.Run("timings are not recorded when disabled", func(t *testing.T) {
+		tm := NewDefaultTaskManager(zap.NewNop())
+		task := tm.CreateTask("ctx", types.TaskStateSubmitted, &types.Message{MessageID: "m1", Role: types.RoleUser})
+		require.NoError(t, tm.UpdateState(task.ID, types.TaskStateWorking))
+		assert.Nil(t, timingsOf(t, tm, task.ID))
+	})
+}
+
+func TestAddRunTimings_AccumulatesAcrossRuns(t *testing.T) {
+	task := &types.Task{ID: "task-1", Metadata: &map[string]any{
+		// timings recorded by an earlier run, as decoded from storage
+		types.TimingsMetadataKey: map[string]any{"queuedAt": "2026-01-01T00:00:00Z", "llmTimeMs": float64(200), "toolTimeMs": float64(50), "iterations": float64(1)},
+	}}
+
+	tracker := NewUsageTracker()
+	tracker.IncrementIteration()
+	tracker.AddLLMTime(100 * time.Millisecond)
+	require.NoError(t, addRunTimings(task, tracker))
+
+	timings, err := types.GetTaskTimings(task)
+	require.NoError(t, err)
+	assert.Equal(t, types.TaskTimings{QueuedAt: "2026-01-01T00:00:00Z", LLMTimeMs: 300, ToolTimeMs: 50, Iterations: 2}, *timings)
+}
+
+func TestStreamHandlerTask_MergesHandlerMetadata(t *testing.T) {
+	task := &types.Task{ID: "task-1", Metadata: &map[string]any{
+		types.UserIDMetadataKey:  "alice",
+		types.TimingsMetadataKey: map[string]any{"llmTimeMs": float64(200)},
+	}}
+	initial := map[string]any{}
+	for key, value := range *task.Metadata {
+		initial[key] = value
+	}
+
+	handlerTask := streamHandlerTask(task)
+	tracker := NewUsageTracker()
+	tracker.IncrementIteration()
+	tracker.AddLLMTime(100 * time.Millisecond)
+	_, err := applyUsageMetadata(handlerTask, tracker)
+	require.NoError(t, err)
+	handlerTask.Artifacts = append(handlerTask.Artifacts, types.Artifact{ArtifactID: "artifact-1"})
+
+	assert.NotContains(t, *task.Metadata, "execution_stats", "the handler writes its own copy")
+	assert.Empty(t, task.Artifacts)
+
+	// the stream records metadata of its own while the handler runs
+	(*task.Metadata)["findings"] = []string{"email"}
+	mergeHandlerMetadata(task, handlerTask, initial)
+
+	timings, err := types.GetTaskTimings(task)
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), timings.LLMTimeMs)
+	assert.Contains(t, *task.Metadata, "execution_stats")
+	assert.Equal(t, "alice", (*task.Metadata)[types.UserIDMetadataKey])
+	assert.Equal(t, []string{"email"}, (*task.Metadata)["findings"])
+}
@@ -0,0 +1,311 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"slices"
+	"strings"
+	"time"
+
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// TranscriptFormat is the format a conversation transcript is rendered in
+type TranscriptFormat string
+
+// Transcript formats
+const (
+	TranscriptFormatMarkdown TranscriptFormat = "markdown"
+	TranscriptFormatHTML     TranscriptFormat = "html"
+)
+
+// transcriptFiles are the filename and media type of the transcript artifact of each format
+var transcriptFiles = map[TranscriptFormat]struct{ filename, mimeType string }{
+	TranscriptFormatMarkdown: {"transcript.md", "text/markdown"},
+	TranscriptFormatHTML:     {"transcript.html", "text/html"},
+}
+
+// transcriptEntry is a message of a transcript with its parts rendered to text
+type transcriptEntry struct {
+	Role  string
+	Parts []transcriptPart
+}
+
+// transcriptPart is a rendered message part: text, a JSON code block, or a file reference
+type transcriptPart struct {
+	Text string
+	Code string
+	File string
+}
+
+// transcriptArtifact is an artifact of the task listed in a transcript
+type transcriptArtifact struct {
+	Name  string
+	Files []string
+}
+
+// transcript is the content of a rendered transcript
+type transcript struct {
+	TaskID    string
+	ContextID string
+	State     string
+	Entries   []transcriptEntry
+	Artifacts []transcriptArtifact
+	Footer    []string
+}
+
+// RenderTranscript renders the conversation of a task - the user and agent messages of its
+// history, the artifacts it produced, and a footer summarizing its token usage and timings -
+// into a Markdown or HTML document
+func RenderTranscript(task *types.Task, format TranscriptFormat) ([]byte, error) {
+	content, err := buildTranscript(task)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case TranscriptFormatMarkdown:
+		return content.markdown(), nil
+	case TranscriptFormatHTML:
+		var buf bytes.Buffer
+		if err := transcriptHTMLTemplate.Execute(&buf, content); err != nil {
+			return nil, fmt.Errorf("failed to render transcript: %w", err)
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported transcript format %q", format)
+	}
+}
+
+// buildTranscript collects the messages, artifacts and usage of a task
+func buildTranscript(task *types.Task) (*transcript, error) {
+	content := &transcript{TaskID: task.ID, ContextID: task.ContextID, State: string(task.Status.State)}
+	for _, message := range conversationMessages(task) {
+		if message.Role != types.RoleUser && message.Role != types.RoleAgent {
+			continue
+		}
+		entry := transcriptEntry{Role: "User"}
+		if message.Role == types.RoleAgent {
+			entry.Role = "Agent"
+		}
+		for _, part := range message.Parts {
+			switch {
+			case part.Text != nil:
+				if text := strings.TrimSpace(*part.Text); text != "" {
+					entry.Parts = append(entry.Parts, transcriptPart{Text: text})
+				}
+			case part.Data != nil:
+				data, err := json.MarshalIndent(part.Data.Data, "", "  ")
+				if err != nil {
+					return nil, fmt.Errorf("failed to render data part: %w", err)
+				}
+				entry.Parts = append(entry.Parts, transcriptPart{Code: string(data)})
+			case part.File != nil:
+				entry.Parts = append(entry.Parts, transcriptPart{File: describeTranscriptFile(part.File)})
+			}
+		}
+		content.Entries = append(content.Entries, entry)
+	}
+
+	for _, artifact := range task.Artifacts {
+		if isTranscriptArtifact(artifact) {
+			continue
+		}
+		listed := transcriptArtifact{Name: artifact.ArtifactID}
+		if artifact.Name != nil && *artifact.Name != "" {
+			listed.Name = *artifact.Name
+		}
+		for _, part := range artifact.Parts {
+			if part.File != nil {
+				listed.Files = append(listed.Files, describeTranscriptFile(part.File))
+			}
+		}
+		content.Artifacts = append(content.Artifacts, listed)
+	}
+
+	usage, recorded, err := types.GetTaskUsage(task)
+	if err != nil {
+		return nil, err
+	}
+	if recorded {
+		content.Footer = append(content.Footer, fmt.Sprintf("Tokens: %d prompt, %d completion, %d total",
+			usage.PromptTokens, usage.CompletionTokens, usage.TotalTokens))
+	}
+	timings, err := types.GetTaskTimings(task)
+	if err != nil {
+		return nil, err
+	}
+	if timings != nil {
+		content.Footer = append(content.Footer, fmt.Sprintf("Model calls: %d, model time: %s, tool time: %s",
+			timings.Iterations,
+			time.Duration(timings.LLMTimeMs)*time.Millisecond,
+			time.Duration(timings.ToolTimeMs)*time.Millisecond))
+	}
+	return content, nil
+}
+
+// conversationMessages returns the history of a task followed by its status message, when the
+// history does not hold it
+func conversationMessages(task *types.Task) []types.Message {
+	messages := task.History
+	if task.Status.Message != nil && !slices.ContainsFunc(messages, func(m types.Message) bool {
+		return m.MessageID == task.Status.Message.MessageID
+	}) {
+		messages = append(slices.Clone(messages), *task.Status.Message)
+	}
+	return messages
+}
+
+// describeTranscriptFile names a file with its media type, and its URI when it has one
+func describeTranscriptFile(file *types.FilePart) string {
+	description := file.Name
+	if description == "" {
+		description = "file"
+	}
+	if file.MediaType != "" {
+		description += " (" + file.MediaType + ")"
+	}
+	if file.FileWithURI != nil {
+		description += " " + *file.FileWithURI
+	}
+	return description
+}
+
+// markdown renders the transcript as a Markdown document
+func (t *transcript) markdown() []byte {
+	var b strings.Builder
+	b.WriteString("# Conversation transcript\n\n")
+	fmt.Fprintf(&b, "- **Task:** `%s`\n- **Context:** `%s`\n- **State:** %s\n", t.TaskID, t.ContextID, t.State)
+	for _, entry := range t.Entries {
+		fmt.Fprintf(&b, "\n## %s\n", entry.Role)
+		for _, part := range entry.Parts {
+			switch {
+			case part.Code != "":
+				fmt.Fprintf(&b, "\n```json\n%s\n```\n", part.Code)
+			case part.File != "":
+				fmt.Fprintf(&b, "\n_Attached file: %s_\n", part.File)
+			default:
+				fmt.Fprintf(&b, "\n%s\n", part.Text)
+			}
+		}
+	}
+	if len(t.Artifacts) > 0 {
+		b.WriteString("\n## Artifacts\n\n")
+		for _, artifact := range t.Artifacts {
+			fmt.Fprintf(&b, "- **%s**", artifact.Name)
+			if len(artifact.Files) > 0 {
+				fmt.Fprintf(&b, ": %s", strings.Join(artifact.Files, ", "))
+			}
+			b.WriteString("\n")
+		}
+	}
+	if len(t.Footer) > 0 {
+		b.WriteString("\n---\n\n")
+		for _, line := range t.Footer {
+			fmt.Fprintf(&b, "%s  \n", line)
+		}
+	}
+	return []byte(b.String())
+}
+
+// transcriptHTMLTemplate renders the transcript as a standalone HTML page
+var transcriptHTMLTemplate = template.Must(template.New("transcript").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Conversation transcript</title>
+<style>
+body { font-family: sans-serif; max-width: 48rem; margin: 2rem auto; line-height: 1.5; }
+.message { border-left: 3px solid #ccc; margin: 1rem 0; padding: 0 1rem; }
+.message.agent { border-color: #4a7bd0; }
+.text { white-space: pre-wrap; }
+pre { background: #f4f4f4; padding: 0.5rem; overflow-x: auto; }
+footer { border-top: 1px solid #ccc; margin-top: 2rem; color: #555; }
+</style>
+</head>
+<body>
+<h1>Conversation transcript</h1>
+<ul>
+<li><strong>Task:</strong> <code>{{.TaskID}}</code></li>
+<li><strong>Context:</strong> <code>{{.ContextID}}</code></li>
+<li><strong>State:</strong> {{.State}}</li>
+</ul>
+{{range .Entries}}<section class="message {{if eq .Role "Agent"}}agent{{else}}user{{end}}">
+<h2>{{.Role}}</h2>
+{{range .Parts}}{{if .Code}}<pre><code>{{.Code}}</code></pre>
+{{else if .File}}<p><em>Attached file: {{.File}}</em></p>
+{{else}}<p class="text">{{.Text}}</p>
+{{end}}{{end}}</section>
+{{end}}{{if .Artifacts}}<h2>Artifacts</h2>
+<ul>
+{{range .Artifacts}}<li><strong>{{.Name}}</strong>{{range $i, $file := .Files}}{{if $i}},{{else}}:{{end}} {{$file}}{{end}}</li>
+{{end}}</ul>
+{{end}}{{if .Footer}}<footer>
+{{range .Footer}}<p>{{.}}</p>
+{{end}}</footer>
+{{end}}</body>
+</html>
+`))
+
+// TaskTranscripts renders the conversation of completed tasks into a transcript artifact
+// stored through the artifact service
+type TaskTranscripts struct {
+	artifactService ArtifactService
+	format          TranscriptFormat
+	logger          *zap.Logger
+}
+
+// NewTaskTranscripts creates transcripts of completed tasks in the format
+func NewTaskTranscripts(artifactService ArtifactService, format TranscriptFormat, logger *zap.Logger) *TaskTranscripts {
+	return &TaskTranscripts{artifactService: artifactService, format: format, logger: logger}
+}
+
+// attach renders the transcript of a completed task and adds it to the task's artifacts,
+// replacing the transcript of an earlier run, and returns it. A transcript that cannot be
+// rendered or stored is logged and leaves the task without one.
+func (t *TaskTranscripts) attach(task *types.Task) *types.Artifact {
+	if t == nil || task == nil || task.Status.State != types.TaskStateCompleted {
+		return nil
+	}
+
+	file, ok := transcriptFiles[t.format]
+	if !ok {
+		t.logger.Warn("unsupported transcript format", zap.String("format", string(t.format)))
+		return nil
+	}
+	task.Artifacts = slices.DeleteFunc(task.Artifacts, isTranscriptArtifact)
+	content, err := RenderTranscript(task, t.format)
+	if err != nil {
+		t.logger.Warn("failed to render transcript",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+		return nil
+	}
+
+	mimeType := file.mimeType
+	artifact, err := t.artifactService.CreateFileArtifact(task.ContextID, "Transcript", "Conversation transcript of the task", file.filename, content, &mimeType)
+	if err != nil {
+		t.logger.Warn("failed to store transcript",
+			zap.String("task_id", task.ID),
+			zap.Error(err))
+		return nil
+	}
+	if artifact.Metadata == nil {
+		artifact.Metadata = &types.Struct{}
+	}
+	(*artifact.Metadata)[types.TranscriptMetadataKey] = string(t.format)
+	task.Artifacts = append(task.Artifacts, artifact)
+	return &artifact
+}
+
+// isTranscriptArtifact reports whether an artifact is the conversation transcript of its task
+func isTranscriptArtifact(artifact types.Artifact) bool {
+	if artifact.Metadata == nil {
+		return false
+	}
+	_, transcript := (*artifact.Metadata)[types.TranscriptMetadataKey]
+	return transcript
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+func newTranscriptTestTask() *types.Task {
+	reportURI := "https://files.example.com/artifacts/ctx-1/a-1/report.csv"
+	reportName := "Report"
+	metadata := types.Struct{
+		types.UsageMetadataKey: types.TokenUsage{PromptTokens: 120, CompletionTokens: 45, TotalTokens: 165},
+	}
+	return &types.Task{
+		ID:        "task-1",
+		ContextID: "ctx-1",
+		Status:    types.TaskStatus{State: types.TaskStateCompleted},
+		Metadata:  &metadata,
+		History: []types.Message{
+			{MessageID: "m-1", Role: types.RoleUser, Parts: []types.Part{
+				types.CreateTextPart("Summarize <sales>"),
+				types.CreateDataPart(map[string]any{"quarter": "Q3"}),
+			}},
+			{MessageID: "m-2", Role: "tool", Parts: []types.Part{types.CreateTextPart("tool output")}},
+			{MessageID: "m-3", Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart("Sales grew by 4%.")}},
+		},
+		Artifacts: []types.Artifact{{
+			ArtifactID: "a-1",
+			Name:       &reportName,
+			Parts:      []types.Part{types.CreateFilePart("report.csv", "text/csv", nil, &reportURI)},
+		}},
+	}
+}
+
+func TestRenderTranscript(t *testing.T) {
+	task := newTranscriptTestTask()
+
+	t.Run("markdown", func(t *testing.T) {
+		content, err := RenderTranscript(task, TranscriptFormatMarkdown)
+		require.NoError(t, err)
+		transcript := string(content)
+		assert.Contains(t, transcript, "# Conversation transcript")
+		assert.Contains(t, transcript, "- **Task:** `task-1`")
+		assert.Contains(t, transcript, "## User\n\nSummarize <sales>\n")
+		assert.Contains(t, transcript, "```json\n{\n  \"quarter\": \"Q3\"\n}\n```")
+		assert.Contains(t, transcript, "## Agent\n\nSales grew by 4%.\n")
+		assert.NotContains(t, transcript, "tool output", "only user and agent messages are transcribed")
+		assert.Contains(t, transcript, "- **Report**: report.csv (text/csv) https://files.example.com/artifacts/ctx-1/a-1/report.csv")
+		assert.Contains(t, transcript, "Tokens: 120 prompt, 45 completion, 165 total")
+	})
+
+	t.Run("html", func(t *testing.T) {
+		content, err := RenderTranscript(task, TranscriptFormatHTML)
+		require.NoError(t, err)
+		transcript := string(content)
+		assert.Contains(t, transcript, "<!DOCTYPE html>")
+		assert.Contains(t, transcript, `<p class="text">Summarize &lt;sales&gt;</p>`)
+		assert.Contains(t, transcript, "<h2>Agent</h2>")
+		assert.Contains(t, transcript, "<li><strong>Report</strong>: report.csv (text/csv)")
+		assert.Contains(t, transcript, "<p>Tokens: 120 prompt, 45 completion, 165 total</p>")
+	})
+
+	_, err := RenderTranscript(task, "pdf")
+	assert.Error(t, err)
+}
+
+func TestTaskTranscripts_Attach(t *testing.T) {
+	service := newTestArtifactService(t, 0)
+	transcripts := NewTaskTranscripts(service, TranscriptFormatMarkdown, zap.NewNop())
+	task := newTranscriptTestTask()
+
+	transcript := transcripts.attach(task)
+	require.NotNil(t, transcript)
+	require.Len(t, task.Artifacts, 2)
+	assert.Equal(t, transcript.ArtifactID, task.Artifacts[1].ArtifactID)
+	assert.Equal(t, "transcript.md", transcript.Parts[0].File.Name)
+	assert.Equal(t, "text/markdown", transcript.Parts[0].File.MediaType)
+	assert.Equal(t, "markdown", (*transcript.Metadata)[types.TranscriptMetadataKey])
+	stored := readArtifactFile(t, service, "ctx-1", *transcript)
+	assert.Contains(t, stored, "Sales grew by 4%.")
+	assert.NotContains(t, stored, "Transcript", "the transcript does not list itself")
+
+	replaced := transcripts.attach(task)
+	require.NotNil(t, replaced)
+	require.Len(t, task.Artifacts, 2, "the transcript of an earlier run is replaced")
+	assert.Equal(t, replaced.ArtifactID, task.Artifacts[1].ArtifactID)
+
+	working := newTranscriptTestTask()
+	working.Status.State = types.TaskStateWorking
+	assert.Nil(t, transcripts.attach(working))
+	assert.Len(t, working.Artifacts, 1)
+}
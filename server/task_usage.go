@@ -0,0 +1,79 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// HandleTaskUsage processes tasks/usage requests
+func (h *DefaultA2AProtocolHandler) HandleTaskUsage(c *gin.Context, req types.JSONRPCRequest) {
+	logger := requestLogger(c.Request.Context(), h.logger)
+	var params types.ContextUsageParams
+	paramsBytes, err := json.Marshal(req.Params)
+	if err != nil {
+		logger.Error("failed to marshal params", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid params")
+		return
+	}
+
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		logger.Error("failed to parse tasks/usage request", zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "invalid request")
+		return
+	}
+
+	if params.ContextID == "" {
+		h.responseSender.SendError(c, req.ID, int(ErrInvalidParams), "context id is required")
+		return
+	}
+
+	usage, err := h.contextUsage(params.ContextID)
+	if err != nil {
+		logger.Error("failed to aggregate context usage",
+			zap.String("context_id", params.ContextID),
+			zap.Error(err))
+		h.responseSender.SendError(c, req.ID, int(ErrInternalError), "failed to aggregate usage")
+		return
+	}
+
+	logger.Info("context usage aggregated",
+		zap.String("context_id", params.ContextID),
+		zap.Int("task_count", usage.TaskCount),
+		zap.Int64("total_tokens", usage.Usage.TotalTokens))
+	h.responseSender.SendSuccess(c, req.ID, usage)
+}
+
+// contextUsage sums the token usage recorded on the tasks of a context
+func (h *DefaultA2AProtocolHandler) contextUsage(contextID string) (*types.ContextUsage, error) {
+	tasks, err := h.storage.ListTasksByContext(contextID, TaskFilter{
+		SortBy:    TaskSortFieldCreatedAt,
+		SortOrder: SortOrderAsc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	usage := &types.ContextUsage{
+		ContextID: contextID,
+		TaskCount: len(tasks),
+		Tasks:     make([]types.TaskUsage, 0, len(tasks)),
+	}
+	for _, task := range tasks {
+		taskUsage, _, err := types.GetTaskUsage(task)
+		if err != nil {
+			return nil, fmt.Errorf("task %s: %w", task.ID, err)
+		}
+		usage.Tasks = append(usage.Tasks, types.TaskUsage{
+			State:  task.Status.State,
+			TaskID: task.ID,
+			Usage:  taskUsage,
+		})
+		usage.Usage = usage.Usage.Add(taskUsage)
+	}
+	return usage, nil
+}
@@ -0,0 +1,101 @@
+package server_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	server "github.com/inference-gateway/adk/server"
+	mocks "github.com/inference-gateway/adk/server/mocks"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestProtocolHandler_HandleTaskUsage(t *testing.T) {
+	withUsage := func(id string, state types.TaskState, usage any) *types.Task {
+		task := &types.Task{ID: id, ContextID: "ctx-1", Status: types.TaskStatus{State: state}}
+		if usage != nil {
+			task.Metadata = &map[string]any{types.UsageMetadataKey: usage}
+		}
+		return task
+	}
+
+	tests := []struct {
+		name        string
+		tasks       []*types.Task
+		params      map[string]any
+		expectError string
+		expected    types.ContextUsage
+	}{
+		{
+			name: "sums the usage of the tasks in the context",
+			tasks: []*types.Task{
+				withUsage("task-1", types.TaskStateCompleted, map[string]any{"prompt_tokens": int64(100), "completion_tokens": int64(20), "total_tokens": int64(120)}),
+				// usage decoded from storage holds float64 values
+				withUsage("task-2", types.TaskStateInputRequired, map[string]any{"prompt_tokens": float64(40), "completion_tokens": float64(10), "total_tokens": float64(50)}),
+				withUsage("task-3", types.TaskStateSubmitted, nil),
+			},
+			params: map[string]any{"contextId": "ctx-1"},
+			expected: types.ContextUsage{
+				ContextID: "ctx-1",
+				TaskCount: 3,
+				Tasks: []types.TaskUsage{
+					{TaskID: "task-1", State: types.TaskStateCompleted, Usage: types.TokenUsage{PromptTokens: 100, CompletionTokens: 20, TotalTokens: 120}},
+					{TaskID: "task-2", State: types.TaskStateInputRequired, Usage: types.TokenUsage{PromptTokens: 40, CompletionTokens: 10, TotalTokens: 50}},
+					{TaskID: "task-3", State: types.TaskStateSubmitted},
+				},
+				Usage: types.TokenUsage{PromptTokens: 140, CompletionTokens: 30, TotalTokens: 170},
+			},
+		},
+		{
+			name:     "unknown context has no usage",
+			params:   map[string]any{"contextId": "ctx-1"},
+			expected: types.ContextUsage{ContextID: "ctx-1", Tasks: []types.TaskUsage{}},
+		},
+		{
+			name:        "missing context id",
+			params:      map[string]any{},
+			expectError: "context id is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			storage := &mocks.FakeStorage{}
+			storage.ListTasksByContextReturns(tt.tasks, nil)
+			h := server.NewDefaultA2AProtocolHandler(logger, storage, &mocks.FakeTaskManager{}, server.NewDefaultResponseSender(logger))
+
+			c, w := newRequestContext(t, "{}")
+			reqID := any("req-1")
+			h.HandleTaskUsage(c, types.JSONRPCRequest{
+				JSONRPC: "2.0",
+				ID:      &reqID,
+				Method:  "tasks/usage",
+				Params:  tt.params,
+			})
+
+			var response struct {
+				Result types.ContextUsage `json:"result"`
+				Error  *struct {
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			if tt.expectError != "" {
+				require.NotNil(t, response.Error)
+				assert.Contains(t, response.Error.Message, tt.expectError)
+				assert.Equal(t, 0, storage.ListTasksByContextCallCount())
+				return
+			}
+
+			require.Nil(t, response.Error)
+			assert.Equal(t, tt.expected, response.Result)
+			contextID, _ := storage.ListTasksByContextArgsForCall(0)
+			assert.Equal(t, "ctx-1", contextID)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+// tlsClientAuthTypes maps the client certificate policies of the configuration to their TLS
+// client authentication types
+var tlsClientAuthTypes = map[string]tls.ClientAuthType{
+	"":                                   tls.NoClientCert,
+	config.TLSClientAuthNone:             tls.NoClientCert,
+	config.TLSClientAuthRequest:          tls.RequestClientCert,
+	config.TLSClientAuthRequire:          tls.RequireAnyClientCert,
+	config.TLSClientAuthVerifyIfGiven:    tls.VerifyClientCertIfGiven,
+	config.TLSClientAuthRequireAndVerify: tls.RequireAndVerifyClientCert,
+}
+
+// certificateStore holds the certificates and client CAs of a TLS server. Every handshake
+// takes them from the store, so reloading the files rotates them without a restart.
+type certificateStore struct {
+	cfg        config.TLSConfig
+	nextProtos []string
+	logger     *zap.Logger
+
+	mu sync.RWMutex
+	// certificates are tried in order for the server name of a handshake, the first one
+	// being the default
+	certificates []tls.Certificate
+	clientCAs    *x509.CertPool
+
+	files []*watchedFile
+}
+
+// newCertificateStore loads the certificates and client CAs of the TLS configuration. The
+// application protocols are offered in every handshake, such as h2 for HTTP/2.
+func newCertificateStore(cfg config.TLSConfig, nextProtos []string, logger *zap.Logger) (*certificateStore, error) {
+	store := &certificateStore{cfg: cfg, nextProtos: nextProtos, logger: logger}
+	for _, path := range store.paths() {
+		file := &watchedFile{path: path}
+		if _, err := file.changed(); err != nil {
+			return nil, fmt.Errorf("failed to read tls file: %w", err)
+		}
+		store.files = append(store.files, file)
+	}
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// paths returns the files of the certificates, keys and client CAs
+func (s *certificateStore) paths() []string {
+	paths := []string{s.cfg.CertPath, s.cfg.KeyPath}
+	for _, pair := range s.cfg.SNICertificates {
+		certPath, keyPath, _ := strings.Cut(pair, ":")
+		paths = append(paths, certPath, keyPath)
+	}
+	if s.cfg.ClientCAPath != "" {
+		paths = append(paths, s.cfg.ClientCAPath)
+	}
+	return paths
+}
+
+// load reads the certificates and client CAs, replacing the current ones once all of them
+// loaded
+func (s *certificateStore) load() error {
+	certificate, err := tls.LoadX509KeyPair(s.cfg.CertPath, s.cfg.KeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load tls certificate: %w", err)
+	}
+	certificates := []tls.Certificate{certificate}
+	for _, pair := range s.cfg.SNICertificates {
+		certPath, keyPath, _ := strings.Cut(pair, ":")
+		certificate, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return fmt.Errorf("failed to load sni certificate '%s': %w", certPath, err)
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	var clientCAs *x509.CertPool
+	if s.cfg.ClientCAPath != "" {
+		data, err := os.ReadFile(s.cfg.ClientCAPath)
+		if err != nil {
+			return fmt.Errorf("failed to read client ca: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(data) {
+			return fmt.Errorf("no certificates found in client ca '%s'", s.cfg.ClientCAPath)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.certificates = certificates
+	s.clientCAs = clientCAs
+	return nil
+}
+
+// serverConfig returns the TLS configuration of an HTTP server, taking the certificates and
+// client CAs of every handshake from the store
+func (s *certificateStore) serverConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		NextProtos:         s.nextProtos,
+		GetCertificate:     s.getCertificate,
+		GetConfigForClient: s.configForClient,
+	}
+}
+
+// configForClient returns the TLS configuration of a handshake with the current client CAs
+func (s *certificateStore) configForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		NextProtos:     s.nextProtos,
+		GetCertificate: s.getCertificate,
+		ClientAuth:     tlsClientAuthTypes[s.cfg.ClientAuth],
+		ClientCAs:      s.clientCAs,
+	}, nil
+}
+
+// getCertificate selects the first certificate valid for the server name of the handshake,
+// or the default certificate when none is
+func (s *certificateStore) getCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if hello.ServerName != "" {
+		for i := range s.certificates {
+			if hello.SupportsCertificate(&s.certificates[i]) == nil {
+				return &s.certificates[i], nil
+			}
+		}
+	}
+	return &s.certificates[0], nil
+}
+
+// watch reloads the certificates and client CAs at the interval while their files change,
+// until the context ends
+func (s *certificateStore) watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.reloadIfChanged()
+		}
+	}
+}
+
+// reloadIfChanged reloads the certificates and client CAs when one of their files changed.
+// Files written one after the other may not match yet, so a failed reload keeps the current
+// certificates and is retried on the next check.
+func (s *certificateStore) reloadIfChanged() bool {
+	changed := false
+	for _, file := range s.files {
+		fileChanged, err := file.changed()
+		if err != nil {
+			s.logger.Warn("failed to check tls file for changes", zap.String("path", file.path), zap.Error(err))
+			continue
+		}
+		changed = changed || fileChanged
+	}
+	if !changed {
+		return false
+	}
+
+	if err := s.load(); err != nil {
+		s.logger.Error("failed to reload tls certificates, keeping the current ones", zap.Error(err))
+		for _, file := range s.files {
+			file.sum = [sha256.Size]byte{}
+		}
+		return false
+	}
+	s.logger.Info("reloaded tls certificates")
+	return true
+}
+
+// httpNextProtos returns the application protocols a TLS server offers, with HTTP/2 unless
+// it is disabled
+func httpNextProtos(http2 bool) []string {
+	if http2 {
+		return []string{"h2", "http/1.1"}
+	}
+	return []string{"http/1.1"}
+}
@@ -0,0 +1,192 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+)
+
+// testCA issues certificates for the TLS tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns the PEM certificate and key of a leaf certificate for the name
+func (ca *testCA) issue(t *testing.T, name string, serial int64, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeTestFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+// serveTLS serves an HTTP server with the TLS configuration of the store, returning its address
+func serveTLS(t *testing.T, store *certificateStore) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &http.Server{
+		Handler:           http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		TLSConfig:         store.serverConfig(),
+		ReadHeaderTimeout: time.Second,
+		ErrorLog:          log.New(io.Discard, "", 0),
+	}
+	go func() { _ = server.ServeTLS(listener, "", "") }()
+	t.Cleanup(func() { _ = server.Close() })
+	return listener.Addr().String()
+}
+
+func TestCertificateStore(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	paths := func(name string) (string, string) {
+		return filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	}
+	for serial, name := range []string{"a.example.com", "b.example.com", "client"} {
+		usage := x509.ExtKeyUsageServerAuth
+		if name == "client" {
+			usage = x509.ExtKeyUsageClientAuth
+		}
+		cert, key := ca.issue(t, name, int64(serial+2), usage)
+		certPath, keyPath := paths(name)
+		writeTestFile(t, certPath, cert)
+		writeTestFile(t, keyPath, key)
+	}
+	caPath := filepath.Join(dir, "ca.crt")
+	writeTestFile(t, caPath, ca.pem)
+
+	certPath, keyPath := paths("a.example.com")
+	sniCertPath, sniKeyPath := paths("b.example.com")
+	store, err := newCertificateStore(config.TLSConfig{
+		Enable:          true,
+		CertPath:        certPath,
+		KeyPath:         keyPath,
+		SNICertificates: []string{sniCertPath + ":" + sniKeyPath},
+		ClientAuth:      config.TLSClientAuthRequireAndVerify,
+		ClientCAPath:    caPath,
+	}, httpNextProtos(true), zap.NewNop())
+	require.NoError(t, err)
+	addr := serveTLS(t, store)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	clientCertPath, clientKeyPath := paths("client")
+	clientCert, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath)
+	require.NoError(t, err)
+
+	dial := func(serverName string, certificates ...tls.Certificate) (*tls.ConnectionState, error) {
+		conn, err := tls.Dial("tcp", addr, &tls.Config{
+			ServerName:   serverName,
+			RootCAs:      roots,
+			Certificates: certificates,
+			NextProtos:   []string{"h2", "http/1.1"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = conn.Close() }()
+		// the server verifies the client certificate after the client finished the handshake
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(200*time.Millisecond)))
+		if _, err := conn.Read(make([]byte, 1)); err != nil && !isTimeout(err) {
+			return nil, err
+		}
+		state := conn.ConnectionState()
+		return &state, nil
+	}
+
+	t.Run("certificate is selected by server name", func(t *testing.T) {
+		state, err := dial("b.example.com", clientCert)
+		require.NoError(t, err)
+		assert.Equal(t, "b.example.com", state.PeerCertificates[0].Subject.CommonName)
+		assert.Equal(t, "h2", state.NegotiatedProtocol)
+
+		state, err = dial("a.example.com", clientCert)
+		require.NoError(t, err)
+		assert.Equal(t, "a.example.com", state.PeerCertificates[0].Subject.CommonName)
+	})
+
+	t.Run("client without certificate is rejected", func(t *testing.T) {
+		_, err := dial("a.example.com")
+		assert.Error(t, err)
+	})
+
+	t.Run("changed certificates are reloaded", func(t *testing.T) {
+		assert.False(t, store.reloadIfChanged(), "unchanged files are not reloaded")
+
+		cert, key := ca.issue(t, "a.example.com", 42, x509.ExtKeyUsageServerAuth)
+		writeTestFile(t, certPath, cert)
+		assert.False(t, store.reloadIfChanged(), "a certificate not matching its key yet keeps the current one")
+
+		writeTestFile(t, keyPath, key)
+		assert.True(t, store.reloadIfChanged())
+
+		state, err := dial("a.example.com", clientCert)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), state.PeerCertificates[0].SerialNumber.Int64())
+	})
+}
+
+// isTimeout reports whether a read failed only because no data arrived in time
+func isTimeout(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+	zap "go.uber.org/zap"
+)
+
+// ToolSideEffectPolicy is implemented by toolboxes that know which tools have side effects,
+// such as sending an email or charging a card, and must not run twice for the same tool call
+type ToolSideEffectPolicy interface {
+	// HasSideEffects checks if the executions of a tool are journaled
+	HasSideEffects(toolName string) bool
+}
+
+var _ ToolSideEffectPolicy = (*DefaultToolBox)(nil)
+
+// Tool journal entry statuses
+const (
+	ToolJournalStatusStarted   = "started"
+	ToolJournalStatusCompleted = "completed"
+	ToolJournalStatusFailed    = "failed"
+)
+
+// ToolJournalEntry records the execution of a side-effecting tool call of a task, so a run
+// resumed after a restart reuses its outcome instead of executing the tool again
+type ToolJournalEntry struct {
+	TaskID     string `json:"taskId"`
+	ToolCallID string `json:"toolCallId"`
+	ToolName   string `json:"toolName"`
+	// Status is started while the tool runs, then completed or failed. An entry left started
+	// belongs to a run that stopped during the execution, whose outcome is unknown.
+	Status      string     `json:"status"`
+	Result      string     `json:"result,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	StartedAt   time.Time  `json:"startedAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// errToolInterrupted is returned for a journaled tool call whose run stopped while it executed
+var errToolInterrupted = errors.New("the task was interrupted while the tool was running and it is not executed again, as it may have completed")
+
+// hasSideEffects checks if a tool is journaled by the toolbox
+func (a *OpenAICompatibleAgentImpl) hasSideEffects(toolName string) bool {
+	policy, ok := a.toolBox.(ToolSideEffectPolicy)
+	return ok && policy.HasSideEffects(toolName)
+}
+
+// executeJournaledTool executes a tool call, journaling it when the tool has side effects
+// and the run is checkpointed. A tool call the journal already holds is not executed again:
+// its recorded outcome is returned instead, reported as replayed.
+func (a *OpenAICompatibleAgentImpl) executeJournaledTool(ctx context.Context, toolCall sdk.ChatCompletionMessageToolCall, args map[string]any) (string, *ToolJournalEntry, bool, error) {
+	run := checkpointerFromContext(ctx)
+	if run == nil || run.checkpoints.journal == nil || !a.hasSideEffects(toolCall.Function.Name) {
+		result, err := a.toolBox.ExecuteTool(ctx, toolCall.Function.Name, args)
+		auditLogFromContext(ctx).toolInvocation(ctx, toolCall.Function.Name, err)
+		return result, nil, false, err
+	}
+
+	if entry := run.journaled(ctx, toolCall.ID); entry != nil {
+		a.logger.Info("tool call was executed before the task was interrupted, replaying its journaled outcome",
+			zap.String("tool", toolCall.Function.Name),
+			zap.String("tool_call_id", toolCall.ID),
+			zap.String("status", entry.Status))
+		switch entry.Status {
+		case ToolJournalStatusCompleted:
+			return entry.Result, entry, true, nil
+		case ToolJournalStatusFailed:
+			return entry.Result, entry, true, errors.New(entry.Error)
+		default:
+			return "", entry, true, errToolInterrupted
+		}
+	}
+
+	entry := run.startTool(ctx, toolCall)
+	result, err := a.toolBox.ExecuteTool(ctx, toolCall.Function.Name, args)
+	auditLogFromContext(ctx).toolInvocation(ctx, toolCall.Function.Name, err)
+	run.finishTool(ctx, entry, result, err)
+	return result, entry, false, err
+}
+
+// journaled returns the journal entry of a tool call of the task, or nil when it has none.
+// A journal that fails to load is logged and treated as empty.
+func (r *runCheckpointer) journaled(ctx context.Context, toolCallID string) *ToolJournalEntry {
+	entry, exists, err := r.checkpoints.journal.GetToolExecution(ctx, r.taskID, toolCallID)
+	if err != nil {
+		requestLogger(ctx, r.checkpoints.logger).Warn("failed to load tool journal entry",
+			zap.String("task_id", r.taskID),
+			zap.String("tool_call_id", toolCallID),
+			zap.Error(err))
+		return nil
+	}
+	if !exists {
+		return nil
+	}
+	return entry
+}
+
+// startTool journals a tool call as started before the tool executes
+func (r *runCheckpointer) startTool(ctx context.Context, toolCall sdk.ChatCompletionMessageToolCall) *ToolJournalEntry {
+	entry := &ToolJournalEntry{
+		TaskID:     r.taskID,
+		ToolCallID: toolCall.ID,
+		ToolName:   toolCall.Function.Name,
+		Status:     ToolJournalStatusStarted,
+		StartedAt:  r.checkpoints.clock.Now(),
+	}
+	r.record(ctx, entry)
+	return entry
+}
+
+// finishTool journals the outcome of an executed tool call. The tool ran even when the run
+// was cancelled meanwhile, so the outcome is recorded regardless.
+func (r *runCheckpointer) finishTool(ctx context.Context, entry *ToolJournalEntry, result string, err error) {
+	completedAt := r.checkpoints.clock.Now()
+	entry.CompletedAt = &completedAt
+	entry.Result = result
+	entry.Status = ToolJournalStatusCompleted
+	if err != nil {
+		entry.Status = ToolJournalStatusFailed
+		entry.Error = err.Error()
+	}
+	r.record(context.WithoutCancel(ctx), entry)
+}
+
+// record saves a journal entry, logging a failed save
+func (r *runCheckpointer) record(ctx context.Context, entry *ToolJournalEntry) {
+	if err := r.checkpoints.journal.RecordToolExecution(ctx, entry); err != nil {
+		requestLogger(ctx, r.checkpoints.logger).Warn("failed to record tool journal entry",
+			zap.String("task_id", r.taskID),
+			zap.String("tool_call_id", entry.ToolCallID),
+			zap.String("status", entry.Status),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/inference-gateway/sdk"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// afterToolCall is what an AfterTool callback saw of a tool call
+type afterToolCall struct {
+	journal  *ToolJournalEntry
+	replayed bool
+	result   map[string]any
+}
+
+// newJournaledAgent returns an agent with a side-effecting send tool that counts its calls,
+// recording what the AfterTool callbacks see
+func newJournaledAgent(t *testing.T, llmClient LLMClient, sends *int, seen *[]afterToolCall) OpenAICompatibleAgent {
+	t.Helper()
+	toolBox := NewDefaultToolBox(&config.ToolBoxConfig{SideEffects: []string{"send"}})
+	toolBox.AddTool(NewBasicTool("send", "Sends an email", map[string]any{"type": "object"},
+		func(ctx context.Context, args map[string]any) (string, error) {
+			*sends++
+			return "sent", nil
+		}))
+	callbacks := &CallbackConfig{AfterTool: []AfterToolCallback{
+		func(ctx context.Context, tool Tool, args map[string]any, toolContext *ToolContext, toolResult map[string]any) map[string]any {
+			*seen = append(*seen, afterToolCall{journal: toolContext.Journal, replayed: toolContext.Replayed, result: toolResult})
+			return nil
+		},
+	}}
+	agent, err := NewAgentBuilder(zap.NewNop()).WithLLMClient(llmClient).WithToolBox(toolBox).WithCallbacks(callbacks).Build()
+	require.NoError(t, err)
+	return agent
+}
+
+func TestAgentRun_JournalsSideEffectingTools(t *testing.T) {
+	storage := NewInMemoryStorage(zap.NewNop(), 0)
+	checkpoints := newTaskCheckpoints(config.TaskCheckpointConfig{Enable: true}, storage, "instance-a", zap.NewNop())
+
+	llmClient := &scriptedLLMClient{responses: []*sdk.CreateChatCompletionStreamResponse{toolCallChunk("call-1", "send"), textChunk("done")}}
+	sends := 0
+	var seen []afterToolCall
+	agent := newJournaledAgent(t, llmClient, &sends, &seen)
+
+	ctx := checkpoints.runContext(context.Background(), "task-1")
+	history := []types.Message{{MessageID: "m1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("send it")}}}
+	assert.Equal(t, types.TaskStateCompleted, runToCompletion(t, ctx, agent, history))
+
+	assert.Equal(t, 1, sends)
+	entry, exists, err := storage.GetToolExecution(context.Background(), "task-1", "call-1")
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, ToolJournalStatusCompleted, entry.Status)
+	assert.Equal(t, "send", entry.ToolName)
+	assert.Equal(t, "sent", entry.Result)
+	assert.NotNil(t, entry.CompletedAt)
+
+	require.Len(t, seen, 1)
+	require.NotNil(t, seen[0].journal)
+	assert.Equal(t, ToolJournalStatusCompleted, seen[0].journal.Status)
+	assert.False(t, seen[0].replayed)
+
+	checkpoints.clear(context.Background(), "task-1")
+	_, exists, err = storage.GetToolExecution(context.Background(), "task-1", "call-1")
+	require.NoError(t, err)
+	assert.False(t, exists, "the journal is cleared with the checkpoint")
+}
+
+func TestAgentRun_ReplaysJournaledToolCalls(t *testing.T) {
+	history := []types.Message{{MessageID: "m1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("send it")}}}
+	pending := sdk.ChatCompletionMessageToolCall{
+		ID:       "call-1",
+		Type:     "function",
+		Function: sdk.ChatCompletionMessageToolCallFunction{Name: "send", Arguments: `{}`},
+	}
+	toolCallMessage := types.NewAssistantMessage("assistant-1", []types.Part{
+		types.CreateDataPart(map[string]any{"tool_calls": []sdk.ChatCompletionMessageToolCall{pending}}),
+	})
+
+	tests := []struct {
+		name       string
+		entry      ToolJournalEntry
+		wantResult string
+		wantError  bool
+	}{
+		{
+			name:       "completed call returns its journaled result",
+			entry:      ToolJournalEntry{Status: ToolJournalStatusCompleted, Result: "sent before the restart"},
+			wantResult: "sent before the restart",
+		},
+		{
+			name:      "call interrupted while running fails without executing again",
+			entry:     ToolJournalEntry{Status: ToolJournalStatusStarted},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := NewInMemoryStorage(zap.NewNop(), 0)
+			checkpoints := newTaskCheckpoints(config.TaskCheckpointConfig{Enable: true}, storage, "instance-a", zap.NewNop())
+			require.NoError(t, storage.SaveCheckpoint(context.Background(), &AgentCheckpoint{
+				TaskID:           "task-1",
+				InstanceID:       "instance-b",
+				HistoryLength:    1,
+				Messages:         append(history, *toolCallMessage),
+				PendingToolCalls: []sdk.ChatCompletionMessageToolCall{pending},
+				Iteration:        1,
+			}))
+			entry := tt.entry
+			entry.TaskID, entry.ToolCallID, entry.ToolName, entry.StartedAt = "task-1", "call-1", "send", time.Now()
+			require.NoError(t, storage.RecordToolExecution(context.Background(), &entry))
+
+			llmClient := &scriptedLLMClient{responses: []*sdk.CreateChatCompletionStreamResponse{textChunk("done")}}
+			sends := 0
+			var seen []afterToolCall
+			agent := newJournaledAgent(t, llmClient, &sends, &seen)
+
+			ctx := checkpoints.runContext(context.Background(), "task-1")
+			assert.Equal(t, types.TaskStateCompleted, runToCompletion(t, ctx, agent, history))
+
+			assert.Equal(t, 0, sends, "a journaled tool call is not executed again")
+			require.Len(t, seen, 1)
+			assert.True(t, seen[0].replayed)
+			require.NotNil(t, seen[0].journal)
+			assert.Equal(t, tt.entry.Status, seen[0].journal.Status)
+			assert.Equal(t, tt.wantResult, seen[0].result["result"])
+			_, hasError := seen[0].result["error"]
+			assert.Equal(t, tt.wantError, hasError)
+		})
+	}
+}
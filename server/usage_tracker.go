@@ -2,7 +2,9 @@ package server
 
 import (
 	"sync"
+	"time"
 
+	types "github.com/inference-gateway/adk/types"
 	sdk "github.com/inference-gateway/sdk"
 )
 
@@ -21,6 +23,10 @@ type UsageTracker struct {
 	toolCalls   int
 	failedTools int
 	llmCalls    int
+
+	// Time spent waiting for the LLM and executing tools
+	llmTime  time.Duration
+	toolTime time.Duration
 }
 
 // NewUsageTracker creates a new usage tracker
@@ -67,6 +73,20 @@ func (ut *UsageTracker) IncrementFailedTools() {
 	ut.failedTools++
 }
 
+// AddLLMTime adds the duration of an LLM call, from the request until its response finished
+func (ut *UsageTracker) AddLLMTime(duration time.Duration) {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	ut.llmTime += duration
+}
+
+// AddToolTime adds the duration of a tool execution
+func (ut *UsageTracker) AddToolTime(duration time.Duration) {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	ut.toolTime += duration
+}
+
 // GetMetadata returns the collected metrics as a metadata map
 func (ut *UsageTracker) GetMetadata() map[string]any {
 	ut.mu.Lock()
@@ -75,11 +95,11 @@ func (ut *UsageTracker) GetMetadata() map[string]any {
 	metadata := make(map[string]any)
 
 	if ut.llmCalls > 0 {
-		metadata["usage"] = map[string]any{
-			"prompt_tokens":     ut.promptTokens,
-			"completion_tokens": ut.completionTokens,
-			"total_tokens":      ut.totalTokens,
-		}
+		metadata[types.UsageMetadataKey] = usageMetadata(types.TokenUsage{
+			PromptTokens:     ut.promptTokens,
+			CompletionTokens: ut.completionTokens,
+			TotalTokens:      ut.totalTokens,
+		})
 	}
 
 	metadata["execution_stats"] = map[string]any{
@@ -99,3 +119,61 @@ func (ut *UsageTracker) HasUsage() bool {
 
 	return ut.llmCalls > 0 || ut.iterations > 0 || ut.messages > 0 || ut.toolCalls > 0
 }
+
+// TokenUsage returns the tokens used by the LLM calls tracked so far, and false when no LLM
+// call reported usage
+func (ut *UsageTracker) TokenUsage() (types.TokenUsage, bool) {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+
+	return types.TokenUsage{
+		PromptTokens:     ut.promptTokens,
+		CompletionTokens: ut.completionTokens,
+		TotalTokens:      ut.totalTokens,
+	}, ut.llmCalls > 0
+}
+
+// Timings returns the time spent waiting for the LLM and executing tools, and the iterations
+// tracked so far
+func (ut *UsageTracker) Timings() (time.Duration, time.Duration, int) {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+
+	return ut.llmTime, ut.toolTime, ut.iterations
+}
+
+// usageMetadata converts token usage to its task metadata representation
+func usageMetadata(usage types.TokenUsage) map[string]any {
+	return map[string]any{
+		"prompt_tokens":     usage.PromptTokens,
+		"completion_tokens": usage.CompletionTokens,
+		"total_tokens":      usage.TotalTokens,
+	}
+}
+
+// applyUsageMetadata copies the tracker's statistics into the task metadata. Its token usage
+// and timings are added to the ones recorded by earlier runs of the task, so a task resumed
+// after input keeps a running total.
+func applyUsageMetadata(task *types.Task, usageTracker *UsageTracker) (map[string]any, error) {
+	previous, _, err := types.GetTaskUsage(task)
+	if err != nil {
+		return nil, err
+	}
+	if err := addRunTimings(task, usageTracker); err != nil {
+		return nil, err
+	}
+
+	metadata := usageTracker.GetMetadata()
+	if usage, ok := usageTracker.TokenUsage(); ok {
+		metadata[types.UsageMetadataKey] = usageMetadata(previous.Add(usage))
+	}
+
+	if task.Metadata == nil {
+		m := make(map[string]any)
+		task.Metadata = &m
+	}
+	for key, value := range metadata {
+		(*task.Metadata)[key] = value
+	}
+	return metadata, nil
+}
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
 
+	types "github.com/inference-gateway/adk/types"
 	sdk "github.com/inference-gateway/sdk"
 )
 
@@ -231,3 +233,31 @@ func TestUsageTracker_ThreadSafety(t *testing.T) {
 	assert.Equal(t, 10, execStats["messages"])
 	assert.Equal(t, 10, execStats["tool_calls"])
 }
+
+func TestApplyUsageMetadata_AccumulatesAcrossRuns(t *testing.T) {
+	task := &types.Task{ID: "task-1", Metadata: &map[string]any{
+		// usage recorded by an earlier run, as decoded from storage
+		"usage":  map[string]any{"prompt_tokens": float64(100), "completion_tokens": float64(20), "total_tokens": float64(120)},
+		"labels": "kept",
+	}}
+
+	tracker := NewUsageTracker()
+	tracker.AddTokenUsage(sdk.CompletionUsage{PromptTokens: 30, CompletionTokens: 10, TotalTokens: 40})
+	_, err := applyUsageMetadata(task, tracker)
+	require.NoError(t, err)
+
+	usage, ok, err := types.GetTaskUsage(task)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.TokenUsage{PromptTokens: 130, CompletionTokens: 30, TotalTokens: 160}, usage)
+	assert.Equal(t, "kept", (*task.Metadata)["labels"])
+
+	idle := NewUsageTracker()
+	idle.IncrementIteration()
+	_, err = applyUsageMetadata(task, idle)
+	require.NoError(t, err)
+
+	usage, _, err = types.GetTaskUsage(task)
+	require.NoError(t, err)
+	assert.Equal(t, int64(160), usage.TotalTokens, "runs without LLM usage keep the recorded total")
+}
@@ -0,0 +1,188 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// requestUserID returns the ID of the user a request is made for: the user a custom
+// authenticator named, then the authenticated subject. Unauthenticated callers and trusted
+// front-ends instead name the user in the configured header or, when accepted, in the
+// request metadata, so authenticated clients cannot act for other users.
+func (s *A2AServerImpl) requestUserID(c *gin.Context, req *types.JSONRPCRequest) string {
+	ctx := c.Request.Context()
+	if userID := middlewares.UserIDFromContext(ctx); userID != "" {
+		return userID
+	}
+	cfg := s.cfg.UserConfig
+	subject := middlewares.SubjectFromContext(ctx)
+	if subject != "" && !slices.Contains(cfg.TrustedSubjects, subject) {
+		return subject
+	}
+	if cfg.Header != "" {
+		if userID := strings.TrimSpace(c.GetHeader(cfg.Header)); userID != "" {
+			return userID
+		}
+	}
+	if cfg.FromMetadata && req != nil {
+		if userID := metadataUserID(*req); userID != "" {
+			return userID
+		}
+	}
+	return subject
+}
+
+// metadataUserID returns the user named by the userId key of the metadata of a request, or of
+// the message it sends
+func metadataUserID(req types.JSONRPCRequest) string {
+	if metadata, ok := req.Params["metadata"].(map[string]any); ok {
+		if userID, _ := metadata[types.UserIDMetadataKey].(string); strings.TrimSpace(userID) != "" {
+			return strings.TrimSpace(userID)
+		}
+	}
+	if params := decodeMessageParams(req); params != nil && params.Message.Metadata != nil {
+		if userID, _ := (*params.Message.Metadata)[types.UserIDMetadataKey].(string); strings.TrimSpace(userID) != "" {
+			return strings.TrimSpace(userID)
+		}
+	}
+	return ""
+}
+
+// identifyUser attaches the user a request is made for to the request's context
+func (s *A2AServerImpl) identifyUser(c *gin.Context, req *types.JSONRPCRequest) {
+	if userID := s.requestUserID(c, req); userID != "" {
+		c.Request = c.Request.WithContext(middlewares.ContextWithUserID(c.Request.Context(), userID))
+	}
+}
+
+// checkUserAccess returns the error answering a request without a user identity when one is
+// required, or a request reaching the task or context of another user
+func (s *A2AServerImpl) checkUserAccess(ctx context.Context, req types.JSONRPCRequest) *JSONRPCMethodError {
+	userID := middlewares.UserIDFromContext(ctx)
+	if userID == "" && s.cfg.UserConfig.Require {
+		return &JSONRPCMethodError{Code: int(ErrInvalidRequest), Message: "user identity is required"}
+	}
+	if params := decodeMessageParams(req); params != nil {
+		return s.checkMessageAccess(ctx, *params)
+	}
+	taskID, contextID := auditRequestTarget(req)
+	return s.checkUserTarget(ctx, userID, taskID, contextID)
+}
+
+// checkMessageAccess returns the error answering a message that reaches the task or context of
+// another user than the one it is sent for, or depends on one of their tasks, whose final
+// message and artifacts would be handed to the new task
+func (s *A2AServerImpl) checkMessageAccess(ctx context.Context, params types.MessageSendParams) *JSONRPCMethodError {
+	userID := middlewares.UserIDFromContext(ctx)
+	var taskID, contextID string
+	if params.Message.TaskID != nil {
+		taskID = *params.Message.TaskID
+	}
+	if params.Message.ContextID != nil {
+		contextID = *params.Message.ContextID
+	}
+	if err := s.checkUserTarget(ctx, userID, taskID, contextID); err != nil {
+		return err
+	}
+	dependencies, _ := parseTaskDependencies(params.Metadata)
+	for _, dependency := range dependencies {
+		if err := s.checkUserTarget(ctx, userID, dependency, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkArtifactAccess answers an HTTP request for the artifacts of a task or context that the
+// caller may not reach with an error, reporting whether the request may proceed
+func (s *A2AServerImpl) checkArtifactAccess(c *gin.Context, taskID, contextID string) bool {
+	userID := s.requestUserID(c, nil)
+	if userID == "" && s.cfg.UserConfig.Require {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user identity is required"})
+		return false
+	}
+	if rpcErr := s.checkUserTarget(c.Request.Context(), userID, taskID, contextID); rpcErr != nil {
+		status := http.StatusNotFound
+		if rpcErr.Code == int(ErrInternalError) {
+			status = http.StatusInternalServerError
+		}
+		c.JSON(status, gin.H{"error": rpcErr.Message})
+		return false
+	}
+	return true
+}
+
+// checkUserTarget returns the error answering a request for a task or context that belongs to
+// another user than the one it is made for. Requests without a user only reach the tasks and
+// contexts of no user. Tasks and contexts of other users are reported as not found, so their
+// existence is not disclosed.
+func (s *A2AServerImpl) checkUserTarget(ctx context.Context, userID, taskID, contextID string) *JSONRPCMethodError {
+	if taskID != "" {
+		if task, exists := s.taskManager.GetTask(taskID); exists && types.GetTaskUserID(task) != userID {
+			return &JSONRPCMethodError{Code: int(ErrTaskNotFound), Message: "task not found"}
+		}
+	}
+	if contextID == "" {
+		return nil
+	}
+	foreign, err := foreignContext(ctx, s.storage, userID, contextID)
+	if err != nil {
+		requestLogger(ctx, s.logger).Error("failed to check context access",
+			zap.String("context_id", contextID),
+			zap.Error(err))
+		return &JSONRPCMethodError{Code: int(ErrInternalError), Message: "failed to check context access"}
+	}
+	if foreign {
+		return &JSONRPCMethodError{Code: int(ErrInvalidParams), Message: "context not found"}
+	}
+	return nil
+}
+
+// foreignContext reports whether a context belongs to another user than the one given, or to
+// any user when none is given, because it was created for them or holds one of their tasks
+func foreignContext(ctx context.Context, storage Storage, userID, contextID string) (bool, error) {
+	if store, ok := storage.(ContextStore); ok {
+		record, exists, err := store.GetContextRecord(ctx, contextID)
+		if err != nil {
+			return false, err
+		}
+		if exists && contextRecordUserID(record) != "" && contextRecordUserID(record) != userID {
+			return true, nil
+		}
+	}
+	tasks, err := storage.ListTasks(TaskFilter{ContextID: &contextID})
+	if err != nil {
+		return false, err
+	}
+	for _, task := range tasks {
+		if types.GetTaskUserID(task) != userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// contextRecordUserID returns the user a context created with contexts/create belongs to
+func contextRecordUserID(record *types.ConversationContext) string {
+	userID, _ := record.Metadata[types.UserIDMetadataKey].(string)
+	return userID
+}
+
+// recordTaskUser records the user a new task belongs to in its metadata. A task keeps the user
+// that created it.
+func (h *DefaultA2AProtocolHandler) recordTaskUser(ctx context.Context, task *types.Task) {
+	userID := middlewares.UserIDFromContext(ctx)
+	if userID == "" || types.GetTaskUserID(task) != "" {
+		return
+	}
+
+	setTaskMetadata(task, types.UserIDMetadataKey, userID)
+}
@@ -0,0 +1,285 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+	websocket "golang.org/x/net/websocket"
+
+	config "github.com/inference-gateway/adk/server/config"
+	middlewares "github.com/inference-gateway/adk/server/middlewares"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestA2AServer_UserPartitioning(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{UserConfig: config.UserConfig{Header: "X-User-ID"}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "assistant"})
+	s.setMemoryService(NewMemoryService(NewInMemoryMemoryStore(), &stubMemoryExtractor{}, config.MemoryConfig{Scope: config.MemoryScopeUser}, zap.NewNop()))
+	router := s.setupRouter(cfg)
+
+	call := func(userID, method string, params any, result any) *types.JSONRPCError {
+		encoded, err := json.Marshal(params)
+		require.NoError(t, err)
+		body := `{"jsonrpc":"2.0","id":"1","method":"` + method + `","params":` + string(encoded) + `}`
+		w := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body))
+		if userID != "" {
+			request.Header.Set("X-User-ID", userID)
+		}
+		router.ServeHTTP(w, request)
+		var response struct {
+			Result json.RawMessage     `json:"result"`
+			Error  *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		if response.Error == nil && result != nil {
+			require.NoError(t, json.Unmarshal(response.Result, result))
+		}
+		return response.Error
+	}
+
+	newUserTask := func(contextID, userID string) *types.Task {
+		task := s.taskManager.CreateTask(contextID, types.TaskStateCompleted, &types.Message{MessageID: "m-" + contextID, Role: types.RoleUser})
+		task.Metadata = &map[string]any{types.UserIDMetadataKey: userID}
+		require.NoError(t, s.storage.StoreDeadLetterTask(task))
+		return task
+	}
+	alices := newUserTask("ctx-alice", "alice")
+	bobs := newUserTask("ctx-bob", "bob")
+
+	var task types.Task
+	require.Nil(t, call("alice", "tasks/get", types.TaskQueryParams{ID: alices.ID}, &task))
+	assert.Equal(t, alices.ID, task.ID)
+	rpcErr := call("alice", "tasks/get", types.TaskQueryParams{ID: bobs.ID}, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, "task not found", rpcErr.Message, "tasks of other users are not disclosed")
+	rpcErr = call("", "tasks/get", types.TaskQueryParams{ID: bobs.ID}, nil)
+	require.NotNil(t, rpcErr, "callers without a user do not reach the tasks of users")
+	assert.Equal(t, "task not found", rpcErr.Message)
+	anonymous := s.taskManager.CreateTask("ctx-anonymous", types.TaskStateCompleted, &types.Message{MessageID: "m-anonymous", Role: types.RoleUser})
+	require.NoError(t, s.storage.StoreDeadLetterTask(anonymous))
+	require.Nil(t, call("", "tasks/get", types.TaskQueryParams{ID: anonymous.ID}, &task))
+
+	var tasks types.TaskList
+	require.Nil(t, call("alice", "tasks/list", types.TaskListParams{UserID: "bob"}, &tasks))
+	require.Len(t, tasks.Tasks, 1)
+	assert.Equal(t, alices.ID, tasks.Tasks[0].ID)
+
+	require.Nil(t, call("", "tasks/list", types.TaskListParams{UserID: "alice"}, &tasks))
+	require.Len(t, tasks.Tasks, 1)
+	assert.Equal(t, anonymous.ID, tasks.Tasks[0].ID)
+
+	var contexts types.ContextList
+	require.Nil(t, call("bob", "contexts/list", types.ContextListParams{}, &contexts))
+	require.Len(t, contexts.Contexts, 1)
+	assert.Equal(t, "ctx-bob", contexts.Contexts[0].ContextID)
+	rpcErr = call("bob", "contexts/get", types.ContextIdParams{ContextID: "ctx-alice"}, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, "context not found", rpcErr.Message)
+	require.Nil(t, call("", "contexts/list", types.ContextListParams{}, &contexts))
+	require.Len(t, contexts.Contexts, 1)
+	assert.Equal(t, "ctx-anonymous", contexts.Contexts[0].ContextID)
+
+	webhook := types.PushNotificationConfig{URL: "https://hooks.example.com/bob"}
+	rpcErr = call("bob", "tasks/pushNotificationConfig/set", types.TaskPushNotificationConfig{Name: alices.ID, PushNotificationConfig: webhook}, nil)
+	require.NotNil(t, rpcErr, "webhooks cannot be registered on the tasks of other users")
+	assert.Equal(t, "task not found", rpcErr.Message)
+	rpcErr = call("bob", "tasks/pushNotificationConfig/set", types.TaskPushNotificationConfig{Name: "tasks/" + alices.ID + "/pushNotificationConfigs/hook", PushNotificationConfig: webhook}, nil)
+	require.NotNil(t, rpcErr, "the task of a resource name is checked")
+	assert.Equal(t, "task not found", rpcErr.Message)
+	require.Nil(t, call("alice", "tasks/pushNotificationConfig/set", types.TaskPushNotificationConfig{Name: alices.ID, PushNotificationConfig: types.PushNotificationConfig{URL: "https://hooks.example.com/alice"}}, nil))
+	for method, params := range map[string]any{
+		"tasks/pushNotificationConfig/get":    map[string]any{"name": alices.ID},
+		"tasks/pushNotificationConfig/list":   map[string]any{"parent": alices.ID},
+		"tasks/pushNotificationConfig/delete": map[string]any{"name": alices.ID, "id": "unknown-task"},
+	} {
+		rpcErr = call("bob", method, params, nil)
+		require.NotNil(t, rpcErr, method)
+		assert.Equal(t, "task not found", rpcErr.Message, method)
+	}
+	var configs []types.TaskPushNotificationConfig
+	require.Nil(t, call("alice", "tasks/pushNotificationConfig/list", map[string]any{"parent": alices.ID}, &configs))
+	require.Len(t, configs, 1, "the config of the owner is left alone")
+
+	var memory types.Memory
+	require.Nil(t, call("alice", "memories/create", types.MemoryCreateParams{UserID: "bob", Content: "Prefers metric units"}, &memory))
+	assert.Equal(t, "alice", memory.UserID, "memories are created for the caller")
+	rpcErr = call("bob", "memories/get", types.MemoryIdParams{ID: memory.ID}, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, "memory not found", rpcErr.Message)
+	var memories types.MemoryListResult
+	require.Nil(t, call("bob", "memories/list", types.MemoryListParams{UserID: "alice"}, &memories))
+	assert.Empty(t, memories.Memories)
+	rpcErr = call("", "memories/list", types.MemoryListParams{UserID: "alice"}, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, "user id requires a user identity", rpcErr.Message)
+	rpcErr = call("", "memories/get", types.MemoryIdParams{ID: memory.ID}, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, "memory not found", rpcErr.Message)
+
+	w := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/artifacts?taskId="+alices.ID, nil)
+	request.Header.Set("X-User-ID", "bob")
+	router.ServeHTTP(w, request)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/artifacts?taskId="+alices.ID, nil))
+	assert.Equal(t, http.StatusNotFound, w.Code, "callers without a user do not list the artifacts of users")
+
+	cfg.UserConfig.Require = true
+	rpcErr = call("", "tasks/list", types.TaskListParams{}, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, "user identity is required", rpcErr.Message)
+	require.Nil(t, call("alice", "tasks/list", types.TaskListParams{}, &tasks))
+}
+
+func TestA2AServer_RequestUserID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{UserConfig: config.UserConfig{Header: "X-User-ID", TrustedSubjects: []string{"frontend"}}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+
+	userID := func(subject, header string) string {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/a2a", nil)
+		if subject != "" {
+			c.Request = c.Request.WithContext(middlewares.ContextWithSubject(c.Request.Context(), subject))
+		}
+		if header != "" {
+			c.Request.Header.Set("X-User-ID", header)
+		}
+		return s.requestUserID(c, nil)
+	}
+
+	assert.Equal(t, "mallory", userID("mallory", "alice"), "authenticated clients cannot name another user")
+	assert.Equal(t, "alice", userID("frontend", "alice"), "trusted front-ends name the user")
+	assert.Equal(t, "frontend", userID("frontend", ""))
+	assert.Equal(t, "alice", userID("", "alice"), "unauthenticated callers name the user")
+}
+
+func TestA2AServer_UserIsolationAcrossPaths(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		UserConfig:         config.UserConfig{Header: "X-User-ID"},
+		CapabilitiesConfig: config.CapabilitiesConfig{Streaming: true, WebSocket: true},
+		ServerConfig:       config.ServerConfig{EnableChatCompletions: true, IdempotencyWindow: time.Minute},
+	}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "assistant"})
+	s.SetStreamingTaskHandler(&historyRecordingHandler{state: types.TaskStateCompleted})
+	router := s.setupRouter(cfg)
+	httpServer := httptest.NewServer(router)
+	t.Cleanup(httpServer.Close)
+
+	alices := s.taskManager.CreateTask("ctx-alice", types.TaskStateCompleted, &types.Message{MessageID: "m-alice", Role: types.RoleUser})
+	alices.Metadata = &map[string]any{types.UserIDMetadataKey: "alice"}
+	require.NoError(t, s.storage.StoreDeadLetterTask(alices))
+
+	post := func(userID, key, method string, params any) (*httptest.ResponseRecorder, json.RawMessage, *types.JSONRPCError) {
+		encoded, err := json.Marshal(params)
+		require.NoError(t, err)
+		body := `{"jsonrpc":"2.0","id":"1","method":"` + method + `","params":` + string(encoded) + `}`
+		w := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/a2a", strings.NewReader(body))
+		request.Header.Set("X-User-ID", userID)
+		if key != "" {
+			request.Header.Set(types.IdempotencyKeyHeader, key)
+		}
+		router.ServeHTTP(w, request)
+		var response struct {
+			Result json.RawMessage     `json:"result"`
+			Error  *types.JSONRPCError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response.Result, response.Error
+	}
+	message := func(contextID string, metadata map[string]any) types.MessageSendParams {
+		params := types.MessageSendParams{Message: types.Message{
+			MessageID: "m-" + contextID,
+			Role:      types.RoleUser,
+			Parts:     []types.Part{types.CreateTextPart("What did we discuss?")},
+		}, Metadata: metadata}
+		if contextID != "" {
+			params.Message.ContextID = &contextID
+		}
+		return params
+	}
+
+	t.Run("batch messages do not reach the contexts of other users", func(t *testing.T) {
+		_, _, rpcErr := post("bob", "", "message/sendBatch", types.MessageSendBatchParams{Messages: []types.MessageSendParams{message("ctx-alice", nil)}})
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, "messages[0]: context not found", rpcErr.Message)
+	})
+
+	t.Run("tasks do not depend on the tasks of other users", func(t *testing.T) {
+		dependsOn := map[string]any{types.DependsOnMetadataKey: []any{alices.ID}}
+		_, _, rpcErr := post("bob", "", "message/send", message("", dependsOn))
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, int(ErrTaskNotFound), rpcErr.Code)
+		assert.Equal(t, "task not found", rpcErr.Message)
+
+		_, _, rpcErr = post("bob", "", "message/sendBatch", types.MessageSendBatchParams{Messages: []types.MessageSendParams{message("", dependsOn)}})
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, "messages[0]: task not found", rpcErr.Message)
+	})
+
+	t.Run("websocket requests are made for the user", func(t *testing.T) {
+		resubscribe := func(userID string) *types.JSONRPCError {
+			wsConfig, err := websocket.NewConfig("ws"+strings.TrimPrefix(httpServer.URL, "http")+types.WebSocketPath, httpServer.URL)
+			require.NoError(t, err)
+			wsConfig.Header.Set("X-User-ID", userID)
+			conn, err := websocket.DialConfig(wsConfig)
+			require.NoError(t, err)
+			defer func() { _ = conn.Close() }()
+
+			require.NoError(t, websocket.Message.Send(conn, `{"jsonrpc":"2.0","id":"1","method":"tasks/resubscribe","params":{"name":"`+alices.ID+`"}}`))
+			var frame string
+			require.NoError(t, websocket.Message.Receive(conn, &frame))
+			var response struct {
+				Error *types.JSONRPCError `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(frame), &response))
+			return response.Error
+		}
+
+		assert.Nil(t, resubscribe("alice"))
+		rpcErr := resubscribe("bob")
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, "task not found", rpcErr.Message)
+	})
+
+	t.Run("chat completion tasks belong to the user", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, chatCompletionsPath, strings.NewReader(`{"messages":[{"role":"user","content":"Hi"}]}`))
+		request.Header.Set("X-User-ID", "bob")
+		router.ServeHTTP(w, request)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		bob := "bob"
+		tasks, err := s.storage.ListTasks(TaskFilter{UserID: &bob})
+		require.NoError(t, err)
+		assert.Len(t, tasks, 1)
+	})
+
+	t.Run("idempotent responses are not replayed to other users", func(t *testing.T) {
+		_, first, rpcErr := post("alice", "key-1", "message/send", message("", nil))
+		require.Nil(t, rpcErr)
+		w, second, rpcErr := post("bob", "key-1", "message/send", message("", nil))
+		require.Nil(t, rpcErr)
+		assert.Empty(t, w.Header().Get(types.IdempotentReplayedHeader))
+
+		var alicesTask, bobsTask types.Task
+		require.NoError(t, json.Unmarshal(first, &alicesTask))
+		require.NoError(t, json.Unmarshal(second, &bobsTask))
+		assert.NotEqual(t, alicesTask.ID, bobsTask.ID)
+	})
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"fmt"
+	"mime"
+	"slices"
 	"time"
 
 	types "github.com/inference-gateway/adk/types"
@@ -21,9 +23,13 @@ type MessageConverter interface {
 	ValidateMessagePart(part types.Part) error
 }
 
+// visionMediaTypes are the image formats vision-capable models accept
+var visionMediaTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp"}
+
 // messageConverter provides efficient conversion with type safety
 type messageConverter struct {
 	logger *zap.Logger
+	vision bool
 }
 
 // NewMessageConverter creates a new message converter
@@ -33,6 +39,15 @@ func NewMessageConverter(logger *zap.Logger) MessageConverter {
 	}
 }
 
+// NewMessageConverterWithVision creates a message converter that, when vision is enabled, turns
+// the image file parts of user messages into image content for vision-capable models
+func NewMessageConverterWithVision(logger *zap.Logger, vision bool) MessageConverter {
+	return &messageConverter{
+		logger: logger,
+		vision: vision,
+	}
+}
+
 // ConvertToSDK converts A2A messages to SDK format with validation
 func (c *messageConverter) ConvertToSDK(messages []types.Message) ([]sdk.Message, error) {
 	result := make([]sdk.Message, 0, len(messages))
@@ -64,6 +79,7 @@ func (c *messageConverter) convertSingleMessage(msg types.Message) (sdk.Message,
 	var toolCallId *string
 	var toolCalls *[]sdk.ChatCompletionMessageToolCall
 	var reasoningContent *string
+	var images []string
 
 	for _, part := range msg.Parts {
 		if part.Text != nil {
@@ -75,8 +91,20 @@ func (c *messageConverter) convertSingleMessage(msg types.Message) (sdk.Message,
 					zap.Error(err))
 			}
 		} else if part.File != nil {
-			c.logger.Debug("file part detected in message",
-				zap.String("message_id", msg.MessageID))
+			text, image := c.convertFilePart(msg.MessageID, part)
+			if text != "" {
+				if content != "" {
+					content += "\n\n"
+				}
+				content += text
+			}
+			if image != "" {
+				images = append(images, image)
+			}
+			if text == "" && image == "" {
+				c.logger.Debug("file part detected in message",
+					zap.String("message_id", msg.MessageID))
+			}
 		} else {
 			c.logger.Warn("empty part detected",
 				zap.String("message_id", msg.MessageID))
@@ -109,6 +137,17 @@ func (c *messageConverter) convertSingleMessage(msg types.Message) (sdk.Message,
 		ReasoningContent: reasoningContent,
 	}
 
+	if len(images) > 0 && sdkRole == sdk.User {
+		parts, err := multimodalContent(content, images)
+		if err != nil {
+			return sdk.Message{}, err
+		}
+		if err := sdkMsg.Content.FromMessageContent1(parts); err != nil {
+			return sdk.Message{}, fmt.Errorf("failed to set message content: %w", err)
+		}
+		return sdkMsg, nil
+	}
+
 	if err := sdkMsg.Content.FromMessageContent0(content); err != nil {
 		return sdk.Message{}, fmt.Errorf("failed to set message content: %w", err)
 	}
@@ -116,6 +155,85 @@ func (c *messageConverter) convertSingleMessage(msg types.Message) (sdk.Message,
 	return sdkMsg, nil
 }
 
+// convertFilePart returns the text the server's file ingestion extracted from a file part, and
+// with vision enabled the URL of its image content
+func (c *messageConverter) convertFilePart(messageID string, part types.Part) (string, string) {
+	file, ingested, err := types.GetIngestedFile(part)
+	if err != nil {
+		c.logger.Warn("failed to read ingested file part",
+			zap.String("message_id", messageID),
+			zap.Error(err))
+	}
+
+	var text string
+	if ingested && file.Text != "" {
+		text = formatIngestedFileText(part.File.Name, file)
+	}
+	if !c.vision {
+		return text, ""
+	}
+	return text, imageURL(part.File, file, ingested)
+}
+
+// imageURL returns the URL a model loads an image file part from: the thumbnail attached by
+// file ingestion, a data URL of the file's bytes or the file's URI. Files that are not in an
+// image format vision models accept have none.
+func imageURL(part *types.FilePart, file types.IngestedFile, ingested bool) string {
+	mediaType := part.MediaType
+	if ingested {
+		mediaType = file.MediaType
+	}
+	mediaType, _, err := mime.ParseMediaType(mediaType)
+	if err != nil || !slices.Contains(visionMediaTypes, mediaType) {
+		return ""
+	}
+
+	switch {
+	case ingested && file.Thumbnail != "":
+		return "data:" + file.ThumbnailMediaType + ";base64," + file.Thumbnail
+	case part.FileWithBytes != nil:
+		return "data:" + mediaType + ";base64," + *part.FileWithBytes
+	case part.FileWithURI != nil:
+		return *part.FileWithURI
+	}
+	return ""
+}
+
+// formatIngestedFileText presents the text extracted from a file part as a section of the
+// message content, headed by the file's name and media type
+func formatIngestedFileText(name string, file types.IngestedFile) string {
+	if name == "" {
+		name = "attachment"
+	}
+	text := fmt.Sprintf("[File: %s (%s)]\n%s", name, file.MediaType, file.Text)
+	if file.TextTruncated {
+		text += "\n[File text truncated]"
+	}
+	return text
+}
+
+// multimodalContent builds the content parts of a user message with image thumbnails, the
+// text first followed by one image part per data URL
+func multimodalContent(text string, images []string) ([]sdk.ContentPart, error) {
+	parts := make([]sdk.ContentPart, 0, len(images)+1)
+	if text != "" {
+		var part sdk.ContentPart
+		if err := part.FromTextContentPart(sdk.TextContentPart{Type: sdk.TextContentPartTypeText, Text: text}); err != nil {
+			return nil, fmt.Errorf("failed to set text content: %w", err)
+		}
+		parts = append(parts, part)
+	}
+	for _, url := range images {
+		var part sdk.ContentPart
+		image := sdk.ImageContentPart{Type: sdk.ImageContentPartTypeImageURL, ImageURL: sdk.ImageURL{URL: url}}
+		if err := part.FromImageContentPart(image); err != nil {
+			return nil, fmt.Errorf("failed to set image content: %w", err)
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
 // processDataPart handles the extraction of data from data parts for both typed and map formats
 func (c *messageConverter) processDataPart(
 	data map[string]any,
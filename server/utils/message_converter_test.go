@@ -826,3 +826,65 @@ func TestMessageConverter_ConvertToSDK_ToolCallsSequence(t *testing.T) {
 	require.NotNil(t, toolMsg.ToolCallID)
 	assert.Equal(t, "call_0_2e5a532f-06e2-4ced-8434-31e25019e144", *toolMsg.ToolCallID)
 }
+
+func TestMessageConverter_ConvertToSDK_IngestedFiles(t *testing.T) {
+	converter := NewMessageConverter(zap.NewNop())
+	notes := types.CreateFilePart("notes.md", "text/markdown", nil, nil, map[string]any{
+		types.IngestedFileMetadataKey: types.IngestedFile{MediaType: "text/markdown", Text: "# Notes\nship it", TextTruncated: true},
+	})
+	photo := types.CreateFilePart("cat.png", "image/png", nil, nil, map[string]any{
+		types.IngestedFileMetadataKey: map[string]any{"mediaType": "image/png", "thumbnail": "aGVsbG8=", "thumbnailMediaType": "image/jpeg"},
+	})
+	raw := types.CreateFilePart("raw.bin", "application/octet-stream", nil, nil)
+
+	result, err := converter.ConvertToSDK([]types.Message{
+		{MessageID: "m1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("summarize"), notes, raw, photo}},
+	})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+
+	text, err := result[0].Content.AsMessageContent0()
+	require.NoError(t, err)
+	assert.Equal(t, "summarize\n\n[File: notes.md (text/markdown)]\n# Notes\nship it\n[File text truncated]", text,
+		"images are left out without vision")
+}
+
+func TestMessageConverter_ConvertToSDK_Vision(t *testing.T) {
+	converter := NewMessageConverterWithVision(zap.NewNop(), true)
+	pixels := "iVBORw0KGgo="
+	uri := "https://example.com/dog.webp"
+	ingested := types.CreateFilePart("cat.png", "image/png", &pixels, nil, map[string]any{
+		types.IngestedFileMetadataKey: types.IngestedFile{MediaType: "image/png", Thumbnail: "aGVsbG8=", ThumbnailMediaType: "image/jpeg"},
+	})
+	bytesPart := types.CreateFilePart("chart.png", "image/png; name=chart.png", &pixels, nil)
+	uriPart := types.CreateFilePart("dog.webp", "image/webp", nil, &uri)
+	svg := types.CreateFilePart("logo.svg", "image/svg+xml", &pixels, nil)
+
+	result, err := converter.ConvertToSDK([]types.Message{
+		{MessageID: "m1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart("compare these"), ingested, bytesPart, uriPart, svg}},
+		{MessageID: "m2", Role: types.RoleAgent, Parts: []types.Part{types.CreateTextPart("here is a chart"), bytesPart}},
+	})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+
+	parts, err := result[0].Content.AsMessageContent1()
+	require.NoError(t, err)
+	require.Len(t, parts, 4)
+	textPart, err := parts[0].AsTextContentPart()
+	require.NoError(t, err)
+	assert.Equal(t, sdk.TextContentPartTypeText, textPart.Type)
+	assert.Equal(t, "compare these", textPart.Text)
+
+	var urls []string
+	for _, part := range parts[1:] {
+		image, err := part.AsImageContentPart()
+		require.NoError(t, err)
+		assert.Equal(t, sdk.ImageContentPartTypeImageURL, image.Type)
+		urls = append(urls, image.ImageURL.URL)
+	}
+	assert.Equal(t, []string{"data:image/jpeg;base64,aGVsbG8=", "data:image/png;base64," + pixels, uri}, urls)
+
+	agentText, err := result[1].Content.AsMessageContent0()
+	require.NoError(t, err)
+	assert.Equal(t, "here is a chart", agentText, "only user messages carry images")
+}
@@ -0,0 +1,229 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+	websocket "golang.org/x/net/websocket"
+
+	types "github.com/inference-gateway/adk/types"
+)
+
+// withWebSocketExtension returns a copy of the agent card advertising the WebSocket
+// streaming endpoint, so clients can prefer it over server-sent events
+func withWebSocketExtension(card types.AgentCard) types.AgentCard {
+	if _, exists := types.GetWebSocketPath(&card); exists {
+		return card
+	}
+
+	params := map[string]any{"path": types.WebSocketPath}
+	card.Capabilities.Extensions = append(slices.Clone(card.Capabilities.Extensions), types.AgentExtension{
+		URI:         types.WebSocketExtensionURI,
+		Description: "Streaming methods are also served over WebSocket",
+		Params:      &params,
+	})
+	return card
+}
+
+// handleA2AWebSocket serves message/stream and tasks/resubscribe over a WebSocket
+// connection, for clients behind proxies that break server-sent events. The client sends
+// a single JSON-RPC request as a text frame and receives every streaming event as a text
+// frame carrying the same JSON-RPC payload as the SSE transport. The server closes the
+// connection when the stream ends.
+func (s *A2AServerImpl) handleA2AWebSocket(c *gin.Context) {
+	wsServer := websocket.Server{
+		Handshake: func(*websocket.Config, *http.Request) error {
+			return nil
+		},
+		Handler: func(conn *websocket.Conn) {
+			s.serveWebSocketStream(c, conn)
+		},
+	}
+	wsServer.ServeHTTP(c.Writer, c.Request)
+}
+
+// serveWebSocketStream reads the streaming request from the connection and dispatches it
+// to the protocol handler with a writer that forwards events as WebSocket frames
+func (s *A2AServerImpl) serveWebSocketStream(c *gin.Context, conn *websocket.Conn) {
+	logger := requestLogger(c.Request.Context(), s.logger)
+	writer := newWebSocketEventWriter(c.Writer, conn)
+
+	limit := s.payloadLimitsFor("message/stream").maxBodySize
+	if limit > 0 {
+		conn.MaxPayloadBytes = int(limit)
+	}
+
+	var frame string
+	if err := websocket.Message.Receive(conn, &frame); err != nil {
+		if errors.Is(err, websocket.ErrFrameTooLarge) {
+			logger.Warn("rejected oversized a2a websocket request", zap.Int64("limit", limit))
+			c.Writer = writer
+			limitErr := bodyTooLargeError(limit)
+			s.responseSender.SendError(c, nil, limitErr.Code, limitErr.Message)
+			writer.finish()
+			return
+		}
+		logger.Debug("websocket closed before a request was received", zap.Error(err))
+		return
+	}
+
+	var req types.JSONRPCRequest
+	if err := json.Unmarshal([]byte(frame), &req); err != nil {
+		logger.Error("failed to parse websocket json request", zap.Error(err))
+		c.Writer = writer
+		s.responseSender.SendError(c, nil, int(ErrParseError), "parse error")
+		writer.finish()
+		return
+	}
+
+	if req.JSONRPC == "" {
+		req.JSONRPC = "2.0"
+	}
+	if req.ID == nil {
+		id := any(s.ids.NewID())
+		req.ID = &id
+	}
+
+	logger.Info("received a2a websocket request",
+		zap.String("method", req.Method),
+		zap.Any("id", req.ID))
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	go func() {
+		var ignored string
+		for websocket.Message.Receive(conn, &ignored) == nil {
+		}
+		cancel()
+	}()
+
+	c.Request = c.Request.WithContext(ctx)
+	c.Writer = writer
+	s.identifyUser(c, &req)
+	ctx = c.Request.Context()
+
+	if s.rejectDuringDrain(c, req) {
+		writer.finish()
+		return
+	}
+
+	if validationErr := s.validateA2ARequest(ctx, req, int64(len(frame))); validationErr != nil {
+		logger.Warn("rejected invalid a2a websocket request",
+			zap.String("method", req.Method),
+			zap.String("reason", validationErr.Message))
+		s.sendMethodError(c, req.ID, validationErr)
+		writer.finish()
+		return
+	}
+
+	s.audit.call(c, req)
+	c.Request = c.Request.WithContext(contextWithPolicy(contextWithAuditLog(ctx, s.audit), s.policy))
+
+	switch req.Method {
+	case "message/stream":
+		s.protocolHandler.HandleMessageStream(c, req, s.streamingTaskHandler)
+	case "tasks/resubscribe":
+		s.protocolHandler.HandleTaskResubscribe(c, req, s.streamingTaskHandler)
+	default:
+		logger.Warn("unsupported websocket method requested", zap.String("method", req.Method))
+		s.responseSender.SendError(c, req.ID, int(ErrMethodNotFound), "method not supported over websocket")
+	}
+
+	writer.finish()
+}
+
+// webSocketEventWriter is a gin.ResponseWriter that turns the server-sent events written by
+// the streaming handlers into WebSocket text frames. Plain JSON responses are sent as a
+// single frame once the handler returns.
+type webSocketEventWriter struct {
+	gin.ResponseWriter
+	conn   *websocket.Conn
+	header http.Header
+	buffer bytes.Buffer
+	err    error
+}
+
+// newWebSocketEventWriter creates a writer forwarding events to the connection
+func newWebSocketEventWriter(w gin.ResponseWriter, conn *websocket.Conn) *webSocketEventWriter {
+	return &webSocketEventWriter{
+		ResponseWriter: w,
+		conn:           conn,
+		header:         make(http.Header),
+	}
+}
+
+// Header returns headers that are discarded, the connection is already upgraded
+func (w *webSocketEventWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader ignores the status code, the connection is already upgraded
+func (w *webSocketEventWriter) WriteHeader(int) {}
+
+// WriteHeaderNow ignores the status code, the connection is already upgraded
+func (w *webSocketEventWriter) WriteHeaderNow() {}
+
+// Write buffers the data and sends every complete event as a frame
+func (w *webSocketEventWriter) Write(data []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	w.buffer.Write(data)
+	w.sendEvents()
+	return len(data), w.err
+}
+
+// WriteString buffers the string and sends every complete event as a frame
+func (w *webSocketEventWriter) WriteString(data string) (int, error) {
+	return w.Write([]byte(data))
+}
+
+// Flush sends every complete event as a frame
+func (w *webSocketEventWriter) Flush() {
+	w.sendEvents()
+}
+
+// sendEvents sends the data of every complete server-sent event in the buffer
+func (w *webSocketEventWriter) sendEvents() {
+	for w.err == nil {
+		event, rest, found := bytes.Cut(w.buffer.Bytes(), []byte("\n\n"))
+		if !found {
+			return
+		}
+		w.send(string(event))
+		remaining := bytes.Clone(rest)
+		w.buffer.Reset()
+		w.buffer.Write(remaining)
+	}
+}
+
+// finish sends whatever is left in the buffer, such as a plain JSON-RPC response
+func (w *webSocketEventWriter) finish() {
+	w.sendEvents()
+	if remaining := strings.TrimSpace(w.buffer.String()); remaining != "" && w.err == nil {
+		w.send(remaining)
+	}
+	w.buffer.Reset()
+}
+
+// send writes the payload of an event as a text frame, skipping the end-of-stream
+// marker since closing the connection ends the stream
+func (w *webSocketEventWriter) send(event string) {
+	for _, line := range strings.Split(event, "\n") {
+		payload := strings.TrimPrefix(line, "data: ")
+		if payload == "" || payload == "[DONE]" {
+			continue
+		}
+		if err := websocket.Message.Send(w.conn, payload); err != nil {
+			w.err = err
+			return
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+	zap "go.uber.org/zap"
+	websocket "golang.org/x/net/websocket"
+
+	client "github.com/inference-gateway/adk/client"
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func newWebSocketTestServer(t *testing.T, enabled bool) (*A2AServerImpl, *httptest.Server) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{CapabilitiesConfig: config.CapabilitiesConfig{Streaming: true, WebSocket: enabled}}
+	s := NewA2AServer(cfg, zap.NewNop(), nil)
+	s.SetAgentCard(types.AgentCard{Name: "agent"})
+
+	httpServer := httptest.NewServer(s.setupRouter(cfg))
+	t.Cleanup(httpServer.Close)
+	return s, httpServer
+}
+
+func TestA2AServer_WebSocketResubscribe(t *testing.T) {
+	s, httpServer := newWebSocketTestServer(t, true)
+	task := s.taskManager.CreateTask("ctx-1", types.TaskStateCompleted, &types.Message{
+		MessageID: "m1",
+		Role:      types.RoleUser,
+		Parts:     []types.Part{types.CreateTextPart("hello")},
+	})
+
+	tests := []struct {
+		name      string
+		transport string
+		discover  bool
+	}{
+		{name: "explicit websocket transport", transport: client.StreamTransportWebSocket},
+		{name: "negotiated from the agent card", transport: client.StreamTransportAuto, discover: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := client.DefaultConfig(httpServer.URL)
+			cfg.StreamTransport = tt.transport
+			a2aClient := client.NewClientWithConfig(cfg)
+
+			if tt.discover {
+				card, err := a2aClient.GetAgentCard(context.Background())
+				require.NoError(t, err)
+				path, ok := types.GetWebSocketPath(card)
+				require.True(t, ok)
+				assert.Equal(t, types.WebSocketPath, path)
+			}
+
+			stream, err := a2aClient.OpenResubscribeStream(context.Background(), types.TaskResubscriptionParams{Name: task.ID})
+			require.NoError(t, err)
+			defer func() { require.NoError(t, stream.Close()) }()
+
+			var events []types.JSONRPCSuccessResponse
+			timeout := time.After(2 * time.Second)
+			for done := false; !done; {
+				select {
+				case event, ok := <-stream.Events():
+					if !ok {
+						done = true
+						break
+					}
+					events = append(events, event)
+				case <-timeout:
+					t.Fatal("websocket stream did not end")
+				}
+			}
+
+			require.NoError(t, stream.Err())
+			require.NotEmpty(t, events)
+			payload, err := json.Marshal(events[len(events)-1].Result)
+			require.NoError(t, err)
+			var status types.TaskStatusUpdateEvent
+			require.NoError(t, json.Unmarshal(payload, &status))
+			assert.Equal(t, task.ID, status.TaskID)
+			assert.Equal(t, types.TaskStateCompleted, status.Status.State)
+			assert.True(t, status.Final)
+		})
+	}
+}
+
+func TestA2AServer_WebSocketUnsupportedMethod(t *testing.T) {
+	_, httpServer := newWebSocketTestServer(t, true)
+	wsURL := "ws" + strings.TrimPrefix(httpServer.URL, "http") + types.WebSocketPath
+
+	conn, err := websocket.Dial(wsURL, "", httpServer.URL)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	require.NoError(t, websocket.Message.Send(conn, `{"jsonrpc":"2.0","id":"1","method":"tasks/get","params":{}}`))
+
+	var frame string
+	require.NoError(t, websocket.Message.Receive(conn, &frame))
+	var response struct {
+		Error *types.JSONRPCError `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(frame), &response))
+	require.NotNil(t, response.Error)
+	assert.Equal(t, int(ErrMethodNotFound), response.Error.Code)
+}
+
+func TestA2AServer_WebSocketDisabled(t *testing.T) {
+	s, httpServer := newWebSocketTestServer(t, false)
+
+	resp, err := http.Get(httpServer.URL + types.WebSocketPath)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	_, advertised := types.GetWebSocketPath(s.GetAgentCard())
+	assert.False(t, advertised)
+}
+
+func TestWebSocketEventWriter_Events(t *testing.T) {
+	frames := make(chan string, 10)
+	httpServer := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		for {
+			var frame string
+			if err := websocket.Message.Receive(conn, &frame); err != nil {
+				close(frames)
+				return
+			}
+			frames <- frame
+		}
+	}))
+	defer httpServer.Close()
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), "", httpServer.URL)
+	require.NoError(t, err)
+
+	writer := newWebSocketEventWriter(nil, conn)
+	_, err = writer.Write([]byte("data: {\"a\":1}\n\ndata: {\"b\""))
+	require.NoError(t, err)
+	_, err = writer.WriteString(":2}\n\ndata: [DONE]\n\n{\"c\":3}")
+	require.NoError(t, err)
+	writer.finish()
+	require.NoError(t, conn.Close())
+
+	var received []string
+	for frame := range frames {
+		received = append(received, frame)
+	}
+	assert.Equal(t, []string{`{"a":1}`, `{"b":2}`, `{"c":3}`}, received)
+}
@@ -0,0 +1,64 @@
+package adktesting
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	server "github.com/inference-gateway/adk/server"
+)
+
+var _ server.Clock = (*FakeClock)(nil)
+
+// FakeClock is a clock that only moves when told to, so task timestamps are deterministic.
+// It is safe for concurrent use.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a fake clock reading the given time
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current time of the clock
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by the duration
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to the given time
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+var _ server.IDGenerator = (*SequentialIDs)(nil)
+
+// SequentialIDs generates the predictable IDs <prefix>-1, <prefix>-2, ... in place of random
+// UUIDs. It is safe for concurrent use.
+type SequentialIDs struct {
+	prefix string
+	next   atomic.Int64
+}
+
+// NewSequentialIDs creates a generator of IDs with the prefix
+func NewSequentialIDs(prefix string) *SequentialIDs {
+	return &SequentialIDs{prefix: prefix}
+}
+
+// NewID returns the next ID of the sequence
+func (g *SequentialIDs) NewID() string {
+	return fmt.Sprintf("%s-%d", g.prefix, g.next.Add(1))
+}
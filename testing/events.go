@@ -0,0 +1,144 @@
+package adktesting
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+)
+
+var _ server.EventSink = (*EventRecorder)(nil)
+
+// EventRecorder records CloudEvents, either those of an agent run drained with Record or those
+// of the tasks processed by a server it is the event sink of. It is safe for concurrent use.
+type EventRecorder struct {
+	mu       sync.Mutex
+	events   []cloudevents.Event
+	recorded chan struct{}
+}
+
+// NewEventRecorder creates an empty event recorder
+func NewEventRecorder() *EventRecorder {
+	return &EventRecorder{recorded: make(chan struct{})}
+}
+
+// Publish records an event delivered to the recorder as an event sink
+func (r *EventRecorder) Publish(ctx context.Context, event cloudevents.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	close(r.recorded)
+	r.recorded = make(chan struct{})
+	return nil
+}
+
+// Close does nothing, the events stay recorded once the server stops
+func (r *EventRecorder) Close() error {
+	return nil
+}
+
+// Record drains the events of a channel, such as the one returned by an agent's RunWithStream,
+// until it is closed, and returns them
+func (r *EventRecorder) Record(events <-chan cloudevents.Event) []cloudevents.Event {
+	var drained []cloudevents.Event
+	for event := range events {
+		_ = r.Publish(context.Background(), event)
+		drained = append(drained, event)
+	}
+	return drained
+}
+
+// Events returns the events recorded so far
+func (r *EventRecorder) Events() []cloudevents.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]cloudevents.Event(nil), r.events...)
+}
+
+// Types returns the types of the events recorded so far, in order
+func (r *EventRecorder) Types() []string {
+	events := r.Events()
+	eventTypes := make([]string, 0, len(events))
+	for _, event := range events {
+		eventTypes = append(eventTypes, event.Type())
+	}
+	return eventTypes
+}
+
+// OfType returns the recorded events of a type
+func (r *EventRecorder) OfType(eventType string) []cloudevents.Event {
+	var matching []cloudevents.Event
+	for _, event := range r.Events() {
+		if event.Type() == eventType {
+			matching = append(matching, event)
+		}
+	}
+	return matching
+}
+
+// ForTask returns the events a server recorded for a task
+func (r *EventRecorder) ForTask(taskID string) []cloudevents.Event {
+	var matching []cloudevents.Event
+	for _, event := range r.Events() {
+		if id, ok := event.Extensions()["taskid"].(string); ok && id == taskID {
+			matching = append(matching, event)
+		}
+	}
+	return matching
+}
+
+// Text returns the text streamed by the recorded delta events, concatenated
+func (r *EventRecorder) Text() string {
+	var text strings.Builder
+	for _, event := range r.OfType(types.EventDelta) {
+		var message types.Message
+		if err := event.DataAs(&message); err != nil {
+			continue
+		}
+		for _, part := range message.Parts {
+			if part.Text != nil {
+				text.WriteString(*part.Text)
+			}
+		}
+	}
+	return text.String()
+}
+
+// Reset forgets the events recorded so far
+func (r *EventRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = nil
+}
+
+// WaitFor waits until an event of the type is recorded and returns the first one, failing the
+// test once the timeout passes. Events reach an event sink in the background, after the
+// response of the request that caused them.
+func (r *EventRecorder) WaitFor(t testing.TB, eventType string, timeout time.Duration) cloudevents.Event {
+	t.Helper()
+	deadline := time.After(timeout)
+	for {
+		r.mu.Lock()
+		recorded := r.recorded
+		for _, event := range r.events {
+			if event.Type() == eventType {
+				r.mu.Unlock()
+				return event
+			}
+		}
+		r.mu.Unlock()
+
+		select {
+		case <-recorded:
+		case <-deadline:
+			t.Fatalf("no %s event recorded within %s, recorded %v", eventType, timeout, r.Types())
+			return cloudevents.Event{}
+		}
+	}
+}
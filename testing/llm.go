@@ -0,0 +1,239 @@
+// Package adktesting provides test doubles for writing deterministic unit tests of agents, task
+// handlers, callbacks and tools without a real LLM provider: a fake LLM client answering with
+// scripted responses and tool calls, LLM clients recording real interactions and replaying
+// them offline, a fake clock, sequential IDs, an event recorder and an A2A server served in
+// memory.
+package adktesting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	server "github.com/inference-gateway/adk/server"
+)
+
+// Response is a scripted answer of a FakeLLMClient
+type Response struct {
+	// Text is the content the model answers with, streamed word by word
+	Text string
+
+	// ToolCalls are the tools the model calls
+	ToolCalls []ToolCall
+
+	// Usage is the token usage reported with the answer
+	Usage *sdk.CompletionUsage
+
+	// Err fails the request instead of answering it
+	Err error
+}
+
+// ToolCall is a tool call of a scripted response
+type ToolCall struct {
+	// ID identifies the call, generated as call-1, call-2, ... when empty
+	ID string
+
+	// Name is the name of the called tool
+	Name string
+
+	// Arguments are the arguments of the call, encoded as JSON
+	Arguments map[string]any
+}
+
+// Reply scripts a text answer
+func Reply(text string) Response {
+	return Response{Text: text}
+}
+
+// CallTool scripts a call of a single tool
+func CallTool(name string, arguments map[string]any) Response {
+	return Response{ToolCalls: []ToolCall{{Name: name, Arguments: arguments}}}
+}
+
+// CallTools scripts parallel calls of several tools
+func CallTools(calls ...ToolCall) Response {
+	return Response{ToolCalls: calls}
+}
+
+// Fail scripts a failed request
+func Fail(err error) Response {
+	return Response{Err: err}
+}
+
+// LLMRequest is a request received by a FakeLLMClient
+type LLMRequest struct {
+	// Messages are the messages sent to the model, including the system prompt
+	Messages []sdk.Message
+
+	// Tools are the tools offered to the model
+	Tools []sdk.ChatCompletionTool
+}
+
+// LastMessage returns the text of the last message of the request
+func (r LLMRequest) LastMessage() string {
+	if len(r.Messages) == 0 {
+		return ""
+	}
+	text, _ := r.Messages[len(r.Messages)-1].Content.AsMessageContent0()
+	return text
+}
+
+var _ server.LLMClient = (*FakeLLMClient)(nil)
+
+// FakeLLMClient is an LLM client that answers each request with the next scripted response
+// and records the requests it receives. A request arriving once the script is exhausted fails.
+// It is safe for concurrent use.
+type FakeLLMClient struct {
+	mu        sync.Mutex
+	responses []Response
+	requests  []LLMRequest
+	toolCalls int
+}
+
+// NewFakeLLMClient creates a fake LLM client answering with the responses in order
+func NewFakeLLMClient(responses ...Response) *FakeLLMClient {
+	return &FakeLLMClient{responses: responses}
+}
+
+// Script appends responses to the script of the client
+func (c *FakeLLMClient) Script(responses ...Response) *FakeLLMClient {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responses = append(c.responses, responses...)
+	return c
+}
+
+// Requests returns the requests received so far
+func (c *FakeLLMClient) Requests() []LLMRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]LLMRequest(nil), c.requests...)
+}
+
+// Remaining returns the number of scripted responses not yet used
+func (c *FakeLLMClient) Remaining() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.responses)
+}
+
+// next records the request and takes the next scripted response, assigning IDs to its tool calls
+func (c *FakeLLMClient) next(messages []sdk.Message, tools []sdk.ChatCompletionTool) (Response, []sdk.ChatCompletionMessageToolCall, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requests = append(c.requests, LLMRequest{Messages: messages, Tools: tools})
+	if len(c.responses) == 0 {
+		return Response{}, nil, fmt.Errorf("fake llm client: no scripted response left for request %d", len(c.requests))
+	}
+	response := c.responses[0]
+	c.responses = c.responses[1:]
+	if response.Err != nil {
+		return Response{}, nil, response.Err
+	}
+
+	toolCalls := make([]sdk.ChatCompletionMessageToolCall, 0, len(response.ToolCalls))
+	for _, call := range response.ToolCalls {
+		c.toolCalls++
+		id := call.ID
+		if id == "" {
+			id = fmt.Sprintf("call-%d", c.toolCalls)
+		}
+		arguments := []byte("{}")
+		if call.Arguments != nil {
+			var err error
+			if arguments, err = json.Marshal(call.Arguments); err != nil {
+				return Response{}, nil, fmt.Errorf("fake llm client: failed to encode arguments of tool %s: %w", call.Name, err)
+			}
+		}
+		toolCalls = append(toolCalls, sdk.ChatCompletionMessageToolCall{
+			ID:       id,
+			Type:     "function",
+			Function: sdk.ChatCompletionMessageToolCallFunction{Name: call.Name, Arguments: string(arguments)},
+		})
+	}
+	return response, toolCalls, nil
+}
+
+// CreateChatCompletion answers the request with the next scripted response
+func (c *FakeLLMClient) CreateChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (*sdk.CreateChatCompletionResponse, error) {
+	response, toolCalls, err := c.next(messages, tools)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := sdk.NewTextMessage(sdk.Assistant, response.Text)
+	if err != nil {
+		return nil, err
+	}
+	finishReason := sdk.Stop
+	if len(toolCalls) > 0 {
+		message.ToolCalls = &toolCalls
+		finishReason = sdk.ToolCalls
+	}
+
+	return &sdk.CreateChatCompletionResponse{
+		Choices: []sdk.ChatCompletionChoice{{Message: message, FinishReason: finishReason}},
+		Model:   "fake",
+		Object:  "chat.completion",
+		Usage:   response.Usage,
+	}, nil
+}
+
+// CreateStreamingChatCompletion streams the next scripted response: its text word by word,
+// followed by a chunk carrying the tool calls, the finish reason and the usage
+func (c *FakeLLMClient) CreateStreamingChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+	responseChan := make(chan *sdk.CreateChatCompletionStreamResponse, 1)
+	errorChan := make(chan error, 1)
+
+	response, toolCalls, err := c.next(messages, tools)
+	if err != nil {
+		errorChan <- err
+		return responseChan, errorChan
+	}
+
+	var chunks []*sdk.CreateChatCompletionStreamResponse
+	if response.Text != "" {
+		for word := range strings.SplitAfterSeq(response.Text, " ") {
+			chunks = append(chunks, &sdk.CreateChatCompletionStreamResponse{
+				Choices: []sdk.ChatCompletionStreamChoice{{Delta: sdk.ChatCompletionStreamResponseDelta{Content: word}}},
+			})
+		}
+	}
+
+	final := sdk.ChatCompletionStreamChoice{FinishReason: sdk.Stop}
+	if len(toolCalls) > 0 {
+		chunkCalls := make([]sdk.ChatCompletionMessageToolCallChunk, 0, len(toolCalls))
+		for i, call := range toolCalls {
+			chunkCalls = append(chunkCalls, sdk.ChatCompletionMessageToolCallChunk{
+				Index:    i,
+				ID:       &call.ID,
+				Type:     new("function"),
+				Function: &call.Function,
+			})
+		}
+		final.Delta.ToolCalls = &chunkCalls
+		final.FinishReason = sdk.ToolCalls
+	}
+	chunks = append(chunks, &sdk.CreateChatCompletionStreamResponse{
+		Choices: []sdk.ChatCompletionStreamChoice{final},
+		Usage:   response.Usage,
+	})
+
+	// the error channel stays open, as a closed one would end the stream before its chunks
+	go func() {
+		defer close(responseChan)
+		for _, chunk := range chunks {
+			select {
+			case responseChan <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return responseChan, errorChan
+}
@@ -0,0 +1,112 @@
+package adktesting
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func userMessage(text string) []types.Message {
+	return []types.Message{{MessageID: "m-1", Role: types.RoleUser, Parts: []types.Part{types.CreateTextPart(text)}}}
+}
+
+func TestFakeLLMClient_AgentRunWithTools(t *testing.T) {
+	var forecasts []string
+	weather := server.NewBasicTool("get_weather", "Returns the weather of a city", map[string]any{"type": "object"},
+		func(ctx context.Context, args map[string]any) (string, error) {
+			forecasts = append(forecasts, args["city"].(string))
+			return "Sunny, 22°C", nil
+		})
+
+	llm := NewFakeLLMClient(
+		CallTool("get_weather", map[string]any{"city": "Berlin"}),
+		Reply("It is sunny in Berlin."),
+	)
+	agent := NewAgent(t, llm, weather)
+
+	events, err := agent.RunWithStream(context.Background(), userMessage("Weather in Berlin?"))
+	require.NoError(t, err)
+	recorder := NewEventRecorder()
+	recorder.Record(events)
+
+	assert.Equal(t, []string{"Berlin"}, forecasts)
+	assert.Equal(t, "It is sunny in Berlin.", recorder.Text())
+	assert.Len(t, recorder.OfType(types.EventToolStarted), 1)
+	assert.Len(t, recorder.OfType(types.EventToolCompleted), 1)
+	assert.Zero(t, llm.Remaining())
+
+	requests := llm.Requests()
+	require.Len(t, requests, 2)
+	assert.Equal(t, "Weather in Berlin?", requests[0].LastMessage())
+	require.Len(t, requests[0].Tools, 1)
+	assert.Equal(t, "get_weather", requests[0].Tools[0].Function.Name)
+	last := requests[1].Messages[len(requests[1].Messages)-1]
+	assert.Equal(t, sdk.Tool, last.Role)
+	require.NotNil(t, last.ToolCallID)
+	assert.Equal(t, "call-1", *last.ToolCallID)
+	assert.Equal(t, "Sunny, 22°C", requests[1].LastMessage())
+}
+
+func TestFakeLLMClient_Failures(t *testing.T) {
+	llm := NewFakeLLMClient(Fail(errors.New("provider unavailable")))
+	agent := NewAgent(t, llm)
+
+	run := func() types.TaskStatus {
+		events, err := agent.RunWithStream(context.Background(), userMessage("Hi"))
+		require.NoError(t, err)
+		statuses := NewEventRecorder()
+		statuses.Record(events)
+		changes := statuses.OfType(types.EventTaskStatusChanged)
+		require.NotEmpty(t, changes)
+		var status types.TaskStatus
+		require.NoError(t, changes[len(changes)-1].DataAs(&status))
+		return status
+	}
+
+	status := run()
+	assert.Equal(t, types.TaskStateFailed, status.State)
+	assert.Contains(t, *status.Message.Parts[len(status.Message.Parts)-1].Text, "provider unavailable")
+
+	status = run()
+	assert.Equal(t, types.TaskStateFailed, status.State)
+	assert.Contains(t, *status.Message.Parts[len(status.Message.Parts)-1].Text, "no scripted response left for request 2")
+}
+
+func TestFakeLLMClient_CreateChatCompletion(t *testing.T) {
+	usage := &sdk.CompletionUsage{PromptTokens: 10, CompletionTokens: 3, TotalTokens: 13}
+	llm := NewFakeLLMClient(Response{Text: "Bonjour", Usage: usage}).
+		Script(CallTools(ToolCall{ID: "lookup", Name: "search"}, ToolCall{Name: "fetch", Arguments: map[string]any{"url": "https://example.com"}}))
+
+	message, err := sdk.NewTextMessage(sdk.User, "Translate: hello")
+	require.NoError(t, err)
+
+	response, err := llm.CreateChatCompletion(context.Background(), []sdk.Message{message})
+	require.NoError(t, err)
+	text, err := response.Choices[0].Message.Content.AsMessageContent0()
+	require.NoError(t, err)
+	assert.Equal(t, "Bonjour", text)
+	assert.Equal(t, sdk.Stop, response.Choices[0].FinishReason)
+	assert.Equal(t, usage, response.Usage)
+
+	response, err = llm.CreateChatCompletion(context.Background(), []sdk.Message{message})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.ToolCalls, response.Choices[0].FinishReason)
+	require.NotNil(t, response.Choices[0].Message.ToolCalls)
+	calls := *response.Choices[0].Message.ToolCalls
+	require.Len(t, calls, 2)
+	assert.Equal(t, "lookup", calls[0].ID)
+	assert.Equal(t, "{}", calls[0].Function.Arguments)
+	assert.Equal(t, "call-2", calls[1].ID)
+	assert.JSONEq(t, `{"url":"https://example.com"}`, calls[1].Function.Arguments)
+
+	_, err = llm.CreateChatCompletion(context.Background(), []sdk.Message{message})
+	assert.EqualError(t, err, "fake llm client: no scripted response left for request 3")
+	assert.Len(t, llm.Requests(), 3)
+}
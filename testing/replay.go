@@ -0,0 +1,310 @@
+package adktesting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+
+	server "github.com/inference-gateway/adk/server"
+)
+
+// RecordEnv is the environment variable that makes RecordOrReplay record interactions with
+// the live LLM client instead of replaying them
+const RecordEnv = "ADK_RECORD_LLM"
+
+// Interaction is a request to an LLM client and what it answered, as stored in a recording
+type Interaction struct {
+	// Messages are the messages of the request
+	Messages []sdk.Message `json:"messages"`
+
+	// Tools are the tools offered with the request
+	Tools []sdk.ChatCompletionTool `json:"tools,omitempty"`
+
+	// Stream reports whether the response was streamed
+	Stream bool `json:"stream"`
+
+	// Response is the answer to a request that was not streamed
+	Response *sdk.CreateChatCompletionResponse `json:"response,omitempty"`
+
+	// Chunks are the chunks of a streamed answer, in order
+	Chunks []*sdk.CreateChatCompletionStreamResponse `json:"chunks,omitempty"`
+
+	// Error is the message of the error the request failed with
+	Error string `json:"error,omitempty"`
+}
+
+// recording is the file format of recorded interactions
+type recording struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// key identifies the request of the interaction, to match replayed requests with
+func (i *Interaction) key() (string, error) {
+	data, err := json.Marshal(struct {
+		Messages []sdk.Message            `json:"messages"`
+		Tools    []sdk.ChatCompletionTool `json:"tools,omitempty"`
+		Stream   bool                     `json:"stream"`
+	}{i.Messages, i.Tools, i.Stream})
+	return string(data), err
+}
+
+var _ server.LLMClient = (*RecordingLLMClient)(nil)
+
+// RecordingLLMClient decorates an LLM client, recording every request with its response, or
+// the chunks of its stream, so they can be saved and served back by a ReplayLLMClient. It is
+// safe for concurrent use.
+type RecordingLLMClient struct {
+	next         server.LLMClient
+	mu           sync.Mutex
+	interactions []Interaction
+	streams      sync.WaitGroup
+}
+
+// NewRecordingLLMClient creates a client recording the interactions with the next client
+func NewRecordingLLMClient(next server.LLMClient) *RecordingLLMClient {
+	return &RecordingLLMClient{next: next}
+}
+
+// Interactions returns the interactions recorded so far, in the order they completed
+func (c *RecordingLLMClient) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// Save writes the recorded interactions to a JSON file, creating its directory. It waits for
+// the streams in flight, which are recorded once they end, after the agent read their last chunk.
+func (c *RecordingLLMClient) Save(file string) error {
+	c.streams.Wait()
+	data, err := json.MarshalIndent(recording{Interactions: c.Interactions()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recording: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	if err := os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	return nil
+}
+
+// record adds a completed interaction
+func (c *RecordingLLMClient) record(interaction Interaction) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, interaction)
+}
+
+// CreateChatCompletion forwards the request and records it with its response
+func (c *RecordingLLMClient) CreateChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (*sdk.CreateChatCompletionResponse, error) {
+	response, err := c.next.CreateChatCompletion(ctx, messages, tools...)
+	interaction := Interaction{Messages: messages, Tools: tools, Response: response}
+	if err != nil {
+		interaction.Response = nil
+		interaction.Error = err.Error()
+	}
+	c.record(interaction)
+	return response, err
+}
+
+// CreateStreamingChatCompletion forwards the request and its chunks, recording the stream
+// once it ends. A stream cut short by the context is not recorded.
+func (c *RecordingLLMClient) CreateStreamingChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+	upstream, upstreamErrors := c.next.CreateStreamingChatCompletion(ctx, messages, tools...)
+	responseChan := make(chan *sdk.CreateChatCompletionStreamResponse)
+	errorChan := make(chan error, 1)
+
+	c.streams.Add(1)
+	go func() {
+		defer c.streams.Done()
+		interaction := Interaction{Messages: messages, Tools: tools, Stream: true}
+		for {
+			select {
+			case chunk, ok := <-upstream:
+				if !ok {
+					// an error sent before the stream closed is still buffered
+					select {
+					case err := <-upstreamErrors:
+						if err != nil {
+							interaction.Error = err.Error()
+							c.record(interaction)
+							errorChan <- err
+							return
+						}
+					default:
+					}
+					c.record(interaction)
+					close(responseChan)
+					return
+				}
+				interaction.Chunks = append(interaction.Chunks, chunk)
+				select {
+				case responseChan <- chunk:
+				case <-ctx.Done():
+					return
+				}
+			case err, ok := <-upstreamErrors:
+				if !ok {
+					upstreamErrors = nil
+					continue
+				}
+				if err == nil {
+					continue
+				}
+				interaction.Error = err.Error()
+				c.record(interaction)
+				errorChan <- err
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return responseChan, errorChan
+}
+
+var _ server.LLMClient = (*ReplayLLMClient)(nil)
+
+// ReplayLLMClient serves recorded interactions back without calling an LLM provider. Each
+// request is answered by the first recorded interaction not served yet with the same
+// messages, tools and streaming mode; a request without one fails. It is safe for concurrent
+// use.
+type ReplayLLMClient struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	keys         []string
+	served       []bool
+	requests     int
+}
+
+// NewReplayLLMClient creates a client replaying the interactions
+func NewReplayLLMClient(interactions ...Interaction) (*ReplayLLMClient, error) {
+	c := &ReplayLLMClient{interactions: interactions, served: make([]bool, len(interactions))}
+	for i := range interactions {
+		key, err := interactions[i].key()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode recorded request %d: %w", i+1, err)
+		}
+		c.keys = append(c.keys, key)
+	}
+	return c, nil
+}
+
+// LoadReplayLLMClient creates a client replaying the interactions saved to a file by a
+// RecordingLLMClient
+func LoadReplayLLMClient(file string) (*ReplayLLMClient, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	var saved recording
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("invalid recording %s: %w", file, err)
+	}
+	return NewReplayLLMClient(saved.Interactions...)
+}
+
+// Remaining returns the number of recorded interactions not served yet
+func (c *ReplayLLMClient) Remaining() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	remaining := 0
+	for _, served := range c.served {
+		if !served {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// next takes the first interaction not served yet that matches the request
+func (c *ReplayLLMClient) next(messages []sdk.Message, tools []sdk.ChatCompletionTool, stream bool) (*Interaction, error) {
+	request := Interaction{Messages: messages, Tools: tools, Stream: stream}
+	key, err := request.key()
+	if err != nil {
+		return nil, fmt.Errorf("replay llm client: failed to encode request: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests++
+	for i := range c.interactions {
+		if !c.served[i] && c.keys[i] == key {
+			c.served[i] = true
+			return &c.interactions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("replay llm client: no recorded interaction matches request %d", c.requests)
+}
+
+// CreateChatCompletion answers with the recorded response
+func (c *ReplayLLMClient) CreateChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (*sdk.CreateChatCompletionResponse, error) {
+	interaction, err := c.next(messages, tools, false)
+	if err != nil {
+		return nil, err
+	}
+	if interaction.Error != "" {
+		return nil, errors.New(interaction.Error)
+	}
+	return interaction.Response, nil
+}
+
+// CreateStreamingChatCompletion streams the recorded chunks, or fails with the recorded error
+func (c *ReplayLLMClient) CreateStreamingChatCompletion(ctx context.Context, messages []sdk.Message, tools ...sdk.ChatCompletionTool) (<-chan *sdk.CreateChatCompletionStreamResponse, <-chan error) {
+	responseChan := make(chan *sdk.CreateChatCompletionStreamResponse, 1)
+	errorChan := make(chan error, 1)
+
+	interaction, err := c.next(messages, tools, true)
+	if err != nil {
+		errorChan <- err
+		return responseChan, errorChan
+	}
+
+	// the error channel stays open, as a closed one would end the stream before its chunks
+	go func() {
+		for _, chunk := range interaction.Chunks {
+			select {
+			case responseChan <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if interaction.Error != "" {
+			errorChan <- errors.New(interaction.Error)
+			return
+		}
+		close(responseChan)
+	}()
+	return responseChan, errorChan
+}
+
+// RecordOrReplay returns an LLM client for an integration test of an agent loop. By default
+// it replays the interactions recorded in the file, so the test runs offline and
+// reproducibly. When the RecordEnv environment variable is set, it records the interactions
+// with the client created by live instead, and saves them to the file once the test ends.
+func RecordOrReplay(t testing.TB, file string, live func() server.LLMClient) server.LLMClient {
+	t.Helper()
+	if os.Getenv(RecordEnv) == "" {
+		replay, err := LoadReplayLLMClient(file)
+		if err != nil {
+			t.Fatalf("failed to load LLM recording, record it with %s=1: %v", RecordEnv, err)
+		}
+		return replay
+	}
+
+	recorder := NewRecordingLLMClient(live())
+	t.Cleanup(func() {
+		if err := recorder.Save(file); err != nil {
+			t.Errorf("failed to save LLM recording: %v", err)
+		}
+	})
+	return recorder
+}
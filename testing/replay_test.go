@@ -0,0 +1,106 @@
+package adktesting
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/inference-gateway/sdk"
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestRecordOrReplay_AgentLoop(t *testing.T) {
+	weather := server.NewBasicTool("get_weather", "Returns the weather of a city", map[string]any{"type": "object"},
+		func(ctx context.Context, args map[string]any) (string, error) {
+			return "Sunny, 22°C", nil
+		})
+	file := filepath.Join(t.TempDir(), "recordings", "weather.json")
+
+	run := func(t *testing.T, llm server.LLMClient) *EventRecorder {
+		events, err := NewAgent(t, llm, weather).RunWithStream(context.Background(), userMessage("Weather in Berlin?"))
+		require.NoError(t, err)
+		recorder := NewEventRecorder()
+		recorder.Record(events)
+		return recorder
+	}
+
+	var recorded *EventRecorder
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(RecordEnv, "1")
+		live := NewFakeLLMClient(
+			CallTool("get_weather", map[string]any{"city": "Berlin"}),
+			Reply("It is sunny in Berlin."),
+		)
+		recorded = run(t, RecordOrReplay(t, file, func() server.LLMClient { return live }))
+		assert.Zero(t, live.Remaining())
+	})
+	require.FileExists(t, file)
+
+	t.Run("replay", func(t *testing.T) {
+		llm := RecordOrReplay(t, file, func() server.LLMClient {
+			t.Fatal("the live client is only created when recording")
+			return nil
+		})
+		replayed := run(t, llm)
+		assert.Equal(t, recorded.Text(), replayed.Text())
+		assert.Equal(t, "It is sunny in Berlin.", replayed.Text())
+		assert.Len(t, replayed.OfType(types.EventToolCompleted), 1)
+		assert.Zero(t, llm.(*ReplayLLMClient).Remaining())
+	})
+}
+
+func TestReplayLLMClient_ChatCompletion(t *testing.T) {
+	recorder := NewRecordingLLMClient(NewFakeLLMClient(Reply("Bonjour"), Fail(errors.New("rate limited"))))
+	hello, err := sdk.NewTextMessage(sdk.User, "Translate: hello")
+	require.NoError(t, err)
+	bye, err := sdk.NewTextMessage(sdk.User, "Translate: bye")
+	require.NoError(t, err)
+
+	_, err = recorder.CreateChatCompletion(context.Background(), []sdk.Message{hello})
+	require.NoError(t, err)
+	_, err = recorder.CreateChatCompletion(context.Background(), []sdk.Message{bye})
+	require.EqualError(t, err, "rate limited")
+
+	file := filepath.Join(t.TempDir(), "translate.json")
+	require.NoError(t, recorder.Save(file))
+	replay, err := LoadReplayLLMClient(file)
+	require.NoError(t, err)
+	assert.Equal(t, 2, replay.Remaining())
+
+	_, err = replay.CreateChatCompletion(context.Background(), []sdk.Message{bye})
+	assert.EqualError(t, err, "rate limited", "requests are matched by content, not order")
+	response, err := replay.CreateChatCompletion(context.Background(), []sdk.Message{hello})
+	require.NoError(t, err)
+	text, err := response.Choices[0].Message.Content.AsMessageContent0()
+	require.NoError(t, err)
+	assert.Equal(t, "Bonjour", text)
+
+	_, err = replay.CreateChatCompletion(context.Background(), []sdk.Message{hello})
+	assert.EqualError(t, err, "replay llm client: no recorded interaction matches request 3")
+	_, errs := replay.CreateStreamingChatCompletion(context.Background(), []sdk.Message{bye})
+	assert.EqualError(t, <-errs, "replay llm client: no recorded interaction matches request 4")
+}
+
+func TestRecordingLLMClient_StreamError(t *testing.T) {
+	recorder := NewRecordingLLMClient(NewFakeLLMClient(Fail(errors.New("provider unavailable"))))
+	message, err := sdk.NewTextMessage(sdk.User, "Hi")
+	require.NoError(t, err)
+
+	_, errs := recorder.CreateStreamingChatCompletion(context.Background(), []sdk.Message{message})
+	assert.EqualError(t, <-errs, "provider unavailable")
+
+	interactions := recorder.Interactions()
+	require.Len(t, interactions, 1)
+	assert.True(t, interactions[0].Stream)
+	assert.Equal(t, "provider unavailable", interactions[0].Error)
+
+	replay, err := NewReplayLLMClient(interactions...)
+	require.NoError(t, err)
+	_, errs = replay.CreateStreamingChatCompletion(context.Background(), []sdk.Message{message})
+	assert.EqualError(t, <-errs, "provider unavailable")
+}
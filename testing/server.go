@@ -0,0 +1,179 @@
+package adktesting
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	zap "go.uber.org/zap"
+
+	client "github.com/inference-gateway/adk/client"
+	server "github.com/inference-gateway/adk/server"
+	config "github.com/inference-gateway/adk/server/config"
+	types "github.com/inference-gateway/adk/types"
+)
+
+// StartTime is the time the fake clock of a Server starts at
+var StartTime = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// BuilderOption adjusts the builder of a Server, such as to replace its task handlers, agent
+// card or register callbacks
+type BuilderOption func(builder server.A2AServerBuilder) server.A2AServerBuilder
+
+// Server is an A2A server served in memory for the duration of a test. Tasks are timestamped by
+// a fake clock, identified by sequential IDs and their events are recorded.
+type Server struct {
+	// URL is the base URL the server is reachable at
+	URL string
+
+	// A2AServer is the server under test
+	A2AServer server.A2AServer
+
+	// Clock timestamps the tasks of the server, starting at StartTime
+	Clock *FakeClock
+
+	// IDs identifies the tasks, contexts and messages of the server as id-1, id-2, ...
+	IDs *SequentialIDs
+
+	// Events records the events of the tasks processed by the server
+	Events *EventRecorder
+
+	messages *SequentialIDs // identifies the messages sent with Send
+}
+
+// NewServer serves the agent with the default task handlers, an in-memory task store and an
+// agent card of a streaming test-agent until the test ends. The options are applied to the
+// builder last, so they can replace any of these.
+func NewServer(t testing.TB, agent server.OpenAICompatibleAgent, options ...BuilderOption) *Server {
+	t.Helper()
+	cfg, err := config.NewWithDefaults(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("failed to load default configuration: %v", err)
+	}
+	return NewServerWithConfig(t, *cfg, agent, options...)
+}
+
+// NewServerWithConfig serves the agent like NewServer, with the configuration
+func NewServerWithConfig(t testing.TB, cfg config.Config, agent server.OpenAICompatibleAgent, options ...BuilderOption) *Server {
+	t.Helper()
+	s := &Server{
+		Clock:    NewFakeClock(StartTime),
+		IDs:      NewSequentialIDs("id"),
+		Events:   NewEventRecorder(),
+		messages: NewSequentialIDs("message"),
+	}
+
+	builder := server.NewA2AServerBuilder(cfg, zap.NewNop()).
+		WithAgent(agent).
+		WithDefaultTaskHandlers().
+		WithAgentCard(testAgentCard()).
+		WithClock(s.Clock).
+		WithIDGenerator(s.IDs).
+		WithEventSink(s.Events)
+	for _, option := range options {
+		builder = option(builder)
+	}
+
+	a2aServer, err := builder.Build()
+	if err != nil {
+		t.Fatalf("failed to build A2A server: %v", err)
+	}
+	s.A2AServer = a2aServer
+
+	ctx, cancel := context.WithCancel(context.Background())
+	processed := make(chan struct{})
+	go func() {
+		defer close(processed)
+		a2aServer.StartTaskProcessor(ctx)
+	}()
+
+	httpServer := httptest.NewServer(a2aServer.Handler())
+	s.URL = httpServer.URL
+
+	t.Cleanup(func() {
+		httpServer.Close()
+		cancel()
+		<-processed
+		stopCtx, stop := context.WithTimeout(context.Background(), 5*time.Second)
+		defer stop()
+		if err := a2aServer.Stop(stopCtx); err != nil {
+			t.Errorf("failed to stop A2A server: %v", err)
+		}
+	})
+
+	// the card can only point at the server once it listens
+	err = a2aServer.UpdateAgentCard(func(card *types.AgentCard) {
+		if card.URL == nil && len(card.SupportedInterfaces) == 0 {
+			card.URL = &s.URL
+		}
+	})
+	if err != nil {
+		t.Fatalf("failed to advertise the server URL: %v", err)
+	}
+	return s
+}
+
+// Client returns an A2A client of the server
+func (s *Server) Client() client.A2AClient {
+	return client.NewClient(s.URL)
+}
+
+// Send sends a user message to the server and waits until its task completes, fails or pauses
+// for input, failing the test when the task cannot be followed
+func (s *Server) Send(t testing.TB, text string) *types.Task {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	task, err := s.Client().SendAndWait(ctx, types.MessageSendParams{
+		Message: types.Message{
+			MessageID: s.messages.NewID(),
+			Role:      types.RoleUser,
+			Parts:     []types.Part{types.CreateTextPart(text)},
+		},
+	}, &client.WaitOptions{PollInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("failed to send message: %v", err)
+	}
+	return task
+}
+
+// testAgentCard is the agent card a Server advertises unless an option replaces it
+func testAgentCard() types.AgentCard {
+	return types.AgentCard{
+		Name:               "test-agent",
+		Description:        "An agent under test",
+		Version:            "0.0.0",
+		ProtocolVersion:    "0.3.0",
+		Capabilities:       types.AgentCapabilities{Streaming: new(true), PushNotifications: new(false)},
+		DefaultInputModes:  []string{"text/plain"},
+		DefaultOutputModes: []string{"text/plain"},
+		Skills:             []types.AgentSkill{},
+	}
+}
+
+// NewAgent builds an agent answering through the LLM client, typically a FakeLLMClient, with
+// the tools and the default agent configuration
+func NewAgent(t testing.TB, llmClient server.LLMClient, tools ...server.Tool) server.OpenAICompatibleAgent {
+	t.Helper()
+	cfg, err := config.NewWithDefaults(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("failed to load default configuration: %v", err)
+	}
+
+	toolBox := server.NewToolBox()
+	for _, tool := range tools {
+		toolBox.AddTool(tool)
+	}
+
+	agent, err := server.NewAgentBuilder(zap.NewNop()).
+		WithConfig(&cfg.AgentConfig).
+		WithLLMClient(llmClient).
+		WithToolBox(toolBox).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build agent: %v", err)
+	}
+	return agent
+}
@@ -0,0 +1,67 @@
+package adktesting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+
+	server "github.com/inference-gateway/adk/server"
+	types "github.com/inference-gateway/adk/types"
+)
+
+func TestServer_Send(t *testing.T) {
+	llm := NewFakeLLMClient(Reply("Hello there"), Reply("Goodbye"))
+	s := NewServer(t, NewAgent(t, llm))
+
+	task := s.Send(t, "Hi")
+	assert.Equal(t, types.TaskStateCompleted, task.Status.State)
+	assert.Equal(t, "id-3", task.ID, "the request and then the context are identified first")
+	assert.Equal(t, "id-2", task.ContextID)
+	require.NotNil(t, task.Status.Timestamp)
+	assert.True(t, task.Status.Timestamp.Equal(StartTime))
+	assert.Equal(t, "Hi", llm.Requests()[0].LastMessage())
+
+	s.Events.WaitFor(t, types.EventTaskStatusChanged, 5*time.Second)
+	assert.Contains(t, s.Events.Types(), types.EventDelta)
+	assert.NotEmpty(t, s.Events.ForTask(task.ID))
+	assert.Empty(t, s.Events.ForTask("unknown"))
+
+	s.Clock.Advance(time.Hour)
+	task = s.Send(t, "Bye")
+	require.NotNil(t, task.Status.Timestamp)
+	assert.True(t, task.Status.Timestamp.Equal(StartTime.Add(time.Hour)))
+}
+
+func TestServer_BuilderOptions(t *testing.T) {
+	card := testAgentCard()
+	card.Name = "weather-agent"
+	s := NewServer(t, NewAgent(t, NewFakeLLMClient(Reply("Sunny"))), func(builder server.A2AServerBuilder) server.A2AServerBuilder {
+		return builder.WithAgentCard(card)
+	})
+
+	fetched, err := s.Client().GetAgentCard(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "weather-agent", fetched.Name)
+	assert.Equal(t, "Sunny", *s.Send(t, "Weather?").Status.Message.Parts[0].Text)
+}
+
+func TestFakeClock(t *testing.T) {
+	clock := NewFakeClock(StartTime)
+	assert.Equal(t, StartTime, clock.Now())
+
+	clock.Advance(90 * time.Second)
+	assert.Equal(t, StartTime.Add(90*time.Second), clock.Now())
+
+	later := StartTime.AddDate(0, 1, 0)
+	clock.Set(later)
+	assert.Equal(t, later, clock.Now())
+}
+
+func TestSequentialIDs(t *testing.T) {
+	ids := NewSequentialIDs("task")
+	assert.Equal(t, "task-1", ids.NewID())
+	assert.Equal(t, "task-2", ids.NewID())
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Auth required constants
+const (
+	// AuthRequestDataKey holds the authorization an auth-required message asks the client for
+	AuthRequestDataKey = "auth_request"
+	// AuthCredentialsDataKey holds the credentials a client resumes an auth-required task
+	// with, in a data part of the message resuming the task
+	AuthCredentialsDataKey = "auth_credentials"
+)
+
+// AuthRequest asks the client to authorize the agent, such as by completing an OAuth flow at
+// the authorization URL, sent in a data part of the auth-required status message under
+// AuthRequestDataKey
+type AuthRequest struct {
+	// Scheme is the authorization scheme the credentials are expected in, such as Bearer
+	Scheme           string   `json:"scheme"`
+	AuthorizationURL string   `json:"authorization_url,omitempty"`
+	Scopes           []string `json:"scopes,omitempty"`
+	Description      string   `json:"description,omitempty"`
+	// ToolCallID, ToolName and Arguments are the tool call that needs the authorization, if
+	// any, run again with the credentials once the task resumes
+	ToolCallID string         `json:"tool_call_id,omitempty"`
+	ToolName   string         `json:"tool_name,omitempty"`
+	Arguments  map[string]any `json:"arguments,omitempty"`
+}
+
+// AuthCredentials are the credentials a client supplies to resume an auth-required task, sent
+// in a data part of the message resuming the task under AuthCredentialsDataKey
+type AuthCredentials struct {
+	Scheme      string `json:"scheme"`
+	Credentials string `json:"credentials,omitempty"`
+}
+
+// NewAuthRequiredMessage creates an auth required message asking the client for authorization:
+// the description and authorization URL as text, for clients that only show text, and the
+// request in a data part
+func NewAuthRequiredMessage(toolCallID string, request AuthRequest) *Message {
+	text := request.Description
+	if text == "" {
+		text = "Authorization is required to continue."
+	}
+	if request.AuthorizationURL != "" {
+		text = fmt.Sprintf("%s Authorize at %s", strings.TrimSpace(text), request.AuthorizationURL)
+	}
+	return &Message{
+		MessageID: fmt.Sprintf("auth-required-%s", toolCallID),
+		Role:      RoleAgent,
+		Parts: []Part{
+			NewTextPart(text),
+			NewDataPart(map[string]any{
+				AuthRequestDataKey: request,
+			}),
+		},
+	}
+}
+
+// NewAuthCredentialsPart creates the part a client sends when resuming an auth-required task
+// with credentials
+func NewAuthCredentialsPart(scheme, credentials string) Part {
+	return NewDataPart(map[string]any{
+		AuthCredentialsDataKey: AuthCredentials{Scheme: scheme, Credentials: credentials},
+	})
+}
+
+// GetAuthRequest returns the authorization an auth-required message asks for, and false when
+// the message does not carry one
+func GetAuthRequest(message *Message) (*AuthRequest, bool, error) {
+	var request AuthRequest
+	found, err := decodeMessageDataValue(message, AuthRequestDataKey, &request)
+	if !found || err != nil {
+		return nil, false, err
+	}
+	return &request, true, nil
+}
+
+// GetAuthCredentials returns the credentials a message resumes an auth-required task with, and
+// false when the message does not carry any
+func GetAuthCredentials(message *Message) (*AuthCredentials, bool, error) {
+	var credentials AuthCredentials
+	found, err := decodeMessageDataValue(message, AuthCredentialsDataKey, &credentials)
+	if !found || err != nil {
+		return nil, false, err
+	}
+	return &credentials, true, nil
+}
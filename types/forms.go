@@ -0,0 +1,250 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Form constants
+const (
+	// FormRequestDataKey holds the form an input-required message asks the user to fill in
+	FormRequestDataKey = "form_request"
+	// FormResponseDataKey holds the data the user filled a form in with, in a data part of
+	// the message resuming the task
+	FormResponseDataKey = "form_response"
+)
+
+// FormRequest asks the user for structured data described by a JSON schema, sent in a data
+// part of the input-required status message under FormRequestDataKey
+type FormRequest struct {
+	// FormID identifies the form, such as shipping_address
+	FormID      string `json:"form_id"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Schema is the JSON schema of an object, whose properties are the fields of the form
+	Schema map[string]any `json:"schema"`
+	// ToolCallID and ToolName are the tool call of the agent that requested the form, if any
+	ToolCallID string `json:"tool_call_id,omitempty"`
+	ToolName   string `json:"tool_name,omitempty"`
+}
+
+// FormResponse is the data the user filled a form in with, sent in a data part of the
+// message resuming the task under FormResponseDataKey
+type FormResponse struct {
+	FormID string         `json:"form_id"`
+	Data   map[string]any `json:"data"`
+}
+
+// NewFormRequestMessage creates an input required message asking the user to fill in a form:
+// the title and description as text, for clients that only show text, and the form in a
+// data part
+func NewFormRequestMessage(messageID string, form FormRequest) *Message {
+	text := strings.TrimSpace(strings.Join([]string{form.Title, form.Description}, "\n\n"))
+	if text == "" {
+		text = fmt.Sprintf("Please fill in the %s form", form.FormID)
+	}
+	return &Message{
+		MessageID: messageID,
+		Role:      RoleAgent,
+		Parts: []Part{
+			NewTextPart(text),
+			NewDataPart(map[string]any{
+				FormRequestDataKey: form,
+			}),
+		},
+	}
+}
+
+// NewFormResponsePart creates the part a client sends when resuming a task with the data it
+// filled a form in with
+func NewFormResponsePart(formID string, data map[string]any) Part {
+	return NewDataPart(map[string]any{
+		FormResponseDataKey: FormResponse{FormID: formID, Data: data},
+	})
+}
+
+// GetFormRequest returns the form an input-required message asks for, and false when the
+// message does not carry one
+func GetFormRequest(message *Message) (*FormRequest, bool, error) {
+	var form FormRequest
+	found, err := decodeMessageDataValue(message, FormRequestDataKey, &form)
+	if !found || err != nil {
+		return nil, false, err
+	}
+	return &form, true, nil
+}
+
+// GetFormResponse returns the form data a message resumes a task with, and false when the
+// message does not carry any
+func GetFormResponse(message *Message) (*FormResponse, bool, error) {
+	var response FormResponse
+	found, err := decodeMessageDataValue(message, FormResponseDataKey, &response)
+	if !found || err != nil {
+		return nil, false, err
+	}
+	return &response, true, nil
+}
+
+// decodeMessageDataValue decodes the value stored under key in the first data part of a
+// message holding it
+func decodeMessageDataValue(message *Message, key string, target any) (bool, error) {
+	if message == nil {
+		return false, nil
+	}
+	for _, part := range message.Parts {
+		if part.Data == nil {
+			continue
+		}
+		raw, exists := part.Data.Data[key]
+		if !exists || raw == nil {
+			continue
+		}
+
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return false, fmt.Errorf("failed to marshal %s: %w", key, err)
+		}
+		if err := json.Unmarshal(data, target); err != nil {
+			return false, fmt.Errorf("failed to unmarshal %s: %w", key, err)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// ValidateFormData checks form data against the JSON schema of a form and describes every
+// problem found, or returns nil when the data is valid. The checks cover type, required,
+// properties, additionalProperties, items, enum, minLength, maxLength, pattern, minimum and
+// maximum; other keywords are ignored.
+func ValidateFormData(schema map[string]any, data map[string]any) []string {
+	var normalizedSchema map[string]any
+	if err := roundTripJSON(schema, &normalizedSchema); err != nil {
+		return []string{fmt.Sprintf("invalid form schema: %s", err.Error())}
+	}
+	var normalizedData any
+	if err := roundTripJSON(data, &normalizedData); err != nil {
+		return []string{fmt.Sprintf("invalid form data: %s", err.Error())}
+	}
+	if normalizedData == nil {
+		normalizedData = map[string]any{}
+	}
+	return validateSchemaValue(normalizedSchema, normalizedData, "")
+}
+
+// roundTripJSON converts a value to its JSON representation, so Go slices and numbers are
+// checked like the decoded JSON of a request
+func roundTripJSON(value any, target any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// validateSchemaValue checks a decoded JSON value against a decoded JSON schema
+func validateSchemaValue(schema map[string]any, value any, path string) []string {
+	field := path
+	if field == "" {
+		field = "form"
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !slices.ContainsFunc(enum, func(allowed any) bool { return reflect.DeepEqual(allowed, value) }) {
+		choices := make([]string, 0, len(enum))
+		for _, allowed := range enum {
+			choices = append(choices, fmt.Sprint(allowed))
+		}
+		return []string{fmt.Sprintf("%s must be one of: %s", field, strings.Join(choices, ", "))}
+	}
+
+	switch schema["type"] {
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return []string{fmt.Sprintf("%s must be a string", field)}
+		}
+		length := len([]rune(text))
+		if minLength, ok := schema["minLength"].(float64); ok && float64(length) < minLength {
+			return []string{fmt.Sprintf("%s must be at least %v characters", field, minLength)}
+		}
+		if maxLength, ok := schema["maxLength"].(float64); ok && float64(length) > maxLength {
+			return []string{fmt.Sprintf("%s must be at most %v characters", field, maxLength)}
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(text) {
+				return []string{fmt.Sprintf("%s must match the pattern %s", field, pattern)}
+			}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []string{fmt.Sprintf("%s must be a boolean", field)}
+		}
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok || (schema["type"] == "integer" && n != float64(int64(n))) {
+			return []string{fmt.Sprintf("%s must be a %s", field, schema["type"])}
+		}
+		if minimum, ok := schema["minimum"].(float64); ok && n < minimum {
+			return []string{fmt.Sprintf("%s must be at least %v", field, minimum)}
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && n > maximum {
+			return []string{fmt.Sprintf("%s must be at most %v", field, maximum)}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s must be an array", field)}
+		}
+		itemSchema, _ := schema["items"].(map[string]any)
+		var problems []string
+		for i, item := range items {
+			problems = append(problems, validateSchemaValue(itemSchema, item, fmt.Sprintf("%s[%d]", field, i))...)
+		}
+		return problems
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s must be an object", field)}
+		}
+		return validateSchemaObject(schema, object, path)
+	}
+	return nil
+}
+
+// validateSchemaObject checks the required, known and nested fields of an object
+func validateSchemaObject(schema map[string]any, object map[string]any, path string) []string {
+	var problems []string
+	prefix := ""
+	if path != "" {
+		prefix = path + "."
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	required, _ := schema["required"].([]any)
+	for _, name := range required {
+		if _, present := object[fmt.Sprint(name)]; !present {
+			problems = append(problems, fmt.Sprintf("missing required field %s%v", prefix, name))
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertySchema, known := properties[name].(map[string]any)
+		switch {
+		case known:
+			problems = append(problems, validateSchemaValue(propertySchema, object[name], prefix+name)...)
+		case schema["additionalProperties"] == false:
+			problems = append(problems, fmt.Sprintf("unknown field %s%s", prefix, name))
+		}
+	}
+	return problems
+}
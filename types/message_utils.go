@@ -1,7 +1,9 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
@@ -96,6 +98,59 @@ func NewInputRequiredMessage(toolCallID, message string) *Message {
 	}
 }
 
+// NewInputRequestMessage creates an input required message asking a structured question: the
+// question as text, for clients that only show text, and the request in a data part. The text
+// of a request with fields ends with the question of the first field missing an answer.
+func NewInputRequestMessage(toolCallID string, request InputRequest) *Message {
+	text := request.Question
+	if missing := request.MissingFields(); len(missing) > 0 {
+		text = strings.TrimSpace(text + "\n\n" + missing[0].Question)
+	}
+	return &Message{
+		MessageID: fmt.Sprintf("input-required-%s", toolCallID),
+		Role:      RoleAgent,
+		Parts: []Part{
+			NewTextPart(text),
+			NewDataPart(map[string]any{
+				InputRequestDataKey: request,
+			}),
+		},
+	}
+}
+
+// NewToolApprovalRequestMessage creates a message asking the client to approve or deny a tool call
+func NewToolApprovalRequestMessage(toolCallID, toolName string, arguments map[string]any) *Message {
+	return &Message{
+		MessageID: fmt.Sprintf("approval-required-%s", toolCallID),
+		Role:      RoleAgent,
+		Parts: []Part{
+			NewTextPart(fmt.Sprintf("Approval required to run tool %s", toolName)),
+			NewDataPart(map[string]any{
+				ToolApprovalRequestDataKey: map[string]any{
+					"tool_call_id": toolCallID,
+					"tool_name":    toolName,
+					"arguments":    arguments,
+				},
+			}),
+		},
+	}
+}
+
+// NewToolApprovalDecisionPart creates the part a client sends when resuming a task to approve
+// or deny the tool call it was asked about
+func NewToolApprovalDecisionPart(toolCallID string, approved bool, reason string) Part {
+	decision := map[string]any{
+		"tool_call_id": toolCallID,
+		"approved":     approved,
+	}
+	if reason != "" {
+		decision["reason"] = reason
+	}
+	return NewDataPart(map[string]any{
+		ToolApprovalDecisionDataKey: decision,
+	})
+}
+
 // NewAgentEvent creates a CloudEvent for agent lifecycle events
 func NewAgentEvent(eventType, eventID string, data map[string]any) cloudevents.Event {
 	event := cloudevents.NewEvent()
@@ -146,3 +201,321 @@ func NewMessageEvent(eventType, eventID string, message *Message) cloudevents.Ev
 
 	return event
 }
+
+// NewArtifactUpdateEvent creates a CloudEvent for an artifact attached to a task, with the artifact in the data field
+func NewArtifactUpdateEvent(artifact Artifact) cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID(fmt.Sprintf("artifact-update-%s", artifact.ArtifactID))
+	event.SetType(EventArtifactUpdate)
+	event.SetSource("adk/agent")
+	event.SetTime(time.Now())
+	_ = event.SetData(cloudevents.ApplicationJSON, artifact)
+
+	return event
+}
+
+// NewTaskFeedbackEvent creates a CloudEvent for feedback submitted on a task, with the feedback in the data field
+func NewTaskFeedbackEvent(feedback TaskFeedback) cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID(feedback.FeedbackID)
+	event.SetType(EventTaskFeedback)
+	event.SetSource("adk/server")
+	event.SetTime(time.Now())
+
+	event.SetExtension("task_id", feedback.TaskID)
+	_ = event.SetData(cloudevents.ApplicationJSON, feedback)
+
+	return event
+}
+
+// NewConfigReloadedEvent creates a CloudEvent for a configuration reload, with the reload in the data field
+func NewConfigReloadedEvent(reload ConfigReload) cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID(reload.ReloadID)
+	event.SetType(EventConfigReloaded)
+	event.SetSource("adk/server")
+	event.SetTime(time.Now())
+
+	_ = event.SetData(cloudevents.ApplicationJSON, reload)
+
+	return event
+}
+
+// NewEventsDroppedEvent creates a CloudEvent reporting events dropped for a consumer falling
+// behind, with the count in the data field
+func NewEventsDroppedEvent(eventID string, dropped EventsDropped) cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID(eventID)
+	event.SetType(EventEventsDropped)
+	event.SetSource("adk/server")
+	event.SetTime(time.Now())
+
+	_ = event.SetData(cloudevents.ApplicationJSON, dropped)
+
+	return event
+}
+
+// GetTaskFeedback returns the feedback entries recorded in a task's metadata
+func GetTaskFeedback(task *Task) ([]TaskFeedback, error) {
+	if task == nil || task.Metadata == nil {
+		return nil, nil
+	}
+	raw, exists := (*task.Metadata)[TaskFeedbackMetadataKey]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal task feedback: %w", err)
+	}
+	var feedback []TaskFeedback
+	if err := json.Unmarshal(data, &feedback); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task feedback: %w", err)
+	}
+	return feedback, nil
+}
+
+// GetTaskUsage returns the token usage recorded in a task's metadata, and false when the task
+// has no usage recorded
+func GetTaskUsage(task *Task) (TokenUsage, bool, error) {
+	if task == nil || task.Metadata == nil {
+		return TokenUsage{}, false, nil
+	}
+	raw, exists := (*task.Metadata)[UsageMetadataKey]
+	if !exists || raw == nil {
+		return TokenUsage{}, false, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return TokenUsage{}, false, fmt.Errorf("failed to marshal task usage: %w", err)
+	}
+	var usage TokenUsage
+	if err := json.Unmarshal(data, &usage); err != nil {
+		return TokenUsage{}, false, fmt.Errorf("failed to unmarshal task usage: %w", err)
+	}
+	return usage, true, nil
+}
+
+// GetTaskStateTransitions returns the state transitions recorded in a task's metadata, oldest
+// first
+func GetTaskStateTransitions(task *Task) ([]TaskStateTransition, error) {
+	if task == nil || task.Metadata == nil {
+		return nil, nil
+	}
+	raw, exists := (*task.Metadata)[StateTransitionsMetadataKey]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal task state transitions: %w", err)
+	}
+	var transitions []TaskStateTransition
+	if err := json.Unmarshal(data, &transitions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task state transitions: %w", err)
+	}
+	return transitions, nil
+}
+
+// GetTaskProgress returns the latest progress recorded in a task's metadata, or nil when none
+// was reported
+func GetTaskProgress(task *Task) (*TaskProgress, error) {
+	if task == nil || task.Metadata == nil {
+		return nil, nil
+	}
+	raw, exists := (*task.Metadata)[ProgressMetadataKey]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal task progress: %w", err)
+	}
+	var progress TaskProgress
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task progress: %w", err)
+	}
+	return &progress, nil
+}
+
+// GetTaskTimings returns the timing metrics recorded in a task's metadata, or nil when none
+// were recorded
+func GetTaskTimings(task *Task) (*TaskTimings, error) {
+	if task == nil || task.Metadata == nil {
+		return nil, nil
+	}
+	raw, exists := (*task.Metadata)[TimingsMetadataKey]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal task timings: %w", err)
+	}
+	var timings TaskTimings
+	if err := json.Unmarshal(data, &timings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task timings: %w", err)
+	}
+	return &timings, nil
+}
+
+// GetTaskUserID returns the ID of the user a task belongs to, or an empty string when the task
+// was created without a user identity
+func GetTaskUserID(task *Task) string {
+	if task == nil || task.Metadata == nil {
+		return ""
+	}
+	userID, _ := (*task.Metadata)[UserIDMetadataKey].(string)
+	return userID
+}
+
+// GetTaskLabels returns the labels recorded in a task's metadata
+func GetTaskLabels(task *Task) (map[string]string, error) {
+	if task == nil || task.Metadata == nil {
+		return nil, nil
+	}
+	raw, exists := (*task.Metadata)[TaskLabelsMetadataKey]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal task labels: %w", err)
+	}
+	var labels map[string]string
+	if err := json.Unmarshal(data, &labels); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task labels: %w", err)
+	}
+	return labels, nil
+}
+
+// GetInputRequest returns the structured question of an input-required message, and false
+// when the message does not carry one
+func GetInputRequest(message *Message) (*InputRequest, bool, error) {
+	var request InputRequest
+	found, err := decodeMessageDataValue(message, InputRequestDataKey, &request)
+	if !found || err != nil {
+		return nil, false, err
+	}
+	return &request, true, nil
+}
+
+// NewInputAnswersPart creates the part a client sends to answer fields of an input request,
+// by field name
+func NewInputAnswersPart(answers map[string]any) Part {
+	return NewDataPart(map[string]any{
+		InputAnswersDataKey: answers,
+	})
+}
+
+// GetInputAnswers returns the answers to the fields of an input request a message carries,
+// and false when it carries none
+func GetInputAnswers(message *Message) (map[string]any, bool, error) {
+	var answers map[string]any
+	found, err := decodeMessageDataValue(message, InputAnswersDataKey, &answers)
+	if !found || err != nil {
+		return nil, false, err
+	}
+	return answers, true, nil
+}
+
+// GetTaskSkillID returns the skill recorded in a task's metadata, the skill named by the
+// latest message naming one, or an empty string
+func GetTaskSkillID(task *Task) string {
+	if task == nil || task.Metadata == nil {
+		return ""
+	}
+	skillID, _ := (*task.Metadata)[SkillIDMetadataKey].(string)
+	return skillID
+}
+
+// GetIngestedFile returns the content the server's file ingestion attached to a file part, and
+// false when the part was not ingested
+func GetIngestedFile(part Part) (IngestedFile, bool, error) {
+	if part.File == nil || part.Metadata == nil {
+		return IngestedFile{}, false, nil
+	}
+	raw, exists := (*part.Metadata)[IngestedFileMetadataKey]
+	if !exists || raw == nil {
+		return IngestedFile{}, false, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return IngestedFile{}, false, fmt.Errorf("failed to marshal ingested file: %w", err)
+	}
+	var file IngestedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return IngestedFile{}, false, fmt.Errorf("failed to unmarshal ingested file: %w", err)
+	}
+	return file, true, nil
+}
+
+// GetSupportedLanguages returns the languages an agent card advertises through the
+// language extension, or nil when the agent does not advertise any
+func GetSupportedLanguages(card *AgentCard) []string {
+	if card == nil {
+		return nil
+	}
+	for _, extension := range card.Capabilities.Extensions {
+		if extension.URI != LanguageExtensionURI || extension.Params == nil {
+			continue
+		}
+		switch supported := (*extension.Params)["supported"].(type) {
+		case []string:
+			return supported
+		case []any:
+			languages := make([]string, 0, len(supported))
+			for _, language := range supported {
+				if code, ok := language.(string); ok {
+					languages = append(languages, code)
+				}
+			}
+			return languages
+		}
+	}
+	return nil
+}
+
+// GetWebSocketPath returns the path of the WebSocket streaming endpoint advertised in the
+// agent card, and whether the agent supports streaming over WebSocket
+func GetWebSocketPath(card *AgentCard) (string, bool) {
+	if card == nil {
+		return "", false
+	}
+	for _, extension := range card.Capabilities.Extensions {
+		if extension.URI != WebSocketExtensionURI {
+			continue
+		}
+		if extension.Params != nil {
+			if path, ok := (*extension.Params)["path"].(string); ok && path != "" {
+				return path, true
+			}
+		}
+		return WebSocketPath, true
+	}
+	return "", false
+}
+
+// GetArtifactUploadURL returns the URL files can be uploaded to before they are referenced in
+// a message, and whether the agent advertises artifact uploads in its card
+func GetArtifactUploadURL(card *AgentCard) (string, bool) {
+	if card == nil {
+		return "", false
+	}
+	for _, extension := range card.Capabilities.Extensions {
+		if extension.URI != ArtifactUploadExtensionURI || extension.Params == nil {
+			continue
+		}
+		if uploadURL, ok := (*extension.Params)["url"].(string); ok && uploadURL != "" {
+			return uploadURL, true
+		}
+	}
+	return "", false
+}
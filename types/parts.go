@@ -0,0 +1,230 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Part kinds of the kind-tagged representation of parts
+const (
+	PartKindText = "text"
+	PartKindFile = "file"
+	PartKindData = "data"
+)
+
+// taggedPart decodes a part in either representation: the canonical one, or the kind-tagged one
+// of A2A 0.3 clients, whose file and data fields hold the file and the data themselves
+type taggedPart struct {
+	Kind     string          `json:"kind"`
+	Text     *string         `json:"text,omitempty"`
+	File     json.RawMessage `json:"file,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	Metadata *Struct         `json:"metadata,omitempty"`
+}
+
+// taggedFile is the file of a kind-tagged part, accepting the field names of either
+// representation
+type taggedFile struct {
+	Name          string  `json:"name"`
+	MimeType      string  `json:"mimeType"`
+	MediaType     string  `json:"mediaType"`
+	Bytes         *string `json:"bytes,omitempty"`
+	FileWithBytes *string `json:"fileWithBytes,omitempty"`
+	URI           *string `json:"uri,omitempty"`
+	FileWithURI   *string `json:"fileWithUri,omitempty"`
+}
+
+// UnmarshalJSON decodes a part from its canonical representation or from the kind-tagged one,
+// so parts always hold a typed text, file or data value. A data part tagged with the data kind
+// holds its data directly, unless it is wrapped in a single data field like a canonical one.
+func (p *Part) UnmarshalJSON(data []byte) error {
+	var tagged taggedPart
+	if err := json.Unmarshal(data, &tagged); err != nil {
+		return err
+	}
+
+	part := Part{Metadata: tagged.Metadata}
+	switch tagged.Kind {
+	case "":
+		part.Text = tagged.Text
+		if len(tagged.File) > 0 && string(tagged.File) != "null" {
+			part.File = &FilePart{}
+			if err := json.Unmarshal(tagged.File, part.File); err != nil {
+				return fmt.Errorf("invalid file of part: %w", err)
+			}
+		}
+		if len(tagged.Data) > 0 && string(tagged.Data) != "null" {
+			part.Data = &DataPart{}
+			if err := json.Unmarshal(tagged.Data, part.Data); err != nil {
+				return fmt.Errorf("invalid data of part: %w", err)
+			}
+		}
+	case PartKindText:
+		text := ""
+		if tagged.Text != nil {
+			text = *tagged.Text
+		}
+		part.Text = &text
+	case PartKindFile:
+		var file taggedFile
+		if len(tagged.File) > 0 {
+			if err := json.Unmarshal(tagged.File, &file); err != nil {
+				return fmt.Errorf("invalid file of %s part: %w", tagged.Kind, err)
+			}
+		}
+		part.File = &FilePart{
+			Name:          file.Name,
+			MediaType:     firstNonEmpty(file.MediaType, file.MimeType),
+			FileWithBytes: file.FileWithBytes,
+			FileWithURI:   file.FileWithURI,
+		}
+		if part.File.FileWithBytes == nil {
+			part.File.FileWithBytes = file.Bytes
+		}
+		if part.File.FileWithURI == nil {
+			part.File.FileWithURI = file.URI
+		}
+	case PartKindData:
+		var values map[string]any
+		if len(tagged.Data) > 0 {
+			if err := json.Unmarshal(tagged.Data, &values); err != nil {
+				return fmt.Errorf("invalid data of %s part: %w", tagged.Kind, err)
+			}
+		}
+		if wrapped, ok := values["data"].(map[string]any); ok && len(values) == 1 {
+			values = wrapped
+		}
+		if values == nil {
+			values = map[string]any{}
+		}
+		part.Data = &DataPart{Data: values}
+	default:
+		return fmt.Errorf("unknown part kind '%s'", tagged.Kind)
+	}
+	*p = part
+	return nil
+}
+
+// firstNonEmpty returns the first of the values that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// NormalizePart converts a part in any of its representations, such as a map decoded from JSON
+// in either the canonical or the kind-tagged form, into a typed part
+func NormalizePart(value any) (Part, error) {
+	switch v := value.(type) {
+	case Part:
+		return v, nil
+	case *Part:
+		if v == nil {
+			return Part{}, fmt.Errorf("part is nil")
+		}
+		return *v, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return Part{}, fmt.Errorf("failed to marshal part: %w", err)
+	}
+	return UnmarshalPart(data)
+}
+
+// Kind returns the kind of the part: text, file or data, or an empty string for an empty part
+func (p Part) Kind() string {
+	switch {
+	case p.Text != nil:
+		return PartKindText
+	case p.File != nil:
+		return PartKindFile
+	case p.Data != nil:
+		return PartKindData
+	}
+	return ""
+}
+
+// AsText returns the text of a text part
+func (p Part) AsText() (string, bool) {
+	if p.Text == nil {
+		return "", false
+	}
+	return *p.Text, true
+}
+
+// AsFile returns the file of a file part
+func (p Part) AsFile() (FilePart, bool) {
+	if p.File == nil {
+		return FilePart{}, false
+	}
+	return *p.File, true
+}
+
+// AsData returns the data of a data part
+func (p Part) AsData() (map[string]any, bool) {
+	if p.Data == nil {
+		return nil, false
+	}
+	return p.Data.Data, true
+}
+
+// PartsText joins the text of the text parts
+func PartsText(parts []Part) string {
+	var text strings.Builder
+	for _, part := range parts {
+		if part.Text != nil {
+			text.WriteString(*part.Text)
+		}
+	}
+	return text.String()
+}
+
+// Text joins the text of the text parts of the message. It returns an empty string for a nil
+// message.
+func (m *Message) Text() string {
+	if m == nil {
+		return ""
+	}
+	return PartsText(m.Parts)
+}
+
+// Files returns the files of the file parts of the message
+func (m *Message) Files() []FilePart {
+	if m == nil {
+		return nil
+	}
+	var files []FilePart
+	for _, part := range m.Parts {
+		if file, ok := part.AsFile(); ok {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// Data returns the data of the data parts of the message
+func (m *Message) Data() []map[string]any {
+	if m == nil {
+		return nil
+	}
+	var data []map[string]any
+	for _, part := range m.Parts {
+		if values, ok := part.AsData(); ok {
+			data = append(data, values)
+		}
+	}
+	return data
+}
+
+// Text joins the text of the text parts of the artifact
+func (a *Artifact) Text() string {
+	if a == nil {
+		return ""
+	}
+	return PartsText(a.Parts)
+}
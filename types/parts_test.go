@@ -0,0 +1,106 @@
+package types
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+	require "github.com/stretchr/testify/require"
+)
+
+func TestPart_UnmarshalKindTagged(t *testing.T) {
+	tests := []struct {
+		name     string
+		jsonData string
+		expected Part
+	}{
+		{
+			name:     "text part",
+			jsonData: `{"kind": "text", "text": "Hello", "metadata": {"lang": "en"}}`,
+			expected: CreateTextPart("Hello", map[string]any{"lang": "en"}),
+		},
+		{
+			name:     "data part holding its data",
+			jsonData: `{"kind": "data", "data": {"result": "success"}}`,
+			expected: CreateDataPart(map[string]any{"result": "success"}),
+		},
+		{
+			name:     "data part in the canonical form",
+			jsonData: `{"kind": "data", "data": {"data": {"result": "success"}}}`,
+			expected: CreateDataPart(map[string]any{"result": "success"}),
+		},
+		{
+			name:     "file part with the field names of A2A 0.3",
+			jsonData: `{"kind": "file", "file": {"name": "report.pdf", "mimeType": "application/pdf", "uri": "https://example.com/report.pdf"}}`,
+			expected: CreateFilePart("report.pdf", "application/pdf", nil, new("https://example.com/report.pdf")),
+		},
+		{
+			name:     "file part with the canonical field names",
+			jsonData: `{"kind": "file", "file": {"name": "notes.txt", "mediaType": "text/plain", "fileWithBytes": "bm90ZXM="}}`,
+			expected: CreateFilePart("notes.txt", "text/plain", new("bm90ZXM="), nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			part, err := UnmarshalPart([]byte(tt.jsonData))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, part)
+		})
+	}
+
+	_, err := UnmarshalPart([]byte(`{"kind": "video", "url": "https://example.com/video.mp4"}`))
+	assert.EqualError(t, err, "failed to unmarshal Part: unknown part kind 'video'")
+}
+
+func TestNormalizePart(t *testing.T) {
+	part, err := NormalizePart(map[string]any{"kind": "data", "data": map[string]any{"tool_name": "get_weather"}})
+	require.NoError(t, err)
+	assert.Equal(t, CreateDataPart(map[string]any{"tool_name": "get_weather"}), part)
+
+	part, err = NormalizePart(map[string]any{"text": "Hi"})
+	require.NoError(t, err)
+	assert.Equal(t, CreateTextPart("Hi"), part)
+
+	part, err = NormalizePart(new(CreateTextPart("typed")))
+	require.NoError(t, err)
+	assert.Equal(t, CreateTextPart("typed"), part)
+
+	_, err = NormalizePart((*Part)(nil))
+	assert.EqualError(t, err, "part is nil")
+}
+
+func TestMessage_Accessors(t *testing.T) {
+	var message Message
+	require.NoError(t, message.UnmarshalJSON([]byte(`{
+		"messageId": "msg-1",
+		"role": "ROLE_AGENT",
+		"parts": [
+			{"kind": "text", "text": "The report "},
+			{"kind": "data", "data": {"pages": 3}},
+			{"kind": "file", "file": {"name": "report.pdf", "mimeType": "application/pdf", "bytes": "cGRm"}},
+			{"text": "is attached."}
+		]
+	}`)))
+
+	assert.Equal(t, "The report is attached.", message.Text())
+	assert.Equal(t, []map[string]any{{"pages": float64(3)}}, message.Data())
+	files := message.Files()
+	require.Len(t, files, 1)
+	assert.Equal(t, "application/pdf", files[0].MediaType)
+	assert.Equal(t, "cGRm", *files[0].FileWithBytes)
+
+	assert.Equal(t, []string{PartKindText, PartKindData, PartKindFile, PartKindText},
+		[]string{message.Parts[0].Kind(), message.Parts[1].Kind(), message.Parts[2].Kind(), message.Parts[3].Kind()})
+	text, ok := message.Parts[0].AsText()
+	assert.True(t, ok)
+	assert.Equal(t, "The report ", text)
+	_, ok = message.Parts[0].AsData()
+	assert.False(t, ok)
+	_, ok = message.Parts[1].AsFile()
+	assert.False(t, ok)
+
+	var missing *Message
+	assert.Empty(t, missing.Text())
+	assert.Nil(t, missing.Files())
+	assert.Equal(t, "ab", (&Artifact{Parts: []Part{CreateTextPart("a"), CreateTextPart("b")}}).Text())
+}
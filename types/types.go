@@ -16,9 +16,261 @@ const (
 	EventToolFailed         = "adk.agent.tool.failed"
 	EventToolResult         = "adk.agent.tool.result"
 	EventInputRequired      = "adk.agent.input.required"
+	EventAuthRequired       = "adk.agent.auth.required"
 	EventTaskInterrupted    = "adk.agent.task.interrupted"
 	EventTaskStatusChanged  = "adk.agent.task.status.changed"
 	EventStreamFailed       = "adk.agent.stream.failed"
+	EventArtifactUpdate     = "adk.agent.artifact.update"
+	EventTaskFeedback       = "adk.task.feedback"
+	EventConfigReloaded     = "adk.server.config.reloaded"
+	EventEventsDropped      = "adk.server.events.dropped"
+)
+
+// Task feedback constants
+const (
+	TaskFeedbackMetadataKey = "feedback"
+	TaskFeedbackMinRating   = 1
+	TaskFeedbackMaxRating   = 5
+)
+
+// Artifact integrity constants
+const (
+	ArtifactChecksumMetadataKey = "sha256"
+	ArtifactChecksumHeader      = "X-Checksum-Sha256"
+)
+
+// Artifact preview constants
+const (
+	// ArtifactPreviewMetadataKey in the metadata of a file part of a response message holds the
+	// ID of the artifact whose content the part carries inline
+	ArtifactPreviewMetadataKey = "artifactPreview"
+)
+
+// Transcript constants
+const (
+	// TranscriptMetadataKey in the metadata of an artifact marks it as the conversation
+	// transcript of its task, holding the format it is rendered in
+	TranscriptMetadataKey = "transcript"
+)
+
+// Idempotency constants
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// Task share scopes granted by a share token
+const (
+	TaskShareScopeTranscript = "transcript"
+	TaskShareScopeArtifacts  = "artifacts"
+)
+
+// Language detection constants
+const (
+	LanguageMetadataKey       = "language"
+	TranslatedFromMetadataKey = "translatedFrom"
+	OriginalTextMetadataKey   = "originalText"
+	LanguageExtensionURI      = "https://github.com/inference-gateway/adk/extensions/languages/v1"
+)
+
+// Task label constants
+const (
+	// TaskLabelsMetadataKey in the message/send metadata and the task metadata holds the labels
+	// of a task, string values by name, which tasks/list can filter tasks on
+	TaskLabelsMetadataKey = "labels"
+)
+
+// Task handoff constants
+const (
+	HandoffFromMetadataKey  = "handoffFrom"
+	HandoffCountMetadataKey = "handoffCount"
+)
+
+// Task recovery constants
+const (
+	// RecoveryCountMetadataKey in the task metadata counts the times the task was recovered
+	// on startup after the server processing it stopped
+	RecoveryCountMetadataKey = "recoveryCount"
+)
+
+// Task progress constants
+const (
+	// ProgressMetadataKey in the task metadata holds the latest progress reported for the
+	// task, and in the metadata of a working status update the progress it reports
+	ProgressMetadataKey = "progress"
+)
+
+// Task timing constants
+const (
+	// TimingsMetadataKey in the task metadata holds the TaskTimings of the task
+	TimingsMetadataKey = "timings"
+)
+
+// Request correlation constants
+const (
+	// RequestIDHeader carries the correlation ID of a JSON-RPC call, accepted from the caller
+	// and returned in the response
+	RequestIDHeader = "X-Request-ID"
+
+	// RequestIDMetadataKey in the task metadata holds the correlation ID of the latest call
+	// that sent a message to the task
+	RequestIDMetadataKey = "requestId"
+)
+
+// Authorization constants
+const (
+	AuthScopesMetadataKey = "authScopes"
+	// AuthSubjectMetadataKey in the task metadata holds the subject of the ID token of the
+	// latest caller that sent a message to the task, which the access policy checks tool
+	// calls against
+	AuthSubjectMetadataKey = "authSubject"
+)
+
+// User identity constants
+const (
+	// UserIDMetadataKey in the task metadata holds the ID of the user the task belongs to,
+	// which only that user can read and continue. In the metadata of a request or its message
+	// it names the user a trusted front-end calls on behalf of, when the server accepts it.
+	UserIDMetadataKey = "userId"
+)
+
+// Skill selection constants
+const (
+	// SkillIDMetadataKey in the message metadata names the skill a message is meant for,
+	// so the input modes of that skill apply instead of the agent defaults, and the agent
+	// handles it with the tools and instructions bound to the skill. In the task metadata it
+	// holds the skill of the latest message naming one.
+	SkillIDMetadataKey = "skillId"
+)
+
+// Model routing constants
+const (
+	// ModelHintMetadataKey in the message metadata names the kind of model a message asks for,
+	// such as fast or smart, which the agent's model router maps to a model
+	ModelHintMetadataKey = "modelHint"
+)
+
+// File ingestion constants
+const (
+	// IngestedFileMetadataKey in the metadata of a file part holds the IngestedFile the server
+	// produced from the file's bytes
+	IngestedFileMetadataKey = "ingestedFile"
+)
+
+// Speech constants
+const (
+	// SpeechOutputMetadataKey in the task metadata holds the media type the agent's response is
+	// synthesized to, recorded when the request accepts it among its output modes
+	SpeechOutputMetadataKey = "speechOutput"
+)
+
+// Task follow-up constants
+const (
+	// FollowUpOfMetadataKey in the task metadata holds the ID of the ended task a follow-up
+	// task was started for, when a message was sent to it
+	FollowUpOfMetadataKey = "followUpOf"
+)
+
+// Schedule constants
+const (
+	// ScheduleIDMetadataKey in the task metadata holds the ID of the schedule that started the
+	// task as one of its recurring runs
+	ScheduleIDMetadataKey = "scheduleId"
+)
+
+// Task dependency constants
+const (
+	// DependsOnMetadataKey in the metadata of a message/send request lists the IDs of the
+	// tasks the new task depends on. The task is queued once they have all completed.
+	DependsOnMetadataKey = "dependsOn"
+
+	// DependencyResultsMetadataKey in the metadata of the message carrying the results of the
+	// tasks a task depended on lists the IDs of those tasks
+	DependencyResultsMetadataKey = "dependencyResults"
+
+	// AwaitingDependenciesMetadataKey in the metadata of a submitted task lists the IDs of the
+	// tasks it depends on while it is held out of the queue. The server sets it and removes it
+	// once the task is queued.
+	AwaitingDependenciesMetadataKey = "awaitingDependencies"
+)
+
+// Task fork constants
+const (
+	// ForkedFromMetadataKey in the task metadata holds the ID of the task a forked task was
+	// copied from
+	ForkedFromMetadataKey = "forkedFrom"
+
+	// ForkedAtMetadataKey in the task metadata holds the index of the last message of the
+	// source task's history copied into a forked task
+	ForkedAtMetadataKey = "forkedAt"
+)
+
+// Guardrail constants
+const (
+	GuardrailMetadataKey = "guardrail"
+)
+
+// State transition history constants
+const (
+	// StateTransitionsMetadataKey in the task metadata holds the ordered state transitions of
+	// the task, recorded when the agent advertises the stateTransitionHistory capability
+	StateTransitionsMetadataKey = "stateTransitions"
+
+	// TaskActorClient triggers a transition with a request, such as a message or a cancellation
+	TaskActorClient = "client"
+	// TaskActorAgent triggers a transition while processing the task
+	TaskActorAgent = "agent"
+	// TaskActorServer triggers a transition on its own, such as a rejection or an expiry
+	TaskActorServer = "server"
+)
+
+// Token usage constants
+const (
+	UsageMetadataKey      = "usage"
+	BudgetExceededDataKey = "budget_exceeded"
+)
+
+// WebSocket streaming transport constants
+const (
+	WebSocketExtensionURI = "https://github.com/inference-gateway/adk/extensions/websocket/v1"
+	WebSocketPath         = "/a2a/ws"
+)
+
+// Stream shutdown constants
+const (
+	StreamEndReasonMetadataKey = "streamEndReason"
+	StreamEndReasonShutdown    = "shutdown"
+)
+
+// Stream heartbeat constants. A heartbeat is a status-update event marked with
+// StreamHeartbeatMetadataKey, sent while a streaming task is working but produces no events.
+const (
+	StreamHeartbeatMetadataKey = "heartbeat"
+)
+
+// Stream result kinds, the "kind" discriminator of the objects a stream delivers
+const (
+	StreamResultKindTask           = "task"
+	StreamResultKindMessage        = "message"
+	StreamResultKindStatusUpdate   = "status-update"
+	StreamResultKindArtifactUpdate = "artifact-update"
+)
+
+// Stream event filter constants. A message/stream client lists the event kinds it wants
+// under StreamEventsMetadataKey in the MessageSendConfiguration metadata.
+const (
+	StreamEventsMetadataKey    = "streamEvents"
+	StreamEventTypeMetadataKey = "eventType"
+	StreamEventDelta           = "delta"
+	StreamEventStatus          = "status"
+	StreamEventArtifact        = "artifact"
+	StreamEventTool            = "tool"
+)
+
+// Artifact upload constants
+const (
+	ArtifactUploadExtensionURI = "https://github.com/inference-gateway/adk/extensions/artifact-upload/v1"
+	ArtifactUploadContextID    = "uploads"
 )
 
 // Tool name constants
@@ -26,6 +278,89 @@ const (
 	ToolInputRequired = "input_required"
 )
 
+// Input request constants
+const (
+	// InputRequestDataKey holds the structured question of an input-required message asked
+	// with the input_required tool, so clients can render a form for the answer
+	InputRequestDataKey = "input_request"
+	// InputAnswersDataKey holds the answers to the fields of an input request, by field name,
+	// in a data part of the message resuming the task
+	InputAnswersDataKey = "input_answers"
+)
+
+// Input request formats, the kind of answer an input request expects
+const (
+	InputFormatText    = "text"
+	InputFormatNumber  = "number"
+	InputFormatBoolean = "boolean"
+	InputFormatDate    = "date"
+	InputFormatEmail   = "email"
+	InputFormatChoice  = "choice"
+)
+
+// InputRequest is the question a task paused on, sent in a data part of the input-required
+// status message under InputRequestDataKey
+type InputRequest struct {
+	Question string `json:"question"`
+	// ExpectedFormat is one of the input request formats, text when empty
+	ExpectedFormat string `json:"expected_format,omitempty"`
+	// Choices are the accepted answers of the choice format
+	Choices []string `json:"choices,omitempty"`
+	// Sensitive marks an answer, such as a password or an account number, that clients
+	// should mask while it is typed
+	Sensitive bool `json:"sensitive,omitempty"`
+	// Fields are the pieces of information the server collects, over one or more messages,
+	// before the task resumes. The question then introduces them.
+	Fields []InputField `json:"fields,omitempty"`
+	// Answers are the answers to the fields collected so far, by field name
+	Answers map[string]any `json:"answers,omitempty"`
+}
+
+// InputField is one piece of information an input request collects
+type InputField struct {
+	Name     string `json:"name"`
+	Question string `json:"question"`
+	// ExpectedFormat is one of the input request formats, text when empty
+	ExpectedFormat string   `json:"expected_format,omitempty"`
+	Choices        []string `json:"choices,omitempty"`
+	Sensitive      bool     `json:"sensitive,omitempty"`
+}
+
+// MissingFields returns the fields of the request that have no answer yet, in order
+func (r *InputRequest) MissingFields() []InputField {
+	var missing []InputField
+	for _, field := range r.Fields {
+		if _, answered := r.Answers[field.Name]; !answered {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
+
+// Tool approval constants
+const (
+	// ToolApprovalRequestDataKey holds the tool call awaiting approval in an approval request message
+	ToolApprovalRequestDataKey = "tool_approval_request"
+	// ToolApprovalDecisionDataKey holds the client's approve or deny decision in a resume message
+	ToolApprovalDecisionDataKey = "tool_approval"
+)
+
+// ToolApprovalRequest is the tool call a task paused on, sent in a data part of the
+// input-required status message under ToolApprovalRequestDataKey
+type ToolApprovalRequest struct {
+	ToolCallID string         `json:"tool_call_id"`
+	ToolName   string         `json:"tool_name"`
+	Arguments  map[string]any `json:"arguments"`
+}
+
+// ToolApprovalDecision is the client's answer to a tool approval request, sent in a data part
+// of the message resuming the task under ToolApprovalDecisionDataKey
+type ToolApprovalDecision struct {
+	ToolCallID string `json:"tool_call_id"`
+	Approved   bool   `json:"approved"`
+	Reason     string `json:"reason,omitempty"`
+}
+
 // A discriminated union representing all possible JSON-RPC 2.0 responses
 // for the A2A specification methods.
 type JSONRPCResponse any
@@ -77,6 +412,7 @@ type MessageSendConfiguration struct {
 	AcceptedOutputModes    []string                `json:"acceptedOutputModes,omitempty"`
 	Blocking               *bool                   `json:"blocking,omitempty"`
 	HistoryLength          *int                    `json:"historyLength,omitempty"`
+	Metadata               map[string]any          `json:"metadata,omitempty"`
 	PushNotificationConfig *PushNotificationConfig `json:"pushNotificationConfig,omitempty"`
 }
 
@@ -92,16 +428,47 @@ type MessageSendParams struct {
 type TaskQueryParams struct {
 	HistoryLength *int           `json:"historyLength,omitempty"`
 	ID            string         `json:"id"`
+	IncludeThread bool           `json:"includeThread,omitempty"`
 	Metadata      map[string]any `json:"metadata,omitempty"`
 }
 
-// Parameters for listing tasks with optional filtering and pagination.
+// A tree view over all tasks that share a context, derived from the history each task
+// inherited from earlier tasks. Nodes are ordered so that parents precede their children.
+type ConversationThread struct {
+	ContextID   string       `json:"contextId"`
+	Nodes       []ThreadNode `json:"nodes"`
+	RootTaskIDs []string     `json:"rootTaskIds"`
+}
+
+// A single task within a conversation thread and the messages it introduced.
+type ThreadNode struct {
+	ChildTaskIDs     []string  `json:"childTaskIds,omitempty"`
+	Messages         []Message `json:"messages"`
+	ParentTaskID     *string   `json:"parentTaskId,omitempty"`
+	ReferenceTaskIDs []string  `json:"referenceTaskIds,omitempty"`
+	State            TaskState `json:"state"`
+	TaskID           string    `json:"taskId"`
+}
+
+// The result of a tasks/get request with includeThread set: the task itself plus
+// the thread view of its context.
+type TaskWithThread struct {
+	Task
+	Thread *ConversationThread `json:"thread,omitempty"`
+}
+
+// Parameters for listing tasks with optional filtering and pagination. UserID lists the tasks of
+// a user; callers identified as a user only list their own tasks, and other callers only the
+// tasks of no user, which the server marks with Unowned.
 type TaskListParams struct {
-	ContextID *string        `json:"contextId,omitempty"`
-	Limit     int            `json:"limit,omitempty"`
-	Metadata  map[string]any `json:"metadata,omitempty"`
-	Offset    int            `json:"offset,omitempty"`
-	State     *TaskState     `json:"state,omitempty"`
+	ContextID *string           `json:"contextId,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Limit     int               `json:"limit,omitempty"`
+	Metadata  map[string]any    `json:"metadata,omitempty"`
+	Offset    int               `json:"offset,omitempty"`
+	State     *TaskState        `json:"state,omitempty"`
+	UserID    string            `json:"userId,omitempty"`
+	Unowned   bool              `json:"-"`
 }
 
 // Parameters for task operations that require only a task ID.
@@ -110,6 +477,303 @@ type TaskIdParams struct {
 	Metadata map[string]any `json:"metadata,omitempty"`
 }
 
+// Parameters for the tasks/feedback method, used to rate a task's outcome.
+// MessageID optionally narrows the feedback to a single agent message in the task history.
+type TaskFeedbackParams struct {
+	Category  *string        `json:"category,omitempty"`
+	ID        string         `json:"id"`
+	MessageID *string        `json:"messageId,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+	Rating    int            `json:"rating"`
+	Text      *string        `json:"text,omitempty"`
+}
+
+// Feedback recorded against a task. Entries are stored in the task metadata under
+// TaskFeedbackMetadataKey together with the agent and prompt version that produced the task.
+type TaskFeedback struct {
+	AgentVersion  string         `json:"agentVersion,omitempty"`
+	Category      *string        `json:"category,omitempty"`
+	ContextID     string         `json:"contextId"`
+	CreatedAt     string         `json:"createdAt"`
+	FeedbackID    string         `json:"feedbackId"`
+	MessageID     *string        `json:"messageId,omitempty"`
+	Metadata      map[string]any `json:"metadata,omitempty"`
+	PromptVersion string         `json:"promptVersion,omitempty"`
+	Rating        int            `json:"rating"`
+	TaskID        string         `json:"taskId"`
+	Text          *string        `json:"text,omitempty"`
+}
+
+// The normalized content of an inbound file part, attached by the server's file ingestion under
+// IngestedFileMetadataKey in the part metadata. MediaType is sniffed from the file's bytes, Text
+// is the plain text extracted from text, HTML, PDF and DOCX files, and Thumbnail is a
+// base64-encoded downscaled copy of an image in ThumbnailMediaType.
+type IngestedFile struct {
+	DeclaredMediaType  string `json:"declaredMediaType,omitempty"`
+	Height             int    `json:"height,omitempty"`
+	MediaType          string `json:"mediaType"`
+	Size               int64  `json:"size"`
+	Text               string `json:"text,omitempty"`
+	TextTruncated      bool   `json:"textTruncated,omitempty"`
+	Thumbnail          string `json:"thumbnail,omitempty"`
+	ThumbnailMediaType string `json:"thumbnailMediaType,omitempty"`
+	Width              int    `json:"width,omitempty"`
+}
+
+// A configuration reload applied by the server, carried by the adk.server.config.reloaded event.
+// Changed lists the reloaded settings: system_prompt, disabled_skills, budget and agent_card.
+type ConfigReload struct {
+	Changed    []string `json:"changed"`
+	ReloadID   string   `json:"reloadId"`
+	ReloadedAt string   `json:"reloadedAt"`
+}
+
+// Events a consumer falling behind did not receive, carried by the adk.server.events.dropped
+// event. Channel names where they were dropped, such as event_sink.
+type EventsDropped struct {
+	Channel string `json:"channel"`
+	Dropped int64  `json:"dropped"`
+}
+
+// Parameters for the tasks/share method. Scopes defaults to the transcript only and
+// TTLSeconds to the server's configured default lifetime.
+type TaskShareParams struct {
+	ID         string   `json:"id"`
+	Scopes     []string `json:"scopes,omitempty"`
+	TTLSeconds *int     `json:"ttlSeconds,omitempty"`
+}
+
+// A read-only share link for a task, returned by tasks/share
+type TaskShareLink struct {
+	ExpiresAt string   `json:"expiresAt"`
+	Scopes    []string `json:"scopes"`
+	TaskID    string   `json:"taskId"`
+	Token     string   `json:"token"`
+	URL       string   `json:"url"`
+}
+
+// A redacted, read-only view of a task served to holders of a share token. Message and
+// artifact metadata is omitted, file contents are withheld and sensitive text is masked.
+type SharedTranscript struct {
+	Artifacts  []Artifact `json:"artifacts,omitempty"`
+	ContextID  string     `json:"contextId"`
+	ExpiresAt  string     `json:"expiresAt"`
+	Messages   []Message  `json:"messages"`
+	Redactions int        `json:"redactions"`
+	State      TaskState  `json:"state"`
+	TaskID     string     `json:"taskId"`
+}
+
+// Token usage of the LLM calls made for a task. It is accumulated across the runs of a task
+// and recorded in the task metadata under UsageMetadataKey.
+type TokenUsage struct {
+	CompletionTokens int64 `json:"completion_tokens"`
+	PromptTokens     int64 `json:"prompt_tokens"`
+	TotalTokens      int64 `json:"total_tokens"`
+}
+
+// Add returns the sum of two token usages
+func (u TokenUsage) Add(other TokenUsage) TokenUsage {
+	return TokenUsage{
+		CompletionTokens: u.CompletionTokens + other.CompletionTokens,
+		PromptTokens:     u.PromptTokens + other.PromptTokens,
+		TotalTokens:      u.TotalTokens + other.TotalTokens,
+	}
+}
+
+// Parameters for the tasks/usage method, which aggregates token usage over a context
+type ContextUsageParams struct {
+	ContextID string `json:"contextId"`
+}
+
+// A change of a task's state in its state transition history, with the actor that triggered it
+type TaskStateTransition struct {
+	Actor     string    `json:"actor"`
+	State     TaskState `json:"state"`
+	Timestamp string    `json:"timestamp"`
+}
+
+// The progress of a working task reported by its handler or tools. Percent is the share of the
+// work done, from 0 to 100, and Note describes the current step.
+type TaskProgress struct {
+	Note      string `json:"note,omitempty"`
+	Percent   int    `json:"percent"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// The timing metrics of a task, recorded in the task metadata under TimingsMetadataKey.
+// QueuedAt and StartedAt are when the task was first submitted and first started working, and
+// CompletedAt when it ended. LLMTimeMs and ToolTimeMs add up the time spent waiting for the
+// model and executing tools over the runs of the task, which took Iterations model calls.
+type TaskTimings struct {
+	CompletedAt string `json:"completedAt,omitempty"`
+	Iterations  int    `json:"iterations"`
+	LLMTimeMs   int64  `json:"llmTimeMs"`
+	QueuedAt    string `json:"queuedAt,omitempty"`
+	StartedAt   string `json:"startedAt,omitempty"`
+	ToolTimeMs  int64  `json:"toolTimeMs"`
+}
+
+// The token usage of one task in a context usage report
+type TaskUsage struct {
+	State  TaskState  `json:"state"`
+	TaskID string     `json:"taskId"`
+	Usage  TokenUsage `json:"usage"`
+}
+
+// Aggregate token usage of the tasks in a context, returned by tasks/usage
+type ContextUsage struct {
+	ContextID string      `json:"contextId"`
+	TaskCount int         `json:"taskCount"`
+	Tasks     []TaskUsage `json:"tasks"`
+	Usage     TokenUsage  `json:"usage"`
+}
+
+// A conversation context managed with the contexts/* methods. Metadata describes the context
+// and Memory holds state shared by the tasks of the context. Contexts created implicitly by
+// a message without a context ID carry only their tasks.
+type ConversationContext struct {
+	ContextID string         `json:"contextId"`
+	CreatedAt string         `json:"createdAt,omitempty"`
+	Memory    map[string]any `json:"memory,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+	TaskCount int            `json:"taskCount"`
+	TaskIDs   []string       `json:"taskIds"`
+}
+
+// Parameters for the contexts/create method. The server generates a context ID when none is given.
+type ContextCreateParams struct {
+	ContextID string         `json:"contextId,omitempty"`
+	Memory    map[string]any `json:"memory,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// Parameters for the contexts/get and contexts/delete methods
+type ContextIdParams struct {
+	ContextID string `json:"contextId"`
+}
+
+// Parameters for the contexts/list method, with optional pagination
+type ContextListParams struct {
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// A page of conversation contexts returned by contexts/list
+type ContextList struct {
+	Contexts  []ConversationContext `json:"contexts"`
+	TotalSize int                   `json:"totalSize"`
+}
+
+// The result of contexts/delete, counting what was removed with the context
+type ContextDeleteResult struct {
+	ArtifactsDeleted int    `json:"artifactsDeleted"`
+	ContextID        string `json:"contextId"`
+	TasksCanceled    int    `json:"tasksCanceled"`
+	TasksDeleted     int    `json:"tasksDeleted"`
+}
+
+// Parameters for the tasks/fork method, which copies the history of a task up to and
+// including the message at HistoryIndex into a new task in the same context. Metadata is
+// recorded on the forked task like the metadata of a message request.
+type TaskForkParams struct {
+	HistoryIndex int            `json:"historyIndex"`
+	ID           string         `json:"id"`
+	Metadata     map[string]any `json:"metadata,omitempty"`
+}
+
+// Parameters for the message/sendBatch method, which starts a task for each of the messages
+// in one request. Each entry takes the params of a message/send request starting a new task.
+type MessageSendBatchParams struct {
+	Messages []MessageSendParams `json:"messages"`
+}
+
+// The result of the message/sendBatch method: the IDs of the tasks started, in the order of
+// the messages of the batch
+type MessageSendBatchResult struct {
+	TaskIDs []string `json:"taskIds"`
+}
+
+// Parameters for the artifacts/list method and the GET /artifacts endpoint. TaskID lists the
+// files of the artifacts of a task and ContextID those of every task of a conversation; at
+// least one of them is required.
+type ArtifactListParams struct {
+	ContextID string `json:"contextId,omitempty"`
+	TaskID    string `json:"taskId,omitempty"`
+}
+
+// A file of an artifact, with the link to download it. Files sent inline have no URL, and
+// Size and UploadedAt are only known for the files held by the artifact service. A file
+// stored for a context without a task artifact referencing it, such as an upload, has no
+// TaskID.
+type ArtifactFile struct {
+	ArtifactID   string `json:"artifactId"`
+	ArtifactName string `json:"artifactName,omitempty"`
+	ContextID    string `json:"contextId"`
+	Filename     string `json:"filename"`
+	MimeType     string `json:"mimeType,omitempty"`
+	Size         *int64 `json:"size,omitempty"`
+	TaskID       string `json:"taskId,omitempty"`
+	UploadedAt   string `json:"uploadedAt,omitempty"`
+	URL          string `json:"url,omitempty"`
+}
+
+// The result of the artifacts/list method: the artifact files in the order of the tasks that
+// produced them, followed by the files of the context no task references
+type ArtifactListResult struct {
+	Artifacts []ArtifactFile `json:"artifacts"`
+}
+
+// The response of the artifacts server to a file upload. The URI references the stored
+// file in a FilePart and SHA256 is the hex-encoded digest of the uploaded content.
+type ArtifactUploadResponse struct {
+	SHA256 string `json:"sha256"`
+	URI    string `json:"uri"`
+}
+
+// A fact or preference remembered across tasks, managed with the memories/* methods. A memory
+// belongs to the user named by UserID when it is set, and otherwise to its context. TaskID
+// names the task it was extracted from; memories created through memories/create have none.
+type Memory struct {
+	Content   string `json:"content"`
+	ContextID string `json:"contextId,omitempty"`
+	CreatedAt string `json:"createdAt"`
+	ID        string `json:"id"`
+	TaskID    string `json:"taskId,omitempty"`
+	UpdatedAt string `json:"updatedAt"`
+	UserID    string `json:"userId,omitempty"`
+}
+
+// Parameters for the memories/create method. The memory belongs to the user named by UserID
+// when it is set, and otherwise to the context.
+type MemoryCreateParams struct {
+	Content   string `json:"content"`
+	ContextID string `json:"contextId,omitempty"`
+	UserID    string `json:"userId,omitempty"`
+}
+
+// Parameters for the memories/get and memories/delete methods
+type MemoryIdParams struct {
+	ID string `json:"id"`
+}
+
+// Parameters for the memories/list method, listing the memories of a user or of a context
+type MemoryListParams struct {
+	ContextID string `json:"contextId,omitempty"`
+	UserID    string `json:"userId,omitempty"`
+}
+
+// The result of the memories/list method, oldest memory first
+type MemoryListResult struct {
+	Memories []Memory `json:"memories"`
+}
+
+// Parameters for the memories/update method, replacing the content of a memory
+type MemoryUpdateParams struct {
+	Content string `json:"content"`
+	ID      string `json:"id"`
+}
+
 // TaskList represents a list of tasks with pagination info (alias for generated type)
 type TaskList = ListTasksResponse
 